@@ -161,6 +161,15 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("torisolation") {
 			*cx.Config.TorIsolation = c.Bool("torisolation")
 		}
+		if c.IsSet("onlynet") {
+			*cx.Config.OnlyNet = c.String("onlynet")
+		}
+		if c.IsSet("torcontrol") {
+			*cx.Config.TorControl = c.String("torcontrol")
+		}
+		if c.IsSet("torpassword") {
+			*cx.Config.TorPassword = c.String("torpassword")
+		}
 		if c.IsSet("addpeer") {
 			*cx.Config.AddPeers = c.StringSlice("addpeer")
 		}
@@ -185,9 +194,24 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("banthreshold") {
 			*cx.Config.BanThreshold = c.Int("banthreshold")
 		}
+		if c.IsSet("banscore") {
+			*cx.Config.BanScores = c.StringSlice("banscore")
+		}
 		if c.IsSet("whitelist") {
 			*cx.Config.Whitelists = c.StringSlice("whitelist")
 		}
+		if c.IsSet("peerauth") {
+			*cx.Config.PeerAuth = c.Bool("peerauth")
+		}
+		if c.IsSet("peerallow") {
+			*cx.Config.PeerAllow = c.StringSlice("peerallow")
+		}
+		if c.IsSet("rpcallowip") {
+			*cx.Config.RPCAllowIPs = c.StringSlice("rpcallowip")
+		}
+		if c.IsSet("rpccanonicaljson") {
+			*cx.Config.RPCCanonicalJSON = c.Bool("rpccanonicaljson")
+		}
 		if c.IsSet("rpcconnect") {
 			*cx.Config.RPCConnect = c.String("rpcconnect")
 		}
@@ -206,6 +230,9 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("rpcquirks") {
 			*cx.Config.RPCQuirks = c.Bool("rpcquirks")
 		}
+		if c.IsSet("rpcwalletproxy") {
+			*cx.Config.RPCWalletProxy = c.String("rpcwalletproxy")
+		}
 		if c.IsSet("norpc") {
 			*cx.Config.DisableRPC = c.Bool("norpc")
 		}
@@ -233,9 +260,33 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("upnp") {
 			*cx.Config.UPNP = c.Bool("upnp")
 		}
+		if c.IsSet("telemetry") {
+			*cx.Config.Telemetry = c.Bool("telemetry")
+		}
+		if c.IsSet("telemetryurl") {
+			*cx.Config.TelemetryURL = c.String("telemetryurl")
+		}
+		if c.IsSet("amountunit") {
+			*cx.Config.AmountUnit = c.String("amountunit")
+		}
+		if c.IsSet("metrics") {
+			*cx.Config.Metrics = c.Bool("metrics")
+		}
+		if c.IsSet("metricslisten") {
+			*cx.Config.MetricsListener = c.String("metricslisten")
+		}
+		if c.IsSet("prune") {
+			*cx.Config.PruneTarget = c.Int("prune")
+		}
+		if c.IsSet("prunedepth") {
+			*cx.Config.PruneDepth = c.Int("prunedepth")
+		}
 		if c.IsSet("minrelaytxfee") {
 			*cx.Config.MinRelayTxFee = c.Float64("minrelaytxfee")
 		}
+		if c.IsSet("feeestimatemode") {
+			*cx.Config.FeeEstimateMode = c.String("feeestimatemode")
+		}
 		if c.IsSet("limitfreerelay") {
 			*cx.Config.FreeTxRelayLimit = c.Float64("limitfreerelay")
 		}
@@ -251,9 +302,33 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("generate") {
 			*cx.Config.Generate = c.Bool("generate")
 		}
+		if c.IsSet("nomining") {
+			*cx.Config.NoMining = c.Bool("nomining")
+		}
 		if c.IsSet("genthreads") {
 			*cx.Config.GenThreads = c.Int("genthreads")
 		}
+		if c.IsSet("gpu") {
+			*cx.Config.GPUDevices = c.StringSlice("gpu")
+		}
+		if c.IsSet("gpuintensity") {
+			*cx.Config.GPUIntensity = c.Int("gpuintensity")
+		}
+		if c.IsSet("inprocessminer") {
+			*cx.Config.InProcessMiner = c.Bool("inprocessminer")
+		}
+		if c.IsSet("mineralgos") {
+			*cx.Config.MinerAlgos = c.StringSlice("mineralgos")
+		}
+		if c.IsSet("payoutpolicy") {
+			*cx.Config.PayoutPolicy = c.String("payoutpolicy")
+		}
+		if c.IsSet("payoutalgoaddr") {
+			*cx.Config.PayoutAlgoAddrs = c.StringSlice("payoutalgoaddr")
+		}
+		if c.IsSet("coinbasedata") {
+			*cx.Config.CoinbaseData = c.String("coinbasedata")
+		}
 		if c.IsSet("solo") {
 			*cx.Config.Solo = c.Bool("solo")
 		}
@@ -273,9 +348,27 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 				*cx.Config.LAN = false
 			}
 		}
+		if c.IsSet("stratumlisten") {
+			*cx.Config.StratumListeners = c.StringSlice("stratumlisten")
+		}
+		if c.IsSet("stratumdiff") {
+			*cx.Config.StratumDifficulty = c.Float64("stratumdiff")
+		}
+		if c.IsSet("lanblockpush") {
+			*cx.Config.LANBlockPush = c.Bool("lanblockpush")
+		}
 		if c.IsSet("controller") {
 			*cx.Config.Controller = c.String("controller")
 		}
+		if c.IsSet("controllerallow") {
+			*cx.Config.ControllerAllow = c.StringSlice("controllerallow")
+		}
+		if c.IsSet("minerlisten") {
+			*cx.Config.MinerListen = c.String("minerlisten")
+		}
+		if c.IsSet("minercontrollers") {
+			*cx.Config.MinerControllers = c.StringSlice("minercontrollers")
+		}
 		if c.IsSet("miningaddrs") {
 			*cx.Config.MiningAddrs = c.StringSlice("miningaddrs")
 		}
@@ -317,21 +410,39 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("sigcachemaxsize") {
 			*cx.Config.SigCacheMaxSize = c.Int("sigcachemaxsize")
 		}
+		if c.IsSet("scriptworkers") {
+			*cx.Config.ScriptWorkers = c.Int("scriptworkers")
+		}
 		if c.IsSet("blocksonly") {
 			*cx.Config.BlocksOnly = c.Bool("blocksonly")
 		}
+		if c.IsSet("norelay") {
+			*cx.Config.NoRelay = c.Bool("norelay")
+		}
 		if c.IsSet("notxindex") {
 			*cx.Config.TxIndex = c.Bool("notxindex")
 		}
 		if c.IsSet("noaddrindex") {
 			*cx.Config.AddrIndex = c.Bool("noaddrindex")
 		}
+		if c.IsSet("wtxindex") {
+			*cx.Config.WTxIndex = c.Bool("wtxindex")
+		}
+		if c.IsSet("spentindex") {
+			*cx.Config.SpentIndex = c.Bool("spentindex")
+		}
+		if c.IsSet("noindexers") {
+			*cx.Config.NoIndexers = c.Bool("noindexers")
+		}
 		if c.IsSet("relaynonstd") {
 			*cx.Config.RelayNonStd = c.Bool("relaynonstd")
 		}
 		if c.IsSet("rejectnonstd") {
 			*cx.Config.RejectNonStd = c.Bool("rejectnonstd")
 		}
+		if c.IsSet("rejectreplacement") {
+			*cx.Config.RejectReplacement = c.Bool("rejectreplacement")
+		}
 		if c.IsSet("noinitialload") {
 			*cx.Config.NoInitialLoad = c.Bool("noinitialload")
 		}
@@ -344,9 +455,24 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("walletpass") {
 			*cx.Config.WalletPass = c.String("walletpass")
 		}
+		if c.IsSet("unlocktimeout") {
+			*cx.Config.UnlockTimeout = c.Duration("unlocktimeout")
+		}
+		if c.IsSet("reauththreshold") {
+			*cx.Config.ReauthThreshold = c.Float64("reauththreshold")
+		}
 		if c.IsSet("onetimetlskey") {
 			*cx.Config.OneTimeTLSKey = c.Bool("onetimetlskey")
 		}
+		if c.IsSet("cpfp") {
+			*cx.Config.CPFP = c.Bool("cpfp")
+		}
+		if c.IsSet("encryptwalletdb") {
+			*cx.Config.EncryptWalletDB = c.Bool("encryptwalletdb")
+		}
+		if c.IsSet("paymentwebhook") {
+			*cx.Config.PaymentWebhook = c.String("paymentwebhook")
+		}
 		if c.IsSet("walletrpclisten") {
 			*cx.Config.WalletRPCListeners = c.StringSlice("walletrpclisten")
 		}
@@ -371,6 +497,21 @@ func beforeFunc(cx *conte.Xt) func(c *cli.Context) error {
 		if c.IsSet("darktheme") {
 			*cx.Config.DarkTheme = c.Bool("darktheme")
 		}
+		if c.IsSet("notifyreceived") {
+			*cx.Config.NotifyReceived = c.Bool("notifyreceived")
+		}
+		if c.IsSet("notifysent") {
+			*cx.Config.NotifySent = c.Bool("notifysent")
+		}
+		if c.IsSet("notifymined") {
+			*cx.Config.NotifyMined = c.Bool("notifymined")
+		}
+		if c.IsSet("fiatcurrency") {
+			*cx.Config.FiatCurrency = c.String("fiatcurrency")
+		}
+		if c.IsSet("pricefeed") {
+			*cx.Config.PriceFeed = c.String("pricefeed")
+		}
 		if c.IsSet("notty") {
 			cx.IsGUI = true
 		}
@@ -21,6 +21,7 @@ func Configure(cx *conte.Xt, commandName string, initial bool) {
 	initLogLevel(cx.Config)
 	Debug("set log level")
 	initDictionary(cx.Config)
+	initAmountUnit(cx.Config)
 	initParams(cx)
 	initDataDir(cx.Config)
 	initTLSStuffs(cx.Config, cx.StateCfg)
@@ -38,13 +39,17 @@ func Configure(cx *conte.Xt, commandName string, initial bool) {
 	validateProfilePort(cx.Config)
 	validateBanDuration(cx.Config)
 	validateWhitelists(cx.Config, cx.StateCfg)
+	validateRPCAllowIPs(cx.Config, cx.StateCfg)
 	validatePeerLists(cx.Config)
 	configListener(cx.Config, cx.ActiveNet)
 	validateUsers(cx.Config)
 	configRPC(cx.Config, cx.ActiveNet)
 	validatePolicies(cx.Config, cx.StateCfg)
+	validatePrune(cx.Config)
+	validatePaymentWebhook(cx.Config)
 	validateOnions(cx.Config)
 	validateMiningStuff(cx.Config, cx.StateCfg, cx.ActiveNet)
+	validateSubsystems(cx.Config)
 	setDiallers(cx.Config, cx.StateCfg)
 	// if the user set the save flag, or file doesn't exist save the file now
 	if cx.StateCfg.Save || !apputil.FileExists(*cx.Config.ConfigFile) {
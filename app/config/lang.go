@@ -1,20 +1,14 @@
 package config
 
 import (
-	"os"
+	"github.com/p9c/pod/pkg/util/lang"
 )
 
-func appLang(lang string) string {
-	if lang == "" || lang == "en_US" {
-		homeLang := os.Getenv("LANG")
-		switch homeLang {
-		case "sr_RS":
-			lang = "rs"
-		default:
-			lang = "en"
-		}
+func appLang(code string) string {
+	if code == "" || code == "en_US" {
+		code = lang.Detect()
 	}
-	return lang
+	return code
 }
 
 func Lang(lang string) string {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -16,8 +17,11 @@ import (
 	"github.com/p9c/pod/app/apputil"
 	"github.com/p9c/pod/app/save"
 	"github.com/p9c/pod/cmd/node"
+	"github.com/p9c/pod/cmd/node/mempool"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/forkhash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/mining/payout"
 	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/interrupt"
@@ -48,11 +52,22 @@ var funcName = "loadConfig"
 
 func initDictionary(cfg *pod.Config) {
 	if cfg.Language == nil || *cfg.Language == "" {
-		*cfg.Language = Lang("en")
+		*cfg.Language = Lang("")
 	}
 	Trace("lang set to", *cfg.Language)
 }
 
+func initAmountUnit(cfg *pod.Config) {
+	unit, err := util.ParseAmountUnit(*cfg.AmountUnit)
+	if err != nil {
+		if *cfg.AmountUnit != "" {
+			Error("unrecognised amount unit", *cfg.AmountUnit, "setting default DUO")
+		}
+		*cfg.AmountUnit = util.AmountDUO.String()
+	}
+	util.SetDisplay(unit, *cfg.Language)
+}
+
 func initDataDir(cfg *pod.Config) {
 	if cfg.DataDir == nil || *cfg.DataDir == "" {
 		Debug("setting default data dir")
@@ -252,6 +267,9 @@ func initListeners(cx *conte.Xt, commandName string, initial bool) {
 	h, p, _ := net.SplitHostPort(*cfg.RPCConnect)
 	if h == "" {
 		*cfg.RPCConnect = net.JoinHostPort("127.0.0.1", p)
+	} else if ip := interfaceIP(h); ip != nil {
+		// the listener is bound to a network interface by name, which is reached on its first address
+		*cfg.RPCConnect = net.JoinHostPort(ip.String(), p)
 	}
 	if len(*cfg.WalletRPCListeners) > 0 {
 		splitted := strings.Split((*cfg.WalletRPCListeners)[0], ":")
@@ -388,7 +406,7 @@ func normalizeAddresses(cfg *pod.Config) {
 	nrm(cfg.AddPeers, port)
 	nrm(cfg.ConnectPeers, port)
 	// nrm(cfg.Listeners, port)
-	nrm(cfg.Whitelists, port)
+	// whitelists are subnets with optional permissions rather than addresses, so they are not given a port
 	// nrm(cfg.RPCListeners, port)
 }
 
@@ -448,38 +466,74 @@ func validateBanDuration(cfg *pod.Config) {
 }
 
 func validateWhitelists(cfg *pod.Config, st *state.Config) {
-	// Validate any given whitelisted IP addresses and networks.
+	// Validate any given whitelisted IP addresses and networks and the permissions they are granted.
 	Trace("validating whitelists")
 	if len(*cfg.Whitelists) > 0 {
-		var ip net.IP
-		st.ActiveWhitelists = make([]*net.IPNet, 0, len(*cfg.Whitelists))
+		st.ActiveWhitelists = make([]connmgr.Whitelist, 0, len(*cfg.Whitelists))
 		for _, addr := range *cfg.Whitelists {
-			_, ipnet, err := net.ParseCIDR(addr)
+			w, err := connmgr.ParseWhitelist(addr)
 			if err != nil {
+				err = fmt.Errorf("%s: %v", funcName, err)
 				Error(err)
-				err = fmt.Errorf("%s '%s'", err.Error())
-				ip = net.ParseIP(addr)
-				if ip == nil {
-					str := err.Error() + " %s: The whitelist value of '%s' is invalid"
-					err = fmt.Errorf(str, funcName, addr)
-					Error(err)
-					fmt.Fprintln(os.Stderr, err)
-					// os.Exit(1)
-				}
-				var bits int
-				if ip.To4() == nil {
-					// IPv6
-					bits = 128
-				} else {
-					bits = 32
-				}
-				ipnet = &net.IPNet{
-					IP:   ip,
-					Mask: net.CIDRMask(bits, bits),
-				}
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+			st.ActiveWhitelists = append(st.ActiveWhitelists, w)
+		}
+	}
+}
+
+// interfaceIP returns the first IPv4, or failing that IPv6, address of the network interface named name, or nil if
+// there is no such interface
+func interfaceIP(name string) (ip net.IP) {
+	if net.ParseIP(name) != nil {
+		return nil
+	}
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if ipnet, ok := addr.(*net.IPNet); ok {
+			if ipnet.IP.To4() != nil {
+				return ipnet.IP
+			}
+			if ip == nil && !ipnet.IP.IsLinkLocalUnicast() {
+				ip = ipnet.IP
+			}
+		}
+	}
+	return
+}
+
+func validateRPCAllowIPs(cfg *pod.Config, st *state.Config) {
+	// An allowlist that is set but has no valid entries is kept empty rather than nil so the RPC server refuses every
+	// connection instead of accepting all of them.
+	Trace("validating rpc allowed ips")
+	st.ActiveRPCAllowIPs = nil
+	if len(*cfg.RPCAllowIPs) == 0 {
+		return
+	}
+	st.ActiveRPCAllowIPs = make([]*net.IPNet, 0, len(*cfg.RPCAllowIPs))
+	for _, addr := range *cfg.RPCAllowIPs {
+		_, ipnet, err := net.ParseCIDR(addr)
+		if err != nil {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				Errorf("%s: the rpcallowip value of '%s' is invalid, ignoring it", funcName, addr)
+				continue
 			}
-			st.ActiveWhitelists = append(st.ActiveWhitelists, ipnet)
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			ipnet = &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}
 		}
+		st.ActiveRPCAllowIPs = append(st.ActiveRPCAllowIPs, ipnet)
 	}
 }
 
@@ -619,6 +673,18 @@ func validatePolicies(cfg *pod.Config, stateConfig *state.Config) {
 		fmt.Fprintln(os.Stderr, err)
 		// os.Exit(1)
 	}
+	Trace("checking fee estimate mode")
+	if _, err := mempool.ParseEstimateMode(*cfg.FeeEstimateMode); err != nil {
+		Warn(err, "-- using conservative")
+		*cfg.FeeEstimateMode = "conservative"
+	}
+	// A negative script worker count means the default of one per CPU core.
+	Trace("checking script worker count")
+	if *cfg.ScriptWorkers < 0 {
+		Warnf("the scriptworkers option may not be less than 0 -- parsed [%d], using one per CPU core",
+			*cfg.ScriptWorkers)
+		*cfg.ScriptWorkers = 0
+	}
 	// Limit the block priority and minimum block sizes to max block size.
 	Trace("validating block priority and minimum size/weight")
 	*cfg.BlockPrioritySize = int(apputil.MinUint32(
@@ -665,13 +731,51 @@ func validatePolicies(cfg *pod.Config, stateConfig *state.Config) {
 		// os.Exit(1)
 	}
 }
+
+// validatePrune raises the prune target to the minimum and turns off the indexes that need every block, since their
+// data can no longer be looked up once blocks are pruned
+func validatePrune(cfg *pod.Config) {
+	if *cfg.PruneTarget <= 0 {
+		*cfg.PruneTarget = 0
+		return
+	}
+	if *cfg.PruneTarget < node.MinPruneTarget {
+		Warnf("prune target %d MB is below the minimum, using %d MB", *cfg.PruneTarget, node.MinPruneTarget)
+		*cfg.PruneTarget = node.MinPruneTarget
+	}
+	if *cfg.PruneDepth <= 0 {
+		*cfg.PruneDepth = node.DefaultPruneDepth
+	} else if *cfg.PruneDepth < node.DefaultPruneDepth {
+		Warnf("prune depth %d is below the minimum, using %d", *cfg.PruneDepth, node.DefaultPruneDepth)
+		*cfg.PruneDepth = node.DefaultPruneDepth
+	}
+	if *cfg.TxIndex || *cfg.AddrIndex || *cfg.WTxIndex || *cfg.SpentIndex {
+		Warn("the transaction, address, witness transaction and spent output indexes are not available when pruning, " +
+			"disabling them")
+		*cfg.TxIndex = false
+		*cfg.AddrIndex = false
+		*cfg.WTxIndex = false
+		*cfg.SpentIndex = false
+	}
+}
+
+// validatePaymentWebhook turns off the payment webhook if it is not an http or https url
+func validatePaymentWebhook(cfg *pod.Config) {
+	if *cfg.PaymentWebhook == "" {
+		return
+	}
+	u, err := url.Parse(*cfg.PaymentWebhook)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		Warnf("payment webhook '%s' is not an http or https url, disabling it", *cfg.PaymentWebhook)
+		*cfg.PaymentWebhook = ""
+	}
+}
+
 func validateOnions(cfg *pod.Config) {
-	// --onionproxy and not --onion are contradictory
-	// TODO: this is kinda stupid hm? switch *and* toggle by presence of flag value, one should be enough
-	if *cfg.Onion && *cfg.OnionProxy != "" {
-		Error("onion enabled but no onionproxy has been configured")
-		Fatal("halting to avoid exposing IP address")
-		// os.Exit(1)
+	// Onion addresses are dialed through the onion proxy, or the proxy if there is no onion proxy.
+	if *cfg.Onion && *cfg.OnionProxy == "" && *cfg.Proxy == "" {
+		Warn("onion is enabled but neither onionproxy nor proxy is configured, tor hidden services can not be" +
+			" reached")
 	}
 	// Tor stream isolation requires either proxy or onion proxy to be set.
 	if *cfg.TorIsolation &&
@@ -682,10 +786,34 @@ func validateOnions(cfg *pod.Config) {
 		fmt.Fprintln(os.Stderr, err)
 		// os.Exit(1)
 	}
+	switch *cfg.OnlyNet {
+	case "":
+	case "onion":
+		// Nothing may be resolved or dialed outside of tor, so the peers are only found through tor hidden services
+		// and the clearnet addresses of the node are not mapped or advertised.
+		if !*cfg.Onion {
+			Warn("onlynet is onion, enabling onion")
+			*cfg.Onion = true
+		}
+		if *cfg.OnionProxy == "" && *cfg.Proxy == "" {
+			Error("onlynet is onion but neither onionproxy nor proxy is configured, no peers can be connected to")
+		}
+		*cfg.DisableDNSSeed = true
+		*cfg.UPNP = false
+	default:
+		Warnf("onlynet '%s' is not a supported network, only onion is, connecting to all networks", *cfg.OnlyNet)
+		*cfg.OnlyNet = ""
+	}
 	if !*cfg.Onion {
 		*cfg.OnionProxy = ""
 	}
-
+	if *cfg.TorControl != "" {
+		if _, _, err := net.SplitHostPort(*cfg.TorControl); err != nil {
+			Warnf("tor control port address '%s' is invalid, not creating a tor hidden service: %v",
+				*cfg.TorControl, err)
+			*cfg.TorControl = ""
+		}
+	}
 }
 
 func validateMiningStuff(cfg *pod.Config, state *state.Config,
@@ -723,6 +851,135 @@ func validateMiningStuff(cfg *pod.Config, state *state.Config,
 	if *cfg.MinerPass != "" {
 		state.ActiveMinerKey = forkhash.Argon2i([]byte(*cfg.MinerPass))
 	}
+	algoAddrs, err := payout.ParseAlgoAddrs(*cfg.PayoutAlgoAddrs, params)
+	if err != nil {
+		Error(err)
+	}
+	if state.Payout, err = payout.New(*cfg.PayoutPolicy, algoAddrs); err != nil {
+		Error(err, "- paying to random mining addresses")
+		state.Payout, _ = payout.New(payout.Random, algoAddrs)
+	}
+	if err = mining.CheckCoinbaseData([]byte(*cfg.CoinbaseData)); err != nil {
+		Error(err, "- mining blocks without it")
+		*cfg.CoinbaseData = ""
+	}
+	if state.Payout.Policy == payout.Fresh && *cfg.RPCWalletProxy == "" {
+		Warn("the fresh payout policy gets new addresses from the wallet at rpcwalletproxy, which is not set, so" +
+			" blocks are paid to the mining addresses in turn")
+	}
+}
+
+// subsystem is a part of the node that can be turned off, with the subsystems it can't work without
+type subsystem struct {
+	name     string
+	requires []string
+	enabled  func(cfg *pod.Config) bool
+	disable  func(cfg *pod.Config)
+}
+
+// subsystems are the parts of the node that can be turned off, each after the subsystems it requires so that turning
+// one off turns off everything depending on it in a single pass
+var subsystems = []subsystem{
+	{
+		name:    "txrelay",
+		enabled: func(cfg *pod.Config) bool { return !*cfg.NoRelay },
+		disable: func(cfg *pod.Config) { *cfg.NoRelay = true },
+	},
+	{
+		name:    "rpc",
+		enabled: func(cfg *pod.Config) bool { return !*cfg.DisableRPC },
+		disable: func(cfg *pod.Config) { *cfg.DisableRPC = true },
+	},
+	{
+		name:    "mining",
+		enabled: func(cfg *pod.Config) bool { return !*cfg.NoMining },
+		disable: func(cfg *pod.Config) { *cfg.NoMining = true },
+	},
+	{
+		name:    "wallet",
+		enabled: func(cfg *pod.Config) bool { return !*cfg.WalletOff },
+		disable: func(cfg *pod.Config) { *cfg.WalletOff = true },
+	},
+	{
+		name:    "indexers",
+		enabled: func(cfg *pod.Config) bool { return !*cfg.NoIndexers },
+		disable: func(cfg *pod.Config) { *cfg.NoIndexers = true },
+	},
+	{
+		name:     "txindex",
+		requires: []string{"indexers"},
+		enabled:  func(cfg *pod.Config) bool { return *cfg.TxIndex },
+		disable:  func(cfg *pod.Config) { *cfg.TxIndex = false },
+	},
+	{
+		name:     "addrindex",
+		requires: []string{"indexers", "txindex"},
+		enabled:  func(cfg *pod.Config) bool { return *cfg.AddrIndex },
+		disable:  func(cfg *pod.Config) { *cfg.AddrIndex = false },
+	},
+	{
+		name:     "wtxindex",
+		requires: []string{"indexers", "txindex"},
+		enabled:  func(cfg *pod.Config) bool { return *cfg.WTxIndex },
+		disable:  func(cfg *pod.Config) { *cfg.WTxIndex = false },
+	},
+	{
+		name:     "spentindex",
+		requires: []string{"indexers"},
+		enabled:  func(cfg *pod.Config) bool { return *cfg.SpentIndex },
+		disable:  func(cfg *pod.Config) { *cfg.SpentIndex = false },
+	},
+	{
+		name:     "cfindex",
+		requires: []string{"indexers"},
+		enabled:  func(cfg *pod.Config) bool { return !*cfg.NoCFilters },
+		disable:  func(cfg *pod.Config) { *cfg.NoCFilters = true },
+	},
+	{
+		name:     "generate",
+		requires: []string{"mining"},
+		enabled:  func(cfg *pod.Config) bool { return *cfg.Generate },
+		disable:  func(cfg *pod.Config) { *cfg.Generate = false },
+	},
+	{
+		name:     "stratum",
+		requires: []string{"mining"},
+		enabled:  func(cfg *pod.Config) bool { return len(*cfg.StratumListeners) > 0 },
+		disable:  func(cfg *pod.Config) { *cfg.StratumListeners = cli.StringSlice{} },
+	},
+	{
+		name:     "rpcwalletproxy",
+		requires: []string{"rpc"},
+		enabled:  func(cfg *pod.Config) bool { return *cfg.RPCWalletProxy != "" },
+		disable:  func(cfg *pod.Config) { *cfg.RPCWalletProxy = "" },
+	},
+}
+
+// validateSubsystems turns off the subsystems that require one that is turned off, warning about each, and reports
+// which subsystems are off. With transaction relay off no transactions are accepted from peers either.
+func validateSubsystems(cfg *pod.Config) {
+	if *cfg.NoRelay {
+		*cfg.BlocksOnly = true
+	}
+	enabled := make(map[string]bool, len(subsystems))
+	var off []string
+	for _, sub := range subsystems {
+		on := sub.enabled(cfg)
+		for _, req := range sub.requires {
+			if on && !enabled[req] {
+				Warnf("%s is turned off because it requires %s, which is off", sub.name, req)
+				sub.disable(cfg)
+				on = false
+			}
+		}
+		enabled[sub.name] = on
+		if !on {
+			off = append(off, sub.name)
+		}
+	}
+	if len(off) > 0 {
+		Info("subsystems turned off:", strings.Join(off, ", "))
+	}
 }
 
 func setDiallers(cfg *pod.Config, stateConfig *state.Config) {
@@ -746,13 +1003,12 @@ func setDiallers(cfg *pod.Config, stateConfig *state.Config) {
 		// Tor isolation flag means proxy credentials will be overridden unless there is also an onion proxy configured
 		// in which case that one will be overridden.
 		torIsolation := false
-		if *cfg.TorIsolation &&
-			*cfg.OnionProxy == "" &&
-			(*cfg.ProxyUser != "" ||
-				*cfg.ProxyPass != "") {
+		if *cfg.TorIsolation && *cfg.OnionProxy == "" {
 			torIsolation = true
-			Warn("Tor isolation set -- overriding specified" +
-				" proxy user credentials")
+			if *cfg.ProxyUser != "" || *cfg.ProxyPass != "" {
+				Warn("Tor isolation set -- overriding specified" +
+					" proxy user credentials")
+			}
 		}
 		proxy := &socks.Proxy{
 			Addr:         *cfg.Proxy,
@@ -790,29 +1046,39 @@ func setDiallers(cfg *pod.Config, stateConfig *state.Config) {
 			Warn("Tor isolation set - overriding specified onionproxy user" +
 				" credentials")
 		}
-	}
-	Trace("setting onion dialer")
-	stateConfig.Oniondial =
-		func(network, addr string, timeout time.Duration) (net.Conn, error) {
-			proxy := &socks.Proxy{
-				Addr:         *cfg.OnionProxy,
-				Username:     *cfg.OnionProxyUser,
-				Password:     *cfg.OnionProxyPass,
-				TorIsolation: *cfg.TorIsolation,
+		// Each connection made through the onion proxy is given its own random credentials when tor isolation is
+		// set, so that tor uses a separate circuit for it.
+		Trace("setting onion dialer")
+		stateConfig.Oniondial =
+			func(network, addr string, timeout time.Duration) (net.Conn, error) {
+				proxy := &socks.Proxy{
+					Addr:         *cfg.OnionProxy,
+					Username:     *cfg.OnionProxyUser,
+					Password:     *cfg.OnionProxyPass,
+					TorIsolation: *cfg.TorIsolation,
+				}
+				return proxy.DialTimeout(network, addr, timeout)
+			}
+		// When configured in bridge mode (both --onion and --proxy are configured), it means that the proxy configured
+		// by --proxy is not a tor proxy, so override the DNS resolution to use the onion-specific proxy.
+		if *cfg.Proxy != "" {
+			Trace("setting proxy lookup")
+			stateConfig.Lookup = func(host string) ([]net.IP, error) {
+				return connmgr.TorLookupIP(host, *cfg.OnionProxy)
 			}
-			return proxy.DialTimeout(network, addr, timeout)
-		}
-
-	// When configured in bridge mode (both --onion and --proxy are configured), it means that the proxy configured by
-	// --proxy is not a tor proxy, so override the DNS resolution to use the onion-specific proxy.
-	Trace("setting proxy lookup")
-	if *cfg.Proxy != "" {
-		stateConfig.Lookup = func(host string) ([]net.IP, error) {
-			return connmgr.TorLookupIP(host, *cfg.OnionProxy)
 		}
 	} else {
 		stateConfig.Oniondial = stateConfig.Dial
 	}
+	// Only onion addresses may be connected to in onion only mode, and nothing may be resolved.
+	if *cfg.OnlyNet == "onion" {
+		stateConfig.Dial = func(network, addr string, timeout time.Duration) (net.Conn, error) {
+			return nil, fmt.Errorf("not connecting to %s, only tor hidden services are allowed by onlynet", addr)
+		}
+		stateConfig.Lookup = func(host string) ([]net.IP, error) {
+			return nil, fmt.Errorf("not resolving %s, only tor hidden services are allowed by onlynet", host)
+		}
+	}
 	// Specifying --noonion means the onion address dial function results in an error.
 	if !*cfg.Onion {
 		stateConfig.Oniondial = func(a, b string, t time.Duration) (net.Conn, error) {
@@ -4,12 +4,14 @@ package conte
 
 import (
 	"sync"
+	"time"
 
 	"go.uber.org/atomic"
 
 	"github.com/urfave/cli"
 
 	"github.com/p9c/pod/app/appdata"
+	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/node/state"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/pod"
@@ -67,8 +69,9 @@ type Xt struct {
 	ChainClient *chain.RPCClient
 	// RealNode is the main node
 	RealNode *chainrpc.Node
-	// Hashrate is the current total hashrate from kopach workers taking work from this node
-	Hashrate atomic.Uint64
+	// MinerStats are the hashrate, solution and rejected block statistics of the kopach workers taking work from this
+	// node
+	MinerStats *hashrate.Stats
 	// Controller is the run state indicator of the controller
 	Controller atomic.Bool
 	// OtherNodes is the count of nodes connected automatically on the LAN
@@ -90,6 +93,7 @@ func GetNewContext(appName, appLang, subtext string) *Xt {
 		Config:           config,
 		ConfigMap:        configMap,
 		StateCfg:         new(state.Config),
+		MinerStats:       hashrate.NewStats(time.Now()),
 		Language:         lang.ExportLanguage(appLang),
 		DataDir:          appdata.Dir(appName, false),
 	}
@@ -98,7 +102,7 @@ func GetNewContext(appName, appLang, subtext string) *Xt {
 func GetContext(cx *Xt) *chainrpc.Context {
 	return &chainrpc.Context{
 		Config: cx.Config, StateCfg: cx.StateCfg, ActiveNet: cx.ActiveNet,
-		Hashrate: cx.Hashrate,
+		MinerStats: cx.MinerStats,
 	}
 }
 
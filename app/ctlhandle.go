@@ -11,10 +11,17 @@ import (
 
 	"github.com/p9c/pod/app/conte"
 	"github.com/p9c/pod/cmd/ctl"
+	rpcctl "github.com/p9c/pod/pkg/rpc/ctl"
 )
 
 const slash = string(os.PathSeparator)
 
+// rpcWaitFlag makes ctl wait for the RPC server to be reachable instead of failing when it isn't running yet
+var rpcWaitFlag = cli.BoolFlag{
+	Name:  "rpcwait",
+	Usage: "wait until the RPC server can be reached before sending commands",
+}
+
 func ctlHandleList(c *cli.Context) error {
 	fmt.Println("Here are the available commands. Pausing a moment as it is a long list...")
 	time.Sleep(2 * time.Second)
@@ -35,11 +42,24 @@ func ctlHandle(cx *conte.Xt) func(c *cli.Context) error {
 				Error(err)
 			}
 		}
+		if c.Bool("rpcwait") && !rpcctl.WaitForRPC(cx, rpcctl.IsWalletMethod(args[0]), cx.KillAll) {
+			return nil
+		}
 		ctl.Main(args, cx)
 		return nil
 	}
 }
 
+func ctlShellHandle(cx *conte.Xt) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		config.Configure(cx, c.Command.Name, true)
+		if c.Bool("rpcwait") && !rpcctl.WaitForRPC(cx, false, cx.KillAll) {
+			return nil
+		}
+		return ctl.Shell(cx)
+	}
+}
+
 func ctlGUIHandle(cx *conte.Xt) func(c *cli.Context) error {
 	return func(c *cli.Context) error {
 		config.Configure(cx, c.Command.Name, true)
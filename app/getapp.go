@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"time"
 
 	"github.com/urfave/cli"
@@ -16,10 +17,13 @@ import (
 	"github.com/p9c/pod/cmd/node/mempool"
 	"github.com/p9c/pod/cmd/walletmain"
 	"github.com/p9c/pod/pkg/coding/base58"
+	"github.com/p9c/pod/pkg/comm/price"
+	"github.com/p9c/pod/pkg/comm/telemetry"
 	"github.com/p9c/pod/pkg/db/blockdb"
 	"github.com/p9c/pod/pkg/rpc/legacy"
 	"github.com/p9c/pod/pkg/util/hdkeychain"
 	"github.com/p9c/pod/pkg/util/interrupt"
+	"github.com/p9c/pod/pkg/util/metrics"
 )
 
 // GetApp defines the pod app
@@ -63,7 +67,65 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 						"list",
 						"l",
 					),
-				), nil, "c"),
+					au.Command(
+						"shell",
+						"type commands into an interactive shell with completion of methods and their parameters",
+						ctlShellHandle(cx),
+						au.SubCommands(),
+						[]cli.Flag{rpcWaitFlag},
+						"sh",
+					),
+				), []cli.Flag{rpcWaitFlag}, "c"),
+			au.Command("watch",
+				"watch addresses and outpoints (txid:index) through the websocket API of a node and print an event "+
+					"as a line of JSON for every transaction paying or spending them",
+				watchHandle(cx), au.SubCommands(), []cli.Flag{
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "read more addresses and outpoints to watch from a file, or stdin if it is -",
+					},
+					cli.StringFlag{
+						Name:  "webhook",
+						Usage: "url that every event is also posted to as JSON",
+					},
+					cli.IntFlag{
+						Name:  "confirmations",
+						Value: 1,
+						Usage: "number of confirmations a transaction needs to be reported as confirmed",
+					},
+					cli.BoolFlag{
+						Name:  "mempool",
+						Usage: "also report transactions when they are accepted into the mempool",
+					},
+				}),
+			au.Command("selftest",
+				"on a test network, get coins from a faucet, send them from the wallet to itself and report whether "+
+					"each step passed once the transaction confirms, starting the wallet if it isn't running",
+				selftestHandle(cx), au.SubCommands(), []cli.Flag{
+					cli.StringFlag{
+						Name:  "faucet",
+						Usage: "url the address and amount are posted to as a form for coins, if not set the coins the wallet already has are used",
+					},
+					cli.Float64Flag{
+						Name:  "amount",
+						Value: 1,
+						Usage: "amount the wallet sends to itself",
+					},
+					cli.IntFlag{
+						Name:  "confirmations",
+						Value: 1,
+						Usage: "number of confirmations the transaction needs to pass",
+					},
+					cli.DurationFlag{
+						Name:  "timeout",
+						Value: 10 * time.Minute,
+						Usage: "how long the coins from the faucet and the confirmations are each waited for",
+					},
+					cli.StringFlag{
+						Name:  "passphrase",
+						Usage: "private passphrase of the wallet, prompted for if not set",
+					},
+				}),
 			au.Command("node", "start parallelcoin full node",
 				nodeHandle(cx), au.SubCommands(
 					au.Command("dropaddrindex",
@@ -86,12 +148,31 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 						au.SubCommands(),
 						nil,
 					),
+					au.Command("dropwtxindex",
+						"drop the witness transaction index",
+						func(c *cli.Context) error {
+							cx.StateCfg.DropWTxIndex = true
+							return nodeHandle(cx)(c)
+						},
+						au.SubCommands(),
+						nil,
+					),
+					au.Command("dropspentindex",
+						"drop the spent output index",
+						func(c *cli.Context) error {
+							cx.StateCfg.DropSpentIndex = true
+							return nodeHandle(cx)(c)
+						},
+						au.SubCommands(),
+						nil,
+					),
 					au.Command("dropindexes",
 						"drop all of the indexes",
 						func(c *cli.Context) error {
 							cx.StateCfg.DropAddrIndex = true
 							cx.StateCfg.DropTxIndex = true
 							cx.StateCfg.DropCfIndex = true
+							cx.StateCfg.DropSpentIndex = true
 							return nodeHandle(cx)(c)
 							// return nil
 						},
@@ -148,11 +229,44 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 							err = legacy.DropWalletHistory(cx.WalletServer)(c)
 							return
 						}, au.SubCommands(), nil),
+					au.Command("signoffline", "sign a partially signed transaction with the keys of the wallet "+
+						"without connecting to the network, for keeping the keys on an air-gapped machine",
+						WalletSignOfflineHandle(cx), au.SubCommands(), []cli.Flag{
+							au.String("in", "file of the transaction to sign, in binary, base64 or a QR code image",
+								"", nil),
+							au.String("out", "file to write the signed transaction to as base64, instead of "+
+								"printing it", "", nil),
+						}),
 				), nil, "w"),
 			au.Command("shell", "start combined wallet/node shell",
 				ShellHandle(cx), au.SubCommands(), nil, "s"),
 			au.Command("kopach", "standalone miner for clusters",
-				KopachHandle(cx), au.SubCommands(), nil, "k"),
+				KopachHandle(cx), au.SubCommands(
+					au.Command("bench",
+						"measure the hashrate of each mining algorithm with a range of thread counts and save the"+
+							" number of threads and the algorithms that mine best in the configuration",
+						kopachBenchHandle(cx), au.SubCommands(), []cli.Flag{
+							cli.DurationFlag{
+								Name:  "duration",
+								Value: 5 * time.Second,
+								Usage: "how long each algorithm is hashed with each number of threads",
+							},
+							cli.IntFlag{
+								Name:  "threads",
+								Value: runtime.NumCPU(),
+								Usage: "most threads measured, counting up in powers of two",
+							},
+							cli.IntFlag{
+								Name:  "fork",
+								Value: -1,
+								Usage: "number of the hard fork whose algorithms are measured, all of them if negative",
+							},
+							cli.BoolFlag{
+								Name:  "dryrun",
+								Usage: "print the recommended settings without saving them",
+							},
+						}),
+				), nil, "k"),
 			au.Command(
 				"worker",
 				"single thread parallelcoin miner controlled with binary IPC interface on stdin/stdout; "+
@@ -189,7 +303,7 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 			cli.StringFlag{
 				Name:        "lang, L",
 				Value:       *cx.Config.Language,
-				Usage:       "sets the data directory base for a pod instance",
+				Usage:       "language of the user interface, detected from the locale if it is not set",
 				EnvVar:      "POD_LANGUAGE",
 				Destination: cx.Config.Language,
 			},
@@ -310,6 +424,23 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"Enable Tor stream isolation by randomizing user credentials"+
 					" for each connection.",
 				cx.Config.TorIsolation),
+			au.String(
+				"onlynet",
+				"Only connect to peers on this network, onion to only make connections to tor hidden services"+
+					" through the onion proxy",
+				"",
+				cx.Config.OnlyNet),
+			au.String(
+				"torcontrol",
+				"Address of the tor control port, to create a tor hidden service for the peer listener"+
+					" (eg. 127.0.0.1:9051)",
+				"",
+				cx.Config.TorControl),
+			au.String(
+				"torpassword",
+				"Password for the tor control port, if it does not use cookie authentication",
+				"",
+				cx.Config.TorPassword),
 			au.StringSlice(
 				"addpeer",
 				"Add a peer to connect with at startup",
@@ -349,11 +480,26 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 					" banning misbehaving peers.",
 				node.DefaultBanThreshold,
 				cx.Config.BanThreshold),
+			au.StringSlice(
+				"banscore",
+				"Set the ban score and ban duration of a kind of misbehavior as offense=score[:duration]"+
+					" (eg. unrequestedblock=50 or checkpointmismatch=100:168h)",
+				cx.Config.BanScores),
 			au.StringSlice(
 				"whitelist",
-				"Add an IP network or IP that will not be banned. (eg. 192."+
-					"168.1.0/24 or ::1)",
+				"Grant permissions to peers from an IP network or IP as [permission,...@]network, with the"+
+					" permissions noban, forcerelay, relay, mempool and bloomfilter, and noban,relay,mempool"+
+					" when none are given (eg. 192.168.1.0/24, mempool@10.0.0.2 or ::1)",
 				cx.Config.Whitelists),
+			au.Bool(
+				"peerauth",
+				"Only connect with peers that authenticate with a node key in peerallow, and encrypt the"+
+					" connections, for private networks",
+				cx.Config.PeerAuth),
+			au.StringSlice(
+				"peerallow",
+				"Add the public key of a node allowed to connect when peerauth is enabled",
+				cx.Config.PeerAllow),
 			au.String(
 				"rpcconnect",
 				"Hostname/IP and port of pod RPC server to connect to",
@@ -361,8 +507,18 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				cx.Config.RPCConnect),
 			au.StringSlice(
 				"rpclisten",
-				"Add an interface/port to listen for RPC connections",
+				"Add an interface/port to listen for RPC connections, the interface may be given by name (eg. eth0:11048)",
 				cx.Config.RPCListeners),
+			au.StringSlice(
+				"rpcallowip",
+				"Allow RPC connections only from this IP network or IP, checked before authentication. (eg. 192."+
+					"168.1.0/24 or ::1)",
+				cx.Config.RPCAllowIPs),
+			au.Bool(
+				"rpccanonicaljson",
+				"Encode RPC results of the node and wallet with sorted object keys and floating point numbers such as "+
+					"amounts as strings with at least 8 decimal places, so responses can be hashed or diffed",
+				cx.Config.RPCCanonicalJSON),
 			au.Int(
 				"rpcmaxclients",
 				"Max number of RPC clients for standard connections",
@@ -385,6 +541,12 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 					" Discouraged unless interoperability issues need to be worked"+
 					" around",
 				cx.Config.RPCQuirks),
+			au.String(
+				"rpcwalletproxy",
+				"Forward wallet commands sent to the node RPC to the wallet RPC server at this address, so clients only"+
+					" need one RPC port and credentials",
+				"",
+				cx.Config.RPCWalletProxy),
 			au.Bool(
 				"norpc",
 				"Disable built-in RPC server -- NOTE: The RPC server"+
@@ -429,12 +591,51 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"upnp",
 				"Use UPnP to map our listening port outside of NAT",
 				cx.Config.UPNP),
+			au.Bool(
+				"telemetry",
+				"Opt in to sending anonymous version, platform, network and sync"+
+					" statistics to the community telemetry endpoint",
+				cx.Config.Telemetry),
+			au.String(
+				"telemetryurl",
+				"https endpoint telemetry reports are sent to",
+				telemetry.DefaultEndpoint,
+				cx.Config.TelemetryURL),
+			au.String(
+				"amountunit",
+				"Unit amounts are shown in by the wallet interfaces (DUO, mDUO, μDUO or Satoshi)",
+				"DUO",
+				cx.Config.AmountUnit),
+			au.Bool(
+				"metrics",
+				"Serve Prometheus metrics of the node, miner and RPC server over HTTP",
+				cx.Config.Metrics),
+			au.String(
+				"metricslisten",
+				"Address to serve Prometheus metrics on at /metrics",
+				metrics.DefaultListener,
+				cx.Config.MetricsListener),
+			au.Int(
+				"prune",
+				"Delete the oldest block files to keep the block database below this size in MB (0 keeps all blocks)",
+				0,
+				cx.Config.PruneTarget),
+			au.Int(
+				"prunedepth",
+				"Number of blocks below the tip that are kept when pruning",
+				node.DefaultPruneDepth,
+				cx.Config.PruneDepth),
 			au.Float64(
 				"minrelaytxfee",
 				"The minimum transaction fee in DUO/kB to be"+
 					" considered a non-zero fee.",
 				mempool.DefaultMinRelayTxFee.ToDUO(),
 				cx.Config.MinRelayTxFee),
+			au.String(
+				"feeestimatemode",
+				"Fee estimation mode of estimatesmartfee calls that don't set one, conservative or economical",
+				"conservative",
+				cx.Config.FeeEstimateMode),
 			au.Float64(
 				"limitfreerelay",
 				"Limit relay of transactions with no transaction"+
@@ -461,26 +662,94 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"generate, g",
 				"Generate (mine) DUO using the CPU",
 				cx.Config.Generate),
+			au.Bool(
+				"nomining",
+				"Disable mining, the miner controller and the mining RPC commands",
+				cx.Config.NoMining),
 			au.Int(
 				"genthreads, G",
 				"Number of CPU threads to use with CPU miner"+
 					" -1 = all cores",
 				1,
 				cx.Config.GenThreads),
+			au.StringSlice(
+				"gpu",
+				"Add an OpenCL device to mine with by its number, or all, in miners built with the opencl tag",
+				cx.Config.GPUDevices),
+			au.Int(
+				"gpuintensity",
+				"Base 2 logarithm of the number of nonces each GPU searches at a time, from 16 to 30",
+				22,
+				cx.Config.GPUIntensity),
+			au.Bool(
+				"inprocessminer",
+				"Mine with threads inside the node on its own block templates instead of running kopach",
+				cx.Config.InProcessMiner),
+			au.StringSlice(
+				"mineralgos",
+				"Add an algorithm for the workers to mine by name, if none are added or none are in the work all of"+
+					" those in the work are mined",
+				cx.Config.MinerAlgos),
+			au.String(
+				"payoutpolicy",
+				"how the address block rewards are paid to is picked: random, roundrobin, peralgo or fresh, which gets"+
+					" a new address for each block from the wallet at rpcwalletproxy",
+				"random",
+				cx.Config.PayoutPolicy),
+			au.StringSlice(
+				"payoutalgoaddr",
+				"Add an algo=address pair to pay the blocks of the algorithm to with the peralgo payout policy",
+				cx.Config.PayoutAlgoAddrs),
+			au.String(
+				"coinbasedata",
+				"text added to the coinbase script of mined blocks, such as a pool tag, as long as the coinbase"+
+					" script stays within the consensus limit",
+				"",
+				cx.Config.CoinbaseData),
 			au.Bool(
 				"solo",
 				"mine DUO even if not connected to the network",
 				cx.Config.Solo),
+			au.StringSlice(
+				"stratumlisten",
+				"Add an algo=address pair to serve stratum mining work for the algorithm on, such as"+
+					" sha256d=0.0.0.0:11048",
+				cx.Config.StratumListeners),
+			au.Float64(
+				"stratumdiff",
+				"Share difficulty stratum miners start with and the lowest it is adjusted to",
+				1,
+				cx.Config.StratumDifficulty),
 			au.Bool(
 				"lan",
 				"mine duo if not connected to nodes on internet",
 				cx.Config.LAN),
+			au.Bool(
+				"lanblockpush",
+				"push newly connected blocks to the other nodes on the LAN over the miner multicast channel",
+				cx.Config.LANBlockPush),
 			au.String(
 				"controller",
 				"port controller listens on for solutions from workers"+
 					" and other node peers",
 				":0",
 				cx.Config.Controller),
+			au.StringSlice(
+				"controllerallow",
+				"Add the identity key of a mining controller that kopach accepts work from, from any controller"+
+					" with the miner pass if none are added",
+				cx.Config.ControllerAllow),
+			au.String(
+				"minerlisten",
+				"address the controller listens on for kopach miners on other networks to register with over"+
+					" unicast, disabled if empty",
+				"",
+				cx.Config.MinerListen),
+			au.StringSlice(
+				"minercontrollers",
+				"Add the address of a mining controller that kopach registers with over unicast, for controllers"+
+					" multicast doesn't reach",
+				cx.Config.MinerControllers),
 			au.Bool(
 				"autoports",
 				"uses random automatic ports for p2p, rpc and controller",
@@ -533,7 +802,8 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				cx.Config.UserAgentComments),
 			au.Bool(
 				"nopeerbloomfilters",
-				"Disable bloom filtering support",
+				"Disable serving BIP37 bloom filters and merkle blocks to peers and stop advertising the bloom "+
+					"filter service, peers that send bloom filter requests are disconnected",
 				cx.Config.NoPeerBloomFilters),
 			au.Bool(
 				"nocfilters",
@@ -545,10 +815,19 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 					" signature verification cache",
 				node.DefaultSigCacheMaxSize,
 				cx.Config.SigCacheMaxSize),
+			au.Int(
+				"scriptworkers",
+				"Number of goroutines verifying transaction scripts when connecting blocks (0 uses one per CPU core)",
+				0,
+				cx.Config.ScriptWorkers),
 			au.Bool(
 				"blocksonly",
 				"Do not accept transactions from remote peers.",
 				cx.Config.BlocksOnly),
+			au.Bool(
+				"norelay",
+				"Disable transaction relay, transactions are neither accepted from nor announced to peers",
+				cx.Config.NoRelay),
 			au.BoolTrue(
 				"txindex",
 				"Disable the transaction index which makes all transactions available via the getrawtransaction RPC",
@@ -558,6 +837,18 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"Disable address-based transaction index which makes the searchrawtransactions RPC available",
 				cx.Config.AddrIndex,
 			),
+			au.Bool(
+				"wtxindex",
+				"Maintain an index of the witness hashes of transactions so getrawtransaction also finds them by wtxid (requires the transaction index)",
+				cx.Config.WTxIndex),
+			au.Bool(
+				"spentindex",
+				"Maintain an index of the transaction inputs spending each output which makes the getspentinfo RPC available",
+				cx.Config.SpentIndex),
+			au.Bool(
+				"noindexers",
+				"Disable every index, the transaction, address, witness transaction, spent output and committed filter indexes",
+				cx.Config.NoIndexers),
 			au.Bool(
 				"relaynonstd",
 				"Relay non-standard transactions regardless of the default"+
@@ -566,6 +857,10 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"Reject non-standard transactions regardless of"+
 					" the default settings for the active network.",
 				cx.Config.RejectNonStd),
+			au.Bool(
+				"rejectreplacement",
+				"Reject transactions replacing mempool transactions that signal replace-by-fee (BIP125)",
+				cx.Config.RejectReplacement),
 			au.Bool(
 				"noinitialload",
 				"Defer wallet creation/opening on startup and"+
@@ -586,11 +881,36 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 					" the wallet was created with one",
 				"",
 				cx.Config.WalletPass),
+			au.Duration(
+				"unlocktimeout",
+				"How long the wallet stays unlocked after the passphrase is entered in the GUI",
+				10*time.Minute,
+				cx.Config.UnlockTimeout),
+			au.Float64(
+				"reauththreshold",
+				"Sends of more than this amount in DUO ask for the wallet passphrase even while the wallet is unlocked",
+				100,
+				cx.Config.ReauthThreshold),
 			au.Bool(
 				"onetimetlskey",
 				"Generate a new TLS certpair at startup, but"+
 					" only write the certificate to disk",
 				cx.Config.OneTimeTLSKey),
+			au.Bool(
+				"cpfp",
+				"When spending unconfirmed change raise the fee so"+
+					" the unconfirmed parent transactions confirm along with it",
+				cx.Config.CPFP),
+			au.Bool(
+				"encryptwalletdb",
+				"Encrypt the whole wallet database with a key derived"+
+					" from the public wallet password",
+				cx.Config.EncryptWalletDB),
+			au.String(
+				"paymentwebhook",
+				"URL that fulfilled payment requests are posted to as JSON",
+				"",
+				cx.Config.PaymentWebhook),
 			au.Bool(
 				"tlsskipverify",
 				"skip verifying tls certificates",
@@ -642,6 +962,29 @@ func GetApp(cx *conte.Xt) (a *cli.App) {
 				"sets the dark theme on the gui interface",
 				cx.Config.DarkTheme,
 			),
+			au.BoolTrue(
+				"notifyreceived",
+				"Show desktop notifications in the GUI for payments received by the wallet",
+				cx.Config.NotifyReceived),
+			au.BoolTrue(
+				"notifysent",
+				"Show desktop notifications in the GUI for payments sent from the wallet being mined",
+				cx.Config.NotifySent),
+			au.BoolTrue(
+				"notifymined",
+				"Show desktop notifications in the GUI for blocks found by the local miner",
+				cx.Config.NotifyMined),
+			au.String(
+				"fiatcurrency",
+				"Currency the value of amounts is shown in next to them in the GUI, none to not fetch exchange rates",
+				price.None,
+				cx.Config.FiatCurrency),
+			au.String(
+				"pricefeed",
+				"Where exchange rates are fetched from, coingecko or the https url of a JSON feed of the price of DUO"+
+					" by currency",
+				price.DefaultProvider,
+				cx.Config.PriceFeed),
 			au.Bool(
 				"notty",
 				"tells pod there is no keyboard input available",
@@ -1,17 +1,21 @@
 package app
 
 import (
+	"fmt"
 	"os"
+	"text/tabwriter"
 
 	"github.com/p9c/pod/app/config"
 
 	"github.com/urfave/cli"
 
 	"github.com/p9c/pod/cmd/kopach"
+	"github.com/p9c/pod/cmd/kopach/bench"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
 
 	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/app/save"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
 
@@ -35,3 +39,55 @@ func KopachHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
 		return
 	}
 }
+
+// kopachBenchHandle measures the hashrate of the mining algorithms with a range of thread counts, prints the results and
+// saves the recommended number of threads and algorithms in the configuration
+func kopachBenchHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		if cx.ActiveNet.Name == netparams.TestNet3Params.Name {
+			fork.IsTestnet = true
+		}
+		cfg := bench.Config{Duration: c.Duration("duration"), MaxThreads: c.Int("threads")}
+		if cfg.MaxThreads < 1 {
+			return fmt.Errorf("the most threads must be at least 1, not %d", cfg.MaxThreads)
+		}
+		if hf := c.Int("fork"); hf >= 0 {
+			if hf >= len(fork.List) {
+				return fmt.Errorf("there is no hard fork %d", hf)
+			}
+			cfg.Forks = []int{hf}
+		} else {
+			for i := range fork.List {
+				cfg.Forks = append(cfg.Forks, i)
+			}
+		}
+		results := bench.Run(cfg, func(r bench.Result) {
+			Infof("%s with %d threads: %.2f hashes/s", r.Algo, r.Threads, r.Hashrate)
+		})
+		tw := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "fork\talgorithm\tthreads\thashes/s\twatts\thashes/s/watt\t")
+		for _, r := range results {
+			watts, perWatt := "-", "-"
+			if r.Watts > 0 {
+				watts, perWatt = fmt.Sprintf("%.1f", r.Watts), fmt.Sprintf("%.2f", r.PerWatt())
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%.2f\t%s\t%s\t\n", r.Fork, r.Algo, r.Threads, r.Hashrate, watts, perWatt)
+		}
+		if err = tw.Flush(); Check(err) {
+			return
+		}
+		threads, algos := bench.Recommend(results)
+		fmt.Printf("\nrecommended threads: %d\nrecommended algorithms: %v\n", threads, algos)
+		if c.Bool("dryrun") {
+			return
+		}
+		*cx.Config.GenThreads = threads
+		*cx.Config.MinerAlgos = algos
+		if !save.Pod(cx.Config) {
+			return fmt.Errorf("failed to save the configuration to %s", *cx.Config.ConfigFile)
+		}
+		fmt.Println("saved to", *cx.Config.ConfigFile)
+		return
+	}
+}
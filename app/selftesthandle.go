@@ -0,0 +1,56 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/urfave/cli"
+
+	"github.com/p9c/pod/app/apputil"
+	"github.com/p9c/pod/app/config"
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/cmd/selftest"
+	"github.com/p9c/pod/cmd/walletmain"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wallet"
+)
+
+func selftestHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		dbFilename :=
+			*cx.Config.DataDir + slash +
+				cx.ActiveNet.Params.Name + slash +
+				wallet.WalletDbName
+		if !apputil.FileExists(dbFilename) {
+			return errors.New("there is no wallet for " + cx.ActiveNet.Params.Name + ", create one first with 'pod wallet'")
+		}
+		var amount util.Amount
+		if amount, err = util.NewAmount(c.Float64("amount")); err != nil || amount <= 0 {
+			return errors.New("the amount must be more than zero")
+		}
+		return selftest.Main(cx, &selftest.Config{
+			Faucet:        c.String("faucet"),
+			Amount:        amount,
+			Confirmations: int64(c.Int("confirmations")),
+			Timeout:       c.Duration("timeout"),
+			Passphrase:    c.String("passphrase"),
+			StartWallet: func() error {
+				// the wallet sends itself on the channel once it is started, and returns without doing so if it fails
+				failed := make(chan error, 1)
+				go func() {
+					if err := walletmain.Main(cx); err != nil {
+						failed <- err
+					}
+				}()
+				Info("starting wallet")
+				select {
+				case cx.WalletServer = <-cx.WalletChan:
+					Info("wallet started")
+					return nil
+				case err := <-failed:
+					return err
+				}
+			},
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	// the formats of the images QR codes are read from
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/p9c/pod/app/config"
+	"github.com/p9c/pod/app/conte"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/coding/qrcode"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/prompt"
+	"github.com/p9c/pod/pkg/util/psbt"
+	"github.com/p9c/pod/pkg/wallet"
+)
+
+// WalletSignOfflineHandle signs a partially signed transaction with the keys of the wallet without starting the wallet
+// server or connecting to a node, so the keys can be kept on a machine that is never online. The transaction is read
+// from the file given with --in, in the binary format, as base64 or as an image of its QR code, and the signed
+// transaction is written as base64 to the file given with --out, or to standard output.
+func WalletSignOfflineHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		in := c.String("in")
+		if in == "" {
+			return errors.New("give the file of the transaction to sign with --in")
+		}
+		var p *psbt.Packet
+		if p, err = readPSBTFile(in); Check(err) {
+			return
+		}
+		loader := wallet.NewLoader(cx.ActiveNet, *cx.Config.WalletFile, 250)
+		var w *wallet.Wallet
+		if w, err = loader.OpenExistingWallet([]byte(*cx.Config.WalletPass), true, cx.Config); Check(err) {
+			return
+		}
+		defer func() {
+			if err := loader.UnloadWallet(); Check(err) {
+			}
+		}()
+		if w.Manager.WatchOnly() {
+			return errors.New("a watching-only wallet has no keys to sign with")
+		}
+		// the transaction is shown before the passphrase is asked for so it can be checked before it is signed
+		printPSBT(os.Stderr, p, cx)
+		var pass []byte
+		if pass, err = prompt.ProvidePrivPassphrase(); Check(err) {
+			return
+		}
+		if err = w.Unlock(pass, nil); Check(err) {
+			return
+		}
+		defer w.Lock()
+		var complete bool
+		if p, complete, err = w.ProcessPSBT(p, true, txscript.SigHashAll, false); Check(err) {
+			return
+		}
+		var signed string
+		if signed, err = p.B64Encode(); Check(err) {
+			return
+		}
+		if out := c.String("out"); out != "" {
+			if err = ioutil.WriteFile(out, []byte(signed+"\n"), 0600); Check(err) {
+				return
+			}
+		} else {
+			fmt.Println(signed)
+		}
+		if complete {
+			fmt.Fprintln(os.Stderr, "the transaction is fully signed and can be broadcast")
+		} else {
+			fmt.Fprintln(os.Stderr, "the transaction still needs signatures from other signers")
+		}
+		return
+	}
+}
+
+// readPSBTFile reads a partially signed transaction from a file, which holds it in the binary format, as base64 or as
+// an image of its QR code
+func readPSBTFile(path string) (p *psbt.Packet, err error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		var f *os.File
+		if f, err = os.Open(path); err != nil {
+			return
+		}
+		defer func() {
+			if err := f.Close(); Check(err) {
+			}
+		}()
+		var img image.Image
+		if img, _, err = image.Decode(f); err != nil {
+			return
+		}
+		var data string
+		if data, err = qrcode.Decode(img); err != nil {
+			return
+		}
+		return psbt.Parse([]byte(data))
+	}
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	return psbt.Parse(b)
+}
+
+// printPSBT prints the outputs of a partially signed transaction and its fee
+func printPSBT(f *os.File, p *psbt.Packet, cx *conte.Xt) {
+	fmt.Fprintln(f, "transaction", p.UnsignedTx.TxHash())
+	for _, txOut := range p.UnsignedTx.TxOut {
+		to := "a nonstandard script"
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, cx.ActiveNet)
+		if err == nil && len(addrs) == 1 {
+			to = addrs[0].EncodeAddress()
+		}
+		fmt.Fprintln(f, "  pays", util.Amount(txOut.Value), "to", to)
+	}
+	if fee, ok := p.Fee(); ok {
+		fmt.Fprintln(f, "  fee", fee)
+	} else {
+		fmt.Fprintln(f, "  fee unknown, the previous transactions of the inputs are missing")
+	}
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/p9c/pod/app/config"
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/cmd/watch"
+)
+
+func watchHandle(cx *conte.Xt) func(c *cli.Context) (err error) {
+	return func(c *cli.Context) (err error) {
+		config.Configure(cx, c.Command.Name, true)
+		targets := []string(c.Args())
+		if file := c.String("file"); file != "" {
+			f := os.Stdin
+			if file != "-" {
+				if f, err = os.Open(file); err != nil {
+					return err
+				}
+				defer f.Close()
+			}
+			var read []string
+			if read, err = watch.ReadTargets(f); err != nil {
+				return err
+			}
+			targets = append(targets, read...)
+		}
+		if len(targets) == 0 {
+			return cli.ShowSubcommandHelp(c)
+		}
+		return watch.Main(cx, &watch.Config{
+			Targets:       targets,
+			Webhook:       c.String("webhook"),
+			Confirmations: int32(c.Int("confirmations")),
+			Mempool:       c.Bool("mempool"),
+		})
+	}
+}
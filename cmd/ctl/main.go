@@ -2,13 +2,13 @@ package ctl
 
 import (
 	"bufio"
-	"bytes"
-	js "encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
+	"golang.org/x/crypto/ssh/terminal"
+
 	"github.com/p9c/pod/app/conte"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/rpc/ctl"
@@ -62,7 +62,8 @@ func Main(args []string, cx *conte.Xt) {
 		params = append(params, arg)
 	}
 	var result []byte
-	result, err = ctl.Call(cx, false, method, params...)
+	// wallet commands are sent to the wallet server
+	result, err = ctl.Call(cx, ctl.IsWalletMethod(method), method, params...)
 	if err != nil {
 		Error(err)
 		return
@@ -97,26 +98,15 @@ func Main(args []string, cx *conte.Xt) {
 	// 	Error(err)
 	// 	os.Exit(1)
 	// }
-	// Choose how to display the result based on its type.
-	strResult := string(result)
-	switch {
-	case strings.HasPrefix(strResult, "{") || strings.HasPrefix(strResult, "["):
-		var dst bytes.Buffer
-		if err := js.Indent(&dst, result, "", "  "); err != nil {
-			fmt.Printf("Failed to format result: %v", err)
-			os.Exit(1)
-		}
-		fmt.Println(dst.String())
-	case strings.HasPrefix(strResult, `"`):
-		var str string
-		if err := js.Unmarshal(result, &str); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to unmarshal result: %v",
-				err)
-			os.Exit(1)
-		}
-		fmt.Println(str)
-	case strResult != "null":
-		fmt.Println(strResult)
+	// Objects and arrays are indented, and colored when they are printed to a terminal
+	color := terminal.IsTerminal(int(os.Stdout.Fd())) && os.Getenv("NO_COLOR") == ""
+	var out string
+	if out, err = ctl.Pretty(result, color); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to format result: %v", err)
+		os.Exit(1)
+	}
+	if out != "" {
+		fmt.Println(out)
 	}
 }
 
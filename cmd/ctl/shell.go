@@ -0,0 +1,175 @@
+package ctl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/rpc/ctl"
+)
+
+const shellPrompt = "pod> "
+
+// shell reads commands a line at a time, completes method names with tab and shows the usage of the method being
+// typed when tab is pressed after it
+type shell struct {
+	cx      *conte.Xt
+	methods []string
+	term    *terminal.Terminal
+	out     io.Writer
+	color   bool
+	// help caches the usage the servers return for each method
+	help     map[string]string
+	helpLock sync.Mutex
+}
+
+// Shell runs an interactive shell sending the commands typed into it to the node, or the wallet for wallet commands,
+// until it is exited with exit, quit or end of input. Lines piped in are run the same without line editing.
+func Shell(cx *conte.Xt) (err error) {
+	s := &shell{cx: cx, methods: ctl.Methods(), out: os.Stdout, help: make(map[string]string)}
+	fd := int(os.Stdin.Fd())
+	if !terminal.IsTerminal(fd) {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if !s.run(scanner.Text()) {
+				break
+			}
+		}
+		return scanner.Err()
+	}
+	var state *terminal.State
+	if state, err = terminal.MakeRaw(fd); err != nil {
+		return
+	}
+	defer func() {
+		if err := terminal.Restore(fd, state); Check(err) {
+		}
+	}()
+	s.term = terminal.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, shellPrompt)
+	s.term.AutoCompleteCallback = s.complete
+	s.out = s.term
+	s.color = os.Getenv("NO_COLOR") == ""
+	fmt.Fprintln(s.out, "type a command, tab completes methods and shows their parameters, help lists them, exit quits")
+	for {
+		var line string
+		if line, err = s.term.ReadLine(); err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return
+		}
+		if !s.run(line) {
+			return
+		}
+	}
+}
+
+// run sends the command on the line and prints its result, it returns false when the shell is to exit
+func (s *shell) run(line string) bool {
+	args := strings.Fields(line)
+	if len(args) == 0 {
+		return true
+	}
+	method, params := args[0], make([]interface{}, 0, len(args)-1)
+	switch method {
+	case "exit", "quit":
+		return false
+	case "help":
+		if len(args) == 1 {
+			fmt.Fprint(s.out, ctl.ListCommands())
+			return true
+		}
+	}
+	for _, p := range args[1:] {
+		params = append(params, p)
+	}
+	flags, err := btcjson.MethodUsageFlags(method)
+	if err != nil {
+		fmt.Fprintf(s.out, "unknown command '%s', help lists the commands\n", method)
+		return true
+	}
+	if flags&unusableFlags != 0 {
+		fmt.Fprintf(s.out, "the '%s' command can only be used via websockets\n", method)
+		return true
+	}
+	// help for a method is asked of the server serving it
+	wallet := ctl.IsWalletMethod(method)
+	if method == "help" {
+		wallet = ctl.IsWalletMethod(args[1])
+	}
+	result, err := ctl.Call(s.cx, wallet, method, params...)
+	if err != nil {
+		fmt.Fprintln(s.out, "error:", err)
+		return true
+	}
+	var out string
+	if out, err = ctl.Pretty(result, s.color); err != nil {
+		out = string(result)
+	}
+	if out != "" {
+		fmt.Fprintln(s.out, out)
+	}
+	return true
+}
+
+// complete is called by the terminal for every key, on tab it completes the method being typed, listing the methods
+// it could be if there are several, or shows the usage of the method once it is typed
+func (s *shell) complete(line string, pos int, key rune) (newLine string, newPos int, ok bool) {
+	if key != '\t' {
+		return
+	}
+	word := strings.Fields(line[:pos])
+	if len(word) == 1 && !strings.HasSuffix(line[:pos], " ") {
+		matches, common := ctl.Complete(s.methods, word[0])
+		switch {
+		case len(matches) == 1:
+			common += " "
+		case len(matches) > 1 && common == word[0]:
+			// the terminal is locked while completing so the list is written after it returns
+			go s.term.Write([]byte(strings.Join(matches, "  ") + "\n"))
+		}
+		return common + line[pos:], len(common), true
+	}
+	if len(word) > 0 {
+		go func() {
+			_, _ = s.term.Write([]byte(s.usage(word[0]) + "\n"))
+		}()
+	}
+	return
+}
+
+// usage returns the usage of the method from the help of the server serving it, falling back to the usage of the
+// registered command if the server can't be reached
+func (s *shell) usage(method string) string {
+	s.helpLock.Lock()
+	defer s.helpLock.Unlock()
+	if u, ok := s.help[method]; ok {
+		return u
+	}
+	u, err := btcjson.MethodUsageText(method)
+	if err != nil {
+		return fmt.Sprintf("unknown command '%s'", method)
+	}
+	if result, err := ctl.Call(s.cx, ctl.IsWalletMethod(method), "help", method); err == nil {
+		var h string
+		if h, err = ctl.Pretty(result, false); err == nil && h != "" {
+			// the help starts with the usage and description of the method, followed by its arguments
+			if i := strings.Index(h, "\n\n"); i > 0 {
+				h = h[:i]
+			}
+			u = h
+		}
+	}
+	s.help[method] = u
+	return u
+}
@@ -0,0 +1,339 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	l "gioui.org/layout"
+
+	"github.com/p9c/pod/app/apputil"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// AddressBookEntry is a labelled address, either one that payments are sent to or one of the wallet's own addresses
+// that payments were requested on
+type AddressBookEntry struct {
+	Address string `json:"address"`
+	Label   string `json:"label"`
+	Receive bool   `json:"receive,omitempty"`
+	Created int64  `json:"created"`
+}
+
+// AddressBook is the labelled addresses of the wallet GUI. It is kept in a JSON file next to the wallet database of the
+// network, as the wallet only stores labels for its accounts.
+type AddressBook struct {
+	mutex   sync.Mutex
+	path    string
+	entries []AddressBookEntry
+}
+
+// addressBookPath is where the address book of the active network is stored
+func (wg *WalletGUI) addressBookPath() string {
+	return filepath.Join(*wg.cx.Config.DataDir, wg.cx.ActiveNet.Name, "addressbook.json")
+}
+
+// LoadAddressBook reads the address book stored at path, which is empty if the file does not exist yet
+func LoadAddressBook(path string) (ab *AddressBook, err error) {
+	ab = &AddressBook{path: path}
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	err = json.Unmarshal(b, &ab.entries)
+	return
+}
+
+// save writes the address book to its file.
+//
+// This function MUST be called with the address book locked.
+func (ab *AddressBook) save() (err error) {
+	var b []byte
+	if b, err = json.MarshalIndent(ab.entries, "", "  "); err != nil {
+		return
+	}
+	apputil.EnsureDir(ab.path)
+	return ioutil.WriteFile(ab.path, b, 0600)
+}
+
+// Set adds the entry to the address book, or relabels the entry that has the same address
+func (ab *AddressBook) Set(entry AddressBookEntry) error {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	for i := range ab.entries {
+		if ab.entries[i].Address == entry.Address {
+			ab.entries[i].Label = entry.Label
+			ab.entries[i].Receive = entry.Receive
+			return ab.save()
+		}
+	}
+	if entry.Created == 0 {
+		entry.Created = time.Now().Unix()
+	}
+	ab.entries = append(ab.entries, entry)
+	return ab.save()
+}
+
+// Remove deletes the entry of the address from the address book
+func (ab *AddressBook) Remove(address string) error {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	for i := range ab.entries {
+		if ab.entries[i].Address == address {
+			ab.entries = append(ab.entries[:i], ab.entries[i+1:]...)
+			return ab.save()
+		}
+	}
+	return nil
+}
+
+// Lookup returns the entry of the address, if it is in the address book
+func (ab *AddressBook) Lookup(address string) (entry AddressBookEntry, ok bool) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	for i := range ab.entries {
+		if ab.entries[i].Address == address {
+			return ab.entries[i], true
+		}
+	}
+	return
+}
+
+// Search returns the entries whose label or address contains the query, ignoring case, sorted by label
+func (ab *AddressBook) Search(query string) (found []AddressBookEntry) {
+	ab.mutex.Lock()
+	defer ab.mutex.Unlock()
+	query = strings.ToLower(strings.TrimSpace(query))
+	for _, e := range ab.entries {
+		if query == "" || strings.Contains(strings.ToLower(e.Label), query) ||
+			strings.Contains(strings.ToLower(e.Address), query) {
+			found = append(found, e)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if li, lj := strings.ToLower(found[i].Label), strings.ToLower(found[j].Label); li != lj {
+			return li < lj
+		}
+		return found[i].Address < found[j].Address
+	})
+	return
+}
+
+// AddressBookPage lists the address book with a form to add and edit labelled addresses. Addresses are searched by
+// label or address, and the wallet's own addresses show the payment requests made on them.
+func (wg *WalletGUI) AddressBookPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.addressBookWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["addressBook"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) addressBookWidgets() (out []l.Widget) {
+	out = append(out,
+		wg.privacyHeading("add or edit an address"),
+		wg.th.Flex().
+			Rigid(wg.rowLabel("Label:")).
+			Rigid(wg.Inset(0.25, wg.inputs["addressBookLabel"].Fn).Fn).
+			Fn,
+		wg.th.Flex().
+			Rigid(wg.rowLabel("Address:")).
+			Rigid(wg.Inset(0.25, wg.inputs["addressBookAddress"].Fn).Fn).
+			Fn,
+		wg.th.Flex().
+			Rigid(
+				wg.Inset(0.25,
+					wg.buttonText(wg.clickables["addressBookSave"], "Save", wg.saveAddressBookEntry),
+				).Fn,
+			).
+			Rigid(
+				wg.Inset(0.25,
+					wg.buttonText(wg.clickables["addressBookClear"], "Clear", func() {
+						wg.editAddressBookEntry(AddressBookEntry{})
+					}),
+				).Fn,
+			).Fn,
+		wg.privacyHeading("addresses"),
+		wg.th.Flex().
+			Rigid(wg.rowLabel("Search:")).
+			Rigid(wg.Inset(0.25, wg.inputs["addressBookSearch"].Fn).Fn).
+			Fn,
+	)
+	entries := wg.addressBook.Search(wg.inputs["addressBookSearch"].GetText())
+	if len(entries) == 0 {
+		return append(out, wg.privacyLine("no addresses, labelled payment requests and sends are added automatically"))
+	}
+	for _, e := range entries {
+		out = append(out, wg.addressBookLine(e))
+	}
+	return
+}
+
+// addressBookLine shows an entry of the address book with the buttons to pay to it or edit it, and to delete it
+func (wg *WalletGUI) addressBookLine(e AddressBookEntry) l.Widget {
+	txt := fmt.Sprintf("%s: %s, sending", e.Label, e.Address)
+	if e.Receive {
+		txt = fmt.Sprintf("%s: %s, receiving", e.Label, e.Address)
+		for _, r := range wg.State.PaymentRequests() {
+			if r.Address == e.Address {
+				txt += fmt.Sprintf(", requested %s %s", wg.formatAmount(r.Amount), r.Status)
+			}
+		}
+	}
+	flex := wg.th.Flex()
+	if !e.Receive {
+		flex = flex.
+			Rigid(
+				wg.Inset(0.25,
+					wg.buttonText(wg.keyedClickable("bookPay"+e.Address), "Pay", func() {
+						wg.payToAddressBookEntry(0, e)
+						wg.ActivePage("send")
+					}),
+				).Fn,
+			)
+	}
+	return flex.
+		Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(wg.keyedClickable("bookEdit"+e.Address), "Edit", func() {
+					wg.editAddressBookEntry(e)
+				}),
+			).Fn,
+		).
+		Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(wg.keyedClickable("bookDelete"+e.Address), "Delete", func() {
+					if err := wg.addressBook.Remove(e.Address); Check(err) {
+						wg.toasts.AddToast("Address book", err.Error(), "Danger")
+					}
+				}),
+			).Fn,
+		).
+		Flexed(1, wg.privacyLine(txt)).
+		Fn
+}
+
+// editAddressBookEntry puts the entry in the address book form
+func (wg *WalletGUI) editAddressBookEntry(e AddressBookEntry) {
+	wg.inputs["addressBookLabel"] = wg.th.Input(e.Label, "Label", "Primary", "DocText", 32, func(pass string) {})
+	wg.inputs["addressBookAddress"] = wg.th.Input(e.Address, "Address", "Primary", "DocText", 32, func(pass string) {})
+}
+
+// saveAddressBookEntry stores the address in the address book form under its label. An address that is one of the
+// wallet's own is stored as a receiving address.
+func (wg *WalletGUI) saveAddressBookEntry() {
+	label := strings.TrimSpace(wg.inputs["addressBookLabel"].GetText())
+	address := strings.TrimSpace(wg.inputs["addressBookAddress"].GetText())
+	if label == "" {
+		wg.toasts.AddToast("Address book", "enter a label for the address", "Danger")
+		return
+	}
+	addr, err := util.DecodeAddress(address, wg.cx.ActiveNet)
+	if err != nil {
+		wg.toasts.AddToast("Address book", "invalid address: "+err.Error(), "Danger")
+		return
+	}
+	go func() {
+		entry := AddressBookEntry{Address: address, Label: label}
+		if e, ok := wg.addressBook.Lookup(address); ok {
+			entry.Receive = e.Receive
+		} else if wg.WalletClient != nil {
+			var v *btcjson.ValidateAddressWalletResult
+			if v, err = wg.WalletClient.ValidateAddress(addr); !Check(err) {
+				entry.Receive = v.IsMine
+			}
+		}
+		if err = wg.addressBook.Set(entry); Check(err) {
+			wg.toasts.AddToast("Address book", err.Error(), "Danger")
+			return
+		}
+		wg.editAddressBookEntry(AddressBookEntry{})
+		wg.invalidate <- struct{}{}
+	}()
+}
+
+// addToAddressBook stores a labelled address that was sent to or requested on, leaving an address that is already in
+// the address book as it is, so the labels edited in the address book are kept
+func (wg *WalletGUI) addToAddressBook(address, label string, receive bool) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return
+	}
+	if _, ok := wg.addressBook.Lookup(address); ok {
+		return
+	}
+	if err := wg.addressBook.Set(AddressBookEntry{Address: address, Label: label, Receive: receive}); Check(err) {
+	}
+}
+
+// payToAddressBookEntry fills in recipient i of the send form with the address and label of the entry
+func (wg *WalletGUI) payToAddressBookEntry(i int, e AddressBookEntry) {
+	if i >= len(wg.sendAddresses) {
+		return
+	}
+	wg.sendAddresses[i].AddressInput = wg.th.Input(e.Address, "Enter a ParallelCoin address (e.g. 9ef0sdjifvmlkdsfnsdlkg)",
+		"Primary", "DocText", 26, func(txt string) {})
+	wg.sendAddresses[i].LabelInput = wg.th.Input(e.Label,
+		"Enter a label for this address to add it to the list of used addresses", "Primary", "DocText", 26,
+		func(pass string) {})
+}
+
+// addressBookPicker lists the sending addresses matching what has been typed into the address or label of recipient i,
+// and fills in the recipient with the one that is picked
+func (wg *WalletGUI) addressBookPicker(i int) {
+	query := wg.sendAddresses[i].LabelInput.GetText()
+	if query == "" {
+		query = wg.sendAddresses[i].AddressInput.GetText()
+	}
+	var entries []AddressBookEntry
+	for _, e := range wg.addressBook.Search(query) {
+		if !e.Receive {
+			entries = append(entries, e)
+		}
+	}
+	if len(entries) == 0 {
+		wg.toasts.AddToast("Address book", "no sending addresses match, add them on the address book page", "Info")
+		return
+	}
+	flex := wg.th.VFlex()
+	for _, e := range entries {
+		e := e
+		flex = flex.Rigid(
+			wg.th.Flex().
+				Rigid(
+					wg.Inset(0.25,
+						wg.buttonText(wg.keyedClickable("bookPick"+e.Address), "Pick", func() {
+							wg.dialog.Close()
+							wg.payToAddressBookEntry(i, e)
+						}),
+					).Fn,
+				).
+				Flexed(1,
+					wg.Inset(0.25,
+						wg.Body2(e.Label+": "+e.Address).Color("PanelText").Fn,
+					).Fn,
+				).Fn,
+		)
+	}
+	wg.dialog.ShowDialog("Pay to", "Info", flex.Fn)()
+}
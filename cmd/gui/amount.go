@@ -0,0 +1,107 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/p9c/pod/pkg/comm/price"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// amountUnit returns the unit selected in the settings, which is DUO when the setting is not recognised
+func (wg *WalletGUI) amountUnit() util.AmountUnit {
+	u, err := util.ParseAmountUnit(*wg.cx.Config.AmountUnit)
+	if err != nil {
+		return util.AmountDUO
+	}
+	return u
+}
+
+// amountLocale returns the amount format of the language selected in the settings
+func (wg *WalletGUI) amountLocale() util.AmountLocale {
+	return util.LocaleFor(*wg.cx.Config.Language)
+}
+
+// formatAmount formats an amount in DUO, as returned by the wallet RPC, in the unit and the language selected in the
+// settings. The settings are read every time so that a change shows on every page straight away.
+func (wg *WalletGUI) formatAmount(duo float64) string {
+	a, err := util.NewAmount(duo)
+	if err != nil {
+		return fmt.Sprint(duo)
+	}
+	return a.FormatLocale(wg.amountUnit(), wg.amountLocale())
+}
+
+// parseAmount parses an amount typed in the unit and the language selected in the settings
+func (wg *WalletGUI) parseAmount(s string) (util.Amount, error) {
+	return util.ParseAmountLocale(s, wg.amountUnit(), wg.amountLocale())
+}
+
+// fiatCurrency returns the currency selected in the settings to show the value of amounts in, empty if there is none
+func (wg *WalletGUI) fiatCurrency() string {
+	if c := *wg.cx.Config.FiatCurrency; c != price.None {
+		return c
+	}
+	return ""
+}
+
+// fiatAmount formats the value of an amount in DUO in the currency selected in the settings, with the time of the rate
+// when it is more than an hour old, as it is while the price feed can't be reached. It is empty without a rate.
+func (wg *WalletGUI) fiatAmount(duo float64) string {
+	currency := wg.fiatCurrency()
+	if currency == "" {
+		return ""
+	}
+	rate, updated, ok := wg.prices.Rate(currency)
+	if !ok {
+		return ""
+	}
+	s := strings.Replace(fmt.Sprintf("%.2f", duo*rate), ".", wg.amountLocale().Decimal, 1) + " " + currency
+	if time.Since(updated) > time.Hour {
+		s += " at the rate of " + updated.Format("Jan 2 15:04")
+	}
+	return s
+}
+
+// withFiat appends the value of an amount in DUO in the currency selected in the settings to its formatted text
+func (wg *WalletGUI) withFiat(txt string, duo float64) string {
+	if fiat := wg.fiatAmount(duo); fiat != "" {
+		return txt + " (" + fiat + ")"
+	}
+	return txt
+}
+
+// fiatInput returns the value of the amount typed into an input in the currency selected in the settings
+func (wg *WalletGUI) fiatInput(txt string) string {
+	a, err := wg.parseAmount(txt)
+	if err != nil || a <= 0 {
+		return ""
+	}
+	return wg.fiatAmount(a.ToDUO())
+}
+
+// updatePrices fetches the exchange rate of the currency selected in the settings from the price feed in the settings
+// when the rate is due to be fetched again
+func (wg *WalletGUI) updatePrices() {
+	currency := wg.fiatCurrency()
+	if currency == "" {
+		return
+	}
+	provider, err := price.ProviderFor(*wg.cx.Config.PriceFeed)
+	if err != nil {
+		if wg.State.FirstNotice("pricefeed:" + *wg.cx.Config.PriceFeed) {
+			go wg.toasts.AddToast("Price feed", err.Error(), "Danger")
+		}
+		return
+	}
+	if provider.Name() != wg.prices.Provider().Name() {
+		wg.prices.SetProvider(provider)
+	}
+	if !wg.prices.Due() {
+		return
+	}
+	if err = wg.prices.Update(currency); err != nil {
+		Debug("fetching exchange rates failed:", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"time"
 
 	l "gioui.org/layout"
 	"gioui.org/text"
@@ -30,7 +31,13 @@ func (wg *WalletGUI) GetAppWidget() (a *p9.App) {
 		save.Pod(wg.cx.Config)
 	})
 	wg.size = a.Size
-	wg.config = cfg.New(wg.cx, wg.th)
+	wg.config = cfg.New(wg.cx, wg.th).
+		SetRestart(wg.restartNode).
+		SetLive("loglevel", wg.applyLogLevel).
+		SetLive("addpeer", wg.applyAddPeers).
+		SetLive("generate", wg.applyGenerate).
+		SetLive("genthreads", wg.applyGenThreads).
+		SetLive("language", wg.applyLanguage)
 	wg.configs = wg.config.Config()
 	a.Pages(map[string]l.Widget{
 		"main": wg.Page("overview", p9.Widgets{
@@ -49,6 +56,30 @@ func (wg *WalletGUI) GetAppWidget() (a *p9.App) {
 			// p9.WidgetSize{Widget: p9.EmptyMaxHeight()},
 			p9.WidgetSize{Widget: wg.HistoryPage()},
 		}),
+		"privacy": wg.Page("privacy", p9.Widgets{
+			p9.WidgetSize{Widget: wg.PrivacyPage()},
+		}),
+		"multisig": wg.Page("shared wallets", p9.Widgets{
+			p9.WidgetSize{Widget: wg.MultisigPage()},
+		}),
+		"coins": wg.Page("coins", p9.Widgets{
+			p9.WidgetSize{Widget: wg.CoinsPage()},
+		}),
+		"offline": wg.Page("offline signing", p9.Widgets{
+			p9.WidgetSize{Widget: wg.OfflinePage()},
+		}),
+		"rescan": wg.Page("rescan", p9.Widgets{
+			p9.WidgetSize{Widget: wg.RescanPage()},
+		}),
+		"peers": wg.Page("peers", p9.Widgets{
+			p9.WidgetSize{Widget: wg.PeersPage()},
+		}),
+		"import": wg.Page("import", p9.Widgets{
+			p9.WidgetSize{Widget: wg.ImportKeyPage()},
+		}),
+		"addressbook": wg.Page("address book", p9.Widgets{
+			p9.WidgetSize{Widget: wg.AddressBookPage()},
+		}),
 		"settings": wg.Page("settings", p9.Widgets{
 			// p9.WidgetSize{Widget: p9.EmptyMaxHeight()},
 			p9.WidgetSize{Widget: func(gtx l.Context) l.Dimensions {
@@ -63,7 +94,7 @@ func (wg *WalletGUI) GetAppWidget() (a *p9.App) {
 			p9.WidgetSize{Widget: p9.EmptyMaxHeight()},
 		}),
 		"log": wg.Page("log", p9.Widgets{
-			p9.WidgetSize{Widget: p9.EmptyMaxHeight()},
+			p9.WidgetSize{Widget: wg.LogPage()},
 		}),
 		"quit": wg.Page("quit", p9.Widgets{
 			p9.WidgetSize{Widget: func(gtx l.Context) l.Dimensions {
@@ -121,7 +152,7 @@ func (wg *WalletGUI) GetAppWidget() (a *p9.App) {
 			p9.WidgetSize{Widget: wg.th.VFlex().SpaceAround().AlignMiddle().Rigid(wg.th.H1("mining").Alignment(text.Middle).Fn).Fn},
 		}),
 		"explorer": wg.Page("explorer", p9.Widgets{
-			p9.WidgetSize{Widget: wg.th.VFlex().SpaceAround().AlignMiddle().Rigid(wg.th.H1("explorer").Alignment(text.Middle).Fn).Fn},
+			p9.WidgetSize{Widget: wg.ExplorerPage()},
 		}),
 	})
 	a.SideBar([]l.Widget{
@@ -129,7 +160,15 @@ func (wg *WalletGUI) GetAppWidget() (a *p9.App) {
 		wg.SideBarButton("send", "send", 1),
 		wg.SideBarButton("receive", "receive", 2),
 		wg.SideBarButton("history", "history", 3),
+		wg.SideBarButton("address book", "addressbook", 16),
+		wg.SideBarButton("privacy", "privacy", 12),
+		wg.SideBarButton("shared wallets", "multisig", 13),
+		wg.SideBarButton("coins", "coins", 14),
+		wg.SideBarButton("offline signing", "offline", 17),
+		wg.SideBarButton("import key", "import", 15),
+		wg.SideBarButton("rescan", "rescan", 18),
 		wg.SideBarButton("explorer", "explorer", 6),
+		wg.SideBarButton("peers", "peers", 19),
 		wg.SideBarButton("mining", "mining", 7),
 		wg.SideBarButton("console", "console", 9),
 		wg.SideBarButton("settings", "settings", 5),
@@ -172,7 +211,10 @@ func (wg *WalletGUI) Page(title string, widget p9.Widgets) func(gtx l.Context) l
 				Rigid(
 					a.Responsive(*wg.Size, p9.Widgets{
 						p9.WidgetSize{
-							Widget: a.Inset(0.25, a.H5(title).Color(wg.BodyColorGet()).Fn).Fn,
+							Widget: a.Flex().AlignMiddle().
+								Rigid(a.Inset(0.25, a.H5(title).Color(wg.BodyColorGet()).Fn).Fn).
+								Rigid(wg.watchOnlyBadge()).
+								Fn,
 						},
 						p9.WidgetSize{
 							Size:   800,
@@ -371,7 +413,7 @@ func (wg *WalletGUI) RunStatusPanel(gtx l.Context) l.Dimensions {
 			// ).
 			Rigid(
 				wg.th.Inset(0.33,
-					wg.th.Body1(fmt.Sprintf("%d", wg.State.bestBlockHeight)).
+					wg.th.Body1(wg.syncStatusText()).
 						Font("go regular").TextScale(p9.Scales["Caption"]).
 						Color("DocText").
 						Fn,
@@ -463,3 +505,23 @@ func (wg *WalletGUI) RunStatusPanel(gtx l.Context) l.Dimensions {
 			Fn(gtx)
 	}(gtx)
 }
+
+// syncStatusText is the best block height, followed by the stage of the sync, its progress and the time left while the
+// chain is not yet in sync
+func (wg *WalletGUI) syncStatusText() string {
+	txt := fmt.Sprintf("%d", wg.State.BestBlockHeight())
+	st := wg.State.SyncStatus()
+	if st == nil || st.Stage == "synced" {
+		return txt
+	}
+	txt += " " + st.Stage
+	for _, stage := range st.Stages {
+		if stage.Name == st.Stage && stage.Progress > 0 {
+			txt += fmt.Sprintf(" %.1f%%", stage.Progress)
+		}
+	}
+	if st.ETA > 0 {
+		txt += fmt.Sprintf(", %v left", time.Duration(st.ETA)*time.Second)
+	}
+	return txt
+}
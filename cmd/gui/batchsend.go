@@ -0,0 +1,319 @@
+package gui
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	l "gioui.org/layout"
+	"github.com/atotto/clipboard"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// maxBatchErrors is how many of the problems found in a batch of payments are listed before the rest are counted
+const maxBatchErrors = 10
+
+// batchPayment is a recipient read from a file of payments
+type batchPayment struct {
+	Address util.Address
+	Amount  util.Amount
+	Label   string
+}
+
+// parseBatchPayments reads the payments in a CSV or TSV file of address, amount and optional label rows, such as the
+// payout lists exported by mining pools. Amounts are in DUO whatever the display settings, as these files are written
+// by programs, with a decimal point or the decimal comma of a spreadsheet. A first row without an amount is taken to be
+// a header, rows starting with # are skipped, and the rows that are not valid payments on the network are described in
+// errs.
+func parseBatchPayments(data string, net *netparams.Params) (payments []batchPayment, errs []string) {
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comma = batchDelimiter(data)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows := make(map[string]int)
+	for row := 1; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err.Error())
+			break
+		}
+		p, err := parseBatchPayment(record, net)
+		if err != nil {
+			// a header names the columns, so neither its address nor its amount parse
+			if row == 1 && len(record) > 1 {
+				if _, e := batchAmount(record[1]); e != nil {
+					continue
+				}
+			}
+			errs = append(errs, fmt.Sprintf("row %d: %v", row, err))
+			continue
+		}
+		// sendmany pays each address once, so a repeated address would silently lose a payment
+		address := p.Address.EncodeAddress()
+		if first, ok := rows[address]; ok {
+			errs = append(errs, fmt.Sprintf("row %d: %s is already paid in row %d", row, address, first))
+			continue
+		}
+		rows[address] = row
+		payments = append(payments, p)
+	}
+	return
+}
+
+// batchDelimiter picks tabs for TSV files, and semicolons for the CSV files of spreadsheets that use a decimal comma
+func batchDelimiter(data string) rune {
+	line := data
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	switch {
+	case strings.Contains(line, "\t"):
+		return '\t'
+	case strings.Contains(line, ";"):
+		return ';'
+	}
+	return ','
+}
+
+// parseBatchPayment checks that a row of a file of payments pays an amount above the dust limit to an address of the
+// network
+func parseBatchPayment(record []string, net *netparams.Params) (p batchPayment, err error) {
+	if len(record) < 2 {
+		return p, errors.New("expected an address and an amount")
+	}
+	if p.Address, err = util.DecodeAddress(strings.TrimSpace(record[0]), net); err != nil {
+		return p, fmt.Errorf("invalid address %q: %v", record[0], err)
+	}
+	if !p.Address.IsForNet(net) {
+		return p, fmt.Errorf("%s is an address of another network", record[0])
+	}
+	var f float64
+	if f, err = batchAmount(record[1]); err != nil || f <= 0 {
+		return p, fmt.Errorf("invalid amount %q", record[1])
+	}
+	if p.Amount, err = util.NewAmount(f); err != nil {
+		return
+	}
+	var pkScript []byte
+	if pkScript, err = txscript.PayToAddrScript(p.Address); err != nil {
+		return
+	}
+	if err = txrules.CheckOutput(wire.NewTxOut(int64(p.Amount), pkScript), txrules.DefaultRelayFeePerKb); err != nil {
+		return p, fmt.Errorf("%s is too small to send", p.Amount)
+	}
+	if len(record) > 2 {
+		p.Label = strings.TrimSpace(record[2])
+	}
+	return
+}
+
+// batchAmount parses an amount in DUO written with a decimal point or a decimal comma
+func batchAmount(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, ".") && strings.Count(s, ",") == 1 {
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// batchErrors joins the problems found in a batch of payments, listing only the first few
+func batchErrors(errs []string) string {
+	if len(errs) > maxBatchErrors {
+		errs = append(errs[:maxBatchErrors:maxBatchErrors], fmt.Sprintf("and %d more", len(errs)-maxBatchErrors))
+	}
+	return strings.Join(errs, "\n")
+}
+
+// importBatchPayments fills the send form with the payments in a CSV or TSV file, replacing the recipients that have
+// nothing filled in, and lists the rows that could not be imported
+func (wg *WalletGUI) importBatchPayments(data string) {
+	payments, errs := parseBatchPayments(data, wg.cx.ActiveNet)
+	if len(payments) == 0 && len(errs) == 0 {
+		errs = append(errs, "no payments were found")
+	}
+	var kept []SendAddress
+	for i := range wg.sendAddresses {
+		if wg.sendAddresses[i].AddressInput.GetText() != "" || wg.sendAddresses[i].AmountInput.GetText() != "" {
+			kept = append(kept, wg.sendAddresses[i])
+		}
+	}
+	if len(payments) > 0 {
+		wg.sendAddresses = kept
+		for _, p := range payments {
+			wg.CreateSendAddressItem()
+			i := len(wg.sendAddresses) - 1
+			wg.payToAddressBookEntry(i, AddressBookEntry{Address: p.Address.EncodeAddress(), Label: p.Label})
+			wg.sendAddresses[i].AmountInput = wg.th.Input(wg.formatAmount(p.Amount.ToDUO()), "Enter amount", "Primary",
+				"DocText", 10, func(pass string) {})
+		}
+		wg.toasts.AddToast("Import payments", fmt.Sprintf("%d recipients imported", len(payments)), "Success")
+	}
+	if len(errs) > 0 {
+		wg.dialog.ShowDialog(fmt.Sprintf("%d rows could not be imported", len(errs)), "Warning", batchErrors(errs))()
+		return
+	}
+	wg.dialog.Close()
+}
+
+// importBatchDialog opens a dialog for importing the payments of a CSV or TSV file, or of text copied from a
+// spreadsheet, into the send form
+func (wg *WalletGUI) importBatchDialog() {
+	wg.dialog.ShowDialog("Import payments from a CSV or TSV file", "Info",
+		wg.th.VFlex().
+			Rigid(
+				wg.Inset(0.25,
+					wg.Caption("One payment per row: address, amount in DUO and an optional label").
+						Color("PanelText").Fn,
+				).Fn,
+			).
+			Rigid(wg.Inset(0.25, wg.inputs["batchPath"].Fn).Fn).
+			Rigid(
+				wg.th.Flex().
+					Rigid(
+						wg.Inset(0.25,
+							wg.buttonText(wg.clickables["batchRead"], "Read file", func() {
+								path := strings.TrimSpace(wg.inputs["batchPath"].GetText())
+								if path == "" {
+									wg.toasts.AddToast("Import payments", "enter the path of the file", "Danger")
+									return
+								}
+								data, err := ioutil.ReadFile(path)
+								if Check(err) {
+									wg.toasts.AddToast("Import payments", err.Error(), "Danger")
+									return
+								}
+								wg.importBatchPayments(string(data))
+							}),
+						).Fn,
+					).
+					Rigid(
+						wg.Inset(0.25,
+							wg.buttonText(wg.clickables["batchPaste"], "Paste", func() {
+								txt, err := clipboard.ReadAll()
+								if Check(err) {
+									wg.toasts.AddToast("Import payments", err.Error(), "Danger")
+									return
+								}
+								wg.importBatchPayments(txt)
+							}),
+						).Fn,
+					).Fn,
+			).Fn,
+	)()
+}
+
+// batchRecipients checks every recipient of the send form against the active network, returning the amounts to pay
+// them and their labels, or the problems found with the rows
+func (wg *WalletGUI) batchRecipients() (amounts map[util.Address]util.Amount, labels map[string]string,
+	total util.Amount, errs []string) {
+	amounts = make(map[util.Address]util.Amount)
+	labels = make(map[string]string)
+	rows := make(map[string]int)
+	for i := range wg.sendAddresses {
+		address := strings.TrimSpace(wg.sendAddresses[i].AddressInput.GetText())
+		amount := strings.TrimSpace(wg.sendAddresses[i].AmountInput.GetText())
+		if address == "" && amount == "" {
+			continue
+		}
+		row := i + 1
+		addr, err := util.DecodeAddress(address, wg.cx.ActiveNet)
+		if err != nil || !addr.IsForNet(wg.cx.ActiveNet) {
+			errs = append(errs, fmt.Sprintf("recipient %d: %q is not an address of this network", row, address))
+			continue
+		}
+		var amt util.Amount
+		if amt, err = wg.parseAmount(amount); err != nil || amt <= 0 {
+			errs = append(errs, fmt.Sprintf("recipient %d: invalid amount %q", row, amount))
+			continue
+		}
+		if first, ok := rows[addr.EncodeAddress()]; ok {
+			errs = append(errs, fmt.Sprintf("recipient %d: %s is already paid by recipient %d", row, address, first))
+			continue
+		}
+		rows[addr.EncodeAddress()] = row
+		amounts[addr] = amt
+		labels[addr.EncodeAddress()] = wg.sendAddresses[i].LabelInput.GetText()
+		total += amt
+	}
+	if len(amounts) == 0 && len(errs) == 0 {
+		errs = append(errs, "enter a recipient to pay")
+	}
+	return
+}
+
+// sendBatch previews paying all of the recipients of the send form in one sendmany transaction
+func (wg *WalletGUI) sendBatch() {
+	amounts, labels, total, errs := wg.batchRecipients()
+	if len(errs) > 0 {
+		wg.dialog.ShowDialog("Check the recipients", "Warning", batchErrors(errs))()
+		return
+	}
+	feeRate, _, err := wg.feeRate()
+	if err != nil {
+		go wg.toasts.AddToast("Fee error", err.Error(), "Danger")
+		return
+	}
+	var preview *btcjson.PreviewSendResult
+	if preview, err = wg.WalletClient.PreviewSendFeeRate("default", amounts, 1, feeRate); Check(err) {
+		go wg.toasts.AddToast("Send error", err.Error(), "Danger")
+		return
+	}
+	wg.dialog.ShowDialog("Confirm send", "Info", wg.batchConfirmation(amounts, labels, total, feeRate, preview))()
+}
+
+// batchConfirmation shows the total and fee of paying a batch of recipients before it is sent with sendmany
+func (wg *WalletGUI) batchConfirmation(amounts map[util.Address]util.Amount, labels map[string]string,
+	total, feeRate util.Amount, preview *btcjson.PreviewSendResult) l.Widget {
+	lines := []string{
+		fmt.Sprintf("Pay %s to %d recipients", wg.formatAmount(total.ToDUO()), len(amounts)),
+		fmt.Sprintf("Fee %s (%s/kB)", wg.formatAmount(preview.Fee), wg.formatAmount(preview.FeeRate)),
+		fmt.Sprintf("Total %s", wg.formatAmount(total.ToDUO()+preview.Fee)),
+	}
+	if preview.AncestorSize > 0 {
+		lines = append(lines,
+			fmt.Sprintf("Spends unconfirmed change, effective package fee rate %s/kB",
+				wg.formatAmount(preview.PackageFeeRate)),
+		)
+	}
+	flex := wg.th.VFlex()
+	for i := range lines {
+		flex = flex.Rigid(
+			wg.Inset(0.25,
+				wg.Body2(lines[i]).Color("PanelText").Fn,
+			).Fn,
+		)
+	}
+	return flex.Rigid(
+		wg.Inset(0.25,
+			wg.buttonText(wg.clickables["sendConfirm"], "Send", func() {
+				wg.dialog.Close()
+				highRisk := total.ToDUO() > *wg.cx.Config.ReauthThreshold
+				wg.authorize("Enter the wallet passphrase to send", highRisk, func() {
+					h, err := wg.WalletClient.SendManyFeeRate("default", amounts, 1, feeRate)
+					if Check(err) {
+						wg.toasts.AddToast("Send error", err.Error(), "Danger")
+						return
+					}
+					for address, label := range labels {
+						wg.addToAddressBook(address, label, false)
+					}
+					wg.toasts.AddToast("TxID", h.String(), "Success")
+				})
+			}),
+		).Fn,
+	).Fn
+}
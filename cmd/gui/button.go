@@ -72,6 +72,27 @@ func (wg *WalletGUI) buttonIcon(b *p9.Clickable, label string, ico *[]byte) func
 	}
 }
 
+// buttonIconClick is an icon button that runs click, where buttonIcon opens the page of its label
+func (wg *WalletGUI) buttonIconClick(b *p9.Clickable, ico *[]byte, click func()) func(gtx l.Context) l.Dimensions {
+	return func(gtx l.Context) l.Dimensions {
+		ic := wg.Icon().
+			Scale(p9.Scales["H5"]).
+			Color(wg.MenuColorGet()).
+			Src(ico).
+			Fn
+		return wg.ButtonLayout(b).
+			CornerRadius(0).
+			Embed(
+				wg.Inset(0.375,
+					ic,
+				).Fn,
+			).
+			Background(wg.TitleBarBackgroundGet()).
+			SetClick(click).
+			Fn(gtx)
+	}
+}
+
 func (wg *WalletGUI) buttonIconText(b *p9.Clickable, label string, ico *[]byte, onClick func()) func(gtx l.Context) l.Dimensions {
 	return func(gtx l.Context) l.Dimensions {
 		ic := wg.Icon().
@@ -98,3 +119,14 @@ func (wg *WalletGUI) buttonIconText(b *p9.Clickable, label string, ico *[]byte,
 		).Fn(gtx)
 	}
 }
+
+// keyedClickable returns the clickable stored under the key, making it the first time, for the buttons of list entries
+// that come and go
+func (wg *WalletGUI) keyedClickable(key string) *p9.Clickable {
+	clk, ok := wg.clickables[key]
+	if !ok {
+		clk = wg.th.Clickable()
+		wg.clickables[key] = clk
+	}
+	return clk
+}
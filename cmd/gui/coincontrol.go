@@ -0,0 +1,331 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+
+	l "gioui.org/layout"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	txsizes "github.com/p9c/pod/pkg/chain/tx/sizes"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/gui/p9"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// p2pkhScriptSize is the size of the pay to pubkey hash script of a change output
+const p2pkhScriptSize = 25
+
+// CoinPlan is a send funded from the outputs picked in the coin control panel, with the fee and change it works out to
+type CoinPlan struct {
+	Inputs  []btcjson.ListUnspentResult
+	Outputs []*wire.TxOut
+	// Labels are the labels of the recipients of the outputs, for the address book
+	Labels  map[string]string
+	In      util.Amount
+	Out     util.Amount
+	Fee     util.Amount
+	Change  util.Amount
+	Size    int
+	FeeRate util.Amount
+}
+
+// keyedBool returns the bool stored under the key, making it the first time, for the checkboxes of list entries that
+// come and go
+func (wg *WalletGUI) keyedBool(key string) *p9.Bool {
+	b, ok := wg.bools[key]
+	if !ok {
+		b = wg.th.Bool(false)
+		wg.bools[key] = b
+	}
+	return b
+}
+
+func coinKey(u *btcjson.ListUnspentResult) string {
+	return fmt.Sprintf("coinSelect%s:%d", u.TxID, u.Vout)
+}
+
+// selectedCoins returns the unspent outputs that are ticked in the coin control panel
+func (wg *WalletGUI) selectedCoins() (selected []btcjson.ListUnspentResult) {
+	unspent, _ := wg.State.Coins()
+	for i := range unspent {
+		if wg.keyedBool(coinKey(&unspent[i])).GetValue() {
+			selected = append(selected, unspent[i])
+		}
+	}
+	return
+}
+
+// clearCoinSelection unticks all of the outputs in the coin control panel
+func (wg *WalletGUI) clearCoinSelection() {
+	unspent, _ := wg.State.Coins()
+	for i := range unspent {
+		wg.keyedBool(coinKey(&unspent[i])).Value(false)
+	}
+}
+
+// coinPlan works out the fee and change of paying the recipients of the send form from the selected outputs, with a
+// change output unless the change would be dust, in which case it is left to the fee. The fee rate is the one chosen in
+// the fee selector, while dust is judged by the relay fee as it is by the wallet.
+func (wg *WalletGUI) coinPlan() (plan *CoinPlan, err error) {
+	plan = &CoinPlan{Inputs: wg.selectedCoins(), Labels: make(map[string]string)}
+	if len(plan.Inputs) == 0 {
+		return nil, errors.New("no outputs are selected")
+	}
+	if plan.FeeRate, _, err = wg.feeRate(); err != nil {
+		return
+	}
+	for i := range plan.Inputs {
+		var amt util.Amount
+		if amt, err = util.NewAmount(plan.Inputs[i].Amount); err != nil {
+			return
+		}
+		plan.In += amt
+	}
+	for i := range wg.sendAddresses {
+		address := wg.sendAddresses[i].AddressInput.GetText()
+		amount := wg.sendAddresses[i].AmountInput.GetText()
+		if address == "" && amount == "" {
+			continue
+		}
+		var addr util.Address
+		if addr, err = util.DecodeAddress(address, wg.cx.ActiveNet); err != nil {
+			return
+		}
+		var amt util.Amount
+		if amt, err = wg.parseAmount(amount); err != nil {
+			return
+		}
+		var pkScript []byte
+		if pkScript, err = txscript.PayToAddrScript(addr); err != nil {
+			return
+		}
+		out := wire.NewTxOut(int64(amt), pkScript)
+		if err = txrules.CheckOutput(out, txrules.DefaultRelayFeePerKb); err != nil {
+			return
+		}
+		plan.Outputs = append(plan.Outputs, out)
+		plan.Labels[addr.EncodeAddress()] = wg.sendAddresses[i].LabelInput.GetText()
+		plan.Out += amt
+	}
+	if len(plan.Outputs) == 0 {
+		return nil, errors.New("enter a recipient to pay")
+	}
+	plan.Size = txsizes.EstimateSerializeSize(len(plan.Inputs), plan.Outputs, true)
+	plan.Fee = txrules.FeeForSerializeSize(plan.FeeRate, plan.Size)
+	plan.Change = plan.In - plan.Out - plan.Fee
+	if plan.Change < 0 {
+		return plan, fmt.Errorf("the selected outputs are %s short of the amount and fee",
+			wg.formatAmount((-plan.Change).ToDUO()))
+	}
+	if txrules.IsDustAmount(plan.Change, p2pkhScriptSize, txrules.DefaultRelayFeePerKb) {
+		plan.Size = txsizes.EstimateSerializeSize(len(plan.Inputs), plan.Outputs, false)
+		plan.Fee, plan.Change = plan.In-plan.Out, 0
+	}
+	return
+}
+
+// coinControlPanel lists the spendable outputs of the wallet on the send page, so the outputs that fund the send can be
+// picked and outputs can be locked, with the fee and change of the send from the picked outputs. When none are picked
+// the wallet selects the coins itself.
+func (wg *WalletGUI) coinControlPanel() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.coinControlWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.Inset(0.25,
+			wg.Fill("DocBg",
+				wg.Inset(0.25,
+					wg.lists["coinControl"].Vertical().Length(len(lines)).ListElement(le).Fn,
+				).Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) coinControlWidgets() (out []l.Widget) {
+	unspent, locked := wg.State.Coins()
+	if unspent == nil {
+		return []l.Widget{wg.privacyLine("loading unspent outputs...")}
+	}
+	out = append(out, wg.privacyHeading("coin control"))
+	selected := wg.selectedCoins()
+	if len(selected) == 0 {
+		out = append(out, wg.privacyLine("no outputs selected, the wallet selects the coins to send"))
+	} else {
+		plan, err := wg.coinPlan()
+		if plan == nil {
+			out = append(out, wg.privacyLine(fmt.Sprintf("%d outputs selected: %s", len(selected), err)))
+		} else {
+			txt := fmt.Sprintf("%d outputs selected, %s, paying %s with a fee of %s (%s/kB), change %s",
+				len(plan.Inputs), wg.formatAmount(plan.In.ToDUO()), wg.formatAmount(plan.Out.ToDUO()),
+				wg.formatAmount(plan.Fee.ToDUO()), wg.formatAmount(plan.FeeRate.ToDUO()),
+				wg.formatAmount(plan.Change.ToDUO()))
+			if err != nil {
+				txt += ": " + err.Error()
+			}
+			out = append(out, wg.privacyLine(txt))
+		}
+		out = append(out,
+			wg.Inset(0.25,
+				wg.buttonText(wg.clickables["coinControlClear"], "Clear selection", wg.clearCoinSelection),
+			).Fn,
+		)
+	}
+	for i := range unspent {
+		u := unspent[i]
+		hash, err := chainhash.NewHashFromStr(u.TxID)
+		if Check(err) {
+			continue
+		}
+		txt := fmt.Sprintf("%s %s, %d confirmations, %s:%d", u.Address, wg.formatAmount(u.Amount), u.Confirmations,
+			u.TxID, u.Vout)
+		if e, ok := wg.addressBook.Lookup(u.Address); ok {
+			txt = e.Label + ": " + txt
+		}
+		key := coinKey(&u)
+		out = append(out,
+			wg.th.Flex().
+				Rigid(
+					wg.Inset(0.25,
+						func(gtx l.Context) l.Dimensions {
+							return wg.th.CheckBox(wg.keyedBool(key)).
+								TextColor("DocText").
+								TextScale(1).
+								IconScale(1).
+								Fn(gtx)
+						},
+					).Fn,
+				).
+				Rigid(wg.coinLockButton(*wire.NewOutPoint(hash, u.Vout), false)).
+				Flexed(1, wg.privacyLine(txt)).
+				Fn,
+		)
+	}
+	if len(locked) > 0 {
+		out = append(out, wg.privacyHeading("locked outputs, which are not spent until they are unlocked"))
+	}
+	for _, op := range locked {
+		out = append(out,
+			wg.th.Flex().
+				Rigid(wg.coinLockButton(*op, true)).
+				Flexed(1, wg.privacyLine(op.String())).
+				Fn,
+		)
+	}
+	return
+}
+
+// coinLockButton locks an output, or unlocks it if it is locked
+func (wg *WalletGUI) coinLockButton(op wire.OutPoint, locked bool) l.Widget {
+	label := "Lock"
+	if locked {
+		label = "Unlock"
+	}
+	return wg.Inset(0.25,
+		wg.buttonText(wg.keyedClickable("coinControlLock"+op.String()), label, func() {
+			go func() {
+				if err := wg.WalletClient.LockUnspent(locked, []*wire.OutPoint{&op}); Check(err) {
+					wg.toasts.AddToast("Coin control", err.Error(), "Danger")
+					return
+				}
+				wg.updateCoins()
+				wg.invalidate <- struct{}{}
+			}()
+		}),
+	).Fn
+}
+
+// coinControlConfirmation shows the fee and change of a send from the selected outputs before it is made
+func (wg *WalletGUI) coinControlConfirmation(plan *CoinPlan) func(gtx l.Context) l.Dimensions {
+	var lines []string
+	for _, o := range plan.Outputs {
+		txt := wg.formatAmount(util.Amount(o.Value).ToDUO())
+		if _, addrs, _, err := txscript.ExtractPkScriptAddrs(o.PkScript, wg.cx.ActiveNet); err == nil && len(addrs) > 0 {
+			txt += " to " + addrs[0].EncodeAddress()
+		}
+		lines = append(lines, "Pay "+txt)
+	}
+	lines = append(lines,
+		fmt.Sprintf("From %d selected outputs totalling %s", len(plan.Inputs), wg.formatAmount(plan.In.ToDUO())),
+		fmt.Sprintf("Fee %s (%s/kB)", wg.formatAmount(plan.Fee.ToDUO()), wg.formatAmount(plan.FeeRate.ToDUO())),
+	)
+	if plan.Change > 0 {
+		lines = append(lines, "Change "+wg.formatAmount(plan.Change.ToDUO()))
+	} else {
+		lines = append(lines, "No change, what is left over is added to the fee")
+	}
+	flex := wg.th.VFlex()
+	for i := range lines {
+		flex = flex.Rigid(
+			wg.Inset(0.25,
+				wg.Body2(lines[i]).Color("PanelText").Fn,
+			).Fn,
+		)
+	}
+	return flex.Rigid(
+		wg.Inset(0.25,
+			wg.buttonText(wg.clickables["sendConfirm"], "Send", func() {
+				wg.dialog.Close()
+				// large sends ask for the passphrase even while the wallet is unlocked
+				highRisk := plan.Out.ToDUO() > *wg.cx.Config.ReauthThreshold
+				wg.authorize("Enter the wallet passphrase to send", highRisk, func() {
+					h, err := wg.sendCoinPlan(plan)
+					if Check(err) {
+						wg.toasts.AddToast("Send error", err.Error(), "Danger")
+						return
+					}
+					for address, label := range plan.Labels {
+						wg.addToAddressBook(address, label, false)
+					}
+					// the spent outputs drop out of the list, and with them out of the selection
+					wg.updateCoins()
+					wg.toasts.AddToast("TxID", h.String(), "Success")
+				})
+			}),
+		).Fn,
+	).Fn
+}
+
+// sendCoinPlan makes the transaction spending the selected outputs with change to a new change address of the wallet,
+// has the wallet sign it and sends it to the node
+func (wg *WalletGUI) sendCoinPlan(plan *CoinPlan) (h *chainhash.Hash, err error) {
+	if wg.ChainClient == nil {
+		return nil, errors.New("not connected to the node")
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	for i := range plan.Inputs {
+		var hash *chainhash.Hash
+		if hash, err = chainhash.NewHashFromStr(plan.Inputs[i].TxID); err != nil {
+			return
+		}
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(hash, plan.Inputs[i].Vout), nil, nil))
+	}
+	for _, o := range plan.Outputs {
+		tx.AddTxOut(o)
+	}
+	if plan.Change > 0 {
+		var change util.Address
+		if change, err = wg.WalletClient.GetRawChangeAddress("default"); err != nil {
+			return
+		}
+		var pkScript []byte
+		if pkScript, err = txscript.PayToAddrScript(change); err != nil {
+			return
+		}
+		tx.AddTxOut(wire.NewTxOut(int64(plan.Change), pkScript))
+	}
+	var signed *wire.MsgTx
+	var complete bool
+	if signed, complete, err = wg.WalletClient.SignRawTransaction(tx); err != nil {
+		return
+	}
+	if !complete {
+		return nil, errors.New("the wallet could not sign all of the selected outputs")
+	}
+	return wg.ChainClient.SendRawTransaction(signed, false)
+}
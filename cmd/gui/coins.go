@@ -0,0 +1,142 @@
+package gui
+
+import (
+	"fmt"
+
+	l "gioui.org/layout"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// CoinsPage is the coin control panel, listing the wallet's unspent outputs so that individual outputs can be locked
+// to keep them out of coin selection, and the locked outputs so that they can be released again
+func (wg *WalletGUI) CoinsPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.coinsWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["coins"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) coinsWidgets() (out []l.Widget) {
+	unspent, locked := wg.State.Coins()
+	if unspent == nil {
+		return []l.Widget{wg.privacyLine("loading unspent outputs...")}
+	}
+	out = append(out, wg.privacyHeading("locked outputs"))
+	if len(locked) == 0 {
+		out = append(out, wg.privacyLine("no locked outputs, locked outputs are never spent by the wallet"))
+	}
+	for _, op := range locked {
+		out = append(out, wg.coinLine(*op, op.String(), "", true))
+	}
+	out = append(out, wg.privacyHeading("spendable outputs"))
+	if len(unspent) == 0 {
+		out = append(out, wg.privacyLine("no unspent outputs"))
+	}
+	for _, u := range unspent {
+		hash, err := chainhash.NewHashFromStr(u.TxID)
+		if Check(err) {
+			continue
+		}
+		txt := fmt.Sprintf("%s:%d %s %s, %d confirmations", u.TxID, u.Vout, u.Address, wg.formatAmount(u.Amount),
+			u.Confirmations)
+		out = append(out, wg.coinLine(*wire.NewOutPoint(hash, u.Vout), txt, u.Address, false))
+	}
+	return
+}
+
+// coinLine shows an output with a button to lock it, or to unlock it if it is already locked, and a button to export the
+// private key of the address it pays to when the address is known
+func (wg *WalletGUI) coinLine(op wire.OutPoint, txt, address string, locked bool) l.Widget {
+	key := "coin" + op.String()
+	clk, ok := wg.clickables[key]
+	if !ok {
+		clk = wg.th.Clickable()
+		wg.clickables[key] = clk
+	}
+	label := "Lock"
+	if locked {
+		label = "Unlock"
+	}
+	flex := wg.th.Flex()
+	if address != "" {
+		exportKey := "export" + op.String()
+		exportClk, ok := wg.clickables[exportKey]
+		if !ok {
+			exportClk = wg.th.Clickable()
+			wg.clickables[exportKey] = exportClk
+		}
+		flex = flex.Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(exportClk, "Export key", func() {
+					wg.authorize("Enter the wallet passphrase to export the private key", true, func() {
+						wg.exportKey(address)
+					})
+				}),
+			).Fn,
+		)
+	}
+	return flex.
+		Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(clk, label, func() {
+					go func() {
+						if err := wg.WalletClient.LockUnspent(locked, []*wire.OutPoint{&op}); Check(err) {
+							wg.toasts.AddToast("Coin control", err.Error(), "Danger")
+							return
+						}
+						wg.updateCoins()
+						wg.invalidate <- struct{}{}
+					}()
+				}),
+			).Fn,
+		).
+		Flexed(1, wg.privacyLine(txt)).
+		Fn
+}
+
+// exportKey shows the private key of an address of the wallet
+func (wg *WalletGUI) exportKey(address string) {
+	if wg.State.WatchOnly() {
+		wg.watchOnlyRefusal()
+		return
+	}
+	addr, err := util.DecodeAddress(address, wg.cx.ActiveNet)
+	if Check(err) {
+		wg.toasts.AddToast("Export key", err.Error(), "Danger")
+		return
+	}
+	var wif *util.WIF
+	if wif, err = wg.WalletClient.DumpPrivKey(addr); Check(err) {
+		wg.toasts.AddToast("Export key", err.Error(), "Danger")
+		return
+	}
+	wg.dialog.ShowDialog("Private key of "+address, "Warning", wif.String())()
+	wg.invalidate <- struct{}{}
+}
+
+// updateCoins fetches the unspent and locked outputs from the wallet
+func (wg *WalletGUI) updateCoins() {
+	unspent, err := wg.WalletClient.ListUnspent()
+	if Check(err) {
+		return
+	}
+	var locked []*wire.OutPoint
+	if locked, err = wg.WalletClient.ListLockUnspent(); Check(err) {
+		return
+	}
+	wg.State.SetCoins(unspent, locked)
+}
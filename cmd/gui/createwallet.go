@@ -1,7 +1,6 @@
 package gui
 
 import (
-	"encoding/hex"
 	"math/rand"
 	"os"
 	"time"
@@ -12,7 +11,6 @@ import (
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
 	"github.com/p9c/pod/pkg/chain/mining/addresses"
-	"github.com/p9c/pod/pkg/util/hdkeychain"
 	"github.com/p9c/pod/pkg/wallet"
 )
 
@@ -25,31 +23,58 @@ func (wg *WalletGUI) WalletPage(gtx l.Context) l.Dimensions {
 					Rigid(
 						wg.th.VFlex().SpaceAround().AlignMiddle().
 							Rigid(
-								wg.th.H4("create new wallet").
+								wg.th.H4(wg.createWalletTitle()).
 									Color("PanelText").
 									// Alignment(text.Middle).
 									Fn,
 							).
 							Rigid(
-								wg.th.Inset(0.25,
+								wg.seedWalletOnly(wg.th.Inset(0.25,
 									wg.passwords["passEditor"].Fn,
-								).Fn,
+								).Fn),
 							).
 							Rigid(
-								wg.th.Inset(0.25,
+								wg.seedWalletOnly(wg.th.Inset(0.25,
 									wg.passwords["confirmPassEditor"].Fn,
-								).Fn,
+								).Fn),
 							).
 							Rigid(
-								wg.th.Inset(0.25,
-									wg.inputs["walletSeed"].Fn,
-								).Fn,
+								wg.restoreWalletOnly(wg.th.Inset(0.25,
+									wg.inputs["restoreMnemonic"].Fn,
+								).Fn),
+							).
+							Rigid(
+								wg.seedWalletOnly(wg.th.Inset(0.25,
+									wg.inputs["mnemonicPassphrase"].Fn,
+								).Fn),
+							).
+							Rigid(
+								wg.watchOnlyWalletOnly(wg.th.Inset(0.25,
+									wg.inputs["watchOnlyXPub"].Fn,
+								).Fn),
+							).
+							Rigid(
+								wg.watchOnlyWalletOnly(wg.th.Inset(0.25,
+									wg.buttonText(wg.clickables["createImportDevice"], "Import from hardware wallet",
+										wg.importFromDevice),
+								).Fn),
 							).
 							Rigid(
 								wg.th.Inset(0.25,
 									wg.passwords["publicPassEditor"].Fn,
 								).Fn,
 							).
+							Rigid(wg.th.Inset(0.25,
+								func(gtx l.Context) l.Dimensions {
+									gtx.Constraints.Min.X = int(wg.th.TextSize.Scale(16).V)
+									return wg.CheckBox(wg.bools["encryption"]).
+										IconColor("Primary").
+										TextColor("DocText").
+										Text("Encrypt the whole wallet database with the public password").
+										Fn(gtx)
+								},
+							).Fn,
+							).
 							Rigid(wg.th.Inset(0.25,
 								func(gtx l.Context) l.Dimensions {
 									gtx.Constraints.Min.X = int(wg.th.TextSize.Scale(16).V)
@@ -79,22 +104,43 @@ func (wg *WalletGUI) WalletPage(gtx l.Context) l.Dimensions {
 								},
 							).Fn,
 							).
-							Rigid(
-								wg.th.Body1("your seed").
-									Color("PanelText").
-									Fn,
+							Rigid(wg.th.Inset(0.25,
+								func(gtx l.Context) l.Dimensions {
+									gtx.Constraints.Min.X = int(wg.th.TextSize.Scale(16).V)
+									return wg.CheckBox(wg.bools["watchOnly"]).
+										IconColor("Primary").
+										TextColor("DocText").
+										Text("Watch-only wallet from an account's extended public key").
+										Fn(gtx)
+								},
+							).Fn,
 							).
-							Rigid(
+							Rigid(wg.seedWalletOnly(wg.th.Inset(0.25,
 								func(gtx l.Context) l.Dimensions {
-									gtx.Constraints.Max.X = int(wg.TextSize.Scale(22).V)
-									return wg.th.Caption(wg.inputs["walletSeed"].GetText()).
-										Font("go regular").
-										TextScale(0.66).
+									gtx.Constraints.Min.X = int(wg.th.TextSize.Scale(16).V)
+									return wg.CheckBox(wg.bools["restore"]).
+										IconColor("Primary").
+										TextColor("DocText").
+										Text("Restore a wallet from its recovery phrase").
 										Fn(gtx)
 								},
+							).Fn),
 							).
 							Rigid(
-								wg.th.Inset(0.5,
+								wg.newWalletOnly(wg.recoveryPhraseWidget()),
+							).
+							Rigid(
+								wg.seedWalletOnly(wg.createWalletLine(wg.walletSeedStatus())),
+							).
+							Rigid(
+								wg.watchOnlyWalletOnly(wg.th.Inset(0.25,
+									wg.th.Body1(wg.watchOnlyXPubStatus()).
+										Color("PanelText").
+										Fn,
+								).Fn),
+							).
+							Rigid(
+								wg.newWalletOnly(wg.th.Inset(0.5,
 									func(gtx l.Context) l.Dimensions {
 										gtx.Constraints.Max.X = int(wg.th.TextSize.Scale(36).V)
 										gtx.Constraints.Min.X = int(wg.th.TextSize.Scale(16).V)
@@ -103,30 +149,31 @@ func (wg *WalletGUI) WalletPage(gtx l.Context) l.Dimensions {
 										})).
 											IconColor("Primary").
 											TextColor("DocText").
-											Text("I have stored the seed and password safely " +
+											Text("I have stored the recovery phrase and password safely " +
 												"and understand it cannot be recovered").
 											Fn(gtx)
 									},
-								).Fn,
+								).Fn),
 							).
 							Rigid(
 								func(gtx l.Context) l.Dimensions {
-									var b []byte
 									var err error
-									seedValid := true
-									if b, err = hex.DecodeString(wg.inputs["walletSeed"].GetText()); Check(err) {
-										seedValid = false
-									} else if len(b) != 0 && len(b) < hdkeychain.MinSeedBytes ||
-										len(b) > hdkeychain.MaxSeedBytes {
-										seedValid = false
-									}
-									if wg.passwords["passEditor"].GetPassword() == "" ||
+									seedErr := wg.checkWalletSeed()
+									if wg.bools["encryption"].GetValue() &&
+										wg.passwords["publicPassEditor"].GetPassword() == "" {
+										// the database would be encrypted with an empty password
+										gtx = gtx.Disabled()
+									} else if wg.creatingWatchOnly() {
+										if _, err = wg.watchOnlyXPub(); err != nil {
+											gtx = gtx.Disabled()
+										}
+									} else if wg.passwords["passEditor"].GetPassword() == "" ||
 										wg.passwords["confirmPassEditor"].GetPassword() == "" ||
 										len(wg.passwords["passEditor"].GetPassword()) < 8 ||
 										wg.passwords["passEditor"].GetPassword() !=
 											wg.passwords["confirmPassEditor"].GetPassword() ||
-										!seedValid ||
-										!wg.bools["ihaveread"].GetValue() {
+										seedErr != nil ||
+										!wg.restoringWallet() && !wg.bools["ihaveread"].GetValue() {
 										gtx = gtx.Disabled()
 									}
 									return wg.th.Flex().
@@ -138,23 +185,50 @@ func (wg *WalletGUI) WalletPage(gtx l.Context) l.Dimensions {
 													// go func() {
 													// wg.ShellRunCommandChan <- "stop"
 													Debug("clicked submit wallet")
+													*wg.cx.Config.EncryptWalletDB = wg.bools["encryption"].GetValue()
+													save.Pod(wg.cx.Config)
 													*wg.cx.Config.WalletFile = *wg.cx.Config.DataDir +
 														string(os.PathSeparator) + wg.cx.ActiveNet.Name +
 														string(os.PathSeparator) + wallet.WalletDbName
 													dbDir := *wg.cx.Config.WalletFile
 													loader := wallet.NewLoader(wg.cx.ActiveNet, dbDir, 250)
-													seed, _ := hex.DecodeString(wg.inputs["walletSeed"].GetText())
-													w, err := loader.CreateNewWallet(
-														[]byte(wg.passwords["publicPassEditor"].GetPassword()),
-														[]byte(wg.passwords["passEditor"].GetPassword()),
-														seed,
-														time.Now(),
-														false,
-														wg.cx.Config,
-													)
+													var w *wallet.Wallet
+													var err error
+													if wg.creatingWatchOnly() {
+														// the account may have been used since the chain began, so
+														// the whole chain is scanned for its history
+														xpub, _ := wg.watchOnlyXPub()
+														w, err = loader.CreateNewWatchingOnlyWallet(
+															[]byte(wg.passwords["publicPassEditor"].GetPassword()),
+															xpub,
+															wg.cx.ActiveNet.GenesisBlock.Header.Timestamp,
+															false,
+															wg.cx.Config,
+														)
+													} else {
+														seed, _ := wg.walletSeed()
+														birthday := time.Now()
+														if wg.restoringWallet() {
+															// a restored wallet may have been used since the chain
+															// began, so the whole chain is rescanned for its
+															// history and the addresses it used are recovered
+															birthday = wg.cx.ActiveNet.GenesisBlock.Header.Timestamp
+														}
+														w, err = loader.CreateNewWallet(
+															[]byte(wg.passwords["publicPassEditor"].GetPassword()),
+															[]byte(wg.passwords["passEditor"].GetPassword()),
+															seed,
+															birthday,
+															false,
+															wg.cx.Config,
+														)
+													}
 													if Check(err) {
 														panic(err)
 													}
+													if wg.creatingWatchOnly() {
+														wg.pairSigningDevice(w)
+													}
 													Warn("refilling mining addresses")
 													addresses.RefillMiningAddresses(w, wg.cx.Config, wg.cx.StateCfg)
 													Warn("done refilling mining addresses")
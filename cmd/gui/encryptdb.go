@@ -0,0 +1,47 @@
+package gui
+
+import (
+	l "gioui.org/layout"
+
+	"github.com/p9c/pod/app/save"
+)
+
+// walletDBWidgets shows whether the whole wallet database is encrypted, with a button to encrypt it while the wallet
+// runs if it is not
+func (wg *WalletGUI) walletDBWidgets() []l.Widget {
+	if wg.State.DBEncrypted() {
+		return []l.Widget{
+			wg.privacyHeading("wallet database"),
+			wg.privacyLine("the whole wallet database, including the transaction history, addresses and labels, is " +
+				"encrypted with the public password"),
+		}
+	}
+	return []l.Widget{
+		wg.privacyHeading("wallet database"),
+		wg.privacyLine("only the private keys in the wallet database are encrypted, so anyone who can read the disk " +
+			"can see the transaction history, addresses and labels of the wallet. Encrypting the whole database " +
+			"with the public password prevents this, and the password is then needed to open the wallet."),
+		wg.Inset(0.25, wg.buttonText(wg.clickables["encryptDB"], "Encrypt", wg.encryptWalletDB)).Fn,
+	}
+}
+
+// encryptWalletDB encrypts the whole database of the running wallet with the public password
+func (wg *WalletGUI) encryptWalletDB() {
+	pass := *wg.cx.Config.WalletPass
+	if pass == "" {
+		go wg.toasts.AddToast("Encrypt wallet database", "the wallet has no public password, so encrypting its "+
+			"database would not protect it", "Danger")
+		return
+	}
+	go func() {
+		if err := wg.WalletClient.EncryptWalletDB(pass); Check(err) {
+			wg.toasts.AddToast("Encrypt wallet database", err.Error(), "Danger")
+			return
+		}
+		*wg.cx.Config.EncryptWalletDB = true
+		save.Pod(wg.cx.Config)
+		wg.State.SetDBEncrypted(true)
+		wg.toasts.AddToast("Encrypt wallet database", "the wallet database is encrypted", "Success")
+		wg.invalidate <- struct{}{}
+	}()
+}
@@ -0,0 +1,315 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	l "gioui.org/layout"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// explorerPageSize is how many transactions of a block or an address are listed on a page of the explorer
+const explorerPageSize = 20
+
+// explorerView is what the explorer page shows, one of a block, a transaction or the transactions of an address
+type explorerView struct {
+	block      *btcjson.GetBlockVerboseResult
+	tx         *btcjson.TxRawResult
+	address    string
+	addressTxs []*btcjson.SearchRawTransactionsResult
+	// page is the page of the transactions of a block or address being shown
+	page int
+}
+
+// ExplorerPage looks up blocks, transactions and addresses on the node, with links to move between them
+func (wg *WalletGUI) ExplorerPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.explorerWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["explorer"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) explorerWidgets() (out []l.Widget) {
+	search := wg.th.Flex().AlignMiddle().
+		Flexed(1, wg.Inset(0.25, wg.inputs["explorerSearch"].Fn).Fn).
+		Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["explorerSearch"], "Search", wg.explorerSearch)).Fn).
+		Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["explorerTip"], "Latest block", func() {
+			go wg.exploreHeight(int64(wg.State.BestBlockHeight()))
+		})).Fn)
+	if wg.State.CanExplorerBack() {
+		search = search.Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["explorerBack"], "Back", func() {
+			wg.State.ExplorerBack()
+		})).Fn)
+	}
+	out = []l.Widget{search.Fn}
+	v := wg.State.ExplorerView()
+	switch {
+	case v == nil:
+		out = append(out, wg.privacyLine("Search for a block by its height or hash, a transaction by its id, or "+
+			"the transactions of an address. Transactions that are not in the wallet are only found when the node "+
+			"keeps a transaction index, and addresses when it keeps an address index."))
+	case v.block != nil:
+		out = append(out, wg.explorerBlockWidgets(v)...)
+	case v.tx != nil:
+		out = append(out, wg.explorerTxWidgets(v.tx)...)
+	default:
+		out = append(out, wg.explorerAddressWidgets(v)...)
+	}
+	return
+}
+
+func (wg *WalletGUI) explorerBlockWidgets(v *explorerView) (out []l.Widget) {
+	b := v.block
+	out = []l.Widget{
+		wg.privacyHeading(fmt.Sprintf("block %d", b.Height)),
+		wg.privacyLine("hash " + b.Hash),
+		wg.privacyLine(fmt.Sprintf("mined %s with %s, %d confirmations",
+			time.Unix(b.Time, 0).Format("2006-01-02 15:04:05"), b.PowAlgo, b.Confirmations)),
+		wg.privacyLine(fmt.Sprintf("%d bytes, difficulty %f, merkle root %s", b.Size, b.Difficulty, b.MerkleRoot)),
+	}
+	nav := wg.th.Flex().AlignMiddle()
+	if b.PreviousHash != "" {
+		hash := b.PreviousHash
+		nav = nav.Rigid(wg.explorerLink("explorerBlock"+hash, "< previous block", func() { wg.exploreBlock(hash) }))
+	}
+	if b.NextHash != "" {
+		hash := b.NextHash
+		nav = nav.Rigid(wg.explorerLink("explorerBlock"+hash, "next block >", func() { wg.exploreBlock(hash) }))
+	}
+	out = append(out, nav.Fn, wg.privacyHeading(fmt.Sprintf("%d transactions", len(b.Tx))))
+	start, end := explorerPageBounds(v.page, len(b.Tx))
+	for _, txid := range b.Tx[start:end] {
+		out = append(out, wg.explorerTxLink(txid))
+	}
+	return append(out, wg.explorerPager(v, end < len(b.Tx)))
+}
+
+func (wg *WalletGUI) explorerTxWidgets(tx *btcjson.TxRawResult) (out []l.Widget) {
+	out = []l.Widget{
+		wg.privacyHeading("transaction"),
+		wg.privacyLine(tx.Txid),
+		wg.privacyLine(fmt.Sprintf("%d bytes, version %d, lock time %d", tx.Size, tx.Version, tx.LockTime)),
+	}
+	if tx.BlockHash == "" {
+		out = append(out, wg.privacyLine("unconfirmed, waiting in the mempool"))
+	} else {
+		hash := tx.BlockHash
+		out = append(out,
+			wg.privacyLine(fmt.Sprintf("mined %s, %d confirmations",
+				time.Unix(tx.Blocktime, 0).Format("2006-01-02 15:04:05"), tx.Confirmations)),
+			wg.explorerLink("explorerBlock"+hash, "in block "+hash, func() { wg.exploreBlock(hash) }),
+		)
+	}
+	out = append(out, wg.privacyHeading(fmt.Sprintf("%d inputs", len(tx.Vin))))
+	for _, in := range tx.Vin {
+		if in.Coinbase != "" {
+			out = append(out, wg.privacyLine("newly generated coins"))
+			continue
+		}
+		txid := in.Txid
+		out = append(out, wg.explorerLink(fmt.Sprintf("explorerTx%s:%d", txid, in.Vout),
+			fmt.Sprintf("%s:%d", txid, in.Vout), func() { wg.exploreTx(txid) }))
+	}
+	out = append(out, wg.privacyHeading(fmt.Sprintf("%d outputs", len(tx.Vout))))
+	for _, o := range tx.Vout {
+		if len(o.ScriptPubKey.Addresses) == 0 {
+			out = append(out, wg.privacyLine(fmt.Sprintf("%d: %s to a %s script", o.N, wg.formatAmount(o.Value),
+				o.ScriptPubKey.Type)))
+			continue
+		}
+		for _, addr := range o.ScriptPubKey.Addresses {
+			a := addr
+			out = append(out, wg.explorerLink("explorerAddress"+a, fmt.Sprintf("%d: %s to %s", o.N,
+				wg.formatAmount(o.Value), a), func() { go wg.exploreAddress(a, 0, true) }))
+		}
+	}
+	return
+}
+
+func (wg *WalletGUI) explorerAddressWidgets(v *explorerView) (out []l.Widget) {
+	out = []l.Widget{
+		wg.privacyHeading("address"),
+		wg.privacyLine(v.address),
+	}
+	if len(v.addressTxs) == 0 {
+		return append(out, wg.privacyLine("no transactions were found"))
+	}
+	out = append(out, wg.privacyHeading("transactions, newest first"))
+	// one more transaction than is shown is fetched to know whether there is a next page
+	txs := v.addressTxs
+	if len(txs) > explorerPageSize {
+		txs = txs[:explorerPageSize]
+	}
+	for _, tx := range txs {
+		out = append(out, wg.explorerTxLink(tx.TxID))
+	}
+	return append(out, wg.explorerPager(v, len(v.addressTxs) > explorerPageSize))
+}
+
+// explorerPager shows buttons to move between the pages of the transactions of a block or address
+func (wg *WalletGUI) explorerPager(v *explorerView, more bool) l.Widget {
+	turn := func(page int) func() {
+		return func() {
+			if v.address != "" {
+				go wg.exploreAddress(v.address, page, false)
+				return
+			}
+			wg.State.SetExplorerPage(page)
+		}
+	}
+	flex := wg.th.Flex().AlignMiddle()
+	if v.page > 0 {
+		flex = flex.Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["explorerPageBack"], "< Page",
+			turn(v.page-1))).Fn)
+	}
+	flex = flex.Rigid(wg.Inset(0.25, wg.th.Caption(fmt.Sprintf("page %d", v.page+1)).Color("DocText").Fn).Fn)
+	if more {
+		flex = flex.Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["explorerPageForward"], "Page >",
+			turn(v.page+1))).Fn)
+	}
+	return flex.Fn
+}
+
+func (wg *WalletGUI) explorerTxLink(txid string) l.Widget {
+	return wg.explorerLink("explorerTx"+txid, txid, func() { wg.exploreTx(txid) })
+}
+
+// explorerLink is a line of text that moves the explorer to what it names when clicked
+func (wg *WalletGUI) explorerLink(key, txt string, click func()) l.Widget {
+	return wg.Inset(0.25,
+		wg.th.ButtonLayout(wg.keyedClickable(key)).Embed(
+			wg.th.Body2(txt).Color("Primary").Font("go regular").Fn,
+		).
+			Background("Transparent").
+			SetClick(click).
+			Fn,
+	).Fn
+}
+
+func explorerPageBounds(page, total int) (start, end int) {
+	start = page * explorerPageSize
+	if start > total {
+		start = total
+	}
+	end = start + explorerPageSize
+	if end > total {
+		end = total
+	}
+	return
+}
+
+// explorerSearch looks up what is entered in the search field, a block height, a block hash or transaction id, or an
+// address
+func (wg *WalletGUI) explorerSearch() {
+	query := strings.TrimSpace(wg.inputs["explorerSearch"].GetText())
+	if query == "" {
+		return
+	}
+	go func() {
+		if height, err := strconv.ParseInt(query, 10, 64); err == nil {
+			wg.exploreHeight(height)
+			return
+		}
+		if hash, err := chainhash.NewHashFromStr(query); err == nil && len(query) == chainhash.MaxHashStringSize {
+			// a hash is either of a block or a transaction, blocks are tried first as they are always indexed
+			if block, err := wg.ChainClient.GetBlockVerbose(hash); err == nil {
+				wg.showExplorerView(&explorerView{block: block}, true)
+				return
+			}
+			if tx, err := wg.ChainClient.GetRawTransactionVerbose(hash); err == nil {
+				wg.showExplorerView(&explorerView{tx: tx}, true)
+				return
+			}
+			wg.toasts.AddToast("Explorer", "no block or transaction was found with this hash", "Danger")
+			return
+		}
+		if _, err := util.DecodeAddress(query, wg.cx.ActiveNet); err == nil {
+			wg.exploreAddress(query, 0, true)
+			return
+		}
+		wg.toasts.AddToast("Explorer", "enter a block height, a block hash, a transaction id or an address",
+			"Danger")
+	}()
+}
+
+func (wg *WalletGUI) exploreHeight(height int64) {
+	hash, err := wg.ChainClient.GetBlockHash(height)
+	if Check(err) {
+		wg.toasts.AddToast("Explorer", err.Error(), "Danger")
+		return
+	}
+	var block *btcjson.GetBlockVerboseResult
+	if block, err = wg.ChainClient.GetBlockVerbose(hash); Check(err) {
+		wg.toasts.AddToast("Explorer", err.Error(), "Danger")
+		return
+	}
+	wg.showExplorerView(&explorerView{block: block}, true)
+}
+
+func (wg *WalletGUI) exploreBlock(hashStr string) {
+	go func() {
+		hash, err := chainhash.NewHashFromStr(hashStr)
+		if Check(err) {
+			return
+		}
+		var block *btcjson.GetBlockVerboseResult
+		if block, err = wg.ChainClient.GetBlockVerbose(hash); Check(err) {
+			wg.toasts.AddToast("Explorer", err.Error(), "Danger")
+			return
+		}
+		wg.showExplorerView(&explorerView{block: block}, true)
+	}()
+}
+
+func (wg *WalletGUI) exploreTx(txid string) {
+	go func() {
+		hash, err := chainhash.NewHashFromStr(txid)
+		if Check(err) {
+			return
+		}
+		var tx *btcjson.TxRawResult
+		if tx, err = wg.ChainClient.GetRawTransactionVerbose(hash); Check(err) {
+			wg.toasts.AddToast("Explorer", err.Error(), "Danger")
+			return
+		}
+		wg.showExplorerView(&explorerView{tx: tx}, true)
+	}()
+}
+
+// exploreAddress fetches a page of the transactions of an address, newest first, which needs the address index of the
+// node. Turning the page replaces the view rather than adding to the history of the back button
+func (wg *WalletGUI) exploreAddress(address string, page int, push bool) {
+	addr, err := util.DecodeAddress(address, wg.cx.ActiveNet)
+	if Check(err) {
+		wg.toasts.AddToast("Explorer", err.Error(), "Danger")
+		return
+	}
+	var txs []*btcjson.SearchRawTransactionsResult
+	if txs, err = wg.ChainClient.SearchRawTransactionsVerbose(addr, page*explorerPageSize, explorerPageSize+1,
+		false, true, nil); Check(err) {
+		wg.toasts.AddToast("Explorer", err.Error(), "Danger")
+		return
+	}
+	wg.showExplorerView(&explorerView{address: address, addressTxs: txs, page: page}, push)
+}
+
+func (wg *WalletGUI) showExplorerView(v *explorerView, push bool) {
+	wg.State.SetExplorerView(v, push)
+	wg.invalidate <- struct{}{}
+}
@@ -0,0 +1,116 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	l "gioui.org/layout"
+
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// feeCustom is the fee target of a fee rate typed in by the user
+const feeCustom = "custom"
+
+// feeTargets are the confirmation targets offered in the fee selector, in blocks, with the names they are shown with
+var feeTargets = []struct {
+	Key    string
+	Name   string
+	Blocks int64
+}{
+	{"feeFast", "fast", 2},
+	{"feeNormal", "normal", 6},
+	{"feeEconomy", "economy", 24},
+}
+
+// updateFeeEstimates fetches the node's fee rate estimates for the confirmation targets of the fee selector. A target
+// the node cannot estimate yet is left out.
+func (wg *WalletGUI) updateFeeEstimates() {
+	if wg.ChainClient == nil {
+		return
+	}
+	estimates := make(map[int64]float64)
+	for _, t := range feeTargets {
+		res, err := wg.ChainClient.EstimateSmartFee(t.Blocks, nil)
+		if Check(err) {
+			continue
+		}
+		if res.FeeRate != nil {
+			estimates[t.Blocks] = *res.FeeRate
+		}
+	}
+	wg.State.SetFeeEstimates(estimates)
+}
+
+// feeRate returns the fee rate per kilobyte chosen in the fee selector with a description of it. A target the node has
+// no estimate for, or an estimate below the relay fee, uses the relay fee, which is what the wallet pays by default.
+func (wg *WalletGUI) feeRate() (rate util.Amount, desc string, err error) {
+	target := wg.feeTarget.Value()
+	if target == feeCustom {
+		// the custom rate is typed in satoshis per byte, which is a thousand satoshis per kilobyte
+		txt := strings.TrimSpace(wg.inputs["feeCustom"].GetText())
+		var perByte float64
+		if perByte, err = strconv.ParseFloat(txt, 64); err != nil {
+			return 0, "", fmt.Errorf("enter the fee rate in satoshis per byte")
+		}
+		rate = util.Amount(perByte * 1000)
+		if rate < txrules.DefaultRelayFeePerKb {
+			return 0, "", fmt.Errorf("the fee rate must be at least %v satoshis per byte",
+				float64(txrules.DefaultRelayFeePerKb)/1000)
+		}
+		return rate, fmt.Sprintf("custom, %s/kB", wg.formatAmount(rate.ToDUO())), nil
+	}
+	for _, t := range feeTargets {
+		if t.Key != target {
+			continue
+		}
+		rate = txrules.DefaultRelayFeePerKb
+		desc = fmt.Sprintf("%s, no estimate for %d blocks yet, %s/kB", t.Name, t.Blocks, wg.formatAmount(rate.ToDUO()))
+		if estimate, ok := wg.State.FeeEstimates()[t.Blocks]; ok {
+			if r, err := util.NewAmount(estimate); err == nil && r > rate {
+				rate = r
+			}
+			desc = fmt.Sprintf("%s, within %d blocks, %s/kB", t.Name, t.Blocks, wg.formatAmount(rate.ToDUO()))
+		}
+		return
+	}
+	return txrules.DefaultRelayFeePerKb, "wallet default", nil
+}
+
+// feeSelector offers the confirmation targets estimated by the node and a custom fee rate in satoshis per byte, with
+// the rate that will be paid
+func (wg *WalletGUI) feeSelector() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		flex := wg.th.Flex().AlignMiddle().
+			Rigid(
+				wg.Inset(0.25, wg.Caption("Fee:").Color("DocText").Fn).Fn,
+			)
+		for _, t := range feeTargets {
+			flex = flex.Rigid(
+				wg.Inset(0.25,
+					wg.th.RadioButton(wg.checkables[t.Key].Color("DocText").IconColor("Primary"), wg.feeTarget, t.Key,
+						fmt.Sprintf("%s (%d blocks)", t.Name, t.Blocks)).Fn,
+				).Fn,
+			)
+		}
+		_, txt, err := wg.feeRate()
+		if err != nil {
+			txt = err.Error()
+		}
+		return flex.
+			Rigid(
+				wg.Inset(0.25,
+					wg.th.RadioButton(wg.checkables["feeCustom"].Color("DocText").IconColor("Primary"), wg.feeTarget,
+						feeCustom, "sat/byte").Fn,
+				).Fn,
+			).
+			Rigid(
+				wg.Inset(0.25, wg.inputs["feeCustom"].Fn).Fn,
+			).
+			Flexed(1,
+				wg.Inset(0.25, wg.Caption(txt).Color("DocText").Fn).Fn,
+			).Fn(gtx)
+	}
+}
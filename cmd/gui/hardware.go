@@ -0,0 +1,278 @@
+package gui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	l "gioui.org/layout"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/coding/base58"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// devicePairing is the hardware wallet whose account key was imported on the create wallet page, which is paired with
+// the wallet when it is created from that key
+type devicePairing struct {
+	device wallet.SigningDevice
+	xpub   string
+}
+
+// pairedDevice returns the model of the hardware wallet that signs for the wallet, empty when none is paired
+func (wg *WalletGUI) pairedDevice() string {
+	model, _ := wg.State.SigningDevice()
+	return model
+}
+
+// importFromDevice looks for connected hardware wallets and fills the watch-only wallet form with the extended public
+// key of the first account of the one that is picked
+func (wg *WalletGUI) importFromDevice() {
+	go func() {
+		devices, err := wallet.SigningDevices(wg.cx.ActiveNet)
+		if Check(err) {
+			wg.toasts.AddToast("Hardware wallet", err.Error(), "Danger")
+			return
+		}
+		switch len(devices) {
+		case 0:
+			wg.toasts.AddToast("Hardware wallet", "no hardware wallet was found, connect and unlock it and check "+
+				"that "+wallet.HWICommand+" is installed", "Warning")
+		case 1:
+			wg.importDeviceXPub(devices[0])
+		default:
+			wg.dialog.ShowDialog("Choose a hardware wallet", "Info", wg.deviceChooser(devices))()
+		}
+	}()
+}
+
+// deviceChooser lists the connected hardware wallets to pick the one to import an account from
+func (wg *WalletGUI) deviceChooser(devices []wallet.SigningDevice) l.Widget {
+	flex := wg.th.VFlex()
+	for i := range devices {
+		d := devices[i]
+		info := d.Info()
+		flex = flex.Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(wg.keyedClickable("device"+info.Path),
+					fmt.Sprintf("%s (%s)", info.Model, info.FingerprintString()), func() {
+						wg.dialog.Close()
+						go wg.importDeviceXPub(d)
+					}),
+			).Fn,
+		)
+	}
+	return flex.Fn
+}
+
+// importDeviceXPub reads the extended public key of the first account from a hardware wallet into the watch-only
+// wallet form and remembers the device to pair it with the new wallet
+func (wg *WalletGUI) importDeviceXPub(d wallet.SigningDevice) {
+	info := d.Info()
+	wg.toasts.AddToast("Hardware wallet", "reading the account key from the "+info.Model, "Info")
+	xpub, err := d.AccountXPub(wallet.AccountDerivationPath(waddrmgr.DefaultAccountNum))
+	if Check(err) {
+		wg.toasts.AddToast("Hardware wallet", err.Error(), "Danger")
+		return
+	}
+	wg.inputs["watchOnlyXPub"] = wg.th.Input(xpub, "account extended public key", "Primary", "DocText", 32,
+		func(pass string) {})
+	wg.devicePairing = &devicePairing{device: d, xpub: xpub}
+	wg.invalidate <- struct{}{}
+}
+
+// pairingDevice returns the hardware wallet the extended public key entered for a watch-only wallet was imported from,
+// or nil if it was entered by hand
+func (wg *WalletGUI) pairingDevice(xpub string) wallet.SigningDevice {
+	if wg.devicePairing == nil || wg.devicePairing.xpub != xpub {
+		return nil
+	}
+	return wg.devicePairing.device
+}
+
+// pairSigningDevice pairs a new watch-only wallet with the hardware wallet its account key was imported from, so the
+// wallet can hand its transactions to the device to sign
+func (wg *WalletGUI) pairSigningDevice(w *wallet.Wallet) {
+	xpub, err := wg.watchOnlyXPub()
+	if err != nil {
+		return
+	}
+	d := wg.pairingDevice(xpub)
+	if d == nil {
+		return
+	}
+	if err = w.PairSigningDevice(waddrmgr.DefaultAccountNum, d); Check(err) {
+		wg.toasts.AddToast("Hardware wallet", "the wallet could not be paired with the "+d.Info().Model+": "+
+			err.Error(), "Danger")
+	}
+	wg.devicePairing = nil
+}
+
+// findPairedDevice finds the connected hardware wallet with the master key fingerprint the wallet was paired with
+func (wg *WalletGUI) findPairedDevice() (d wallet.SigningDevice, err error) {
+	model, fingerprint := wg.State.SigningDevice()
+	var fp uint32
+	if fp, err = wallet.ParseFingerprint(fingerprint); err != nil {
+		return
+	}
+	if d, err = wallet.FindSigningDevice(wg.cx.ActiveNet, fp); err != nil {
+		err = fmt.Errorf("connect and unlock the %s: %v", model, err)
+	}
+	return
+}
+
+// sendWithDevice creates the transaction paying the recipients of the send form unsigned, to be signed on the paired
+// hardware wallet once the send is confirmed
+func (wg *WalletGUI) sendWithDevice() {
+	if len(wg.selectedCoins()) > 0 {
+		go wg.toasts.AddToast("Coin control", "outputs cannot be picked for a send signed on a hardware wallet",
+			"Warning")
+		return
+	}
+	amounts, labels, total, errs := wg.batchRecipients()
+	if len(errs) > 0 {
+		wg.dialog.ShowDialog("Check the recipients", "Warning", batchErrors(errs))()
+		return
+	}
+	feeRate, _, err := wg.feeRate()
+	if err != nil {
+		go wg.toasts.AddToast("Fee error", err.Error(), "Danger")
+		return
+	}
+	var res *btcjson.SigningDevicePSBTResult
+	if res, err = wg.WalletClient.CreateSigningDevicePSBT("default", amounts, 1, &feeRate); Check(err) {
+		go wg.toasts.AddToast("Send error", err.Error(), "Danger")
+		return
+	}
+	wg.dialog.ShowDialog("Confirm send", "Info", wg.deviceSendConfirmation(res, len(amounts), labels, total))()
+}
+
+// deviceSendConfirmation shows the total and fee of a send before it is passed to the hardware wallet to sign
+func (wg *WalletGUI) deviceSendConfirmation(res *btcjson.SigningDevicePSBTResult, recipients int,
+	labels map[string]string, total util.Amount) l.Widget {
+	lines := []string{
+		fmt.Sprintf("Pay %s to %d recipients", wg.formatAmount(total.ToDUO()), recipients),
+		fmt.Sprintf("Fee %s", wg.formatAmount(res.Fee)),
+		fmt.Sprintf("Total %s", wg.formatAmount(total.ToDUO()+res.Fee)),
+		fmt.Sprintf("The transaction is signed on the %s (%s), check the amounts shown on its screen before "+
+			"confirming", res.SigningDevice, res.Fingerprint),
+	}
+	flex := wg.th.VFlex()
+	for i := range lines {
+		flex = flex.Rigid(
+			wg.Inset(0.25,
+				wg.Body2(lines[i]).Color("PanelText").Fn,
+			).Fn,
+		)
+	}
+	return flex.Rigid(
+		wg.Inset(0.25,
+			wg.buttonText(wg.clickables["sendConfirm"], "Sign on device", func() {
+				wg.dialog.Close()
+				go func() {
+					h, err := wg.signOnDevice(res)
+					if Check(err) {
+						wg.toasts.AddToast("Send error", err.Error(), "Danger")
+						return
+					}
+					for address, label := range labels {
+						wg.addToAddressBook(address, label, false)
+					}
+					wg.toasts.AddToast("TxID", h.String(), "Success")
+				}()
+			}),
+		).Fn,
+	).Fn
+}
+
+// signOnDevice has the paired hardware wallet sign a transaction created for it, which the user confirms on the
+// device, and publishes it
+func (wg *WalletGUI) signOnDevice(res *btcjson.SigningDevicePSBTResult) (h *chainhash.Hash, err error) {
+	var d wallet.SigningDevice
+	if d, err = wg.findPairedDevice(); err != nil {
+		return
+	}
+	var p *psbt.Packet
+	if p, err = psbt.NewFromRawBytes(strings.NewReader(res.PSBT), true); err != nil {
+		return
+	}
+	wg.toasts.AddToast("Hardware wallet", "confirm the transaction on the "+res.SigningDevice, "Info")
+	if p, err = d.SignPSBT(p); err != nil {
+		return
+	}
+	var b64 string
+	if b64, err = p.B64Encode(); err != nil {
+		return
+	}
+	return wg.WalletClient.PublishPSBT(b64)
+}
+
+// verifyOnDeviceButton is the button of a receiving address that shows it on the screen of the paired hardware wallet
+func (wg *WalletGUI) verifyOnDeviceButton(address string) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if wg.pairedDevice() == "" {
+			return l.Dimensions{}
+		}
+		return wg.Inset(0.25,
+			wg.buttonText(wg.keyedClickable("verifyOnDevice"+address), "Verify on device", func() {
+				go wg.verifyOnDevice(address)
+			}),
+		).Fn(gtx)
+	}
+}
+
+// verifyOnDevice shows the key of a receiving address on the screen of the paired hardware wallet and checks it is
+// the key the wallet derived, so an address altered on this computer is caught before it is handed out
+func (wg *WalletGUI) verifyOnDevice(address string) {
+	fail := func(err error) {
+		wg.toasts.AddToast("Verify address", err.Error(), "Danger")
+	}
+	addr, err := util.DecodeAddress(address, wg.cx.ActiveNet)
+	if Check(err) {
+		fail(err)
+		return
+	}
+	var res *btcjson.ValidateAddressWalletResult
+	if res, err = wg.WalletClient.ValidateAddress(addr); Check(err) {
+		fail(err)
+		return
+	}
+	if res.HDKeyPath == "" {
+		fail(fmt.Errorf("%s is not an address of the hardware wallet's account", address))
+		return
+	}
+	var path []uint32
+	if path, err = wallet.ParseDerivationPath(res.HDKeyPath); Check(err) {
+		fail(err)
+		return
+	}
+	var d wallet.SigningDevice
+	if d, err = wg.findPairedDevice(); Check(err) {
+		fail(err)
+		return
+	}
+	model := d.Info().Model
+	wg.toasts.AddToast("Verify address", "the address is shown on the "+model, "Info")
+	var shown string
+	if shown, err = d.DisplayAddress(path); Check(err) {
+		fail(err)
+		return
+	}
+	// the device encodes the address for bitcoin, so only the key hashes are compared
+	var hash []byte
+	if hash, _, err = base58.CheckDecode(shown); Check(err) {
+		fail(err)
+		return
+	}
+	if !bytes.Equal(hash, addr.ScriptAddress()) {
+		wg.toasts.AddToast("Verify address", "the "+model+" derived a different key for "+address+
+			", do not use this address", "Danger")
+		return
+	}
+	wg.toasts.AddToast("Verify address", fmt.Sprintf("the %s holds the key of %s, which it shows as %s", model,
+		address, shown), "Success")
+}
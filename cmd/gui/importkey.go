@@ -0,0 +1,235 @@
+package gui
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	l "gioui.org/layout"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	txsizes "github.com/p9c/pod/pkg/chain/tx/sizes"
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// sweepConfTarget is the number of blocks a sweep transaction pays its fee to be confirmed within
+const sweepConfTarget = 6
+
+// KeyPreview is a private key being imported along with the unspent outputs found for its address, so the user can
+// see the balance before choosing to import the key or sweep its coins
+type KeyPreview struct {
+	WIF     *util.WIF
+	Address string
+	Scan    *btcjson.ScanTxOutSetResult
+}
+
+// ImportKeyPage lets a private key, such as the one of a paper wallet, be imported into the wallet or swept into a new
+// address of the wallet. The coins of the key are found in the UTXO set of the node first, so the user sees them
+// straight away instead of waiting on a rescan that may find nothing.
+func (wg *WalletGUI) ImportKeyPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.importKeyWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["importKey"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) importKeyWidgets() (out []l.Widget) {
+	out = append(out,
+		wg.privacyHeading("import private key"),
+		wg.th.Inset(0.25, wg.passwords["importKey"].Fn).Fn,
+		wg.th.Flex().
+			Rigid(
+				wg.Inset(0.25,
+					wg.buttonText(wg.clickables["importPreview"], "Preview", func() {
+						wif := wg.passwords["importKey"].GetPassword()
+						go wg.previewKey(wif)
+					}),
+				).Fn,
+			).Fn,
+	)
+	preview := wg.State.KeyPreview()
+	if preview == nil {
+		return append(out, wg.privacyLine("enter a private key to see the coins it holds before importing it"))
+	}
+	scan := preview.Scan
+	out = append(out,
+		wg.privacyHeading(fmt.Sprintf("%s holds %s", preview.Address, wg.formatAmount(scan.TotalAmount))),
+		wg.privacyLine(fmt.Sprintf("%d unspent outputs at block height %d", len(scan.Unspents), scan.Height)),
+	)
+	for _, u := range scan.Unspents {
+		out = append(out,
+			wg.privacyLine(fmt.Sprintf("%s:%d %s, from block %d", u.TxID, u.Vout, wg.formatAmount(u.Amount), u.Height)),
+		)
+	}
+	// A key without coins is imported without a rescan, as there is nothing for the rescan to find.
+	importLabel := "Import"
+	if len(scan.Unspents) > 0 {
+		importLabel = "Import and rescan"
+	}
+	actions := wg.th.Flex().
+		Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(wg.clickables["importImport"], importLabel, func() {
+					wg.authorize("Enter the wallet passphrase to import the private key", false, func() {
+						wg.importKey(preview)
+					})
+				}),
+			).Fn,
+		)
+	if len(scan.Unspents) > 0 {
+		actions = actions.Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(wg.clickables["importSweep"], "Sweep to wallet", func() {
+					go wg.sweepKey(preview)
+				}),
+			).Fn,
+		)
+	}
+	return append(out, actions.Fn)
+}
+
+// previewKey finds the unspent outputs of the address of a private key in the UTXO set of the node
+func (wg *WalletGUI) previewKey(encoded string) {
+	wif, err := util.DecodeWIF(encoded)
+	if Check(err) {
+		wg.toasts.AddToast("Import key", "Invalid private key: "+err.Error(), "Danger")
+		return
+	}
+	if !wif.IsForNet(wg.cx.ActiveNet) {
+		wg.toasts.AddToast("Import key", "The private key is for another network", "Danger")
+		return
+	}
+	var pk *util.AddressPubKey
+	if pk, err = util.NewAddressPubKey(wif.SerializePubKey(), wg.cx.ActiveNet); Check(err) {
+		wg.toasts.AddToast("Import key", err.Error(), "Danger")
+		return
+	}
+	address := pk.AddressPubKeyHash().EncodeAddress()
+	if wg.ChainClient == nil {
+		wg.toasts.AddToast("Import key", "not connected to the node", "Danger")
+		return
+	}
+	var scan *btcjson.ScanTxOutSetResult
+	if scan, err = wg.ChainClient.ScanTxOutSet([]string{"addr(" + address + ")"}); Check(err) {
+		wg.toasts.AddToast("Import key", err.Error(), "Danger")
+		return
+	}
+	wg.State.SetKeyPreview(&KeyPreview{WIF: wif, Address: address, Scan: scan})
+	wg.invalidate <- struct{}{}
+}
+
+// importKey adds the previewed private key to the wallet, rescanning the chain for its transactions if it holds coins
+func (wg *WalletGUI) importKey(preview *KeyPreview) {
+	if wg.WalletClient == nil {
+		wg.toasts.AddToast("Import key", "not connected to the wallet", "Danger")
+		return
+	}
+	if err := wg.WalletClient.ImportPrivKeyRescan(preview.WIF, "", len(preview.Scan.Unspents) > 0); Check(err) {
+		wg.toasts.AddToast("Import key", err.Error(), "Danger")
+		return
+	}
+	wg.clearKeyPreview()
+	wg.toasts.AddToast("Import key", "imported "+preview.Address, "Success")
+}
+
+// sweepKey spends all of the previewed coins of a private key to a new address of the wallet, so the coins are safe
+// from anyone else holding a copy of the key, which is not itself added to the wallet
+func (wg *WalletGUI) sweepKey(preview *KeyPreview) {
+	if wg.ChainClient == nil || wg.WalletClient == nil {
+		wg.toasts.AddToast("Sweep", "not connected to the node and wallet", "Danger")
+		return
+	}
+	txid, err := wg.sweep(preview)
+	if Check(err) {
+		wg.toasts.AddToast("Sweep", err.Error(), "Danger")
+		return
+	}
+	wg.clearKeyPreview()
+	wg.toasts.AddToast("Sweep", "swept "+preview.Address+" in transaction "+txid.String(), "Success")
+}
+
+func (wg *WalletGUI) sweep(preview *KeyPreview) (txid *chainhash.Hash, err error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	var total util.Amount
+	pkScripts := make([][]byte, len(preview.Scan.Unspents))
+	for i, u := range preview.Scan.Unspents {
+		var hash *chainhash.Hash
+		if hash, err = chainhash.NewHashFromStr(u.TxID); Check(err) {
+			return
+		}
+		var amount util.Amount
+		if amount, err = util.NewAmount(u.Amount); Check(err) {
+			return
+		}
+		total += amount
+		if pkScripts[i], err = hex.DecodeString(u.ScriptPubKey); Check(err) {
+			return
+		}
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(hash, u.Vout), nil, nil))
+	}
+	var addr util.Address
+	if addr, err = wg.WalletClient.GetNewAddress("default"); Check(err) {
+		return
+	}
+	var pkScript []byte
+	if pkScript, err = txscript.PayToAddrScript(addr); Check(err) {
+		return
+	}
+	out := wire.NewTxOut(0, pkScript)
+	size := txsizes.EstimateSerializeSize(len(tx.TxIn), []*wire.TxOut{out}, false)
+	if !preview.WIF.CompressPubKey {
+		// The size estimate is for compressed public keys, which are shorter than uncompressed ones.
+		size += (ec.PubKeyBytesLenUncompressed - ec.PubKeyBytesLenCompressed) * len(tx.TxIn)
+	}
+	var relayFee util.Amount
+	if relayFee, err = util.NewAmount(*wg.cx.Config.MinRelayTxFee); Check(err) {
+		return
+	}
+	feeRate := relayFee
+	// The relay fee is paid when the node has no fee estimate yet.
+	if estimate, estErr := wg.ChainClient.EstimateSmartFee(sweepConfTarget, nil); !Check(estErr) &&
+		estimate.FeeRate != nil {
+		if estimated, estErr := util.NewAmount(*estimate.FeeRate); !Check(estErr) && estimated > feeRate {
+			feeRate = estimated
+		}
+	}
+	out.Value = int64(total - txrules.FeeForSerializeSize(feeRate, size))
+	if txrules.IsDustAmount(util.Amount(out.Value), len(pkScript), relayFee) {
+		err = errors.New("the coins are worth less than the fee to sweep them")
+		return
+	}
+	tx.AddTxOut(out)
+	getKey := txscript.KeyClosure(func(util.Address) (*ec.PrivateKey, bool, error) {
+		return preview.WIF.PrivKey, preview.WIF.CompressPubKey, nil
+	})
+	for i := range tx.TxIn {
+		if tx.TxIn[i].SignatureScript, err = txscript.SignTxOutput(wg.cx.ActiveNet, tx, i, pkScripts[i],
+			txscript.SigHashAll, getKey, nil, nil); Check(err) {
+			return
+		}
+	}
+	return wg.ChainClient.SendRawTransaction(tx, false)
+}
+
+// clearKeyPreview forgets the previewed private key once it has been imported or swept
+func (wg *WalletGUI) clearKeyPreview() {
+	wg.passwords["importKey"].Wipe()
+	wg.State.SetKeyPreview(nil)
+	wg.invalidate <- struct{}{}
+}
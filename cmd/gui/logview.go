@@ -0,0 +1,234 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	l "gioui.org/layout"
+	"github.com/atotto/clipboard"
+
+	"github.com/p9c/pod/pkg/util/logi"
+)
+
+// logViewLines is how many of the most recent log entries of the workers are kept for the log page
+const logViewLines = 1000
+
+// logLine is an entry logged by one of the workers, with the name of the worker that logged it
+type logLine struct {
+	source string
+	entry  logi.Entry
+}
+
+// logView keeps the most recent entries logged by the node, wallet and miner workers, and the snapshot of them shown
+// while the log page is paused
+type logView struct {
+	mutex  sync.Mutex
+	lines  []logLine
+	paused []logLine
+}
+
+// newLogView sets up the log buffer and the filters of the log page, with every level and worker shown
+func (wg *WalletGUI) newLogView() {
+	wg.logs = &logView{}
+	for _, level := range logi.Levels[1:] {
+		wg.bools["logLevel"+level] = wg.th.Bool(true)
+	}
+	wg.bools["logNode"] = wg.th.Bool(true)
+	wg.bools["logMiner"] = wg.th.Bool(true)
+}
+
+// logHandler returns the handler for the entries piped from a worker, which keeps them for the log page and redraws
+// it if it is showing
+func (wg *WalletGUI) logHandler(source string) func(ent *logi.Entry) error {
+	return func(ent *logi.Entry) (err error) {
+		wg.logs.add(source, ent)
+		if wg.ActivePageGet() == "log" && !wg.logs.isPaused() {
+			// entries can arrive far faster than frames are drawn, so they are not queued up for redrawing
+			select {
+			case wg.invalidate <- struct{}{}:
+			default:
+			}
+		}
+		return
+	}
+}
+
+func (lv *logView) add(source string, ent *logi.Entry) {
+	lv.mutex.Lock()
+	defer lv.mutex.Unlock()
+	lv.lines = append(lv.lines, logLine{source: source, entry: *ent})
+	// the buffer is trimmed in batches so the entries are not copied for every one logged
+	if len(lv.lines) >= logViewLines*2 {
+		lv.lines = append([]logLine{}, lv.lines[len(lv.lines)-logViewLines:]...)
+	}
+}
+
+// entries returns the kept entries, or the snapshot taken when the log page was paused
+func (lv *logView) entries() []logLine {
+	lv.mutex.Lock()
+	defer lv.mutex.Unlock()
+	if lv.paused != nil {
+		return lv.paused
+	}
+	lines := lv.lines
+	if len(lines) > logViewLines {
+		lines = lines[len(lines)-logViewLines:]
+	}
+	return append([]logLine{}, lines...)
+}
+
+func (lv *logView) isPaused() bool {
+	lv.mutex.Lock()
+	defer lv.mutex.Unlock()
+	return lv.paused != nil
+}
+
+// togglePause freezes the log page on the entries kept so far, or goes back to showing them as they are logged
+func (lv *logView) togglePause() {
+	lv.mutex.Lock()
+	defer lv.mutex.Unlock()
+	if lv.paused != nil {
+		lv.paused = nil
+		return
+	}
+	lv.paused = append([]logLine{}, lv.lines...)
+}
+
+func (lv *logView) clear() {
+	lv.mutex.Lock()
+	defer lv.mutex.Unlock()
+	lv.lines = nil
+	if lv.paused != nil {
+		lv.paused = []logLine{}
+	}
+}
+
+// LogPage shows the entries logged by the node, wallet and miner, filtered by level, worker, subsystem and text
+func (wg *WalletGUI) LogPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.filteredLog()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return wg.logLineWidget(&lines[index])(gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.VFlex().
+				Rigid(wg.logFilterWidgets()).
+				Flexed(1,
+					wg.th.Fill("DocBg",
+						wg.lists["log"].
+							Vertical().
+							ScrollToEnd().
+							Length(len(lines)).
+							ListElement(le).
+							Fn,
+					).Fn,
+				).
+				Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) logFilterWidgets() l.Widget {
+	levels := wg.th.Flex().AlignMiddle().
+		Rigid(wg.th.Inset(0.25, wg.th.Caption("show").Color("DocText").Fn).Fn).
+		Rigid(wg.logCheckBox("logNode", "node")).
+		Rigid(wg.logCheckBox("logMiner", "miner"))
+	for _, level := range logi.Levels[1:] {
+		levels = levels.Rigid(wg.logCheckBox("logLevel"+level, level))
+	}
+	pause := "Pause"
+	if wg.logs.isPaused() {
+		pause = "Resume"
+	}
+	return wg.th.VFlex().
+		Rigid(levels.Fn).
+		Rigid(
+			wg.th.Flex().AlignMiddle().
+				Flexed(0.5, wg.Inset(0.25, wg.inputs["logSubsystem"].Fn).Fn).
+				Flexed(0.5, wg.Inset(0.25, wg.inputs["logSearch"].Fn).Fn).
+				Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["logPause"], pause, wg.logs.togglePause)).Fn).
+				Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["logCopy"], "Copy", wg.copyLog)).Fn).
+				Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["logClear"], "Clear", wg.logs.clear)).Fn).
+				Fn,
+		).
+		Fn
+}
+
+func (wg *WalletGUI) logCheckBox(key, label string) l.Widget {
+	return wg.th.Inset(0.25,
+		func(gtx l.Context) l.Dimensions {
+			return wg.th.CheckBox(wg.bools[key]).
+				TextColor("DocText").
+				TextScale(1).
+				Text(label).
+				IconScale(1).
+				Fn(gtx)
+		},
+	).Fn
+}
+
+// filteredLog returns the kept entries of the checked levels and workers whose subsystem and text contain what is
+// entered in the filter and search fields
+func (wg *WalletGUI) filteredLog() (out []logLine) {
+	subsystem := strings.ToLower(strings.TrimSpace(wg.inputs["logSubsystem"].GetText()))
+	search := strings.ToLower(strings.TrimSpace(wg.inputs["logSearch"].GetText()))
+	for _, line := range wg.logs.entries() {
+		if b, ok := wg.bools["logLevel"+line.entry.Level]; ok && !b.GetValue() {
+			continue
+		}
+		if (line.source == "miner" && !wg.bools["logMiner"].GetValue()) ||
+			(line.source == "node" && !wg.bools["logNode"].GetValue()) {
+			continue
+		}
+		if subsystem != "" && !strings.Contains(strings.ToLower(line.entry.Package), subsystem) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(line.entry.Text), search) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return
+}
+
+func (wg *WalletGUI) logLineWidget(line *logLine) l.Widget {
+	color := "DocText"
+	switch line.entry.Level {
+	case logi.Fatal, logi.Error:
+		color = "Danger"
+	case logi.Warn:
+		color = "Warning"
+	case logi.Debug, logi.Trace:
+		color = "Hint"
+	}
+	return wg.th.Inset(0.1,
+		wg.th.Caption(line.String()).Color(color).Font("go regular").Fn,
+	).Fn
+}
+
+// String formats the entry as a line of text, as it is shown on the log page and copied from it
+func (ll *logLine) String() string {
+	return fmt.Sprintf("%s %-5s %-5s %s: %s %s", ll.entry.Time.Format("2006-01-02 15:04:05.000"), ll.source,
+		ll.entry.Level, ll.entry.Package, ll.entry.Text, ll.entry.CodeLocation)
+}
+
+// copyLog copies the entries shown on the log page to the clipboard, so they can be pasted into a problem report
+func (wg *WalletGUI) copyLog() {
+	lines := wg.filteredLog()
+	if len(lines) == 0 {
+		go wg.toasts.AddToast("Copy log", "there are no log entries to copy", "Danger")
+		return
+	}
+	txt := make([]string, len(lines))
+	for i := range lines {
+		txt[i] = lines[i].String()
+	}
+	go func() {
+		if err := clipboard.WriteAll(strings.Join(txt, "\n")); Check(err) {
+			wg.toasts.AddToast("Copy log", err.Error(), "Danger")
+			return
+		}
+		wg.toasts.AddToast("Copy log", fmt.Sprintf("copied %d log entries", len(lines)), "Success")
+	}()
+}
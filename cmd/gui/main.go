@@ -1,8 +1,7 @@
 package gui
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"path/filepath"
 	"runtime"
 
 	l "gioui.org/layout"
@@ -11,13 +10,13 @@ import (
 	"github.com/p9c/pod/app/apputil"
 	"github.com/p9c/pod/pkg/gui/dialog"
 	"github.com/p9c/pod/pkg/gui/toast"
-	"github.com/p9c/pod/pkg/util/hdkeychain"
 
 	"github.com/p9c/pod/app/save"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util/logi/consume"
 
 	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/comm/price"
 	"github.com/p9c/pod/pkg/comm/stdconn/worker"
 	"github.com/p9c/pod/pkg/gui/cfg"
 	"github.com/p9c/pod/pkg/gui/f"
@@ -25,6 +24,7 @@ import (
 	"github.com/p9c/pod/pkg/gui/p9"
 	rpcclient "github.com/p9c/pod/pkg/rpc/client"
 	"github.com/p9c/pod/pkg/util/interrupt"
+	"github.com/p9c/pod/pkg/util/lang"
 )
 
 func Main(cx *conte.Xt, c *cli.Context) (err error) {
@@ -35,6 +35,8 @@ func Main(cx *conte.Xt, c *cli.Context) (err error) {
 		c:          c,
 		invalidate: make(chan struct{}),
 		quit:       cx.KillAll,
+		showWindow: make(chan struct{}),
+		closed:     make(chan struct{}),
 		// runnerQuit: make(chan struct{}),
 		size:     &size,
 		noWallet: &noWallet,
@@ -65,6 +67,8 @@ type WalletGUI struct {
 	running, mining           bool
 	invalidate                chan struct{}
 	quit                      chan struct{}
+	showWindow, closed        chan struct{}
+	tray                      bool
 	runnerQuit                chan struct{}
 	minerQuit                 chan struct{}
 	sendAddresses             []SendAddress
@@ -73,18 +77,35 @@ type WalletGUI struct {
 	State                     State
 	Shell, Miner              *worker.Worker
 	ChainClient, WalletClient *rpcclient.Client
+	WalletNotifier            *rpcclient.Client
 	txs                       []btcjson.ListTransactionsResult
 	console                   *Console
 	toasts                    *toast.Toasts
 	dialog                    *dialog.Dialog
 	noWallet                  *bool
+	unlock                    unlockSession
+	addressBook               *AddressBook
+	feeTarget                 *p9.Enum
+	devicePairing             *devicePairing
+	recoveryPhrase            *recoveryPhrase
+	logs                      *logView
+	prices                    *price.Feed
 }
 
 func (wg *WalletGUI) Run() (err error) {
-	wg.th = p9.NewTheme(p9fonts.Collection(), wg.quit)
+	wg.th = p9.NewTheme(p9fonts.Collection(), wg.quit).SetTranslator(lang.T)
 	wg.th.Dark = wg.cx.Config.DarkTheme
+	// catalogs in the data directory add languages and translations to those built in
+	if err := lang.LoadCatalogs(filepath.Join(*wg.cx.Config.DataDir, "lang")); Check(err) {
+	}
+	lang.SetLanguage(*wg.cx.Config.Language)
+	provider, err := price.ProviderFor(*wg.cx.Config.PriceFeed)
+	if Check(err) {
+		provider = &price.CoinGecko{}
+	}
+	wg.prices = price.New(provider, filepath.Join(*wg.cx.Config.DataDir, "prices.json"))
 	wg.th.Colors.SetTheme(*wg.th.Dark)
-	wg.sidebarButtons = make([]*p9.Clickable, 12)
+	wg.sidebarButtons = make([]*p9.Clickable, 20)
 	for i := range wg.sidebarButtons {
 		wg.sidebarButtons[i] = wg.th.Clickable()
 	}
@@ -104,11 +125,26 @@ func (wg *WalletGUI) Run() (err error) {
 		"transactions": wg.th.List(),
 		"settings":     wg.th.List(),
 		"received":     wg.th.List(),
+		"privacy":      wg.th.List(),
+		"multisig":     wg.th.List(),
+		"coins":        wg.th.List(),
+		"importKey":    wg.th.List(),
+		"addressBook":  wg.th.List(),
+		"txDetail":     wg.th.List(),
+		"coinControl":  wg.th.List(),
+		"offline":      wg.th.List(),
+		"rescan":       wg.th.List(),
+		"log":          wg.th.List(),
+		"peers":        wg.th.List(),
+		"explorer":     wg.th.List(),
 	}
 	wg.clickables = map[string]*p9.Clickable{
 		"createWallet":            wg.th.Clickable(),
+		"createImportDevice":      wg.th.Clickable(),
 		"quit":                    wg.th.Clickable(),
 		"sendSend":                wg.th.Clickable(),
+		"sendConfirm":             wg.th.Clickable(),
+		"unlockConfirm":           wg.th.Clickable(),
 		"sendClearAll":            wg.th.Clickable(),
 		"sendAddRecipient":        wg.th.Clickable(),
 		"receiveCreateNewAddress": wg.th.Clickable(),
@@ -120,9 +156,50 @@ func (wg *WalletGUI) Run() (err error) {
 		"transactions50":          wg.th.Clickable(),
 		"txPageForward":           wg.th.Clickable(),
 		"txPageBack":              wg.th.Clickable(),
+		"importPreview":           wg.th.Clickable(),
+		"importImport":            wg.th.Clickable(),
+		"importSweep":             wg.th.Clickable(),
+		"addressBookSave":         wg.th.Clickable(),
+		"addressBookClear":        wg.th.Clickable(),
+		"txRebroadcast":           wg.th.Clickable(),
+		"paymentQRCopy":           wg.th.Clickable(),
+		"receiveCopyURI":          wg.th.Clickable(),
+		"receiveShowQR":           wg.th.Clickable(),
+		"scanQRRead":              wg.th.Clickable(),
+		"sendCoinControl":         wg.th.Clickable(),
+		"coinControlClear":        wg.th.Clickable(),
+		"sendImport":              wg.th.Clickable(),
+		"batchRead":               wg.th.Clickable(),
+		"batchPaste":              wg.th.Clickable(),
+		"offlineExport":           wg.th.Clickable(),
+		"offlineSign":             wg.th.Clickable(),
+		"offlineCheck":            wg.th.Clickable(),
+		"offlineBroadcast":        wg.th.Clickable(),
+		"sharedKey":               wg.th.Clickable(),
+		"sharedCreate":            wg.th.Clickable(),
+		"sharedImport":            wg.th.Clickable(),
+		"sharedPaste":             wg.th.Clickable(),
+		"rescanStart":             wg.th.Clickable(),
+		"encryptDB":               wg.th.Clickable(),
+		"mnemonicNext":            wg.th.Clickable(),
+		"mnemonicBack":            wg.th.Clickable(),
+		"logPause":                wg.th.Clickable(),
+		"logCopy":                 wg.th.Clickable(),
+		"logClear":                wg.th.Clickable(),
+		"peerAdd":                 wg.th.Clickable(),
+		"explorerSearch":          wg.th.Clickable(),
+		"explorerTip":             wg.th.Clickable(),
+		"explorerBack":            wg.th.Clickable(),
+		"explorerPageBack":        wg.th.Clickable(),
+		"explorerPageForward":     wg.th.Clickable(),
 	}
 	wg.checkables = map[string]*p9.Checkable{
+		"feeFast":    wg.th.Checkable(),
+		"feeNormal":  wg.th.Checkable(),
+		"feeEconomy": wg.th.Checkable(),
+		"feeCustom":  wg.th.Checkable(),
 	}
+	wg.feeTarget = wg.th.Enum().SetValue("feeNormal")
 	wg.bools = map[string]*p9.Bool{
 		"runstate":     wg.th.Bool(wg.running),
 		"encryption":   wg.th.Bool(false),
@@ -132,23 +209,50 @@ func (wg *WalletGUI) Run() (err error) {
 		"showGenerate": wg.th.Bool(true),
 		"showSent":     wg.th.Bool(true),
 		"showReceived": wg.th.Bool(true),
+		"coinControl":  wg.th.Bool(false),
+		"watchOnly":    wg.th.Bool(false),
+		"restore":      wg.th.Bool(false),
 	}
 	pass := ""
 	passConfirm := ""
-	seed := make([]byte, hdkeychain.MaxSeedBytes)
-	_, _ = rand.Read(seed)
-	seedString := hex.EncodeToString(seed)
+	unlockPass := ""
+	importKey := ""
 	wg.inputs = map[string]*p9.Input{
-		"receiveLabel":   wg.th.Input("", "Label", "Primary", "DocText", 32, func(pass string) {}),
-		"receiveAmount":  wg.th.Input("", "Amount", "Primary", "DocText", 32, func(pass string) {}),
-		"receiveMessage": wg.th.Input("", "Message", "Primary", "DocText", 32, func(pass string) {}),
-		"console":        wg.th.Input("", "enter rpc command", "Primary", "DocText", 32, func(pass string) {}),
-		"walletSeed":     wg.th.Input(seedString, "wallet seed", "Primary", "DocText", 32, func(pass string) {}),
+		"receiveLabel":         wg.th.Input("", "Label", "Primary", "DocText", 32, func(pass string) {}),
+		"receiveAmount":        wg.th.Input("", "Amount", "Primary", "DocText", 32, func(pass string) {}),
+		"receiveMessage":       wg.th.Input("", "Message", "Primary", "DocText", 32, func(pass string) {}),
+		"receiveExpiry":        wg.th.Input("24", "Hours", "Primary", "DocText", 32, func(pass string) {}),
+		"console":              wg.th.Input("", "enter rpc command", "Primary", "DocText", 32, func(pass string) {}),
+		"restoreMnemonic":      wg.th.Input("", "recovery phrase, or the hex seed of an older wallet", "Primary", "DocText", 32, func(pass string) {}),
+		"mnemonicPassphrase":   wg.th.Input("", "recovery phrase passphrase (optional)", "Primary", "DocText", 32, func(pass string) {}),
+		"watchOnlyXPub":        wg.th.Input("", "account extended public key", "Primary", "DocText", 32, func(pass string) {}),
+		"addressBookLabel":     wg.th.Input("", "Label", "Primary", "DocText", 32, func(pass string) {}),
+		"addressBookAddress":   wg.th.Input("", "Address", "Primary", "DocText", 32, func(pass string) {}),
+		"addressBookSearch":    wg.th.Input("", "Search by label or address", "Primary", "DocText", 32, func(pass string) {}),
+		"scanQRPath":           wg.th.Input("", "Path of an image of a QR code", "Primary", "DocText", 32, func(pass string) {}),
+		"feeCustom":            wg.th.Input("", "sat/byte", "Primary", "DocText", 8, func(pass string) {}),
+		"batchPath":            wg.th.Input("", "Path of a CSV or TSV file of payments", "Primary", "DocText", 32, func(pass string) {}),
+		"offlineExportPath":    wg.th.Input("", "Path to save the unsigned transaction to", "Primary", "DocText", 32, func(pass string) {}),
+		"offlineSignPath":      wg.th.Input("", "Path of the transaction to sign, or of an image of its QR code", "Primary", "DocText", 32, func(pass string) {}),
+		"offlineSignedPath":    wg.th.Input("", "Path to save the signed transaction to", "Primary", "DocText", 32, func(pass string) {}),
+		"offlineBroadcastPath": wg.th.Input("", "Path of the signed transaction, or of an image of its QR code", "Primary", "DocText", 32, func(pass string) {}),
+		"sharedName":           wg.th.Input("", "Name of the shared wallet", "Primary", "DocText", 32, func(pass string) {}),
+		"sharedKeys":           wg.th.Input("", "Keys of the other cosigners, separated by commas", "Primary", "DocText", 32, func(pass string) {}),
+		"sharedRequired":       wg.th.Input("", "Number of signatures needed to spend", "Primary", "DocText", 8, func(pass string) {}),
+		"sharedSavePath":       wg.th.Input("", "Path to save transactions for the cosigners to", "Primary", "DocText", 32, func(pass string) {}),
+		"sharedImportPath":     wg.th.Input("", "Path of a transaction signed by a cosigner, or of an image of its QR code", "Primary", "DocText", 32, func(pass string) {}),
+		"rescanHeight":         wg.th.Input("", "Height of the block to start the rescan from", "Primary", "DocText", 12, func(pass string) {}),
+		"logSubsystem":         wg.th.Input("", "Subsystem", "Primary", "DocText", 32, func(pass string) {}),
+		"logSearch":            wg.th.Input("", "Search", "Primary", "DocText", 32, func(pass string) {}),
+		"peerAddress":          wg.th.Input("", "Address of the peer, as host:port", "Primary", "DocText", 32, func(pass string) {}),
+		"explorerSearch":       wg.th.Input("", "Block height or hash, transaction id or address", "Primary", "DocText", 32, func(pass string) {}),
 	}
 	wg.passwords = map[string]*p9.Password{
 		"passEditor":        wg.th.Password("password", &pass, "Primary", "DocText", 32, func(pass string) {}),
 		"confirmPassEditor": wg.th.Password("confirm", &passConfirm, "Primary", "DocText", 32, func(pass string) {}),
 		"publicPassEditor":  wg.th.Password("public password (optional)", wg.cx.Config.WalletPass, "Primary", "DocText", 32, func(pass string) {}),
+		"unlockPass":        wg.th.Password("wallet passphrase", &unlockPass, "Primary", "DocText", 32, func(pass string) {}),
+		"importKey":         wg.th.Password("private key (WIF)", &importKey, "Primary", "DocText", 32, func(pass string) {}),
 	}
 	wg.toasts = toast.New(wg.th)
 	wg.dialog = dialog.New(wg.th)
@@ -191,6 +295,12 @@ func (wg *WalletGUI) Run() (err error) {
 				Debug("showing", n, "per page")
 			}),
 	}
+	if wg.addressBook, err = LoadAddressBook(wg.addressBookPath()); Check(err) {
+		// a damaged address book is left on disk and the book starts out empty
+		err = nil
+	}
+	wg.newRecoveryPhrase()
+	wg.newLogView()
 	wg.Tickers()
 	wg.App = wg.GetAppWidget()
 	wg.CreateSendAddressItem()
@@ -217,28 +327,9 @@ func (wg *WalletGUI) Run() (err error) {
 		wg.MinerRunCommandChan <- "run"
 	}
 	wg.Size = wg.w["main"].Width
-	go func() {
-		if err := wg.w["main"].
-			Size(800, 480).
-			Title("ParallelCoin Wallet").
-			Open().
-			Run(
-				func(gtx l.Context) l.Dimensions {
-					return p9.If(*wg.noWallet,
-						wg.WalletPage,
-						wg.App.Fn(),
-					)(gtx)
-				},
-				wg.Overlay(),
-				// wg.InitWallet(),
-				func() {
-					Debug("quitting wallet gui")
-					consume.Kill(wg.Shell)
-					consume.Kill(wg.Miner)
-					close(wg.quit)
-				}, wg.quit); Check(err) {
-		}
-	}()
+	wg.tray = wg.startTray()
+	wg.openWindow()
+	windowOpen := true
 	interrupt.AddHandler(func() {
 		Debug("quitting wallet gui")
 		consume.Kill(wg.Shell)
@@ -250,7 +341,17 @@ out:
 		select {
 		case <-wg.invalidate:
 			// Debug("invalidating render queue")
-			wg.w["main"].Window.Invalidate()
+			if windowOpen {
+				wg.w["main"].Window.Invalidate()
+			}
+		case <-wg.closed:
+			Info("the wallet window was closed, pod keeps running in the system tray")
+			windowOpen = false
+		case <-wg.showWindow:
+			if !windowOpen {
+				wg.openWindow()
+				windowOpen = true
+			}
 		case <-wg.quit:
 			Debug("closing GUI on quit signal")
 			Debug("disconnecting chain client")
@@ -267,6 +368,10 @@ out:
 					wg.WalletClient = nil
 				}
 			}
+			if wg.WalletNotifier != nil {
+				wg.WalletNotifier.Shutdown()
+				wg.WalletNotifier = nil
+			}
 			if wg.Shell != nil {
 				Debug("stopping shell")
 				// wg.ShellRunCommandChan <- "stop"
@@ -284,3 +389,34 @@ out:
 	// app.Main()
 	return
 }
+
+// openWindow opens the main window of the wallet. Closing it quits, unless the wallet is in the system tray, where it
+// keeps running until it is quit from the tray
+func (wg *WalletGUI) openWindow() {
+	go func() {
+		if err := wg.w["main"].
+			Size(800, 480).
+			Title("ParallelCoin Wallet").
+			Open().
+			Run(
+				func(gtx l.Context) l.Dimensions {
+					return p9.If(*wg.noWallet,
+						wg.WalletPage,
+						wg.App.Fn(),
+					)(gtx)
+				},
+				wg.Overlay(),
+				// wg.InitWallet(),
+				func() {
+					if wg.tray {
+						wg.closed <- struct{}{}
+						return
+					}
+					Debug("quitting wallet gui")
+					consume.Kill(wg.Shell)
+					consume.Kill(wg.Miner)
+					close(wg.quit)
+				}, wg.quit); Check(err) {
+		}
+	}()
+}
@@ -0,0 +1,215 @@
+package gui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	l "gioui.org/layout"
+
+	"github.com/p9c/pod/pkg/util/bip39"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// confirmWords is how many words of a new recovery phrase are asked for back before the wallet is created
+const confirmWords = 3
+
+// recoveryPhrase is the phrase of a wallet being created on the create wallet page, and the words of it that are asked
+// for back to confirm it was written down
+type recoveryPhrase struct {
+	words      []string
+	confirm    []int
+	confirming bool
+}
+
+// newRecoveryPhrase generates the phrase of the new wallet and picks the words of it to ask for back
+func (wg *WalletGUI) newRecoveryPhrase() {
+	rp := &recoveryPhrase{}
+	wg.recoveryPhrase = rp
+	entropy, err := bip39.NewEntropy(bip39.RecommendedEntropyBits)
+	if Check(err) {
+		return
+	}
+	var mnemonic string
+	if mnemonic, err = bip39.NewMnemonic(entropy); Check(err) {
+		return
+	}
+	rp.words = strings.Fields(mnemonic)
+	for len(rp.confirm) < confirmWords {
+		var n *big.Int
+		if n, err = rand.Int(rand.Reader, big.NewInt(int64(len(rp.words)))); Check(err) {
+			rp.words = nil
+			return
+		}
+		if !containsInt(rp.confirm, int(n.Int64())) {
+			rp.confirm = append(rp.confirm, int(n.Int64()))
+		}
+	}
+	sort.Ints(rp.confirm)
+	for i, pos := range rp.confirm {
+		wg.inputs[fmt.Sprintf("mnemonicConfirm%d", i)] = wg.th.Input("", fmt.Sprintf("word #%d", pos+1),
+			"Primary", "DocText", 16, func(pass string) {})
+	}
+}
+
+// restoringWallet returns whether the create wallet page is set to restore a wallet from its recovery phrase
+func (wg *WalletGUI) restoringWallet() bool {
+	return !wg.creatingWatchOnly() && wg.bools["restore"].GetValue()
+}
+
+// restoreWalletOnly shows a widget of the create wallet page only when a wallet is being restored
+func (wg *WalletGUI) restoreWalletOnly(w l.Widget) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if !wg.restoringWallet() {
+			return l.Dimensions{}
+		}
+		return w(gtx)
+	}
+}
+
+// newWalletOnly shows a widget of the create wallet page only when a wallet with a new recovery phrase is being
+// created
+func (wg *WalletGUI) newWalletOnly(w l.Widget) l.Widget {
+	return wg.seedWalletOnly(func(gtx l.Context) l.Dimensions {
+		if wg.restoringWallet() {
+			return l.Dimensions{}
+		}
+		return w(gtx)
+	})
+}
+
+// walletSeed returns the seed the wallet is created from, which is derived from the recovery phrase and its
+// passphrase. A wallet being restored may also be given the hex seed of a wallet created before recovery phrases were
+// used
+func (wg *WalletGUI) walletSeed() (seed []byte, err error) {
+	if err = wg.checkWalletSeed(); err != nil {
+		return
+	}
+	passphrase := wg.inputs["mnemonicPassphrase"].GetText()
+	if wg.restoringWallet() {
+		phrase := strings.TrimSpace(wg.inputs["restoreMnemonic"].GetText())
+		if seed, err = hex.DecodeString(phrase); err == nil {
+			return
+		}
+		return bip39.NewSeed(phrase, passphrase), nil
+	}
+	return bip39.NewSeed(strings.Join(wg.recoveryPhrase.words, " "), passphrase), nil
+}
+
+// checkWalletSeed returns why the wallet cannot yet be created from the recovery phrase, without the cost of deriving
+// the seed from it, so it can be checked as the page is drawn
+func (wg *WalletGUI) checkWalletSeed() (err error) {
+	if wg.restoringWallet() {
+		phrase := strings.TrimSpace(wg.inputs["restoreMnemonic"].GetText())
+		if phrase == "" {
+			return errors.New("enter the recovery phrase of the wallet to restore")
+		}
+		if b, e := hex.DecodeString(phrase); e == nil {
+			if len(b) < hdkeychain.MinSeedBytes || len(b) > hdkeychain.MaxSeedBytes {
+				return hdkeychain.ErrInvalidSeedLen
+			}
+			return
+		}
+		_, err = bip39.EntropyFromMnemonic(phrase)
+		return
+	}
+	rp := wg.recoveryPhrase
+	if len(rp.words) == 0 {
+		return errors.New("a recovery phrase could not be generated")
+	}
+	if !rp.confirming {
+		return errors.New("write down the recovery phrase, then confirm it")
+	}
+	for i, pos := range rp.confirm {
+		given := strings.ToLower(strings.TrimSpace(wg.inputs[fmt.Sprintf("mnemonicConfirm%d", i)].GetText()))
+		if given != rp.words[pos] {
+			return fmt.Errorf("enter word #%d of the recovery phrase", pos+1)
+		}
+	}
+	return
+}
+
+// walletSeedStatus describes the recovery phrase entered or being confirmed on the create wallet page
+func (wg *WalletGUI) walletSeedStatus() string {
+	if err := wg.checkWalletSeed(); err != nil {
+		return err.Error()
+	}
+	if wg.restoringWallet() {
+		return "the wallet will be restored and the chain rescanned for its transactions once it starts"
+	}
+	return "the recovery phrase is confirmed"
+}
+
+// recoveryPhraseWidget shows the words of a new wallet's recovery phrase to be written down, and then asks for some of
+// them back to confirm they were
+func (wg *WalletGUI) recoveryPhraseWidget() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		rp := wg.recoveryPhrase
+		flex := wg.th.VFlex().AlignMiddle()
+		if !rp.confirming {
+			flex = flex.
+				Rigid(wg.createWalletLine("Write down these words in order and keep them safe. They are the only way to " +
+					"restore the wallet if this computer is lost, and anyone who has them can spend its coins."))
+			for i := 0; i < len(rp.words); i += 6 {
+				end := i + 6
+				if end > len(rp.words) {
+					end = len(rp.words)
+				}
+				flex = flex.Rigid(
+					wg.th.Body1(numberedWords(rp.words[i:end], i)).Color("PanelText").Font("go regular").Fn,
+				)
+			}
+			return flex.
+				Rigid(wg.th.Inset(0.25,
+					wg.buttonText(wg.clickables["mnemonicNext"], "I have written them down", func() {
+						rp.confirming = true
+					}),
+				).Fn).
+				Fn(gtx)
+		}
+		flex = flex.Rigid(wg.createWalletLine("Enter these words of the recovery phrase to confirm it was written " +
+			"down correctly"))
+		for i := range rp.confirm {
+			flex = flex.Rigid(wg.th.Inset(0.25, wg.inputs[fmt.Sprintf("mnemonicConfirm%d", i)].Fn).Fn)
+		}
+		return flex.
+			Rigid(wg.th.Inset(0.25,
+				wg.buttonText(wg.clickables["mnemonicBack"], "Show the words again", func() {
+					rp.confirming = false
+				}),
+			).Fn).
+			Fn(gtx)
+	}
+}
+
+// createWalletLine is a line of text on the create wallet page
+func (wg *WalletGUI) createWalletLine(txt string) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		gtx.Constraints.Max.X = int(wg.th.TextSize.Scale(36).V)
+		return wg.th.Inset(0.25,
+			wg.th.Body1(txt).Color("PanelText").Fn,
+		).Fn(gtx)
+	}
+}
+
+// numberedWords lists the words with their positions in the recovery phrase, the first being at the given offset
+func numberedWords(words []string, start int) string {
+	out := make([]string, len(words))
+	for i, w := range words {
+		out[i] = fmt.Sprintf("%d. %s", start+i+1, w)
+	}
+	return strings.Join(out, "   ")
+}
+
+func containsInt(s []int, n int) bool {
+	for _, v := range s {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,342 @@
+package gui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	l "gioui.org/layout"
+	"github.com/atotto/clipboard"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util/psbt"
+)
+
+// MultisigPage is the shared wallet page. It creates multisig accounts from the keys of the cosigners, shows their
+// balances and hands out their addresses, and takes the transactions spending from them through collecting the
+// cosigners' signatures to being broadcast
+func (wg *WalletGUI) MultisigPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.multisigWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["multisig"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) multisigWidgets() (out []l.Widget) {
+	accts, pending := wg.State.Multisig()
+	if accts == nil {
+		return []l.Widget{wg.privacyLine("loading shared wallets...")}
+	}
+	out = append(out, wg.privacyHeading("shared wallets"))
+	if len(accts) == 0 {
+		out = append(out, wg.privacyLine("no shared wallets, create one below"))
+	}
+	for i := range accts {
+		a := accts[i]
+		out = append(out,
+			wg.privacyLine(fmt.Sprintf("%s: %d of %d, balance %s (%s spendable), %d addresses", a.Account,
+				a.Required, len(a.Keys), wg.formatAmount(a.Balance), wg.formatAmount(a.Spendable), a.Addresses)),
+			wg.th.Flex().
+				Rigid(wg.Inset(0.25, wg.buttonText(wg.keyedClickable("sharedAddress"+a.Account), "New address",
+					func() { wg.newSharedAddress(a.Account) })).Fn).
+				Rigid(wg.Inset(0.25, wg.buttonText(wg.keyedClickable("sharedCopyKey"+a.Account), "Copy my key",
+					func() {
+						go clipboard.WriteAll(a.XPub)
+						go wg.toasts.AddToast("Shared wallet", "your key is copied, send it to the cosigners", "Info")
+					})).Fn).
+				Rigid(wg.Inset(0.25, wg.buttonText(wg.keyedClickable("sharedSpend"+a.Account), "Spend",
+					func() { wg.spendShared(a.Account) })).Fn).
+				Fn,
+		)
+	}
+	out = append(out,
+		wg.privacyHeading("create a shared wallet"),
+		wg.privacyLine("Every cosigner creates the shared wallet with the same number of signatures and the keys of "+
+			"all of the others. Get your key for the account name first and send it to the cosigners."),
+		wg.Inset(0.25, wg.inputs["sharedName"].Fn).Fn,
+		wg.Inset(0.25, wg.buttonText(wg.clickables["sharedKey"], "Get my key", wg.sharedKey)).Fn,
+		wg.Inset(0.25, wg.inputs["sharedKeys"].Fn).Fn,
+		wg.Inset(0.25, wg.inputs["sharedRequired"].Fn).Fn,
+		wg.Inset(0.25, wg.buttonText(wg.clickables["sharedCreate"], "Create", wg.createShared)).Fn,
+		wg.privacyHeading("transactions awaiting signatures"),
+	)
+	if len(pending) == 0 {
+		out = append(out, wg.privacyLine("none, spend from a shared wallet to the recipients of the send form "+
+			"to start one"))
+	}
+	for i := range pending {
+		p := pending[i]
+		status := "ready to broadcast"
+		if !p.Complete {
+			status = "collecting signatures"
+		}
+		out = append(out, wg.privacyLine(fmt.Sprintf("%s from %s, %s", p.TxID, p.Account, status)))
+		for i, in := range p.Inputs {
+			out = append(out,
+				wg.privacyLine(fmt.Sprintf("    input %d: %d of %d signatures", i, in.Signatures, in.Required)),
+			)
+		}
+		buttons := wg.th.Flex().
+			Rigid(wg.Inset(0.25, wg.buttonText(wg.keyedClickable("sharedCopy"+p.TxID), "Copy", func() {
+				go clipboard.WriteAll(p.PSBT)
+				go wg.toasts.AddToast("Shared wallet", "the transaction is copied, send it to the cosigners", "Info")
+			})).Fn).
+			Rigid(wg.Inset(0.25, wg.buttonText(wg.keyedClickable("sharedSave"+p.TxID), "Save", func() {
+				wg.saveSharedPSBT(p.PSBT)
+			})).Fn)
+		if p.Complete {
+			buttons = buttons.Rigid(wg.Inset(0.25, wg.buttonText(wg.keyedClickable("sharedBroadcast"+p.TxID),
+				"Broadcast", func() { wg.broadcastShared(p.PSBT) })).Fn)
+		} else {
+			buttons = buttons.Rigid(wg.Inset(0.25, wg.buttonText(wg.keyedClickable("sharedSign"+p.TxID), "Sign",
+				func() { wg.signShared(p.PSBT) })).Fn)
+		}
+		out = append(out, buttons.Fn)
+	}
+	out = append(out,
+		wg.Inset(0.25, wg.inputs["sharedSavePath"].Fn).Fn,
+		wg.privacyHeading("add a cosigner's signatures"),
+		wg.privacyLine("Merges the signatures in a transaction signed by a cosigner into the ones collected here"),
+		wg.Inset(0.25, wg.inputs["sharedImportPath"].Fn).Fn,
+		wg.th.Flex().
+			Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["sharedImport"], "Read file", func() {
+				path := strings.TrimSpace(wg.inputs["sharedImportPath"].GetText())
+				if path == "" {
+					go wg.toasts.AddToast("Shared wallet", "enter the path of the transaction", "Danger")
+					return
+				}
+				go func() {
+					b64, err := readPSBT(path)
+					if Check(err) {
+						wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+						return
+					}
+					wg.importSharedPSBT(b64)
+				}()
+			})).Fn).
+			Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["sharedPaste"], "Paste", func() {
+				go func() {
+					txt, err := clipboard.ReadAll()
+					if Check(err) {
+						wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+						return
+					}
+					var p *psbt.Packet
+					if p, err = psbt.Parse([]byte(txt)); Check(err) {
+						wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+						return
+					}
+					var b64 string
+					if b64, err = p.B64Encode(); Check(err) {
+						return
+					}
+					wg.importSharedPSBT(b64)
+				}()
+			})).Fn).
+			Fn,
+	)
+	return
+}
+
+// updateMultisig fetches the shared wallets and the transactions collecting signatures for them
+func (wg *WalletGUI) updateMultisig() {
+	accts, err := wg.WalletClient.ListMultisigAccounts()
+	if Check(err) {
+		return
+	}
+	var pending []btcjson.MultisigPSBTResult
+	if pending, err = wg.WalletClient.ListMultisigPSBTs(); Check(err) {
+		return
+	}
+	wg.State.SetMultisig(accts, pending)
+}
+
+// sharedKey creates the wallet account for the shared wallet named in the form if there is none yet, and copies the
+// extended public key it contributes to the shared wallet for sending to the cosigners
+func (wg *WalletGUI) sharedKey() {
+	name := strings.TrimSpace(wg.inputs["sharedName"].GetText())
+	if name == "" {
+		go wg.toasts.AddToast("Shared wallet", "enter the name of the shared wallet", "Danger")
+		return
+	}
+	go func() {
+		xpub, err := wg.WalletClient.GetAccountXPub(name)
+		if err != nil {
+			// the wallet account the shared wallet's keys are derived from is created the first time
+			if err = wg.WalletClient.CreateNewAccount(name); Check(err) {
+				wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+				return
+			}
+			if xpub, err = wg.WalletClient.GetAccountXPub(name); Check(err) {
+				wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+				return
+			}
+		}
+		if err = clipboard.WriteAll(xpub); Check(err) {
+			wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+			return
+		}
+		wg.toasts.AddToast("Shared wallet", "your key is copied, send it to the cosigners", "Info")
+	}()
+}
+
+// createShared creates the shared wallet of the form from the keys of the other cosigners
+func (wg *WalletGUI) createShared() {
+	name := strings.TrimSpace(wg.inputs["sharedName"].GetText())
+	keys := strings.FieldsFunc(wg.inputs["sharedKeys"].GetText(), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+	required, err := strconv.Atoi(strings.TrimSpace(wg.inputs["sharedRequired"].GetText()))
+	switch {
+	case name == "":
+		go wg.toasts.AddToast("Shared wallet", "enter the name of the shared wallet", "Danger")
+		return
+	case len(keys) == 0:
+		go wg.toasts.AddToast("Shared wallet", "enter the keys of the other cosigners", "Danger")
+		return
+	case err != nil || required < 1 || required > len(keys)+1:
+		go wg.toasts.AddToast("Shared wallet", fmt.Sprintf("enter the number of signatures needed, from 1 to %d",
+			len(keys)+1), "Danger")
+		return
+	}
+	go func() {
+		// the wallet account is created here too, for a cosigner who did not get their key from this page
+		if _, err := wg.WalletClient.GetAccountXPub(name); err != nil {
+			if err = wg.WalletClient.CreateNewAccount(name); Check(err) {
+				wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+				return
+			}
+		}
+		res, err := wg.WalletClient.CreateMultisigAccount(name, required, keys)
+		if Check(err) {
+			wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+			return
+		}
+		wg.toasts.AddToast("Shared wallet", fmt.Sprintf("created %s, %d of %d", res.Account, res.Required,
+			len(res.Keys)), "Success")
+		wg.updateMultisig()
+	}()
+}
+
+// newSharedAddress derives the next address of a shared wallet and shows its QR code
+func (wg *WalletGUI) newSharedAddress(account string) {
+	go func() {
+		address, err := wg.WalletClient.GetNewMultisigAddress(account)
+		if Check(err) {
+			wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+			return
+		}
+		wg.showPaymentQR(account+" address", address)
+		wg.updateMultisig()
+	}()
+}
+
+// spendShared starts a transaction from a shared wallet paying the recipients of the send form, and adds this
+// wallet's signatures to it
+func (wg *WalletGUI) spendShared(account string) {
+	amounts, labels, _, errs := wg.batchRecipients()
+	if len(errs) > 0 {
+		wg.dialog.ShowDialog("Check the recipients", "Warning", batchErrors(errs))()
+		return
+	}
+	if len(amounts) == 0 {
+		go wg.toasts.AddToast("Shared wallet", "enter the recipients on the send page", "Danger")
+		return
+	}
+	feeRate, _, err := wg.feeRate()
+	if err != nil {
+		go wg.toasts.AddToast("Fee error", err.Error(), "Danger")
+		return
+	}
+	go func() {
+		res, err := wg.WalletClient.CreateMultisigPSBT(account, amounts, &feeRate)
+		if Check(err) {
+			wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+			return
+		}
+		for address, label := range labels {
+			wg.addToAddressBook(address, label, false)
+		}
+		wg.updateMultisig()
+		if wg.State.WatchOnly() {
+			wg.toasts.AddToast("Shared wallet", "the transaction is waiting for the cosigners' signatures", "Info")
+			return
+		}
+		wg.signShared(res.PSBT)
+	}()
+}
+
+// signShared adds this wallet's signatures to a transaction spending from a shared wallet
+func (wg *WalletGUI) signShared(b64 string) {
+	wg.authorize("Sign shared wallet transaction", false, func() {
+		res, err := wg.WalletClient.SignMultisigPSBT(b64)
+		if Check(err) {
+			wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+			return
+		}
+		wg.sharedProgress(res)
+	})
+}
+
+// importSharedPSBT merges the signatures of a transaction signed by a cosigner into the ones collected here
+func (wg *WalletGUI) importSharedPSBT(b64 string) {
+	res, err := wg.WalletClient.ImportMultisigPSBT(b64)
+	if Check(err) {
+		wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+		return
+	}
+	wg.sharedProgress(res)
+}
+
+// sharedProgress tells whether a shared wallet transaction can be broadcast or still needs signatures
+func (wg *WalletGUI) sharedProgress(res *btcjson.MultisigPSBTResult) {
+	if res.Complete {
+		wg.toasts.AddToast("Shared wallet", "the transaction has all of its signatures and can be broadcast",
+			"Success")
+	} else {
+		wg.toasts.AddToast("Shared wallet", fmt.Sprintf("%d signatures added, copy the transaction to the next "+
+			"cosigner", res.Signed), "Info")
+	}
+	wg.updateMultisig()
+}
+
+// saveSharedPSBT saves a shared wallet transaction to the file given in the form, for a cosigner to sign
+func (wg *WalletGUI) saveSharedPSBT(b64 string) {
+	path := strings.TrimSpace(wg.inputs["sharedSavePath"].GetText())
+	if path == "" {
+		go wg.toasts.AddToast("Shared wallet", "enter the path of the file to save the transaction to", "Danger")
+		return
+	}
+	go func() {
+		if err := ioutil.WriteFile(path, []byte(b64+"\n"), 0600); Check(err) {
+			wg.toasts.AddToast("Shared wallet", err.Error(), "Danger")
+			return
+		}
+		wg.toasts.AddToast("Shared wallet", "saved to "+path, "Success")
+	}()
+}
+
+// broadcastShared publishes a shared wallet transaction that has all of its signatures
+func (wg *WalletGUI) broadcastShared(b64 string) {
+	go func() {
+		h, err := wg.WalletClient.PublishPSBT(b64)
+		if Check(err) {
+			wg.toasts.AddToast("Broadcast", err.Error(), "Danger")
+			return
+		}
+		wg.toasts.AddToast("TxID", h.String(), "Success")
+		wg.updateMultisig()
+	}()
+}
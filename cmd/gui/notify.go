@@ -0,0 +1,68 @@
+package gui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util/lang"
+)
+
+// windowsToast shows a toast notification with the title and message filled in as powershell strings. Toasts have to
+// come from a registered application, so they are shown as coming from powershell
+const windowsToast = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$t = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$x = $t.GetElementsByTagName('text')
+$x.Item(0).AppendChild($t.CreateTextNode('%s')) > $null
+$x.Item(1).AppendChild($t.CreateTextNode('%s')) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe').Show([Windows.UI.Notifications.ToastNotification]::new($t))`
+
+// notifyTx announces payments received by the wallet, payments sent from it being mined and blocks found by the local
+// miner, each once, in a toast and on the desktop, where they are enabled in the settings
+func (wg *WalletGUI) notifyTx(tx *btcjson.ListTransactionsResult) {
+	// the wallet also notifies the transactions in the blocks it catches up with after being offline, which are old news
+	if tx.BlockTime != 0 && time.Since(time.Unix(tx.BlockTime, 0)) > time.Hour {
+		return
+	}
+	var title, msg string
+	switch {
+	case tx.Category == "receive" && *wg.cx.Config.NotifyReceived:
+		title, msg = "Payment received", fmt.Sprintf("received %s on %s", wg.formatAmount(tx.Amount), tx.Address)
+	case tx.Category == "send" && tx.Confirmations > 0 && *wg.cx.Config.NotifySent:
+		title, msg = "Payment confirmed", fmt.Sprintf("sent %s to %s", wg.formatAmount(-tx.Amount), tx.Address)
+	case tx.Category == "immature" && *wg.cx.Config.NotifyMined:
+		title, msg = "Block found", fmt.Sprintf("the miner found block %s paying %s", tx.BlockHash,
+			wg.formatAmount(tx.Amount))
+	default:
+		return
+	}
+	if !wg.State.FirstNotice(fmt.Sprintf("%s:%s:%d", tx.Category, tx.TxID, tx.Vout)) {
+		return
+	}
+	go wg.toasts.AddToast(title, msg, "Success")
+	// the desktop shows the title as it is, while the toast is translated as it is rendered
+	desktopNotify(lang.T(title), msg)
+}
+
+// desktopNotify shows a notification on the desktop with the notification tool of the platform, if it has one
+func desktopNotify(title, msg string) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "windows":
+		quote := strings.NewReplacer("'", "''")
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-WindowStyle", "Hidden", "-Command",
+			fmt.Sprintf(windowsToast, quote.Replace(title), quote.Replace(msg)))
+	case "darwin":
+		cmd = exec.Command("osascript", "-e", fmt.Sprintf("display notification %q with title %q", msg, title))
+	default:
+		cmd = exec.Command("notify-send", "--app-name", "ParallelCoin Wallet", title, msg)
+	}
+	go func() {
+		if err := cmd.Run(); err != nil {
+			Debug("desktop notification failed:", err)
+		}
+	}()
+}
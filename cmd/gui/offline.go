@@ -0,0 +1,262 @@
+package gui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image/png"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	l "gioui.org/layout"
+
+	"github.com/p9c/pod/pkg/coding/qrcode"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	rpcclient "github.com/p9c/pod/pkg/rpc/client"
+	"github.com/p9c/pod/pkg/util/psbt"
+)
+
+// OfflinePage takes a send through the three steps of spending from a wallet whose keys are kept on a machine that is
+// never online: the transaction of the send form is exported unsigned, signed by the offline wallet, on this page or
+// with pod wallet signoffline, and the signed transaction is brought back to be broadcast
+func (wg *WalletGUI) OfflinePage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.offlineWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["offline"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) offlineWidgets() []l.Widget {
+	return []l.Widget{
+		wg.privacyHeading("1. export the unsigned transaction"),
+		wg.privacyLine("Creates the transaction paying the recipients of the send form without signing it. The " +
+			"outputs it spends stay locked until it is broadcast or they are unlocked in the coin control panel."),
+		wg.Inset(0.25, wg.inputs["offlineExportPath"].Fn).Fn,
+		wg.Inset(0.25, wg.buttonText(wg.clickables["offlineExport"], "Export", wg.exportUnsigned)).Fn,
+		wg.privacyHeading("2. sign it on the offline machine"),
+		wg.privacyLine("Sign it here in the offline wallet, or with: pod wallet signoffline --in <file> --out " +
+			"<signed file>"),
+		wg.Inset(0.25, wg.inputs["offlineSignPath"].Fn).Fn,
+		wg.Inset(0.25, wg.inputs["offlineSignedPath"].Fn).Fn,
+		wg.Inset(0.25, wg.buttonText(wg.clickables["offlineSign"], "Sign", wg.signOffline)).Fn,
+		wg.privacyHeading("3. broadcast the signed transaction"),
+		wg.Inset(0.25, wg.inputs["offlineBroadcastPath"].Fn).Fn,
+		wg.Inset(0.25, wg.buttonText(wg.clickables["offlineCheck"], "Check and broadcast", wg.checkSigned)).Fn,
+	}
+}
+
+// exportUnsigned creates the transaction paying the recipients of the send form, with the derivation paths of the keys
+// that sign it so the offline wallet finds them, and saves it to the export path. When it fits in a QR code the code
+// is saved beside it as a PNG image and shown.
+func (wg *WalletGUI) exportUnsigned() {
+	path := strings.TrimSpace(wg.inputs["offlineExportPath"].GetText())
+	if path == "" {
+		go wg.toasts.AddToast("Export", "enter the path of the file to save the transaction to", "Danger")
+		return
+	}
+	amounts, labels, _, errs := wg.batchRecipients()
+	if len(errs) > 0 {
+		wg.dialog.ShowDialog("Check the recipients", "Warning", batchErrors(errs))()
+		return
+	}
+	if len(amounts) == 0 {
+		go wg.toasts.AddToast("Export", "enter the recipients on the send page", "Danger")
+		return
+	}
+	feeRate, _, err := wg.feeRate()
+	if err != nil {
+		go wg.toasts.AddToast("Fee error", err.Error(), "Danger")
+		return
+	}
+	var inputs []btcjson.TransactionInput
+	for _, u := range wg.selectedCoins() {
+		inputs = append(inputs, btcjson.TransactionInput{Txid: u.TxID, Vout: u.Vout})
+	}
+	go func() {
+		lock, derivs, rate := true, true, feeRate.ToDUO()
+		res, err := wg.WalletClient.WalletCreateFundedPSBT(inputs, amounts, nil,
+			&btcjson.WalletCreateFundedPSBTOpts{LockUnspents: &lock, FeeRate: &rate}, &derivs)
+		if Check(err) {
+			wg.toasts.AddToast("Export", err.Error(), "Danger")
+			return
+		}
+		if err = ioutil.WriteFile(path, []byte(res.PSBT+"\n"), 0600); Check(err) {
+			wg.toasts.AddToast("Export", err.Error(), "Danger")
+			return
+		}
+		for address, label := range labels {
+			wg.addToAddressBook(address, label, false)
+		}
+		if err = writeQRImage(qrImagePath(path), res.PSBT); Check(err) {
+			wg.toasts.AddToast("Export", fmt.Sprintf("saved to %s, %v, carry the file to the offline machine",
+				path, err), "Warning")
+			return
+		}
+		wg.toasts.AddToast("Export", fmt.Sprintf("saved to %s with its QR code in %s, fee %s", path,
+			qrImagePath(path), wg.formatAmount(res.Fee)), "Success")
+		wg.showOfflineQR("Unsigned transaction", res.PSBT)
+		wg.invalidate <- struct{}{}
+	}()
+}
+
+// signOffline signs the transaction in the file at the sign path with the keys of the wallet and saves it to the
+// signed path
+func (wg *WalletGUI) signOffline() {
+	path := strings.TrimSpace(wg.inputs["offlineSignPath"].GetText())
+	out := strings.TrimSpace(wg.inputs["offlineSignedPath"].GetText())
+	if path == "" || out == "" {
+		go wg.toasts.AddToast("Sign", "enter the paths of the transaction and of the file to save it signed to",
+			"Danger")
+		return
+	}
+	wg.authorize("Sign offline transaction", false, func() {
+		b64, err := readPSBT(path)
+		if Check(err) {
+			wg.toasts.AddToast("Sign", err.Error(), "Danger")
+			return
+		}
+		var res *btcjson.WalletProcessPSBTResult
+		if res, err = wg.WalletClient.WalletProcessPSBT(b64, true, rpcclient.SigHashAll, false); Check(err) {
+			wg.toasts.AddToast("Sign", err.Error(), "Danger")
+			return
+		}
+		if err = ioutil.WriteFile(out, []byte(res.PSBT+"\n"), 0600); Check(err) {
+			wg.toasts.AddToast("Sign", err.Error(), "Danger")
+			return
+		}
+		if !res.Complete {
+			wg.toasts.AddToast("Sign", fmt.Sprintf("saved to %s, it still needs signatures from other signers",
+				out), "Warning")
+			return
+		}
+		msg := fmt.Sprintf("saved to %s, carry it to the online machine to broadcast", out)
+		if err = writeQRImage(qrImagePath(out), res.PSBT); err == nil {
+			msg = fmt.Sprintf("saved to %s with its QR code in %s, carry it to the online machine to broadcast",
+				out, qrImagePath(out))
+			wg.showOfflineQR("Signed transaction", res.PSBT)
+		}
+		wg.toasts.AddToast("Sign", msg, "Success")
+	})
+}
+
+// checkSigned reads the signed transaction at the broadcast path and shows what it pays before it is broadcast
+func (wg *WalletGUI) checkSigned() {
+	path := strings.TrimSpace(wg.inputs["offlineBroadcastPath"].GetText())
+	if path == "" {
+		go wg.toasts.AddToast("Broadcast", "enter the path of the signed transaction", "Danger")
+		return
+	}
+	go func() {
+		b64, err := readPSBT(path)
+		if Check(err) {
+			wg.toasts.AddToast("Broadcast", err.Error(), "Danger")
+			return
+		}
+		var res *btcjson.DecodePSBTResult
+		if res, err = wg.WalletClient.DecodePSBT(b64); Check(err) {
+			wg.toasts.AddToast("Broadcast", err.Error(), "Danger")
+			return
+		}
+		wg.dialog.ShowDialog("Confirm broadcast", "Info", wg.broadcastConfirmation(b64, res))()
+		wg.invalidate <- struct{}{}
+	}()
+}
+
+// broadcastConfirmation lists the outputs and fee of a signed transaction with a button to broadcast it
+func (wg *WalletGUI) broadcastConfirmation(b64 string, res *btcjson.DecodePSBTResult) l.Widget {
+	var lines []string
+	for _, vout := range res.Tx.Vout {
+		lines = append(lines, fmt.Sprintf("Pay %s to %s", wg.formatAmount(vout.Value),
+			strings.Join(vout.ScriptPubKey.Addresses, ", ")))
+	}
+	if res.Fee != nil {
+		lines = append(lines, fmt.Sprintf("Fee %s", wg.formatAmount(*res.Fee)))
+	}
+	flex := wg.th.VFlex()
+	for i := range lines {
+		flex = flex.Rigid(
+			wg.Inset(0.25,
+				wg.Body2(lines[i]).Color("PanelText").Fn,
+			).Fn,
+		)
+	}
+	return flex.Rigid(
+		wg.Inset(0.25,
+			wg.buttonText(wg.clickables["offlineBroadcast"], "Broadcast", func() {
+				wg.dialog.Close()
+				go func() {
+					h, err := wg.WalletClient.PublishPSBT(b64)
+					if Check(err) {
+						wg.toasts.AddToast("Broadcast", err.Error(), "Danger")
+						return
+					}
+					wg.toasts.AddToast("TxID", h.String(), "Success")
+				}()
+			}),
+		).Fn,
+	).Fn
+}
+
+// showOfflineQR opens a dialog with the QR code of a partially signed transaction, for a camera on the other machine to
+// read
+func (wg *WalletGUI) showOfflineQR(title, b64 string) {
+	qr := wg.th.QRCode(b64)
+	if qr.Err() != nil {
+		return
+	}
+	wg.dialog.ShowDialog(title, "Info", wg.Inset(0.5, qr.Fn).Fn)()
+}
+
+// readPSBT reads a partially signed transaction from a file holding it in the binary format, as base64 or as an image
+// of its QR code, returning it as base64
+func readPSBT(path string) (b64 string, err error) {
+	var b []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png", ".jpg", ".jpeg", ".gif":
+		var data string
+		if data, err = readQRImage(path); err != nil {
+			return
+		}
+		b = []byte(data)
+	default:
+		if b, err = ioutil.ReadFile(path); err != nil {
+			return
+		}
+	}
+	var p *psbt.Packet
+	if p, err = psbt.Parse(b); err != nil {
+		return
+	}
+	return p.B64Encode()
+}
+
+// qrImagePath is the path the QR code of the transaction saved at path is saved to
+func qrImagePath(path string) string {
+	return path + ".png"
+}
+
+// writeQRImage saves the QR code of a partially signed transaction as a PNG image, failing when it is too large for
+// a QR code
+func writeQRImage(path, b64 string) (err error) {
+	img, err := qrcode.Encode(b64, 0, qrcode.ECLevelM)
+	if err != nil {
+		return errors.New("the transaction is too large for a QR code")
+	}
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0600)
+}
@@ -8,6 +8,7 @@ import (
 	icons2 "golang.org/x/exp/shiny/materialdesign/icons"
 
 	"github.com/p9c/pod/pkg/gui/p9"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 )
 
 func (wg *WalletGUI) OverviewPage() l.Widget {
@@ -15,9 +16,11 @@ func (wg *WalletGUI) OverviewPage() l.Widget {
 		balanceColumn := wg.th.Column(p9.Rows{
 			{Label: "Available:", W: wg.balanceWidget(wg.State.balance)},
 			{Label: "Unconfirmed:", W: wg.balanceWidget(wg.State.balanceUnconfirmed)},
-			{Label: "Total:", W: wg.balanceWidget(wg.State.balance + wg.State.balanceUnconfirmed)},
+			{Label: "Immature:", W: wg.balanceWidget(wg.State.balanceImmature)},
+			{Label: "Locked:", W: wg.balanceWidget(wg.State.balanceLocked)},
+			{Label: "Total:", W: wg.balanceWidget(wg.State.balanceTotal)},
 		}, "bariol bold", 1).List
-		return wg.th.Responsive(*wg.App.Size, p9.Widgets{
+		return wg.th.VFlex().Rigid(wg.AnnouncementBanner()).Rigid(wg.WatchOnlyBanner()).Rigid(wg.ReconciliationBanner()).Flexed(1, wg.th.Responsive(*wg.App.Size, p9.Widgets{
 			{
 				Widget: wg.th.VFlex().
 					Rigid(
@@ -142,10 +145,77 @@ func (wg *WalletGUI) OverviewPage() l.Widget {
 					).
 					Fn,
 			},
-		}).Fn(gtx)
+		}).Fn).Fn(gtx)
 	}
 }
 
+// AnnouncementBanner shows the signed network announcements relayed to the node, such as upgrade deadlines
+func (wg *WalletGUI) AnnouncementBanner() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		announcements := wg.State.Announcements()
+		if len(announcements) == 0 {
+			return l.Dimensions{}
+		}
+		var out []l.Widget
+		for i := range announcements {
+			out = append(out,
+				wg.th.Flex().AlignMiddle().
+					Rigid(
+						wg.Icon().Color("DocBg").Scale(1).Src(&icons2.AlertWarning).Fn,
+					).
+					Flexed(1,
+						wg.th.Inset(0.25,
+							wg.th.Body1(announcements[i]).Color("DocBg").Fn,
+						).Fn,
+					).Fn,
+			)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("Danger",
+				wg.th.Inset(0.25,
+					wg.th.SliceToWidget(out, l.Vertical),
+				).Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+// ReconciliationBanner shows what was found out of line the last time the wallet history was reconciled with the
+// chain, such as after restoring the wallet from a backup, so a changed balance is not a surprise
+func (wg *WalletGUI) ReconciliationBanner() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		r := wg.State.Reconciliation()
+		if r == nil {
+			return l.Dimensions{}
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("Warning",
+				wg.th.Inset(0.25,
+					wg.th.Flex().AlignMiddle().
+						Rigid(
+							wg.Icon().Color("DocBg").Scale(1).Src(&icons2.ActionHistory).Fn,
+						).
+						Flexed(1,
+							wg.th.Inset(0.25,
+								wg.th.Body1(reconciliationSummary(r)).Color("DocBg").Fn,
+							).Fn,
+						).Fn,
+				).Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+// reconciliationSummary describes the differences a reconciliation of the wallet history found
+func reconciliationSummary(r *btcjson.GetReconciliationResult) string {
+	return fmt.Sprintf(
+		"wallet history reconciled with the chain from height %d to %d: %d missing transactions found, "+
+			"%d rejected transactions removed, %d addresses derived, balance %.8f -> %.8f DUO",
+		r.FromHeight, r.ToHeight, len(r.MissingTxs), len(r.ExtraTxs), r.AddressesDerived, r.BalanceBefore,
+		r.BalanceAfter,
+	)
+}
+
 // RecentTransactions generates a display showing recent transactions
 //
 // fields to use: Address, Amount, BlockIndex, BlockTime, Category, Confirmations, Generated
@@ -168,7 +238,7 @@ func (wg *WalletGUI) RecentTransactions() l.Widget {
 		}
 		out = append(out,
 			wg.th.Fill("DocBg",
-				wg.th.Body1(fmt.Sprintf("%-6.8f DUO", txs.Amount)).Color("PanelText").Fn,
+				wg.th.Body1(wg.formatAmount(txs.Amount)).Color("PanelText").Fn,
 			).Fn,
 		)
 
@@ -242,6 +312,21 @@ func (wg *WalletGUI) RecentTransactions() l.Widget {
 								).
 								Fn,
 						).
+						Rigid(
+							func(gtx l.Context) l.Dimensions {
+								if !txs.Conflicted {
+									return l.Dimensions{}
+								}
+								return wg.th.Flex().AlignMiddle().
+									Rigid(
+										wg.Icon().Color("Danger").Scale(1).Src(&icons2.AlertError).Fn,
+									).
+									Rigid(
+										wg.th.Caption("conflicted ").Color("Danger").Fn,
+									).
+									Fn(gtx)
+							},
+						).
 						Rigid(
 							wg.th.Flex().AlignMiddle().
 								Rigid(
@@ -255,18 +340,13 @@ func (wg *WalletGUI) RecentTransactions() l.Widget {
 								).
 								Fn,
 						).
-						// TODO: this thing hasn't got data going in yet, before we can display anything we need data
-						//  also the index `i` is not from wg.State.txs it is from wg.State.lastTxs
-						//  - even if these two data sets overlap if you want them to relate to each other you need
-						//  to define their integration. Simple way would be for eg: as you intend, to merge them into
-						//  one and only update (add) the extra data on page display. I think that it's so trivial for
-						//  10 instances of the listtransactions result struct just keep them separate so the logic is
-						//  cleaner. In other words, add a second fetcher in ticker.go for the history/tx page, and
-						//  handle the damn empty list, nil panics are Satan.
-						//  my advice is use the second field and keep them separated
-						// Rigid(
-						// 	wg.Inset(0.1, wg.buttonText(wg.State.txs[i].clickTx, "details", wg.txPage(i))).Fn,
-						// ).
+						Rigid(
+							wg.Inset(0.1,
+								wg.buttonText(wg.keyedClickable("txDetail"+txs.TxID), "details", func() {
+									wg.showTxDetail(txs.TxID)
+								}),
+							).Fn,
+						).
 						Fn,
 				).
 					Fn(gtx)
@@ -293,8 +373,8 @@ func leftPadTo(length, limit int, txt string) string {
 }
 
 func (wg *WalletGUI) balanceWidget(balance float64) l.Widget {
-	bal := leftPadTo(15, 15, fmt.Sprintf("%6.8f", balance))
-	return wg.th.Flex().AlignEnd().
+	bal := leftPadTo(24, 24, wg.formatAmount(balance))
+	row := wg.th.Flex().AlignEnd().
 		Rigid(wg.th.Body1(" ").Fn).
 		Rigid(
 			wg.th.Caption(bal).
@@ -302,6 +382,18 @@ func (wg *WalletGUI) balanceWidget(balance float64) l.Widget {
 				Fn,
 		).
 		Fn
+	fiat := wg.fiatAmount(balance)
+	if fiat == "" {
+		return row
+	}
+	return wg.th.VFlex().AlignEnd().
+		Rigid(row).
+		Rigid(
+			wg.th.Caption(fiat).
+				Color("Hint").
+				Fn,
+		).
+		Fn
 }
 
 //
@@ -0,0 +1,175 @@
+package gui
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	l "gioui.org/layout"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	rpcclient "github.com/p9c/pod/pkg/rpc/client"
+	"github.com/p9c/pod/pkg/util/lang"
+)
+
+// PeersPage lists the peers the node is connected to, with buttons to disconnect or ban them, the bans of the node
+// with buttons to lift them, and a form to add a persistent peer
+func (wg *WalletGUI) PeersPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.peersWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["peers"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) peersWidgets() (out []l.Widget) {
+	out = []l.Widget{
+		wg.privacyHeading("add a peer"),
+		wg.privacyLine("The node keeps connecting to a persistent peer, reconnecting whenever the connection is lost."),
+		wg.th.Flex().AlignMiddle().
+			Flexed(1, wg.Inset(0.25, wg.inputs["peerAddress"].Fn).Fn).
+			Rigid(wg.Inset(0.25, wg.buttonText(wg.clickables["peerAdd"], "Add", wg.addPeer)).Fn).
+			Fn,
+	}
+	peers, banned := wg.State.Peers()
+	if peers == nil {
+		return append(out, wg.privacyLine("loading peers..."))
+	}
+	out = append(out, wg.privacyHeading(lang.Tf("%d connected peers", len(peers))))
+	if len(peers) == 0 {
+		out = append(out, wg.privacyLine("the node is not connected to any peers"))
+	}
+	best := int32(wg.State.BestBlockHeight())
+	for i := range peers {
+		out = append(out, wg.peerLine(&peers[i], best))
+	}
+	if len(banned) > 0 {
+		out = append(out, wg.privacyHeading("banned"))
+	}
+	for i := range banned {
+		b := banned[i]
+		out = append(out,
+			wg.th.Flex().AlignMiddle().
+				Rigid(wg.Inset(0.25,
+					wg.buttonText(wg.keyedClickable("peerUnban"+b.Address), "Unban", func() {
+						wg.peerAction("Unban peer", "the ban of "+b.Address+" was lifted", func() error {
+							return wg.ChainClient.SetBan(b.Address, btcjson.SBRemove, nil, nil)
+						})
+					}),
+				).Fn).
+				Flexed(1, wg.privacyLine(fmt.Sprintf("%s until %s, %s", b.Address,
+					time.Unix(b.BannedUntil, 0).Format("2006-01-02 15:04"), b.BanReason))).
+				Fn,
+		)
+	}
+	return
+}
+
+// peerLine shows the address, direction, user agent, ping and sync state of a peer with buttons to disconnect and ban
+// it
+func (wg *WalletGUI) peerLine(p *btcjson.GetPeerInfoResult, best int32) l.Widget {
+	direction := "outbound"
+	if p.Inbound {
+		direction = "inbound"
+	}
+	// ping times are reported in microseconds
+	ping := "no ping yet"
+	if p.PingTime > 0 {
+		ping = fmt.Sprintf("ping %.0fms", p.PingTime/1000)
+	}
+	if p.PingWait > 0 {
+		ping = fmt.Sprintf("waiting %.0fms for ping", p.PingWait/1000)
+	}
+	txt := fmt.Sprintf("%s %s %s, %s, %s", p.Addr, direction, p.SubVer, ping, peerSyncState(p, best))
+	id, addr := strconv.Itoa(int(p.ID)), p.Addr
+	return wg.th.Flex().AlignMiddle().
+		Rigid(wg.Inset(0.25,
+			wg.buttonText(wg.keyedClickable("peerDisconnect"+id), "Disconnect", func() {
+				wg.peerAction("Disconnect peer", addr+" was disconnected", func() error {
+					return wg.ChainClient.Node(btcjson.NDisconnect, id, nil)
+				})
+			}),
+		).Fn).
+		Rigid(wg.Inset(0.25,
+			wg.buttonText(wg.keyedClickable("peerBan"+id), "Ban", func() {
+				host, _, err := net.SplitHostPort(addr)
+				if err != nil {
+					host = addr
+				}
+				wg.peerAction("Ban peer", host+" was banned and disconnected", func() error {
+					return wg.ChainClient.SetBan(host, btcjson.SBAdd, nil, nil)
+				})
+			}),
+		).Fn).
+		Flexed(1, wg.privacyLine(txt)).
+		Fn
+}
+
+// peerSyncState describes how far along the chain a peer is compared to the node
+func peerSyncState(p *btcjson.GetPeerInfoResult, best int32) string {
+	height := p.CurrentHeight
+	if height == 0 {
+		height = p.StartingHeight
+	}
+	state := fmt.Sprintf("at block %d", height)
+	if height < best {
+		state = fmt.Sprintf("%d blocks behind", best-height)
+	}
+	if p.SyncNode {
+		state += ", syncing the node"
+	}
+	return state
+}
+
+// addPeer adds the address entered in the form as a persistent peer of the node
+func (wg *WalletGUI) addPeer() {
+	host := strings.TrimSpace(wg.inputs["peerAddress"].GetText())
+	if host == "" {
+		go wg.toasts.AddToast("Add peer", "enter the address of the peer to add", "Danger")
+		return
+	}
+	wg.peerAction("Add peer", host+" was added as a persistent peer", func() error {
+		return wg.ChainClient.AddNode(host, rpcclient.ANAdd)
+	})
+}
+
+// peerAction runs a command on the node's peers, announcing whether it worked and refreshing the list of peers
+func (wg *WalletGUI) peerAction(title, done string, action func() error) {
+	go func() {
+		if err := action(); Check(err) {
+			wg.toasts.AddToast(title, err.Error(), "Danger")
+			return
+		}
+		wg.toasts.AddToast(title, done, "Success")
+		wg.updatePeers()
+		wg.invalidate <- struct{}{}
+	}()
+}
+
+// updatePeers fetches the peers the node is connected to and its bans
+func (wg *WalletGUI) updatePeers() {
+	peers, err := wg.ChainClient.GetPeerInfo()
+	if Check(err) {
+		return
+	}
+	var banned []btcjson.ListBannedResult
+	if banned, err = wg.ChainClient.ListBanned(); Check(err) {
+		return
+	}
+	if peers == nil {
+		peers = []btcjson.GetPeerInfoResult{}
+	}
+	wg.State.SetPeers(peers, banned)
+}
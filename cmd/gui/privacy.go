@@ -0,0 +1,81 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	l "gioui.org/layout"
+)
+
+// PrivacyPage shows the wallet's privacy report: reused addresses, transactions that merged several addresses and
+// round amount payments, along with suggestions for improving them
+func (wg *WalletGUI) PrivacyPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := append(wg.privacyReportWidgets(), wg.walletDBWidgets()...)
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["privacy"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) privacyReportWidgets() (out []l.Widget) {
+	report := wg.State.PrivacyReport()
+	if report == nil {
+		return []l.Widget{wg.privacyLine("analysing wallet history...")}
+	}
+	out = append(out,
+		wg.privacyHeading(fmt.Sprintf("%d transactions analysed", report.Transactions)),
+	)
+	if len(report.Suggestions) == 0 {
+		out = append(out, wg.privacyLine("no privacy problems were found in the wallet history"))
+	}
+	for i := range report.Suggestions {
+		out = append(out, wg.privacyLine("• "+report.Suggestions[i]))
+	}
+	if len(report.ReusedAddresses) > 0 {
+		out = append(out, wg.privacyHeading("reused addresses"))
+		for _, r := range report.ReusedAddresses {
+			out = append(out,
+				wg.privacyLine(fmt.Sprintf("%s received %d times, %s in total", r.Address, r.Received, wg.formatAmount(r.Amount))),
+			)
+		}
+	}
+	if len(report.MergedInputs) > 0 {
+		out = append(out, wg.privacyHeading("transactions merging addresses"))
+		for _, m := range report.MergedInputs {
+			out = append(out,
+				wg.privacyLine(fmt.Sprintf("%s spent from %s", m.TxID, strings.Join(m.Addresses, ", "))),
+			)
+		}
+	}
+	if len(report.RoundAmounts) > 0 {
+		out = append(out, wg.privacyHeading("round amount payments"))
+		for _, r := range report.RoundAmounts {
+			out = append(out,
+				wg.privacyLine(fmt.Sprintf("%s paid %s to %s", r.TxID, wg.formatAmount(r.Amount), r.Address)),
+			)
+		}
+	}
+	return
+}
+
+func (wg *WalletGUI) privacyHeading(txt string) l.Widget {
+	return wg.th.Inset(0.25,
+		wg.th.H6(txt).Color("DocText").Fn,
+	).Fn
+}
+
+func (wg *WalletGUI) privacyLine(txt string) l.Widget {
+	return wg.th.Inset(0.25,
+		wg.th.Body2(txt).Color("DocText").Fn,
+	).Fn
+}
@@ -0,0 +1,193 @@
+package gui
+
+import (
+	"errors"
+	"image"
+	// the formats of the images QR codes are read from
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+
+	l "gioui.org/layout"
+	"github.com/atotto/clipboard"
+
+	"github.com/p9c/pod/pkg/coding/qrcode"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// paymentRequestURI is the payment URI of a payment request, which wallets that scan its QR code fill in their send
+// form from
+func (wg *WalletGUI) paymentRequestURI(r *btcjson.PaymentRequestResult) (uri string, err error) {
+	p := util.PaymentURI{Label: r.Label, Message: r.Message}
+	if p.Address, err = util.DecodeAddress(r.Address, wg.cx.ActiveNet); err != nil {
+		return
+	}
+	if p.Amount, err = util.NewAmount(r.Amount); err != nil {
+		return
+	}
+	return p.String(), nil
+}
+
+// showPaymentQR opens a dialog with the QR code of a payment URI and a button to copy the URI
+func (wg *WalletGUI) showPaymentQR(title, uri string) {
+	qr := wg.th.QRCode(uri)
+	if err := qr.Err(); err != nil {
+		wg.toasts.AddToast("QR code", err.Error(), "Danger")
+		return
+	}
+	wg.dialog.ShowDialog(title, "Info",
+		wg.th.VFlex().
+			Rigid(wg.Inset(0.5, qr.Fn).Fn).
+			Rigid(wg.Inset(0.25, wg.Caption(uri).Font("go regular").Color("PanelText").Fn).Fn).
+			Rigid(
+				wg.Inset(0.25,
+					wg.buttonText(wg.clickables["paymentQRCopy"], "Copy", func() {
+						go clipboard.WriteAll(uri)
+					}),
+				).Fn,
+			).Fn,
+	)()
+}
+
+// fillPaymentURI fills in recipient i of the send form from a payment URI or a bare address, such as is held in a QR
+// code. The label of an address that is in the address book is used when the URI has none.
+func (wg *WalletGUI) fillPaymentURI(i int, uri string) (err error) {
+	if i >= len(wg.sendAddresses) {
+		return
+	}
+	var p util.PaymentURI
+	if p, err = util.ParsePaymentURI(uri, wg.cx.ActiveNet); err != nil {
+		return
+	}
+	address := p.Address.EncodeAddress()
+	if e, ok := wg.addressBook.Lookup(address); ok && p.Label == "" {
+		p.Label = e.Label
+	}
+	wg.payToAddressBookEntry(i, AddressBookEntry{Address: address, Label: p.Label})
+	if p.Amount > 0 {
+		wg.sendAddresses[i].AmountInput = wg.th.Input(wg.formatAmount(p.Amount.ToDUO()), "Enter amount", "Primary",
+			"DocText", 10, func(pass string) {})
+	}
+	if p.Message != "" {
+		wg.toasts.AddToast("Payment request", p.Message, "Info")
+	}
+	return
+}
+
+// pastePaymentURI fills in recipient i of the send form from a payment URI or address copied to the clipboard
+func (wg *WalletGUI) pastePaymentURI(i int) {
+	txt, err := clipboard.ReadAll()
+	if Check(err) {
+		wg.toasts.AddToast("Paste", err.Error(), "Danger")
+		return
+	}
+	if err = wg.fillPaymentURI(i, txt); err != nil {
+		wg.toasts.AddToast("Paste", "the clipboard has no payment URI or address: "+err.Error(), "Danger")
+	}
+}
+
+// scanQRImage opens a dialog for reading the QR code in an image file, such as a screenshot of a payment request, into
+// recipient i of the send form. The clipboard only holds text and there is no camera support, so the code is read from
+// a file.
+func (wg *WalletGUI) scanQRImage(i int) {
+	wg.dialog.ShowDialog("Read a QR code from an image file", "Info",
+		wg.th.VFlex().
+			Rigid(wg.Inset(0.25, wg.inputs["scanQRPath"].Fn).Fn).
+			Rigid(
+				wg.Inset(0.25,
+					wg.buttonText(wg.clickables["scanQRRead"], "Read", func() {
+						path := strings.TrimSpace(wg.inputs["scanQRPath"].GetText())
+						uri, err := readQRImage(path)
+						if err == nil {
+							err = wg.fillPaymentURI(i, uri)
+						}
+						if err != nil {
+							wg.toasts.AddToast("QR code", err.Error(), "Danger")
+							return
+						}
+						wg.dialog.Close()
+					}),
+				).Fn,
+			).Fn,
+	)()
+}
+
+// readQRImage reads the data of the QR code in a PNG, JPEG or GIF image file
+func readQRImage(path string) (data string, err error) {
+	if path == "" {
+		return "", errors.New("enter the path of the image")
+	}
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return
+	}
+	defer func() {
+		if err := f.Close(); Check(err) {
+		}
+	}()
+	var img image.Image
+	if img, _, err = image.Decode(f); err != nil {
+		return
+	}
+	return qrcode.Decode(img)
+}
+
+// paymentRequestQRButton is the button of a payment request that shows its QR code
+func (wg *WalletGUI) paymentRequestQRButton(r *btcjson.PaymentRequestResult) l.Widget {
+	return wg.Inset(0.25,
+		wg.buttonText(wg.keyedClickable("requestQR"+r.Address), "QR code", func() {
+			uri, err := wg.paymentRequestURI(r)
+			if Check(err) {
+				wg.toasts.AddToast("QR code", err.Error(), "Danger")
+				return
+			}
+			wg.showPaymentQR("Payment request", uri)
+		}),
+	).Fn
+}
+
+// paymentRequestCopyButton is the button of a payment request that copies its payment URI
+func (wg *WalletGUI) paymentRequestCopyButton(r *btcjson.PaymentRequestResult) l.Widget {
+	return wg.Inset(0.25,
+		wg.buttonText(wg.keyedClickable("requestCopy"+r.Address), "Copy URI", func() {
+			uri, err := wg.paymentRequestURI(r)
+			if Check(err) {
+				wg.toasts.AddToast("Copy", err.Error(), "Danger")
+				return
+			}
+			go clipboard.WriteAll(uri)
+		}),
+	).Fn
+}
+
+// requestURIWidget shows the payment URI of the payment request last created in the receive form, with buttons to copy
+// it and show its QR code
+func (wg *WalletGUI) requestURIWidget() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		uri := wg.State.RequestURI()
+		if uri == "" {
+			return l.Dimensions{}
+		}
+		return wg.Inset(0.25,
+			wg.th.Flex().
+				Flexed(1, wg.Inset(0.25, wg.Caption(uri).Font("go regular").Color("DocText").Fn).Fn).
+				Rigid(
+					wg.Inset(0.25,
+						wg.buttonText(wg.clickables["receiveCopyURI"], "Copy", func() {
+							go clipboard.WriteAll(uri)
+						}),
+					).Fn,
+				).
+				Rigid(
+					wg.Inset(0.25,
+						wg.buttonText(wg.clickables["receiveShowQR"], "QR code", func() {
+							wg.showPaymentQR("Payment request", uri)
+						}),
+					).Fn,
+				).Fn,
+		).Fn(gtx)
+	}
+}
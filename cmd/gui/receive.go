@@ -2,22 +2,29 @@ package gui
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	l "gioui.org/layout"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 )
 
+// ReceivePage is the form for requesting payments, followed by the list of payment requests the wallet is tracking
 func (wg *WalletGUI) ReceivePage() l.Widget {
-	le := func(gtx l.Context, index int) l.Dimensions {
-		return wg.Caption("BalaaaaaaaaaaaaaaaO_" + fmt.Sprint(index)).Color("DocBg").Fn(gtx)
-	}
 	return func(gtx l.Context) l.Dimensions {
+		lines := wg.paymentRequestWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
 		return wg.th.VFlex().
 			Rigid(
 				wg.receiveTop(),
 			).
 			Flexed(1,
 				wg.Inset(0.25, wg.Fill("DocBg", wg.Inset(0.25,
-					wg.lists["received"].Vertical().Length(len(wg.sendAddresses)).ListElement(le).Fn,
+					wg.lists["received"].Vertical().Length(len(lines)).ListElement(le).Fn,
 				).Fn).Fn).Fn,
 			).Fn(gtx)
 	}
@@ -33,7 +40,7 @@ func (wg *WalletGUI) receiveTop() l.Widget {
 							wg.th.Flex().
 								SpaceBetween().
 								Rigid(
-									wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1, wg.Caption("Use this form to request payments. All fields are optional.").Color("DocText").Fn).Fn).Fn).Fn,
+									wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1, wg.Caption("Use this form to request payments. The label and message are optional.").Color("DocText").Fn).Fn).Fn).Fn,
 								).
 								Rigid(
 									wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1, wg.Caption("Label:").Color("DocText").Fn).Fn).Fn).Fn,
@@ -49,7 +56,7 @@ func (wg *WalletGUI) receiveTop() l.Widget {
 							Rigid(
 								wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1,
 									// wg.Caption("0.00000 DUO/kb").Color("DocText")
-									wg.inputs["receiveAmount"].Fn).Fn).Fn).Fn,
+									wg.inputs["receiveLabel"].Fn).Fn).Fn).Fn,
 							).Fn,
 					).Fn,
 				).Rigid(
@@ -57,12 +64,12 @@ func (wg *WalletGUI) receiveTop() l.Widget {
 						wg.th.Flex().
 							SpaceBetween().
 							Rigid(
-								wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1, wg.Caption("Amount:").Color("DocText").Fn).Fn).Fn).Fn,
+								wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1, wg.Caption(fmt.Sprintf("Amount (%s):", wg.amountUnit())).Color("DocText").Fn).Fn).Fn).Fn,
 							).
 							Rigid(
 								wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1,
 									// wg.Caption("0.00000 DUO/kb").Color("DocText")
-									wg.inputs["receiveLabel"].Fn).Fn).Fn).Fn,
+									wg.inputs["receiveAmount"].Fn).Fn).Fn).Fn,
 							).Fn,
 					).Fn,
 				).Rigid(
@@ -78,24 +85,152 @@ func (wg *WalletGUI) receiveTop() l.Widget {
 									wg.inputs["receiveMessage"].Fn).Fn).Fn).Fn,
 							).Fn,
 					).Fn,
+				).Rigid(
+					wg.Inset(0.25,
+						wg.th.Flex().
+							SpaceBetween().
+							Rigid(
+								wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1, wg.Caption("Expires after (hours, 0 never expires):").Color("DocText").Fn).Fn).Fn).Fn,
+							).
+							Rigid(
+								wg.Inset(0.0, wg.Fill("DocBg", wg.Inset(0.1,
+									wg.inputs["receiveExpiry"].Fn).Fn).Fn).Fn,
+							).Fn,
+					).Fn,
 				).Rigid(
 					wg.Inset(0.25,
 						wg.th.Flex().
 							SpaceBetween().
 							Rigid(
 								wg.Inset(0.25,
-									wg.buttonText(wg.clickables["receiveCreateNewAddress"], "Create new receiving address", wg.Send),
+									wg.buttonText(wg.clickables["receiveCreateNewAddress"], "Create payment request", wg.CreatePaymentRequest),
 								).Fn,
 							).
 							Rigid(
 								wg.Inset(0.25,
-									wg.buttonText(wg.clickables["receiveClear"], "Clear", wg.ClearAllAddresses),
+									wg.buttonText(wg.clickables["receiveClear"], "Clear", wg.clearReceiveInputs),
 								).Fn,
 							).
 						Fn,
 					).Fn,
+				).Rigid(
+					wg.requestURIWidget(),
 				).Fn,
 			).Fn,
 		).Fn,
 	).Fn
 }
+
+// paymentRequestWidgets lists the payment requests, newest first
+func (wg *WalletGUI) paymentRequestWidgets() (out []l.Widget) {
+	requests := wg.State.PaymentRequests()
+	if len(requests) == 0 {
+		return []l.Widget{wg.privacyLine("no payment requests, requests are marked fulfilled once the amount is received in a confirmed transaction")}
+	}
+	for i := len(requests) - 1; i >= 0; i-- {
+		r := &requests[i]
+		txt := fmt.Sprintf("%s %s to %s, %s", r.Status, wg.formatAmount(r.Amount), r.Address,
+			time.Unix(r.Created, 0).Format("2006-01-02 15:04"))
+		if r.Label != "" {
+			txt = r.Label + ": " + txt
+		}
+		switch {
+		case r.Status == "fulfilled":
+			txt += fmt.Sprintf(", paid %s", time.Unix(r.Fulfilled, 0).Format("2006-01-02 15:04"))
+		case r.Expires != 0:
+			txt += fmt.Sprintf(", expires %s", time.Unix(r.Expires, 0).Format("2006-01-02 15:04"))
+		}
+		if r.Status != "fulfilled" && r.Received > 0 {
+			txt += fmt.Sprintf(", %s received", wg.formatAmount(r.Received))
+		}
+		if paid := wg.State.UnconfirmedPaid(r.Address); r.Status == "pending" && paid > 0 {
+			txt += fmt.Sprintf(", %s paid awaiting confirmation", wg.formatAmount(paid))
+		}
+		out = append(out,
+			wg.th.Flex().
+				Rigid(wg.paymentRequestQRButton(r)).
+				Rigid(wg.paymentRequestCopyButton(r)).
+				Rigid(wg.verifyOnDeviceButton(r.Address)).
+				Flexed(1, wg.privacyLine(txt)).
+				Fn,
+		)
+	}
+	return
+}
+
+// CreatePaymentRequest asks the wallet to track a request for the amount in the receive form, paid to a new address
+func (wg *WalletGUI) CreatePaymentRequest() {
+	if wg.WalletClient == nil {
+		wg.toasts.AddToast("Payment request", "not connected to the wallet", "Danger")
+		return
+	}
+	amount, err := wg.parseAmount(wg.inputs["receiveAmount"].GetText())
+	if err != nil || amount <= 0 {
+		wg.toasts.AddToast("Payment request", "enter the amount to request", "Danger")
+		return
+	}
+	hours := 24.0
+	if txt := strings.TrimSpace(wg.inputs["receiveExpiry"].GetText()); txt != "" {
+		if hours, err = strconv.ParseFloat(txt, 64); err != nil || hours < 0 {
+			wg.toasts.AddToast("Payment request", "the expiry must be a number of hours", "Danger")
+			return
+		}
+	}
+	label, message := wg.inputs["receiveLabel"].GetText(), wg.inputs["receiveMessage"].GetText()
+	go func() {
+		r, err := wg.WalletClient.CreatePaymentRequest(amount, label, message, time.Duration(hours*float64(time.Hour)))
+		if Check(err) {
+			wg.toasts.AddToast("Payment request", err.Error(), "Danger")
+			return
+		}
+		wg.addToAddressBook(r.Address, r.Label, true)
+		wg.updatePaymentRequests()
+		wg.toasts.AddToast("Payment request", "send "+wg.formatAmount(r.Amount)+" to "+r.Address, "Success")
+		// the QR code of the new request is shown straight away for the payer to scan, and its URI stays in the form
+		if uri, err := wg.paymentRequestURI(r); !Check(err) {
+			wg.State.SetRequestURI(uri)
+			wg.showPaymentQR("Payment request", uri)
+		}
+		wg.invalidate <- struct{}{}
+	}()
+}
+
+// clearReceiveInputs empties the receive form
+func (wg *WalletGUI) clearReceiveInputs() {
+	wg.inputs["receiveLabel"] = wg.th.Input("", "Label", "Primary", "DocText", 32, func(pass string) {})
+	wg.inputs["receiveAmount"] = wg.th.Input("", "Amount", "Primary", "DocText", 32, func(pass string) {})
+	wg.inputs["receiveMessage"] = wg.th.Input("", "Message", "Primary", "DocText", 32, func(pass string) {})
+	wg.inputs["receiveExpiry"] = wg.th.Input("24", "Hours", "Primary", "DocText", 32, func(pass string) {})
+	wg.State.SetRequestURI("")
+}
+
+// updatePaymentRequests fetches the payment requests from the wallet and announces the ones that have just been paid
+func (wg *WalletGUI) updatePaymentRequests() {
+	requests, err := wg.WalletClient.ListPaymentRequests()
+	if Check(err) {
+		return
+	}
+	// labelled requests made before the address book existed, or by another client of the wallet, are added to it too
+	for i := range requests {
+		wg.addToAddressBook(requests[i].Address, requests[i].Label, true)
+	}
+	for _, r := range wg.State.SetPaymentRequests(requests) {
+		txt := wg.formatAmount(r.Amount) + " received on " + r.Address
+		if r.Label != "" {
+			txt = r.Label + ": " + txt
+		}
+		go wg.toasts.AddToast("Payment received", txt, "Success")
+	}
+}
+
+// trackRequestPayment follows the payments to the addresses of pending payment requests in the transactions the wallet
+// notifies, so a request shows it is paid as soon as the payment is seen rather than only once it is mined
+func (wg *WalletGUI) trackRequestPayment(tx *btcjson.ListTransactionsResult) {
+	if tx.Category != "receive" {
+		return
+	}
+	if wg.State.TrackRequestPayment(tx.Address, fmt.Sprintf("%s:%d", tx.TxID, tx.Vout), tx.Amount,
+		tx.Confirmations > 0) {
+		wg.invalidate <- struct{}{}
+	}
+}
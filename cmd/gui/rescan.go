@@ -0,0 +1,81 @@
+package gui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	l "gioui.org/layout"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// RescanPage rescans the chain for the wallet's transactions from a chosen height, showing the progress the wallet
+// reports as the rescan runs
+func (wg *WalletGUI) RescanPage() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		lines := wg.rescanWidgets()
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("DocBg",
+				wg.lists["rescan"].
+					Vertical().
+					Length(len(lines)).
+					ListElement(le).
+					Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) rescanWidgets() (out []l.Widget) {
+	out = []l.Widget{
+		wg.privacyHeading("rescan the chain"),
+		wg.privacyLine("Looks for the transactions of the wallet's addresses in the blocks from the given height to " +
+			"the tip of the chain, for when transactions are missing from the history, such as after restoring a " +
+			"wallet or importing keys."),
+		wg.Inset(0.25, wg.inputs["rescanHeight"].Fn).Fn,
+		wg.Inset(0.25, wg.buttonText(wg.clickables["rescanStart"], "Rescan", wg.rescanFromHeight)).Fn,
+	}
+	if wg.WalletNotifier == nil {
+		out = append(out, wg.privacyLine("the progress of rescans is not shown while the wallet's notifications "+
+			"are not connected"))
+	}
+	p := wg.State.RescanProgress()
+	if p == nil {
+		return
+	}
+	status := fmt.Sprintf("rescanning block %d of %d, %.1f%% done, %d transactions found", p.Height, p.EndHeight,
+		p.Percent, p.Found)
+	if p.Finished {
+		status = fmt.Sprintf("rescanned blocks %d to %d, %d transactions found", p.StartHeight, p.Height, p.Found)
+	}
+	return append(out,
+		wg.privacyHeading("progress"),
+		wg.Inset(0.25, wg.th.ProgressBar().Color("Primary").SetProgress(int(p.Percent)).Fn).Fn,
+		wg.privacyLine(status),
+	)
+}
+
+// rescanFromHeight starts a rescan of the chain from the height entered in the form
+func (wg *WalletGUI) rescanFromHeight() {
+	height, err := strconv.ParseInt(strings.TrimSpace(wg.inputs["rescanHeight"].GetText()), 10, 32)
+	best := wg.State.BestBlockHeight()
+	if err != nil || height < 0 || int(height) > best {
+		go wg.toasts.AddToast("Rescan", fmt.Sprintf("enter a height from 0 to %d", best), "Danger")
+		return
+	}
+	go func() {
+		if err := wg.WalletClient.RescanFromHeight(int32(height)); Check(err) {
+			wg.toasts.AddToast("Rescan", err.Error(), "Danger")
+			return
+		}
+		// the bar starts empty until the wallet reports its first progress
+		wg.State.SetRescanProgress(btcjson.NewWalletRescanProgressNtfn(int32(height), int32(height), int32(best), 0,
+			0, false))
+		wg.toasts.AddToast("Rescan", fmt.Sprintf("rescanning from block %d", height), "Info")
+		wg.invalidate <- struct{}{}
+	}()
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/p9c/pod/app/save"
 	"github.com/p9c/pod/pkg/util/interrupt"
-	"github.com/p9c/pod/pkg/util/logi"
 	"github.com/p9c/pod/pkg/util/logi/consume"
 )
 
@@ -43,11 +42,7 @@ func (wg *WalletGUI) Runner() (err error) {
 						"--pipelog", "shell"}
 					// args = apputil.PrependForWindows(args)
 					wg.runnerQuit = make(chan struct{})
-					wg.Shell = consume.Log(wg.runnerQuit, func(ent *logi.Entry) (err error) {
-						// TODO: make a log view for this
-						// Debug(ent.Level, ent.Time, ent.Text, ent.CodeLocation)
-						return
-					}, func(pkg string) (out bool) {
+					wg.Shell = consume.Log(wg.runnerQuit, wg.logHandler("node"), func(pkg string) (out bool) {
 						return false
 					}, args...)
 					consume.Start(wg.Shell)
@@ -93,11 +88,7 @@ func (wg *WalletGUI) Runner() (err error) {
 					args := []string{os.Args[0], "-D", *wg.cx.Config.DataDir, "--pipelog", "kopach"}
 					// args = apputil.PrependForWindows(args)
 					wg.minerQuit = make(chan struct{})
-					wg.Miner = consume.Log(wg.minerQuit, func(ent *logi.Entry) (err error) {
-						// TODO: make a log view for this
-						// Debug(ent.Level, ent.Time, ent.Text, ent.CodeLocation)
-						return
-					}, func(pkg string) (out bool) {
+					wg.Miner = consume.Log(wg.minerQuit, wg.logHandler("miner"), func(pkg string) (out bool) {
 						return false
 					}, args...)
 					consume.Start(wg.Miner)
@@ -132,3 +123,23 @@ func (wg *WalletGUI) Runner() (err error) {
 	// }
 	return nil
 }
+
+// RestartWorkers restarts the shell and the miner, if they are running, so changed settings take effect. The commands
+// are sent in order from one goroutine so the miner is only started again once the shell it connects to is back up.
+func (wg *WalletGUI) RestartWorkers() {
+	running, mining := wg.running, wg.mining
+	if !running {
+		Debug("workers not running, settings take effect when they are started")
+		return
+	}
+	go func() {
+		if mining {
+			wg.MinerRunCommandChan <- "stop"
+		}
+		wg.ShellRunCommandChan <- "stop"
+		wg.ShellRunCommandChan <- "run"
+		if mining {
+			wg.MinerRunCommandChan <- "run"
+		}
+	}()
+}
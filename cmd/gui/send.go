@@ -1,13 +1,14 @@
 package gui
 
 import (
+	"fmt"
 	l "gioui.org/layout"
 	"gioui.org/text"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/coding/base58"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util"
 	"golang.org/x/exp/shiny/materialdesign/icons"
-	"strconv"
 
 	"github.com/p9c/pod/pkg/gui/p9"
 )
@@ -17,6 +18,7 @@ type SendAddress struct {
 	LabelInput        *p9.Input
 	AddressBookBtn    *p9.Clickable
 	PasteClipboardBtn *p9.Clickable
+	ScanQRBtn         *p9.Clickable
 	ClearBtn          *p9.Clickable
 	AmountInput       *p9.Input
 	// AmountInput       *counter.Counter
@@ -28,17 +30,25 @@ func (wg *WalletGUI) SendPage() l.Widget {
 	// le := func(gtx l.Context, index int) l.Dimensions {
 	// 	return wg.singleSendAddress(gtx, index)
 	// }
-	return wg.th.VFlex().
-		Flexed(1,
-			// wg.Inset(0.25,
-			func(gtx l.Context) l.Dimensions {
-				return wg.lists["send"].Vertical().Length(len(wg.sendAddresses)).ListElement(wg.singleSendAddress).Fn(gtx)
-			},
-			// ).Fn,
-		).
-		Rigid(
-			wg.sendFooter(),
-		).Fn
+	return func(gtx l.Context) l.Dimensions {
+		flex := wg.th.VFlex().
+			Rigid(wg.WatchOnlyBanner()).
+			Flexed(1,
+				// wg.Inset(0.25,
+				func(gtx l.Context) l.Dimensions {
+					return wg.lists["send"].Vertical().Length(len(wg.sendAddresses)).ListElement(wg.singleSendAddress).Fn(gtx)
+				},
+				// ).Fn,
+			)
+		// the coin control panel shares the page with the recipients while it is open
+		if wg.bools["coinControl"].GetValue() {
+			flex = flex.Flexed(1, wg.coinControlPanel())
+		}
+		return flex.
+			Rigid(
+				wg.sendFooter(),
+			).Fn(gtx)
+	}
 }
 
 func (wg *WalletGUI) CreateSendAddressItem() {
@@ -66,6 +76,7 @@ func (wg *WalletGUI) CreateSendAddressItem() {
 			// },
 			AddressBookBtn:    new(p9.Clickable),
 			PasteClipboardBtn: new(p9.Clickable),
+			ScanQRBtn:         new(p9.Clickable),
 			ClearBtn:          new(p9.Clickable),
 			SubtractFee:       new(p9.Bool),
 			AllAvailableBtn:   new(p9.Clickable),
@@ -104,79 +115,126 @@ func (wg *WalletGUI) checkSendItem(address, amount string) (check bool) {
 }
 
 func (wg *WalletGUI) Send() {
-	// ToDo Send RPC command
 	// TODO: yes, do one like the runner in run.go
+	if wg.State.WatchOnly() {
+		// a watch-only wallet paired with a hardware wallet sends transactions the device signs
+		if wg.pairedDevice() != "" && wg.WalletClient != nil {
+			wg.sendWithDevice()
+			return
+		}
+		wg.watchOnlyRefusal()
+		return
+	}
 	if wg.WalletClient != nil {
+		// a send from outputs picked in the coin control panel pays all of the recipients in one transaction
+		if len(wg.selectedCoins()) > 0 {
+			plan, err := wg.coinPlan()
+			if err != nil {
+				go wg.toasts.AddToast("Coin control", err.Error(), "Danger")
+				return
+			}
+			wg.dialog.ShowDialog("Confirm send", "Info", wg.coinControlConfirmation(plan))()
+			return
+		}
 		if len(wg.sendAddresses) < 2 {
 			if wg.checkSendItem(wg.sendAddresses[0].AddressInput.GetText(), wg.sendAddresses[0].AmountInput.GetText()) {
 				address, err := util.DecodeAddress(wg.sendAddresses[0].AddressInput.GetText(), nil)
 				if err != nil {
+					go wg.toasts.AddToast("Address error", err.Error(), "Danger")
+					return
 				}
-				var h *chainhash.Hash
-				amountFloat, err := strconv.ParseFloat(wg.sendAddresses[0].AmountInput.GetText(), 32)
+				amount, err := wg.parseAmount(wg.sendAddresses[0].AmountInput.GetText())
 				if err != nil {
+					go wg.toasts.AddToast("Amount error", err.Error(), "Danger")
+					return
 				}
-				amount, err := util.NewAmount(amountFloat)
+				feeRate, _, err := wg.feeRate()
 				if err != nil {
+					go wg.toasts.AddToast("Fee error", err.Error(), "Danger")
+					return
 				}
-				if h, err = wg.ChainClient.SendToAddress(address, amount); Check(err) {
-					go wg.toasts.AddToast("TxID", h.String(), "Danger")
+				// sendtoaddress spends from the default account with one confirmation, so preview the same
+				var preview *btcjson.PreviewSendResult
+				if preview, err = wg.WalletClient.PreviewSendFeeRate("default",
+					map[util.Address]util.Amount{address: amount}, 1, feeRate); Check(err) {
+					go wg.toasts.AddToast("Send error", err.Error(), "Danger")
+					return
 				}
-				// TODO: this is the txid hash
+				label := wg.sendAddresses[0].LabelInput.GetText()
+				wg.dialog.ShowDialog("Confirm send", "Info",
+					wg.sendConfirmation(address, label, amount, feeRate, preview))()
 			}
 		} else {
-			//		for _, sendAddress := range wg.sendAddresses {
-			//			fmt.Println(sendAddress.AmountInput.GetText())
-			//			address, err := util.DecodeAddress("sendAddress.AmountInput.GetText()", nil)
-			//			if err != nil {
-			//			}
-			//			var h *chainhash.Hash
-			//			if h, err = wg.ChainClient.SendToAddress(address, 1); Check(err) {
-			//			}
-			//			// TODO: this is the txid hash
-			//			_ = h
-			//		}
+			// several recipients are paid together in one sendmany transaction
+			wg.sendBatch()
 		}
 	}
 }
 
+// sendConfirmation shows the fee of a previewed send at the chosen fee rate before it is made, and adds the address to the address book under
+// its label once it is sent. When the transaction spends unconfirmed change
+// the fee rate of the whole unconfirmed package is shown too, as that is what decides when the parents confirm.
+func (wg *WalletGUI) sendConfirmation(address util.Address, label string, amount, feeRate util.Amount,
+	preview *btcjson.PreviewSendResult) func(gtx l.Context) l.Dimensions {
+	lines := []string{
+		fmt.Sprintf("Pay %s to %s", wg.withFiat(wg.formatAmount(amount.ToDUO()), amount.ToDUO()),
+			address.EncodeAddress()),
+		fmt.Sprintf("Fee %s (%s/kB)", wg.formatAmount(preview.Fee), wg.formatAmount(preview.FeeRate)),
+	}
+	if preview.AncestorSize > 0 {
+		lines = append(lines,
+			fmt.Sprintf("Spends unconfirmed change, effective package fee rate %s/kB",
+				wg.formatAmount(preview.PackageFeeRate)),
+		)
+		if preview.CPFP {
+			lines = append(lines, "The fee includes extra to get the unconfirmed parent transactions mined")
+		}
+	}
+	flex := wg.th.VFlex()
+	for i := range lines {
+		flex = flex.Rigid(
+			wg.Inset(0.25,
+				wg.Body2(lines[i]).Color("PanelText").Fn,
+			).Fn,
+		)
+	}
+	return flex.Rigid(
+		wg.Inset(0.25,
+			wg.buttonText(wg.clickables["sendConfirm"], "Send", func() {
+				wg.dialog.Close()
+				// large sends ask for the passphrase even while the wallet is unlocked
+				highRisk := amount.ToDUO() > *wg.cx.Config.ReauthThreshold
+				wg.authorize("Enter the wallet passphrase to send", highRisk, func() {
+					var h *chainhash.Hash
+					var err error
+					if h, err = wg.WalletClient.SendToAddressFeeRate(address, amount, feeRate); Check(err) {
+						wg.toasts.AddToast("Send error", err.Error(), "Danger")
+						return
+					}
+					wg.addToAddressBook(address.EncodeAddress(), label, false)
+					wg.toasts.AddToast("TxID", h.String(), "Success")
+				})
+			}),
+		).Fn,
+	).Fn
+}
+
 func (wg *WalletGUI) sendFooter() l.Widget {
 	return wg.th.VFlex().
 		Rigid(
 			wg.Inset(0.25,
 				wg.th.Flex().
 					SpaceBetween().
-					Rigid(
-						wg.Inset(0.0, wg.Fill("DocBg",
-							wg.Inset(0.5,
-								wg.Caption("Transaction Fee:").
-									Color("DocText").Fn,
-							).Fn,
-						).Fn,
-						).Fn,
-					).
-					Rigid(
+					Flexed(1,
 						wg.Inset(0.0, wg.Fill("DocBg",
-							wg.Inset(0.5,
-								wg.Caption("0.00000 DUO/kb").
-									Color("DocText").Fn,
-							).Fn,
+							wg.Inset(0.25, wg.feeSelector()).Fn,
 						).Fn,
 						).Fn,
 					).
 					Rigid(
 						wg.Inset(0.0, wg.Fill("DocBg",
 							wg.Inset(0.5,
-								wg.Caption("net").
-									Color("DocText").Fn,
-							).Fn,
-						).Fn,
-						).Fn,
-					).
-					Flexed(1,
-						wg.Inset(0.0, wg.Fill("DocBg",
-							wg.Inset(0.5,
-								wg.Caption("Balance 0.00000 DUO").
+								wg.Caption("Balance "+wg.withFiat(wg.formatAmount(wg.State.balance), wg.State.balance)).
 									Color("DocText").Fn,
 							).Fn,
 						).Fn,
@@ -202,6 +260,25 @@ func (wg *WalletGUI) sendFooter() l.Widget {
 						wg.buttonText(wg.clickables["sendAddRecipient"],
 							"Add Recipient", wg.CreateSendAddressItem)).Fn,
 				).
+				Rigid(
+					wg.Inset(0.25,
+						wg.buttonText(wg.clickables["sendImport"],
+							"Import CSV", wg.importBatchDialog)).Fn,
+				).
+				Rigid(
+					wg.Inset(0.25,
+						wg.buttonText(wg.clickables["sendCoinControl"],
+							"Coin control", func() {
+								open := !wg.bools["coinControl"].GetValue()
+								wg.bools["coinControl"].Value(open)
+								if open && wg.WalletClient != nil {
+									go func() {
+										wg.updateCoins()
+										wg.invalidate <- struct{}{}
+									}()
+								}
+							})).Fn,
+				).
 				Flexed(1,
 					wg.Inset(0.25,
 						wg.Caption("Balance:0.00000000").Alignment(text.End).Color("DocText").Fn).Fn,
@@ -229,12 +306,21 @@ func (wg *WalletGUI) singleSendAddress(gtx l.Context, i int) l.Dimensions {
 										Rigid(
 											// wg.sendButton(wg.sendAddresses[index].AddressBookBtn, "AddressBook", func() {}),
 											// wg.sendIconButton("settings", 2, &icons.ActionBook),
-											wg.buttonIcon(wg.sendAddresses[i].AddressBookBtn, "settings", &icons.ActionBook),
+											wg.buttonIconClick(wg.sendAddresses[i].AddressBookBtn, &icons.ActionBook, func() {
+												wg.addressBookPicker(i)
+											}),
 										).
 										Rigid(
 											// wg.sendButton(wg.sendAddresses[index].PasteClipboardBtn, "Paste", func() {}),
 											// wg.sendIconButton("settings", 2, &icons.ActionSettings),
-											wg.buttonIcon(wg.sendAddresses[i].PasteClipboardBtn, "settings", &icons.ActionSettings),
+											wg.buttonIconClick(wg.sendAddresses[i].PasteClipboardBtn, &icons.ContentContentPaste, func() {
+												wg.pastePaymentURI(i)
+											}),
+										).
+										Rigid(
+											wg.buttonIconClick(wg.sendAddresses[i].ScanQRBtn, &icons.ImageCropFree, func() {
+												wg.scanQRImage(i)
+											}),
 										).
 										Rigid(
 											// wg.sendButton(wg.sendAddresses[index].ClearBtn, "Close", func() {}),
@@ -262,7 +348,7 @@ func (wg *WalletGUI) singleSendAddress(gtx l.Context, i int) l.Dimensions {
 						wg.Inset(0.25,
 							wg.th.Flex().
 								Rigid(
-									wg.rowLabel("Amount:"),
+									wg.rowLabel(fmt.Sprintf("Amount (%s):", wg.amountUnit())),
 								).
 								Rigid(
 									wg.Flex().
@@ -271,7 +357,13 @@ func (wg *WalletGUI) singleSendAddress(gtx l.Context, i int) l.Dimensions {
 										).
 										Rigid(
 											wg.Inset(0.25,
-												wg.buttonText(wg.sendAddresses[i].PasteClipboardBtn,
+												wg.Caption(wg.fiatInput(wg.sendAddresses[i].AmountInput.GetText())).
+													Color("Hint").Fn,
+											).Fn,
+										).
+										Rigid(
+											wg.Inset(0.25,
+												wg.buttonText(wg.keyedClickable(fmt.Sprint("sendSubtractFee", i)),
 													"Subtract fee from amount", func() {})).Fn,
 										).
 										Rigid(
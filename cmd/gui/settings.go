@@ -0,0 +1,90 @@
+package gui
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+
+	rpcclient "github.com/p9c/pod/pkg/rpc/client"
+	"github.com/p9c/pod/pkg/util/lang"
+	"github.com/p9c/pod/pkg/util/logi/consume"
+)
+
+var errNotConnected = errors.New("not connected to the node")
+
+// applyLogLevel sets the log level of the running workers to the one in the settings
+func (wg *WalletGUI) applyLogLevel() error {
+	level := *wg.cx.Config.LogLevel
+	if wg.running {
+		consume.SetLevel(wg.Shell, level)
+	}
+	if wg.mining {
+		consume.SetLevel(wg.Miner, level)
+	}
+	return nil
+}
+
+// applyAddPeers adds the peers in the settings to the running node as persistent peers, those it is already connected
+// to are left as they are
+func (wg *WalletGUI) applyAddPeers() (err error) {
+	if !wg.running {
+		return
+	}
+	if wg.ChainClient == nil {
+		return errNotConnected
+	}
+	for _, addr := range *wg.cx.Config.AddPeers {
+		if err = wg.ChainClient.AddNode(addr, rpcclient.ANAdd); err != nil &&
+			!strings.Contains(err.Error(), "already connected") {
+			return
+		}
+	}
+	return nil
+}
+
+// applyGenerate starts or stops the miner to match the generate setting
+func (wg *WalletGUI) applyGenerate() error {
+	switch {
+	case *wg.cx.Config.Generate && !wg.mining:
+		wg.MinerRunCommandChan <- "run"
+	case !*wg.cx.Config.Generate && wg.mining:
+		wg.MinerRunCommandChan <- "stop"
+	}
+	return nil
+}
+
+// applyGenThreads restarts the miner, if it is running, with the number of threads in the settings
+func (wg *WalletGUI) applyGenThreads() error {
+	if wg.mining {
+		wg.MinerRunCommandChan <- "restart"
+	}
+	return nil
+}
+
+// applyLanguage switches the interface to the language in the settings
+func (wg *WalletGUI) applyLanguage() error {
+	lang.SetLanguage(*wg.cx.Config.Language)
+	return nil
+}
+
+// restartNode restarts the node and wallet with the restart RPC so changed settings take effect, keeping the worker
+// and its log running as the process starts itself again in place. Where that is not possible the workers are stopped
+// and started again instead
+func (wg *WalletGUI) restartNode() {
+	// on windows the restarted process is a new one that is not connected to the worker
+	if runtime.GOOS == "windows" || !wg.running || wg.ChainClient == nil {
+		wg.RestartWorkers()
+		return
+	}
+	mining := wg.mining
+	go func() {
+		if err := wg.ChainClient.Restart(); Check(err) {
+			wg.toasts.AddToast("Restart", err.Error(), "Danger")
+			return
+		}
+		if mining {
+			wg.MinerRunCommandChan <- "restart"
+		}
+		wg.toasts.AddToast("Restart", "the node and wallet are restarting with the changed settings", "Info")
+	}()
+}
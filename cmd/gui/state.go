@@ -9,6 +9,7 @@ import (
 	"github.com/kofoworola/godate"
 
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/gui/p9"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 )
@@ -20,6 +21,9 @@ type State struct {
 	bestBlockHash      *chainhash.Hash
 	balance            float64
 	balanceUnconfirmed float64
+	balanceImmature    float64
+	balanceLocked      float64
+	balanceTotal       float64
 	txs                []tx
 	lastTxs            []btcjson.ListTransactionsResult
 	lastTimeStrings    []string
@@ -28,6 +32,32 @@ type State struct {
 	txPage             int
 	allTxs             []btcjson.ListTransactionsResult
 	allTimeStrings     []string
+	announcements      []string
+	conflicted         map[string]struct{}
+	noticed            map[string]struct{}
+	privacyReport      *btcjson.PrivacyReportResult
+	multisigAccounts   []btcjson.MultisigAccountResult
+	multisigPSBTs      []btcjson.MultisigPSBTResult
+	unspent            []btcjson.ListUnspentResult
+	lockedOutpoints    []*wire.OutPoint
+	keyPreview         *KeyPreview
+	syncStatus         *btcjson.GetSyncStatusResult
+	paymentRequests    []btcjson.PaymentRequestResult
+	fulfilledRequests  map[string]struct{}
+	unconfirmedPaid    map[string]map[string]float64
+	requestURI         string
+	reconciliation     *btcjson.GetReconciliationResult
+	txDetail           *TxDetail
+	feeEstimates       map[int64]float64
+	watchOnly          bool
+	signingDevice      string
+	deviceFingerprint  string
+	rescanProgress     *btcjson.WalletRescanProgressNtfn
+	dbEncrypted        bool
+	peers              []btcjson.GetPeerInfoResult
+	bannedPeers        []btcjson.ListBannedResult
+	explorerView       *explorerView
+	explorerHistory    []*explorerView
 }
 
 type tx struct {
@@ -137,16 +167,387 @@ func (s *State) SetBestBlockHash(h *chainhash.Hash) {
 	s.bestBlockHash = h
 }
 
-func (s *State) SetBalance(total float64) {
+// SetBalances stores the balances of a single getwalletbalances snapshot together, so the overview never shows a mix of
+// balances taken before and after a block or a payment arrived
+func (s *State) SetBalances(b *btcjson.GetWalletBalancesResult) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	s.lastUpdated = time.Now()
-	s.balance = total
+	s.balance = b.Confirming + b.Confirmed
+	s.balanceUnconfirmed = b.Unconfirmed
+	s.balanceImmature = b.Immature
+	s.balanceLocked = b.Locked
+	s.balanceTotal = b.Total
 }
 
-func (s *State) SetBalanceUnconfirmed(unconfirmed float64) {
+// Announcements returns the active network announcements reported by the node
+func (s *State) Announcements() []string {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	s.lastUpdated = time.Now()
-	s.balanceUnconfirmed = unconfirmed
+	return s.announcements
+}
+
+// SetAnnouncements sets the active network announcements reported by the node
+func (s *State) SetAnnouncements(announcements []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.announcements = announcements
+}
+
+// NewConflicts returns the ids of the conflicted transactions in txs that have not been seen before
+func (s *State) NewConflicts(txs []btcjson.ListTransactionsResult) (txids []string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conflicted == nil {
+		s.conflicted = make(map[string]struct{})
+	}
+	for i := range txs {
+		if !txs[i].Conflicted {
+			continue
+		}
+		if _, ok := s.conflicted[txs[i].TxID]; ok {
+			continue
+		}
+		s.conflicted[txs[i].TxID] = struct{}{}
+		txids = append(txids, txs[i].TxID)
+	}
+	return
+}
+
+// FirstNotice returns whether the event with the key is seen for the first time, so it is only announced once
+func (s *State) FirstNotice(key string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.noticed == nil {
+		s.noticed = make(map[string]struct{})
+	}
+	if _, ok := s.noticed[key]; ok {
+		return false
+	}
+	s.noticed[key] = struct{}{}
+	return true
+}
+
+// PrivacyReport returns the last privacy report fetched from the wallet
+func (s *State) PrivacyReport() *btcjson.PrivacyReportResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.privacyReport
+}
+
+// SetPrivacyReport stores the privacy report fetched from the wallet
+func (s *State) SetPrivacyReport(report *btcjson.PrivacyReportResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.privacyReport = report
+}
+
+// Multisig returns the last multisig accounts and pending partially signed transactions fetched from the wallet
+func (s *State) Multisig() ([]btcjson.MultisigAccountResult, []btcjson.MultisigPSBTResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.multisigAccounts, s.multisigPSBTs
+}
+
+// SetMultisig stores the multisig accounts and pending partially signed transactions fetched from the wallet
+func (s *State) SetMultisig(accts []btcjson.MultisigAccountResult, pending []btcjson.MultisigPSBTResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.multisigAccounts, s.multisigPSBTs = accts, pending
+}
+
+// Coins returns the last unspent and locked outputs fetched from the wallet for the coin control page
+func (s *State) Coins() ([]btcjson.ListUnspentResult, []*wire.OutPoint) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.unspent, s.lockedOutpoints
+}
+
+// SetCoins stores the unspent and locked outputs fetched from the wallet
+func (s *State) SetCoins(unspent []btcjson.ListUnspentResult, locked []*wire.OutPoint) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unspent, s.lockedOutpoints = unspent, locked
+}
+
+// KeyPreview returns the private key being imported and the coins found for it
+func (s *State) KeyPreview() *KeyPreview {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.keyPreview
+}
+
+// SetKeyPreview stores the private key being imported and the coins found for it
+func (s *State) SetKeyPreview(preview *KeyPreview) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keyPreview = preview
+}
+
+// SyncStatus returns the last sync status fetched from the node
+func (s *State) SyncStatus() *btcjson.GetSyncStatusResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.syncStatus
+}
+
+// SetSyncStatus stores the sync status fetched from the node
+func (s *State) SetSyncStatus(status *btcjson.GetSyncStatusResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.syncStatus = status
+}
+
+// PaymentRequests returns the payment requests last fetched from the wallet
+func (s *State) PaymentRequests() []btcjson.PaymentRequestResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.paymentRequests
+}
+
+// SetPaymentRequests stores the payment requests fetched from the wallet and returns the ones that were fulfilled since
+// the last update. Requests already fulfilled when they are first fetched are not returned.
+func (s *State) SetPaymentRequests(requests []btcjson.PaymentRequestResult) (fulfilled []btcjson.PaymentRequestResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	first := s.fulfilledRequests == nil
+	if first {
+		s.fulfilledRequests = make(map[string]struct{})
+	}
+	for i := range requests {
+		if requests[i].Status != "fulfilled" {
+			continue
+		}
+		if _, ok := s.fulfilledRequests[requests[i].Address]; ok {
+			continue
+		}
+		s.fulfilledRequests[requests[i].Address] = struct{}{}
+		if !first {
+			fulfilled = append(fulfilled, requests[i])
+		}
+	}
+	s.paymentRequests = requests
+	return
+}
+
+// RequestURI returns the payment URI of the last payment request created in the receive form
+func (s *State) RequestURI() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.requestURI
+}
+
+// SetRequestURI stores the payment URI of the payment request just created in the receive form
+func (s *State) SetRequestURI(uri string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.requestURI = uri
+}
+
+// TrackRequestPayment records an output paying the address of a pending payment request, keyed by its outpoint, while
+// it is unconfirmed, and forgets it once it is confirmed, as the wallet then counts it in the amount received. It
+// returns whether the address is that of a pending request.
+func (s *State) TrackRequestPayment(address, outpoint string, amount float64, confirmed bool) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	pending := false
+	for i := range s.paymentRequests {
+		if s.paymentRequests[i].Address == address && s.paymentRequests[i].Status == "pending" {
+			pending = true
+			break
+		}
+	}
+	if confirmed {
+		delete(s.unconfirmedPaid[address], outpoint)
+		return pending
+	}
+	if !pending {
+		return false
+	}
+	if s.unconfirmedPaid == nil {
+		s.unconfirmedPaid = make(map[string]map[string]float64)
+	}
+	if s.unconfirmedPaid[address] == nil {
+		s.unconfirmedPaid[address] = make(map[string]float64)
+	}
+	s.unconfirmedPaid[address][outpoint] = amount
+	return true
+}
+
+// UnconfirmedPaid returns the amount paid to the address of a payment request in transactions not yet mined
+func (s *State) UnconfirmedPaid(address string) (amount float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, a := range s.unconfirmedPaid[address] {
+		amount += a
+	}
+	return
+}
+
+// Reconciliation returns the last reconciliation of the wallet history with the chain that found it out of line, or nil
+func (s *State) Reconciliation() *btcjson.GetReconciliationResult {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.reconciliation
+}
+
+// SetReconciliation stores the latest reconciliation of the wallet history if it changed anything, and returns whether
+// it is one that has not been seen before
+func (s *State) SetReconciliation(r *btcjson.GetReconciliationResult) (isNew bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !r.Reconciled || !r.Changed {
+		return false
+	}
+	isNew = s.reconciliation == nil || s.reconciliation.Finished != r.Finished
+	s.reconciliation = r
+	return
+}
+
+// TxDetail returns the transaction shown in the transaction detail dialog, or nil
+func (s *State) TxDetail() *TxDetail {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.txDetail
+}
+
+// SetTxDetail stores the transaction shown in the transaction detail dialog
+func (s *State) SetTxDetail(detail *TxDetail) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.txDetail = detail
+}
+
+// FeeEstimates returns the node's fee rate estimates in DUO per kilobyte keyed by their confirmation target in blocks
+func (s *State) FeeEstimates() map[int64]float64 {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.feeEstimates
+}
+
+// SetFeeEstimates stores the node's fee rate estimates
+func (s *State) SetFeeEstimates(estimates map[int64]float64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.feeEstimates = estimates
+}
+
+// WatchOnly returns whether the wallet is watching-only, holding no private keys
+func (s *State) WatchOnly() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.watchOnly
+}
+
+// SetWatchOnly stores whether the wallet is watching-only
+func (s *State) SetWatchOnly(watchOnly bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.watchOnly = watchOnly
+}
+
+// SigningDevice returns the model and master key fingerprint of the hardware wallet that signs for the wallet, which
+// are empty when no device is paired
+func (s *State) SigningDevice() (model, fingerprint string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.signingDevice, s.deviceFingerprint
+}
+
+// SetSigningDevice stores the hardware wallet paired with the wallet
+func (s *State) SetSigningDevice(model, fingerprint string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.signingDevice, s.deviceFingerprint = model, fingerprint
+}
+
+// DBEncrypted returns whether the whole wallet database is encrypted with the public password
+func (s *State) DBEncrypted() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.dbEncrypted
+}
+
+// SetDBEncrypted stores whether the whole wallet database is encrypted
+func (s *State) SetDBEncrypted(encrypted bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.dbEncrypted = encrypted
+}
+
+// RescanProgress returns the last progress report of a rescan of the wallet, nil when none was started
+func (s *State) RescanProgress() *btcjson.WalletRescanProgressNtfn {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.rescanProgress
+}
+
+// SetRescanProgress stores the progress of a rescan of the wallet
+func (s *State) SetRescanProgress(progress *btcjson.WalletRescanProgressNtfn) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.rescanProgress = progress
+}
+
+// Peers returns the peers the node is connected to and the bans of the node, nil until they are first fetched
+func (s *State) Peers() ([]btcjson.GetPeerInfoResult, []btcjson.ListBannedResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.peers, s.bannedPeers
+}
+
+// SetPeers stores the peers the node is connected to and the bans of the node
+func (s *State) SetPeers(peers []btcjson.GetPeerInfoResult, banned []btcjson.ListBannedResult) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.peers, s.bannedPeers = peers, banned
+}
+
+// ExplorerView returns what the explorer page is showing, nil before anything was looked up
+func (s *State) ExplorerView() *explorerView {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.explorerView
+}
+
+// SetExplorerView shows a block, transaction or address on the explorer page, keeping what was shown before for the
+// back button when push is set
+func (s *State) SetExplorerView(v *explorerView, push bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if push && s.explorerView != nil {
+		s.explorerHistory = append(s.explorerHistory, s.explorerView)
+	}
+	s.explorerView = v
+}
+
+// SetExplorerPage turns to a page of the transactions of the block shown on the explorer page
+func (s *State) SetExplorerPage(page int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.explorerView == nil {
+		return
+	}
+	// the view is copied as the one being replaced may still be in use drawing the page
+	v := *s.explorerView
+	v.page = page
+	s.explorerView = &v
+}
+
+// CanExplorerBack returns whether there is something to go back to on the explorer page
+func (s *State) CanExplorerBack() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.explorerHistory) > 0
+}
+
+// ExplorerBack shows what the explorer page showed before the last lookup
+func (s *State) ExplorerBack() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.explorerHistory) == 0 {
+		return
+	}
+	s.explorerView = s.explorerHistory[len(s.explorerHistory)-1]
+	s.explorerHistory = s.explorerHistory[:len(s.explorerHistory)-1]
 }
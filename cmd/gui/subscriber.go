@@ -2,6 +2,7 @@ package gui
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
@@ -43,8 +44,23 @@ func (wg *WalletGUI) Subscriber() *rpcclient.NotificationHandlers {
 			// what does this actually do
 			Debug(account, balance, confirmed)
 		},
+		OnNewTx: func(account string, details *btcjson.ListTransactionsResult) {
+			wg.notifyTx(details)
+			wg.trackRequestPayment(details)
+		},
 		OnWalletLockState: func(locked bool) {
-			// switch interface to unlock page
+			// the passphrase has to be entered again once the wallet locks itself
+			if locked {
+				wg.unlock.end()
+			}
+		},
+		OnWalletRescanProgress: func(progress *btcjson.WalletRescanProgressNtfn) {
+			wg.State.SetRescanProgress(progress)
+			if progress.Finished {
+				go wg.toasts.AddToast("Rescan finished", fmt.Sprintf("rescanned to block %d, found %d transactions",
+					progress.Height, progress.Found), "Success")
+			}
+			wg.invalidate <- struct{}{}
 		},
 		OnUnknownNotification: func(method string, params []json.RawMessage) {},
 	}
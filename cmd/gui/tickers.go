@@ -16,7 +16,6 @@ import (
 	"github.com/p9c/pod/pkg/gui/p9"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	rpcclient "github.com/p9c/pod/pkg/rpc/client"
-	"github.com/p9c/pod/pkg/util"
 )
 
 func (wg *WalletGUI) updateThingies() (err error) {
@@ -56,6 +55,27 @@ func (wg *WalletGUI) walletClient() (err error) {
 	return
 }
 
+// walletNotifications connects to the websocket of the wallet server for the notifications handled by the Subscriber
+func (wg *WalletGUI) walletNotifications() (err error) {
+	var certs []byte
+	if *wg.cx.Config.TLS && *wg.cx.Config.RPCCert != "" {
+		if certs, err = ioutil.ReadFile(*wg.cx.Config.RPCCert); Check(err) {
+			return
+		}
+	}
+	walletRPC := (*wg.cx.Config.WalletRPCListeners)[0]
+	wg.WalletNotifier, err = rpcclient.New(&rpcclient.ConnConfig{
+		Host:     walletRPC,
+		Endpoint: "ws",
+		User:     *wg.cx.Config.Username,
+		Pass:     *wg.cx.Config.Password,
+		// websocket connections are only made without TLS when this is set
+		TLS:          !*wg.cx.Config.TLS,
+		Certificates: certs,
+	}, wg.Subscriber())
+	return
+}
+
 func (wg *WalletGUI) goRoutines() {
 	var err error
 	if wg.ActivePageGet() == "goroutines" {
@@ -136,6 +156,10 @@ func (wg *WalletGUI) Tickers() {
 							wg.WalletClient = nil
 						}
 					}
+					if wg.WalletNotifier != nil {
+						wg.WalletNotifier.Shutdown()
+						wg.WalletNotifier = nil
+					}
 					// // the remaining actions require a running shell
 					// if !wg.running {
 					// 	break
@@ -146,6 +170,9 @@ func (wg *WalletGUI) Tickers() {
 					if err = wg.walletClient(); Check(err) {
 						break
 					}
+					// without notifications the wallet is still usable, only rescan progress is not shown
+					if err = wg.walletNotifications(); Check(err) {
+					}
 					// if we got to here both are connected
 					break preconnect
 				case <-wg.quit:
@@ -157,6 +184,7 @@ func (wg *WalletGUI) Tickers() {
 				select {
 				case <-seconds:
 					wg.goRoutines()
+					go wg.updatePrices()
 					// the remaining actions require a running shell, if it has been stopped we need to stop
 					if !wg.running {
 						break out
@@ -170,16 +198,24 @@ func (wg *WalletGUI) Tickers() {
 					}
 					wg.State.SetBestBlockHeight(int(height))
 					wg.State.SetBestBlockHash(h)
-					var unconfirmed util.Amount
-					if unconfirmed, err = wg.WalletClient.GetUnconfirmedBalance("default"); Check(err) {
-						// break out
+					var syncStatus *btcjson.GetSyncStatusResult
+					if syncStatus, err = wg.ChainClient.GetSyncStatus(); !Check(err) {
+						wg.State.SetSyncStatus(syncStatus)
 					}
-					wg.State.SetBalanceUnconfirmed(unconfirmed.ToDUO())
-					var confirmed util.Amount
-					if confirmed, err = wg.WalletClient.GetBalance("default"); Check(err) {
-						// break out
+					var netInfo *btcjson.GetNetworkInfoResult
+					if netInfo, err = wg.ChainClient.GetNetworkInfo(); !Check(err) {
+						wg.State.SetAnnouncements(netInfo.Announcements)
+					}
+					var walletInfo *btcjson.GetWalletInfoResult
+					if walletInfo, err = wg.WalletClient.GetWalletInfo(); !Check(err) {
+						wg.State.SetWatchOnly(walletInfo.WatchingOnly)
+						wg.State.SetSigningDevice(walletInfo.SigningDevice, walletInfo.Fingerprint)
+						wg.State.SetDBEncrypted(walletInfo.DatabaseEncrypted)
+					}
+					var balances *btcjson.GetWalletBalancesResult
+					if balances, err = wg.WalletClient.GetWalletBalances("default"); !Check(err) {
+						wg.State.SetBalances(balances)
 					}
-					wg.State.SetBalance(confirmed.ToDUO())
 					// don't update this unless it's in view
 					// if wg.ActivePageGet() == "main" {
 					// Debug("updating recent transactions")
@@ -197,6 +233,41 @@ func (wg *WalletGUI) Tickers() {
 					}
 					// Debug(len(atr))
 					wg.State.SetAllTxs(atr)
+					for _, txid := range wg.State.NewConflicts(atr) {
+						go wg.toasts.AddToast("Transaction conflicted",
+							"A competing spend was mined, "+txid+" will not confirm", "Danger")
+					}
+					var reconciliation *btcjson.GetReconciliationResult
+					if reconciliation, err = wg.WalletClient.GetReconciliation(); !Check(err) &&
+						wg.State.SetReconciliation(reconciliation) {
+						go wg.toasts.AddToast("Wallet history reconciled",
+							reconciliationSummary(reconciliation), "Warning")
+					}
+					// payment requests are checked on every tick so fulfilled requests are announced on any page
+					wg.updatePaymentRequests()
+					// the privacy report walks the whole wallet history so only refresh it while it is being viewed
+					if wg.ActivePageGet() == "privacy" {
+						var report *btcjson.PrivacyReportResult
+						if report, err = wg.WalletClient.GetPrivacyReport(); !Check(err) {
+							wg.State.SetPrivacyReport(report)
+						}
+					}
+					if wg.ActivePageGet() == "multisig" {
+						wg.updateMultisig()
+					}
+					if wg.ActivePageGet() == "coins" ||
+						(wg.ActivePageGet() == "send" && wg.bools["coinControl"].GetValue()) {
+						wg.updateCoins()
+					}
+					if wg.ActivePageGet() == "peers" {
+						wg.updatePeers()
+					}
+					// fee estimates are only shown in the fee selector of the send page
+					if wg.ActivePageGet() == "send" {
+						wg.updateFeeEstimates()
+					}
+					// the confirmations of the transaction in the detail dialog are kept up to date while it is open
+					wg.updateTxDetail()
 					wg.invalidate <- struct{}{}
 				case <-wg.quit:
 					break totalOut
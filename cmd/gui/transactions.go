@@ -54,7 +54,7 @@ func (wg *WalletGUI) OldTransactionsPage() l.Widget {
 							wg.Inset(0.25, wg.Caption("Label:").Color("DocText").Fn).Fn,
 						).
 						Rigid(
-							wg.Inset(0.25, wg.Caption("Amount(DUO):").Color("DocText").Fn).Fn,
+							wg.Inset(0.25, wg.Caption(fmt.Sprintf("Amount(%s):", wg.amountUnit())).Color("DocText").Fn).Fn,
 						).Fn,
 				).Fn,
 			).
@@ -99,7 +99,7 @@ func (wg *WalletGUI) singleTransaction(gtx l.Context, i int) l.Dimensions {
 									wg.Inset(0.1, wg.Caption(fmt.Sprint(wg.txs[i].Comment)).Color("DocText").Fn).Fn,
 								).
 								Rigid(
-									wg.Inset(0.1, wg.Caption(fmt.Sprint(wg.txs[i].Amount)).Color("DocText").Fn).Fn,
+									wg.Inset(0.1, wg.Caption(wg.formatAmount(wg.txs[i].Amount)).Color("DocText").Fn).Fn,
 								).Fn,
 						).Fn,
 					).Rigid(
@@ -150,6 +150,9 @@ func (wg *WalletGUI) singleTransaction(gtx l.Context, i int) l.Dimensions {
 									).
 									Fn,
 							).
+							Rigid(
+								wg.th.Caption(wg.txFeeText(wg.txs[i])).Fn,
+							).
 							Rigid(
 								wg.th.Flex().AlignMiddle().
 									Rigid(
@@ -169,6 +172,18 @@ func (wg *WalletGUI) singleTransaction(gtx l.Context, i int) l.Dimensions {
 	).Fn(gtx)
 }
 
+// txFeeText returns the fee, virtual size and effective fee rate of a sent transaction, and nothing for other rows.
+func (wg *WalletGUI) txFeeText(tx btcjson.ListTransactionsResult) string {
+	if tx.Category != "send" || tx.VSize == 0 {
+		return ""
+	}
+	if tx.Fee == nil || tx.FeeRate == nil {
+		return fmt.Sprintf("fee unknown %d vB ", tx.VSize)
+	}
+	// Fees of sends are listed as negative amounts.
+	return fmt.Sprintf("fee %s %d vB %.1f sat/vB ", wg.formatAmount(-*tx.Fee), tx.VSize, *tx.FeeRate)
+}
+
 // func (wg *WalletGUI) ClearAddress(i int) {
 //	wg.sendAddresses = remove(wg.sendAddresses, i)
 // }
@@ -194,6 +209,16 @@ func (wg *WalletGUI) txPage(i int) func() {
 	if true {
 		return func() {}
 	}
+	var fee, vsize, feeRate string
+	if wg.State.txs[i].data.Fee != nil {
+		fee = wg.formatAmount(*wg.State.txs[i].data.Fee)
+	}
+	if wg.State.txs[i].data.VSize != 0 {
+		vsize = fmt.Sprintf("%d vB", wg.State.txs[i].data.VSize)
+	}
+	if wg.State.txs[i].data.FeeRate != nil {
+		feeRate = fmt.Sprintf("%.1f sat/vB", *wg.State.txs[i].data.FeeRate)
+	}
 	txLayout := []l.Widget{
 		wg.txItem("TxId:", wg.State.txs[i].data.TxID),
 		wg.txItem("Comment:", wg.State.txs[i].data.Comment),
@@ -206,12 +231,15 @@ func (wg *WalletGUI) txPage(i int) func() {
 		wg.txItem("BlockTime:", fmt.Sprint(wg.State.txs[i].data.BlockTime)),
 		wg.txItem("Category:", wg.State.txs[i].data.Category),
 		wg.txItem("Confirmations:", fmt.Sprint(wg.State.txs[i].data.Confirmations)),
-		wg.txItem("Fee:", fmt.Sprint(wg.State.txs[i].data.Fee)),
+		wg.txItem("Fee:", fee),
+		wg.txItem("Virtual Size:", vsize),
+		wg.txItem("Fee Rate:", feeRate),
 		wg.txItem("InvolvesWatchOnly:", fmt.Sprint(wg.State.txs[i].data.InvolvesWatchOnly)),
 		wg.txItem("Time:", fmt.Sprint(wg.State.txs[i].data.Time)),
 		wg.txItem("TimeReceived:", fmt.Sprint(wg.State.txs[i].data.TimeReceived)),
 		wg.txItem("Vout:", fmt.Sprint(wg.State.txs[i].data.Vout)),
 		wg.txItem("WalletConflicts:", fmt.Sprint(wg.State.txs[i].data.WalletConflicts)),
+		wg.txItem("Conflicted:", fmt.Sprint(wg.State.txs[i].data.Conflicted)),
 		wg.txItem("Comment:", wg.State.txs[i].data.Comment),
 		wg.txItem("OtherAccount:", wg.State.txs[i].data.OtherAccount),
 	}
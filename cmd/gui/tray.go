@@ -0,0 +1,91 @@
+// +build windows linux,tray
+
+package gui
+
+import (
+	"bytes"
+	"encoding/binary"
+	"runtime"
+	"time"
+
+	"github.com/getlantern/systray"
+)
+
+// startTray puts the wallet in the system tray, with its sync status and balance, a switch for the miner and items to
+// show the window and to quit. While the tray icon is up, closing the window leaves pod running in the background.
+//
+// On linux the tray needs gtk3 and libappindicator3 and is only built with the tray build tag
+func (wg *WalletGUI) startTray() bool {
+	go func() {
+		// the tray runs its own event loop, which must stay on the thread it was set up on
+		runtime.LockOSThread()
+		systray.Run(wg.trayReady, func() {})
+	}()
+	return true
+}
+
+func (wg *WalletGUI) trayReady() {
+	systray.SetIcon(trayIconBytes())
+	systray.SetTooltip("ParallelCoin Wallet")
+	status := systray.AddMenuItem("", "progress of the sync of the chain")
+	status.Disable()
+	balance := systray.AddMenuItem("", "balance of the wallet")
+	balance.Disable()
+	mining := systray.AddMenuItemCheckbox("Mining", "start or stop the miner", wg.mining)
+	systray.AddSeparator()
+	show := systray.AddMenuItem("Show wallet", "open the wallet window")
+	quit := systray.AddMenuItem("Quit", "stop the node, wallet and miner and quit")
+	update := func() {
+		if !wg.running {
+			status.SetTitle("node stopped")
+		} else {
+			status.SetTitle("block " + wg.syncStatusText())
+		}
+		balance.SetTitle("balance " + wg.formatAmount(wg.State.Balance()))
+		if wg.mining {
+			mining.Check()
+		} else {
+			mining.Uncheck()
+		}
+	}
+	update()
+	seconds := time.NewTicker(time.Second)
+	defer seconds.Stop()
+	for {
+		select {
+		case <-seconds.C:
+			update()
+		case <-mining.ClickedCh:
+			if wg.mining {
+				wg.MinerRunCommandChan <- "stop"
+			} else {
+				wg.MinerRunCommandChan <- "run"
+			}
+			update()
+		case <-show.ClickedCh:
+			wg.showWindow <- struct{}{}
+		case <-quit.ClickedCh:
+			close(wg.quit)
+		case <-wg.quit:
+			systray.Quit()
+			return
+		}
+	}
+}
+
+// trayIconBytes returns the tray icon in the format of the platform, windows only loads icons from .ico files, which
+// can hold a png image as is
+func trayIconBytes() []byte {
+	if runtime.GOOS != "windows" {
+		return trayIcon
+	}
+	var buf bytes.Buffer
+	// the header of the icon file, with a single image in it
+	_ = binary.Write(&buf, binary.LittleEndian, []uint16{0, 1, 1})
+	// the directory entry of the image, 64x64 with 32 bits per pixel, which follows the 22 bytes of headers
+	buf.Write([]byte{64, 64, 0, 0})
+	_ = binary.Write(&buf, binary.LittleEndian, []uint16{1, 32})
+	_ = binary.Write(&buf, binary.LittleEndian, []uint32{uint32(len(trayIcon)), 22})
+	buf.Write(trayIcon)
+	return buf.Bytes()
+}
@@ -0,0 +1,9 @@
+// +build !windows
+// +build !linux !tray
+
+package gui
+
+// startTray does nothing where the system tray is not supported, so closing the window quits
+func (wg *WalletGUI) startTray() bool {
+	return false
+}
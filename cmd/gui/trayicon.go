@@ -0,0 +1,131 @@
+// +build windows linux,tray
+
+package gui
+
+// trayIcon is legacy/logo/logo64x64.png, the icon shown in the system tray
+var trayIcon = []byte{
+	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52,
+	0x00, 0x00, 0x00, 0x40, 0x00, 0x00, 0x00, 0x40, 0x08, 0x06, 0x00, 0x00, 0x00, 0xaa, 0x69, 0x71,
+	0xde, 0x00, 0x00, 0x00, 0x04, 0x73, 0x42, 0x49, 0x54, 0x08, 0x08, 0x08, 0x08, 0x7c, 0x08, 0x64,
+	0x88, 0x00, 0x00, 0x00, 0x09, 0x70, 0x48, 0x59, 0x73, 0x00, 0x00, 0x0b, 0xd0, 0x00, 0x00, 0x0b,
+	0xd0, 0x01, 0x77, 0x31, 0x74, 0x8f, 0x00, 0x00, 0x00, 0x19, 0x74, 0x45, 0x58, 0x74, 0x53, 0x6f,
+	0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x00, 0x77, 0x77, 0x77, 0x2e, 0x69, 0x6e, 0x6b, 0x73, 0x63,
+	0x61, 0x70, 0x65, 0x2e, 0x6f, 0x72, 0x67, 0x9b, 0xee, 0x3c, 0x1a, 0x00, 0x00, 0x07, 0x32, 0x49,
+	0x44, 0x41, 0x54, 0x78, 0x9c, 0xdd, 0x5b, 0x6d, 0x4c, 0x53, 0x57, 0x18, 0x7e, 0x38, 0xb6, 0x15,
+	0x86, 0xb4, 0xd0, 0x61, 0x4d, 0x1d, 0x0e, 0x51, 0xfc, 0x1e, 0x2c, 0xd3, 0x91, 0xb8, 0xc4, 0x0f,
+	0x62, 0x8c, 0x51, 0xff, 0x98, 0x8d, 0xcc, 0x85, 0x1f, 0x8e, 0x4c, 0x16, 0x13, 0xfc, 0xb3, 0x84,
+	0xcd, 0x1f, 0xfc, 0x11, 0xa6, 0xfe, 0x9a, 0x99, 0x9b, 0x5b, 0x8c, 0x66, 0x84, 0x84, 0x25, 0x44,
+	0x67, 0xe2, 0xd4, 0x18, 0xe6, 0x4c, 0x4c, 0xa4, 0x50, 0xa8, 0xe0, 0xc7, 0x04, 0x41, 0x40, 0x94,
+	0x82, 0x40, 0x71, 0x95, 0x4f, 0x29, 0x5a, 0x2e, 0x6d, 0xef, 0xd9, 0x8f, 0xdb, 0xea, 0xe5, 0x96,
+	0xca, 0xed, 0xfd, 0x68, 0xeb, 0x9e, 0xe4, 0x4d, 0x7a, 0xef, 0x39, 0xf7, 0x3d, 0xef, 0xf3, 0x9e,
+	0xde, 0x73, 0xcf, 0x39, 0xef, 0x7b, 0xe2, 0xa0, 0x3e, 0x4c, 0x00, 0xb6, 0x00, 0x58, 0x07, 0x60,
+	0x35, 0x80, 0x95, 0x00, 0x52, 0x00, 0x24, 0x03, 0x58, 0xe0, 0xaf, 0x33, 0x09, 0x60, 0x1c, 0xc0,
+	0x18, 0x80, 0x87, 0x00, 0x3a, 0x01, 0x3c, 0x00, 0x60, 0x05, 0xf0, 0x2c, 0x02, 0x36, 0x2a, 0x8e,
+	0x1c, 0x00, 0x27, 0x00, 0xb4, 0x01, 0x60, 0x01, 0x50, 0x89, 0xc2, 0x02, 0x68, 0xf5, 0xeb, 0xfa,
+	0x38, 0xa2, 0x0c, 0x24, 0x40, 0x0f, 0xe0, 0x10, 0x80, 0x76, 0x48, 0x27, 0x3c, 0x97, 0xb4, 0x03,
+	0xf8, 0x0e, 0x40, 0x52, 0x84, 0x38, 0x89, 0x82, 0x11, 0xc0, 0x11, 0x00, 0xa3, 0x50, 0x8f, 0xb8,
+	0x50, 0x46, 0x01, 0x7c, 0x0f, 0xee, 0x55, 0x8a, 0x1a, 0xe2, 0x00, 0x7c, 0x09, 0xee, 0x1d, 0x8d,
+	0x14, 0xf1, 0xd9, 0x1c, 0xf1, 0x0d, 0x00, 0xa2, 0x32, 0xd7, 0x20, 0xac, 0x00, 0x60, 0x93, 0x61,
+	0xb8, 0xd2, 0xd2, 0x00, 0x20, 0x53, 0x55, 0xc6, 0x3c, 0x7c, 0x06, 0x6e, 0xb4, 0x8e, 0x36, 0x69,
+	0xa1, 0x4c, 0x00, 0xc8, 0x57, 0x91, 0x37, 0x08, 0x80, 0x93, 0x31, 0x40, 0x74, 0x2e, 0x39, 0x01,
+	0x15, 0x5e, 0x09, 0x1d, 0x80, 0x73, 0x31, 0x40, 0x4e, 0xac, 0xfc, 0x09, 0x20, 0x5e, 0x49, 0xf2,
+	0x7f, 0xc7, 0x00, 0xa9, 0x70, 0xe5, 0xaa, 0xdf, 0x76, 0x59, 0x20, 0x00, 0xfe, 0x88, 0x01, 0x32,
+	0x52, 0xe5, 0x1c, 0x64, 0xbe, 0x0e, 0x11, 0x7d, 0xe7, 0xf5, 0x7a, 0xbd, 0x4b, 0xaf, 0xd7, 0xbb,
+	0x14, 0xd6, 0xfb, 0xa3, 0x54, 0xf2, 0xf9, 0x6a, 0x92, 0xd5, 0x68, 0x34, 0x9e, 0x03, 0x07, 0x0e,
+	0x34, 0xb6, 0xb4, 0xb4, 0x58, 0xdd, 0x6e, 0xf7, 0x63, 0x4a, 0xa9, 0x87, 0xbe, 0x86, 0xd7, 0xed,
+	0x76, 0x77, 0xb7, 0xb4, 0xb4, 0x58, 0xf7, 0xef, 0xdf, 0xdf, 0xa4, 0xd5, 0x6a, 0x19, 0x99, 0xed,
+	0x7d, 0x1e, 0x2e, 0xf9, 0x15, 0xe0, 0x3e, 0x2b, 0xaa, 0x90, 0x2f, 0x2e, 0x2e, 0x6e, 0x60, 0x18,
+	0xa6, 0x8f, 0x8a, 0x84, 0xc7, 0xe3, 0x71, 0x94, 0x94, 0x94, 0xd4, 0xc7, 0xc5, 0xc5, 0x49, 0x5d,
+	0x57, 0x8c, 0x03, 0x58, 0x2e, 0x96, 0x3c, 0x81, 0x4a, 0x93, 0x9c, 0x84, 0x84, 0x84, 0x97, 0x9d,
+	0x9d, 0x9d, 0x0d, 0x62, 0x89, 0x0b, 0xd1, 0xdf, 0xdf, 0x7f, 0xcb, 0x64, 0x32, 0x8d, 0x48, 0x6c,
+	0xbf, 0x1e, 0xdc, 0xec, 0x75, 0x4e, 0x7c, 0xad, 0x06, 0x79, 0x83, 0xc1, 0x30, 0x31, 0x36, 0x36,
+	0x76, 0x5f, 0x2a, 0xf9, 0x00, 0xa6, 0xa6, 0xa6, 0xec, 0x19, 0x19, 0x19, 0x0e, 0x89, 0x76, 0x7c,
+	0x25, 0x24, 0x2b, 0xf4, 0x88, 0x11, 0x40, 0x17, 0x80, 0x77, 0xc5, 0x78, 0x4a, 0x2c, 0xb4, 0x5a,
+	0xad, 0xc7, 0xe1, 0x70, 0xb4, 0x2d, 0x5c, 0xb8, 0xf0, 0x23, 0x61, 0x99, 0xd7, 0xeb, 0x1d, 0xac,
+	0xa9, 0xa9, 0x79, 0x74, 0xfe, 0xfc, 0xf9, 0x84, 0x9e, 0x9e, 0x9e, 0x44, 0x86, 0x61, 0xe6, 0x65,
+	0x64, 0x64, 0x4c, 0xe6, 0xe5, 0xe5, 0x4d, 0xee, 0xdc, 0xb9, 0xf3, 0xfd, 0xf8, 0xf8, 0xf8, 0x65,
+	0xc2, 0x67, 0x18, 0x86, 0xb1, 0x2f, 0x5e, 0xbc, 0xd8, 0x38, 0x3a, 0x3a, 0x9a, 0x1c, 0xa6, 0x29,
+	0x43, 0x00, 0x56, 0x81, 0x9b, 0xc9, 0xce, 0x8a, 0x23, 0x50, 0xa1, 0xf7, 0xaf, 0x5c, 0xb9, 0x52,
+	0x33, 0x5b, 0x67, 0x9e, 0x3a, 0x75, 0xca, 0xa2, 0xd3, 0xe9, 0x42, 0x0e, 0x70, 0x84, 0x10, 0x5f,
+	0x49, 0x49, 0x49, 0x3d, 0xcb, 0xb2, 0xe3, 0xc2, 0x87, 0xed, 0x76, 0xfb, 0x4d, 0x89, 0xf6, 0x94,
+	0x86, 0x22, 0xaf, 0x87, 0x0a, 0x4b, 0xda, 0x0d, 0x1b, 0x36, 0x3c, 0xa2, 0x94, 0x7a, 0xf9, 0xc6,
+	0xb3, 0x2c, 0x3b, 0xb1, 0x7b, 0xf7, 0xee, 0x16, 0xb1, 0x3a, 0xb2, 0xb2, 0xb2, 0xec, 0x1e, 0x8f,
+	0x67, 0x40, 0xe8, 0x84, 0xa2, 0xa2, 0x22, 0x29, 0x4e, 0x18, 0x41, 0x88, 0xfd, 0x84, 0x43, 0x6a,
+	0xf4, 0xfe, 0x93, 0x27, 0x4f, 0x9a, 0x04, 0x76, 0x7b, 0xf7, 0xed, 0xdb, 0x77, 0x2b, 0x5c, 0x3d,
+	0x39, 0x39, 0x39, 0x5d, 0x2c, 0xcb, 0xba, 0xf8, 0x8a, 0xdc, 0x6e, 0xf7, 0x63, 0x42, 0x88, 0x4f,
+	0x82, 0x5d, 0xdf, 0xce, 0xe6, 0x00, 0xc5, 0x77, 0x72, 0xcc, 0x66, 0xf3, 0x33, 0x3a, 0xf3, 0xfb,
+	0x4e, 0xef, 0xde, 0xbd, 0x5b, 0x37, 0x5b, 0x5d, 0x93, 0xc9, 0x34, 0x52, 0x58, 0x58, 0xd8, 0x54,
+	0x58, 0x58, 0xd8, 0x14, 0x6a, 0xa4, 0x2f, 0x2f, 0x2f, 0xb7, 0x08, 0xff, 0x05, 0x7b, 0xf6, 0xec,
+	0xb9, 0x27, 0xc1, 0xb6, 0x36, 0x21, 0xf9, 0x1c, 0x35, 0x7a, 0xbf, 0xb4, 0xb4, 0xd4, 0x2a, 0xb0,
+	0x97, 0xcd, 0xcc, 0xcc, 0xec, 0x17, 0xd6, 0xab, 0xac, 0xac, 0xac, 0xa1, 0x94, 0xba, 0xf9, 0x9d,
+	0x5b, 0x51, 0x51, 0x61, 0x11, 0xd6, 0x4b, 0x48, 0x48, 0x78, 0xc9, 0xb2, 0xec, 0x18, 0x5f, 0xe1,
+	0x8d, 0x1b, 0x37, 0x82, 0xea, 0x89, 0x94, 0xf5, 0x7c, 0x07, 0xfc, 0xa4, 0x86, 0x03, 0x1a, 0x1b,
+	0x1b, 0x6b, 0xf9, 0xc6, 0x4e, 0x4e, 0x4e, 0x76, 0x0a, 0xeb, 0x1c, 0x3d, 0x7a, 0xb4, 0x4e, 0xd8,
+	0xab, 0x01, 0x94, 0x95, 0x95, 0x59, 0x85, 0xf5, 0x85, 0xf3, 0x88, 0x91, 0x91, 0x91, 0x66, 0x89,
+	0xf6, 0x1d, 0xe7, 0x3b, 0xa0, 0x55, 0x8a, 0x92, 0xfc, 0xfc, 0xfc, 0x3b, 0x0c, 0xc3, 0xf4, 0xf1,
+	0x85, 0x5f, 0x3e, 0x38, 0x38, 0x78, 0x87, 0x6f, 0x6c, 0x73, 0x73, 0x73, 0x10, 0x21, 0x8f, 0xc7,
+	0xd3, 0x1f, 0xca, 0x01, 0xd3, 0xd3, 0xd3, 0x7d, 0xc2, 0xfa, 0xfe, 0x7f, 0xcb, 0x2b, 0x78, 0xbd,
+	0x5e, 0xa9, 0x73, 0x82, 0x66, 0x00, 0xd0, 0x80, 0xdb, 0xb7, 0x5f, 0x07, 0x09, 0x48, 0x4e, 0x4e,
+	0xf6, 0xe8, 0x74, 0xba, 0x25, 0xa1, 0xca, 0x13, 0x13, 0x13, 0x67, 0xac, 0xc9, 0x9f, 0x3e, 0x7d,
+	0xea, 0xe3, 0x5f, 0x1b, 0x0c, 0x86, 0x49, 0x8d, 0x46, 0x93, 0x16, 0xea, 0x79, 0xad, 0x56, 0xbb,
+	0x44, 0xaf, 0xd7, 0xbf, 0x98, 0x98, 0x98, 0x48, 0x0c, 0xdc, 0xeb, 0xeb, 0xeb, 0x9b, 0xc7, 0xaf,
+	0x43, 0x08, 0x59, 0x10, 0xfc, 0xa4, 0x28, 0x64, 0x01, 0x48, 0x25, 0xe0, 0x82, 0x16, 0xa2, 0xa6,
+	0x88, 0xe1, 0x82, 0x10, 0x32, 0x63, 0x29, 0xca, 0x30, 0x0c, 0xe5, 0x5f, 0xbf, 0x78, 0xf1, 0x22,
+	0x1e, 0x00, 0xf3, 0x06, 0x15, 0x6e, 0x7f, 0x9d, 0x57, 0x48, 0x49, 0x49, 0x61, 0xf9, 0xd7, 0x94,
+	0x52, 0x8f, 0x54, 0xf3, 0x00, 0x6c, 0x21, 0x90, 0xd8, 0xfb, 0x4a, 0xc0, 0xeb, 0xf5, 0x6a, 0xec,
+	0x76, 0x7b, 0x73, 0xa8, 0xf2, 0xb6, 0xb6, 0xb6, 0x7f, 0x7c, 0x3e, 0xdf, 0x8c, 0x1e, 0xcf, 0xce,
+	0xce, 0x9e, 0x51, 0x67, 0x7a, 0x7a, 0x7a, 0x5c, 0x86, 0x09, 0xeb, 0x08, 0xb8, 0xa9, 0x61, 0xd4,
+	0xb0, 0x63, 0xc7, 0x8e, 0x34, 0x9f, 0xcf, 0xf7, 0xaf, 0xf0, 0x3e, 0xc3, 0x30, 0xf6, 0xed, 0xdb,
+	0xb7, 0xaf, 0x11, 0xde, 0xdf, 0xb8, 0x71, 0xe3, 0x52, 0xfe, 0x75, 0x6f, 0x6f, 0xaf, 0x53, 0x46,
+	0xf3, 0xab, 0x08, 0xb8, 0x58, 0x5d, 0xd4, 0xd0, 0xdd, 0xdd, 0xfd, 0x5e, 0x5a, 0x5a, 0x9a, 0xe6,
+	0xea, 0xd5, 0xab, 0xb5, 0x63, 0x63, 0x63, 0xf7, 0x87, 0x86, 0x86, 0x9a, 0xab, 0xaa, 0xaa, 0x2c,
+	0x26, 0x93, 0xc9, 0xe4, 0x74, 0x3a, 0x8d, 0xfc, 0xba, 0x05, 0x05, 0x05, 0xb7, 0x75, 0x3a, 0x5d,
+	0x3a, 0xff, 0xde, 0xc5, 0x8b, 0x17, 0x7d, 0x90, 0x8e, 0x95, 0x00, 0xd0, 0x0b, 0x89, 0x9f, 0xb9,
+	0xa2, 0xa2, 0xa2, 0x9b, 0xc2, 0x91, 0x9b, 0x5f, 0xee, 0x72, 0xb9, 0xda, 0xf9, 0x65, 0x97, 0x2f,
+	0x5f, 0xae, 0x91, 0xda, 0x96, 0xc1, 0x60, 0x98, 0x98, 0x9a, 0x9a, 0xea, 0x11, 0x34, 0x37, 0x95,
+	0x9a, 0x9a, 0x2a, 0x75, 0x79, 0x4c, 0x01, 0xd8, 0x09, 0x5e, 0x47, 0x68, 0x63, 0x16, 0x3a, 0x9d,
+	0x6e, 0xba, 0xa3, 0xa3, 0xa3, 0x6b, 0xfe, 0xfc, 0xf9, 0x4b, 0xf9, 0xf7, 0x6d, 0x36, 0xdb, 0xad,
+	0xe1, 0xe1, 0x61, 0x63, 0x88, 0xc7, 0xc4, 0x20, 0x89, 0x20, 0xc6, 0x02, 0x8d, 0x42, 0xa4, 0xa7,
+	0xa7, 0x3f, 0x75, 0x3a, 0x9d, 0x5d, 0x66, 0xb3, 0x79, 0x03, 0xff, 0x3e, 0xa5, 0xf4, 0xf9, 0xde,
+	0xbd, 0x7b, 0xe5, 0xbe, 0xbe, 0x49, 0x11, 0x8f, 0xa9, 0x89, 0x45, 0x76, 0x76, 0x76, 0x77, 0x75,
+	0x75, 0xb5, 0xa5, 0xb7, 0xb7, 0x57, 0x9f, 0x9c, 0x9c, 0xfc, 0x81, 0xb0, 0xfc, 0xf0, 0xe1, 0xc3,
+	0xad, 0x0e, 0x87, 0x63, 0x91, 0xdc, 0x76, 0x34, 0x00, 0x5c, 0x50, 0x78, 0x03, 0x44, 0x0e, 0x06,
+	0x06, 0x06, 0x6e, 0x9b, 0xcd, 0xe6, 0xf7, 0x09, 0x21, 0xcb, 0x11, 0x62, 0x1f, 0xcf, 0x62, 0xb1,
+	0xd4, 0x1e, 0x3b, 0x76, 0x6c, 0xab, 0x02, 0xcd, 0xb9, 0x08, 0xb8, 0xec, 0x8c, 0x98, 0x81, 0xd9,
+	0x6c, 0x5e, 0x46, 0x08, 0x09, 0xd9, 0xb3, 0xd7, 0xae, 0x5d, 0xab, 0xdd, 0xb6, 0x6d, 0xdb, 0x16,
+	0x85, 0x9a, 0x73, 0x11, 0x00, 0xc3, 0x0a, 0x29, 0x53, 0x15, 0x2c, 0xcb, 0x0e, 0x1f, 0x3c, 0x78,
+	0xb0, 0x71, 0xd7, 0xae, 0x5d, 0x5b, 0x29, 0xa5, 0x4a, 0xcd, 0x5c, 0x87, 0x09, 0xb8, 0x3d, 0xc0,
+	0x98, 0x05, 0xcb, 0xb2, 0xa3, 0x97, 0x2e, 0x5d, 0xb2, 0xa4, 0xa4, 0xa4, 0xc4, 0x9f, 0x3e, 0x7d,
+	0x7a, 0xa3, 0xc2, 0xea, 0xbb, 0x34, 0xe0, 0x92, 0x92, 0x62, 0x06, 0x3d, 0x3d, 0x3d, 0x5d, 0x5e,
+	0xaf, 0x17, 0x1d, 0x1d, 0x1d, 0x4c, 0x75, 0x75, 0xf5, 0x3b, 0x55, 0x55, 0x55, 0x1f, 0x32, 0x0c,
+	0x93, 0xab, 0x52, 0x73, 0x0f, 0x35, 0x98, 0x65, 0x77, 0x24, 0x9a, 0xc8, 0xcc, 0xcc, 0xfc, 0x24,
+	0x82, 0xcd, 0xb5, 0x11, 0x70, 0xa9, 0x68, 0x74, 0xae, 0x9a, 0xff, 0x43, 0x50, 0x00, 0x56, 0x02,
+	0x2e, 0xc7, 0xe7, 0x81, 0x14, 0x0d, 0xf5, 0xf5, 0xf5, 0x8b, 0x28, 0xa5, 0xcf, 0x15, 0x35, 0x2b,
+	0x72, 0x68, 0x81, 0x7f, 0x10, 0x04, 0x80, 0xeb, 0x52, 0x34, 0xb4, 0xb6, 0xb6, 0x66, 0x6c, 0xde,
+	0xbc, 0xd9, 0x41, 0x29, 0x0d, 0x19, 0x68, 0x88, 0x61, 0x5c, 0x07, 0x5e, 0xc7, 0xce, 0xcf, 0x49,
+	0xd5, 0xd2, 0xd0, 0xd0, 0xb0, 0x76, 0xd3, 0xa6, 0x4d, 0xce, 0xb7, 0xd0, 0x09, 0x67, 0x81, 0xd7,
+	0x0e, 0xb8, 0x0d, 0x19, 0x83, 0xa1, 0xcd, 0x66, 0x5b, 0xfd, 0x96, 0x39, 0xa1, 0x1d, 0xfe, 0x3d,
+	0x41, 0xfe, 0x5a, 0xe0, 0x77, 0x39, 0x1a, 0x6d, 0x36, 0xdb, 0xea, 0xdc, 0xdc, 0xdc, 0xa0, 0x8d,
+	0x8d, 0x18, 0x45, 0x45, 0xe0, 0x07, 0xdf, 0x01, 0xbf, 0xe1, 0x0d, 0x41, 0x43, 0x31, 0xa8, 0xab,
+	0xab, 0x0b, 0xda, 0xc1, 0x89, 0x41, 0x8c, 0x02, 0x28, 0x0f, 0x5c, 0xf0, 0x1d, 0x30, 0x01, 0xe0,
+	0xd7, 0x88, 0x9b, 0x13, 0x79, 0x9c, 0x04, 0xb7, 0x00, 0x04, 0x10, 0x9c, 0x40, 0xf4, 0x33, 0x14,
+	0x5c, 0x1b, 0x9c, 0x39, 0x73, 0x66, 0x08, 0xb1, 0x35, 0xc7, 0x78, 0x06, 0xe0, 0x97, 0xb9, 0x2a,
+	0x15, 0x42, 0xc1, 0xe8, 0x90, 0x3f, 0xf2, 0xe3, 0x93, 0xbb, 0x25, 0xa6, 0x90, 0x14, 0x88, 0xf1,
+	0x52, 0x1c, 0xb8, 0xdc, 0x5b, 0xc5, 0x1a, 0x2e, 0x2b, 0x2b, 0xb3, 0x52, 0x4a, 0x7d, 0x51, 0x76,
+	0x80, 0x15, 0x61, 0xc4, 0x3f, 0x32, 0xc1, 0x25, 0x16, 0x29, 0x66, 0x40, 0x71, 0x71, 0x71, 0xc3,
+	0x85, 0x0b, 0x17, 0xa2, 0xe5, 0x80, 0x71, 0x00, 0x41, 0x99, 0x26, 0x73, 0xe1, 0x0b, 0xa5, 0x0d,
+	0x31, 0x1a, 0x8d, 0xd1, 0x4a, 0xb2, 0xce, 0x0b, 0x97, 0x7c, 0x00, 0x27, 0xa2, 0x64, 0xb0, 0x92,
+	0x32, 0x23, 0x0a, 0x1c, 0x2e, 0xe2, 0x00, 0x54, 0xc6, 0x00, 0x09, 0xa9, 0x72, 0x16, 0x0a, 0x64,
+	0x8e, 0xeb, 0xc0, 0x25, 0x1e, 0x47, 0x9b, 0x4c, 0xb8, 0xf2, 0x17, 0x00, 0xad, 0x5c, 0xf2, 0x7c,
+	0x27, 0x9c, 0x8d, 0x01, 0x52, 0x62, 0xa5, 0x4a, 0x49, 0xf2, 0x01, 0x10, 0x70, 0x89, 0xc7, 0xd1,
+	0x26, 0x37, 0x97, 0x1c, 0x87, 0x4a, 0xe1, 0xfe, 0x00, 0x3e, 0x45, 0x64, 0x4f, 0x88, 0x89, 0x95,
+	0xe7, 0xe0, 0xbe, 0x5c, 0x11, 0xc1, 0x72, 0x70, 0xb9, 0xb7, 0xd1, 0x26, 0x1d, 0x10, 0x2b, 0x24,
+	0x7c, 0xe7, 0xe5, 0x22, 0x70, 0x6c, 0xce, 0x29, 0xc3, 0x70, 0xb9, 0x32, 0x82, 0x28, 0x1d, 0x9b,
+	0xe3, 0x23, 0x05, 0x5c, 0xfa, 0xa9, 0x9c, 0x30, 0xb5, 0x14, 0xe2, 0x87, 0xc1, 0x9d, 0x3f, 0x8e,
+	0x19, 0x24, 0x81, 0xcb, 0xc0, 0x6c, 0x83, 0x7a, 0xc4, 0x5b, 0x01, 0x14, 0xe3, 0x2d, 0x08, 0xe9,
+	0xaf, 0x07, 0x37, 0x1a, 0x37, 0x43, 0xde, 0xe1, 0x69, 0x1f, 0x80, 0x7b, 0x00, 0x7e, 0x00, 0x10,
+	0x94, 0x69, 0xae, 0x04, 0x54, 0xfd, 0x5c, 0xf8, 0x91, 0x0a, 0x2e, 0x13, 0x6d, 0x2d, 0x80, 0x35,
+	0xe0, 0xd2, 0x52, 0x8c, 0x98, 0xfd, 0xf8, 0xfc, 0x28, 0x82, 0x8f, 0xcf, 0xab, 0x1a, 0xbb, 0xfc,
+	0x0f, 0x5c, 0xc8, 0xce, 0xd2, 0xc1, 0x00, 0xef, 0x56, 0x00, 0x00, 0x00, 0x00, 0x49, 0x45, 0x4e,
+	0x44, 0xae, 0x42, 0x60, 0x82,
+}
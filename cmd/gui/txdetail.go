@@ -0,0 +1,181 @@
+package gui
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	l "gioui.org/layout"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TxDetail is the wallet transaction shown in the transaction detail dialog. The confirmations are counted from the
+// chain of the node rather than taken from the wallet, so they keep up with the node while the dialog is open.
+type TxDetail struct {
+	Tx            *btcjson.GetTransactionResult
+	MsgTx         *wire.MsgTx
+	Confirmations int64
+}
+
+// showTxDetail opens the detail dialog of a wallet transaction
+func (wg *WalletGUI) showTxDetail(txid string) {
+	go func() {
+		detail, err := wg.fetchTxDetail(txid)
+		if Check(err) {
+			wg.toasts.AddToast("Transaction", err.Error(), "Danger")
+			return
+		}
+		wg.State.SetTxDetail(detail)
+		wg.dialog.ShowDialog("Transaction "+txid, "Info", wg.txDetailWidget())()
+		wg.invalidate <- struct{}{}
+	}()
+}
+
+// fetchTxDetail gets a transaction from the wallet and its confirmations from the chain
+func (wg *WalletGUI) fetchTxDetail(txid string) (detail *TxDetail, err error) {
+	if wg.WalletClient == nil || wg.ChainClient == nil {
+		return nil, fmt.Errorf("not connected to the wallet")
+	}
+	var hash *chainhash.Hash
+	if hash, err = chainhash.NewHashFromStr(txid); err != nil {
+		return
+	}
+	detail = &TxDetail{}
+	if detail.Tx, err = wg.WalletClient.GetTransaction(hash); err != nil {
+		return
+	}
+	var raw []byte
+	if raw, err = hex.DecodeString(detail.Tx.Hex); err != nil {
+		return
+	}
+	detail.MsgTx = wire.NewMsgTx(wire.TxVersion)
+	if err = detail.MsgTx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return
+	}
+	// the wallet leaves the block hash empty until the transaction is mined, and a block that is reorganised out of the
+	// chain has no confirmations
+	if detail.Tx.BlockHash != "" {
+		var blockHash *chainhash.Hash
+		if blockHash, err = chainhash.NewHashFromStr(detail.Tx.BlockHash); err != nil {
+			return
+		}
+		var header *btcjson.GetBlockHeaderVerboseResult
+		if header, err = wg.ChainClient.GetBlockHeaderVerbose(blockHash); err != nil {
+			return
+		}
+		if header.Confirmations > 0 {
+			detail.Confirmations = header.Confirmations
+		}
+	}
+	return
+}
+
+// updateTxDetail refreshes the transaction in the detail dialog while it is open
+func (wg *WalletGUI) updateTxDetail() {
+	detail := wg.State.TxDetail()
+	if detail == nil {
+		return
+	}
+	if !wg.dialog.Showing() {
+		wg.State.SetTxDetail(nil)
+		return
+	}
+	updated, err := wg.fetchTxDetail(detail.Tx.TxID)
+	if Check(err) {
+		return
+	}
+	wg.State.SetTxDetail(updated)
+}
+
+// txDetailWidget shows the transaction in the detail dialog, with a button to broadcast it again while it is not mined
+func (wg *WalletGUI) txDetailWidget() func(gtx l.Context) l.Dimensions {
+	return func(gtx l.Context) l.Dimensions {
+		detail := wg.State.TxDetail()
+		if detail == nil {
+			return l.Dimensions{}
+		}
+		lines := wg.txDetailLines(detail)
+		if detail.Confirmations == 0 {
+			lines = append(lines,
+				wg.Inset(0.25,
+					wg.buttonText(wg.clickables["txRebroadcast"], "Rebroadcast", func() {
+						go wg.rebroadcast(detail.MsgTx)
+					}),
+				).Fn,
+			)
+		}
+		le := func(gtx l.Context, index int) l.Dimensions {
+			return lines[index](gtx)
+		}
+		return wg.lists["txDetail"].Vertical().Length(len(lines)).ListElement(le).Fn(gtx)
+	}
+}
+
+func (wg *WalletGUI) txDetailLines(detail *TxDetail) (out []l.Widget) {
+	tx := detail.Tx
+	line := func(txt string) l.Widget {
+		return wg.Inset(0.25, wg.Body2(txt).Color("PanelText").Fn).Fn
+	}
+	status := fmt.Sprintf("%d confirmations", detail.Confirmations)
+	if detail.Confirmations == 0 {
+		status = "unconfirmed"
+	}
+	out = append(out,
+		line(status),
+		line("Amount "+wg.formatAmount(tx.Amount)),
+		line("Time "+time.Unix(tx.Time, 0).Format("2006-01-02 15:04:05")),
+	)
+	// the wallet only knows the fee of the transactions it sent
+	if tx.Fee != 0 {
+		out = append(out, line("Fee "+wg.formatAmount(-tx.Fee)))
+	}
+	if tx.BlockHash != "" {
+		out = append(out, line("Block "+tx.BlockHash))
+	}
+	if len(tx.WalletConflicts) > 0 {
+		out = append(out, line("Conflicts with "+strings.Join(tx.WalletConflicts, ", ")))
+	}
+	out = append(out, line(fmt.Sprintf("%d inputs", len(detail.MsgTx.TxIn))))
+	for _, in := range detail.MsgTx.TxIn {
+		out = append(out, line("  "+in.PreviousOutPoint.String()))
+	}
+	out = append(out, line(fmt.Sprintf("%d outputs", len(detail.MsgTx.TxOut))))
+	for i, o := range detail.MsgTx.TxOut {
+		var addrs []string
+		if _, addresses, _, err := txscript.ExtractPkScriptAddrs(o.PkScript, wg.cx.ActiveNet); err == nil {
+			for _, a := range addresses {
+				addrs = append(addrs, a.EncodeAddress())
+			}
+		}
+		txt := fmt.Sprintf("  %d: %s", i, wg.formatAmount(util.Amount(o.Value).ToDUO()))
+		if len(addrs) > 0 {
+			txt += " to " + strings.Join(addrs, ", ")
+		}
+		out = append(out, line(txt))
+	}
+	return append(out,
+		line("Raw transaction"),
+		wg.Inset(0.25, wg.Caption(tx.Hex).Font("go regular").Color("PanelText").Fn).Fn,
+	)
+}
+
+// rebroadcast sends an unconfirmed transaction to the node again, which relays it to its peers
+func (wg *WalletGUI) rebroadcast(msgTx *wire.MsgTx) {
+	if wg.ChainClient == nil {
+		wg.toasts.AddToast("Rebroadcast", "not connected to the node", "Danger")
+		return
+	}
+	h, err := wg.ChainClient.SendRawTransaction(msgTx, false)
+	if Check(err) {
+		wg.toasts.AddToast("Rebroadcast", err.Error(), "Danger")
+		return
+	}
+	wg.toasts.AddToast("Rebroadcast", h.String(), "Success")
+}
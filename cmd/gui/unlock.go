@@ -0,0 +1,93 @@
+package gui
+
+import (
+	"sync"
+	"time"
+
+	l "gioui.org/layout"
+)
+
+// defaultUnlockTimeout is used when no unlock timeout is configured
+const defaultUnlockTimeout = 10 * time.Minute
+
+// unlockSession tracks how long the wallet stays unlocked after the passphrase was entered in the GUI. The wallet is
+// unlocked with walletpassphrase for the same timeout, so the session ends no later than the wallet locks itself again.
+type unlockSession struct {
+	sync.Mutex
+	until time.Time
+}
+
+// active returns whether the wallet is still unlocked
+func (s *unlockSession) active() bool {
+	s.Lock()
+	defer s.Unlock()
+	return time.Now().Before(s.until)
+}
+
+// start begins a session lasting until the given time
+func (s *unlockSession) start(until time.Time) {
+	s.Lock()
+	defer s.Unlock()
+	s.until = until
+}
+
+// end ends the session, so the passphrase is asked for again before the next action that needs the wallet unlocked
+func (s *unlockSession) end() {
+	s.Lock()
+	defer s.Unlock()
+	s.until = time.Time{}
+}
+
+// unlockTimeout returns how long the wallet stays unlocked after the passphrase is entered
+func (wg *WalletGUI) unlockTimeout() time.Duration {
+	if t := *wg.cx.Config.UnlockTimeout; t > 0 {
+		return t
+	}
+	return defaultUnlockTimeout
+}
+
+// authorize runs action once the wallet is unlocked. While an unlock session is running the action runs straight away,
+// except for high-risk actions, which always ask for the passphrase again.
+func (wg *WalletGUI) authorize(title string, highRisk bool, action func()) {
+	if wg.State.WatchOnly() {
+		wg.watchOnlyRefusal()
+		return
+	}
+	if !highRisk && wg.unlock.active() {
+		go action()
+		return
+	}
+	wg.dialog.ShowDialog(title, "Warning", wg.passphrasePrompt(action))()
+}
+
+// passphrasePrompt asks for the wallet passphrase, unlocks the wallet with it for the unlock timeout and then runs
+// action
+func (wg *WalletGUI) passphrasePrompt(action func()) func(gtx l.Context) l.Dimensions {
+	return wg.th.VFlex().
+		Rigid(
+			wg.Inset(0.25,
+				wg.passwords["unlockPass"].Fn,
+			).Fn,
+		).
+		Rigid(
+			wg.Inset(0.25,
+				wg.buttonText(wg.clickables["unlockConfirm"], "Unlock", func() {
+					pass := wg.passwords["unlockPass"].GetPassword()
+					wg.passwords["unlockPass"].Wipe()
+					wg.dialog.Close()
+					go func() {
+						timeout := wg.unlockTimeout()
+						// The session starts before the wallet is unlocked so that it never outlasts the unlock.
+						until := time.Now().Add(timeout)
+						if err := wg.WalletClient.WalletPassphrase(pass, int64(timeout.Seconds())); Check(err) {
+							wg.toasts.AddToast("Unlock error", err.Error(), "Danger")
+							return
+						}
+						wg.unlock.start(until)
+						action()
+						wg.invalidate <- struct{}{}
+					}()
+				}),
+			).Fn,
+		).Fn
+}
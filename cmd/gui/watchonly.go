@@ -0,0 +1,143 @@
+package gui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	l "gioui.org/layout"
+	icons2 "golang.org/x/exp/shiny/materialdesign/icons"
+
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// creatingWatchOnly returns whether the create wallet page is set to create a watch-only wallet
+func (wg *WalletGUI) creatingWatchOnly() bool {
+	return wg.bools["watchOnly"].GetValue()
+}
+
+// createWalletTitle is the title of the create wallet page for the kind of wallet being created
+func (wg *WalletGUI) createWalletTitle() string {
+	if wg.creatingWatchOnly() {
+		return "create watch-only wallet"
+	}
+	if wg.restoringWallet() {
+		return "restore wallet"
+	}
+	return "create new wallet"
+}
+
+// seedWalletOnly shows a widget of the create wallet page only when a wallet with a seed is being created
+func (wg *WalletGUI) seedWalletOnly(w l.Widget) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if wg.creatingWatchOnly() {
+			return l.Dimensions{}
+		}
+		return w(gtx)
+	}
+}
+
+// watchOnlyWalletOnly shows a widget of the create wallet page only when a watch-only wallet is being created
+func (wg *WalletGUI) watchOnlyWalletOnly(w l.Widget) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if !wg.creatingWatchOnly() {
+			return l.Dimensions{}
+		}
+		return w(gtx)
+	}
+}
+
+// watchOnlyXPub returns the account extended public key entered for a watch-only wallet, checked the way the wallet
+// will check it when it is created
+func (wg *WalletGUI) watchOnlyXPub() (xpub string, err error) {
+	xpub = strings.TrimSpace(wg.inputs["watchOnlyXPub"].GetText())
+	if xpub == "" {
+		return "", errors.New("enter the extended public key of the account to watch")
+	}
+	var key *hdkeychain.ExtendedKey
+	if key, err = hdkeychain.NewKeyFromString(xpub); err != nil {
+		return "", errors.New("not an extended public key: " + err.Error())
+	}
+	switch {
+	case key.IsPrivate():
+		return "", errors.New("this is an extended private key, enter the public key of the account instead")
+	case !key.IsForNet(wg.cx.ActiveNet):
+		return "", errors.New("the extended public key is for another network")
+	case key.Depth() != 3:
+		return "", errors.New("the extended public key is not of an account, which is at m/44'/coin'/account'")
+	}
+	return
+}
+
+// watchOnlyXPubStatus describes the extended public key entered for a watch-only wallet
+func (wg *WalletGUI) watchOnlyXPubStatus() string {
+	xpub, err := wg.watchOnlyXPub()
+	if err != nil {
+		return err.Error()
+	}
+	if d := wg.pairingDevice(xpub); d != nil {
+		return "the wallet will watch this account and send with the " + d.Info().Model + ", which signs its transactions"
+	}
+	return "the wallet will watch this account and cannot spend from it"
+}
+
+// watchOnlyBadge marks the page headers of a watch-only wallet, naming the hardware wallet that signs for it if it is
+// paired with one
+func (wg *WalletGUI) watchOnlyBadge() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if !wg.State.WatchOnly() {
+			return l.Dimensions{}
+		}
+		badge := "watch-only"
+		if model := wg.pairedDevice(); model != "" {
+			badge = "signs on " + model
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("Warning",
+				wg.th.Inset(0.1,
+					wg.th.Caption(badge).Color("DocBg").Fn,
+				).Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+// WatchOnlyBanner tells that the wallet is watch-only, so payments to it are seen but it cannot send or export keys
+// unless a hardware wallet holding its keys signs for it
+func (wg *WalletGUI) WatchOnlyBanner() l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		if !wg.State.WatchOnly() {
+			return l.Dimensions{}
+		}
+		text := "watch-only wallet: balances and history are tracked, but it holds no private keys so it cannot " +
+			"send or export keys"
+		if model, fingerprint := wg.State.SigningDevice(); model != "" {
+			text = fmt.Sprintf("hardware wallet: the private keys are kept on the %s (%s), sends are signed on "+
+				"the device and receiving addresses can be verified on its screen", model, fingerprint)
+		}
+		return wg.th.Inset(0.25,
+			wg.th.Fill("Warning",
+				wg.th.Inset(0.25,
+					wg.th.Flex().AlignMiddle().
+						Rigid(
+							wg.Icon().Color("DocBg").Scale(1).Src(&icons2.ActionVisibility).Fn,
+						).
+						Flexed(1,
+							wg.th.Inset(0.25,
+								wg.th.Body1(text).Color("DocBg").Fn,
+							).Fn,
+						).Fn,
+				).Fn,
+			).Fn,
+		).Fn(gtx)
+	}
+}
+
+// watchOnlyRefusal tells that an action needing private keys cannot be done by a watch-only wallet
+func (wg *WalletGUI) watchOnlyRefusal() {
+	if model := wg.pairedDevice(); model != "" {
+		wg.toasts.AddToast("Hardware wallet", "the private keys of this wallet never leave the "+model, "Warning")
+		return
+	}
+	wg.toasts.AddToast("Watch-only wallet", "this wallet holds no private keys, so it cannot sign", "Warning")
+}
@@ -0,0 +1,192 @@
+// Package bench measures the hashrate of the mining algorithms with a range of thread counts, so the number of threads
+// kopach mines with, and the algorithms it prefers, can be set from measurements on the machine instead of guessed.
+package bench
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// Keep is the fraction of its best hashrate an algorithm must reach with the recommended number of threads to be kept
+// in the recommended algorithms
+const Keep = 0.9
+
+// Config is what a benchmark measures
+type Config struct {
+	// Duration is how long each algorithm is hashed with each thread count
+	Duration time.Duration
+	// Forks are the numbers of the hard forks whose algorithms are measured
+	Forks []int
+	// MaxThreads is the most threads measured
+	MaxThreads int
+}
+
+// Result is the hashrate of an algorithm with a number of threads
+type Result struct {
+	Fork     int
+	Algo     string
+	Threads  int
+	Hashrate float64
+	// Watts is the average power drawn by the processors while hashing, or zero if it can't be read
+	Watts float64
+}
+
+// PerWatt returns the hashes per second per watt, or zero if the power drawn is not known
+func (r Result) PerWatt() float64 {
+	if r.Watts <= 0 {
+		return 0
+	}
+	return r.Hashrate / r.Watts
+}
+
+// ThreadCounts returns the powers of two below max and max itself
+func ThreadCounts(max int) (counts []int) {
+	for n := 1; n < max; n *= 2 {
+		counts = append(counts, n)
+	}
+	if max > 0 {
+		counts = append(counts, max)
+	}
+	return
+}
+
+// Run measures every algorithm of the forks with each thread count, calling report with each result as it is measured
+func Run(cfg Config, report func(Result)) (results []Result) {
+	for _, hf := range cfg.Forks {
+		if hf < 0 || hf >= len(fork.List) {
+			Warn("there is no hard fork", hf)
+			continue
+		}
+		height := forkHeight(hf)
+		for _, ver := range versions(hf) {
+			for _, threads := range ThreadCounts(cfg.MaxThreads) {
+				r := Result{Fork: hf, Algo: fork.List[hf].AlgoVers[ver], Threads: threads}
+				r.Hashrate, r.Watts = measure(ver, height, threads, cfg.Duration)
+				if report != nil {
+					report(r)
+				}
+				results = append(results, r)
+			}
+		}
+	}
+	return
+}
+
+// forkHeight returns a height the hard fork is active at on the current network
+func forkHeight(hf int) (height int32) {
+	if !fork.IsTestnet {
+		return fork.List[hf].ActivationHeight
+	}
+	height = fork.List[hf].TestnetStart
+	// the first block of testnet is hashed with fewer rounds than the rest
+	if height == 1 {
+		height++
+	}
+	return
+}
+
+// versions returns the block versions of the algorithms of the hard fork in order
+func versions(hf int) (vers []int32) {
+	for v := range fork.List[hf].AlgoVers {
+		vers = append(vers, v)
+	}
+	sort.Slice(vers, func(i, j int) bool { return vers[i] < vers[j] })
+	return
+}
+
+// measure hashes block headers of the algorithm version on threads goroutines for the duration, returning the hashes
+// per second and the watts drawn
+func measure(ver, height int32, threads int, d time.Duration) (hashrate, watts float64) {
+	var stop atomic.Bool
+	var hashes atomic.Uint64
+	var wg sync.WaitGroup
+	startEnergy, metered := energy()
+	start := time.Now()
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var prev, merkle chainhash.Hash
+			rand.Read(prev[:])
+			rand.Read(merkle[:])
+			h := wire.NewBlockHeader(ver, &prev, &merkle, fork.GetMinBits(fork.GetAlgoName(ver, height), height),
+				rand.Uint32())
+			var count uint64
+			for !stop.Load() {
+				h.BlockHashWithAlgos(height)
+				h.Nonce++
+				count++
+			}
+			hashes.Add(count)
+		}()
+	}
+	time.Sleep(d)
+	stop.Store(true)
+	wg.Wait()
+	elapsed := time.Since(start).Seconds()
+	hashrate = float64(hashes.Load()) / elapsed
+	if metered {
+		if endEnergy, ok := energy(); ok {
+			watts = float64(used(startEnergy, endEnergy)) / 1e6 / elapsed
+		}
+	}
+	return
+}
+
+// Recommend returns the thread count at which the algorithms hash fastest against the best each of them did with any
+// thread count, averaged over the algorithms, and the algorithms that reach Keep of their best with that count, in the
+// order they were measured
+func Recommend(results []Result) (threads int, algos []string) {
+	type key struct {
+		fork int
+		algo string
+	}
+	var order []key
+	best := make(map[key]float64)
+	rates := make(map[int]map[key]float64)
+	for _, r := range results {
+		k := key{r.Fork, r.Algo}
+		if _, ok := best[k]; !ok {
+			order = append(order, k)
+		}
+		if r.Hashrate > best[k] {
+			best[k] = r.Hashrate
+		}
+		if rates[r.Threads] == nil {
+			rates[r.Threads] = make(map[key]float64)
+		}
+		rates[r.Threads][k] = r.Hashrate
+	}
+	var counts []int
+	for t := range rates {
+		counts = append(counts, t)
+	}
+	sort.Ints(counts)
+	var bestScore float64
+	for _, t := range counts {
+		var score float64
+		for _, k := range order {
+			if best[k] > 0 {
+				score += rates[t][k] / best[k]
+			}
+		}
+		// fewer threads are kept when more don't hash any faster
+		if score > bestScore {
+			bestScore, threads = score, t
+		}
+	}
+	for _, k := range order {
+		if best[k] > 0 && rates[threads][k] >= Keep*best[k] {
+			algos = append(algos, k.algo)
+		}
+	}
+	return
+}
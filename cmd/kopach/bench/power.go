@@ -0,0 +1,61 @@
+package bench
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// powercap is where Linux shows the energy counters of the processor packages. On other platforms, without the RAPL
+// driver or without permission to read the counters the power drawn is not measured
+const powercap = "/sys/class/powercap"
+
+// reading is the energy counter of a processor package in microjoules, and the value it wraps around at
+type reading struct {
+	uj, max uint64
+}
+
+// energy reads the energy counter of every processor package
+func energy() (readings []reading, ok bool) {
+	zones, _ := filepath.Glob(filepath.Join(powercap, "intel-rapl:*"))
+	for _, z := range zones {
+		// subzones of a package such as intel-rapl:0:0 are counted in the package already
+		if strings.Count(filepath.Base(z), ":") != 1 {
+			continue
+		}
+		var r reading
+		var err error
+		if r.uj, err = readUint(filepath.Join(z, "energy_uj")); err != nil {
+			return nil, false
+		}
+		if r.max, err = readUint(filepath.Join(z, "max_energy_range_uj")); err != nil {
+			return nil, false
+		}
+		readings = append(readings, r)
+	}
+	return readings, len(readings) > 0
+}
+
+// used returns the microjoules used by the processor packages between two readings
+func used(start, end []reading) (uj uint64) {
+	for i := range start {
+		if i >= len(end) {
+			break
+		}
+		if end[i].uj < start[i].uj {
+			uj += end[i].max - start[i].uj + end[i].uj
+		} else {
+			uj += end[i].uj - start[i].uj
+		}
+	}
+	return
+}
+
+func readUint(path string) (u uint64, err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	return strconv.ParseUint(strings.TrimSpace(string(b)), 10, 64)
+}
@@ -6,6 +6,7 @@ import (
 	"net/rpc"
 
 	"github.com/p9c/pod/cmd/kopach/control/job"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 )
 
 type Client struct {
@@ -80,3 +81,26 @@ func (c *Client) SendPass(pass string) (err error) {
 	}
 	return
 }
+
+// SetAlgos tells the worker the names of the algorithms to mine out of those in the work
+func (c *Client) SetAlgos(names []string) (err error) {
+	var reply bool
+	err = c.Call("Worker.SetAlgos", names, &reply)
+	if err != nil {
+		Error(err)
+		return
+	}
+	if reply != true {
+		err = errors.New("set algorithms command not acknowledged")
+	}
+	return
+}
+
+// Stats queries the worker for its hashrate, hashes and solutions
+func (c *Client) Stats() (stats btcjson.MinerWorkerStatsResult, err error) {
+	err = c.Call("Worker.Stats", 1, &stats)
+	if err != nil {
+		Error(err)
+	}
+	return
+}
@@ -0,0 +1,146 @@
+// Package blkpush carries full blocks between the nodes of a LAN cluster over the miner multicast channel, so that
+// nodes behind the same uplink don't each download a new block from the internet.
+package blkpush
+
+import (
+	"errors"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/coding/simplebuffer"
+	"github.com/p9c/pod/pkg/coding/simplebuffer/Bytes"
+	"github.com/p9c/pod/pkg/coding/simplebuffer/Hash"
+	"github.com/p9c/pod/pkg/coding/simplebuffer/Uint16"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// Magic is the marker for packets containing a segment of a pushed block
+var Magic = []byte{'b', 'l', 'k', 'p'}
+
+const (
+	// SegmentSize is the most bytes of a serialized block carried by one message. A message is sent as fec shards of a
+	// third of its size each, which must fit in a datagram along with the encryption overhead.
+	SegmentSize = 16384
+	// assemblyTimeout is how long the segments of a block are kept waiting for the rest of them
+	assemblyTimeout = time.Minute
+)
+
+type Container struct {
+	simplebuffer.Container
+}
+
+// LoadContainer takes a message byte slice payload and loads it into a container ready to be decoded
+func LoadContainer(b []byte) (out Container) {
+	out.Data = b
+	return
+}
+
+// Get returns the containers carrying the serialized block in segments, which are sent as separate messages
+func Get(block *util.Block) (out []*simplebuffer.Container, err error) {
+	var b []byte
+	if b, err = block.Bytes(); Check(err) {
+		return
+	}
+	count := (len(b) + SegmentSize - 1) / SegmentSize
+	if count > 1<<16-1 {
+		err = errors.New("block is too large to push")
+		return
+	}
+	for i := 0; i < count; i++ {
+		end := (i + 1) * SegmentSize
+		if end > len(b) {
+			end = len(b)
+		}
+		out = append(out, simplebuffer.Serializers{
+			Hash.New().Put(*block.Hash()),
+			Uint16.New().Put(uint16(i)),
+			Uint16.New().Put(uint16(count)),
+			Bytes.New().Put(b[i*SegmentSize : end]),
+		}.CreateContainer(Magic))
+	}
+	return
+}
+
+// GetHash returns the hash of the block the segment belongs to
+func (j *Container) GetHash() *chainhash.Hash {
+	return Hash.New().DecodeOne(j.Get(0)).Get()
+}
+
+// GetIndex returns the position of the segment in the block
+func (j *Container) GetIndex() uint16 {
+	return Uint16.New().DecodeOne(j.Get(1)).Get()
+}
+
+// GetCount returns the number of segments the block is split into
+func (j *Container) GetCount() uint16 {
+	return Uint16.New().DecodeOne(j.Get(2)).Get()
+}
+
+// GetSegment returns the bytes of the serialized block carried by the segment
+func (j *Container) GetSegment() []byte {
+	return Bytes.New().DecodeOne(j.Get(3)).Get()
+}
+
+// partial is a block whose segments are still arriving
+type partial struct {
+	segments [][]byte
+	received int
+	first    time.Time
+}
+
+// Assembler collects the segments of pushed blocks until the blocks are complete. It is not safe for concurrent
+// access, which the transport handlers don't need as they run on the single listener goroutine.
+type Assembler struct {
+	partials map[chainhash.Hash]*partial
+}
+
+// NewAssembler returns an empty Assembler
+func NewAssembler() *Assembler {
+	return &Assembler{partials: make(map[chainhash.Hash]*partial)}
+}
+
+// Add stores the segment in the container, and returns the block once all of its segments have been received. Blocks
+// that don't complete within a minute are dropped, as a segment got lost and the block will arrive from a peer.
+func (a *Assembler) Add(j Container) (block *util.Block, err error) {
+	for hash, p := range a.partials {
+		if time.Since(p.first) > assemblyTimeout {
+			delete(a.partials, hash)
+		}
+	}
+	hash, index, count := *j.GetHash(), int(j.GetIndex()), int(j.GetCount())
+	p, ok := a.partials[hash]
+	if !ok {
+		p = &partial{segments: make([][]byte, count), first: time.Now()}
+		a.partials[hash] = p
+	}
+	if len(p.segments) != count || index >= count {
+		delete(a.partials, hash)
+		err = errors.New("inconsistent block segment received")
+		return
+	}
+	if p.segments[index] != nil {
+		return
+	}
+	p.segments[index] = j.GetSegment()
+	p.received++
+	if p.received < count {
+		return
+	}
+	delete(a.partials, hash)
+	var b []byte
+	for i := range p.segments {
+		b = append(b, p.segments[i]...)
+	}
+	if block, err = util.NewBlockFromBytes(b); Check(err) {
+		return
+	}
+	if !block.Hash().IsEqual(&hash) {
+		block, err = nil, errors.New("assembled block does not match the pushed block hash")
+	}
+	return
+}
+
+// Forget drops the segments received for the block with the passed hash
+func (a *Assembler) Forget(hash *chainhash.Hash) {
+	delete(a.partials, *hash)
+}
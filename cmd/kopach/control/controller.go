@@ -4,15 +4,17 @@ import (
 	"container/ring"
 	"errors"
 	"fmt"
-	"math/rand"
 	"net"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/VividCortex/ewma"
 	"go.uber.org/atomic"
 
 	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/cmd/kopach/control/blkpush"
 	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/kopach/control/job"
 	"github.com/p9c/pod/cmd/kopach/control/p2padvt"
@@ -23,9 +25,10 @@ import (
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/mining"
 	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
 	"github.com/p9c/pod/pkg/coding/simplebuffer/Uint16"
+	"github.com/p9c/pod/pkg/comm/nodeauth"
 	"github.com/p9c/pod/pkg/comm/transport"
-	rav "github.com/p9c/pod/pkg/data/ring"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
@@ -34,11 +37,16 @@ const (
 	MaxDatagramSize      = 8192
 	UDP4MulticastAddress = "224.0.0.1:11049"
 	BufferSize           = 4096
+	// KeyFileName is the name of the file in the network data directory that holds the identity key the controller
+	// signs what it broadcasts with, so miners can tell its work from work sent in its name
+	KeyFileName = "controller_private_key"
 )
 
 type Controller struct {
 	multiConn *transport.Channel
-	// uniConn                *transport.Channel
+	// uniConn receives the registrations of kopach on other networks, which are sent the work over it
+	uniConn                *transport.Channel
+	miners                 *miners
 	active                 atomic.Bool
 	quit                   chan struct{}
 	cx                     *conte.Xt
@@ -58,12 +66,36 @@ type Controller struct {
 	began                  time.Time
 	otherNodes             map[string]time.Time
 	listenPort             int
-	hashCount              atomic.Uint64
-	hashSampleBuf          *rav.BufferUint64
 	lastNonce              int32
+	assembler              *blkpush.Assembler
+	// lanBlocks holds the blocks received from other nodes on the LAN, which are not pushed back to them
+	lanBlocks   map[chainhash.Hash]time.Time
+	lanBlocksMx sync.Mutex
+}
+
+// loadKey loads the identity key of the controller from the network data directory, creating it the first time
+func loadKey(cx *conte.Xt) (key *ec.PrivateKey, err error) {
+	dir := filepath.Join(*cx.Config.DataDir, cx.ActiveNet.Name)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return
+	}
+	var created bool
+	if key, created, err = nodeauth.LoadOrCreateKey(filepath.Join(dir, KeyFileName)); err != nil {
+		return
+	}
+	if created {
+		Info("created a new identity key for the miner controller")
+	}
+	Infof("the controller key of this node is %s, add it to controllerallow for kopach to only mine work from it",
+		nodeauth.KeyString(key.PubKey()))
+	return
 }
 
 func Run(cx *conte.Xt) (quit chan struct{}) {
+	if *cx.Config.NoMining {
+		Info("not running controller with mining turned off")
+		return
+	}
 	mining := true
 	cx.Controller.Store(true)
 	if len(cx.StateCfg.ActiveMiningAddrs) < 1 {
@@ -84,13 +116,15 @@ func Run(cx *conte.Xt) (quit chan struct{}) {
 		cx:                     cx,
 		sendAddresses:          []*net.UDPAddr{},
 		submitChan:             make(chan []byte),
-		blockTemplateGenerator: getBlkTemplateGenerator(cx),
+		blockTemplateGenerator: GetBlkTemplateGenerator(cx),
 		coinbases:              make(map[int32]*util.Tx),
 		buffer:                 ring.New(BufferSize),
 		began:                  time.Now(),
 		otherNodes:             make(map[string]time.Time),
 		listenPort:             int(Uint16.GetActualPort(*cx.Config.Controller)),
-		hashSampleBuf:          rav.NewBufferUint64(100),
+		assembler:              blkpush.NewAssembler(),
+		lanBlocks:              make(map[chainhash.Hash]time.Time),
+		miners:                 newMiners(),
 	}
 	quit = ctrl.quit
 	ctrl.lastTxUpdate.Store(time.Now().UnixNano())
@@ -98,8 +132,13 @@ func Run(cx *conte.Xt) (quit chan struct{}) {
 	ctrl.height.Store(0)
 	ctrl.active.Store(false)
 	var err error
+	var key *ec.PrivateKey
+	if key, err = loadKey(cx); Check(err) {
+		close(ctrl.quit)
+		return
+	}
 	ctrl.multiConn, err = transport.NewBroadcastChannel("controller",
-		ctrl, *cx.Config.MinerPass,
+		ctrl, *cx.Config.MinerPass, &transport.Auth{Key: key},
 		transport.DefaultPort, MaxDatagramSize, handlersMulticast,
 		ctrl.quit)
 	if err != nil {
@@ -107,6 +146,14 @@ func Run(cx *conte.Xt) (quit chan struct{}) {
 		close(ctrl.quit)
 		return
 	}
+	if *cx.Config.MinerListen != "" {
+		if ctrl.uniConn, err = transport.NewUnicastListener("controllerunicast", ctrl, *cx.Config.MinerPass,
+			&transport.Auth{Key: key}, *cx.Config.MinerListen, MaxDatagramSize, handlersUnicast, ctrl.quit); Check(err) {
+			close(ctrl.quit)
+			return
+		}
+		Info("listening for kopach to register on", ctrl.uniConn.Receiver.LocalAddr())
+	}
 	pM := pause.GetPauseContainer(cx)
 	var pauseShards [][]byte
 	if pauseShards = transport.GetShards(pM.Data); Check(err) {
@@ -117,10 +164,7 @@ func Run(cx *conte.Xt) (quit chan struct{}) {
 	interrupt.AddHandler(func() {
 		Debug("miner controller shutting down")
 		ctrl.active.Store(false)
-		err := ctrl.multiConn.SendMany(pause.PauseMagic, pauseShards)
-		if err != nil {
-			Error(err)
-		}
+		err := ctrl.broadcast(pause.PauseMagic, pauseShards)
 		if err = ctrl.multiConn.Close(); Check(err) {
 		}
 		close(ctrl.quit)
@@ -137,6 +181,9 @@ func Run(cx *conte.Xt) (quit chan struct{}) {
 		go rebroadcaster(ctrl)
 		go submitter(ctrl)
 	}
+	if *cx.Config.LANBlockPush {
+		cx.RealNode.Chain.Subscribe(ctrl.getBlockPusher())
+	}
 	go advertiser(ctrl)
 	factor := 10
 	ticker := time.NewTicker(time.Second * time.Duration(factor))
@@ -152,7 +199,12 @@ func Run(cx *conte.Xt) (quit chan struct{}) {
 						ctrl.active.Store(true)
 					}
 				}
-				Debugf("cluster hashrate %.2f", ctrl.HashReport()/float64(factor))
+				cx.MinerStats.Tick(time.Now())
+				Debugf("cluster hashrate %.2f", cx.MinerStats.Hashrate())
+				if ctrl.uniConn != nil {
+					miners, workers := ctrl.miners.count()
+					Debug(miners, "kopach registered over unicast with", workers, "workers")
+				}
 			case <-ctrl.quit:
 				Debug("quitting on close quit channel")
 				cont = false
@@ -174,26 +226,6 @@ func Run(cx *conte.Xt) (quit chan struct{}) {
 	return
 }
 
-func (c *Controller) HashReport() float64 {
-	c.hashSampleBuf.Add(c.hashCount.Load())
-	av := ewma.NewMovingAverage()
-	var i int
-	var prev uint64
-	if err := c.hashSampleBuf.ForEach(func(v uint64) error {
-		if i < 1 {
-			prev = v
-		} else {
-			interval := v - prev
-			av.Add(float64(interval))
-			prev = v
-		}
-		i++
-		return nil
-	}); Check(err) {
-	}
-	return av.Value()
-}
-
 var handlersMulticast = transport.Handlers{
 	// Solutions submitted by workers
 	string(sol.SolutionMagic): func(ctx interface{}, src net.Addr, dst string,
@@ -213,6 +245,7 @@ var handlersMulticast = transport.Handlers{
 		if !msgBlock.Header.PrevBlock.IsEqual(&c.cx.RPCServer.Cfg.Chain.
 			BestSnapshot().Hash) {
 			Debug("block submitted by kopach miner worker is stale")
+			c.cx.MinerStats.AddRejected(src.String(), time.Now())
 			// c.UpdateAndSendTemplate()
 			return
 		}
@@ -220,6 +253,7 @@ var handlersMulticast = transport.Handlers{
 		cb, ok := c.coinbases[msgBlock.Header.Version]
 		if !ok {
 			Debug("coinbases not found", cb)
+			c.cx.MinerStats.AddRejected(src.String(), time.Now())
 			return
 		}
 		cbs := []*util.Tx{cb}
@@ -229,15 +263,14 @@ var handlersMulticast = transport.Handlers{
 			msgBlock.Transactions = append(msgBlock.Transactions, txs[i].MsgTx())
 		}
 		// set old blocks to pause and send pause directly as block is probably a solution
-		err = c.multiConn.SendMany(pause.PauseMagic, c.pauseShards)
-		if err != nil {
-			Error(err)
+		if err = c.broadcast(pause.PauseMagic, c.pauseShards); err != nil {
 			return
 		}
 		block := util.NewBlock(msgBlock)
 		isOrphan, err := c.cx.RealNode.SyncManager.ProcessBlock(block,
 			blockchain.BFNone)
 		if err != nil {
+			c.cx.MinerStats.AddRejected(src.String(), time.Now())
 			// Anything other than a rule violation is an unexpected error, so log that error as an internal error.
 			if _, ok := err.(blockchain.RuleError); !ok {
 				Warnf(
@@ -254,6 +287,7 @@ var handlersMulticast = transport.Handlers{
 			}
 		}
 		Trace("the block was accepted")
+		c.cx.MinerStats.AddSolution(src.String(), time.Now())
 		coinbaseTx := block.MsgBlock().Transactions[0].TxOut[0]
 		prevHeight := block.Height() - 1
 		prevBlock, _ := c.cx.RealNode.Chain.BlockByHeight(prevHeight)
@@ -307,6 +341,36 @@ var handlersMulticast = transport.Handlers{
 		c.cx.OtherNodes.Store(int32(len(c.otherNodes)))
 		return
 	},
+	// blocks pushed by other nodes on the LAN
+	string(blkpush.Magic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
+		c := ctx.(*Controller)
+		if !c.active.Load() {
+			return
+		}
+		j := blkpush.LoadContainer(b)
+		hash := j.GetHash()
+		// Blocks the node already has, which includes the ones it pushed itself, are not assembled again.
+		var have bool
+		if have, err = c.cx.RealNode.Chain.HaveBlock(hash); err != nil || have {
+			c.assembler.Forget(hash)
+			return
+		}
+		var block *util.Block
+		if block, err = c.assembler.Add(j); err != nil || block == nil {
+			return
+		}
+		c.addLANBlock(hash)
+		Debug("received block", hash, "pushed from", src)
+		isOrphan, err := c.cx.RealNode.SyncManager.ProcessBlock(block, blockchain.BFNone)
+		if err != nil {
+			Warn("block pushed by lan peer rejected:", err)
+			return nil
+		}
+		if isOrphan {
+			Debug("block pushed by lan peer is an orphan")
+		}
+		return
+	},
 	// hashrate reports from workers
 	string(hashrate.HashrateMagic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
 		c := ctx.(*Controller)
@@ -321,8 +385,9 @@ var handlersMulticast = transport.Handlers{
 			return
 		}
 		c.lastNonce = nonce
-		// add to total hash counts
-		c.hashCount.Store(c.hashCount.Load() + uint64(count))
+		// add to the hash counts of the worker on the algorithm it reported
+		c.cx.MinerStats.AddHashes(src.String(), fork.GetAlgoName(hp.GetVersion(), hp.GetHeight()), uint64(count),
+			time.Now())
 		return
 	},
 }
@@ -346,10 +411,7 @@ func (c *Controller) sendNewBlockTemplate() (err error) {
 		Warn("jobShards", shardsLen)
 		return fmt.Errorf("jobShards len %d", shardsLen)
 	}
-	err = c.multiConn.SendMany(job.Magic, jobShards)
-	if err != nil {
-		Error(err)
-	}
+	err = c.broadcast(job.Magic, jobShards)
 	c.prevHash.Store(&template.Block.Header.PrevBlock)
 	c.oldBlocks.Store(jobShards)
 	c.lastGenerated.Store(time.Now().UnixNano())
@@ -364,12 +426,16 @@ func getNewBlockTemplate(cx *conte.Xt, bTG *mining.BlkTmplGenerator,
 		Debug("no mining addresses")
 		return
 	}
-	// Choose a payment address at random.
-	rand.Seed(time.Now().UnixNano())
-	payToAddr := cx.StateCfg.ActiveMiningAddrs[rand.Intn(len(*cx.Config.
-		MiningAddrs))]
+	// Choose a payment address by the payout policy, the coinbase of each algorithm is paid to its own when the job
+	// is made
+	payToAddr, err := cx.StateCfg.Payout.Address(cx.StateCfg.ActiveMiningAddrs, fork.SHA256d,
+		cx.RealNode.Chain.BestSnapshot().Height+1)
+	if err != nil {
+		Error(err)
+		return
+	}
 	Trace("calling new block template")
-	template, err := bTG.NewBlockTemplate(0, payToAddr,
+	template, err = bTG.NewBlockTemplate(0, payToAddr,
 		fork.SHA256d)
 	if err != nil {
 		Error(err)
@@ -379,7 +445,8 @@ func getNewBlockTemplate(cx *conte.Xt, bTG *mining.BlkTmplGenerator,
 	return
 }
 
-func getBlkTemplateGenerator(cx *conte.Xt) *mining.BlkTmplGenerator {
+// GetBlkTemplateGenerator returns a block template generator for the node with the block policy of the configuration
+func GetBlkTemplateGenerator(cx *conte.Xt) *mining.BlkTmplGenerator {
 	policy := mining.Policy{
 		BlockMinWeight:    uint32(*cx.Config.BlockMinWeight),
 		BlockMaxWeight:    uint32(*cx.Config.BlockMaxWeight),
@@ -389,9 +456,11 @@ func getBlkTemplateGenerator(cx *conte.Xt) *mining.BlkTmplGenerator {
 		TxMinFreeFee:      cx.StateCfg.ActiveMinRelayTxFee,
 	}
 	s := cx.RealNode
-	return mining.NewBlkTmplGenerator(&policy,
+	generator := mining.NewBlkTmplGenerator(&policy,
 		s.ChainParams, s.TxMemPool, s.Chain, s.TimeSource,
 		s.SigCache, s.HashCache)
+	generator.CoinbaseData = []byte(*cx.Config.CoinbaseData)
+	return generator
 }
 
 func advertiser(ctrl *Controller) {
@@ -444,10 +513,7 @@ out:
 			if !ok {
 				Debug("template is nil")
 			}
-			err := c.multiConn.SendMany(job.Magic, oB)
-			if err != nil {
-				Error(err)
-			}
+			_ = c.broadcast(job.Magic, oB)
 			c.oldBlocks.Store(oB)
 			break
 		case <-c.quit:
@@ -499,6 +565,62 @@ func (c *Controller) getNotifier() func(n *blockchain.Notification) {
 	}
 }
 
+// getBlockPusher returns a chain notification handler that pushes the blocks connected to the chain to the other
+// nodes on the LAN, unless they were received from the LAN
+func (c *Controller) getBlockPusher() func(n *blockchain.Notification) {
+	return func(n *blockchain.Notification) {
+		if !c.active.Load() || !c.Ready.Load() || n.Type != blockchain.NTBlockConnected {
+			return
+		}
+		block, ok := n.Data.(*util.Block)
+		if !ok {
+			Warn("chain connected notification is not a block")
+			return
+		}
+		if c.isLANBlock(block.Hash()) {
+			return
+		}
+		// Sending is left out of the notification so it doesn't hold up the chain.
+		go c.pushBlock(block)
+	}
+}
+
+// pushBlock sends the block to the other nodes on the LAN
+func (c *Controller) pushBlock(block *util.Block) {
+	containers, err := blkpush.Get(block)
+	if err != nil {
+		Error(err)
+		return
+	}
+	Debug("pushing block", block.Hash(), "in", len(containers), "segments")
+	for i := range containers {
+		if err = c.multiConn.SendMany(blkpush.Magic, transport.GetShards(containers[i].Data)); Check(err) {
+			return
+		}
+	}
+}
+
+// addLANBlock records that the block with the passed hash was received from another node on the LAN. Records older
+// than a minute are dropped, as by then the block has been connected or rejected.
+func (c *Controller) addLANBlock(hash *chainhash.Hash) {
+	c.lanBlocksMx.Lock()
+	defer c.lanBlocksMx.Unlock()
+	for h, t := range c.lanBlocks {
+		if time.Since(t) > time.Minute {
+			delete(c.lanBlocks, h)
+		}
+	}
+	c.lanBlocks[*hash] = time.Now()
+}
+
+// isLANBlock returns whether the block with the passed hash was received from another node on the LAN
+func (c *Controller) isLANBlock(hash *chainhash.Hash) bool {
+	c.lanBlocksMx.Lock()
+	defer c.lanBlocksMx.Unlock()
+	_, ok := c.lanBlocks[*hash]
+	return ok
+}
+
 func (c *Controller) UpdateAndSendTemplate() {
 	c.coinbases = make(map[int32]*util.Tx)
 	template := getNewBlockTemplate(c.cx, c.blockTemplateGenerator)
@@ -518,8 +640,7 @@ func (c *Controller) UpdateAndSendTemplate() {
 		}
 		shards := transport.GetShards(mC.Data)
 		c.oldBlocks.Store(shards)
-		if err := c.multiConn.SendMany(job.Magic, shards); Check(err) {
-		}
+		_ = c.broadcast(job.Magic, shards)
 		c.prevHash.Store(&template.Block.Header.PrevBlock)
 		c.lastGenerated.Store(time.Now().UnixNano())
 		c.lastTxUpdate.Store(time.Now().UnixNano())
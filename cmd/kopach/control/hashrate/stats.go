@@ -0,0 +1,201 @@
+package hashrate
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VividCortex/ewma"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// StatsForget is how long a worker that has stopped reporting stays in the statistics. Its hashes, solutions and
+// rejected shares are kept in the totals after it is dropped.
+const StatsForget = time.Minute
+
+// Stats aggregates the hashes, solutions and rejected shares of miner workers, with a moving average of the hashrate of
+// each worker on each algorithm. Tick must be called at a steady interval to fold the hashes counted since the last
+// tick into the averages.
+type Stats struct {
+	mx       sync.Mutex
+	started  time.Time
+	lastTick time.Time
+	workers  map[string]*workerStats
+	// totals holds the counts of workers that have been dropped
+	totals btcjson.MinerWorkerStatsResult
+}
+
+type workerStats struct {
+	algos     map[string]*algoStats
+	solutions uint64
+	rejected  uint64
+	lastSeen  time.Time
+}
+
+type algoStats struct {
+	hashes  uint64
+	pending uint64
+	rate    ewma.MovingAverage
+}
+
+// NewStats returns empty statistics started at the given time.
+func NewStats(now time.Time) *Stats {
+	return &Stats{started: now, lastTick: now, workers: make(map[string]*workerStats)}
+}
+
+// AddHashes counts hashes done by a worker on an algorithm.
+func (s *Stats) AddHashes(worker, algo string, count uint64, now time.Time) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	w := s.worker(worker, now)
+	a, ok := w.algos[algo]
+	if !ok {
+		a = &algoStats{rate: ewma.NewMovingAverage()}
+		w.algos[algo] = a
+	}
+	a.hashes += count
+	a.pending += count
+}
+
+// AddSolution counts a block found by a worker that was accepted.
+func (s *Stats) AddSolution(worker string, now time.Time) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.worker(worker, now).solutions++
+}
+
+// AddRejected counts a block found by a worker that was rejected or stale.
+func (s *Stats) AddRejected(worker string, now time.Time) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.worker(worker, now).rejected++
+}
+
+// Tick folds the hashes counted since the last tick into the hashrate averages and drops workers that have not
+// reported for StatsForget.
+func (s *Stats) Tick(now time.Time) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	elapsed := now.Sub(s.lastTick).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	s.lastTick = now
+	for id, w := range s.workers {
+		if now.Sub(w.lastSeen) > StatsForget {
+			r := w.result(id)
+			s.totals.Hashes += r.Hashes
+			s.totals.Solutions += r.Solutions
+			s.totals.Rejected += r.Rejected
+			delete(s.workers, id)
+			continue
+		}
+		for _, a := range w.algos {
+			a.rate.Add(float64(a.pending) / elapsed)
+			a.pending = 0
+		}
+	}
+}
+
+// Hashrate returns the total hashrate of the workers in hashes per second.
+func (s *Stats) Hashrate() (rate float64) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	for _, w := range s.workers {
+		for _, a := range w.algos {
+			rate += a.rate.Value()
+		}
+	}
+	return
+}
+
+// Total returns the statistics of all the workers combined as those of a single worker with the given id, which is
+// how a worker process reports its own to the miner that spawned it.
+func (s *Stats) Total(id string, now time.Time) btcjson.MinerWorkerStatsResult {
+	r := s.Report(now)
+	return btcjson.MinerWorkerStatsResult{
+		ID:        id,
+		Hashrate:  r.Hashrate,
+		Hashes:    r.Hashes,
+		Solutions: r.Solutions,
+		Rejected:  r.Rejected,
+		LastSeen:  now.Unix(),
+		Algos:     r.Algos,
+	}
+}
+
+// Report returns the statistics of all the workers, and their totals.
+func (s *Stats) Report(now time.Time) (out btcjson.GetMinerStatsResult) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	workers := make([]btcjson.MinerWorkerStatsResult, 0, len(s.workers))
+	for id, w := range s.workers {
+		workers = append(workers, w.result(id))
+	}
+	out = Combine(workers, s.started, now)
+	out.Hashes += s.totals.Hashes
+	out.Solutions += s.totals.Solutions
+	out.Rejected += s.totals.Rejected
+	return
+}
+
+// Combine totals the statistics of workers, sorted by id, into a report.
+func Combine(workers []btcjson.MinerWorkerStatsResult, started, now time.Time) (out btcjson.GetMinerStatsResult) {
+	out.Uptime = int64(now.Sub(started).Seconds())
+	out.Workers = workers
+	sort.Slice(out.Workers, func(i, j int) bool { return out.Workers[i].ID < out.Workers[j].ID })
+	algos := make(map[string]*btcjson.MinerAlgoStatsResult)
+	for _, w := range workers {
+		out.Hashrate += w.Hashrate
+		out.Hashes += w.Hashes
+		out.Solutions += w.Solutions
+		out.Rejected += w.Rejected
+		for _, a := range w.Algos {
+			total, ok := algos[a.Algo]
+			if !ok {
+				total = &btcjson.MinerAlgoStatsResult{Algo: a.Algo}
+				algos[a.Algo] = total
+			}
+			total.Hashrate += a.Hashrate
+			total.Hashes += a.Hashes
+		}
+	}
+	out.Algos = sortedAlgos(algos)
+	return
+}
+
+// worker returns the statistics of a worker, adding it if it is new, and marks it as seen.
+func (s *Stats) worker(id string, now time.Time) *workerStats {
+	w, ok := s.workers[id]
+	if !ok {
+		w = &workerStats{algos: make(map[string]*algoStats)}
+		s.workers[id] = w
+	}
+	w.lastSeen = now
+	return w
+}
+
+func (w *workerStats) result(id string) (out btcjson.MinerWorkerStatsResult) {
+	out.ID = id
+	out.Solutions = w.solutions
+	out.Rejected = w.rejected
+	out.LastSeen = w.lastSeen.Unix()
+	algos := make(map[string]*btcjson.MinerAlgoStatsResult)
+	for name, a := range w.algos {
+		algos[name] = &btcjson.MinerAlgoStatsResult{Algo: name, Hashrate: a.rate.Value(), Hashes: a.hashes}
+		out.Hashrate += a.rate.Value()
+		out.Hashes += a.hashes
+	}
+	out.Algos = sortedAlgos(algos)
+	return
+}
+
+func sortedAlgos(algos map[string]*btcjson.MinerAlgoStatsResult) (out []btcjson.MinerAlgoStatsResult) {
+	out = make([]btcjson.MinerAlgoStatsResult, 0, len(algos))
+	for _, a := range algos {
+		out = append(out, *a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Algo < out[j].Algo })
+	return
+}
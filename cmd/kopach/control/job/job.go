@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"net"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/p9c/pod/app/conte"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/fork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/coding/simplebuffer"
 	"github.com/p9c/pod/pkg/coding/simplebuffer/Bitses"
@@ -17,6 +20,7 @@ import (
 	"github.com/p9c/pod/pkg/coding/simplebuffer/Hashes"
 	"github.com/p9c/pod/pkg/coding/simplebuffer/IPs"
 	"github.com/p9c/pod/pkg/coding/simplebuffer/Int32"
+	"github.com/p9c/pod/pkg/coding/simplebuffer/String"
 	"github.com/p9c/pod/pkg/coding/simplebuffer/Uint16"
 	"github.com/p9c/pod/pkg/util"
 )
@@ -37,6 +41,8 @@ type Job struct {
 	Bitses          blockchain.TargetBits
 	Hashes          map[int32]*chainhash.Hash
 	CoinBases       map[int32]*util.Tx
+	PayoutPolicy    string
+	Payouts         map[int32]string
 }
 
 // Get returns a message broadcast by a node and each field is decoded where possible avoiding memory allocation
@@ -90,9 +96,7 @@ func Get(cx *conte.Xt, mB *util.Block, msg simplebuffer.Serializers, cbs *map[in
 	mTS := make(map[int32]*chainhash.Hash)
 	txs := mB.Transactions()[0]
 	rtx := mB.Transactions()[1:]
-	for i, v := range rtx {
-		txr[i] = v
-	}
+	txr = append(txr, rtx...)
 	nbH := bH
 	if (cx.ActiveNet.Net == wire.MainNet &&
 		nbH == fork.List[1].ActivationHeight) ||
@@ -100,10 +104,21 @@ func Get(cx *conte.Xt, mB *util.Block, msg simplebuffer.Serializers, cbs *map[in
 			nbH == fork.List[1].TestnetStart) {
 		nbH++
 	}
+	// the coinbase of each algorithm pays to the address the payout policy picks for it
+	payouts := make(map[int32]string)
 	for i := range bitsMap {
 		val = blockchain.CalcBlockSubsidy(nbH, cx.ActiveNet, i)
 		txc := txs.MsgTx().Copy()
 		txc.TxOut[len(txc.TxOut)-1].Value = val
+		var payTo util.Address
+		if payTo, err = cx.StateCfg.Payout.Address(cx.StateCfg.ActiveMiningAddrs, fork.GetAlgoName(i, nbH),
+			bH); !Check(err) {
+			var pkScript []byte
+			if pkScript, err = txscript.PayToAddrScript(payTo); !Check(err) {
+				txc.TxOut[len(txc.TxOut)-1].PkScript = pkScript
+				payouts[i] = payTo.EncodeAddress()
+			}
+		}
 		txx := util.NewTx(txc.Copy())
 		// Traces(txs)
 		(*cbs)[i] = txx
@@ -118,6 +133,7 @@ func Get(cx *conte.Xt, mB *util.Block, msg simplebuffer.Serializers, cbs *map[in
 	mHashes := Hashes.NewHashes()
 	mHashes.Put(mTS)
 	msg = append(msg, mHashes)
+	msg = append(msg, String.New().Put(cx.StateCfg.Payout.PolicyName()), String.New().Put(encodePayouts(payouts)))
 	// previously were sending blocks, no need for that really miner only needs
 	// valid block headers
 	// txs := mB.MsgBlock().Transactions
@@ -168,6 +184,49 @@ func (j *Container) GetHashes() (out map[int32]*chainhash.Hash) {
 	return Hashes.NewHashes().DecodeOne(j.Get(7)).Get()
 }
 
+// GetPayoutPolicy returns the payout policy of the controller, or an empty string if the job is from a controller
+// that doesn't send it
+func (j *Container) GetPayoutPolicy() string {
+	if j.Count() < 10 {
+		return ""
+	}
+	return String.New().DecodeOne(j.Get(8)).Get()
+}
+
+// GetPayouts returns the addresses the coinbase of each version pays to
+func (j *Container) GetPayouts() map[int32]string {
+	if j.Count() < 10 {
+		return nil
+	}
+	return decodePayouts(String.New().DecodeOne(j.Get(9)).Get())
+}
+
+// encodePayouts encodes the addresses of each version as space separated version=address pairs
+func encodePayouts(payouts map[int32]string) string {
+	var pairs []string
+	for ver, addr := range payouts {
+		pairs = append(pairs, fmt.Sprint(ver, "=", addr))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+func decodePayouts(s string) (payouts map[int32]string) {
+	payouts = make(map[int32]string)
+	for _, pair := range strings.Fields(s) {
+		split := strings.SplitN(pair, "=", 2)
+		if len(split) != 2 {
+			continue
+		}
+		ver, err := strconv.ParseInt(split[0], 10, 32)
+		if err != nil {
+			continue
+		}
+		payouts[int32(ver)] = split[1]
+	}
+	return
+}
+
 func (j *Container) String() (s string) {
 	s += fmt.Sprint("\ntype '"+string(Magic)+"' elements:", j.Count())
 	s += "\n"
@@ -211,6 +270,13 @@ func (j *Container) String() (s string) {
 		s += fmt.Sprintf("  %2d %s\n", sortedBitses[i],
 			hashes[int32(sortedBitses[i])].String())
 	}
+	s += fmt.Sprint("9 Payout policy: ", j.GetPayoutPolicy())
+	s += "\n"
+	s += "10 Payouts:\n"
+	payouts := j.GetPayouts()
+	for i := range sortedBitses {
+		s += fmt.Sprintf("  %2d %s\n", sortedBitses[i], payouts[int32(sortedBitses[i])])
+	}
 
 	// s += spew.Sdump(j.GetHashes())
 	return
@@ -231,6 +297,8 @@ func (j *Container) Struct() (out Job) {
 		PrevBlockHash:   j.GetPrevBlockHash(),
 		Bitses:          j.GetBitses(),
 		Hashes:          j.GetHashes(),
+		PayoutPolicy:    j.GetPayoutPolicy(),
+		Payouts:         j.GetPayouts(),
 	}
 	return
 }
@@ -0,0 +1,53 @@
+// Package register is a message type for Simplebuffers sent by kopach to the controllers it is configured with, which
+// are not reached by multicast. A controller sends its work to the address a registration came from until the kopach
+// stops registering
+package register
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/coding/simplebuffer"
+	"github.com/p9c/pod/pkg/coding/simplebuffer/Int32"
+	"github.com/p9c/pod/pkg/coding/simplebuffer/String"
+	"github.com/p9c/pod/pkg/coding/simplebuffer/Time"
+)
+
+var Magic = []byte{'r', 'e', 'g', 'i'}
+
+const (
+	// Interval is how often kopach registers with each of its controllers
+	Interval = 5 * time.Second
+	// Timeout is how long a controller keeps sending work to a kopach that has stopped registering
+	Timeout = 3 * Interval
+)
+
+type Container struct {
+	simplebuffer.Container
+}
+
+// Get returns the registration of the kopach with the id running the number of workers
+func Get(id string, workers int32) Container {
+	return Container{*simplebuffer.Serializers{
+		Time.New().Put(time.Now()),
+		String.New().Put(id),
+		Int32.New().Put(workers),
+	}.CreateContainer(Magic)}
+}
+
+// LoadContainer takes a message byte slice payload and loads it into a container ready to be decoded
+func LoadContainer(b []byte) (out Container) {
+	out.Data = b
+	return
+}
+
+func (j *Container) GetTime() time.Time {
+	return Time.New().DecodeOne(j.Get(0)).Get()
+}
+
+func (j *Container) GetID() string {
+	return String.New().DecodeOne(j.Get(1)).Get()
+}
+
+func (j *Container) GetWorkers() int32 {
+	return Int32.New().DecodeOne(j.Get(2)).Get()
+}
@@ -0,0 +1,104 @@
+package control
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/cmd/kopach/control/hashrate"
+	"github.com/p9c/pod/cmd/kopach/control/job"
+	"github.com/p9c/pod/cmd/kopach/control/register"
+	"github.com/p9c/pod/cmd/kopach/control/sol"
+	"github.com/p9c/pod/pkg/comm/transport"
+)
+
+// miner is a kopach registered with the controller over unicast
+type miner struct {
+	addr    *net.UDPAddr
+	id      string
+	workers int32
+	last    time.Time
+}
+
+// miners are the kopach registered with the controller over unicast, by the address their registrations come from
+type miners struct {
+	mx sync.Mutex
+	m  map[string]*miner
+}
+
+func newMiners() *miners {
+	return &miners{m: make(map[string]*miner)}
+}
+
+// add records a registration, returning whether the kopach was not registered before
+func (m *miners) add(addr *net.UDPAddr, id string, workers int32, now time.Time) (added bool) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	key := addr.String()
+	_, registered := m.m[key]
+	m.m[key] = &miner{addr: addr, id: id, workers: workers, last: now}
+	return !registered
+}
+
+// live returns the addresses of the kopach that registered within register.Timeout, forgetting the rest
+func (m *miners) live(now time.Time) (addrs []*net.UDPAddr) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	for key, mnr := range m.m {
+		if now.Sub(mnr.last) > register.Timeout {
+			Info("kopach", mnr.id, "at", key, "stopped registering")
+			delete(m.m, key)
+			continue
+		}
+		addrs = append(addrs, mnr.addr)
+	}
+	return
+}
+
+// count returns the number of kopach registered and the workers they run
+func (m *miners) count() (miners, workers int) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	for _, mnr := range m.m {
+		workers += int(mnr.workers)
+	}
+	return len(m.m), workers
+}
+
+// handlersUnicast handle the registrations of kopach on other networks, and their solutions and hashrate reports,
+// which they relay from their workers
+var handlersUnicast = transport.Handlers{
+	string(register.Magic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
+		c := ctx.(*Controller)
+		addr, ok := src.(*net.UDPAddr)
+		if !ok {
+			return
+		}
+		r := register.LoadContainer(b)
+		if c.miners.add(addr, r.GetID(), r.GetWorkers(), time.Now()) {
+			Info("kopach", r.GetID(), "at", addr, "registered with", r.GetWorkers(), "workers")
+			// a kopach that just registered is sent the current work rather than waiting for the next rebroadcast
+			if shards, ok := c.oldBlocks.Load().([][]byte); ok && c.active.Load() {
+				if err = c.uniConn.SendManyTo(addr, job.Magic, shards); Check(err) {
+				}
+			}
+		}
+		return
+	},
+	string(sol.SolutionMagic):      handlersMulticast[string(sol.SolutionMagic)],
+	string(hashrate.HashrateMagic): handlersMulticast[string(hashrate.HashrateMagic)],
+}
+
+// broadcast sends the work or pause message over multicast, and to each kopach registered over unicast
+func (c *Controller) broadcast(magic []byte, shards [][]byte) (err error) {
+	if err = c.multiConn.SendMany(magic, shards); Check(err) {
+	}
+	if c.uniConn == nil {
+		return
+	}
+	for _, addr := range c.miners.live(time.Now()) {
+		if err = c.uniConn.SendManyTo(addr, magic, shards); Check(err) {
+		}
+	}
+	return
+}
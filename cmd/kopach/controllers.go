@@ -0,0 +1,206 @@
+package kopach
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/VividCortex/ewma"
+
+	"github.com/p9c/pod/cmd/kopach/control/job"
+)
+
+const (
+	// ControllerTimeout is how long a controller can go without broadcasting a job before it is taken to be down
+	ControllerTimeout = 3 * time.Second
+	// controllerForget is how long a controller that is down stays in the list before it is dropped
+	controllerForget = time.Minute
+	// broadcastInterval is how often controllers broadcast their current job
+	broadcastInterval = time.Second
+)
+
+// ControllerStatus is the state of a controller jobs have been received from, which the GUI is sent
+type ControllerStatus struct {
+	// Addr is the address of the controller listener
+	Addr string
+	// Height is the height of the block of the latest job of the controller
+	Height int32
+	// LastSeen is when the latest job of the controller arrived
+	LastSeen time.Time
+	// Latency is the moving average of how late the broadcasts of the controller arrive, past the interval they are
+	// sent at
+	Latency time.Duration
+	// Jobs is how many jobs have been received from the controller
+	Jobs int
+	// Healthy is whether the controller has broadcast a job within ControllerTimeout
+	Healthy bool
+	// Active is whether the workers are mining on the jobs of the controller
+	Active bool
+}
+
+// controller is a controller jobs have been received from, with its latest job to hand to the workers on failover.
+type controller struct {
+	ControllerStatus
+	firstSeen time.Time
+	latency   ewma.MovingAverage
+	job       *job.Container
+}
+
+// Controllers ranks the controllers jobs are received from and picks the one the workers mine on. The active
+// controller is only replaced when it stops broadcasting or another one is ahead of it on the chain, so the workers
+// don't flip between controllers on the same block.
+type Controllers struct {
+	mx     sync.Mutex
+	list   map[string]*controller
+	active string
+}
+
+// NewControllers returns an empty list of controllers.
+func NewControllers() *Controllers {
+	return &Controllers{list: make(map[string]*controller)}
+}
+
+// Seen records a job from a controller and returns whether the workers should mine on it, which is when the
+// controller is or has just become the active one.
+func (cs *Controllers) Seen(addr string, j *job.Container, now time.Time) (mine, switched bool) {
+	cs.mx.Lock()
+	defer cs.mx.Unlock()
+	c, ok := cs.list[addr]
+	if !ok {
+		c = &controller{firstSeen: now, latency: ewma.NewMovingAverage()}
+		c.Addr = addr
+		cs.list[addr] = c
+	} else if late := now.Sub(c.LastSeen) - broadcastInterval; late > 0 {
+		c.latency.Add(float64(late))
+	} else {
+		c.latency.Add(0)
+	}
+	c.LastSeen = now
+	c.Height = j.GetNewHeight()
+	c.Jobs++
+	c.job = j
+	if cs.active == addr {
+		return true, false
+	}
+	active, ok := cs.list[cs.active]
+	if ok && active.healthy(now) && active.Height >= c.Height {
+		return false, false
+	}
+	if ok {
+		Info("switching from controller", cs.active, "at height", active.Height, "to", addr, "at height", c.Height)
+	} else {
+		Info("mining on jobs from controller", addr)
+	}
+	cs.active = addr
+	return true, true
+}
+
+// Failover replaces the active controller when it has stopped broadcasting, or was dropped, with the best of the
+// healthy controllers, and returns its latest job. It returns nil when there is no healthy controller to mine on.
+func (cs *Controllers) Failover(now time.Time) (addr string, j *job.Container, switched bool) {
+	cs.mx.Lock()
+	defer cs.mx.Unlock()
+	for a, c := range cs.list {
+		if now.Sub(c.LastSeen) > controllerForget {
+			delete(cs.list, a)
+		}
+	}
+	if active, ok := cs.list[cs.active]; ok && active.healthy(now) {
+		return cs.active, active.job, false
+	}
+	previous := cs.active
+	cs.active = ""
+	ranked := cs.ranked(now)
+	if len(ranked) < 1 || !ranked[0].healthy(now) {
+		if previous != "" {
+			Warn("controller", previous, "stopped broadcasting and there is no other controller to fail over to")
+		}
+		return "", nil, previous != ""
+	}
+	cs.active = ranked[0].Addr
+	if previous == "" {
+		Info("mining on jobs from controller", cs.active)
+	} else {
+		Info("controller", previous, "stopped broadcasting, failing over to", cs.active)
+	}
+	return cs.active, ranked[0].job, true
+}
+
+// Down marks a controller as down, when it says it is pausing, so the next failover skips it.
+func (cs *Controllers) Down(addr string) {
+	cs.mx.Lock()
+	defer cs.mx.Unlock()
+	if c, ok := cs.list[addr]; ok {
+		c.LastSeen = time.Time{}
+	}
+}
+
+// Status returns the state of the controllers, best first.
+func (cs *Controllers) Status(now time.Time) (out []ControllerStatus) {
+	cs.mx.Lock()
+	defer cs.mx.Unlock()
+	for _, c := range cs.ranked(now) {
+		status := c.ControllerStatus
+		status.Latency = time.Duration(c.latency.Value())
+		status.Healthy = c.healthy(now)
+		status.Active = c.Addr == cs.active
+		out = append(out, status)
+	}
+	return
+}
+
+// ranked returns the controllers ordered healthy first, then by the highest chain, the lowest latency and the
+// longest known.
+func (cs *Controllers) ranked(now time.Time) (out []*controller) {
+	for _, c := range cs.list {
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.healthy(now) != b.healthy(now) {
+			return a.healthy(now)
+		}
+		if a.Height != b.Height {
+			return a.Height > b.Height
+		}
+		if a.latency.Value() != b.latency.Value() {
+			return a.latency.Value() < b.latency.Value()
+		}
+		return a.firstSeen.Before(b.firstSeen)
+	})
+	return
+}
+
+// healthy returns whether the controller has broadcast a job recently.
+func (c *controller) healthy(now time.Time) bool {
+	return now.Sub(c.LastSeen) <= ControllerTimeout
+}
+
+// mineOn sends a job to the workers, or pauses them if it is nil.
+func (w *Worker) mineOn(j *job.Container) {
+	for i := range w.clients {
+		var err error
+		if j == nil {
+			Debug("sending pause to worker", i)
+			err = w.clients[i].Pause()
+		} else {
+			err = w.clients[i].NewJob(j)
+		}
+		if err != nil {
+			Error(err)
+		}
+	}
+}
+
+// sendStatus sends the state of the controllers to the GUI, replacing any it hasn't read yet.
+func (w *Worker) sendStatus() {
+	status := w.controllers.Status(time.Now())
+	select {
+	case <-w.ControllerChan:
+	default:
+	}
+	select {
+	case w.ControllerChan <- status:
+	default:
+	}
+}
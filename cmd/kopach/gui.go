@@ -16,6 +16,7 @@ import (
 	"github.com/p9c/pod/pkg/gui/fonts/p9fonts"
 	icons "github.com/p9c/pod/pkg/gui/ico/svg"
 	"github.com/p9c/pod/pkg/gui/p9"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
 
@@ -37,6 +38,8 @@ type MinerModel struct {
 	modalScrim, modalClose *p9.Clickable
 	password               *p9.Password
 	threadSlider           *p9.IntSlider
+	controllers            []ControllerStatus
+	stats                  btcjson.GetMinerStatsResult
 }
 
 func (w *Worker) Run() {
@@ -104,6 +107,10 @@ func (w *Worker) Run() {
 			select {
 			case <-minerModel.worker.Update:
 				win.Window.Invalidate()
+			case minerModel.controllers = <-minerModel.worker.ControllerChan:
+				win.Window.Invalidate()
+			case minerModel.stats = <-minerModel.worker.StatsChan:
+				win.Window.Invalidate()
 			}
 		}
 	}()
@@ -122,6 +129,8 @@ func (m *MinerModel) Widget(gtx l.Context) l.Dimensions {
 							Rigid(m.RunControl).
 							Rigid(m.SetThreads).
 							Rigid(m.PreSharedKey).
+							Rigid(m.ActiveController).
+							Rigid(m.WorkerStats).
 							Rigid(m.VSpacer).
 							Rigid(m.H5("found blocks").Fn).
 							Rigid(
@@ -211,7 +220,7 @@ func (m *MinerModel) Header(gtx l.Context) l.Dimensions {
 			).Fn,
 		).Flexed(1,
 			m.Inset(0.5,
-				m.Body1(fmt.Sprintf("%d hash/s", int(m.worker.hashrate))).
+				m.Body1(fmt.Sprintf("%d hash/s", int(m.stats.Hashrate))).
 					Color("DocBg").
 					Alignment(text.End).
 					Fn,
@@ -271,6 +280,55 @@ func (m *MinerModel) PreSharedKey(gtx l.Context) l.Dimensions {
 	).Fn(gtx)
 }
 
+// ActiveController shows the controller the workers are mining on and how many others are standing by to fail over to
+func (m *MinerModel) ActiveController(gtx l.Context) l.Dimensions {
+	status := "no controller"
+	standby := 0
+	for _, c := range m.controllers {
+		if c.Active {
+			status = fmt.Sprintf("%s at height %d, %v late", c.Addr, c.Height, c.Latency.Round(time.Millisecond))
+		} else if c.Healthy {
+			standby++
+		}
+	}
+	if standby > 0 {
+		status += fmt.Sprintf(", %d standing by", standby)
+	}
+	return m.Inset(0.25,
+		m.Flex().Flexed(0.5,
+			m.Body1("controller").
+				Color("DocText").
+				Fn,
+		).Flexed(0.5,
+			m.Body1(status).
+				Color("DocText").
+				Fn,
+		).Fn,
+	).Fn(gtx)
+}
+
+// WorkerStats shows the hashrate of each worker and the blocks they found
+func (m *MinerModel) WorkerStats(gtx l.Context) l.Dimensions {
+	status := "no workers running"
+	if len(m.stats.Workers) > 0 {
+		status = fmt.Sprintf("%d running, %d blocks found", len(m.stats.Workers), m.stats.Solutions)
+		for _, w := range m.stats.Workers {
+			status += fmt.Sprintf("\n%s: %d hash/s, %d found", w.ID, int(w.Hashrate), w.Solutions)
+		}
+	}
+	return m.Inset(0.25,
+		m.Flex().Flexed(0.5,
+			m.Body1("workers").
+				Color("DocText").
+				Fn,
+		).Flexed(0.5,
+			m.Body1(status).
+				Color("DocText").
+				Fn,
+		).Fn,
+	).Fn(gtx)
+}
+
 func (m *MinerModel) BlockInfoModalCloser(gtx l.Context) l.Dimensions {
 	return m.Button(m.modalScrim.SetClick(func() {
 		m.modalOn = false
@@ -0,0 +1,292 @@
+// Package inproc runs the kopach controller and workers as goroutines inside the node. The work is made from the block
+// templates of the node's own generator the same way the controller makes it for kopach, and the blocks the threads
+// solve are processed by the node directly, so there is no worker process or multicast channel to go wrong for a
+// single machine mining solo.
+package inproc
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/cmd/kopach/control/job"
+	"github.com/p9c/pod/cmd/kopach/control/p2padvt"
+	"github.com/p9c/pod/cmd/kopach/worker"
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// work is what the threads mine on, the header fields and coinbase of each algorithm version on the best block
+type work struct {
+	height    int32
+	prevHash  chainhash.Hash
+	versions  []int32
+	bits      blockchain.TargetBits
+	merkles   map[int32]*chainhash.Hash
+	coinbases map[int32]*util.Tx
+	txs       []*util.Tx
+}
+
+// Miner mines with threads inside the node on the templates of its block template generator
+type Miner struct {
+	cx        *conte.Xt
+	generator *mining.BlkTmplGenerator
+	mx        sync.Mutex
+	wg        sync.WaitGroup
+	quit      chan struct{}
+	mining    atomic.Bool
+	work      atomic.Value // *work
+	// update makes the controller make new work straight away, such as when a block was found
+	update       chan struct{}
+	lastTxUpdate time.Time
+	generated    time.Time
+}
+
+// New returns a miner mining the templates of the generator once it is started
+func New(cx *conte.Xt, generator *mining.BlkTmplGenerator) *Miner {
+	return &Miner{cx: cx, generator: generator, update: make(chan struct{}, 1)}
+}
+
+// Start mines with the number of threads, all of the cores for -1, replacing the threads already mining
+func (m *Miner) Start(threads int) {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	m.stop()
+	if threads < 0 || threads > runtime.NumCPU() {
+		threads = runtime.NumCPU()
+	}
+	if threads == 0 {
+		return
+	}
+	Info("starting in-process miner with", threads, "threads")
+	m.quit = make(chan struct{})
+	m.work.Store((*work)(nil))
+	m.mining.Store(true)
+	m.wg.Add(threads + 1)
+	go m.controller(m.quit)
+	for i := 0; i < threads; i++ {
+		go m.worker(fmt.Sprint("cpu", i), m.quit)
+	}
+}
+
+// Stop stops the threads mining, and returns once they have
+func (m *Miner) Stop() {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	m.stop()
+}
+
+// stop stops the threads mining.
+//
+// This function MUST be called with the miner locked.
+func (m *Miner) stop() {
+	if !m.mining.Load() {
+		return
+	}
+	close(m.quit)
+	m.wg.Wait()
+	m.mining.Store(false)
+	Info("in-process miner stopped")
+}
+
+// Mining returns whether threads are mining
+func (m *Miner) Mining() bool {
+	return m.mining.Load()
+}
+
+// controller keeps the work up to date with the best block and the mempool, as the kopach controller does for the
+// work it sends, and pauses the threads while the node isn't current
+func (m *Miner) controller(quit chan struct{}) {
+	defer m.wg.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	var lastErr string
+	for {
+		if !m.cx.IsCurrent() {
+			m.work.Store((*work)(nil))
+		} else if m.stale() {
+			w, err := m.newWork()
+			if err != nil {
+				if err.Error() != lastErr {
+					Warn("in-process miner has no work:", err)
+					lastErr = err.Error()
+				}
+			} else {
+				lastErr = ""
+			}
+			m.work.Store(w)
+		}
+		select {
+		case <-ticker.C:
+		case <-m.update:
+		case <-quit:
+			return
+		case <-m.cx.KillAll:
+			return
+		}
+	}
+}
+
+// stale returns whether the work is missing or out of date, which is when the best block has changed or the mempool has
+// been updated since the template was made and it has been at least a minute
+func (m *Miner) stale() bool {
+	w, _ := m.work.Load().(*work)
+	if w == nil {
+		return true
+	}
+	best := m.generator.BestSnapshot()
+	if !w.prevHash.IsEqual(&best.Hash) {
+		return true
+	}
+	return m.generator.GetTxSource().LastUpdated() != m.lastTxUpdate &&
+		time.Now().After(m.generated.Add(time.Minute))
+}
+
+// newWork makes the work on the best block from a new block template, with the coinbase and merkle root of each
+// algorithm made as they are for the work sent to kopach
+func (m *Miner) newWork() (w *work, err error) {
+	height := m.cx.RealNode.Chain.BestSnapshot().Height + 1
+	var payTo util.Address
+	if payTo, err = m.cx.StateCfg.Payout.Address(m.cx.StateCfg.ActiveMiningAddrs, fork.SHA256d, height); err != nil {
+		return
+	}
+	m.lastTxUpdate = m.generator.GetTxSource().LastUpdated()
+	var template *mining.BlockTemplate
+	if template, err = m.generator.NewBlockTemplate(0, payTo, fork.SHA256d); err != nil {
+		return
+	}
+	m.generated = time.Now()
+	coinbases := make(map[int32]*util.Tx)
+	j, txs := job.Get(m.cx, util.NewBlock(template.Block), p2padvt.Get(m.cx), &coinbases)
+	if len(coinbases) == 0 {
+		return nil, fmt.Errorf("failed to make the work of block %d", height)
+	}
+	w = &work{
+		height:    j.GetNewHeight(),
+		prevHash:  template.Block.Header.PrevBlock,
+		bits:      j.GetBitses(),
+		merkles:   j.GetHashes(),
+		coinbases: coinbases,
+		txs:       txs,
+	}
+	w.versions = preferred(w.bits, w.height, *m.cx.Config.MinerAlgos)
+	Debug("in-process miner working on block", w.height, "with", len(txs), "transactions")
+	return
+}
+
+// preferred returns the algorithm versions of the work that are in the algorithms to mine, or all of them if none
+// are, in order
+func preferred(bits blockchain.TargetBits, height int32, algos []string) (versions []int32) {
+	var all []int32
+	for v := range bits {
+		all = append(all, v)
+		name := fork.GetAlgoName(v, height)
+		for _, a := range algos {
+			if strings.EqualFold(strings.TrimSpace(a), name) {
+				versions = append(versions, v)
+				break
+			}
+		}
+	}
+	if len(versions) == 0 {
+		versions = all
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	return
+}
+
+// worker searches nonces of the work, moving on to the next algorithm every worker.RoundsPerAlgo hashes as the kopach
+// workers do, so the algorithms are mined evenly
+func (m *Miner) worker(id string, quit chan struct{}) {
+	defer m.wg.Done()
+	nonce := rand.Uint32()
+	// the threads start on different algorithms
+	round := uint(rand.Intn(1 << 16))
+	for {
+		select {
+		case <-quit:
+			return
+		case <-m.cx.KillAll:
+			return
+		default:
+		}
+		w, _ := m.work.Load().(*work)
+		if w == nil {
+			select {
+			case <-time.After(time.Second / 10):
+			case <-quit:
+				return
+			}
+			continue
+		}
+		version := w.versions[round%uint(len(w.versions))]
+		round++
+		header := wire.BlockHeader{
+			Version:    version,
+			PrevBlock:  w.prevHash,
+			MerkleRoot: *w.merkles[version],
+			Timestamp:  time.Now(),
+			Bits:       w.bits[version],
+			Nonce:      nonce,
+		}
+		target := fork.CompactToBig(header.Bits)
+		var count uint64
+		solved := false
+		for ; count < worker.RoundsPerAlgo && !solved; count++ {
+			hash := header.BlockHashWithAlgos(w.height)
+			if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+				solved = true
+			} else {
+				header.Nonce++
+			}
+		}
+		nonce = header.Nonce + 1
+		m.cx.MinerStats.AddHashes(id, fork.GetAlgoName(version, w.height), count, time.Now())
+		if solved {
+			m.submit(id, w, &header)
+		}
+	}
+}
+
+// submit processes the block solved with the header, as the kopach controller does with solutions from its workers
+func (m *Miner) submit(id string, w *work, header *wire.BlockHeader) {
+	if best := m.cx.RealNode.Chain.BestSnapshot(); !header.PrevBlock.IsEqual(&best.Hash) {
+		Debug("block found by the in-process miner is stale")
+		m.cx.MinerStats.AddRejected(id, time.Now())
+		return
+	}
+	msgBlock := wire.MsgBlock{Header: *header}
+	msgBlock.Transactions = append(msgBlock.Transactions, w.coinbases[header.Version].MsgTx())
+	for _, tx := range w.txs {
+		msgBlock.Transactions = append(msgBlock.Transactions, tx.MsgTx())
+	}
+	block := util.NewBlock(&msgBlock)
+	block.SetHeight(w.height)
+	isOrphan, err := m.cx.RealNode.SyncManager.ProcessBlock(block, blockchain.BFNone)
+	if err != nil {
+		m.cx.MinerStats.AddRejected(id, time.Now())
+		Warn("block found by the in-process miner rejected:", err)
+		return
+	}
+	if isOrphan {
+		Warn("block found by the in-process miner is an orphan")
+	}
+	m.cx.MinerStats.AddSolution(id, time.Now())
+	Infof("in-process miner found block %d %s with %s", w.height, block.Hash(),
+		fork.GetAlgoName(header.Version, w.height))
+	select {
+	case m.update <- struct{}{}:
+	default:
+	}
+}
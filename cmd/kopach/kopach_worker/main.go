@@ -3,11 +3,13 @@ package kopach_worker
 import (
 	"net/rpc"
 	"os"
+	"strconv"
 
 	"github.com/urfave/cli"
 
 	"github.com/p9c/pod/app/conte"
 	"github.com/p9c/pod/cmd/kopach/worker"
+	"github.com/p9c/pod/cmd/kopach/worker/gpu"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
 	"github.com/p9c/pod/pkg/util/interrupt"
@@ -31,8 +33,25 @@ func KopachWorkerHandle(cx *conte.Xt) func(c *cli.Context) error {
 		if len(os.Args) > 4 {
 			log.L.SetLevel(os.Args[4], true, "pod")
 		}
+		// a worker given the number of a GPU and the intensity to run it at after the log level mines on the GPU
+		var miner *gpu.Miner
+		if len(os.Args) > 6 {
+			device, err := strconv.Atoi(os.Args[5])
+			if err != nil {
+				Error("GPU device", os.Args[5], "is not a number")
+				return err
+			}
+			intensity, err := strconv.Atoi(os.Args[6])
+			if err != nil {
+				intensity = gpu.DefaultIntensity
+			}
+			if miner, err = gpu.Open(device, intensity); Check(err) {
+				return err
+			}
+			defer miner.Close()
+		}
 		Debug("miner worker starting")
-		w, conn := worker.New(os.Args[2], cx.KillAll)
+		w, conn := worker.New(os.Args[2], miner, cx.KillAll)
 		interrupt.AddHandler(func() {
 			Debug("KopachWorkerHandle interrupt")
 			if err := conn.Close(); Check(err) {
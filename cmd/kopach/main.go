@@ -9,12 +9,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/VividCortex/ewma"
 	"github.com/urfave/cli"
 	"go.uber.org/atomic"
 
-	"github.com/p9c/pod/pkg/data/ring"
-
 	"github.com/p9c/pod/app/conte"
 	"github.com/p9c/pod/app/save"
 	"github.com/p9c/pod/cmd/kopach/client"
@@ -22,20 +19,18 @@ import (
 	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/kopach/control/job"
 	"github.com/p9c/pod/cmd/kopach/control/pause"
+	"github.com/p9c/pod/cmd/kopach/control/register"
 	"github.com/p9c/pod/cmd/kopach/control/sol"
+	"github.com/p9c/pod/cmd/kopach/worker/gpu"
 	"github.com/p9c/pod/pkg/chain/fork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/comm/nodeauth"
 	"github.com/p9c/pod/pkg/comm/stdconn/worker"
 	"github.com/p9c/pod/pkg/comm/transport"
-	rav "github.com/p9c/pod/pkg/data/ring"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
 
-type HashCount struct {
-	uint64
-	Time time.Time
-}
-
 type SolutionData struct {
 	time       time.Time
 	height     int
@@ -51,20 +46,27 @@ type SolutionData struct {
 }
 
 type Worker struct {
-	id                  string
-	cx                  *conte.Xt
-	height              int32
-	active              atomic.Bool
-	conn                *transport.Channel
-	ctx                 context.Context
-	quit                chan struct{}
-	sendAddresses       []*net.UDPAddr
-	clients             []*client.Client
-	workers             []*worker.Worker
-	FirstSender         atomic.String
-	lastSent            atomic.Int64
-	Status              atomic.String
-	HashTick            chan HashCount
+	id            string
+	cx            *conte.Xt
+	height        int32
+	active        atomic.Bool
+	conn          *transport.Channel
+	ctx           context.Context
+	quit          chan struct{}
+	sendAddresses []*net.UDPAddr
+	clients       []*client.Client
+	workers       []*worker.Worker
+	FirstSender   atomic.String
+	controllers   *Controllers
+	// uniConn registers with the controllers in MinerControllers and receives their work
+	uniConn *transport.Channel
+	// unicastAddrs are the addresses the controllers in MinerControllers resolved to when last registered with
+	unicastAddrs atomic.Value
+	// ControllerChan receives the state of the controllers, best first, when it changes and every second
+	ControllerChan chan []ControllerStatus
+	Status         atomic.String
+	// StatsChan receives the hashrate, hashes and solutions of the workers every second
+	StatsChan           chan btcjson.GetMinerStatsResult
 	LastHash            *chainhash.Hash
 	StartChan, StopChan chan struct{}
 	SetThreads          chan int
@@ -72,10 +74,7 @@ type Worker struct {
 	solutions           []SolutionData
 	solutionCount       int
 	Update              chan struct{}
-	hashCount           atomic.Uint64
-	hashSampleBuf       *rav.BufferUint64
-	hashrate            float64
-	lastNonce           int32
+	started             time.Time
 }
 
 func (w *Worker) Start() {
@@ -92,12 +91,21 @@ func (w *Worker) Start() {
 		w.workers = append(w.workers, cmd)
 		w.clients = append(w.clients, client.New(cmd.StdConn))
 	}
+	for _, d := range w.gpus() {
+		Debug("starting worker on", d)
+		cmd, _ := worker.Spawn(w.quit, os.Args[0], "worker", w.id, w.cx.ActiveNet.Name, *w.cx.Config.LogLevel,
+			fmt.Sprint(d.ID), fmt.Sprint(*w.cx.Config.GPUIntensity))
+		w.workers = append(w.workers, cmd)
+		w.clients = append(w.clients, client.New(cmd.StdConn))
+	}
 	for i := range w.clients {
 		Debug("sending pass to worker", i)
 		err := w.clients[i].SendPass(*w.cx.Config.MinerPass)
 		if err != nil {
 			Error(err)
 		}
+		if err = w.clients[i].SetAlgos(*w.cx.Config.MinerAlgos); Check(err) {
+		}
 	}
 	w.active.Store(true)
 	interrupt.AddHandler(func() {
@@ -105,6 +113,28 @@ func (w *Worker) Start() {
 	})
 }
 
+// gpus returns the GPUs configured to mine on, listing those found so they can be chosen by number
+func (w *Worker) gpus() (devices []gpu.Device) {
+	configured := len(*w.cx.Config.GPUDevices) > 0
+	devices, err := gpu.Devices()
+	if err != nil {
+		if configured {
+			Warn(err)
+		}
+		return nil
+	}
+	for _, d := range devices {
+		Infof("found %s on %s with %d compute units and %d MB", d, d.Platform, d.ComputeUnits, d.GlobalMemory>>20)
+	}
+	if !configured {
+		return nil
+	}
+	if devices, err = gpu.Select(devices, *w.cx.Config.GPUDevices); Check(err) {
+		return nil
+	}
+	return
+}
+
 func (w *Worker) Stop() {
 	var err error
 	for i := range w.clients {
@@ -131,27 +161,39 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 		if _, err = rand.Read(randomBytes); Check(err) {
 		}
 		w := &Worker{
-			id:            fmt.Sprintf("%x", randomBytes),
-			cx:            cx,
-			ctx:           ctx,
-			quit:          cx.KillAll,
-			sendAddresses: []*net.UDPAddr{},
-			StartChan:     make(chan struct{}),
-			StopChan:      make(chan struct{}),
-			SetThreads:    make(chan int),
-			solutions:     make([]SolutionData, 0, 2048),
-			Update:        make(chan struct{}),
-			hashSampleBuf: ring.NewBufferUint64(1000),
+			id:             fmt.Sprintf("%x", randomBytes),
+			cx:             cx,
+			ctx:            ctx,
+			quit:           cx.KillAll,
+			sendAddresses:  []*net.UDPAddr{},
+			StartChan:      make(chan struct{}),
+			StopChan:       make(chan struct{}),
+			SetThreads:     make(chan int),
+			solutions:      make([]SolutionData, 0, 2048),
+			Update:         make(chan struct{}),
+			controllers:    NewControllers(),
+			ControllerChan: make(chan []ControllerStatus, 1),
+			StatsChan:      make(chan btcjson.GetMinerStatsResult, 1),
+			started:        time.Now(),
 		}
 		Warn("kopachgui", *cx.Config.KopachGUI)
 		if *cx.Config.KopachGUI {
 			Info("opening miner controller GUI")
 			go w.Run()
 		}
-		w.lastSent.Store(time.Now().UnixNano())
 		w.active.Store(false)
+		// with controller keys allowed, work and pause messages signed by any other key are dropped
+		var allowed nodeauth.Allowlist
+		if allowed, err = nodeauth.ParseAllowlist(*cx.Config.ControllerAllow); Check(err) {
+			cancel()
+			return
+		}
+		if len(allowed) > 0 {
+			Info("only mining work from the", len(allowed), "controllers in controllerallow")
+		}
+		auth := &transport.Auth{Allowed: allowed, Guarded: []string{string(job.Magic), string(pause.PauseMagic)}}
 		Debug("opening broadcast channel listener")
-		w.conn, err = transport.NewBroadcastChannel("kopachmain", w, *cx.Config.MinerPass,
+		w.conn, err = transport.NewBroadcastChannel("kopachmain", w, *cx.Config.MinerPass, auth,
 			transport.DefaultPort, control.MaxDatagramSize, handlers,
 			cx.KillAll)
 		if err != nil {
@@ -159,6 +201,15 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 			cancel()
 			return
 		}
+		// controllers on other networks are registered with over unicast, and send their work back the same way
+		if len(*cx.Config.MinerControllers) > 0 {
+			if w.uniConn, err = transport.NewUnicastListener("kopachunicast", w, *cx.Config.MinerPass, auth,
+				":0", control.MaxDatagramSize, handlersUnicast, cx.KillAll); Check(err) {
+				cancel()
+				return
+			}
+			go w.registerer()
+		}
 		// start up the workers
 		if *cx.Config.Generate {
 			w.Start()
@@ -171,24 +222,14 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 			for {
 				select {
 				case <-ticker.C:
-					// if the last message sent was 3 seconds ago the server is almost certainly disconnected or crashed
-					// so clear FirstSender
-					since := time.Now().Sub(time.Unix(0, w.lastSent.Load()))
-					wasSending := since > time.Second*3 && w.FirstSender.Load() != ""
-					if wasSending {
-						Debug("previous current controller has stopped broadcasting", since, w.FirstSender.Load())
-						// when this string is clear other broadcasts will be listened to
-						w.FirstSender.Store("")
-						// pause the workers
-						for i := range w.clients {
-							Debug("sending pause to worker", i)
-							err := w.clients[i].Pause()
-							if err != nil {
-								Error(err)
-							}
-						}
+					// if the active controller hasn't broadcast for ControllerTimeout it is almost certainly disconnected
+					// or crashed so the workers are moved to the next best controller, or paused if there is none
+					if addr, j, switched := w.controllers.Failover(time.Now()); switched {
+						w.FirstSender.Store(addr)
+						w.mineOn(j)
 					}
-					w.hashrate = w.HashReport()
+					w.sendStatus()
+					w.sendStats()
 				case <-w.StartChan:
 					*cx.Config.Generate = true
 					save.Pod(cx.Config)
@@ -238,22 +279,6 @@ func Handle(cx *conte.Xt) func(c *cli.Context) error {
 
 // these are the handlers for specific message types.
 var handlers = transport.Handlers{
-	string(hashrate.HashrateMagic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
-		c := ctx.(*Worker)
-		if !c.active.Load() {
-			Debug("not active")
-			return
-		}
-		hp := hashrate.LoadContainer(b)
-		id := hp.GetID()
-		// if this is not one of our workers reports ignore it
-		if id != c.id {
-			return
-		}
-		count := hp.GetCount()
-		c.hashCount.Store(c.hashCount.Load() + uint64(count))
-		return
-	},
 	string(job.Magic): func(ctx interface{}, src net.Addr, dst string,
 		b []byte) (err error) {
 		w := ctx.(*Worker)
@@ -266,21 +291,16 @@ var handlers = transport.Handlers{
 		w.height = j.GetNewHeight()
 		cP := j.GetControllerListenerPort()
 		addr := net.JoinHostPort(ips[0].String(), fmt.Sprint(cP))
-		firstSender := w.FirstSender.Load()
-		otherSent := firstSender != addr && firstSender != ""
-		if otherSent {
-			Trace("ignoring other controller job")
-			// ignore other controllers while one is active and received first
+		mine, switched := w.controllers.Seen(addr, &j, time.Now())
+		if !mine {
+			Trace("ignoring job from standby controller", addr)
 			return
 		}
-		w.FirstSender.Store(addr)
-		w.lastSent.Store(time.Now().UnixNano())
-		for i := range w.clients {
-			err := w.clients[i].NewJob(&j)
-			if err != nil {
-				Error(err)
-			}
+		if switched {
+			w.FirstSender.Store(addr)
+			w.sendStatus()
 		}
+		w.mineOn(&j)
 		return
 	},
 	string(pause.PauseMagic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
@@ -291,19 +311,20 @@ var handlers = transport.Handlers{
 		np := p.GetControllerListenerPort()
 		ns := net.JoinHostPort(ni, fmt.Sprint(np))
 		if fs == ns {
-			for i := range w.clients {
-				Debug("sending pause to worker", i, fs, ns)
-				err := w.clients[i].Pause()
-				if err != nil {
-					Error(err)
-				}
-			}
+			// the active controller is going away so fail over now rather than after ControllerTimeout
+			Debug("active controller", fs, "paused")
+			w.controllers.Down(ns)
+			addr, j, _ := w.controllers.Failover(time.Now())
+			w.FirstSender.Store(addr)
+			w.mineOn(j)
+			w.sendStatus()
 		}
 		return
 	},
 	string(sol.SolutionMagic): func(ctx interface{}, src net.Addr, dst string,
 		b []byte) (err error) {
 		w := ctx.(*Worker)
+		w.relay(sol.SolutionMagic, src, b)
 		portSlice := strings.Split(w.FirstSender.Load(), ":")
 		if len(portSlice) < 2 {
 			Debug("error with solution", w.FirstSender.Load(), portSlice)
@@ -340,27 +361,107 @@ var handlers = transport.Handlers{
 				w.Update <- struct{}{}
 			}
 		}
-		w.FirstSender.Store("")
+		return
+	},
+	// hashrate reports from the workers are only handled to relay them to controllers on other networks
+	string(hashrate.HashrateMagic): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
+		ctx.(*Worker).relay(hashrate.HashrateMagic, src, b)
 		return
 	},
 }
 
-func (w *Worker) HashReport() float64 {
-	w.hashSampleBuf.Add(w.hashCount.Load())
-	av := ewma.NewMovingAverage()
-	var i int
-	var prev uint64
-	if err := w.hashSampleBuf.ForEach(func(v uint64) error {
-		if i < 1 {
-			prev = v
-		} else {
-			interval := v - prev
-			av.Add(float64(interval))
-			prev = v
+// handlersUnicast handle the work sent by the controllers in MinerControllers
+var handlersUnicast = transport.Handlers{
+	string(job.Magic):        handlers[string(job.Magic)],
+	string(pause.PauseMagic): handlers[string(pause.PauseMagic)],
+}
+
+// registerer registers with each of the controllers in MinerControllers every register.Interval, so they send it their
+// work, resolving their addresses each time in case they change
+func (w *Worker) registerer() {
+	ticker := time.NewTicker(register.Interval)
+	defer ticker.Stop()
+	for {
+		var addrs []*net.UDPAddr
+		for _, c := range *w.cx.Config.MinerControllers {
+			addr, err := net.ResolveUDPAddr("udp4", c)
+			if Check(err) {
+				continue
+			}
+			addrs = append(addrs, addr)
 		}
-		i++
-		return nil
-	}); Check(err) {
+		w.unicastAddrs.Store(addrs)
+		shards := transport.GetShards(register.Get(w.id, int32(len(w.clients))).Data)
+		for _, addr := range addrs {
+			if err := w.uniConn.SendManyTo(addr, register.Magic, shards); Check(err) {
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// relay sends a message from the workers to the controllers in MinerControllers, which don't receive their multicast.
+// Messages from the workers of other kopach on the network are left for those to relay
+func (w *Worker) relay(magic []byte, src net.Addr, b []byte) {
+	if w.uniConn == nil || !isLocal(src) {
+		return
+	}
+	addrs, _ := w.unicastAddrs.Load().([]*net.UDPAddr)
+	shards := transport.GetShards(b)
+	for _, addr := range addrs {
+		if err := w.uniConn.SendManyTo(addr, magic, shards); Check(err) {
+		}
+	}
+}
+
+// isLocal returns whether the address is one of this machine
+func isLocal(src net.Addr) bool {
+	udp, ok := src.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	if udp.IP.IsLoopback() {
+		return true
+	}
+	addrs, err := net.InterfaceAddrs()
+	if Check(err) {
+		return false
+	}
+	for _, a := range addrs {
+		if ipNet, ok := a.(*net.IPNet); ok && ipNet.IP.Equal(udp.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// sendStats collects the statistics of each worker over the stdconn worker protocol and sends their totals to the GUI,
+// replacing those not yet received
+func (w *Worker) sendStats() {
+	now := time.Now()
+	workers := make([]btcjson.MinerWorkerStatsResult, 0, len(w.clients))
+	for i := range w.clients {
+		ws, err := w.clients[i].Stats()
+		if err != nil {
+			continue
+		}
+		// GPU workers report their device, CPU workers are numbered
+		if ws.ID == w.id {
+			ws.ID = fmt.Sprint("cpu", i)
+		}
+		workers = append(workers, ws)
+	}
+	stats := hashrate.Combine(workers, w.started, now)
+	select {
+	case <-w.StatsChan:
+	default:
+	}
+	select {
+	case w.StatsChan <- stats:
+	default:
 	}
-	return av.Value()
 }
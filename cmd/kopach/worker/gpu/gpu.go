@@ -0,0 +1,107 @@
+// Package gpu is the OpenCL mining backend for kopach workers. It is only built into miners compiled with the opencl
+// build tag, as it needs the OpenCL headers and library, otherwise no devices are found.
+//
+// Only the memory-light algorithms are searched on the GPU, which on the chain before the hard fork is SHA256d. The
+// algorithms after the hard fork run a long division of a number tens of kilobytes long before hashing, which a GPU
+// does no faster than a CPU, so a GPU worker waits for work it can mine rather than mining those.
+package gpu
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+)
+
+const (
+	// MinIntensity and MaxIntensity are the bounds of the base 2 logarithm of the number of nonces searched in one
+	// run of the kernel. Below the minimum a GPU spends more time being launched than hashing, and above the maximum
+	// the count of hashes done does not fit in a hashrate report
+	MinIntensity = 16
+	MaxIntensity = 30
+	// DefaultIntensity keeps a run of the kernel under a second on most GPUs so the worker stops soon after new work
+	// arrives
+	DefaultIntensity = 22
+)
+
+// ErrNoOpenCL is returned when the miner was built without OpenCL
+var ErrNoOpenCL = errors.New("GPU mining needs a miner built with the opencl tag")
+
+// Device is an OpenCL GPU that can be mined on
+type Device struct {
+	// ID is the number of the device counting through the devices of every platform
+	ID           int
+	Platform     string
+	Name         string
+	ComputeUnits int
+	GlobalMemory uint64
+}
+
+func (d Device) String() string {
+	return fmt.Sprintf("gpu%d %s", d.ID, d.Name)
+}
+
+// Supported returns whether the GPU can mine the algorithm at the height
+func Supported(algo string, height int32) bool {
+	return algo == fork.SHA256d && fork.GetCurrent(height) == 0
+}
+
+// Select returns the devices chosen in the configuration by number, or all of them if one is "all"
+func Select(devices []Device, chosen []string) (out []Device, err error) {
+	for _, c := range chosen {
+		if strings.TrimSpace(c) == "all" {
+			return devices, nil
+		}
+	}
+	for _, c := range chosen {
+		var id int
+		if id, err = strconv.Atoi(strings.TrimSpace(c)); err != nil {
+			return nil, fmt.Errorf("GPU device '%s' is not a number", c)
+		}
+		if id < 0 || id >= len(devices) {
+			return nil, fmt.Errorf("there is no GPU device %d, %d were found", id, len(devices))
+		}
+		out = append(out, devices[id])
+	}
+	return
+}
+
+// ClampIntensity returns the intensity within MinIntensity and MaxIntensity
+func ClampIntensity(intensity int) int {
+	switch {
+	case intensity < MinIntensity:
+		return MinIntensity
+	case intensity > MaxIntensity:
+		return MaxIntensity
+	}
+	return intensity
+}
+
+// headerWords splits an 80 byte block header into the big endian words the kernel hashes
+func headerWords(header []byte) (out [20]uint32) {
+	for i := range out {
+		out[i] = binary.BigEndian.Uint32(header[i*4:])
+	}
+	return
+}
+
+// targetWords splits a target into big endian words, most significant first, as the kernel compares hashes with it
+func targetWords(target *big.Int) (out [8]uint32) {
+	b := target.Bytes()
+	if len(b) > 32 {
+		for i := range out {
+			out[i] = ^uint32(0)
+		}
+		return
+	}
+	buf := make([]byte, 32)
+	copy(buf[32-len(b):], b)
+	for i := range out {
+		out[i] = binary.BigEndian.Uint32(buf[i*4:])
+	}
+	return
+}
@@ -0,0 +1,107 @@
+package gpu
+
+// kernelSource is the OpenCL program searching nonces for SHA256d. Each work item hashes the header with the nonce
+// start plus its global id and compares the hash, read as a little endian number as the chain does, with the target.
+// The first work item to find a hash under the target records its nonce in the result, which the worker checks again
+// before sending the block.
+const kernelSource = `
+#define ROTR(x, n) rotate((uint)(x), (uint)(32 - (n)))
+#define CH(x, y, z) bitselect((z), (y), (x))
+#define MAJ(x, y, z) bitselect((x), (y), ((z) ^ (x)))
+#define S0(x) (ROTR((x), 2) ^ ROTR((x), 13) ^ ROTR((x), 22))
+#define S1(x) (ROTR((x), 6) ^ ROTR((x), 11) ^ ROTR((x), 25))
+#define s0(x) (ROTR((x), 7) ^ ROTR((x), 18) ^ ((x) >> 3))
+#define s1(x) (ROTR((x), 17) ^ ROTR((x), 19) ^ ((x) >> 10))
+#define SWAP32(x) ((((x) >> 24) & 0xffu) | (((x) >> 8) & 0xff00u) | (((x) << 8) & 0xff0000u) | ((x) << 24))
+
+__constant uint K[64] = {
+	0x428a2f98u, 0x71374491u, 0xb5c0fbcfu, 0xe9b5dba5u, 0x3956c25bu, 0x59f111f1u, 0x923f82a4u, 0xab1c5ed5u,
+	0xd807aa98u, 0x12835b01u, 0x243185beu, 0x550c7dc3u, 0x72be5d74u, 0x80deb1feu, 0x9bdc06a7u, 0xc19bf174u,
+	0xe49b69c1u, 0xefbe4786u, 0x0fc19dc6u, 0x240ca1ccu, 0x2de92c6fu, 0x4a7484aau, 0x5cb0a9dcu, 0x76f988dau,
+	0x983e5152u, 0xa831c66du, 0xb00327c8u, 0xbf597fc7u, 0xc6e00bf3u, 0xd5a79147u, 0x06ca6351u, 0x14292967u,
+	0x27b70a85u, 0x2e1b2138u, 0x4d2c6dfcu, 0x53380d13u, 0x650a7354u, 0x766a0abbu, 0x81c2c92eu, 0x92722c85u,
+	0xa2bfe8a1u, 0xa81a664bu, 0xc24b8b70u, 0xc76c51a3u, 0xd192e819u, 0xd6990624u, 0xf40e3585u, 0x106aa070u,
+	0x19a4c116u, 0x1e376c08u, 0x2748774cu, 0x34b0bcb5u, 0x391c0cb3u, 0x4ed8aa4au, 0x5b9cca4fu, 0x682e6ff3u,
+	0x748f82eeu, 0x78a5636fu, 0x84c87814u, 0x8cc70208u, 0x90befffau, 0xa4506cebu, 0xbef9a3f7u, 0xc67178f2u
+};
+
+__constant uint H0[8] = {
+	0x6a09e667u, 0xbb67ae85u, 0x3c6ef372u, 0xa54ff53au, 0x510e527fu, 0x9b05688cu, 0x1f83d9abu, 0x5be0cd19u
+};
+
+void sha256_block(uint *state, const uint *block)
+{
+	uint w[64];
+	int i;
+	for (i = 0; i < 16; i++)
+		w[i] = block[i];
+	for (i = 16; i < 64; i++)
+		w[i] = s1(w[i - 2]) + w[i - 7] + s0(w[i - 15]) + w[i - 16];
+	uint a = state[0], b = state[1], c = state[2], d = state[3];
+	uint e = state[4], f = state[5], g = state[6], h = state[7];
+	for (i = 0; i < 64; i++) {
+		uint t1 = h + S1(e) + CH(e, f, g) + K[i] + w[i];
+		uint t2 = S0(a) + MAJ(a, b, c);
+		h = g;
+		g = f;
+		f = e;
+		e = d + t1;
+		d = c;
+		c = b;
+		b = a;
+		a = t1 + t2;
+	}
+	state[0] += a;
+	state[1] += b;
+	state[2] += c;
+	state[3] += d;
+	state[4] += e;
+	state[5] += f;
+	state[6] += g;
+	state[7] += h;
+}
+
+__kernel void search_sha256d(__constant uint *header, __constant uint *target, const uint start,
+	__global uint *result)
+{
+	const uint nonce = start + (uint)get_global_id(0);
+	uint block[16];
+	uint state[8];
+	uint digest[8];
+	int i;
+	for (i = 0; i < 8; i++)
+		state[i] = H0[i];
+	for (i = 0; i < 16; i++)
+		block[i] = header[i];
+	sha256_block(state, block);
+	// the nonce is the last word of the header, stored little endian
+	block[0] = header[16];
+	block[1] = header[17];
+	block[2] = header[18];
+	block[3] = SWAP32(nonce);
+	block[4] = 0x80000000u;
+	for (i = 5; i < 15; i++)
+		block[i] = 0;
+	block[15] = 640;
+	sha256_block(state, block);
+	for (i = 0; i < 8; i++) {
+		block[i] = state[i];
+		digest[i] = H0[i];
+	}
+	block[8] = 0x80000000u;
+	for (i = 9; i < 15; i++)
+		block[i] = 0;
+	block[15] = 256;
+	sha256_block(digest, block);
+	// the most significant word of the hash as a little endian number is the last word of the digest
+	for (i = 0; i < 8; i++) {
+		uint word = SWAP32(digest[7 - i]);
+		if (word < target[i])
+			break;
+		if (word > target[i])
+			return;
+	}
+	if (atomic_cmpxchg(&result[0], 0u, 1u) == 0u)
+		result[1] = nonce;
+}
+`
@@ -0,0 +1,36 @@
+// +build !opencl
+
+package gpu
+
+import (
+	"math/big"
+)
+
+// Miner searches nonces on a GPU, which without OpenCL can never be opened
+type Miner struct {
+	device Device
+}
+
+// Devices returns the GPUs that can be mined on, of which there are none without OpenCL
+func Devices() ([]Device, error) {
+	return nil, ErrNoOpenCL
+}
+
+// Open prepares a GPU for mining
+func Open(id, intensity int) (*Miner, error) {
+	return nil, ErrNoOpenCL
+}
+
+// Device returns the GPU the miner runs on
+func (m *Miner) Device() Device {
+	return m.device
+}
+
+// Search hashes a run of nonces from start
+func (m *Miner) Search(header []byte, target *big.Int, start uint32) (nonce uint32, found bool, count uint32,
+	err error) {
+	return 0, false, 0, ErrNoOpenCL
+}
+
+// Close releases the GPU
+func (m *Miner) Close() {}
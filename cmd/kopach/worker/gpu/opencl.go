@@ -0,0 +1,272 @@
+// +build opencl
+
+package gpu
+
+/*
+#cgo linux LDFLAGS: -lOpenCL
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#define CL_TARGET_OPENCL_VERSION 120
+#ifdef __APPLE__
+#include <OpenCL/opencl.h>
+#else
+#include <CL/cl.h>
+#endif
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"unsafe"
+)
+
+// Miner searches nonces on a GPU
+type Miner struct {
+	mx        sync.Mutex
+	device    Device
+	intensity int
+	context   C.cl_context
+	queue     C.cl_command_queue
+	program   C.cl_program
+	kernel    C.cl_kernel
+	header    C.cl_mem
+	target    C.cl_mem
+	result    C.cl_mem
+}
+
+// clError describes an OpenCL status code returned by a call
+func clError(call string, status C.cl_int) error {
+	return fmt.Errorf("%s failed with OpenCL error %d", call, int(status))
+}
+
+// deviceIDs lists the GPUs of every platform in the order their numbers are given
+func deviceIDs() (ids []C.cl_device_id, devices []Device, err error) {
+	var nPlatforms C.cl_uint
+	if status := C.clGetPlatformIDs(0, nil, &nPlatforms); status != C.CL_SUCCESS {
+		return nil, nil, clError("clGetPlatformIDs", status)
+	}
+	if nPlatforms == 0 {
+		return
+	}
+	platforms := make([]C.cl_platform_id, nPlatforms)
+	if status := C.clGetPlatformIDs(nPlatforms, &platforms[0], nil); status != C.CL_SUCCESS {
+		return nil, nil, clError("clGetPlatformIDs", status)
+	}
+	for _, p := range platforms {
+		var nDevices C.cl_uint
+		// a platform without GPUs returns CL_DEVICE_NOT_FOUND
+		if status := C.clGetDeviceIDs(p, C.CL_DEVICE_TYPE_GPU, 0, nil, &nDevices); status != C.CL_SUCCESS ||
+			nDevices == 0 {
+			continue
+		}
+		pIDs := make([]C.cl_device_id, nDevices)
+		if status := C.clGetDeviceIDs(p, C.CL_DEVICE_TYPE_GPU, nDevices, &pIDs[0], nil); status != C.CL_SUCCESS {
+			return nil, nil, clError("clGetDeviceIDs", status)
+		}
+		platform := platformString(p, C.CL_PLATFORM_NAME)
+		for _, id := range pIDs {
+			var units C.cl_uint
+			var memory C.cl_ulong
+			C.clGetDeviceInfo(id, C.CL_DEVICE_MAX_COMPUTE_UNITS, C.size_t(unsafe.Sizeof(units)),
+				unsafe.Pointer(&units), nil)
+			C.clGetDeviceInfo(id, C.CL_DEVICE_GLOBAL_MEM_SIZE, C.size_t(unsafe.Sizeof(memory)),
+				unsafe.Pointer(&memory), nil)
+			devices = append(devices, Device{
+				ID:           len(devices),
+				Platform:     platform,
+				Name:         deviceString(id, C.CL_DEVICE_NAME),
+				ComputeUnits: int(units),
+				GlobalMemory: uint64(memory),
+			})
+			ids = append(ids, id)
+		}
+	}
+	return
+}
+
+func platformString(p C.cl_platform_id, param C.cl_platform_info) string {
+	var size C.size_t
+	if C.clGetPlatformInfo(p, param, 0, nil, &size) != C.CL_SUCCESS || size == 0 {
+		return ""
+	}
+	buf := make([]byte, size)
+	if C.clGetPlatformInfo(p, param, size, unsafe.Pointer(&buf[0]), nil) != C.CL_SUCCESS {
+		return ""
+	}
+	return C.GoString((*C.char)(unsafe.Pointer(&buf[0])))
+}
+
+func deviceString(d C.cl_device_id, param C.cl_device_info) string {
+	var size C.size_t
+	if C.clGetDeviceInfo(d, param, 0, nil, &size) != C.CL_SUCCESS || size == 0 {
+		return ""
+	}
+	buf := make([]byte, size)
+	if C.clGetDeviceInfo(d, param, size, unsafe.Pointer(&buf[0]), nil) != C.CL_SUCCESS {
+		return ""
+	}
+	return C.GoString((*C.char)(unsafe.Pointer(&buf[0])))
+}
+
+// Devices returns the GPUs that can be mined on
+func Devices() (devices []Device, err error) {
+	_, devices, err = deviceIDs()
+	return
+}
+
+// Open prepares a GPU for mining, building the kernel for it
+func Open(id, intensity int) (m *Miner, err error) {
+	ids, devices, err := deviceIDs()
+	if err != nil {
+		return
+	}
+	if id < 0 || id >= len(ids) {
+		return nil, fmt.Errorf("there is no GPU device %d, %d were found", id, len(ids))
+	}
+	m = &Miner{device: devices[id], intensity: ClampIntensity(intensity)}
+	if m.intensity != intensity {
+		Warnf("GPU intensity %d is out of range, using %d", intensity, m.intensity)
+	}
+	dev := ids[id]
+	var status C.cl_int
+	if m.context = C.clCreateContext(nil, 1, &dev, nil, nil, &status); status != C.CL_SUCCESS {
+		return nil, clError("clCreateContext", status)
+	}
+	if m.queue = C.clCreateCommandQueue(m.context, dev, 0, &status); status != C.CL_SUCCESS {
+		m.Close()
+		return nil, clError("clCreateCommandQueue", status)
+	}
+	src := C.CString(kernelSource)
+	defer C.free(unsafe.Pointer(src))
+	if m.program = C.clCreateProgramWithSource(m.context, 1, &src, nil, &status); status != C.CL_SUCCESS {
+		m.Close()
+		return nil, clError("clCreateProgramWithSource", status)
+	}
+	if status = C.clBuildProgram(m.program, 1, &dev, nil, nil, nil); status != C.CL_SUCCESS {
+		buildLog := m.buildLog(dev)
+		m.Close()
+		return nil, fmt.Errorf("%v: %s", clError("clBuildProgram", status), buildLog)
+	}
+	name := C.CString("search_sha256d")
+	defer C.free(unsafe.Pointer(name))
+	if m.kernel = C.clCreateKernel(m.program, name, &status); status != C.CL_SUCCESS {
+		m.Close()
+		return nil, clError("clCreateKernel", status)
+	}
+	if m.header = C.clCreateBuffer(m.context, C.CL_MEM_READ_ONLY, 20*4, nil, &status); status != C.CL_SUCCESS {
+		m.Close()
+		return nil, clError("clCreateBuffer", status)
+	}
+	if m.target = C.clCreateBuffer(m.context, C.CL_MEM_READ_ONLY, 8*4, nil, &status); status != C.CL_SUCCESS {
+		m.Close()
+		return nil, clError("clCreateBuffer", status)
+	}
+	if m.result = C.clCreateBuffer(m.context, C.CL_MEM_READ_WRITE, 2*4, nil, &status); status != C.CL_SUCCESS {
+		m.Close()
+		return nil, clError("clCreateBuffer", status)
+	}
+	for i, buf := range []*C.cl_mem{&m.header, &m.target} {
+		if status = C.clSetKernelArg(m.kernel, C.cl_uint(i), C.size_t(unsafe.Sizeof(*buf)),
+			unsafe.Pointer(buf)); status != C.CL_SUCCESS {
+			m.Close()
+			return nil, clError("clSetKernelArg", status)
+		}
+	}
+	if status = C.clSetKernelArg(m.kernel, 3, C.size_t(unsafe.Sizeof(m.result)),
+		unsafe.Pointer(&m.result)); status != C.CL_SUCCESS {
+		m.Close()
+		return nil, clError("clSetKernelArg", status)
+	}
+	Infof("mining on %s (%s) with %d compute units at intensity %d", m.device, m.device.Platform,
+		m.device.ComputeUnits, m.intensity)
+	return
+}
+
+func (m *Miner) buildLog(dev C.cl_device_id) string {
+	var size C.size_t
+	if C.clGetProgramBuildInfo(m.program, dev, C.CL_PROGRAM_BUILD_LOG, 0, nil, &size) != C.CL_SUCCESS ||
+		size == 0 {
+		return ""
+	}
+	buf := make([]byte, size)
+	if C.clGetProgramBuildInfo(m.program, dev, C.CL_PROGRAM_BUILD_LOG, size, unsafe.Pointer(&buf[0]),
+		nil) != C.CL_SUCCESS {
+		return ""
+	}
+	return C.GoString((*C.char)(unsafe.Pointer(&buf[0])))
+}
+
+// Device returns the GPU the miner runs on
+func (m *Miner) Device() Device {
+	return m.device
+}
+
+// Search hashes the 80 byte header with 2 to the power of the intensity nonces from start, returning the count of
+// nonces searched and, if one gives a hash under the target, that nonce
+func (m *Miner) Search(header []byte, target *big.Int, start uint32) (nonce uint32, found bool, count uint32,
+	err error) {
+	if len(header) != 80 {
+		return 0, false, 0, errors.New("block header is not 80 bytes long")
+	}
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	hw := headerWords(header)
+	tw := targetWords(target)
+	result := [2]C.cl_uint{}
+	if status := C.clEnqueueWriteBuffer(m.queue, m.header, C.CL_TRUE, 0, C.size_t(unsafe.Sizeof(hw)),
+		unsafe.Pointer(&hw[0]), 0, nil, nil); status != C.CL_SUCCESS {
+		return 0, false, 0, clError("clEnqueueWriteBuffer", status)
+	}
+	if status := C.clEnqueueWriteBuffer(m.queue, m.target, C.CL_TRUE, 0, C.size_t(unsafe.Sizeof(tw)),
+		unsafe.Pointer(&tw[0]), 0, nil, nil); status != C.CL_SUCCESS {
+		return 0, false, 0, clError("clEnqueueWriteBuffer", status)
+	}
+	if status := C.clEnqueueWriteBuffer(m.queue, m.result, C.CL_TRUE, 0, C.size_t(unsafe.Sizeof(result)),
+		unsafe.Pointer(&result[0]), 0, nil, nil); status != C.CL_SUCCESS {
+		return 0, false, 0, clError("clEnqueueWriteBuffer", status)
+	}
+	s := C.cl_uint(start)
+	if status := C.clSetKernelArg(m.kernel, 2, C.size_t(unsafe.Sizeof(s)), unsafe.Pointer(&s)); status !=
+		C.CL_SUCCESS {
+		return 0, false, 0, clError("clSetKernelArg", status)
+	}
+	count = 1 << uint(m.intensity)
+	global := C.size_t(count)
+	if status := C.clEnqueueNDRangeKernel(m.queue, m.kernel, 1, nil, &global, nil, 0, nil, nil); status !=
+		C.CL_SUCCESS {
+		return 0, false, 0, clError("clEnqueueNDRangeKernel", status)
+	}
+	if status := C.clEnqueueReadBuffer(m.queue, m.result, C.CL_TRUE, 0, C.size_t(unsafe.Sizeof(result)),
+		unsafe.Pointer(&result[0]), 0, nil, nil); status != C.CL_SUCCESS {
+		return 0, false, count, clError("clEnqueueReadBuffer", status)
+	}
+	return uint32(result[1]), result[0] != 0, count, nil
+}
+
+// Close releases the GPU
+func (m *Miner) Close() {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	for _, buf := range []C.cl_mem{m.header, m.target, m.result} {
+		if buf != nil {
+			C.clReleaseMemObject(buf)
+		}
+	}
+	if m.kernel != nil {
+		C.clReleaseKernel(m.kernel)
+	}
+	if m.program != nil {
+		C.clReleaseProgram(m.program)
+	}
+	if m.queue != nil {
+		C.clReleaseCommandQueue(m.queue)
+	}
+	if m.context != nil {
+		C.clReleaseContext(m.context)
+	}
+	m.header, m.target, m.result, m.kernel, m.program, m.queue, m.context = nil, nil, nil, nil, nil, nil, nil
+}
@@ -1,20 +1,22 @@
 package worker
 
 import (
+	"bytes"
 	"crypto/cipher"
 	"errors"
 	"math/rand"
 	"net"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/kopach/control/sol"
+	"github.com/p9c/pod/cmd/kopach/worker/gpu"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/fork"
 
-	"github.com/VividCortex/ewma"
 	"go.uber.org/atomic"
 
 	"github.com/p9c/pod/cmd/kopach/control"
@@ -23,7 +25,7 @@ import (
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/comm/stdconn"
 	"github.com/p9c/pod/pkg/comm/transport"
-	"github.com/p9c/pod/pkg/data/ring"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/interrupt"
 )
@@ -49,8 +51,12 @@ type Worker struct {
 	startChan     chan struct{}
 	stopChan      chan struct{}
 	running       atomic.Bool
-	hashCount     atomic.Uint64
-	hashSampleBuf *ring.BufferUint64
+	// stats counts the hashes and solutions of this worker, which the kopach that spawned it collects
+	stats *hashrate.Stats
+	// gpu is the device the worker searches nonces on, or nil for a worker mining on the CPU
+	gpu *gpu.Miner
+	// prefer is the names of the algorithms the worker mines out of those in the work
+	prefer atomic.Value // []string
 }
 
 type Counter struct {
@@ -94,42 +100,29 @@ func (c *Counter) GetAlgoVer() (ver int32) {
 }
 
 func (w *Worker) hashReport() {
-	w.hashSampleBuf.Add(w.hashCount.Load())
-	av := ewma.NewMovingAverage(15)
-	var i int
-	var prev uint64
-	if err := w.hashSampleBuf.ForEach(func(v uint64) error {
-		if i < 1 {
-			prev = v
-		} else {
-			interval := v - prev
-			av.Add(float64(interval))
-			prev = v
-		}
-		i++
-		return nil
-	}); Check(err) {
-	}
-	// Info("kopach",w.hashSampleBuf.Cursor, w.hashSampleBuf.Buf)
-	Tracef("average hashrate %.2f", av.Value())
+	w.stats.Tick(time.Now())
+	Tracef("average hashrate %.2f", w.stats.Hashrate())
 }
 
 // NewWithConnAndSemaphore is exposed to enable use an actual network connection while retaining the same RPC API to
-// allow a worker to be configured to run on a bare metal system with a different launcher main
-func NewWithConnAndSemaphore(id string, conn *stdconn.StdConn, quit chan struct{}) *Worker {
+// allow a worker to be configured to run on a bare metal system with a different launcher main. A worker given a GPU
+// miner searches nonces on it instead of the CPU.
+func NewWithConnAndSemaphore(id string, miner *gpu.Miner, conn *stdconn.StdConn, quit chan struct{}) *Worker {
 	Debug("creating new worker")
 	msgBlock := wire.MsgBlock{Header: wire.BlockHeader{}}
 	w := &Worker{
-		id:            id,
-		pipeConn:      conn,
-		Quit:          quit,
-		roller:        NewCounter(RoundsPerAlgo),
-		startChan:     make(chan struct{}),
-		stopChan:      make(chan struct{}),
-		hashSampleBuf: ring.NewBufferUint64(1000),
+		id:        id,
+		pipeConn:  conn,
+		Quit:      quit,
+		roller:    NewCounter(RoundsPerAlgo),
+		startChan: make(chan struct{}),
+		stopChan:  make(chan struct{}),
+		stats:     hashrate.NewStats(time.Now()),
+		gpu:       miner,
 	}
 	w.msgBlock.Store(msgBlock)
 	w.block.Store(util.NewBlock(&msgBlock))
+	w.prefer.Store([]string(nil))
 	w.dispatchReady.Store(false)
 	// with this we can report cumulative hash counts as well as using it to distribute algorithms evenly
 	w.startNonce = uint32(w.roller.C.Load())
@@ -193,6 +186,10 @@ out:
 				if w.block.Load() == nil || w.bitses.Load() == nil ||
 					w.hashes.Load() == nil || !w.dispatchReady.Load() {
 					// Info("stop was called before we started working")
+				} else if w.gpu != nil {
+					if w.searchGPU() {
+						break running
+					}
 				} else {
 					// work
 					nH := w.block.Load().(*util.Block).Height()
@@ -228,9 +225,10 @@ out:
 						default:
 						}
 						// send out broadcast containing worker nonce and algorithm and count of blocks
-						w.hashCount.Store(w.hashCount.Load() + uint64(w.roller.RoundsPerAlgo.Load()))
+						w.stats.AddHashes(w.id, fork.GetAlgoName(hv, nH), uint64(w.roller.RoundsPerAlgo.Load()),
+							time.Now())
 						nextAlgo = w.roller.C.Load() + 1
-						hashReport := hashrate.Get(w.roller.RoundsPerAlgo.Load(), nextAlgo, nH, w.id)
+						hashReport := hashrate.Get(w.roller.RoundsPerAlgo.Load(), hv, nH, w.id)
 						err := w.dispatchConn.SendMany(hashrate.HashrateMagic,
 							transport.GetShards(hashReport.Data))
 						if err != nil {
@@ -240,14 +238,7 @@ out:
 					hash := mb.Header.BlockHashWithAlgos(nH)
 					bigHash := blockchain.HashToBig(&hash)
 					if bigHash.Cmp(fork.CompactToBig(mb.Header.Bits)) <= 0 {
-						srs := sol.GetSolContainer(w.senderPort.Load(), mb)
-						err := w.dispatchConn.SendMany(sol.SolutionMagic,
-							transport.GetShards(srs.Data))
-						if err != nil {
-							Error(err)
-						}
-						Trace("sent solution")
-
+						w.sendSolution(mb)
 						break running
 					}
 					mb.Header.Version = nextAlgo
@@ -261,12 +252,87 @@ out:
 	Debug("worker finished")
 }
 
+// sendSolution dispatches a solved block to the controller the work came from
+func (w *Worker) sendSolution(mb *wire.MsgBlock) {
+	srs := sol.GetSolContainer(w.senderPort.Load(), mb)
+	err := w.dispatchConn.SendMany(sol.SolutionMagic,
+		transport.GetShards(srs.Data))
+	if err != nil {
+		Error(err)
+	}
+	Trace("sent solution")
+	// whether the block is accepted is only known to the node, the worker counts what it found
+	w.stats.AddSolution(w.id, time.Now())
+}
+
+// gpuAlgo returns the first block version in the work that can be mined on a GPU at the height
+func (w *Worker) gpuAlgo(height int32) (ver int32, ok bool) {
+	for _, v := range w.roller.Algos.Load().([]int32) {
+		if gpu.Supported(fork.GetAlgoName(v, height), height) {
+			return v, true
+		}
+	}
+	return
+}
+
+// searchGPU runs the GPU over the nonces following those it searched last, returning whether it solved the block
+func (w *Worker) searchGPU() (solved bool) {
+	nH := w.block.Load().(*util.Block).Height()
+	hv, ok := w.gpuAlgo(nH)
+	if !ok {
+		// none of the algorithms in the work can be mined on a GPU, wait for work that has one
+		time.Sleep(time.Millisecond * 100)
+		return
+	}
+	mr, ok := w.hashes.Load().(map[int32]*chainhash.Hash)[hv]
+	if !ok {
+		return
+	}
+	bits, ok := w.bitses.Load().(blockchain.TargetBits)[hv]
+	if !ok {
+		return
+	}
+	mmb := w.msgBlock.Load().(wire.MsgBlock)
+	mb := &mmb
+	mb.Header.Version = hv
+	mb.Header.MerkleRoot = *mr
+	mb.Header.Bits = bits
+	mb.Header.Timestamp = time.Now()
+	var header bytes.Buffer
+	if err := mb.Header.Serialize(&header); Check(err) {
+		return
+	}
+	target := fork.CompactToBig(bits)
+	nonce, found, count, err := w.gpu.Search(header.Bytes(), target, mb.Header.Nonce)
+	if err != nil {
+		Error(w.gpu.Device(), err)
+		time.Sleep(time.Second)
+		return
+	}
+	w.stats.AddHashes(w.id, fork.GetAlgoName(hv, nH), uint64(count), time.Now())
+	hashReport := hashrate.Get(int32(count), hv, nH, w.id)
+	if err = w.dispatchConn.SendMany(hashrate.HashrateMagic, transport.GetShards(hashReport.Data)); Check(err) {
+	}
+	if found {
+		mb.Header.Nonce = nonce
+		hash := mb.Header.BlockHashWithAlgos(nH)
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			w.sendSolution(mb)
+			return true
+		}
+		Warn(w.gpu.Device(), "found a nonce that does not solve the block")
+	}
+	mb.Header.Nonce += count
+	w.msgBlock.Store(*mb)
+	return
+}
+
 // New initialises the state for a worker, loading the work function handler that runs a round of processing between
 // checking quit signal and work semaphore
-func New(id string, quit chan struct{}) (w *Worker, conn net.Conn) {
+func New(id string, miner *gpu.Miner, quit chan struct{}) (w *Worker, conn net.Conn) {
 	// log.L.SetLevel("trace", true)
 	sc := stdconn.New(os.Stdin, os.Stdout, quit)
-	return NewWithConnAndSemaphore(id, &sc, quit), &sc
+	return NewWithConnAndSemaphore(id, miner, &sc, quit), &sc
 }
 
 // NewJob is a delivery of a new job for the worker, this makes the miner start mining from pause or pause, prepare the
@@ -285,16 +351,17 @@ func (w *Worker) NewJob(job *job.Container, reply *bool) (err error) {
 		*reply = true
 		return
 	}
+	newHeight := job.GetNewHeight()
 	var algos []int32
 	for i := range j.Bitses {
 		// we don't need to know net params if version numbers come with jobs
 		algos = append(algos, i)
 	}
+	algos = w.preferred(algos, newHeight)
 	w.lastMerkle = j.Hashes[5]
 	*reply = true
 	// halting current work
 	w.stopChan <- struct{}{}
-	newHeight := job.GetNewHeight()
 
 	if len(algos) > 0 {
 		// if we didn't get them in the job don't update the old
@@ -335,6 +402,34 @@ func (w *Worker) NewJob(job *job.Container, reply *bool) (err error) {
 	return
 }
 
+// preferred returns the algorithm versions in the work that the worker was told to mine, or all of them if it wasn't
+// told to mine any of them
+func (w *Worker) preferred(algos []int32, height int32) (out []int32) {
+	prefer := w.prefer.Load().([]string)
+	for _, v := range algos {
+		name := fork.GetAlgoName(v, height)
+		for _, p := range prefer {
+			if strings.EqualFold(strings.TrimSpace(p), name) {
+				out = append(out, v)
+				break
+			}
+		}
+	}
+	if len(out) == 0 {
+		return algos
+	}
+	return
+}
+
+// SetAlgos gives the names of the algorithms the worker mines out of those in the work, all of them are mined if none
+// of the names are in it
+func (w *Worker) SetAlgos(names []string, reply *bool) (err error) {
+	Debug("mining algorithms", names)
+	w.prefer.Store(names)
+	*reply = true
+	return
+}
+
 // Pause signals the worker to stop working, releases its semaphore and the worker is then idle
 func (w *Worker) Pause(_ int, reply *bool) (err error) {
 	Trace("pausing from IPC")
@@ -355,6 +450,17 @@ func (w *Worker) Stop(_ int, reply *bool) (err error) {
 	return
 }
 
+// Stats returns the hashrate, hashes and solutions of the worker, totalled over the algorithms it mined. A GPU worker
+// reports the device it mines on as its id.
+func (w *Worker) Stats(_ int, reply *btcjson.MinerWorkerStatsResult) (err error) {
+	id := w.id
+	if w.gpu != nil {
+		id = w.gpu.Device().String()
+	}
+	*reply = w.stats.Total(id, time.Now())
+	return
+}
+
 // SendPass gives the encryption key configured in the kopach controller ( pod) configuration to allow workers to
 // dispatch their solutions
 func (w *Worker) SendPass(pass string, reply *bool) (err error) {
@@ -364,7 +470,7 @@ func (w *Worker) SendPass(pass string, reply *bool) (err error) {
 	// rp := fmt.Sprint(rand.Intn(32767) + 1025)
 	var conn *transport.Channel
 	conn, err = transport.NewBroadcastChannel(
-		"kopachworker", w, pass, transport.DefaultPort,
+		"kopachworker", w, pass, nil, transport.DefaultPort,
 		control.MaxDatagramSize, transport.Handlers{}, w.Quit)
 	if err != nil {
 		Error(err)
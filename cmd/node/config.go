@@ -139,6 +139,10 @@ const (
 	DefaultMaxOrphanTransactions = 100
 	// DefaultMaxOrphanTxSize       = 100000
 	DefaultSigCacheMaxSize = 100000
+	// MinPruneTarget is the smallest prune target in MB, which leaves room for the block file being written
+	MinPruneTarget = 550
+	// DefaultPruneDepth is the number of blocks below the tip that are kept when pruning
+	DefaultPruneDepth = 288
 	// These are set to default on because more often one wants them than not
 	// DefaultTxIndex   = true
 	// DefaultAddrIndex = true
@@ -21,6 +21,8 @@ VERSION:
 COMMANDS:
      dropaddrindex  drop the address search index
      droptxindex    drop the address search index
+     dropwtxindex   drop the witness transaction index
+     dropspentindex drop the spent output index
      dropcfindex    drop the address search index
 
 GLOBAL OPTIONS:
@@ -0,0 +1,22 @@
+package node
+
+import (
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/cmd/kopach/control"
+	"github.com/p9c/pod/cmd/kopach/inproc"
+	"github.com/p9c/pod/pkg/rpc/chainrpc"
+)
+
+// runInProcessMiner makes the miner that mines in the node instead of a kopach process, sharing the block template
+// generator of the RPC server, and starts it if generate is on. setgenerate starts and stops it after that.
+func runInProcessMiner(cx *conte.Xt, server *chainrpc.Node) {
+	generator := control.GetBlkTemplateGenerator(cx)
+	if len(server.RPCServers) > 0 {
+		generator = server.RPCServers[0].Cfg.Generator
+	}
+	miner := inproc.New(cx, generator)
+	cx.StateCfg.LocalMiner = miner
+	if *cx.Config.Generate && *cx.Config.GenThreads != 0 {
+		miner.Start(*cx.Config.GenThreads)
+	}
+}
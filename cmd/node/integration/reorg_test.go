@@ -0,0 +1,160 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/cmd/node/integration/rpctest"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// reorgCluster is a cluster of connected nodes along with the branches a reorg script has mined on it
+type reorgCluster struct {
+	nodes []*rpctest.Harness
+	// fork is the best block when the script starts, which the branches grow from
+	fork *chainhash.Hash
+	// branches holds the blocks of each branch by name, in order
+	branches map[string][]*util.Block
+}
+
+// reorgStep is one step of a reorg script
+type reorgStep struct {
+	name string
+	run  func(c *reorgCluster) error
+	// tip names the branch whose last block every node must have as its best block after the step. When it is empty
+	// the chain selection rules decide, and the nodes only have to agree.
+	tip string
+}
+
+// mineBranch returns a step that mines numBlocks blocks with the algorithm algo on the node with the index node, on
+// top of the branch from or the fork point if from is empty, and appends them to the branch named branch. The first
+// block spends a mature output of the wallet of the node to a new address of it.
+func mineBranch(node int, branch, from, algo string, numBlocks int) func(c *reorgCluster) error {
+	return func(c *reorgCluster) error {
+		r := c.nodes[node]
+		parent := c.fork
+		if from != "" {
+			parent = c.branches[from][len(c.branches[from])-1].Hash()
+		}
+		var txns []*util.Tx
+		if r.ConfirmedBalance() > 0 {
+			addr, err := r.NewAddress()
+			if err != nil {
+				return err
+			}
+			pkScript, err := txscript.PayToAddrScript(addr)
+			if err != nil {
+				return err
+			}
+			output := wire.NewTxOut(int64(util.SatoshiPerBitcoin), pkScript)
+			tx, err := r.CreateTransaction([]*wire.TxOut{output}, 10, true)
+			if err != nil {
+				return err
+			}
+			// The inputs are spent by the block, and return to the wallet when the block is disconnected.
+			r.UnlockOutputs(tx.TxIn)
+			txns = append(txns, util.NewTx(tx))
+		}
+		blocks, err := r.GenerateBranch(parent, algo, numBlocks, txns)
+		if err != nil {
+			return err
+		}
+		c.branches[branch] = append(c.branches[branch], blocks...)
+		return nil
+	}
+}
+
+// invalidateBranch returns a step that invalidates the first block of the branch on every node
+func invalidateBranch(branch string) func(c *reorgCluster) error {
+	return func(c *reorgCluster) error {
+		for _, r := range c.nodes {
+			if err := r.Node.InvalidateBlock(c.branches[branch][0].Hash()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// reconsiderBranch returns a step that reconsiders the first block of the branch on every node
+func reconsiderBranch(branch string) func(c *reorgCluster) error {
+	return func(c *reorgCluster) error {
+		for _, r := range c.nodes {
+			if err := r.Node.ReconsiderBlock(c.branches[branch][0].Hash()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// TestReorgScript drives scripted reorganizations on a cluster of two simnet nodes, mining competing branches with
+// different algorithms and switching between them with invalidateblock and reconsiderblock. After each step the nodes
+// must agree on the best block, and the wallet and the tx and address indexes of each node must match its main chain.
+func TestReorgScript(t *testing.T) {
+	podCfg := []string{"--txindex", "--addrindex"}
+	var nodes []*rpctest.Harness
+	for i := 0; i < 2; i++ {
+		r, err := rpctest.New(&netparams.SimNetParams, nil, podCfg)
+		if err != nil {
+			t.Fatalf("unable to create harness #%d: %v", i, err)
+		}
+		defer r.TearDown()
+		// Only the first node mines the mature outputs spent in the script.
+		if err := r.SetUp(i == 0, 5); err != nil {
+			t.Fatalf("unable to setup harness #%d: %v", i, err)
+		}
+		nodes = append(nodes, r)
+	}
+	if err := rpctest.ConnectNode(nodes[1], nodes[0]); err != nil {
+		t.Fatalf("unable to connect the nodes: %v", err)
+	}
+	if err := rpctest.JoinNodes(nodes, rpctest.Blocks); err != nil {
+		t.Fatalf("unable to join the nodes: %v", err)
+	}
+	forkHash, _, err := nodes[0].Node.GetBestBlock()
+	if err != nil {
+		t.Fatalf("unable to get the best block: %v", err)
+	}
+	c := &reorgCluster{nodes: nodes, fork: forkHash, branches: make(map[string][]*util.Block)}
+	script := []reorgStep{
+		{"mine a sha256d branch", mineBranch(0, "a", "", fork.SHA256d, 2), "a"},
+		{"mine a competing scrypt branch", mineBranch(1, "b", "", fork.Scrypt, 3), ""},
+		{"invalidate the sha256d branch", invalidateBranch("a"), "b"},
+		{"reconsider the sha256d branch", reconsiderBranch("a"), ""},
+		{"invalidate the scrypt branch", invalidateBranch("b"), "a"},
+		{"extend the sha256d branch with scrypt blocks", mineBranch(1, "a", "a", fork.Scrypt, 2), "a"},
+		{"reconsider the scrypt branch", reconsiderBranch("b"), ""},
+		{"invalidate the extended branch", invalidateBranch("a"), "b"},
+		{"reconsider the extended branch", reconsiderBranch("a"), ""},
+	}
+	for i, step := range script {
+		if err := step.run(c); err != nil {
+			t.Fatalf("step #%d (%s): %v", i, step.name, err)
+		}
+		if err := rpctest.JoinNodes(nodes, rpctest.Blocks); err != nil {
+			t.Fatalf("step #%d (%s): unable to join the nodes: %v", i, step.name, err)
+		}
+		for j, r := range nodes {
+			if step.tip != "" {
+				branch := c.branches[step.tip]
+				want := branch[len(branch)-1].Hash()
+				best, _, err := r.Node.GetBestBlock()
+				if err != nil {
+					t.Fatalf("step #%d (%s): unable to get the best block of node #%d: %v", i, step.name, j, err)
+				}
+				if !best.IsEqual(want) {
+					t.Fatalf("step #%d (%s): best block of node #%d is %v, want %v of branch %s", i, step.name, j,
+						best, want, step.tip)
+				}
+			}
+			if err := r.CheckConsistency(); err != nil {
+				t.Fatalf("step #%d (%s): node #%d: %v", i, step.name, j, err)
+			}
+		}
+	}
+}
@@ -16,9 +16,10 @@ import (
 )
 
 // solveBlock attempts to find a nonce which makes the passed block header hash to a value less than the target
-// difficulty. When a successful solution is found true is returned and the nonce field of the passed header is updated
-// with the solution. False is returned if no solution exists.
-func solveBlock(header *wire.BlockHeader, targetDifficulty *big.Int) bool {
+// difficulty, using the proof of work algorithm of the block version at the given height. When a successful solution is
+// found true is returned and the nonce field of the passed header is updated with the solution. False is returned if no
+// solution exists.
+func solveBlock(header *wire.BlockHeader, height int32, targetDifficulty *big.Int) bool {
 	// sbResult is used by the solver goroutines to send results.
 	type sbResult struct {
 		found bool
@@ -35,7 +36,7 @@ func solveBlock(header *wire.BlockHeader, targetDifficulty *big.Int) bool {
 				return
 			default:
 				hdr.Nonce = i
-				hash := hdr.BlockHash()
+				hash := hdr.BlockHashWithAlgos(height)
 				if blockchain.HashToBig(&hash).Cmp(targetDifficulty) <= 0 {
 					select {
 					case results <- sbResult{true, i}:
@@ -175,7 +176,7 @@ func CreateBlock(prevBlock *util.Block, inclusionTxs []*util.Tx,
 			return nil, err
 		}
 	}
-	found := solveBlock(&block.Header, net.PowLimit)
+	found := solveBlock(&block.Header, blockHeight, net.PowLimit)
 	if !found {
 		return nil, errors.New("Unable to solve block")
 	}
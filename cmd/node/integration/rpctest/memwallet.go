@@ -271,6 +271,9 @@ func (m *memWallet) UnwindBlock(height int32, header *wire.BlockHeader) {
 
 // unwindBlock undoes the effect that a particular block had on the wallet's internal utxo state.
 func (m *memWallet) unwindBlock(update *chainUpdate) {
+	// The parent of the disconnected block is the new tip, so coinbase maturity is judged from it until the next block
+	// is connected.
+	m.currentHeight = update.blockHeight - 1
 	undo := m.reorgJournal[update.blockHeight]
 	for _, utxo := range undo.utxosCreated {
 		delete(m.utxos, utxo)
@@ -480,6 +483,20 @@ func (m *memWallet) ConfirmedBalance() util.Amount {
 	return balance
 }
 
+// matureOutputs returns the height the wallet is synced to and the values of its mature and unlocked outputs, which
+// make up its confirmed balance. This function is safe for concurrent access.
+func (m *memWallet) matureOutputs() (int32, map[wire.OutPoint]util.Amount) {
+	m.RLock()
+	defer m.RUnlock()
+	outputs := make(map[wire.OutPoint]util.Amount)
+	for op, utxo := range m.utxos {
+		if utxo.isMature(m.currentHeight) && !utxo.isLocked {
+			outputs[op] = utxo.value
+		}
+	}
+	return m.currentHeight, outputs
+}
+
 // keyToAddr maps the passed private to corresponding p2pkh address.
 func keyToAddr(key *ec.PrivateKey, net *netparams.Params) (util.Address, error) {
 	serializedKey := key.PubKey().SerializeCompressed()
@@ -0,0 +1,221 @@
+package rpctest
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// consistencyTimeout is how long CheckConsistency waits for the wallet of a harness to catch up with its node
+const consistencyTimeout = 10 * time.Second
+
+// GenerateBranch creates numBlocks blocks with the proof of work algorithm named by algo on top of the block with the
+// passed hash and submits them to the node, returning the blocks in order. The first block includes the passed
+// transactions. The parent does not have to be the tip of the main chain, so this builds the competing branches that
+// make the node reorganize its chain. This function is safe for concurrent access.
+func (h *Harness) GenerateBranch(parent *chainhash.Hash, algo string, numBlocks int,
+	txns []*util.Tx) ([]*util.Block, error) {
+	h.Lock()
+	defer h.Unlock()
+	mBlock, err := h.Node.GetBlock(parent)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	header, err := h.Node.GetBlockHeaderVerbose(parent)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	prevBlock := util.NewBlock(mBlock)
+	prevBlock.SetHeight(header.Height)
+	blocks := make([]*util.Block, 0, numBlocks)
+	for i := 0; i < numBlocks; i++ {
+		version := fork.GetAlgoVer(algo, prevBlock.Height()+1)
+		block, err := CreateBlock(prevBlock, txns, version, time.Time{}, h.wallet.coinbaseAddr, nil, h.ActiveNet)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		if err := h.Node.SubmitBlock(block, nil); err != nil {
+			return nil, fmt.Errorf("block %v at height %d was rejected: %v", block.Hash(), block.Height(), err)
+		}
+		blocks = append(blocks, block)
+		prevBlock, txns = block, nil
+	}
+	return blocks, nil
+}
+
+// CheckConsistency verifies that the wallet of the harness and the optional indexes of its node agree with the main
+// chain of the node, as they must after the chain has been reorganized:
+//
+//   - The mature outputs of the wallet are exactly the unspent outputs of the main chain paying to it, and so its
+//     confirmed balance is their sum
+//   - With --txindex, every transaction of the main chain is indexed in the main chain block holding it
+//   - With --addrindex, the transactions of the main chain paying to the coinbase address of the wallet are found for
+//     it, and nothing else that is not in the mempool is
+//
+// The wallet is given a few seconds to process the notifications of the last reorganization. The main chain must not
+// change while the check runs.
+func (h *Harness) CheckConsistency() error {
+	bestHash, bestHeight, err := h.Node.GetBestBlock()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	h.wallet.RLock()
+	scripts := make([][]byte, 0, len(h.wallet.addrs))
+	for _, addr := range h.wallet.addrs {
+		scripts = append(scripts, addr.ScriptAddress())
+	}
+	h.wallet.RUnlock()
+	coinbaseScript := h.wallet.coinbaseAddr.ScriptAddress()
+	txIndex, addrIndex := h.hasArg("--txindex"), h.hasArg("--addrindex")
+	want := make(map[wire.OutPoint]util.Amount)
+	mainTxns := make(map[chainhash.Hash]struct{})
+	var addrTxns []chainhash.Hash
+	for height := int32(1); height <= bestHeight; height++ {
+		hash, err := h.Node.GetBlockHash(int64(height))
+		if err != nil {
+			Error(err)
+			return err
+		}
+		block, err := h.Node.GetBlock(hash)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		for _, tx := range block.Transactions {
+			txHash := tx.TxHash()
+			mainTxns[txHash] = struct{}{}
+			if txIndex {
+				res, err := h.Node.GetRawTransactionVerbose(&txHash)
+				if err != nil {
+					return fmt.Errorf("transaction %v of block %v (height %d) is not in the tx index: %v",
+						txHash, hash, height, err)
+				}
+				if res.BlockHash != hash.String() {
+					return fmt.Errorf("transaction %v of block %v (height %d) is indexed in block %s",
+						txHash, hash, height, res.BlockHash)
+				}
+			}
+			paysCoinbase := false
+			for i, txOut := range tx.TxOut {
+				if bytes.Contains(txOut.PkScript, coinbaseScript) {
+					paysCoinbase = true
+				}
+				mine := false
+				for _, script := range scripts {
+					mine = mine || bytes.Contains(txOut.PkScript, script)
+				}
+				if !mine || blockchain.IsCoinBaseTx(tx) && bestHeight < height+int32(h.ActiveNet.CoinbaseMaturity) {
+					continue
+				}
+				res, err := h.Node.GetTxOut(&txHash, uint32(i), false)
+				if err != nil {
+					Error(err)
+					return err
+				}
+				if res != nil {
+					want[wire.OutPoint{Hash: txHash, Index: uint32(i)}] = util.Amount(txOut.Value)
+				}
+			}
+			if paysCoinbase {
+				addrTxns = append(addrTxns, txHash)
+			}
+		}
+	}
+	if addrIndex {
+		if err = h.checkAddrIndex(mainTxns, addrTxns); err != nil {
+			return err
+		}
+	}
+	var wantBalance util.Amount
+	for _, value := range want {
+		wantBalance += value
+	}
+	deadline := time.Now().Add(consistencyTimeout)
+	for {
+		height, have := h.wallet.matureOutputs()
+		var missing, extra int
+		for op, value := range want {
+			if v, ok := have[op]; !ok || v != value {
+				missing++
+			}
+		}
+		for op := range have {
+			if _, ok := want[op]; !ok {
+				extra++
+			}
+		}
+		if height == bestHeight && missing == 0 && extra == 0 && h.wallet.ConfirmedBalance() == wantBalance {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("wallet synced to height %d does not match the chain at block %v (height %d): "+
+				"balance %v, want %v, %d outputs missing and %d not in the chain", height, bestHash, bestHeight,
+				h.wallet.ConfirmedBalance(), wantBalance, missing, extra)
+		}
+		time.Sleep(time.Millisecond * 100)
+	}
+}
+
+// checkAddrIndex verifies that the address index finds the passed transactions paying to the coinbase address of the
+// wallet, and that every other transaction it finds is either in the main chain or in the mempool
+func (h *Harness) checkAddrIndex(mainTxns map[chainhash.Hash]struct{}, addrTxns []chainhash.Hash) error {
+	const pageSize = 100
+	found := make(map[chainhash.Hash]struct{})
+	for skip := 0; ; skip += pageSize {
+		txns, err := h.Node.SearchRawTransactions(h.wallet.coinbaseAddr, skip, pageSize, false, nil)
+		// Searching past the last transaction of the address finds nothing, which is an error.
+		if rpcErr, ok := err.(*btcjson.RPCError); ok && rpcErr.Code == btcjson.ErrRPCNoTxInfo {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("address index search failed: %v", err)
+		}
+		for _, tx := range txns {
+			found[tx.TxHash()] = struct{}{}
+		}
+		if len(txns) < pageSize {
+			break
+		}
+	}
+	for _, txHash := range addrTxns {
+		if _, ok := found[txHash]; !ok {
+			return fmt.Errorf("transaction %v of the main chain is not in the address index", txHash)
+		}
+	}
+	mempool, err := h.Node.GetRawMempool()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	for _, txHash := range mempool {
+		mainTxns[*txHash] = struct{}{}
+	}
+	for txHash := range found {
+		if _, ok := mainTxns[txHash]; !ok {
+			return fmt.Errorf("address index holds transaction %v, which is neither in the main chain nor the mempool",
+				txHash)
+		}
+	}
+	return nil
+}
+
+// hasArg returns whether the node of the harness was started with the passed argument
+func (h *Harness) hasArg(arg string) bool {
+	for _, a := range h.node.config.extra {
+		if a == arg {
+			return true
+		}
+	}
+	return false
+}
@@ -95,13 +95,19 @@ func Main(cx *conte.Xt) (err error) {
 		return nil
 	}
 	// drop indexes and exit if requested. NOTE: The order is important here because dropping the tx index also drops
-	// the address index since it relies on it
+	// the address and witness transaction indexes since they rely on it
 	if cx.StateCfg.DropAddrIndex {
 		Warn("dropping address index")
 		if err = indexers.DropAddrIndex(db, interrupt.ShutdownRequestChan); Check(err) {
 			return
 		}
 	}
+	if cx.StateCfg.DropWTxIndex {
+		Warn("dropping witness transaction index")
+		if err = indexers.DropWTxIndex(db, interrupt.ShutdownRequestChan); Check(err) {
+			return
+		}
+	}
 	if cx.StateCfg.DropTxIndex {
 		Warn("dropping transaction index")
 		if err = indexers.DropTxIndex(db, interrupt.ShutdownRequestChan); Check(err) {
@@ -114,6 +120,12 @@ func Main(cx *conte.Xt) (err error) {
 			return
 		}
 	}
+	if cx.StateCfg.DropSpentIndex {
+		Warn("dropping spent output index")
+		if err = indexers.DropSpentIndex(db, interrupt.ShutdownRequestChan); Check(err) {
+			return
+		}
+	}
 	// return now if an interrupt signal was triggered
 	if interrupt.Requested() {
 		return nil
@@ -124,6 +136,8 @@ func Main(cx *conte.Xt) (err error) {
 		Errorf("unable to start server on %v: %v", *cx.Config.Listeners, err)
 		return err
 	}
+	server.Metrics.GaugeFunc("pod_miner_hashes_per_second", "Hashrate of the kopach workers mining on this node",
+		func() float64 { return cx.MinerStats.Hashrate() })
 	server.Start()
 	cx.RealNode = server
 	if len(server.RPCServers) > 0 {
@@ -139,10 +153,19 @@ func Main(cx *conte.Xt) (err error) {
 	Debug("starting controller")
 	control.Run(cx)
 	Debug("controller started")
+	if *cx.Config.InProcessMiner && !*cx.Config.NoMining {
+		runInProcessMiner(cx, server)
+	}
+	if len(*cx.Config.StratumListeners) > 0 {
+		runStratum(cx)
+	}
 	cx.Controller.Store(true)
 	gracefulShutdown := func() {
 		Info("gracefully shutting down the server...")
 		Debug("stopping controller")
+		if cx.StateCfg.LocalMiner != nil {
+			cx.StateCfg.LocalMiner.Stop()
+		}
 		e := server.Stop()
 		if e != nil {
 			Warn("failed to stop server", e)
@@ -68,6 +68,36 @@ type observedTransaction struct {
 	mined int32
 }
 
+// EstimateMode selects how cautious the estimates of EstimateSmartFee are.
+type EstimateMode int
+
+const (
+	// EstimateConservative estimates from all of the tracked transactions and needs 95% of them to confirm in time,
+	// which is less likely to underpay when fees rise.
+	EstimateConservative EstimateMode = iota
+	// EstimateEconomical estimates from the transactions mined in recent blocks and needs 85% of them to confirm in
+	// time, which follows falling fees sooner.
+	EstimateEconomical
+)
+
+// ParseEstimateMode returns the estimate mode with the passed name, which is conservative or economical in any case.
+func ParseEstimateMode(name string) (EstimateMode, error) {
+	switch strings.ToLower(name) {
+	case "conservative":
+		return EstimateConservative, nil
+	case "economical":
+		return EstimateEconomical, nil
+	}
+	return 0, fmt.Errorf("unknown fee estimate mode %q", name)
+}
+
+// feeSample is a tracked transaction as seen by EstimateSmartFee.
+type feeSample struct {
+	feeRate SatoshiPerByte
+	// The number of blocks it took to confirm.
+	blocks int32
+}
+
 // observedTxSet is a set of txs that can that is sorted by hash. It exists for serialization purposes so that a
 // serialized state always comes out the same.
 type observedTxSet []*observedTransaction
@@ -96,6 +126,17 @@ const (
 	DefaultEstimateFeeMinRegisteredBlocks = 3
 	bytePerKb                             = 1000
 	duoPerSatoshi                         = 1e-8
+	// smartFeeBucketSpacing is the ratio between the fee rates bounding the buckets that EstimateSmartFee groups
+	// transactions in.
+	smartFeeBucketSpacing = 1.1
+	// smartFeeMinSamples is the number of transactions a range of buckets needs before its confirmation rate is used.
+	smartFeeMinSamples = 10
+	// smartFeeEconomicalHorizon is the number of recent blocks the transactions for economical estimates are mined in.
+	smartFeeEconomicalHorizon = estimateFeeDepth
+	// smartFeeConservativeThreshold and smartFeeEconomicalThreshold are the shares of the transactions paying a fee
+	// rate that must have confirmed within the target for it to be estimated.
+	smartFeeConservativeThreshold = 0.95
+	smartFeeEconomicalThreshold   = 0.85
 )
 
 // In case the format for the serialized version of the FeeEstimator changes, we use a version number. If the version
@@ -131,6 +172,79 @@ func (ef *FeeEstimator) EstimateFee(numBlocks uint32) (DUOPerKilobyte, error) {
 	return ef.cached[int(numBlocks)-1].ToBtcPerKb(), nil
 }
 
+// EstimateSmartFee estimates the fee per kilobyte for a transaction to confirm within confTarget blocks, and returns it
+// along with the number of blocks the estimate is for. The tracked transactions are grouped in buckets by fee rate, and
+// the estimate is the median fee rate of the cheapest range of buckets in which enough of the transactions confirmed
+// within the target, scanning down from the most expensive one. When there is not enough data for the target, the
+// estimate is for the nearest longer target that has it. Targets longer than the blocks tracked are shortened to them.
+func (ef *FeeEstimator) EstimateSmartFee(confTarget uint32, mode EstimateMode) (DUOPerKilobyte, uint32, error) {
+	ef.mtx.Lock()
+	defer ef.mtx.Unlock()
+	if ef.numBlocksRegistered < ef.minRegisteredBlocks {
+		return -1, 0, errors.New("not enough blocks have been observed")
+	}
+	if confTarget == 0 {
+		return -1, 0, errors.New("cannot confirm transaction in zero blocks")
+	}
+	if confTarget > estimateFeeDepth {
+		confTarget = estimateFeeDepth
+	}
+	threshold := smartFeeConservativeThreshold
+	var samples []feeSample
+	for i, b := range ef.bin {
+		for _, o := range b {
+			if mode == EstimateEconomical && ef.lastKnownHeight-o.mined >= smartFeeEconomicalHorizon {
+				continue
+			}
+			samples = append(samples, feeSample{feeRate: o.feeRate, blocks: int32(i) + 1})
+		}
+	}
+	if mode == EstimateEconomical {
+		threshold = smartFeeEconomicalThreshold
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].feeRate > samples[j].feeRate })
+	for target := confTarget; target <= estimateFeeDepth; target++ {
+		if rate, ok := estimateBucketed(samples, int32(target), threshold); ok {
+			return rate.ToBtcPerKb(), target, nil
+		}
+	}
+	return -1, 0, errors.New("insufficient data or no feerate found")
+}
+
+// estimateBucketed returns the estimated fee rate for a transaction to confirm within target blocks from samples
+// sorted by descending fee rate, and whether enough of the samples confirmed in time for an estimate. The samples are
+// collected into ranges of buckets from the highest fee rate down, a range being complete once it ends at a bucket
+// boundary holding smartFeeMinSamples. The estimate is the median fee rate of the last complete range in which the
+// share confirmed within the target reached threshold, the scan stopping at the first one that falls short of it.
+func estimateBucketed(samples []feeSample, target int32, threshold float64) (rate SatoshiPerByte, ok bool) {
+	bucket := func(r SatoshiPerByte) int {
+		if r <= 1 {
+			return 0
+		}
+		return int(math.Log(float64(r)) / math.Log(smartFeeBucketSpacing))
+	}
+	start, confirmed := 0, 0
+	for i, s := range samples {
+		if s.blocks <= target {
+			confirmed++
+		}
+		// A range only ends where the bucket changes, so transactions paying the same fee rate are never split.
+		if i+1 < len(samples) && bucket(samples[i+1].feeRate) == bucket(s.feeRate) {
+			continue
+		}
+		total := i + 1 - start
+		if total < smartFeeMinSamples {
+			continue
+		}
+		if float64(confirmed)/float64(total) < threshold {
+			break
+		}
+		rate, ok = samples[start+total/2].feeRate, true
+		start, confirmed = i+1, 0
+	}
+	return
+}
+
 func // LastKnownHeight returns the height of the last block which was
 // registered.
 (ef *FeeEstimator) LastKnownHeight() int32 {
@@ -369,3 +369,60 @@ func newTestFeeEstimator(binSize, maxReplacements, maxRollback uint32) *FeeEstim
 		dropped:             make([]*registeredBlock, 0, maxRollback),
 	}
 }
+
+// TestEstimateSmartFee tests that smart fee estimates find the cheapest fee rate that confirmed within the target, and
+// that economical estimates forget old blocks.
+func TestEstimateSmartFee(t *testing.T) {
+	eft := estimateFeeTester{ef: newTestFeeEstimator(100, 100, 1), t: t}
+	if _, _, err := eft.ef.EstimateSmartFee(1, EstimateConservative); err == nil {
+		t.Fatal("estimated a fee without any transactions")
+	}
+	// Transactions paying the high fee confirm in the next block, the ones paying the low fee only in the fourth.
+	var fast, slow []*TxDesc
+	var fastTxs, slowTxs []*wire.MsgTx
+	for i := 0; i < 20; i++ {
+		fast = append(fast, eft.testTx(10000))
+		slow = append(slow, eft.testTx(1000))
+		eft.ef.ObserveTransaction(fast[i])
+		eft.ef.ObserveTransaction(slow[i])
+		fastTxs = append(fastTxs, fast[i].Tx.MsgTx())
+		slowTxs = append(slowTxs, slow[i].Tx.MsgTx())
+	}
+	eft.newBlock(fastTxs)
+	eft.newBlock(nil)
+	eft.newBlock(nil)
+	eft.newBlock(slowTxs)
+	tests := []struct {
+		target, blocks uint32
+		want           DUOPerKilobyte
+	}{
+		{1, 1, expectedFeePerKilobyte(fast[0])},
+		{3, 3, expectedFeePerKilobyte(fast[0])},
+		{4, 4, expectedFeePerKilobyte(slow[0])},
+		{estimateFeeDepth + 5, estimateFeeDepth, expectedFeePerKilobyte(slow[0])},
+	}
+	for _, mode := range []EstimateMode{EstimateConservative, EstimateEconomical} {
+		for _, test := range tests {
+			got, blocks, err := eft.ef.EstimateSmartFee(test.target, mode)
+			if err != nil {
+				t.Fatalf("mode %d target %d: %v", mode, test.target, err)
+			}
+			if got != test.want || blocks != test.blocks {
+				t.Errorf("mode %d target %d: got %v for %d blocks, want %v for %d blocks", mode, test.target, got,
+					blocks, test.want, test.blocks)
+			}
+		}
+	}
+	// Once the blocks are past the economical horizon only conservative estimates use them.
+	for i := 0; i < smartFeeEconomicalHorizon; i++ {
+		eft.newBlock(nil)
+	}
+	if _, _, err := eft.ef.EstimateSmartFee(4, EstimateEconomical); err == nil {
+		t.Error("economical estimate used transactions mined before the horizon")
+	}
+	if got, _, err := eft.ef.EstimateSmartFee(4, EstimateConservative); err != nil ||
+		got != expectedFeePerKilobyte(slow[0]) {
+		t.Errorf("conservative estimate after the horizon: got %v (%v), want %v", got, err,
+			expectedFeePerKilobyte(slow[0]))
+	}
+}
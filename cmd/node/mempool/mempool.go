@@ -54,6 +54,10 @@ type Config struct {
 	// FeeEstimatator provides a feeEstimator. If it is not nil, the mempool records all new transactions it
 	// observes into the feeEstimator.
 	FeeEstimator *FeeEstimator
+	// TxEvicted, if not nil, is called for every transaction removed from the pool without being mined, with the
+	// replacement whose acceptance removed it, or nil when it was removed for another reason such as conflicting with a
+	// connected block. It is called with the mempool lock held so it must not call back into the pool.
+	TxEvicted func(tx *util.Tx, replacedBy *util.Tx)
 }
 
 // Policy houses the policy (configuration parameters) that is used to control the mempool.
@@ -80,6 +84,10 @@ type Policy struct {
 	MaxSigOpCostPerTx int
 	// MinRelayTxFee defines the minimum transaction fee in DUO/kB to be considered a non-zero fee.
 	MinRelayTxFee util.Amount
+	// RejectReplacement defines whether to reject transactions replacing transactions in the pool that signal
+	// replaceability as described in BIP125. If true, any transaction spending an output already spent in the pool is
+	// rejected as a double spend.
+	RejectReplacement bool
 }
 
 // Tag represents an identifier to use for tagging orphan transactions. The caller may choose any scheme it desires
@@ -131,8 +139,37 @@ const (
 	// orphanExpireScanInterval is the minimum amount of time in between
 	// scans of the orphan pool to evict expired transactions.
 	orphanExpireScanInterval = time.Minute * 5
+	// MaxRBFSequence is the maximum sequence number an input can use to signal that the transaction spending it can be
+	// replaced, as described in BIP125.
+	MaxRBFSequence = 0xfffffffd
+	// MaxReplacementEvictions is the maximum number of transactions that can be evicted from the pool when accepting a
+	// replacement transaction.
+	MaxReplacementEvictions = 100
 )
 
+// MempoolAcceptResult describes a transaction that would be accepted into the memory pool.
+type MempoolAcceptResult struct {
+	// TxFee is the fee paid by the transaction and TxSize is its virtual size.
+	TxFee  int64
+	TxSize int64
+	// Replaces are the transactions in the pool, along with their descendants, that the transaction would replace.
+	Replaces []*util.Tx
+	// MissingParents are the hashes of the unknown parents of an orphan transaction. An orphan is not checked further
+	// as its inputs are not known.
+	MissingParents []*chainhash.Hash
+}
+
+// mempoolAcceptance is the outcome of the checks of a transaction for acceptance into the memory pool.
+type mempoolAcceptance struct {
+	missingParents []*chainhash.Hash
+	utxoView       *blockchain.UtxoViewpoint
+	bestHeight     int32
+	fee            int64
+	size           int64
+	// replaces are the transactions in the pool, along with their descendants, the transaction replaces.
+	replaces map[chainhash.Hash]*util.Tx
+}
+
 var // Ensure the TxPool type implements the mining.TxSource interface.
 	_ mining.TxSource = (*TxPool)(nil)
 
@@ -145,6 +182,27 @@ func (mp *TxPool) CheckSpend(op wire.OutPoint) *util.Tx {
 	return txR
 }
 
+// CheckMempoolAcceptance checks whether the passed transaction would be accepted into the memory pool without adding
+// it, for the testmempoolaccept RPC. The free transaction rate limiter is not applied, as it depends on the
+// transactions relayed before. This function is safe for concurrent access.
+func (mp *TxPool) CheckMempoolAcceptance(b *blockchain.BlockChain, tx *util.Tx) (*MempoolAcceptResult, error) {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	acceptance, err := mp.checkMempoolAcceptance(b, tx, true, false, true)
+	if err != nil {
+		return nil, err
+	}
+	result := &MempoolAcceptResult{
+		TxFee:          acceptance.fee,
+		TxSize:         acceptance.size,
+		MissingParents: acceptance.missingParents,
+	}
+	for _, replaced := range acceptance.replaces {
+		result.Replaces = append(result.Replaces, replaced)
+	}
+	return result, nil
+}
+
 // Count returns the number of transactions in the main pool. It does not include the orphan pool. This function is safe
 // for concurrent access.
 func (mp *TxPool) Count() int {
@@ -167,6 +225,19 @@ func (mp *TxPool) FetchTransaction(txHash *chainhash.Hash) (*util.Tx, error) {
 	return nil, fmt.Errorf("transaction is not in the pool")
 }
 
+// FetchTxDesc returns the descriptor of the requested transaction in the main transaction pool. This function is safe
+// for concurrent access.
+func (mp *TxPool) FetchTxDesc(txHash *chainhash.Hash) (*TxDesc, error) {
+	// Protect concurrent access.
+	mp.mtx.RLock()
+	txDesc, exists := mp.pool[*txHash]
+	mp.mtx.RUnlock()
+	if exists {
+		return txDesc, nil
+	}
+	return nil, fmt.Errorf("transaction is not in the pool")
+}
+
 // HaveTransaction returns whether or not the passed transaction already exists in the main pool or in the orphan pool.
 // This function is safe for concurrent access.
 func (mp *TxPool) HaveTransaction(hash *chainhash.Hash) bool {
@@ -338,7 +409,7 @@ func (mp *TxPool) RemoveDoubleSpends(tx *util.Tx) {
 	for _, txIn := range tx.MsgTx().TxIn {
 		if txRedeemer, ok := mp.outpoints[txIn.PreviousOutPoint]; ok {
 			if !txRedeemer.Hash().IsEqual(tx.Hash()) {
-				mp.removeTransaction(txRedeemer, true)
+				mp.evictTransaction(txRedeemer, nil)
 			}
 		}
 	}
@@ -374,7 +445,11 @@ func (mp *TxPool) RemoveOrphansByTag(tag Tag) uint64 {
 func (mp *TxPool) RemoveTransaction(tx *util.Tx, removeRedeemers bool) {
 	// Protect concurrent access.
 	mp.mtx.Lock()
-	mp.removeTransaction(tx, removeRedeemers)
+	if removeRedeemers {
+		mp.evictTransaction(tx, nil)
+	} else {
+		mp.removeTransaction(tx, false)
+	}
 	mp.mtx.Unlock()
 }
 
@@ -467,116 +542,32 @@ func (mp *TxPool) addTransaction(utxoView *blockchain.UtxoViewpoint, tx *util.Tx
 }
 
 // checkPoolDoubleSpend checks whether or not the passed transaction is attempting to spend coins already spent by other
-// transactions in the pool. Note it does not check for double spends against transactions already in the main chain.
-// This function MUST be called with the mempool lock held (for reads).
-func (mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) error {
+// transactions in the pool. If it is, and all of those transactions signal replaceability as described in BIP125, the
+// transaction is a replacement for them, which is only accepted if it also passes validateReplacement. Note it does
+// not check for double spends against transactions already in the main chain. This function MUST be called with the
+// mempool lock held (for reads).
+func (mp *TxPool) checkPoolDoubleSpend(tx *util.Tx) (isReplacement bool, err error) {
 	for _, txIn := range tx.MsgTx().TxIn {
-		if txR, exists := mp.outpoints[txIn.PreviousOutPoint]; exists {
+		txR, exists := mp.outpoints[txIn.PreviousOutPoint]
+		if !exists {
+			continue
+		}
+		if mp.cfg.Policy.RejectReplacement || !mp.signalsReplacement(txR, nil) {
 			str := fmt.Sprintf("output %v already spent by "+
 				"transaction %v in the memory pool",
 				txIn.PreviousOutPoint, txR.Hash())
-			return txRuleError(wire.RejectDuplicate, str)
+			return false, txRuleError(wire.RejectDuplicate, str)
 		}
+		isReplacement = true
 	}
-	return nil
+	return isReplacement, nil
 }
 
-// fetchInputUtxos loads utxo details about the input transactions referenced by the passed transaction. First it loads
-// the details form the viewpoint of the main chain, then it adjusts them based upon the contents of the transaction
-// pool. This function MUST be called with the mempool lock held (for reads).
-func (mp *TxPool) fetchInputUtxos(tx *util.Tx) (*blockchain.UtxoViewpoint, error) {
-	utxoView, err := mp.cfg.FetchUtxoView(tx)
-	if err != nil {
-		Error(err)
-		return nil, err
-	}
-	// Attempt to populate any missing inputs from the transaction pool.
-	for _, txIn := range tx.MsgTx().TxIn {
-		prevOut := &txIn.PreviousOutPoint
-		entry := utxoView.LookupEntry(*prevOut)
-		if entry != nil && !entry.IsSpent() {
-			continue
-		}
-		if poolTxDesc, exists := mp.pool[prevOut.Hash]; exists {
-			// AddTxOut ignores out of range index values,
-			// so it is safe to call without bounds checking here.
-			utxoView.AddTxOut(poolTxDesc.Tx, prevOut.Index,
-				mining.UnminedHeight)
-		}
-	}
-	return utxoView, nil
-}
-
-// haveTransaction returns whether or not the passed transaction already exists in the main pool or in the orphan pool.
-// This function MUST be called with the mempool lock held (for reads).
-func (mp *TxPool) haveTransaction(
-	hash *chainhash.Hash) bool {
-	return mp.isTransactionInPool(hash) || mp.isOrphanInPool(hash)
-}
-
-// isOrphanInPool returns whether or not the passed transaction already exists in the orphan pool. This function MUST be
-// called with the mempool lock held (for reads).
-func (mp *TxPool) isOrphanInPool(hash *chainhash.Hash) bool {
-	if _, exists := mp.orphans[*hash]; exists {
-		return true
-	}
-	return false
-}
-
-// isTransactionInPool returns whether or not the passed transaction already exists in the main pool. This function MUST
-// be called with the mempool lock held (for reads).
-func (mp *TxPool) isTransactionInPool(hash *chainhash.Hash) bool {
-	if _, exists := mp.pool[*hash]; exists {
-		return true
-	}
-	return false
-}
-
-// limitNumOrphans limits the number of orphan transactions by evicting a random orphan if adding a new one would cause
-// it to overflow the max allowed. This function MUST be called with the mempool lock held (for writes).
-func (mp *TxPool) limitNumOrphans() error {
-	// Scan through the orphan pool and remove any expired orphans when it's time. This is done for efficiency so the
-	// scan only happens periodically instead of on every orphan added to the pool.
-	if now := time.Now(); now.After(mp.nextExpireScan) {
-		origNumOrphans := len(mp.orphans)
-		for _, otx := range mp.orphans {
-			if now.After(otx.expiration) {
-				// Remove redeemers too because the missing parents are very unlikely to ever materialize since the
-				// orphan has already been around more than long enough for them to be delivered.
-				mp.removeOrphan(otx.tx, true)
-			}
-		}
-		// Set next expiration scan to occur after the scan interval.
-		mp.nextExpireScan = now.Add(orphanExpireScanInterval)
-		numOrphans := len(mp.orphans)
-		if numExpired := origNumOrphans - numOrphans; numExpired > 0 {
-			Debugf("Expired %d %s (remaining: %d)",
-				numExpired, logi.PickNoun(numExpired, "orphan", "orphans"),
-				numOrphans,
-			)
-		}
-	}
-	// Nothing to do if adding another orphan will not cause the pool to exceed the limit.
-	if len(mp.orphans)+1 <= mp.cfg.Policy.MaxOrphanTxs {
-		return nil
-	}
-	// Remove a random entry from the map. For most compilers, Go's range statement iterates starting at a random item
-	// although that is not 100% guaranteed by the spec. The iteration order is not important here because an adversary
-	// would have to be able to pull off preimage attacks on the hashing function in order to target eviction of
-	// specific entries anyways.
-	for _, otx := range mp.orphans {
-		// Don't remove redeemers in the case of a random eviction since it is quite possible it might be needed again
-		// shortly.
-		mp.removeOrphan(otx.tx, false)
-		break
-	}
-	return nil
-}
-
-// maybeAcceptTransaction is the internal function which implements the public MaybeAcceptTransaction. See the comment
-// for MaybeAcceptTransaction for more details. This function MUST be called with the mempool lock held (for writes).
-func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx, isNew, rateLimit, rejectDupOrphans bool,
-) ([]*chainhash.Hash, *TxDesc, error) {
+// checkMempoolAcceptance performs all of the checks for accepting the passed transaction into the memory pool,
+// without adding it. When the transaction is an orphan only the missing parents are set in the result. This function
+// MUST be called with the mempool lock held (for writes when rateLimit is set, otherwise for reads).
+func (mp *TxPool) checkMempoolAcceptance(b *blockchain.BlockChain, tx *util.Tx, isNew, rateLimit,
+	rejectDupOrphans bool) (*mempoolAcceptance, error) {
 	txHash := tx.Hash()
 	// If a transaction has witness data, and segwit isn't active yet, If segwit isn't active yet, then we won't accept
 	// it into the mempool as it can't be mined yet.
@@ -584,23 +575,23 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 		segwitActive, err := mp.cfg.IsDeploymentActive(chaincfg.DeploymentSegwit)
 		if err != nil {
 			Error(err)
-			return nil, nil, err
+			return nil, err
 		}
 		if !segwitActive {
 			str := fmt.Sprintf("transaction %v has witness data, "+
 				"but segwit isn't active yet", txHash)
-			return nil, nil, txRuleError(wire.RejectNonstandard, str)
+			return nil, txRuleError(wire.RejectNonstandard, str)
 		}
 	}
 	if blockchain.ContainsBlacklisted(b, tx, hardfork.Blacklist) {
-		return nil, nil, errors.New("transaction contains blacklisted address")
+		return nil, errors.New("transaction contains blacklisted address")
 	}
 	// Don't accept the transaction if it already exists in the pool. This applies to orphan transactions as well when
 	// the reject duplicate orphans flag is set. This check is intended to be a quick check to weed out duplicates.
 	if mp.isTransactionInPool(txHash) || (rejectDupOrphans &&
 		mp.isOrphanInPool(txHash)) {
 		str := fmt.Sprintf("already have transaction %v", txHash)
-		return nil, nil, txRuleError(wire.RejectDuplicate, str)
+		return nil, txRuleError(wire.RejectDuplicate, str)
 	}
 	// Perform preliminary sanity checks on the transaction. This makes use of blockchain which contains the invariant
 	// rules for what transactions are allowed into blocks.
@@ -608,15 +599,15 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	if err != nil {
 		Error(err)
 		if cErr, ok := err.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, chainRuleError(cErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 	// A standalone transaction must not be a coinbase transaction.
 	if blockchain.IsCoinBase(tx) {
 		str := fmt.Sprintf("transaction %v is an individual coinbase",
 			txHash)
-		return nil, nil, txRuleError(wire.RejectInvalid, str)
+		return nil, txRuleError(wire.RejectInvalid, str)
 	}
 	// Get the current height of the main chain. A standalone transaction will be mined into the next block at best, so
 	// its height is at least one more than the current height.
@@ -642,7 +633,7 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 			}
 			str := fmt.Sprintf("transaction %v is not standard: %v",
 				txHash, err)
-			return nil, nil, txRuleError(rejectCode, str)
+			return nil, txRuleError(rejectCode, str)
 		}
 	}
 	// The transaction may not use any of the same outputs as other transactions already in the pool as that would
@@ -650,10 +641,10 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	// within the transaction pool itself. The transaction could still be double spending coins from the main chain at
 	// this point. There is a more in-depth check that happens later after fetching the referenced transaction inputs
 	// from the main chain which examines the actual spend data and prevents double spends.
-	err = mp.checkPoolDoubleSpend(tx)
+	isReplacement, err := mp.checkPoolDoubleSpend(tx)
 	if err != nil {
 		Error(err)
-		return nil, nil, err
+		return nil, err
 	}
 	// Fetch all of the unspent transaction outputs referenced by the inputs to this transaction. This function also
 	// attempts to fetch the transaction itself to be used for detecting a duplicate transaction without needing to do a
@@ -662,9 +653,9 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	if err != nil {
 		Error(err)
 		if cErr, ok := err.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, chainRuleError(cErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 	// Don't allow the transaction if it exists in the main chain and is not not already fully spent.
 	prevOut := wire.OutPoint{Hash: *txHash}
@@ -672,7 +663,7 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 		prevOut.Index = uint32(txOutIdx)
 		entry := utxoView.LookupEntry(prevOut)
 		if entry != nil && !entry.IsSpent() {
-			return nil, nil, txRuleError(wire.RejectDuplicate,
+			return nil, txRuleError(wire.RejectDuplicate,
 				"transaction already exists")
 		}
 		utxoView.RemoveEntry(prevOut)
@@ -690,7 +681,7 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 		}
 	}
 	if len(missingParents) > 0 {
-		return missingParents, nil, nil
+		return &mempoolAcceptance{missingParents: missingParents}, nil
 	}
 	// Don't allow the transaction into the mempool unless its sequence lock is active, meaning that it'll be allowed
 	// into the next block with respect to its defined relative lock times.
@@ -698,13 +689,13 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	if err != nil {
 		Error(err)
 		if cErr, ok := err.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, chainRuleError(cErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 	if !blockchain.SequenceLockActive(sequenceLock, nextBlockHeight,
 		medianTimePast) {
-		return nil, nil, txRuleError(wire.RejectNonstandard,
+		return nil, txRuleError(wire.RejectNonstandard,
 			"transaction's sequence locks on inputs not met")
 	}
 	// Perform several checks on the transaction inputs using the invariant rules in blockchain for what transactions
@@ -714,9 +705,9 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	if err != nil {
 		Error(err)
 		if cErr, ok := err.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, chainRuleError(cErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 	// Don't allow transactions with non-standard inputs if the network parameters forbid their acceptance.
 	if !mp.cfg.Policy.AcceptNonStd {
@@ -731,7 +722,7 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 			}
 			str := fmt.Sprintf("transaction %v has a non-standard "+
 				"input: %v", txHash, err)
-			return nil, nil, txRuleError(rejectCode, str)
+			return nil, txRuleError(rejectCode, str)
 		}
 	}
 	// NOTE: if you modify this code to accept non-standard transactions, you should add code here to check that the
@@ -744,14 +735,14 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	if err != nil {
 		Error(err)
 		if cErr, ok := err.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, chainRuleError(cErr)
 		}
-		return nil, nil, err
+		return nil, err
 	}
 	if sigOpCost > mp.cfg.Policy.MaxSigOpCostPerTx {
 		str := fmt.Sprintf("transaction %v sigop cost is too high: %d > %d",
 			txHash, sigOpCost, mp.cfg.Policy.MaxSigOpCostPerTx)
-		return nil, nil, txRuleError(wire.RejectNonstandard, str)
+		return nil, txRuleError(wire.RejectNonstandard, str)
 	}
 	// Don't allow transactions with fees too low to get into a mined block. Most miners allow a free transaction area
 	// in blocks they mine to go alongside the area used for high-priority transactions as well as transactions with
@@ -765,7 +756,7 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	if serializedSize >= (DefaultBlockPrioritySize-1000) && txFee < minFee {
 		str := fmt.Sprintf("transaction %v has %d fees which is under the required amount of %d",
 			txHash, txFee, minFee)
-		return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+		return nil, txRuleError(wire.RejectInsufficientFee, str)
 	}
 	// Require that free transactions have sufficient priority to be mined in the next block. Transactions which are
 	// being added back to the memory pool from blocks that have been disconnected during a reorg are exempted.
@@ -776,7 +767,7 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 			str := fmt.Sprintf("transaction %v has insufficient "+
 				"priority (%v <= %v)", txHash,
 				currentPriority, mining.MinHighPriority)
-			return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+			return nil, txRuleError(wire.RejectInsufficientFee, str)
 		}
 	}
 	// Free-to-relay transactions are rate limited here to prevent penny -flooding with tiny transactions as a form of
@@ -791,7 +782,7 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 		if mp.pennyTotal >= mp.cfg.Policy.FreeTxRelayLimit*10*1000 {
 			str := fmt.Sprintf("transaction %v has been rejected "+
 				"by the rate limiter due to low fees", txHash)
-			return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+			return nil, txRuleError(wire.RejectInsufficientFee, str)
 		}
 		oldTotal := mp.pennyTotal
 		mp.pennyTotal += float64(serializedSize)
@@ -802,6 +793,14 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 			mp.cfg.Policy.FreeTxRelayLimit*10*1000,
 		)
 	}
+	// A transaction spending outputs already spent in the pool replaces the transactions spending them, along with
+	// their descendants, if it follows the BIP125 replacement rules.
+	var replaces map[chainhash.Hash]*util.Tx
+	if isReplacement {
+		if replaces, err = mp.validateReplacement(tx, txFee); err != nil {
+			return nil, err
+		}
+	}
 	// Verify crypto signatures for each input and reject the transaction if any don't verify.
 	err = blockchain.ValidateTransactionScripts(b, tx, utxoView,
 		txscript.StandardVerifyFlags, mp.cfg.SigCache,
@@ -809,15 +808,150 @@ func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx,
 	if err != nil {
 		Error(err)
 		if cErr, ok := err.(blockchain.RuleError); ok {
-			return nil, nil, chainRuleError(cErr)
+			return nil, chainRuleError(cErr)
+		}
+		return nil, err
+	}
+	return &mempoolAcceptance{
+		utxoView:   utxoView,
+		bestHeight: bestHeight,
+		fee:        txFee,
+		size:       serializedSize,
+		replaces:   replaces,
+	}, nil
+}
+
+// evictTransaction removes the passed transaction along with all transactions that redeem its outputs from the pool,
+// and reports each removed transaction to the TxEvicted callback with the replacement that caused it, which may be nil.
+// This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) evictTransaction(tx *util.Tx, replacedBy *util.Tx) {
+	evicted := mp.txDescendants(tx, nil)
+	if _, exists := mp.pool[*tx.Hash()]; exists {
+		evicted[*tx.Hash()] = tx
+	}
+	mp.removeTransaction(tx, true)
+	if mp.cfg.TxEvicted == nil {
+		return
+	}
+	for _, etx := range evicted {
+		mp.cfg.TxEvicted(etx, replacedBy)
+	}
+}
+
+// fetchInputUtxos loads utxo details about the input transactions referenced by the passed transaction. First it loads
+// the details form the viewpoint of the main chain, then it adjusts them based upon the contents of the transaction
+// pool. This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) fetchInputUtxos(tx *util.Tx) (*blockchain.UtxoViewpoint, error) {
+	utxoView, err := mp.cfg.FetchUtxoView(tx)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Attempt to populate any missing inputs from the transaction pool.
+	for _, txIn := range tx.MsgTx().TxIn {
+		prevOut := &txIn.PreviousOutPoint
+		entry := utxoView.LookupEntry(*prevOut)
+		if entry != nil && !entry.IsSpent() {
+			continue
+		}
+		if poolTxDesc, exists := mp.pool[prevOut.Hash]; exists {
+			// AddTxOut ignores out of range index values,
+			// so it is safe to call without bounds checking here.
+			utxoView.AddTxOut(poolTxDesc.Tx, prevOut.Index,
+				mining.UnminedHeight)
 		}
+	}
+	return utxoView, nil
+}
+
+// haveTransaction returns whether or not the passed transaction already exists in the main pool or in the orphan pool.
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) haveTransaction(
+	hash *chainhash.Hash) bool {
+	return mp.isTransactionInPool(hash) || mp.isOrphanInPool(hash)
+}
+
+// isOrphanInPool returns whether or not the passed transaction already exists in the orphan pool. This function MUST be
+// called with the mempool lock held (for reads).
+func (mp *TxPool) isOrphanInPool(hash *chainhash.Hash) bool {
+	if _, exists := mp.orphans[*hash]; exists {
+		return true
+	}
+	return false
+}
+
+// isTransactionInPool returns whether or not the passed transaction already exists in the main pool. This function MUST
+// be called with the mempool lock held (for reads).
+func (mp *TxPool) isTransactionInPool(hash *chainhash.Hash) bool {
+	if _, exists := mp.pool[*hash]; exists {
+		return true
+	}
+	return false
+}
+
+// limitNumOrphans limits the number of orphan transactions by evicting a random orphan if adding a new one would cause
+// it to overflow the max allowed. This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) limitNumOrphans() error {
+	// Scan through the orphan pool and remove any expired orphans when it's time. This is done for efficiency so the
+	// scan only happens periodically instead of on every orphan added to the pool.
+	if now := time.Now(); now.After(mp.nextExpireScan) {
+		origNumOrphans := len(mp.orphans)
+		for _, otx := range mp.orphans {
+			if now.After(otx.expiration) {
+				// Remove redeemers too because the missing parents are very unlikely to ever materialize since the
+				// orphan has already been around more than long enough for them to be delivered.
+				mp.removeOrphan(otx.tx, true)
+			}
+		}
+		// Set next expiration scan to occur after the scan interval.
+		mp.nextExpireScan = now.Add(orphanExpireScanInterval)
+		numOrphans := len(mp.orphans)
+		if numExpired := origNumOrphans - numOrphans; numExpired > 0 {
+			Debugf("Expired %d %s (remaining: %d)",
+				numExpired, logi.PickNoun(numExpired, "orphan", "orphans"),
+				numOrphans,
+			)
+		}
+	}
+	// Nothing to do if adding another orphan will not cause the pool to exceed the limit.
+	if len(mp.orphans)+1 <= mp.cfg.Policy.MaxOrphanTxs {
+		return nil
+	}
+	// Remove a random entry from the map. For most compilers, Go's range statement iterates starting at a random item
+	// although that is not 100% guaranteed by the spec. The iteration order is not important here because an adversary
+	// would have to be able to pull off preimage attacks on the hashing function in order to target eviction of
+	// specific entries anyways.
+	for _, otx := range mp.orphans {
+		// Don't remove redeemers in the case of a random eviction since it is quite possible it might be needed again
+		// shortly.
+		mp.removeOrphan(otx.tx, false)
+		break
+	}
+	return nil
+}
+
+// maybeAcceptTransaction is the internal function which implements the public MaybeAcceptTransaction. See the comment
+// for MaybeAcceptTransaction for more details. This function MUST be called with the mempool lock held (for writes).
+func (mp *TxPool) maybeAcceptTransaction(b *blockchain.BlockChain, tx *util.Tx, isNew, rateLimit, rejectDupOrphans bool,
+) ([]*chainhash.Hash, *TxDesc, error) {
+	acceptance, err := mp.checkMempoolAcceptance(b, tx, isNew, rateLimit, rejectDupOrphans)
+	if err != nil {
 		return nil, nil, err
 	}
+	if len(acceptance.missingParents) > 0 {
+		return acceptance.missingParents, nil, nil
+	}
+	// Remove the transactions being replaced, along with their descendants, so the outputs they spend are free for the
+	// replacement.
+	for _, replaced := range acceptance.replaces {
+		Debug("replacing transaction", replaced.Hash(), "with", tx.Hash())
+		mp.evictTransaction(replaced, tx)
+	}
 	// Add to transaction pool.
-	txD := mp.addTransaction(utxoView, tx, bestHeight, txFee)
+	txD := mp.addTransaction(acceptance.utxoView, tx, acceptance.bestHeight, acceptance.fee)
 	Debugf(
-		"accepted transaction %v (pool size: %v) %s",
-		txHash,
+		"accepted transaction %v (pool size: %v)",
+		tx.Hash(),
 		len(mp.pool),
 	)
 	return nil, txD, nil
@@ -978,6 +1112,162 @@ func (mp *TxPool) removeTransaction(tx *util.Tx, removeRedeemers bool) {
 	}
 }
 
+// signalsReplacement returns whether the passed transaction signals that it can be replaced, as described in BIP125.
+// A transaction signals replaceability when one of its inputs has a sequence number of at most MaxRBFSequence, or when
+// one of its unconfirmed ancestors does. The cache holds the ancestors already found not to signal and may be nil. This
+// function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) signalsReplacement(tx *util.Tx, cache map[chainhash.Hash]struct{}) bool {
+	if cache == nil {
+		cache = make(map[chainhash.Hash]struct{})
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		if txIn.Sequence <= MaxRBFSequence {
+			return true
+		}
+		hash := txIn.PreviousOutPoint.Hash
+		parent, ok := mp.pool[hash]
+		if !ok {
+			continue
+		}
+		if _, ok := cache[hash]; ok {
+			continue
+		}
+		if mp.signalsReplacement(parent.Tx, cache) {
+			return true
+		}
+		cache[hash] = struct{}{}
+	}
+	return false
+}
+
+// txAncestors adds the unconfirmed ancestors of the passed transaction to the passed map, which may be nil, and returns
+// it. These are the transactions in the pool it spends outputs of, and their ancestors in turn. This function MUST be
+// called with the mempool lock held (for reads).
+func (mp *TxPool) txAncestors(tx *util.Tx, ancestors map[chainhash.Hash]*util.Tx) map[chainhash.Hash]*util.Tx {
+	if ancestors == nil {
+		ancestors = make(map[chainhash.Hash]*util.Tx)
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		parent, ok := mp.pool[txIn.PreviousOutPoint.Hash]
+		if !ok {
+			continue
+		}
+		if _, ok := ancestors[*parent.Tx.Hash()]; ok {
+			continue
+		}
+		ancestors[*parent.Tx.Hash()] = parent.Tx
+		mp.txAncestors(parent.Tx, ancestors)
+	}
+	return ancestors
+}
+
+// txConflicts returns the transactions in the pool that spend any of the outputs spent by the passed transaction. This
+// function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) txConflicts(tx *util.Tx) map[chainhash.Hash]*util.Tx {
+	conflicts := make(map[chainhash.Hash]*util.Tx)
+	for _, txIn := range tx.MsgTx().TxIn {
+		if conflict, ok := mp.outpoints[txIn.PreviousOutPoint]; ok {
+			conflicts[*conflict.Hash()] = conflict
+		}
+	}
+	return conflicts
+}
+
+// txDescendants adds the descendants of the passed transaction to the passed map, which may be nil, and returns it.
+// These are the transactions in the pool spending its outputs, and their descendants in turn. This function MUST be
+// called with the mempool lock held (for reads).
+func (mp *TxPool) txDescendants(tx *util.Tx, descendants map[chainhash.Hash]*util.Tx) map[chainhash.Hash]*util.Tx {
+	if descendants == nil {
+		descendants = make(map[chainhash.Hash]*util.Tx)
+	}
+	prevOut := wire.OutPoint{Hash: *tx.Hash()}
+	for txOutIdx := range tx.MsgTx().TxOut {
+		prevOut.Index = uint32(txOutIdx)
+		child, ok := mp.outpoints[prevOut]
+		if !ok {
+			continue
+		}
+		if _, ok := descendants[*child.Hash()]; ok {
+			continue
+		}
+		descendants[*child.Hash()] = child
+		mp.txDescendants(child, descendants)
+	}
+	return descendants
+}
+
+// validateReplacement checks that the passed transaction, which spends outputs already spent by transactions in the
+// pool that signal replaceability, follows the replacement rules of BIP125. It returns the transactions it replaces,
+// which are the conflicting transactions along with their descendants. A replacement must:
+//
+// - not spend outputs of any of the transactions it replaces
+//
+// - not replace more than MaxReplacementEvictions transactions
+//
+// - pay a higher fee rate than each of the conflicting transactions
+//
+// - not spend unconfirmed outputs that none of the conflicting transactions spend
+//
+// - pay at least the fees of all of the transactions it replaces, plus the minimum relay fee for its own size, so its
+// relay is paid for
+//
+// This function MUST be called with the mempool lock held (for reads).
+func (mp *TxPool) validateReplacement(tx *util.Tx, txFee int64) (map[chainhash.Hash]*util.Tx, error) {
+	txHash := tx.Hash()
+	conflicts := mp.txConflicts(tx)
+	replaces := make(map[chainhash.Hash]*util.Tx, len(conflicts))
+	conflictParents := make(map[chainhash.Hash]struct{})
+	for hash, conflict := range conflicts {
+		replaces[hash] = conflict
+		mp.txDescendants(conflict, replaces)
+		for _, txIn := range conflict.MsgTx().TxIn {
+			conflictParents[txIn.PreviousOutPoint.Hash] = struct{}{}
+		}
+	}
+	for hash := range mp.txAncestors(tx, nil) {
+		if _, ok := replaces[hash]; ok {
+			str := fmt.Sprintf("replacement transaction %v spends outputs of transaction %v, which it replaces",
+				txHash, hash)
+			return nil, txRuleError(wire.RejectInvalid, str)
+		}
+	}
+	if len(replaces) > MaxReplacementEvictions {
+		str := fmt.Sprintf("replacement transaction %v replaces %d transactions, more than the maximum of %d",
+			txHash, len(replaces), MaxReplacementEvictions)
+		return nil, txRuleError(wire.RejectNonstandard, str)
+	}
+	txSize := GetTxVirtualSize(tx)
+	feePerKB := txFee * 1000 / txSize
+	for hash := range conflicts {
+		if conflict := mp.pool[hash]; feePerKB <= conflict.FeePerKB {
+			str := fmt.Sprintf("replacement transaction %v has a fee rate of %d, which is not higher than the fee "+
+				"rate of %d of the replaced transaction %v", txHash, feePerKB, conflict.FeePerKB, hash)
+			return nil, txRuleError(wire.RejectInsufficientFee, str)
+		}
+	}
+	for _, txIn := range tx.MsgTx().TxIn {
+		if _, ok := conflictParents[txIn.PreviousOutPoint.Hash]; ok {
+			continue
+		}
+		if _, ok := mp.pool[txIn.PreviousOutPoint.Hash]; ok {
+			str := fmt.Sprintf("replacement transaction %v spends new unconfirmed output %v", txHash,
+				txIn.PreviousOutPoint)
+			return nil, txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	var replacedFee int64
+	for hash := range replaces {
+		replacedFee += mp.pool[hash].Fee
+	}
+	minFee := replacedFee + calcMinRequiredTxRelayFee(txSize, mp.cfg.Policy.MinRelayTxFee)
+	if txFee < minFee {
+		str := fmt.Sprintf("replacement transaction %v has %d fees which is under the required amount of %d to "+
+			"replace %d transactions", txHash, txFee, minFee, len(replaces))
+		return nil, txRuleError(wire.RejectInsufficientFee, str)
+	}
+	return replaces, nil
+}
+
 // New returns a new memory pool for validating and storing standalone transactions until they are mined into a block.
 func New(cfg *Config) *TxPool {
 	return &TxPool{
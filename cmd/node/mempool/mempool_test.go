@@ -735,3 +735,135 @@ func TestCheckSpend(t *testing.T) {
 		t.Fatalf("Unexpeced spend found in pool: %v", spend)
 	}
 }
+
+// createReplaceableTx creates an unsigned transaction spending the provided inputs with the given sequence number and
+// paying the passed fee, with the rest split evenly between the requested number of outputs.
+func (p *poolHarness) createReplaceableTx(inputs []spendableOutput, numOutputs uint32, fee util.Amount,
+	sequence uint32) *util.Tx {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	totalInput := -fee
+	for _, input := range inputs {
+		tx.AddTxIn(&wire.TxIn{PreviousOutPoint: input.outPoint, Sequence: sequence})
+		totalInput += input.amount
+	}
+	for i := uint32(0); i < numOutputs; i++ {
+		tx.AddTxOut(&wire.TxOut{PkScript: p.payScript, Value: int64(totalInput) / int64(numOutputs)})
+	}
+	return util.NewTx(tx)
+}
+
+// TestReplacement ensures that transactions in the pool are only replaced when they signal replaceability and the
+// replacement follows the rules of BIP125.
+func TestReplacement(t *testing.T) {
+	t.Parallel()
+	harness, _, err := newPoolHarness(&netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	mp := harness.txPool
+	coinA := spendableOutput{outPoint: wire.OutPoint{Hash: chainhash.Hash{1}}, amount: 1e8}
+	coinB := spendableOutput{outPoint: wire.OutPoint{Hash: chainhash.Hash{2}}, amount: 1e8}
+	// The pool holds a transaction signalling replaceability along with a child of it, and a transaction that does not
+	// signal.
+	addTx := func(tx *util.Tx, fee int64) {
+		mp.addTransaction(blockchain.NewUtxoViewpoint(), tx, harness.chain.BestHeight(), fee)
+	}
+	original := harness.createReplaceableTx([]spendableOutput{coinA}, 2, 1000, MaxRBFSequence)
+	addTx(original, 1000)
+	child := harness.createReplaceableTx([]spendableOutput{txOutToSpendableOut(original, 0)}, 1, 1000,
+		wire.MaxTxInSequenceNum)
+	addTx(child, 1000)
+	final := harness.createReplaceableTx([]spendableOutput{coinB}, 1, 1000, wire.MaxTxInSequenceNum)
+	addTx(final, 1000)
+	if !mp.signalsReplacement(original, nil) || !mp.signalsReplacement(child, nil) ||
+		mp.signalsReplacement(final, nil) {
+		t.Fatal("signalsReplacement: wrong signalling of the pool transactions")
+	}
+	// Only the signalling transactions can be replaced.
+	if _, err := mp.checkPoolDoubleSpend(harness.createReplaceableTx([]spendableOutput{coinB}, 1, 5000,
+		wire.MaxTxInSequenceNum)); err == nil {
+		t.Fatal("checkPoolDoubleSpend: replaced a transaction not signalling replaceability")
+	}
+	isReplacement, err := mp.checkPoolDoubleSpend(harness.createReplaceableTx([]spendableOutput{coinA}, 1, 5000,
+		wire.MaxTxInSequenceNum))
+	if err != nil || !isReplacement {
+		t.Fatalf("checkPoolDoubleSpend: got %v, %v, want a replacement", isReplacement, err)
+	}
+	tests := []struct {
+		name   string
+		inputs []spendableOutput
+		fee    util.Amount
+		valid  bool
+	}{
+		{name: "less than the replaced fees", inputs: []spendableOutput{coinA}, fee: 1999},
+		{name: "not paying for its relay", inputs: []spendableOutput{coinA}, fee: 2000},
+		{name: "spending a replaced output", inputs: []spendableOutput{coinA, txOutToSpendableOut(original, 1)},
+			fee: 5000},
+		{name: "spending a new unconfirmed output", inputs: []spendableOutput{coinA, txOutToSpendableOut(final, 0)},
+			fee: 5000},
+		{name: "valid", inputs: []spendableOutput{coinA}, fee: 5000, valid: true},
+	}
+	for _, test := range tests {
+		tx := harness.createReplaceableTx(test.inputs, 1, test.fee, wire.MaxTxInSequenceNum)
+		replaces, err := mp.validateReplacement(tx, int64(test.fee))
+		if !test.valid {
+			if err == nil {
+				t.Fatalf("validateReplacement (%s): accepted an invalid replacement", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("validateReplacement (%s): %v", test.name, err)
+		}
+		if len(replaces) != 2 || replaces[*original.Hash()] == nil || replaces[*child.Hash()] == nil {
+			t.Fatalf("validateReplacement (%s): replaces %v, want the original and its child", test.name, replaces)
+		}
+	}
+	// Replacements are rejected when the policy does not allow them.
+	mp.cfg.Policy.RejectReplacement = true
+	if _, err := mp.checkPoolDoubleSpend(harness.createReplaceableTx([]spendableOutput{coinA}, 1, 5000,
+		wire.MaxTxInSequenceNum)); err == nil {
+		t.Fatal("checkPoolDoubleSpend: replaced a transaction when the policy rejects replacements")
+	}
+}
+
+// TestTxEvicted ensures the TxEvicted callback is given the transactions removed from the pool without being mined, with
+// the replacement that removed them.
+func TestTxEvicted(t *testing.T) {
+	t.Parallel()
+	harness, _, err := newPoolHarness(&netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	mp := harness.txPool
+	evicted := make(map[chainhash.Hash]*util.Tx)
+	mp.cfg.TxEvicted = func(tx *util.Tx, replacedBy *util.Tx) {
+		evicted[*tx.Hash()] = replacedBy
+	}
+	addTx := func(tx *util.Tx) {
+		mp.addTransaction(blockchain.NewUtxoViewpoint(), tx, harness.chain.BestHeight(), 1000)
+	}
+	coinA := spendableOutput{outPoint: wire.OutPoint{Hash: chainhash.Hash{1}}, amount: 1e8}
+	coinB := spendableOutput{outPoint: wire.OutPoint{Hash: chainhash.Hash{2}}, amount: 1e8}
+	original := harness.createReplaceableTx([]spendableOutput{coinA}, 1, 1000, MaxRBFSequence)
+	addTx(original)
+	child := harness.createReplaceableTx([]spendableOutput{txOutToSpendableOut(original, 0)}, 1, 1000,
+		wire.MaxTxInSequenceNum)
+	addTx(child)
+	mined := harness.createReplaceableTx([]spendableOutput{coinB}, 1, 1000, wire.MaxTxInSequenceNum)
+	addTx(mined)
+	// Transactions removed because they were mined are not evictions.
+	mp.RemoveTransaction(mined, false)
+	if len(evicted) != 0 {
+		t.Fatalf("TxEvicted: got %d evictions for a mined transaction", len(evicted))
+	}
+	// A replaced transaction and its descendants are reported with the replacement.
+	replacement := harness.createReplaceableTx([]spendableOutput{coinA}, 1, 5000, wire.MaxTxInSequenceNum)
+	mp.evictTransaction(original, replacement)
+	if len(evicted) != 2 || evicted[*original.Hash()] != replacement || evicted[*child.Hash()] != replacement {
+		t.Fatalf("TxEvicted: got %v, want the original and its child replaced by %v", evicted, replacement.Hash())
+	}
+	if mp.Count() != 0 {
+		t.Fatalf("evictTransaction: %d transactions left in the pool", mp.Count())
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	"github.com/p9c/pod/pkg/chain/mining/payout"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	"github.com/p9c/pod/pkg/comm/stdconn/worker"
 	"github.com/p9c/pod/pkg/util"
 )
@@ -18,10 +20,27 @@ type Config struct {
 	ActiveMiningAddrs   []util.Address
 	ActiveMinerKey      []byte
 	ActiveMinRelayTxFee util.Amount
-	ActiveWhitelists    []*net.IPNet
+	ActiveWhitelists    []connmgr.Whitelist
+	ActiveRPCAllowIPs   []*net.IPNet
 	DropAddrIndex       bool
 	DropTxIndex         bool
+	DropWTxIndex        bool
+	DropSpentIndex      bool
 	DropCfIndex         bool
 	Save                bool
 	Miner               *worker.Worker
+	// LocalMiner mines in the node itself when InProcessMiner is set, and is nil otherwise
+	LocalMiner LocalMiner
+	// Payout picks which of the ActiveMiningAddrs, or other addresses, block templates pay to
+	Payout *payout.Payout
+}
+
+// LocalMiner mines blocks with threads inside the node instead of in a kopach process
+type LocalMiner interface {
+	// Start mines with the number of threads, all of the cores for -1, replacing the threads already mining
+	Start(threads int)
+	// Stop stops the threads mining
+	Stop()
+	// Mining returns whether threads are mining
+	Mining() bool
 }
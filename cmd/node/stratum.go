@@ -0,0 +1,46 @@
+package node
+
+import (
+	"net"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/cmd/kopach/control"
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/rpc/stratum"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/interrupt"
+)
+
+// runStratum starts a stratum server for each of the configured algo=address listeners, which are stopped on
+// shutdown. Listeners that can't be started are logged and skipped so they don't take the node down with them.
+func runStratum(cx *conte.Xt) {
+	if len(cx.StateCfg.ActiveMiningAddrs) < 1 {
+		Warn("not running stratum servers without mining addresses")
+		return
+	}
+	for _, l := range *cx.Config.StratumListeners {
+		algo, address, err := stratum.ParseListener(l)
+		if err != nil {
+			Error(err)
+			continue
+		}
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			Error("failed to start stratum server:", err)
+			continue
+		}
+		srv := stratum.New(&stratum.Config{
+			Algo:       algo,
+			Generator:  control.GetBlkTemplateGenerator(cx),
+			PayToAddrs: cx.StateCfg.ActiveMiningAddrs,
+			Payout:     cx.StateCfg.Payout,
+			ProcessBlock: func(block *util.Block) (bool, error) {
+				return cx.RealNode.SyncManager.ProcessBlock(block, blockchain.BFNone)
+			},
+			IsCurrent:  cx.IsCurrent,
+			Difficulty: *cx.Config.StratumDifficulty,
+		}, listener)
+		srv.Start()
+		interrupt.AddHandler(srv.Stop)
+	}
+}
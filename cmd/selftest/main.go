@@ -0,0 +1,267 @@
+package selftest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/p9c/pod/app/conte"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	rpcclient "github.com/p9c/pod/pkg/rpc/client"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/prompt"
+)
+
+const (
+	// faucetTimeout is how long the faucet is given to accept a request for coins.
+	faucetTimeout = 30 * time.Second
+	// pollInterval is how often the wallet is asked whether the awaited coins have arrived.
+	pollInterval = 5 * time.Second
+	// walletStartTimeout is how long a wallet started by the self test is given to answer RPC requests.
+	walletStartTimeout = 2 * time.Minute
+)
+
+// Config is the configuration of a self test.
+type Config struct {
+	// Faucet is a url the address of the wallet is posted to for coins, if it is not empty. Otherwise the coins the
+	// wallet already has are used.
+	Faucet string
+	// Amount is the amount sent by the wallet to itself
+	Amount util.Amount
+	// Confirmations is how many confirmations the round trip transaction needs to pass
+	Confirmations int64
+	// Timeout is how long the coins from the faucet and the confirmations are each waited for
+	Timeout time.Duration
+	// Passphrase is the private passphrase of the wallet, which is prompted for if it is empty
+	Passphrase string
+	// StartWallet starts the wallet when its RPC server doesn't answer
+	StartWallet func() error
+}
+
+// Main runs the self test on a test network: it requests coins for the wallet from the faucet, sends them from the
+// wallet to itself, waits for the transaction to confirm and prints whether each step passed. An error is returned if
+// any of them failed.
+func Main(cx *conte.Xt, cfg *Config) (err error) {
+	if cx.ActiveNet.Net == wire.MainNet {
+		return errors.New("the self test only runs on a test network")
+	}
+	if cfg.Faucet != "" {
+		var u *url.URL
+		if u, err = url.Parse(cfg.Faucet); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("faucet '%s' is not an http or https url", cfg.Faucet)
+		}
+	}
+	var certs []byte
+	if *cx.Config.TLS && *cx.Config.RPCCert != "" {
+		if certs, err = ioutil.ReadFile(*cx.Config.RPCCert); err != nil {
+			return err
+		}
+	}
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         (*cx.Config.WalletRPCListeners)[0],
+		User:         *cx.Config.Username,
+		Pass:         *cx.Config.Password,
+		TLS:          *cx.Config.TLS,
+		Certificates: certs,
+		HTTPPostMode: true,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	defer client.Shutdown()
+	t := &test{out: os.Stdout, client: client, cfg: cfg}
+	return t.run()
+}
+
+// test is a run of the self test against the RPC server of a wallet.
+type test struct {
+	out    io.Writer
+	client *rpcclient.Client
+	cfg    *Config
+	failed bool
+}
+
+// step runs a step of the test, unless an earlier one failed, and prints whether it passed.
+func (t *test) step(name string, fn func() (string, error)) {
+	if t.failed {
+		return
+	}
+	result, err := fn()
+	if err != nil {
+		t.failed = true
+		_, _ = fmt.Fprintf(t.out, "FAIL %s: %v\n", name, err)
+		return
+	}
+	_, _ = fmt.Fprintf(t.out, "PASS %s %s\n", name, result)
+}
+
+// run runs the steps of the test in order, stopping at the first one that fails.
+func (t *test) run() error {
+	var (
+		from, to util.Address
+		txHash   *chainhash.Hash
+	)
+	t.step("wallet", t.connect)
+	t.step("unlock", t.unlock)
+	t.step("address", func() (result string, err error) {
+		if from, err = t.client.GetNewAddress("default"); err != nil {
+			return "", err
+		}
+		if to, err = t.client.GetNewAddress("default"); err != nil {
+			return "", err
+		}
+		return from.EncodeAddress(), nil
+	})
+	t.step("funds", func() (string, error) {
+		return t.fund(from)
+	})
+	t.step("send", func() (result string, err error) {
+		if txHash, err = t.client.SendToAddress(to, t.cfg.Amount); err != nil {
+			return "", err
+		}
+		return txHash.String(), nil
+	})
+	t.step("confirm", func() (string, error) {
+		return t.confirm(txHash, to)
+	})
+	if err := t.client.WalletLock(); err != nil {
+		Error(err)
+	}
+	if t.failed {
+		_, _ = fmt.Fprintln(t.out, "selftest failed")
+		return errors.New("selftest failed")
+	}
+	_, _ = fmt.Fprintln(t.out, "selftest passed")
+	return nil
+}
+
+// connect checks that the wallet answers RPC requests, starting it if it doesn't.
+func (t *test) connect() (string, error) {
+	if _, err := t.client.GetBalance("*"); err == nil {
+		return "running", nil
+	} else if t.cfg.StartWallet == nil {
+		return "", err
+	}
+	if err := t.cfg.StartWallet(); err != nil {
+		return "", err
+	}
+	var err error
+	deadline := time.Now().Add(walletStartTimeout)
+	for time.Now().Before(deadline) {
+		if _, err = t.client.GetBalance("*"); err == nil {
+			return "started", nil
+		}
+		time.Sleep(time.Second)
+	}
+	return "", err
+}
+
+// unlock unlocks the wallet for long enough to run the rest of the test.
+func (t *test) unlock() (string, error) {
+	pass := t.cfg.Passphrase
+	if pass == "" {
+		p, err := prompt.ProvidePrivPassphrase()
+		if err != nil {
+			return "", err
+		}
+		pass = string(p)
+	}
+	timeout := int64(2*t.cfg.Timeout/time.Second) + 60
+	return "", t.client.WalletPassphrase(pass, timeout)
+}
+
+// fund requests coins for the address from the faucet and waits for them to arrive, or without a faucet checks that
+// the wallet already has enough to send.
+func (t *test) fund(addr util.Address) (string, error) {
+	if t.cfg.Faucet == "" {
+		balance, err := t.client.GetBalance("*")
+		if err != nil {
+			return "", err
+		}
+		if balance <= t.cfg.Amount {
+			return "", fmt.Errorf("the wallet has %v, which is not enough to send %v, use a faucet", balance,
+				t.cfg.Amount)
+		}
+		return balance.String(), nil
+	}
+	if err := requestCoins(t.cfg.Faucet, addr, t.cfg.Amount); err != nil {
+		return "", err
+	}
+	var received util.Amount
+	err := poll(t.cfg.Timeout, func() (done bool, err error) {
+		received, err = t.client.GetReceivedByAddressMinConf(addr, 0)
+		return received > 0, err
+	})
+	if err != nil {
+		return "", fmt.Errorf("no coins from the faucet: %v", err)
+	}
+	return received.String(), nil
+}
+
+// confirm waits for the transaction to get the configured number of confirmations and checks that the coins arrived.
+func (t *test) confirm(txHash *chainhash.Hash, to util.Address) (string, error) {
+	var confirmations int64
+	err := poll(t.cfg.Timeout, func() (bool, error) {
+		tx, err := t.client.GetTransaction(txHash)
+		if err != nil {
+			return false, err
+		}
+		confirmations = tx.Confirmations
+		return confirmations >= t.cfg.Confirmations, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("%d of %d confirmations: %v", confirmations, t.cfg.Confirmations, err)
+	}
+	received, err := t.client.GetReceivedByAddressMinConf(to, int(t.cfg.Confirmations))
+	if err != nil {
+		return "", err
+	}
+	if received != t.cfg.Amount {
+		return "", fmt.Errorf("received %v instead of %v", received, t.cfg.Amount)
+	}
+	return fmt.Sprintf("%d confirmations", confirmations), nil
+}
+
+// requestCoins posts the address and amount to the faucet as a form, which must be answered with a success status.
+func requestCoins(faucet string, addr util.Address, amount util.Amount) error {
+	client := &http.Client{Timeout: faucetTimeout}
+	res, err := client.PostForm(faucet, url.Values{
+		"address": {addr.EncodeAddress()},
+		"amount":  {fmt.Sprint(amount.ToDUO())},
+	})
+	if err != nil {
+		return err
+	}
+	if err = res.Body.Close(); err != nil {
+		Error(err)
+	}
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("faucet returned status %s", res.Status)
+	}
+	return nil
+}
+
+// poll calls fn every pollInterval until it is done or the timeout passes. Errors are retried, and the last one is
+// returned when the timeout passes.
+func poll(timeout time.Duration, fn func() (bool, error)) (err error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		var done bool
+		if done, err = fn(); done && err == nil {
+			return nil
+		}
+		if time.Now().Add(pollInterval).After(deadline) {
+			if err == nil {
+				err = errors.New("timed out")
+			}
+			return err
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,62 @@
+package selftest
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestRequestCoins ensures the address and amount are posted to the faucet and a failure status is reported.
+func TestRequestCoins(t *testing.T) {
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), &netparams.TestNet3Params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := http.StatusOK
+	var gotAddr, gotAmount string
+	faucet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAddr, gotAmount = r.PostFormValue("address"), r.PostFormValue("amount")
+		w.WriteHeader(status)
+	}))
+	defer faucet.Close()
+	if err = requestCoins(faucet.URL, addr, util.Amount(150000000)); err != nil {
+		t.Fatal(err)
+	}
+	if gotAddr != addr.EncodeAddress() || gotAmount != "1.5" {
+		t.Errorf("faucet got address %s and amount %s, want %s and 1.5", gotAddr, gotAmount, addr.EncodeAddress())
+	}
+	status = http.StatusServiceUnavailable
+	if err = requestCoins(faucet.URL, addr, util.Amount(150000000)); err == nil {
+		t.Error("no error when the faucet returned a failure status")
+	}
+}
+
+// TestStep ensures each step is reported and the steps after a failed one are skipped.
+func TestStep(t *testing.T) {
+	var out bytes.Buffer
+	tst := &test{out: &out}
+	ran := 0
+	tst.step("first", func() (string, error) {
+		ran++
+		return "ok", nil
+	})
+	tst.step("second", func() (string, error) {
+		ran++
+		return "", errors.New("broken")
+	})
+	tst.step("third", func() (string, error) {
+		ran++
+		return "ok", nil
+	})
+	if ran != 2 || !tst.failed {
+		t.Errorf("ran %d steps and failed is %v, want 2 and true", ran, tst.failed)
+	}
+	if want := "PASS first ok\nFAIL second: broken\n"; out.String() != want {
+		t.Errorf("got output %q, want %q", out.String(), want)
+	}
+}
@@ -662,6 +662,18 @@ func (s *ChainService) rollBackToHeight(height uint32) (*waddrmgr.BlockStamp, er
 // OnAddr is invoked when a peer receives an addr bitcoin message and is used to notify the server about advertised
 // addresses.
 func (sp *ServerPeer) OnAddr(_ *peer.Peer, msg *wire.MsgAddr) {
+	sp.addAdvertisedAddresses(msg.Command(), msg.AddrList)
+}
+
+// OnAddrV2 is invoked when a peer receives an addrv2 bitcoin message, which can also carry Tor v3 and I2P addresses,
+// and is used to notify the server about advertised addresses.
+func (sp *ServerPeer) OnAddrV2(_ *peer.Peer, msg *wire.MsgAddrV2) {
+	sp.addAdvertisedAddresses(msg.Command(), msg.AddrList)
+}
+
+// addAdvertisedAddresses adds the addresses advertised by the peer in an addr or addrv2 message to the known addresses
+// of the peer and to the address manager.
+func (sp *ServerPeer) addAdvertisedAddresses(command string, addrList []*wire.NetAddress) {
 	// Ignore addresses when running on the simulation test network. This helps prevent the network from becoming
 	// another public test network since it will not be able to learn about other peers that have not specifically been
 	// provided.
@@ -673,15 +685,15 @@ func (sp *ServerPeer) OnAddr(_ *peer.Peer, msg *wire.MsgAddr) {
 		return
 	}
 	// A message that has no addresses is invalid.
-	if len(msg.AddrList) == 0 {
+	if len(addrList) == 0 {
 		Errorf(
 			"command [%s] from %s does not contain any addresses",
-			msg.Command(), sp.Addr(),
+			command, sp.Addr(),
 		)
 		sp.Disconnect()
 		return
 	}
-	for _, na := range msg.AddrList {
+	for _, na := range addrList {
 		// Don't add more address if we're disconnecting.
 		if !sp.Connected() {
 			return
@@ -698,7 +710,7 @@ func (sp *ServerPeer) OnAddr(_ *peer.Peer, msg *wire.MsgAddr) {
 	// Add addresses to server address manager. The address manager handles the details of things such as preventing
 	// duplicate addresses, max addresses, and last seen updates. XXX bitcoind gives a 2 hour time penalty here, do we
 	// want to do the same?
-	sp.server.addrManager.AddAddresses(msg.AddrList, sp.NA())
+	sp.server.addrManager.AddAddresses(addrList, sp.NA())
 }
 
 // OnFeeFilter is invoked when a peer receives a feefilter bitcoin message and is used by remote peers to request that
@@ -1149,6 +1161,7 @@ func newPeerConfig(sp *ServerPeer) *peer.Config {
 			OnReject:    sp.OnReject,
 			OnFeeFilter: sp.OnFeeFilter,
 			OnAddr:      sp.OnAddr,
+			OnAddrV2:    sp.OnAddrV2,
 			OnRead:      sp.OnRead,
 			OnWrite:     sp.OnWrite,
 			// Note: The reference client currently bans peers that send alerts not signed with its key. We could verify
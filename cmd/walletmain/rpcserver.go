@@ -15,6 +15,7 @@ import (
 	"github.com/p9c/pod/cmd/node/state"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/pod"
+	"github.com/p9c/pod/pkg/rpc/audit"
 	"github.com/p9c/pod/pkg/rpc/legacy"
 	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/wallet"
@@ -210,11 +211,18 @@ func startRPCServers(config *pod.Config, stateCfg *state.Config, activeNet *netp
 			err := errors.New("failed to create listeners for legacy RPC server")
 			return nil, err
 		}
+		// The wallet shares the audit log of the node, so that one log covers both servers.
+		auditLog, err := audit.Open(filepath.Join(*config.DataDir, activeNet.Name, audit.FileName))
+		if err != nil {
+			return nil, err
+		}
 		opts := legacy.Options{
 			Username:            *config.Username,
 			Password:            *config.Password,
 			MaxPOSTClients:      int64(*config.WalletRPCMaxClients),
 			MaxWebsocketClients: int64(*config.WalletRPCMaxWebsockets),
+			AuditLog:            auditLog,
+			CanonicalJSON:       *config.RPCCanonicalJSON,
 		}
 		legacyServer = legacy.NewServer(&opts, walletLoader, listeners)
 	}
@@ -0,0 +1,215 @@
+package watch
+
+import (
+	"bytes"
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/pkg/chain/wire"
+	rpcclient "github.com/p9c/pod/pkg/rpc/client"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/interrupt"
+)
+
+const (
+	// webhookTimeout is how long the webhook endpoint is given to accept an event.
+	webhookTimeout = 30 * time.Second
+	// webhookQueueSize is how many events can be waiting to be posted to the webhook before new ones are dropped.
+	webhookQueueSize = 1000
+)
+
+// Config is the configuration of a watch.
+type Config struct {
+	// Targets are the addresses and the outpoints, in txid:index form, to watch
+	Targets []string
+	// Webhook is a url that every event is posted to as JSON as well as being printed, if it is not empty
+	Webhook string
+	// Confirmations is how many confirmations a transaction needs to be reported as confirmed
+	Confirmations int32
+	// Mempool enables reporting transactions when they are accepted into the mempool of the node
+	Mempool bool
+}
+
+// clientConnected is queued when the websocket client connects or reconnects to the node.
+type clientConnected struct{}
+
+// blockConnected is queued for a block connected to the main chain, with the transactions in it that match the filter.
+type blockConnected struct {
+	height int32
+	header *wire.BlockHeader
+	txs    []*util.Tx
+}
+
+// blockDisconnected is queued for a block disconnected from the main chain.
+type blockDisconnected struct {
+	height int32
+}
+
+// relevantTxAccepted is queued for a transaction matching the filter that was accepted into the mempool.
+type relevantTxAccepted []byte
+
+// Main connects to the websocket API of the node and prints an event as a line of JSON to stdout for every transaction
+// that pays one of the watched addresses or spends one of the watched outpoints, until it is interrupted.
+func Main(cx *conte.Xt, cfg *Config) (err error) {
+	addrs, outPoints, err := ParseTargets(cfg.Targets, cx.ActiveNet)
+	if err != nil {
+		return err
+	}
+	var hooks chan []byte
+	if cfg.Webhook != "" {
+		var u *url.URL
+		if u, err = url.Parse(cfg.Webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("webhook '%s' is not an http or https url", cfg.Webhook)
+		}
+		hooks = make(chan []byte, webhookQueueSize)
+		defer close(hooks)
+		go postWebhooks(cfg.Webhook, hooks)
+	}
+	w := newWatcher(cx.ActiveNet, addrs, outPoints, cfg.Confirmations, cfg.Mempool, func(ev *Event) {
+		emit(os.Stdout, hooks, ev)
+	})
+	quit := make(chan struct{})
+	interrupt.AddHandler(func() {
+		close(quit)
+	})
+	// The notifications are all handled by the loop below in the order they arrive.
+	ntfns := make(chan interface{}, 100)
+	queue := func(n interface{}) {
+		select {
+		case ntfns <- n:
+		case <-quit:
+		}
+	}
+	var certs []byte
+	if *cx.Config.TLS && *cx.Config.RPCCert != "" {
+		if certs, err = ioutil.ReadFile(*cx.Config.RPCCert); err != nil {
+			return err
+		}
+	}
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+		Host:         *cx.Config.RPCConnect,
+		Endpoint:     "ws",
+		User:         *cx.Config.Username,
+		Pass:         *cx.Config.Password,
+		TLS:          *cx.Config.TLS,
+		Certificates: certs,
+	}, &rpcclient.NotificationHandlers{
+		OnClientConnected: func() {
+			queue(clientConnected{})
+		},
+		OnFilteredBlockConnected: func(height int32, header *wire.BlockHeader, txs []*util.Tx) {
+			queue(blockConnected{height: height, header: header, txs: txs})
+		},
+		OnFilteredBlockDisconnected: func(height int32, header *wire.BlockHeader) {
+			queue(blockDisconnected{height: height})
+		},
+		OnRelevantTxAccepted: func(transaction []byte) {
+			queue(relevantTxAccepted(transaction))
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer client.Shutdown()
+	Infof("watching %d addresses and %d outpoints at %s", len(addrs), len(outPoints), *cx.Config.RPCConnect)
+	loaded := false
+	for {
+		select {
+		case n := <-ntfns:
+			switch n := n.(type) {
+			case clientConnected:
+				// The transaction filter of the node is lost when the connection is, so it is loaded again on every
+				// connection, including the outpoints that were found since the watch started.
+				if err = load(client, w, cx.ActiveNet.Net); err != nil {
+					if !loaded {
+						return err
+					}
+					Error("failed to load the transaction filter:", err)
+					continue
+				}
+				loaded = true
+			case blockConnected:
+				hash := n.header.BlockHash()
+				w.blockConnected(n.height, &hash, n.txs)
+			case blockDisconnected:
+				w.blockDisconnected(n.height)
+			case relevantTxAccepted:
+				tx, err := util.NewTxFromBytes(n)
+				if err != nil {
+					Error("failed to decode relevant transaction:", err)
+					continue
+				}
+				w.txAccepted(tx)
+			}
+		case <-quit:
+			return nil
+		}
+	}
+}
+
+// load checks the node is on the same network, loads the watched addresses and outpoints into its transaction filter
+// and registers for the blocks connected to and disconnected from the main chain.
+func load(client *rpcclient.Client, w *watcher, net wire.BitcoinNet) (err error) {
+	var nodeNet wire.BitcoinNet
+	if nodeNet, err = client.GetCurrentNet(); err != nil {
+		return err
+	}
+	if nodeNet != net {
+		return errors.New("the node is on a different network")
+	}
+	addrs, outPoints := w.filter()
+	if err = client.LoadTxFilter(true, addrs, outPoints); err != nil {
+		return err
+	}
+	if err = client.NotifyBlocks(); err != nil {
+		return err
+	}
+	_, w.tip, err = client.GetBestBlock()
+	return err
+}
+
+// emit prints an event as a line of JSON and queues it for the webhook.
+func emit(out io.Writer, hooks chan<- []byte, ev *Event) {
+	b, err := js.Marshal(ev)
+	if err != nil {
+		Error(err)
+		return
+	}
+	if _, err = fmt.Fprintln(out, string(b)); err != nil {
+		Error(err)
+	}
+	if hooks == nil {
+		return
+	}
+	select {
+	case hooks <- b:
+	default:
+		Warn("webhook queue is full, dropping", ev.Event, "event for", ev.TxID)
+	}
+}
+
+// postWebhooks posts the events to the webhook in the order they were emitted until the channel is closed.
+func postWebhooks(webhook string, hooks <-chan []byte) {
+	client := &http.Client{Timeout: webhookTimeout}
+	for b := range hooks {
+		res, err := client.Post(webhook, "application/json", bytes.NewReader(b))
+		if err != nil {
+			Warn("watch webhook failed:", err)
+			continue
+		}
+		if err = res.Body.Close(); err != nil {
+			Error(err)
+		}
+		if res.StatusCode/100 != 2 {
+			Warn("watch webhook returned status", res.Status)
+		}
+	}
+}
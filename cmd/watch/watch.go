@@ -0,0 +1,253 @@
+package watch
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// reorgDepth is how many blocks a confirmed transaction is remembered for after it was reported, so that it can be
+// reported again if its block is disconnected.
+const reorgDepth = 100
+
+// The kinds of events reported by a watch.
+const (
+	// EventMempool is reported when a transaction paying a watched address or spending a watched outpoint is accepted
+	// into the mempool of the node.
+	EventMempool = "mempool"
+	// EventConfirmed is reported when such a transaction has reached the required number of confirmations.
+	EventConfirmed = "confirmed"
+	// EventDisconnected is reported when the block of a transaction that was reported as confirmed is disconnected
+	// from the main chain. The transaction is reported as confirmed again once it is mined into another block.
+	EventDisconnected = "disconnected"
+)
+
+// Output is an output of a transaction paying a watched address.
+type Output struct {
+	Address  string  `json:"address"`
+	OutPoint string  `json:"outpoint"`
+	Amount   float64 `json:"amount"`
+}
+
+// Event is printed, as a line of JSON, and posted to the webhook for every transaction that pays a watched address
+// or spends a watched outpoint.
+type Event struct {
+	Event         string   `json:"event"`
+	TxID          string   `json:"txid"`
+	Height        int32    `json:"height,omitempty"`
+	BlockHash     string   `json:"blockhash,omitempty"`
+	Confirmations int32    `json:"confirmations"`
+	Received      []Output `json:"received,omitempty"`
+	Spent         []string `json:"spent,omitempty"`
+}
+
+// ReadTargets reads the addresses and outpoints to watch from r, separated by whitespace. Everything on a line after
+// a # is a comment.
+func ReadTargets(r io.Reader) (targets []string, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		targets = append(targets, strings.Fields(line)...)
+	}
+	return targets, scanner.Err()
+}
+
+// ParseTargets splits the targets into the addresses and the outpoints, in txid:index form, to watch.
+func ParseTargets(targets []string, params *netparams.Params) (addrs []util.Address, outPoints []wire.OutPoint,
+	err error) {
+	for _, t := range targets {
+		if i := strings.LastIndexByte(t, ':'); i >= 0 {
+			if i != chainhash.MaxHashStringSize {
+				return nil, nil, fmt.Errorf("%s is not a valid outpoint", t)
+			}
+			var hash *chainhash.Hash
+			if hash, err = chainhash.NewHashFromStr(t[:i]); err != nil {
+				return nil, nil, fmt.Errorf("%s is not a valid outpoint: %v", t, err)
+			}
+			var index uint64
+			if index, err = strconv.ParseUint(t[i+1:], 10, 32); err != nil {
+				return nil, nil, fmt.Errorf("%s is not a valid outpoint: %v", t, err)
+			}
+			outPoints = append(outPoints, *wire.NewOutPoint(hash, uint32(index)))
+			continue
+		}
+		var addr util.Address
+		if addr, err = util.DecodeAddress(t, params); err != nil {
+			return nil, nil, fmt.Errorf("%s is not a valid address: %v", t, err)
+		}
+		if !addr.IsForNet(params) {
+			return nil, nil, fmt.Errorf("%s is not an address for %s", t, params.Name)
+		}
+		addrs = append(addrs, addr)
+	}
+	if len(addrs) == 0 && len(outPoints) == 0 {
+		return nil, nil, fmt.Errorf("there are no addresses or outpoints to watch")
+	}
+	return
+}
+
+// watcher matches the transactions notified by the node against the watched addresses and outpoints, and tracks
+// them until they are confirmed.
+type watcher struct {
+	params        *netparams.Params
+	confirmations int32
+	mempool       bool
+	addrs         map[string]util.Address
+	outPoints     map[wire.OutPoint]struct{}
+	// pending are the transactions in blocks that do not have enough confirmations yet, in the order they were mined
+	pending []*Event
+	// reported are the transactions reported as confirmed within the last reorgDepth blocks
+	reported []*Event
+	tip      int32
+	emit     func(*Event)
+}
+
+// newWatcher returns a watcher for the addresses and outpoints that passes the events to emit.
+func newWatcher(params *netparams.Params, addrs []util.Address, outPoints []wire.OutPoint, confirmations int32,
+	mempool bool, emit func(*Event)) *watcher {
+	if confirmations < 1 {
+		confirmations = 1
+	}
+	w := &watcher{
+		params:        params,
+		confirmations: confirmations,
+		mempool:       mempool,
+		addrs:         make(map[string]util.Address, len(addrs)),
+		outPoints:     make(map[wire.OutPoint]struct{}, len(outPoints)),
+		emit:          emit,
+	}
+	for _, addr := range addrs {
+		w.addrs[addr.EncodeAddress()] = addr
+	}
+	for _, op := range outPoints {
+		w.outPoints[op] = struct{}{}
+	}
+	return w
+}
+
+// filter returns the addresses and outpoints to load into the transaction filter of the node. The outpoints include
+// the outputs paying watched addresses that were seen since the watch started.
+func (w *watcher) filter() (addrs []util.Address, outPoints []wire.OutPoint) {
+	for _, addr := range w.addrs {
+		addrs = append(addrs, addr)
+	}
+	for op := range w.outPoints {
+		outPoints = append(outPoints, op)
+	}
+	return
+}
+
+// match returns the event for a transaction, or nil if it neither pays a watched address nor spends a watched
+// outpoint. The outputs paying watched addresses are watched from then on, so that spending them is reported too.
+func (w *watcher) match(tx *util.Tx) *Event {
+	ev := &Event{TxID: tx.Hash().String()}
+	msgTx := tx.MsgTx()
+	for _, txIn := range msgTx.TxIn {
+		if _, ok := w.outPoints[txIn.PreviousOutPoint]; ok {
+			ev.Spent = append(ev.Spent, txIn.PreviousOutPoint.String())
+		}
+	}
+	for i, txOut := range msgTx.TxOut {
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, w.params)
+		if err != nil {
+			continue
+		}
+		for _, addr := range addrs {
+			if _, ok := w.addrs[addr.EncodeAddress()]; !ok {
+				continue
+			}
+			op := wire.NewOutPoint(tx.Hash(), uint32(i))
+			w.outPoints[*op] = struct{}{}
+			ev.Received = append(ev.Received, Output{
+				Address:  addr.EncodeAddress(),
+				OutPoint: op.String(),
+				Amount:   util.Amount(txOut.Value).ToDUO(),
+			})
+		}
+	}
+	if len(ev.Spent) == 0 && len(ev.Received) == 0 {
+		return nil
+	}
+	return ev
+}
+
+// txAccepted handles a transaction accepted into the mempool of the node.
+func (w *watcher) txAccepted(tx *util.Tx) {
+	ev := w.match(tx)
+	if ev == nil || !w.mempool {
+		return
+	}
+	ev.Event = EventMempool
+	w.emit(ev)
+}
+
+// blockConnected handles a block connected to the main chain, reporting the transactions that now have enough
+// confirmations.
+func (w *watcher) blockConnected(height int32, hash *chainhash.Hash, txs []*util.Tx) {
+	w.tip = height
+	for _, tx := range txs {
+		ev := w.match(tx)
+		if ev == nil {
+			continue
+		}
+		ev.Event = EventConfirmed
+		ev.Height = height
+		ev.BlockHash = hash.String()
+		w.pending = append(w.pending, ev)
+	}
+	pending := w.pending[:0]
+	for _, ev := range w.pending {
+		confirmations := w.tip - ev.Height + 1
+		if confirmations < w.confirmations {
+			pending = append(pending, ev)
+			continue
+		}
+		ev.Confirmations = confirmations
+		w.emit(ev)
+		w.reported = append(w.reported, ev)
+	}
+	w.pending = pending
+	reported := w.reported[:0]
+	for _, ev := range w.reported {
+		if w.tip-ev.Height < reorgDepth {
+			reported = append(reported, ev)
+		}
+	}
+	w.reported = reported
+}
+
+// blockDisconnected handles a block disconnected from the main chain, reporting the transactions in it that were
+// reported as confirmed.
+func (w *watcher) blockDisconnected(height int32) {
+	w.tip = height - 1
+	pending := w.pending[:0]
+	for _, ev := range w.pending {
+		if ev.Height < height {
+			pending = append(pending, ev)
+		}
+	}
+	w.pending = pending
+	reported := w.reported[:0]
+	for _, ev := range w.reported {
+		if ev.Height < height {
+			reported = append(reported, ev)
+			continue
+		}
+		disconnected := *ev
+		disconnected.Event = EventDisconnected
+		disconnected.Confirmations = 0
+		w.emit(&disconnected)
+	}
+	w.reported = reported
+}
@@ -0,0 +1,92 @@
+package watch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestParseTargets ensures addresses and outpoints are told apart and invalid targets are rejected.
+func TestParseTargets(t *testing.T) {
+	params := &netparams.MainNetParams
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash := chainhash.DoubleHashH([]byte("watch"))
+	targets, err := ReadTargets(strings.NewReader(addr.EncodeAddress() + " # the till\n\n" + hash.String() + ":2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addrs, outPoints, err := ParseTargets(targets, params)
+	if err != nil {
+		t.Fatalf("unexpected error parsing %v: %v", targets, err)
+	}
+	if len(addrs) != 1 || addrs[0].EncodeAddress() != addr.EncodeAddress() {
+		t.Fatalf("got addresses %v, want %s", addrs, addr)
+	}
+	if len(outPoints) != 1 || outPoints[0] != *wire.NewOutPoint(&hash, 2) {
+		t.Fatalf("got outpoints %v, want %s:2", outPoints, hash)
+	}
+	for _, target := range []string{"notanaddress", hash.String() + ":x", "abc:1"} {
+		if _, _, err = ParseTargets([]string{target}, params); err == nil {
+			t.Errorf("%s was parsed without an error", target)
+		}
+	}
+	if _, _, err = ParseTargets(nil, params); err == nil {
+		t.Error("no targets were parsed without an error")
+	}
+}
+
+// TestWatcherConfirmations ensures transactions are reported once they have enough confirmations, that spending
+// a received output is reported, and that a disconnected block is reported.
+func TestWatcherConfirmations(t *testing.T) {
+	params := &netparams.MainNetParams
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []Event
+	w := newWatcher(params, []util.Address{addr}, nil, 2, true, func(ev *Event) {
+		events = append(events, *ev)
+	})
+	pay := wire.NewMsgTx(wire.TxVersion)
+	pay.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{1}, 0), nil, nil))
+	pay.AddTxOut(wire.NewTxOut(100000000, pkScript))
+	payTx := util.NewTx(pay)
+	spend := wire.NewMsgTx(wire.TxVersion)
+	spend.AddTxIn(wire.NewTxIn(wire.NewOutPoint(payTx.Hash(), 0), nil, nil))
+	spendTx := util.NewTx(spend)
+	w.txAccepted(payTx)
+	if len(events) != 1 || events[0].Event != EventMempool || len(events[0].Received) != 1 ||
+		events[0].Received[0].Amount != 1 {
+		t.Fatalf("unexpected events after mempool acceptance: %+v", events)
+	}
+	w.blockConnected(10, &chainhash.Hash{10}, []*util.Tx{payTx})
+	if len(events) != 1 {
+		t.Fatalf("transaction was reported before it had enough confirmations: %+v", events)
+	}
+	w.blockConnected(11, &chainhash.Hash{11}, []*util.Tx{spendTx})
+	if len(events) != 2 || events[1].Event != EventConfirmed || events[1].TxID != payTx.Hash().String() ||
+		events[1].Confirmations != 2 {
+		t.Fatalf("unexpected events after the second block: %+v", events)
+	}
+	w.blockConnected(12, &chainhash.Hash{12}, nil)
+	if len(events) != 3 || events[2].TxID != spendTx.Hash().String() || len(events[2].Spent) != 1 {
+		t.Fatalf("spending the received output was not reported: %+v", events)
+	}
+	w.blockDisconnected(12)
+	w.blockDisconnected(11)
+	if len(events) != 4 || events[3].Event != EventDisconnected || events[3].TxID != spendTx.Hash().String() {
+		t.Fatalf("disconnecting the block of the spend was not reported: %+v", events)
+	}
+}
@@ -19,6 +19,7 @@ require (
 	github.com/davecgh/go-spew v1.1.1
 	github.com/egonelbre/expgio v0.0.0-20201101174813-bf265dd8d318 // indirect
 	github.com/enceve/crypto v0.0.0-20160707101852-34d48bb93815
+	github.com/getlantern/systray v1.1.0
 	github.com/gioapp/gel v0.0.0-20201002070804-a38b199dc376
 	github.com/ipfs/go-bitswap v0.2.20
 	github.com/ipfs/go-blockservice v0.1.3
@@ -65,8 +66,7 @@ require (
 	golang.org/x/exp v0.0.0-20200924195034-c827fd4f18b9
 	golang.org/x/image v0.0.0-20200927104501-e162460cd6b5
 	golang.org/x/net v0.0.0-20200301022130-244492dfa37a
-	golang.org/x/sys v0.0.0-20200317113312-5766fd39f98d // indirect
+	golang.org/x/text v0.3.2
 	gopkg.in/check.v1 v1.0.0-20200227125254-8fa46927fb4f // indirect
 	lukechampine.com/blake3 v1.0.0
-
 )
@@ -89,6 +89,9 @@ type BlockChain struct {
 	sigCache            *txscript.SigCache
 	indexManager        IndexManager
 	hashCache           *txscript.HashCache
+	pruneTarget         uint64
+	pruneDepth          int32
+	scriptWorkers       int
 	// The following fields are calculated based upon the provided chain parameters. They are also set when the instance
 	// is created and can't be changed afterwards, so there is no need to protect them with a separate mutex.
 	minRetargetTimespan int64 // target timespan / adjustment factor
@@ -111,6 +114,9 @@ type BlockChain struct {
 	// These fields are related to checkpoint handling. They are protected by the chain lock.
 	nextCheckpoint *chaincfg.Checkpoint
 	checkpointNode *BlockNode
+	// pruneHeight is the height of the highest block removed from the database by pruning, or -1 when no blocks have
+	// been removed.
+	pruneHeight atomic.Int32
 	// The state is used as a fairly efficient way to cache information about the current best chain state that is
 	// returned to callers when requested. It operates on the principle of MVCC such that any time a new block becomes
 	// the best block, the state pointer is replaced with a new struct and the old state is left untouched. In this way,
@@ -534,6 +540,7 @@ func (b *BlockChain) connectBlock(node *BlockNode, block *util.Block,
 	b.stateLock.Lock()
 	b.stateSnapshot = state
 	b.stateLock.Unlock()
+	b.maybePrune()
 	//
 	// // TODO: this should not run if the chain is syncing
 	// tN := time.Now()
@@ -1413,6 +1420,15 @@ type Config struct {
 	// O(N^2) validation complexity due to the SigHashAll flag. This field can be nil if the caller is not interested in
 	// using a signature cache.
 	HashCache *txscript.HashCache
+	// PruneTarget is the size in bytes the stored blocks are pruned down to. Only blocks before the latest checkpoint
+	// and more than PruneDepth blocks below the tip are removed. Pruning is disabled when it is zero.
+	PruneTarget uint64
+	// PruneDepth is the number of blocks below the tip whose blocks and spend journal entries are kept when pruning,
+	// which is the deepest reorganization that can be handled.
+	PruneDepth int32
+	// ScriptWorkers is the number of goroutines that verify the scripts of the transactions in a block when it is
+	// connected. It is the number of CPU cores when it is zero.
+	ScriptWorkers int
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -1455,6 +1471,9 @@ func New(config *Config) (*BlockChain, error) {
 		timeSource:            config.TimeSource,
 		sigCache:              config.SigCache,
 		indexManager:          config.IndexManager,
+		pruneTarget:           config.PruneTarget,
+		pruneDepth:            config.PruneDepth,
+		scriptWorkers:         config.ScriptWorkers,
 		minRetargetTimespan:   targetTimespan / adjustmentFactor,
 		maxRetargetTimespan:   targetTimespan * adjustmentFactor,
 		blocksPerRetarget:     int32(targetTimespan / targetTimePerBlock),
@@ -1473,6 +1492,14 @@ func New(config *Config) (*BlockChain, error) {
 	if err := b.initChainState(); err != nil {
 		return nil, err
 	}
+	err := b.db.View(func(dbTx database.Tx) error {
+		b.pruneHeight.Store(dbFetchPruneHeight(dbTx))
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
 	// Perform any upgrades to the various chain-specific buckets as needed.
 	if err := b.maybeUpgradeDbBuckets(config.Interrupt); err != nil {
 		return nil, err
@@ -41,6 +41,9 @@ var (
 	// spendJournalBucketName is the name of the db bucket used to house transactions outputs that are spent in each
 	// block.
 	spendJournalBucketName = []byte("spendjournal")
+	// pruneHeightKeyName is the name of the db key used to store the height of the highest block removed from the
+	// database by pruning.
+	pruneHeightKeyName = []byte("pruneheight")
 	// utxoSetVersionKeyName is the name of the db key used to store the version of the utxo set currently in the
 	// database.
 	utxoSetVersionKeyName = []byte("utxosetversion")
@@ -419,6 +422,23 @@ func dbRemoveSpendJournalEntry(dbTx database.Tx, blockHash *chainhash.Hash) erro
 	return spendBucket.Delete(blockHash[:])
 }
 
+// dbPutPruneHeight uses an existing database transaction to store the height of the highest pruned block.
+func dbPutPruneHeight(dbTx database.Tx, height int32) error {
+	var serialized [4]byte
+	byteOrder.PutUint32(serialized[:], uint32(height))
+	return dbTx.Metadata().Put(pruneHeightKeyName, serialized[:])
+}
+
+// dbFetchPruneHeight uses an existing database transaction to fetch the height of the highest pruned block. It returns
+// -1 when no blocks have been pruned.
+func dbFetchPruneHeight(dbTx database.Tx) int32 {
+	serialized := dbTx.Metadata().Get(pruneHeightKeyName)
+	if len(serialized) != 4 {
+		return -1
+	}
+	return int32(byteOrder.Uint32(serialized))
+}
+
 // The unspent transaction output (
 // utxo) set consists of an entry for each unspent output using a format that is optimized to reduce space using domain specific compression algorithms.  This format is a slightly modified version of the format used in Bitcoin Core.
 // Each entry is keyed by an outpoint as specified below.
@@ -93,6 +93,15 @@ type Checkpoint struct {
 	Hash   *chainhash.Hash
 }
 
+// AssumeUTXO identifies a UTXO set snapshot that is known to be good, by the block it was taken at and the hash of its
+// serialized coins. Nodes can be bootstrapped from such a snapshot instead of downloading and validating every block
+// before it.
+type AssumeUTXO struct {
+	Height    int32
+	BlockHash *chainhash.Hash
+	UTXOHash  *chainhash.Hash
+}
+
 // DNSSeed identifies a DNS seed.
 type DNSSeed struct {
 	// Host defines the hostname of the seed.
@@ -176,6 +185,11 @@ type Params struct {
 	GenerateSupported bool
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints []Checkpoint
+	// AssumeUTXO are the UTXO set snapshots that nodes can be bootstrapped from, ordered from oldest to newest.
+	AssumeUTXO []AssumeUTXO
+	// AnnounceKeys are the hex encoded compressed public keys of the maintainers whose signatures are accepted on
+	// announce messages relayed across the peer to peer network. Announcements are ignored if there are none.
+	AnnounceKeys []string
 	// These fields are related to voting on consensus rule changes as defined by BIP0009.
 	//
 	// RuleChangeActivationThreshold is the number of blocks in a threshold state retarget window for which a positive
@@ -35,6 +35,9 @@ var MainNetParams = Params{
 		// {, newHashFromStr("")},
 		// {200069, newHashFromStr("000000000000044e641986c8ee672460e853a11b352869cb8a4a8ba0b3f3e6dc")},
 	},
+	// UTXO set snapshots ordered from oldest to newest. None has been verified yet, so loadutxoset refuses every
+	// snapshot until one is added here.
+	AssumeUTXO: []AssumeUTXO{},
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
@@ -31,6 +31,8 @@ var RegressionTestParams = Params{
 	GenerateSupported:        true,
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: nil,
+	// UTXO set snapshots ordered from oldest to newest.
+	AssumeUTXO: nil,
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
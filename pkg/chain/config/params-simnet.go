@@ -35,6 +35,8 @@ var SimNetParams = Params{
 	GenerateSupported:        true,
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: nil,
+	// UTXO set snapshots ordered from oldest to newest.
+	AssumeUTXO: nil,
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
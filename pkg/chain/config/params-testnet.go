@@ -36,6 +36,9 @@ var TestNet3Params = Params{
 	Checkpoints: []Checkpoint{
 		// {546, newHashFromStr("000000002a936ca763904c3c35fce2f3556c559c0214345d31b1bcebf76acb70")},
 	},
+	// UTXO set snapshots ordered from oldest to newest. None has been verified yet, so loadutxoset refuses every
+	// snapshot until one is added here.
+	AssumeUTXO: []AssumeUTXO{},
 	// Consensus rule change deployments.
 	//
 	// The miner confirmation window is defined as:
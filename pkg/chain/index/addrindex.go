@@ -3,6 +3,7 @@ package indexers
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 
 	blockchain "github.com/p9c/pod/pkg/chain"
@@ -661,6 +662,14 @@ func (idx *AddrIndex) TxRegionsForAddress(dbTx database.Tx, addr util.Address, n
 	return regions, skipped, err
 }
 
+// AllTxRegionsForAddress returns the block regions of every transaction confirmed in a block that involves the passed
+// address, oldest first. It is used to aggregate balances and unspent outputs of an address, which needs the complete
+// history rather than a page of it. This function is safe for concurrent access.
+func (idx *AddrIndex) AllTxRegionsForAddress(dbTx database.Tx, addr util.Address) ([]database.BlockRegion, error) {
+	regions, _, err := idx.TxRegionsForAddress(dbTx, addr, 0, math.MaxUint32, false)
+	return regions, err
+}
+
 // indexUnconfirmedAddresses modifies the unconfirmed (memory-only) address index to include mappings for the addresses
 // encoded by the passed public key script to the transaction. This function is safe for concurrent access.
 func (idx *AddrIndex) indexUnconfirmedAddresses(pkScript []byte, tx *util.Tx) {
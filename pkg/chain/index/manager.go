@@ -2,6 +2,7 @@ package indexers
 
 import (
 	"fmt"
+	"sync/atomic"
 
 	blockchain "github.com/p9c/pod/pkg/chain"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
@@ -111,6 +112,18 @@ func dbIndexDisconnectBlock(dbTx database.Tx, indexer Indexer, block *util.Block
 type Manager struct {
 	db             database.DB
 	enabledIndexes []Indexer
+	// catchUpHeight and catchUpTarget are the heights the indexes have been caught up to and are being caught up to
+	// at startup, accessed atomically.
+	catchUpHeight int32
+	catchUpTarget int32
+}
+
+// CatchUpProgress returns the height the indexes have been caught up to and the height they are being caught up to,
+// which are the same once the indexes have caught up with the chain.
+//
+// This function is safe for concurrent access.
+func (m *Manager) CatchUpProgress() (height, target int32) {
+	return atomic.LoadInt32(&m.catchUpHeight), atomic.LoadInt32(&m.catchUpTarget)
 }
 
 // Ensure the Manager type implements the blockchain.IndexManager interface.
@@ -345,6 +358,8 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 		Error(err)
 		return err
 	}
+	atomic.StoreInt32(&m.catchUpTarget, bestHeight)
+	atomic.StoreInt32(&m.catchUpHeight, lowestHeight)
 	// Nothing to index if all of the indexes are caught up.
 	if lowestHeight == bestHeight {
 		return nil
@@ -396,6 +411,7 @@ func (m *Manager) Init(chain *blockchain.BlockChain, interrupt <-chan struct{})
 			}
 			indexerHeights[i] = height
 		}
+		atomic.StoreInt32(&m.catchUpHeight, height)
 		// Log indexing progress.
 		progressLogger.LogBlockHeight(block)
 		if interruptRequested(interrupt) {
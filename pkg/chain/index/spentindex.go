@@ -0,0 +1,197 @@
+package indexers
+
+import (
+	"fmt"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// spentIndexName is the human-readable name for the index.
+	spentIndexName = "spent output index"
+	// outpointKeySize is the size of a serialized outpoint used as the key of the spent output index.
+	outpointKeySize = chainhash.HashSize + 4
+	// spentEntrySize is the size of a serialized spent output index entry.
+	spentEntrySize = chainhash.HashSize + 4 + 4
+)
+
+var (
+	// spentIndexKey is the key of the spent output index and the db bucket used to house it.
+	spentIndexKey = []byte("spentbyoutpointidx")
+)
+
+// The spent output index maps every outpoint spent in the main chain to the transaction input that spends it:
+//
+//   Field           Type              Size
+//   txid            chainhash.Hash    32
+//   output index    uint32            4
+//   -----
+//   Key: 36 bytes
+//
+//   spending txid   chainhash.Hash    32
+//   input index     uint32            4
+//   block height    uint32            4
+//   -----
+//   Value: 40 bytes
+//
+// Unlike the witness transaction index it does not use the internal block IDs, so it does not require the transaction
+// index to be enabled.
+
+// SpentInfo describes the transaction input that spends an outpoint in the main chain.
+type SpentInfo struct {
+	TxID   chainhash.Hash
+	Index  uint32
+	Height int32
+}
+
+// outpointKey returns the key of the provided outpoint in the spent output index.
+func outpointKey(op *wire.OutPoint) []byte {
+	key := make([]byte, outpointKeySize)
+	copy(key, op.Hash[:])
+	byteOrder.PutUint32(key[chainhash.HashSize:], op.Index)
+	return key
+}
+
+// putSpentIndexEntry serializes the provided spending input into the target byte slice, which must be at least
+// spentEntrySize bytes.
+func putSpentIndexEntry(target []byte, txid *chainhash.Hash, index uint32, height int32) {
+	copy(target, txid[:])
+	byteOrder.PutUint32(target[chainhash.HashSize:], index)
+	byteOrder.PutUint32(target[chainhash.HashSize+4:], uint32(height))
+}
+
+// deserializeSpentIndexEntry decodes the passed serialized spent output index entry.
+func deserializeSpentIndexEntry(serialized []byte) (*SpentInfo, error) {
+	if len(serialized) < spentEntrySize {
+		return nil, errDeserialize("unexpected end of data")
+	}
+	var info SpentInfo
+	copy(info.TxID[:], serialized[:chainhash.HashSize])
+	info.Index = byteOrder.Uint32(serialized[chainhash.HashSize:])
+	info.Height = int32(byteOrder.Uint32(serialized[chainhash.HashSize+4:]))
+	return &info, nil
+}
+
+// dbAddSpentIndexEntries uses an existing database transaction to add a spent output index entry for every input of
+// the non-coinbase transactions in the passed block.
+func dbAddSpentIndexEntries(dbTx database.Tx, block *util.Block) error {
+	spentIndex := dbTx.Metadata().Bucket(spentIndexKey)
+	height := block.Height()
+	for _, tx := range block.Transactions()[1:] {
+		for i, txIn := range tx.MsgTx().TxIn {
+			serializedData := make([]byte, spentEntrySize)
+			putSpentIndexEntry(serializedData, tx.Hash(), uint32(i), height)
+			if err := spentIndex.Put(outpointKey(&txIn.PreviousOutPoint), serializedData); err != nil {
+				Error(err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dbRemoveSpentIndexEntries uses an existing database transaction to remove the spent output index entry of every
+// input of the non-coinbase transactions in the passed block.
+func dbRemoveSpentIndexEntries(dbTx database.Tx, block *util.Block) error {
+	spentIndex := dbTx.Metadata().Bucket(spentIndexKey)
+	for _, tx := range block.Transactions()[1:] {
+		for _, txIn := range tx.MsgTx().TxIn {
+			if err := spentIndex.Delete(outpointKey(&txIn.PreviousOutPoint)); err != nil {
+				Error(err)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SpentIndex implements a spent output index. That is to say, it supports querying which transaction input spends an
+// outpoint.
+type SpentIndex struct {
+	db database.DB
+}
+
+// Ensure the SpentIndex type implements the Indexer interface.
+var _ Indexer = (*SpentIndex)(nil)
+
+// Init is only provided to satisfy the Indexer interface as there is nothing to initialize for this index. This is
+// part of the Indexer interface.
+func (idx *SpentIndex) Init() error {
+	// Nothing to do.
+	return nil
+}
+
+// Key returns the database key to use for the index as a byte slice. This is part of the Indexer interface.
+func (idx *SpentIndex) Key() []byte {
+	return spentIndexKey
+}
+
+// Name returns the human-readable name of the index. This is part of the Indexer interface.
+func (idx *SpentIndex) Name() string {
+	return spentIndexName
+}
+
+// Create is invoked when the indexer manager determines the index needs to be created for the first time. It creates
+// the bucket for the spent output index. This is part of the Indexer interface.
+func (idx *SpentIndex) Create(dbTx database.Tx) error {
+	_, err := dbTx.Metadata().CreateBucket(spentIndexKey)
+	return err
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been connected to the main chain. This indexer adds
+// an outpoint-to-spending input mapping for every input spent in the passed block. This is part of the Indexer
+// interface.
+func (idx *SpentIndex) ConnectBlock(dbTx database.Tx, block *util.Block, stxos []blockchain.SpentTxOut) error {
+	return dbAddSpentIndexEntries(dbTx, block)
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been disconnected from the main chain. This indexer
+// removes the outpoint-to-spending input mapping for every input spent in the block. This is part of the Indexer
+// interface.
+func (idx *SpentIndex) DisconnectBlock(dbTx database.Tx, block *util.Block, stxos []blockchain.SpentTxOut) error {
+	return dbRemoveSpentIndexEntries(dbTx, block)
+}
+
+// SpentInfo returns the transaction input in the main chain that spends the provided outpoint.
+//
+// When the outpoint is unspent, or only spent by transactions that are not yet in a block, nil will be returned for
+// both the entry and the error.
+//
+// This function is safe for concurrent access.
+func (idx *SpentIndex) SpentInfo(op *wire.OutPoint) (*SpentInfo, error) {
+	var info *SpentInfo
+	err := idx.db.View(func(dbTx database.Tx) error {
+		serializedData := dbTx.Metadata().Bucket(spentIndexKey).Get(outpointKey(op))
+		if len(serializedData) == 0 {
+			return nil
+		}
+		var err error
+		if info, err = deserializeSpentIndexEntry(serializedData); err != nil {
+			return database.DBError{
+				ErrorCode: database.ErrCorruption,
+				Description: fmt.Sprintf("corrupt spent output index "+
+					"entry for %s: %v", op, err),
+			}
+		}
+		return nil
+	})
+	return info, err
+}
+
+// NewSpentIndex returns a new instance of an indexer that is used to create a mapping of every outpoint spent in the
+// blockchain to the transaction input that spends it and the height of the block it is in.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in turn is used by the blockchain package.
+// This allows the index to be seamlessly maintained along with the chain.
+func NewSpentIndex(db database.DB) *SpentIndex {
+	return &SpentIndex{db: db}
+}
+
+// DropSpentIndex drops the spent output index from the provided database if it exists.
+func DropSpentIndex(db database.DB, interrupt <-chan struct{}) error {
+	return dropIndex(db, spentIndexKey, spentIndexName, interrupt)
+}
@@ -0,0 +1,38 @@
+package indexers
+
+import (
+	"bytes"
+	"testing"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestSpentIndexSerialization ensures outpoint keys are unique per output and spent output index entries round trip.
+func TestSpentIndexSerialization(t *testing.T) {
+	hash := chainhash.DoubleHashH([]byte("spent"))
+	key0 := outpointKey(wire.NewOutPoint(&hash, 0))
+	key1 := outpointKey(wire.NewOutPoint(&hash, 1))
+	if len(key0) != outpointKeySize {
+		t.Fatalf("outpoint key is %d bytes, want %d", len(key0), outpointKeySize)
+	}
+	if bytes.Equal(key0, key1) {
+		t.Fatal("outpoints of the same transaction have the same key")
+	}
+	if !bytes.Equal(key0[:chainhash.HashSize], hash[:]) {
+		t.Fatal("outpoint key does not start with the transaction hash")
+	}
+	spender := chainhash.DoubleHashH([]byte("spender"))
+	serialized := make([]byte, spentEntrySize)
+	putSpentIndexEntry(serialized, &spender, 3, 123456)
+	info, err := deserializeSpentIndexEntry(serialized)
+	if err != nil {
+		t.Fatalf("unexpected error deserializing entry: %v", err)
+	}
+	if info.TxID != spender || info.Index != 3 || info.Height != 123456 {
+		t.Fatalf("entry did not round trip: got %s:%d at %d", info.TxID, info.Index, info.Height)
+	}
+	if _, err = deserializeSpentIndexEntry(serialized[:spentEntrySize-1]); err == nil {
+		t.Fatal("short entry deserialized without an error")
+	}
+}
@@ -162,8 +162,15 @@ func dbPutTxIndexEntry(dbTx database.Tx, txHash *chainhash.Hash, serializedData
 // from the transaction index. When there is no entry for the provided hash, nil will be returned for the both the
 // region and the error.
 func dbFetchTxIndexEntry(dbTx database.Tx, txHash *chainhash.Hash) (*database.BlockRegion, error) {
+	return dbFetchTxIndexEntryFrom(dbTx, txIndexKey, txHash)
+}
+
+// dbFetchTxIndexEntryFrom fetches the block region for the provided hash from the index in the given bucket, which
+// holds entries serialized by putTxIndexEntry.
+func dbFetchTxIndexEntryFrom(dbTx database.Tx, bucketKey []byte, txHash *chainhash.Hash) (*database.BlockRegion,
+	error) {
 	// Load the record from the database and return now if it doesn't exist.
-	txIndex := dbTx.Metadata().Bucket(txIndexKey)
+	txIndex := dbTx.Metadata().Bucket(bucketKey)
 	serializedData := txIndex.Get(txHash[:])
 	if len(serializedData) == 0 {
 		return nil, nil
@@ -415,13 +422,17 @@ func dropBlockIDIndex(db database.DB) error {
 	})
 }
 
-// DropTxIndex drops the transaction index from the provided database if it exists. Since the address index relies on
-// it, the address index will also be dropped when it exists.
+// DropTxIndex drops the transaction index from the provided database if it exists. Since the address and witness
+// transaction indexes rely on it, they will also be dropped when they exist.
 func DropTxIndex(db database.DB, interrupt <-chan struct{}) error {
 	err := dropIndex(db, addrIndexKey, addrIndexName, interrupt)
 	if err != nil {
 		Error(err)
 		return err
 	}
+	if err = DropWTxIndex(db, interrupt); err != nil {
+		Error(err)
+		return err
+	}
 	return dropIndex(db, txIndexKey, txIndexName, interrupt)
 }
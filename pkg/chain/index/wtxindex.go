@@ -0,0 +1,160 @@
+package indexers
+
+import (
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// wtxIndexName is the human-readable name for the index.
+	wtxIndexName = "witness transaction index"
+)
+
+var (
+	// wtxIndexKey is the key of the witness transaction index and the db bucket used to house it.
+	wtxIndexKey = []byte("wtxbyhashidx")
+)
+
+// The witness transaction index maps the witness hash (wtxid) of every transaction in the main chain that has witness
+// data to the location of the transaction, using the same entries as the transaction index:
+//
+//   Field           Type              Size
+//   wtxid           chainhash.Hash    32
+//   block id        uint32            4
+//   start offset    uint32            4
+//   tx length       uint32            4
+//   -----
+//   Total: 44 bytes
+//
+// The wtxid of a transaction without witness data is its txid, so those are found in the transaction index and are not
+// stored again. The block ids are those of the internal block ID index maintained by the transaction index, which is
+// why this index requires it.
+
+// dbAddWTxIndexEntries uses an existing database transaction to add a witness transaction index entry for every
+// transaction with witness data in the passed block.
+func dbAddWTxIndexEntries(dbTx database.Tx, block *util.Block, blockID uint32) error {
+	txLocs, err := block.TxLoc()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	wtxIndex := dbTx.Metadata().Bucket(wtxIndexKey)
+	for i, tx := range block.Transactions() {
+		if !tx.HasWitness() {
+			continue
+		}
+		serializedData := make([]byte, txEntrySize)
+		putTxIndexEntry(serializedData, blockID, txLocs[i])
+		if err := wtxIndex.Put(tx.WitnessHash()[:], serializedData); err != nil {
+			Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// dbRemoveWTxIndexEntries uses an existing database transaction to remove the witness transaction index entry of every
+// transaction with witness data in the passed block.
+func dbRemoveWTxIndexEntries(dbTx database.Tx, block *util.Block) error {
+	wtxIndex := dbTx.Metadata().Bucket(wtxIndexKey)
+	for _, tx := range block.Transactions() {
+		if !tx.HasWitness() {
+			continue
+		}
+		if err := wtxIndex.Delete(tx.WitnessHash()[:]); err != nil {
+			Error(err)
+			return err
+		}
+	}
+	return nil
+}
+
+// WTxIndex implements a transaction by witness hash index. That is to say, it supports querying all transactions by
+// their wtxid.
+type WTxIndex struct {
+	db database.DB
+}
+
+// Ensure the WTxIndex type implements the Indexer interface.
+var _ Indexer = (*WTxIndex)(nil)
+
+// Init is only provided to satisfy the Indexer interface as there is nothing to initialize for this index. This is
+// part of the Indexer interface.
+func (idx *WTxIndex) Init() error {
+	// Nothing to do.
+	return nil
+}
+
+// Key returns the database key to use for the index as a byte slice. This is part of the Indexer interface.
+func (idx *WTxIndex) Key() []byte {
+	return wtxIndexKey
+}
+
+// Name returns the human-readable name of the index. This is part of the Indexer interface.
+func (idx *WTxIndex) Name() string {
+	return wtxIndexName
+}
+
+// Create is invoked when the indexer manager determines the index needs to be created for the first time. It creates
+// the bucket for the witness hash-based transaction index. This is part of the Indexer interface.
+func (idx *WTxIndex) Create(dbTx database.Tx) error {
+	_, err := dbTx.Metadata().CreateBucket(wtxIndexKey)
+	return err
+}
+
+// ConnectBlock is invoked by the index manager when a new block has been connected to the main chain. This indexer adds
+// a witness hash-to-transaction mapping for every transaction with witness data in the passed block. This is part of
+// the Indexer interface.
+func (idx *WTxIndex) ConnectBlock(dbTx database.Tx, block *util.Block, stxos []blockchain.SpentTxOut) error {
+	// The transaction index connects the block first, so the internal block ID is already assigned.
+	blockID, err := dbFetchBlockIDByHash(dbTx, block.Hash())
+	if err != nil {
+		Error(err)
+		return err
+	}
+	return dbAddWTxIndexEntries(dbTx, block, blockID)
+}
+
+// DisconnectBlock is invoked by the index manager when a block has been disconnected from the main chain. This indexer
+// removes the witness hash-to-transaction mapping for every transaction with witness data in the block. This is part of
+// the Indexer interface.
+func (idx *WTxIndex) DisconnectBlock(dbTx database.Tx, block *util.Block, stxos []blockchain.SpentTxOut) error {
+	return dbRemoveWTxIndexEntries(dbTx, block)
+}
+
+// TxBlockRegion returns the block region for the transaction with the provided witness hash from the witness
+// transaction index.
+//
+// Transactions without witness data are not in this index, as their wtxid is their txid, so callers should look the
+// hash up in the transaction index as well.
+//
+// When there is no entry for the provided hash, nil will be returned for the both the entry and the error.
+//
+// This function is safe for concurrent access.
+func (idx *WTxIndex) TxBlockRegion(hash *chainhash.Hash) (*database.BlockRegion, error) {
+	var region *database.BlockRegion
+	err := idx.db.View(func(dbTx database.Tx) error {
+		var err error
+		region, err = dbFetchTxIndexEntryFrom(dbTx, wtxIndexKey, hash)
+		return err
+	})
+	return region, err
+}
+
+// NewWTxIndex returns a new instance of an indexer that is used to create a mapping of the witness hashes of all
+// transactions with witness data in the blockchain to the respective block, location within the block, and size of
+// the transaction.
+//
+// It implements the Indexer interface which plugs into the IndexManager that in turn is used by the blockchain package.
+// It relies on the internal block ID index of the transaction index, which must be enabled and placed before it in the
+// indexes passed to the IndexManager.
+func NewWTxIndex(db database.DB) *WTxIndex {
+	return &WTxIndex{db: db}
+}
+
+// DropWTxIndex drops the witness transaction index from the provided database if it exists.
+func DropWTxIndex(db database.DB, interrupt <-chan struct{}) error {
+	return dropIndex(db, wtxIndexKey, wtxIndexName, interrupt)
+}
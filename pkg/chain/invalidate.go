@@ -0,0 +1,157 @@
+package blockchain
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// ErrUnknownBlock is returned when invalidating or reconsidering a block that is not in the block index
+var ErrUnknownBlock = errors.New("block is not known")
+
+// InvalidateBlock marks the block with the passed hash as invalid, along with all of its descendants. When the block is
+// part of the main chain, the chain is reorganized to the valid chain with the most work that is available, which is at
+// least the chain ending at the parent of the block.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) InvalidateBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return ErrUnknownBlock
+	}
+	if node.parent == nil {
+		return errors.New("the genesis block can not be invalidated")
+	}
+	// Disconnecting the block needs its parent, so blocks right after pruned ones can not be invalidated.
+	if b.BestChain.Contains(node) && node.parent.height <= b.pruneHeight.Load() {
+		return fmt.Errorf("block %v can not be disconnected as its parent has been pruned", hash)
+	}
+	b.Index.SetStatusFlags(node, statusValidateFailed)
+	for _, n := range b.descendants(node) {
+		b.Index.SetStatusFlags(n, statusInvalidAncestor)
+	}
+	err := b.activateBestChain()
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		Error("failed to write block index changes:", writeErr)
+	}
+	if err != nil {
+		return err
+	}
+	Infof("invalidated block %v (height %d)", node.hash, node.height)
+	return nil
+}
+
+// ReconsiderBlock removes the invalid status of the block with the passed hash, its ancestors and its descendants that
+// were marked invalid because of it or by InvalidateBlock, and reorganizes the chain to the valid chain with the most
+// work. Blocks that really are invalid are marked invalid again when they are validated during the reorganization.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ReconsiderBlock(hash *chainhash.Hash) error {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	node := b.Index.LookupNode(hash)
+	if node == nil {
+		return ErrUnknownBlock
+	}
+	for n := node; n != nil; n = n.parent {
+		b.Index.UnsetStatusFlags(n, statusValidateFailed|statusInvalidAncestor)
+	}
+	for _, n := range b.descendants(node) {
+		b.Index.UnsetStatusFlags(n, statusValidateFailed|statusInvalidAncestor)
+	}
+	err := b.activateBestChain()
+	if writeErr := b.Index.flushToDB(); writeErr != nil {
+		Error("failed to write block index changes:", writeErr)
+	}
+	if err != nil {
+		return err
+	}
+	Infof("reconsidered block %v (height %d)", node.hash, node.height)
+	return nil
+}
+
+// descendants returns the block nodes in the block index that descend from the passed node. This function is safe for
+// concurrent access.
+func (b *BlockChain) descendants(node *BlockNode) (nodes []*BlockNode) {
+	b.Index.RLock()
+	defer b.Index.RUnlock()
+	for _, n := range b.Index.index {
+		if n.height > node.height && n.Ancestor(node.height) == node {
+			nodes = append(nodes, n)
+		}
+	}
+	return
+}
+
+// bestChainCandidate returns the block node the chain should be reorganized to. This is the last block of the main
+// chain that is not known to be invalid, unless a block on a side chain has more work, using the same comparison as
+// connectBestChain. A side chain block is only a candidate when it and all of its ancestors that are not in the main
+// chain are not known to be invalid and have their block data stored, and the block where it forks from the main chain
+// has not been pruned, as it is needed to disconnect the blocks after it. Of side chain blocks with the same work the
+// highest one is chosen. This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) bestChainCandidate() *BlockNode {
+	b.Index.RLock()
+	defer b.Index.RUnlock()
+	best := b.BestChain.Tip()
+	for best != nil && best.status.KnownInvalid() {
+		best = best.parent
+	}
+	if best == nil {
+		return nil
+	}
+	pruneHeight := b.pruneHeight.Load()
+	for _, n := range b.Index.index {
+		if n.status.KnownInvalid() || b.BestChain.Contains(n) {
+			continue
+		}
+		if best.workSum != nil {
+			if cmp := n.workSum.Cmp(best.workSum); cmp < 0 || cmp == 0 && n.height <= best.height {
+				continue
+			}
+		}
+		a := n
+		for ; a != nil && !b.BestChain.Contains(a); a = a.parent {
+			if !a.status.HaveData() || a.status.KnownInvalid() {
+				break
+			}
+		}
+		if a != nil && b.BestChain.Contains(a) && a.height > pruneHeight {
+			best = n
+		}
+	}
+	return best
+}
+
+// activateBestChain reorganizes the chain to the valid chain with the most work. Blocks that fail to connect during the
+// reorganization are marked invalid and the next best chain is tried, until the chain reaches a valid tip. This
+// function may modify node statuses in the block index without flushing. This function MUST be called with the chain
+// state lock held (for writes).
+func (b *BlockChain) activateBestChain() error {
+	for {
+		best := b.bestChainCandidate()
+		if best == nil {
+			return AssertError("there is no valid block to reorganize the chain to")
+		}
+		if best == b.BestChain.Tip() {
+			return nil
+		}
+		detachNodes, attachNodes := list.New(), list.New()
+		if b.BestChain.Contains(best) {
+			for n := b.BestChain.Tip(); n != best; n = n.parent {
+				detachNodes.PushBack(n)
+			}
+		} else {
+			detachNodes, attachNodes = b.getReorganizeNodes(best)
+		}
+		err := b.reorganizeChain(detachNodes, attachNodes)
+		// A block that failed to connect marks the branch invalid, so the next candidate is another one.
+		if _, ok := err.(RuleError); ok && b.Index.NodeStatus(best).KnownInvalid() {
+			continue
+		}
+		return err
+	}
+}
@@ -3,6 +3,7 @@ package mining
 import (
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
 	"github.com/p9c/pod/pkg/chain/hardfork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
@@ -10,6 +11,56 @@ import (
 	"github.com/p9c/pod/pkg/util"
 )
 
+// IsHardForkActivation returns whether the block at the height is the hard fork activation block, whose coinbase pays
+// out the hard fork disbursement in place of a standard coinbase.
+func IsHardForkActivation(params *netparams.Params, height int32) bool {
+	return height == fork.List[1].ActivationHeight && params.Net == wire.MainNet ||
+		height == fork.List[1].TestnetStart && params.Net == wire.TestNet3
+}
+
+// CoinbaseSubsidy is how the coinbase of a new block pays out the block subsidy, excluding fees.
+type CoinbaseSubsidy struct {
+	// Miner is paid to the address of the miner
+	Miner util.Amount
+	// Payees is the total paid to the hard fork disbursement payees on the hard fork activation block
+	Payees util.Amount
+	// Core is paid to the development team multisig on the hard fork activation block
+	Core util.Amount
+}
+
+// CalcCoinbaseSubsidy returns how the coinbase created for a new block at the height and with the block version pays
+// out the subsidy. These are the amounts the coinbase of a block template pays.
+func CalcCoinbaseSubsidy(params *netparams.Params, height int32, version int32) (cs CoinbaseSubsidy) {
+	if !IsHardForkActivation(params, height) {
+		cs.Miner = util.Amount(blockchain.CalcBlockSubsidy(height, params, version))
+		return
+	}
+	for _, payee := range hardForkPayees(params) {
+		cs.Payees += payee.Amount
+	}
+	cs.Core = hardForkCoreAmount(params)
+	// the miner's reward is based on the last non-hf reward
+	cs.Miner = util.Amount(blockchain.CalcBlockSubsidy(height+1, params, version))
+	return
+}
+
+// hardForkPayees returns the payees of the hard fork disbursement on the network.
+func hardForkPayees(params *netparams.Params) []hardfork.Payee {
+	if params.Net == wire.TestNet3 {
+		return hardfork.TestnetPayees
+	}
+	return hardfork.Payees
+}
+
+// hardForkCoreAmount returns the amount paid to the development team multisig in the hard fork disbursement on the
+// network.
+func hardForkCoreAmount(params *netparams.Params) util.Amount {
+	if params.Net == wire.TestNet3 {
+		return hardfork.TestnetCoreAmount
+	}
+	return hardfork.CoreAmount
+}
+
 // createHardForkSubsidyTx creates the transaction that must be on the hard fork activation block in place of a standard
 // coinbase transaction.
 //
@@ -17,10 +68,8 @@ import (
 // the developers and to a 3 of 4 multisig to the development team for marketing and ongoing development costs multisig
 // tx: NUM_SIGS PUBKEY PUBKEY PUBKEY... NUM_PUBKEYS OP_CHECKMULTISIG
 func createHardForkSubsidyTx(params *netparams.Params, coinbaseScript []byte, nextBlockHeight int32, addr util.Address, version int32) (*util.Tx, error) {
-	payees := hardfork.Payees
-	if params.Net == wire.TestNet3 {
-		payees = hardfork.TestnetPayees
-	}
+	payees := hardForkPayees(params)
+	subsidy := CalcCoinbaseSubsidy(params, nextBlockHeight, version)
 	tx := wire.NewMsgTx(wire.TxVersion)
 	tx.AddTxIn(&wire.TxIn{
 		// Coinbase transactions have no inputs, so previous outpoint is zero hash and max index.
@@ -50,18 +99,14 @@ func createHardForkSubsidyTx(params *netparams.Params, coinbaseScript []byte, ne
 		AddOp(txscript.OP_4).
 		AddOp(txscript.OP_CHECKMULTISIG)
 	script, _ := builder.Script()
-	amt := hardfork.CoreAmount
-	if params.Net == wire.TestNet3 {
-		amt = hardfork.TestnetCoreAmount
-	}
 	tx.AddTxOut(&wire.TxOut{
-		Value:    int64(amt),
+		Value:    int64(subsidy.Core),
 		PkScript: script,
 	})
 	// add miner's reward based on last non-hf reward
 	script, _ = txscript.PayToAddrScript(addr)
 	tx.AddTxOut(&wire.TxOut{
-		Value:    blockchain.CalcBlockSubsidy(nextBlockHeight+1, params, version),
+		Value:    int64(subsidy.Miner),
 		PkScript: script,
 	})
 	return util.NewTx(tx), nil
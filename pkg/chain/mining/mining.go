@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"container/heap"
 	"fmt"
+	"math"
 	"time"
 
 	blockchain "github.com/p9c/pod/pkg/chain"
@@ -108,6 +109,8 @@ type (
 		TimeSource  blockchain.MedianTimeSource
 		SigCache    *txscript.SigCache
 		HashCache   *txscript.HashCache
+		// CoinbaseData is added to the coinbase script of generated blocks after the coinbase flags, such as a pool tag
+		CoinbaseData []byte
 	}
 )
 
@@ -210,11 +213,42 @@ func mergeUtxoView(viewA *blockchain.UtxoViewpoint, viewB *blockchain.UtxoViewpo
 
 // standardCoinbaseScript returns a standard script suitable for use as the signature script of the coinbase transaction
 // of a new block. In particular, it starts with the block height that is required by version 2 blocks and adds the
-// extra nonce as well as additional coinbase flags.
-func standardCoinbaseScript(nextBlockHeight int32, extraNonce uint64) ([]byte, error) {
-	return txscript.NewScriptBuilder().AddInt64(int64(nextBlockHeight)).
-		AddInt64(int64(extraNonce)).AddData([]byte(CoinbaseFlags)).
-		Script()
+// extra nonce as well as additional coinbase flags and the extra data, if there is any.
+func standardCoinbaseScript(nextBlockHeight int32, extraNonce uint64, extraData []byte) ([]byte, error) {
+	flags, err := CoinbaseFlagsScript(extraData)
+	if err != nil {
+		return nil, err
+	}
+	script, err := txscript.NewScriptBuilder().AddInt64(int64(nextBlockHeight)).
+		AddInt64(int64(extraNonce)).Script()
+	if err != nil {
+		return nil, err
+	}
+	return append(script, flags...), nil
+}
+
+// CoinbaseFlagsScript returns the pushes of the coinbase flags and the extra data, if there is any, which end the
+// signature script of the coinbase of generated blocks.
+func CoinbaseFlagsScript(extraData []byte) ([]byte, error) {
+	builder := txscript.NewScriptBuilder().AddData([]byte(CoinbaseFlags))
+	if len(extraData) > 0 {
+		builder.AddData(extraData)
+	}
+	return builder.Script()
+}
+
+// CheckCoinbaseData returns an error if the coinbase script of a block with the extra data would be longer than the
+// consensus limit, at the largest block height and extra nonce.
+func CheckCoinbaseData(extraData []byte) error {
+	script, err := standardCoinbaseScript(math.MaxInt32, math.MaxInt64, extraData)
+	if err != nil {
+		return err
+	}
+	if len(script) > blockchain.MaxCoinbaseScriptLen {
+		return fmt.Errorf("coinbase data of %d bytes makes the coinbase script %d bytes long, over the limit of %d",
+			len(extraData), len(script), blockchain.MaxCoinbaseScriptLen)
+	}
+	return nil
 }
 
 // createCoinbaseTx returns a coinbase transaction paying an appropriate subsidy based on the passed block height to the
@@ -223,10 +257,7 @@ func standardCoinbaseScript(nextBlockHeight int32, extraNonce uint64) ([]byte, e
 func createCoinbaseTx(params *netparams.Params, coinbaseScript []byte, nextBlockHeight int32,
 	addr util.Address, version int32) (*util.Tx, error) {
 	// if this is the hard fork activation height coming up, we create the special disbursement coinbase
-	if nextBlockHeight == fork.List[1].ActivationHeight &&
-		params.Net == wire.MainNet ||
-		nextBlockHeight == fork.List[1].TestnetStart &&
-			params.Net == wire.TestNet3 {
+	if IsHardForkActivation(params, nextBlockHeight) {
 		return createHardForkSubsidyTx(params, coinbaseScript, nextBlockHeight, addr, version)
 	}
 
@@ -398,11 +429,15 @@ Address, algo string) (*BlockTemplate, error) {
 	// below. The extra nonce helps ensure the transaction is not a duplicate transaction (paying the same value to the
 	// same public key address would otherwise be an identical transaction for block version 1).
 	extraNonce := uint64(0)
-	coinbaseScript, err := standardCoinbaseScript(nextBlockHeight, extraNonce)
+	coinbaseScript, err := standardCoinbaseScript(nextBlockHeight, extraNonce, g.CoinbaseData)
 	if err != nil {
 		Error(err)
 		return nil, err
 	}
+	if len(coinbaseScript) > blockchain.MaxCoinbaseScriptLen {
+		return nil, fmt.Errorf("coinbase script length of %d with %d bytes of coinbase data is over %d",
+			len(coinbaseScript), len(g.CoinbaseData), blockchain.MaxCoinbaseScriptLen)
+	}
 	coinbaseTx, err := createCoinbaseTx(g.ChainParams, coinbaseScript, nextBlockHeight, payToAddress, vers)
 	if err != nil {
 		Error(err)
@@ -818,7 +853,7 @@ MsgBlock) error {
 // It also recalculates and updates the new merkle root that results from changing the coinbase script.
 func (g *BlkTmplGenerator) UpdateExtraNonce(msgBlock *wire.MsgBlock,
 	blockHeight int32, extraNonce uint64) error {
-	coinbaseScript, err := standardCoinbaseScript(blockHeight, extraNonce)
+	coinbaseScript, err := standardCoinbaseScript(blockHeight, extraNonce, g.CoinbaseData)
 	if err != nil {
 		Error(err)
 		return err
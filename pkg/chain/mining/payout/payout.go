@@ -0,0 +1,153 @@
+// Package payout picks the mining address the coinbase of each block template pays to, by one of a set of policies
+// that can be chosen in the node configuration.
+package payout
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// Random pays each template to one of the mining addresses picked at random
+	Random = "random"
+	// RoundRobin pays each block to the next of the mining addresses in turn
+	RoundRobin = "roundrobin"
+	// PerAlgo pays the blocks of each algorithm to the address configured for it, and those of the other algorithms
+	// as with RoundRobin
+	PerAlgo = "peralgo"
+	// Fresh pays each block to a new address from the wallet, and as with RoundRobin while the wallet can't be reached
+	Fresh = "fresh"
+)
+
+// Policies are the names of the payout policies
+var Policies = []string{Random, RoundRobin, PerAlgo, Fresh}
+
+// ErrNoAddresses is returned when there is no address to pay to
+var ErrNoAddresses = errors.New("no mining addresses are configured")
+
+// Payout picks the addresses block templates pay to. The policies that rotate the address only move on when templates
+// are made for a new height, so the templates of one block all pay to the same address.
+type Payout struct {
+	Policy string
+	// AlgoAddrs are the addresses the blocks of each algorithm are paid to with the PerAlgo policy
+	AlgoAddrs map[string]util.Address
+	// NewAddress gets a new address from the wallet for the Fresh policy, or is nil without a wallet
+	NewAddress func() (util.Address, error)
+	mx         sync.Mutex
+	height     int32
+	next       int
+	fresh      util.Address
+}
+
+// New returns a Payout with the policy, and the algorithm addresses for the PerAlgo policy
+func New(policy string, algoAddrs map[string]util.Address) (p *Payout, err error) {
+	if policy == "" {
+		policy = Random
+	}
+	valid := false
+	for _, name := range Policies {
+		if policy == name {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("unknown payout policy '%s', it must be one of %s", policy,
+			strings.Join(Policies, ", "))
+	}
+	if algoAddrs == nil {
+		algoAddrs = make(map[string]util.Address)
+	}
+	return &Payout{Policy: policy, AlgoAddrs: algoAddrs, height: -1}, nil
+}
+
+// ParseAlgoAddrs parses algo=address pairs into the addresses for each algorithm
+func ParseAlgoAddrs(pairs []string, params *netparams.Params) (algoAddrs map[string]util.Address, err error) {
+	algoAddrs = make(map[string]util.Address)
+	for _, pair := range pairs {
+		split := strings.SplitN(pair, "=", 2)
+		if len(split) != 2 || split[1] == "" {
+			return nil, fmt.Errorf("payout address '%s' is not of the form algo=address", pair)
+		}
+		if !knownAlgo(split[0]) {
+			return nil, fmt.Errorf("payout address '%s' has an unknown algorithm", pair)
+		}
+		var addr util.Address
+		if addr, err = util.DecodeAddress(split[1], params); err != nil {
+			return nil, fmt.Errorf("payout address '%s' failed to decode: %v", pair, err)
+		}
+		if !addr.IsForNet(params) {
+			return nil, fmt.Errorf("payout address '%s' is on the wrong network", pair)
+		}
+		algoAddrs[split[0]] = addr
+	}
+	return
+}
+
+func knownAlgo(algo string) bool {
+	for i := range fork.List {
+		if _, ok := fork.List[i].Algos[algo]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Address returns the address a template of the algorithm at the height pays to, out of the mining addresses. A nil
+// Payout pays to one of them at random.
+func (p *Payout) Address(addrs []util.Address, algo string, height int32) (addr util.Address, err error) {
+	if p == nil {
+		return random(addrs)
+	}
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if height != p.height {
+		if p.height >= 0 {
+			p.next++
+		}
+		p.height, p.fresh = height, nil
+	}
+	switch p.Policy {
+	case PerAlgo:
+		if addr = p.AlgoAddrs[algo]; addr != nil {
+			return
+		}
+	case Fresh:
+		if p.fresh != nil {
+			return p.fresh, nil
+		}
+		if p.NewAddress != nil {
+			if p.fresh, err = p.NewAddress(); err == nil {
+				return p.fresh, nil
+			}
+			Warn("paying to a mining address as a new address could not be got from the wallet:", err)
+		}
+	case Random:
+		return random(addrs)
+	}
+	if len(addrs) == 0 {
+		return nil, ErrNoAddresses
+	}
+	return addrs[p.next%len(addrs)], nil
+}
+
+func random(addrs []util.Address) (util.Address, error) {
+	if len(addrs) == 0 {
+		return nil, ErrNoAddresses
+	}
+	return addrs[rand.Intn(len(addrs))], nil
+}
+
+// PolicyName returns the name of the policy, which is Random for a nil Payout
+func (p *Payout) PolicyName() string {
+	if p == nil {
+		return Random
+	}
+	return p.Policy
+}
@@ -0,0 +1,116 @@
+package payout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/fork"
+	"github.com/p9c/pod/pkg/util"
+)
+
+func testAddrs(t *testing.T, n int) (addrs []util.Address) {
+	for i := 0; i < n; i++ {
+		hash := make([]byte, 20)
+		hash[0] = byte(i + 1)
+		addr, err := util.NewAddressPubKeyHash(hash, &netparams.MainNetParams)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs = append(addrs, addr)
+	}
+	return
+}
+
+func mustAddress(t *testing.T, p *Payout, addrs []util.Address, algo string, height int32) util.Address {
+	addr, err := p.Address(addrs, algo, height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+// TestRoundRobin ensures that the address only moves on to the next in turn at a new height
+func TestRoundRobin(t *testing.T) {
+	addrs := testAddrs(t, 3)
+	p, err := New(RoundRobin, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []util.Address{addrs[0], addrs[0], addrs[1], addrs[2], addrs[0]} {
+		height := int32(10 + i)
+		if i > 0 {
+			height--
+		}
+		if got := mustAddress(t, p, addrs, fork.SHA256d, height); got.String() != want.String() {
+			t.Fatalf("template %d paid to %s, expected %s", i, got, want)
+		}
+	}
+}
+
+// TestPerAlgo ensures that the algorithms with an address are paid to it, and the others in turn
+func TestPerAlgo(t *testing.T) {
+	addrs := testAddrs(t, 3)
+	p, err := New(PerAlgo, map[string]util.Address{fork.Scrypt: addrs[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := mustAddress(t, p, addrs, fork.Scrypt, 1); got.String() != addrs[2].String() {
+		t.Fatalf("scrypt paid to %s instead of its address", got)
+	}
+	if got := mustAddress(t, p, addrs, fork.SHA256d, 1); got.String() != addrs[0].String() {
+		t.Fatalf("sha256d paid to %s instead of the first mining address", got)
+	}
+}
+
+// TestFresh ensures that a new address is got from the wallet once for each height, and that the mining addresses
+// are paid to while the wallet can't be reached
+func TestFresh(t *testing.T) {
+	addrs := testAddrs(t, 4)
+	p, err := New(Fresh, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet := addrs[2:]
+	calls := 0
+	p.NewAddress = func() (util.Address, error) {
+		if calls >= len(wallet) {
+			return nil, errors.New("wallet is locked")
+		}
+		calls++
+		return wallet[calls-1], nil
+	}
+	mining := addrs[:2]
+	if got := mustAddress(t, p, mining, fork.SHA256d, 1); got.String() != wallet[0].String() {
+		t.Fatalf("paid to %s instead of a new address", got)
+	}
+	if got := mustAddress(t, p, mining, fork.Scrypt, 1); got.String() != wallet[0].String() || calls != 1 {
+		t.Fatalf("another template of the block paid to %s after %d new addresses", got, calls)
+	}
+	if got := mustAddress(t, p, mining, fork.SHA256d, 2); got.String() != wallet[1].String() {
+		t.Fatalf("next block paid to %s instead of a new address", got)
+	}
+	if got := mustAddress(t, p, mining, fork.SHA256d, 3); got.String() != mining[0].String() {
+		t.Fatalf("paid to %s instead of a mining address without the wallet", got)
+	}
+}
+
+// TestPolicyErrors ensures that unknown policies and algorithms, and templates without addresses, are refused
+func TestPolicyErrors(t *testing.T) {
+	if _, err := New("everyone", nil); err == nil {
+		t.Fatal("unknown policy was accepted")
+	}
+	if _, err := ParseAlgoAddrs([]string{"md5=" + testAddrs(t, 1)[0].EncodeAddress()},
+		&netparams.MainNetParams); err == nil {
+		t.Fatal("unknown algorithm was accepted")
+	}
+	algoAddrs, err := ParseAlgoAddrs([]string{fork.Scrypt + "=" + testAddrs(t, 1)[0].EncodeAddress()},
+		&netparams.MainNetParams)
+	if err != nil || algoAddrs[fork.Scrypt] == nil {
+		t.Fatalf("algorithm address was not parsed: %v", err)
+	}
+	p, _ := New("", nil)
+	if _, err = p.Address(nil, fork.SHA256d, 1); err != ErrNoAddresses {
+		t.Fatalf("template without addresses got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+)
+
+// ErrStateReleased is returned when reading from a pinned state after it has been released.
+var ErrStateReleased = errors.New("pinned chain state has been released")
+
+// PinnedState is a read only view of the main chain as of the moment it was pinned. It is backed by a database
+// transaction, so blocks connected or disconnected afterwards are not visible through it, allowing a sequence of reads
+// to observe a consistent chain.
+//
+// A pinned state holds the database open and must be released when it is no longer needed. It is safe for concurrent
+// access.
+type PinnedState struct {
+	mtx   sync.RWMutex
+	dbTx  database.Tx
+	state *BestState
+}
+
+// PinState pins the current main chain state. The returned state must be released with Release.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) PinState() (*PinnedState, error) {
+	// The chain lock is held for reads so the best state and the database can not be updated between starting the
+	// transaction and taking the best state.
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	dbTx, err := b.db.Begin(false)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &PinnedState{dbTx: dbTx, state: b.BestSnapshot()}, nil
+}
+
+// Best returns the best block state at the time the state was pinned.
+func (p *PinnedState) Best() *BestState {
+	return p.state
+}
+
+// BlockHashByHeight returns the hash of the block at the given height in the pinned main chain.
+func (p *PinnedState) BlockHashByHeight(height int32) (hash *chainhash.Hash, err error) {
+	if height < 0 || height > p.state.Height {
+		str := fmt.Sprintf("BlockHashByHeight: no block at height %d exists", height)
+		return nil, errNotInMainChain(str)
+	}
+	err = p.view(func(dbTx database.Tx) (err error) {
+		hash, err = dbFetchHashByHeight(dbTx, height)
+		return
+	})
+	return
+}
+
+// FetchUtxoEntry loads the unspent transaction output for the outpoint as of the pinned main chain. As with
+// BlockChain.FetchUtxoEntry both the entry and the error are nil if there is no data for the output.
+func (p *PinnedState) FetchUtxoEntry(outpoint wire.OutPoint) (entry *UtxoEntry, err error) {
+	err = p.view(func(dbTx database.Tx) (err error) {
+		entry, err = dbFetchUtxoEntry(dbTx, outpoint)
+		return
+	})
+	return
+}
+
+// Release ends the database transaction backing the pinned state. Further reads return ErrStateReleased.
+func (p *PinnedState) Release() error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.dbTx == nil {
+		return nil
+	}
+	err := p.dbTx.Rollback()
+	p.dbTx = nil
+	return err
+}
+
+// view runs fn with the database transaction of the pinned state, unless it has been released.
+func (p *PinnedState) view(fn func(dbTx database.Tx) error) error {
+	p.mtx.RLock()
+	defer p.mtx.RUnlock()
+	if p.dbTx == nil {
+		return ErrStateReleased
+	}
+	return fn(p.dbTx)
+}
@@ -0,0 +1,47 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestPinnedState ensures a pinned state serves the chain it was pinned at and can not be read once released.
+func TestPinnedState(t *testing.T) {
+	chain, teardownFunc, err := chainSetup("pinnedstate", &netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardownFunc()
+	pinned, err := chain.PinState()
+	if err != nil {
+		t.Fatalf("PinState: %v", err)
+	}
+	if pinned.Best().Height != 0 {
+		t.Fatalf("Best: got height %d, want 0", pinned.Best().Height)
+	}
+	hash, err := pinned.BlockHashByHeight(0)
+	if err != nil {
+		t.Fatalf("BlockHashByHeight(0): %v", err)
+	}
+	if !hash.IsEqual(netparams.MainNetParams.GenesisHash) {
+		t.Fatalf("BlockHashByHeight(0): got %v, want %v", hash, netparams.MainNetParams.GenesisHash)
+	}
+	if _, err = pinned.BlockHashByHeight(1); !isNotInMainChainErr(err) {
+		t.Fatalf("BlockHashByHeight(1): got error %v, want not in main chain", err)
+	}
+	entry, err := pinned.FetchUtxoEntry(wire.OutPoint{Hash: *hash})
+	if err != nil || entry != nil {
+		t.Fatalf("FetchUtxoEntry: got %v, %v, want no entry", entry, err)
+	}
+	if err = pinned.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, err = pinned.BlockHashByHeight(0); err != ErrStateReleased {
+		t.Fatalf("BlockHashByHeight after release: got error %v, want %v", err, ErrStateReleased)
+	}
+	if err = pinned.Release(); err != nil {
+		t.Fatalf("second Release: %v", err)
+	}
+}
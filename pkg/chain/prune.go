@@ -0,0 +1,83 @@
+package blockchain
+
+import (
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	database "github.com/p9c/pod/pkg/db"
+)
+
+// maybePrune removes the oldest blocks from the database when pruning is enabled and the stored blocks take up more
+// than the prune target. Blocks after the latest checkpoint and within the prune depth of the tip are always kept, so
+// reorganizations up to the prune depth can still be handled. The spend journal entries of the removed blocks are
+// removed along with them as they can no longer be disconnected.
+//
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) maybePrune() {
+	if b.pruneTarget == 0 {
+		return
+	}
+	keepHeight := b.BestChain.Tip().height - b.pruneDepth
+	if checkpoint := b.LatestCheckpoint(); checkpoint != nil && checkpoint.Height < keepHeight {
+		keepHeight = checkpoint.Height
+	}
+	if keepHeight <= b.pruneHeight.Load()+1 {
+		return
+	}
+	keep := b.BestChain.NodeByHeight(keepHeight)
+	if keep == nil {
+		return
+	}
+	pruned, err := b.db.Prune(b.pruneTarget, &keep.hash)
+	if err != nil {
+		Error("failed to prune block database:", err)
+		return
+	}
+	if len(pruned) == 0 {
+		return
+	}
+	pruneHeight := b.pruneHeight.Load()
+	for i := range pruned {
+		if node := b.Index.LookupNode(&pruned[i]); node != nil && node.height > pruneHeight {
+			pruneHeight = node.height
+		}
+	}
+	err = b.db.Update(func(dbTx database.Tx) error {
+		for i := range pruned {
+			if err := dbRemoveSpendJournalEntry(dbTx, &pruned[i]); err != nil {
+				return err
+			}
+		}
+		return dbPutPruneHeight(dbTx, pruneHeight)
+	})
+	if err != nil {
+		Error("failed to remove spend journal entries of pruned blocks:", err)
+		return
+	}
+	b.pruneHeight.Store(pruneHeight)
+	Infof("pruned %d blocks up to height %d", len(pruned), pruneHeight)
+}
+
+// IsPruned returns whether old blocks are removed from the database to keep it within the prune target, or are missing
+// because the chain was bootstrapped from a UTXO set snapshot.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsPruned() bool {
+	return b.pruneTarget > 0 || b.pruneHeight.Load() >= 0
+}
+
+// PruneHeight returns the height of the highest block that has been removed from the database by pruning, or -1 when
+// no blocks have been removed.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) PruneHeight() int32 {
+	return b.pruneHeight.Load()
+}
+
+// IsBlockPruned returns whether the block with the given hash has been removed from the database by pruning, or was
+// never stored because it is below the snapshot the chain was bootstrapped from. Blocks that are not known are not
+// reported as pruned.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) IsBlockPruned(hash *chainhash.Hash) bool {
+	node := b.Index.LookupNode(hash)
+	return node != nil && node.height <= b.pruneHeight.Load()
+}
@@ -29,6 +29,7 @@ type txValidator struct {
 	flags        txscript.ScriptFlags
 	sigCache     *txscript.SigCache
 	hashCache    *txscript.HashCache
+	workers      int
 }
 
 // sendResult sends the result of a script pair validation on the internal result channel while respecting the quit
@@ -105,27 +106,18 @@ out:
 	}
 }
 
-// Validate validates the scripts for all of the passed transaction inputs using multiple goroutines.
+// Validate validates the scripts for all of the passed transaction inputs using the worker goroutines of the validator.
 func (v *txValidator) Validate(items []*txValidateItem) error {
 	if len(items) == 0 {
 		return nil
 	}
-	// Limit the number of goroutines to do script validation based on the number of processor cores.
-	//
-	// This helps ensure the system stays reasonably responsive under heavy load.
-	maxGoRoutines := runtime.NumCPU() * 3
-	if maxGoRoutines <= 0 {
-		maxGoRoutines = 1
-	}
-	if maxGoRoutines > len(items)*3 {
-		maxGoRoutines = len(items) * 3
+	// Start up validation handlers that are used to asynchronously validate each transaction input. There is no point
+	// in having more of them than there are inputs.
+	workers := v.workers
+	if workers > len(items) {
+		workers = len(items)
 	}
-	// maxGoRoutines = 1
-	//
-	// Start up validation handlers that are used to asynchronously validate each transaction input.
-	//
-	// TODO: this creates an insane amount of goroutines that run for tens of milliseconds each and... well... parallelize...
-	for i := 0; i < maxGoRoutines; i++ {
+	for i := 0; i < workers; i++ {
 		go v.validateHandler()
 	}
 	// Validate each of the inputs.
@@ -160,9 +152,19 @@ func (v *txValidator) Validate(items []*txValidateItem) error {
 	return nil
 }
 
-// newTxValidator returns a new instance of txValidator to be used for validating transaction scripts asynchronously.
+// scriptWorkerCount returns the number of goroutines validating scripts for the passed configured number, which is one
+// per CPU core when it is not positive. Script validation is CPU bound, so more than that only adds contention.
+func scriptWorkerCount(workers int) int {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	return workers
+}
+
+// newTxValidator returns a new instance of txValidator to be used for validating transaction scripts asynchronously
+// with the passed number of worker goroutines, or one per CPU core when it is not positive.
 func newTxValidator(utxoView *UtxoViewpoint, flags txscript.ScriptFlags,
-	sigCache *txscript.SigCache, hashCache *txscript.HashCache) *txValidator {
+	sigCache *txscript.SigCache, hashCache *txscript.HashCache, workers int) *txValidator {
 	return &txValidator{
 		validateChan: make(chan *txValidateItem),
 		quitChan:     make(chan struct{}),
@@ -171,6 +173,7 @@ func newTxValidator(utxoView *UtxoViewpoint, flags txscript.ScriptFlags,
 		sigCache:     sigCache,
 		hashCache:    hashCache,
 		flags:        flags,
+		workers:      scriptWorkerCount(workers),
 	}
 }
 
@@ -212,16 +215,21 @@ func ValidateTransactionScripts(b *BlockChain, tx *util.Tx, utxoView *UtxoViewpo
 		}
 		txValItems = append(txValItems, txVI)
 	}
-	// Validate all of the inputs.
-	validator := newTxValidator(utxoView, flags, sigCache, hashCache)
+	// Validate all of the inputs. The mempool validates without a chain, with the default number of workers.
+	workers := scriptWorkerCount(0)
+	if b != nil {
+		workers = b.scriptWorkers
+	}
+	validator := newTxValidator(utxoView, flags, sigCache, hashCache, workers)
 	return validator.Validate(txValItems)
 }
 
-// checkBlockScripts executes and validates the scripts for all transactions in the passed block using multiple
-// goroutines.
+// checkBlockScripts executes and validates the scripts for all transactions in the passed block using the passed
+// number of worker goroutines, or one per CPU core when it is not positive. The inputs of all of the transactions are
+// shared between the workers, so a block with a few large transactions keeps them as busy as one with many small ones.
 func checkBlockScripts(block *util.Block, utxoView *UtxoViewpoint,
 	scriptFlags txscript.ScriptFlags, sigCache *txscript.SigCache,
-	hashCache *txscript.HashCache) error {
+	hashCache *txscript.HashCache, workers int) error {
 	// First determine if segwit is active according to the scriptFlags. If it isn't then we don't need to interact with
 	// the HashCache.
 	segwitActive := scriptFlags&txscript.ScriptVerifyWitness == txscript.ScriptVerifyWitness
@@ -264,7 +272,7 @@ func checkBlockScripts(block *util.Block, utxoView *UtxoViewpoint,
 		}
 	}
 	// Validate all of the inputs.
-	validator := newTxValidator(utxoView, scriptFlags, sigCache, hashCache)
+	validator := newTxValidator(utxoView, scriptFlags, sigCache, hashCache, workers)
 	// start := time.Now()
 	if err := validator.Validate(txValItems); err != nil {
 		return err
@@ -2,10 +2,13 @@ package blockchain
 
 import (
 	"fmt"
+	"math"
 	"runtime"
 	"testing"
 
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
 )
 
 // TestCheckBlockScripts ensures that validating the all of the scripts in a known-good block doesn't return an error.
@@ -33,9 +36,39 @@ func TestCheckBlockScripts(t *testing.T) {
 		return
 	}
 	scriptFlags := txscript.ScriptBip16
-	err = checkBlockScripts(blocks[0], view, scriptFlags, nil, nil)
-	if err != nil {
-		t.Errorf("Transaction script validation failed: %v\n", err)
-		return
+	// The result must not depend on how many workers share the inputs.
+	for _, workers := range []int{1, 0, runtime.NumCPU() * 2} {
+		err = checkBlockScripts(blocks[0], view, scriptFlags, nil, nil, workers)
+		if err != nil {
+			t.Errorf("Transaction script validation failed with %d workers: %v\n", workers, err)
+			return
+		}
+	}
+}
+
+// TestValidateTransactionScriptsWithoutChain ensures transactions are validated without a chain, as the mempool validates
+// them, using the default number of workers.
+func TestValidateTransactionScriptsWithoutChain(t *testing.T) {
+	prev := wire.NewMsgTx(1)
+	prev.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: math.MaxUint32}, nil, nil))
+	prev.AddTxOut(wire.NewTxOut(1e8, []byte{txscript.OP_TRUE}))
+	prev.AddTxOut(wire.NewTxOut(1e8, []byte{txscript.OP_FALSE}))
+	view := NewUtxoViewpoint()
+	view.AddTxOuts(util.NewTx(prev), 1)
+	tests := []struct {
+		vout  uint32
+		valid bool
+	}{
+		{0, true},
+		{1, false},
+	}
+	for _, test := range tests {
+		tx := wire.NewMsgTx(1)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: prev.TxHash(), Index: test.vout}, nil, nil))
+		tx.AddTxOut(wire.NewTxOut(1e7, []byte{txscript.OP_TRUE}))
+		err := ValidateTransactionScripts(nil, util.NewTx(tx), view, txscript.ScriptBip16, nil, nil)
+		if (err == nil) != test.valid {
+			t.Errorf("spending output %d: got error %v, want valid %v", test.vout, err, test.valid)
+		}
 	}
 }
@@ -7,16 +7,20 @@ import (
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	"github.com/p9c/pod/pkg/util"
 )
 
 // PeerNotifier exposes methods to notify peers of status changes to transactions, blocks, etc. Currently server (in the
-// main package) implements this interface.
+// main package) implements this interface. PeerMisbehaved increases the ban score of the peer for the offense and
+// disconnects it.
 type PeerNotifier interface {
 	AnnounceNewTransactions(newTxs []*mempool.TxDesc)
 	UpdatePeerHeights(latestBlkHash *chainhash.Hash, latestHeight int32, updateSource *peer.Peer)
 	RelayInventory(invVect *wire.InvVect, data interface{})
 	TransactionConfirmed(tx *util.Tx)
+	TransactionProcessed(tx *util.Tx, source *peer.Peer, err error)
+	PeerMisbehaved(p *peer.Peer, offense connmgr.Offense)
 }
 
 // Config is a configuration struct used to initialize a new SyncManager.
@@ -15,6 +15,7 @@ import (
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
 	peerpkg "github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/util"
 )
@@ -48,6 +49,8 @@ type (
 		nextCheckpoint   *chaincfg.Checkpoint
 		// An optional fee estimator.
 		feeEstimator *mempool.FeeEstimator
+		// downloads measures the download rate of blocks for the sync status.
+		downloads downloadMeter
 	}
 	// blockMsg packages a bitcoin block message and the peer it came from together so the block handler has access to
 	// that information.
@@ -287,6 +290,8 @@ out:
 					peerID = sm.syncPeer.ID()
 				}
 				msg.reply <- peerID
+			case getSyncStatusMsg:
+				msg.reply <- sm.syncStatus()
 			case processBlockMsg:
 				var heightUpdate int32
 				header := &msg.block.MsgBlock().Header
@@ -434,6 +439,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 		)
 		return
 	}
+	sm.downloads.add(time.Now(), bmsg.block.MsgBlock().SerializeSize())
 	// If we didn't ask for this block then the peer is misbehaving.
 	blockHash := bmsg.block.Hash()
 	if _, exists = state.requestedBlocks[*blockHash]; !exists {
@@ -448,7 +454,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 					pp.Addr(),
 				)
 			})
-			pp.Disconnect()
+			sm.peerNotifier.PeerMisbehaved(pp, connmgr.OffenseUnrequestedBlock)
 			return
 		}
 	}
@@ -546,7 +552,7 @@ func (sm *SyncManager) handleBlockMsg(workerNumber uint32, bmsg *blockMsg) {
 			Errorf("failed to get block locator for the latest block: %v",
 				err)
 		} else {
-			err := pp.PushGetBlocksMsg(locator, orphanRoot)
+			err := sm.historyPeer(pp).PushGetBlocksMsg(locator, orphanRoot)
 			if err != nil {
 				Error(err)
 			}
@@ -752,7 +758,7 @@ func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 			"got %d unrequested headers from %s -- disconnecting",
 			numHeaders, peer,
 		)
-		peer.Disconnect()
+		sm.peerNotifier.PeerMisbehaved(peer, connmgr.OffenseUnrequestedHeaders)
 		return
 	}
 	// Nothing to do for an empty headers message.
@@ -810,7 +816,7 @@ func (sm *SyncManager) handleHeadersMsg(hmsg *headersMsg) {
 					peer,
 					sm.nextCheckpoint.Hash,
 				)
-				peer.Disconnect()
+				sm.peerNotifier.PeerMisbehaved(peer, connmgr.OffenseCheckpointMismatch)
 				return
 			}
 			break
@@ -931,7 +937,7 @@ func (sm *SyncManager) handleInvMsg(imsg *invMsg) {
 					Error("failed to get block locator for the latest block:", err)
 					continue
 				}
-				err = peer.PushGetBlocksMsg(locator, orphanRoot)
+				err = sm.historyPeer(peer).PushGetBlocksMsg(locator, orphanRoot)
 				if err != nil {
 					Error(err)
 				}
@@ -1061,6 +1067,7 @@ func (sm *SyncManager) handleTxMsg(tmsg *txMsg) {
 	// inv.
 	delete(state.requestedTxns, *txHash)
 	delete(sm.requestedTxns, *txHash)
+	sm.peerNotifier.TransactionProcessed(tmsg.tx, peer, err)
 	if err != nil {
 		Error(err)
 		// Do not request this transaction again until a new block has been processed.
@@ -1146,15 +1153,15 @@ func (sm *SyncManager) isSyncCandidate(peer *peerpkg.Peer) bool {
 			return false
 		}
 	} else {
-		// The peer is not a candidate for sync if it's not a full node. Additionally, if the segwit soft-fork package
-		// has activated, then the peer must also be upgraded.
+		// The peer is not a candidate for sync if it's not a full node or a pruned node serving the recent blocks.
+		// Additionally, if the segwit soft-fork package has activated, then the peer must also be upgraded.
 		segwitActive, err := sm.chain.IsDeploymentActive(chaincfg.DeploymentSegwit)
 		if err != nil {
 			Error(err)
 			Error("unable to query for segwit soft-fork state:", err)
 		}
 		nodeServices := peer.Services()
-		if nodeServices&wire.SFNodeNetwork != wire.SFNodeNetwork ||
+		if nodeServices&(wire.SFNodeNetwork|wire.SFNodeNetworkLimited) == 0 ||
 			(segwitActive && !peer.IsWitnessEnabled()) {
 			return false
 		}
@@ -1207,7 +1214,7 @@ func (sm *SyncManager) startSync() {
 		return
 	}
 	best := sm.chain.BestSnapshot()
-	var bestPeer *peerpkg.Peer
+	var bestPeer, limitedPeer *peerpkg.Peer
 	for peer, state := range sm.peerStates {
 		if !state.syncCandidate {
 			continue
@@ -1225,9 +1232,20 @@ func (sm *SyncManager) startSync() {
 			state.syncCandidate = false
 			continue
 		}
+		// Full nodes are preferred, as a pruned peer only serves the blocks near its tip. It is used when no full node
+		// is available and the missing blocks are all within its window.
+		if !sm.isArchivePeer(peer) {
+			if peer.LastBlock()-best.Height <= wire.NodeNetworkLimitedBlocks {
+				limitedPeer = peer
+			}
+			continue
+		}
 		// TODO(davec): Use a better algorithm to choose the best peer. For now, just pick the first available candidate.
 		bestPeer = peer
 	}
+	if bestPeer == nil {
+		bestPeer = limitedPeer
+	}
 	// Start syncing from the best peer if one was selected.
 	if bestPeer != nil {
 		// Clear the requestedBlocks if the sync peer changes, otherwise we may ignore blocks we need that the last sync
@@ -1277,6 +1295,32 @@ func (sm *SyncManager) startSync() {
 	}
 }
 
+// isArchivePeer returns whether the peer serves the whole history of the chain, rather than only the recent blocks
+// retained by a pruned node. Peers in regression test mode are treated as full nodes like in isSyncCandidate.
+func (sm *SyncManager) isArchivePeer(peer *peerpkg.Peer) bool {
+	return sm.chainParams == &netparams.RegressionTestParams ||
+		peer.Services()&wire.SFNodeNetwork == wire.SFNodeNetwork
+}
+
+// historyPeer returns the peer to request the blocks missing below those announced by the given peer from. A pruned
+// peer is only asked when the missing blocks are within the window it serves, otherwise the sync peer or another full
+// node is asked instead, falling back to the given peer when there is none.
+func (sm *SyncManager) historyPeer(peer *peerpkg.Peer) *peerpkg.Peer {
+	if sm.isArchivePeer(peer) ||
+		peer.LastBlock()-sm.chain.BestSnapshot().Height <= wire.NodeNetworkLimitedBlocks {
+		return peer
+	}
+	if sm.syncPeer != nil && sm.isArchivePeer(sm.syncPeer) {
+		return sm.syncPeer
+	}
+	for p := range sm.peerStates {
+		if sm.isArchivePeer(p) {
+			return p
+		}
+	}
+	return peer
+}
+
 // New constructs a new SyncManager. Use Start to begin processing asynchronous block, tx, and inv updates.
 func New(config *Config) (*SyncManager, error) {
 	sm := SyncManager{
@@ -0,0 +1,142 @@
+package netsync
+
+import (
+	"time"
+)
+
+// The stages of the sync of the chain reported by SyncStatus
+const (
+	// SyncStageConnecting is when there is no peer to sync from yet
+	SyncStageConnecting = "connecting"
+	// SyncStageHeaders is when the headers up to the next checkpoint are being downloaded
+	SyncStageHeaders = "headers"
+	// SyncStageBlocks is when blocks are being downloaded and connected
+	SyncStageBlocks = "blocks"
+	// SyncStageSynced is when the chain is in sync with the connected peers
+	SyncStageSynced = "synced"
+	// SyncStageIndexes is the catching up of the optional indexes with the chain. It is done when the chain is opened,
+	// so it is never the current stage of the sync manager.
+	SyncStageIndexes = "indexes"
+)
+
+// downloadRateWindow is how far back downloaded blocks are counted to give the download rate
+const downloadRateWindow = time.Minute
+
+// SyncStatus describes how far the sync of the chain with the connected peers has progressed
+type SyncStatus struct {
+	Stage string
+	// SyncPeer is the ID of the peer the chain is synced from, 0 if there is none
+	SyncPeer int32
+	// PeerHeight is the height of the best block announced by the sync peer
+	PeerHeight int32
+	// HeaderHeight is the height of the last downloaded header and HeaderTarget is the height headers are downloaded
+	// to. Outside of headers-first mode headers arrive with their blocks, so these are the block and peer heights.
+	HeaderHeight int32
+	HeaderTarget int32
+	// BlockHeight is the height of the best block of the chain
+	BlockHeight int32
+	// BlocksPerSecond and BytesPerSecond are the download rate of blocks over the last minute
+	BlocksPerSecond float64
+	BytesPerSecond  float64
+	// ETA is the estimated time until the chain reaches the height of the sync peer, 0 when unknown or in sync
+	ETA time.Duration
+}
+
+// getSyncStatusMsg is a message type to be sent across the message channel for retrieving the sync status.
+type getSyncStatusMsg struct {
+	reply chan *SyncStatus
+}
+
+// downloadSample is a block received from a peer
+type downloadSample struct {
+	at   time.Time
+	size int
+}
+
+// downloadMeter measures the rate blocks are downloaded at. It is only accessed from the blockHandler goroutine.
+type downloadMeter struct {
+	samples []downloadSample
+}
+
+// add records a block of the given serialized size received at the given time
+func (d *downloadMeter) add(at time.Time, size int) {
+	d.prune(at)
+	d.samples = append(d.samples, downloadSample{at: at, size: size})
+}
+
+// prune forgets the blocks received before the rate window
+func (d *downloadMeter) prune(now time.Time) {
+	var i int
+	for i < len(d.samples) && now.Sub(d.samples[i].at) > downloadRateWindow {
+		i++
+	}
+	d.samples = d.samples[i:]
+}
+
+// rates returns the number of blocks and bytes downloaded per second over the rate window
+func (d *downloadMeter) rates(now time.Time) (blocks, bytes float64) {
+	d.prune(now)
+	if len(d.samples) == 0 {
+		return
+	}
+	// Measuring from the first block in the window keeps the rate from being diluted by the time before the download
+	// started, though never over less than a second, so a single block does not give a huge rate.
+	elapsed := now.Sub(d.samples[0].at).Seconds()
+	if elapsed < 1 {
+		elapsed = 1
+	}
+	var size int
+	for i := range d.samples {
+		size += d.samples[i].size
+	}
+	return float64(len(d.samples)) / elapsed, float64(size) / elapsed
+}
+
+// SyncStatus returns the stage and progress of the sync of the chain.
+func (sm *SyncManager) SyncStatus() *SyncStatus {
+	reply := make(chan *SyncStatus)
+	sm.msgChan <- getSyncStatusMsg{reply: reply}
+	return <-reply
+}
+
+// syncStatus works out the sync status. It must only be called from the blockHandler goroutine.
+func (sm *SyncManager) syncStatus() *SyncStatus {
+	best := sm.chain.BestSnapshot()
+	s := &SyncStatus{
+		BlockHeight:  best.Height,
+		HeaderHeight: best.Height,
+		HeaderTarget: best.Height,
+		PeerHeight:   best.Height,
+	}
+	now := time.Now()
+	s.BlocksPerSecond, s.BytesPerSecond = sm.downloads.rates(now)
+	if sm.syncPeer != nil {
+		s.SyncPeer = sm.syncPeer.ID()
+		if peerHeight := sm.syncPeer.LastBlock(); peerHeight > s.PeerHeight {
+			s.PeerHeight = peerHeight
+		}
+		s.HeaderTarget = s.PeerHeight
+	}
+	switch {
+	case sm.current():
+		s.Stage = SyncStageSynced
+		return s
+	case sm.syncPeer == nil:
+		s.Stage = SyncStageConnecting
+		return s
+	}
+	s.Stage = SyncStageBlocks
+	if sm.headersFirstMode && sm.nextCheckpoint != nil {
+		s.HeaderTarget = sm.nextCheckpoint.Height
+		if back := sm.headerList.Back(); back != nil {
+			s.HeaderHeight = back.Value.(*headerNode).height
+		}
+		if s.HeaderHeight < s.HeaderTarget {
+			s.Stage = SyncStageHeaders
+		}
+	}
+	if remaining := s.PeerHeight - s.BlockHeight; remaining > 0 && s.BlocksPerSecond > 0 {
+		s.ETA = time.Duration(float64(remaining) / s.BlocksPerSecond * float64(time.Second))
+	}
+	return s
+}
@@ -36,9 +36,18 @@ type (
 		PrevInputValues []util.Amount
 		TotalInput      util.Amount
 		ChangeIndex     int // negative if no change
+		// EstimatedSize is the estimated virtual size of the signed transaction the fee was calculated for.
+		EstimatedSize int
+		// AncestorFees and AncestorSize are the total fee and virtual size of the unconfirmed transactions the inputs
+		// spend from, which are mined together with the transaction as a package.
+		AncestorFees util.Amount
+		AncestorSize int
 	}
 	// ChangeSource provides P2PKH change output scripts for transaction creation.
 	ChangeSource func() ([]byte, error)
+	// AncestorSource reports the total fee and virtual size of the unconfirmed transactions that the passed inputs
+	// spend outputs of, including their own unconfirmed ancestors.
+	AncestorSource func(inputs []*wire.TxIn) (fees util.Amount, size int, err error)
 	// SecretsSource provides private keys and redeem scripts necessary for constructing transaction input signatures.
 	// Secrets are looked up by the corresponding Address for the previous output script. Addresses for lookup are
 	// created using the source's blockchain parameters and means a single SecretsSource can only manage secrets for a
@@ -79,6 +88,15 @@ func (insufficientFundsError) Error() string {
 // BUGS: Fee estimation may be off when redeeming non-compressed P2PKH outputs.
 func NewUnsignedTransaction(outputs []*wire.TxOut, relayFeePerKb util.Amount,
 	fetchInputs InputSource, fetchChange ChangeSource) (*AuthoredTx, error) {
+	return NewUnsignedPackageTransaction(outputs, relayFeePerKb, fetchInputs, fetchChange, nil)
+}
+
+// NewUnsignedPackageTransaction creates an unsigned transaction in the same way as NewUnsignedTransaction, except that
+// when fetchAncestors is not nil the fee is raised as needed for the transaction and the unconfirmed ancestors of its
+// inputs to pay relayFeePerKb as a package. This lets a spend of unconfirmed change pay for a parent that was sent
+// with too low a fee (child pays for parent).
+func NewUnsignedPackageTransaction(outputs []*wire.TxOut, relayFeePerKb util.Amount,
+	fetchInputs InputSource, fetchChange ChangeSource, fetchAncestors AncestorSource) (*AuthoredTx, error) {
 	targetAmount := h.SumOutputValues(outputs)
 	estimatedSize := txsizes.EstimateVirtualSize(0, 1, 0, outputs, true)
 	targetFee := txrules.FeeForSerializeSize(relayFeePerKb, estimatedSize)
@@ -107,6 +125,20 @@ func NewUnsignedTransaction(outputs []*wire.TxOut, relayFeePerKb util.Amount,
 		maxSignedSize := txsizes.EstimateVirtualSize(p2pkh, p2wpkh,
 			nested, outputs, true)
 		maxRequiredFee := txrules.FeeForSerializeSize(relayFeePerKb, maxSignedSize)
+		var ancestorFees util.Amount
+		var ancestorSize int
+		if fetchAncestors != nil {
+			if ancestorFees, ancestorSize, err = fetchAncestors(inputs); err != nil {
+				Error(err)
+				return nil, err
+			}
+			// The ancestors are mined together with this transaction, so any fee they are short of reaching the fee rate
+			// on their own is added to this transaction's fee.
+			packageFee := txrules.FeeForSerializeSize(relayFeePerKb, maxSignedSize+ancestorSize) - ancestorFees
+			if packageFee > maxRequiredFee {
+				maxRequiredFee = packageFee
+			}
+		}
 		remainingAmount := inputAmount - targetAmount
 		if remainingAmount < maxRequiredFee {
 			targetFee = maxRequiredFee
@@ -142,6 +174,9 @@ func NewUnsignedTransaction(outputs []*wire.TxOut, relayFeePerKb util.Amount,
 			PrevInputValues: inputValues,
 			TotalInput:      inputAmount,
 			ChangeIndex:     changeIndex,
+			EstimatedSize:   maxSignedSize,
+			AncestorFees:    ancestorFees,
+			AncestorSize:    ancestorSize,
 		}, nil
 	}
 }
@@ -160,6 +195,21 @@ func (tx *AuthoredTx) RandomizeChangePosition() {
 	tx.ChangeIndex = RandomizeOutputPosition(tx.Tx.TxOut, tx.ChangeIndex)
 }
 
+// Fee returns the fee paid by the authored transaction.
+func (tx *AuthoredTx) Fee() util.Amount {
+	return tx.TotalInput - h.SumOutputValues(tx.Tx.TxOut)
+}
+
+// PackageFeeRate returns the fee per kilobyte paid by the authored transaction together with its unconfirmed
+// ancestors, which is the fee rate miners see when deciding whether to include them.
+func (tx *AuthoredTx) PackageFeeRate() util.Amount {
+	size := tx.EstimatedSize + tx.AncestorSize
+	if size == 0 {
+		return 0
+	}
+	return (tx.Fee() + tx.AncestorFees) * 1000 / util.Amount(size)
+}
+
 // AddAllInputScripts modifies transaction a transaction by adding inputs scripts for each input. Previous output
 // scripts being redeemed by each input are passed in prevPkScripts and the slice length must match the number of
 // inputs. Private keys and redeem scripts are looked up using a SecretsSource based on the previous output script.
@@ -202,3 +202,44 @@ func TestNewUnsignedTransaction(t *testing.T) {
 		}
 	}
 }
+
+func TestNewUnsignedPackageTransaction(t *testing.T) {
+	const relayFee = 1e3
+	outputs := p2pkhOutputs(1e6)
+	childSize := txsizes.EstimateVirtualSize(1, 0, 0, outputs, true)
+	tests := []struct {
+		name         string
+		ancestorFees util.Amount
+		ancestorSize int
+		fee          util.Amount
+	}{
+		{"no ancestors", 0, 0, txrules.FeeForSerializeSize(relayFee, childSize)},
+		{"ancestor paying enough", 1e3, 200, txrules.FeeForSerializeSize(relayFee, childSize)},
+		{"ancestor paying nothing", 0, 200, txrules.FeeForSerializeSize(relayFee, childSize+200)},
+		{"ancestor paying half", 100, 200, txrules.FeeForSerializeSize(relayFee, childSize+200) - 100},
+	}
+	changeSource := func() ([]byte, error) {
+		return make([]byte, txsizes.P2WPKHPkScriptSize), nil
+	}
+	for _, test := range tests {
+		ancestorSource := func([]*wire.TxIn) (util.Amount, int, error) {
+			return test.ancestorFees, test.ancestorSize, nil
+		}
+		tx, err := NewUnsignedPackageTransaction(outputs, relayFee, makeInputSource(p2pkhOutputs(1e8)),
+			changeSource, ancestorSource)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if tx.Fee() != test.fee {
+			t.Errorf("%s: got fee %v, expected %v", test.name, tx.Fee(), test.fee)
+		}
+		if tx.AncestorFees != test.ancestorFees || tx.AncestorSize != test.ancestorSize {
+			t.Errorf("%s: got ancestors %v/%d, expected %v/%d", test.name, tx.AncestorFees, tx.AncestorSize,
+				test.ancestorFees, test.ancestorSize)
+		}
+		if tx.PackageFeeRate() < relayFee*999/1000 {
+			t.Errorf("%s: package fee rate %v is below the relay fee", test.name, tx.PackageFeeRate())
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package wtxmgr
+
+import (
+	"fmt"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// ConflictedTx is an unmined transaction that was removed from the store because a competing spend of one of its
+// inputs was mined. The details are those the transaction had at the time it was removed.
+type ConflictedTx struct {
+	TxDetails
+	// ConflictedBy is the hash of the mined transaction that double spent this one, or of the transaction that double
+	// spent the unmined transaction this one depended on
+	ConflictedBy chainhash.Hash
+	// Detected is the time the conflict was recorded
+	Detected time.Time
+}
+
+// Conflicted transactions are saved in the conflicted bucket keyed by the transaction hash. The value is:
+//
+//   [0:32]  Hash of the conflicting transaction (32 bytes)
+//   [32:40] Detection time (8 bytes)
+//   [40:44] Number of credits, N (4 bytes)
+//   N times:
+//     [0:4]   Output index (4 bytes)
+//     [4:12]  Amount (8 bytes)
+//     [12]    Flags (1 byte)
+//               0x02: Change
+//   [0:4]   Number of debits, M (4 bytes)
+//   M times:
+//     [0:4]   Input index (4 bytes)
+//     [4:12]  Amount (8 bytes)
+//   [0:]    Unmined transaction record value (varies)
+func valueConflicted(details *TxDetails, by *chainhash.Hash, detected time.Time) ([]byte, error) {
+	rec, err := valueTxRecord(&details.TxRecord)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	v := make([]byte, 48+13*len(details.Credits)+12*len(details.Debits)+len(rec))
+	copy(v, by[:])
+	byteOrder.PutUint64(v[32:40], uint64(detected.Unix()))
+	byteOrder.PutUint32(v[40:44], uint32(len(details.Credits)))
+	off := 44
+	for _, c := range details.Credits {
+		byteOrder.PutUint32(v[off:off+4], c.Index)
+		byteOrder.PutUint64(v[off+4:off+12], uint64(c.Amount))
+		if c.Change {
+			v[off+12] = 1 << 1
+		}
+		off += 13
+	}
+	byteOrder.PutUint32(v[off:off+4], uint32(len(details.Debits)))
+	off += 4
+	for _, d := range details.Debits {
+		byteOrder.PutUint32(v[off:off+4], d.Index)
+		byteOrder.PutUint64(v[off+4:off+12], uint64(d.Amount))
+		off += 12
+	}
+	copy(v[off:], rec)
+	return v, nil
+}
+
+func readRawConflicted(txHash *chainhash.Hash, v []byte, c *ConflictedTx) error {
+	short := func() error {
+		str := fmt.Sprintf("%s: short read for conflicted transaction %v", bucketConflicted, txHash)
+		return storeError(ErrData, str, nil)
+	}
+	if len(v) < 44 {
+		return short()
+	}
+	copy(c.ConflictedBy[:], v[:32])
+	c.Detected = time.Unix(int64(byteOrder.Uint64(v[32:40])), 0)
+	c.Block = BlockMeta{Block: Block{Height: -1}}
+	n := int(byteOrder.Uint32(v[40:44]))
+	off := 44
+	if len(v) < off+13*n+4 {
+		return short()
+	}
+	for i := 0; i < n; i++ {
+		c.Credits = append(c.Credits, CreditRecord{
+			Index:  byteOrder.Uint32(v[off : off+4]),
+			Amount: util.Amount(byteOrder.Uint64(v[off+4 : off+12])),
+			Change: v[off+12]&(1<<1) != 0,
+		})
+		off += 13
+	}
+	m := int(byteOrder.Uint32(v[off : off+4]))
+	off += 4
+	if len(v) < off+12*m {
+		return short()
+	}
+	for i := 0; i < m; i++ {
+		c.Debits = append(c.Debits, DebitRecord{
+			Index:  byteOrder.Uint32(v[off : off+4]),
+			Amount: util.Amount(byteOrder.Uint64(v[off+4 : off+12])),
+		})
+		off += 12
+	}
+	return readRawTxRecord(txHash, v[off:], &c.TxRecord)
+}
+
+func putRawConflicted(ns walletdb.ReadWriteBucket, k, v []byte) error {
+	// The bucket is created on demand since stores created before conflicts were recorded do not have it.
+	b, err := ns.CreateBucketIfNotExists(bucketConflicted)
+	if err != nil {
+		Error(err)
+		str := "failed to create conflicted bucket"
+		return storeError(ErrDatabase, str, err)
+	}
+	if err = b.Put(k, v); err != nil {
+		Error(err)
+		str := "failed to put conflicted record"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func deleteRawConflicted(ns walletdb.ReadWriteBucket, k []byte) error {
+	b := ns.NestedReadWriteBucket(bucketConflicted)
+	if b == nil {
+		return nil
+	}
+	if err := b.Delete(k); err != nil {
+		Error(err)
+		str := "failed to delete conflicted record"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// recordConflict saves the details of the unmined transaction rec, and of every unmined transaction spending its
+// outputs, as conflicted by the transaction with hash by. It must be called before the transactions are removed from the
+// unmined bucket.
+func (s *Store) recordConflict(ns walletdb.ReadWriteBucket, rec *TxRecord, by *chainhash.Hash) error {
+	v := existsRawUnmined(ns, rec.Hash[:])
+	if v == nil {
+		return nil
+	}
+	// A transaction spending several outputs of a conflicted transaction is reached more than once.
+	if b := ns.NestedReadBucket(bucketConflicted); b != nil && b.Get(rec.Hash[:]) != nil {
+		return nil
+	}
+	details, err := s.unminedTxDetails(ns, &rec.Hash, v)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	if v, err = valueConflicted(details, by, time.Now()); err != nil {
+		return err
+	}
+	if err = putRawConflicted(ns, rec.Hash[:], v); err != nil {
+		return err
+	}
+	Infof("transaction %v conflicted by mined transaction %v", &rec.Hash, by)
+	if s.NotifyConflict != nil {
+		s.NotifyConflict(&rec.Hash, by)
+	}
+	for i := range rec.MsgTx.TxOut {
+		k := canonicalOutPoint(&rec.Hash, uint32(i))
+		for _, spenderHash := range fetchUnminedInputSpendTxHashes(ns, k) {
+			spenderVal := existsRawUnmined(ns, spenderHash[:])
+			if spenderVal == nil {
+				continue
+			}
+			var spender TxRecord
+			if err = readRawTxRecord(&spenderHash, spenderVal, &spender); err != nil {
+				Error(err)
+				return err
+			}
+			if err = s.recordConflict(ns, &spender, by); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// recordDoubleSpends records every unmined transaction double spending an input of the mined transaction rec, and the
+// unmined transactions depending on them, as conflicted by rec.
+func (s *Store) recordDoubleSpends(ns walletdb.ReadWriteBucket, rec *TxRecord) error {
+	for _, input := range rec.MsgTx.TxIn {
+		prevOut := &input.PreviousOutPoint
+		prevOutKey := canonicalOutPoint(&prevOut.Hash, prevOut.Index)
+		for _, doubleSpendHash := range fetchUnminedInputSpendTxHashes(ns, prevOutKey) {
+			// The mined transaction itself may still be recorded as unmined.
+			if doubleSpendHash == rec.Hash {
+				continue
+			}
+			doubleSpendVal := existsRawUnmined(ns, doubleSpendHash[:])
+			if doubleSpendVal == nil {
+				continue
+			}
+			var doubleSpend TxRecord
+			if err := readRawTxRecord(&doubleSpendHash, doubleSpendVal, &doubleSpend); err != nil {
+				Error(err)
+				return err
+			}
+			if err := s.recordConflict(ns, &doubleSpend, &rec.Hash); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ConflictedTxs returns the details of all transactions that were removed from the store because a competing spend was
+// mined. Not having any conflicted transactions is not an error.
+func (s *Store) ConflictedTxs(ns walletdb.ReadBucket) (txs []ConflictedTx, err error) {
+	b := ns.NestedReadBucket(bucketConflicted)
+	if b == nil {
+		return
+	}
+	err = b.ForEach(func(k, v []byte) error {
+		if len(k) < 32 {
+			str := fmt.Sprintf("%s: short key (expected %d bytes, read %d)", bucketConflicted, 32, len(k))
+			return storeError(ErrData, str, nil)
+		}
+		var txHash chainhash.Hash
+		copy(txHash[:], k)
+		var c ConflictedTx
+		if err := readRawConflicted(&txHash, v, &c); err != nil {
+			return err
+		}
+		txs = append(txs, c)
+		return nil
+	})
+	return
+}
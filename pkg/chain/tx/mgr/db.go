@@ -62,6 +62,7 @@ var (
 	bucketUnmined        = []byte("m")
 	bucketUnminedCredits = []byte("mc")
 	bucketUnminedInputs  = []byte("mi")
+	bucketConflicted     = []byte("x")
 	// Root (namespace) bucket keys
 	rootCreateDate   = []byte("date")
 	rootVersion      = []byte("vers")
@@ -1299,6 +1300,12 @@ func createStore(ns walletdb.ReadWriteBucket) error {
 		str := "failed to create unmined inputs bucket"
 		return storeError(ErrDatabase, str, err)
 	}
+	_, err = ns.CreateBucket(bucketConflicted)
+	if err != nil {
+		Error(err)
+		str := "failed to create conflicted bucket"
+		return storeError(ErrDatabase, str, err)
+	}
 	return nil
 }
 
@@ -80,6 +80,9 @@ type (
 		chainParams *netparams.Params
 		// Event callbacks. These execute in the same goroutine as the wtxmgr caller.
 		NotifyUnspent func(hash *chainhash.Hash, index uint32)
+		// NotifyConflict is called when an unmined transaction is removed because the transaction with hash by, which
+		// double spends it or one of its unmined inputs, was mined.
+		NotifyConflict func(conflicted, by *chainhash.Hash)
 	}
 )
 
@@ -134,7 +137,7 @@ func Open(ns walletdb.ReadBucket, chainParams *netparams.Params) (*Store, error)
 		Error(err)
 		return nil, err
 	}
-	s := &Store{chainParams, nil} // TODO: set callbacks
+	s := &Store{chainParams, nil, nil} // TODO: set callbacks
 	return s, nil
 }
 
@@ -283,6 +286,15 @@ func (s *Store) insertMinedTx(ns walletdb.ReadWriteBucket, rec *TxRecord,
 	if _, v := existsTxRecord(ns, &rec.Hash, &block.Block); v != nil {
 		return nil
 	}
+	// A transaction previously recorded as conflicted is no longer so once it is mined, for example after a reorg.
+	if err := deleteRawConflicted(ns, rec.Hash[:]); err != nil {
+		return err
+	}
+	// Record the unmined double spends this transaction invalidates while their debits can still be looked up, they
+	// are removed once the balance has been updated below.
+	if err := s.recordDoubleSpends(ns, rec); err != nil {
+		return err
+	}
 	// If a block record does not yet exist for any transactions from this block, insert a block record first.
 	// Otherwise, update it by adding the transaction hash to the set of transactions from this block.
 	var err error
@@ -1558,6 +1558,10 @@ func TestInsertConfirmedDoubleSpendTx(t *testing.T) {
 		t.Fatal(err)
 	}
 	defer teardown()
+	var notified int
+	store.NotifyConflict = func(conflicted, by *chainhash.Hash) {
+		notified++
+	}
 	// In order to reproduce real-world scenarios, we'll use a new database transaction for each interaction with the
 	// wallet.
 	//
@@ -1704,6 +1708,27 @@ func TestInsertConfirmedDoubleSpendTx(t *testing.T) {
 				confirmedSpend, minedTxs[0].Hash)
 		}
 	})
+	// The removed unconfirmed spends should have been recorded as conflicted by the confirmed spend, with the details
+	// they had before removal.
+	commitDBTx(t, store, db, func(ns walletdb.ReadWriteBucket) {
+		conflicted, err := store.ConflictedTxs(ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicted) != 3 || notified != 3 {
+			t.Fatalf("expected 3 conflicted txs and notifications, got %v and %v", len(conflicted), notified)
+		}
+		for _, c := range conflicted {
+			if !c.ConflictedBy.IsEqual(&confirmedSpendRec.Hash) {
+				t.Fatalf("expected tx %v to be conflicted by %v, got %v",
+					c.Hash, confirmedSpendRec.Hash, c.ConflictedBy)
+			}
+			if len(c.Credits) != 1 || len(c.Debits) != 1 {
+				t.Fatalf("expected 1 credit and 1 debit for conflicted tx %v, got %d and %d",
+					c.Hash, len(c.Credits), len(c.Debits))
+			}
+		}
+	})
 }
 
 // TestAddDuplicateCreditAfterConfirm aims to test the case where a duplicate unconfirmed credit is added to the store
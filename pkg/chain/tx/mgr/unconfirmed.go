@@ -30,6 +30,9 @@ func (s *Store) insertMemPoolTx(ns walletdb.ReadWriteBucket, rec *TxRecord) erro
 		Error(err)
 		return err
 	}
+	if err = deleteRawConflicted(ns, rec.Hash[:]); err != nil {
+		return err
+	}
 	err = putRawUnmined(ns, rec.Hash[:], v)
 	if err != nil {
 		Error(err)
@@ -0,0 +1,50 @@
+package txscript
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// sigHashTypeNames maps the sighash flag names used by the RPC interface to their hash types.
+var sigHashTypeNames = map[string]SigHashType{
+	"ALL":                 SigHashAll,
+	"NONE":                SigHashNone,
+	"SINGLE":              SigHashSingle,
+	"ALL|ANYONECANPAY":    SigHashAll | SigHashAnyOneCanPay,
+	"NONE|ANYONECANPAY":   SigHashNone | SigHashAnyOneCanPay,
+	"SINGLE|ANYONECANPAY": SigHashSingle | SigHashAnyOneCanPay,
+}
+
+// ParseSigHashType returns the hash type for one of the sighash flag names ALL, NONE, SINGLE, ALL|ANYONECANPAY,
+// NONE|ANYONECANPAY and SINGLE|ANYONECANPAY.
+func ParseSigHashType(flags string) (SigHashType, error) {
+	hashType, ok := sigHashTypeNames[flags]
+	if !ok {
+		return 0, fmt.Errorf("invalid sighash type %q", flags)
+	}
+	return hashType, nil
+}
+
+// String returns the sighash flag name of a hash type, or its value in hex for a hash type without one.
+func (hashType SigHashType) String() string {
+	for name, t := range sigHashTypeNames {
+		if t == hashType {
+			return name
+		}
+	}
+	return fmt.Sprintf("%#x", uint32(hashType))
+}
+
+// CalcSigHash returns the signature hash of input idx of tx for the given script and hash type. When witness is true
+// the BIP0143 digest committing to the amount of the spent output is calculated, otherwise the legacy digest is, and
+// the amount is not used.
+func CalcSigHash(tx *wire.MsgTx, idx int, script []byte, amt int64, hashType SigHashType, witness bool) ([]byte, error) {
+	if idx < 0 || idx >= len(tx.TxIn) {
+		return nil, fmt.Errorf("input index %d out of range, transaction has %d inputs", idx, len(tx.TxIn))
+	}
+	if witness {
+		return CalcWitnessSigHash(script, NewTxSigHashes(tx), hashType, tx, idx, amt)
+	}
+	return CalcSignatureHash(script, hashType, tx, idx)
+}
@@ -0,0 +1,78 @@
+package txscript
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestParseSigHashType ensures the sighash flag names map to the expected hash types and that unknown names are
+// rejected.
+func TestParseSigHashType(t *testing.T) {
+	tests := []struct {
+		flags    string
+		hashType SigHashType
+		valid    bool
+	}{
+		{"ALL", SigHashAll, true},
+		{"NONE", SigHashNone, true},
+		{"SINGLE", SigHashSingle, true},
+		{"ALL|ANYONECANPAY", SigHashAll | SigHashAnyOneCanPay, true},
+		{"NONE|ANYONECANPAY", SigHashNone | SigHashAnyOneCanPay, true},
+		{"SINGLE|ANYONECANPAY", SigHashSingle | SigHashAnyOneCanPay, true},
+		{"all", 0, false},
+		{"ANYONECANPAY", 0, false},
+		{"", 0, false},
+	}
+	for _, test := range tests {
+		hashType, err := ParseSigHashType(test.flags)
+		if (err == nil) != test.valid {
+			t.Errorf("ParseSigHashType(%q): unexpected error state %v", test.flags, err)
+			continue
+		}
+		if hashType != test.hashType {
+			t.Errorf("ParseSigHashType(%q): got %d, want %d", test.flags, hashType, test.hashType)
+		}
+	}
+}
+
+// TestCalcSigHash ensures CalcSigHash computes the same digests as the legacy and witness sighash functions and
+// rejects out of range inputs.
+func TestCalcSigHash(t *testing.T) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 2}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1000, []byte{OP_TRUE}))
+	script := []byte{OP_DUP, OP_HASH160, OP_DATA_20,
+		1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20,
+		OP_EQUALVERIFY, OP_CHECKSIG}
+	legacy, err := CalcSignatureHash(script, SigHashAll, tx, 1)
+	if err != nil {
+		t.Fatalf("CalcSignatureHash: %v", err)
+	}
+	got, err := CalcSigHash(tx, 1, script, 5000, SigHashAll, false)
+	if err != nil {
+		t.Fatalf("CalcSigHash: %v", err)
+	}
+	if !bytes.Equal(got, legacy) {
+		t.Errorf("legacy sighash mismatch: got %x, want %x", got, legacy)
+	}
+	witness, err := CalcWitnessSigHash(script, NewTxSigHashes(tx), SigHashAll, tx, 1, 5000)
+	if err != nil {
+		t.Fatalf("CalcWitnessSigHash: %v", err)
+	}
+	got, err = CalcSigHash(tx, 1, script, 5000, SigHashAll, true)
+	if err != nil {
+		t.Fatalf("CalcSigHash: %v", err)
+	}
+	if !bytes.Equal(got, witness) {
+		t.Errorf("witness sighash mismatch: got %x, want %x", got, witness)
+	}
+	if bytes.Equal(legacy, witness) {
+		t.Error("legacy and witness sighashes should differ")
+	}
+	if _, err = CalcSigHash(tx, 2, script, 0, SigHashAll, false); err == nil {
+		t.Error("CalcSigHash accepted an out of range input index")
+	}
+}
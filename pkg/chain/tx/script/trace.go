@@ -0,0 +1,43 @@
+package txscript
+
+// TraceStep records the execution of a single opcode by the script engine.
+type TraceStep struct {
+	// Script is the index of the script the opcode belongs to: 0 is the signature script, 1 the public key script and
+	// any further scripts are pay-to-script-hash redeem scripts or witness scripts.
+	Script int
+	// Offset is the index of the opcode within its script.
+	Offset int
+	// Opcode is the disassembly of the opcode and its data.
+	Opcode string
+	// Stack and AltStack are the contents of the data and alternate stacks after the opcode was executed, from the
+	// bottom to the top.
+	Stack    [][]byte
+	AltStack [][]byte
+	// Failed is set when executing the opcode failed.
+	Failed bool
+}
+
+// ExecuteTrace executes all scripts in the script engine like Execute, recording every opcode executed along with the
+// state of the stacks after it. When execution fails the error is returned, and if an opcode caused it that opcode is
+// the last step returned and is marked as failed.
+func (vm *Engine) ExecuteTrace() (steps []TraceStep, err error) {
+	for done := false; !done; {
+		var scriptIdx, scriptOff int
+		if scriptIdx, scriptOff, err = vm.curPC(); err != nil {
+			return steps, err
+		}
+		step := TraceStep{
+			Script: scriptIdx,
+			Offset: scriptOff,
+			Opcode: vm.scripts[scriptIdx][scriptOff].print(false),
+		}
+		done, err = vm.Step()
+		step.Stack, step.AltStack = vm.GetStack(), vm.GetAltStack()
+		step.Failed = err != nil
+		steps = append(steps, step)
+		if err != nil {
+			return steps, err
+		}
+	}
+	return steps, vm.CheckErrorCondition(true)
+}
@@ -0,0 +1,50 @@
+package txscript
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestExecuteTrace ensures every executed opcode is recorded with the resulting stack and that a failing opcode is the
+// last step of the trace.
+func TestExecuteTrace(t *testing.T) {
+	newEngine := func(sigScript, pkScript []byte) *Engine {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, sigScript, nil))
+		tx.AddTxOut(wire.NewTxOut(0, nil))
+		vm, err := NewEngine(pkScript, tx, 0, 0, nil, nil, 0)
+		if err != nil {
+			t.Fatalf("NewEngine: %v", err)
+		}
+		return vm
+	}
+	// 2 3 OP_ADD 5 OP_EQUAL succeeds.
+	steps, err := newEngine([]byte{OP_2, OP_3}, []byte{OP_ADD, OP_5, OP_EQUAL}).ExecuteTrace()
+	if err != nil {
+		t.Fatalf("ExecuteTrace: unexpected error %v", err)
+	}
+	if len(steps) != 5 {
+		t.Fatalf("got %d steps, want 5", len(steps))
+	}
+	if steps[2].Script != 1 || steps[2].Offset != 0 || steps[2].Opcode != "OP_ADD" {
+		t.Errorf("unexpected step %+v", steps[2])
+	}
+	if len(steps[2].Stack) != 1 || !bytes.Equal(steps[2].Stack[0], []byte{5}) {
+		t.Errorf("unexpected stack after OP_ADD: %x", steps[2].Stack)
+	}
+	// 2 3 OP_ADD 6 OP_EQUALVERIFY fails at the last opcode.
+	steps, err = newEngine([]byte{OP_2, OP_3}, []byte{OP_ADD, OP_6, OP_EQUALVERIFY}).ExecuteTrace()
+	if err == nil {
+		t.Fatal("ExecuteTrace: expected an error")
+	}
+	if last := steps[len(steps)-1]; last.Opcode != "OP_EQUALVERIFY" || !last.Failed {
+		t.Errorf("unexpected failing step %+v", last)
+	}
+	for _, step := range steps[:len(steps)-1] {
+		if step.Failed {
+			t.Errorf("step %+v marked as failed", step)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package blockchain
+
+import (
+	"errors"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+)
+
+// ScannedOutput is an unspent output found by a scan of the UTXO set
+type ScannedOutput struct {
+	OutPoint wire.OutPoint
+	Entry    *UtxoEntry
+}
+
+// UTXOScan is the result of a scan of the UTXO set
+type UTXOScan struct {
+	// Hash and Height identify the best block the UTXO set was scanned at
+	Hash   chainhash.Hash
+	Height int32
+	// Scanned is the number of unspent outputs in the UTXO set
+	Scanned uint64
+	// Outputs are the unspent outputs that pay to one of the scanned public key scripts
+	Outputs []ScannedOutput
+}
+
+// ScanUTXOSet returns the unspent outputs paying to any of the passed public key scripts, so that the coins of
+// addresses outside of the wallet can be found without the address index or a rescan of the blocks. The UTXO set is
+// read from a single database transaction, so blocks connected during the scan are not included.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) ScanUTXOSet(pkScripts [][]byte) (scan *UTXOScan, err error) {
+	scripts := make(map[string]struct{}, len(pkScripts))
+	for i := range pkScripts {
+		scripts[string(pkScripts[i])] = struct{}{}
+	}
+	err = b.db.View(func(dbTx database.Tx) error {
+		meta := dbTx.Metadata()
+		state, err := deserializeBestChainState(meta.Get(chainStateKeyName))
+		if err != nil {
+			return err
+		}
+		scan = &UTXOScan{Hash: state.hash, Height: int32(state.height)}
+		return meta.Bucket(utxoSetBucketName).ForEach(func(k, v []byte) error {
+			scan.Scanned++
+			if len(k) <= chainhash.HashSize {
+				return errors.New("corrupt outpoint key in the UTXO set")
+			}
+			entry, err := deserializeUtxoEntry(v)
+			if err != nil {
+				return err
+			}
+			if _, ok := scripts[string(entry.PkScript())]; !ok {
+				return nil
+			}
+			var op wire.OutPoint
+			copy(op.Hash[:], k[:chainhash.HashSize])
+			index, _ := deserializeVLQ(k[chainhash.HashSize:])
+			op.Index = uint32(index)
+			scan.Outputs = append(scan.Outputs, ScannedOutput{OutPoint: op, Entry: entry})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scan, nil
+}
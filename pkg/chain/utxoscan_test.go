@@ -0,0 +1,69 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+
+	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+)
+
+// TestScanUTXOSet ensures that a scan of the UTXO set finds exactly the unspent outputs paying to the scanned scripts.
+func TestScanUTXOSet(t *testing.T) {
+	params := chaincfg.MainNetParams
+	chain, teardownFunc, err := chainSetup("utxoscan", &netparams.Params{Params: &params})
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardownFunc()
+	wanted := []byte{txscript.OP_TRUE}
+	other := []byte{txscript.OP_FALSE}
+	outputs := []struct {
+		op       wire.OutPoint
+		pkScript []byte
+		amount   int64
+	}{
+		{wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}, wanted, 5e8},
+		{wire.OutPoint{Hash: chainhash.Hash{1}, Index: 300}, other, 4e8},
+		{wire.OutPoint{Hash: chainhash.Hash{2}, Index: 200}, wanted, 3e8},
+	}
+	err = chain.db.Update(func(dbTx database.Tx) error {
+		utxoBucket := dbTx.Metadata().Bucket(utxoSetBucketName)
+		for i := range outputs {
+			entry, err := serializeUtxoEntry(&UtxoEntry{amount: outputs[i].amount, pkScript: outputs[i].pkScript,
+				blockHeight: 1})
+			if err != nil {
+				return err
+			}
+			if err = utxoBucket.Put(*outpointKey(outputs[i].op), entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scan, err := chain.ScanUTXOSet([][]byte{wanted})
+	if err != nil {
+		t.Fatalf("ScanUTXOSet: %v", err)
+	}
+	// The genesis coinbase is not in the UTXO set, as it can not be spent.
+	if scan.Height != 0 || scan.Hash != *params.GenesisHash || scan.Scanned != uint64(len(outputs)) {
+		t.Fatalf("ScanUTXOSet: got height %d, hash %v, %d scanned", scan.Height, scan.Hash, scan.Scanned)
+	}
+	if len(scan.Outputs) != 2 {
+		t.Fatalf("ScanUTXOSet: got %d outputs, want 2", len(scan.Outputs))
+	}
+	for i, want := range []int{0, 2} {
+		got := scan.Outputs[i]
+		if got.OutPoint != outputs[want].op || got.Entry.Amount() != outputs[want].amount ||
+			!bytes.Equal(got.Entry.PkScript(), wanted) {
+			t.Fatalf("ScanUTXOSet: output #%d is %v %d", i, got.OutPoint, got.Entry.Amount())
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package blockchain
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+
+	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// utxoSnapshotVersion is the version of the UTXO set snapshot serialization
+const utxoSnapshotVersion = 1
+
+// ErrSnapshotIndexes is returned when loading a UTXO set snapshot while optional indexes are enabled, as the blocks
+// before the snapshot are not available to index.
+var ErrSnapshotIndexes = errors.New("UTXO set snapshots can not be loaded while optional indexes are enabled")
+
+// ErrNoAssumeUTXO is returned when loading a UTXO set snapshot on a network with no known snapshots. Loading snapshots
+// does nothing until a verified one is added to the AssumeUTXO network parameters.
+var ErrNoAssumeUTXO = errors.New("this network has no known UTXO set snapshots, so none can be loaded until one is " +
+	"added to its AssumeUTXO parameters")
+
+// A UTXO set snapshot holds everything needed to continue the chain from the block it was taken at without the blocks
+// before it: the headers of the chain, the block itself and the unspent outputs after it was connected.
+//
+// The serialized format is:
+//
+//   <network><version><height><total txns><headers><block><coin count><coins>
+//   Field        Type               Size
+//   network      uint32             4 bytes
+//   version      uint32             4 bytes
+//   height       uint32             4 bytes
+//   total txns   uint64             8 bytes
+//   headers      []wire.BlockHeader the headers of the blocks after the genesis block and before the snapshot block
+//   block        wire.MsgBlock      the block the snapshot was taken at
+//   coin count   uint64             8 bytes
+//   coins        <key><entry>       the outpoint keys and serialized utxo entries of the utxo set, as stored in the
+//                                   database and in the same order, each written as variable length bytes
+//
+// All integers are little endian. The hash of a snapshot is the double sha256 of its coins, so it is independent of
+// the format of the chain data around them.
+// -----------------------------------------------------------------------------
+
+// UTXOSnapshot describes a UTXO set snapshot
+type UTXOSnapshot struct {
+	// Hash and Height identify the block the snapshot was taken at
+	Hash   chainhash.Hash
+	Height int32
+	// UTXOHash is the hash of the coins of the snapshot
+	UTXOHash chainhash.Hash
+	// Coins is the number of unspent outputs in the snapshot
+	Coins uint64
+}
+
+// snapshotCoinWriter writes the coins of a snapshot while hashing them
+type snapshotCoinWriter struct {
+	w io.Writer
+	h hash.Hash
+}
+
+// write writes a coin of a snapshot
+func (c *snapshotCoinWriter) write(key, entry []byte) (err error) {
+	w := io.MultiWriter(c.w, c.h)
+	if err = wire.WriteVarBytes(w, 0, key); err != nil {
+		return
+	}
+	return wire.WriteVarBytes(w, 0, entry)
+}
+
+// DumpUTXOSnapshot writes a snapshot of the UTXO set at the current best block to w. The snapshot is read from a
+// single database transaction, so blocks connected while it is written are not included.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) DumpUTXOSnapshot(w io.Writer) (snap *UTXOSnapshot, err error) {
+	bw := bufio.NewWriter(w)
+	err = b.db.View(func(dbTx database.Tx) error {
+		meta := dbTx.Metadata()
+		state, err := deserializeBestChainState(meta.Get(chainStateKeyName))
+		if err != nil {
+			return err
+		}
+		if state.height == 0 {
+			return errors.New("no UTXO set snapshot can be taken at the genesis block")
+		}
+		fields := []interface{}{uint32(b.params.Net), uint32(utxoSnapshotVersion), state.height, state.totalTxns}
+		for i := range fields {
+			if err = binary.Write(bw, binary.LittleEndian, fields[i]); err != nil {
+				return err
+			}
+		}
+		// The headers are read from the block index, as the blocks may have been pruned.
+		blockIndexBucket := meta.Bucket(blockIndexBucketName)
+		for height := int32(1); height < int32(state.height); height++ {
+			hash, err := dbFetchHashByHeight(dbTx, height)
+			if err != nil {
+				return err
+			}
+			header, _, err := deserializeBlockRow(blockIndexBucket.Get(blockIndexKey(hash, uint32(height))))
+			if err != nil {
+				return err
+			}
+			if err = header.Serialize(bw); err != nil {
+				return err
+			}
+		}
+		blockBytes, err := dbTx.FetchBlock(&state.hash)
+		if err != nil {
+			return err
+		}
+		if _, err = bw.Write(blockBytes); err != nil {
+			return err
+		}
+		utxoBucket := meta.Bucket(utxoSetBucketName)
+		var coins uint64
+		if err = utxoBucket.ForEach(func(k, v []byte) error {
+			coins++
+			return nil
+		}); err != nil {
+			return err
+		}
+		if err = binary.Write(bw, binary.LittleEndian, coins); err != nil {
+			return err
+		}
+		cw := &snapshotCoinWriter{w: bw, h: sha256.New()}
+		if err = utxoBucket.ForEach(cw.write); err != nil {
+			return err
+		}
+		snap = &UTXOSnapshot{
+			Hash:     state.hash,
+			Height:   int32(state.height),
+			UTXOHash: chainhash.HashH(cw.h.Sum(nil)),
+			Coins:    coins,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = bw.Flush(); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// LoadUTXOSnapshot bootstraps the chain from the UTXO set snapshot read from r, replacing the UTXO set and making the
+// block the snapshot was taken at the best block. The snapshot must be one of the AssumeUTXO snapshots of the network
+// and the current best block must be part of its chain. ErrNoAssumeUTXO is returned if the network has none.
+//
+// The blocks before the snapshot are treated as pruned, so they are not served to peers and reorganizations past the
+// snapshot are not possible. The snapshot is loaded in a single database transaction, so nothing is changed when it
+// fails to load.
+//
+// This function is safe for concurrent access.
+func (b *BlockChain) LoadUTXOSnapshot(r io.Reader) (*UTXOSnapshot, error) {
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+	if b.indexManager != nil {
+		return nil, ErrSnapshotIndexes
+	}
+	if len(b.params.AssumeUTXO) == 0 {
+		return nil, ErrNoAssumeUTXO
+	}
+	br := bufio.NewReader(r)
+	var network, version, height uint32
+	var totalTxns uint64
+	fields := []interface{}{&network, &version, &height, &totalTxns}
+	for i := range fields {
+		if err := binary.Read(br, binary.LittleEndian, fields[i]); err != nil {
+			return nil, err
+		}
+	}
+	if wire.BitcoinNet(network) != b.params.Net {
+		return nil, fmt.Errorf("UTXO set snapshot is for network %v, not %v", wire.BitcoinNet(network), b.params.Net)
+	}
+	if version != utxoSnapshotVersion {
+		return nil, fmt.Errorf("unknown UTXO set snapshot version %d", version)
+	}
+	// The snapshot must be a known one before anything else is read from it, which also bounds the number of headers.
+	var assumed *chaincfg.AssumeUTXO
+	for i := range b.params.AssumeUTXO {
+		if b.params.AssumeUTXO[i].Height == int32(height) {
+			assumed = &b.params.AssumeUTXO[i]
+		}
+	}
+	if assumed == nil {
+		return nil, fmt.Errorf("there is no known UTXO set snapshot at height %d", height)
+	}
+	tip := b.BestChain.Tip()
+	if tip.height >= assumed.Height {
+		return nil, fmt.Errorf("the chain is already at height %d, past the UTXO set snapshot", tip.height)
+	}
+	// The headers link the genesis block to the snapshot block by hash, so they are proven by the known block hash.
+	headers := make([]wire.BlockHeader, assumed.Height)
+	prevHash := *b.params.GenesisHash
+	for i := 0; i < len(headers)-1; i++ {
+		if err := headers[i].Deserialize(br); err != nil {
+			return nil, err
+		}
+		if headers[i].PrevBlock != prevHash {
+			return nil, fmt.Errorf("UTXO set snapshot header at height %d does not connect", i+1)
+		}
+		prevHash = headers[i].BlockHash()
+	}
+	var msgBlock wire.MsgBlock
+	if err := msgBlock.Deserialize(br); err != nil {
+		return nil, err
+	}
+	block := util.NewBlock(&msgBlock)
+	block.SetHeight(assumed.Height)
+	headers[len(headers)-1] = msgBlock.Header
+	if msgBlock.Header.PrevBlock != prevHash || !block.Hash().IsEqual(assumed.BlockHash) {
+		return nil, fmt.Errorf("UTXO set snapshot block is not %v", assumed.BlockHash)
+	}
+	merkles := BuildMerkleTreeStore(block.Transactions(), false)
+	if !merkles[len(merkles)-1].IsEqual(&msgBlock.Header.MerkleRoot) {
+		return nil, fmt.Errorf("UTXO set snapshot block %v has an invalid merkle root", assumed.BlockHash)
+	}
+	if tip.height > 0 && headers[tip.height-1].BlockHash() != tip.hash {
+		return nil, fmt.Errorf("the best block %v is not in the chain of the UTXO set snapshot", tip.hash)
+	}
+	// Create the block nodes the chain does not have yet, which are only added to the index once the snapshot is
+	// stored.
+	var nodes []*BlockNode
+	parent := tip
+	for i := tip.height; i < assumed.Height; i++ {
+		node := NewBlockNode(&headers[i], parent)
+		node.status = statusValid
+		nodes = append(nodes, node)
+		parent = node
+	}
+	snapTip := parent
+	snapTip.status |= statusDataStored
+	var coins uint64
+	err := b.db.Update(func(dbTx database.Tx) error {
+		// Replace the utxo set with the coins of the snapshot.
+		utxoBucket := dbTx.Metadata().Bucket(utxoSetBucketName)
+		var keys [][]byte
+		if err := utxoBucket.ForEach(func(k, v []byte) error {
+			keys = append(keys, k)
+			return nil
+		}); err != nil {
+			return err
+		}
+		for i := range keys {
+			if err := utxoBucket.Delete(keys[i]); err != nil {
+				return err
+			}
+		}
+		if err := binary.Read(br, binary.LittleEndian, &coins); err != nil {
+			return err
+		}
+		// The coins are only hashed while reading, they are written to the database instead.
+		cw := &snapshotCoinWriter{w: ioutil.Discard, h: sha256.New()}
+		for i := uint64(0); i < coins; i++ {
+			key, err := wire.ReadVarBytes(br, 0, uint32(chainhash.HashSize+maxUint32VLQSerializeSize), "outpoint key")
+			if err != nil {
+				return err
+			}
+			entry, err := wire.ReadVarBytes(br, 0, wire.MaxMessagePayload, "utxo entry")
+			if err != nil {
+				return err
+			}
+			if err = cw.write(key, entry); err != nil {
+				return err
+			}
+			if err = utxoBucket.Put(key, entry); err != nil {
+				return err
+			}
+		}
+		if utxoHash := chainhash.HashH(cw.h.Sum(nil)); !utxoHash.IsEqual(assumed.UTXOHash) {
+			return fmt.Errorf("UTXO set snapshot has hash %v, not %v", utxoHash, assumed.UTXOHash)
+		}
+		for _, node := range nodes {
+			if err := dbStoreBlockNode(dbTx, node); err != nil {
+				return err
+			}
+			if err := dbPutBlockIndex(dbTx, &node.hash, node.height); err != nil {
+				return err
+			}
+		}
+		if err := dbStoreBlock(dbTx, block); err != nil {
+			return err
+		}
+		state := &BestState{Hash: snapTip.hash, Height: snapTip.height, TotalTxns: totalTxns}
+		if err := dbPutBestState(dbTx, state, snapTip.workSum); err != nil {
+			return err
+		}
+		return dbPutPruneHeight(dbTx, assumed.Height-1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		b.Index.addNode(node)
+	}
+	b.BestChain.SetTip(snapTip)
+	numTxns := uint64(len(msgBlock.Transactions))
+	state := newBestState(snapTip, uint64(msgBlock.SerializeSize()), uint64(GetBlockWeight(block)), numTxns,
+		totalTxns, snapTip.CalcPastMedianTime())
+	b.stateLock.Lock()
+	b.stateSnapshot = state
+	b.stateLock.Unlock()
+	b.pruneHeight.Store(assumed.Height - 1)
+	Infof("loaded UTXO set snapshot of %d coins at block %v (height %d)", coins, snapTip.hash, snapTip.height)
+	return &UTXOSnapshot{Hash: snapTip.hash, Height: snapTip.height, UTXOHash: *assumed.UTXOHash, Coins: coins}, nil
+}
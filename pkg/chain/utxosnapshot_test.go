@@ -0,0 +1,151 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"sort"
+	"testing"
+	"time"
+
+	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestUTXOSnapshot ensures that a chain is bootstrapped from a known UTXO set snapshot, that unknown or altered
+// snapshots are refused without changing the chain, that the bootstrapped chain is restored when the chain is opened
+// again and that dumping its UTXO set gives back the same snapshot.
+func TestUTXOSnapshot(t *testing.T) {
+	params := chaincfg.MainNetParams
+	chain, teardownFunc, err := chainSetup("utxosnapshot", &netparams.Params{Params: &params})
+	if err != nil {
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+	defer teardownFunc()
+	// Build a snapshot of a chain of three blocks holding two coins.
+	const height = 3
+	var snapshot bytes.Buffer
+	fields := []interface{}{uint32(params.Net), uint32(utxoSnapshotVersion), uint32(height), uint64(height + 1)}
+	for i := range fields {
+		if err = binary.Write(&snapshot, binary.LittleEndian, fields[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prevHash := *params.GenesisHash
+	timestamp := params.GenesisBlock.Header.Timestamp
+	for i := 1; i < height; i++ {
+		timestamp = timestamp.Add(time.Minute)
+		header := wire.BlockHeader{Version: 1, PrevBlock: prevHash, Timestamp: timestamp,
+			Bits: params.GenesisBlock.Header.Bits, Nonce: uint32(i)}
+		if err = header.Serialize(&snapshot); err != nil {
+			t.Fatal(err)
+		}
+		prevHash = header.BlockHash()
+	}
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{3, height, 0, 0},
+		nil))
+	coinbase.AddTxOut(wire.NewTxOut(2e8, []byte{txscript.OP_TRUE}))
+	coinbase.AddTxOut(wire.NewTxOut(1e8, []byte{txscript.OP_TRUE}))
+	msgBlock := wire.NewMsgBlock(&wire.BlockHeader{Version: 1, PrevBlock: prevHash,
+		Timestamp: timestamp.Add(time.Minute), Bits: params.GenesisBlock.Header.Bits})
+	if err = msgBlock.AddTransaction(coinbase); err != nil {
+		t.Fatal(err)
+	}
+	block := util.NewBlock(msgBlock)
+	merkles := BuildMerkleTreeStore(block.Transactions(), false)
+	msgBlock.Header.MerkleRoot = *merkles[len(merkles)-1]
+	block = util.NewBlock(msgBlock)
+	if err = msgBlock.Serialize(&snapshot); err != nil {
+		t.Fatal(err)
+	}
+	coins := make(map[string][]byte)
+	for i, txOut := range coinbase.TxOut {
+		entry, err := serializeUtxoEntry(&UtxoEntry{amount: txOut.Value, pkScript: txOut.PkScript,
+			blockHeight: height, packedFlags: tfCoinBase})
+		if err != nil {
+			t.Fatal(err)
+		}
+		coins[string(*outpointKey(wire.OutPoint{Hash: coinbase.TxHash(), Index: uint32(i)}))] = entry
+	}
+	var keys []string
+	for key := range coins {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if err = binary.Write(&snapshot, binary.LittleEndian, uint64(len(keys))); err != nil {
+		t.Fatal(err)
+	}
+	cw := &snapshotCoinWriter{w: &snapshot, h: sha256.New()}
+	for _, key := range keys {
+		if err = cw.write([]byte(key), coins[key]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	utxoHash := chainhash.HashH(cw.h.Sum(nil))
+	// The snapshot is refused while the network has no known snapshots, until it is a known one, and with a different
+	// hash.
+	if _, err = chain.LoadUTXOSnapshot(bytes.NewReader(snapshot.Bytes())); err != ErrNoAssumeUTXO {
+		t.Fatalf("loading without known snapshots: got error %v, want %v", err, ErrNoAssumeUTXO)
+	}
+	params.AssumeUTXO = []chaincfg.AssumeUTXO{{Height: height + 1, BlockHash: block.Hash(), UTXOHash: &chainhash.Hash{}}}
+	if _, err = chain.LoadUTXOSnapshot(bytes.NewReader(snapshot.Bytes())); err == nil {
+		t.Fatal("loaded an unknown snapshot")
+	}
+	params.AssumeUTXO = []chaincfg.AssumeUTXO{{Height: height, BlockHash: block.Hash(), UTXOHash: &chainhash.Hash{}}}
+	if _, err = chain.LoadUTXOSnapshot(bytes.NewReader(snapshot.Bytes())); err == nil {
+		t.Fatal("loaded a snapshot with the wrong hash")
+	}
+	if best := chain.BestSnapshot(); best.Height != 0 || chain.IsPruned() {
+		t.Fatalf("chain changed by a snapshot that failed to load, height %d", best.Height)
+	}
+	params.AssumeUTXO[0].UTXOHash = &utxoHash
+	snap, err := chain.LoadUTXOSnapshot(bytes.NewReader(snapshot.Bytes()))
+	if err != nil {
+		t.Fatalf("LoadUTXOSnapshot: %v", err)
+	}
+	if snap.Height != height || snap.Hash != *block.Hash() || snap.UTXOHash != utxoHash || snap.Coins != 2 {
+		t.Fatalf("LoadUTXOSnapshot: got %+v", snap)
+	}
+	if _, err = chain.LoadUTXOSnapshot(bytes.NewReader(snapshot.Bytes())); err == nil {
+		t.Fatal("loaded a snapshot twice")
+	}
+	// The chain is restored from the database when it is opened again.
+	for i, c := range []*BlockChain{chain, nil} {
+		if c == nil {
+			if c, err = New(&Config{DB: chain.db, ChainParams: chain.params, TimeSource: NewMedianTime(),
+				SigCache: txscript.NewSigCache(1000)}); err != nil {
+				t.Fatalf("New: %v", err)
+			}
+		}
+		best := c.BestSnapshot()
+		if best.Height != height || best.Hash != *block.Hash() || best.TotalTxns != height+1 || best.NumTxns != 1 {
+			t.Fatalf("#%d: got best state %+v", i, best)
+		}
+		if !c.IsPruned() || c.PruneHeight() != height-1 {
+			t.Fatalf("#%d: got prune height %d", i, c.PruneHeight())
+		}
+		entry, err := c.FetchUtxoEntry(wire.OutPoint{Hash: coinbase.TxHash(), Index: 1})
+		if err != nil || entry == nil || entry.Amount() != 1e8 || entry.BlockHeight() != height {
+			t.Fatalf("#%d: FetchUtxoEntry: got %v, %v", i, entry, err)
+		}
+		hash, err := c.BlockHashByHeight(1)
+		if err != nil || hash == nil {
+			t.Fatalf("#%d: BlockHashByHeight(1): %v", i, err)
+		}
+		if !c.IsBlockPruned(hash) || c.IsBlockPruned(block.Hash()) {
+			t.Fatalf("#%d: the blocks below the snapshot are not the only pruned ones", i)
+		}
+	}
+	var dump bytes.Buffer
+	if snap, err = chain.DumpUTXOSnapshot(&dump); err != nil {
+		t.Fatalf("DumpUTXOSnapshot: %v", err)
+	}
+	if snap.UTXOHash != utxoHash || !bytes.Equal(dump.Bytes(), snapshot.Bytes()) {
+		t.Fatalf("DumpUTXOSnapshot: got hash %v, want %v", snap.UTXOHash, utxoHash)
+	}
+}
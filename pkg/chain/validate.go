@@ -329,7 +329,7 @@ func (b *BlockChain) checkConnectBlock(node *BlockNode, block *util.Block, view
 	// attacks.
 	if runScripts {
 		err := checkBlockScripts(block, view, scriptFlags, b.sigCache,
-			b.hashCache)
+			b.hashCache, b.scriptWorkers)
 		if err != nil {
 			Error(err)
 			return err
@@ -51,6 +51,9 @@ const (
 	CmdCFilter      = "cfilter"
 	CmdCFHeaders    = "cfheaders"
 	CmdCFCheckpt    = "cfcheckpt"
+	CmdAnnounce     = "announce"
+	CmdSendAddrV2   = "sendaddrv2"
+	CmdAddrV2       = "addrv2"
 )
 
 // MessageEncoding represents the wire message encoding format to be used.
@@ -139,6 +142,12 @@ func makeEmptyMessage(command string) (Message, error) {
 		msg = &MsgCFHeaders{}
 	case CmdCFCheckpt:
 		msg = &MsgCFCheckpt{}
+	case CmdAnnounce:
+		msg = &MsgAnnounce{}
+	case CmdSendAddrV2:
+		msg = &MsgSendAddrV2{}
+	case CmdAddrV2:
+		msg = &MsgAddrV2{}
 	default:
 		return nil, fmt.Errorf("unhandled command [%s]", command)
 	}
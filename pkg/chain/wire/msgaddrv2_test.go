@@ -0,0 +1,118 @@
+package wire
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestAddrV2Wire tests the MsgAddrV2 wire encode and decode of addresses on each network.
+func TestAddrV2Wire(t *testing.T) {
+	pver := ProtocolVersion
+	timestamp := time.Unix(0x495fab29, 0)
+	torV3 := bytes.Repeat([]byte{0xab}, 32)
+	i2p := bytes.Repeat([]byte{0xcd}, 32)
+	msg := NewMsgAddrV2()
+	if cmd := msg.Command(); cmd != "addrv2" {
+		t.Errorf("NewMsgAddrV2: wrong command - got %v want addrv2", cmd)
+	}
+	addrs := []*NetAddress{
+		NewNetAddressTimestamp(timestamp, SFNodeNetwork, net.ParseIP("127.0.0.1"), 11047),
+		NewNetAddressTimestamp(timestamp, SFNodeNetwork, net.ParseIP("2001:db8::1"), 11047),
+		NewNetAddressTimestamp(timestamp, SFNodeNetwork,
+			net.ParseIP("fd87:d87e:eb43:102:304:506:708:90a"), 11047),
+		NewNetAddressNetwork(NetTorV3, torV3, 11047, SFNodeNetwork|SFNodeWitness),
+		NewNetAddressNetwork(NetI2P, i2p, 0, SFNodeNetwork),
+	}
+	addrs[3].Timestamp = timestamp
+	addrs[4].Timestamp = timestamp
+	if err := msg.AddAddresses(addrs...); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("encode of MsgAddrV2 failed: %v", err)
+	}
+	wantBytes := []byte{
+		0x05,                   // Varint for number of addresses
+		0x29, 0xab, 0x5f, 0x49, // Timestamp
+		0x01,       // Services
+		0x01, 0x04, // Network IPv4, 4 byte address
+		0x7f, 0x00, 0x00, 0x01, // IP 127.0.0.1
+		0x2b, 0x27, // Port 11047 in big-endian
+	}
+	if !bytes.Equal(buf.Bytes()[:len(wantBytes)], wantBytes) {
+		t.Errorf("BtcEncode: wrong IPv4 encoding - got %x, want %x", buf.Bytes()[:len(wantBytes)], wantBytes)
+	}
+	if uint32(buf.Len()) > msg.MaxPayloadLength(pver) {
+		t.Errorf("encoded length %d exceeds max payload %d", buf.Len(), msg.MaxPayloadLength(pver))
+	}
+	var readmsg MsgAddrV2
+	if err := readmsg.BtcDecode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("decode of MsgAddrV2 failed: %v", err)
+	}
+	if !reflect.DeepEqual(msg.AddrList, readmsg.AddrList) {
+		t.Errorf("decoded message mismatch - got %v, want %v", spew.Sdump(readmsg.AddrList), spew.Sdump(msg.AddrList))
+	}
+	for i, na := range readmsg.AddrList {
+		if want := i >= 3; na.RequiresAddrV2() != want {
+			t.Errorf("address #%d: RequiresAddrV2 is %v, want %v", i, na.RequiresAddrV2(), want)
+		}
+	}
+}
+
+// TestAddrV2WireErrors performs negative tests against wire decode of MsgAddrV2 to confirm unknown networks are skipped
+// and malformed addresses are rejected.
+func TestAddrV2WireErrors(t *testing.T) {
+	pver := ProtocolVersion
+	tests := []struct {
+		name    string
+		buf     []byte
+		count   int
+		wantErr bool
+	}{
+		{
+			name:  "unknown network is skipped",
+			buf:   []byte{0x01, 0x29, 0xab, 0x5f, 0x49, 0x01, 0x2a, 0x02, 0x01, 0x02, 0x2b, 0x27},
+			count: 0,
+		},
+		{
+			name:    "wrong address size",
+			buf:     []byte{0x01, 0x29, 0xab, 0x5f, 0x49, 0x01, 0x04, 0x02, 0x01, 0x02, 0x2b, 0x27},
+			wantErr: true,
+		},
+		{
+			name:    "oversized address",
+			buf:     []byte{0x01, 0x29, 0xab, 0x5f, 0x49, 0x01, 0x2a, 0xfd, 0x01, 0x02},
+			wantErr: true,
+		},
+		{
+			name:    "too many addresses",
+			buf:     []byte{0xfd, 0xe9, 0x03},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		var msg MsgAddrV2
+		err := msg.BtcDecode(bytes.NewReader(test.buf), pver, BaseEncoding)
+		if (err != nil) != test.wantErr {
+			t.Errorf("%s: got error %v", test.name, err)
+			continue
+		}
+		if err == nil && len(msg.AddrList) != test.count {
+			t.Errorf("%s: got %d addresses, want %d", test.name, len(msg.AddrList), test.count)
+		}
+	}
+	msg := NewMsgAddrV2()
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, AddrV2Version-1, BaseEncoding); err == nil {
+		t.Error("encode succeeded for protocol version before AddrV2Version")
+	}
+	if err := msg.BtcDecode(&buf, AddrV2Version-1, BaseEncoding); err == nil {
+		t.Error("decode succeeded for protocol version before AddrV2Version")
+	}
+}
@@ -0,0 +1,126 @@
+package wire
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// MaxAnnounceMessageLen is the maximum number of bytes the text of an announcement may contain.
+const MaxAnnounceMessageLen = 1024
+
+// MsgAnnounce implements the Message interface and represents a signed maintainer announcement such as an upgrade
+// deadline or a critical bug warning. Announcements are only accepted and relayed if the signature verifies against one
+// of the announcement keys in the chain parameters. This message was not added until protocol version AnnounceVersion.
+//
+//        ===============================================
+//        |   Field         |   Data Type   |   Size    |
+//        ===============================================
+//        |   ID            |   uint32      |   4       |
+//        -----------------------------------------------
+//        |   Expiration    |   int64       |   8       |
+//        -----------------------------------------------
+//        |   Priority      |   int32       |   4       |
+//        -----------------------------------------------
+//        |   Message       |   string      |   ?       |
+//        -----------------------------------------------
+//        |   Signature     |   []uchar     |   ?       |
+//        -----------------------------------------------
+//
+// The signature covers the double sha256 hash of all of the fields preceding it, see SignatureHash.
+type MsgAnnounce struct {
+	// ID identifies the announcement, a newer announcement with the same ID replaces an older one
+	ID uint32
+	// Expiration is the unix time after which the announcement is no longer shown or relayed
+	Expiration int64
+	// Priority orders announcements when several are active, higher is more important
+	Priority int32
+	// Message is the human readable text of the announcement
+	Message string
+	// Signature is the DER encoded signature of SignatureHash by an announcement key
+	Signature []byte
+}
+
+// encodeBody writes the signed part of the message
+func (msg *MsgAnnounce) encodeBody(w io.Writer, pver uint32) (err error) {
+	if len(msg.Message) > MaxAnnounceMessageLen {
+		str := fmt.Sprintf("announcement message too long [len %v, max %v]", len(msg.Message), MaxAnnounceMessageLen)
+		return messageError("MsgAnnounce.BtcEncode", str)
+	}
+	if err = writeElements(w, msg.ID, msg.Expiration, msg.Priority); err != nil {
+		return
+	}
+	return WriteVarString(w, pver, msg.Message)
+}
+
+// SignatureHash returns the hash that the signature of the announcement must sign
+func (msg *MsgAnnounce) SignatureHash() (h chainhash.Hash, err error) {
+	var buf bytes.Buffer
+	if err = msg.encodeBody(&buf, AnnounceVersion); err != nil {
+		return
+	}
+	return chainhash.DoubleHashH(buf.Bytes()), nil
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface
+// implementation.
+func (msg *MsgAnnounce) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) (err error) {
+	if pver < AnnounceVersion {
+		str := fmt.Sprintf("announce message invalid for protocol version %d", pver)
+		return messageError("MsgAnnounce.BtcDecode", str)
+	}
+	if err = readElements(r, &msg.ID, &msg.Expiration, &msg.Priority); err != nil {
+		return
+	}
+	if msg.Message, err = ReadVarString(r, pver); err != nil {
+		return
+	}
+	if len(msg.Message) > MaxAnnounceMessageLen {
+		str := fmt.Sprintf("announcement message too long [len %v, max %v]", len(msg.Message), MaxAnnounceMessageLen)
+		return messageError("MsgAnnounce.BtcDecode", str)
+	}
+	msg.Signature, err = ReadVarBytes(r, pver, maxSignatureSize, "announce signature")
+	return
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface
+// implementation.
+func (msg *MsgAnnounce) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) (err error) {
+	if pver < AnnounceVersion {
+		str := fmt.Sprintf("announce message invalid for protocol version %d", pver)
+		return messageError("MsgAnnounce.BtcEncode", str)
+	}
+	if len(msg.Signature) > maxSignatureSize {
+		str := fmt.Sprintf("announce signature too long [len %v, max %v]", len(msg.Signature), maxSignatureSize)
+		return messageError("MsgAnnounce.BtcEncode", str)
+	}
+	if err = msg.encodeBody(w, pver); err != nil {
+		return
+	}
+	return WriteVarBytes(w, pver, msg.Signature)
+}
+
+// Command returns the protocol command string for the message. This is part of the Message interface implementation.
+func (msg *MsgAnnounce) Command() string {
+	return CmdAnnounce
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver. This is part of the Message
+// interface implementation.
+func (msg *MsgAnnounce) MaxPayloadLength(pver uint32) uint32 {
+	// ID 4 bytes + Expiration 8 bytes + Priority 4 bytes + message and signature with their var int lengths
+	return 16 + MaxVarIntPayload + MaxAnnounceMessageLen + MaxVarIntPayload + maxSignatureSize
+}
+
+// NewMsgAnnounce returns a new unsigned announce message that conforms to the Message interface. See MsgAnnounce for
+// details.
+func NewMsgAnnounce(id uint32, expiration int64, priority int32, message string) *MsgAnnounce {
+	return &MsgAnnounce{
+		ID:         id,
+		Expiration: expiration,
+		Priority:   priority,
+		Message:    message,
+	}
+}
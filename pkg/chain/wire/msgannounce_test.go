@@ -0,0 +1,78 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestAnnounceLatest tests the MsgAnnounce API against the latest protocol version.
+func TestAnnounceLatest(t *testing.T) {
+	pver := ProtocolVersion
+	msg := NewMsgAnnounce(7, 1600000000, 100, "upgrade before block 300000")
+	msg.Signature = []byte{0x30, 0x01, 0x02}
+	// Ensure the command is expected value.
+	wantCmd := "announce"
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgAnnounce: wrong command - got %v want %v", cmd, wantCmd)
+	}
+	// Test encode and decode with latest protocol version.
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("encode of MsgAnnounce failed %v err <%v>", msg, err)
+	}
+	if uint32(buf.Len()) > msg.MaxPayloadLength(pver) {
+		t.Errorf("encoded length %d exceeds max payload %d", buf.Len(), msg.MaxPayloadLength(pver))
+	}
+	var readmsg MsgAnnounce
+	if err := readmsg.BtcDecode(&buf, pver, BaseEncoding); err != nil {
+		t.Fatalf("decode of MsgAnnounce failed [%v] err <%v>", buf, err)
+	}
+	if !reflect.DeepEqual(msg, &readmsg) {
+		t.Errorf("decoded message mismatch - got %v, want %v", spew.Sdump(readmsg), spew.Sdump(msg))
+	}
+}
+
+// TestAnnounceSignatureHash ensures the signature hash commits to the announcement content but not the signature.
+func TestAnnounceSignatureHash(t *testing.T) {
+	msg := NewMsgAnnounce(1, 1600000000, 1, "test")
+	h1, err := msg.SignatureHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Signature = []byte{1, 2, 3}
+	h2, _ := msg.SignatureHash()
+	if h1 != h2 {
+		t.Error("signature hash changed when the signature was set")
+	}
+	msg.Message = "tampered"
+	h3, _ := msg.SignatureHash()
+	if h1 == h3 {
+		t.Error("signature hash did not change when the message changed")
+	}
+}
+
+// TestAnnounceWireErrors performs negative tests against wire encode and decode of MsgAnnounce to confirm error paths
+// work as expected.
+func TestAnnounceWireErrors(t *testing.T) {
+	msg := NewMsgAnnounce(1, 1600000000, 1, "test")
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, FeeFilterVersion, BaseEncoding); err == nil {
+		t.Error("encode succeeded for protocol version before AnnounceVersion")
+	}
+	if err := msg.BtcDecode(&buf, FeeFilterVersion, BaseEncoding); err == nil {
+		t.Error("decode succeeded for protocol version before AnnounceVersion")
+	}
+	msg.Message = strings.Repeat("x", MaxAnnounceMessageLen+1)
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err == nil {
+		t.Error("encode succeeded with oversized message")
+	}
+	msg.Message = "test"
+	msg.Signature = make([]byte, maxSignatureSize+1)
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err == nil {
+		t.Error("encode succeeded with oversized signature")
+	}
+}
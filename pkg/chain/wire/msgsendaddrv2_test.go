@@ -0,0 +1,33 @@
+package wire
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSendAddrV2 tests the MsgSendAddrV2 API against the latest protocol version and the version before it was added.
+func TestSendAddrV2(t *testing.T) {
+	msg := NewMsgSendAddrV2()
+	// Ensure the command is expected value.
+	wantCmd := "sendaddrv2"
+	if cmd := msg.Command(); cmd != wantCmd {
+		t.Errorf("NewMsgSendAddrV2: wrong command - got %v want %v", cmd, wantCmd)
+	}
+	if maxPayload := msg.MaxPayloadLength(ProtocolVersion); maxPayload != 0 {
+		t.Errorf("MaxPayloadLength: wrong max payload length - got %v, want 0", maxPayload)
+	}
+	var buf bytes.Buffer
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Errorf("encode of MsgSendAddrV2 failed: %v", err)
+	}
+	if err := msg.BtcDecode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+		t.Errorf("decode of MsgSendAddrV2 failed: %v", err)
+	}
+	// Older protocol versions should fail since the message didn't exist yet.
+	if err := msg.BtcEncode(&buf, AddrV2Version-1, BaseEncoding); err == nil {
+		t.Error("encode succeeded for protocol version before AddrV2Version")
+	}
+	if err := msg.BtcDecode(&buf, AddrV2Version-1, BaseEncoding); err == nil {
+		t.Error("decode succeeded for protocol version before AddrV2Version")
+	}
+}
@@ -32,6 +32,16 @@ type NetAddress struct {
 	IP net.IP
 	// Port the peer is using.  This is encoded in big endian on the wire which differs from most everything else.
 	Port uint16
+	// Network is the BIP0155 network of Addr for addresses that do not fit in IP, such as Tor v3 and I2P addresses,
+	// and is zero for addresses held in IP. These addresses can only be relayed in addrv2 messages (MsgAddrV2).
+	Network NetworkID
+	// Addr is the address on Network when Network is not zero.
+	Addr []byte
+}
+
+// RequiresAddrV2 returns whether the address can only be encoded in an addrv2 message (MsgAddrV2).
+func (na *NetAddress) RequiresAddrV2() bool {
+	return na.Network != 0
 }
 
 // HasService returns whether the specified service is supported by the address.
@@ -103,7 +113,8 @@ func readNetAddress(r io.Reader, pver uint32, na *NetAddress, ts bool) error {
 }
 
 // writeNetAddress serializes a NetAddress to w depending on the protocol version and whether or not the timestamp is
-// included per ts. Some messages like version do not include the timestamp.
+// included per ts. Some messages like version do not include the timestamp. Addresses that require addrv2 are written
+// with a zero IP.
 func writeNetAddress(w io.Writer, pver uint32, na *NetAddress, ts bool) error {
 	// NOTE: The bitcoin protocol uses a uint32 for the timestamp so it will stop working somewhere around 2106. Also
 	// timestamp wasn't added until until protocol version >= NetAddressTimeVersion.
@@ -0,0 +1,161 @@
+package wire
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+)
+
+// NetworkID identifies the network of an address in an addrv2 message (BIP0155).
+type NetworkID uint8
+
+const (
+	// NetIPv4 is the network ID of IPv4 addresses.
+	NetIPv4 NetworkID = iota + 1
+	// NetIPv6 is the network ID of IPv6 addresses.
+	NetIPv6
+	// NetTorV2 is the network ID of Tor v2 hidden service addresses, which are held in IP in the OnionCat range.
+	NetTorV2
+	// NetTorV3 is the network ID of Tor v3 hidden service addresses, which are the ed25519 public key of the service.
+	NetTorV3
+	// NetI2P is the network ID of I2P addresses, which are the sha256 hash of the destination.
+	NetI2P
+	// NetCJDNS is the network ID of CJDNS addresses.
+	NetCJDNS
+)
+
+// maxAddrV2Size is the largest address allowed in an addrv2 message, whatever its network.
+const maxAddrV2Size = 512
+
+// netIDAddrSize is the size of the addresses of each known network. Addresses of unknown networks are skipped.
+var netIDAddrSize = map[NetworkID]int{
+	NetIPv4:  4,
+	NetIPv6:  16,
+	NetTorV2: 10,
+	NetTorV3: 32,
+	NetI2P:   32,
+	NetCJDNS: 16,
+}
+
+// onionCatPrefix is the start of the IPv6 range Tor v2 addresses are held in, followed by the 10 byte address.
+var onionCatPrefix = []byte{0xfd, 0x87, 0xd8, 0x7e, 0xeb, 0x43}
+
+// Map of network IDs back to their names for pretty printing.
+var netIDStrings = map[NetworkID]string{
+	NetIPv4:  "ipv4",
+	NetIPv6:  "ipv6",
+	NetTorV2: "torv2",
+	NetTorV3: "torv3",
+	NetI2P:   "i2p",
+	NetCJDNS: "cjdns",
+}
+
+// String returns the NetworkID in human-readable form.
+func (n NetworkID) String() string {
+	if s, ok := netIDStrings[n]; ok {
+		return s
+	}
+	return fmt.Sprintf("Unknown NetworkID (%d)", uint8(n))
+}
+
+// NewNetAddressNetwork returns a new NetAddress for an address on a network that does not fit in an IP, such as a Tor
+// v3 or I2P address, using the provided port and supported services with defaults for the remaining fields.
+func NewNetAddressNetwork(network NetworkID, addr []byte, port uint16, services ServiceFlag) *NetAddress {
+	na := NewNetAddressIPPort(nil, port, services)
+	na.Network = network
+	na.Addr = addr
+	return na
+}
+
+// maxNetAddressV2Payload returns the max payload size for a NetAddress in an addrv2 message.
+func maxNetAddressV2Payload() uint32 {
+	// Timestamp 4 bytes + services (varInt) + network ID 1 byte + address length (varInt) + address + port 2 bytes.
+	return 4 + MaxVarIntPayload + 1 + MaxVarIntPayload + maxAddrV2Size + 2
+}
+
+// readNetAddressV2 reads a NetAddress encoded as in an addrv2 message from r. It returns false without an error for an
+// address on an unknown network, which must be skipped.
+func readNetAddressV2(r io.Reader, pver uint32, na *NetAddress) (known bool, err error) {
+	if err = readElement(r, (*uint32Time)(&na.Timestamp)); err != nil {
+		return
+	}
+	var services uint64
+	if services, err = ReadVarInt(r, pver); err != nil {
+		return
+	}
+	var network NetworkID
+	if err = readElement(r, (*uint8)(&network)); err != nil {
+		return
+	}
+	var addr []byte
+	if addr, err = ReadVarBytes(r, pver, maxAddrV2Size, "addr"); err != nil {
+		return
+	}
+	var port uint16
+	if port, err = binarySerializer.Uint16(r, bigEndian); err != nil {
+		return
+	}
+	size, known := netIDAddrSize[network]
+	if !known {
+		return false, nil
+	}
+	if len(addr) != size {
+		str := fmt.Sprintf("%v address is %d bytes, not %d", network, len(addr), size)
+		return false, messageError("readNetAddressV2", str)
+	}
+	*na = NetAddress{
+		Timestamp: na.Timestamp,
+		Services:  ServiceFlag(services),
+		Port:      port,
+	}
+	switch network {
+	case NetIPv4:
+		na.IP = net.IPv4(addr[0], addr[1], addr[2], addr[3])
+	case NetIPv6:
+		na.IP = net.IP(addr)
+	case NetTorV2:
+		na.IP = net.IP(append(append([]byte{}, onionCatPrefix...), addr...))
+	default:
+		na.Network = network
+		na.Addr = addr
+	}
+	return true, nil
+}
+
+// writeNetAddressV2 serializes a NetAddress to w as in an addrv2 message. Addresses held in IP are written as IPv4,
+// Tor v2 or IPv6 addresses depending on their range.
+func writeNetAddressV2(w io.Writer, pver uint32, na *NetAddress) error {
+	network, addr := na.Network, na.Addr
+	if network == 0 {
+		ip := na.IP.To16()
+		switch {
+		case na.IP.To4() != nil:
+			network, addr = NetIPv4, na.IP.To4()
+		case ip != nil && bytes.Equal(ip[:len(onionCatPrefix)], onionCatPrefix):
+			network, addr = NetTorV2, ip[len(onionCatPrefix):]
+		case ip != nil:
+			network, addr = NetIPv6, ip
+		default:
+			network, addr = NetIPv6, net.IPv6zero
+		}
+	}
+	if len(addr) > maxAddrV2Size {
+		str := fmt.Sprintf("%v address is %d bytes, max %d", network, len(addr), maxAddrV2Size)
+		return messageError("writeNetAddressV2", str)
+	}
+	if err := writeElement(w, uint32(na.Timestamp.Unix())); err != nil {
+		return err
+	}
+	if err := WriteVarInt(w, pver, uint64(na.Services)); err != nil {
+		return err
+	}
+	if err := writeElement(w, uint8(network)); err != nil {
+		return err
+	}
+	if err := WriteVarBytes(w, pver, addr); err != nil {
+		return err
+	}
+	return binary.Write(w, bigEndian, na.Port)
+}
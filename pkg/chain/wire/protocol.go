@@ -9,7 +9,7 @@ import (
 // XXX pedro: we will probably need to bump this.
 const (
 	// ProtocolVersion is the latest protocol version this package supports.
-	ProtocolVersion uint32 = 70013
+	ProtocolVersion uint32 = 70015
 	// MultipleAddressVersion is the protocol version which added multiple addresses per message (pver >=
 	// MultipleAddressVersion).
 	MultipleAddressVersion uint32 = 209
@@ -31,6 +31,11 @@ const (
 	SendHeadersVersion uint32 = 70012
 	// FeeFilterVersion is the protocol version which added a new feefilter message.
 	FeeFilterVersion uint32 = 70013
+	// AnnounceVersion is the protocol version which added the signed announce message.
+	AnnounceVersion uint32 = 70014
+	// AddrV2Version is the protocol version which added the sendaddrv2 and addrv2 messages that relay addresses of
+	// networks other than IPv4 and IPv6, such as Tor v3 and I2P (BIP0155).
+	AddrV2Version uint32 = 70015
 )
 
 // ServiceFlag identifies services supported by a bitcoin peer.
@@ -54,18 +59,25 @@ const (
 	SFNodeCF
 	// SFNode2X is a flag used to indicate a peer is running the Segwit2X software.
 	SFNode2X
+	// SFNodeNetworkLimited is a flag used to indicate a peer is a pruned node that serves at least the last
+	// NodeNetworkLimitedBlocks blocks (BIP0159).
+	SFNodeNetworkLimited ServiceFlag = 1 << 10
 )
 
+// NodeNetworkLimitedBlocks is the number of blocks below the tip that a peer advertising SFNodeNetworkLimited serves.
+const NodeNetworkLimitedBlocks = 288
+
 // Map of service flags back to their constant names for pretty printing.
 var sfStrings = map[ServiceFlag]string{
-	SFNodeNetwork: "SFNodeNetwork",
-	SFNodeGetUTXO: "SFNodeGetUTXO",
-	SFNodeBloom:   "SFNodeBloom",
-	SFNodeWitness: "SFNodeWitness",
-	SFNodeXthin:   "SFNodeXthin",
-	SFNodeBit5:    "SFNodeBit5",
-	SFNodeCF:      "SFNodeCF",
-	SFNode2X:      "SFNode2X",
+	SFNodeNetwork:        "SFNodeNetwork",
+	SFNodeGetUTXO:        "SFNodeGetUTXO",
+	SFNodeBloom:          "SFNodeBloom",
+	SFNodeWitness:        "SFNodeWitness",
+	SFNodeXthin:          "SFNodeXthin",
+	SFNodeBit5:           "SFNodeBit5",
+	SFNodeCF:             "SFNodeCF",
+	SFNode2X:             "SFNode2X",
+	SFNodeNetworkLimited: "SFNodeNetworkLimited",
 }
 
 // orderedSFStrings is an ordered list of service flags from highest to lowest.
@@ -78,6 +90,7 @@ var orderedSFStrings = []ServiceFlag{
 	SFNodeBit5,
 	SFNodeCF,
 	SFNode2X,
+	SFNodeNetworkLimited,
 }
 
 // String returns the ServiceFlag in human-readable form.
@@ -0,0 +1,432 @@
+package qrcode
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// Errors returned by Decode
+var (
+	errNotFound     = errors.New("goqr: no qrcode found in the image")
+	errFormat       = errors.New("goqr: unreadable qrcode format information")
+	errChecksum     = errors.New("goqr: qrcode error correction check failed")
+	errUnsupported  = errors.New("goqr: unsupported qrcode data mode")
+	errTruncated    = errors.New("goqr: qrcode data is truncated")
+	errInconsistent = errors.New("goqr: qrcode is not square")
+)
+
+// Decode reads the data of the QR code in an image. The code must be upright and undistorted on a light background,
+// as it is in a screenshot or in an image made by Encode, so this reads codes passed around as images rather than
+// codes photographed by a camera. The error correction codes are checked but not used to repair the data, so a damaged
+// code is rejected instead of being read wrongly.
+func Decode(img image.Image) (data string, err error) {
+	var modules [][]bool
+	if modules, err = sample(img); err != nil {
+		return
+	}
+	return DecodeModules(modules)
+}
+
+// sample finds the modules of the QR code in an image from the size of its top left finder pattern
+func sample(img image.Image) (modules [][]bool, err error) {
+	b := img.Bounds()
+	if b.Empty() {
+		return nil, errNotFound
+	}
+	lum := make([][]uint8, b.Dy())
+	var min, max uint8 = 255, 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		row := make([]uint8, b.Dx())
+		for x := b.Min.X; x < b.Max.X; x++ {
+			g := color.GrayModel.Convert(img.At(x, y)).(color.Gray).Y
+			row[x-b.Min.X] = g
+			if g < min {
+				min = g
+			}
+			if g > max {
+				max = g
+			}
+		}
+		lum[y-b.Min.Y] = row
+	}
+	if max-min < 64 {
+		return nil, errNotFound
+	}
+	threshold := uint8((int(min) + int(max)) / 2)
+	dark := func(x, y int) bool { return lum[y][x] < threshold }
+	// the bounds of the dark modules are the bounds of the code, as the finder patterns are in three of its corners
+	left, top, right, bottom := b.Dx(), b.Dy(), -1, -1
+	for y := range lum {
+		for x := range lum[y] {
+			if dark(x, y) {
+				if x < left {
+					left = x
+				}
+				if x > right {
+					right = x
+				}
+				if y < top {
+					top = y
+				}
+				if y > bottom {
+					bottom = y
+				}
+			}
+		}
+	}
+	if right < 0 {
+		return nil, errNotFound
+	}
+	width, height := right-left+1, bottom-top+1
+	if math.Abs(float64(width-height)) > float64(width)/10 {
+		return nil, errInconsistent
+	}
+	// the top edge of the finder pattern is 7 modules long, and the row through its middle is 7 modules of
+	// dark, light, dark, light and dark runs
+	edge := 0
+	for x := left; x <= right && dark(x, top); x++ {
+		edge++
+	}
+	y := top + edge/2
+	runs, x := 0, left
+	for ; x <= right && runs < 5; runs++ {
+		d := dark(x, y)
+		for x <= right && dark(x, y) == d {
+			x++
+		}
+	}
+	finder := float64(x - left)
+	if runs < 5 || finder == 0 {
+		return nil, errNotFound
+	}
+	n := int(math.Round(float64(width) / (finder / 7)))
+	version := int(math.Round(float64(n-17) / 4))
+	if version < 1 || version > 40 {
+		return nil, errNotFound
+	}
+	n = 17 + version*4
+	mx, my := float64(width)/float64(n), float64(height)/float64(n)
+	modules = make([][]bool, n)
+	for r := range modules {
+		modules[r] = make([]bool, n)
+		for c := range modules[r] {
+			modules[r][c] = dark(left+int((float64(c)+0.5)*mx), top+int((float64(r)+0.5)*my))
+		}
+	}
+	return
+}
+
+// DecodeModules reads the data of a QR code from its modules, indexed by row and then column, without the quiet zone
+func DecodeModules(modules [][]bool) (data string, err error) {
+	n := len(modules)
+	version := (n - 17) / 4
+	if version < 1 || version > 40 || n != 17+version*4 {
+		return "", errNotFound
+	}
+	for r := range modules {
+		if len(modules[r]) != n {
+			return "", errInconsistent
+		}
+	}
+	var level ECLevel
+	var mask int
+	if level, mask, err = readFormat(modules); err != nil {
+		return
+	}
+	function := functionModules(version)
+	// the codewords are read in pairs of columns from the right, alternately upwards and downwards, skipping the
+	// vertical timing pattern
+	var bits bitBuffer
+	for right := n - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for v := 0; v < n; v++ {
+			r := v
+			if upward {
+				r = n - 1 - v
+			}
+			for j := 0; j < 2; j++ {
+				c := right - j
+				if function[r][c] {
+					continue
+				}
+				bits = append(bits, modules[r][c] != masked(mask, r, c))
+			}
+		}
+	}
+	table := errorCorrectionTable[version][level]
+	ecPerBlock := table[0]
+	var sizes []int
+	for i := 0; i < table[1]; i++ {
+		sizes = append(sizes, table[2])
+	}
+	for i := 0; i < table[3]; i++ {
+		sizes = append(sizes, table[4])
+	}
+	total := 0
+	for _, s := range sizes {
+		total += s + ecPerBlock
+	}
+	if len(bits) < total*8 {
+		return "", errTruncated
+	}
+	codewords := make([]byte, total)
+	for i := range codewords {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				codewords[i] |= 1 << uint(7-j)
+			}
+		}
+	}
+	// the data codewords of the blocks are interleaved, followed by their interleaved error correction codewords
+	blocks := make([][]byte, len(sizes))
+	k := 0
+	for i := 0; i < sizes[len(sizes)-1]; i++ {
+		for b := range blocks {
+			if i < sizes[b] {
+				blocks[b] = append(blocks[b], codewords[k])
+				k++
+			}
+		}
+	}
+	for i := 0; i < ecPerBlock; i++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], codewords[k])
+			k++
+		}
+	}
+	var stream bitBuffer
+	for b := range blocks {
+		if !rsValid(blocks[b], ecPerBlock) {
+			return "", errChecksum
+		}
+		for _, c := range blocks[b][:sizes[b]] {
+			stream.appendByte(c)
+		}
+	}
+	return readSegments(stream, version)
+}
+
+// readFormat reads the error correction level and mask from whichever copy of the format information is closest to
+// a valid one
+func readFormat(modules [][]bool) (level ECLevel, mask int, err error) {
+	n := len(modules)
+	var first, second int
+	for i := 0; i < 15; i++ {
+		var r1, c1, r2, c2 int
+		switch {
+		case i < 6:
+			r1, c1 = 8, i
+		case i == 6:
+			r1, c1 = 8, 7
+		case i == 7:
+			r1, c1 = 8, 8
+		case i == 8:
+			r1, c1 = 7, 8
+		default:
+			r1, c1 = 14-i, 8
+		}
+		if i < 7 {
+			r2, c2 = n-1-i, 8
+		} else {
+			r2, c2 = 8, n-15+i
+		}
+		first, second = first<<1, second<<1
+		if modules[r1][c1] {
+			first |= 1
+		}
+		if modules[r2][c2] {
+			second |= 1
+		}
+	}
+	best := 16
+	for l, masks := range typeInformationTable {
+		for m, bits := range masks {
+			for _, read := range []int{first, second} {
+				if d := bitCount(read ^ bits); d < best {
+					best, level, mask = d, l, m
+				}
+			}
+		}
+	}
+	// the format information code corrects up to three bit errors
+	if best > 3 {
+		return 0, 0, errFormat
+	}
+	return
+}
+
+func bitCount(x int) (count int) {
+	for ; x != 0; x &= x - 1 {
+		count++
+	}
+	return
+}
+
+// functionModules marks the modules of a QR code of the version that are not data: the finder patterns and their
+// separators, the timing and alignment patterns, the format and version information and the dark module
+func functionModules(version int) [][]bool {
+	n := 17 + version*4
+	function := make([][]bool, n)
+	for r := range function {
+		function[r] = make([]bool, n)
+	}
+	fill := func(top, left, height, width int) {
+		for r := top; r < top+height; r++ {
+			for c := left; c < left+width; c++ {
+				function[r][c] = true
+			}
+		}
+	}
+	// finder patterns and separators, with the format information next to them
+	fill(0, 0, 9, 9)
+	fill(0, n-8, 9, 8)
+	fill(n-8, 0, 8, 9)
+	// timing patterns
+	fill(6, 0, 1, n)
+	fill(0, 6, n, 1)
+	pat := positionAdjustPatternTable[version]
+	for _, r := range pat {
+		for _, c := range pat {
+			// alignment patterns are left out where they would overlap the finder patterns
+			if (r < 9 && c < 9) || (r < 9 && c > n-9) || (r > n-9 && c < 9) {
+				continue
+			}
+			fill(r-2, c-2, 5, 5)
+		}
+	}
+	if version >= 7 {
+		fill(n-11, 0, 3, 6)
+		fill(0, n-11, 6, 3)
+	}
+	return function
+}
+
+// masked returns whether the data module at the row and column is inverted by the mask
+func masked(mask, r, c int) bool {
+	switch mask {
+	case 0:
+		return (r+c)%2 == 0
+	case 1:
+		return r%2 == 0
+	case 2:
+		return c%3 == 0
+	case 3:
+		return (r+c)%3 == 0
+	case 4:
+		return (r/2+c/3)%2 == 0
+	case 5:
+		return r*c%2+r*c%3 == 0
+	case 6:
+		return (r*c%2+r*c%3)%2 == 0
+	default:
+		return ((r+c)%2+r*c%3)%2 == 0
+	}
+}
+
+// readSegments decodes the numeric, alphanumeric and byte segments of the data bit stream
+func readSegments(stream bitBuffer, version int) (data string, err error) {
+	alphanum := make([]byte, len(alphanumTable))
+	for c, v := range alphanumTable {
+		alphanum[v] = c
+	}
+	pos := 0
+	read := func(n int) (v int, ok bool) {
+		if pos+n > len(stream) {
+			return 0, false
+		}
+		for i := 0; i < n; i++ {
+			v <<= 1
+			if stream[pos+i] {
+				v |= 1
+			}
+		}
+		pos += n
+		return v, true
+	}
+	countBits := func(small, medium, large int) int {
+		switch {
+		case version <= 9:
+			return small
+		case version <= 26:
+			return medium
+		default:
+			return large
+		}
+	}
+	var out strings.Builder
+	for {
+		mode, ok := read(4)
+		if !ok || mode == 0 {
+			return out.String(), nil
+		}
+		var count int
+		switch mode {
+		case 1:
+			if count, ok = read(countBits(10, 12, 14)); !ok {
+				return "", errTruncated
+			}
+			for ; count > 0; count -= 3 {
+				digits, width := 3, 10
+				if count == 2 {
+					digits, width = 2, 7
+				} else if count == 1 {
+					digits, width = 1, 4
+				}
+				var v int
+				if v, ok = read(width); !ok {
+					return "", errTruncated
+				}
+				s := make([]byte, digits)
+				for i := digits - 1; i >= 0; i-- {
+					s[i] = byte('0' + v%10)
+					v /= 10
+				}
+				out.Write(s)
+			}
+		case 2:
+			if count, ok = read(countBits(9, 11, 13)); !ok {
+				return "", errTruncated
+			}
+			for ; count > 1; count -= 2 {
+				var v int
+				if v, ok = read(11); !ok || v/45 >= len(alphanum) {
+					return "", errTruncated
+				}
+				out.WriteByte(alphanum[v/45])
+				out.WriteByte(alphanum[v%45])
+			}
+			if count == 1 {
+				var v int
+				if v, ok = read(6); !ok || v >= len(alphanum) {
+					return "", errTruncated
+				}
+				out.WriteByte(alphanum[v])
+			}
+		case 4:
+			if count, ok = read(countBits(8, 16, 16)); !ok {
+				return "", errTruncated
+			}
+			for ; count > 0; count-- {
+				var v int
+				if v, ok = read(8); !ok {
+					return "", errTruncated
+				}
+				out.WriteByte(byte(v))
+			}
+		case 7:
+			// an extended channel interpretation only says how the bytes that follow are encoded, and the data is
+			// returned as it is
+			if _, ok = read(8); !ok {
+				return "", errTruncated
+			}
+		default:
+			return "", errUnsupported
+		}
+	}
+}
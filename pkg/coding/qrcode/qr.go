@@ -93,21 +93,21 @@ var errorCorrectionTable = []map[ECLevel][]int{
 	{ECLevelL: {24, 2, 97, 0, 0}, ECLevelM: {22, 2, 38, 2, 39}, ECLevelQ: {22, 4, 18, 2, 19}, ECLevelH: {26, 4, 14, 2, 15}},
 	{ECLevelL: {30, 2, 116, 0, 0}, ECLevelM: {22, 3, 36, 2, 37}, ECLevelQ: {20, 4, 16, 4, 17}, ECLevelH: {24, 4, 12, 4, 13}},
 	{ECLevelL: {18, 2, 68, 2, 69}, ECLevelM: {26, 4, 43, 1, 44}, ECLevelQ: {24, 6, 19, 2, 20}, ECLevelH: {28, 6, 15, 2, 16}}, // version 10
-	{ECLevelL: {20, 4, 81, 0, 0}, ECLevelM: {31, 1, 50, 4, 51}, ECLevelQ: {28, 4, 22, 4, 23}, ECLevelH: {24, 3, 12, 8, 13}},
+	{ECLevelL: {20, 4, 81, 0, 0}, ECLevelM: {30, 1, 50, 4, 51}, ECLevelQ: {28, 4, 22, 4, 23}, ECLevelH: {24, 3, 12, 8, 13}},
 	{ECLevelL: {24, 2, 92, 2, 93}, ECLevelM: {22, 6, 36, 2, 37}, ECLevelQ: {26, 4, 20, 6, 21}, ECLevelH: {28, 7, 14, 4, 15}},
 	{ECLevelL: {26, 4, 107, 0, 0}, ECLevelM: {22, 8, 37, 1, 38}, ECLevelQ: {24, 8, 20, 4, 21}, ECLevelH: {22, 12, 11, 4, 12}},
 	{ECLevelL: {30, 3, 115, 1, 116}, ECLevelM: {24, 4, 40, 5, 41}, ECLevelQ: {20, 11, 16, 5, 17}, ECLevelH: {24, 11, 12, 5, 13}},
-	{ECLevelL: {22, 5, 87, 1, 88}, ECLevelM: {24, 5, 41, 5, 42}, ECLevelQ: {30, 5, 24, 7, 25}, ECLevelH: {30, 5, 24, 7, 25}},
+	{ECLevelL: {22, 5, 87, 1, 88}, ECLevelM: {24, 5, 41, 5, 42}, ECLevelQ: {30, 5, 24, 7, 25}, ECLevelH: {24, 11, 12, 7, 13}},
 	{ECLevelL: {24, 5, 98, 1, 99}, ECLevelM: {28, 7, 45, 3, 46}, ECLevelQ: {24, 15, 19, 2, 20}, ECLevelH: {30, 3, 15, 13, 16}},
 	{ECLevelL: {28, 1, 107, 5, 108}, ECLevelM: {28, 10, 46, 1, 47}, ECLevelQ: {28, 1, 22, 15, 23}, ECLevelH: {28, 2, 14, 17, 15}},
 	{ECLevelL: {30, 5, 120, 1, 121}, ECLevelM: {26, 9, 43, 4, 44}, ECLevelQ: {28, 17, 22, 1, 23}, ECLevelH: {28, 2, 14, 19, 15}},
 	{ECLevelL: {28, 3, 113, 4, 114}, ECLevelM: {26, 3, 44, 11, 45}, ECLevelQ: {26, 17, 21, 4, 22}, ECLevelH: {26, 9, 13, 16, 14}},
-	{ECLevelL: {28, 3, 107, 6, 108}, ECLevelM: {26, 3, 41, 13, 42}, ECLevelQ: {30, 15, 24, 5, 25}, ECLevelH: {28, 15, 15, 10, 16}}, // version 20
+	{ECLevelL: {28, 3, 107, 5, 108}, ECLevelM: {26, 3, 41, 13, 42}, ECLevelQ: {30, 15, 24, 5, 25}, ECLevelH: {28, 15, 15, 10, 16}}, // version 20
 	{ECLevelL: {28, 4, 116, 4, 117}, ECLevelM: {26, 17, 42, 0, 0}, ECLevelQ: {28, 17, 22, 6, 23}, ECLevelH: {30, 19, 16, 6, 17}},
 	{ECLevelL: {28, 2, 111, 7, 112}, ECLevelM: {28, 17, 46, 0, 0}, ECLevelQ: {30, 7, 24, 16, 25}, ECLevelH: {24, 34, 13, 0, 0}},
 	{ECLevelL: {30, 4, 121, 5, 122}, ECLevelM: {28, 4, 47, 14, 48}, ECLevelQ: {30, 11, 24, 14, 25}, ECLevelH: {30, 16, 15, 14, 16}},
 	{ECLevelL: {30, 6, 117, 4, 118}, ECLevelM: {28, 6, 45, 14, 46}, ECLevelQ: {30, 11, 24, 16, 25}, ECLevelH: {30, 30, 16, 2, 17}},
-	{ECLevelL: {26, 8, 106, 4, 107}, ECLevelM: {28, 8, 47, 13, 48}, ECLevelQ: {230, 7, 24, 22, 25}, ECLevelH: {30, 22, 15, 13, 16}},
+	{ECLevelL: {26, 8, 106, 4, 107}, ECLevelM: {28, 8, 47, 13, 48}, ECLevelQ: {30, 7, 24, 22, 25}, ECLevelH: {30, 22, 15, 13, 16}},
 	{ECLevelL: {28, 10, 114, 2, 115}, ECLevelM: {28, 19, 46, 4, 47}, ECLevelQ: {28, 28, 22, 6, 23}, ECLevelH: {30, 33, 16, 4, 17}},
 	{ECLevelL: {30, 8, 122, 4, 123}, ECLevelM: {28, 22, 45, 3, 46}, ECLevelQ: {30, 8, 23, 26, 24}, ECLevelH: {30, 12, 15, 28, 16}},
 	{ECLevelL: {30, 3, 117, 10, 118}, ECLevelM: {28, 3, 45, 23, 46}, ECLevelQ: {30, 4, 24, 31, 25}, ECLevelH: {30, 11, 15, 31, 16}},
@@ -116,7 +116,7 @@ var errorCorrectionTable = []map[ECLevel][]int{
 	{ECLevelL: {30, 13, 115, 3, 116}, ECLevelM: {28, 2, 46, 29, 47}, ECLevelQ: {30, 42, 24, 1, 25}, ECLevelH: {30, 23, 15, 28, 16}},
 	{ECLevelL: {30, 17, 115, 0, 0}, ECLevelM: {28, 10, 46, 23, 47}, ECLevelQ: {30, 10, 24, 35, 25}, ECLevelH: {30, 19, 15, 35, 16}},
 	{ECLevelL: {30, 17, 115, 1, 116}, ECLevelM: {28, 14, 46, 21, 47}, ECLevelQ: {30, 29, 24, 19, 25}, ECLevelH: {30, 11, 15, 46, 16}},
-	{ECLevelL: {30, 13, 115, 6, 116}, ECLevelM: {28, 14, 46, 13, 47}, ECLevelQ: {30, 44, 24, 7, 25}, ECLevelH: {30, 59, 16, 1, 17}},
+	{ECLevelL: {30, 13, 115, 6, 116}, ECLevelM: {28, 14, 46, 23, 47}, ECLevelQ: {30, 44, 24, 7, 25}, ECLevelH: {30, 59, 16, 1, 17}},
 	{ECLevelL: {30, 12, 121, 7, 122}, ECLevelM: {28, 12, 47, 26, 48}, ECLevelQ: {30, 39, 24, 14, 25}, ECLevelH: {30, 22, 15, 41, 16}},
 	{ECLevelL: {30, 6, 121, 14, 122}, ECLevelM: {28, 6, 47, 34, 48}, ECLevelQ: {30, 46, 24, 10, 25}, ECLevelH: {30, 2, 15, 64, 16}},
 	{ECLevelL: {30, 17, 122, 4, 123}, ECLevelM: {28, 29, 46, 14, 47}, ECLevelQ: {30, 49, 24, 10, 25}, ECLevelH: {30, 24, 15, 46, 16}},
@@ -125,46 +125,6 @@ var errorCorrectionTable = []map[ECLevel][]int{
 	{ECLevelL: {30, 19, 118, 6, 119}, ECLevelM: {28, 18, 47, 31, 48}, ECLevelQ: {30, 34, 24, 34, 25}, ECLevelH: {30, 20, 15, 61, 16}}, // version 40
 }
 
-// Galois field antilog to log convertion table
-var int2exp = []int{
-	0xff, 0x00, 0x01, 0x19, 0x02, 0x32, 0x1a, 0xc6, 0x03, 0xdf, 0x33, 0xee, 0x1b, 0x68, 0xc7, 0x4b,
-	0x04, 0x64, 0xe0, 0x0e, 0x34, 0x8d, 0xef, 0x81, 0x1c, 0xc1, 0x69, 0xf8, 0xc8, 0x08, 0x4c, 0x71,
-	0x05, 0x8a, 0x65, 0x2f, 0xe1, 0x24, 0x0f, 0x21, 0x35, 0x93, 0x8e, 0xda, 0xf0, 0x12, 0x82, 0x45,
-	0x1d, 0xb5, 0xc2, 0x7d, 0x6a, 0x27, 0xf9, 0xb9, 0xc9, 0x9a, 0x09, 0x78, 0x4d, 0xe4, 0x72, 0xa6,
-	0x06, 0xbf, 0x8b, 0x62, 0x66, 0xdd, 0x30, 0xfd, 0xe2, 0x98, 0x25, 0xb3, 0x10, 0x91, 0x22, 0x88,
-	0x36, 0xd0, 0x94, 0xce, 0x8f, 0x96, 0xdb, 0xbd, 0xf1, 0xd2, 0x13, 0x5c, 0x83, 0x38, 0x46, 0x40,
-	0x1e, 0x42, 0xb6, 0xa3, 0xc3, 0x48, 0x7e, 0x6e, 0x6b, 0x3a, 0x28, 0x54, 0xfa, 0x85, 0xba, 0x3d,
-	0xca, 0x5e, 0x9b, 0x9f, 0x0a, 0x15, 0x79, 0x2b, 0x4e, 0xd4, 0xe5, 0xac, 0x73, 0xf3, 0xa7, 0x57,
-	0x07, 0x70, 0xc0, 0xf7, 0x8c, 0x80, 0x63, 0x0d, 0x67, 0x4a, 0xde, 0xed, 0x31, 0xc5, 0xfe, 0x18,
-	0xe3, 0xa5, 0x99, 0x77, 0x26, 0xb8, 0xb4, 0x7c, 0x11, 0x44, 0x92, 0xd9, 0x23, 0x20, 0x89, 0x2e,
-	0x37, 0x3f, 0xd1, 0x5b, 0x95, 0xbc, 0xcf, 0xcd, 0x90, 0x87, 0x97, 0xb2, 0xdc, 0xfc, 0xbe, 0x61,
-	0xf2, 0x56, 0xd3, 0xab, 0x14, 0x2a, 0x5d, 0x9e, 0x84, 0x3c, 0x39, 0x53, 0x47, 0x6d, 0x41, 0xa2,
-	0x1f, 0x2d, 0x43, 0xd8, 0xb7, 0x7b, 0xa4, 0x76, 0xc4, 0x17, 0x49, 0xec, 0x7f, 0x0c, 0x6f, 0xf6,
-	0x6c, 0xa1, 0x3b, 0x52, 0x29, 0x9d, 0x55, 0xaa, 0xfb, 0x60, 0x86, 0xb1, 0xbb, 0xcc, 0x3e, 0x5a,
-	0xcb, 0x59, 0x5f, 0xb0, 0x9c, 0xa9, 0xa0, 0x51, 0x0b, 0xf5, 0x16, 0xeb, 0x7a, 0x75, 0x2c, 0xd7,
-	0x4f, 0xae, 0xd5, 0xe9, 0xe6, 0xe7, 0xad, 0xe8, 0x74, 0xd6, 0xf4, 0xea, 0xa8, 0x50, 0x58, 0xaf,
-}
-
-// Galois field log to antilog convertion table
-var exp2int = []int{
-	0x01, 0x02, 0x04, 0x08, 0x10, 0x20, 0x40, 0x80, 0x1d, 0x3a, 0x74, 0xe8, 0xcd, 0x87, 0x13, 0x26,
-	0x4c, 0x98, 0x2d, 0x5a, 0xb4, 0x75, 0xea, 0xc9, 0x8f, 0x03, 0x06, 0x0c, 0x18, 0x30, 0x60, 0xc0,
-	0x9d, 0x27, 0x4e, 0x9c, 0x25, 0x4a, 0x94, 0x35, 0x6a, 0xd4, 0xb5, 0x77, 0xee, 0xc1, 0x9f, 0x23,
-	0x46, 0x8c, 0x05, 0x0a, 0x14, 0x28, 0x50, 0xa0, 0x5d, 0xba, 0x69, 0xd2, 0xb9, 0x6f, 0xde, 0xa1,
-	0x5f, 0xbe, 0x61, 0xc2, 0x99, 0x2f, 0x5e, 0xbc, 0x65, 0xca, 0x89, 0x0f, 0x1e, 0x3c, 0x78, 0xf0,
-	0xfd, 0xe7, 0xd3, 0xbb, 0x6b, 0xd6, 0xb1, 0x7f, 0xfe, 0xe1, 0xdf, 0xa3, 0x5b, 0xb6, 0x71, 0xe2,
-	0xd9, 0xaf, 0x43, 0x86, 0x11, 0x22, 0x44, 0x88, 0x0d, 0x1a, 0x34, 0x68, 0xd0, 0xbd, 0x67, 0xce,
-	0x81, 0x1f, 0x3e, 0x7c, 0xf8, 0xed, 0xc7, 0x93, 0x3b, 0x76, 0xec, 0xc5, 0x97, 0x33, 0x66, 0xcc,
-	0x85, 0x17, 0x2e, 0x5c, 0xb8, 0x6d, 0xda, 0xa9, 0x4f, 0x9e, 0x21, 0x42, 0x84, 0x15, 0x2a, 0x54,
-	0xa8, 0x4d, 0x9a, 0x29, 0x52, 0xa4, 0x55, 0xaa, 0x49, 0x92, 0x39, 0x72, 0xe4, 0xd5, 0xb7, 0x73,
-	0xe6, 0xd1, 0xbf, 0x63, 0xc6, 0x91, 0x3f, 0x7e, 0xfc, 0xe5, 0xd7, 0xb3, 0x7b, 0xf6, 0xf1, 0xff,
-	0xe3, 0xdb, 0xab, 0x4b, 0x96, 0x31, 0x62, 0xc4, 0x95, 0x37, 0x6e, 0xdc, 0xa5, 0x57, 0xae, 0x41,
-	0x82, 0x19, 0x32, 0x64, 0xc8, 0x8d, 0x07, 0x0e, 0x1c, 0x38, 0x70, 0xe0, 0xdd, 0xa7, 0x53, 0xa6,
-	0x51, 0xa2, 0x59, 0xb2, 0x79, 0xf2, 0xf9, 0xef, 0xc3, 0x9b, 0x2b, 0x56, 0xac, 0x45, 0x8a, 0x09,
-	0x12, 0x24, 0x48, 0x90, 0x3d, 0x7a, 0xf4, 0xf5, 0xf7, 0xf3, 0xfb, 0xeb, 0xcb, 0x8b, 0x0b, 0x16,
-	0x2c, 0x58, 0xb0, 0x7d, 0xfa, 0xe9, 0xcf, 0x83, 0x1b, 0x36, 0x6c, 0xd8, 0xad, 0x47, 0x8e, 0x00,
-}
-
 var alphanumTable = map[byte]int{
 	'0': 0, '1': 1, '2': 2, '3': 3, '4': 4, '5': 5, '6': 6, '7': 7, '8': 8, '9': 9,
 	'A': 10, 'B': 11, 'C': 12, 'D': 13, 'E': 14, 'F': 15, 'G': 16, 'H': 17, 'I': 18, 'J': 19,
@@ -209,17 +169,19 @@ func Encode(data string, version int, level ECLevel) (image.Image, error) {
 // Module count on a side
 func (qr *Qrcode) len() int { return qr.Version*4 + (7+1)*2 + 1 }
 
-func (qr *Qrcode) Encode() (image.Image, error) {
+// Modules returns the modules of the QR code of the data, indexed by row and then column, without the quiet zone, for
+// drawing the code at any size
+func Modules(data string, version int, level ECLevel) ([][]bool, error) {
 
-	// check version
-	if qr.Version < 0 || 40 < qr.Version {
-		return nil, errInvalidVersion
-	}
+	qr := new(Qrcode)
+	qr.data = data
+	qr.Version = version
+	qr.Level = level
 
-	// check level
-	if qr.Level != 0 && len(errorCorrectionTable[1][qr.Level]) == 0 {
-		return nil, errInvalidLevel
-	}
+	return qr.modules()
+}
+
+func (qr *Qrcode) Encode() (image.Image, error) {
 
 	// check module size
 	if qr.ModuleSize < 1 {
@@ -231,45 +193,9 @@ func (qr *Qrcode) Encode() (image.Image, error) {
 		return nil, errInvalidQuietZoneWidth
 	}
 
-	// set encoding mode (kanji mode not supported)
-	qr.selectMode()
-
-	// set version & level
-	qr.selectVersionLevel()
-	if qr.Version == 0 || qr.Level == 0 {
-		return nil, errInvalidDataSize
-	}
-
-	// initialize qrcode
-	for i := 0; i < qr.len(); i++ {
-		s := make([]int, qr.len())
-		qr.module = append(qr.module, s)
-	}
-
-	// place pattern to qr.module
-	qr.placePatterns()
-
-	// encode data -> qr.encodedData
-	qr.encodeData()
-
-	// place encoded data to qr.module
-	qr.mapData()
-
-	// mask
-	qr.maskData()
-
-	// [][]int to [][]bool
-	module := make([][]bool, 0)
-	for _, row := range qr.module {
-		r := make([]bool, qr.len())
-		for c, cell := range row {
-			if cell > 0 {
-				r[c] = true
-			} else {
-				r[c] = false
-			}
-		}
-		module = append(module, r)
+	module, err := qr.modules()
+	if err != nil {
+		return nil, err
 	}
 
 	// quiet zone
@@ -322,6 +248,62 @@ func (qr *Qrcode) Encode() (image.Image, error) {
 	return rgba, nil
 }
 
+// modules encodes the data into the modules of the QR code
+func (qr *Qrcode) modules() ([][]bool, error) {
+
+	// check version
+	if qr.Version < 0 || 40 < qr.Version {
+		return nil, errInvalidVersion
+	}
+
+	// check level
+	if qr.Level != 0 && len(errorCorrectionTable[1][qr.Level]) == 0 {
+		return nil, errInvalidLevel
+	}
+
+	// set encoding mode (kanji mode not supported)
+	qr.selectMode()
+
+	// set version & level
+	qr.selectVersionLevel()
+	if qr.Version == 0 || qr.Level == 0 {
+		return nil, errInvalidDataSize
+	}
+
+	// initialize qrcode
+	for i := 0; i < qr.len(); i++ {
+		s := make([]int, qr.len())
+		qr.module = append(qr.module, s)
+	}
+
+	// place pattern to qr.module
+	qr.placePatterns()
+
+	// encode data -> qr.encodedData
+	qr.encodeData()
+
+	// place encoded data to qr.module
+	qr.mapData()
+
+	// mask
+	qr.maskData()
+
+	// [][]int to [][]bool
+	module := make([][]bool, 0)
+	for _, row := range qr.module {
+		r := make([]bool, qr.len())
+		for c, cell := range row {
+			if cell > 0 {
+				r[c] = true
+			} else {
+				r[c] = false
+			}
+		}
+		module = append(module, r)
+	}
+	return module, nil
+}
+
 // qrcode version information table
 func (qr *Qrcode) table() []int         { return errorCorrectionTable[qr.Version][qr.Level] }
 func (qr *Qrcode) ecCodeWords() int     { return qr.table()[0] }
@@ -574,36 +556,13 @@ func (qr *Qrcode) errorCorrectionCode(data []byte, block, blockIndex int, c chan
 	totalBlkCount := qr.blkCount()[0] + qr.blkCount()[1] // total blocks
 	dcw := qr.totalDataCodeWords()                       // total data code words
 
-	// message polynominal
-	msg := polynominal{}
-	for i := 0; i < len(data); i++ {
-		x := len(data) + eccw - 1 - i
-		msg[x] = int2exp[data[i]]
-	}
-
-	// generator polynominal
-	gen := polynominal{0: 0}
-	for i := 0; i < eccw; i++ {
-		p := polynominal{1: 0, 0: i}
-		gen = gen.multiply(p)
-	}
-
-	// error collection code words
-	for i := len(data) - 1; i >= 0; i-- {
-		p := polynominal{i: msg[i+eccw]}
-		g := gen.multiply(p)
-		msg = msg.xor(g)
-		delete(msg, i+eccw)
-	}
-
-	ec := make([]byte, eccw)
-	for i := 0; i < eccw; i++ {
-		ec[i] = byte(exp2int[msg[eccw-1-i]])
-	}
+	ec := rsRemainder(data, eccw)
 
 	for i, v := range data {
 		j := blockIndex + i*totalBlkCount
-		if j >= dcw {
+		// the last data code word of the longer blocks of the second group is interleaved after the last of all the
+		// others, so the shorter blocks don't take up places there
+		if i == qr.dataCodeWords()[0] {
 			j -= qr.blkCount()[0]
 		}
 		qr.encodedData[j] = v
@@ -1077,54 +1036,3 @@ func (p *bitBuffer) bytes() []byte {
 	}
 	return bytebuf.Bytes()
 }
-
-// polynominal ( Galois field 256 )
-// used to calculate error correction code
-// (a^x)*(x^25) + (a^y)*(x^24) + (a^z)*(x^23) + .... => {25:x,24:y,23:z,.....}
-type polynominal map[int]int
-
-func (p0 polynominal) multiply(p1 polynominal) polynominal {
-	poly := polynominal{}
-	for x1, a1 := range p1 {
-		for x0, a0 := range p0 {
-			x := x1 + x0
-			for x >= 255 {
-				x -= 255
-				x = (x >> 8) + (x & 255)
-			}
-			a := a1 + a0
-			for a >= 255 {
-				a -= 255
-				a = (a >> 8) + (a & 255)
-			}
-			if poly[x] == 0 {
-				poly[x] = exp2int[a]
-			} else {
-				anti := poly[x] ^ exp2int[a]
-				poly[x] = anti
-			}
-		}
-	}
-	for x, a := range poly {
-		poly[x] = int2exp[a]
-	}
-	return poly
-}
-
-func (p0 polynominal) xor(p1 polynominal) polynominal {
-	poly := polynominal{}
-	for x0, a0 := range p0 {
-		poly[x0] = exp2int[a0]
-	}
-	for x1, a1 := range p1 {
-		if poly[x1] == 0 {
-			poly[x1] = exp2int[a1]
-		} else {
-			poly[x1] = poly[x1] ^ exp2int[a1]
-		}
-	}
-	for x, a := range poly {
-		poly[x] = int2exp[a]
-	}
-	return poly
-}
@@ -0,0 +1,68 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRSRemainder checks the error correction codewords of the 1-M code of "HELLO WORLD"
+func TestRSRemainder(t *testing.T) {
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	got := rsRemainder(data, len(want))
+	if !bytes.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	block := append(append([]byte{}, data...), got...)
+	if !rsValid(block, len(want)) {
+		t.Fatal("valid block failed the check")
+	}
+	block[3] ^= 1
+	if rsValid(block, len(want)) {
+		t.Fatal("damaged block passed the check")
+	}
+}
+
+// TestRoundTrip encodes as much data of each mode as fits in every version at each error correction level and decodes
+// it again, which covers the versions with blocks of two sizes
+func TestRoundTrip(t *testing.T) {
+	modes := map[string]string{"numeric": "7", "alphanum": "A", "8bitbyte": "a"}
+	for _, level := range []ECLevel{ECLevelL, ECLevelM, ECLevelQ, ECLevelH} {
+		for mode, unit := range modes {
+			for version := 1; version <= 40; version++ {
+				data := strings.Repeat(unit, maxDataSize(version, level, mode))
+				modules, err := Modules(data, version, level)
+				if err != nil {
+					t.Fatalf("level %d version %d %s: %v", level, version, mode, err)
+				}
+				var decoded string
+				if decoded, err = DecodeModules(modules); err != nil {
+					t.Fatalf("level %d version %d %s: %v", level, version, mode, err)
+				}
+				if decoded != data {
+					t.Fatalf("level %d version %d %s: decoded %q", level, version, mode, decoded)
+				}
+			}
+		}
+	}
+}
+
+// TestDecodeImage decodes the image of a payment URI made by Encode, scaled up as it is for display
+func TestDecodeImage(t *testing.T) {
+	data := "parallelcoin:aBxhMcrUcmXQzrqC5AXcQUPHvCbMaycrPd?amount=1.5&label=Coffee"
+	for _, size := range []int{1, 3, 8} {
+		qr := &Qrcode{data: data, ModuleSize: size, QuietZoneWidth: 4}
+		img, err := qr.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded string
+		if decoded, err = Decode(img); err != nil {
+			t.Fatalf("module size %d: %v", size, err)
+		}
+		if decoded != data {
+			t.Fatalf("module size %d: decoded %q", size, decoded)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package qrcode
+
+// gfMultiply multiplies in the Galois field of 256 elements that QR codes compute their error correction codes in
+func gfMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11d)
+		z ^= int((y>>uint(i))&1) * int(x)
+	}
+	return byte(z)
+}
+
+// rsValid returns whether a block of data codewords followed by its error correction codewords is a valid Reed-Solomon
+// code word, which it is when the block is zero at each root of the generator polynomial
+func rsValid(block []byte, ecCodeWords int) bool {
+	root := byte(1)
+	for i := 0; i < ecCodeWords; i++ {
+		var s byte
+		for _, b := range block {
+			s = gfMultiply(s, root) ^ b
+		}
+		if s != 0 {
+			return false
+		}
+		root = gfMultiply(root, 2)
+	}
+	return true
+}
+
+// rsRemainder returns the error correction codewords of a block of data codewords, the remainder of the data divided
+// by the generator polynomial whose roots are the first ecCodeWords powers of the field's generator
+func rsRemainder(data []byte, ecCodeWords int) []byte {
+	// the generator polynomial's coefficients from the highest degree down, leaving out the leading one
+	divisor := make([]byte, ecCodeWords)
+	divisor[ecCodeWords-1] = 1
+	root := byte(1)
+	for i := 0; i < ecCodeWords; i++ {
+		for j := range divisor {
+			divisor[j] = gfMultiply(divisor[j], root)
+			if j+1 < len(divisor) {
+				divisor[j] ^= divisor[j+1]
+			}
+		}
+		root = gfMultiply(root, 2)
+	}
+	remainder := make([]byte, ecCodeWords)
+	for _, b := range data {
+		factor := b ^ remainder[0]
+		copy(remainder, remainder[1:])
+		remainder[ecCodeWords-1] = 0
+		for i := range remainder {
+			remainder[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return remainder
+}
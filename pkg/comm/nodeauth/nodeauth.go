@@ -0,0 +1,324 @@
+// Package nodeauth implements authenticated and encrypted peer connections for private networks, where every node has
+// an identity key and only accepts connections with the nodes whose public keys are in its allowlist.
+//
+// The handshake follows the pattern of the Noise protocol framework: both ends exchange ephemeral keys, derive the keys
+// of the session from their Diffie-Hellman secret, and then prove over the encrypted channel that they hold their
+// identity key by signing the hash of the handshake, which binds the proof to the session so it can't be replayed or
+// relayed. Only nodes in the allowlist of the other end get past the handshake, so a private network is not reachable by
+// peers that only learn its magic bytes.
+package nodeauth
+
+import (
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+)
+
+const (
+	// protocolName identifies the version of the handshake, and is hashed into the keys of the session.
+	protocolName = "pod-nodeauth-1"
+	// keySize is the size of a serialized compressed public key.
+	keySize = 33
+	// tagSize is the size of the authentication tag that encryption adds to the data of a frame.
+	tagSize = 16
+	// maxFrameSize is the largest ciphertext of a frame, whose size is sent as a 2 byte integer.
+	maxFrameSize = 1<<16 - 1
+	// maxFramePayload is the largest amount of data sent in a frame.
+	maxFramePayload = maxFrameSize - tagSize
+)
+
+// The roles hashed with the handshake hash for the signatures of each end, so that the signature of one end can't be
+// reflected back as the other.
+var (
+	initiatorRole = []byte("initiator")
+	responderRole = []byte("responder")
+)
+
+var (
+	// ErrNotAllowed is returned by Handshake when the identity key of the other end is not in the allowlist.
+	ErrNotAllowed = errors.New("node key is not in the allowlist")
+	// ErrBadSignature is returned by Handshake when the other end fails to prove it holds its identity key.
+	ErrBadSignature = errors.New("invalid handshake signature")
+)
+
+// LoadOrCreateKey loads the identity key of the node from the file, or creates a new key and saves it to the file when
+// the file doesn't exist yet.
+func LoadOrCreateKey(path string) (key *ec.PrivateKey, created bool, err error) {
+	var b []byte
+	if b, err = ioutil.ReadFile(path); err == nil {
+		var keyBytes []byte
+		if keyBytes, err = hex.DecodeString(strings.TrimSpace(string(b))); err != nil {
+			return nil, false, fmt.Errorf("can't decode the node key in %s: %v", path, err)
+		}
+		key, _ = ec.PrivKeyFromBytes(ec.S256(), keyBytes)
+		return key, false, nil
+	}
+	if key, err = ec.NewPrivateKey(ec.S256()); err != nil {
+		return nil, false, err
+	}
+	if err = ioutil.WriteFile(path, []byte(hex.EncodeToString(key.Serialize())), 0600); err != nil {
+		return nil, false, err
+	}
+	return key, true, nil
+}
+
+// KeyString returns the hex encoding of the compressed public key, which is how node keys are given in the allowlist.
+func KeyString(key *ec.PublicKey) string {
+	return hex.EncodeToString(key.SerializeCompressed())
+}
+
+// Allowlist is the set of the identity keys of the nodes that are allowed to connect, by their compressed public key.
+type Allowlist map[[keySize]byte]struct{}
+
+// ParseAllowlist returns the allowlist of the hex encoded public keys.
+func ParseAllowlist(keys []string) (Allowlist, error) {
+	allowed := make(Allowlist, len(keys))
+	for _, s := range keys {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node key %q: %v", s, err)
+		}
+		pub, err := ec.ParsePubKey(b, ec.S256())
+		if err != nil {
+			return nil, fmt.Errorf("invalid node key %q: %v", s, err)
+		}
+		var k [keySize]byte
+		copy(k[:], pub.SerializeCompressed())
+		allowed[k] = struct{}{}
+	}
+	return allowed, nil
+}
+
+// Allowed returns whether the node with the identity key is in the allowlist.
+func (a Allowlist) Allowed(key *ec.PublicKey) bool {
+	var k [keySize]byte
+	copy(k[:], key.SerializeCompressed())
+	_, ok := a[k]
+	return ok
+}
+
+// Conn is a connection that completed the handshake. Everything written to it is encrypted and authenticated with the
+// keys of the session.
+type Conn struct {
+	net.Conn
+	remoteKey *ec.PublicKey
+	readMtx   sync.Mutex
+	recv      cipher.AEAD
+	recvNonce uint64
+	readBuf   []byte
+	writeMtx  sync.Mutex
+	send      cipher.AEAD
+	sendNonce uint64
+}
+
+// Handshake authenticates the other end of the connection with the identity key of the node, as the initiator for
+// outbound connections, and returns the encrypted connection. The handshake must complete within the timeout. The
+// connection is not closed when the handshake fails.
+func Handshake(conn net.Conn, key *ec.PrivateKey, allowed Allowlist, initiator bool,
+	timeout time.Duration) (c *Conn, err error) {
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	ephemeral, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		return nil, err
+	}
+	// The initiator sends its ephemeral key first, and the responder answers with its own.
+	local := ephemeral.PubKey().SerializeCompressed()
+	remote := make([]byte, keySize)
+	if initiator {
+		if _, err = conn.Write(local); err == nil {
+			_, err = io.ReadFull(conn, remote)
+		}
+	} else {
+		if _, err = io.ReadFull(conn, remote); err == nil {
+			_, err = conn.Write(local)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	remoteEphemeral, err := ec.ParsePubKey(remote, ec.S256())
+	if err != nil {
+		return nil, err
+	}
+	// The handshake hash covers the ephemeral keys of both ends in the order they were sent.
+	h := sha256.New()
+	h.Write([]byte(protocolName))
+	if initiator {
+		h.Write(local)
+		h.Write(remote)
+	} else {
+		h.Write(remote)
+		h.Write(local)
+	}
+	handshakeHash := h.Sum(nil)
+	c = &Conn{Conn: conn}
+	if c.send, c.recv, err = sessionCiphers(ec.GenerateSharedSecret(ephemeral, remoteEphemeral), handshakeHash,
+		initiator); err != nil {
+		return nil, err
+	}
+	localRole, remoteRole := initiatorRole, responderRole
+	if !initiator {
+		localRole, remoteRole = responderRole, initiatorRole
+	}
+	// The initiator proves its identity first, so a responder only reveals its identity to the nodes it allows.
+	if initiator {
+		if err = c.sendIdentity(key, handshakeHash, localRole); err == nil {
+			c.remoteKey, err = c.recvIdentity(allowed, handshakeHash, remoteRole)
+		}
+	} else {
+		if c.remoteKey, err = c.recvIdentity(allowed, handshakeHash, remoteRole); err == nil {
+			err = c.sendIdentity(key, handshakeHash, localRole)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// sessionCiphers derives the ciphers that each end sends with from the Diffie-Hellman secret of the ephemeral keys.
+func sessionCiphers(secret, handshakeHash []byte, initiator bool) (send, recv cipher.AEAD, err error) {
+	keys := make([]byte, 2*chacha20poly1305.KeySize)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, secret, handshakeHash, []byte(protocolName)), keys); err != nil {
+		return nil, nil, err
+	}
+	initiatorKey, responderKey := keys[:chacha20poly1305.KeySize], keys[chacha20poly1305.KeySize:]
+	if !initiator {
+		initiatorKey, responderKey = responderKey, initiatorKey
+	}
+	if send, err = chacha20poly1305.New(initiatorKey); err != nil {
+		return nil, nil, err
+	}
+	if recv, err = chacha20poly1305.New(responderKey); err != nil {
+		return nil, nil, err
+	}
+	return send, recv, nil
+}
+
+// identityHash is the hash signed by an end of the handshake to prove it holds its identity key.
+func identityHash(handshakeHash, role []byte) []byte {
+	h := sha256.Sum256(append(append([]byte{}, handshakeHash...), role...))
+	return h[:]
+}
+
+// sendIdentity sends the identity key of the node along with its signature of the handshake.
+func (c *Conn) sendIdentity(key *ec.PrivateKey, handshakeHash, role []byte) error {
+	sig, err := key.Sign(identityHash(handshakeHash, role))
+	if err != nil {
+		return err
+	}
+	return c.writeFrame(append(key.PubKey().SerializeCompressed(), sig.Serialize()...))
+}
+
+// recvIdentity receives the identity key of the other end and checks that it signed the handshake and is allowed.
+func (c *Conn) recvIdentity(allowed Allowlist, handshakeHash, role []byte) (*ec.PublicKey, error) {
+	msg, err := c.readFrame()
+	if err != nil {
+		return nil, err
+	}
+	if len(msg) <= keySize {
+		return nil, ErrBadSignature
+	}
+	remoteKey, err := ec.ParsePubKey(msg[:keySize], ec.S256())
+	if err != nil {
+		return nil, err
+	}
+	sig, err := ec.ParseDERSignature(msg[keySize:], ec.S256())
+	if err != nil || !sig.Verify(identityHash(handshakeHash, role), remoteKey) {
+		return nil, ErrBadSignature
+	}
+	if !allowed.Allowed(remoteKey) {
+		return nil, ErrNotAllowed
+	}
+	return remoteKey, nil
+}
+
+// RemoteKey returns the identity key of the node at the other end of the connection.
+func (c *Conn) RemoteKey() *ec.PublicKey {
+	return c.remoteKey
+}
+
+// nonce returns the nonce of the frame with the counter.
+func nonce(counter uint64) []byte {
+	n := make([]byte, chacha20poly1305.NonceSize)
+	binary.LittleEndian.PutUint64(n[chacha20poly1305.NonceSize-8:], counter)
+	return n
+}
+
+// writeFrame encrypts the data, which must fit in a frame, and writes it prefixed with its size.
+func (c *Conn) writeFrame(data []byte) error {
+	frame := make([]byte, 2, 2+len(data)+tagSize)
+	frame = c.send.Seal(frame, nonce(c.sendNonce), data, nil)
+	c.sendNonce++
+	binary.BigEndian.PutUint16(frame, uint16(len(frame)-2))
+	_, err := c.Conn.Write(frame)
+	return err
+}
+
+// readFrame reads the next frame and returns its decrypted data.
+func (c *Conn) readFrame() ([]byte, error) {
+	var size [2]byte
+	if _, err := io.ReadFull(c.Conn, size[:]); err != nil {
+		return nil, err
+	}
+	frame := make([]byte, binary.BigEndian.Uint16(size[:]))
+	if _, err := io.ReadFull(c.Conn, frame); err != nil {
+		return nil, err
+	}
+	data, err := c.recv.Open(frame[:0], nonce(c.recvNonce), frame, nil)
+	if err != nil {
+		return nil, err
+	}
+	c.recvNonce++
+	return data, nil
+}
+
+// Read reads decrypted data from the connection.
+func (c *Conn) Read(b []byte) (n int, err error) {
+	c.readMtx.Lock()
+	defer c.readMtx.Unlock()
+	for len(c.readBuf) == 0 {
+		if c.readBuf, err = c.readFrame(); err != nil {
+			return 0, err
+		}
+	}
+	n = copy(b, c.readBuf)
+	c.readBuf = c.readBuf[n:]
+	return n, nil
+}
+
+// Write encrypts the data and writes it to the connection, in as many frames as it takes.
+func (c *Conn) Write(b []byte) (n int, err error) {
+	c.writeMtx.Lock()
+	defer c.writeMtx.Unlock()
+	for n < len(b) {
+		end := n + maxFramePayload
+		if end > len(b) {
+			end = len(b)
+		}
+		if err = c.writeFrame(b[n:end]); err != nil {
+			return n, err
+		}
+		n = end
+	}
+	return n, nil
+}
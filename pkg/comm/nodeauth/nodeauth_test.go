@@ -0,0 +1,107 @@
+package nodeauth
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+)
+
+// handshake runs the handshake between two nodes over a pipe, returning the connection and error of each end.
+func handshake(initiatorKey, responderKey *ec.PrivateKey, initiatorAllows,
+	responderAllows Allowlist) (ic, rc *Conn, iErr, rErr error) {
+	a, b := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		rc, rErr = Handshake(b, responderKey, responderAllows, false, time.Second)
+		if rErr != nil {
+			b.Close()
+		}
+		close(done)
+	}()
+	ic, iErr = Handshake(a, initiatorKey, initiatorAllows, true, time.Second)
+	if iErr != nil {
+		a.Close()
+	}
+	<-done
+	return
+}
+
+// allow returns the allowlist of the keys.
+func allow(t *testing.T, keys ...*ec.PrivateKey) Allowlist {
+	var s []string
+	for _, k := range keys {
+		s = append(s, KeyString(k.PubKey()))
+	}
+	allowed, err := ParseAllowlist(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return allowed
+}
+
+// TestHandshake ensures that nodes in each other's allowlist complete the handshake and exchange data, and that a node
+// missing from the allowlist of either end is refused.
+func TestHandshake(t *testing.T) {
+	keyA, _ := ec.NewPrivateKey(ec.S256())
+	keyB, _ := ec.NewPrivateKey(ec.S256())
+	keyC, _ := ec.NewPrivateKey(ec.S256())
+	ic, rc, iErr, rErr := handshake(keyA, keyB, allow(t, keyB), allow(t, keyA))
+	if iErr != nil || rErr != nil {
+		t.Fatalf("handshake failed: %v, %v", iErr, rErr)
+	}
+	if !ic.RemoteKey().IsEqual(keyB.PubKey()) || !rc.RemoteKey().IsEqual(keyA.PubKey()) {
+		t.Fatal("handshake authenticated the wrong keys")
+	}
+	// More data than fits in a frame is split over several.
+	msg := bytes.Repeat([]byte{0x5a}, maxFramePayload+100)
+	go func() {
+		if _, err := ic.Write(msg); err != nil {
+			t.Error(err)
+		}
+	}()
+	got := make([]byte, len(msg))
+	if _, err := io.ReadFull(rc, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, msg) {
+		t.Fatal("data changed in transit")
+	}
+	ic.Close()
+	rc.Close()
+	// The responder refuses an initiator it does not allow.
+	if _, _, _, rErr = handshake(keyC, keyB, allow(t, keyB), allow(t, keyA)); rErr != ErrNotAllowed {
+		t.Fatalf("responder got %v, want %v", rErr, ErrNotAllowed)
+	}
+	// The initiator refuses a responder it does not allow.
+	if _, _, iErr, _ = handshake(keyA, keyC, allow(t, keyB), allow(t, keyA)); iErr != ErrNotAllowed {
+		t.Fatalf("initiator got %v, want %v", iErr, ErrNotAllowed)
+	}
+}
+
+// TestLoadOrCreateKey ensures that the node key is created once and then loaded from its file.
+func TestLoadOrCreateKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodeauth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "node_private_key")
+	key, created, err := LoadOrCreateKey(path)
+	if err != nil || !created {
+		t.Fatalf("got created %v, %v, want a new key", created, err)
+	}
+	loaded, created, err := LoadOrCreateKey(path)
+	if err != nil || created {
+		t.Fatalf("got created %v, %v, want the saved key", created, err)
+	}
+	if !bytes.Equal(loaded.Serialize(), key.Serialize()) {
+		t.Fatal("loaded a different key than was saved")
+	}
+}
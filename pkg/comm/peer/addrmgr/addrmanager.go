@@ -1,6 +1,7 @@
 package addrmgr
 
 import (
+	"bytes"
 	"container/list"
 	crand "crypto/rand" // for seeding
 	"encoding/base32"
@@ -12,12 +13,15 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"golang.org/x/crypto/sha3"
+
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
 )
@@ -50,12 +54,31 @@ type serializedKnownAddress struct {
 	LastSuccess int64
 	// no refcount or tried, that is available from context.
 }
+
+// serializedAddrManager is the format of the peers file. The buckets are slices rather than arrays so that a file saved
+// with a different bucket layout can still be read, in which case its addresses are placed in buckets anew.
 type serializedAddrManager struct {
 	Version      int
 	Key          [32]byte
 	Addresses    []*serializedKnownAddress
-	NewBuckets   [newBucketCount][]string // string is NetAddressKey
-	TriedBuckets [triedBucketCount][]string
+	NewBuckets   [][]string // string is NetAddressKey
+	TriedBuckets [][]string
+}
+
+// BucketInfo is the fill level of the buckets of an address manager.
+type BucketInfo struct {
+	// New is the number of addresses in the new buckets, each of which can be in several of them
+	New int
+	// Tried is the number of addresses in the tried buckets
+	Tried int
+	// NewBucketSize is the number of addresses a new bucket can hold
+	NewBucketSize int
+	// TriedBucketSize is the number of addresses a tried bucket can hold
+	TriedBucketSize int
+	// NewBuckets is the number of addresses in each new bucket
+	NewBuckets []int
+	// TriedBuckets is the number of addresses in each tried bucket
+	TriedBuckets []int
 }
 type localAddress struct {
 	na    *wire.NetAddress
@@ -290,11 +313,10 @@ out:
 	Trace("address handler done")
 }
 
-// savePeers saves all the known addresses to a file so they can be read back in at next run.
-func (a *AddrManager) savePeers() {
-	a.mtx.Lock()
-	defer a.mtx.Unlock()
-	// First we make a serialisable datastructure so we can encode it to json.
+// serialize makes a serialisable datastructure of the address manager so it can be encoded to json.
+//
+// This function MUST be called with the address manager locked.
+func (a *AddrManager) serialize() *serializedAddrManager {
 	sam := new(serializedAddrManager)
 	sam.Version = serialisationVersion
 	copy(sam.Key[:], a.key[:])
@@ -313,6 +335,7 @@ func (a *AddrManager) savePeers() {
 		sam.Addresses[i] = ska
 		i++
 	}
+	sam.NewBuckets = make([][]string, len(a.addrNew))
 	for i := range a.addrNew {
 		sam.NewBuckets[i] = make([]string, len(a.addrNew[i]))
 		j := 0
@@ -321,6 +344,7 @@ func (a *AddrManager) savePeers() {
 			j++
 		}
 	}
+	sam.TriedBuckets = make([][]string, len(a.addrTried))
 	for i := range a.addrTried {
 		sam.TriedBuckets[i] = make([]string, a.addrTried[i].Len())
 		j := 0
@@ -330,6 +354,14 @@ func (a *AddrManager) savePeers() {
 			j++
 		}
 	}
+	return sam
+}
+
+// savePeers saves all the known addresses to a file so they can be read back in at next run.
+func (a *AddrManager) savePeers() {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	sam := a.serialize()
 	w, err := os.Create(a.PeersFile)
 	if err != nil {
 		Errorf("error opening file %s: %v", a.PeersFile, err)
@@ -337,7 +369,7 @@ func (a *AddrManager) savePeers() {
 	}
 	enc := json.NewEncoder(w)
 	defer w.Close()
-	if err := enc.Encode(&sam); err != nil {
+	if err := enc.Encode(sam); err != nil {
 		Errorf("failed to encode file %s: %v", a.PeersFile, err)
 		return
 	}
@@ -386,30 +418,31 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 		Error(err)
 		return fmt.Errorf("error reading %s: %v", filePath, err)
 	}
-	if sam.Version != serialisationVersion {
+	return a.restore(&sam)
+}
+
+// restore loads the addresses of a serialized address manager into the empty address manager. The addresses are put
+// back in the buckets they were saved in, unless the file has a different bucket layout, in which case they are placed
+// anew as if they were imported.
+func (a *AddrManager) restore(sam *serializedAddrManager) (err error) {
+	if sam.Version < 1 || sam.Version > serialisationVersion {
 		return fmt.Errorf(
 			"unknown version %v in serialized addrmanager",
 			sam.Version,
 		)
 	}
 	copy(a.key[:], sam.Key[:])
+	if len(sam.NewBuckets) != newBucketCount || len(sam.TriedBuckets) != triedBucketCount {
+		Warnf("serialized addrmanager has %d new and %d tried buckets, placing its addresses anew",
+			len(sam.NewBuckets), len(sam.TriedBuckets))
+		_, err = a.merge(sam)
+		return
+	}
 	for _, v := range sam.Addresses {
-		ka := new(KnownAddress)
-		ka.na, err = a.DeserializeNetAddress(v.Addr)
-		if err != nil {
-			Error(err)
-			return fmt.Errorf("failed to deserialize netaddress "+
-				"%s: %v", v.Addr, err)
-		}
-		ka.srcAddr, err = a.DeserializeNetAddress(v.Src)
-		if err != nil {
-			Error(err)
-			return fmt.Errorf("failed to deserialize netaddress "+
-				"%s: %v", v.Src, err)
+		var ka *KnownAddress
+		if ka, err = a.deserializeKnownAddress(v); err != nil {
+			return
 		}
-		ka.attempts = v.Attempts
-		ka.lastattempt = time.Unix(v.LastAttempt, 0)
-		ka.lastsuccess = time.Unix(v.LastSuccess, 0)
 		a.addrIndex[NetAddressKey(ka.na)] = ka
 	}
 	for i := range sam.NewBuckets {
@@ -452,6 +485,134 @@ func (a *AddrManager) deserializePeers(filePath string) error {
 	return nil
 }
 
+// deserializeKnownAddress converts a serialized known address back to one that is not in any bucket yet.
+func (a *AddrManager) deserializeKnownAddress(v *serializedKnownAddress) (*KnownAddress, error) {
+	var err error
+	ka := new(KnownAddress)
+	ka.na, err = a.DeserializeNetAddress(v.Addr)
+	if err != nil {
+		Error(err)
+		return nil, fmt.Errorf("failed to deserialize netaddress "+
+			"%s: %v", v.Addr, err)
+	}
+	ka.srcAddr, err = a.DeserializeNetAddress(v.Src)
+	if err != nil {
+		Error(err)
+		return nil, fmt.Errorf("failed to deserialize netaddress "+
+			"%s: %v", v.Src, err)
+	}
+	ka.na.Timestamp = time.Unix(v.TimeStamp, 0)
+	ka.attempts = v.Attempts
+	ka.lastattempt = time.Unix(v.LastAttempt, 0)
+	ka.lastsuccess = time.Unix(v.LastSuccess, 0)
+	return ka, nil
+}
+
+// merge adds the routable addresses of a serialized address manager that are not known yet, placing them in the buckets
+// chosen with the key of this address manager. It returns the number of addresses added.
+//
+// This function MUST be called with the address manager locked.
+func (a *AddrManager) merge(sam *serializedAddrManager) (added int, err error) {
+	tried := make(map[string]bool)
+	for i := range sam.TriedBuckets {
+		for _, k := range sam.TriedBuckets[i] {
+			tried[k] = true
+		}
+	}
+	// Deserialize every address before adding any so that a malformed file adds nothing.
+	kas := make([]*KnownAddress, len(sam.Addresses))
+	for i, v := range sam.Addresses {
+		if kas[i], err = a.deserializeKnownAddress(v); err != nil {
+			return
+		}
+	}
+	for i, ka := range kas {
+		key := NetAddressKey(ka.na)
+		if _, ok := a.addrIndex[key]; ok || !IsRoutable(ka.na) {
+			continue
+		}
+		if a.place(key, ka, tried[sam.Addresses[i].Addr]) {
+			added++
+		}
+	}
+	return
+}
+
+// place adds an address that is not in any bucket to its tried bucket if it was tried and there is room in it, so that
+// vetted addresses keep their quality, and otherwise to its new bucket if there is room in it. It reports whether the
+// address was added.
+//
+// This function MUST be called with the address manager locked.
+func (a *AddrManager) place(key string, ka *KnownAddress, tried bool) bool {
+	if tried {
+		bucket := a.getTriedBucket(ka.na)
+		if a.addrTried[bucket].Len() < triedBucketSize {
+			ka.tried = true
+			a.addrTried[bucket].PushBack(ka)
+			a.addrIndex[key] = ka
+			a.nTried++
+			return true
+		}
+	}
+	bucket := a.getNewBucket(ka.na, ka.srcAddr)
+	if len(a.addrNew[bucket]) >= newBucketSize {
+		return false
+	}
+	ka.refs++
+	a.addrNew[bucket][key] = ka
+	a.addrIndex[key] = ka
+	a.nNew++
+	return true
+}
+
+// Export writes all the known addresses to w in the format of the peers file and returns how many were written, so
+// that they can be imported by another address manager.
+func (a *AddrManager) Export(w io.Writer) (int, error) {
+	a.mtx.Lock()
+	sam := a.serialize()
+	a.mtx.Unlock()
+	return len(sam.Addresses), json.NewEncoder(w).Encode(sam)
+}
+
+// Import adds the addresses exported by another address manager, or read from its peers file, that are not known yet.
+// The addresses are placed in the buckets chosen with the key of this address manager, in the tried buckets for those
+// the other address manager had tried when there is room for them. It returns the number of addresses added.
+func (a *AddrManager) Import(r io.Reader) (int, error) {
+	var sam serializedAddrManager
+	if err := json.NewDecoder(r).Decode(&sam); err != nil {
+		Error(err)
+		return 0, fmt.Errorf("error reading addresses: %v", err)
+	}
+	if sam.Version < 1 || sam.Version > serialisationVersion {
+		return 0, fmt.Errorf(
+			"unknown version %v in serialized addrmanager",
+			sam.Version,
+		)
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	return a.merge(&sam)
+}
+
+// BucketInfo returns the number of new and tried addresses and the fill level of each bucket.
+func (a *AddrManager) BucketInfo() (info BucketInfo) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	info.New = a.nNew
+	info.Tried = a.nTried
+	info.NewBucketSize = newBucketSize
+	info.TriedBucketSize = triedBucketSize
+	info.NewBuckets = make([]int, len(a.addrNew))
+	for i := range a.addrNew {
+		info.NewBuckets[i] = len(a.addrNew[i])
+	}
+	info.TriedBuckets = make([]int, len(a.addrTried))
+	for i := range a.addrTried {
+		info.TriedBuckets[i] = a.addrTried[i].Len()
+	}
+	return
+}
+
 // DeserializeNetAddress converts a given address string to a *wire.NetAddress
 func (a *AddrManager) DeserializeNetAddress(addr string) (*wire.NetAddress, error) {
 	host, portStr, err := net.SplitHostPort(addr)
@@ -604,12 +765,49 @@ func (a *AddrManager) reset() {
 	}
 }
 
+// torV3Version is the version byte at the end of a Tor v3 onion address.
+const torV3Version = 3
+
+// noPadding is the base32 encoding of Tor v3 and I2P addresses, which are not padded.
+var noPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// torV3Checksum returns the checksum of the Tor v3 onion address of the given public key.
+func torV3Checksum(pubKey []byte) []byte {
+	h := sha3.New256()
+	h.Write([]byte(".onion checksum"))
+	h.Write(pubKey)
+	h.Write([]byte{torV3Version})
+	return h.Sum(nil)[:2]
+}
+
 // HostToNetAddress returns a netaddress given a host address.
 //
-// If the address is a Tor .onion address this will be taken care of.
+// If the address is a Tor .onion address or an I2P .b32.i2p address this will be taken care of.
 //
 // Else if the host is not an IP address it will be resolved ( via Tor if required).
 func (a *AddrManager) HostToNetAddress(host string, port uint16, services wire.ServiceFlag) (*wire.NetAddress, error) {
+	// Tor v3 address is 56 char base32 of the public key, checksum and version + ".onion"
+	if len(host) == 62 && strings.HasSuffix(host, ".onion") {
+		data, err := noPadding.DecodeString(strings.ToUpper(host[:56]))
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		pubKey := data[:32]
+		if data[34] != torV3Version || !bytes.Equal(data[32:34], torV3Checksum(pubKey)) {
+			return nil, fmt.Errorf("invalid tor v3 address %s", host)
+		}
+		return wire.NewNetAddressNetwork(wire.NetTorV3, pubKey, port, services), nil
+	}
+	// I2P address is 52 char base32 of the destination hash + ".b32.i2p"
+	if len(host) == 60 && strings.HasSuffix(host, ".b32.i2p") {
+		data, err := noPadding.DecodeString(strings.ToUpper(host[:52]))
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		return wire.NewNetAddressNetwork(wire.NetI2P, data, port, services), nil
+	}
 	// Tor address is 16 char base32 + ".onion"
 	var ip net.IP
 	if len(host) == 22 && host[16:] == ".onion" {
@@ -638,8 +836,18 @@ func (a *AddrManager) HostToNetAddress(host string, port uint16, services wire.S
 }
 
 // ipString returns a string for the ip from the provided NetAddress. If the ip is in the range used for Tor addresses
-// then it will be transformed into the relevant .onion address.
+// then it will be transformed into the relevant .onion address, as are Tor v3 addresses, and I2P addresses are
+// transformed into their .b32.i2p address.
 func ipString(na *wire.NetAddress) string {
+	switch {
+	case IsTorV3(na):
+		data := append(append(append([]byte{}, na.Addr...), torV3Checksum(na.Addr)...), torV3Version)
+		return strings.ToLower(noPadding.EncodeToString(data)) + ".onion"
+	case IsI2P(na):
+		return strings.ToLower(noPadding.EncodeToString(na.Addr)) + ".b32.i2p"
+	case na.RequiresAddrV2():
+		return fmt.Sprintf("%v:%x", na.Network, na.Addr)
+	}
 	if IsOnionCatTor(na) {
 		// We know now that na.IP is long enough.
 		s := base32.StdEncoding.EncodeToString(na.IP[6:])
@@ -848,7 +1056,7 @@ func (a *AddrManager) SetServices(addr *wire.NetAddress, services wire.ServiceFl
 // AddLocalAddress adds na to the list of known local addresses to advertise with the given priority.
 func (a *AddrManager) AddLocalAddress(na *wire.NetAddress, priority AddressPriority) error {
 	if !IsRoutable(na) {
-		return fmt.Errorf("address %s is not routable", na.IP)
+		return fmt.Errorf("address %s is not routable", ipString(na))
 	}
 	a.lamtx.Lock()
 	defer a.lamtx.Unlock()
@@ -867,6 +1075,31 @@ func (a *AddrManager) AddLocalAddress(na *wire.NetAddress, priority AddressPrior
 	return nil
 }
 
+// LocalAddress is a local address advertised to peers along with its priority.
+type LocalAddress struct {
+	// Host is the IP of the address, or its .onion name for Tor addresses.
+	Host  string
+	Port  uint16
+	Score AddressPriority
+}
+
+// LocalAddresses returns the known local addresses, ordered by their ip:port keys.
+func (a *AddrManager) LocalAddresses() []LocalAddress {
+	a.lamtx.Lock()
+	defer a.lamtx.Unlock()
+	keys := make([]string, 0, len(a.localAddresses))
+	for key := range a.localAddresses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	addrs := make([]LocalAddress, len(keys))
+	for i, key := range keys {
+		la := a.localAddresses[key]
+		addrs[i] = LocalAddress{Host: ipString(la.na), Port: la.na.Port, Score: la.score}
+	}
+	return addrs
+}
+
 // getReachabilityFrom returns the relative reachability of the provided local address to the provided remote address.
 func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 	const (
@@ -881,8 +1114,14 @@ func getReachabilityFrom(localAddr, remoteAddr *wire.NetAddress) int {
 	if !IsRoutable(remoteAddr) {
 		return Unreachable
 	}
-	if IsOnionCatTor(remoteAddr) {
-		if IsOnionCatTor(localAddr) {
+	if IsI2P(remoteAddr) {
+		if IsI2P(localAddr) {
+			return Private
+		}
+		return Unreachable
+	}
+	if IsTor(remoteAddr) {
+		if IsTor(localAddr) {
 			return Private
 		}
 		if IsRoutable(localAddr) && IsIPv4(localAddr) {
@@ -947,14 +1186,12 @@ func (a *AddrManager) GetBestLocalAddress(remoteAddr *wire.NetAddress) *wire.Net
 		}
 	}
 	if bestAddress != nil {
-		Tracef("suggesting address %s:%d for %s:%d", bestAddress.IP,
-			bestAddress.Port, remoteAddr.IP, remoteAddr.Port)
+		Tracef("suggesting address %s for %s", NetAddressKey(bestAddress), NetAddressKey(remoteAddr))
 	} else {
-		Tracef("no worthy address for %s:%d", remoteAddr.IP,
-			remoteAddr.Port)
+		Tracef("no worthy address for %s", NetAddressKey(remoteAddr))
 		// Send something unroutable if nothing suitable.
 		var ip net.IP
-		if !IsIPv4(remoteAddr) && !IsOnionCatTor(remoteAddr) {
+		if !IsIPv4(remoteAddr) && !IsTor(remoteAddr) {
 			ip = net.IPv6zero
 		} else {
 			ip = net.IPv4zero
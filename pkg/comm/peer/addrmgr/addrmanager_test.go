@@ -1,10 +1,12 @@
 package addrmgr_test
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"net"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -183,6 +185,18 @@ func TestAddLocalAddress(t *testing.T) {
 		}
 	}
 }
+func TestLocalAddresses(t *testing.T) {
+	amgr := addrmgr.New("testlocaladdresses", nil)
+	for _, ip := range []string{"2620:100::1", "192.168.0.100", "204.124.1.1"} {
+		// Unroutable addresses are rejected, which is covered by TestAddLocalAddress.
+		_ = amgr.AddLocalAddress(&wire.NetAddress{IP: net.ParseIP(ip), Port: 11047}, addrmgr.UpnpPrio)
+	}
+	local := amgr.LocalAddresses()
+	if len(local) != 2 || local[0].Host != "204.124.1.1" || local[1].Host != "2620:100::1" ||
+		local[0].Port != 11047 || local[0].Score != addrmgr.UpnpPrio {
+		t.Errorf("got local addresses %+v, want 204.124.1.1 and 2620:100::1", local)
+	}
+}
 func TestAttempt(t *testing.T) {
 	n := addrmgr.New("testattempt", lookupFunc)
 	// Add a new address and get it
@@ -269,6 +283,49 @@ func TestGood(t *testing.T) {
 		t.Errorf("Number of addresses in cache: got %d, want %d", numCache, numAddrs/4)
 	}
 }
+func TestExportImport(t *testing.T) {
+	n := addrmgr.New("testexportimport", lookupFunc)
+	addrs := make([]*wire.NetAddress, 100)
+	var err error
+	for i := range addrs {
+		s := fmt.Sprintf("%d.173.147.%d:11047", i/10+60, i%10+60)
+		if addrs[i], err = n.DeserializeNetAddress(s); err != nil {
+			t.Fatalf("Failed to turn %s into an address: %v", s, err)
+		}
+	}
+	srcAddr := wire.NewNetAddressIPPort(net.IPv4(173, 144, 173, 111), 11047, 0)
+	n.AddAddresses(addrs, srcAddr)
+	for _, addr := range addrs[:10] {
+		n.Good(addr)
+	}
+	info := n.BucketInfo()
+	if info.New+info.Tried != n.NumAddresses() || info.Tried != 10 {
+		t.Fatalf("got %d new and %d tried addresses, want %d of which 10 tried", info.New, info.Tried,
+			n.NumAddresses())
+	}
+	var buf bytes.Buffer
+	exported, err := n.Export(&buf)
+	if err != nil || exported != n.NumAddresses() {
+		t.Fatalf("exported %d addresses with error %v, want %d", exported, err, n.NumAddresses())
+	}
+	dump := buf.Bytes()
+	m := addrmgr.New("testexportimport", lookupFunc)
+	imported, err := m.Import(bytes.NewReader(dump))
+	if err != nil || imported != exported || m.NumAddresses() != exported {
+		t.Fatalf("imported %d of %d addresses with error %v", imported, exported, err)
+	}
+	// Vetted addresses stay tried, in the buckets chosen by the importing address manager.
+	if tried := m.BucketInfo().Tried; tried != 10 {
+		t.Errorf("got %d tried addresses after import, want 10", tried)
+	}
+	// Addresses already known are not imported again.
+	if imported, err = m.Import(bytes.NewReader(dump)); err != nil || imported != 0 {
+		t.Errorf("imported %d addresses again with error %v, want none", imported, err)
+	}
+	if _, err = m.Import(strings.NewReader(`{"Version":99}`)); err == nil {
+		t.Error("imported addresses of an unknown version")
+	}
+}
 func TestGetAddress(t *testing.T) {
 	n := addrmgr.New("testgetaddress", lookupFunc)
 	// Get an address from an empty set (should error)
@@ -421,3 +478,40 @@ func TestNetAddressKey(t *testing.T) {
 		}
 	}
 }
+
+// TestHostToNetAddressAddrV2 ensures Tor v3 and I2P hosts, which only fit in addrv2 messages, are parsed, checked and
+// turned back into the same host.
+func TestHostToNetAddressAddrV2(t *testing.T) {
+	n := addrmgr.New("testhosttonetaddressaddrv2", nil)
+	tests := []struct {
+		host    string
+		network wire.NetworkID
+		valid   bool
+	}{
+		{"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczad.onion", wire.NetTorV3, true},
+		{"duckduckgogg42xjoc72x3sjasowoarfbgcmvfimaftt6twagswzczae.onion", wire.NetTorV3, false},
+		{"ukeu3k5oycgaauneqgtnvselmt4yemvoilkln7jpvamvfx7dnkdq.b32.i2p", wire.NetI2P, true},
+	}
+	for _, test := range tests {
+		na, err := n.HostToNetAddress(test.host, 11047, wire.SFNodeNetwork)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("%s: invalid address was accepted", test.host)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.host, err)
+			continue
+		}
+		if na.Network != test.network || !na.RequiresAddrV2() {
+			t.Errorf("%s: got network %v, want %v", test.host, na.Network, test.network)
+		}
+		if !addrmgr.IsRoutable(na) {
+			t.Errorf("%s: address is not routable", test.host)
+		}
+		if key, want := addrmgr.NetAddressKey(na), test.host+":11047"; key != want {
+			t.Errorf("%s: got key %s, want %s", test.host, key, want)
+		}
+	}
+}
@@ -80,6 +80,23 @@ func IsOnionCatTor(na *wire.NetAddress) bool {
 	return onionCatNet.Contains(na.IP)
 }
 
+// IsTorV3 returns whether or not the passed address is a Tor v3 hidden service address, which can only be relayed in
+// addrv2 messages.
+func IsTorV3(na *wire.NetAddress) bool {
+	return na.Network == wire.NetTorV3
+}
+
+// IsTor returns whether or not the passed address is a Tor hidden service address, either a v2 address in the OnionCat
+// range or a v3 address.
+func IsTor(na *wire.NetAddress) bool {
+	return IsOnionCatTor(na) || IsTorV3(na)
+}
+
+// IsI2P returns whether or not the passed address is an I2P address, which can only be relayed in addrv2 messages.
+func IsI2P(na *wire.NetAddress) bool {
+	return na.Network == wire.NetI2P
+}
+
 // IsRFC1918 returns whether or not the passed address is part of the IPv4 private network address space as defined by
 // RFC1918 (10.0.0.0/8, 172.16.0.0/12, or 192.168.0.0/16).
 func IsRFC1918(na *wire.NetAddress) bool {
@@ -174,21 +191,31 @@ func IsRFC6598(na *wire.NetAddress) bool {
 // IPv4: It is either a zero or all bits set address. I
 //
 // Pv6: It is either a zero or RFC3849 documentation address.
+//
+// Addresses that do not fit in an IP are valid if they are not empty.
 func IsValid(na *wire.NetAddress) bool {
+	if na.RequiresAddrV2() {
+		return len(na.Addr) > 0
+	}
 	// IsUnspecified returns if address is 0, so only all bits set, and RFC3849 need to be explicitly checked.
 	return na.IP != nil && !(na.IP.IsUnspecified() ||
 		na.IP.Equal(net.IPv4bcast))
 }
 
 // IsRoutable returns whether or not the passed address is routable over the public internet. This is true as long as
-// the address is valid and is not in any reserved ranges.
+// the address is valid and is not in any reserved ranges. Of the addresses that do not fit in an IP only Tor v3 and I2P
+// addresses are routable.
 func IsRoutable(na *wire.NetAddress) bool {
+	if na.RequiresAddrV2() {
+		return IsValid(na) && (IsTorV3(na) || IsI2P(na))
+	}
 	return IsValid(na) && !(IsRFC1918(na) || IsRFC2544(na) || IsRFC3927(na) || IsRFC4862(na) || IsRFC3849(na) || IsRFC4843(na) || IsRFC5737(na) || IsRFC6598(na) || IsLocal(na) || (IsRFC4193(na) && !IsOnionCatTor(na)))
 }
 
 // GroupKey returns a string representing the network group an address is part of. This is the /16 for IPv4, the /32
 // (/36 for he.net) for IPv6, the string "local" for a local address, the string "tor:key" where key is the /4 of the
-// onion address for Tor address, and the string "unroutable" for an unroutable address.
+// onion address for Tor address, the string "i2p:key" where key is the /4 of the address for I2P addresses, and the
+// string "unroutable" for an unroutable address.
 func GroupKey(na *wire.NetAddress) string {
 	if IsLocal(na) {
 		return "local"
@@ -196,6 +223,12 @@ func GroupKey(na *wire.NetAddress) string {
 	if !IsRoutable(na) {
 		return "unroutable"
 	}
+	if IsTorV3(na) {
+		return fmt.Sprintf("tor:%d", na.Addr[0]&((1<<4)-1))
+	}
+	if IsI2P(na) {
+		return fmt.Sprintf("i2p:%d", na.Addr[0]&((1<<4)-1))
+	}
 	if IsIPv4(na) {
 		return na.IP.Mask(net.CIDRMask(16, 32)).String()
 	}
@@ -0,0 +1,112 @@
+package connmgr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"time"
+)
+
+// BanEntry is a ban of the addresses of a subnet.
+type BanEntry struct {
+	Subnet  *net.IPNet `json:"-"`
+	Created time.Time  `json:"created"`
+	Until   time.Time  `json:"until"`
+	Reason  string     `json:"reason"`
+}
+
+// BanList holds the bans of the node by the subnet they apply to, which is a single host for bans of misbehaving peers.
+// It is not safe for concurrent access.
+type BanList map[string]*BanEntry
+
+// ParseSubnet returns the subnet of a network in CIDR notation, or the subnet of only the address when given an IP.
+func ParseSubnet(s string) (*net.IPNet, error) {
+	if _, subnet, err := net.ParseCIDR(s); err == nil {
+		return subnet, nil
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an IP address or subnet", s)
+	}
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 8*net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// Ban bans the subnet until the given time, replacing an existing ban of the subnet.
+func (b BanList) Ban(subnet *net.IPNet, until time.Time, reason string) {
+	b[subnet.String()] = &BanEntry{Subnet: subnet, Created: time.Now(), Until: until, Reason: reason}
+}
+
+// Unban removes the ban of the subnet, returning false if it isn't banned.
+func (b BanList) Unban(subnet *net.IPNet) bool {
+	key := subnet.String()
+	if _, ok := b[key]; !ok {
+		return false
+	}
+	delete(b, key)
+	return true
+}
+
+// Banned returns the ban that the address is under at the time, if any.
+func (b BanList) Banned(ip net.IP, now time.Time) (*BanEntry, bool) {
+	for _, entry := range b {
+		if now.Before(entry.Until) && entry.Subnet.Contains(ip) {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+// Expire removes the bans that ended by the time, returning whether any were removed.
+func (b BanList) Expire(now time.Time) (expired bool) {
+	for key, entry := range b {
+		if !now.Before(entry.Until) {
+			delete(b, key)
+			expired = true
+		}
+	}
+	return
+}
+
+// LoadBanList reads the ban list saved in the file at path. An empty ban list is returned when the file doesn't exist.
+func LoadBanList(path string) (BanList, error) {
+	b := make(BanList)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("can't decode the ban list in %s: %v", path, err)
+	}
+	for key, entry := range b {
+		if entry == nil {
+			delete(b, key)
+			continue
+		}
+		if entry.Subnet, err = ParseSubnet(key); err != nil {
+			return nil, fmt.Errorf("invalid ban in %s: %v", path, err)
+		}
+	}
+	return b, nil
+}
+
+// Save writes the ban list to the file at path, replacing the previous file only once it is completely written.
+func (b BanList) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".new"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
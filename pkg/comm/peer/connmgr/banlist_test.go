@@ -0,0 +1,89 @@
+package connmgr
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestBanList ensures that bans apply to the addresses of their subnet until they end, and that the ban list is saved
+// and loaded again.
+func TestBanList(t *testing.T) {
+	now := time.Now()
+	b := make(BanList)
+	host, err := ParseSubnet("192.0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subnet, err := ParseSubnet("2001:db8::/32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Ban(host, now.Add(time.Hour), "unrequestedblock")
+	b.Ban(subnet, now.Add(time.Minute), "manual")
+	tests := []struct {
+		ip     string
+		at     time.Time
+		banned bool
+	}{
+		{"192.0.2.1", now, true},
+		{"192.0.2.2", now, false},
+		{"2001:db8::1", now, true},
+		{"2001:db8::1", now.Add(2 * time.Minute), false},
+		{"2001:db9::1", now, false},
+	}
+	for _, test := range tests {
+		if _, banned := b.Banned(net.ParseIP(test.ip), test.at); banned != test.banned {
+			t.Errorf("%s at %v: got banned %v, want %v", test.ip, test.at, banned, test.banned)
+		}
+	}
+	dir, err := ioutil.TempDir("", "banlist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "banlist.json")
+	if err = b.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadBanList(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, banned := loaded.Banned(net.ParseIP("192.0.2.1"), now)
+	if !banned || entry.Reason != "unrequestedblock" || !entry.Until.Equal(now.Add(time.Hour)) {
+		t.Fatalf("got ban %+v after loading the ban list", entry)
+	}
+	if !loaded.Expire(now.Add(2*time.Minute)) || len(loaded) != 1 {
+		t.Fatalf("got %d bans after the subnet ban expired, want 1", len(loaded))
+	}
+	if !loaded.Unban(host) || loaded.Unban(host) || len(loaded) != 0 {
+		t.Fatal("unbanning the host did not remove only its ban")
+	}
+}
+
+// TestParseOffenseScores ensures that configured ban scores replace the defaults of their offense only.
+func TestParseOffenseScores(t *testing.T) {
+	scores, err := ParseOffenseScores([]string{"unrequestedblock=50", "Mempool=10:48h"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := DefaultOffenseScores()
+	if got := scores[OffenseUnrequestedBlock]; got.Score != 50 || got.Transient || got.Duration != 0 {
+		t.Errorf("got unrequested block score %+v", got)
+	}
+	if got := scores[OffenseMempool]; got.Score != 10 || !got.Transient || got.Duration != 48*time.Hour {
+		t.Errorf("got mempool score %+v", got)
+	}
+	if got := scores[OffenseGetData]; got != defaults[OffenseGetData] {
+		t.Errorf("got getdata score %+v, want the default %+v", got, defaults[OffenseGetData])
+	}
+	for _, s := range []string{"unrequestedblock", "nosuchoffense=1", "mempool=-1", "mempool=1:forever"} {
+		if _, err = ParseOffenseScores([]string{s}); err == nil {
+			t.Errorf("ban score %q parsed without error", s)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package connmgr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Offense is a kind of misbehavior of a peer that increases its ban score.
+type Offense int
+
+const (
+	// OffenseUnrequestedBlock is sending a block that was not requested.
+	OffenseUnrequestedBlock Offense = iota
+	// OffenseUnrequestedHeaders is sending headers when the node is not syncing headers.
+	OffenseUnrequestedHeaders
+	// OffenseCheckpointMismatch is sending a header that doesn't match the checkpoint at its height.
+	OffenseCheckpointMismatch
+	// OffenseGetData is requesting too much data in a short time. Its score is that of a getdata message of the maximum
+	// size, smaller messages add a proportional part of it.
+	OffenseGetData
+	// OffenseMempool is flooding the node with mempool requests.
+	OffenseMempool
+	// OffenseInvalidAnnouncement is relaying an announcement with a bad signature.
+	OffenseInvalidAnnouncement
+	// OffenseBloomFilter is sending bloom filter messages to a node that doesn't serve them.
+	OffenseBloomFilter
+)
+
+// offenseNames are the names of the offenses in the configuration and in the reasons of bans.
+var offenseNames = map[Offense]string{
+	OffenseUnrequestedBlock:    "unrequestedblock",
+	OffenseUnrequestedHeaders:  "unrequestedheaders",
+	OffenseCheckpointMismatch:  "checkpointmismatch",
+	OffenseGetData:             "getdata",
+	OffenseMempool:             "mempool",
+	OffenseInvalidAnnouncement: "invalidannouncement",
+	OffenseBloomFilter:         "bloomfilter",
+}
+
+// String returns the name of the offense.
+func (o Offense) String() string {
+	if name, ok := offenseNames[o]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown Offense (%d)", int(o))
+}
+
+// OffenseScore is how much an offense increases the ban score of a peer, and how long the ban lasts when the offense
+// takes the score over the ban threshold. A zero duration uses the ban duration of the configuration.
+type OffenseScore struct {
+	// Score is added to the persistent ban score, or to the decaying one when Transient is set, so that only a burst of
+	// offenses leads to a ban.
+	Score     uint32
+	Transient bool
+	Duration  time.Duration
+}
+
+// OffenseScores are the scores of the offenses.
+type OffenseScores map[Offense]OffenseScore
+
+// DefaultOffenseScores returns the default scores of the offenses.
+func DefaultOffenseScores() OffenseScores {
+	return OffenseScores{
+		OffenseUnrequestedBlock:    {Score: 100},
+		OffenseUnrequestedHeaders:  {Score: 20, Transient: true},
+		OffenseCheckpointMismatch:  {Score: 100},
+		OffenseGetData:             {Score: 99, Transient: true},
+		OffenseMempool:             {Score: 33, Transient: true},
+		OffenseInvalidAnnouncement: {Score: 20, Transient: true},
+		OffenseBloomFilter:         {Score: 100},
+	}
+}
+
+// ParseOffenseScores returns the default scores of the offenses with the scores configured in the form
+// offense=score[:duration], such as unrequestedblock=50 or checkpointmismatch=100:168h, replacing the defaults.
+func ParseOffenseScores(scores []string) (OffenseScores, error) {
+	offenses := make(map[string]Offense, len(offenseNames))
+	for o, name := range offenseNames {
+		offenses[name] = o
+	}
+	parsed := DefaultOffenseScores()
+	for _, s := range scores {
+		parts := strings.SplitN(s, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("ban score %q is not in the form offense=score[:duration]", s)
+		}
+		o, ok := offenses[strings.ToLower(strings.TrimSpace(parts[0]))]
+		if !ok {
+			return nil, fmt.Errorf("unknown offense %q in ban score %q", parts[0], s)
+		}
+		score := parsed[o]
+		values := strings.SplitN(parts[1], ":", 2)
+		n, err := strconv.ParseUint(strings.TrimSpace(values[0]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score in ban score %q: %v", s, err)
+		}
+		score.Score = uint32(n)
+		if len(values) == 2 {
+			if score.Duration, err = time.ParseDuration(strings.TrimSpace(values[1])); err != nil {
+				return nil, fmt.Errorf("invalid duration in ban score %q: %v", s, err)
+			}
+		}
+		parsed[o] = score
+	}
+	return parsed, nil
+}
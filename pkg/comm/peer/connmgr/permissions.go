@@ -0,0 +1,118 @@
+package connmgr
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Permissions are the flags of what a whitelisted peer is allowed to do beyond the policy applied to other peers.
+type Permissions uint32
+
+const (
+	// PermissionNoBan exempts the peer from banning and disconnection for misbehavior.
+	PermissionNoBan Permissions = 1 << iota
+	// PermissionForceRelay relays the transactions of the peer even when they are already in the mempool. It implies
+	// PermissionRelay.
+	PermissionForceRelay
+	// PermissionRelay accepts transactions from the peer even when the node only relays blocks.
+	PermissionRelay
+	// PermissionMempool allows the peer to request the contents of the mempool, without the bloom filter service and
+	// without a ban score for repeated requests.
+	PermissionMempool
+	// PermissionBloomFilter allows the peer to load bloom filters even when the node doesn't serve them.
+	PermissionBloomFilter
+	// PermissionsImplicit are the permissions of a whitelist entry that doesn't list any.
+	PermissionsImplicit = PermissionNoBan | PermissionRelay | PermissionMempool
+)
+
+// permissionNames are the names of the permissions in the configuration, in the order they are listed.
+var permissionNames = []struct {
+	p    Permissions
+	name string
+}{
+	{PermissionNoBan, "noban"},
+	{PermissionForceRelay, "forcerelay"},
+	{PermissionRelay, "relay"},
+	{PermissionMempool, "mempool"},
+	{PermissionBloomFilter, "bloomfilter"},
+}
+
+// Has returns whether all of the permissions in p are granted.
+func (perms Permissions) Has(p Permissions) bool {
+	return perms&p == p
+}
+
+// Names returns the names of the granted permissions.
+func (perms Permissions) Names() []string {
+	names := make([]string, 0, len(permissionNames))
+	for _, pn := range permissionNames {
+		if perms.Has(pn.p) {
+			names = append(names, pn.name)
+		}
+	}
+	return names
+}
+
+// String returns the names of the granted permissions separated by commas.
+func (perms Permissions) String() string {
+	return strings.Join(perms.Names(), ",")
+}
+
+// ParsePermissions returns the permissions named in a comma separated list.
+func ParsePermissions(s string) (Permissions, error) {
+	var perms Permissions
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		found := false
+		for _, pn := range permissionNames {
+			if pn.name == name {
+				perms |= pn.p
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown permission %q", name)
+		}
+	}
+	if perms.Has(PermissionForceRelay) {
+		perms |= PermissionRelay
+	}
+	return perms, nil
+}
+
+// Whitelist grants permissions to the peers connecting from the addresses of a subnet.
+type Whitelist struct {
+	Subnet      *net.IPNet
+	Permissions Permissions
+}
+
+// ParseWhitelist parses a whitelist entry in the form [permission,...@]subnet, such as mempool,relay@192.168.1.0/24 or
+// ::1. An entry without permissions grants PermissionsImplicit.
+func ParseWhitelist(s string) (Whitelist, error) {
+	w := Whitelist{Permissions: PermissionsImplicit}
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		perms, err := ParsePermissions(s[:i])
+		if err != nil {
+			return w, fmt.Errorf("invalid whitelist %q: %v", s, err)
+		}
+		w.Permissions, s = perms, s[i+1:]
+	}
+	subnet, err := ParseSubnet(strings.TrimSpace(s))
+	if err != nil {
+		return w, fmt.Errorf("invalid whitelist: %v", err)
+	}
+	w.Subnet = subnet
+	return w, nil
+}
+
+// WhitelistPermissions returns the combined permissions of the whitelist entries whose subnet contains the address.
+func WhitelistPermissions(whitelists []Whitelist, ip net.IP) (perms Permissions) {
+	for _, w := range whitelists {
+		if w.Subnet.Contains(ip) {
+			perms |= w.Permissions
+		}
+	}
+	return
+}
@@ -0,0 +1,38 @@
+package connmgr
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+// TestParseWhitelist ensures that whitelist entries grant their listed permissions, or the implicit ones when none are
+// listed, to the addresses of their subnet.
+func TestParseWhitelist(t *testing.T) {
+	tests := []struct {
+		entry string
+		ip    string
+		names []string
+	}{
+		{"192.168.1.0/24", "192.168.1.7", []string{"noban", "relay", "mempool"}},
+		{"mempool@192.168.1.0/24", "192.168.1.7", []string{"mempool"}},
+		{"forcerelay,BloomFilter@::1", "::1", []string{"forcerelay", "relay", "bloomfilter"}},
+		{"noban@10.0.0.1", "10.0.0.2", []string{}},
+	}
+	for _, test := range tests {
+		w, err := ParseWhitelist(test.entry)
+		if err != nil {
+			t.Errorf("%s: %v", test.entry, err)
+			continue
+		}
+		perms := WhitelistPermissions([]Whitelist{w}, net.ParseIP(test.ip))
+		if names := perms.Names(); !reflect.DeepEqual(names, test.names) {
+			t.Errorf("%s: got permissions %v for %s, want %v", test.entry, names, test.ip, test.names)
+		}
+	}
+	for _, s := range []string{"all@10.0.0.1", "mempool@", "mempool@10.0.0.300", "@10.0.0.1"} {
+		if _, err := ParseWhitelist(s); err == nil {
+			t.Errorf("whitelist %q parsed without error", s)
+		}
+	}
+}
@@ -26,8 +26,8 @@ import (
 
 const (
 	// MaxProtocolVersion is the max protocol version the peer supports.
-	MaxProtocolVersion = wire.FeeFilterVersion
-	// DefaultTrickleInterval is the min time between attempts to send an inv message to a peer.
+	MaxProtocolVersion = wire.AddrV2Version
+	// DefaultTrickleInterval is the average time between attempts to send an inv message to a peer.
 	DefaultTrickleInterval = time.Second
 	// MinAcceptableProtocolVersion is the lowest protocol version that a connected peer may support.
 	MinAcceptableProtocolVersion = 1
@@ -36,6 +36,10 @@ const (
 	// invTrickleSize is the maximum amount of inventory to send in a single message when trickling inventory to remote
 	// peers.
 	maxInvTrickleSize = 5000
+	// minTrickleFraction and maxTrickleFactor bound the randomized delay between inventory trickles to a fraction and a
+	// multiple of the trickle interval.
+	minTrickleFraction = 10
+	maxTrickleFactor   = 4
 	// maxKnownInventory is the maximum number of items to keep in the known inventory cache.
 	maxKnownInventory = 30000
 	// pingInterval is the interval of time to wait in between sending ping messages.
@@ -79,6 +83,8 @@ type MessageListeners struct {
 	OnGetAddr func(p *Peer, msg *wire.MsgGetAddr)
 	// OnAddr is invoked when a peer receives an addr bitcoin message.
 	OnAddr func(p *Peer, msg *wire.MsgAddr)
+	// OnAddrV2 is invoked when a peer receives an addrv2 bitcoin message.
+	OnAddrV2 func(p *Peer, msg *wire.MsgAddrV2)
 	// OnPing is invoked when a peer receives a ping bitcoin message.
 	OnPing func(p *Peer, msg *wire.MsgPing)
 	// OnPong is invoked when a peer receives a pong bitcoin message.
@@ -121,6 +127,8 @@ type MessageListeners struct {
 	OnGetCFCheckpt func(p *Peer, msg *wire.MsgGetCFCheckpt)
 	// OnFeeFilter is invoked when a peer receives a feefilter bitcoin message.
 	OnFeeFilter func(p *Peer, msg *wire.MsgFeeFilter)
+	// OnAnnounce is invoked when a peer receives a signed announce message.
+	OnAnnounce func(p *Peer, msg *wire.MsgAnnounce)
 	// OnFilterAdd is invoked when a peer receives a filteradd bitcoin message.
 	OnFilterAdd func(p *Peer, msg *wire.MsgFilterAdd)
 	// OnFilterClear is invoked when a peer receives a filterclear bitcoin
@@ -143,6 +151,9 @@ type MessageListeners struct {
 	// OnSendHeaders is invoked when a peer receives a sendheaders bitcoin
 	// message.
 	OnSendHeaders func(p *Peer, msg *wire.MsgSendHeaders)
+	// OnSendAddrV2 is invoked when a peer receives a sendaddrv2 bitcoin
+	// message during the version handshake.
+	OnSendAddrV2 func(p *Peer, msg *wire.MsgSendAddrV2)
 	// OnRead is invoked when a peer receives a bitcoin message.
 	//
 	// It consists of the number of bytes read, the message, and whether or not an error in the read occurred.
@@ -192,7 +203,8 @@ type Config struct {
 	// Listeners houses callback functions to be invoked on receiving peer
 	// messages.
 	Listeners MessageListeners
-	// TrickleInterval is the duration of the ticker which trickles down the inventory to a peer.
+	// TrickleInterval is the average delay between trickles of inventory to a peer. The actual delays are randomized so
+	// the timing of announcements does not reveal which transactions originated from this node.
 	TrickleInterval time.Duration
 }
 
@@ -292,6 +304,11 @@ type StatsSnap struct {
 	LastPingNonce  uint64
 	LastPingTime   time.Time
 	LastPingMicros int64
+	// BytesSentPerMsg and BytesRecvPerMsg are the bytes sent and received by the type of message.
+	BytesSentPerMsg map[string]uint64
+	BytesRecvPerMsg map[string]uint64
+	// PingSamples are the round trip times in microseconds of the last PingWindowSize pings, oldest first.
+	PingSamples []int64
 }
 
 // HashFunc is a function which returns a block hash, height and error It is used as a callback to get newest block
@@ -355,6 +372,7 @@ type Peer struct {
 	advertisedProtoVer   uint32 // protocol version advertised by remote
 	protocolVersion      uint32 // negotiated protocol version
 	sendHeadersPreferred bool   // peer sent a sendheaders message
+	sendAddrV2           bool   // peer sent a sendaddrv2 message
 	verAckReceived       bool
 	witnessEnabled       bool
 	wireEncoding         wire.MessageEncoding
@@ -375,6 +393,9 @@ type Peer struct {
 	lastPingNonce      uint64    // Set to Nonce if we have a pending ping.
 	lastPingTime       time.Time // Time we sent last ping.
 	lastPingMicros     int64     // Time for last ping to return.
+	pings              pingWindow
+	msgBytesSent       map[string]uint64
+	msgBytesRecv       map[string]uint64
 	stallControl       chan stallControlMsg
 	outputQueue        chan outMsg
 	sendQueue          chan outMsg
@@ -440,23 +461,26 @@ func (p *Peer) StatsSnapshot() *StatsSnap {
 	p.flagsMtx.Unlock()
 	// Get a copy of all relevant flags and stats.
 	statsSnap := &StatsSnap{
-		ID:             id,
-		Addr:           addr,
-		UserAgent:      userAgent,
-		Services:       services,
-		LastSend:       p.LastSend(),
-		LastRecv:       p.LastRecv(),
-		BytesSent:      p.BytesSent(),
-		BytesRecv:      p.BytesReceived(),
-		ConnTime:       p.timeConnected,
-		TimeOffset:     p.timeOffset,
-		Version:        protocolVersion,
-		Inbound:        p.inbound,
-		StartingHeight: p.startingHeight,
-		LastBlock:      p.lastBlock,
-		LastPingNonce:  p.lastPingNonce,
-		LastPingMicros: p.lastPingMicros,
-		LastPingTime:   p.lastPingTime,
+		ID:              id,
+		Addr:            addr,
+		UserAgent:       userAgent,
+		Services:        services,
+		LastSend:        p.LastSend(),
+		LastRecv:        p.LastRecv(),
+		BytesSent:       p.BytesSent(),
+		BytesRecv:       p.BytesReceived(),
+		ConnTime:        p.timeConnected,
+		TimeOffset:      p.timeOffset,
+		Version:         protocolVersion,
+		Inbound:         p.inbound,
+		StartingHeight:  p.startingHeight,
+		LastBlock:       p.lastBlock,
+		LastPingNonce:   p.lastPingNonce,
+		LastPingMicros:  p.lastPingMicros,
+		LastPingTime:    p.lastPingTime,
+		BytesSentPerMsg: copyCounts(p.msgBytesSent),
+		BytesRecvPerMsg: copyCounts(p.msgBytesRecv),
+		PingSamples:     p.pings.values(),
 	}
 	p.statsMtx.RUnlock()
 	return statsSnap
@@ -673,6 +697,15 @@ func (p *Peer) WantsHeaders() bool {
 	return sendHeadersPreferred
 }
 
+// WantsAddrV2 returns if the peer wants addresses relayed in addrv2 messages instead of addr messages. This function is
+// safe for concurrent access.
+func (p *Peer) WantsAddrV2() bool {
+	p.flagsMtx.Lock()
+	sendAddrV2 := p.sendAddrV2
+	p.flagsMtx.Unlock()
+	return sendAddrV2
+}
+
 // IsWitnessEnabled returns true if the peer has signalled that it supports segregated witness. This function is safe
 // for concurrent access.
 func (p *Peer) IsWitnessEnabled() bool {
@@ -683,34 +716,47 @@ func (p *Peer) IsWitnessEnabled() bool {
 }
 
 // PushAddrMsg sends an addr message to the connected peer using the provided
-// addresses.
+// addresses, or an addrv2 message if the peer asked for them with sendaddrv2.
 //
 // This function is useful over manually sending the message via QueueMessage since it automatically limits the
 // addresses to the maximum number allowed by the message and randomizes the chosen addresses when there are too many.
+// Addresses that can only be encoded in an addrv2 message, such as Tor v3 addresses, are left out of addr messages.
 //
 // It returns the addresses that were actually sent and no message will be sent if there are no entries in the provided
 // addresses slice. This function is safe for concurrent access.
 func (p *Peer) PushAddrMsg(addresses []*wire.NetAddress) ([]*wire.NetAddress, error) {
-	addressCount := len(addresses)
+	addrV2 := p.WantsAddrV2()
+	addrList := make([]*wire.NetAddress, 0, len(addresses))
+	for _, na := range addresses {
+		if addrV2 || !na.RequiresAddrV2() {
+			addrList = append(addrList, na)
+		}
+	}
+	addressCount := len(addrList)
 	// Nothing to send.
 	if addressCount == 0 {
 		return nil, nil
 	}
-	msg := wire.NewMsgAddr()
-	msg.AddrList = make([]*wire.NetAddress, addressCount)
-	copy(msg.AddrList, addresses)
 	// Randomize the addresses sent if there are more than the maximum allowed.
 	if addressCount > wire.MaxAddrPerMsg {
 		// Shuffle the address list.
 		for i := 0; i < wire.MaxAddrPerMsg; i++ {
 			j := i + rand.Intn(addressCount-i)
-			msg.AddrList[i], msg.AddrList[j] = msg.AddrList[j], msg.AddrList[i]
+			addrList[i], addrList[j] = addrList[j], addrList[i]
 		}
 		// Truncate it to the maximum size.
-		msg.AddrList = msg.AddrList[:wire.MaxAddrPerMsg]
+		addrList = addrList[:wire.MaxAddrPerMsg]
 	}
-	p.QueueMessage(msg, nil)
-	return msg.AddrList, nil
+	if addrV2 {
+		msg := wire.NewMsgAddrV2()
+		msg.AddrList = addrList
+		p.QueueMessage(msg, nil)
+	} else {
+		msg := wire.NewMsgAddr()
+		msg.AddrList = addrList
+		p.QueueMessage(msg, nil)
+	}
+	return addrList, nil
 }
 
 // PushGetBlocksMsg sends a getblocks message for the provided block locator and stop hash. It will ignore back-to-back
@@ -858,6 +904,7 @@ func (p *Peer) handlePongMsg(msg *wire.MsgPong) {
 			p.lastPingMicros = time.Since(p.lastPingTime).Nanoseconds()
 			p.lastPingMicros /= 1000 // convert to microseconds.
 			p.lastPingNonce = 0
+			p.pings.add(p.lastPingMicros)
 		}
 		p.statsMtx.Unlock()
 	}
@@ -868,6 +915,11 @@ func (p *Peer) readMessage(encoding wire.MessageEncoding) (wire.Message, []byte,
 	n, msg, buf, err := wire.ReadMessageWithEncodingN(p.conn,
 		p.ProtocolVersion(), p.cfg.ChainParams.Net, encoding)
 	atomic.AddUint64(&p.bytesReceived, uint64(n))
+	if msg != nil {
+		p.statsMtx.Lock()
+		p.msgBytesRecv[msg.Command()] += uint64(n)
+		p.statsMtx.Unlock()
+	}
 	if p.cfg.Listeners.OnRead != nil {
 		p.cfg.Listeners.OnRead(p, n, msg, err)
 	}
@@ -940,6 +992,9 @@ func (p *Peer) writeMessage(msg wire.Message, enc wire.MessageEncoding) error {
 	n, err := wire.WriteMessageWithEncodingN(p.conn, msg,
 		p.ProtocolVersion(), p.cfg.ChainParams.Net, enc)
 	atomic.AddUint64(&p.bytesSent, uint64(n))
+	p.statsMtx.Lock()
+	p.msgBytesSent[cmd] += uint64(n)
+	p.statsMtx.Unlock()
 	if p.cfg.Listeners.OnWrite != nil {
 		p.cfg.Listeners.OnWrite(p, n, msg, err)
 	}
@@ -1230,6 +1285,25 @@ out:
 			if p.cfg.Listeners.OnAddr != nil {
 				p.cfg.Listeners.OnAddr(p, msg)
 			}
+		case *wire.MsgAddrV2:
+			if p.cfg.Listeners.OnAddrV2 != nil {
+				p.cfg.Listeners.OnAddrV2(p, msg)
+			}
+		case *wire.MsgSendAddrV2:
+			// BIP0155 only allows sendaddrv2 before the verack, a later one is ignored.
+			p.flagsMtx.Lock()
+			verAckReceived := p.verAckReceived
+			if !verAckReceived {
+				p.sendAddrV2 = true
+			}
+			p.flagsMtx.Unlock()
+			if verAckReceived {
+				Debugf("ignoring sendaddrv2 after verack from %s", p)
+				break
+			}
+			if p.cfg.Listeners.OnSendAddrV2 != nil {
+				p.cfg.Listeners.OnSendAddrV2(p, msg)
+			}
 		case *wire.MsgPing:
 			p.handlePingMsg(msg)
 			if p.cfg.Listeners.OnPing != nil {
@@ -1304,6 +1378,10 @@ out:
 			if p.cfg.Listeners.OnFeeFilter != nil {
 				p.cfg.Listeners.OnFeeFilter(p, msg)
 			}
+		case *wire.MsgAnnounce:
+			if p.cfg.Listeners.OnAnnounce != nil {
+				p.cfg.Listeners.OnAnnounce(p, msg)
+			}
 		case *wire.MsgFilterAdd:
 			if p.cfg.Listeners.OnFilterAdd != nil {
 				p.cfg.Listeners.OnFilterAdd(p, msg)
@@ -1349,6 +1427,20 @@ out:
 	Trace("peer input handler done for", p)
 }
 
+// trickleDelay returns a random delay until the next inventory trickle. The delays are exponentially distributed with
+// a mean of interval so that announcements to each peer happen at independent, unpredictable times, and are bounded to
+// avoid both bursts and long stalls.
+func trickleDelay(interval time.Duration) time.Duration {
+	d := time.Duration(rand.ExpFloat64() * float64(interval))
+	if min := interval / minTrickleFraction; d < min {
+		return min
+	}
+	if max := interval * maxTrickleFactor; d > max {
+		return max
+	}
+	return d
+}
+
 // queueHandler handles the queuing of outgoing data for the peer.
 //
 // This runs as a muxer for various sources of input so we can ensure that server and peer handlers will not block on us
@@ -1359,8 +1451,8 @@ func (p *Peer) queueHandler() {
 	Trace("starting queueHandler for", p.addr)
 	pendingMsgs := list.New()
 	invSendQueue := list.New()
-	trickleTicker := time.NewTicker(p.cfg.TrickleInterval)
-	defer trickleTicker.Stop()
+	trickleTimer := time.NewTimer(trickleDelay(p.cfg.TrickleInterval))
+	defer trickleTimer.Stop()
 	// We keep the waiting flag so that we know if we have a message queued to the outHandler or not.
 	//
 	// We could use the presence of a head of the list for this but then we have rather racy concerns about whether it
@@ -1413,7 +1505,8 @@ out:
 					invSendQueue.PushBack(iv)
 				}
 			}
-		case <-trickleTicker.C:
+		case <-trickleTimer.C:
+			trickleTimer.Reset(trickleDelay(p.cfg.TrickleInterval))
 			// Don't send anything if we're disconnecting or there is no queued inventory. version is known if send
 			// queue has any entries.
 			if atomic.LoadInt32(&p.disconnect) != 0 ||
@@ -1837,6 +1930,10 @@ func (p *Peer) start() error {
 	go p.queueHandler()
 	go p.outHandler()
 	go p.pingHandler()
+	// Ask for addresses in addrv2 messages before sending our verack, as BIP0155 requires, if the peer supports them.
+	if p.ProtocolVersion() >= wire.AddrV2Version {
+		p.QueueMessage(wire.NewMsgSendAddrV2(), nil)
+	}
 	// Send our verack message now that the IO processing machinery has started.
 	p.QueueMessage(wire.NewMsgVerAck(), nil)
 	return nil
@@ -1911,6 +2008,8 @@ func newPeerBase(origCfg *Config, inbound bool) *Peer {
 		cfg:             cfg, // Copy so caller can't mutate.
 		services:        cfg.Services,
 		protocolVersion: cfg.ProtocolVersion,
+		msgBytesSent:    make(map[string]uint64),
+		msgBytesRecv:    make(map[string]uint64),
 	}
 	return &p
 }
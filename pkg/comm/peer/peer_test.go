@@ -328,6 +328,9 @@ func TestPeerListeners(t *testing.T) {
 			OnAddr: func(p *peer.Peer, msg *wire.MsgAddr) {
 				ok <- msg
 			},
+			OnAddrV2: func(p *peer.Peer, msg *wire.MsgAddrV2) {
+				ok <- msg
+			},
 			OnPing: func(p *peer.Peer, msg *wire.MsgPing) {
 				ok <- msg
 			},
@@ -382,6 +385,9 @@ func TestPeerListeners(t *testing.T) {
 			OnFeeFilter: func(p *peer.Peer, msg *wire.MsgFeeFilter) {
 				ok <- msg
 			},
+			OnAnnounce: func(p *peer.Peer, msg *wire.MsgAnnounce) {
+				ok <- msg
+			},
 			OnFilterAdd: func(p *peer.Peer, msg *wire.MsgFilterAdd) {
 				ok <- msg
 			},
@@ -526,6 +532,10 @@ func TestPeerListeners(t *testing.T) {
 			"OnFeeFilter",
 			wire.NewMsgFeeFilter(15000),
 		},
+		{
+			"OnAnnounce",
+			wire.NewMsgAnnounce(1, 1600000000, 1, "announcement"),
+		},
 		{
 			"OnFilterAdd",
 			wire.NewMsgFilterAdd([]byte{0x01}),
@@ -552,6 +562,10 @@ func TestPeerListeners(t *testing.T) {
 			"OnSendHeaders",
 			wire.NewMsgSendHeaders(),
 		},
+		{
+			"OnAddrV2",
+			wire.NewMsgAddrV2(),
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for _, test := range tests {
@@ -564,6 +578,10 @@ func TestPeerListeners(t *testing.T) {
 			return
 		}
 	}
+	// The outbound peer sent sendaddrv2 during the handshake.
+	if !inPeer.WantsAddrV2() {
+		t.Errorf("TestPeerListeners: sendaddrv2 was not negotiated")
+	}
 	inPeer.Disconnect()
 	outPeer.Disconnect()
 }
@@ -0,0 +1,61 @@
+package peer
+
+import (
+	"sort"
+)
+
+// PingWindowSize is the number of the most recent ping round trip times of a peer that its latency percentiles are
+// calculated from.
+const PingWindowSize = 32
+
+// pingWindow holds the most recent ping round trip times of a peer in microseconds, overwriting the oldest once it is
+// full.
+type pingWindow struct {
+	samples [PingWindowSize]int64
+	count   int
+	next    int
+}
+
+// add records a ping round trip time.
+func (w *pingWindow) add(micros int64) {
+	w.samples[w.next] = micros
+	w.next = (w.next + 1) % PingWindowSize
+	if w.count < PingWindowSize {
+		w.count++
+	}
+}
+
+// values returns a copy of the recorded round trip times, oldest first.
+func (w *pingWindow) values() []int64 {
+	values := make([]int64, 0, w.count)
+	start := (w.next - w.count + PingWindowSize) % PingWindowSize
+	for i := 0; i < w.count; i++ {
+		values = append(values, w.samples[(start+i)%PingWindowSize])
+	}
+	return values
+}
+
+// Percentile returns the smallest of the samples that at least pct percent of the samples are less than or equal to,
+// or zero when there are no samples. The samples are sorted in place.
+func Percentile(samples []int64, pct float64) int64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	rank := int(pct/100*float64(len(samples)) + 0.999999)
+	if rank < 1 {
+		rank = 1
+	} else if rank > len(samples) {
+		rank = len(samples)
+	}
+	return samples[rank-1]
+}
+
+// copyCounts returns a copy of the byte counts of the message types.
+func copyCounts(counts map[string]uint64) map[string]uint64 {
+	c := make(map[string]uint64, len(counts))
+	for cmd, n := range counts {
+		c[cmd] = n
+	}
+	return c
+}
@@ -0,0 +1,47 @@
+package peer
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestPingWindow ensures that the ping window keeps only the most recent round trip times in order.
+func TestPingWindow(t *testing.T) {
+	var w pingWindow
+	if values := w.values(); len(values) != 0 {
+		t.Fatalf("got %v from an empty window", values)
+	}
+	for i := int64(1); i <= PingWindowSize+3; i++ {
+		w.add(i)
+	}
+	values := w.values()
+	if len(values) != PingWindowSize || values[0] != 4 || values[PingWindowSize-1] != PingWindowSize+3 {
+		t.Fatalf("got %v after overflowing the window", values)
+	}
+}
+
+// TestPercentile ensures that percentiles are taken by nearest rank.
+func TestPercentile(t *testing.T) {
+	samples := []int64{70, 10, 50, 30, 90, 20, 80, 40, 100, 60}
+	tests := []struct {
+		pct  float64
+		want int64
+	}{
+		{0, 10},
+		{50, 50},
+		{90, 90},
+		{95, 100},
+		{100, 100},
+	}
+	for _, test := range tests {
+		if got := Percentile(samples, test.pct); got != test.want {
+			t.Errorf("percentile %v: got %d, want %d", test.pct, got, test.want)
+		}
+	}
+	if !reflect.DeepEqual(samples, []int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}) {
+		t.Errorf("samples were not sorted: %v", samples)
+	}
+	if got := Percentile(nil, 50); got != 0 {
+		t.Errorf("got %d for no samples", got)
+	}
+}
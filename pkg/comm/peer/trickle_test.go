@@ -0,0 +1,26 @@
+package peer
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTrickleDelay ensures the randomized trickle delays stay within their bounds and average out near the trickle
+// interval.
+func TestTrickleDelay(t *testing.T) {
+	interval := 100 * time.Millisecond
+	min, max := interval/minTrickleFraction, interval*maxTrickleFactor
+	const samples = 10000
+	var total time.Duration
+	for i := 0; i < samples; i++ {
+		d := trickleDelay(interval)
+		if d < min || d > max {
+			t.Fatalf("delay %v out of range [%v, %v]", d, min, max)
+		}
+		total += d
+	}
+	// Clamping shifts the mean of the exponential distribution slightly, so only a loose check is made.
+	if mean := total / samples; mean < interval*3/4 || mean > interval*5/4 {
+		t.Errorf("mean delay %v is not close to the interval %v", mean, interval)
+	}
+}
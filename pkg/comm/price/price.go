@@ -0,0 +1,166 @@
+// Package price provides the exchange rate of DUO to fiat currencies for showing the value of amounts in the wallet.
+//
+// Rates are fetched over HTTPS from a pluggable Provider and cached on disk, so the last known rates are still
+// available, marked with the time they were fetched, while there is no connection to the provider.
+package price
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// None is the currency setting that turns off fetching rates
+	None = "none"
+	// RefreshInterval is how long fetched rates are used before they are fetched again
+	RefreshInterval = time.Minute * 5
+	// RetryInterval is how long to wait after a failed fetch before trying again
+	RetryInterval = time.Minute
+	// requestTimeout bounds how long a single fetch may take
+	requestTimeout = time.Second * 20
+)
+
+// Currencies are the fiat currencies offered in the settings
+var Currencies = []string{None, "USD", "EUR", "GBP", "JPY", "CNY", "RUB", "CHF", "CAD", "AUD", "BRL", "INR", "KRW",
+	"TRY"}
+
+// cache is the format rates are saved to disk in
+type cache struct {
+	Provider string             `json:"provider"`
+	Updated  int64              `json:"updated"`
+	Rates    map[string]float64 `json:"rates"`
+}
+
+// Feed keeps the rates of DUO fetched from a Provider
+type Feed struct {
+	sync.Mutex
+	provider  Provider
+	client    *http.Client
+	cacheFile string
+	rates     map[string]float64
+	updated   time.Time
+	attempted time.Time
+	lastError error
+}
+
+// New creates a Feed fetching rates from the provider, starting with the rates cached in the file, if there are any.
+// Rates are not cached if the file name is empty.
+func New(provider Provider, cacheFile string) (f *Feed) {
+	f = &Feed{
+		provider:  provider,
+		client:    &http.Client{Timeout: requestTimeout},
+		cacheFile: cacheFile,
+		rates:     make(map[string]float64),
+	}
+	if cacheFile == "" {
+		return
+	}
+	b, err := ioutil.ReadFile(cacheFile)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			Error(err)
+		}
+		return
+	}
+	var c cache
+	if err = json.Unmarshal(b, &c); Check(err) {
+		return
+	}
+	// rates of another provider are still good to show until the first fetch from this one
+	for currency, rate := range c.Rates {
+		f.rates[currency] = rate
+	}
+	f.updated = time.Unix(c.Updated, 0)
+	return
+}
+
+// SetProvider changes the provider rates are fetched from, the next update fetches them from it straight away
+func (f *Feed) SetProvider(provider Provider) {
+	f.Lock()
+	defer f.Unlock()
+	f.provider = provider
+	f.updated, f.attempted = time.Time{}, time.Time{}
+}
+
+// Provider returns the provider rates are fetched from
+func (f *Feed) Provider() Provider {
+	f.Lock()
+	defer f.Unlock()
+	return f.provider
+}
+
+// Rate returns the price of one DUO in the currency and when it was fetched, ok is false if there is no rate for it
+func (f *Feed) Rate(currency string) (rate float64, updated time.Time, ok bool) {
+	f.Lock()
+	defer f.Unlock()
+	rate, ok = f.rates[strings.ToLower(currency)]
+	return rate, f.updated, ok
+}
+
+// Due returns whether the rates are old enough to be fetched again, and the last attempt to fetch them, if it failed,
+// is long enough ago to try again
+func (f *Feed) Due() bool {
+	f.Lock()
+	defer f.Unlock()
+	return time.Since(f.updated) > RefreshInterval && time.Since(f.attempted) > RetryInterval
+}
+
+// Update fetches the rates of the currencies from the provider and caches them. If the fetch fails the rates fetched
+// before are kept.
+func (f *Feed) Update(currencies ...string) (err error) {
+	var wanted []string
+	for _, c := range currencies {
+		if c != "" && c != None {
+			wanted = append(wanted, strings.ToLower(c))
+		}
+	}
+	if len(wanted) == 0 {
+		return errors.New("no currencies to fetch rates in")
+	}
+	f.Lock()
+	provider := f.provider
+	f.attempted = time.Now()
+	f.Unlock()
+	var rates map[string]float64
+	if rates, err = provider.Rates(f.client, wanted); err == nil && len(rates) == 0 {
+		err = errors.New("price feed has no rates for " + strings.Join(wanted, ", "))
+	}
+	f.Lock()
+	f.lastError = err
+	if err != nil {
+		f.Unlock()
+		return
+	}
+	for currency, rate := range rates {
+		f.rates[strings.ToLower(currency)] = rate
+	}
+	f.updated = time.Now()
+	c := cache{Provider: provider.Name(), Updated: f.updated.Unix(), Rates: make(map[string]float64, len(f.rates))}
+	for currency, rate := range f.rates {
+		c.Rates[currency] = rate
+	}
+	f.Unlock()
+	if f.cacheFile == "" {
+		return
+	}
+	var b []byte
+	if b, err = json.Marshal(c); Check(err) {
+		return
+	}
+	if err = ioutil.WriteFile(f.cacheFile, b, 0600); Check(err) {
+	}
+	return
+}
+
+// LastError returns the error of the last fetch of the rates, nil if it succeeded
+func (f *Feed) LastError() error {
+	f.Lock()
+	defer f.Unlock()
+	return f.lastError
+}
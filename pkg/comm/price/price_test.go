@@ -0,0 +1,119 @@
+package price
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestProviderFor ensures price feeds can only be fetched over https
+func TestProviderFor(t *testing.T) {
+	if p, err := ProviderFor(""); err != nil || p.Name() != DefaultProvider {
+		t.Fatalf("default provider not used: %v %v", p, err)
+	}
+	if _, err := ProviderFor("http://example.com/price"); err == nil {
+		t.Fatal("expected error for non-https feed")
+	}
+	if p, err := ProviderFor("https://example.com/price"); err != nil || p.Name() != "https://example.com/price" {
+		t.Fatalf("json feed not used: %v %v", p, err)
+	}
+}
+
+// TestProviders ensures the rates are fetched from the built in providers with the currencies asked for
+func TestProviders(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		q := req.URL.Query()
+		switch req.URL.Path {
+		case "/coingecko":
+			if q.Get("ids") != coinGeckoID || q.Get("vs_currencies") != "usd,eur" {
+				t.Errorf("unexpected query %v", q)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]map[string]float64{coinGeckoID: {"usd": 0.02, "eur": 0.018}})
+		case "/feed":
+			if q.Get("currencies") != "usd,eur" {
+				t.Errorf("unexpected query %v", q)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]float64{"usd": 0.03, "eur": 0.027})
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer srv.Close()
+	tests := []struct {
+		provider Provider
+		usd      float64
+	}{
+		{&CoinGecko{Endpoint: srv.URL + "/coingecko"}, 0.02},
+		{&JSONFeed{URL: srv.URL + "/feed"}, 0.03},
+	}
+	for _, test := range tests {
+		rates, err := test.provider.Rates(srv.Client(), []string{"usd", "eur"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rates["usd"] != test.usd || len(rates) != 2 {
+			t.Errorf("%s: unexpected rates %v", test.provider.Name(), rates)
+		}
+	}
+	if _, err := (&JSONFeed{URL: srv.URL + "/missing"}).Rates(srv.Client(), []string{"usd"}); err == nil {
+		t.Error("expected error for a feed returning not found")
+	}
+}
+
+type testProvider struct {
+	rates map[string]float64
+	err   error
+}
+
+func (p *testProvider) Name() string { return "test" }
+
+func (p *testProvider) Rates(*http.Client, []string) (map[string]float64, error) {
+	return p.rates, p.err
+}
+
+// TestFeedCache ensures rates are cached, kept when the provider can't be reached and loaded again from the cache
+func TestFeedCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "price")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "prices.json")
+	p := &testProvider{rates: map[string]float64{"usd": 0.02}}
+	f := New(p, file)
+	if _, _, ok := f.Rate("USD"); ok {
+		t.Fatal("rate before the first update")
+	}
+	if !f.Due() {
+		t.Fatal("rates not due before the first update")
+	}
+	if err = f.Update(None); err == nil {
+		t.Fatal("expected error updating without currencies")
+	}
+	if err = f.Update("USD"); err != nil {
+		t.Fatal(err)
+	}
+	if rate, _, ok := f.Rate("USD"); !ok || rate != 0.02 {
+		t.Fatalf("unexpected rate %v %v", rate, ok)
+	}
+	if f.Due() {
+		t.Fatal("rates due straight after an update")
+	}
+	// offline the last rates are kept
+	p.err = errors.New("offline")
+	if err = f.Update("USD"); err == nil || f.LastError() == nil {
+		t.Fatal("expected error from the provider")
+	}
+	if rate, _, ok := f.Rate("usd"); !ok || rate != 0.02 {
+		t.Fatalf("rate not kept while offline %v %v", rate, ok)
+	}
+	g := New(p, file)
+	if rate, updated, ok := g.Rate("USD"); !ok || rate != 0.02 || updated.IsZero() {
+		t.Fatalf("rate not loaded from the cache %v %v %v", rate, updated, ok)
+	}
+}
@@ -0,0 +1,109 @@
+package price
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultProvider is the name of the provider used when none is configured
+	DefaultProvider = "coingecko"
+	// CoinGeckoEndpoint is the simple price endpoint of the CoinGecko API
+	CoinGeckoEndpoint = "https://api.coingecko.com/api/v3/simple/price"
+	// coinGeckoID is the id of ParallelCoin on CoinGecko
+	coinGeckoID = "parallelcoin"
+)
+
+// Provider fetches the price of one DUO in each of a list of currencies, given as lower case ISO 4217 codes
+type Provider interface {
+	// Name identifies the provider
+	Name() string
+	// Rates returns the prices of one DUO by currency, currencies the provider doesn't have a price in are left out
+	Rates(client *http.Client, currencies []string) (map[string]float64, error)
+}
+
+// CoinGecko fetches prices from the public CoinGecko API
+type CoinGecko struct {
+	// Endpoint is the simple price endpoint, CoinGeckoEndpoint if it is empty
+	Endpoint string
+}
+
+// Name returns coingecko
+func (c *CoinGecko) Name() string {
+	return DefaultProvider
+}
+
+// Rates fetches the prices of DUO from CoinGecko
+func (c *CoinGecko) Rates(client *http.Client, currencies []string) (rates map[string]float64, err error) {
+	endpoint := c.Endpoint
+	if endpoint == "" {
+		endpoint = CoinGeckoEndpoint
+	}
+	q := url.Values{"ids": {coinGeckoID}, "vs_currencies": {strings.Join(currencies, ",")}}
+	var prices map[string]map[string]float64
+	if err = getJSON(client, endpoint+"?"+q.Encode(), &prices); err != nil {
+		return
+	}
+	return prices[coinGeckoID], nil
+}
+
+// JSONFeed fetches prices from an https URL with the currencies in the currencies query parameter, which returns a JSON
+// object of the price of one DUO by currency, such as {"usd": 0.012, "eur": 0.011}. It lets communities run their own
+// price feed, from the exchanges DUO trades on, without changes to the wallet.
+type JSONFeed struct {
+	URL string
+}
+
+// Name returns the URL of the feed
+func (j *JSONFeed) Name() string {
+	return j.URL
+}
+
+// Rates fetches the prices of DUO from the feed
+func (j *JSONFeed) Rates(client *http.Client, currencies []string) (rates map[string]float64, err error) {
+	var u *url.URL
+	if u, err = url.Parse(j.URL); err != nil {
+		return
+	}
+	q := u.Query()
+	q.Set("currencies", strings.Join(currencies, ","))
+	u.RawQuery = q.Encode()
+	err = getJSON(client, u.String(), &rates)
+	return
+}
+
+// ProviderFor returns the provider of a price feed setting, which is either the name of a built in provider or the
+// https URL of a JSON feed
+func ProviderFor(setting string) (Provider, error) {
+	switch setting {
+	case "", DefaultProvider:
+		return &CoinGecko{}, nil
+	}
+	u, err := url.Parse(setting)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "https" {
+		return nil, fmt.Errorf("price feed must be %s or an https url: '%s'", DefaultProvider, setting)
+	}
+	return &JSONFeed{URL: setting}, nil
+}
+
+// getJSON fetches the URL and decodes the JSON it returns into v
+func getJSON(client *http.Client, u string, v interface{}) (err error) {
+	var res *http.Response
+	if res, err = client.Get(u); err != nil {
+		return
+	}
+	defer func() {
+		if err := res.Body.Close(); Check(err) {
+		}
+	}()
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("price feed returned status %s", res.Status)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -0,0 +1,203 @@
+// Package telemetry provides an opt-in reporter that periodically posts a small anonymous summary of the state of a
+// node to a community endpoint over HTTPS.
+//
+// Nothing is sent unless the reporter is explicitly started. The report contains no addresses, keys or identifiers
+// other than a random session identifier that is regenerated every time the node starts.
+package telemetry
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEndpoint is the community collection endpoint reports are posted to
+	DefaultEndpoint = "https://telemetry.parallelcoin.io/v1/report"
+	// DefaultInterval is the time between reports
+	DefaultInterval = time.Hour * 6
+	// firstReportDelay gives the node time to connect to peers before the first report is sent
+	firstReportDelay = time.Minute * 5
+	// requestTimeout bounds how long a single report may take to be delivered
+	requestTimeout = time.Second * 30
+)
+
+// Report is the anonymous summary that is posted to the endpoint
+type Report struct {
+	Session  string `json:"session"`
+	Version  string `json:"version"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Network  string `json:"network"`
+	Height   int32  `json:"height"`
+	Peers    int32  `json:"peers"`
+	Synced   bool   `json:"synced"`
+	Uptime   int64  `json:"uptime"`
+	Reported int64  `json:"reported"`
+}
+
+// Stats are the node statistics a Source returns to be included in a Report
+type Stats struct {
+	Version string
+	Network string
+	Height  int32
+	Peers   int32
+	Synced  bool
+	Uptime  int64
+}
+
+// Source returns the current node statistics
+type Source func() Stats
+
+// Status is a snapshot of the state of a Reporter
+type Status struct {
+	Enabled   bool
+	Endpoint  string
+	Interval  time.Duration
+	Sent      uint64
+	LastSent  time.Time
+	LastError string
+	Report    Report
+}
+
+// Reporter periodically posts a Report built from its Source to the endpoint
+type Reporter struct {
+	sync.Mutex
+	endpoint  string
+	interval  time.Duration
+	source    Source
+	session   string
+	client    *http.Client
+	enabled   bool
+	sent      uint64
+	lastSent  time.Time
+	lastError error
+}
+
+// New creates a Reporter. The endpoint of an enabled reporter must be an https URL. If enabled is false the reporter
+// never sends anything but can still be queried for the report that would be sent, so an endpoint that could not be used
+// is only warned about.
+func New(endpoint string, interval time.Duration, enabled bool, source Source) (r *Reporter, err error) {
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	var u *url.URL
+	if u, err = url.Parse(endpoint); Check(err) {
+		return
+	}
+	if u.Scheme != "https" {
+		if enabled {
+			err = fmt.Errorf("telemetry endpoint must use https: '%s'", endpoint)
+			return
+		}
+		Warn("telemetry endpoint does not use https and will not be used if telemetry is enabled:", endpoint)
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	session := make([]byte, 8)
+	if _, err = rand.Read(session); Check(err) {
+		return
+	}
+	r = &Reporter{
+		endpoint: endpoint,
+		interval: interval,
+		source:   source,
+		session:  hex.EncodeToString(session),
+		client:   &http.Client{Timeout: requestTimeout},
+		enabled:  enabled,
+	}
+	return
+}
+
+// Report assembles the report that will be sent next
+func (r *Reporter) Report() Report {
+	st := r.source()
+	return Report{
+		Session:  r.session,
+		Version:  st.Version,
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		Network:  st.Network,
+		Height:   st.Height,
+		Peers:    st.Peers,
+		Synced:   st.Synced,
+		Uptime:   st.Uptime,
+		Reported: time.Now().Unix(),
+	}
+}
+
+// Send posts a single report to the endpoint
+func (r *Reporter) Send() (err error) {
+	if !r.enabled {
+		return errors.New("telemetry is not enabled")
+	}
+	var b []byte
+	if b, err = json.Marshal(r.Report()); Check(err) {
+		return
+	}
+	var res *http.Response
+	if res, err = r.client.Post(r.endpoint, "application/json", bytes.NewReader(b)); err == nil {
+		if err = res.Body.Close(); Check(err) {
+		}
+		if res.StatusCode/100 != 2 {
+			err = fmt.Errorf("telemetry endpoint returned status %s", res.Status)
+		}
+	}
+	r.Lock()
+	r.lastError = err
+	if err == nil {
+		r.sent++
+		r.lastSent = time.Now()
+	}
+	r.Unlock()
+	return
+}
+
+// Run sends reports at the configured interval until quit is closed. It returns immediately if the reporter is not
+// enabled.
+func (r *Reporter) Run(quit <-chan struct{}) {
+	if !r.enabled {
+		return
+	}
+	Info("telemetry reporting enabled, sending to", r.endpoint)
+	t := time.NewTimer(firstReportDelay)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			if err := r.Send(); err != nil {
+				Debug("telemetry report failed:", err)
+			}
+			t.Reset(r.interval)
+		case <-quit:
+			return
+		}
+	}
+}
+
+// Status returns the current state of the reporter and the report that would be sent next
+func (r *Reporter) Status() (s Status) {
+	r.Lock()
+	s = Status{
+		Enabled:  r.enabled,
+		Endpoint: r.endpoint,
+		Interval: r.interval,
+		Sent:     r.sent,
+		LastSent: r.lastSent,
+	}
+	if r.lastError != nil {
+		s.LastError = r.lastError.Error()
+	}
+	r.Unlock()
+	s.Report = r.Report()
+	return
+}
@@ -0,0 +1,64 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func testSource() Stats {
+	return Stats{Version: "0.0.1", Network: "simnet", Height: 42, Peers: 3, Synced: true, Uptime: 10}
+}
+
+// TestNewRejectsPlainHTTP ensures reports can only be configured to go out over https, while a disabled reporter
+// accepts any endpoint as it never sends to it
+func TestNewRejectsPlainHTTP(t *testing.T) {
+	if _, err := New("http://example.com/report", 0, true, testSource); err == nil {
+		t.Fatal("expected error for non-https endpoint")
+	}
+	r, err := New("http://example.com/report", 0, false, testSource)
+	if err != nil {
+		t.Fatalf("disabled reporter with non-https endpoint: %v", err)
+	}
+	if err = r.Send(); err == nil {
+		t.Fatal("disabled reporter sent a report")
+	}
+	r, err = New("", 0, true, testSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.endpoint != DefaultEndpoint || r.interval != DefaultInterval {
+		t.Fatalf("defaults not applied: %s %v", r.endpoint, r.interval)
+	}
+}
+
+// TestSend ensures a report is delivered with the expected content and the status is updated
+func TestSend(t *testing.T) {
+	var got Report
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+	}))
+	defer srv.Close()
+	r, err := New(srv.URL, 0, false, testSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.client = srv.Client()
+	if err = r.Send(); err == nil {
+		t.Fatal("disabled reporter sent a report")
+	}
+	r.enabled = true
+	if err = r.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if got.Height != 42 || got.Peers != 3 || got.Network != "simnet" || got.Session != r.session {
+		t.Fatalf("unexpected report %+v", got)
+	}
+	st := r.Status()
+	if st.Sent != 1 || st.LastError != "" || st.LastSent.IsZero() {
+		t.Fatalf("unexpected status %+v", st)
+	}
+}
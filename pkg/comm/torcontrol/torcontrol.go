@@ -0,0 +1,278 @@
+// Package torcontrol implements enough of the Tor control protocol to authenticate to the control port of a Tor daemon
+// and create a hidden service for the peer listener of the node, so that it can accept inbound connections over Tor
+// without any configuration of Tor itself.
+package torcontrol
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// NewKey is passed to AddOnion to have Tor generate a new key for the hidden service.
+	NewKey = "NEW:ED25519-V3"
+	// statusOK is the status code of a successful reply.
+	statusOK = 250
+	// cookieSize is the size of the authentication cookie written by Tor.
+	cookieSize = 32
+)
+
+// The keys of the HMACs computed for safe cookie authentication, from the Tor control protocol specification.
+var (
+	serverHashKey = []byte("Tor safe cookie authentication server-to-controller hash")
+	clientHashKey = []byte("Tor safe cookie authentication controller-to-server hash")
+)
+
+// Conn is a connection to the control port of a Tor daemon. A hidden service created with AddOnion lasts as long as
+// the connection it was created on.
+type Conn struct {
+	conn net.Conn
+	text *textproto.Conn
+}
+
+// Dial connects to the control port of Tor at addr.
+func Dial(addr string, timeout time.Duration) (*Conn, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn), nil
+}
+
+// NewConn returns a Conn that talks the control protocol over conn.
+func NewConn(conn net.Conn) *Conn {
+	return &Conn{conn: conn, text: textproto.NewConn(conn)}
+}
+
+// Close closes the connection, which removes the hidden services created on it.
+func (c *Conn) Close() error {
+	return c.text.Close()
+}
+
+// Command sends a command and returns the lines of the reply without the status codes, or an error if the reply is
+// not successful.
+func (c *Conn) Command(format string, args ...interface{}) (lines []string, err error) {
+	if err = c.text.PrintfLine(format, args...); err != nil {
+		return nil, err
+	}
+	return c.readReply()
+}
+
+// readReply reads a reply, which is made of lines starting with the status code followed by '-' for all but the last
+// line, which has a ' ' instead. A '+' starts a data line whose data follows on the next lines until a line with a
+// single dot.
+func (c *Conn) readReply() (lines []string, err error) {
+	for {
+		var line string
+		if line, err = c.text.ReadLine(); err != nil {
+			return nil, err
+		}
+		if len(line) < 4 {
+			return nil, fmt.Errorf("invalid tor control reply line '%s'", line)
+		}
+		var code int
+		if code, err = strconv.Atoi(line[:3]); err != nil {
+			return nil, fmt.Errorf("invalid tor control reply line '%s'", line)
+		}
+		if code != statusOK {
+			return nil, fmt.Errorf("tor control error %d: %s", code, line[4:])
+		}
+		switch line[3] {
+		case ' ':
+			return append(lines, line[4:]), nil
+		case '-':
+			lines = append(lines, line[4:])
+		case '+':
+			var data []string
+			if data, err = c.text.ReadDotLines(); err != nil {
+				return nil, err
+			}
+			lines = append(lines, line[4:]+"\n"+strings.Join(data, "\n"))
+		default:
+			return nil, fmt.Errorf("invalid tor control reply line '%s'", line)
+		}
+	}
+}
+
+// Authenticate authenticates the connection with the first method the control port accepts: no authentication, the
+// password if one is given, or the authentication cookie Tor writes to a file, which must be readable.
+func (c *Conn) Authenticate(password string) (err error) {
+	var lines []string
+	if lines, err = c.Command("PROTOCOLINFO 1"); err != nil {
+		return err
+	}
+	methods := make(map[string]bool)
+	var cookieFile string
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "AUTH ") {
+			continue
+		}
+		fields := parseKeyValues(line[len("AUTH "):])
+		for _, m := range strings.Split(fields["METHODS"], ",") {
+			methods[m] = true
+		}
+		cookieFile = fields["COOKIEFILE"]
+	}
+	switch {
+	case methods["NULL"]:
+		_, err = c.Command("AUTHENTICATE")
+	case methods["HASHEDPASSWORD"] && password != "":
+		_, err = c.Command("AUTHENTICATE %s", quote(password))
+	case methods["SAFECOOKIE"] && cookieFile != "":
+		err = c.authenticateSafeCookie(cookieFile)
+	case methods["COOKIE"] && cookieFile != "":
+		var cookie []byte
+		if cookie, err = readCookie(cookieFile); err != nil {
+			return err
+		}
+		_, err = c.Command("AUTHENTICATE %s", hex.EncodeToString(cookie))
+	case methods["HASHEDPASSWORD"]:
+		err = errors.New("the tor control port requires a password")
+	default:
+		err = errors.New("the tor control port offers no supported authentication method")
+	}
+	return err
+}
+
+// authenticateSafeCookie proves knowledge of the authentication cookie without sending it, after checking that the
+// control port also knows it.
+func (c *Conn) authenticateSafeCookie(cookieFile string) (err error) {
+	var cookie []byte
+	if cookie, err = readCookie(cookieFile); err != nil {
+		return err
+	}
+	clientNonce := make([]byte, 32)
+	if _, err = io.ReadFull(rand.Reader, clientNonce); err != nil {
+		return err
+	}
+	var lines []string
+	if lines, err = c.Command("AUTHCHALLENGE SAFECOOKIE %s", hex.EncodeToString(clientNonce)); err != nil {
+		return err
+	}
+	if len(lines) != 1 || !strings.HasPrefix(lines[0], "AUTHCHALLENGE ") {
+		return errors.New("invalid reply to AUTHCHALLENGE")
+	}
+	fields := parseKeyValues(lines[0][len("AUTHCHALLENGE "):])
+	var serverHash, serverNonce []byte
+	if serverHash, err = hex.DecodeString(fields["SERVERHASH"]); err != nil {
+		return err
+	}
+	if serverNonce, err = hex.DecodeString(fields["SERVERNONCE"]); err != nil {
+		return err
+	}
+	msg := bytes.Join([][]byte{cookie, clientNonce, serverNonce}, nil)
+	if !hmac.Equal(serverHash, safeCookieHash(serverHashKey, msg)) {
+		return errors.New("the tor control port does not know the authentication cookie")
+	}
+	_, err = c.Command("AUTHENTICATE %s", hex.EncodeToString(safeCookieHash(clientHashKey, msg)))
+	return err
+}
+
+// AddOnion creates a hidden service with the private key, or with a new key if it is NewKey, that forwards connections
+// on the virtual port to target. It returns the service ID, which is the onion address without the .onion suffix, and
+// the private key of the service when a new key was generated.
+func (c *Conn) AddOnion(privateKey string, virtPort int, target string) (serviceID, key string, err error) {
+	var lines []string
+	if lines, err = c.Command("ADD_ONION %s Port=%d,%s", privateKey, virtPort, target); err != nil {
+		return "", "", err
+	}
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "ServiceID="):
+			serviceID = line[len("ServiceID="):]
+		case strings.HasPrefix(line, "PrivateKey="):
+			key = line[len("PrivateKey="):]
+		}
+	}
+	if serviceID == "" {
+		return "", "", errors.New("tor did not return the service ID of the hidden service")
+	}
+	return serviceID, key, nil
+}
+
+// Wait blocks until the connection is closed, discarding any asynchronous events, and returns the error that closed it.
+func (c *Conn) Wait() error {
+	for {
+		if _, err := c.text.ReadLine(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseKeyValues parses the space separated key=value pairs of a reply line, where values may be quoted strings.
+func parseKeyValues(s string) map[string]string {
+	kv := make(map[string]string)
+	for s != "" {
+		s = strings.TrimLeft(s, " ")
+		i := strings.IndexByte(s, '=')
+		if i < 0 {
+			break
+		}
+		key := s[:i]
+		s = s[i+1:]
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			// Find the closing quote, skipping escaped characters.
+			end := 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(s) {
+				end = len(s) - 1
+			}
+			var err error
+			if value, err = strconv.Unquote(s[:end+1]); err != nil {
+				value = s[1:end]
+			}
+			s = s[end+1:]
+		} else {
+			if i = strings.IndexByte(s, ' '); i < 0 {
+				i = len(s)
+			}
+			value = s[:i]
+			s = s[i:]
+		}
+		kv[key] = value
+	}
+	return kv
+}
+
+// quote quotes a string for a command argument.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// readCookie reads the authentication cookie from the file Tor wrote it to.
+func readCookie(cookieFile string) ([]byte, error) {
+	cookie, err := ioutil.ReadFile(cookieFile)
+	if err != nil {
+		return nil, err
+	}
+	if len(cookie) != cookieSize {
+		return nil, fmt.Errorf("tor authentication cookie %s is %d bytes, not %d", cookieFile, len(cookie),
+			cookieSize)
+	}
+	return cookie, nil
+}
+
+// safeCookieHash computes an HMAC-SHA256 for safe cookie authentication.
+func safeCookieHash(key, msg []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(msg)
+	return h.Sum(nil)
+}
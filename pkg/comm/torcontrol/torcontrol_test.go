@@ -0,0 +1,106 @@
+package torcontrol
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeTor serves the control protocol on conn, answering each command with reply, which is passed the command.
+func fakeTor(t *testing.T, conn net.Conn, reply func(cmd string) string) {
+	text := textproto.NewConn(conn)
+	defer text.Close()
+	for {
+		cmd, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		if err = text.PrintfLine("%s", reply(cmd)); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+}
+
+// TestSafeCookieAddOnion ensures the connection authenticates with a safe cookie and creates a hidden service.
+func TestSafeCookieAddOnion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "torcontrol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cookie := bytes.Repeat([]byte{7}, cookieSize)
+	cookieFile := filepath.Join(dir, "control_auth_cookie")
+	if err = ioutil.WriteFile(cookieFile, cookie, 0600); err != nil {
+		t.Fatal(err)
+	}
+	serverNonce := bytes.Repeat([]byte{9}, 32)
+	var authenticated bool
+	client, server := net.Pipe()
+	go fakeTor(t, server, func(cmd string) string {
+		switch {
+		case cmd == "PROTOCOLINFO 1":
+			return fmt.Sprintf("250-PROTOCOLINFO 1\r\n250-AUTH METHODS=COOKIE,SAFECOOKIE COOKIEFILE=%q\r\n"+
+				"250-VERSION Tor=\"0.4.8.9\"\r\n250 OK", cookieFile)
+		case strings.HasPrefix(cmd, "AUTHCHALLENGE SAFECOOKIE "):
+			clientNonce, _ := hex.DecodeString(cmd[len("AUTHCHALLENGE SAFECOOKIE "):])
+			msg := bytes.Join([][]byte{cookie, clientNonce, serverNonce}, nil)
+			authenticated = false
+			return fmt.Sprintf("250 AUTHCHALLENGE SERVERHASH=%x SERVERNONCE=%x",
+				safeCookieHash(serverHashKey, msg), serverNonce)
+		case strings.HasPrefix(cmd, "AUTHENTICATE "):
+			authenticated = true
+			return "250 OK"
+		case cmd == "ADD_ONION "+NewKey+" Port=11047,127.0.0.1:11047" && authenticated:
+			return "250-ServiceID=abcdefghijklmnopqrstuvwxyz234567abcdefghijklmnopqrstuvwx\r\n" +
+				"250-PrivateKey=ED25519-V3:a2V5\r\n250 OK"
+		}
+		return "510 Unrecognized command"
+	})
+	c := NewConn(client)
+	defer c.Close()
+	if err = c.Authenticate(""); err != nil {
+		t.Fatalf("authentication failed: %v", err)
+	}
+	serviceID, key, err := c.AddOnion(NewKey, 11047, "127.0.0.1:11047")
+	if err != nil {
+		t.Fatalf("creating the hidden service failed: %v", err)
+	}
+	if serviceID != "abcdefghijklmnopqrstuvwxyz234567abcdefghijklmnopqrstuvwx" || key != "ED25519-V3:a2V5" {
+		t.Errorf("got service %s with key %s", serviceID, key)
+	}
+	if _, err = c.Command("GETINFO version"); err == nil || !strings.Contains(err.Error(), "510") {
+		t.Errorf("error reply was not returned as an error: %v", err)
+	}
+}
+
+// TestPasswordRequired ensures a control port that only accepts a password is not authenticated to without one.
+func TestPasswordRequired(t *testing.T) {
+	client, server := net.Pipe()
+	var password string
+	go fakeTor(t, server, func(cmd string) string {
+		if cmd == "PROTOCOLINFO 1" {
+			return "250-PROTOCOLINFO 1\r\n250-AUTH METHODS=HASHEDPASSWORD\r\n250 OK"
+		}
+		password = cmd
+		return "250 OK"
+	})
+	c := NewConn(client)
+	defer c.Close()
+	if err := c.Authenticate(""); err == nil {
+		t.Fatal("authenticated without a password")
+	}
+	if err := c.Authenticate(`pa"ss`); err != nil {
+		t.Fatal(err)
+	}
+	if password != `AUTHENTICATE "pa\"ss"` {
+		t.Errorf("got %s", password)
+	}
+}
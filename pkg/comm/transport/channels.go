@@ -1,18 +1,21 @@
 package transport
 
 import (
-	"crypto/cipher"
+	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"runtime"
 	"strings"
 	"time"
 
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
 	"github.com/p9c/pod/pkg/coding/fec"
 	"github.com/p9c/pod/pkg/coding/gcm"
 	"github.com/p9c/pod/pkg/comm/multicast"
+	"github.com/p9c/pod/pkg/comm/nodeauth"
 )
 
 const (
@@ -21,6 +24,9 @@ const (
 	closed
 	other
 	DefaultPort = 11049
+	// readBuffers is how many of the largest datagrams a listener holds while the signatures of those before them are
+	// checked
+	readBuffers = 64
 )
 
 var DefaultIP = net.IPv4(224, 0, 0, 1)
@@ -44,9 +50,10 @@ type (
 		firstSender     *string
 		lastSent        *time.Time
 		MaxDatagramSize int
-		receiveCiph     cipher.AEAD
+		auth            *Auth
+		opener          *opener
 		Receiver        *net.UDPConn
-		sendCiph        cipher.AEAD
+		sealer          *sealer
 		Sender          *net.UDPConn
 	}
 )
@@ -59,33 +66,90 @@ func (c *Channel) SetDestination(dst string) (err error) {
 	return
 }
 
-// Send fires off some data through the configured multicast's outbound.
-func (c *Channel) Send(magic []byte, nonce []byte, data []byte) (n int, err error) {
+// Send fires off a shard of the message with the id through the configured multicast's outbound, sealed in an
+// envelope signed with the identity key of the channel.
+func (c *Channel) Send(magic []byte, id []byte, data []byte) (n int, err error) {
+	var msg []byte
+	if msg, err = c.seal(magic, id, data); err != nil {
+		return
+	}
+	n, err = c.Sender.Write(msg)
+	// DEBUG(msg)
+	return
+}
+
+// seal returns the packet carrying a shard of the message with the id
+func (c *Channel) seal(magic []byte, id []byte, data []byte) (msg []byte, err error) {
 	if len(data) == 0 {
 		err = errors.New("not sending empty packet")
 		Error(err)
 		return
 	}
-	var msg []byte
-	if msg, err = EncryptMessage(c.Creator, c.sendCiph, magic, nonce, data); Check(err) {
+	if msg, err = c.sealer.seal(magic, id, data, time.Now()); Check(err) {
+	}
+	return
+}
+
+// newID returns a random id for a message
+func newID() (id []byte, err error) {
+	id = make([]byte, idSize)
+	if _, err = io.ReadFull(rand.Reader, id); Check(err) {
 	}
-	n, err = c.Sender.Write(msg)
-	// DEBUG(msg)
 	return
 }
 
 // SendMany sends a BufIter of shards as produced by GetShards
 func (c *Channel) SendMany(magic []byte, b [][]byte) (err error) {
-	if nonce, err := GetNonce(c.sendCiph); Check(err) {
-	} else {
-		for i := 0; i < len(b); i++ {
-			// DEBUG(i)
-			if _, err = c.Send(magic, nonce, b[i]); Check(err) {
-				// debug.PrintStack()
-			}
+	var id []byte
+	if id, err = newID(); err != nil {
+		return
+	}
+	for i := 0; i < len(b); i++ {
+		// DEBUG(i)
+		if _, err = c.Send(magic, id, b[i]); Check(err) {
+			// debug.PrintStack()
 		}
-		Trace(c.Creator, "sent packets", string(magic), hex.EncodeToString(nonce), c.Sender.LocalAddr(), c.Sender.RemoteAddr())
 	}
+	Trace(c.Creator, "sent packets", string(magic), hex.EncodeToString(id), c.Sender.LocalAddr(), c.Sender.RemoteAddr())
+	return
+}
+
+// SendManyTo sends shards as produced by GetShards to the address from the socket the channel receives on, so the
+// replies of a peer that can't be reached directly, such as one behind NAT, come back to the channel
+func (c *Channel) SendManyTo(addr *net.UDPAddr, magic []byte, b [][]byte) (err error) {
+	var id []byte
+	if id, err = newID(); err != nil {
+		return
+	}
+	for i := 0; i < len(b); i++ {
+		var msg []byte
+		if msg, err = c.seal(magic, id, b[i]); err != nil {
+			return
+		}
+		if _, err = c.Receiver.WriteToUDP(msg, addr); Check(err) {
+		}
+	}
+	Trace(c.Creator, "sent packets", string(magic), hex.EncodeToString(id), c.Receiver.LocalAddr(), addr)
+	return
+}
+
+// Key returns the identity key the channel signs what it sends with
+func (c *Channel) Key() *ec.PublicKey {
+	return c.sealer.key.PubKey()
+}
+
+// newEnvelope prepares the channel to seal what it sends and open what it receives with the pre shared key
+func (c *Channel) newEnvelope(key string, auth *Auth) (err error) {
+	psk := gcm.GetKey(key)
+	var identity *ec.PrivateKey
+	if auth != nil {
+		identity = auth.Key
+	}
+	if c.sealer, err = newSealer(psk, identity); Check(err) {
+		return
+	}
+	c.opener = newOpener(psk)
+	c.auth = auth
 	return
 }
 
@@ -107,24 +171,27 @@ func GetShards(data []byte) (shards [][]byte) {
 	return
 }
 
-// NewUnicastChannel sets up a listener and sender for a specified destination
-func NewUnicastChannel(creator string, ctx interface{}, key, sender, receiver string, maxDatagramSize int,
-	handlers Handlers, quit chan struct{}) (channel *Channel, err error) {
+// NewUnicastChannel sets up a listener and sender for a specified destination. The auth gives the identity key the
+// channel signs with and the senders it accepts messages from, a nil auth signs with a new key and accepts anyone with
+// the key.
+func NewUnicastChannel(creator string, ctx interface{}, key string, auth *Auth, sender, receiver string,
+	maxDatagramSize int, handlers Handlers, quit chan struct{}) (channel *Channel, err error) {
 	channel = &Channel{
 		Creator:         creator,
 		MaxDatagramSize: maxDatagramSize,
 		buffers:         make(map[string]*MsgBuffer),
 		context:         ctx,
+		Ready:           make(chan struct{}),
 	}
 	var magics []string
 
 	for i := range handlers {
 		magics = append(magics, i)
 	}
-	if channel.sendCiph, err = gcm.GetCipher(key); Check(err) {
-	}
-	if channel.receiveCiph, err = gcm.GetCipher(key); Check(err) {
+	if err = channel.newEnvelope(key, auth); Check(err) {
+		return
 	}
+	defer close(channel.Ready)
 	channel.Receiver, err = Listen(receiver, channel, maxDatagramSize, handlers, quit)
 	channel.Sender, err = NewSender(sender, maxDatagramSize)
 	if err != nil {
@@ -134,6 +201,24 @@ func NewUnicastChannel(creator string, ctx interface{}, key, sender, receiver st
 	return
 }
 
+// NewUnicastListener returns a channel that receives on the address and sends with SendManyTo from the same socket,
+// for peers that are not on the same network as the channel, and so can't be reached by multicast. The auth is as for
+// NewUnicastChannel.
+func NewUnicastListener(creator string, ctx interface{}, key string, auth *Auth, address string,
+	maxDatagramSize int, handlers Handlers, quit chan struct{}) (channel *Channel, err error) {
+	channel = &Channel{Creator: creator, MaxDatagramSize: maxDatagramSize,
+		buffers: make(map[string]*MsgBuffer), context: ctx, Ready: make(chan struct{})}
+	if err = channel.newEnvelope(key, auth); Check(err) {
+		return
+	}
+	defer close(channel.Ready)
+	if channel.Receiver, err = Listen(address, channel, maxDatagramSize, handlers, quit); Check(err) {
+		return
+	}
+	Debug("started unicast listener", channel.Creator, channel.Receiver.LocalAddr())
+	return
+}
+
 // NewSender creates a new UDP connection to a specified address
 func NewSender(address string, maxDatagramSize int) (conn *net.UDPConn, err error) {
 	var addr *net.UDPAddr
@@ -162,7 +247,7 @@ func Listen(address string, channel *Channel, maxDatagramSize int, handlers Hand
 		return nil, errors.New("unable to start connection ")
 	}
 	Debug("starting listener on", conn.LocalAddr(), "->", conn.RemoteAddr())
-	if err = conn.SetReadBuffer(maxDatagramSize); Check(err) {
+	if err = conn.SetReadBuffer(readBuffers * maxDatagramSize); Check(err) {
 		// not a critical error but should not happen
 	}
 	go Handle(address, channel, handlers, maxDatagramSize, quit)
@@ -170,20 +255,15 @@ func Listen(address string, channel *Channel, maxDatagramSize int, handlers Hand
 }
 
 // NewBroadcastChannel returns a broadcaster and listener with a given handler on a multicast address and specified
-// port. The handlers define the messages that will be processed and any other messages are ignored
-func NewBroadcastChannel(creator string, ctx interface{}, key string, port int, maxDatagramSize int, handlers Handlers,
-	quit chan struct{}) (channel *Channel, err error) {
+// port. The handlers define the messages that will be processed and any other messages are ignored. The auth gives
+// the identity key the channel signs with and the senders it accepts messages from, a nil auth signs with a new key
+// and accepts anyone with the key.
+func NewBroadcastChannel(creator string, ctx interface{}, key string, auth *Auth, port int, maxDatagramSize int,
+	handlers Handlers, quit chan struct{}) (channel *Channel, err error) {
 	channel = &Channel{Creator: creator, MaxDatagramSize: maxDatagramSize,
 		buffers: make(map[string]*MsgBuffer), context: ctx, Ready: make(chan struct{})}
-	if channel.sendCiph, err = gcm.GetCipher(key); Check(err) {
-	}
-	if channel.sendCiph == nil {
-		panic("nil send cipher")
-	}
-	if channel.receiveCiph, err = gcm.GetCipher(key); Check(err) {
-	}
-	if channel.receiveCiph == nil {
-		panic("nil receive cipher")
+	if err = channel.newEnvelope(key, auth); Check(err) {
+		return
 	}
 	if channel.Receiver, err = ListenBroadcast(port, channel, maxDatagramSize, handlers, quit); Check(err) {
 	}
@@ -220,7 +300,7 @@ func ListenBroadcast(
 	}
 	// DEBUG("magics", magics, PrevCallers())
 	Debug("starting broadcast listener", channel.Creator, address, magics)
-	if err = conn.SetReadBuffer(maxDatagramSize); Check(err) {
+	if err = conn.SetReadBuffer(readBuffers * maxDatagramSize); Check(err) {
 	}
 	channel.Receiver = conn
 	go Handle(address, channel, handlers, maxDatagramSize, quit)
@@ -275,59 +355,57 @@ out:
 				*channel.lastSent = time.Now()
 			}
 			msg := buffer[:numBytes]
-			nL := channel.receiveCiph.NonceSize()
-			nonceBytes := msg[4 : 4+nL]
-			nonce := string(nonceBytes)
-			// if nonce == seenNonce {
-			// 	DEBUG("seen this one")
-			// 	continue
-			// }
-			// seenNonce = nonce
-			// decipher
-			var shard []byte
-			if shard, err = channel.receiveCiph.Open(nil, nonceBytes, msg[4+len(nonceBytes):], nil); err != nil {
+			now := time.Now()
+			// open the envelope, dropping packets that are forged, replayed or from senders not allowed to send them.
+			// The remaining shards of a message already handled are dropped before their signatures are checked
+			var e *envelope
+			if e, err = channel.opener.open(msg, now); err != nil {
+				Trace(channel.Creator, "dropped packet from", src, err)
+				continue
+			}
+			if channel.opener.isDelivered(e.id) {
 				continue
 			}
+			var sender *ec.PublicKey
+			if sender, err = channel.opener.verify(e, now); err != nil {
+				Trace(channel.Creator, "dropped packet from", src, err)
+				continue
+			}
+			if !channel.auth.allows(magic, sender) {
+				Debug(channel.Creator, "dropped", magic, "message from", src, "signed by", nodeauth.KeyString(sender),
+					"which is not allowed")
+				continue
+			}
+			nonce, shard := e.id, e.shard
 			// DEBUG("read", numBytes, "from", src, err, hex.EncodeToString(msg))
 			if bn, ok := channel.buffers[nonce]; ok {
-				if !bn.Decoded {
-					bn.Buffers = append(bn.Buffers, shard)
-					if len(bn.Buffers) >= 3 {
-						// DEBUG(len(bn.Buffers))
-						// try to decode it
-						var cipherText []byte
-						cipherText, err = fec.Decode(bn.Buffers)
-						if err != nil {
-							Error(err)
-							continue
-						}
-						bn.Decoded = true
-						// DEBUG(numBytes, src, err)
-						// Tracef("received packet with magic %s from %s", magic, src.String())
-						if err = handler(channel.context, src, address, cipherText); Check(err) {
-							continue
-						}
-						// src = nil
-						// buffer = buffer[:0]
+				bn.Buffers = append(bn.Buffers, shard)
+				if len(bn.Buffers) >= 3 {
+					// DEBUG(len(bn.Buffers))
+					// try to decode it
+					var cipherText []byte
+					cipherText, err = fec.Decode(bn.Buffers)
+					if err != nil {
+						Error(err)
+						continue
 					}
-				} else {
-					// if nonce == seenNonce {
-					// 	continue
-					// }
-					// seenNonce = nonce
+					channel.opener.deliver(nonce, now)
 					for i := range channel.buffers {
-						if i != nonce || (channel.buffers[i].Decoded &&
-							len(channel.buffers[i].Buffers) > 8) {
-							// superseded messages can be deleted from the buffers, we don't add more data for the
-							// already decoded.
+						if i == nonce || now.Sub(channel.buffers[i].First) > MaxSkew {
+							// decoded and abandoned messages are deleted from the buffers, the rest of the shards of
+							// the decoded are dropped when they arrive.
 							// todo: this will be changed to track stats for the puncture rate and redundancy scaling
 							delete(channel.buffers, i)
 						}
 					}
+					// Tracef("received packet with magic %s from %s", magic, src.String())
+					if err = handler(channel.context, src, address, cipherText); Check(err) {
+						continue
+					}
 				}
 			} else {
 				channel.buffers[nonce] = &MsgBuffer{[][]byte{},
-					time.Now(), false, src}
+					now, false, src}
 				channel.buffers[nonce].Buffers = append(channel.buffers[nonce].
 					Buffers, shard)
 			}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+var (
+	testRegister = []byte("regi")
+	testWork     = []byte("work")
+)
+
+// TestUnicastListener ensures that a message sent to a unicast listener is answered from the socket it arrived on, so
+// the reply reaches the sender without it being dialled.
+func TestUnicastListener(t *testing.T) {
+	quit := make(chan struct{})
+	defer close(quit)
+	work := make(chan []byte, 1)
+	var server *Channel
+	handlers := Handlers{
+		string(testRegister): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
+			return server.SendManyTo(src.(*net.UDPAddr), testWork, GetShards(b))
+		},
+	}
+	var err error
+	server, err = NewUnicastListener("server", nil, "pass", nil, "127.0.0.1:0", 8192, handlers, quit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewUnicastListener("client", nil, "pass", nil, "127.0.0.1:0", 8192, Handlers{
+		string(testWork): func(ctx interface{}, src net.Addr, dst string, b []byte) (err error) {
+			work <- b
+			return
+		},
+	}, quit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = client.SendManyTo(server.Receiver.LocalAddr().(*net.UDPAddr), testRegister,
+		GetShards([]byte("register"))); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case b := <-work:
+		if string(b) != "register" {
+			t.Fatalf("reply carried %q", b)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no reply was received")
+	}
+}
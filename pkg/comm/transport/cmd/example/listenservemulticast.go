@@ -24,7 +24,7 @@ func main() {
 	quit := make(chan struct{})
 	var c *transport.Channel
 	var err error
-	if c, err = transport.NewBroadcastChannel("test", nil, "cipher",
+	if c, err = transport.NewBroadcastChannel("test", nil, "cipher", nil,
 		1234, 8192, transport.Handlers{
 			TestMagic: func(ctx interface{}, src net.Addr, dst string,
 				b []byte) (err error) {
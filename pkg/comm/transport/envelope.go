@@ -0,0 +1,330 @@
+package transport
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/comm/nodeauth"
+)
+
+// Every packet is sealed in an envelope with the keys of a session of its sender, which is started with a random id and
+// replaced every RekeyInterval. The key of a session is derived from the pre shared key and the session id, and each
+// packet of a session has the next sequence number, which is the nonce it is encrypted with. Inside the encryption are
+// the id of the message the packet is a shard of and the time it was sent, and after it the identity key of the sender
+// and its signature of the packet. A packet is dropped if its sequence number was already received in its session, or
+// its time is further than MaxSkew from the clock of the receiver, so packets can't be replayed, and a message can only
+// be sent in the name of a sender that holds its identity key, even by those that know the pre shared key.
+//
+//	magic[4] session[8] sequence[8] sealed(message id[8] time[8] shard) key[33] signature[64]
+const (
+	// protocolName identifies the version of the envelope, and is hashed into the keys of the sessions
+	protocolName = "pod-transport-1"
+	// MaxSkew is how far the time a packet was sent may be from the clock of the receiver before it is dropped
+	MaxSkew = 90 * time.Second
+	// RekeyInterval is how long a sender uses the keys of a session before starting a new one
+	RekeyInterval = 10 * time.Minute
+	// replayWindow is how far behind the newest sequence number of a session a packet can arrive and still be accepted
+	replayWindow = 64
+	magicSize    = 4
+	sessionSize  = 8
+	headerSize   = magicSize + sessionSize + 8
+	idSize       = 8
+	stampSize    = 8
+	keySize      = 33
+	sigSize      = 64
+)
+
+var (
+	// ErrShortPacket is returned for a packet too short to hold an envelope
+	ErrShortPacket = errors.New("packet is too short")
+	// ErrReplay is returned for a packet whose sequence number was already received, or is too old to tell
+	ErrReplay = errors.New("packet was already received")
+	// ErrStale is returned for a packet sent too long before or after the time it was received
+	ErrStale = errors.New("packet time is too far from the current time")
+	// ErrBadSignature is returned for a packet not signed by the identity key it carries, or by a different key than
+	// the other packets of its session
+	ErrBadSignature = errors.New("invalid packet signature")
+)
+
+// Auth is how a channel proves who it is to the channels receiving from it, and who it accepts messages from
+type Auth struct {
+	// Key is the identity key the packets sent are signed with. A channel without one signs with a key it makes
+	Key *ec.PrivateKey
+	// Allowed are the identity keys of the senders that the Guarded messages are accepted from, from anyone if empty
+	Allowed nodeauth.Allowlist
+	// Guarded are the magics of the messages that are only accepted from the Allowed senders
+	Guarded []string
+}
+
+// allows returns whether the message with the magic is accepted from the sender
+func (a *Auth) allows(magic string, sender *ec.PublicKey) bool {
+	if a == nil || len(a.Allowed) == 0 {
+		return true
+	}
+	for _, g := range a.Guarded {
+		if g == magic {
+			return a.Allowed.Allowed(sender)
+		}
+	}
+	return true
+}
+
+// sessionCipher derives the cipher of a session from the pre shared key
+func sessionCipher(psk []byte, session []byte) (ciph cipher.AEAD, err error) {
+	key := make([]byte, 32)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, psk, session, []byte(protocolName)), key); err != nil {
+		return
+	}
+	var block cipher.Block
+	if block, err = aes.NewCipher(key); err != nil {
+		return
+	}
+	return cipher.NewGCM(block)
+}
+
+// sequenceNonce returns the nonce a packet with the sequence number is encrypted with
+func sequenceNonce(seq uint64) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint64(nonce[4:], seq)
+	return nonce
+}
+
+// packetHash is the hash that the sender of a packet signs
+func packetHash(packet []byte) []byte {
+	h := sha256.Sum256(packet)
+	return h[:]
+}
+
+// sealer encrypts and signs the packets a channel sends
+type sealer struct {
+	mx      sync.Mutex
+	psk     []byte
+	key     *ec.PrivateKey
+	session []byte
+	started time.Time
+	seq     uint64
+	ciph    cipher.AEAD
+}
+
+func newSealer(psk []byte, key *ec.PrivateKey) (s *sealer, err error) {
+	if key == nil {
+		if key, err = ec.NewPrivateKey(ec.S256()); err != nil {
+			return
+		}
+	}
+	return &sealer{psk: psk, key: key}, nil
+}
+
+// rekey starts a new session
+func (s *sealer) rekey(now time.Time) (err error) {
+	session := make([]byte, sessionSize)
+	if _, err = io.ReadFull(rand.Reader, session); err != nil {
+		return
+	}
+	if s.ciph, err = sessionCipher(s.psk, session); err != nil {
+		return
+	}
+	s.session, s.started, s.seq = session, now, 0
+	return
+}
+
+// seal returns the packet carrying a shard of the message with the id
+func (s *sealer) seal(magic []byte, id []byte, shard []byte, now time.Time) (packet []byte, err error) {
+	if len(magic) != magicSize {
+		return nil, errors.New("magic must be 4 bytes long")
+	}
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.ciph == nil || now.Sub(s.started) >= RekeyInterval || s.seq == ^uint64(0) {
+		if err = s.rekey(now); err != nil {
+			return
+		}
+	}
+	s.seq++
+	packet = make([]byte, headerSize, headerSize+idSize+stampSize+len(shard)+s.ciph.Overhead()+keySize+sigSize)
+	copy(packet, magic)
+	copy(packet[magicSize:], s.session)
+	binary.BigEndian.PutUint64(packet[magicSize+sessionSize:], s.seq)
+	plain := make([]byte, idSize+stampSize, idSize+stampSize+len(shard))
+	copy(plain, id)
+	binary.BigEndian.PutUint64(plain[idSize:], uint64(now.UnixNano()))
+	plain = append(plain, shard...)
+	packet = s.ciph.Seal(packet, sequenceNonce(s.seq), plain, packet[:headerSize])
+	packet = append(packet, s.key.PubKey().SerializeCompressed()...)
+	var sig *ec.Signature
+	if sig, err = s.key.Sign(packetHash(packet)); err != nil {
+		return nil, err
+	}
+	return append(append(packet, padded(sig.R)...), padded(sig.S)...), nil
+}
+
+// padded returns the 32 byte big endian encoding of a half of a signature
+func padded(n *big.Int) []byte {
+	b := n.Bytes()
+	return append(make([]byte, sigSize/2-len(b)), b...)
+}
+
+// session is what a receiver knows of a session of a sender
+type session struct {
+	ciph   cipher.AEAD
+	sender *ec.PublicKey
+	// top is the newest sequence number received, and bit n of seen is set if top-n was received
+	top  uint64
+	seen uint64
+	last time.Time
+}
+
+// fresh returns whether the sequence number hasn't been received before
+func (s *session) fresh(seq uint64) bool {
+	if seq == 0 {
+		return false
+	}
+	if seq > s.top {
+		return true
+	}
+	behind := s.top - seq
+	return behind < replayWindow && s.seen&(1<<behind) == 0
+}
+
+// mark records that the sequence number was received
+func (s *session) mark(seq uint64) {
+	if seq > s.top {
+		if ahead := seq - s.top; ahead >= replayWindow {
+			s.seen = 0
+		} else {
+			s.seen <<= ahead
+		}
+		s.seen |= 1
+		s.top = seq
+		return
+	}
+	s.seen |= 1 << (s.top - seq)
+}
+
+// opener checks and decrypts the packets a channel receives
+type opener struct {
+	psk      []byte
+	sessions map[string]*session
+	// delivered are the ids of the messages already handled, whose other shards are dropped without checking them
+	delivered map[string]time.Time
+	pruned    time.Time
+}
+
+func newOpener(psk []byte) *opener {
+	return &opener{psk: psk, sessions: make(map[string]*session), delivered: make(map[string]time.Time)}
+}
+
+// envelope is a decrypted packet whose signature is not checked yet, as checking it takes far longer than decrypting,
+// so it is only done for the shards of messages that are not handled yet
+type envelope struct {
+	session *session
+	sid     string
+	seq     uint64
+	signed  []byte
+	sig     []byte
+	// id is the id of the message the shard belongs to, unique for each sender
+	id    string
+	shard []byte
+}
+
+// open decrypts the packet, returning an error if it was not sealed with the pre shared key, was already received or
+// is stale
+func (o *opener) open(packet []byte, now time.Time) (e *envelope, err error) {
+	if len(packet) < headerSize+idSize+stampSize+16+keySize+sigSize {
+		return nil, ErrShortPacket
+	}
+	o.prune(now)
+	e = &envelope{
+		sid:    string(packet[magicSize : magicSize+sessionSize]),
+		seq:    binary.BigEndian.Uint64(packet[magicSize+sessionSize:]),
+		signed: packet[:len(packet)-sigSize],
+		sig:    packet[len(packet)-sigSize:],
+	}
+	var known bool
+	if e.session, known = o.sessions[e.sid]; !known {
+		e.session = &session{}
+		if e.session.ciph, err = sessionCipher(o.psk, []byte(e.sid)); err != nil {
+			return nil, err
+		}
+	}
+	if !e.session.fresh(e.seq) {
+		return nil, ErrReplay
+	}
+	var plain []byte
+	if plain, err = e.session.ciph.Open(nil, sequenceNonce(e.seq), e.signed[headerSize:len(e.signed)-keySize],
+		packet[:headerSize]); err != nil {
+		return nil, err
+	}
+	sent := time.Unix(0, int64(binary.BigEndian.Uint64(plain[idSize:])))
+	if sent.Before(now.Add(-MaxSkew)) || sent.After(now.Add(MaxSkew)) {
+		return nil, ErrStale
+	}
+	e.id, e.shard = e.sid+string(plain[:idSize]), plain[idSize+stampSize:]
+	return e, nil
+}
+
+// verify checks the signature of the envelope and returns its sender, after which the packet is not accepted again
+func (o *opener) verify(e *envelope, now time.Time) (sender *ec.PublicKey, err error) {
+	s := e.session
+	keyBytes := e.signed[len(e.signed)-keySize:]
+	if s.sender == nil {
+		if sender, err = ec.ParsePubKey(keyBytes, ec.S256()); err != nil {
+			return nil, ErrBadSignature
+		}
+	} else if string(s.sender.SerializeCompressed()) != string(keyBytes) {
+		return nil, ErrBadSignature
+	} else {
+		sender = s.sender
+	}
+	sig := &ec.Signature{R: new(big.Int).SetBytes(e.sig[:sigSize/2]), S: new(big.Int).SetBytes(e.sig[sigSize/2:])}
+	if !sig.Verify(packetHash(e.signed), sender) {
+		return nil, ErrBadSignature
+	}
+	// a session is only kept once a packet of it is signed, and by the key that signed it
+	s.sender = sender
+	s.mark(e.seq)
+	s.last = now
+	o.sessions[e.sid] = s
+	return sender, nil
+}
+
+// isDelivered returns whether the message with the id was already handled
+func (o *opener) isDelivered(id string) bool {
+	_, ok := o.delivered[id]
+	return ok
+}
+
+// deliver records that the message with the id was handled, so its other shards are dropped
+func (o *opener) deliver(id string, now time.Time) {
+	o.delivered[id] = now
+}
+
+// prune forgets the sessions and messages not heard from for longer than a packet can be delayed, whose packets would
+// be stale
+func (o *opener) prune(now time.Time) {
+	if now.Sub(o.pruned) < MaxSkew {
+		return
+	}
+	for id, s := range o.sessions {
+		if now.Sub(s.last) > 2*MaxSkew {
+			delete(o.sessions, id)
+		}
+	}
+	for id, t := range o.delivered {
+		if now.Sub(t) > 2*MaxSkew {
+			delete(o.delivered, id)
+		}
+	}
+	o.pruned = now
+}
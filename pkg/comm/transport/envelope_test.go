@@ -0,0 +1,199 @@
+package transport
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/comm/nodeauth"
+)
+
+var (
+	testMagic = []byte("test")
+	testID    = []byte("messages")
+)
+
+// openPacket opens the packet and checks its signature as a channel does.
+func openPacket(o *opener, packet []byte, now time.Time) (sender *ec.PublicKey, id string, shard []byte, err error) {
+	var e *envelope
+	if e, err = o.open(packet, now); err != nil {
+		return
+	}
+	if sender, err = o.verify(e, now); err != nil {
+		return
+	}
+	return sender, e.id, e.shard, nil
+}
+
+// newTestSealer returns a sealer with the pre shared key and a new identity key.
+func newTestSealer(t *testing.T, psk []byte) (*sealer, *ec.PrivateKey) {
+	key, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := newSealer(psk, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, key
+}
+
+// TestEnvelope ensures that a sealed packet opens to its shard and sender, and that a replayed, tampered, stale or
+// wrongly keyed packet is dropped.
+func TestEnvelope(t *testing.T) {
+	psk := bytes.Repeat([]byte{1}, 32)
+	now := time.Now()
+	s, key := newTestSealer(t, psk)
+	packet, err := s.seal(testMagic, testID, []byte("shard"), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := newOpener(psk)
+	sender, id, shard, err := openPacket(o, packet, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sender.IsEqual(key.PubKey()) || string(shard) != "shard" {
+		t.Fatalf("opened the shard %q from the wrong sender", shard)
+	}
+	if _, _, _, err = openPacket(o, packet, now); err != ErrReplay {
+		t.Fatalf("replayed packet was not dropped: %v", err)
+	}
+	next, err := s.seal(testMagic, testID, []byte("shard"), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, nextID, _, err := openPacket(o, next, now); err != nil || nextID != id {
+		t.Fatalf("shards of the same message got different ids: %v", err)
+	}
+	tampered, _ := s.seal(testMagic, testID, []byte("shard"), now)
+	tampered[headerSize] ^= 1
+	if _, _, _, err = openPacket(o, tampered, now); err == nil {
+		t.Fatal("tampered packet was not dropped")
+	}
+	stale, _ := s.seal(testMagic, testID, []byte("shard"), now.Add(-2*MaxSkew))
+	if _, _, _, err = openPacket(o, stale, now); err != ErrStale {
+		t.Fatalf("stale packet was not dropped: %v", err)
+	}
+	wrong, _ := newTestSealer(t, bytes.Repeat([]byte{2}, 32))
+	other, _ := wrong.seal(testMagic, testID, []byte("shard"), now)
+	if _, _, _, err = openPacket(o, other, now); err == nil {
+		t.Fatal("packet sealed with another pre shared key was not dropped")
+	}
+	if _, _, _, err = openPacket(o, packet[:headerSize+10], now); err != ErrShortPacket {
+		t.Fatalf("short packet was not dropped: %v", err)
+	}
+}
+
+// TestEnvelopeForgedSender ensures that a packet signed by a different key than it carries, or than the other packets
+// of its session, is dropped.
+func TestEnvelopeForgedSender(t *testing.T) {
+	psk := bytes.Repeat([]byte{1}, 32)
+	now := time.Now()
+	s, _ := newTestSealer(t, psk)
+	o := newOpener(psk)
+	first, _ := s.seal(testMagic, testID, []byte("shard"), now)
+	if _, _, _, err := openPacket(o, first, now); err != nil {
+		t.Fatal(err)
+	}
+	// another key signs a packet of the same session, as someone that knows the pre shared key could
+	forger, _ := ec.NewPrivateKey(ec.S256())
+	s.key = forger
+	forged, _ := s.seal(testMagic, testID, []byte("shard"), now)
+	if _, _, _, err := openPacket(o, forged, now); err != ErrBadSignature {
+		t.Fatalf("packet signed by another key in the session was not dropped: %v", err)
+	}
+	// the key carried by the packet is swapped for another without signing it again
+	swapped, _ := s.seal(testMagic, testID, []byte("shard"), now)
+	other, _ := ec.NewPrivateKey(ec.S256())
+	copy(swapped[len(swapped)-sigSize-keySize:], other.PubKey().SerializeCompressed())
+	if _, _, _, err := openPacket(newOpener(psk), swapped, now); err != ErrBadSignature {
+		t.Fatalf("packet carrying a key that didn't sign it was not dropped: %v", err)
+	}
+}
+
+// TestEnvelopeReordered ensures that packets arriving out of order within the replay window are accepted once each.
+func TestEnvelopeReordered(t *testing.T) {
+	psk := bytes.Repeat([]byte{1}, 32)
+	now := time.Now()
+	s, _ := newTestSealer(t, psk)
+	o := newOpener(psk)
+	var packets [][]byte
+	for i := 0; i < replayWindow+2; i++ {
+		p, err := s.seal(testMagic, testID, []byte{byte(i)}, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packets = append(packets, p)
+	}
+	last := len(packets) - 1
+	if _, _, _, err := openPacket(o, packets[last], now); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := openPacket(o, packets[last-1], now); err != nil {
+		t.Fatalf("packet behind the newest was dropped: %v", err)
+	}
+	if _, _, _, err := openPacket(o, packets[last-1], now); err != ErrReplay {
+		t.Fatalf("reordered packet was accepted twice: %v", err)
+	}
+	if _, _, _, err := openPacket(o, packets[0], now); err != ErrReplay {
+		t.Fatalf("packet behind the replay window was accepted: %v", err)
+	}
+}
+
+// TestEnvelopeRekey ensures that a sender starts a new session after RekeyInterval, which the receiver accepts.
+func TestEnvelopeRekey(t *testing.T) {
+	psk := bytes.Repeat([]byte{1}, 32)
+	now := time.Now()
+	s, _ := newTestSealer(t, psk)
+	o := newOpener(psk)
+	first, _ := s.seal(testMagic, testID, []byte("shard"), now)
+	later := now.Add(RekeyInterval)
+	second, _ := s.seal(testMagic, testID, []byte("shard"), later)
+	if bytes.Equal(first[magicSize:magicSize+sessionSize], second[magicSize:magicSize+sessionSize]) {
+		t.Fatal("the session was not replaced after the rekey interval")
+	}
+	if _, _, _, err := openPacket(o, second, later); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestDelivered ensures that a delivered message is remembered for as long as its packets could be replayed.
+func TestDelivered(t *testing.T) {
+	now := time.Now()
+	o := newOpener(bytes.Repeat([]byte{1}, 32))
+	o.deliver("message", now)
+	o.prune(now.Add(MaxSkew))
+	if !o.isDelivered("message") {
+		t.Fatal("delivered message was forgotten while its packets are not stale")
+	}
+	o.prune(now.Add(3 * MaxSkew))
+	if o.isDelivered("message") {
+		t.Fatal("delivered message was not forgotten after its packets became stale")
+	}
+}
+
+// TestAuthAllows ensures that guarded messages are only accepted from allowed senders, and others from anyone.
+func TestAuthAllows(t *testing.T) {
+	controller, _ := ec.NewPrivateKey(ec.S256())
+	stranger, _ := ec.NewPrivateKey(ec.S256())
+	allowed, err := nodeauth.ParseAllowlist([]string{nodeauth.KeyString(controller.PubKey())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var open *Auth
+	if !open.allows("work", stranger.PubKey()) {
+		t.Fatal("a channel without auth refused a message")
+	}
+	a := &Auth{Allowed: allowed, Guarded: []string{"work"}}
+	if !a.allows("work", controller.PubKey()) {
+		t.Fatal("guarded message from an allowed sender was refused")
+	}
+	if a.allows("work", stranger.PubKey()) {
+		t.Fatal("guarded message from a sender not allowed was accepted")
+	}
+	if !a.allows("sols", stranger.PubKey()) {
+		t.Fatal("unguarded message was refused")
+	}
+}
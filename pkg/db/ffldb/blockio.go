@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
+	"sync/atomic"
 
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	"github.com/p9c/pod/pkg/chain/wire"
@@ -48,8 +50,18 @@ const (
 var (
 	// castagnoli houses the Catagnoli polynomial used for CRC-32 checksums.
 	castagnoli = crc32.MakeTable(crc32.Castagnoli)
+	// blockBytesRead and blockBytesWritten count the bytes read from and written to the flat block files by all the
+	// block stores of the process.
+	blockBytesRead    uint64
+	blockBytesWritten uint64
 )
 
+// BlockIOStats returns the total number of bytes read from and written to the flat block files since the process
+// started.
+func BlockIOStats() (read, written uint64) {
+	return atomic.LoadUint64(&blockBytesRead), atomic.LoadUint64(&blockBytesWritten)
+}
+
 type (
 	// filer is an interface which acts very similar to a *os.File and is typically implemented by it. It exists so the
 	// test code can provide mock files for properly testing corruption and file system issues.
@@ -258,6 +270,28 @@ func (s *blockStore) deleteFile(fileNum uint32) error {
 	return nil
 }
 
+// closeFile closes the read-only handle for the passed flat file number if it is open and removes it from the least
+// recently used tracking, so that the file can be deleted.
+func (s *blockStore) closeFile(fileNum uint32) {
+	s.obfMutex.Lock()
+	defer s.obfMutex.Unlock()
+	blockFile, ok := s.openBlockFiles[fileNum]
+	if !ok {
+		return
+	}
+	s.lruMutex.Lock()
+	if elem, ok := s.fileNumToLRUElem[fileNum]; ok {
+		s.openBlocksLRU.Remove(elem)
+		delete(s.fileNumToLRUElem, fileNum)
+	}
+	s.lruMutex.Unlock()
+	// Close the file under its write lock so it is not closed out from under any readers.
+	blockFile.Lock()
+	_ = blockFile.file.Close()
+	blockFile.Unlock()
+	delete(s.openBlockFiles, fileNum)
+}
+
 // blockFile attempts to return an existing file handle for the passed flat file number if it is already open as well as
 // marking it as most recently used. It will also open the file when it's not already open subject to the rules
 // described in openFile.
@@ -319,6 +353,7 @@ func (s *blockStore) writeData(data []byte, fieldName string) error {
 	wc := s.writeCursor
 	n, err := wc.curFile.file.WriteAt(data, int64(wc.curOffset))
 	wc.curOffset += uint32(n)
+	atomic.AddUint64(&blockBytesWritten, uint64(n))
 	if err != nil {
 		Error(err)
 		str := fmt.Sprintf("failed to write %s to file %d at "+
@@ -434,6 +469,7 @@ func (s *blockStore) readBlock(hash *chainhash.Hash, loc blockLocation) ([]byte,
 	serializedData := make([]byte, loc.blockLen)
 	n, err := blockFile.file.ReadAt(serializedData, int64(loc.fileOffset))
 	blockFile.RUnlock()
+	atomic.AddUint64(&blockBytesRead, uint64(n))
 	if err != nil {
 		Error(err)
 		str := fmt.Sprintf("failed to read block %s from file %d, "+
@@ -484,8 +520,9 @@ func (s *blockStore) readBlockRegion(loc blockLocation, offset, numBytes uint32)
 	// for network + 4 bytes for block length. Thus, add 8 bytes to adjust.
 	readOffset := loc.fileOffset + 8 + offset
 	serializedData := make([]byte, numBytes)
-	_, err = blockFile.file.ReadAt(serializedData, int64(readOffset))
+	n, err := blockFile.file.ReadAt(serializedData, int64(readOffset))
 	blockFile.RUnlock()
+	atomic.AddUint64(&blockBytesRead, uint64(n))
 	if err != nil {
 		Error(err)
 		str := fmt.Sprintf("failed to read region from block file %d, "+
@@ -610,23 +647,49 @@ func (s *blockStore) handleRollback(oldBlockFileNum, oldBlockOffset uint32) {
 	}
 }
 
+// blockFileInfo is the number and size of a flat block file on disk.
+type blockFileInfo struct {
+	fileNum uint32
+	size    int64
+}
+
+// listBlockFiles returns the flat block files found in the database directory. The file names are zero padded, so the
+// files are in ascending order of file number as the directory listing is sorted by name.
+func listBlockFiles(dbPath string) ([]blockFileInfo, error) {
+	infos, err := ioutil.ReadDir(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	var files []blockFileInfo
+	for _, info := range infos {
+		var fileNum uint32
+		if _, err := fmt.Sscanf(info.Name(), blockFilenameTemplate, &fileNum); err != nil ||
+			info.Name() != fmt.Sprintf(blockFilenameTemplate, fileNum) {
+			continue
+		}
+		files = append(files, blockFileInfo{fileNum: fileNum, size: info.Size()})
+	}
+	return files, nil
+}
+
 // scanBlockFiles searches the database directory for all flat block files to find the end of the most recent file.
 //
 // This position is considered the current write cursor which is also stored in the metadata.
 //
 // Thus, it is used to detect unexpected shutdowns in the middle of writes so the block files can be reconciled.
+//
+// The directory is listed rather than probing file numbers from zero, since the oldest files are removed when the
+// database is pruned.
 func scanBlockFiles(dbPath string) (int, uint32) {
 	lastFile := -1
 	fileLen := uint32(0)
-	for i := 0; ; i++ {
-		filePath := blockFilePath(dbPath, uint32(i))
-		st, err := os.Stat(filePath)
-		if err != nil {
-			Trace(err)
-			break
-		}
-		lastFile = i
-		fileLen = uint32(st.Size())
+	files, err := listBlockFiles(dbPath)
+	if err != nil {
+		Trace(err)
+	}
+	if len(files) > 0 {
+		lastFile = int(files[len(files)-1].fileNum)
+		fileLen = uint32(files[len(files)-1].size)
 	}
 	Tracef("Scan found latest block file #%d with length %d", lastFile, fileLen)
 	return lastFile, fileLen
@@ -1797,6 +1797,96 @@ func (db *db) Close() error {
 	return closeErr
 }
 
+// Prune removes the oldest flat block files, along with the block index entries of the blocks they hold, until the
+// block files take up no more than targetSize bytes. The file holding the block identified by keep, the files after it
+// and the file currently being written are never removed. The hashes of the removed blocks are returned.
+//
+// The block index entries are removed first and the cache is flushed before any file is deleted, so that an unexpected
+// shutdown can at worst leave behind files that are no longer referenced, which are removed by the next prune.
+//
+// This function is part of the database.DB interface implementation.
+func (db *db) Prune(targetSize uint64, keep *chainhash.Hash) ([]chainhash.Hash, error) {
+	files, err := listBlockFiles(db.store.basePath)
+	if err != nil {
+		Error(err)
+		return nil, makeDbErr(database.ErrDriverSpecific, err.Error(), err)
+	}
+	var totalSize uint64
+	for _, file := range files {
+		totalSize += uint64(file.size)
+	}
+	if totalSize <= targetSize {
+		return nil, nil
+	}
+	var pruned []chainhash.Hash
+	var remove []uint32
+	err = db.Update(func(dbTx database.Tx) error {
+		tx := dbTx.(*transaction)
+		keepRow, err := tx.fetchBlockRow(keep)
+		if err != nil {
+			return err
+		}
+		keepFileNum := deserializeBlockLoc(keepRow).blockFileNum
+		wc := db.store.writeCursor
+		wc.RLock()
+		if wc.curFileNum < keepFileNum {
+			keepFileNum = wc.curFileNum
+		}
+		wc.RUnlock()
+		// Remove the oldest files until the target is met, stopping at the first file that must be kept.
+		for _, file := range files {
+			if totalSize <= targetSize || file.fileNum >= keepFileNum {
+				break
+			}
+			remove = append(remove, file.fileNum)
+			totalSize -= uint64(file.size)
+		}
+		if len(remove) == 0 {
+			return nil
+		}
+		cutoff := remove[len(remove)-1]
+		err = tx.blockIdxBucket.ForEach(func(k, v []byte) error {
+			if deserializeBlockLoc(v).blockFileNum <= cutoff {
+				var hash chainhash.Hash
+				copy(hash[:], k)
+				pruned = append(pruned, hash)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for i := range pruned {
+			if err := tx.blockIdxBucket.Delete(pruned[i][:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil || len(remove) == 0 {
+		return nil, err
+	}
+	// Make sure the removed block index entries are persisted before the files they point to are deleted.
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+	db.closeLock.RLock()
+	defer db.closeLock.RUnlock()
+	if db.closed {
+		return pruned, makeDbErr(database.ErrDbNotOpen, errDbNotOpenStr, nil)
+	}
+	if err := db.cache.flush(); err != nil {
+		return pruned, err
+	}
+	for _, fileNum := range remove {
+		db.store.closeFile(fileNum)
+		if err := db.store.deleteFileFunc(fileNum); err != nil {
+			return pruned, err
+		}
+	}
+	Debugf("pruned %d blocks in %d block files", len(pruned), len(remove))
+	return pruned, nil
+}
+
 func // fileExists reports whether the named file or directory exists.
 fileExists(name string) bool {
 	if _, err := os.Stat(name); err != nil {
@@ -599,3 +599,99 @@ func testCorruption(tc *testContext) bool {
 // 	// Test various corruption scenarios.
 // 	testCorruption(tc)
 // }
+
+// TestPrune ensures pruning removes the oldest block files and their block index entries, keeps the requested block
+// and everything after it, and leaves a database that can be reopened and written to.
+func TestPrune(t *testing.T) {
+	t.Parallel()
+	// Make distinct blocks from the genesis block by changing the nonce.
+	blocks := make([]*util.Block, 100)
+	for i := range blocks {
+		msgBlock := *chaincfg.MainNetParams.GenesisBlock
+		msgBlock.Header.Nonce = uint32(i)
+		blocks[i] = util.NewBlock(&msgBlock)
+	}
+	dbPath := filepath.Join(os.TempDir(), "ffldb-prune")
+	_ = os.RemoveAll(dbPath)
+	idb, err := openDB(dbPath, blockDataNet, true)
+	if err != nil {
+		t.Errorf("openDB: unexpected error: %v", err)
+		return
+	}
+	defer os.RemoveAll(dbPath)
+	// Use small files so the test blocks span many of them.
+	idb.(*db).store.maxBlockFileSize = 4096
+	for _, block := range blocks {
+		err := idb.Update(func(tx database.Tx) error {
+			return tx.StoreBlock(block)
+		})
+		if err != nil {
+			t.Errorf("StoreBlock: unexpected error: %v", err)
+			idb.Close()
+			return
+		}
+	}
+	before, _ := listBlockFiles(dbPath)
+	// Nothing is removed when the target is not exceeded.
+	pruned, err := idb.Prune(1<<30, blocks[len(blocks)-1].Hash())
+	if err != nil || len(pruned) != 0 {
+		t.Errorf("Prune: unexpected result with a large target: %d blocks, %v", len(pruned), err)
+	}
+	// Prune as much as possible while keeping the second half of the blocks.
+	keep := len(blocks) / 2
+	pruned, err = idb.Prune(0, blocks[keep].Hash())
+	if err != nil {
+		t.Errorf("Prune: unexpected error: %v", err)
+		idb.Close()
+		return
+	}
+	after, _ := listBlockFiles(dbPath)
+	if len(pruned) == 0 || len(after) >= len(before) {
+		t.Errorf("Prune: removed %d blocks and %d of %d files", len(pruned), len(before)-len(after), len(before))
+	}
+	if len(pruned) > keep {
+		t.Errorf("Prune: removed %d blocks, want at most %d", len(pruned), keep)
+	}
+	err = idb.View(func(tx database.Tx) error {
+		for i, block := range blocks {
+			has, err := tx.HasBlock(block.Hash())
+			if err != nil {
+				return err
+			}
+			if want := i >= len(pruned); has != want {
+				t.Errorf("HasBlock #%d: got %v, want %v", i, has, want)
+			}
+		}
+		_, err := tx.FetchBlock(blocks[0].Hash())
+		checkDbError(t, "FetchBlock: pruned block", err, database.ErrBlockNotFound)
+		_, err = tx.FetchBlock(blocks[keep].Hash())
+		return err
+	})
+	if err != nil {
+		t.Errorf("View: unexpected error: %v", err)
+	}
+	// Reopen the database and ensure new blocks are appended after the remaining files.
+	if err := idb.Close(); err != nil {
+		t.Errorf("Close: unexpected error: %v", err)
+		return
+	}
+	idb, err = openDB(dbPath, blockDataNet, false)
+	if err != nil {
+		t.Errorf("openDB: unexpected error reopening: %v", err)
+		return
+	}
+	defer idb.Close()
+	wc := idb.(*db).store.writeCursor
+	if last := after[len(after)-1]; wc.curFileNum != last.fileNum || wc.curOffset != uint32(last.size) {
+		t.Errorf("write cursor: got %d:%d, want %d:%d", wc.curFileNum, wc.curOffset, last.fileNum, last.size)
+	}
+	err = idb.Update(func(tx database.Tx) error {
+		if err := tx.StoreBlock(blocks[0]); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("StoreBlock after prune: unexpected error: %v", err)
+	}
+}
@@ -387,6 +387,12 @@ type DB interface {
 	//
 	// Calling Rollback or Commit on the transaction passed to the user-supplied function will result in a panic.
 	Update(fn func(tx Tx) error) error
+	// Prune removes the oldest blocks from the database, a whole flat file at a time, until the stored blocks take up
+	// no more than targetSize bytes. The block identified by keep and all blocks stored after it are retained. The
+	// hashes of the removed blocks are returned so the caller can remove any data it keeps about them.
+	//
+	// The removed blocks are no longer reported by HasBlock and fetching them returns ErrBlockNotFound.
+	Prune(targetSize uint64, keep *chainhash.Hash) ([]chainhash.Hash, error)
 	// Close cleanly shuts down the database and syncs all data. It will block until all database transactions have been
 	// finalized (rolled back or committed).
 	Close() error
@@ -583,10 +583,11 @@ func testAdditionalErrors(
 	return true
 }
 
-// TestInterface performs all interfaces tests for this database driver.
+// TestInterface performs all interfaces tests for this database driver. Any args are passed to the driver after the
+// database path.
 func TestInterface(
-	t Tester, dbType, dbPath string) {
-	db, err := walletdb.Create(dbType, dbPath)
+	t Tester, dbType, dbPath string, args ...interface{}) {
+	db, err := walletdb.Create(dbType, append([]interface{}{dbPath}, args...)...)
 	if err != nil {
 		t.Errorf("Failed to create test database (%s) %v", dbType, err)
 		return
@@ -0,0 +1,195 @@
+package edb
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/p9c/pod/pkg/coding/snacl"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util/zero"
+)
+
+var (
+	// ErrNotEncrypted is returned when opening a database that was not created by this driver.
+	ErrNotEncrypted = errors.New("wallet database is not encrypted")
+	// ErrWrongPassphrase is returned when the passphrase does not decrypt the database.
+	ErrWrongPassphrase = errors.New("wrong passphrase for encrypted wallet database")
+	// ErrCorrupt is returned when a stored key or value fails to decrypt.
+	ErrCorrupt = errors.New("encrypted wallet database entry can not be decrypted")
+	// ErrEncrypted is returned when wrapping or encrypting a database that is already encrypted.
+	ErrEncrypted = errors.New("wallet database is already encrypted")
+)
+
+var (
+	// metaBucketKey is the top level bucket holding the parameters needed to derive the database key. It is the only
+	// bucket stored in the clear, and can not collide with an encrypted name as those are longer.
+	metaBucketKey = []byte("edb")
+	// paramsKey stores the scrypt parameters of the passphrase key.
+	paramsKey = []byte("params")
+	// masterKeyKey stores the database key encrypted with the passphrase key, so that the passphrase can be changed
+	// without encrypting the whole database again.
+	masterKeyKey = []byte("masterkey")
+)
+
+// ivSize is the length of the synthetic initialization vector prepended to encrypted keys.
+const ivSize = aes.BlockSize
+
+// codec encrypts the keys and values of a database with subkeys of the database key.
+type codec struct {
+	// plain is set for the codec of a database that is not yet encrypted, which passes keys and values through
+	plain  bool
+	master *snacl.CryptoKey
+	keys   cipher.Block
+	macKey []byte
+	values *snacl.CryptoKey
+}
+
+// newCodec derives the key and value subkeys from the database key.
+func newCodec(master *snacl.CryptoKey) (*codec, error) {
+	keys, err := aes.NewCipher(subkey(master, "key encryption"))
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	values := new(snacl.CryptoKey)
+	copy(values[:], subkey(master, "value encryption"))
+	return &codec{master: master, keys: keys, macKey: subkey(master, "key authentication"), values: values}, nil
+}
+
+// subkey derives an independent key for one purpose from the database key.
+func subkey(master *snacl.CryptoKey, purpose string) []byte {
+	mac := hmac.New(sha256.New, master[:])
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
+
+// iv returns the synthetic initialization vector of a key, which authenticates it and makes its encryption
+// deterministic so the same key always maps to the same stored key.
+func (c *codec) iv(key []byte) []byte {
+	mac := hmac.New(sha256.New, c.macKey)
+	mac.Write(key)
+	return mac.Sum(nil)[:ivSize]
+}
+
+// encryptKey returns the stored form of a key. Empty keys are passed through so the backing database reports them as
+// invalid.
+func (c *codec) encryptKey(key []byte) []byte {
+	if c.plain || len(key) == 0 {
+		return key
+	}
+	iv := c.iv(key)
+	out := make([]byte, ivSize+len(key))
+	copy(out, iv)
+	cipher.NewCTR(c.keys, iv).XORKeyStream(out[ivSize:], key)
+	return out
+}
+
+// decryptKey returns the plain key of a stored key.
+func (c *codec) decryptKey(stored []byte) ([]byte, error) {
+	if c.plain {
+		return stored, nil
+	}
+	if len(stored) <= ivSize {
+		return nil, ErrCorrupt
+	}
+	key := make([]byte, len(stored)-ivSize)
+	cipher.NewCTR(c.keys, stored[:ivSize]).XORKeyStream(key, stored[ivSize:])
+	if !hmac.Equal(c.iv(key), stored[:ivSize]) {
+		return nil, ErrCorrupt
+	}
+	return key, nil
+}
+
+// encryptValue seals a value with a random nonce.
+func (c *codec) encryptValue(value []byte) ([]byte, error) {
+	if c.plain {
+		return value, nil
+	}
+	return c.values.Encrypt(value)
+}
+
+// decryptValue opens a stored value. A nil stored value, which is what the backing database returns for nested
+// buckets and missing keys, stays nil, while an empty value decrypts to an empty, non-nil slice.
+func (c *codec) decryptValue(stored []byte) ([]byte, error) {
+	if c.plain || stored == nil {
+		return stored, nil
+	}
+	value, err := c.values.Decrypt(stored)
+	if err != nil {
+		return nil, ErrCorrupt
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	return value, nil
+}
+
+// zero clears the database key and its subkeys. The key cipher holds its own expanded copy of the key and is dropped
+// with the codec.
+func (c *codec) zero() {
+	if c.plain {
+		return
+	}
+	c.master.Zero()
+	c.values.Zero()
+	zero.Bytes(c.macKey)
+}
+
+// writeMeta stores the database key encrypted with a key derived from the passphrase.
+func writeMeta(tx walletdb.ReadWriteTx, master *snacl.CryptoKey, pass []byte) error {
+	meta := tx.ReadWriteBucket(metaBucketKey)
+	if meta == nil {
+		var err error
+		if meta, err = tx.CreateTopLevelBucket(metaBucketKey); err != nil {
+			return err
+		}
+	}
+	sk, err := snacl.NewSecretKey(&pass, snacl.DefaultN, snacl.DefaultR, snacl.DefaultP)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	defer sk.Zero()
+	wrapped, err := sk.Encrypt(master[:])
+	if err != nil {
+		Error(err)
+		return err
+	}
+	if err = meta.Put(paramsKey, sk.Marshal()); err != nil {
+		return err
+	}
+	return meta.Put(masterKeyKey, wrapped)
+}
+
+// readMeta recovers the database key with the passphrase. ErrNotEncrypted is returned if the database has no
+// encryption parameters.
+func readMeta(tx walletdb.ReadTx, pass []byte) (*snacl.CryptoKey, error) {
+	meta := tx.ReadBucket(metaBucketKey)
+	if meta == nil {
+		return nil, ErrNotEncrypted
+	}
+	var sk snacl.SecretKey
+	if err := sk.Unmarshal(meta.Get(paramsKey)); err != nil {
+		Error(err)
+		return nil, err
+	}
+	defer sk.Zero()
+	if err := sk.DeriveKey(&pass); err != nil {
+		if err == snacl.ErrInvalidPassword {
+			return nil, ErrWrongPassphrase
+		}
+		Error(err)
+		return nil, err
+	}
+	unwrapped, err := sk.Decrypt(meta.Get(masterKeyKey))
+	if err != nil || len(unwrapped) != snacl.KeySize {
+		return nil, ErrCorrupt
+	}
+	master := new(snacl.CryptoKey)
+	copy(master[:], unwrapped)
+	zero.Bytes(unwrapped)
+	return master, nil
+}
@@ -0,0 +1,560 @@
+package edb
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/p9c/pod/pkg/coding/snacl"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	_ "github.com/p9c/pod/pkg/db/walletdb/bdb"
+)
+
+// transaction wraps a transaction of the backing database, encrypting the names of the top level buckets.
+type transaction struct {
+	tx walletdb.ReadWriteTx
+	c  *codec
+}
+
+func (tx *transaction) ReadBucket(key []byte) walletdb.ReadBucket {
+	return tx.ReadWriteBucket(key)
+}
+func (tx *transaction) ReadWriteBucket(key []byte) walletdb.ReadWriteBucket {
+	b := tx.tx.ReadWriteBucket(tx.c.encryptKey(key))
+	// Don't return a non-nil interface to a nil pointer.
+	if b == nil {
+		return nil
+	}
+	return &bucket{b: b, c: tx.c}
+}
+
+// ForEachBucket invokes the passed function with the plain key of every top level bucket, leaving out the bucket of
+// encryption parameters.
+//
+// This function is part of the walletdb.ReadTx interface implementation.
+func (tx *transaction) ForEachBucket(fn func(key []byte) error) error {
+	return tx.tx.ForEachBucket(func(stored []byte) error {
+		if bytes.Equal(stored, metaBucketKey) {
+			return nil
+		}
+		key, err := tx.c.decryptKey(stored)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		return fn(key)
+	})
+}
+func (tx *transaction) CreateTopLevelBucket(key []byte) (walletdb.ReadWriteBucket, error) {
+	b, err := tx.tx.CreateTopLevelBucket(tx.c.encryptKey(key))
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &bucket{b: b, c: tx.c}, nil
+}
+func (tx *transaction) DeleteTopLevelBucket(key []byte) error {
+	return tx.tx.DeleteTopLevelBucket(tx.c.encryptKey(key))
+}
+
+// Commit commits all changes that have been made through the root bucket and all of its sub-buckets to persistent
+// storage.
+//
+// This function is part of the walletdb.Tx interface implementation.
+func (tx *transaction) Commit() error {
+	return tx.tx.Commit()
+}
+
+// Rollback undoes all changes that have been made to the root bucket and all of its sub-buckets.
+//
+// This function is part of the walletdb.Tx interface implementation.
+func (tx *transaction) Rollback() error {
+	return tx.tx.Rollback()
+}
+
+// bucket wraps a bucket of the backing database, encrypting its keys and values.
+type bucket struct {
+	b walletdb.ReadWriteBucket
+	c *codec
+}
+
+// Enforce bucket implements the walletdb Bucket interfaces.
+var _ walletdb.ReadWriteBucket = (*bucket)(nil)
+
+// NestedReadWriteBucket retrieves a nested bucket with the given key. Returns nil if the bucket does not exist.
+//
+// This function is part of the walletdb.ReadWriteBucket interface implementation.
+func (b *bucket) NestedReadWriteBucket(key []byte) walletdb.ReadWriteBucket {
+	nested := b.b.NestedReadWriteBucket(b.c.encryptKey(key))
+	if nested == nil {
+		return nil
+	}
+	return &bucket{b: nested, c: b.c}
+}
+func (b *bucket) NestedReadBucket(key []byte) walletdb.ReadBucket {
+	return b.NestedReadWriteBucket(key)
+}
+
+// CreateBucket creates and returns a new nested bucket with the given key.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) CreateBucket(key []byte) (walletdb.ReadWriteBucket, error) {
+	nested, err := b.b.CreateBucket(b.c.encryptKey(key))
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &bucket{b: nested, c: b.c}, nil
+}
+
+// CreateBucketIfNotExists creates and returns a new nested bucket with the given key if it does not already exist.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) CreateBucketIfNotExists(key []byte) (walletdb.ReadWriteBucket, error) {
+	nested, err := b.b.CreateBucketIfNotExists(b.c.encryptKey(key))
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &bucket{b: nested, c: b.c}, nil
+}
+
+// DeleteNestedBucket removes a nested bucket with the given key.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) DeleteNestedBucket(key []byte) error {
+	return b.b.DeleteNestedBucket(b.c.encryptKey(key))
+}
+
+// ForEach invokes the passed function with every key/value pair in the bucket, in the order of the plain keys.
+//
+// This includes nested buckets, in which case the value is nil, but it does not include the key/value pairs within
+// those nested buckets.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) ForEach(fn func(k, v []byte) error) error {
+	if b.c.plain {
+		return b.b.ForEach(fn)
+	}
+	c := b.cursor()
+	if c.err != nil {
+		return c.err
+	}
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		if c.err != nil {
+			return c.err
+		}
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return c.err
+}
+
+// Put saves the specified key/value pair to the bucket.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) Put(key, value []byte) error {
+	sealed, err := b.c.encryptValue(value)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	return b.b.Put(b.c.encryptKey(key), sealed)
+}
+
+// Get returns the value for the given key, or nil if the key does not exist in this bucket or is a nested bucket.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) Get(key []byte) []byte {
+	value, err := b.c.decryptValue(b.b.Get(b.c.encryptKey(key)))
+	if err != nil {
+		Error(err)
+		return nil
+	}
+	return value
+}
+
+// Delete removes the specified key from the bucket.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) Delete(key []byte) error {
+	return b.b.Delete(b.c.encryptKey(key))
+}
+func (b *bucket) ReadCursor() walletdb.ReadCursor {
+	return b.ReadWriteCursor()
+}
+
+// ReadWriteCursor returns a new cursor, allowing for iteration over the bucket's key/value pairs and nested buckets in
+// forward or backward order.
+//
+// This function is part of the walletdb.Bucket interface implementation.
+func (b *bucket) ReadWriteCursor() walletdb.ReadWriteCursor {
+	if b.c.plain {
+		return b.b.ReadWriteCursor()
+	}
+	return b.cursor()
+}
+
+// cursor decrypts and sorts the keys of the bucket. Keys that fail to decrypt are skipped and the error is kept for
+// ForEach to return.
+func (b *bucket) cursor() *cursor {
+	c := &cursor{b: b, pos: -1}
+	bc := b.b.ReadCursor()
+	for stored, _ := bc.First(); stored != nil; stored, _ = bc.Next() {
+		key, err := b.c.decryptKey(stored)
+		if err != nil {
+			Error(err)
+			c.err = err
+			continue
+		}
+		c.keys = append(c.keys, entry{key: key, stored: append([]byte(nil), stored...)})
+	}
+	sort.Slice(c.keys, func(i, j int) bool {
+		return bytes.Compare(c.keys[i].key, c.keys[j].key) < 0
+	})
+	return c
+}
+
+// entry is a plain key of a bucket with its stored form.
+type entry struct {
+	key, stored []byte
+}
+
+// cursor iterates over the keys of a bucket in the order of the plain keys, which is kept in memory because the
+// backing database orders the encrypted keys.
+//
+// As with the bdb cursor, modifications to the bucket other than through cursor.Delete are not seen by the cursor.
+type cursor struct {
+	b    *bucket
+	keys []entry
+	pos  int
+	err  error
+}
+
+// at returns the pair at the current position, or nil if the cursor is past either end.
+func (c *cursor) at() (key, value []byte) {
+	if c.pos < 0 || c.pos >= len(c.keys) {
+		return nil, nil
+	}
+	e := c.keys[c.pos]
+	value, err := c.b.c.decryptValue(c.b.b.Get(e.stored))
+	if err != nil {
+		Error(err)
+		c.err = err
+	}
+	return e.key, value
+}
+
+// Delete removes the current key/value pair the cursor is at without invalidating the cursor.
+//
+// Returns ErrIncompatibleValue if attempted when the cursor points to a nested bucket.
+//
+// This function is part of the walletdb.Cursor interface implementation.
+func (c *cursor) Delete() error {
+	if c.pos < 0 || c.pos >= len(c.keys) {
+		return nil
+	}
+	if c.b.b.NestedReadBucket(c.keys[c.pos].stored) != nil {
+		return walletdb.ErrIncompatibleValue
+	}
+	if err := c.b.b.Delete(c.keys[c.pos].stored); err != nil {
+		return err
+	}
+	c.keys = append(c.keys[:c.pos], c.keys[c.pos+1:]...)
+	// Step back so that Next moves to the pair after the deleted one.
+	c.pos--
+	return nil
+}
+
+// First positions the cursor at the first key/value pair and returns the pair.
+//
+// This function is part of the walletdb.Cursor interface implementation.
+func (c *cursor) First() (key, value []byte) {
+	c.pos = 0
+	return c.at()
+}
+
+// Last positions the cursor at the last key/value pair and returns the pair.
+//
+// This function is part of the walletdb.Cursor interface implementation.
+func (c *cursor) Last() (key, value []byte) {
+	c.pos = len(c.keys) - 1
+	return c.at()
+}
+
+// Next moves the cursor one key/value pair forward and returns the new pair.
+//
+// This function is part of the walletdb.Cursor interface implementation.
+func (c *cursor) Next() (key, value []byte) {
+	if c.pos < len(c.keys) {
+		c.pos++
+	}
+	return c.at()
+}
+
+// Prev moves the cursor one key/value pair backward and returns the new pair.
+//
+// This function is part of the walletdb.Cursor interface implementation.
+func (c *cursor) Prev() (key, value []byte) {
+	if c.pos >= 0 {
+		c.pos--
+	}
+	return c.at()
+}
+
+// Seek positions the cursor at the passed seek key.
+//
+// If the key does not exist, the cursor is moved to the next key after seek.
+//
+// Returns the new pair.
+//
+// This function is part of the walletdb.Cursor interface implementation.
+func (c *cursor) Seek(seek []byte) (key, value []byte) {
+	c.pos = sort.Search(len(c.keys), func(i int) bool {
+		return bytes.Compare(c.keys[i].key, seek) >= 0
+	})
+	return c.at()
+}
+
+// db wraps a bdb database, encrypting everything stored in it. The codec is replaced when a wrapped database is
+// encrypted, and transactions keep the codec they began with.
+type db struct {
+	db  walletdb.DB
+	mtx sync.Mutex
+	c   *codec
+}
+
+// Enforce db implements the walletdb.Db interface.
+var _ walletdb.DB = (*db)(nil)
+
+// BeginReadTx begins a read only transaction. The backing transaction is begun together with taking the codec, so
+// that it sees the database from the same side of an encryption as the codec.
+func (db *db) BeginReadTx() (walletdb.ReadTx, error) {
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return db.beginTx(db.db.BeginReadTx())
+}
+
+// BeginReadWriteTx begins a read write transaction. The codec is taken once the backing transaction holds the write
+// lock, which an encryption holds until it has replaced the codec.
+func (db *db) BeginReadWriteTx() (walletdb.ReadWriteTx, error) {
+	tx, err := db.db.BeginReadWriteTx()
+	db.mtx.Lock()
+	defer db.mtx.Unlock()
+	return db.beginTx(tx, err)
+}
+
+// beginTx wraps a transaction of the backing database. Read only transactions of the bdb driver implement the read
+// write interface, and writes through them fail with ErrTxNotWritable.
+func (db *db) beginTx(tx walletdb.ReadTx, err error) (*transaction, error) {
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &transaction{tx: tx.(walletdb.ReadWriteTx), c: db.c}, nil
+}
+
+// Copy writes a copy of the database to the provided writer. The copy is encrypted in the same way, and is opened with
+// the same passphrase.
+//
+// This function is part of the walletdb.Db interface implementation.
+func (db *db) Copy(w io.Writer) error {
+	return db.db.Copy(w)
+}
+
+// Close cleanly shuts down the database and syncs all data.
+//
+// This function is part of the walletdb.Db interface implementation.
+func (db *db) Close() error {
+	err := db.db.Close()
+	if err == nil {
+		db.mtx.Lock()
+		db.c.zero()
+		db.mtx.Unlock()
+	}
+	return err
+}
+
+// ChangePassphrase encrypts the database key of an encrypted database with a key derived from a new passphrase, within
+// the transaction tx so the change can be made together with the matching change in the wallet. ErrNotEncrypted is
+// returned if tx is not a transaction of this driver.
+func ChangePassphrase(tx walletdb.ReadWriteTx, pass []byte) error {
+	etx, ok := tx.(*transaction)
+	if !ok || etx.c.plain {
+		return ErrNotEncrypted
+	}
+	return writeMeta(etx.tx, etx.c.master, pass)
+}
+
+// Wrap returns an unencrypted database, opened with the bdb driver, wrapped so that it passes everything through
+// unchanged until it is encrypted with Encrypt while in use. ErrEncrypted is returned if the database is encrypted.
+func Wrap(backing walletdb.DB) (walletdb.DB, error) {
+	err := walletdb.View(backing, func(tx walletdb.ReadTx) error {
+		if tx.ReadBucket(metaBucketKey) != nil {
+			return ErrEncrypted
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &db{db: backing, c: &codec{plain: true}}, nil
+}
+
+// Encrypt encrypts a database returned by Wrap in place, with a key derived from the passphrase, while it remains in
+// use. Everything is encrypted in a single transaction, so the database is either left unencrypted or is wholly
+// encrypted, and transactions begun after it are encrypted. ErrEncrypted is returned if the database is already
+// encrypted, and ErrNotEncrypted if it was not returned by Wrap or opened with this driver.
+//
+// Note that the unencrypted data may still be recoverable from the free pages of the file until they are reused.
+func Encrypt(d walletdb.DB, pass []byte) (err error) {
+	edb, ok := d.(*db)
+	if !ok {
+		return ErrNotEncrypted
+	}
+	tx, err := edb.db.BeginReadWriteTx()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	edb.mtx.Lock()
+	plain := edb.c.plain
+	edb.mtx.Unlock()
+	if !plain {
+		if e := tx.Rollback(); e != nil {
+			Error(e)
+		}
+		return ErrEncrypted
+	}
+	master, err := snacl.GenerateCryptoKey()
+	var c *codec
+	if err == nil {
+		c, err = newCodec(master)
+	}
+	if err == nil {
+		if err = encryptBuckets(tx, c); err == nil {
+			err = writeMeta(tx, master, pass)
+		}
+		if err != nil {
+			c.zero()
+		}
+	}
+	if err != nil {
+		Error(err)
+		if e := tx.Rollback(); e != nil {
+			Error(e)
+		}
+		return err
+	}
+	edb.mtx.Lock()
+	defer edb.mtx.Unlock()
+	if err = tx.Commit(); err != nil {
+		Error(err)
+		c.zero()
+		return err
+	}
+	edb.c = c
+	return nil
+}
+
+// IsEncrypted returns whether the database was opened with this driver or has been encrypted since it was wrapped.
+func IsEncrypted(d walletdb.DB) bool {
+	edb, ok := d.(*db)
+	if !ok {
+		return false
+	}
+	edb.mtx.Lock()
+	defer edb.mtx.Unlock()
+	return !edb.c.plain
+}
+
+// encryptBuckets replaces every top level bucket of the backing transaction with an encrypted copy.
+func encryptBuckets(tx walletdb.ReadWriteTx, c *codec) error {
+	var names [][]byte
+	err := tx.ForEachBucket(func(name []byte) error {
+		names = append(names, append([]byte(nil), name...))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	etx := &transaction{tx: tx, c: c}
+	for _, name := range names {
+		dst, err := etx.CreateTopLevelBucket(name)
+		if err != nil {
+			return err
+		}
+		if err = copyPlainBucket(dst, tx.ReadWriteBucket(name)); err != nil {
+			return err
+		}
+		if err = tx.DeleteTopLevelBucket(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyPlainBucket copies the key/value pairs and nested buckets of an unencrypted bucket into another bucket.
+func copyPlainBucket(dst walletdb.ReadWriteBucket, src walletdb.ReadBucket) error {
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		nested, err := dst.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+		return copyPlainBucket(nested, src.NestedReadBucket(k))
+	})
+}
+
+// openDB opens the encrypted database at the provided path.
+//
+// walletdb.ErrDbDoesNotExist is returned if the database doesn't exist and the create flag is not set, and
+// walletdb.ErrDbExists if it exists and the create flag is set.
+func openDB(dbPath string, pass []byte, create bool) (walletdb.DB, error) {
+	_, err := os.Stat(dbPath)
+	switch {
+	case create && err == nil:
+		return nil, walletdb.ErrDbExists
+	case !create && os.IsNotExist(err):
+		return nil, walletdb.ErrDbDoesNotExist
+	}
+	var backing walletdb.DB
+	if create {
+		backing, err = walletdb.Create("bdb", dbPath)
+	} else {
+		backing, err = walletdb.Open("bdb", dbPath)
+	}
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var master *snacl.CryptoKey
+	if create {
+		if master, err = snacl.GenerateCryptoKey(); err == nil {
+			err = walletdb.Update(backing, func(tx walletdb.ReadWriteTx) error {
+				return writeMeta(tx, master, pass)
+			})
+		}
+	} else {
+		err = walletdb.View(backing, func(tx walletdb.ReadTx) (err error) {
+			master, err = readMeta(tx, pass)
+			return
+		})
+	}
+	var c *codec
+	if err == nil {
+		c, err = newCodec(master)
+	}
+	if err != nil {
+		if e := backing.Close(); e != nil {
+			Error(e)
+		}
+		return nil, err
+	}
+	return &db{db: backing, c: c}, nil
+}
@@ -0,0 +1,32 @@
+/*Package edb implements an instance of walletdb that encrypts the whole database at rest, using a bdb database for the
+backing datastore.
+
+Every key and value, including the names of buckets, is encrypted before it is written to the bolt file, so that the
+transaction history, labels and addresses of a wallet can not be read by someone with access to the disk, even though
+the private keys are already encrypted separately. The encryption key is derived from a passphrase with scrypt. Wallets
+use their public passphrase, which must therefore be changed from the insecure default for the encryption to protect
+anything.
+
+Keys are encrypted deterministically so that a key can be looked up directly, while values are sealed with a random
+nonce. As the encrypted keys are not stored in the order of the plain keys, cursors decrypt and sort the keys of a
+bucket when they are created.
+
+Usage
+
+This package is only a driver to the walletdb package and provides the database type of "edb". The Open and Create
+functions take the database path as a string and the passphrase as a byte slice:
+
+	db, err := walletdb.Open("edb", "path/to/database.db", []byte("passphrase"))
+	if err != nil {
+		// Handle error
+	}
+	db, err := walletdb.Create("edb", "path/to/database.db", []byte("passphrase"))
+	if err != nil {
+		// Handle error
+	}
+
+Opening a database that is not encrypted returns ErrNotEncrypted, and an existing bdb database can be converted with
+EncryptFile. A bdb database that is in use can instead be wrapped with Wrap when it is opened, which passes everything
+through unchanged until Encrypt converts it in place without closing it.
+*/
+package edb
@@ -0,0 +1,63 @@
+package edb
+
+import (
+	"fmt"
+
+	"github.com/p9c/pod/pkg/db/walletdb"
+)
+
+const (
+	dbType = "edb"
+)
+
+// parseArgs parses the arguments from the walletdb Open/Create methods.
+func parseArgs(funcName string, args ...interface{}) (string, []byte, error) {
+	if len(args) != 2 {
+		return "", nil, fmt.Errorf("invalid arguments to %s.%s -- "+
+			"expected database path and passphrase", dbType, funcName)
+	}
+	dbPath, ok := args[0].(string)
+	if !ok {
+		return "", nil, fmt.Errorf("first argument to %s.%s is invalid -- "+
+			"expected database path string", dbType, funcName)
+	}
+	pass, ok := args[1].([]byte)
+	if !ok {
+		return "", nil, fmt.Errorf("second argument to %s.%s is invalid -- "+
+			"expected passphrase byte slice", dbType, funcName)
+	}
+	return dbPath, pass, nil
+}
+
+// openDBDriver is the callback provided during driver registration that opens an existing database for use.
+func openDBDriver(args ...interface{}) (walletdb.DB, error) {
+	dbPath, pass, err := parseArgs("Open", args...)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return openDB(dbPath, pass, false)
+}
+
+// createDBDriver is the callback provided during driver registration that creates, initializes, and opens a database
+// for use.
+func createDBDriver(args ...interface{}) (walletdb.DB, error) {
+	dbPath, pass, err := parseArgs("Create", args...)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return openDB(dbPath, pass, true)
+}
+func init() {
+	// Register the driver.
+	driver := walletdb.Driver{
+		DbType: dbType,
+		Create: createDBDriver,
+		Open:   openDBDriver,
+	}
+	if err := walletdb.RegisterDriver(driver); err != nil {
+		panic(fmt.Sprintf("Failed to regiser database driver '%s': %v",
+			dbType, err))
+	}
+}
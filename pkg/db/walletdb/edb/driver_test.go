@@ -0,0 +1,341 @@
+package edb_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/db/walletdb/edb"
+)
+
+// dbType is the database type name for this driver.
+const dbType = "edb"
+
+var (
+	testPass  = []byte("passphrase")
+	testBkt   = []byte("transactions")
+	testKey   = []byte("wallet transaction key")
+	testValue = []byte("wallet transaction label")
+)
+
+// tempDB returns a path for a database in a new temporary directory, and a function removing it.
+func tempDB(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "edb_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "wallet.db"), func() { os.RemoveAll(dir) }
+}
+
+// putTestValue stores the test value under the test key, in a nested bucket of the test bucket.
+func putTestValue(db walletdb.DB) error {
+	return walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		b, err := tx.CreateTopLevelBucket(testBkt)
+		if err != nil {
+			return err
+		}
+		nested, err := b.CreateBucket([]byte("nested"))
+		if err != nil {
+			return err
+		}
+		return nested.Put(testKey, testValue)
+	})
+}
+
+// getTestValue returns the value stored by putTestValue.
+func getTestValue(db walletdb.DB) (value []byte, err error) {
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+		b := tx.ReadBucket(testBkt)
+		if b == nil {
+			return walletdb.ErrBucketNotFound
+		}
+		nested := b.NestedReadBucket([]byte("nested"))
+		if nested == nil {
+			return walletdb.ErrBucketNotFound
+		}
+		value = append([]byte(nil), nested.Get(testKey)...)
+		return nil
+	})
+	return
+}
+
+// TestOpen ensures that a database can only be opened with the passphrase it was created with, and that nothing stored
+// in it can be found in the file in the clear.
+func TestOpen(t *testing.T) {
+	dbPath, cleanup := tempDB(t)
+	defer cleanup()
+	if _, err := walletdb.Open(dbType, dbPath, testPass); err != walletdb.ErrDbDoesNotExist {
+		t.Fatalf("Open: got error %v, want %v", err, walletdb.ErrDbDoesNotExist)
+	}
+	db, err := walletdb.Create(dbType, dbPath, testPass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = putTestValue(db); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = walletdb.Create(dbType, dbPath, testPass); err != walletdb.ErrDbExists {
+		t.Fatalf("Create: got error %v, want %v", err, walletdb.ErrDbExists)
+	}
+	raw, err := ioutil.ReadFile(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, plain := range [][]byte{testBkt, testKey, testValue} {
+		if bytes.Contains(raw, plain) {
+			t.Errorf("database file contains %q in the clear", plain)
+		}
+	}
+	if _, err = walletdb.Open(dbType, dbPath, []byte("wrong")); err != edb.ErrWrongPassphrase {
+		t.Fatalf("Open: got error %v, want %v", err, edb.ErrWrongPassphrase)
+	}
+	if db, err = walletdb.Open(dbType, dbPath, testPass); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	value, err := getTestValue(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, testValue) {
+		t.Errorf("got value %q, want %q", value, testValue)
+	}
+}
+
+// TestCursor ensures that cursors and ForEach visit the keys in the order of the plain keys, even though they are
+// stored in a different order.
+func TestCursor(t *testing.T) {
+	dbPath, cleanup := tempDB(t)
+	defer cleanup()
+	db, err := walletdb.Create(dbType, dbPath, testPass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	keys := []string{"a", "b", "ba", "c", "d", "e"}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		b, err := tx.CreateTopLevelBucket(testBkt)
+		if err != nil {
+			return err
+		}
+		for i := len(keys) - 1; i >= 0; i-- {
+			if keys[i] == "c" {
+				_, err = b.CreateBucket([]byte(keys[i]))
+			} else {
+				err = b.Put([]byte(keys[i]), []byte("value "+keys[i]))
+			}
+			if err != nil {
+				return err
+			}
+		}
+		var got []string
+		err = b.ForEach(func(k, v []byte) error {
+			if (v == nil) != (string(k) == "c") {
+				t.Errorf("ForEach: key %q has value %q", k, v)
+			}
+			got = append(got, string(k))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(got) != len(keys) {
+			t.Fatalf("ForEach: got keys %v, want %v", got, keys)
+		}
+		for i := range keys {
+			if got[i] != keys[i] {
+				t.Fatalf("ForEach: got keys %v, want %v", got, keys)
+			}
+		}
+		c := b.ReadWriteCursor()
+		if k, v := c.Seek([]byte("b0")); string(k) != "ba" || string(v) != "value ba" {
+			t.Errorf("Seek: got %q %q, want ba", k, v)
+		}
+		if k, _ := c.Prev(); string(k) != "b" {
+			t.Errorf("Prev: got %q, want b", k)
+		}
+		if k, _ := c.Last(); string(k) != "e" {
+			t.Errorf("Last: got %q, want e", k)
+		}
+		// Delete every value while iterating, which must leave only the nested bucket.
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if err := c.Delete(); err != nil && string(k) != "c" {
+				return err
+			}
+		}
+		got = nil
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			got = append(got, string(k))
+		}
+		if len(got) != 1 || got[0] != "c" || b.Get([]byte("a")) != nil {
+			t.Errorf("after deleting values got keys %v, want [c]", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestEncryptFile ensures that an unencrypted database is converted with all of its contents.
+func TestEncryptFile(t *testing.T) {
+	dbPath, cleanup := tempDB(t)
+	defer cleanup()
+	db, err := walletdb.Create("bdb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = putTestValue(db); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = walletdb.Open(dbType, dbPath, testPass); err != edb.ErrNotEncrypted {
+		t.Fatalf("Open: got error %v, want %v", err, edb.ErrNotEncrypted)
+	}
+	if err = edb.EncryptFile(dbPath, testPass); err != nil {
+		t.Fatal(err)
+	}
+	if err = edb.EncryptFile(dbPath, testPass); err != walletdb.ErrDbExists {
+		t.Fatalf("EncryptFile: got error %v on encrypted database, want %v", err, walletdb.ErrDbExists)
+	}
+	if db, err = walletdb.Open(dbType, dbPath, testPass); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	value, err := getTestValue(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, testValue) {
+		t.Errorf("got value %q, want %q", value, testValue)
+	}
+}
+
+// TestChangePassphrase ensures that after changing the passphrase the database opens with the new passphrase only.
+func TestChangePassphrase(t *testing.T) {
+	dbPath, cleanup := tempDB(t)
+	defer cleanup()
+	db, err := walletdb.Create(dbType, dbPath, testPass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = putTestValue(db); err != nil {
+		t.Fatal(err)
+	}
+	newPass := []byte("new passphrase")
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		return edb.ChangePassphrase(tx, newPass)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = walletdb.Open(dbType, dbPath, testPass); err != edb.ErrWrongPassphrase {
+		t.Fatalf("Open: got error %v with the old passphrase, want %v", err, edb.ErrWrongPassphrase)
+	}
+	if db, err = walletdb.Open(dbType, dbPath, newPass); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	value, err := getTestValue(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, testValue) {
+		t.Errorf("got value %q, want %q", value, testValue)
+	}
+}
+
+// TestEncrypt ensures that a wrapped database can be encrypted while it is open, keeping its contents and remaining
+// usable, and that it then only opens with the passphrase.
+func TestEncrypt(t *testing.T) {
+	dbPath, cleanup := tempDB(t)
+	defer cleanup()
+	backing, err := walletdb.Create("bdb", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := edb.Wrap(backing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = putTestValue(db); err != nil {
+		t.Fatal(err)
+	}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		return edb.ChangePassphrase(tx, testPass)
+	})
+	if err != edb.ErrNotEncrypted {
+		t.Fatalf("ChangePassphrase: got error %v before encrypting, want %v", err, edb.ErrNotEncrypted)
+	}
+	if edb.IsEncrypted(db) {
+		t.Fatal("IsEncrypted: wrapped database reported encrypted")
+	}
+	if err = edb.Encrypt(db, testPass); err != nil {
+		t.Fatal(err)
+	}
+	if !edb.IsEncrypted(db) {
+		t.Fatal("IsEncrypted: encrypted database reported unencrypted")
+	}
+	if err = edb.Encrypt(db, testPass); err != edb.ErrEncrypted {
+		t.Fatalf("Encrypt: got error %v on encrypted database, want %v", err, edb.ErrEncrypted)
+	}
+	value, err := getTestValue(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, testValue) {
+		t.Errorf("got value %q, want %q", value, testValue)
+	}
+	var buckets []string
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		if _, err := tx.CreateTopLevelBucket([]byte("labels")); err != nil {
+			return err
+		}
+		return tx.ForEachBucket(func(key []byte) error {
+			buckets = append(buckets, string(key))
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 2 {
+		t.Errorf("ForEachBucket: got buckets %q, want the test bucket and labels", buckets)
+	}
+	if err = db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = walletdb.Open(dbType, dbPath, []byte("wrong")); err != edb.ErrWrongPassphrase {
+		t.Fatalf("Open: got error %v with the wrong passphrase, want %v", err, edb.ErrWrongPassphrase)
+	}
+	if backing, err = walletdb.Open("bdb", dbPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = edb.Wrap(backing); err != edb.ErrEncrypted {
+		t.Fatalf("Wrap: got error %v on encrypted database, want %v", err, edb.ErrEncrypted)
+	}
+	if err = backing.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if db, err = walletdb.Open(dbType, dbPath, testPass); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if value, err = getTestValue(db); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, testValue) {
+		t.Errorf("got value %q after reopening, want %q", value, testValue)
+	}
+}
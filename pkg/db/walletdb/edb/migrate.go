@@ -0,0 +1,85 @@
+package edb
+
+import (
+	"os"
+
+	bolt "github.com/coreos/bbolt"
+
+	"github.com/p9c/pod/pkg/db/walletdb"
+)
+
+// EncryptFile converts the unencrypted bdb database at dbPath into an encrypted database using the passphrase. The
+// encrypted copy is written next to the original and then replaces it, so the original is left untouched if the
+// conversion fails.
+//
+// Note that the unencrypted data may still be recoverable from the disk blocks the original file occupied.
+func EncryptFile(dbPath string, pass []byte) error {
+	tmpPath := dbPath + ".encrypting"
+	if err := os.Remove(tmpPath); err != nil && !os.IsNotExist(err) {
+		Error(err)
+		return err
+	}
+	if err := encryptCopy(dbPath, tmpPath, pass); err != nil {
+		if e := os.Remove(tmpPath); e != nil && !os.IsNotExist(e) {
+			Error(e)
+		}
+		return err
+	}
+	return os.Rename(tmpPath, dbPath)
+}
+
+// encryptCopy writes an encrypted copy of the bdb database at srcPath to a new database at dstPath.
+func encryptCopy(srcPath, dstPath string, pass []byte) (err error) {
+	src, err := bolt.Open(srcPath, 0600, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		Error(err)
+		return err
+	}
+	defer func() {
+		if e := src.Close(); e != nil && err == nil {
+			err = e
+		}
+	}()
+	err = src.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(metaBucketKey) != nil {
+			return walletdb.ErrDbExists
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	dst, err := openDB(dstPath, pass, true)
+	if err != nil {
+		return err
+	}
+	err = src.View(func(stx *bolt.Tx) error {
+		return walletdb.Update(dst, func(dtx walletdb.ReadWriteTx) error {
+			return stx.ForEach(func(name []byte, sb *bolt.Bucket) error {
+				db, err := dtx.CreateTopLevelBucket(name)
+				if err != nil {
+					return err
+				}
+				return copyBucket(db, sb)
+			})
+		})
+	})
+	if e := dst.Close(); e != nil && err == nil {
+		err = e
+	}
+	return err
+}
+
+// copyBucket copies the key/value pairs and nested buckets of a bolt bucket into a walletdb bucket.
+func copyBucket(dst walletdb.ReadWriteBucket, src *bolt.Bucket) error {
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		nested, err := dst.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+		return copyBucket(nested, src.Bucket(k))
+	})
+}
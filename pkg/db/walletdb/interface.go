@@ -12,6 +12,8 @@ type ReadTx interface {
 	// ReadBucket opens the root bucket for read only access. If the bucket described by the key does not exist, nil is
 	// returned.
 	ReadBucket(key []byte) ReadBucket
+	// ForEachBucket invokes the passed function with the key of every top level bucket.
+	ForEachBucket(func(key []byte) error) error
 	// Rollback closes the transaction, discarding changes (if any) if the database was modified by a write transaction.
 	Rollback() error
 }
@@ -1,28 +1,31 @@
 package cfg
 
 import (
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	l "gioui.org/layout"
 	"github.com/urfave/cli"
 	"golang.org/x/exp/shiny/materialdesign/icons"
 
-	"github.com/p9c/pod/app/save"
 	"github.com/p9c/pod/pkg/gui/p9"
 	"github.com/p9c/pod/pkg/pod"
 )
 
 type Item struct {
 	slug        string
+	hook        string
 	typ         string
 	label       string
 	description string
 	widget      string
 	dataType    string
 	options     []string
+	restart     bool
 	Slot        interface{}
 }
 
@@ -88,12 +91,14 @@ func (c *Config) Config() GroupsMap {
 			}
 			tabNames[sgf.Group][sgf.Slug] = &Item{
 				slug:        sgf.Slug,
+				hook:        sgf.Hooks,
 				typ:         sgf.Type,
 				label:       sgf.Label,
 				description: sgf.Description,
 				widget:      sgf.Widget,
 				dataType:    sgf.Datatype,
 				options:     sgf.Options,
+				restart:     sgf.Hooks != "" && c.live[sgf.Hooks] == nil,
 				Slot:        c.cx.ConfigMap[sgf.Slug],
 			}
 			// Debugs(sgf)
@@ -105,7 +110,7 @@ func (c *Config) Config() GroupsMap {
 					Debug(sgf.Slug, "submitted", b)
 					bb := c.cx.ConfigMap[sgf.Slug].(*bool)
 					*bb = b
-					save.Pod(c.cx.Config)
+					c.changed(tgs)
 					if sgf.Slug == "DarkTheme" {
 						c.th.Colors.SetTheme(b)
 					}
@@ -115,38 +120,52 @@ func (c *Config) Config() GroupsMap {
 					"Primary", "PanelBg", 26, func(txt string) {
 						Debug(sgf.Slug, "submitted", txt)
 						i := c.cx.ConfigMap[sgf.Slug].(*int)
-						if n, err := strconv.Atoi(txt); !Check(err) {
-							*i = n
+						n, err := strconv.Atoi(txt)
+						if c.setError(sgf.Slug, validateNumber(n < 0, err)) {
+							return
 						}
-						save.Pod(c.cx.Config)
+						*i = n
+						c.changed(tgs)
 					})
 			case "time":
 				c.inputs[sgf.Slug] = c.th.Input(fmt.Sprint(*tgs.Slot.(*time.Duration)), sgf.Slug,
 					"Primary", "PanelBg", 26, func(txt string) {
 						Debug(sgf.Slug, "submitted", txt)
 						tt := c.cx.ConfigMap[sgf.Slug].(*time.Duration)
-						if d, err := time.ParseDuration(txt); !Check(err) {
-							*tt = d
+						d, err := time.ParseDuration(txt)
+						if err != nil {
+							err = errors.New("not a valid duration, such as 90s or 1h30m")
+						} else if d < 0 {
+							err = errNegative
 						}
-						save.Pod(c.cx.Config)
+						if c.setError(sgf.Slug, err) {
+							return
+						}
+						*tt = d
+						c.changed(tgs)
 					})
 			case "float":
 				c.inputs[sgf.Slug] = c.th.Input(strconv.FormatFloat(*tgs.Slot.(*float64), 'f', -1, 64), sgf.Slug,
 					"Primary", "PanelBg", 26, func(txt string) {
 						Debug(sgf.Slug, "submitted", txt)
 						ff := c.cx.ConfigMap[sgf.Slug].(*float64)
-						if f, err := strconv.ParseFloat(txt, 64); !Check(err) {
-							*ff = f
+						f, err := strconv.ParseFloat(txt, 64)
+						if c.setError(sgf.Slug, validateNumber(f < 0, err)) {
+							return
 						}
-						save.Pod(c.cx.Config)
+						*ff = f
+						c.changed(tgs)
 					})
 			case "string":
 				c.inputs[sgf.Slug] = c.th.Input(*tgs.Slot.(*string), sgf.Slug,
 					"Primary", "PanelBg", 26, func(txt string) {
 						Debug(sgf.Slug, "submitted", txt)
+						if c.setError(sgf.Slug, c.validateText(sgf.Type, txt)) {
+							return
+						}
 						ss := c.cx.ConfigMap[sgf.Slug].(*string)
 						*ss = txt
-						save.Pod(c.cx.Config)
+						c.changed(tgs)
 					})
 			case "password":
 				c.passwords[sgf.Slug] = c.th.Password("password", tgs.Slot.(*string),
@@ -154,15 +173,24 @@ func (c *Config) Config() GroupsMap {
 						Debug(sgf.Slug, "submitted", txt)
 						pp := c.cx.ConfigMap[sgf.Slug].(*string)
 						*pp = txt
-						save.Pod(c.cx.Config)
+						c.changed(tgs)
 					})
 			case "multi":
 				c.multis[sgf.Slug] = c.th.Multiline(tgs.Slot.(*cli.StringSlice),
 					"Primary", "PanelBg", 30, func(txt []string) {
 						Debug(sgf.Slug, "submitted", txt)
+						var err error
+						for i := range txt {
+							if err = c.validateText(sgf.Type, txt[i]); err != nil {
+								break
+							}
+						}
+						if c.setError(sgf.Slug, err) {
+							return
+						}
 						sss := c.cx.ConfigMap[sgf.Slug].(*cli.StringSlice)
 						*sss = txt
-						save.Pod(c.cx.Config)
+						c.changed(tgs)
 					})
 				// c.multis[sgf.Slug]
 			case "radio":
@@ -174,7 +202,7 @@ func (c *Config) Config() GroupsMap {
 				c.enums[sgf.Slug] = c.th.Enum().SetValue(txt).SetOnChange(func(value string) {
 					rr := c.cx.ConfigMap[sgf.Slug].(*string)
 					*rr = value
-					save.Pod(c.cx.Config)
+					c.changed(tgs)
 				})
 				c.lists[sgf.Slug] = c.th.List()
 			}
@@ -209,7 +237,7 @@ func (gm GroupsMap) Widget(ng *Config) l.Widget {
 		groups = append(groups, List{name: i, items: li})
 	}
 	sort.Sort(groups)
-	var out []l.Widget
+	out := []l.Widget{ng.restartBanner}
 	first := true
 	for i := range groups {
 		// Debug(groups[i].name)
@@ -308,6 +336,73 @@ func (c *Config) RenderConfigItem(item *Item, position int) []l.Widget {
 	return []l.Widget{func(l.Context) l.Dimensions { return l.Dimensions{} }}
 }
 
+// label renders the label of a setting, marking the settings that only take effect after a restart and those that are
+// applied to the running workers as soon as they are changed
+func (c *Config) label(item *Item) l.Widget {
+	note := "needs restart"
+	if _, ok := c.live[item.hook]; ok {
+		note = "applies immediately"
+	} else if !item.restart {
+		return c.th.Body1(item.label).Fn
+	}
+	return c.th.Flex().
+		Rigid(
+			c.th.Body1(item.label).Fn,
+		).
+		Rigid(
+			c.th.Inset(0.25, c.th.Caption(note).Color("Hint").Fn).Fn,
+		).
+		Fn
+}
+
+// description renders the description of a setting followed by the reason the last value entered was rejected
+func (c *Config) description(item *Item) l.Widget {
+	return func(gtx l.Context) l.Dimensions {
+		msg := c.getError(item.slug)
+		if msg == "" {
+			return c.th.Caption(item.description).Fn(gtx)
+		}
+		return c.th.VFlex().
+			Rigid(
+				c.th.Caption(item.description).Fn,
+			).
+			Rigid(
+				c.th.Caption(msg).Color("Danger").Fn,
+			).
+			Fn(gtx)
+	}
+}
+
+// restartBanner lists the changed settings that need a restart along with a button to apply them by restarting the
+// workers
+func (c *Config) restartBanner(gtx l.Context) l.Dimensions {
+	pending := c.Pending()
+	if len(pending) == 0 {
+		return l.Dimensions{}
+	}
+	return c.th.Fill("Warning",
+		c.th.Inset(0.5,
+			c.th.Flex().
+				Flexed(1,
+					c.th.VFlex().
+						Rigid(
+							c.th.Body1("Changes need a restart to take effect").Color("Dark").Fn,
+						).
+						Rigid(
+							c.th.Caption(strings.Join(pending, ", ")).Color("Dark").Fn,
+						).
+						Fn,
+				).
+				Rigid(
+					c.th.Button(c.clickables["restart"].SetClick(c.ApplyAndRestart)).
+						Text("apply and restart").
+						Fn,
+				).
+				Fn,
+		).Fn,
+	).Fn(gtx)
+}
+
 func (c *Config) RenderToggle(item *Item) []l.Widget {
 	return []l.Widget{
 		func(gtx l.Context) l.Dimensions {
@@ -318,10 +413,10 @@ func (c *Config) RenderToggle(item *Item) []l.Widget {
 				Rigid(
 					c.th.VFlex().
 						Rigid(
-							c.th.Body1(item.label).Fn,
+							c.label(item),
 						).
 						Rigid(
-							c.th.Caption(item.description).Fn,
+							c.description(item),
 						).
 						Fn,
 				).Fn,
@@ -335,13 +430,13 @@ func (c *Config) RenderInteger(item *Item) []l.Widget {
 		func(gtx l.Context) l.Dimensions {
 			return c.th.Inset(0.25, c.th.VFlex().
 				Rigid(
-					c.th.Body1(item.label).Fn,
+					c.label(item),
 				).
 				Rigid(
 					c.inputs[item.slug].Fn,
 				).
 				Rigid(
-					c.th.Caption(item.description).Fn,
+					c.description(item),
 				).
 				Fn,
 			).
@@ -355,13 +450,13 @@ func (c *Config) RenderTime(item *Item) []l.Widget {
 		func(gtx l.Context) l.Dimensions {
 			return c.th.Inset(0.25, c.th.VFlex().
 				Rigid(
-					c.th.Body1(item.label).Fn,
+					c.label(item),
 				).
 				Rigid(
 					c.inputs[item.slug].Fn,
 				).
 				Rigid(
-					c.th.Caption(item.description).Fn,
+					c.description(item),
 				).
 				Fn,
 			).
@@ -375,13 +470,13 @@ func (c *Config) RenderFloat(item *Item) []l.Widget {
 		func(gtx l.Context) l.Dimensions {
 			return c.th.Inset(0.25, c.th.VFlex().
 				Rigid(
-					c.th.Body1(item.label).Fn,
+					c.label(item),
 				).
 				Rigid(
 					c.inputs[item.slug].Fn,
 				).
 				Rigid(
-					c.th.Caption(item.description).Fn,
+					c.description(item),
 				).
 				Fn,
 			).
@@ -395,13 +490,13 @@ func (c *Config) RenderString(item *Item) []l.Widget {
 		c.th.Inset(0.25,
 			c.th.VFlex().
 				Rigid(
-					c.th.Body1(item.label).Fn,
+					c.label(item),
 				).
 				Rigid(
 					c.inputs[item.slug].Fn,
 				).
 				Rigid(
-					c.th.Caption(item.description).Fn,
+					c.description(item),
 				).
 				Fn,
 		).
@@ -414,13 +509,13 @@ func (c *Config) RenderPassword(item *Item) []l.Widget {
 		c.th.Inset(0.25,
 			c.th.VFlex().
 				Rigid(
-					c.th.Body1(item.label).Fn,
+					c.label(item),
 				).
 				Rigid(
 					c.passwords[item.slug].Fn,
 				).
 				Rigid(
-					c.th.Caption(item.description).Fn,
+					c.description(item),
 				).
 				Fn,
 		).
@@ -436,10 +531,10 @@ func (c *Config) RenderMulti(item *Item, position int) []l.Widget {
 			return c.th.Inset(0.25,
 				c.th.VFlex().
 					Rigid(
-						c.th.Body1(item.label).Fn,
+						c.label(item),
 					).
 					Rigid(
-						c.th.Caption(item.description).Fn,
+						c.description(item),
 					).Fn,
 			).
 				Fn(gtx)
@@ -471,7 +566,7 @@ func (c *Config) RenderRadio(item *Item) []l.Widget {
 		return c.th.Inset(0.25,
 			c.th.VFlex().
 				Rigid(
-					c.th.Body1(item.label).Fn,
+					c.label(item),
 				).
 				Rigid(
 					c.th.Flex().
@@ -487,7 +582,7 @@ func (c *Config) RenderRadio(item *Item) []l.Widget {
 							},
 						).
 						Rigid(
-							c.th.Caption(item.description).Fn,
+							c.description(item),
 						).
 						Fn,
 				).Fn,
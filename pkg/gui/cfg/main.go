@@ -1,7 +1,12 @@
 package cfg
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
 	"github.com/p9c/pod/app/conte"
+	"github.com/p9c/pod/app/save"
 	"github.com/p9c/pod/pkg/gui/p9"
 )
 
@@ -28,6 +33,13 @@ type Config struct {
 	configs    GroupsMap
 	passwords  map[string]*p9.Password
 	quit       chan struct{}
+	// mx guards the validation errors and the changes waiting for a restart, which are set from the input handlers
+	mx      sync.Mutex
+	errors  map[string]string
+	pending map[string]string
+	restart func()
+	// live applies the settings with the given hook to the running workers, so they don't need a restart
+	live map[string]func() error
 }
 
 func (c *Config) Init() *Config {
@@ -47,6 +59,7 @@ func (c *Config) Init() *Config {
 	}
 	c.clickables = map[string]*p9.Clickable{
 		// "quit": ng.th.Clickable(),
+		"restart": c.th.Clickable(),
 	}
 	c.checkables = map[string]*p9.Checkable{
 		// "runmodenode":   ng.th.Checkable(),
@@ -57,5 +70,85 @@ func (c *Config) Init() *Config {
 	c.inputs = make(map[string]*p9.Input)
 	c.multis = make(map[string]*p9.Multi)
 	c.passwords = make(map[string]*p9.Password)
+	c.errors = make(map[string]string)
+	c.pending = make(map[string]string)
+	c.live = make(map[string]func() error)
+	return c
+}
+
+// SetRestart sets the function the apply and restart button calls to restart the workers with the changed settings
+func (c *Config) SetRestart(fn func()) *Config {
+	c.restart = fn
+	return c
+}
+
+// SetLive sets the function that applies the settings with the hook to the running workers when they are changed, so
+// they are not marked as needing a restart. It must be called before the settings are built by Config
+func (c *Config) SetLive(hook string, fn func() error) *Config {
+	c.live[hook] = fn
 	return c
 }
+
+// setError records the validation error of a setting, or clears it when err is nil, and returns whether there was one
+func (c *Config) setError(slug string, err error) bool {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	if err != nil {
+		c.errors[slug] = err.Error()
+		return true
+	}
+	delete(c.errors, slug)
+	return false
+}
+
+// getError returns the validation error of a setting, empty when its value is valid
+func (c *Config) getError(slug string) string {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.errors[slug]
+}
+
+// changed saves the configuration after a setting was changed and applies it to the running workers, or notes it if
+// it only takes effect after a restart. A setting that fails to apply is also left for the restart
+func (c *Config) changed(item *Item) {
+	save.Pod(c.cx.Config)
+	if apply, ok := c.live[item.hook]; ok {
+		go func() {
+			if err := apply(); Check(err) {
+				c.setError(item.slug, fmt.Errorf("could not be applied now, so it takes effect on restart: %v", err))
+				c.addPending(item)
+			}
+		}()
+		return
+	}
+	if item.restart {
+		c.addPending(item)
+	}
+}
+
+func (c *Config) addPending(item *Item) {
+	c.mx.Lock()
+	c.pending[item.slug] = item.label
+	c.mx.Unlock()
+}
+
+// Pending returns the labels of the changed settings that take effect when the workers are restarted
+func (c *Config) Pending() (labels []string) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	for _, label := range c.pending {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return
+}
+
+// ApplyAndRestart restarts the workers so the pending changes take effect
+func (c *Config) ApplyAndRestart() {
+	c.mx.Lock()
+	c.pending = make(map[string]string)
+	c.mx.Unlock()
+	if c.restart != nil {
+		c.restart()
+	}
+}
@@ -0,0 +1,85 @@
+package cfg
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
+	"github.com/p9c/pod/pkg/util"
+)
+
+var errNegative = errors.New("must not be negative")
+
+// validateNumber checks the text of a numeric setting parsed, none of which may be negative.
+func validateNumber(negative bool, err error) error {
+	if err != nil {
+		return errors.New("not a valid number")
+	}
+	if negative {
+		return errNegative
+	}
+	return nil
+}
+
+// validateText checks the value of a text setting against the kind of value its type tag says it holds. Empty values
+// are always accepted as they leave the setting at its default.
+func (c *Config) validateText(typ, txt string) error {
+	if txt == "" {
+		return nil
+	}
+	switch typ {
+	case "address":
+		return validateAddress(txt)
+	case "url":
+		return validateURL(txt)
+	case "whitelist":
+		if _, err := connmgr.ParseWhitelist(txt); err != nil {
+			return err
+		}
+	case "base58":
+		if _, err := util.DecodeAddress(txt, c.cx.ActiveNet); err != nil {
+			return fmt.Errorf("%s is not a valid %s address", txt, c.cx.ActiveNet.Name)
+		}
+	}
+	return nil
+}
+
+// validateAddress accepts a host with an optional port, or a network in CIDR notation.
+func validateAddress(txt string) error {
+	if _, _, err := net.ParseCIDR(txt); err == nil {
+		return nil
+	}
+	host, port, err := net.SplitHostPort(txt)
+	if err != nil {
+		// there is no port, or this is a bare IPv6 address
+		host, port = txt, ""
+	}
+	if strings.ContainsAny(host, " \t/") {
+		return fmt.Errorf("%s is not a valid host", host)
+	}
+	if port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 0 || n > 65535 {
+			return fmt.Errorf("%s is not a valid port", port)
+		}
+	}
+	return nil
+}
+
+// validateURL accepts an http or https url, or an address for the settings that also take a plain host and port.
+func validateURL(txt string) error {
+	if !strings.Contains(txt, "://") {
+		return validateAddress(txt)
+	}
+	u, err := url.Parse(txt)
+	if err != nil {
+		return errors.New("not a valid url")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("must be an http or https url")
+	}
+	return nil
+}
@@ -106,3 +106,8 @@ func (d *Dialog) DrawDialog() func(gtx l.Context) {
 func (d *Dialog) Close() {
 	d.content = nil
 }
+
+// Showing returns whether a dialog is being shown
+func (d *Dialog) Showing() bool {
+	return d.content != nil
+}
@@ -130,7 +130,7 @@ func (b *Button) Fn(gtx l.Context) l.Dimensions {
 			paint.ColorOp{Color: b.color}.Add(gtx.Ops)
 			return b.th.Text().
 				Alignment(text.Middle).
-				Fn(gtx, b.shaper, b.font, b.textSize, b.text)
+				Fn(gtx, b.shaper, b.font, b.textSize, b.th.tr(b.text))
 		})
 	}
 	bl.Embed(fn)
@@ -151,5 +151,5 @@ func (th *Theme) Caption(txt string) (l *Label) {
 func (l *Label) Fn(gtx l.Context) l.Dimensions {
 	paint.ColorOp{Color: l.color}.Add(gtx.Ops)
 	tl := Text{alignment: l.alignment, maxLines: l.maxLines}
-	return tl.Fn(gtx, l.shaper, l.font, l.textSize, l.text)
+	return tl.Fn(gtx, l.shaper, l.font, l.textSize, l.th.tr(l.text))
 }
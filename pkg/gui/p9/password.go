@@ -163,3 +163,9 @@ func (p *Password) Fn(gtx l.Context) l.Dimensions {
 func (p *Password) GetPassword() string {
 	return p.passInput.editor.Text()
 }
+
+// Wipe clears the password so it does not stay in the editor once it has been used
+func (p *Password) Wipe() {
+	p.pass.SetText("")
+	*p.password = ""
+}
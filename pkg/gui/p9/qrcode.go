@@ -0,0 +1,91 @@
+package p9
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	l "gioui.org/layout"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+
+	"github.com/p9c/pod/pkg/coding/qrcode"
+)
+
+// quietZone is the width in modules of the light border a QR code needs around it to be read
+const quietZone = 4
+
+// QRCode is a widget showing the QR code of a text, such as an address or payment URI, as dark modules on a light
+// square so it can be scanned whichever theme is in use
+type QRCode struct {
+	th      *Theme
+	text    string
+	modules [][]bool
+	err     error
+	size    unit.Value
+}
+
+// QRCode creates a widget showing the QR code of the text
+func (th *Theme) QRCode(text string) *QRCode {
+	q := &QRCode{th: th, size: th.TextSize.Scale(16)}
+	return q.Text(text)
+}
+
+// Text sets the text shown in the QR code, encoding it only when it has changed
+func (q *QRCode) Text(text string) *QRCode {
+	if text == q.text && (q.modules != nil || q.err != nil) {
+		return q
+	}
+	q.text, q.modules, q.err = text, nil, nil
+	if text != "" {
+		q.modules, q.err = qrcode.Modules(text, 0, qrcode.ECLevelM)
+		Check(q.err)
+	}
+	return q
+}
+
+// Size sets the largest width and height of the QR code, which is shrunk to fit smaller constraints
+func (q *QRCode) Size(size unit.Value) *QRCode {
+	q.size = size
+	return q
+}
+
+// Err returns the error encoding the text, which is too long for a QR code
+func (q *QRCode) Err() error {
+	return q.err
+}
+
+// Fn draws the QR code, which is whole modules in size so the modules are all the same size and the code stays sharp
+func (q *QRCode) Fn(gtx l.Context) l.Dimensions {
+	if len(q.modules) == 0 {
+		return l.Dimensions{}
+	}
+	side := gtx.Px(q.size)
+	if side > gtx.Constraints.Max.X {
+		side = gtx.Constraints.Max.X
+	}
+	if side > gtx.Constraints.Max.Y {
+		side = gtx.Constraints.Max.Y
+	}
+	n := len(q.modules) + quietZone*2
+	m := side / n
+	if m < 1 {
+		m = 1
+	}
+	side = m * n
+	paint.ColorOp{Color: color.RGBA{R: 255, G: 255, B: 255, A: 255}}.Add(gtx.Ops)
+	paint.PaintOp{Rect: f32.Rectangle{Max: f32.Point{X: float32(side), Y: float32(side)}}}.Add(gtx.Ops)
+	paint.ColorOp{Color: color.RGBA{A: 255}}.Add(gtx.Ops)
+	for r, row := range q.modules {
+		for c, dark := range row {
+			if !dark {
+				continue
+			}
+			x, y := float32((c+quietZone)*m), float32((r+quietZone)*m)
+			paint.PaintOp{
+				Rect: f32.Rectangle{Min: f32.Point{X: x, Y: y}, Max: f32.Point{X: x + float32(m), Y: y + float32(m)}},
+			}.Add(gtx.Ops)
+		}
+	}
+	return l.Dimensions{Size: image.Point{X: side, Y: side}}
+}
@@ -16,6 +16,7 @@ type Theme struct {
 	Dark          *bool
 	iconCache     IconCache
 	WidgetPool    *Pool
+	translate     func(string) string
 }
 
 // NewTheme creates a new theme to use for rendering a user interface
@@ -32,3 +33,18 @@ func NewTheme(fontCollection []text.FontFace, quit chan struct{}) (th *Theme) {
 	th.WidgetPool = th.NewPool()
 	return
 }
+
+// SetTranslator sets the function the text of labels, buttons and input hints is translated with as it is rendered,
+// so changing the language takes effect on the next frame
+func (th *Theme) SetTranslator(translate func(string) string) *Theme {
+	th.translate = translate
+	return th
+}
+
+// tr translates the text of a widget with the translator of the theme, if it has one
+func (th *Theme) tr(txt string) string {
+	if th.translate == nil {
+		return txt
+	}
+	return th.translate(txt)
+}
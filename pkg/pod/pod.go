@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/p9c/pod/app/appdata"
+	"github.com/p9c/pod/pkg/comm/price"
+	"github.com/p9c/pod/pkg/util/lang"
 	log "github.com/p9c/pod/pkg/util/logi"
 
 	"github.com/urfave/cli"
@@ -61,11 +63,14 @@ type Field struct {
 
 func GetConfigSchema(cfg *Config, cfgMap map[string]interface{}) Schema {
 	t := reflect.TypeOf(*cfg)
-	var levelOptions, network []string
+	var levelOptions, network, amountUnits, languages, currencies []string
 	for _, i := range log.Levels {
 		levelOptions = append(levelOptions, i)
 	}
 	network = []string{"mainnet", "testnet", "regtestnet", "simnet"}
+	amountUnits = []string{"DUO", "mDUO", "μDUO", "Satoshi"}
+	languages = lang.Languages()
+	currencies = price.Currencies
 
 	//  groups = []string{"config", "node", "debug", "rpc", "wallet", "proxy", "policy", "mining", "tls"}
 	// var groups []string
@@ -78,6 +83,12 @@ func GetConfigSchema(cfg *Config, cfgMap map[string]interface{}) Schema {
 			options = levelOptions
 		case field.Name == "Network":
 			options = network
+		case field.Name == "AmountUnit":
+			options = amountUnits
+		case field.Name == "Language":
+			options = languages
+		case field.Name == "FiatCurrency":
+			options = currencies
 		}
 		f := Field{
 			Slug:        field.Name,
@@ -90,7 +101,7 @@ func GetConfigSchema(cfg *Config, cfgMap map[string]interface{}) Schema {
 			Options:     options,
 			Datatype:    field.Type.String(),
 			Model:       field.Tag.Get("json"),
-			Hooks:       field.Tag.Get("hooks"),
+			Hooks:       field.Tag.Get("hook"),
 			// value:       cfgMap[field.Tag.Get("model")],
 		}
 		if f.Group != "" {
@@ -124,65 +135,100 @@ func GetConfigSchema(cfg *Config, cfgMap map[string]interface{}) Schema {
 // Config is
 type Config struct {
 	sync.Mutex
-	AddCheckpoints     *cli.StringSlice `group:"debug" label:"AddCheckpoints" description:"add custom checkpoints" type:"" widget:"multi" json:"AddCheckpoints" hook:"restart"`
-	AddPeers           *cli.StringSlice `group:"node" label:"Add Peers" description:"manually adds addresses to try to connect to" type:"address" widget:"multi" json:"AddPeers" hook:"addpeer"`
-	AddrIndex          *bool            `group:"node" label:"Addr Index" description:"maintain a full address-based transaction index which makes the searchrawtransactions RPC available" type:"" widget:"toggle"  json:"AddrIndex" hook:"dropaddrindex"`
-	AutoPorts          *bool            `group:"node" label:"AutomaticPorts" description:"RPC and controller ports are randomized, use with controller for automatic peer discovery" type:"" widget:"toggle" json:"AutoPorts" hook:"restart"`
-	BanDuration        *time.Duration   `group:"debug" label:"Ban Duration" description:"how long a ban of a misbehaving peer lasts" type:"" widget:"time" json:"BanDuration" hook:"restart"`
-	BanThreshold       *int             `group:"debug" label:"Ban Threshold" description:"ban score that triggers a ban (default 100)" type:"" widget:"integer" json:"BanThreshold" hook:"restart"`
-	BlockMaxSize       *int             `group:"mining" label:"Block Max Size" description:"maximum block size in bytes to be used when creating a block" type:"" widget:"integer" json:"BlockMaxSize" hook:"restart"`
-	BlockMaxWeight     *int             `group:"mining" label:"Block Max Weight" description:"maximum block weight to be used when creating a block" type:"" widget:"integer" json:"BlockMaxWeight" hook:"restart"`
-	BlockMinSize       *int             `group:"mining" label:"Block Min Size" description:"minimum block size in bytes to be used when creating a block" type:"" widget:"integer" json:"BlockMinSize" hook:"restart"`
-	BlockMinWeight     *int             `group:"mining" label:"Block Min Weight" description:"minimum block weight to be used when creating a block" type:"" widget:"integer" json:"BlockMinWeight" hook:"restart"`
-	BlockPrioritySize  *int             `group:"mining" label:"Block Priority Size" description:"size in bytes for high-priority/low-fee transactions when creating a block" type:"" widget:"integer" json:"BlockPrioritySize" hook:"restart"`
-	BlocksOnly         *bool            `group:"node" label:"Blocks Only" description:"do not accept transactions from remote peers" type:"" widget:"toggle" json:"BlocksOnly" hook:"restart"`
-	CAFile             *string          `group:"tls" label:"Certificate Authority File" description:"certificate authority file for TLS certificate validation" type:"path" widget:"string" json:"CAFile" hook:"restart"`
-	ConfigFile         *string          `group:"config" label:"Configuration File" description:"location of configuration file, cannot actually be changed" type:"path" widget:"string" json:"ConfigFile" hook:"restart"`
-	ConnectPeers       *cli.StringSlice `group:"node" label:"Connect Peers" description:"connect ONLY to these addresses (disables inbound connections)" type:"address" widget:"multi" json:"ConnectPeers" hook:"restart"`
-	Controller         *string          `group:"mining" label:"Controller Listener" description:"address to bind miner controller to" type:"address" widget:"string" json:"Controller" hook:"controller"`
-	CPUProfile         *string          `group:"debug" label:"CPU Profile" description:"write cpu profile to this file" type:"path" widget:"string" json:"CPUProfile" hook:"restart"`
-	DataDir            *string          `group:"config" label:"Data Directory" description:"root folder where application data is stored" type:"path" widget:"string" json:"DataDir" hook:"restart"`
-	DbType             *string          `group:"debug" label:"Database Type" description:"type of database storage engine to use (only one right now)" type:"" widget:"string" json:"DbType" hook:"restart"`
-	DisableBanning     *bool            `group:"debug" label:"Disable Banning" description:"disables banning of misbehaving peers" type:"" widget:"toggle" json:"DisableBanning" hook:"restart"`
-	DisableCheckpoints *bool            `group:"debug" label:"Disable Checkpoints" description:"disables all checkpoints" type:"" widget:"toggle" json:"DisableCheckpoints" hook:"restart"`
-	DisableDNSSeed     *bool            `group:"node" label:"Disable DNS Seed" description:"disable seeding of addresses to peers" type:"" widget:"toggle" json:"DisableDNSSeed" hook:"restart"`
-	DisableListen      *bool            `group:"node" label:"Disable Listen" description:"disables inbound connections for the peer to peer network" type:"" widget:"toggle" json:"DisableListen" hook:"restart"`
-	DisableRPC         *bool            `group:"rpc" label:"Disable RPC" description:"disable rpc servers" type:"" widget:"toggle" json:"DisableRPC" hook:"restart"`
-	ExternalIPs        *cli.StringSlice `group:"node" label:"External IP Addresses" description:"extra addresses to tell peers they can connect to" type:"address" widget:"multi" json:"ExternalIPs" hook:"restart"`
-	FreeTxRelayLimit   *float64         `group:"policy" label:"Free Tx Relay Limit" description:"limit relay of transactions with no transaction fee to the given amount in thousands of bytes per minute" type:"" widget:"float" json:"FreeTxRelayLimit" hook:"restart"`
-	Generate           *bool            `group:"mining" label:"Generate Blocks" description:"turn on Kopach CPU miner" type:"" widget:"toggle" json:"Generate" hook:"generate"`
-	GenThreads         *int             `group:"mining" label:"Gen Threads" description:"number of threads to mine with" type:"" widget:"integer" json:"GenThreads" hook:"genthreads"`
-	Language           *string          `group:"config" label:"Language" description:"user interface language i18 localization" type:"" widget:"string" json:"Language" hook:"language"`
-	LimitPass          *string          `group:"rpc" label:"Limit Pass" description:"limited user password" type:"" widget:"password" json:"LimitPass" hook:"restart"`
-	LimitUser          *string          `group:"rpc" label:"Limit User" description:"limited user name" type:"" widget:"string" json:"LimitUser" hook:"restart"`
-	Listeners          *cli.StringSlice `group:"node" label:"Listeners" description:"list of addresses to bind the node listener to" type:"address" widget:"multi" json:"Listeners" hook:"restart"`
-	LogDir             *string          `group:"config" label:"Log Dir" description:"folder where log files are written" type:"path" widget:"string" json:"LogDir" hook:"restart"`
-	LogLevel           *string          `group:"config" label:"Log Level" description:"maximum log level to output\n(fatal error check warning info debug trace - what is selected includes all items to the left of the one in that list)" type:"" widget:"radio" json:"LogLevel" hook:"loglevel"`
-	MaxOrphanTxs       *int             `group:"policy" label:"Max Orphan Txs" description:"max number of orphan transactions to keep in memory" type:"" widget:"integer" json:"MaxOrphanTxs" hook:"restart"`
-	MaxPeers           *int             `group:"node" label:"Max Peers" description:"maximum number of peers to hold connections with" type:"" widget:"integer" json:"MaxPeers" hook:"restart"`
-	MinerPass          *string          `group:"mining" label:"Miner Pass" description:"password that encrypts the connection to the mining controller" type:"" widget:"password" json:"MinerPass" hook:"restart"`
-	MiningAddrs        *cli.StringSlice
-	// `group:"mining" label:"Mining Addrs" description:"addresses to pay block rewards to (TODO, make this auto)" type:"base58" widget:"multi" json:"MiningAddrs" hook:"miningaddr"`
+	AddCheckpoints         *cli.StringSlice `group:"debug" label:"AddCheckpoints" description:"add custom checkpoints" type:"" widget:"multi" json:"AddCheckpoints" hook:"restart"`
+	AddPeers               *cli.StringSlice `group:"node" label:"Add Peers" description:"manually adds addresses to try to connect to" type:"address" widget:"multi" json:"AddPeers" hook:"addpeer"`
+	AddrIndex              *bool            `group:"node" label:"Addr Index" description:"maintain a full address-based transaction index which makes the searchrawtransactions RPC available" type:"" widget:"toggle"  json:"AddrIndex" hook:"dropaddrindex"`
+	AmountUnit             *string          `group:"config" label:"Amount Unit" description:"unit amounts are shown in by the wallet interfaces" type:"" widget:"radio" json:"AmountUnit" hook:""`
+	AutoPorts              *bool            `group:"node" label:"AutomaticPorts" description:"RPC and controller ports are randomized, use with controller for automatic peer discovery" type:"" widget:"toggle" json:"AutoPorts" hook:"restart"`
+	BanDuration            *time.Duration   `group:"debug" label:"Ban Duration" description:"how long a ban of a misbehaving peer lasts" type:"" widget:"time" json:"BanDuration" hook:"restart"`
+	BanScores              *cli.StringSlice `group:"debug" label:"Ban Scores" description:"ban score and ban duration of a kind of peer misbehavior, as offense=score[:duration]" type:"" widget:"multi" json:"BanScores" hook:"restart"`
+	BanThreshold           *int             `group:"debug" label:"Ban Threshold" description:"ban score that triggers a ban (default 100)" type:"" widget:"integer" json:"BanThreshold" hook:"restart"`
+	BlockMaxSize           *int             `group:"mining" label:"Block Max Size" description:"maximum block size in bytes to be used when creating a block" type:"" widget:"integer" json:"BlockMaxSize" hook:"restart"`
+	BlockMaxWeight         *int             `group:"mining" label:"Block Max Weight" description:"maximum block weight to be used when creating a block" type:"" widget:"integer" json:"BlockMaxWeight" hook:"restart"`
+	BlockMinSize           *int             `group:"mining" label:"Block Min Size" description:"minimum block size in bytes to be used when creating a block" type:"" widget:"integer" json:"BlockMinSize" hook:"restart"`
+	BlockMinWeight         *int             `group:"mining" label:"Block Min Weight" description:"minimum block weight to be used when creating a block" type:"" widget:"integer" json:"BlockMinWeight" hook:"restart"`
+	BlockPrioritySize      *int             `group:"mining" label:"Block Priority Size" description:"size in bytes for high-priority/low-fee transactions when creating a block" type:"" widget:"integer" json:"BlockPrioritySize" hook:"restart"`
+	BlocksOnly             *bool            `group:"node" label:"Blocks Only" description:"do not accept transactions from remote peers" type:"" widget:"toggle" json:"BlocksOnly" hook:"restart"`
+	CAFile                 *string          `group:"tls" label:"Certificate Authority File" description:"certificate authority file for TLS certificate validation" type:"path" widget:"string" json:"CAFile" hook:"restart"`
+	CoinbaseData           *string          `group:"mining" label:"Coinbase Data" description:"text added to the coinbase script of mined blocks, such as a pool tag, as long as the coinbase script stays within the consensus limit" type:"" widget:"string" json:"CoinbaseData" hook:"restart"`
+	ConfigFile             *string          `group:"config" label:"Configuration File" description:"location of configuration file, cannot actually be changed" type:"path" widget:"string" json:"ConfigFile" hook:"restart"`
+	ConnectPeers           *cli.StringSlice `group:"node" label:"Connect Peers" description:"connect ONLY to these addresses (disables inbound connections)" type:"address" widget:"multi" json:"ConnectPeers" hook:"restart"`
+	Controller             *string          `group:"mining" label:"Controller Listener" description:"address to bind miner controller to" type:"address" widget:"string" json:"Controller" hook:"controller"`
+	ControllerAllow        *cli.StringSlice `group:"mining" label:"Controller Allow" description:"identity keys of the mining controllers that kopach accepts work and pause messages from, from any controller with the miner pass if empty" type:"" widget:"multi" json:"ControllerAllow" hook:"restart"`
+	CPFP                   *bool            `group:"wallet" label:"CPFP" description:"when spending unconfirmed change raise the fee so the unconfirmed parent transactions confirm along with it" type:"" widget:"toggle" json:"CPFP" hook:"restart"`
+	CPUProfile             *string          `group:"debug" label:"CPU Profile" description:"write cpu profile to this file" type:"path" widget:"string" json:"CPUProfile" hook:"restart"`
+	DataDir                *string          `group:"config" label:"Data Directory" description:"root folder where application data is stored" type:"path" widget:"string" json:"DataDir" hook:"restart"`
+	DbType                 *string          `group:"debug" label:"Database Type" description:"type of database storage engine to use (only one right now)" type:"" widget:"string" json:"DbType" hook:"restart"`
+	DisableBanning         *bool            `group:"debug" label:"Disable Banning" description:"disables banning of misbehaving peers" type:"" widget:"toggle" json:"DisableBanning" hook:"restart"`
+	DisableCheckpoints     *bool            `group:"debug" label:"Disable Checkpoints" description:"disables all checkpoints" type:"" widget:"toggle" json:"DisableCheckpoints" hook:"restart"`
+	DisableDNSSeed         *bool            `group:"node" label:"Disable DNS Seed" description:"disable seeding of addresses to peers" type:"" widget:"toggle" json:"DisableDNSSeed" hook:"restart"`
+	DisableListen          *bool            `group:"node" label:"Disable Listen" description:"disables inbound connections for the peer to peer network" type:"" widget:"toggle" json:"DisableListen" hook:"restart"`
+	DisableRPC             *bool            `group:"rpc" label:"Disable RPC" description:"disable rpc servers" type:"" widget:"toggle" json:"DisableRPC" hook:"restart"`
+	EncryptWalletDB        *bool            `group:"wallet" label:"Encrypt Wallet Database" description:"encrypt the whole wallet database with a key derived from the public wallet password so the transaction history can not be read from disk" type:"" widget:"toggle" json:"EncryptWalletDB" hook:"restart"`
+	ExternalIPs            *cli.StringSlice `group:"node" label:"External IP Addresses" description:"extra addresses to tell peers they can connect to" type:"address" widget:"multi" json:"ExternalIPs" hook:"restart"`
+	FeeEstimateMode        *string          `group:"policy" label:"Fee Estimate Mode" description:"fee estimation mode of estimatesmartfee calls that don't set one, conservative or economical" type:"" widget:"string" json:"FeeEstimateMode" hook:""`
+	FiatCurrency           *string          `group:"config" label:"Fiat Currency" description:"currency the value of amounts is shown in next to them in the GUI, none to not fetch exchange rates" type:"" widget:"radio" json:"FiatCurrency" hook:""`
+	FreeTxRelayLimit       *float64         `group:"policy" label:"Free Tx Relay Limit" description:"limit relay of transactions with no transaction fee to the given amount in thousands of bytes per minute" type:"" widget:"float" json:"FreeTxRelayLimit" hook:"restart"`
+	Generate               *bool            `group:"mining" label:"Generate Blocks" description:"turn on Kopach CPU miner" type:"" widget:"toggle" json:"Generate" hook:"generate"`
+	GenThreads             *int             `group:"mining" label:"Gen Threads" description:"number of threads to mine with" type:"" widget:"integer" json:"GenThreads" hook:"genthreads"`
+	GPUDevices             *cli.StringSlice `group:"mining" label:"GPU Devices" description:"OpenCL devices to mine with by number, or all, in miners built with the opencl tag" type:"" widget:"multi" json:"GPUDevices" hook:"restart"`
+	GPUIntensity           *int             `group:"mining" label:"GPU Intensity" description:"base 2 logarithm of the number of nonces each GPU searches at a time, from 16 to 30" type:"" widget:"integer" json:"GPUIntensity" hook:"restart"`
+	InProcessMiner         *bool            `group:"mining" label:"In Process Miner" description:"mine with threads inside the node on its own block templates instead of running kopach" type:"" widget:"toggle" json:"InProcessMiner" hook:"restart"`
+	LANBlockPush           *bool            `group:"mining" label:"LAN Block Push" description:"push newly connected blocks to the other nodes on the LAN over the miner multicast channel" type:"" widget:"toggle" json:"LANBlockPush" hook:"restart"`
+	Language               *string          `group:"config" label:"Language" description:"language of the user interface, more can be added as catalogs in the lang folder of the data directory" type:"" widget:"radio" json:"Language" hook:"language"`
+	LimitPass              *string          `group:"rpc" label:"Limit Pass" description:"limited user password" type:"" widget:"password" json:"LimitPass" hook:"restart"`
+	LimitUser              *string          `group:"rpc" label:"Limit User" description:"limited user name" type:"" widget:"string" json:"LimitUser" hook:"restart"`
+	Listeners              *cli.StringSlice `group:"node" label:"Listeners" description:"list of addresses to bind the node listener to" type:"address" widget:"multi" json:"Listeners" hook:"restart"`
+	LogDir                 *string          `group:"config" label:"Log Dir" description:"folder where log files are written" type:"path" widget:"string" json:"LogDir" hook:"restart"`
+	LogLevel               *string          `group:"config" label:"Log Level" description:"maximum log level to output\n(fatal error check warning info debug trace - what is selected includes all items to the left of the one in that list)" type:"" widget:"radio" json:"LogLevel" hook:"loglevel"`
+	MaxOrphanTxs           *int             `group:"policy" label:"Max Orphan Txs" description:"max number of orphan transactions to keep in memory" type:"" widget:"integer" json:"MaxOrphanTxs" hook:"restart"`
+	MaxPeers               *int             `group:"node" label:"Max Peers" description:"maximum number of peers to hold connections with" type:"" widget:"integer" json:"MaxPeers" hook:"restart"`
+	Metrics                *bool            `group:"node" label:"Metrics" description:"serve Prometheus metrics of the node, miner and RPC server over HTTP" type:"" widget:"toggle" json:"Metrics" hook:"restart"`
+	MetricsListener        *string          `group:"node" label:"Metrics Listener" description:"address to serve Prometheus metrics on at /metrics" type:"address" widget:"string" json:"MetricsListener" hook:"restart"`
+	MinerAlgos             *cli.StringSlice `group:"mining" label:"Miner Algorithms" description:"algorithms the workers mine, by name, or all of those in the work if empty or none of them are in it" type:"" widget:"multi" json:"MinerAlgos" hook:"restart"`
+	MinerControllers       *cli.StringSlice `group:"mining" label:"Miner Controllers" description:"addresses of the mining controllers kopach registers with over unicast to receive work from, for controllers multicast doesn't reach" type:"address" widget:"multi" json:"MinerControllers" hook:"restart"`
+	MinerListen            *string          `group:"mining" label:"Miner Listen" description:"address the mining controller listens on for kopach miners on other networks to register with over unicast, disabled if empty" type:"address" widget:"string" json:"MinerListen" hook:"restart"`
+	MinerPass              *string          `group:"mining" label:"Miner Pass" description:"password that encrypts the connection to the mining controller" type:"" widget:"password" json:"MinerPass" hook:"restart"`
+	MiningAddrs            *cli.StringSlice `group:"mining" label:"Mining Addrs" description:"addresses to pay block rewards to" type:"base58" widget:"multi" json:"MiningAddrs" hook:"miningaddr"`
 	MinRelayTxFee          *float64         `group:"policy" label:"Min Relay Tx Fee" description:"the minimum transaction fee in DUO/kB to be considered a non-zero fee" type:"" widget:"float" json:"MinRelayTxFee" hook:"restart"`
 	Network                *string          `group:"node" label:"Network" description:"connect to this network: mainnet, testnet)" type:"" widget:"radio" json:"Network" hook:"restart"`
 	NoCFilters             *bool            `group:"node" label:"No CFilters" description:"disable committed filtering (CF) support" type:"" widget:"toggle" json:"NoCFilters" hook:"restart"`
+	NoIndexers             *bool            `group:"node" label:"No Indexers" description:"disable every index, the transaction, address, witness transaction, spent output and committed filter indexes" type:"" widget:"toggle" json:"NoIndexers" hook:"restart"`
+	NoMining               *bool            `group:"mining" label:"No Mining" description:"disable mining, the miner controller and the mining RPC commands" type:"" widget:"toggle" json:"NoMining" hook:"restart"`
 	NodeOff                *bool            `group:"debug" label:"Node Off" description:"turn off the node backend" type:"" widget:"toggle" json:"NodeOff" hook:"node"`
 	NoInitialLoad          *bool            `group:"debug" label:"No initial load" description:"do not load a wallet at startup" type:"" widget:"toggle" json:"NoInitialLoad" hook:"restart"`
-	NoPeerBloomFilters     *bool            `group:"node" label:"No Peer Bloom Filters" description:"disable bloom filtering support" type:"" widget:"toggle" json:"NoPeerBloomFilters" hook:"restart"`
+	NoPeerBloomFilters     *bool            `group:"node" label:"No Peer Bloom Filters" description:"disable serving BIP37 bloom filters to peers, which are disconnected if they send bloom filter requests" type:"" widget:"toggle" json:"NoPeerBloomFilters" hook:"restart"`
+	NoRelay                *bool            `group:"node" label:"No Relay" description:"disable transaction relay, transactions are neither accepted from nor announced to peers" type:"" widget:"toggle" json:"NoRelay" hook:"restart"`
 	NoRelayPriority        *bool            `group:"policy" label:"No Relay Priority" description:"do not require free or low-fee transactions to have high priority for relaying" type:"" widget:"toggle" json:"NoRelayPriority" hook:"restart"`
+	NotifyMined            *bool            `group:"config" label:"Notify Mined Blocks" description:"show a desktop notification in the GUI when the local miner finds a block paying to the wallet" type:"" widget:"toggle" json:"NotifyMined" hook:""`
+	NotifyReceived         *bool            `group:"config" label:"Notify Received Payments" description:"show a desktop notification in the GUI when the wallet receives a payment" type:"" widget:"toggle" json:"NotifyReceived" hook:""`
+	NotifySent             *bool            `group:"config" label:"Notify Confirmed Sends" description:"show a desktop notification in the GUI when a payment sent from the wallet is mined" type:"" widget:"toggle" json:"NotifySent" hook:""`
 	OneTimeTLSKey          *bool            `group:"wallet" label:"One Time TLS Key" description:"generate a new TLS certificate pair at startup, but only write the certificate to disk" type:"" widget:"toggle" json:"OneTimeTLSKey" hook:"restart"`
 	Onion                  *bool            `group:"proxy" label:"Onion" description:"enable tor proxy" type:"" widget:"toggle" json:"Onion" hook:"restart"`
 	OnionProxy             *string          `group:"proxy" label:"Onion Proxy" description:"address of tor proxy you want to connect to" type:"address" widget:"string" json:"OnionProxy" hook:"restart"`
 	OnionProxyPass         *string          `group:"proxy" label:"Onion Proxy Pass" description:"password for tor proxy" type:"" widget:"password" json:"OnionProxyPass" hook:"restart"`
 	OnionProxyUser         *string          `group:"proxy" label:"Onion Proxy User" description:"tor proxy username" type:"" widget:"string" json:"OnionProxyUser" hook:"restart"`
+	OnlyNet                *string          `group:"proxy" label:"Only Net" description:"only connect to peers on this network, onion to only connect to tor hidden services" type:"" widget:"string" json:"OnlyNet" hook:"restart"`
 	Password               *string          `group:"rpc" label:"Password" description:"password for client RPC connections" type:"" widget:"password" json:"Password" hook:"restart"`
+	PaymentWebhook         *string          `group:"wallet" label:"Payment Webhook" description:"url that fulfilled payment requests are posted to as JSON" type:"url" widget:"string" json:"PaymentWebhook" hook:"restart"`
+	PayoutAlgoAddrs        *cli.StringSlice `group:"mining" label:"Payout Algo Addrs" description:"algo=address pairs of the addresses the blocks of each algorithm are paid to with the peralgo payout policy" type:"" widget:"multi" json:"PayoutAlgoAddrs" hook:"restart"`
+	PayoutPolicy           *string          `group:"mining" label:"Payout Policy" description:"how the address block rewards are paid to is picked: random, roundrobin, peralgo or fresh from the wallet" type:"" widget:"string" json:"PayoutPolicy" hook:"restart"`
+	PeerAllow              *cli.StringSlice `group:"node" label:"Peer Allow" description:"public keys of the nodes allowed to connect when peer authentication is enabled" type:"" widget:"multi" json:"PeerAllow" hook:"restart"`
+	PeerAuth               *bool            `group:"node" label:"Peer Auth" description:"only connect with peers authenticated by a node key in peerallow, for private networks" type:"" widget:"toggle" json:"PeerAuth" hook:"restart"`
 	PipeLog                *bool            `group:"config" label:"Pipe Logger" description:"enable pipe based loggerIPC" type:"" widget:"toggle" json:"PipeLog" hook:""`
+	PriceFeed              *string          `group:"config" label:"Price Feed" description:"where the exchange rates of the fiat currency are fetched from, coingecko or the https url of a JSON feed of the price of DUO by currency" type:"" widget:"string" json:"PriceFeed" hook:""`
 	Profile                *string          `group:"debug" label:"Profile" description:"http profiling on given port (1024-40000)" type:"url" widget:"string" json:"Profile" hook:"restart"`
 	Proxy                  *string          `group:"proxy" label:"Proxy" description:"address of proxy to connect to for outbound connections" type:"url" widget:"string" json:"Proxy" hook:"restart"`
 	ProxyPass              *string          `group:"proxy" label:"Proxy Pass" description:"proxy password, if required" type:"" widget:"password" json:"ProxyPass" hook:"restart"`
 	ProxyUser              *string          `group:"proxy" label:"ProxyUser" description:"proxy username, if required" type:"" widget:"string" json:"ProxyUser" hook:"restart"`
+	PruneDepth             *int             `group:"node" label:"Prune Depth" description:"number of blocks below the tip that are kept when pruning, which is the deepest reorganization that can be handled" type:"" widget:"integer" json:"PruneDepth" hook:"restart"`
+	PruneTarget            *int             `group:"node" label:"Prune Target" description:"delete the oldest block files to keep the block database below this size in MB (0 keeps all blocks)" type:"" widget:"integer" json:"PruneTarget" hook:"restart"`
+	ReauthThreshold        *float64         `group:"wallet" label:"Re-authentication Threshold" description:"sends of more than this amount in DUO ask for the wallet passphrase even while the wallet is unlocked" type:"" widget:"float" json:"ReauthThreshold" hook:""`
 	RejectNonStd           *bool            `group:"node" label:"Reject Non Std" description:"reject non-standard transactions regardless of the default settings for the active network" type:"" widget:"toggle" json:"RejectNonStd" hook:"restart"`
+	RejectReplacement      *bool            `group:"policy" label:"Reject Replacement" description:"reject transactions replacing mempool transactions that signal replace-by-fee (BIP125)" type:"" widget:"toggle" json:"RejectReplacement" hook:"restart"`
 	RelayNonStd            *bool            `group:"node" label:"Relay Non Std" description:"relay non-standard transactions regardless of the default settings for the active network" type:"" widget:"toggle" json:"RelayNonStd" hook:"restart"`
+	RPCAllowIPs            *cli.StringSlice `group:"rpc" label:"RPC Allow IPs" description:"networks in CIDR notation or single IPs that may connect to the RPC server, any if empty" type:"address" widget:"multi" json:"RPCAllowIPs" hook:"restart"`
+	RPCCanonicalJSON       *bool            `group:"rpc" label:"RPC Canonical JSON" description:"encode RPC results with the keys of objects sorted and floating point numbers such as amounts as fixed point strings, so the same result is always encoded the same" type:"" widget:"toggle" json:"RPCCanonicalJSON" hook:"restart"`
 	RPCCert                *string          `group:"rpc" label:"RPC Cert" description:"location of RPC TLS certificate" type:"path" widget:"string" json:"RPCCert" hook:"restart"`
 	RPCConnect             *string          `group:"wallet" label:"RPC Connect" description:"full node RPC for wallet" type:"address" widget:"string" json:"RPCConnect" hook:"restart"`
 	RPCKey                 *string          `group:"rpc" label:"RPC Key" description:"location of rpc TLS key" type:"path" widget:"string" json:"RPCKey" hook:"restart"`
@@ -191,20 +237,30 @@ type Config struct {
 	RPCMaxConcurrentReqs   *int             `group:"rpc" label:"Maximum RPC Concurrent Reqs" description:"maximum number of requests to process concurrently" type:"" widget:"integer" json:"RPCMaxConcurrentReqs" hook:"restart"`
 	RPCMaxWebsockets       *int             `group:"rpc" label:"Maximum RPC Websockets" description:"maximum number of websocket clients to allow" type:"" widget:"integer" json:"RPCMaxWebsockets" hook:"restart"`
 	RPCQuirks              *bool            `group:"rpc" label:"RPC Quirks" description:"enable bugs that replicate bitcoin core RPC's JSON" type:"" widget:"toggle" json:"RPCQuirks" hook:"restart"`
+	RPCWalletProxy         *string          `group:"rpc" label:"RPC Wallet Proxy" description:"address of the wallet RPC server that wallet commands sent to the node RPC are forwarded to, with the RPC username and password" type:"address" widget:"string" json:"RPCWalletProxy" hook:"restart"`
+	ScriptWorkers          *int             `group:"node" label:"Script Workers" description:"number of goroutines verifying transaction scripts when connecting blocks (0 uses one per CPU core)" type:"" widget:"integer" json:"ScriptWorkers" hook:"restart"`
 	ServerPass             *string          `group:"rpc" label:"Server Pass" description:"password for server connections" type:"" widget:"password" json:"ServerPass" hook:"restart"`
 	ServerTLS              *bool            `group:"wallet" label:"Server TLS" description:"enable TLS for the wallet connection to node RPC server" type:"" widget:"toggle" json:"ServerTLS" hook:"restart"`
 	ServerUser             *string          `group:"rpc" label:"Server User" description:"username for chain server connections" type:"" widget:"string" json:"ServerUser" hook:"restart"`
 	SigCacheMaxSize        *int             `group:"node" label:"Sig Cache Max Size" description:"the maximum number of entries in the signature verification cache" type:"" widget:"integer" json:"SigCacheMaxSize" hook:"restart"`
 	Solo                   *bool            `group:"mining" label:"Solo Generate" description:"mine even if not connected to a network" type:"" widget:"toggle" json:"Solo" hook:"restart"`
+	SpentIndex             *bool            `group:"node" label:"Spent Index" description:"maintain an index of the transaction inputs spending each output which makes the getspentinfo RPC available" type:"" widget:"toggle" json:"SpentIndex" hook:"restart"`
+	StratumDifficulty      *float64         `group:"mining" label:"Stratum Difficulty" description:"share difficulty stratum miners start with and the lowest it is adjusted to" type:"" widget:"float" json:"StratumDifficulty" hook:"restart"`
+	StratumListeners       *cli.StringSlice `group:"mining" label:"Stratum Listeners" description:"algo=address pairs to serve stratum mining work for each algorithm on, such as sha256d=0.0.0.0:11048" type:"address" widget:"multi" json:"StratumListeners" hook:"restart"`
+	Telemetry              *bool            `group:"node" label:"Telemetry" description:"opt in to sending anonymous version, platform, network and sync statistics to the community telemetry endpoint" type:"" widget:"toggle" json:"Telemetry" hook:"restart"`
+	TelemetryURL           *string          `group:"node" label:"Telemetry URL" description:"https endpoint telemetry reports are sent to" type:"url" widget:"string" json:"TelemetryURL" hook:"restart"`
 	TLS                    *bool            `group:"tls" label:"TLS" description:"enable TLS for RPC connections" type:"" widget:"toggle" json:"TLS" hook:"restart"`
 	TLSSkipVerify          *bool            `group:"tls" label:"TLS Skip Verify" description:"skip TLS certificate verification (ignore CA errors)" type:"" widget:"toggle" json:"TLSSkipVerify" hook:"restart"`
+	TorControl             *string          `group:"proxy" label:"Tor Control" description:"address of the tor control port, used to create a hidden service for the peer listener" type:"address" widget:"string" json:"TorControl" hook:"restart"`
 	TorIsolation           *bool            `group:"proxy" label:"Tor Isolation" description:"makes a separate proxy connection for each connection" type:"" widget:"toggle" json:"TorIsolation" hook:"restart"`
-	TrickleInterval        *time.Duration   `group:"policy" label:"Trickle Interval" description:"minimum time between attempts to send new inventory to a connected peer" type:"" widget:"time" json:"TrickleInterval" hook:"restart"`
+	TorPassword            *string          `group:"proxy" label:"Tor Password" description:"password for the tor control port, if it is not authenticated with a cookie" type:"" widget:"password" json:"TorPassword" hook:"restart"`
+	TrickleInterval        *time.Duration   `group:"policy" label:"Trickle Interval" description:"average time between attempts to send new inventory to a connected peer" type:"" widget:"time" json:"TrickleInterval" hook:"restart"`
 	TxIndex                *bool            `group:"node" label:"Tx Index" description:"maintain a full hash-based transaction index which makes all transactions available via the getrawtransaction RPC" type:"" widget:"toggle" json:"TxIndex" hook:"droptxindex"`
+	UnlockTimeout          *time.Duration   `group:"wallet" label:"Unlock Timeout" description:"how long the wallet stays unlocked after the passphrase is entered in the GUI" type:"" widget:"time" json:"UnlockTimeout" hook:""`
 	UPNP                   *bool            `group:"node" label:"UPNP" description:"enable UPNP for NAT traversal" type:"" widget:"toggle" json:"UPNP" hook:"restart"`
 	UserAgentComments      *cli.StringSlice `group:"node" label:"User Agent Comments" description:"comment to add to the user agent -- See BIP 14 for more information" type:"" widget:"multi" json:"UserAgentComments" hook:"restart"`
 	Username               *string          `group:"rpc" label:"Username" description:"password for client RPC connections" type:"" widget:"string" json:"Username" hook:"restart"`
-	Wallet                 *bool            `group:"debug" label:"Connect to Wallet" description:"set ctl to connect to wallet instead of chain server" type:"" widget:"toggle" json:"Wallet"`
+	Wallet                 *bool            `group:"debug" label:"Connect to Wallet" description:"set ctl to connect to wallet instead of chain server" type:"" widget:"toggle" json:"Wallet" hook:""`
 	WalletFile             *string          `group:"config" label:"Wallet File" description:"wallet database file" type:"path" widget:"string" featured:"true" json:"WalletFile" hook:"restart"`
 	WalletOff              *bool            `group:"debug" label:"Wallet Off" description:"turn off the wallet backend" type:"" widget:"toggle" json:"WalletOff" hook:"wallet"`
 	WalletPass             *string          `group:"wallet" label:"Wallet Pass" description:"password encrypting public data in wallet" type:"" widget:"password" json:"WalletPass" hook:"restart"`
@@ -212,11 +268,12 @@ type Config struct {
 	WalletRPCMaxClients    *int             `group:"wallet" label:"Legacy RPC Max Clients" description:"maximum number of RPC clients allowed for wallet RPC" type:"" widget:"integer" json:"WalletRPCMaxClients" hook:"restart"`
 	WalletRPCMaxWebsockets *int             `group:"wallet" label:"Legacy RPC Max Websockets" description:"maximum number of websocket clients allowed for wallet RPC" type:"" widget:"integer" json:"WalletRPCMaxWebsockets" hook:"restart"`
 	WalletServer           *string          `group:"wallet" label:"Wallet Server" description:"node address to connect wallet server to" type:"address" widget:"string" json:"WalletServer" hook:"restart"`
-	Whitelists             *cli.StringSlice `group:"debug" label:"Whitelists" description:"peers that you don't want to ever ban" type:"address" widget:"multi" json:"Whitelists" hook:"restart"`
+	Whitelists             *cli.StringSlice `group:"debug" label:"Whitelists" description:"networks of peers granted permissions as [noban,forcerelay,relay,mempool,bloomfilter@]network" type:"whitelist" widget:"multi" json:"Whitelists" hook:"restart"`
+	WTxIndex               *bool            `group:"node" label:"Witness Tx Index" description:"maintain an index of the witness hashes of transactions which makes getrawtransaction also find them by wtxid (requires the transaction index)" type:"" widget:"toggle" json:"WTxIndex" hook:"restart"`
 	LAN                    *bool            `group:"debug" label:"LAN" description:"run without any connection to nodes on the internet (does not apply on mainnet)" type:"" widget:"toggle" json:"LAN" hook:"restart"`
 	KopachGUI              *bool            `group:"mining" label:"Kopach GUI" description:"enables GUI for miner" type:"" widget:"toggle" json:"KopachGUI" hook:"restart"`
 	GUI                    *bool            `group:"mining" label:"GUI" description:"enables GUI" type:"" widget:"toggle" json:"GUI" hook:"restart"`
-	DarkTheme              *bool            `group:"config" label:"Dark Theme" description:"sets dark theme for GUI" type:"" widget:"toggle" json:"DarkTheme" hook:"restart"`
+	DarkTheme              *bool            `group:"config" label:"Dark Theme" description:"sets dark theme for GUI" type:"" widget:"toggle" json:"DarkTheme" hook:""`
 }
 
 func EmptyConfig() (c *Config, conf map[string]interface{}) {
@@ -225,8 +282,10 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		AddCheckpoints:         newStringSlice(),
 		AddPeers:               newStringSlice(),
 		AddrIndex:              newbool(),
+		AmountUnit:             newstring(),
 		AutoPorts:              newbool(),
 		BanDuration:            newDuration(),
+		BanScores:              newStringSlice(),
 		BanThreshold:           newint(),
 		BlockMaxSize:           newint(),
 		BlockMaxWeight:         newint(),
@@ -235,9 +294,12 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		BlockPrioritySize:      newint(),
 		BlocksOnly:             newbool(),
 		CAFile:                 newstring(),
+		CoinbaseData:           newstring(),
 		ConfigFile:             newstring(),
 		ConnectPeers:           newStringSlice(),
 		Controller:             newstring(),
+		ControllerAllow:        newStringSlice(),
+		CPFP:                   newbool(),
 		CPUProfile:             newstring(),
 		DarkTheme:              newbool(),
 		DataDir:                &datadir,
@@ -247,13 +309,20 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		DisableDNSSeed:         newbool(),
 		DisableListen:          newbool(),
 		DisableRPC:             newbool(),
+		EncryptWalletDB:        newbool(),
 		ExternalIPs:            newStringSlice(),
+		FeeEstimateMode:        newstring(),
+		FiatCurrency:           newstring(),
 		FreeTxRelayLimit:       newfloat64(),
 		Generate:               newbool(),
 		GenThreads:             newint(),
+		GPUDevices:             newStringSlice(),
+		GPUIntensity:           newint(),
+		InProcessMiner:         newbool(),
 		KopachGUI:              newbool(),
 		GUI:                    newbool(),
 		LAN:                    newbool(),
+		LANBlockPush:           newbool(),
 		Language:               newstring(),
 		LimitPass:              newstring(),
 		LimitUser:              newstring(),
@@ -262,28 +331,52 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		LogLevel:               newstring(),
 		MaxOrphanTxs:           newint(),
 		MaxPeers:               newint(),
+		Metrics:                newbool(),
+		MetricsListener:        newstring(),
+		MinerAlgos:             newStringSlice(),
+		MinerControllers:       newStringSlice(),
+		MinerListen:            newstring(),
 		MinerPass:              newstring(),
 		MiningAddrs:            newStringSlice(),
 		MinRelayTxFee:          newfloat64(),
 		Network:                newstring(),
 		NoCFilters:             newbool(),
+		NoIndexers:             newbool(),
+		NoMining:               newbool(),
 		NodeOff:                newbool(),
 		NoInitialLoad:          newbool(),
 		NoPeerBloomFilters:     newbool(),
+		NoRelay:                newbool(),
 		NoRelayPriority:        newbool(),
+		NotifyMined:            newbool(),
+		NotifyReceived:         newbool(),
+		NotifySent:             newbool(),
 		OneTimeTLSKey:          newbool(),
 		Onion:                  newbool(),
 		OnionProxy:             newstring(),
 		OnionProxyPass:         newstring(),
 		OnionProxyUser:         newstring(),
+		OnlyNet:                newstring(),
 		Password:               newstring(),
+		PaymentWebhook:         newstring(),
+		PayoutAlgoAddrs:        newStringSlice(),
+		PayoutPolicy:           newstring(),
+		PeerAllow:              newStringSlice(),
+		PeerAuth:               newbool(),
 		PipeLog:                newbool(),
+		PriceFeed:              newstring(),
 		Profile:                newstring(),
 		Proxy:                  newstring(),
 		ProxyPass:              newstring(),
 		ProxyUser:              newstring(),
+		PruneDepth:             newint(),
+		PruneTarget:            newint(),
+		ReauthThreshold:        newfloat64(),
 		RejectNonStd:           newbool(),
+		RejectReplacement:      newbool(),
 		RelayNonStd:            newbool(),
+		RPCAllowIPs:            newStringSlice(),
+		RPCCanonicalJSON:       newbool(),
 		RPCCert:                newstring(),
 		RPCConnect:             newstring(),
 		RPCKey:                 newstring(),
@@ -292,16 +385,26 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		RPCMaxConcurrentReqs:   newint(),
 		RPCMaxWebsockets:       newint(),
 		RPCQuirks:              newbool(),
+		RPCWalletProxy:         newstring(),
+		ScriptWorkers:          newint(),
 		ServerPass:             newstring(),
 		ServerTLS:              newbool(),
 		ServerUser:             newstring(),
 		SigCacheMaxSize:        newint(),
 		Solo:                   newbool(),
+		SpentIndex:             newbool(),
+		StratumDifficulty:      newfloat64(),
+		StratumListeners:       newStringSlice(),
+		Telemetry:              newbool(),
+		TelemetryURL:           newstring(),
 		TLS:                    newbool(),
 		TLSSkipVerify:          newbool(),
+		TorControl:             newstring(),
 		TorIsolation:           newbool(),
+		TorPassword:            newstring(),
 		TrickleInterval:        newDuration(),
 		TxIndex:                newbool(),
+		UnlockTimeout:          newDuration(),
 		UPNP:                   newbool(),
 		UserAgentComments:      newStringSlice(),
 		Username:               newstring(),
@@ -314,13 +417,16 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		WalletRPCMaxWebsockets: newint(),
 		WalletServer:           newstring(),
 		Whitelists:             newStringSlice(),
+		WTxIndex:               newbool(),
 	}
 	conf = map[string]interface{}{
 		"AddCheckpoints":         c.AddCheckpoints,
 		"AddPeers":               c.AddPeers,
 		"AddrIndex":              c.AddrIndex,
+		"AmountUnit":             c.AmountUnit,
 		"AutoPorts":              c.AutoPorts,
 		"BanDuration":            c.BanDuration,
+		"BanScores":              c.BanScores,
 		"BanThreshold":           c.BanThreshold,
 		"BlockMaxSize":           c.BlockMaxSize,
 		"BlockMaxWeight":         c.BlockMaxWeight,
@@ -329,9 +435,12 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"BlockPrioritySize":      c.BlockPrioritySize,
 		"BlocksOnly":             c.BlocksOnly,
 		"CAFile":                 c.CAFile,
+		"CoinbaseData":           c.CoinbaseData,
 		"ConfigFile":             c.ConfigFile,
 		"ConnectPeers":           c.ConnectPeers,
 		"Controller":             c.Controller,
+		"ControllerAllow":        c.ControllerAllow,
+		"CPFP":                   c.CPFP,
 		"CPUProfile":             c.CPUProfile,
 		"DarkTheme":              c.DarkTheme,
 		"DataDir":                c.DataDir,
@@ -341,13 +450,20 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"DisableDNSSeed":         c.DisableDNSSeed,
 		"DisableListen":          c.DisableListen,
 		"DisableRPC":             c.DisableRPC,
+		"EncryptWalletDB":        c.EncryptWalletDB,
 		"ExternalIPs":            c.ExternalIPs,
+		"FeeEstimateMode":        c.FeeEstimateMode,
+		"FiatCurrency":           c.FiatCurrency,
 		"FreeTxRelayLimit":       c.FreeTxRelayLimit,
 		"Generate":               c.Generate,
 		"GenThreads":             c.GenThreads,
+		"GPUDevices":             c.GPUDevices,
+		"GPUIntensity":           c.GPUIntensity,
+		"InProcessMiner":         c.InProcessMiner,
 		"KopachGUI":              c.KopachGUI,
 		"GUI":                    c.GUI,
 		"LAN":                    c.LAN,
+		"LANBlockPush":           c.LANBlockPush,
 		"Language":               c.Language,
 		"LimitPass":              c.LimitPass,
 		"LimitUser":              c.LimitUser,
@@ -356,28 +472,52 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"LogLevel":               c.LogLevel,
 		"MaxOrphanTxs":           c.MaxOrphanTxs,
 		"MaxPeers":               c.MaxPeers,
+		"Metrics":                c.Metrics,
+		"MetricsListener":        c.MetricsListener,
+		"MinerAlgos":             c.MinerAlgos,
+		"MinerControllers":       c.MinerControllers,
+		"MinerListen":            c.MinerListen,
 		"MinerPass":              c.MinerPass,
 		"MiningAddrs":            c.MiningAddrs,
 		"MinRelayTxFee":          c.MinRelayTxFee,
 		"Network":                c.Network,
 		"NoCFilters":             c.NoCFilters,
+		"NoIndexers":             c.NoIndexers,
+		"NoMining":               c.NoMining,
 		"NodeOff":                c.NodeOff,
 		"NoInitialLoad":          c.NoInitialLoad,
 		"NoPeerBloomFilters":     c.NoPeerBloomFilters,
+		"NoRelay":                c.NoRelay,
 		"NoRelayPriority":        c.NoRelayPriority,
+		"NotifyMined":            c.NotifyMined,
+		"NotifyReceived":         c.NotifyReceived,
+		"NotifySent":             c.NotifySent,
 		"OneTimeTLSKey":          c.OneTimeTLSKey,
 		"Onion":                  c.Onion,
 		"OnionProxy":             c.OnionProxy,
 		"OnionProxyPass":         c.OnionProxyPass,
 		"OnionProxyUser":         c.OnionProxyUser,
+		"OnlyNet":                c.OnlyNet,
 		"Password":               c.Password,
+		"PaymentWebhook":         c.PaymentWebhook,
+		"PayoutAlgoAddrs":        c.PayoutAlgoAddrs,
+		"PayoutPolicy":           c.PayoutPolicy,
+		"PeerAllow":              c.PeerAllow,
+		"PeerAuth":               c.PeerAuth,
 		"PipeLog":                c.PipeLog,
+		"PriceFeed":              c.PriceFeed,
 		"Profile":                c.Profile,
 		"Proxy":                  c.Proxy,
 		"ProxyPass":              c.ProxyPass,
 		"ProxyUser":              c.ProxyUser,
+		"PruneDepth":             c.PruneDepth,
+		"PruneTarget":            c.PruneTarget,
+		"ReauthThreshold":        c.ReauthThreshold,
 		"RejectNonStd":           c.RejectNonStd,
+		"RejectReplacement":      c.RejectReplacement,
 		"RelayNonStd":            c.RelayNonStd,
+		"RPCAllowIPs":            c.RPCAllowIPs,
+		"RPCCanonicalJSON":       c.RPCCanonicalJSON,
 		"RPCCert":                c.RPCCert,
 		"RPCConnect":             c.RPCConnect,
 		"RPCKey":                 c.RPCKey,
@@ -386,16 +526,26 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"RPCMaxConcurrentReqs":   c.RPCMaxConcurrentReqs,
 		"RPCMaxWebsockets":       c.RPCMaxWebsockets,
 		"RPCQuirks":              c.RPCQuirks,
+		"RPCWalletProxy":         c.RPCWalletProxy,
+		"ScriptWorkers":          c.ScriptWorkers,
 		"ServerPass":             c.ServerPass,
 		"ServerTLS":              c.ServerTLS,
 		"ServerUser":             c.ServerUser,
 		"SigCacheMaxSize":        c.SigCacheMaxSize,
 		"Solo":                   c.Solo,
+		"SpentIndex":             c.SpentIndex,
+		"StratumDifficulty":      c.StratumDifficulty,
+		"StratumListeners":       c.StratumListeners,
+		"Telemetry":              c.Telemetry,
+		"TelemetryURL":           c.TelemetryURL,
 		"TLS":                    c.TLS,
 		"TLSSkipVerify":          c.TLSSkipVerify,
+		"TorControl":             c.TorControl,
 		"TorIsolation":           c.TorIsolation,
+		"TorPassword":            c.TorPassword,
 		"TrickleInterval":        c.TrickleInterval,
 		"TxIndex":                c.TxIndex,
+		"UnlockTimeout":          c.UnlockTimeout,
 		"UPNP":                   c.UPNP,
 		"UserAgentComments":      c.UserAgentComments,
 		"Username":               c.Username,
@@ -408,6 +558,7 @@ func EmptyConfig() (c *Config, conf map[string]interface{}) {
 		"WalletRPCMaxWebsockets": c.WalletRPCMaxWebsockets,
 		"WalletServer":           c.WalletServer,
 		"Whitelists":             c.Whitelists,
+		"WTxIndex":               c.WTxIndex,
 	}
 	return
 }
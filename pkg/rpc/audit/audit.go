@@ -0,0 +1,133 @@
+// Package audit keeps an append-only log of the state-changing RPC commands run on a node or wallet, so that operators
+// sharing a node can see who did what and from where.
+package audit
+
+import (
+	"bufio"
+	js "encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// FileName is the name of the audit log file in the data directory of the active network
+const FileName = "audit.log"
+
+// Log is an append-only audit log holding one JSON encoded entry per line. It is safe for concurrent access, including
+// by the node and wallet servers of one process sharing the same file.
+type Log struct {
+	sync.Mutex
+	path string
+	file *os.File
+}
+
+// Open opens the audit log at path for appending, creating it and its directory if they do not exist
+func Open(path string) (l *Log, err error) {
+	if err = os.MkdirAll(filepath.Dir(path), 0700); Check(err) {
+		return
+	}
+	var f *os.File
+	if f, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600); Check(err) {
+		return
+	}
+	// A line cut short by a crash is ended, so that it does not swallow the next entry.
+	var fi os.FileInfo
+	if fi, err = f.Stat(); !Check(err) && fi.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err = f.ReadAt(last, fi.Size()-1); !Check(err) && last[0] != '\n' {
+			_, err = f.Write([]byte{'\n'})
+		}
+	}
+	if err != nil {
+		if err := f.Close(); Check(err) {
+		}
+		return
+	}
+	return &Log{path: path, file: f}, nil
+}
+
+// Record appends an entry to the log. Failing to write the entry is logged but otherwise ignored, so that the command
+// being recorded is not affected. It is safe to call on a nil log, which records nothing.
+func (l *Log) Record(e *btcjson.AuditLogEntry) {
+	if l == nil {
+		return
+	}
+	b, err := js.Marshal(e)
+	if Check(err) {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.file == nil {
+		return
+	}
+	if _, err = l.file.Write(append(b, '\n')); Check(err) {
+		return
+	}
+	if err = l.file.Sync(); Check(err) {
+	}
+}
+
+// Entries returns up to count entries of the log, oldest first, leaving out the skip most recent ones. Lines that can
+// not be decoded, such as one cut short by a crash, are skipped.
+func (l *Log) Entries(count, skip int) (entries []btcjson.AuditLogEntry, err error) {
+	entries = []btcjson.AuditLogEntry{}
+	if l == nil || count <= 0 {
+		return
+	}
+	if skip < 0 {
+		skip = 0
+	}
+	l.Lock()
+	defer l.Unlock()
+	var f *os.File
+	if f, err = os.Open(l.path); Check(err) {
+		return
+	}
+	defer func() {
+		if err := f.Close(); Check(err) {
+		}
+	}()
+	// Only the last count+skip entries are needed, so older ones are dropped while reading.
+	keep := count + skip
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var e btcjson.AuditLogEntry
+		if js.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+		entries = append(entries, e)
+		if len(entries) > keep {
+			entries = entries[1:]
+		}
+	}
+	if err = scanner.Err(); Check(err) {
+		return
+	}
+	if skip >= len(entries) {
+		return entries[:0], nil
+	}
+	entries = entries[:len(entries)-skip]
+	if len(entries) > count {
+		entries = entries[len(entries)-count:]
+	}
+	return
+}
+
+// Close closes the log file. Entries recorded after the log is closed are dropped.
+func (l *Log) Close() (err error) {
+	if l == nil {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.file == nil {
+		return
+	}
+	err = l.file.Close()
+	l.file = nil
+	return
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// TestLog ensures that recorded entries survive reopening the log and are returned newest last, honouring count and
+// skip, and that damaged lines are skipped.
+func TestLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "mainnet", FileName)
+	l, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	methods := []string{"addnode", "setgenerate", "stop"}
+	for i, m := range methods {
+		l.Record(&btcjson.AuditLogEntry{Time: int64(i), Server: "node", User: "admin", Source: "127.0.0.1:1234",
+			Method: m})
+	}
+	if err = l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// A line cut short by a crash is skipped, and does not swallow the entry recorded after it.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.WriteString(`{"time":3,"method":"sendtoad`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	if l, err = Open(path); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.Record(&btcjson.AuditLogEntry{Time: 4, Server: "wallet", User: "admin", Method: "sendtoaddress"})
+	tests := []struct {
+		count, skip int
+		want        []string
+	}{
+		{100, 0, []string{"addnode", "setgenerate", "stop", "sendtoaddress"}},
+		{2, 0, []string{"stop", "sendtoaddress"}},
+		{2, 1, []string{"setgenerate", "stop"}},
+		{100, 4, nil},
+		{0, 0, nil},
+	}
+	for i, test := range tests {
+		entries, err := l.Entries(test.count, test.skip)
+		if err != nil {
+			t.Fatalf("test #%d: %v", i, err)
+		}
+		if len(entries) != len(test.want) {
+			t.Errorf("test #%d: got %d entries, want %d", i, len(entries), len(test.want))
+			continue
+		}
+		for j := range entries {
+			if entries[j].Method != test.want[j] {
+				t.Errorf("test #%d: entry #%d is %s, want %s", i, j, entries[j].Method, test.want[j])
+			}
+		}
+	}
+}
@@ -43,6 +43,43 @@ func NewDebugLevelCmd(levelSpec string) *DebugLevelCmd {
 	}
 }
 
+// DumpUTXOSetCmd defines the dumputxoset JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type DumpUTXOSetCmd struct {
+	Filename string
+}
+
+// NewDumpUTXOSetCmd returns a new instance which can be used to issue a dumputxoset JSON-RPC command.
+func NewDumpUTXOSetCmd(filename string) *DumpUTXOSetCmd {
+	return &DumpUTXOSetCmd{
+		Filename: filename,
+	}
+}
+
+// EvaluateScriptCmd defines the evaluatescript JSON-RPC command. This command is not a standard Bitcoin command. It is
+// an extension for pod.
+type EvaluateScriptCmd struct {
+	ScriptPubKey string
+	ScriptSig    *string
+	RawTx        *string
+	Index        *uint32  `jsonrpcdefault:"0"`
+	Amount       *float64 `jsonrpcdefault:"0"`
+}
+
+// NewEvaluateScriptCmd returns a new instance which can be used to issue an evaluatescript JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewEvaluateScriptCmd(scriptPubKey string, scriptSig, rawTx *string, index *uint32,
+	amount *float64) *EvaluateScriptCmd {
+	return &EvaluateScriptCmd{
+		ScriptPubKey: scriptPubKey,
+		ScriptSig:    scriptSig,
+		RawTx:        rawTx,
+		Index:        index,
+		Amount:       amount,
+	}
+}
+
 // GenerateCmd defines the generate JSON-RPC command.
 type GenerateCmd struct {
 	NumBlocks uint32
@@ -55,6 +92,159 @@ func NewGenerateCmd(numBlocks uint32) *GenerateCmd {
 	}
 }
 
+// GenerateToAddressCmd defines the generatetoaddress JSON-RPC command.
+type GenerateToAddressCmd struct {
+	NumBlocks uint32
+	Address   string
+	MaxTries  *uint64 `jsonrpcdefault:"1000000"`
+	Algo      *string
+}
+
+// NewGenerateToAddressCmd returns a new instance which can be used to issue a generatetoaddress JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGenerateToAddressCmd(numBlocks uint32, address string, maxTries *uint64, algo *string) *GenerateToAddressCmd {
+	return &GenerateToAddressCmd{
+		NumBlocks: numBlocks,
+		Address:   address,
+		MaxTries:  maxTries,
+		Algo:      algo,
+	}
+}
+
+// GenerateBlockCmd defines the generateblock JSON-RPC command.
+type GenerateBlockCmd struct {
+	Address      string
+	Transactions []string
+	Algo         *string
+}
+
+// NewGenerateBlockCmd returns a new instance which can be used to issue a generateblock JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGenerateBlockCmd(address string, transactions []string, algo *string) *GenerateBlockCmd {
+	return &GenerateBlockCmd{
+		Address:      address,
+		Transactions: transactions,
+		Algo:         algo,
+	}
+}
+
+// CalculateSigHashCmd defines the calculatesighash JSON-RPC command. This command is not a standard Bitcoin command. It
+// is an extension for pod.
+type CalculateSigHashCmd struct {
+	RawTx    string
+	Index    uint32
+	Script   string
+	Amount   *float64 `jsonrpcdefault:"0"`
+	HashType *string  `jsonrpcdefault:"\"ALL\""`
+	Witness  *bool    `jsonrpcdefault:"false"`
+}
+
+// NewCalculateSigHashCmd returns a new instance which can be used to issue a calculatesighash JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewCalculateSigHashCmd(rawTx string, index uint32, script string, amount *float64, hashType *string,
+	witness *bool) *CalculateSigHashCmd {
+	return &CalculateSigHashCmd{
+		RawTx:    rawTx,
+		Index:    index,
+		Script:   script,
+		Amount:   amount,
+		HashType: hashType,
+		Witness:  witness,
+	}
+}
+
+// DumpAddrManCmd defines the dumpaddrman JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type DumpAddrManCmd struct {
+	Filename string
+}
+
+// NewDumpAddrManCmd returns a new instance which can be used to issue a dumpaddrman JSON-RPC command.
+func NewDumpAddrManCmd(filename string) *DumpAddrManCmd {
+	return &DumpAddrManCmd{
+		Filename: filename,
+	}
+}
+
+// GetAddressBalanceCmd defines the getaddressbalance JSON-RPC command. This command is not a standard Bitcoin command.
+// It is an extension for pod.
+type GetAddressBalanceCmd struct {
+	Addresses []string
+}
+
+// NewGetAddressBalanceCmd returns a new instance which can be used to issue a getaddressbalance JSON-RPC command.
+func NewGetAddressBalanceCmd(addresses []string) *GetAddressBalanceCmd {
+	return &GetAddressBalanceCmd{
+		Addresses: addresses,
+	}
+}
+
+// GetAddressTxIDsCmd defines the getaddresstxids JSON-RPC command. This command is not a standard Bitcoin command. It
+// is an extension for pod.
+type GetAddressTxIDsCmd struct {
+	Addresses      []string
+	IncludeMempool *bool `jsonrpcdefault:"true"`
+}
+
+// NewGetAddressTxIDsCmd returns a new instance which can be used to issue a getaddresstxids JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewGetAddressTxIDsCmd(addresses []string, includeMempool *bool) *GetAddressTxIDsCmd {
+	return &GetAddressTxIDsCmd{
+		Addresses:      addresses,
+		IncludeMempool: includeMempool,
+	}
+}
+
+// GetAddressUtxosCmd defines the getaddressutxos JSON-RPC command. This command is not a standard Bitcoin command. It
+// is an extension for pod.
+type GetAddressUtxosCmd struct {
+	Addresses      []string
+	IncludeMempool *bool `jsonrpcdefault:"false"`
+}
+
+// NewGetAddressUtxosCmd returns a new instance which can be used to issue a getaddressutxos JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewGetAddressUtxosCmd(addresses []string, includeMempool *bool) *GetAddressUtxosCmd {
+	return &GetAddressUtxosCmd{
+		Addresses:      addresses,
+		IncludeMempool: includeMempool,
+	}
+}
+
+// GetAddrManInfoCmd defines the getaddrmaninfo JSON-RPC command. This command is not a standard Bitcoin command. It is
+// an extension for pod.
+type GetAddrManInfoCmd struct{}
+
+// NewGetAddrManInfoCmd returns a new instance which can be used to issue a getaddrmaninfo JSON-RPC command.
+func NewGetAddrManInfoCmd() *GetAddrManInfoCmd {
+	return &GetAddrManInfoCmd{}
+}
+
+// GetAuditLogCmd defines the getauditlog JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type GetAuditLogCmd struct {
+	Count *int `jsonrpcdefault:"100"`
+	Skip  *int `jsonrpcdefault:"0"`
+}
+
+// NewGetAuditLogCmd returns a new instance which can be used to issue a getauditlog JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetAuditLogCmd(count, skip *int) *GetAuditLogCmd {
+	return &GetAuditLogCmd{
+		Count: count,
+		Skip:  skip,
+	}
+}
+
 // GetBestBlockCmd defines the getbestblock JSON-RPC command.
 type GetBestBlockCmd struct{}
 
@@ -63,6 +253,24 @@ func NewGetBestBlockCmd() *GetBestBlockCmd {
 	return &GetBestBlockCmd{}
 }
 
+// GetBlockSubsidyCmd defines the getblocksubsidy JSON-RPC command. This command is not a standard Bitcoin command. It
+// is an extension for pod.
+type GetBlockSubsidyCmd struct {
+	Height *int32
+	Algo   *string
+}
+
+// NewGetBlockSubsidyCmd returns a new instance which can be used to issue a getblocksubsidy JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetBlockSubsidyCmd(height *int32, algo *string) *GetBlockSubsidyCmd {
+	return &GetBlockSubsidyCmd{
+		Height: height,
+		Algo:   algo,
+	}
+}
+
 // GetCurrentNetCmd defines the getcurrentnet JSON-RPC command.
 type GetCurrentNetCmd struct{}
 
@@ -86,6 +294,100 @@ func NewGetHeadersCmd(blockLocators []string, hashStop string) *GetHeadersCmd {
 	}
 }
 
+// GetTelemetryInfoCmd defines the gettelemetryinfo JSON-RPC command. This command is not a standard Bitcoin command.
+// It is an extension for pod.
+type GetTelemetryInfoCmd struct{}
+
+// NewGetTelemetryInfoCmd returns a new instance which can be used to issue a gettelemetryinfo JSON-RPC command.
+func NewGetTelemetryInfoCmd() *GetTelemetryInfoCmd {
+	return &GetTelemetryInfoCmd{}
+}
+
+// GetSnapshotCmd defines the getsnapshot JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type GetSnapshotCmd struct{}
+
+// NewGetSnapshotCmd returns a new instance which can be used to issue a getsnapshot JSON-RPC command.
+func NewGetSnapshotCmd() *GetSnapshotCmd {
+	return &GetSnapshotCmd{}
+}
+
+// GetSpentInfoCmd defines the getspentinfo JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type GetSpentInfoCmd struct {
+	TxID  string
+	Index uint32
+}
+
+// NewGetSpentInfoCmd returns a new instance which can be used to issue a getspentinfo JSON-RPC command.
+func NewGetSpentInfoCmd(txID string, index uint32) *GetSpentInfoCmd {
+	return &GetSpentInfoCmd{
+		TxID:  txID,
+		Index: index,
+	}
+}
+
+// GetSyncStatusCmd defines the getsyncstatus JSON-RPC command. This command is not a standard Bitcoin command. It is
+// an extension for pod.
+type GetSyncStatusCmd struct{}
+
+// NewGetSyncStatusCmd returns a new instance which can be used to issue a getsyncstatus JSON-RPC command.
+func NewGetSyncStatusCmd() *GetSyncStatusCmd {
+	return &GetSyncStatusCmd{}
+}
+
+// LoadAddrManCmd defines the loadaddrman JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type LoadAddrManCmd struct {
+	Filename string
+}
+
+// NewLoadAddrManCmd returns a new instance which can be used to issue a loadaddrman JSON-RPC command.
+func NewLoadAddrManCmd(filename string) *LoadAddrManCmd {
+	return &LoadAddrManCmd{
+		Filename: filename,
+	}
+}
+
+// LoadUTXOSetCmd defines the loadutxoset JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type LoadUTXOSetCmd struct {
+	Filename string
+}
+
+// NewLoadUTXOSetCmd returns a new instance which can be used to issue a loadutxoset JSON-RPC command.
+func NewLoadUTXOSetCmd(filename string) *LoadUTXOSetCmd {
+	return &LoadUTXOSetCmd{
+		Filename: filename,
+	}
+}
+
+// ReleaseSnapshotCmd defines the releasesnapshot JSON-RPC command. This command is not a standard Bitcoin command. It
+// is an extension for pod.
+type ReleaseSnapshotCmd struct {
+	Snapshot string
+}
+
+// NewReleaseSnapshotCmd returns a new instance which can be used to issue a releasesnapshot JSON-RPC command.
+func NewReleaseSnapshotCmd(snapshot string) *ReleaseSnapshotCmd {
+	return &ReleaseSnapshotCmd{
+		Snapshot: snapshot,
+	}
+}
+
+// SubmitHeaderCmd defines the submitheader JSON-RPC command. This command is not a standard Bitcoin command. It is an
+// extension for pod.
+type SubmitHeaderCmd struct {
+	HexData string
+}
+
+// NewSubmitHeaderCmd returns a new instance which can be used to issue a submitheader JSON-RPC command.
+func NewSubmitHeaderCmd(hexData string) *SubmitHeaderCmd {
+	return &SubmitHeaderCmd{
+		HexData: hexData,
+	}
+}
+
 // VersionCmd defines the version JSON-RPC command. NOTE: This is a btcsuite extension ported from github.com/decred/dcrd/dcrjson.
 type VersionCmd struct{}
 
@@ -100,8 +402,28 @@ func init() {
 	MustRegisterCmd("debuglevel", (*DebugLevelCmd)(nil), flags)
 	MustRegisterCmd("node", (*NodeCmd)(nil), flags)
 	MustRegisterCmd("generate", (*GenerateCmd)(nil), flags)
+	MustRegisterCmd("generateblock", (*GenerateBlockCmd)(nil), flags)
+	MustRegisterCmd("generatetoaddress", (*GenerateToAddressCmd)(nil), flags)
+	MustRegisterCmd("calculatesighash", (*CalculateSigHashCmd)(nil), flags)
+	MustRegisterCmd("dumpaddrman", (*DumpAddrManCmd)(nil), flags)
+	MustRegisterCmd("dumputxoset", (*DumpUTXOSetCmd)(nil), flags)
+	MustRegisterCmd("evaluatescript", (*EvaluateScriptCmd)(nil), flags)
+	MustRegisterCmd("getaddressbalance", (*GetAddressBalanceCmd)(nil), flags)
+	MustRegisterCmd("getaddresstxids", (*GetAddressTxIDsCmd)(nil), flags)
+	MustRegisterCmd("getaddressutxos", (*GetAddressUtxosCmd)(nil), flags)
+	MustRegisterCmd("getaddrmaninfo", (*GetAddrManInfoCmd)(nil), flags)
+	MustRegisterCmd("getauditlog", (*GetAuditLogCmd)(nil), flags)
 	MustRegisterCmd("getbestblock", (*GetBestBlockCmd)(nil), flags)
+	MustRegisterCmd("getblocksubsidy", (*GetBlockSubsidyCmd)(nil), flags)
 	MustRegisterCmd("getcurrentnet", (*GetCurrentNetCmd)(nil), flags)
 	MustRegisterCmd("getheaders", (*GetHeadersCmd)(nil), flags)
+	MustRegisterCmd("getsnapshot", (*GetSnapshotCmd)(nil), flags)
+	MustRegisterCmd("getspentinfo", (*GetSpentInfoCmd)(nil), flags)
+	MustRegisterCmd("getsyncstatus", (*GetSyncStatusCmd)(nil), flags)
+	MustRegisterCmd("gettelemetryinfo", (*GetTelemetryInfoCmd)(nil), flags)
+	MustRegisterCmd("loadaddrman", (*LoadAddrManCmd)(nil), flags)
+	MustRegisterCmd("loadutxoset", (*LoadUTXOSetCmd)(nil), flags)
+	MustRegisterCmd("releasesnapshot", (*ReleaseSnapshotCmd)(nil), flags)
+	MustRegisterCmd("submitheader", (*SubmitHeaderCmd)(nil), flags)
 	MustRegisterCmd("version", (*VersionCmd)(nil), flags)
 }
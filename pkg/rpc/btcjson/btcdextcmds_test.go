@@ -107,6 +107,162 @@ func TestPodExtCmds(t *testing.T) {
 				NumBlocks: 1,
 			},
 		},
+		{
+			name: "generatetoaddress",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("generatetoaddress", 2, "1Address")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGenerateToAddressCmd(2, "1Address", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"generatetoaddress","netparams":[2,"1Address"],"id":1}`,
+			unmarshalled: &btcjson.GenerateToAddressCmd{
+				NumBlocks: 2,
+				Address:   "1Address",
+				MaxTries:  btcjson.Uint64(1000000),
+			},
+		},
+		{
+			name: "generatetoaddress optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("generatetoaddress", 2, "1Address", 500, "scrypt")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGenerateToAddressCmd(2, "1Address", btcjson.Uint64(500), btcjson.String("scrypt"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"generatetoaddress","netparams":[2,"1Address",500,"scrypt"],"id":1}`,
+			unmarshalled: &btcjson.GenerateToAddressCmd{
+				NumBlocks: 2,
+				Address:   "1Address",
+				MaxTries:  btcjson.Uint64(500),
+				Algo:      btcjson.String("scrypt"),
+			},
+		},
+		{
+			name: "generateblock",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("generateblock", "1Address", []string{"0011"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGenerateBlockCmd("1Address", []string{"0011"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"generateblock","netparams":["1Address",["0011"]],"id":1}`,
+			unmarshalled: &btcjson.GenerateBlockCmd{
+				Address:      "1Address",
+				Transactions: []string{"0011"},
+			},
+		},
+		{
+			name: "dumpaddrman",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("dumpaddrman", "peers.json")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDumpAddrManCmd("peers.json")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"dumpaddrman","netparams":["peers.json"],"id":1}`,
+			unmarshalled: &btcjson.DumpAddrManCmd{Filename: "peers.json"},
+		},
+		{
+			name: "dumputxoset",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("dumputxoset", "utxo.dat")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDumpUTXOSetCmd("utxo.dat")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"dumputxoset","netparams":["utxo.dat"],"id":1}`,
+			unmarshalled: &btcjson.DumpUTXOSetCmd{Filename: "utxo.dat"},
+		},
+		{
+			name: "getsyncstatus",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getsyncstatus")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSyncStatusCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getsyncstatus","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetSyncStatusCmd{},
+		},
+		{
+			name: "getaddressbalance",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressbalance", []string{"1Address"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressBalanceCmd([]string{"1Address"})
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getaddressbalance","netparams":[["1Address"]],"id":1}`,
+			unmarshalled: &btcjson.GetAddressBalanceCmd{Addresses: []string{"1Address"}},
+		},
+		{
+			name: "getaddresstxids",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddresstxids", []string{"1Address", "1Other"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressTxIDsCmd([]string{"1Address", "1Other"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddresstxids","netparams":[["1Address","1Other"]],"id":1}`,
+			unmarshalled: &btcjson.GetAddressTxIDsCmd{
+				Addresses:      []string{"1Address", "1Other"},
+				IncludeMempool: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "getaddressutxos",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddressutxos", []string{"1Address"}, true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddressUtxosCmd([]string{"1Address"}, btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaddressutxos","netparams":[["1Address"],true],"id":1}`,
+			unmarshalled: &btcjson.GetAddressUtxosCmd{
+				Addresses:      []string{"1Address"},
+				IncludeMempool: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "getaddrmaninfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaddrmaninfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAddrManInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getaddrmaninfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetAddrManInfoCmd{},
+		},
+		{
+			name: "getauditlog",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getauditlog")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAuditLogCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getauditlog","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetAuditLogCmd{
+				Count: btcjson.Int(100),
+				Skip:  btcjson.Int(0),
+			},
+		},
+		{
+			name: "getauditlog optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getauditlog", 10, 5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAuditLogCmd(btcjson.Int(10), btcjson.Int(5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getauditlog","netparams":[10,5],"id":1}`,
+			unmarshalled: &btcjson.GetAuditLogCmd{
+				Count: btcjson.Int(10),
+				Skip:  btcjson.Int(5),
+			},
+		},
 		{
 			name: "getbestblock",
 			newCmd: func() (interface{}, error) {
@@ -173,6 +329,185 @@ func TestPodExtCmds(t *testing.T) {
 				HashStop: "000000000000000000ba33b33e1fad70b69e234fc24414dd47113bff38f523f7",
 			},
 		},
+		{
+			name: "calculatesighash",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("calculatesighash", "0100", 0, "76a9")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCalculateSigHashCmd("0100", 0, "76a9", nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"calculatesighash","netparams":["0100",0,"76a9"],"id":1}`,
+			unmarshalled: &btcjson.CalculateSigHashCmd{
+				RawTx:    "0100",
+				Index:    0,
+				Script:   "76a9",
+				Amount:   btcjson.Float64(0),
+				HashType: btcjson.String("ALL"),
+				Witness:  btcjson.Bool(false),
+			},
+		},
+		{
+			name: "calculatesighash optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("calculatesighash", "0100", 1, "76a9", 0.5, "SINGLE|ANYONECANPAY", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCalculateSigHashCmd("0100", 1, "76a9", btcjson.Float64(0.5),
+					btcjson.String("SINGLE|ANYONECANPAY"), btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"calculatesighash","netparams":["0100",1,"76a9",0.5,"SINGLE|ANYONECANPAY",true],"id":1}`,
+			unmarshalled: &btcjson.CalculateSigHashCmd{
+				RawTx:    "0100",
+				Index:    1,
+				Script:   "76a9",
+				Amount:   btcjson.Float64(0.5),
+				HashType: btcjson.String("SINGLE|ANYONECANPAY"),
+				Witness:  btcjson.Bool(true),
+			},
+		},
+		{
+			name: "evaluatescript",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("evaluatescript", "5287", "5253")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEvaluateScriptCmd("5287", btcjson.String("5253"), nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"evaluatescript","netparams":["5287","5253"],"id":1}`,
+			unmarshalled: &btcjson.EvaluateScriptCmd{
+				ScriptPubKey: "5287",
+				ScriptSig:    btcjson.String("5253"),
+				Index:        btcjson.Uint32(0),
+				Amount:       btcjson.Float64(0),
+			},
+		},
+		{
+			name: "evaluatescript optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("evaluatescript", "5287", "", "0100", 1, 0.5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEvaluateScriptCmd("5287", btcjson.String(""), btcjson.String("0100"),
+					btcjson.Uint32(1), btcjson.Float64(0.5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"evaluatescript","netparams":["5287","","0100",1,0.5],"id":1}`,
+			unmarshalled: &btcjson.EvaluateScriptCmd{
+				ScriptPubKey: "5287",
+				ScriptSig:    btcjson.String(""),
+				RawTx:        btcjson.String("0100"),
+				Index:        btcjson.Uint32(1),
+				Amount:       btcjson.Float64(0.5),
+			},
+		},
+		{
+			name: "gettelemetryinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gettelemetryinfo")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetTelemetryInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"gettelemetryinfo","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetTelemetryInfoCmd{},
+		},
+		{
+			name: "getblocksubsidy",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblocksubsidy")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockSubsidyCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblocksubsidy","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetBlockSubsidyCmd{
+				Height: nil,
+				Algo:   nil,
+			},
+		},
+		{
+			name: "getblocksubsidy optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblocksubsidy", 300000, "scrypt")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockSubsidyCmd(btcjson.Int32(300000), btcjson.String("scrypt"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblocksubsidy","netparams":[300000,"scrypt"],"id":1}`,
+			unmarshalled: &btcjson.GetBlockSubsidyCmd{
+				Height: btcjson.Int32(300000),
+				Algo:   btcjson.String("scrypt"),
+			},
+		},
+		{
+			name: "getsnapshot",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getsnapshot")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSnapshotCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getsnapshot","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetSnapshotCmd{},
+		},
+		{
+			name: "getspentinfo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getspentinfo", "123", 1)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetSpentInfoCmd("123", 1)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getspentinfo","netparams":["123",1],"id":1}`,
+			unmarshalled: &btcjson.GetSpentInfoCmd{
+				TxID:  "123",
+				Index: 1,
+			},
+		},
+		{
+			name: "loadaddrman",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("loadaddrman", "peers.json")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewLoadAddrManCmd("peers.json")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"loadaddrman","netparams":["peers.json"],"id":1}`,
+			unmarshalled: &btcjson.LoadAddrManCmd{Filename: "peers.json"},
+		},
+		{
+			name: "loadutxoset",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("loadutxoset", "utxo.dat")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewLoadUTXOSetCmd("utxo.dat")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"loadutxoset","netparams":["utxo.dat"],"id":1}`,
+			unmarshalled: &btcjson.LoadUTXOSetCmd{Filename: "utxo.dat"},
+		},
+		{
+			name: "releasesnapshot",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("releasesnapshot", "0123abcd")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewReleaseSnapshotCmd("0123abcd")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"releasesnapshot","netparams":["0123abcd"],"id":1}`,
+			unmarshalled: &btcjson.ReleaseSnapshotCmd{Snapshot: "0123abcd"},
+		},
+		{
+			name: "submitheader",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("submitheader", "00112233")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSubmitHeaderCmd("00112233")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"submitheader","netparams":["00112233"],"id":1}`,
+			unmarshalled: &btcjson.SubmitHeaderCmd{HexData: "00112233"},
+		},
 		{
 			name: "version",
 			newCmd: func() (interface{}, error) {
@@ -1,5 +1,7 @@
 package btcjson
 
+import "encoding/json"
+
 // VersionResult models objects included in the version response.  In the actual result, these objects are keyed by the program or API name. NOTE: This is a btcsuite extension ported from github.com/decred/dcrd/dcrjson.
 type VersionResult struct {
 	VersionString string `json:"versionstring"`
@@ -9,3 +11,174 @@ type VersionResult struct {
 	Prerelease    string `json:"prerelease"`
 	BuildMetadata string `json:"buildmetadata"`
 }
+
+// TelemetryReportResult models the anonymous report sent by the telemetry reporter. This is an extension for pod.
+type TelemetryReportResult struct {
+	Session string `json:"session"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+	Arch    string `json:"arch"`
+	Network string `json:"network"`
+	Height  int32  `json:"height"`
+	Peers   int32  `json:"peers"`
+	Synced  bool   `json:"synced"`
+	Uptime  int64  `json:"uptime"`
+}
+
+// GetTelemetryInfoResult models the data returned from the gettelemetryinfo command. This is an extension for pod.
+type GetTelemetryInfoResult struct {
+	Enabled     bool                  `json:"enabled"`
+	Endpoint    string                `json:"endpoint"`
+	Interval    int64                 `json:"interval"`
+	ReportsSent uint64                `json:"reportssent"`
+	LastSent    int64                 `json:"lastsent"`
+	LastError   string                `json:"lasterror,omitempty"`
+	Report      TelemetryReportResult `json:"report"`
+}
+
+// CalculateSigHashResult models the data returned from the calculatesighash command. This is an extension for pod.
+type CalculateSigHashResult struct {
+	SigHash  string `json:"sighash"`
+	HashType uint32 `json:"hashtype"`
+	Witness  bool   `json:"witness"`
+}
+
+// EvaluateScriptStep models a single executed opcode in the result of the evaluatescript command. This is an extension
+// for pod.
+type EvaluateScriptStep struct {
+	Script   int      `json:"script"`
+	Offset   int      `json:"offset"`
+	Opcode   string   `json:"opcode"`
+	Stack    []string `json:"stack"`
+	AltStack []string `json:"altstack,omitempty"`
+	Failed   bool     `json:"failed,omitempty"`
+}
+
+// EvaluateScriptResult models the data returned from the evaluatescript command. This is an extension for pod.
+type EvaluateScriptResult struct {
+	Valid        bool                 `json:"valid"`
+	Error        string               `json:"error,omitempty"`
+	FailedOpcode string               `json:"failedopcode,omitempty"`
+	Steps        []EvaluateScriptStep `json:"steps"`
+}
+
+// GetSnapshotResult models the data returned from the getsnapshot command. This is an extension for pod.
+type GetSnapshotResult struct {
+	Snapshot string `json:"snapshot"`
+	Height   int32  `json:"height"`
+	Hash     string `json:"hash"`
+	Expires  int64  `json:"expires"`
+}
+
+// SyncStageResult models the progress of a stage of the sync in the result of the getsyncstatus command. This is an
+// extension for pod.
+type SyncStageResult struct {
+	Name     string  `json:"name"`
+	Progress float64 `json:"progress"`
+}
+
+// GetSyncStatusResult models the data returned from the getsyncstatus command. This is an extension for pod.
+type GetSyncStatusResult struct {
+	Stage           string            `json:"stage"`
+	SyncPeer        int32             `json:"syncpeer"`
+	Blocks          int32             `json:"blocks"`
+	Headers         int32             `json:"headers"`
+	PeerBlocks      int32             `json:"peerblocks"`
+	Stages          []SyncStageResult `json:"stages"`
+	BlocksPerSecond float64           `json:"blockspersecond"`
+	BytesPerSecond  float64           `json:"bytespersecond"`
+	ETA             int64             `json:"eta"`
+}
+
+// DumpAddrManResult models the data returned from the dumpaddrman command. This is an extension for pod.
+type DumpAddrManResult struct {
+	Filename  string `json:"filename"`
+	Addresses int    `json:"addresses"`
+}
+
+// LoadAddrManResult models the data returned from the loadaddrman command. This is an extension for pod.
+type LoadAddrManResult struct {
+	Added     int `json:"added"`
+	Addresses int `json:"addresses"`
+}
+
+// UTXOSetSnapshotResult models the data returned from the dumputxoset and loadutxoset commands. This is an extension
+// for pod.
+type UTXOSetSnapshotResult struct {
+	Filename string `json:"filename"`
+	Hash     string `json:"hash"`
+	Height   int32  `json:"height"`
+	UTXOHash string `json:"utxohash"`
+	Coins    uint64 `json:"coins"`
+}
+
+// GetAddressBalanceResult models the data returned from the getaddressbalance command. The amounts are in satoshi.
+// This is an extension for pod.
+type GetAddressBalanceResult struct {
+	Balance     int64 `json:"balance"`
+	Received    int64 `json:"received"`
+	Unconfirmed int64 `json:"unconfirmed"`
+}
+
+// GetAddressUtxosResult models an unspent output in the result of the getaddressutxos command. This is an extension
+// for pod.
+type GetAddressUtxosResult struct {
+	Address       string `json:"address"`
+	TxID          string `json:"txid"`
+	OutputIndex   uint32 `json:"outputIndex"`
+	Script        string `json:"script"`
+	Satoshis      int64  `json:"satoshis"`
+	Height        int32  `json:"height"`
+	Confirmations int64  `json:"confirmations"`
+}
+
+// GetSpentInfoResult models the data returned from the getspentinfo command, the transaction input that spends the
+// requested output and the height of its block. This is an extension for pod.
+type GetSpentInfoResult struct {
+	TxID   string `json:"txid"`
+	Index  uint32 `json:"index"`
+	Height int32  `json:"height"`
+}
+
+// GenerateBlockResult models the data returned from the generateblock command.
+type GenerateBlockResult struct {
+	Hash string `json:"hash"`
+}
+
+// GetBlockSubsidyResult models the data returned from the getblocksubsidy command, the coinbase reward of a block at a
+// height mined with an algorithm, excluding fees, and how it is split on the hard fork activation block. This is an
+// extension for pod.
+type GetBlockSubsidyResult struct {
+	Height          int32   `json:"height"`
+	Algo            string  `json:"algo"`
+	Fork            int     `json:"fork"`
+	Subsidy         float64 `json:"subsidy"`
+	Miner           float64 `json:"miner"`
+	Payees          float64 `json:"payees,omitempty"`
+	Core            float64 `json:"core,omitempty"`
+	HalvingInterval int32   `json:"halvinginterval,omitempty"`
+	NextHalving     int32   `json:"nexthalving,omitempty"`
+}
+
+// GetAddrManInfoResult models the data returned from the getaddrmaninfo command. This is an extension for pod.
+type GetAddrManInfoResult struct {
+	New             int   `json:"new"`
+	Tried           int   `json:"tried"`
+	Total           int   `json:"total"`
+	NewBucketSize   int   `json:"newbucketsize"`
+	TriedBucketSize int   `json:"triedbucketsize"`
+	NewBuckets      []int `json:"newbuckets"`
+	TriedBuckets    []int `json:"triedbuckets"`
+}
+
+// AuditLogEntry models a state-changing RPC recorded in the audit log and returned by the getauditlog command. This is
+// an extension for pod.
+type AuditLogEntry struct {
+	Time   int64             `json:"time"`
+	Server string            `json:"server"`
+	User   string            `json:"user"`
+	Source string            `json:"source"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	Error  string            `json:"error,omitempty"`
+}
@@ -1,5 +1,41 @@
 package btcjson
 
+// CreateMultisigAccountCmd defines the createmultisigaccount JSON-RPC command.
+type CreateMultisigAccountCmd struct {
+	Account   string
+	NRequired int
+	Keys      []string
+}
+
+// NewCreateMultisigAccountCmd returns a new instance which can be used to issue a createmultisigaccount JSON-RPC
+// command.
+func NewCreateMultisigAccountCmd(account string, nRequired int, keys []string) *CreateMultisigAccountCmd {
+	return &CreateMultisigAccountCmd{
+		Account:   account,
+		NRequired: nRequired,
+		Keys:      keys,
+	}
+}
+
+// CreateMultisigPSBTCmd defines the createmultisigpsbt JSON-RPC command.
+type CreateMultisigPSBTCmd struct {
+	Account string
+	Amounts map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	FeeRate *float64           // In DUO per kilobyte
+}
+
+// NewCreateMultisigPSBTCmd returns a new instance which can be used to issue a createmultisigpsbt JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewCreateMultisigPSBTCmd(account string, amounts map[string]float64, feeRate *float64) *CreateMultisigPSBTCmd {
+	return &CreateMultisigPSBTCmd{
+		Account: account,
+		Amounts: amounts,
+		FeeRate: feeRate,
+	}
+}
+
 // CreateNewAccountCmd defines the createnewaccount JSON-RPC command.
 type CreateNewAccountCmd struct {
 	Account string
@@ -12,6 +48,50 @@ func NewCreateNewAccountCmd(account string) *CreateNewAccountCmd {
 	}
 }
 
+// CreatePaymentRequestCmd defines the createpaymentrequest JSON-RPC command.
+type CreatePaymentRequestCmd struct {
+	Amount  float64 // In DUO
+	Label   *string
+	Message *string
+	Expiry  *int64 `jsonrpcdefault:"86400"` // In seconds
+}
+
+// NewCreatePaymentRequestCmd returns a new instance which can be used to issue a createpaymentrequest JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewCreatePaymentRequestCmd(amount float64, label, message *string, expiry *int64) *CreatePaymentRequestCmd {
+	return &CreatePaymentRequestCmd{
+		Amount:  amount,
+		Label:   label,
+		Message: message,
+		Expiry:  expiry,
+	}
+}
+
+// CreateSigningDevicePSBTCmd defines the createsigningdevicepsbt JSON-RPC command.
+type CreateSigningDevicePSBTCmd struct {
+	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	FromAccount *string            `jsonrpcdefault:"\"default\""`
+	MinConf     *int               `jsonrpcdefault:"1"`
+	FeeRate     *float64           // In DUO per kilobyte
+}
+
+// NewCreateSigningDevicePSBTCmd returns a new instance which can be used to issue a createsigningdevicepsbt JSON-RPC
+// command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewCreateSigningDevicePSBTCmd(amounts map[string]float64, fromAccount *string, minConf *int,
+	feeRate *float64) *CreateSigningDevicePSBTCmd {
+	return &CreateSigningDevicePSBTCmd{
+		Amounts:     amounts,
+		FromAccount: fromAccount,
+		MinConf:     minConf,
+		FeeRate:     feeRate,
+	}
+}
+
 // DumpWalletCmd defines the dumpwallet JSON-RPC command.
 type DumpWalletCmd struct {
 	Filename string
@@ -24,6 +104,74 @@ func NewDumpWalletCmd(filename string) *DumpWalletCmd {
 	}
 }
 
+// EncryptWalletDBCmd defines the encryptwalletdb JSON-RPC command.
+type EncryptWalletDBCmd struct {
+	Passphrase string
+}
+
+// NewEncryptWalletDBCmd returns a new instance which can be used to issue an encryptwalletdb JSON-RPC command.
+func NewEncryptWalletDBCmd(passphrase string) *EncryptWalletDBCmd {
+	return &EncryptWalletDBCmd{
+		Passphrase: passphrase,
+	}
+}
+
+// GetAccountXPubCmd defines the getaccountxpub JSON-RPC command.
+type GetAccountXPubCmd struct {
+	Account string
+}
+
+// NewGetAccountXPubCmd returns a new instance which can be used to issue a getaccountxpub JSON-RPC command.
+func NewGetAccountXPubCmd(account string) *GetAccountXPubCmd {
+	return &GetAccountXPubCmd{
+		Account: account,
+	}
+}
+
+// GetNewMultisigAddressCmd defines the getnewmultisigaddress JSON-RPC command.
+type GetNewMultisigAddressCmd struct {
+	Account string
+}
+
+// NewGetNewMultisigAddressCmd returns a new instance which can be used to issue a getnewmultisigaddress JSON-RPC
+// command.
+func NewGetNewMultisigAddressCmd(account string) *GetNewMultisigAddressCmd {
+	return &GetNewMultisigAddressCmd{
+		Account: account,
+	}
+}
+
+// GetPrivacyReportCmd defines the getprivacyreport JSON-RPC command.
+type GetPrivacyReportCmd struct{}
+
+// NewGetPrivacyReportCmd returns a new instance which can be used to issue a getprivacyreport JSON-RPC command.
+func NewGetPrivacyReportCmd() *GetPrivacyReportCmd {
+	return &GetPrivacyReportCmd{}
+}
+
+// GetReconciliationCmd defines the getreconciliation JSON-RPC command.
+type GetReconciliationCmd struct{}
+
+// NewGetReconciliationCmd returns a new instance which can be used to issue a getreconciliation JSON-RPC command.
+func NewGetReconciliationCmd() *GetReconciliationCmd {
+	return &GetReconciliationCmd{}
+}
+
+// GetWalletBalancesCmd defines the getwalletbalances JSON-RPC command.
+type GetWalletBalancesCmd struct {
+	Account *string `jsonrpcdefault:"\"*\""`
+}
+
+// NewGetWalletBalancesCmd returns a new instance which can be used to issue a getwalletbalances JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewGetWalletBalancesCmd(account *string) *GetWalletBalancesCmd {
+	return &GetWalletBalancesCmd{
+		Account: account,
+	}
+}
+
 // ImportAddressCmd defines the importaddress JSON-RPC command.
 type ImportAddressCmd struct {
 	Address string
@@ -40,6 +188,18 @@ func NewImportAddressCmd(address string, account string, rescan *bool) *ImportAd
 	}
 }
 
+// ImportMultisigPSBTCmd defines the importmultisigpsbt JSON-RPC command.
+type ImportMultisigPSBTCmd struct {
+	PSBT string
+}
+
+// NewImportMultisigPSBTCmd returns a new instance which can be used to issue an importmultisigpsbt JSON-RPC command.
+func NewImportMultisigPSBTCmd(psbt string) *ImportMultisigPSBTCmd {
+	return &ImportMultisigPSBTCmd{
+		PSBT: psbt,
+	}
+}
+
 // ImportPubKeyCmd defines the importpubkey JSON-RPC command.
 type ImportPubKeyCmd struct {
 	PubKey string
@@ -66,6 +226,64 @@ func NewImportWalletCmd(filename string) *ImportWalletCmd {
 	}
 }
 
+// ListMultisigAccountsCmd defines the listmultisigaccounts JSON-RPC command.
+type ListMultisigAccountsCmd struct{}
+
+// NewListMultisigAccountsCmd returns a new instance which can be used to issue a listmultisigaccounts JSON-RPC command.
+func NewListMultisigAccountsCmd() *ListMultisigAccountsCmd {
+	return &ListMultisigAccountsCmd{}
+}
+
+// ListMultisigPSBTsCmd defines the listmultisigpsbts JSON-RPC command.
+type ListMultisigPSBTsCmd struct{}
+
+// NewListMultisigPSBTsCmd returns a new instance which can be used to issue a listmultisigpsbts JSON-RPC command.
+func NewListMultisigPSBTsCmd() *ListMultisigPSBTsCmd {
+	return &ListMultisigPSBTsCmd{}
+}
+
+// ListPaymentRequestsCmd defines the listpaymentrequests JSON-RPC command.
+type ListPaymentRequestsCmd struct{}
+
+// NewListPaymentRequestsCmd returns a new instance which can be used to issue a listpaymentrequests JSON-RPC command.
+func NewListPaymentRequestsCmd() *ListPaymentRequestsCmd {
+	return &ListPaymentRequestsCmd{}
+}
+
+// PreviewSendCmd defines the previewsend JSON-RPC command.
+type PreviewSendCmd struct {
+	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	FromAccount *string            `jsonrpcdefault:"\"default\""`
+	MinConf     *int               `jsonrpcdefault:"1"`
+	FeeRate     *float64           // In DUO per kilobyte
+}
+
+// NewPreviewSendCmd returns a new instance which can be used to issue a previewsend JSON-RPC command.
+//
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+func NewPreviewSendCmd(amounts map[string]float64, fromAccount *string, minConf *int,
+	feeRate *float64) *PreviewSendCmd {
+	return &PreviewSendCmd{
+		Amounts:     amounts,
+		FromAccount: fromAccount,
+		MinConf:     minConf,
+		FeeRate:     feeRate,
+	}
+}
+
+// PublishPSBTCmd defines the publishpsbt JSON-RPC command.
+type PublishPSBTCmd struct {
+	PSBT string
+}
+
+// NewPublishPSBTCmd returns a new instance which can be used to issue a publishpsbt JSON-RPC command.
+func NewPublishPSBTCmd(psbt string) *PublishPSBTCmd {
+	return &PublishPSBTCmd{
+		PSBT: psbt,
+	}
+}
+
 // RenameAccountCmd defines the renameaccount JSON-RPC command.
 type RenameAccountCmd struct {
 	OldAccount string
@@ -79,14 +297,56 @@ func NewRenameAccountCmd(oldAccount, newAccount string) *RenameAccountCmd {
 		NewAccount: newAccount,
 	}
 }
+
+// RescanFromHeightCmd defines the rescanfromheight JSON-RPC command.
+type RescanFromHeightCmd struct {
+	Height int32
+}
+
+// NewRescanFromHeightCmd returns a new instance which can be used to issue a rescanfromheight JSON-RPC command.
+func NewRescanFromHeightCmd(height int32) *RescanFromHeightCmd {
+	return &RescanFromHeightCmd{
+		Height: height,
+	}
+}
+
+// SignMultisigPSBTCmd defines the signmultisigpsbt JSON-RPC command.
+type SignMultisigPSBTCmd struct {
+	PSBT string
+}
+
+// NewSignMultisigPSBTCmd returns a new instance which can be used to issue a signmultisigpsbt JSON-RPC command.
+func NewSignMultisigPSBTCmd(psbt string) *SignMultisigPSBTCmd {
+	return &SignMultisigPSBTCmd{
+		PSBT: psbt,
+	}
+}
 func init() {
 	// The commands in this file are only usable with a wallet server.
 	flags := UFWalletOnly
+	MustRegisterCmd("createmultisigaccount", (*CreateMultisigAccountCmd)(nil), flags)
+	MustRegisterCmd("createmultisigpsbt", (*CreateMultisigPSBTCmd)(nil), flags)
 	MustRegisterCmd("createnewaccount", (*CreateNewAccountCmd)(nil), flags)
+	MustRegisterCmd("createpaymentrequest", (*CreatePaymentRequestCmd)(nil), flags)
+	MustRegisterCmd("createsigningdevicepsbt", (*CreateSigningDevicePSBTCmd)(nil), flags)
 	MustRegisterCmd("dumpwallet", (*DumpWalletCmd)(nil), flags)
+	MustRegisterCmd("encryptwalletdb", (*EncryptWalletDBCmd)(nil), flags)
+	MustRegisterCmd("getaccountxpub", (*GetAccountXPubCmd)(nil), flags)
+	MustRegisterCmd("getnewmultisigaddress", (*GetNewMultisigAddressCmd)(nil), flags)
+	MustRegisterCmd("getprivacyreport", (*GetPrivacyReportCmd)(nil), flags)
+	MustRegisterCmd("getreconciliation", (*GetReconciliationCmd)(nil), flags)
+	MustRegisterCmd("getwalletbalances", (*GetWalletBalancesCmd)(nil), flags)
 	MustRegisterCmd("importaddress", (*ImportAddressCmd)(nil), flags)
+	MustRegisterCmd("importmultisigpsbt", (*ImportMultisigPSBTCmd)(nil), flags)
 	MustRegisterCmd("importpubkey", (*ImportPubKeyCmd)(nil), flags)
 	MustRegisterCmd("importwallet", (*ImportWalletCmd)(nil), flags)
+	MustRegisterCmd("listmultisigaccounts", (*ListMultisigAccountsCmd)(nil), flags)
+	MustRegisterCmd("listmultisigpsbts", (*ListMultisigPSBTsCmd)(nil), flags)
+	MustRegisterCmd("listpaymentrequests", (*ListPaymentRequestsCmd)(nil), flags)
+	MustRegisterCmd("previewsend", (*PreviewSendCmd)(nil), flags)
+	MustRegisterCmd("publishpsbt", (*PublishPSBTCmd)(nil), flags)
 	MustRegisterCmd("renameaccount", (*RenameAccountCmd)(nil), flags)
+	MustRegisterCmd("rescanfromheight", (*RescanFromHeightCmd)(nil), flags)
+	MustRegisterCmd("signmultisigpsbt", (*SignMultisigPSBTCmd)(nil), flags)
 
 }
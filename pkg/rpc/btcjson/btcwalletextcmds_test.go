@@ -23,6 +23,52 @@ func TestBtcWalletExtCmds(t *testing.T) {
 		marshalled   string
 		unmarshalled interface{}
 	}{
+		{
+			name: "createmultisigaccount",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createmultisigaccount", "acct", 2, []string{"xpub1", "xpub2"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCreateMultisigAccountCmd("acct", 2, []string{"xpub1", "xpub2"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createmultisigaccount","netparams":["acct",2,["xpub1","xpub2"]],"id":1}`,
+			unmarshalled: &btcjson.CreateMultisigAccountCmd{
+				Account:   "acct",
+				NRequired: 2,
+				Keys:      []string{"xpub1", "xpub2"},
+			},
+		},
+		{
+			name: "createmultisigpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createmultisigpsbt", "acct", `{"1Address":0.5}`)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewCreateMultisigPSBTCmd("acct", amounts, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createmultisigpsbt","netparams":["acct",{"1Address":0.5}],"id":1}`,
+			unmarshalled: &btcjson.CreateMultisigPSBTCmd{
+				Account: "acct",
+				Amounts: map[string]float64{"1Address": 0.5},
+			},
+		},
+		{
+			name: "createmultisigpsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createmultisigpsbt", "acct", `{"1Address":0.5}`, 0.0001)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewCreateMultisigPSBTCmd("acct", amounts, btcjson.Float64(0.0001))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createmultisigpsbt","netparams":["acct",{"1Address":0.5},0.0001],"id":1}`,
+			unmarshalled: &btcjson.CreateMultisigPSBTCmd{
+				Account: "acct",
+				Amounts: map[string]float64{"1Address": 0.5},
+				FeeRate: btcjson.Float64(0.0001),
+			},
+		},
 		{
 			name: "createnewaccount",
 			newCmd: func() (interface{}, error) {
@@ -36,6 +82,71 @@ func TestBtcWalletExtCmds(t *testing.T) {
 				Account: "acct",
 			},
 		},
+		{
+			name: "createpaymentrequest",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createpaymentrequest", 1.5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCreatePaymentRequestCmd(1.5, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createpaymentrequest","netparams":[1.5],"id":1}`,
+			unmarshalled: &btcjson.CreatePaymentRequestCmd{
+				Amount: 1.5,
+				Expiry: btcjson.Int64(86400),
+			},
+		},
+		{
+			name: "createpaymentrequest optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createpaymentrequest", 1.5, "invoice 7", "thanks", 3600)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCreatePaymentRequestCmd(1.5, btcjson.String("invoice 7"),
+					btcjson.String("thanks"), btcjson.Int64(3600))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createpaymentrequest","netparams":[1.5,"invoice 7","thanks",3600],"id":1}`,
+			unmarshalled: &btcjson.CreatePaymentRequestCmd{
+				Amount:  1.5,
+				Label:   btcjson.String("invoice 7"),
+				Message: btcjson.String("thanks"),
+				Expiry:  btcjson.Int64(3600),
+			},
+		},
+		{
+			name: "createsigningdevicepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createsigningdevicepsbt", `{"1Address":0.5}`)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewCreateSigningDevicePSBTCmd(amounts, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createsigningdevicepsbt","netparams":[{"1Address":0.5}],"id":1}`,
+			unmarshalled: &btcjson.CreateSigningDevicePSBTCmd{
+				Amounts:     map[string]float64{"1Address": 0.5},
+				FromAccount: btcjson.String("default"),
+				MinConf:     btcjson.Int(1),
+			},
+		},
+		{
+			name: "createsigningdevicepsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("createsigningdevicepsbt", `{"1Address":0.5}`, "acct", 0, 0.0001)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewCreateSigningDevicePSBTCmd(amounts, btcjson.String("acct"), btcjson.Int(0),
+					btcjson.Float64(0.0001))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"createsigningdevicepsbt","netparams":[{"1Address":0.5},"acct",0,0.0001],"id":1}`,
+			unmarshalled: &btcjson.CreateSigningDevicePSBTCmd{
+				Amounts:     map[string]float64{"1Address": 0.5},
+				FromAccount: btcjson.String("acct"),
+				MinConf:     btcjson.Int(0),
+				FeeRate:     btcjson.Float64(0.0001),
+			},
+		},
 		{
 			name: "dumpwallet",
 			newCmd: func() (interface{}, error) {
@@ -49,6 +160,93 @@ func TestBtcWalletExtCmds(t *testing.T) {
 				Filename: "filename",
 			},
 		},
+		{
+			name: "encryptwalletdb",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("encryptwalletdb", "pass")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEncryptWalletDBCmd("pass")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"encryptwalletdb","netparams":["pass"],"id":1}`,
+			unmarshalled: &btcjson.EncryptWalletDBCmd{
+				Passphrase: "pass",
+			},
+		},
+		{
+			name: "getaccountxpub",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getaccountxpub", "acct")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetAccountXPubCmd("acct")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getaccountxpub","netparams":["acct"],"id":1}`,
+			unmarshalled: &btcjson.GetAccountXPubCmd{
+				Account: "acct",
+			},
+		},
+		{
+			name: "getnewmultisigaddress",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getnewmultisigaddress", "acct")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetNewMultisigAddressCmd("acct")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getnewmultisigaddress","netparams":["acct"],"id":1}`,
+			unmarshalled: &btcjson.GetNewMultisigAddressCmd{
+				Account: "acct",
+			},
+		},
+		{
+			name: "getprivacyreport",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getprivacyreport")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetPrivacyReportCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getprivacyreport","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetPrivacyReportCmd{},
+		},
+		{
+			name: "getreconciliation",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getreconciliation")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetReconciliationCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getreconciliation","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetReconciliationCmd{},
+		},
+		{
+			name: "getwalletbalances",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getwalletbalances")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetWalletBalancesCmd(nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getwalletbalances","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetWalletBalancesCmd{
+				Account: btcjson.String("*"),
+			},
+		},
+		{
+			name: "getwalletbalances optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getwalletbalances", "acct")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetWalletBalancesCmd(btcjson.String("acct"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getwalletbalances","netparams":["acct"],"id":1}`,
+			unmarshalled: &btcjson.GetWalletBalancesCmd{
+				Account: btcjson.String("acct"),
+			},
+		},
 		{
 			name: "importaddress",
 			newCmd: func() (interface{}, error) {
@@ -78,6 +276,19 @@ func TestBtcWalletExtCmds(t *testing.T) {
 				Rescan:  btcjson.Bool(false),
 			},
 		},
+		{
+			name: "importmultisigpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("importmultisigpsbt", "cHNidP8=")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewImportMultisigPSBTCmd("cHNidP8=")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importmultisigpsbt","netparams":["cHNidP8="],"id":1}`,
+			unmarshalled: &btcjson.ImportMultisigPSBTCmd{
+				PSBT: "cHNidP8=",
+			},
+		},
 		{
 			name: "importpubkey",
 			newCmd: func() (interface{}, error) {
@@ -119,6 +330,86 @@ func TestBtcWalletExtCmds(t *testing.T) {
 				Filename: "filename",
 			},
 		},
+		{
+			name: "listmultisigaccounts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listmultisigaccounts")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListMultisigAccountsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listmultisigaccounts","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListMultisigAccountsCmd{},
+		},
+		{
+			name: "listmultisigpsbts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listmultisigpsbts")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListMultisigPSBTsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listmultisigpsbts","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListMultisigPSBTsCmd{},
+		},
+		{
+			name: "listpaymentrequests",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listpaymentrequests")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListPaymentRequestsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listpaymentrequests","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListPaymentRequestsCmd{},
+		},
+		{
+			name: "previewsend",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("previewsend", `{"1Address":0.5}`)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewPreviewSendCmd(amounts, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"previewsend","netparams":[{"1Address":0.5}],"id":1}`,
+			unmarshalled: &btcjson.PreviewSendCmd{
+				Amounts:     map[string]float64{"1Address": 0.5},
+				FromAccount: btcjson.String("default"),
+				MinConf:     btcjson.Int(1),
+			},
+		},
+		{
+			name: "previewsend optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("previewsend", `{"1Address":0.5}`, "acct", 0, 0.0001)
+			},
+			staticCmd: func() interface{} {
+				amounts := map[string]float64{"1Address": 0.5}
+				return btcjson.NewPreviewSendCmd(amounts, btcjson.String("acct"), btcjson.Int(0),
+					btcjson.Float64(0.0001))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"previewsend","netparams":[{"1Address":0.5},"acct",0,0.0001],"id":1}`,
+			unmarshalled: &btcjson.PreviewSendCmd{
+				Amounts:     map[string]float64{"1Address": 0.5},
+				FromAccount: btcjson.String("acct"),
+				MinConf:     btcjson.Int(0),
+				FeeRate:     btcjson.Float64(0.0001),
+			},
+		},
+		{
+			name: "publishpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("publishpsbt", "cHNidP8=")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewPublishPSBTCmd("cHNidP8=")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"publishpsbt","netparams":["cHNidP8="],"id":1}`,
+			unmarshalled: &btcjson.PublishPSBTCmd{
+				PSBT: "cHNidP8=",
+			},
+		},
 		{
 			name: "renameaccount",
 			newCmd: func() (interface{}, error) {
@@ -133,6 +424,32 @@ func TestBtcWalletExtCmds(t *testing.T) {
 				NewAccount: "newacct",
 			},
 		},
+		{
+			name: "rescanfromheight",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("rescanfromheight", 1000)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewRescanFromHeightCmd(1000)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"rescanfromheight","netparams":[1000],"id":1}`,
+			unmarshalled: &btcjson.RescanFromHeightCmd{
+				Height: 1000,
+			},
+		},
+		{
+			name: "signmultisigpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("signmultisigpsbt", "cHNidP8=")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSignMultisigPSBTCmd("cHNidP8=")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"signmultisigpsbt","netparams":["cHNidP8="],"id":1}`,
+			unmarshalled: &btcjson.SignMultisigPSBTCmd{
+				PSBT: "cHNidP8=",
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
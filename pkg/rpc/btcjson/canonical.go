@@ -0,0 +1,167 @@
+package btcjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// canonicalDecimals is the least number of decimal places floating point numbers are given in canonical JSON, which
+// is the precision of amounts.
+const canonicalDecimals = 8
+
+// CanonicalJSON marshals v to JSON that is the same for the same value every time, so that it can be hashed or diffed:
+// the keys of every object are sorted, and floating point numbers, such as amounts, are encoded as strings in fixed
+// point notation with at least 8 decimal places.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	// The tree the value is decoded into is made of maps for objects, which are encoded with sorted keys. The numbers
+	// are kept as they were encoded, and those that come from floating point values are found by walking the value.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree interface{}
+	if err = dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return json.Marshal(canonicalize(reflect.ValueOf(v), tree))
+}
+
+// MarshalCanonicalResponse marshals the passed id, result, and RPCError to a JSON-RPC response byte slice like
+// MarshalResponse, with the result encoded as canonical JSON.
+func MarshalCanonicalResponse(id interface{}, result interface{}, rpcErr *RPCError) ([]byte, error) {
+	marshalledResult, err := CanonicalJSON(result)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	response, err := NewResponse(id, marshalledResult, rpcErr)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return json.Marshal(&response)
+}
+
+// marshalerType is the type of values that encode themselves, whose encoding is left as it is.
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// canonicalize replaces the numbers in the decoded JSON tree that were encoded from the floating point values in v with
+// fixed point strings, and returns the tree.
+func canonicalize(v reflect.Value, tree interface{}) interface{} {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return tree
+		}
+		if v.Type().Implements(marshalerType) {
+			return tree
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Type().Implements(marshalerType) ||
+		(v.CanAddr() && v.Addr().Type().Implements(marshalerType)) {
+		return tree
+	}
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if _, ok := tree.(json.Number); ok {
+			return formatCanonicalFloat(v.Float(), v.Type().Bits())
+		}
+	case reflect.Struct:
+		if obj, ok := tree.(map[string]interface{}); ok {
+			canonicalizeStruct(v, obj)
+		}
+	case reflect.Map:
+		obj, ok := tree.(map[string]interface{})
+		if !ok {
+			break
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			key, ok := mapKey(iter.Key())
+			if !ok {
+				continue
+			}
+			if elem, ok := obj[key]; ok {
+				obj[key] = canonicalize(iter.Value(), elem)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		arr, ok := tree.([]interface{})
+		if !ok || len(arr) != v.Len() {
+			break
+		}
+		for i := range arr {
+			arr[i] = canonicalize(v.Index(i), arr[i])
+		}
+	}
+	return tree
+}
+
+// canonicalizeStruct canonicalizes the members of the decoded object for the fields of the struct they were encoded
+// from, including those of embedded structs.
+func canonicalizeStruct(v reflect.Value, obj map[string]interface{}) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			fv := v.Field(i)
+			if fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				canonicalizeStruct(fv, obj)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if elem, ok := obj[name]; ok {
+			obj[name] = canonicalize(v.Field(i), elem)
+		}
+	}
+}
+
+// mapKey returns the object key a map key is encoded as, if it is a string or an integer.
+func mapKey(k reflect.Value) (string, bool) {
+	switch k.Kind() {
+	case reflect.String:
+		return k.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), true
+	}
+	return "", false
+}
+
+// formatCanonicalFloat formats f in fixed point notation with at least canonicalDecimals decimal places, and more
+// when they are needed to keep its exact value.
+func formatCanonicalFloat(f float64, bits int) string {
+	s := strconv.FormatFloat(f, 'f', -1, bits)
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		s += "."
+		i = len(s) - 1
+	}
+	if decimals := len(s) - i - 1; decimals < canonicalDecimals {
+		s += strings.Repeat("0", canonicalDecimals-decimals)
+	}
+	return s
+}
@@ -0,0 +1,66 @@
+package btcjson_test
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// TestCanonicalJSON tests that canonical JSON sorts the keys of objects and encodes floating point numbers as fixed
+// point strings, while leaving other values as they are encoded normally.
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		expect string
+	}{
+		{
+			name: "struct fields",
+			value: &btcjson.GetAddressBalanceResult{
+				Balance:     5,
+				Received:    7,
+				Unconfirmed: -2,
+			},
+			expect: `{"balance":5,"received":7,"unconfirmed":-2}`,
+		},
+		{
+			name: "amounts",
+			value: btcjson.ListUnspentResult{
+				TxID:          "123",
+				Vout:          1,
+				Address:       "1Address",
+				ScriptPubKey:  "76a9",
+				Amount:        1,
+				Confirmations: 6,
+				Spendable:     true,
+			},
+			expect: `{"account":"","address":"1Address","amount":"1.00000000","confirmations":6,"scriptPubKey":"76a9",` +
+				`"spendable":true,"txid":"123","vout":1}`,
+		},
+		{
+			name: "nested maps and slices",
+			value: map[string]interface{}{
+				"z": []float64{0.5, 0.000000001},
+				"a": map[string]float64{"y": 12.25, "b": 0},
+				"m": []int{3, 1, 2},
+			},
+			expect: `{"a":{"b":"0.00000000","y":"12.25000000"},"m":[3,1,2],"z":["0.50000000","0.000000001"]}`,
+		},
+		{
+			name:   "pointer to float",
+			value:  []*float64{btcjson.Float64(2.5), nil},
+			expect: `["2.50000000",null]`,
+		},
+	}
+	t.Logf("Running %d tests", len(tests))
+	for i, test := range tests {
+		b, err := btcjson.CanonicalJSON(test.value)
+		if err != nil {
+			t.Errorf("Test #%d (%s) unexpected error: %v", i, test.name, err)
+			continue
+		}
+		if string(b) != test.expect {
+			t.Errorf("Test #%d (%s) got %s, want %s", i, test.name, b, test.expect)
+		}
+	}
+}
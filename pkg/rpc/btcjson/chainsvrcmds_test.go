@@ -35,6 +35,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"addnode","netparams":["127.0.0.1","remove"],"id":1}`,
 			unmarshalled: &btcjson.AddNodeCmd{Addr: "127.0.0.1", SubCmd: btcjson.ANRemove},
 		},
+		{
+			name: "clearbanned",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("clearbanned")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewClearBannedCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"clearbanned","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ClearBannedCmd{},
+		},
 		{
 			name: "createrawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -96,6 +107,34 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"decodescript","netparams":["00"],"id":1}`,
 			unmarshalled: &btcjson.DecodeScriptCmd{HexScript: "00"},
 		},
+		{
+			name: "estimatesmartfee",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("estimatesmartfee", 6)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEstimateSmartFeeCmd(6, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"estimatesmartfee","netparams":[6],"id":1}`,
+			unmarshalled: &btcjson.EstimateSmartFeeCmd{
+				ConfTarget:   6,
+				EstimateMode: btcjson.NewEstimateSmartFeeMode(btcjson.EstimateModeUnset),
+			},
+		},
+		{
+			name: "estimatesmartfee optional estimatemode",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("estimatesmartfee", 6, "ECONOMICAL")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewEstimateSmartFeeCmd(6, btcjson.NewEstimateSmartFeeMode(btcjson.EstimateModeEconomical))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"estimatesmartfee","netparams":[6,"ECONOMICAL"],"id":1}`,
+			unmarshalled: &btcjson.EstimateSmartFeeCmd{
+				ConfTarget:   6,
+				EstimateMode: btcjson.NewEstimateSmartFeeMode(btcjson.EstimateModeEconomical),
+			},
+		},
 		{
 			name: "getaddednodeinfo",
 			newCmd: func() (interface{}, error) {
@@ -201,6 +240,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getblockcount","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetBlockCountCmd{},
 		},
+		{
+			name: "getblockcount snapshot",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockcount", "0123abcd")
+			},
+			staticCmd: func() interface{} {
+				return &btcjson.GetBlockCountCmd{Snapshot: btcjson.String("0123abcd")}
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getblockcount","netparams":["0123abcd"],"id":1}`,
+			unmarshalled: &btcjson.GetBlockCountCmd{Snapshot: btcjson.String("0123abcd")},
+		},
 		{
 			name: "getblockhash",
 			newCmd: func() (interface{}, error) {
@@ -212,6 +262,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getblockhash","netparams":[123],"id":1}`,
 			unmarshalled: &btcjson.GetBlockHashCmd{Index: 123},
 		},
+		{
+			name: "getblockhash snapshot",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockhash", 123, "0123abcd")
+			},
+			staticCmd: func() interface{} {
+				return &btcjson.GetBlockHashCmd{Index: 123, Snapshot: btcjson.String("0123abcd")}
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getblockhash","netparams":[123,"0123abcd"],"id":1}`,
+			unmarshalled: &btcjson.GetBlockHashCmd{Index: 123, Snapshot: btcjson.String("0123abcd")},
+		},
 		{
 			name: "getblockheader",
 			newCmd: func() (interface{}, error) {
@@ -309,6 +370,56 @@ func TestChainSvrCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "getblocktemplate optional - template request with algo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblocktemplate", `{"mode":"template","capabilities":["longpoll"],"algo":"scrypt"}`)
+			},
+			staticCmd: func() interface{} {
+				template := btcjson.TemplateRequest{
+					Mode:         "template",
+					Capabilities: []string{"longpoll"},
+					Algo:         "scrypt",
+				}
+				return btcjson.NewGetBlockTemplateCmd(&template)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblocktemplate","netparams":[{"mode":"template","capabilities":["longpoll"],"algo":"scrypt"}],"id":1}`,
+			unmarshalled: &btcjson.GetBlockTemplateCmd{
+				Request: &btcjson.TemplateRequest{
+					Mode:         "template",
+					Capabilities: []string{"longpoll"},
+					Algo:         "scrypt",
+				},
+			},
+		},
+		{
+			name: "getblockfilter",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockfilter", "123")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockFilterCmd("123", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockfilter","netparams":["123"],"id":1}`,
+			unmarshalled: &btcjson.GetBlockFilterCmd{
+				BlockHash:  "123",
+				FilterType: nil,
+			},
+		},
+		{
+			name: "getblockfilter optional filtertype",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getblockfilter", "123", "basic")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetBlockFilterCmd("123", btcjson.NewFilterTypeName(btcjson.FilterTypeBasic))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getblockfilter","netparams":["123","basic"],"id":1}`,
+			unmarshalled: &btcjson.GetBlockFilterCmd{
+				BlockHash:  "123",
+				FilterType: btcjson.NewFilterTypeName(btcjson.FilterTypeBasic),
+			},
+		},
 		{
 			name: "getcfilter",
 			newCmd: func() (interface{}, error) {
@@ -431,6 +542,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getmempoolinfo","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetMempoolInfoCmd{},
 		},
+		{
+			name: "getminerstats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getminerstats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetMinerStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getminerstats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetMinerStatsCmd{},
+		},
 		{
 			name: "getmininginfo",
 			newCmd: func() (interface{}, error) {
@@ -517,6 +639,17 @@ func TestChainSvrCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"getpeerinfo","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.GetPeerInfoCmd{},
 		},
+		{
+			name: "getpeerstats",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getpeerstats")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewGetPeerStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getpeerstats","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.GetPeerStatsCmd{},
+		},
 		{
 			name: "getrawmempool",
 			newCmd: func() (interface{}, error) {
@@ -586,6 +719,24 @@ func TestChainSvrCmds(t *testing.T) {
 				IncludeMempool: btcjson.Bool(true),
 			},
 		},
+		{
+			name: "gettxspendingprevout",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gettxspendingprevout", `[{"txid":"123","vout":1}]`)
+			},
+			staticCmd: func() interface{} {
+				outputs := []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				}
+				return btcjson.NewGetTxSpendingPrevOutCmd(outputs)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettxspendingprevout","netparams":[[{"txid":"123","vout":1}]],"id":1}`,
+			unmarshalled: &btcjson.GetTxSpendingPrevOutCmd{
+				Outputs: []btcjson.TransactionInput{
+					{Txid: "123", Vout: 1},
+				},
+			},
+		},
 		{
 			name: "gettxout optional",
 			newCmd: func() (interface{}, error) {
@@ -601,6 +752,24 @@ func TestChainSvrCmds(t *testing.T) {
 				IncludeMempool: btcjson.Bool(true),
 			},
 		},
+		{
+			name: "gettxout snapshot",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("gettxout", "123", 1, false, "0123abcd")
+			},
+			staticCmd: func() interface{} {
+				cmd := btcjson.NewGetTxOutCmd("123", 1, btcjson.Bool(false))
+				cmd.Snapshot = btcjson.String("0123abcd")
+				return cmd
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"gettxout","netparams":["123",1,false,"0123abcd"],"id":1}`,
+			unmarshalled: &btcjson.GetTxOutCmd{
+				Txid:           "123",
+				Vout:           1,
+				IncludeMempool: btcjson.Bool(false),
+				Snapshot:       btcjson.String("0123abcd"),
+			},
+		},
 		{
 			name: "gettxoutproof",
 			newCmd: func() (interface{}, error) {
@@ -668,6 +837,17 @@ func TestChainSvrCmds(t *testing.T) {
 				Data: btcjson.String("00112233"),
 			},
 		},
+		{
+			name: "getwork algo",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("getwork", "", "sha256d")
+			},
+			staticCmd: func() interface{} {
+				return &btcjson.GetWorkCmd{Data: btcjson.String(""), Algo: btcjson.String("sha256d")}
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getwork","netparams":["","sha256d"],"id":1}`,
+			unmarshalled: &btcjson.GetWorkCmd{Data: btcjson.String(""), Algo: btcjson.String("sha256d")},
+		},
 		{
 			name: "help",
 			newCmd: func() (interface{}, error) {
@@ -707,6 +887,17 @@ func TestChainSvrCmds(t *testing.T) {
 				BlockHash: "123",
 			},
 		},
+		{
+			name: "listbanned",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("listbanned")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewListBannedCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listbanned","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.ListBannedCmd{},
+		},
 		{
 			name: "ping",
 			newCmd: func() (interface{}, error) {
@@ -744,6 +935,33 @@ func TestChainSvrCmds(t *testing.T) {
 				BlockHash: "123",
 			},
 		},
+		{
+			name: "scantxoutset",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("scantxoutset", "status")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewScanTxOutSetCmd("status", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"scantxoutset","netparams":["status"],"id":1}`,
+			unmarshalled: &btcjson.ScanTxOutSetCmd{
+				Action: "status",
+			},
+		},
+		{
+			name: "scantxoutset with scan objects",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("scantxoutset", "start", `["addr(1Address)","raw(51)"]`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewScanTxOutSetCmd("start", &[]string{"addr(1Address)", "raw(51)"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"scantxoutset","netparams":["start",["addr(1Address)","raw(51)"]],"id":1}`,
+			unmarshalled: &btcjson.ScanTxOutSetCmd{
+				Action:      "start",
+				ScanObjects: &[]string{"addr(1Address)", "raw(51)"},
+			},
+		},
 		{
 			name: "searchrawtransactions",
 			newCmd: func() (interface{}, error) {
@@ -911,6 +1129,39 @@ func TestChainSvrCmds(t *testing.T) {
 				AllowHighFees: btcjson.Bool(false),
 			},
 		},
+		{
+			name: "setban",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setban", "192.0.2.0/24", btcjson.SBAdd)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetBanCmd("192.0.2.0/24", btcjson.SBAdd, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setban","netparams":["192.0.2.0/24","add"],"id":1}`,
+			unmarshalled: &btcjson.SetBanCmd{
+				Subnet:   "192.0.2.0/24",
+				SubCmd:   btcjson.SBAdd,
+				BanTime:  btcjson.Int64(0),
+				Absolute: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "setban optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("setban", "192.0.2.1", btcjson.SBAdd, 1600000000, true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSetBanCmd("192.0.2.1", btcjson.SBAdd, btcjson.Int64(1600000000),
+					btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setban","netparams":["192.0.2.1","add",1600000000,true],"id":1}`,
+			unmarshalled: &btcjson.SetBanCmd{
+				Subnet:   "192.0.2.1",
+				SubCmd:   btcjson.SBAdd,
+				BanTime:  btcjson.Int64(1600000000),
+				Absolute: btcjson.Bool(true),
+			},
+		},
 		{
 			name: "setgenerate",
 			newCmd: func() (interface{}, error) {
@@ -994,6 +1245,34 @@ func TestChainSvrCmds(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "testmempoolaccept",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("testmempoolaccept", []string{"1122"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewTestMempoolAcceptCmd([]string{"1122"}, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"testmempoolaccept","netparams":[["1122"]],"id":1}`,
+			unmarshalled: &btcjson.TestMempoolAcceptCmd{
+				RawTxns:    []string{"1122"},
+				MaxFeeRate: btcjson.Float64(0.1),
+			},
+		},
+		{
+			name: "testmempoolaccept optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("testmempoolaccept", []string{"1122"}, 0.5)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewTestMempoolAcceptCmd([]string{"1122"}, btcjson.Float64(0.5))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"testmempoolaccept","netparams":[["1122"],0.5],"id":1}`,
+			unmarshalled: &btcjson.TestMempoolAcceptCmd{
+				RawTxns:    []string{"1122"},
+				MaxFeeRate: btcjson.Float64(0.5),
+			},
+		},
 		{
 			name: "uptime",
 			newCmd: func() (interface{}, error) {
@@ -28,6 +28,14 @@ type DecodeScriptResult struct {
 	P2sh      string   `json:"p2sh,omitempty"`
 }
 
+// EstimateSmartFeeResult models the data returned from the estimatesmartfee command. The fee rate is missing when no
+// estimate could be made, and the errors say why.
+type EstimateSmartFeeResult struct {
+	FeeRate *float64 `json:"feerate,omitempty"`
+	Errors  []string `json:"errors,omitempty"`
+	Blocks  int64    `json:"blocks"`
+}
+
 // GetAddedNodeInfoResult models the data from the getaddednodeinfo command.
 type GetAddedNodeInfoResult struct {
 	AddedNode string                        `json:"addednode"`
@@ -57,6 +65,12 @@ type GetBlockChainInfoResult struct {
 	Bip9SoftForks        map[string]*Bip9SoftForkDescription `json:"bip9_softforks"`
 }
 
+// GetBlockFilterResult models the data returned from the getblockfilter command.
+type GetBlockFilterResult struct {
+	Filter string `json:"filter"`
+	Header string `json:"header"`
+}
+
 // GetBlockHeaderVerboseResult models the data from the getblockheader command when the verbose flag is set. When the
 // verbose flag is not set, getblockheader returns a hex-encoded string.
 type GetBlockHeaderVerboseResult struct {
@@ -109,6 +123,12 @@ type GetBlockTemplateResult struct {
 	// Block proposal from BIP 0023.
 	Capabilities  []string `json:"capabilities,omitempty"`
 	RejectReasion string   `json:"reject-reason,omitempty"`
+	// The payout policy of the node, and the address the coinbase pays to when it is included
+	PayoutPolicy  string `json:"payoutpolicy,omitempty"`
+	PayoutAddress string `json:"payoutaddress,omitempty"`
+	// The proof of work algorithm of the template, by name and ID
+	PowAlgo   string `json:"pow_algo"`
+	PowAlgoID uint32 `json:"pow_algo_id"`
 }
 
 // GetBlockTemplateResultAux models the coinbaseaux field of the getblocktemplate command.
@@ -176,6 +196,36 @@ type GetMempoolInfoResult struct {
 	Bytes int64 `json:"bytes"`
 }
 
+// GetMinerStatsResult models the data from the getminerstats command, the statistics of the kopach miner workers
+// taking work from the node.
+type GetMinerStatsResult struct {
+	Hashrate  float64                  `json:"hashrate"`
+	Hashes    uint64                   `json:"hashes"`
+	Solutions uint64                   `json:"solutions"`
+	Rejected  uint64                   `json:"rejected"`
+	Uptime    int64                    `json:"uptime"`
+	Algos     []MinerAlgoStatsResult   `json:"algos"`
+	Workers   []MinerWorkerStatsResult `json:"workers"`
+}
+
+// MinerWorkerStatsResult models the statistics of a single miner worker in a getminerstats result.
+type MinerWorkerStatsResult struct {
+	ID        string                 `json:"id"`
+	Hashrate  float64                `json:"hashrate"`
+	Hashes    uint64                 `json:"hashes"`
+	Solutions uint64                 `json:"solutions"`
+	Rejected  uint64                 `json:"rejected"`
+	LastSeen  int64                  `json:"lastseen"`
+	Algos     []MinerAlgoStatsResult `json:"algos"`
+}
+
+// MinerAlgoStatsResult models the hashes done on one algorithm in a getminerstats result.
+type MinerAlgoStatsResult struct {
+	Algo     string  `json:"algo"`
+	Hashrate float64 `json:"hashrate"`
+	Hashes   uint64  `json:"hashes"`
+}
+
 // GetMiningInfoResult models the data from the getmininginfo command.
 type GetMiningInfoResult struct {
 	Blocks              int64   `json:"blocks"`
@@ -198,6 +248,8 @@ type GetMiningInfoResult struct {
 	GenAlgo             string  `json:"genalgo"`
 	GenProcLimit        int32   `json:"genproclimit"`
 	HashesPerSec        int64   `json:"hashespersec"`
+	LongPollClients     int64   `json:"longpollclients"`
+	LongPollTemplates   int64   `json:"longpolltemplates"`
 	NetworkHashPS       int64   `json:"networkhashps"`
 	PooledTx            uint64  `json:"pooledtx"`
 	TestNet             bool    `json:"testnet"`
@@ -218,6 +270,8 @@ type GetMiningInfoResult0 struct {
 	Generate           bool    `json:"generate"`
 	GenProcLimit       int32   `json:"genproclimit"`
 	HashesPerSec       int64   `json:"hashespersec"`
+	LongPollClients    int64   `json:"longpollclients"`
+	LongPollTemplates  int64   `json:"longpolltemplates"`
 	NetworkHashPS      int64   `json:"networkhashps"`
 	PooledTx           uint64  `json:"pooledtx"`
 	TestNet            bool    `json:"testnet"`
@@ -245,31 +299,72 @@ type GetNetworkInfoResult struct {
 	IncrementalFee  float64                `json:"incrementalfee"`
 	LocalAddresses  []LocalAddressesResult `json:"localaddresses"`
 	Warnings        string                 `json:"warnings"`
+	Announcements   []string               `json:"announcements"`
+}
+
+// ListBannedResult models the data of a ban returned from the listbanned command, with the times in seconds since the
+// Unix epoch.
+type ListBannedResult struct {
+	Address     string `json:"address"`
+	BannedUntil int64  `json:"banned_until"`
+	BanCreated  int64  `json:"ban_created"`
+	BanReason   string `json:"ban_reason"`
 }
 
 // GetPeerInfoResult models the data returned from the getpeerinfo command.
 type GetPeerInfoResult struct {
-	ID             int32   `json:"id"`
-	Addr           string  `json:"addr"`
-	AddrLocal      string  `json:"addrlocal,omitempty"`
-	Services       string  `json:"services"`
-	RelayTxes      bool    `json:"relaytxes"`
-	LastSend       int64   `json:"lastsend"`
-	LastRecv       int64   `json:"lastrecv"`
-	BytesSent      uint64  `json:"bytessent"`
-	BytesRecv      uint64  `json:"bytesrecv"`
-	ConnTime       int64   `json:"conntime"`
-	TimeOffset     int64   `json:"timeoffset"`
-	PingTime       float64 `json:"pingtime"`
-	PingWait       float64 `json:"pingwait,omitempty"`
-	Version        uint32  `json:"version"`
-	SubVer         string  `json:"subver"`
-	Inbound        bool    `json:"inbound"`
-	StartingHeight int32   `json:"startingheight"`
-	CurrentHeight  int32   `json:"currentheight,omitempty"`
-	BanScore       int32   `json:"banscore"`
-	FeeFilter      int64   `json:"feefilter"`
-	SyncNode       bool    `json:"syncnode"`
+	ID               int32                 `json:"id"`
+	Addr             string                `json:"addr"`
+	AddrLocal        string                `json:"addrlocal,omitempty"`
+	Services         string                `json:"services"`
+	RelayTxes        bool                  `json:"relaytxes"`
+	LastSend         int64                 `json:"lastsend"`
+	LastRecv         int64                 `json:"lastrecv"`
+	BytesSent        uint64                `json:"bytessent"`
+	BytesRecv        uint64                `json:"bytesrecv"`
+	ConnTime         int64                 `json:"conntime"`
+	TimeOffset       int64                 `json:"timeoffset"`
+	PingTime         float64               `json:"pingtime"`
+	PingWait         float64               `json:"pingwait,omitempty"`
+	Version          uint32                `json:"version"`
+	SubVer           string                `json:"subver"`
+	Inbound          bool                  `json:"inbound"`
+	StartingHeight   int32                 `json:"startingheight"`
+	CurrentHeight    int32                 `json:"currentheight,omitempty"`
+	BanScore         int32                 `json:"banscore"`
+	FeeFilter        int64                 `json:"feefilter"`
+	SyncNode         bool                  `json:"syncnode"`
+	BlockServeTime   float64               `json:"blockservetime,omitempty"`
+	Permissions      []string              `json:"permissions"`
+	BytesSentPerMsg  map[string]uint64     `json:"bytessentpermsg"`
+	BytesRecvPerMsg  map[string]uint64     `json:"bytesrecvpermsg"`
+	PingPercentiles  PingPercentilesResult `json:"pingpercentiles"`
+	AddrRelayEnabled bool                  `json:"addrrelayenabled"`
+	AddrV2           bool                  `json:"addrv2"`
+	AddrProcessed    uint64                `json:"addrprocessed"`
+}
+
+// PingPercentilesResult models the distribution of the latest ping times, in microseconds, of one or more peers.
+type PingPercentilesResult struct {
+	Samples int     `json:"samples"`
+	Min     float64 `json:"min"`
+	P50     float64 `json:"p50"`
+	P90     float64 `json:"p90"`
+	Max     float64 `json:"max"`
+}
+
+// GetPeerStatsResult models the data returned from the getpeerstats command.
+type GetPeerStatsResult struct {
+	Peers           int                   `json:"peers"`
+	Inbound         int                   `json:"inbound"`
+	Outbound        int                   `json:"outbound"`
+	TotalBytesRecv  uint64                `json:"totalbytesrecv"`
+	TotalBytesSent  uint64                `json:"totalbytessent"`
+	BytesSentPerMsg map[string]uint64     `json:"bytessentpermsg"`
+	BytesRecvPerMsg map[string]uint64     `json:"bytesrecvpermsg"`
+	PingPercentiles PingPercentilesResult `json:"pingpercentiles"`
+	SyncPeer        int32                 `json:"syncpeer"`
+	TimeMillis      int64                 `json:"timemillis"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool command when the verbose flag is set. When
@@ -294,6 +389,14 @@ type GetTxOutResult struct {
 	Coinbase      bool               `json:"coinbase"`
 }
 
+// GetTxSpendingPrevOutResult models the data from the gettxspendingprevout command. SpendingTxid is only set when a
+// mempool transaction spends the output.
+type GetTxSpendingPrevOutResult struct {
+	Txid         string `json:"txid"`
+	Vout         uint32 `json:"vout"`
+	SpendingTxid string `json:"spendingtxid,omitempty"`
+}
+
 // GetWorkResult models the data from the getwork command.
 type GetWorkResult struct {
 	Data     string `json:"data"`
@@ -304,44 +407,46 @@ type GetWorkResult struct {
 type (
 	// InfoChainResult models the data returned by the chain server getinfo command.
 	InfoChainResult struct {
-		Version             int32   `json:"version"`
-		ProtocolVersion     int32   `json:"protocolversion"`
-		Blocks              int32   `json:"blocks"`
-		TimeOffset          int64   `json:"timeoffset"`
-		Connections         int32   `json:"connections"`
-		Proxy               string  `json:"proxy"`
-		PowAlgoID           uint32  `json:"pow_algo_id"`
-		PowAlgo             string  `json:"pow_algo"`
-		Difficulty          float64 `json:"difficulty"`
-		DifficultyBlake2b   float64 `json:"difficulty_blake2b"`
-		DifficultyBlake14lr float64 `json:"difficulty_blake14lr"`
-		DifficultyBlake2s   float64 `json:"difficulty_blake2s"`
-		DifficultyKeccak    float64 `json:"difficulty_keccak"`
-		DifficultyScrypt    float64 `json:"difficulty_scrypt"`
-		DifficultySHA256D   float64 `json:"difficulty_sha256d"`
-		DifficultySkein     float64 `json:"difficulty_skein"`
-		DifficultyStribog   float64 `json:"difficulty_stribog"`
-		DifficultyX11       float64 `json:"difficulty_x11"`
-		TestNet             bool    `json:"testnet"`
-		RelayFee            float64 `json:"relayfee"`
-		Errors              string  `json:"errors"`
+		Version             int32    `json:"version"`
+		ProtocolVersion     int32    `json:"protocolversion"`
+		Blocks              int32    `json:"blocks"`
+		TimeOffset          int64    `json:"timeoffset"`
+		Connections         int32    `json:"connections"`
+		Proxy               string   `json:"proxy"`
+		PowAlgoID           uint32   `json:"pow_algo_id"`
+		PowAlgo             string   `json:"pow_algo"`
+		Difficulty          float64  `json:"difficulty"`
+		DifficultyBlake2b   float64  `json:"difficulty_blake2b"`
+		DifficultyBlake14lr float64  `json:"difficulty_blake14lr"`
+		DifficultyBlake2s   float64  `json:"difficulty_blake2s"`
+		DifficultyKeccak    float64  `json:"difficulty_keccak"`
+		DifficultyScrypt    float64  `json:"difficulty_scrypt"`
+		DifficultySHA256D   float64  `json:"difficulty_sha256d"`
+		DifficultySkein     float64  `json:"difficulty_skein"`
+		DifficultyStribog   float64  `json:"difficulty_stribog"`
+		DifficultyX11       float64  `json:"difficulty_x11"`
+		TestNet             bool     `json:"testnet"`
+		RelayFee            float64  `json:"relayfee"`
+		Errors              string   `json:"errors"`
+		Announcements       []string `json:"announcements"`
 	}
 	// InfoChainResult0 is pre-hardfork getinfo response
 	InfoChainResult0 struct {
-		Version           int32   `json:"version"`
-		ProtocolVersion   int32   `json:"protocolversion"`
-		Blocks            int32   `json:"blocks"`
-		TimeOffset        int64   `json:"timeoffset"`
-		Connections       int32   `json:"connections"`
-		Proxy             string  `json:"proxy"`
-		PowAlgoID         uint32  `json:"pow_algo_id"`
-		PowAlgo           string  `json:"pow_algo"`
-		Difficulty        float64 `json:"difficulty"`
-		DifficultySHA256D float64 `json:"difficulty_sha256d"`
-		DifficultyScrypt  float64 `json:"difficulty_scrypt"`
-		TestNet           bool    `json:"testnet"`
-		RelayFee          float64 `json:"relayfee"`
-		Errors            string  `json:"errors"`
+		Version           int32    `json:"version"`
+		ProtocolVersion   int32    `json:"protocolversion"`
+		Blocks            int32    `json:"blocks"`
+		TimeOffset        int64    `json:"timeoffset"`
+		Connections       int32    `json:"connections"`
+		Proxy             string   `json:"proxy"`
+		PowAlgoID         uint32   `json:"pow_algo_id"`
+		PowAlgo           string   `json:"pow_algo"`
+		Difficulty        float64  `json:"difficulty"`
+		DifficultySHA256D float64  `json:"difficulty_sha256d"`
+		DifficultyScrypt  float64  `json:"difficulty_scrypt"`
+		TestNet           bool     `json:"testnet"`
+		RelayFee          float64  `json:"relayfee"`
+		Errors            string   `json:"errors"`
+		Announcements     []string `json:"announcements"`
 	}
 	// LocalAddressesResult models the localaddresses data from the getnetworkinfo command.
 	LocalAddressesResult struct {
@@ -362,6 +467,24 @@ type (
 		Addresses []string `json:"addresses,omitempty"`
 		Value     float64  `json:"value"`
 	}
+	// ScanTxOutSetResult models the data from the scantxoutset command.
+	ScanTxOutSetResult struct {
+		Success     bool                  `json:"success"`
+		TxOuts      uint64                `json:"txouts"`
+		Height      int64                 `json:"height"`
+		BestBlock   string                `json:"bestblock"`
+		Unspents    []ScanTxOutSetUnspent `json:"unspents"`
+		TotalAmount float64               `json:"total_amount"`
+	}
+	// ScanTxOutSetUnspent models an unspent output found by the scantxoutset command.
+	ScanTxOutSetUnspent struct {
+		TxID         string  `json:"txid"`
+		Vout         uint32  `json:"vout"`
+		ScriptPubKey string  `json:"scriptPubKey"`
+		Desc         string  `json:"desc"`
+		Amount       float64 `json:"amount"`
+		Height       int64   `json:"height"`
+	}
 	// ScriptPubKeyResult models the scriptPubKey data of a tx script. It is defined separately since it is used by
 	// multiple commands.
 	ScriptPubKeyResult struct {
@@ -401,6 +524,21 @@ type (
 			Status bool `json:"status"`
 		} `json:"reject"`
 	}
+	// TestMempoolAcceptFees models the fees of a transaction in the testmempoolaccept command.
+	TestMempoolAcceptFees struct {
+		Base float64 `json:"base"`
+	}
+	// TestMempoolAcceptResult models the data from the testmempoolaccept command. Replaces lists the transactions in
+	// the mempool that would be replaced (BIP125), and is an extension for pod.
+	TestMempoolAcceptResult struct {
+		Txid         string                 `json:"txid"`
+		Wtxid        string                 `json:"wtxid"`
+		Allowed      bool                   `json:"allowed"`
+		VSize        int64                  `json:"vsize,omitempty"`
+		Fees         *TestMempoolAcceptFees `json:"fees,omitempty"`
+		Replaces     []string               `json:"replaces,omitempty"`
+		RejectReason string                 `json:"reject-reason,omitempty"`
+	}
 	// TxRawDecodeResult models the data from the decoderawtransaction command.
 	TxRawDecodeResult struct {
 		Txid     string `json:"txid"`
@@ -44,6 +44,41 @@ func NewNotifyNewTransactionsCmd(verbose *bool) *NotifyNewTransactionsCmd {
 	}
 }
 
+// NotifyMempoolEventsCmd defines the notifymempoolevents JSON-RPC command.
+type NotifyMempoolEventsCmd struct{}
+
+// NewNotifyMempoolEventsCmd returns a new instance which can be used to issue a notifymempoolevents JSON-RPC command.
+func NewNotifyMempoolEventsCmd() *NotifyMempoolEventsCmd {
+	return &NotifyMempoolEventsCmd{}
+}
+
+// StopNotifyMempoolEventsCmd defines the stopnotifymempoolevents JSON-RPC command.
+type StopNotifyMempoolEventsCmd struct{}
+
+// NewStopNotifyMempoolEventsCmd returns a new instance which can be used to issue a stopnotifymempoolevents JSON-RPC
+// command.
+func NewStopNotifyMempoolEventsCmd() *StopNotifyMempoolEventsCmd {
+	return &StopNotifyMempoolEventsCmd{}
+}
+
+// NotifyTemplateEvictionsCmd defines the notifytemplateevictions JSON-RPC command.
+type NotifyTemplateEvictionsCmd struct{}
+
+// NewNotifyTemplateEvictionsCmd returns a new instance which can be used to issue a notifytemplateevictions JSON-RPC
+// command.
+func NewNotifyTemplateEvictionsCmd() *NotifyTemplateEvictionsCmd {
+	return &NotifyTemplateEvictionsCmd{}
+}
+
+// StopNotifyTemplateEvictionsCmd defines the stopnotifytemplateevictions JSON-RPC command.
+type StopNotifyTemplateEvictionsCmd struct{}
+
+// NewStopNotifyTemplateEvictionsCmd returns a new instance which can be used to issue a stopnotifytemplateevictions
+// JSON-RPC command.
+func NewStopNotifyTemplateEvictionsCmd() *StopNotifyTemplateEvictionsCmd {
+	return &StopNotifyTemplateEvictionsCmd{}
+}
+
 // SessionCmd defines the session JSON-RPC command.
 type SessionCmd struct{}
 
@@ -82,23 +117,34 @@ type OutPoint struct {
 	Index uint32 `json:"index"`
 }
 
-// LoadTxFilterCmd defines the loadtxfilter request parameters to load or reload a transaction filter.
+// LoadTxFilterCmd defines the loadtxfilter request parameters to load or reload a transaction filter. Scripts are
+// addr() and raw() output descriptors, hex public key scripts, or hex templates ending in * that match every script
+// starting with the bytes before it. Compact filters are notified with txmatch notifications holding only the matched
+// outputs and spent outpoints instead of the whole transactions. Adding to a filter without Compact leaves it as it is.
 //
 // NOTE: This is a pod extension ported from github.com/decred/dcrd/dcrjson and requires a websocket connection.
 type LoadTxFilterCmd struct {
 	Reload    bool
 	Addresses []string
 	OutPoints []OutPoint
+	Scripts   *[]string
+	Compact   *bool
 }
 
 // NewLoadTxFilterCmd returns a new instance which can be used to issue a loadtxfilter JSON-RPC command.
 //
+// The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the
+// default value.
+//
 // NOTE: This is a pod extension ported from github.com/decred/dcrd/dcrjson and requires a websocket connection.
-func NewLoadTxFilterCmd(reload bool, addresses []string, outPoints []OutPoint) *LoadTxFilterCmd {
+func NewLoadTxFilterCmd(reload bool, addresses []string, outPoints []OutPoint, scripts *[]string,
+	compact *bool) *LoadTxFilterCmd {
 	return &LoadTxFilterCmd{
 		Reload:    reload,
 		Addresses: addresses,
 		OutPoints: outPoints,
+		Scripts:   scripts,
+		Compact:   compact,
 	}
 }
 
@@ -185,20 +231,54 @@ type RescanBlocksCmd struct {
 func NewRescanBlocksCmd(blockHashes []string) *RescanBlocksCmd {
 	return &RescanBlocksCmd{BlockHashes: blockHashes}
 }
+
+// SearchRawTransactionsStreamCmd defines the searchrawtransactionsstream JSON-RPC command.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+type SearchRawTransactionsStreamCmd struct {
+	Address     string
+	Cursor      *int  `jsonrpcdefault:"0"`
+	BatchSize   *int  `jsonrpcdefault:"100"`
+	VinExtra    *int  `jsonrpcdefault:"0"`
+	Reverse     *bool `jsonrpcdefault:"false"`
+	FilterAddrs *[]string
+}
+
+// NewSearchRawTransactionsStreamCmd returns a new instance which can be used to issue a searchrawtransactionsstream
+// JSON-RPC command. The parameters which are pointers indicate they are optional. Passing nil for optional parameters
+// will use the default value.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func NewSearchRawTransactionsStreamCmd(address string, cursor, batchSize, vinExtra *int, reverse *bool,
+	filterAddrs *[]string) *SearchRawTransactionsStreamCmd {
+	return &SearchRawTransactionsStreamCmd{
+		Address:     address,
+		Cursor:      cursor,
+		BatchSize:   batchSize,
+		VinExtra:    vinExtra,
+		Reverse:     reverse,
+		FilterAddrs: filterAddrs,
+	}
+}
 func init() {
 	// The commands in this file are only usable by websockets.
 	flags := UFWebsocketOnly
 	MustRegisterCmd("authenticate", (*AuthenticateCmd)(nil), flags)
 	MustRegisterCmd("loadtxfilter", (*LoadTxFilterCmd)(nil), flags)
 	MustRegisterCmd("notifyblocks", (*NotifyBlocksCmd)(nil), flags)
+	MustRegisterCmd("notifymempoolevents", (*NotifyMempoolEventsCmd)(nil), flags)
 	MustRegisterCmd("notifynewtransactions", (*NotifyNewTransactionsCmd)(nil), flags)
 	MustRegisterCmd("notifyreceived", (*NotifyReceivedCmd)(nil), flags)
 	MustRegisterCmd("notifyspent", (*NotifySpentCmd)(nil), flags)
+	MustRegisterCmd("notifytemplateevictions", (*NotifyTemplateEvictionsCmd)(nil), flags)
 	MustRegisterCmd("session", (*SessionCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyblocks", (*StopNotifyBlocksCmd)(nil), flags)
+	MustRegisterCmd("stopnotifymempoolevents", (*StopNotifyMempoolEventsCmd)(nil), flags)
 	MustRegisterCmd("stopnotifynewtransactions", (*StopNotifyNewTransactionsCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyspent", (*StopNotifySpentCmd)(nil), flags)
 	MustRegisterCmd("stopnotifyreceived", (*StopNotifyReceivedCmd)(nil), flags)
+	MustRegisterCmd("stopnotifytemplateevictions", (*StopNotifyTemplateEvictionsCmd)(nil), flags)
 	MustRegisterCmd("rescan", (*RescanCmd)(nil), flags)
 	MustRegisterCmd("rescanblocks", (*RescanBlocksCmd)(nil), flags)
+	MustRegisterCmd("searchrawtransactionsstream", (*SearchRawTransactionsStreamCmd)(nil), flags)
 }
@@ -93,6 +93,50 @@ func TestChainSvrWsCmds(t *testing.T) {
 			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifynewtransactions","netparams":[],"id":1}`,
 			unmarshalled: &btcjson.StopNotifyNewTransactionsCmd{},
 		},
+		{
+			name: "notifymempoolevents",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifymempoolevents")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyMempoolEventsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifymempoolevents","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyMempoolEventsCmd{},
+		},
+		{
+			name: "stopnotifymempoolevents",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("stopnotifymempoolevents")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewStopNotifyMempoolEventsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifymempoolevents","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.StopNotifyMempoolEventsCmd{},
+		},
+		{
+			name: "notifytemplateevictions",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("notifytemplateevictions")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewNotifyTemplateEvictionsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"notifytemplateevictions","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.NotifyTemplateEvictionsCmd{},
+		},
+		{
+			name: "stopnotifytemplateevictions",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("stopnotifytemplateevictions")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewStopNotifyTemplateEvictionsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"stopnotifytemplateevictions","netparams":[],"id":1}`,
+			unmarshalled: &btcjson.StopNotifyTemplateEvictionsCmd{},
+		},
 		{
 			name: "notifyreceived",
 			newCmd: func() (interface{}, error) {
@@ -197,7 +241,7 @@ func TestChainSvrWsCmds(t *testing.T) {
 					Hash:  "0000000000000000000000000000000000000000000000000000000000000123",
 					Index: 0,
 				}}
-				return btcjson.NewLoadTxFilterCmd(false, addrs, ops)
+				return btcjson.NewLoadTxFilterCmd(false, addrs, ops, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"loadtxfilter","netparams":[false,["1Address"],[{"hash":"0000000000000000000000000000000000000000000000000000000000000123","index":0}]],"id":1}`,
 			unmarshalled: &btcjson.LoadTxFilterCmd{
@@ -206,6 +250,24 @@ func TestChainSvrWsCmds(t *testing.T) {
 				OutPoints: []btcjson.OutPoint{{Hash: "0000000000000000000000000000000000000000000000000000000000000123", Index: 0}},
 			},
 		},
+		{
+			name: "loadtxfilter scripts",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("loadtxfilter", true, `[]`, `[]`, `["raw(6a)","6a*"]`, true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewLoadTxFilterCmd(true, []string{}, []btcjson.OutPoint{},
+					&[]string{"raw(6a)", "6a*"}, btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"loadtxfilter","netparams":[true,[],[],["raw(6a)","6a*"],true],"id":1}`,
+			unmarshalled: &btcjson.LoadTxFilterCmd{
+				Reload:    true,
+				Addresses: []string{},
+				OutPoints: []btcjson.OutPoint{},
+				Scripts:   &[]string{"raw(6a)", "6a*"},
+				Compact:   btcjson.Bool(true),
+			},
+		},
 		{
 			name: "rescanblocks",
 			newCmd: func() (interface{}, error) {
@@ -220,6 +282,25 @@ func TestChainSvrWsCmds(t *testing.T) {
 				BlockHashes: []string{"0000000000000000000000000000000000000000000000000000000000000123"},
 			},
 		},
+		{
+			name: "searchrawtransactionsstream",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("searchrawtransactionsstream", "1Address", 200, 50)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewSearchRawTransactionsStreamCmd("1Address", btcjson.Int(200), btcjson.Int(50),
+					nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"searchrawtransactionsstream","netparams":["1Address",200,50],"id":1}`,
+			unmarshalled: &btcjson.SearchRawTransactionsStreamCmd{
+				Address:     "1Address",
+				Cursor:      btcjson.Int(200),
+				BatchSize:   btcjson.Int(50),
+				VinExtra:    btcjson.Int(0),
+				Reverse:     btcjson.Bool(false),
+				FilterAddrs: nil,
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
@@ -38,6 +38,18 @@ const (
 	// RelevantTxAcceptedNtfnMethod is the new method used for notifications from the chain server that inform a client
 	// that a transaction that matches the loaded filter was accepted by the mempool.
 	RelevantTxAcceptedNtfnMethod = "relevanttxaccepted"
+	// MempoolEventNtfnMethod is the method used for notifications from the chain server that a transaction submitted
+	// by a peer or an RPC client was accepted or rejected by the mempool.
+	MempoolEventNtfnMethod = "mempoolevent"
+	// TemplateEvictionNtfnMethod is the method used for notifications from the chain server that a transaction in
+	// block templates issued to miners was evicted or replaced in the mempool.
+	TemplateEvictionNtfnMethod = "templateeviction"
+	// TxMatchNtfnMethod is the method used for notifications from the chain server to clients with a compact
+	// transaction filter that a transaction in the mempool or a new block matches the filter.
+	TxMatchNtfnMethod = "txmatch"
+	// SearchRawTransactionsBatchNtfnMethod is the method used for notifications from the chain server carrying the
+	// next batch of transactions found by a searchrawtransactionsstream command.
+	SearchRawTransactionsBatchNtfnMethod = "searchrawtransactionsbatch"
 )
 
 // BlockConnectedNtfn defines the blockconnected JSON-RPC notification. NOTE: Deprecated. Use FilteredBlockConnectedNtfn
@@ -229,6 +241,94 @@ type RelevantTxAcceptedNtfn struct {
 func NewRelevantTxAcceptedNtfn(txHex string) *RelevantTxAcceptedNtfn {
 	return &RelevantTxAcceptedNtfn{Transaction: txHex}
 }
+
+// MatchedOutput is an output of a transaction that matched a transaction filter.
+type MatchedOutput struct {
+	Vout         uint32  `json:"vout"`
+	Amount       float64 `json:"amount"`
+	ScriptPubKey string  `json:"scriptpubkey"`
+}
+
+// TxMatchNtfn defines the txmatch JSON-RPC notification. Outputs are the outputs paying to the addresses and scripts of
+// the filter and Spends the outpoints of the filter the transaction spends. Height is -1 and BlockHash empty for a
+// transaction accepted into the mempool.
+type TxMatchNtfn struct {
+	TxID      string          `json:"txid"`
+	Height    int32           `json:"height"`
+	BlockHash string          `json:"blockhash,omitempty"`
+	Outputs   []MatchedOutput `json:"outputs"`
+	Spends    []OutPoint      `json:"spends"`
+}
+
+// NewTxMatchNtfn returns a new instance which can be used to issue a txmatch JSON-RPC notification.
+func NewTxMatchNtfn(txID string, height int32, blockHash string, outputs []MatchedOutput,
+	spends []OutPoint) *TxMatchNtfn {
+	return &TxMatchNtfn{
+		TxID:      txID,
+		Height:    height,
+		BlockHash: blockHash,
+		Outputs:   outputs,
+		Spends:    spends,
+	}
+}
+
+// MempoolEventNtfn defines the mempoolevent JSON-RPC notification. RejectCode and Reason are empty for accepted
+// transactions, and Peer is empty for transactions submitted over RPC.
+type MempoolEventNtfn struct {
+	TxID       string
+	Accepted   bool
+	RejectCode string
+	Reason     string
+	Peer       string
+}
+
+// NewMempoolEventNtfn returns a new instance which can be used to issue a mempoolevent JSON-RPC notification.
+func NewMempoolEventNtfn(txID string, accepted bool, rejectCode, reason, peer string) *MempoolEventNtfn {
+	return &MempoolEventNtfn{
+		TxID:       txID,
+		Accepted:   accepted,
+		RejectCode: rejectCode,
+		Reason:     reason,
+		Peer:       peer,
+	}
+}
+
+// TemplateEvictionNtfn defines the templateeviction JSON-RPC notification. ReplacedBy is the transaction whose
+// acceptance replaced the evicted one, and is empty when it was evicted for another reason. TemplateIDs are the IDs of
+// the issued block templates including the transaction, most recent first.
+type TemplateEvictionNtfn struct {
+	TxID        string
+	ReplacedBy  string
+	TemplateIDs []string
+}
+
+// NewTemplateEvictionNtfn returns a new instance which can be used to issue a templateeviction JSON-RPC notification.
+func NewTemplateEvictionNtfn(txID, replacedBy string, templateIDs []string) *TemplateEvictionNtfn {
+	return &TemplateEvictionNtfn{
+		TxID:        txID,
+		ReplacedBy:  replacedBy,
+		TemplateIDs: templateIDs,
+	}
+}
+
+// SearchRawTransactionsBatchNtfn defines the searchrawtransactionsbatch JSON-RPC notification. Cursor is the cursor to
+// pass to searchrawtransactionsstream to continue after the transactions in the batch.
+type SearchRawTransactionsBatchNtfn struct {
+	Address      string
+	Cursor       int
+	Transactions []SearchRawTransactionsResult
+}
+
+// NewSearchRawTransactionsBatchNtfn returns a new instance which can be used to issue a searchrawtransactionsbatch
+// JSON-RPC notification.
+func NewSearchRawTransactionsBatchNtfn(address string, cursor int,
+	transactions []SearchRawTransactionsResult) *SearchRawTransactionsBatchNtfn {
+	return &SearchRawTransactionsBatchNtfn{
+		Address:      address,
+		Cursor:       cursor,
+		Transactions: transactions,
+	}
+}
 func init() {
 	// The commands in this file are only usable by websockets and are notifications.
 	flags := UFWebsocketOnly | UFNotification
@@ -243,4 +343,8 @@ func init() {
 	MustRegisterCmd(TxAcceptedNtfnMethod, (*TxAcceptedNtfn)(nil), flags)
 	MustRegisterCmd(TxAcceptedVerboseNtfnMethod, (*TxAcceptedVerboseNtfn)(nil), flags)
 	MustRegisterCmd(RelevantTxAcceptedNtfnMethod, (*RelevantTxAcceptedNtfn)(nil), flags)
+	MustRegisterCmd(MempoolEventNtfnMethod, (*MempoolEventNtfn)(nil), flags)
+	MustRegisterCmd(TemplateEvictionNtfnMethod, (*TemplateEvictionNtfn)(nil), flags)
+	MustRegisterCmd(TxMatchNtfnMethod, (*TxMatchNtfn)(nil), flags)
+	MustRegisterCmd(SearchRawTransactionsBatchNtfnMethod, (*SearchRawTransactionsBatchNtfn)(nil), flags)
 }
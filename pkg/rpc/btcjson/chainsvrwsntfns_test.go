@@ -218,6 +218,75 @@ func TestChainSvrWsNtfns(t *testing.T) {
 				Transaction: "001122",
 			},
 		},
+		{
+			name: "txmatch",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("txmatch", "123", 100000, "456", `[{"vout":1,"amount":1.5,"scriptpubkey":"6a"}]`,
+					`[{"hash":"789","index":0}]`)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewTxMatchNtfn("123", 100000, "456",
+					[]btcjson.MatchedOutput{{Vout: 1, Amount: 1.5, ScriptPubKey: "6a"}},
+					[]btcjson.OutPoint{{Hash: "789", Index: 0}})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"txmatch","netparams":["123",100000,"456",[{"vout":1,"amount":1.5,"scriptpubkey":"6a"}],[{"hash":"789","index":0}]],"id":null}`,
+			unmarshalled: &btcjson.TxMatchNtfn{
+				TxID:      "123",
+				Height:    100000,
+				BlockHash: "456",
+				Outputs:   []btcjson.MatchedOutput{{Vout: 1, Amount: 1.5, ScriptPubKey: "6a"}},
+				Spends:    []btcjson.OutPoint{{Hash: "789", Index: 0}},
+			},
+		},
+		{
+			name: "mempoolevent",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("mempoolevent", "123", false, "REJECT_INSUFFICIENTFEE", "low fee", "1.2.3.4:11047")
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewMempoolEventNtfn("123", false, "REJECT_INSUFFICIENTFEE", "low fee", "1.2.3.4:11047")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"mempoolevent","netparams":["123",false,"REJECT_INSUFFICIENTFEE","low fee","1.2.3.4:11047"],"id":null}`,
+			unmarshalled: &btcjson.MempoolEventNtfn{
+				TxID:       "123",
+				Accepted:   false,
+				RejectCode: "REJECT_INSUFFICIENTFEE",
+				Reason:     "low fee",
+				Peer:       "1.2.3.4:11047",
+			},
+		},
+		{
+			name: "templateeviction",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("templateeviction", "123", "456", `["abc","def"]`)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewTemplateEvictionNtfn("123", "456", []string{"abc", "def"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"templateeviction","netparams":["123","456",["abc","def"]],"id":null}`,
+			unmarshalled: &btcjson.TemplateEvictionNtfn{
+				TxID:        "123",
+				ReplacedBy:  "456",
+				TemplateIDs: []string{"abc", "def"},
+			},
+		},
+		{
+			name: "searchrawtransactionsbatch",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("searchrawtransactionsbatch", "1Address", 1,
+					`[{"txid":"123","hash":"","size":"","vsize":"","version":1,"locktime":0,"vin":null,"vout":null}]`)
+			},
+			staticNtfn: func() interface{} {
+				txs := []btcjson.SearchRawTransactionsResult{{TxID: "123", Version: 1}}
+				return btcjson.NewSearchRawTransactionsBatchNtfn("1Address", 1, txs)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"searchrawtransactionsbatch","netparams":["1Address",1,[{"txid":"123","hash":"","size":"","vsize":"","version":1,"locktime":0,"vin":null,"vout":null}]],"id":null}`,
+			unmarshalled: &btcjson.SearchRawTransactionsBatchNtfn{
+				Address:      "1Address",
+				Cursor:       1,
+				Transactions: []btcjson.SearchRawTransactionsResult{{TxID: "123", Version: 1}},
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
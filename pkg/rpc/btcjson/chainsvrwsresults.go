@@ -12,3 +12,13 @@ type RescannedBlock struct {
 	Hash         string   `json:"hash"`
 	Transactions []string `json:"transactions"`
 }
+
+// SearchRawTransactionsStreamResult models the data from the searchrawtransactionsstream command, which is returned
+// once the transactions have been sent as searchrawtransactionsbatch notifications.
+//
+// NOTE: This is a pod extension.
+type SearchRawTransactionsStreamResult struct {
+	Cursor   int  `json:"cursor"`
+	Count    int  `json:"count"`
+	Complete bool `json:"complete"`
+}
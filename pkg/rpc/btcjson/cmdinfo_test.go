@@ -130,7 +130,7 @@ func TestMethodUsageText(t *testing.T) {
 		{
 			name:     "getblockcount",
 			method:   "getblockcount",
-			expected: "getblockcount",
+			expected: `getblockcount ("snapshot")`,
 		},
 		{
 			name:     "getblock",
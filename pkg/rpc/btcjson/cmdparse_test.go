@@ -359,7 +359,7 @@ func TestNewCmdErrors(t *testing.T) {
 		},
 		{
 			name:   "too many parameters to command with no optional",
-			method: "getblockcount",
+			method: "getbestblockhash",
 			args:   []interface{}{"123"},
 			err:    btcjson.BTCJSONError{ErrorCode: btcjson.ErrNumParams},
 		},
@@ -456,7 +456,7 @@ func TestUnmarshalCmdErrors(t *testing.T) {
 			name: "incorrect number of netparams",
 			request: btcjson.Request{
 				Jsonrpc: "1.0",
-				Method:  "getblockcount",
+				Method:  "getbestblockhash",
 				Params:  []json.RawMessage{[]byte(`"bogusparam"`)},
 				ID:      nil,
 			},
@@ -30,6 +30,18 @@ func NewAddWitnessAddressCmd(address string) *AddWitnessAddressCmd {
 	}
 }
 
+// CombinePSBTCmd defines the combinepsbt JSON-RPC command.
+type CombinePSBTCmd struct {
+	Txs []string
+}
+
+// NewCombinePSBTCmd returns a new instance which can be used to issue a combinepsbt JSON-RPC command.
+func NewCombinePSBTCmd(txs []string) *CombinePSBTCmd {
+	return &CombinePSBTCmd{
+		Txs: txs,
+	}
+}
+
 // CreateMultisigCmd defines the createmultisig JSON-RPC command.
 type CreateMultisigCmd struct {
 	NRequired int
@@ -44,6 +56,18 @@ func NewCreateMultisigCmd(nRequired int, keys []string) *CreateMultisigCmd {
 	}
 }
 
+// DecodePSBTCmd defines the decodepsbt JSON-RPC command.
+type DecodePSBTCmd struct {
+	PSBT string
+}
+
+// NewDecodePSBTCmd returns a new instance which can be used to issue a decodepsbt JSON-RPC command.
+func NewDecodePSBTCmd(psbt string) *DecodePSBTCmd {
+	return &DecodePSBTCmd{
+		PSBT: psbt,
+	}
+}
+
 // DropWalletHistoryCmd defines the restart JSON-RPC command.
 type DropWalletHistoryCmd struct{}
 
@@ -100,6 +124,21 @@ func NewEstimatePriorityCmd(numBlocks int64) *EstimatePriorityCmd {
 	}
 }
 
+// FinalizePSBTCmd defines the finalizepsbt JSON-RPC command.
+type FinalizePSBTCmd struct {
+	PSBT    string
+	Extract *bool `jsonrpcdefault:"true"`
+}
+
+// NewFinalizePSBTCmd returns a new instance which can be used to issue a finalizepsbt JSON-RPC command. The parameters
+// which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+func NewFinalizePSBTCmd(psbt string, extract *bool) *FinalizePSBTCmd {
+	return &FinalizePSBTCmd{
+		PSBT:    psbt,
+		Extract: extract,
+	}
+}
+
 // GetAccountCmd defines the getaccount JSON-RPC command.
 type GetAccountCmd struct {
 	Address string
@@ -453,10 +492,12 @@ type SendManyCmd struct {
 	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
 	MinConf     *int               `jsonrpcdefault:"1"`
 	Comment     *string
+	FeeRate     *float64 // In DUO per kilobyte
 }
 
 // NewSendManyCmd returns a new instance which can be used to issue a sendmany JSON-RPC command. The parameters which
-// are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
+// are pointers indicate they are optional. Passing nil for optional parameters will use the default value. The fee rate
+// is left to the wallet, set FeeRate on the command to choose it.
 func NewSendManyCmd(fromAccount string, amounts map[string]float64, minConf *int, comment *string) *SendManyCmd {
 	return &SendManyCmd{
 		FromAccount: fromAccount,
@@ -472,11 +513,12 @@ type SendToAddressCmd struct {
 	Amount    float64
 	Comment   *string
 	CommentTo *string
+	FeeRate   *float64 // In DUO per kilobyte
 }
 
 // NewSendToAddressCmd returns a new instance which can be used to issue a sendtoaddress JSON-RPC command. The
 // parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
-// value.
+// value. The fee rate is left to the wallet, set FeeRate on the command to choose it.
 func NewSendToAddressCmd(address string, amount float64, comment, commentTo *string) *SendToAddressCmd {
 	return &SendToAddressCmd{
 		Address:   address,
@@ -554,6 +596,39 @@ func NewSignRawTransactionCmd(hexEncodedTx string, inputs *[]RawTxInput, privKey
 	}
 }
 
+// WalletCreateFundedPSBTOpts are the options of the walletcreatefundedpsbt JSON-RPC command.
+type WalletCreateFundedPSBTOpts struct {
+	Account        *string  `json:"account,omitempty"`
+	MinConf        *int     `json:"minconf,omitempty"`
+	ChangeAddress  *string  `json:"changeAddress,omitempty"`
+	ChangePosition *int     `json:"changePosition,omitempty"`
+	LockUnspents   *bool    `json:"lockUnspents,omitempty"`
+	FeeRate        *float64 `json:"feeRate,omitempty"`
+}
+
+// WalletCreateFundedPSBTCmd defines the walletcreatefundedpsbt JSON-RPC command.
+type WalletCreateFundedPSBTCmd struct {
+	Inputs      []TransactionInput
+	Outputs     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	LockTime    *uint32            `jsonrpcdefault:"0"`
+	Options     *WalletCreateFundedPSBTOpts
+	Bip32Derivs *bool `jsonrpcdefault:"false"`
+}
+
+// NewWalletCreateFundedPSBTCmd returns a new instance which can be used to issue a walletcreatefundedpsbt JSON-RPC
+// command. The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use
+// the default value.
+func NewWalletCreateFundedPSBTCmd(inputs []TransactionInput, outputs map[string]float64, lockTime *uint32,
+	options *WalletCreateFundedPSBTOpts, bip32Derivs *bool) *WalletCreateFundedPSBTCmd {
+	return &WalletCreateFundedPSBTCmd{
+		Inputs:      inputs,
+		Outputs:     outputs,
+		LockTime:    lockTime,
+		Options:     options,
+		Bip32Derivs: bip32Derivs,
+	}
+}
+
 // WalletLockCmd defines the walletlock JSON-RPC command.
 type WalletLockCmd struct{}
 
@@ -590,17 +665,40 @@ func NewWalletPassphraseChangeCmd(oldPassphrase, newPassphrase string) *WalletPa
 		NewPassphrase: newPassphrase,
 	}
 }
+
+// WalletProcessPSBTCmd defines the walletprocesspsbt JSON-RPC command.
+type WalletProcessPSBTCmd struct {
+	PSBT        string
+	Sign        *bool   `jsonrpcdefault:"true"`
+	SighashType *string `jsonrpcdefault:"\"ALL\""`
+	Bip32Derivs *bool   `jsonrpcdefault:"false"`
+}
+
+// NewWalletProcessPSBTCmd returns a new instance which can be used to issue a walletprocesspsbt JSON-RPC command. The
+// parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default
+// value.
+func NewWalletProcessPSBTCmd(psbt string, sign *bool, sighashType *string, bip32Derivs *bool) *WalletProcessPSBTCmd {
+	return &WalletProcessPSBTCmd{
+		PSBT:        psbt,
+		Sign:        sign,
+		SighashType: sighashType,
+		Bip32Derivs: bip32Derivs,
+	}
+}
 func init() {
 	// The commands in this file are only usable with a wallet server.
 	flags := UFWalletOnly
 	MustRegisterCmd("addmultisigaddress", (*AddMultisigAddressCmd)(nil), flags)
 	MustRegisterCmd("addwitnessaddress", (*AddWitnessAddressCmd)(nil), flags)
+	MustRegisterCmd("combinepsbt", (*CombinePSBTCmd)(nil), flags)
 	MustRegisterCmd("createmultisig", (*CreateMultisigCmd)(nil), flags)
+	MustRegisterCmd("decodepsbt", (*DecodePSBTCmd)(nil), flags)
 	MustRegisterCmd("dropwallethistory", (*DropWalletHistoryCmd)(nil), flags)
 	MustRegisterCmd("dumpprivkey", (*DumpPrivKeyCmd)(nil), flags)
 	MustRegisterCmd("encryptwallet", (*EncryptWalletCmd)(nil), flags)
 	MustRegisterCmd("estimatefee", (*EstimateFeeCmd)(nil), flags)
 	MustRegisterCmd("estimatepriority", (*EstimatePriorityCmd)(nil), flags)
+	MustRegisterCmd("finalizepsbt", (*FinalizePSBTCmd)(nil), flags)
 	MustRegisterCmd("getaccount", (*GetAccountCmd)(nil), flags)
 	MustRegisterCmd("getaccountaddress", (*GetAccountAddressCmd)(nil), flags)
 	MustRegisterCmd("getaddressesbyaccount", (*GetAddressesByAccountCmd)(nil), flags)
@@ -630,7 +728,9 @@ func init() {
 	MustRegisterCmd("settxfee", (*SetTxFeeCmd)(nil), flags)
 	MustRegisterCmd("signmessage", (*SignMessageCmd)(nil), flags)
 	MustRegisterCmd("signrawtransaction", (*SignRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("walletcreatefundedpsbt", (*WalletCreateFundedPSBTCmd)(nil), flags)
 	MustRegisterCmd("walletlock", (*WalletLockCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrase", (*WalletPassphraseCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrasechange", (*WalletPassphraseChangeCmd)(nil), flags)
+	MustRegisterCmd("walletprocesspsbt", (*WalletProcessPSBTCmd)(nil), flags)
 }
@@ -68,6 +68,19 @@ func TestWalletSvrCmds(t *testing.T) {
 				Address: "1address",
 			},
 		},
+		{
+			name: "combinepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("combinepsbt", []string{"cHNidP8A", "cHNidP8B"})
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewCombinePSBTCmd([]string{"cHNidP8A", "cHNidP8B"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"combinepsbt","netparams":[["cHNidP8A","cHNidP8B"]],"id":1}`,
+			unmarshalled: &btcjson.CombinePSBTCmd{
+				Txs: []string{"cHNidP8A", "cHNidP8B"},
+			},
+		},
 		{
 			name: "createmultisig",
 			newCmd: func() (interface{}, error) {
@@ -83,6 +96,19 @@ func TestWalletSvrCmds(t *testing.T) {
 				Keys:      []string{"031234", "035678"},
 			},
 		},
+		{
+			name: "decodepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("decodepsbt", "cHNidP8A")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewDecodePSBTCmd("cHNidP8A")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"decodepsbt","netparams":["cHNidP8A"],"id":1}`,
+			unmarshalled: &btcjson.DecodePSBTCmd{
+				PSBT: "cHNidP8A",
+			},
+		},
 		{
 			name: "dumpprivkey",
 			newCmd: func() (interface{}, error) {
@@ -135,6 +161,34 @@ func TestWalletSvrCmds(t *testing.T) {
 				NumBlocks: 6,
 			},
 		},
+		{
+			name: "finalizepsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("finalizepsbt", "cHNidP8A")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewFinalizePSBTCmd("cHNidP8A", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","netparams":["cHNidP8A"],"id":1}`,
+			unmarshalled: &btcjson.FinalizePSBTCmd{
+				PSBT:    "cHNidP8A",
+				Extract: btcjson.Bool(true),
+			},
+		},
+		{
+			name: "finalizepsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("finalizepsbt", "cHNidP8A", false)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewFinalizePSBTCmd("cHNidP8A", btcjson.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","netparams":["cHNidP8A",false],"id":1}`,
+			unmarshalled: &btcjson.FinalizePSBTCmd{
+				PSBT:    "cHNidP8A",
+				Extract: btcjson.Bool(false),
+			},
+		},
 		{
 			name: "getaccount",
 			newCmd: func() (interface{}, error) {
@@ -1000,6 +1054,29 @@ func TestWalletSvrCmds(t *testing.T) {
 				Comment:     btcjson.String("comment"),
 			},
 		},
+		{
+			name: "sendmany optional3",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendmany", "from", `{"1Address":0.5}`, 6, "", 0.0002)
+			},
+			staticCmd: func() interface{} {
+				return &btcjson.SendManyCmd{
+					FromAccount: "from",
+					Amounts:     map[string]float64{"1Address": 0.5},
+					MinConf:     btcjson.Int(6),
+					Comment:     btcjson.String(""),
+					FeeRate:     btcjson.Float64(0.0002),
+				}
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","netparams":["from",{"1Address":0.5},6,"",0.0002],"id":1}`,
+			unmarshalled: &btcjson.SendManyCmd{
+				FromAccount: "from",
+				Amounts:     map[string]float64{"1Address": 0.5},
+				MinConf:     btcjson.Int(6),
+				Comment:     btcjson.String(""),
+				FeeRate:     btcjson.Float64(0.0002),
+			},
+		},
 		{
 			name: "sendtoaddress",
 			newCmd: func() (interface{}, error) {
@@ -1033,6 +1110,29 @@ func TestWalletSvrCmds(t *testing.T) {
 				CommentTo: btcjson.String("commentto"),
 			},
 		},
+		{
+			name: "sendtoaddress optional2",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("sendtoaddress", "1Address", 0.5, "", "", 0.0002)
+			},
+			staticCmd: func() interface{} {
+				return &btcjson.SendToAddressCmd{
+					Address:   "1Address",
+					Amount:    0.5,
+					Comment:   btcjson.String(""),
+					CommentTo: btcjson.String(""),
+					FeeRate:   btcjson.Float64(0.0002),
+				}
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","netparams":["1Address",0.5,"","",0.0002],"id":1}`,
+			unmarshalled: &btcjson.SendToAddressCmd{
+				Address:   "1Address",
+				Amount:    0.5,
+				Comment:   btcjson.String(""),
+				CommentTo: btcjson.String(""),
+				FeeRate:   btcjson.Float64(0.0002),
+			},
+		},
 		{
 			name: "setaccount",
 			newCmd: func() (interface{}, error) {
@@ -1158,6 +1258,51 @@ func TestWalletSvrCmds(t *testing.T) {
 				Flags:    btcjson.String("ALL"),
 			},
 		},
+		{
+			name: "walletcreatefundedpsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletcreatefundedpsbt", `[]`, `{"1Address":0.5}`)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletCreateFundedPSBTCmd([]btcjson.TransactionInput{},
+					map[string]float64{"1Address": 0.5}, nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","netparams":[[],{"1Address":0.5}],"id":1}`,
+			unmarshalled: &btcjson.WalletCreateFundedPSBTCmd{
+				Inputs:      []btcjson.TransactionInput{},
+				Outputs:     map[string]float64{"1Address": 0.5},
+				LockTime:    btcjson.Uint32(0),
+				Options:     nil,
+				Bip32Derivs: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "walletcreatefundedpsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletcreatefundedpsbt", `[{"txid":"123","vout":1}]`, `{"1Address":0.5}`,
+					100, `{"account":"savings","changePosition":0,"lockUnspents":true}`, true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletCreateFundedPSBTCmd([]btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+					map[string]float64{"1Address": 0.5}, btcjson.Uint32(100), &btcjson.WalletCreateFundedPSBTOpts{
+						Account:        btcjson.String("savings"),
+						ChangePosition: btcjson.Int(0),
+						LockUnspents:   btcjson.Bool(true),
+					}, btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","netparams":[[{"txid":"123","vout":1}],{"1Address":0.5},100,{"account":"savings","changePosition":0,"lockUnspents":true},true],"id":1}`,
+			unmarshalled: &btcjson.WalletCreateFundedPSBTCmd{
+				Inputs:   []btcjson.TransactionInput{{Txid: "123", Vout: 1}},
+				Outputs:  map[string]float64{"1Address": 0.5},
+				LockTime: btcjson.Uint32(100),
+				Options: &btcjson.WalletCreateFundedPSBTOpts{
+					Account:        btcjson.String("savings"),
+					ChangePosition: btcjson.Int(0),
+					LockUnspents:   btcjson.Bool(true),
+				},
+				Bip32Derivs: btcjson.Bool(true),
+			},
+		},
 		{
 			name: "walletlock",
 			newCmd: func() (interface{}, error) {
@@ -1197,6 +1342,39 @@ func TestWalletSvrCmds(t *testing.T) {
 				NewPassphrase: "new",
 			},
 		},
+		{
+			name: "walletprocesspsbt",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletprocesspsbt", "cHNidP8A")
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletProcessPSBTCmd("cHNidP8A", nil, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","netparams":["cHNidP8A"],"id":1}`,
+			unmarshalled: &btcjson.WalletProcessPSBTCmd{
+				PSBT:        "cHNidP8A",
+				Sign:        btcjson.Bool(true),
+				SighashType: btcjson.String("ALL"),
+				Bip32Derivs: btcjson.Bool(false),
+			},
+		},
+		{
+			name: "walletprocesspsbt optional",
+			newCmd: func() (interface{}, error) {
+				return btcjson.NewCmd("walletprocesspsbt", "cHNidP8A", false, "NONE", true)
+			},
+			staticCmd: func() interface{} {
+				return btcjson.NewWalletProcessPSBTCmd("cHNidP8A", btcjson.Bool(false), btcjson.String("NONE"),
+					btcjson.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","netparams":["cHNidP8A",false,"NONE",true],"id":1}`,
+			unmarshalled: &btcjson.WalletProcessPSBTCmd{
+				PSBT:        "cHNidP8A",
+				Sign:        btcjson.Bool(false),
+				SighashType: btcjson.String("NONE"),
+				Bip32Derivs: btcjson.Bool(true),
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
@@ -26,6 +26,43 @@ type (
 		Details         []GetTransactionDetailsResult `json:"details"`
 		Hex             string                        `json:"hex"`
 	}
+	// GetReconciliationResult models the data from the getreconciliation command. The wallet history is reconciled with
+	// the chain each time the wallet connects to it, and reconciled is false until that has finished.
+	GetReconciliationResult struct {
+		Reconciled       bool     `json:"reconciled"`
+		Changed          bool     `json:"changed"`
+		Started          int64    `json:"started"`
+		Finished         int64    `json:"finished"`
+		FromHeight       int32    `json:"fromheight"`
+		ToHeight         int32    `json:"toheight"`
+		AddressesDerived int      `json:"addressesderived"`
+		MissingTxs       []string `json:"missingtxs"`
+		ExtraTxs         []string `json:"extratxs"`
+		BalanceBefore    float64  `json:"balancebefore"`
+		BalanceAfter     float64  `json:"balanceafter"`
+	}
+	// GetWalletBalancesResult models the data from the getwalletbalances command. The balances are all taken from the
+	// same state of the wallet, as of the block at height and blockhash, and add up to the total.
+	GetWalletBalancesResult struct {
+		Height      int32   `json:"height"`
+		BlockHash   string  `json:"blockhash"`
+		Unconfirmed float64 `json:"unconfirmed"`
+		Confirming  float64 `json:"confirming"`
+		Confirmed   float64 `json:"confirmed"`
+		Immature    float64 `json:"immature"`
+		Locked      float64 `json:"locked"`
+		Total       float64 `json:"total"`
+	}
+	// GetWalletInfoResult models the data from the getwalletinfo command. A watching-only wallet holds no private keys,
+	// so it is always locked and cannot send.
+	GetWalletInfoResult struct {
+		WatchingOnly      bool   `json:"watchingonly"`
+		Locked            bool   `json:"locked"`
+		Birthday          int64  `json:"birthday"`
+		SigningDevice     string `json:"signingdevice,omitempty"`
+		Fingerprint       string `json:"fingerprint,omitempty"`
+		DatabaseEncrypted bool   `json:"databaseencrypted"`
+	}
 	// InfoWalletResult models the data returned by the wallet server getinfo command.
 	InfoWalletResult struct {
 		Version         int32   `json:"version"`
@@ -58,6 +95,8 @@ type (
 		Category          string   `json:"category"`
 		Confirmations     int64    `json:"confirmations"`
 		Fee               *float64 `json:"fee,omitempty"`
+		VSize             int64    `json:"vsize,omitempty"`
+		FeeRate           *float64 `json:"feerate,omitempty"`
 		Generated         bool     `json:"generated,omitempty"`
 		InvolvesWatchOnly bool     `json:"involveswatchonly,omitempty"`
 		Time              int64    `json:"time"`
@@ -66,6 +105,7 @@ type (
 		TxID              string   `json:"txid"`
 		Vout              uint32   `json:"vout"`
 		WalletConflicts   []string `json:"walletconflicts"`
+		Conflicted        bool     `json:"conflicted,omitempty"`
 		Comment           string   `json:"comment,omitempty"`
 		OtherAccount      string   `json:"otheraccount,omitempty"`
 	}
@@ -101,6 +141,137 @@ type (
 		Confirmations int64   `json:"confirmations"`
 		Spendable     bool    `json:"spendable"`
 	}
+	// MultisigAccountResult models the data from the createmultisigaccount and listmultisigaccounts commands.
+	MultisigAccountResult struct {
+		Account   string   `json:"account"`
+		Required  int      `json:"required"`
+		Keys      []string `json:"keys"`
+		XPub      string   `json:"xpub"`
+		Addresses uint32   `json:"addresses"`
+		Balance   float64  `json:"balance"`
+		Spendable float64  `json:"spendable"`
+	}
+	// MultisigPSBTInputResult models the signing progress of one input of a multisig partially signed transaction.
+	MultisigPSBTInputResult struct {
+		Signatures int `json:"signatures"`
+		Required   int `json:"required"`
+	}
+	// MultisigPSBTResult models the data from the createmultisigpsbt, signmultisigpsbt, importmultisigpsbt and
+	// listmultisigpsbts commands.
+	MultisigPSBTResult struct {
+		Account  string                    `json:"account,omitempty"`
+		TxID     string                    `json:"txid"`
+		PSBT     string                    `json:"psbt"`
+		Signed   int                       `json:"signed"`
+		Complete bool                      `json:"complete"`
+		Hex      string                    `json:"hex,omitempty"`
+		Inputs   []MultisigPSBTInputResult `json:"inputs"`
+	}
+	// PaymentRequestResult models the data from the createpaymentrequest and listpaymentrequests commands.
+	PaymentRequestResult struct {
+		Address   string  `json:"address"`
+		Amount    float64 `json:"amount"`
+		Label     string  `json:"label,omitempty"`
+		Message   string  `json:"message,omitempty"`
+		Created   int64   `json:"created"`
+		Expires   int64   `json:"expires"`
+		Status    string  `json:"status"`
+		Received  float64 `json:"received"`
+		Fulfilled int64   `json:"fulfilled,omitempty"`
+	}
+	// PreviewSendResult models the data from the previewsend command.
+	PreviewSendResult struct {
+		Fee            float64 `json:"fee"`
+		Size           int     `json:"vsize"`
+		FeeRate        float64 `json:"feerate"`
+		AncestorFees   float64 `json:"ancestorfees"`
+		AncestorSize   int     `json:"ancestorvsize"`
+		PackageFeeRate float64 `json:"packagefeerate"`
+		CPFP           bool    `json:"cpfp"`
+	}
+	// SigningDevicePSBTResult models the data from the createsigningdevicepsbt command.
+	SigningDevicePSBTResult struct {
+		TxID          string  `json:"txid"`
+		PSBT          string  `json:"psbt"`
+		Fee           float64 `json:"fee"`
+		SigningDevice string  `json:"signingdevice"`
+		Fingerprint   string  `json:"fingerprint"`
+	}
+	// WalletCreateFundedPSBTResult models the data from the walletcreatefundedpsbt command.
+	WalletCreateFundedPSBTResult struct {
+		PSBT      string  `json:"psbt"`
+		Fee       float64 `json:"fee"`
+		ChangePos int     `json:"changepos"`
+	}
+	// WalletProcessPSBTResult models the data from the walletprocesspsbt command.
+	WalletProcessPSBTResult struct {
+		PSBT     string `json:"psbt"`
+		Complete bool   `json:"complete"`
+	}
+	// FinalizePSBTResult models the data from the finalizepsbt command. The transaction is extracted in hex when it is
+	// complete and extraction was asked for, otherwise the packet is returned.
+	FinalizePSBTResult struct {
+		PSBT     string `json:"psbt,omitempty"`
+		Hex      string `json:"hex,omitempty"`
+		Complete bool   `json:"complete"`
+	}
+	// PSBTBip32DerivResult models a key derivation of an input or output of a partially signed transaction.
+	PSBTBip32DerivResult struct {
+		PubKey            string `json:"pubkey"`
+		MasterFingerprint string `json:"master_fingerprint"`
+		Path              string `json:"path"`
+	}
+	// DecodePSBTInputResult models an input of a partially signed transaction decoded by the decodepsbt command.
+	DecodePSBTInputResult struct {
+		NonWitnessUtxo    *Vout                  `json:"non_witness_utxo,omitempty"`
+		PartialSignatures map[string]string      `json:"partial_signatures,omitempty"`
+		Sighash           string                 `json:"sighash,omitempty"`
+		RedeemScript      *ScriptSig             `json:"redeem_script,omitempty"`
+		Bip32Derivs       []PSBTBip32DerivResult `json:"bip32_derivs,omitempty"`
+		FinalScriptSig    *ScriptSig             `json:"final_scriptsig,omitempty"`
+		Unknown           map[string]string      `json:"unknown,omitempty"`
+	}
+	// DecodePSBTOutputResult models an output of a partially signed transaction decoded by the decodepsbt command.
+	DecodePSBTOutputResult struct {
+		RedeemScript *ScriptSig             `json:"redeem_script,omitempty"`
+		Bip32Derivs  []PSBTBip32DerivResult `json:"bip32_derivs,omitempty"`
+		Unknown      map[string]string      `json:"unknown,omitempty"`
+	}
+	// DecodePSBTResult models the data from the decodepsbt command. The fee is only given when the previous outputs of
+	// all the inputs are known.
+	DecodePSBTResult struct {
+		Tx      TxRawDecodeResult        `json:"tx"`
+		Unknown map[string]string        `json:"unknown"`
+		Inputs  []DecodePSBTInputResult  `json:"inputs"`
+		Outputs []DecodePSBTOutputResult `json:"outputs"`
+		Fee     *float64                 `json:"fee,omitempty"`
+	}
+	// PrivacyReportResult models the data from the getprivacyreport command.
+	PrivacyReportResult struct {
+		Transactions    int                   `json:"transactions"`
+		ReusedAddresses []ReusedAddressResult `json:"reusedaddresses"`
+		MergedInputs    []MergedInputsResult  `json:"mergedinputs"`
+		RoundAmounts    []RoundAmountResult   `json:"roundamounts"`
+		Suggestions     []string              `json:"suggestions"`
+	}
+	// ReusedAddressResult models a wallet address that has received funds in more than one transaction.
+	ReusedAddressResult struct {
+		Address  string  `json:"address"`
+		Received int     `json:"received"`
+		Amount   float64 `json:"amount"`
+	}
+	// MergedInputsResult models a transaction that spent outputs belonging to several wallet addresses, linking them
+	// together on chain.
+	MergedInputsResult struct {
+		TxID      string   `json:"txid"`
+		Addresses []string `json:"addresses"`
+	}
+	// RoundAmountResult models a payment with a round amount, which makes it easy to tell the payment from the change.
+	RoundAmountResult struct {
+		TxID    string  `json:"txid"`
+		Address string  `json:"address"`
+		Amount  float64 `json:"amount"`
+	}
 	// SignRawTransactionError models the data that contains script verification errors from the signrawtransaction
 	// request.
 	SignRawTransactionError struct {
@@ -130,6 +301,7 @@ type (
 		Hex          string   `json:"hex,omitempty"`
 		Script       string   `json:"script,omitempty"`
 		SigsRequired int32    `json:"sigsrequired,omitempty"`
+		HDKeyPath    string   `json:"hdkeypath,omitempty"`
 	}
 	// GetBestBlockResult models the data from the getbestblock command.
 	GetBestBlockResult struct {
@@ -10,6 +10,12 @@ const (
 	// NewTxNtfnMethod is the method used to notify that a wallet server has added a new transaction to the transaction
 	// store.
 	NewTxNtfnMethod = "newtx"
+	// TxConflictedNtfnMethod is the method used to notify that a wallet transaction was conflicted by a mined
+	// transaction spending the same inputs.
+	TxConflictedNtfnMethod = "txconflicted"
+	// WalletRescanProgressNtfnMethod is the method used to notify the progress of a rescan of the chain for the
+	// transactions of the wallet.
+	WalletRescanProgressNtfnMethod = "walletrescanprogress"
 )
 
 // AccountBalanceNtfn defines the accountbalance JSON-RPC notification.
@@ -65,6 +71,44 @@ func NewNewTxNtfn(account string, details ListTransactionsResult) *NewTxNtfn {
 		Details: details,
 	}
 }
+
+// TxConflictedNtfn defines the txconflicted JSON-RPC notification.
+type TxConflictedNtfn struct {
+	TxID         string
+	ConflictedBy string
+}
+
+// NewTxConflictedNtfn returns a new instance which can be used to issue a txconflicted JSON-RPC notification.
+func NewTxConflictedNtfn(txid, conflictedBy string) *TxConflictedNtfn {
+	return &TxConflictedNtfn{
+		TxID:         txid,
+		ConflictedBy: conflictedBy,
+	}
+}
+
+// WalletRescanProgressNtfn defines the walletrescanprogress JSON-RPC notification.
+type WalletRescanProgressNtfn struct {
+	StartHeight int32
+	Height      int32
+	EndHeight   int32
+	Percent     float64
+	Found       int
+	Finished    bool
+}
+
+// NewWalletRescanProgressNtfn returns a new instance which can be used to issue a walletrescanprogress JSON-RPC
+// notification.
+func NewWalletRescanProgressNtfn(startHeight, height, endHeight int32, percent float64, found int,
+	finished bool) *WalletRescanProgressNtfn {
+	return &WalletRescanProgressNtfn{
+		StartHeight: startHeight,
+		Height:      height,
+		EndHeight:   endHeight,
+		Percent:     percent,
+		Found:       found,
+		Finished:    finished,
+	}
+}
 func init() {
 	// The commands in this file are only usable with a wallet server via websockets and are notifications.
 	flags := UFWalletOnly | UFWebsocketOnly | UFNotification
@@ -72,4 +116,6 @@ func init() {
 	MustRegisterCmd(PodConnectedNtfnMethod, (*PodConnectedNtfn)(nil), flags)
 	MustRegisterCmd(WalletLockStateNtfnMethod, (*WalletLockStateNtfn)(nil), flags)
 	MustRegisterCmd(NewTxNtfnMethod, (*NewTxNtfn)(nil), flags)
+	MustRegisterCmd(TxConflictedNtfnMethod, (*TxConflictedNtfn)(nil), flags)
+	MustRegisterCmd(WalletRescanProgressNtfnMethod, (*WalletRescanProgressNtfn)(nil), flags)
 }
@@ -111,6 +111,38 @@ func TestWalletSvrWsNtfns(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "txconflicted",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("txconflicted", "123", "456")
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewTxConflictedNtfn("123", "456")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"txconflicted","netparams":["123","456"],"id":null}`,
+			unmarshalled: &btcjson.TxConflictedNtfn{
+				TxID:         "123",
+				ConflictedBy: "456",
+			},
+		},
+		{
+			name: "walletrescanprogress",
+			newNtfn: func() (interface{}, error) {
+				return btcjson.NewCmd("walletrescanprogress", 100, 150, 200, 50.0, 3, false)
+			},
+			staticNtfn: func() interface{} {
+				return btcjson.NewWalletRescanProgressNtfn(100, 150, 200, 50, 3, false)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletrescanprogress","netparams":[100,150,200,50,3,false],"id":null}`,
+			unmarshalled: &btcjson.WalletRescanProgressNtfn{
+				StartHeight: 100,
+				Height:      150,
+				EndHeight:   200,
+				Percent:     50,
+				Found:       3,
+				Finished:    false,
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 	for i, test := range tests {
@@ -0,0 +1,155 @@
+package chainrpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
+)
+
+// Announcements keeps the verified maintainer announcements received from the network so they can be relayed to new
+// peers and shown to the user
+type Announcements struct {
+	sync.Mutex
+	keys   []*ec.PublicKey
+	active map[uint32]*wire.MsgAnnounce
+}
+
+// NewAnnouncements creates an announcement store that accepts messages signed by any of the given hex encoded public
+// keys
+func NewAnnouncements(keys []string) (a *Announcements, err error) {
+	a = &Announcements{active: make(map[uint32]*wire.MsgAnnounce)}
+	for i := range keys {
+		var b []byte
+		if b, err = hex.DecodeString(keys[i]); Check(err) {
+			return nil, err
+		}
+		var pk *ec.PublicKey
+		if pk, err = ec.ParsePubKey(b, ec.S256()); Check(err) {
+			return nil, err
+		}
+		a.keys = append(a.keys, pk)
+	}
+	return
+}
+
+// Verify returns an error if the announcement is not signed by one of the announcement keys
+func (a *Announcements) Verify(msg *wire.MsgAnnounce) (err error) {
+	if len(a.keys) == 0 {
+		return errors.New("no announcement keys are configured for this network")
+	}
+	var sig *ec.Signature
+	if sig, err = ec.ParseDERSignature(msg.Signature, ec.S256()); err != nil {
+		return
+	}
+	h, err := msg.SignatureHash()
+	if err != nil {
+		return
+	}
+	for i := range a.keys {
+		if sig.Verify(h[:], a.keys[i]) {
+			return nil
+		}
+	}
+	return fmt.Errorf("announcement %d is not signed by an announcement key", msg.ID)
+}
+
+// Add stores an announcement if it is valid, unexpired and not already known, and returns true if it should be relayed.
+// An announcement with the ID of a known one replaces it only if it expires later, so older versions circulating on the
+// network can't bring back a superseded message.
+func (a *Announcements) Add(msg *wire.MsgAnnounce) (isNew bool, err error) {
+	if msg.Expiration <= time.Now().Unix() {
+		return
+	}
+	if err = a.Verify(msg); err != nil {
+		return
+	}
+	a.Lock()
+	defer a.Unlock()
+	if old, ok := a.active[msg.ID]; ok && msg.Expiration <= old.Expiration {
+		return
+	}
+	a.active[msg.ID] = msg
+	return true, nil
+}
+
+// Active returns the unexpired announcements ordered from the highest priority, removing any that have expired
+func (a *Announcements) Active() (msgs []*wire.MsgAnnounce) {
+	now := time.Now().Unix()
+	a.Lock()
+	for id, msg := range a.active {
+		if msg.Expiration <= now {
+			delete(a.active, id)
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	a.Unlock()
+	sort.Slice(msgs, func(i, j int) bool {
+		if msgs[i].Priority != msgs[j].Priority {
+			return msgs[i].Priority > msgs[j].Priority
+		}
+		return msgs[i].ID < msgs[j].ID
+	})
+	return
+}
+
+// Warnings returns the text of the active announcements ordered from the highest priority
+func (a *Announcements) Warnings() (w []string) {
+	for _, msg := range a.Active() {
+		w = append(w, msg.Message)
+	}
+	return
+}
+
+// OnAnnounce is invoked when a peer receives an announce message. Valid announcements that are new are stored and
+// relayed to the other peers, and peers sending announcements with bad signatures get their ban score increased.
+func (np *NodePeer) OnAnnounce(_ *peer.Peer, msg *wire.MsgAnnounce) {
+	isNew, err := np.Server.Announcements.Add(msg)
+	if err != nil {
+		Debugf("peer %v sent an invalid announcement: %v", np, err)
+		if len(np.Server.Announcements.keys) > 0 {
+			np.AddOffense(connmgr.OffenseInvalidAnnouncement)
+		}
+		return
+	}
+	if !isNew {
+		return
+	}
+	Warn("network announcement:", msg.Message)
+	np.Server.BroadcastMessage(msg, np)
+}
+
+// PushAnnouncements sends the active announcements to a peer that understands them
+func (np *NodePeer) PushAnnouncements() {
+	if np.ProtocolVersion() < wire.AnnounceVersion {
+		return
+	}
+	for _, msg := range np.Server.Announcements.Active() {
+		np.QueueMessage(msg, nil)
+	}
+}
+
+// AnnouncementWarnings returns the active announcements joined into a single string for the errors field of RPC results
+func (s *Server) AnnouncementWarnings() string {
+	return strings.Join(s.AnnouncementMessages(), "; ")
+}
+
+// AnnouncementMessages returns the text of the active announcements ordered from the highest priority, for the
+// announcements field of RPC results. Clients should show these rather than splitting the errors field, as the text of
+// an announcement may contain the separator.
+func (s *Server) AnnouncementMessages() []string {
+	msgs := []string{}
+	if s.Cfg.Announcements != nil {
+		msgs = append(msgs, s.Cfg.Announcements.Warnings()...)
+	}
+	return msgs
+}
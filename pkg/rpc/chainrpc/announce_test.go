@@ -0,0 +1,93 @@
+package chainrpc
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+)
+
+// TestAnnouncementsAdd ensures an announcement replaces a known one with the same ID only if it expires later.
+func TestAnnouncementsAdd(t *testing.T) {
+	key, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewAnnouncements([]string{hex.EncodeToString(key.PubKey().SerializeCompressed())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := func(expiration int64, message string) *wire.MsgAnnounce {
+		msg := wire.NewMsgAnnounce(1, expiration, 0, message)
+		h, err := msg.SignatureHash()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := key.Sign(h[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg.Signature = sig.Serialize()
+		return msg
+	}
+	now := time.Now().Unix()
+	tests := []struct {
+		msg   *wire.MsgAnnounce
+		isNew bool
+	}{
+		{signed(now+100, "first"), true},
+		{signed(now+100, "first"), false},
+		{signed(now+100, "same expiration"), false},
+		{signed(now+50, "older"), false},
+		{signed(now+200, "newer"), true},
+		{signed(now-1, "expired"), false},
+	}
+	for i, test := range tests {
+		isNew, err := a.Add(test.msg)
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if isNew != test.isNew {
+			t.Errorf("%d: %q added as new %v, want %v", i, test.msg.Message, isNew, test.isNew)
+		}
+	}
+	if w := a.Warnings(); len(w) != 1 || w[0] != "newer" {
+		t.Errorf("got warnings %v, want [newer]", w)
+	}
+}
+
+// TestAnnouncementMessages ensures the announcements are listed as they were signed, even when their text contains the
+// separator of the joined errors field.
+func TestAnnouncementMessages(t *testing.T) {
+	s := &Server{}
+	if msgs := s.AnnouncementMessages(); msgs == nil || len(msgs) != 0 {
+		t.Fatalf("got %#v without announcements, want an empty list", msgs)
+	}
+	key, err := ec.NewPrivateKey(ec.S256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewAnnouncements([]string{hex.EncodeToString(key.PubKey().SerializeCompressed())})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := wire.NewMsgAnnounce(1, time.Now().Unix()+100, 0, "upgrade by block 100; old nodes will fork off")
+	h, err := msg.SignatureHash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err := key.Sign(h[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg.Signature = sig.Serialize()
+	if _, err = a.Add(msg); err != nil {
+		t.Fatal(err)
+	}
+	s.Cfg.Announcements = a
+	if msgs := s.AnnouncementMessages(); len(msgs) != 1 || msgs[0] != msg.Message {
+		t.Fatalf("got announcements %q, want [%q]", msgs, msg.Message)
+	}
+}
@@ -40,6 +40,18 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.AddNodeCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "calculatesighash",
+		Handler: "CalculateSigHash",
+		Cmd:     "*btcjson.CalculateSigHashCmd",
+		ResType: "btcjson.CalculateSigHashResult",
+	},
+	{
+		Method:  "clearbanned",
+		Handler: "ClearBanned",
+		Cmd:     "*None",
+		ResType: "None",
+	},
 	{
 		Method:  "createrawtransaction",
 		Handler: "CreateRawTransaction",
@@ -58,16 +70,52 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.DecodeScriptCmd",
 		ResType: "btcjson.DecodeScriptResult",
 	},
+	{
+		Method:  "dumpaddrman",
+		Handler: "DumpAddrMan",
+		Cmd:     "*btcjson.DumpAddrManCmd",
+		ResType: "btcjson.DumpAddrManResult",
+	},
+	{
+		Method:  "dumputxoset",
+		Handler: "DumpUTXOSet",
+		Cmd:     "*btcjson.DumpUTXOSetCmd",
+		ResType: "btcjson.UTXOSetSnapshotResult",
+	},
 	{
 		Method:  "estimatefee",
 		Handler: "EstimateFee",
 		Cmd:     "*btcjson.EstimateFeeCmd",
 		ResType: "float64",
 	},
+	{
+		Method:  "estimatesmartfee",
+		Handler: "EstimateSmartFee",
+		Cmd:     "*btcjson.EstimateSmartFeeCmd",
+		ResType: "btcjson.EstimateSmartFeeResult",
+	},
+	{
+		Method:  "evaluatescript",
+		Handler: "EvaluateScript",
+		Cmd:     "*btcjson.EvaluateScriptCmd",
+		ResType: "btcjson.EvaluateScriptResult",
+	},
 	{
 		Method:  "generate",
 		Handler: "Generate",
-		Cmd:     "*None",
+		Cmd:     "*btcjson.GenerateCmd",
+		ResType: "[]string",
+	},
+	{
+		Method:  "generateblock",
+		Handler: "GenerateBlock",
+		Cmd:     "*btcjson.GenerateBlockCmd",
+		ResType: "btcjson.GenerateBlockResult",
+	},
+	{
+		Method:  "generatetoaddress",
+		Handler: "GenerateToAddress",
+		Cmd:     "*btcjson.GenerateToAddressCmd",
 		ResType: "[]string",
 	},
 	{
@@ -76,6 +124,36 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetAddedNodeInfoCmd",
 		ResType: "[]btcjson.GetAddedNodeInfoResultAddr",
 	},
+	{
+		Method:  "getaddressbalance",
+		Handler: "GetAddressBalance",
+		Cmd:     "*btcjson.GetAddressBalanceCmd",
+		ResType: "btcjson.GetAddressBalanceResult",
+	},
+	{
+		Method:  "getaddresstxids",
+		Handler: "GetAddressTxIDs",
+		Cmd:     "*btcjson.GetAddressTxIDsCmd",
+		ResType: "[]string",
+	},
+	{
+		Method:  "getaddressutxos",
+		Handler: "GetAddressUtxos",
+		Cmd:     "*btcjson.GetAddressUtxosCmd",
+		ResType: "[]btcjson.GetAddressUtxosResult",
+	},
+	{
+		Method:  "getaddrmaninfo",
+		Handler: "GetAddrManInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetAddrManInfoResult",
+	},
+	{
+		Method:  "getauditlog",
+		Handler: "GetAuditLog",
+		Cmd:     "*btcjson.GetAuditLogCmd",
+		ResType: "[]btcjson.AuditLogEntry",
+	},
 	{
 		Method:  "getbestblock",
 		Handler: "GetBestBlock",
@@ -103,9 +181,15 @@ var handlers = handlersT{
 	{
 		Method:  "getblockcount",
 		Handler: "GetBlockCount",
-		Cmd:     "*None",
+		Cmd:     "*btcjson.GetBlockCountCmd",
 		ResType: "int64",
 	},
+	{
+		Method:  "getblockfilter",
+		Handler: "GetBlockFilter",
+		Cmd:     "*btcjson.GetBlockFilterCmd",
+		ResType: "btcjson.GetBlockFilterResult",
+	},
 	{
 		Method:  "getblockhash",
 		Handler: "GetBlockHash",
@@ -118,6 +202,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetBlockHeaderCmd",
 		ResType: "btcjson.GetBlockHeaderVerboseResult",
 	},
+	{
+		Method:  "getblocksubsidy",
+		Handler: "GetBlockSubsidy",
+		Cmd:     "*btcjson.GetBlockSubsidyCmd",
+		ResType: "btcjson.GetBlockSubsidyResult",
+	},
 	{
 		Method:  "getblocktemplate",
 		Handler: "GetBlockTemplate",
@@ -184,6 +274,12 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "btcjson.GetMempoolInfoResult",
 	},
+	{
+		Method:  "getminerstats",
+		Handler: "GetMinerStats",
+		Cmd:     "*None",
+		ResType: "btcjson.GetMinerStatsResult",
+	},
 	{
 		Method:  "getmininginfo",
 		Handler: "GetMiningInfo",
@@ -202,12 +298,24 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetNetworkHashPSCmd",
 		ResType: "[]btcjson.GetPeerInfoResult",
 	},
+	{
+		Method:  "getnetworkinfo",
+		Handler: "GetNetworkInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetNetworkInfoResult",
+	},
 	{
 		Method:  "getpeerinfo",
 		Handler: "GetPeerInfo",
 		Cmd:     "*None",
 		ResType: "[]btcjson.GetPeerInfoResult",
 	},
+	{
+		Method:  "getpeerstats",
+		Handler: "GetPeerStats",
+		Cmd:     "*None",
+		ResType: "btcjson.GetPeerStatsResult",
+	},
 	{
 		Method:  "getrawmempool",
 		Handler: "GetRawMempool",
@@ -220,18 +328,78 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetRawTransactionCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "getsnapshot",
+		Handler: "GetSnapshot",
+		Cmd:     "*None",
+		ResType: "btcjson.GetSnapshotResult",
+	},
+	{
+		Method:  "getspentinfo",
+		Handler: "GetSpentInfo",
+		Cmd:     "*btcjson.GetSpentInfoCmd",
+		ResType: "btcjson.GetSpentInfoResult",
+	},
+	{
+		Method:  "getsyncstatus",
+		Handler: "GetSyncStatus",
+		Cmd:     "*btcjson.GetSyncStatusCmd",
+		ResType: "btcjson.GetSyncStatusResult",
+	},
+	{
+		Method:  "gettelemetryinfo",
+		Handler: "GetTelemetryInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetTelemetryInfoResult",
+	},
 	{
 		Method:  "gettxout",
 		Handler: "GetTxOut",
 		Cmd:     "*btcjson.GetTxOutCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "gettxspendingprevout",
+		Handler: "GetTxSpendingPrevOut",
+		Cmd:     "*btcjson.GetTxSpendingPrevOutCmd",
+		ResType: "[]btcjson.GetTxSpendingPrevOutResult",
+	},
+	{
+		Method:  "getwork",
+		Handler: "GetWork",
+		Cmd:     "*btcjson.GetWorkCmd",
+		ResType: "btcjson.GetWorkResult",
+	},
 	{
 		Method:  "help",
 		Handler: "Help",
 		Cmd:     "*btcjson.HelpCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "invalidateblock",
+		Handler: "InvalidateBlock",
+		Cmd:     "*btcjson.InvalidateBlockCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "listbanned",
+		Handler: "ListBanned",
+		Cmd:     "*None",
+		ResType: "[]btcjson.ListBannedResult",
+	},
+	{
+		Method:  "loadaddrman",
+		Handler: "LoadAddrMan",
+		Cmd:     "*btcjson.LoadAddrManCmd",
+		ResType: "btcjson.LoadAddrManResult",
+	},
+	{
+		Method:  "loadutxoset",
+		Handler: "LoadUTXOSet",
+		Cmd:     "*btcjson.LoadUTXOSetCmd",
+		ResType: "btcjson.UTXOSetSnapshotResult",
+	},
 	{
 		Method:  "node",
 		Handler: "Node",
@@ -244,6 +412,24 @@ var handlers = handlersT{
 		Cmd:     "*None",
 		ResType: "None",
 	},
+	{
+		Method:  "reconsiderblock",
+		Handler: "ReconsiderBlock",
+		Cmd:     "*btcjson.ReconsiderBlockCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "releasesnapshot",
+		Handler: "ReleaseSnapshot",
+		Cmd:     "*btcjson.ReleaseSnapshotCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "scantxoutset",
+		Handler: "ScanTxOutSet",
+		Cmd:     "*btcjson.ScanTxOutSetCmd",
+		ResType: "btcjson.ScanTxOutSetResult",
+	},
 	{
 		Method:  "searchrawtransactions",
 		Handler: "SearchRawTransactions",
@@ -256,6 +442,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SendRawTransactionCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "setban",
+		Handler: "SetBan",
+		Cmd:     "*btcjson.SetBanCmd",
+		ResType: "None",
+	},
 	{
 		Method:  "setgenerate",
 		Handler: "SetGenerate",
@@ -286,6 +478,18 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.SubmitBlockCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "submitheader",
+		Handler: "SubmitHeader",
+		Cmd:     "*btcjson.SubmitHeaderCmd",
+		ResType: "string",
+	},
+	{
+		Method:  "testmempoolaccept",
+		Handler: "TestMempoolAccept",
+		Cmd:     "*btcjson.TestMempoolAcceptCmd",
+		ResType: "[]btcjson.TestMempoolAcceptResult",
+	},
 	{
 		Method:  "uptime",
 		Handler: "Uptime",
@@ -0,0 +1,300 @@
+package chainrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// DefaultGenerateMaxTries is the number of nonces generatetoaddress tries over all the blocks it mines when it isn't
+// given a number.
+const DefaultGenerateMaxTries = 1000000
+
+// listedTxSource is the source of the transactions listed for a block by generateblock, which are all included in it.
+type listedTxSource struct {
+	descs []*mining.TxDesc
+	have  map[chainhash.Hash]struct{}
+}
+
+// LastUpdated returns the zero time, as the listed transactions don't change
+func (l *listedTxSource) LastUpdated() time.Time {
+	return time.Time{}
+}
+
+// MiningDescs returns the descriptors of the listed transactions
+func (l *listedTxSource) MiningDescs() []*mining.TxDesc {
+	return l.descs
+}
+
+// HaveTransaction returns whether the transaction is one of the listed transactions
+func (l *listedTxSource) HaveTransaction(hash *chainhash.Hash) bool {
+	_, ok := l.have[*hash]
+	return ok
+}
+
+// GeneratePolicy is the mining policy of the blocks made by generateblock, which takes every listed transaction that
+// fits in a block whatever fee it pays.
+var GeneratePolicy = mining.Policy{
+	BlockMaxWeight: blockchain.MaxBlockWeight,
+	BlockMaxSize:   blockchain.MaxBlockBaseSize,
+}
+
+// CheckGenerateNetwork returns an error suitable for the reply unless the node is on a network where blocks can be mined
+// by the node itself, which is regtest or simnet.
+func CheckGenerateNetwork(s *Server) error {
+	netwk := (*s.Config.Network)[0]
+	if !s.Cfg.ChainParams.GenerateSupported || !(netwk == 'r' || netwk == 's') {
+		return &btcjson.RPCError{
+			Code: btcjson.ErrRPCDifficulty,
+			Message: fmt.Sprintf("No support for generating blocks on the current network, %s, as it's only "+
+				"possible on regtest and simnet.", s.Cfg.ChainParams.Net),
+		}
+	}
+	return nil
+}
+
+// GenerateAlgo returns the algorithm blocks are generated with, the one given if it is of the current hard fork or the
+// default algorithm of the server if none is.
+func GenerateAlgo(s *Server, algo *string) (string, error) {
+	if algo == nil || *algo == "" {
+		return s.Cfg.Algo, nil
+	}
+	height := s.Cfg.Chain.BestSnapshot().Height + 1
+	if _, ok := fork.List[fork.GetCurrent(height)].Algos[*algo]; !ok {
+		return "", &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Unknown algorithm " + *algo,
+		}
+	}
+	return *algo, nil
+}
+
+// DecodeGenerateAddress decodes the address generated blocks pay to
+func DecodeGenerateAddress(s *Server, address string) (util.Address, error) {
+	addr, err := util.DecodeAddress(address, s.Cfg.ChainParams)
+	if err != nil || !addr.IsForNet(s.Cfg.ChainParams) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Invalid address or key: " + address,
+		}
+	}
+	return addr, nil
+}
+
+// solveBlock looks for a nonce that makes the proof of work hash of the block header at the height meet its target,
+// trying at most tries nonces. It returns the number of nonces tried and whether one was found, which is set in the
+// header.
+func solveBlock(header *wire.BlockHeader, height int32, tries uint64) (tried uint64, solved bool) {
+	target := fork.CompactToBig(header.Bits)
+	for header.Nonce = 0; tried < tries; header.Nonce++ {
+		tried++
+		hash := header.BlockHashWithAlgos(height)
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+			return tried, true
+		}
+		if header.Nonce == ^uint32(0) {
+			break
+		}
+	}
+	return tried, false
+}
+
+// GenerateBlocks mines up to n blocks of the algorithm on the best block, paying to the address, and processes them
+// as blocks submitted to the node. The transactions are taken from the source, the mempool when it is nil. It stops
+// when the nonces tried over all the blocks reach maxTries, and returns the hashes of the blocks mined.
+func GenerateBlocks(s *Server, n uint32, payAddr util.Address, algo string, source mining.TxSource,
+	maxTries uint64) (hashes []string, err error) {
+	generator := *s.Cfg.Generator
+	if source != nil {
+		policy := GeneratePolicy
+		generator.TxSource, generator.Policy = source, &policy
+	}
+	hashes = make([]string, 0, n)
+	for uint32(len(hashes)) < n && maxTries > 0 {
+		select {
+		case <-s.Quit:
+			return hashes, nil
+		default:
+		}
+		var template *mining.BlockTemplate
+		if template, err = generator.NewBlockTemplate(0, payAddr, algo); Check(err) {
+			return nil, InternalRPCError(err.Error(), "Failed to create new block template")
+		}
+		// a listed transaction the generator leaves out, such as one that is not final, fails the block
+		if source != nil && len(template.Block.Transactions) != len(source.MiningDescs())+1 {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCVerify,
+				Message: "Not all of the transactions can be included in the block",
+			}
+		}
+		header := &template.Block.Header
+		tried, solved := solveBlock(header, template.Height, maxTries)
+		maxTries -= tried
+		if !solved {
+			break
+		}
+		block := util.NewBlock(template.Block)
+		block.SetHeight(template.Height)
+		if _, err = s.Cfg.SyncMgr.SubmitBlock(block, blockchain.BFNone); Check(err) {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCVerify,
+				Message: "Generated block was rejected: " + err.Error(),
+			}
+		}
+		hash := header.BlockHash()
+		Debug("generated block", hash, "at height", template.Height, "with", algo)
+		hashes = append(hashes, hash.String())
+	}
+	return hashes, nil
+}
+
+// GenerateTxSource returns the source of the transactions listed for generateblock, which are transaction IDs of the
+// mempool or serialized, hex-encoded transactions. The transactions may spend the outputs of the ones listed before
+// them.
+func GenerateTxSource(s *Server, txs []string) (source *listedTxSource, err error) {
+	source = &listedTxSource{have: make(map[chainhash.Hash]struct{})}
+	height := s.Cfg.Chain.BestSnapshot().Height + 1
+	listed := make([]*util.Tx, 0, len(txs))
+	for _, t := range txs {
+		var tx *util.Tx
+		if len(t) == chainhash.MaxHashStringSize {
+			var hash *chainhash.Hash
+			if hash, err = chainhash.NewHashFromStr(t); err != nil {
+				return nil, DecodeHexError(t)
+			}
+			if tx, err = s.Cfg.TxMemPool.FetchTransaction(hash); err != nil {
+				return nil, &btcjson.RPCError{
+					Code:    btcjson.ErrRPCNoTxInfo,
+					Message: "Transaction " + t + " is not in the mempool",
+				}
+			}
+		} else {
+			var b []byte
+			if b, err = hex.DecodeString(t); err != nil {
+				return nil, DecodeHexError(t)
+			}
+			var msgTx wire.MsgTx
+			if err = msgTx.Deserialize(bytes.NewReader(b)); err != nil {
+				return nil, &btcjson.RPCError{
+					Code:    btcjson.ErrRPCDeserialization,
+					Message: "Transaction decode failed: " + err.Error(),
+				}
+			}
+			tx = util.NewTx(&msgTx)
+		}
+		// the outputs of the transactions listed before this one are added to the view so it can spend them
+		var view *blockchain.UtxoViewpoint
+		if view, err = s.Cfg.Chain.FetchUtxoView(tx); Check(err) {
+			return nil, InternalRPCError(err.Error(), "Failed to fetch the inputs of "+tx.Hash().String())
+		}
+		for _, prev := range listed {
+			view.AddTxOuts(prev, mining.UnminedHeight)
+		}
+		var fee int64
+		if fee, err = blockchain.CheckTransactionInputs(tx, height, view, s.Cfg.ChainParams); err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCVerify,
+				Message: "Transaction " + tx.Hash().String() + " can't be included: " + err.Error(),
+			}
+		}
+		listed = append(listed, tx)
+		source.have[*tx.Hash()] = struct{}{}
+		source.descs = append(source.descs, &mining.TxDesc{
+			Tx:       tx,
+			Added:    time.Now(),
+			Height:   height - 1,
+			Fee:      fee,
+			FeePerKB: fee * 1000 / int64(tx.MsgTx().SerializeSize()),
+		})
+	}
+	return source, nil
+}
+
+// HandleGenerateToAddress implements the generatetoaddress command.
+func HandleGenerateToAddress(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GenerateToAddressCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("generatetoaddress")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if err = CheckGenerateNetwork(s); err != nil {
+		return nil, err
+	}
+	payAddr, err := DecodeGenerateAddress(s, c.Address)
+	if err != nil {
+		return nil, err
+	}
+	algo, err := GenerateAlgo(s, c.Algo)
+	if err != nil {
+		return nil, err
+	}
+	maxTries := uint64(DefaultGenerateMaxTries)
+	if c.MaxTries != nil {
+		maxTries = *c.MaxTries
+	}
+	return GenerateBlocks(s, c.NumBlocks, payAddr, algo, nil, maxTries)
+}
+
+// HandleGenerateBlock implements the generateblock command, which mines a block with exactly the transactions listed.
+func HandleGenerateBlock(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GenerateBlockCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("generateblock")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if err = CheckGenerateNetwork(s); err != nil {
+		return nil, err
+	}
+	payAddr, err := DecodeGenerateAddress(s, c.Address)
+	if err != nil {
+		return nil, err
+	}
+	algo, err := GenerateAlgo(s, c.Algo)
+	if err != nil {
+		return nil, err
+	}
+	source, err := GenerateTxSource(s, c.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := GenerateBlocks(s, 1, payAddr, algo, source, ^uint64(0))
+	if err != nil {
+		return nil, err
+	}
+	if len(hashes) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInternal.Code,
+			Message: "No nonce of the block meets its target",
+		}
+	}
+	return &btcjson.GenerateBlockResult{Hash: hashes[0]}, nil
+}
@@ -0,0 +1,34 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestSolveBlock ensures that a nonce meeting an easy target is found and set in the header, and that the search gives
+// up after the number of tries when the target can't be met.
+func TestSolveBlock(t *testing.T) {
+	header := wire.BlockHeader{
+		Version:   fork.GetAlgoVer(fork.SHA256d, 1),
+		PrevBlock: chainhash.Hash{1},
+		Timestamp: time.Unix(1600000000, 0),
+		Bits:      0x207fffff,
+	}
+	tried, solved := solveBlock(&header, 1, 1000)
+	if !solved || tried == 0 {
+		t.Fatalf("easy target was not met after %d tries", tried)
+	}
+	hash := header.BlockHashWithAlgos(1)
+	if blockchain.HashToBig(&hash).Cmp(fork.CompactToBig(header.Bits)) > 0 {
+		t.Fatalf("nonce %d set in the header doesn't meet the target", header.Nonce)
+	}
+	header.Bits = 0x03000001
+	if tried, solved = solveBlock(&header, 1, 10); solved || tried != 10 {
+		t.Fatalf("impossible target got solved %v after %d tries", solved, tried)
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	js "encoding/json"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"time"
 
 	"github.com/conformal/fastsha256"
@@ -14,13 +14,31 @@ import (
 	blockchain "github.com/p9c/pod/pkg/chain"
 	"github.com/p9c/pod/pkg/chain/fork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util"
 )
 
-// Uint256Size is the number of bytes needed to represent an unsigned 256-bit integer.
-const Uint256Size = 32
+const (
+	// Uint256Size is the number of bytes needed to represent an unsigned 256-bit integer.
+	Uint256Size = 32
+	// GetWorkLongPollPath is the path of the url that getwork clients are told to long poll for new work with the
+	// X-Long-Polling header of the replies to getwork.
+	GetWorkLongPollPath = "/LP"
+	// GetWorkRollNTimeSeconds is how far getwork clients may roll the timestamp of their work forward, which they are
+	// told with the X-Roll-NTime header of the replies to getwork.
+	GetWorkRollNTimeSeconds = 120
+	// MaxGetWorkBlocks is the number of the blocks most recently handed out by getwork on the best block that solutions
+	// are accepted for.
+	MaxGetWorkBlocks = 4096
+)
+
+// getWorkBlock is a block handed out by getwork and the height it connects to the main chain at.
+type getWorkBlock struct {
+	block  *wire.MsgBlock
+	height int32
+}
 
 // GetworkDataLen is the length of the data field of the getwork RPC.
 //
@@ -61,7 +79,12 @@ func BigToLEUint256(n *big.Int) [Uint256Size]byte {
 	return buf
 }
 
-// HandleGetWork handles the getwork call
+// HandleGetWork handles the getwork call.
+//
+// Work is handed out for the algorithm given in the request, or the default algorithm of the server, from the same
+// work state as getblocktemplate, with a pay to one of the mining addresses as getwork clients can't build their own
+// coinbase. Every request gets a variation of the block template with its own extra nonce, so that clients never
+// search the same nonce space. A request with data submits a solution for work handed out before.
 func HandleGetWork(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*btcjson.GetWorkCmd)
 	if len(s.StateCfg.ActiveMiningAddrs) == 0 {
@@ -86,103 +109,39 @@ func HandleGetWork(s *Server, cmd interface{}, closeChan <-chan struct{}) (inter
 			Message: "Pod is not yet synchronised...",
 		}
 	}
-	state := s.GBTWorkState
+	var algo string
+	if c.Algo != nil {
+		algo = *c.Algo
+	}
+	state, err := s.WorkState(algo)
+	if err != nil {
+		return nil, err
+	}
 	state.Lock()
 	defer state.Unlock()
-	if c.Data != nil {
-		return HandleGetWorkSubmission(s, *c.Data)
+	if c.Data != nil && *c.Data != "" {
+		return HandleGetWorkSubmission(s, state, *c.Data)
 	}
-	// Choose a payment address at random.
-	rand.Seed(time.Now().UnixNano())
-	payToAddr := s.StateCfg.ActiveMiningAddrs[rand.Intn(len(s.StateCfg.ActiveMiningAddrs))]
-	lastTxUpdate := s.GBTWorkState.LastTxUpdate
-	latestHash := &s.Cfg.Chain.BestSnapshot().Hash
-	generator := s.Cfg.Generator
-	if state.Template == nil {
-		var err error
-		state.Template, err = generator.NewBlockTemplate(0, payToAddr,
-			s.Cfg.Algo)
-		if err != nil {
-			Error(err)
-			return nil, err
-		}
+	// The block template is regenerated when the best block or the mempool changed, and otherwise has its timestamp
+	// updated, exactly as for getblocktemplate.
+	if err = state.UpdateBlockTemplate(s, false); err != nil {
+		return nil, err
 	}
-	msgBlock := state.Template.Block
-	if msgBlock == nil || state.prevHash == nil ||
-		!state.prevHash.IsEqual(latestHash) ||
-		(state.LastTxUpdate != lastTxUpdate &&
-			time.Now().After(state.LastGenerated.Add(time.Minute))) {
-		//	Reset the extra nonce and clear all cached template variations if the best block changed.
-		if state.prevHash != nil && !state.prevHash.IsEqual(latestHash) {
-			e := state.UpdateBlockTemplate(s, false)
-			if e != nil {
-				Warn("failed to update block template", e)
-			}
-		}
-		//	Reset the previous best hash the block template was generated against so any errors below cause the next
-		//	invocation to try again.
-		state.prevHash = nil
-		var err error
-		state.Template, err = generator.NewBlockTemplate(0, payToAddr,
-			s.Cfg.Algo)
-		if err != nil {
-			errStr := fmt.Sprintf("Failed to create new block template: %v", err)
-			Error(errStr)
-			return nil, &btcjson.RPCError{
-				Code:    btcjson.ErrRPCInternal.Code,
-				Message: errStr,
-			}
-		}
-		msgBlock = state.Template.Block
-		// Update work state to ensure another block template isn't generated until needed.
-		state.Template.Block = msgBlock
-		state.LastGenerated = time.Now()
-		state.LastTxUpdate = lastTxUpdate
-		state.prevHash = latestHash
-		Debugc(func() string {
-			return fmt.Sprintf(
-				"generated block template (timestamp %v, target %064x, "+
-					"merkle root %s, signature script %x)",
-				msgBlock.Header.Timestamp, fork.CompactToBig(msgBlock.Header.
-					Bits), msgBlock.Header.MerkleRoot,
-				msgBlock.Transactions[0].TxIn[0].SignatureScript,
-			)
-		})
-	} else {
-		// At this point, there is a saved block template and a new request for work was made but either the available
-		// transactions haven't change or it hasn't been long enough to trigger a new block template to be generated.
-		//
-		// So, update the existing block template and track the variations so each variation can be regenerated if a
-		// caller finds an answer and makes a submission against it. Update the time of the block template to the
-		// current time while accounting for the median time of the past several blocks per the chain consensus rules.
-		e := generator.UpdateBlockTime(0, msgBlock)
-		if e != nil {
-			Warn("failed to update block time", e)
-		}
-		// Increment the extra nonce and update the block template with the new value by regenerating the coinbase
-		// script and setting the merkle root to the new value.
-		Debugf(
-			"updated block template (timestamp %v, target %064x, "+
-				"merkle root %s, signature script %x)",
-			msgBlock.Header.Timestamp, fork.CompactToBig(msgBlock.Header.Bits),
-			msgBlock.Header.MerkleRoot, msgBlock.Transactions[0].TxIn[0].
-				SignatureScript)
-	}
-	// In order to efficiently store the variations of block templates that have been provided to callers save a pointer
-	// to the block as well as the modified signature script keyed by the merkle root. This information, along with the
-	// data that is included in a work submission, is used to rebuild the block before checking the submitted solution.
-	/*
-		coinbaseTx := msgBlock.Transactions[0]
-		state.blockInfo[msgBlock.Header.MerkleRoot] = &workStateBlockInfo{
-			msgBlock:        msgBlock,
-			signatureScript: coinbaseTx.TxIn[0].SignatureScript,
-		}
-	*/
+	msgBlock, err := state.NewGetWorkBlock(s.Cfg.Generator)
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError("Failed to update extra nonce: "+err.Error(), "")
+	}
+	Debugf(
+		"getwork %s block (timestamp %v, target %064x, merkle root %s, signature script %x)",
+		state.Algo, msgBlock.Header.Timestamp, fork.CompactToBig(msgBlock.Header.Bits), msgBlock.Header.MerkleRoot,
+		msgBlock.Transactions[0].TxIn[0].SignatureScript,
+	)
 	// Serialize the block header into a buffer large enough to hold the the block header and the internal sha256
 	// padding that is added and returned as part of the data below.
 	data := make([]byte, 0, GetworkDataLen)
 	buf := bytes.NewBuffer(data)
-	err := msgBlock.Header.Serialize(buf)
+	err = msgBlock.Header.Serialize(buf)
 	if err != nil {
 		Error(err)
 		errStr := fmt.Sprintf("Failed to serialize data: %v", err)
@@ -196,6 +155,7 @@ func HandleGetWork(s *Server, cmd interface{}, closeChan <-chan struct{}) (inter
 	// the first chunk of the block header (sha256 operates on 64-byte chunks) which is before the nonce.
 	//
 	// This allows sophisticated callers to avoid hashing the first chunk over and over while iterating the nonce range.
+	// It is only of use for sha256d, miners of the other algorithms ignore it.
 	data = data[:buf.Len()]
 	midstate := fastsha256.MidState256(data)
 	// Expand the data slice to include the full data buffer and apply the internal sha256 padding which consists of a
@@ -237,8 +197,12 @@ func HandleGetWork(s *Server, cmd interface{}, closeChan <-chan struct{}) (inter
 // HandleGetWorkSubmission is a helper for handleGetWork which deals with the calling submitting work to be verified and
 // processed.
 //
+// The block is rebuilt from the work handed out with the merkle root of the submitted header, which must otherwise
+// match it apart from the nonce and the timestamp. The timestamp may have been rolled forward by up to
+// GetWorkRollNTimeSeconds.
+//
 // This function MUST be called with the RPC workstate locked.
-func HandleGetWorkSubmission(s *Server, hexData string) (interface{}, error) {
+func HandleGetWorkSubmission(s *Server, state *GBTWorkState, hexData string) (interface{}, error) {
 	// Ensure the provided data is sane.
 	if len(hexData)%2 != 0 {
 		hexData = "0" + hexData
@@ -276,33 +240,37 @@ func HandleGetWorkSubmission(s *Server, hexData string) (interface{}, error) {
 				"contain a valid block header: %v", err),
 		}
 	}
-	// Look up the full block for the provided data based on the merkle root.
+	// Look up the work handed out with the merkle root of the submitted header.
 	//
 	// Return false to indicate the solve failed if it's not available.
-	state := s.GBTWorkState
-
-	if state.Template.Block.Header.MerkleRoot.String() == "" {
+	work, ok := state.getWorkBlocks[submittedHeader.MerkleRoot]
+	if !ok {
 		Debug(
-			"Block submitted via getwork has no matching template for merkle root",
+			"block submitted via getwork has no matching work for merkle root",
 			submittedHeader.MerkleRoot)
 		return false, nil
 	}
-	// Reconstruct the block using the submitted header stored block info.
-	msgBlock := state.Template.Block
-	block := util.NewBlock(msgBlock)
+	if err = CheckGetWorkHeader(&submittedHeader, &work.block.Header, state.Algo, work.height); err != nil {
+		Debug("block submitted via getwork rejected:", err)
+		return false, nil
+	}
+	latestHash := &s.Cfg.Chain.BestSnapshot().Hash
+	if !submittedHeader.PrevBlock.IsEqual(latestHash) {
+		Debugf("block submitted via getwork with previous block %s is stale", submittedHeader.PrevBlock)
+		return false, nil
+	}
+	// Reconstruct the block from a copy of the work, so the same work can be solved again with another nonce.
+	msgBlock := *work.block
 	msgBlock.Header.Timestamp = submittedHeader.Timestamp
 	msgBlock.Header.Nonce = submittedHeader.Nonce
-	msgBlock.Transactions[0].TxIn[0].SignatureScript = state.Template.Block.
-		Transactions[0].TxIn[0].SignatureScript
-	merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
-	msgBlock.Header.MerkleRoot = *merkles[len(merkles)-1]
-	// Ensure the submitted block hash is less than the target difficulty.
-	pl := fork.GetMinDiff(s.Cfg.Algo, s.Cfg.Chain.BestSnapshot().Height)
-	err = blockchain.CheckProofOfWork(block, pl, s.Cfg.Chain.BestSnapshot().Height)
+	block := util.NewBlock(&msgBlock)
+	// Ensure the submitted block hash, by the algorithm of its version, is less than the target difficulty.
+	powLimit := fork.GetMinDiff(fork.GetAlgoName(msgBlock.Header.Version, work.height), work.height)
+	err = blockchain.CheckProofOfWork(block, powLimit, work.height)
 	if err != nil {
-		Error(err)
 		// Anything other than a rule violation is an unexpected error, so return that error as an internal error.
 		if _, ok := err.(blockchain.RuleError); !ok {
+			Error(err)
 			return nil, &btcjson.RPCError{
 				Code: btcjson.ErrRPCInternal.Code,
 				Message: fmt.Sprintf("Unexpected error while checking proof"+
@@ -312,19 +280,13 @@ func HandleGetWorkSubmission(s *Server, hexData string) (interface{}, error) {
 		Debug("block submitted via getwork does not meet the required proof of work:", err)
 		return false, nil
 	}
-	latestHash := &s.Cfg.Chain.BestSnapshot().Hash
-	if !msgBlock.Header.PrevBlock.IsEqual(latestHash) {
-		Debugf("block submitted via getwork with previous block %s is stale", msgBlock.Header.PrevBlock)
-		return false, nil
-	}
 	// Process this block using the same rules as blocks coming from other nodes. This will in turn relay it to the
 	// network like normal.
-	_, isOrphan, err := s.Cfg.Chain.ProcessBlock(0, block, 0,
-		s.Cfg.Chain.BestSnapshot().Height)
+	isOrphan, err := s.Cfg.SyncMgr.SubmitBlock(block, blockchain.BFNone)
 	if err != nil || isOrphan {
-		Error(err)
 		// Anything other than a rule violation is an unexpected error, so return that error as an internal error.
-		if _, ok := err.(blockchain.RuleError); !ok {
+		if _, ok := err.(blockchain.RuleError); err != nil && !ok {
+			Error(err)
 			return nil, &btcjson.RPCError{
 				Code:    btcjson.ErrRPCInternal.Code,
 				Message: fmt.Sprintf("Unexpected error while processing block: %v", err),
@@ -334,11 +296,107 @@ func HandleGetWorkSubmission(s *Server, hexData string) (interface{}, error) {
 		return false, nil
 	}
 	// The block was accepted.
-	blockSha := block.Hash()
-	Info("block submitted via getwork accepted:", blockSha)
+	Info("block submitted via getwork accepted:", block.Hash())
 	return true, nil
 }
 
+// CheckGetWorkHeader checks that the header of a solution submitted with getwork is the header of the work it was
+// handed out with, apart from the nonce and the timestamp, and that the version is that of the algorithm of the work
+// state at the height of the block. The timestamp may only have been rolled forward, by up to GetWorkRollNTimeSeconds.
+func CheckGetWorkHeader(submitted, issued *wire.BlockHeader, algo string, height int32) error {
+	if version := fork.GetAlgoVer(algo, height); submitted.Version != version || issued.Version != version {
+		return fmt.Errorf("version %d is not %d of algorithm %s", submitted.Version, version, algo)
+	}
+	if !submitted.PrevBlock.IsEqual(&issued.PrevBlock) {
+		return fmt.Errorf("previous block %s is not %s", submitted.PrevBlock, issued.PrevBlock)
+	}
+	if submitted.Bits != issued.Bits {
+		return fmt.Errorf("bits %08x are not %08x", submitted.Bits, issued.Bits)
+	}
+	if submitted.Timestamp.Before(issued.Timestamp) ||
+		submitted.Timestamp.After(issued.Timestamp.Add(time.Second*GetWorkRollNTimeSeconds)) {
+		return fmt.Errorf("timestamp %v is rolled out of range from %v", submitted.Timestamp, issued.Timestamp)
+	}
+	return nil
+}
+
+// NewGetWorkBlock returns a copy of the current block template with the next extra nonce in its coinbase, so that every
+// getwork request is handed out a different part of the nonce space, and remembers it by its merkle root to rebuild
+// the block from a submitted solution. The copies made on an earlier best block are forgotten as their work is stale,
+// as are the oldest ones once MaxGetWorkBlocks are remembered.
+//
+// This function MUST be called with the state locked.
+func (state *GBTWorkState) NewGetWorkBlock(generator *mining.BlkTmplGenerator) (*wire.MsgBlock, error) {
+	template := state.Template.Block
+	if state.getWorkBlocks == nil || !state.getWorkPrevHash.IsEqual(&template.Header.PrevBlock) {
+		state.getWorkBlocks = make(map[chainhash.Hash]*getWorkBlock)
+		state.getWorkOrder = state.getWorkOrder[:0]
+		state.getWorkPrevHash = template.Header.PrevBlock
+		state.extraNonce = 0
+	}
+	msgBlock := &wire.MsgBlock{
+		Header:       template.Header,
+		Transactions: make([]*wire.MsgTx, len(template.Transactions)),
+	}
+	copy(msgBlock.Transactions, template.Transactions)
+	msgBlock.Transactions[0] = template.Transactions[0].Copy()
+	state.extraNonce++
+	if err := generator.UpdateExtraNonce(msgBlock, state.Template.Height, state.extraNonce); err != nil {
+		return nil, err
+	}
+	if len(state.getWorkOrder) >= MaxGetWorkBlocks {
+		delete(state.getWorkBlocks, state.getWorkOrder[0])
+		state.getWorkOrder = state.getWorkOrder[1:]
+	}
+	state.getWorkBlocks[msgBlock.Header.MerkleRoot] = &getWorkBlock{block: msgBlock, height: state.Template.Height}
+	state.getWorkOrder = append(state.getWorkOrder, msgBlock.Header.MerkleRoot)
+	return msgBlock, nil
+}
+
+// GetWorkAlgo returns the algorithm named by a getwork request in the body of an HTTP request, empty for the default
+// algorithm, and false if the body is not a single getwork request.
+func GetWorkAlgo(body []byte) (algo string, ok bool) {
+	var request btcjson.Request
+	if err := js.Unmarshal(body, &request); err != nil || request.Method != "getwork" {
+		return "", false
+	}
+	if len(request.Params) > 1 {
+		_ = js.Unmarshal(request.Params[1], &algo)
+	}
+	return algo, true
+}
+
+// WaitForWork waits for the block template of the work state of an algorithm to go stale, for a getwork client long
+// polling for new work, for at most LongPollTimeout. ErrClientQuit is returned if the client closes the connection
+// before.
+func (s *Server) WaitForWork(algo string, closeChan <-chan struct{}) error {
+	state, err := s.WorkState(algo)
+	if err != nil {
+		return err
+	}
+	state.Lock()
+	if state.Template == nil || state.prevHash == nil {
+		// There is no work yet, so the client gets the first that is generated.
+		state.Unlock()
+		return nil
+	}
+	longPollChan, done, err := state.LongPoll.Register(state.prevHash, state.TemplateSeq)
+	state.Unlock()
+	if err != nil {
+		return err
+	}
+	defer done()
+	timeout := time.NewTimer(LongPollTimeout)
+	defer timeout.Stop()
+	select {
+	case <-closeChan:
+		return ErrClientQuit
+	case <-longPollChan:
+	case <-timeout.C:
+	}
+	return nil
+}
+
 // ReverseUint32Array treats the passed bytes as a series of uint32s and reverses the byte order of each uint32.
 //
 // The passed byte slice must be a multiple of 4 for a correct result.
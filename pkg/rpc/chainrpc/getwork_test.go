@@ -0,0 +1,111 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// TestCheckGetWorkHeader ensures a submitted header must be the one handed out apart from the nonce and a timestamp
+// rolled forward within range, with the version of the algorithm of the work.
+func TestCheckGetWorkHeader(t *testing.T) {
+	issued := wire.BlockHeader{
+		Version:   fork.GetAlgoVer(fork.Scrypt, 1),
+		PrevBlock: chainhash.Hash{1},
+		Timestamp: time.Unix(1600000000, 0),
+		Bits:      0x1d00ffff,
+	}
+	submitted := issued
+	submitted.Nonce = 12345
+	submitted.Timestamp = issued.Timestamp.Add(time.Minute)
+	if err := CheckGetWorkHeader(&submitted, &issued, fork.Scrypt, 1); err != nil {
+		t.Fatalf("valid submission rejected: %v", err)
+	}
+	tests := []struct {
+		name   string
+		change func(h *wire.BlockHeader)
+	}{
+		{"version", func(h *wire.BlockHeader) { h.Version = fork.GetAlgoVer(fork.SHA256d, 1) }},
+		{"previous block", func(h *wire.BlockHeader) { h.PrevBlock = chainhash.Hash{2} }},
+		{"bits", func(h *wire.BlockHeader) { h.Bits = 0x1c00ffff }},
+		{"timestamp rolled back", func(h *wire.BlockHeader) { h.Timestamp = issued.Timestamp.Add(-time.Second) }},
+		{"timestamp rolled too far", func(h *wire.BlockHeader) {
+			h.Timestamp = issued.Timestamp.Add(time.Second * (GetWorkRollNTimeSeconds + 1))
+		}},
+	}
+	for _, test := range tests {
+		h := submitted
+		test.change(&h)
+		if err := CheckGetWorkHeader(&h, &issued, fork.Scrypt, 1); err == nil {
+			t.Errorf("%s: invalid submission accepted", test.name)
+		}
+	}
+	// The work must be of the algorithm of the work state it is submitted to.
+	if err := CheckGetWorkHeader(&submitted, &issued, fork.SHA256d, 1); err == nil {
+		t.Error("submission for another algorithm accepted")
+	}
+}
+
+// TestNewGetWorkBlock ensures every getwork request is handed out a copy of the template with its own extra nonce that
+// is remembered by merkle root, and that the copies are forgotten when the best block changes.
+func TestNewGetWorkBlock(t *testing.T) {
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), nil, nil))
+	coinbase.AddTxOut(wire.NewTxOut(5000, []byte{0x51}))
+	template := &wire.MsgBlock{
+		Header:       wire.BlockHeader{PrevBlock: chainhash.Hash{1}},
+		Transactions: []*wire.MsgTx{coinbase},
+	}
+	state := &GBTWorkState{Template: &mining.BlockTemplate{Block: template, Height: 1}}
+	generator := &mining.BlkTmplGenerator{}
+	first, err := state.NewGetWorkBlock(generator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := state.NewGetWorkBlock(generator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Header.MerkleRoot == second.Header.MerkleRoot {
+		t.Fatal("two requests were handed out the same work")
+	}
+	if template.Transactions[0].TxIn[0].SignatureScript != nil {
+		t.Error("the coinbase of the template was changed")
+	}
+	for _, b := range []*wire.MsgBlock{first, second} {
+		if work, ok := state.getWorkBlocks[b.Header.MerkleRoot]; !ok || work.block != b || work.height != 1 {
+			t.Errorf("work with merkle root %s is not remembered", b.Header.MerkleRoot)
+		}
+	}
+	template.Header.PrevBlock = chainhash.Hash{2}
+	if _, err = state.NewGetWorkBlock(generator); err != nil {
+		t.Fatal(err)
+	}
+	if len(state.getWorkBlocks) != 1 {
+		t.Errorf("%d blocks remembered after the best block changed, want 1", len(state.getWorkBlocks))
+	}
+}
+
+// TestGetWorkAlgo ensures getwork requests and the algorithm they name are recognised.
+func TestGetWorkAlgo(t *testing.T) {
+	tests := []struct {
+		body      string
+		algo      string
+		isGetWork bool
+	}{
+		{`{"jsonrpc":"1.0","method":"getwork","netparams":[],"id":1}`, "", true},
+		{`{"jsonrpc":"1.0","method":"getwork","netparams":["","scrypt"],"id":1}`, "scrypt", true},
+		{`{"jsonrpc":"1.0","method":"getblockcount","netparams":[],"id":1}`, "", false},
+		{`[{"jsonrpc":"1.0","method":"getwork","netparams":[],"id":1}]`, "", false},
+	}
+	for _, test := range tests {
+		algo, isGetWork := GetWorkAlgo([]byte(test.body))
+		if algo != test.algo || isGetWork != test.isGetWork {
+			t.Errorf("%s: got %q, %v, want %q, %v", test.body, algo, isGetWork, test.algo, test.isGetWork)
+		}
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -23,11 +25,16 @@ import (
 	"github.com/p9c/pod/cmd/node/version"
 	blockchain "github.com/p9c/pod/pkg/chain"
 	chaincfg "github.com/p9c/pod/pkg/chain/config"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/chain/fork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	netsync "github.com/p9c/pod/pkg/chain/sync"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/db/blockdb"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
@@ -87,6 +94,87 @@ func HandleAskWallet(
 	return nil, ErrRPCNoWallet
 }
 
+// HandleCalculateSigHash handles calculatesighash commands. It returns the digest the node's script engine signs and
+// verifies for an input, so external signers can check their own sighash implementations against it.
+func HandleCalculateSigHash(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.CalculateSigHashCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("calculatesighash")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	// Deserialize the transaction.
+	hexStr := c.RawTx
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	serializedTx, err := hex.DecodeString(hexStr)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(hexStr)
+	}
+	var mtx wire.MsgTx
+	err = mtx.Deserialize(bytes.NewReader(serializedTx))
+	if err != nil {
+		Error(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDeserialization,
+			Message: "TX decode failed: " + err.Error(),
+		}
+	}
+	script, err := hex.DecodeString(c.Script)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.Script)
+	}
+	hashType := txscript.SigHashAll
+	if c.HashType != nil {
+		if hashType, err = txscript.ParseSigHashType(*c.HashType); err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: err.Error(),
+			}
+		}
+	}
+	var amount util.Amount
+	if c.Amount != nil {
+		if amount, err = util.NewAmount(*c.Amount); err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "Invalid amount: " + err.Error(),
+			}
+		}
+	}
+	witness := c.Witness != nil && *c.Witness
+	sigHash, err := txscript.CalcSigHash(&mtx, int(c.Index), script, int64(amount), hashType, witness)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: err.Error(),
+		}
+	}
+	return &btcjson.CalculateSigHashResult{
+		SigHash:  hex.EncodeToString(sigHash),
+		HashType: uint32(hashType),
+		Witness:  witness,
+	}, nil
+}
+
+// HandleClearBanned implements the clearbanned command.
+func HandleClearBanned(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	s.Cfg.ConnMgr.ClearBanned()
+	return nil, nil
+}
+
 // HandleCreateRawTransaction handles createrawtransaction commands.
 func HandleCreateRawTransaction(
 	s *Server,
@@ -315,6 +403,102 @@ func HandleDecodeScript(
 	return reply, nil
 }
 
+// HandleDumpAddrMan implements the dumpaddrman command, writing the known peer addresses to a file in the format of the
+// peers file so that other nodes can import them with loadaddrman. Relative file names are in the data directory. NOTE:
+// This is a pod extension.
+func HandleDumpAddrMan(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.DumpAddrManCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("dumpaddrman")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	filename := DataFilename(s, c.Filename)
+	f, err := os.Create(filename)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Could not create " + filename + ": " + err.Error(),
+		}
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			Error(err)
+		}
+	}()
+	n, err := s.Cfg.ConnMgr.ExportAddresses(f)
+	if err != nil {
+		return nil, InternalRPCError(err.Error(), "Could not write addresses")
+	}
+	return &btcjson.DumpAddrManResult{
+		Filename:  filename,
+		Addresses: n,
+	}, nil
+}
+
+// DataFilename resolves the name of a file read or written by a command, relative names being in the data directory.
+func DataFilename(s *Server, filename string) string {
+	if filepath.IsAbs(filename) {
+		return filepath.Clean(filename)
+	}
+	return filepath.Join(*s.Config.DataDir, filename)
+}
+
+// HandleDumpUTXOSet implements the dumputxoset command, writing a snapshot of the UTXO set at the best block that new
+// nodes can be bootstrapped from with loadutxoset. This is a pod extension.
+func HandleDumpUTXOSet(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.DumpUTXOSetCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("dumputxoset")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	filename := DataFilename(s, c.Filename)
+	// The snapshot is written to a temporary file first, so that a failed dump does not leave a truncated snapshot.
+	tmpFilename := filename + ".incomplete"
+	f, err := os.Create(tmpFilename)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Could not create " + filename + ": " + err.Error(),
+		}
+	}
+	snap, err := s.Cfg.Chain.DumpUTXOSnapshot(f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpFilename, filename)
+	}
+	if err != nil {
+		if err := os.Remove(tmpFilename); err != nil {
+			Error(err)
+		}
+		return nil, InternalRPCError(err.Error(), "Could not dump the UTXO set")
+	}
+	return &btcjson.UTXOSetSnapshotResult{
+		Filename: filename,
+		Hash:     snap.Hash.String(),
+		Height:   snap.Height,
+		UTXOHash: snap.UTXOHash.String(),
+		Coins:    snap.Coins,
+	}, nil
+}
+
 // HandleEstimateFee handles estimatefee commands.
 func HandleEstimateFee(
 	s *Server,
@@ -352,6 +536,158 @@ func HandleEstimateFee(
 	return float64(feeRate), nil
 }
 
+// HandleEstimateSmartFee handles estimatesmartfee commands. When no estimate can be made the result holds the reason
+// instead of a fee rate, as bitcoin core does.
+func HandleEstimateSmartFee(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.EstimateSmartFeeCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("estimatesmartfee")
+		Debug(h, err)
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if s.Cfg.FeeEstimator == nil {
+		return nil, errors.New("fee estimation disabled")
+	}
+	if c.ConfTarget <= 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "parameter conftarget must be positive",
+		}
+	}
+	name := *s.Config.FeeEstimateMode
+	if c.EstimateMode != nil && *c.EstimateMode != btcjson.EstimateModeUnset {
+		name = string(*c.EstimateMode)
+	}
+	mode, err := mempool.ParseEstimateMode(name)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: err.Error(),
+		}
+	}
+	feeRate, blocks, err := s.Cfg.FeeEstimator.EstimateSmartFee(uint32(c.ConfTarget), mode)
+	if err != nil {
+		return &btcjson.EstimateSmartFeeResult{Errors: []string{err.Error()}}, nil
+	}
+	rate := float64(feeRate)
+	return &btcjson.EstimateSmartFeeResult{FeeRate: &rate, Blocks: int64(blocks)}, nil
+}
+
+// HandleEvaluateScript handles evaluatescript commands. It runs a public key script, together with a signature script or
+// the input of a transaction spending it, through the script engine one opcode at a time and returns the trace.
+func HandleEvaluateScript(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.EvaluateScriptCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("evaluatescript")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	pkScript, err := hex.DecodeString(c.ScriptPubKey)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.ScriptPubKey)
+	}
+	var sigScript []byte
+	if c.ScriptSig != nil {
+		if sigScript, err = hex.DecodeString(*c.ScriptSig); err != nil {
+			Error(err)
+			return nil, DecodeHexError(*c.ScriptSig)
+		}
+	}
+	// Without a transaction the scripts are run in an otherwise empty transaction, so signature checks fail.
+	mtx := wire.NewMsgTx(wire.TxVersion)
+	var idx int
+	if c.RawTx != nil && *c.RawTx != "" {
+		serializedTx, err := hex.DecodeString(*c.RawTx)
+		if err != nil {
+			Error(err)
+			return nil, DecodeHexError(*c.RawTx)
+		}
+		if err = mtx.Deserialize(bytes.NewReader(serializedTx)); err != nil {
+			Error(err)
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCDeserialization,
+				Message: "TX decode failed: " + err.Error(),
+			}
+		}
+		if c.Index != nil {
+			idx = int(*c.Index)
+		}
+		if idx >= len(mtx.TxIn) {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: fmt.Sprintf("Input index %d out of range, transaction has %d inputs", idx, len(mtx.TxIn)),
+			}
+		}
+		if len(sigScript) > 0 {
+			mtx.TxIn[idx].SignatureScript = sigScript
+		}
+	} else {
+		mtx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, sigScript, nil))
+		mtx.AddTxOut(wire.NewTxOut(0, nil))
+	}
+	var amount util.Amount
+	if c.Amount != nil {
+		if amount, err = util.NewAmount(*c.Amount); err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "Invalid amount: " + err.Error(),
+			}
+		}
+	}
+	result := &btcjson.EvaluateScriptResult{Steps: []btcjson.EvaluateScriptStep{}}
+	vm, err := txscript.NewEngine(pkScript, mtx, idx, txscript.StandardVerifyFlags, nil,
+		txscript.NewTxSigHashes(mtx), int64(amount))
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	steps, err := vm.ExecuteTrace()
+	for i := range steps {
+		step := btcjson.EvaluateScriptStep{
+			Script: steps[i].Script,
+			Offset: steps[i].Offset,
+			Opcode: steps[i].Opcode,
+			Stack:  make([]string, len(steps[i].Stack)),
+			Failed: steps[i].Failed,
+		}
+		for j, item := range steps[i].Stack {
+			step.Stack[j] = hex.EncodeToString(item)
+		}
+		for _, item := range steps[i].AltStack {
+			step.AltStack = append(step.AltStack, hex.EncodeToString(item))
+		}
+		if step.Failed {
+			result.FailedOpcode = step.Opcode
+		}
+		result.Steps = append(result.Steps, step)
+	}
+	if err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	result.Valid = true
+	return result, nil
+}
+
 // HandleGenerate handles generate commands.
 func HandleGenerate(
 	s *Server,
@@ -365,45 +701,43 @@ func HandleGenerate(
 			Message: "No payment addresses specified via --miningaddr",
 		}
 	}
+	c, ok := cmd.(*btcjson.GenerateCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "invalid generate command",
+		}
+	}
 	// Respond with an error if there's virtually 0 chance of mining a block with the CPU.
-	if !s.Cfg.ChainParams.GenerateSupported {
-		return nil, &btcjson.RPCError{
-			Code: btcjson.ErrRPCDifficulty,
-			Message: fmt.Sprintf("No support for `generate` on the current"+
-				" network, %s, as it's unlikely to be possible to mine a block"+
-				" with the CPU.", s.Cfg.ChainParams.Net),
-		}
-	}
-	Debug("cpu miner stuff is missing here")
-	// Set the algorithm according to the port we were called on
-	// s.Cfg.CPUMiner.SetAlgo(s.Cfg.Algo)
-	// c := cmd.(*btcjson.GenerateCmd)
-	// // Respond with an error if the client is requesting 0 blocks to be
-	// // generated.
-	// if c.NumBlocks == 0 {
-	// 	return nil, &btcjson.RPCError{
-	// 		Code:    btcjson.ErrRPCInternal.Code,
-	// 		Message: "Please request a nonzero number of blocks to generate.",
-	// 	}
-	// }
-	// // Create a reply
-	// reply := make([]string, c.NumBlocks)
-	// blockHashes, err := s.Cfg.CPUMiner.GenerateNBlocks(0, c.NumBlocks,
-	// 	s.Cfg.Algo)
-	// if err != nil {
-	// 	L.ScriptError(err)
-	// 	return nil, &btcjson.RPCError{
-	// 		Code:    btcjson.ErrRPCInternal.Code,
-	// 		Message: err.ScriptError(),
-	// 	}
-	// }
-	// // Mine the correct number of blocks, assigning the hex representation of
-	// // the hash of each one to its place in the reply.
-	// for i, hash := range blockHashes {
-	// 	reply[i] = hash.String()
-	// }
-	// return reply, nil
-	return nil, nil
+	if err := CheckGenerateNetwork(s); err != nil {
+		return nil, err
+	}
+	// Respond with an error if the client is requesting 0 blocks to be generated.
+	if c.NumBlocks == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInternal.Code,
+			Message: "Please request a nonzero number of blocks to generate.",
+		}
+	}
+	// The blocks are mined with the algorithm of the port we were called on, paying to the mining addresses by the
+	// payout policy.
+	hashes := make([]string, 0, c.NumBlocks)
+	for uint32(len(hashes)) < c.NumBlocks {
+		payAddr, err := s.StateCfg.Payout.Address(s.StateCfg.ActiveMiningAddrs, s.Cfg.Algo,
+			s.Cfg.Chain.BestSnapshot().Height+1)
+		if err != nil {
+			return nil, InternalRPCError(err.Error(), "Failed to choose a payment address")
+		}
+		generated, err := GenerateBlocks(s, 1, payAddr, s.Cfg.Algo, nil, ^uint64(0))
+		if err != nil {
+			return nil, err
+		}
+		if len(generated) == 0 {
+			break
+		}
+		hashes = append(hashes, generated...)
+	}
+	return hashes, nil
 }
 
 // HandleGetAddedNodeInfo handles getaddednodeinfo commands.
@@ -506,6 +840,201 @@ func HandleGetAddedNodeInfo(
 	return results, nil
 }
 
+// HandleGetAddressBalance implements the getaddressbalance command. The balance is the value of the unspent outputs
+// paying the addresses in the chain, and the unconfirmed amount is what the transactions in the mempool add to or take
+// from it. NOTE: This is a pod extension.
+func HandleGetAddressBalance(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.GetAddressBalanceCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("getaddressbalance")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	addrs, err := DecodeIndexedAddresses(s, c.Addresses)
+	if err != nil {
+		return nil, err
+	}
+	txns, outputs, err := FetchAddressHistory(s, addrs, true)
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to load address index entries")
+	}
+	var result btcjson.GetAddressBalanceResult
+	byOutPoint := make(map[wire.OutPoint]*AddressOutput, len(outputs))
+	for i := range outputs {
+		out := &outputs[i]
+		byOutPoint[out.OutPoint] = out
+		if out.Mempool {
+			result.Unconfirmed += out.TxOut.Value
+			continue
+		}
+		result.Received += out.TxOut.Value
+		entry, err := s.Cfg.Chain.FetchUtxoEntry(out.OutPoint)
+		if err != nil {
+			Error(err)
+			return nil, InternalRPCError(err.Error(), "Failed to fetch unspent output")
+		}
+		if entry != nil && !entry.IsSpent() {
+			result.Balance += out.TxOut.Value
+		}
+	}
+	// Outputs of the addresses spent by transactions in the mempool are taken off the unconfirmed amount.
+	for i := range txns {
+		if !txns[i].Mempool {
+			continue
+		}
+		for _, txIn := range txns[i].Tx.TxIn {
+			if out, ok := byOutPoint[txIn.PreviousOutPoint]; ok {
+				result.Unconfirmed -= out.TxOut.Value
+			}
+		}
+	}
+	return result, nil
+}
+
+// HandleGetAddressTxIDs implements the getaddresstxids command, returning the IDs of the transactions involving the
+// addresses in the order they were confirmed. NOTE: This is a pod extension.
+func HandleGetAddressTxIDs(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.GetAddressTxIDsCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("getaddresstxids")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	addrs, err := DecodeIndexedAddresses(s, c.Addresses)
+	if err != nil {
+		return nil, err
+	}
+	includeMempool := c.IncludeMempool == nil || *c.IncludeMempool
+	txns, _, err := FetchAddressHistory(s, addrs, includeMempool)
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to load address index entries")
+	}
+	txIDs := make([]string, len(txns))
+	for i := range txns {
+		txIDs[i] = txns[i].Hash.String()
+	}
+	return txIDs, nil
+}
+
+// HandleGetAddressUtxos implements the getaddressutxos command, returning the unspent outputs paying the addresses.
+// NOTE: This is a pod extension.
+func HandleGetAddressUtxos(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.GetAddressUtxosCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("getaddressutxos")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	addrs, err := DecodeIndexedAddresses(s, c.Addresses)
+	if err != nil {
+		return nil, err
+	}
+	includeMempool := c.IncludeMempool != nil && *c.IncludeMempool
+	_, outputs, err := FetchAddressHistory(s, addrs, includeMempool)
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to load address index entries")
+	}
+	best := s.Cfg.Chain.BestSnapshot()
+	utxos := make([]btcjson.GetAddressUtxosResult, 0, len(outputs))
+	for i := range outputs {
+		out := &outputs[i]
+		// When the mempool is included, outputs it spends are no longer unspent.
+		if includeMempool && s.Cfg.TxMemPool.CheckSpend(out.OutPoint) != nil {
+			continue
+		}
+		var confirmations int64
+		if !out.Mempool {
+			entry, err := s.Cfg.Chain.FetchUtxoEntry(out.OutPoint)
+			if err != nil {
+				Error(err)
+				return nil, InternalRPCError(err.Error(), "Failed to fetch unspent output")
+			}
+			if entry == nil || entry.IsSpent() {
+				continue
+			}
+			confirmations = int64(best.Height-out.Height) + 1
+		}
+		utxos = append(utxos, btcjson.GetAddressUtxosResult{
+			Address:       out.Address,
+			TxID:          out.OutPoint.Hash.String(),
+			OutputIndex:   out.OutPoint.Index,
+			Script:        hex.EncodeToString(out.TxOut.PkScript),
+			Satoshis:      out.TxOut.Value,
+			Height:        out.Height,
+			Confirmations: confirmations,
+		})
+	}
+	return utxos, nil
+}
+
+// HandleGetAddrManInfo implements the getaddrmaninfo command, returning the number of new and tried addresses and the
+// fill level of the buckets of the address manager. NOTE: This is a pod extension.
+func HandleGetAddrManInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	info := s.Cfg.ConnMgr.AddressBuckets()
+	return &btcjson.GetAddrManInfoResult{
+		New:             info.New,
+		Tried:           info.Tried,
+		Total:           info.New + info.Tried,
+		NewBucketSize:   info.NewBucketSize,
+		TriedBucketSize: info.TriedBucketSize,
+		NewBuckets:      info.NewBuckets,
+		TriedBuckets:    info.TriedBuckets,
+	}, nil
+}
+
+// HandleGetAuditLog implements the getauditlog command, returning the most recent entries of the audit log. NOTE: This
+// is a pod extension.
+func HandleGetAuditLog(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.GetAuditLogCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("getauditlog")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if s.Cfg.AuditLog == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "The audit log is not available",
+		}
+	}
+	entries, err := s.Cfg.AuditLog.Entries(*c.Count, *c.Skip)
+	if err != nil {
+		return nil, InternalRPCError(err.Error(), "Failed to read the audit log")
+	}
+	return entries, nil
+}
+
 // HandleGetBestBlock implements the getbestblock command.
 func HandleGetBestBlock(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	// All other "get block" commands give either the height, the hash, or both but require the block SHA. This gets
@@ -661,9 +1190,13 @@ func HandleGetBlockChainInfo(
 		BestBlockHash: chainSnapshot.Hash.String(),
 		Difficulty:    GetDifficultyRatio(chainSnapshot.Bits, params, 2),
 		MedianTime:    chainSnapshot.MedianTime.Unix(),
-		Pruned:        false,
+		Pruned:        chain.IsPruned(),
 		Bip9SoftForks: make(map[string]*btcjson.Bip9SoftForkDescription),
 	}
+	// The prune height is the height of the lowest block that is still stored.
+	if chainInfo.Pruned {
+		chainInfo.PruneHeight = chain.PruneHeight() + 1
+	}
 	// Next, populate the response with information describing the current status of soft-forks deployed via the
 	// super-majority block signalling mechanism.
 	height := chainSnapshot.Height
@@ -749,10 +1282,111 @@ func HandleGetBlockCount(
 	cmd interface{},
 	closeChan <-chan struct{},
 ) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GetBlockCountCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("getblockcount")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	pinned, err := s.PinnedState(c.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if pinned != nil {
+		return int64(pinned.Best().Height), nil
+	}
 	best := s.Cfg.Chain.BestSnapshot()
 	return int64(best.Height), nil
 }
 
+// BlockFilterTypes maps the filter type names of the getblockfilter command to the filter types of the CF index.
+var BlockFilterTypes = map[btcjson.FilterTypeName]wire.FilterType{
+	btcjson.FilterTypeBasic: wire.GCSFilterRegular,
+}
+
+// HandleGetBlockFilter implements the getblockfilter command, which returns the same compact filter as getcfilter
+// along with its filter header, with the filter type given by name as in Bitcoin Core.
+func HandleGetBlockFilter(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	if s.Cfg.CfIndex == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCNoCFIndex,
+			Message: "The CF index must be enabled for this command",
+		}
+	}
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GetBlockFilterCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("getblockfilter")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.BlockHash)
+	}
+	filterTypeName := btcjson.FilterTypeBasic
+	if c.FilterType != nil {
+		filterTypeName = *c.FilterType
+	}
+	filterType, ok := BlockFilterTypes[filterTypeName]
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Unknown filtertype " + string(filterTypeName),
+		}
+	}
+	if have, err := s.Cfg.Chain.HaveBlock(hash); err != nil || !have {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	filterBytes, err := s.Cfg.CfIndex.FilterByBlockHash(hash, filterType)
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to read filter")
+	}
+	headerBytes, err := s.Cfg.CfIndex.FilterHeaderByBlockHash(hash, filterType)
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to read filter header")
+	}
+	// The index only holds filters for blocks in the main chain that it has caught up to.
+	if len(filterBytes) == 0 || len(headerBytes) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Filter not found",
+		}
+	}
+	var header chainhash.Hash
+	if err = header.SetBytes(headerBytes); err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Invalid filter header")
+	}
+	return &btcjson.GetBlockFilterResult{
+		Filter: hex.EncodeToString(filterBytes),
+		Header: header.String(),
+	}, nil
+}
+
 // HandleGetBlockHash implements the getblockhash command.
 func HandleGetBlockHash(
 	s *Server,
@@ -775,7 +1409,16 @@ func HandleGetBlockHash(
 			// "invalid subcommand for addnode",
 		}
 	}
-	hash, err := s.Cfg.Chain.BlockHashByHeight(int32(c.Index))
+	pinned, err := s.PinnedState(c.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var hash *chainhash.Hash
+	if pinned != nil {
+		hash, err = pinned.BlockHashByHeight(int32(c.Index))
+	} else {
+		hash, err = s.Cfg.Chain.BlockHashByHeight(int32(c.Index))
+	}
 	if err != nil {
 		Error(err)
 		return nil, &btcjson.RPCError{
@@ -871,7 +1514,77 @@ func HandleGetBlockHeader(
 		Bits:          strconv.FormatInt(int64(blockHeader.Bits), 16),
 		Difficulty:    GetDifficultyRatio(blockHeader.Bits, params, a),
 	}
-	return blockHeaderReply, nil
+	return blockHeaderReply, nil
+}
+
+// HandleGetBlockSubsidy implements the getblocksubsidy command. It reports the subsidy a block at the height mined
+// with the algorithm pays, using the same calculation the coinbase of a block template is made with.
+func HandleGetBlockSubsidy(
+	s *Server,
+	cmd interface{},
+	closeChan <-chan struct{},
+) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GetBlockSubsidyCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("getblocksubsidy")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	params := s.Cfg.ChainParams
+	// default to the block that is being mined next
+	height := s.Cfg.Chain.BestSnapshot().Height + 1
+	if c.Height != nil {
+		if *c.Height < 0 {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "Block height out of range",
+			}
+		}
+		height = *c.Height
+	}
+	algo := s.Cfg.Algo
+	if c.Algo != nil {
+		algo = *c.Algo
+		if _, ok := fork.List[fork.GetCurrent(height)].Algos[algo]; !ok {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: fmt.Sprintf("Unknown algorithm %q at height %d", algo, height),
+			}
+		}
+	}
+	version := fork.GetAlgoVer(algo, height)
+	subsidy := mining.CalcCoinbaseSubsidy(params, height, version)
+	result := &btcjson.GetBlockSubsidyResult{
+		Height:  height,
+		Algo:    fork.GetAlgoName(version, height),
+		Fork:    fork.GetCurrent(height),
+		Subsidy: (subsidy.Miner + subsidy.Payees + subsidy.Core).ToDUO(),
+		Miner:   subsidy.Miner.ToDUO(),
+		Payees:  subsidy.Payees.ToDUO(),
+		Core:    subsidy.Core.ToDUO(),
+	}
+	// before the hard fork the subsidy halves every reduction interval
+	if result.Fork == 0 && params.SubsidyReductionInterval != 0 {
+		interval := params.SubsidyReductionInterval
+		result.HalvingInterval = interval
+		forkStart := fork.List[1].ActivationHeight
+		if fork.IsTestnet {
+			forkStart = fork.List[1].TestnetStart
+		}
+		if next := (height/interval + 1) * interval; next < forkStart {
+			result.NextHalving = next
+		}
+	}
+	return result, nil
 }
 
 // HandleGetBlockTemplate implements the getblocktemplate command. See https:// en.bitcoin.it/wiki/BIP_0022 and
@@ -924,10 +1637,10 @@ func HandleGetBlockTemplate(
 // See https://en.bitcoin.it/wiki/ BIP_0022 for more details.
 func HandleGetBlockTemplateLongPoll(
 	s *Server,
+	state *GBTWorkState,
 	longPollID string,
 	useCoinbaseValue bool, closeChan <-chan struct{},
 ) (interface{}, error) {
-	state := s.GBTWorkState
 	state.Lock()
 	// The state unlock is intentionally not deferred here since it needs to be manually unlocked before waiting for a
 	// notification about block template changes.
@@ -936,7 +1649,7 @@ func HandleGetBlockTemplateLongPoll(
 		return nil, err
 	}
 	// Just return the current block template if the long poll ID provided by the caller is invalid.
-	prevHash, lastGenerated, err := DecodeTemplateID(longPollID)
+	prevHash, lastGenerated, seq, err := DecodeTemplateID(longPollID)
 	if err != nil {
 		Error(err)
 		result, err := state.BlockTemplateResult(useCoinbaseValue, nil)
@@ -948,11 +1661,16 @@ func HandleGetBlockTemplateLongPoll(
 		state.Unlock()
 		return result, nil
 	}
+	// A long poll ID in the deprecated format only has the time the template was generated, so it is taken to be the
+	// current template if that was generated in the same second.
+	if seq == 0 && lastGenerated == state.LastGenerated.Unix() {
+		Debug("getblocktemplate long poll with a deprecated longpollid without a template sequence number")
+		seq = state.TemplateSeq
+	}
 	// Return the block template now if the specific block template/ identified by the long poll ID no longer matches
 	// the current block template as this means the provided template is stale.
 	prevTemplateHash := &state.Template.Block.Header.PrevBlock
-	if !prevHash.IsEqual(prevTemplateHash) ||
-		lastGenerated != state.LastGenerated.Unix() {
+	if !prevHash.IsEqual(prevTemplateHash) || seq != state.TemplateSeq {
 		// Include whether or not it is valid to submit work against the old block template depending on whether or not
 		// a solution has already been found and added to the block chain.
 		submitOld := prevHash.IsEqual(prevTemplateHash)
@@ -966,18 +1684,33 @@ func HandleGetBlockTemplateLongPoll(
 		state.Unlock()
 		return result, nil
 	}
-	// Register the previous hash and last generated time for notifications Get a channel that will be notified when the
-	// template associated with the provided ID is stale and a new block template should be returned to the caller.
-	longPollChan := state.TemplateUpdateChan(prevHash, lastGenerated)
+	// Register the previous hash and template sequence number for notifications Get a channel that will be notified
+	// when the template associated with the provided ID is stale and a new block template should be returned to the
+	// caller.
+	longPollChan, done, err := state.LongPoll.Register(prevHash, seq)
 	state.Unlock()
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: err.Error(),
+		}
+	}
+	timeout := time.NewTimer(LongPollTimeout)
 	select {
 	// When the client closes before it's time to send a reply, just return now so the goroutine doesn't hang around.
 	case <-closeChan:
+		timeout.Stop()
+		done()
 		return nil, ErrClientQuit
 	// Wait until signal received to send the reply.
 	case <-longPollChan:
 		// Fallthrough
+	// Clients that have waited long enough are sent the current template, so no client is held indefinitely.
+	case <-timeout.C:
+		// Fallthrough
 	}
+	timeout.Stop()
+	done()
 	// Get the lastest block template
 	state.Lock()
 	defer state.Unlock()
@@ -1030,11 +1763,30 @@ func HandleGetBlockTemplateProposal(
 	}
 	block := util.NewBlock(&msgBlock)
 	// Ensure the block is building from the expected previous block.
-	expectedPrevHash := s.Cfg.Chain.BestSnapshot().Hash
+	best := s.Cfg.Chain.BestSnapshot()
+	expectedPrevHash := best.Hash
 	prevHash := &block.MsgBlock().Header.PrevBlock
 	if !expectedPrevHash.IsEqual(prevHash) {
 		return "bad-prevblk", nil
 	}
+	block.SetHeight(best.Height + 1)
+	header := &msgBlock.Header
+	algo := fork.GetAlgoName(header.Version, best.Height+1)
+	// The time and target are checked on their own, so that a proposal differing from one checked before only by the
+	// time/increment and target mutations can be given the earlier result.
+	reason, err := CheckHeaderTimeAndTarget(s, header, best, algo)
+	if err != nil || reason != "" {
+		return reason, err
+	}
+	key, err := ProposalKey(&msgBlock)
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to serialize block proposal")
+	}
+	if result, ok := s.Proposals.Lookup(prevHash, algo, key, header.Timestamp); ok {
+		Debug("block proposal", key, "was checked before:", result)
+		return result, nil
+	}
 	if err := s.Cfg.Chain.CheckConnectBlockTemplate(0, block); err != nil {
 		if _, ok := err.(blockchain.RuleError); !ok {
 			errStr := fmt.Sprintf("failed to process block proposal: %v", err)
@@ -1046,12 +1798,36 @@ func HandleGetBlockTemplateProposal(
 			}
 		}
 		Info("rejected block proposal:", err)
-
-		return ChainErrToGBTErrString(err), nil
+		result := ChainErrToGBTErrString(err)
+		s.Proposals.Add(prevHash, key, header.Timestamp, result)
+		return result, nil
 	}
+	s.Proposals.Add(prevHash, key, header.Timestamp, nil)
 	return nil, nil
 }
 
+// CheckHeaderTimeAndTarget checks the time of a block header of the algorithm built on the best block, and that its
+// target is the one required at that time, returning the BIP 0022 reason the header is invalid, if it is.
+func CheckHeaderTimeAndTarget(s *Server, header *wire.BlockHeader, best *blockchain.BestState, algo string) (
+	reason string, err error) {
+	if !header.Timestamp.After(best.MedianTime) {
+		return "time-too-old", nil
+	}
+	maxTime := s.Cfg.TimeSource.AdjustedTime().Add(time.Second * blockchain.MaxTimeOffsetSeconds)
+	if header.Timestamp.After(maxTime) {
+		return "time-too-new", nil
+	}
+	bits, err := s.Cfg.Chain.CalcNextRequiredDifficulty(0, header.Timestamp, algo)
+	if err != nil {
+		Error(err)
+		return "", InternalRPCError(err.Error(), "Unable to calculate the required difficulty")
+	}
+	if header.Bits != bits {
+		return "bad-diffbits", nil
+	}
+	return "", nil
+}
+
 // HandleGetBlockTemplateRequest is a helper for handleGetBlockTemplate which deals with generating and returning block
 // templates to the caller. It handles both long poll requests as specified by BIP 0022 as well as regular requests.
 //
@@ -1108,14 +1884,26 @@ func HandleGetBlockTemplateRequest(
 			Message: "Pod is not yet synchronised...",
 		}
 	}
+	// Serve the templates of the algorithm requested, if any, rather than those of the default algorithm.
+	var algo string
+	if request != nil {
+		algo = request.Algo
+	}
+	workState, err := s.WorkState(algo)
+	if err != nil {
+		return nil, err
+	}
 	// When a long poll ID was provided, this is a long poll request by the client to be notified when block template
 	// referenced by the ID should be replaced with a new one.
 	if request != nil && request.LongPollID != "" {
-		return HandleGetBlockTemplateLongPoll(s, request.LongPollID,
+		result, err := HandleGetBlockTemplateLongPoll(s, workState, request.LongPollID,
 			useCoinbaseValue, closeChan)
+		if err != nil {
+			return nil, err
+		}
+		return ApplyRequestTarget(result, request.Target)
 	}
 	// Protect concurrent access when updating block templates.
-	workState := s.GBTWorkState
 	workState.Lock()
 	defer workState.Unlock()
 	// Get and return a block template. A new block template will be generated when the current best block has changed
@@ -1127,7 +1915,11 @@ func HandleGetBlockTemplateRequest(
 	if err := workState.UpdateBlockTemplate(s, useCoinbaseValue); err != nil {
 		return nil, err
 	}
-	return workState.BlockTemplateResult(useCoinbaseValue, nil)
+	result, err := workState.BlockTemplateResult(useCoinbaseValue, nil)
+	if err != nil || request == nil {
+		return result, err
+	}
+	return ApplyRequestTarget(result, request.Target)
 }
 
 // HandleGetCFilter implements the getcfilter command.
@@ -1307,6 +2099,9 @@ func HandleGetDifficulty(s *Server, cmd interface{}, closeChan <-chan struct{})
 // HandleGetGenerate implements the getgenerate command.
 func HandleGetGenerate(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) { // cpuminer
 	generating := s.StateCfg.Miner != nil
+	if s.StateCfg.LocalMiner != nil {
+		generating = s.StateCfg.LocalMiner.Mining()
+	}
 	if generating {
 		Debug("miner is running internally")
 	} else {
@@ -1320,13 +2115,11 @@ func HandleGetGenerate(s *Server, cmd interface{}, closeChan <-chan struct{}) (i
 // var startTime = time.Now()
 
 // HandleGetHashesPerSec implements the gethashespersec command.
-func HandleGetHashesPerSec(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) { // cpuminer
-	// return int64(s.,
-	// Cfg.CPUMiner.HashesPerSecond()), nil
-	// TODO: finish this - needs generator for momentary rate (ewma)
-	Debug("miner hashes per second - multicast thing TODO")
-	// simple average for now
-	return int(s.Cfg.Hashrate.Load()), nil
+func HandleGetHashesPerSec(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	if s.Cfg.MinerStats == nil {
+		return float64(0), nil
+	}
+	return s.Cfg.MinerStats.Hashrate(), nil
 }
 
 // HandleGetHeaders implements the getheaders command.
@@ -1447,6 +2240,8 @@ func HandleGetInfo(
 			DifficultyScrypt:  dScrypt,
 			TestNet:           (*s.Config.Network)[0] == 't',
 			RelayFee:          s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+			Errors:            s.AnnouncementWarnings(),
+			Announcements:     s.AnnouncementMessages(),
 		}
 	case 1:
 		foundcount, height := 0, best.Height
@@ -1503,6 +2298,8 @@ func HandleGetInfo(
 			DifficultyX11:       dX11,
 			TestNet:             (*s.Config.Network)[0] == 't',
 			RelayFee:            s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+			Errors:              s.AnnouncementWarnings(),
+			Announcements:       s.AnnouncementMessages(),
 		}
 	}
 	return ret, nil
@@ -1543,6 +2340,12 @@ func HandleGetMiningInfo(s *Server, cmd interface{},
 	}
 	var Difficulty, dScrypt, dSHA256D float64
 	var lastbitsScrypt, lastbitsSHA256D uint32
+	var longPoll LongPollStats
+	for _, state := range s.WorkStates() {
+		stats := state.LongPoll.Stats()
+		longPoll.Clients += stats.Clients
+		longPoll.Templates += stats.Templates
+	}
 	best := s.Cfg.Chain.BestSnapshot()
 	v := s.Cfg.Chain.Index.LookupNode(&best.Hash)
 	foundCount, height := 0, best.Height
@@ -1589,9 +2392,11 @@ func HandleGetMiningInfo(s *Server, cmd interface{},
 			DifficultyScrypt:   dScrypt,
 			// Generate:           s.Cfg.CPUMiner.IsMining(),
 			// GenProcLimit:       s.Cfg.CPUMiner.NumWorkers(),
-			// HashesPerSec:       int64(s.Cfg.CPUMiner.HashesPerSecond()),
-			NetworkHashPS: networkHashesPerSec,
-			PooledTx:      uint64(s.Cfg.TxMemPool.Count()),
+			HashesPerSec:      minerHashesPerSec(s),
+			LongPollClients:   int64(longPoll.Clients),
+			LongPollTemplates: int64(longPoll.Templates),
+			NetworkHashPS:     networkHashesPerSec,
+			PooledTx:          uint64(s.Cfg.TxMemPool.Count()),
 			TestNet:       (*s.Config.Network)[0] == 't',
 		}
 	case 1:
@@ -1635,6 +2440,9 @@ func HandleGetMiningInfo(s *Server, cmd interface{},
 			Difficulty:         Difficulty,
 			DifficultyScrypt:   dScrypt,
 			DifficultySHA256D:  dSHA256D,
+			HashesPerSec:       minerHashesPerSec(s),
+			LongPollClients:    int64(longPoll.Clients),
+			LongPollTemplates:  int64(longPoll.Templates),
 			NetworkHashPS:      networkHashesPerSec,
 			PooledTx:           uint64(s.Cfg.TxMemPool.Count()),
 			TestNet:            (*s.Config.Network)[0] == 't',
@@ -1643,6 +2451,23 @@ func HandleGetMiningInfo(s *Server, cmd interface{},
 	return ret, nil
 }
 
+// minerHashesPerSec returns the hashrate of the kopach workers mining on the node's controller.
+func minerHashesPerSec(s *Server) int64 {
+	if s.Cfg.MinerStats == nil {
+		return 0
+	}
+	return int64(s.Cfg.MinerStats.Hashrate())
+}
+
+// HandleGetMinerStats implements the getminerstats command.
+func HandleGetMinerStats(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	if s.Cfg.MinerStats == nil {
+		return &btcjson.GetMinerStatsResult{}, nil
+	}
+	stats := s.Cfg.MinerStats.Report(time.Now())
+	return &stats, nil
+}
+
 // HandleGetNetTotals implements the getnettotals command.
 func HandleGetNetTotals(
 	s *Server,
@@ -1760,6 +2585,56 @@ func HandleGetNetworkHashPS(s *Server, cmd interface{}, closeChan <-chan struct{
 	return hashesPerSec.Int64(), nil
 }
 
+// HandleGetNetworkInfo implements the getnetworkinfo command.
+func HandleGetNetworkInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	local := s.Cfg.ConnMgr.LocalAddresses()
+	localAddrs := make([]btcjson.LocalAddressesResult, len(local))
+	for i := range local {
+		localAddrs[i] = btcjson.LocalAddressesResult{
+			Address: local[i].Host,
+			Port:    local[i].Port,
+			Score:   int32(local[i].Score),
+		}
+	}
+	// Onion addresses are only dialed through a proxy, which is the onion proxy if one is configured.
+	onionProxy := *s.Config.OnionProxy
+	if onionProxy == "" {
+		onionProxy = *s.Config.Proxy
+	}
+	onionOnly := *s.Config.OnlyNet == "onion"
+	networks := []btcjson.NetworksResult{
+		{Name: "ipv4", Limited: onionOnly, Reachable: !onionOnly, Proxy: *s.Config.Proxy},
+		{Name: "ipv6", Limited: onionOnly, Reachable: !onionOnly, Proxy: *s.Config.Proxy},
+		{
+			Name:      "onion",
+			Limited:   !*s.Config.Onion,
+			Reachable: *s.Config.Onion && onionProxy != "",
+			Proxy:     onionProxy,
+		},
+	}
+	for i := range networks {
+		networks[i].ProxyRandomizeCredentials = networks[i].Proxy != "" && *s.Config.TorIsolation
+	}
+	return &btcjson.GetNetworkInfoResult{
+		Version: int32(
+			1000000*version.AppMajor +
+				10000*version.AppMinor +
+				100*version.AppPatch),
+		SubVersion:      fmt.Sprintf("/%s:%s/", UserAgentName, UserAgentVersion),
+		ProtocolVersion: int32(MaxProtocolVersion),
+		LocalServices:   fmt.Sprintf("%016x", uint64(s.Cfg.ConnMgr.LocalServices())),
+		LocalRelay:      !*s.Config.BlocksOnly,
+		TimeOffset:      int64(s.Cfg.TimeSource.Offset().Seconds()),
+		Connections:     s.Cfg.ConnMgr.ConnectedCount(),
+		NetworkActive:   true,
+		Networks:        networks,
+		RelayFee:        s.StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		LocalAddresses:  localAddrs,
+		Warnings:        s.AnnouncementWarnings(),
+		Announcements:   s.AnnouncementMessages(),
+	}, nil
+}
+
 // HandleGetPeerInfo implements the getpeerinfo command.
 func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	peers := s.Cfg.ConnMgr.ConnectedPeers()
@@ -1788,6 +2663,14 @@ func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (i
 			BanScore:       int32(p.GetBanScore()),
 			FeeFilter:      p.GetFeeFilter(),
 			SyncNode:       statsSnap.ID == syncPeerID,
+			BlockServeTime: float64(p.GetBlockServeTime() / time.Microsecond),
+			Permissions:    p.GetPermissions().Names(),
+			BytesSentPerMsg:  statsSnap.BytesSentPerMsg,
+			BytesRecvPerMsg:  statsSnap.BytesRecvPerMsg,
+			PingPercentiles:  PingPercentiles(statsSnap.PingSamples),
+			AddrRelayEnabled: p.IsAddrRelayEnabled(),
+			AddrV2:           p.ToPeer().WantsAddrV2(),
+			AddrProcessed:    p.GetAddrProcessed(),
 		}
 		if p.ToPeer().LastPingNonce() != 0 {
 			wait := float64(time.Since(statsSnap.LastPingTime).Nanoseconds())
@@ -1799,6 +2682,54 @@ func HandleGetPeerInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (i
 	return infos, nil
 }
 
+// HandleGetPeerStats implements the getpeerstats command.
+func HandleGetPeerStats(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	peers := s.Cfg.ConnMgr.ConnectedPeers()
+	totalBytesRecv, totalBytesSent := s.Cfg.ConnMgr.NetTotals()
+	reply := &btcjson.GetPeerStatsResult{
+		Peers:           len(peers),
+		TotalBytesRecv:  totalBytesRecv,
+		TotalBytesSent:  totalBytesSent,
+		BytesSentPerMsg: make(map[string]uint64),
+		BytesRecvPerMsg: make(map[string]uint64),
+		SyncPeer:        s.Cfg.SyncMgr.SyncPeerID(),
+		TimeMillis:      time.Now().UTC().UnixNano() / int64(time.Millisecond),
+	}
+	var samples []int64
+	for _, p := range peers {
+		statsSnap := p.ToPeer().StatsSnapshot()
+		if statsSnap.Inbound {
+			reply.Inbound++
+		} else {
+			reply.Outbound++
+		}
+		for command, n := range statsSnap.BytesSentPerMsg {
+			reply.BytesSentPerMsg[command] += n
+		}
+		for command, n := range statsSnap.BytesRecvPerMsg {
+			reply.BytesRecvPerMsg[command] += n
+		}
+		samples = append(samples, statsSnap.PingSamples...)
+	}
+	reply.PingPercentiles = PingPercentiles(samples)
+	return reply, nil
+}
+
+// PingPercentiles returns the distribution of ping round trip times in microseconds. The samples are sorted in place.
+func PingPercentiles(samples []int64) btcjson.PingPercentilesResult {
+	if len(samples) == 0 {
+		return btcjson.PingPercentilesResult{}
+	}
+	p50 := peer.Percentile(samples, 50)
+	return btcjson.PingPercentilesResult{
+		Samples: len(samples),
+		Min:     float64(samples[0]),
+		P50:     float64(p50),
+		P90:     float64(peer.Percentile(samples, 90)),
+		Max:     float64(samples[len(samples)-1]),
+	}
+}
+
 // HandleGetRawMempool implements the getrawmempool command.
 func HandleGetRawMempool(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	c := cmd.(*btcjson.GetRawMempoolCmd)
@@ -1865,6 +2796,14 @@ func HandleGetRawTransaction(s *Server, cmd interface{}, closeChan <-chan struct
 			context := "Failed to retrieve transaction location"
 			return nil, InternalRPCError(err.Error(), context)
 		}
+		// Transactions with witness data may also be looked up by their wtxid.
+		if blockRegion == nil && s.Cfg.WTxIndex != nil {
+			if blockRegion, err = s.Cfg.WTxIndex.TxBlockRegion(txHash); err != nil {
+				Error(err)
+				context := "Failed to retrieve transaction location"
+				return nil, InternalRPCError(err.Error(), context)
+			}
+		}
 		if blockRegion == nil {
 			return nil, NoTxInfoError(txHash)
 		}
@@ -1940,6 +2879,151 @@ func HandleGetRawTransaction(s *Server, cmd interface{}, closeChan <-chan struct
 	return *rawTxn, nil
 }
 
+// HandleGetSnapshot implements the getsnapshot command. It pins the current state of the chain and returns a token that
+// getblockhash and gettxout accept to read the chain as of the pinned block. NOTE: This is a pod extension.
+func HandleGetSnapshot(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	token, pinned, err := s.Snapshots.Pin(s.Cfg.Chain)
+	if err != nil {
+		if err == ErrTooManySnapshots {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCMisc,
+				Message: err.Error(),
+			}
+		}
+		return nil, InternalRPCError(err.Error(), "Could not pin chain snapshot")
+	}
+	best := pinned.Best()
+	return &btcjson.GetSnapshotResult{
+		Snapshot: token,
+		Height:   best.Height,
+		Hash:     best.Hash.String(),
+		Expires:  int64(SnapshotTimeout / time.Second),
+	}, nil
+}
+
+// HandleGetSpentInfo implements the getspentinfo command. It looks up the transaction input in the main chain that
+// spends the requested output in the spent output index. NOTE: This is a pod extension.
+func HandleGetSpentInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.GetSpentInfoCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("getspentinfo")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	// Respond with an error if the spent output index is not enabled.
+	spentIndex := s.Cfg.SpentIndex
+	if spentIndex == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Spent output index must be enabled (--spentindex)",
+		}
+	}
+	txHash, err := chainhash.NewHashFromStr(c.TxID)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.TxID)
+	}
+	info, err := spentIndex.SpentInfo(wire.NewOutPoint(txHash, c.Index))
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to load spent output index entry")
+	}
+	if info == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidAddressOrKey,
+			Message: "Unable to get spent info",
+		}
+	}
+	return &btcjson.GetSpentInfoResult{
+		TxID:   info.TxID.String(),
+		Index:  info.Index,
+		Height: info.Height,
+	}, nil
+}
+
+// HandleGetSyncStatus implements the getsyncstatus command. NOTE: This is a pod extension.
+func HandleGetSyncStatus(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	st := s.Cfg.SyncMgr.SyncStatus()
+	// The indexes are caught up when the chain is opened, so with no index manager there is nothing to catch up.
+	indexHeight, indexTarget := int32(0), int32(0)
+	if s.Cfg.IndexManager != nil {
+		indexHeight, indexTarget = s.Cfg.IndexManager.CatchUpProgress()
+	}
+	connected := 0.0
+	if st.Stage != netsync.SyncStageConnecting {
+		connected = 100
+	}
+	return &btcjson.GetSyncStatusResult{
+		Stage:      st.Stage,
+		SyncPeer:   st.SyncPeer,
+		Blocks:     st.BlockHeight,
+		Headers:    st.HeaderHeight,
+		PeerBlocks: st.PeerHeight,
+		Stages: []btcjson.SyncStageResult{
+			{Name: netsync.SyncStageConnecting, Progress: connected},
+			{Name: netsync.SyncStageHeaders, Progress: SyncProgress(st.HeaderHeight, st.HeaderTarget)},
+			{Name: netsync.SyncStageBlocks, Progress: SyncProgress(st.BlockHeight, st.PeerHeight)},
+			{Name: netsync.SyncStageIndexes, Progress: SyncProgress(indexHeight, indexTarget)},
+		},
+		BlocksPerSecond: st.BlocksPerSecond,
+		BytesPerSecond:  st.BytesPerSecond,
+		ETA:             int64(st.ETA / time.Second),
+	}, nil
+}
+
+// SyncProgress returns how far a sync stage has got from height zero to its target height as a percentage.
+func SyncProgress(height, target int32) float64 {
+	if target <= 0 || height >= target {
+		return 100
+	}
+	if height <= 0 {
+		return 0
+	}
+	return math.Floor(float64(height)/float64(target)*10000) / 100
+}
+
+// HandleGetTelemetryInfo implements the gettelemetryinfo command. The report that would be sent is returned even when
+// telemetry is disabled so users can see exactly what opting in shares.
+func HandleGetTelemetryInfo(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	if s.Cfg.Telemetry == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Telemetry reporter is not available",
+		}
+	}
+	st := s.Cfg.Telemetry.Status()
+	var lastSent int64
+	if !st.LastSent.IsZero() {
+		lastSent = st.LastSent.Unix()
+	}
+	return &btcjson.GetTelemetryInfoResult{
+		Enabled:     st.Enabled,
+		Endpoint:    st.Endpoint,
+		Interval:    int64(st.Interval / time.Second),
+		ReportsSent: st.Sent,
+		LastSent:    lastSent,
+		LastError:   st.LastError,
+		Report: btcjson.TelemetryReportResult{
+			Session: st.Report.Session,
+			Version: st.Report.Version,
+			OS:      st.Report.OS,
+			Arch:    st.Report.Arch,
+			Network: st.Report.Network,
+			Height:  st.Report.Height,
+			Peers:   st.Report.Peers,
+			Synced:  st.Report.Synced,
+			Uptime:  st.Report.Uptime,
+		},
+	}, nil
+}
+
 // HandleGetTxOut handles gettxout commands.
 func HandleGetTxOut(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	var msg string
@@ -1976,6 +3060,14 @@ func HandleGetTxOut(s *Server, cmd interface{}, closeChan <-chan struct{}) (inte
 	if c.IncludeMempool != nil {
 		includeMempool = *c.IncludeMempool
 	}
+	// A snapshot reads the chain as of the block it was pinned at, which the mempool does not match.
+	pinned, err := s.PinnedState(c.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if pinned != nil {
+		includeMempool = false
+	}
 	// TODO: This is racy.  It should attempt to fetch it directly and check the error.
 	if includeMempool && s.Cfg.TxMemPool.HaveTransaction(txHash) {
 		tx, err := s.Cfg.TxMemPool.FetchTransaction(txHash)
@@ -2005,7 +3097,14 @@ func HandleGetTxOut(s *Server, cmd interface{}, closeChan <-chan struct{}) (inte
 		isCoinbase = blockchain.IsCoinBaseTx(mtx)
 	} else {
 		out := wire.OutPoint{Hash: *txHash, Index: c.Vout}
-		entry, err := s.Cfg.Chain.FetchUtxoEntry(out)
+		var entry *blockchain.UtxoEntry
+		best := s.Cfg.Chain.BestSnapshot()
+		if pinned != nil {
+			entry, err = pinned.FetchUtxoEntry(out)
+			best = pinned.Best()
+		} else {
+			entry, err = s.Cfg.Chain.FetchUtxoEntry(out)
+		}
 		if err != nil {
 			Error(err)
 			return nil, NoTxInfoError(txHash)
@@ -2016,7 +3115,6 @@ func HandleGetTxOut(s *Server, cmd interface{}, closeChan <-chan struct{}) (inte
 		if entry == nil || entry.IsSpent() {
 			return nil, nil
 		}
-		best := s.Cfg.Chain.BestSnapshot()
 		bestBlockHash = best.Hash.String()
 		confirmations = 1 + best.Height - entry.BlockHeight()
 		value = entry.Amount()
@@ -2046,59 +3144,251 @@ func HandleGetTxOut(s *Server, cmd interface{}, closeChan <-chan struct{}) (inte
 		},
 		Coinbase: isCoinbase,
 	}
-	return txOutReply, nil
+	return txOutReply, nil
+}
+
+// HandleGetTxSpendingPrevOut handles gettxspendingprevout commands. For each of the given outputs it reports the mempool
+// transaction spending it, if there is one.
+func HandleGetTxSpendingPrevOut(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.GetTxSpendingPrevOutCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("gettxspendingprevout")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if len(c.Outputs) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Outputs must not be empty",
+		}
+	}
+	results := make([]btcjson.GetTxSpendingPrevOutResult, 0, len(c.Outputs))
+	for _, output := range c.Outputs {
+		txHash, err := chainhash.NewHashFromStr(output.Txid)
+		if err != nil {
+			Error(err)
+			return nil, DecodeHexError(output.Txid)
+		}
+		result := btcjson.GetTxSpendingPrevOutResult{
+			Txid: output.Txid,
+			Vout: output.Vout,
+		}
+		if spender := s.Cfg.TxMemPool.CheckSpend(*wire.NewOutPoint(txHash, output.Vout)); spender != nil {
+			result.SpendingTxid = spender.Hash().String()
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// HandleHelp implements the help command.
+func HandleHelp(s *Server, cmd interface{}, closeChan <-chan struct{}) (
+	interface{}, error) {
+	var msg string
+	var err error
+	// c, ok := cmd.(*btcjson.GetRawTransactionCmd)
+	c, ok := cmd.(*btcjson.HelpCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCUsage(true)
+		if err != nil {
+			msg = err.Error() + "\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+			// "invalid subcommand for addnode",
+		}
+	}
+	// Provide a usage overview of all commands when no specific command was specified.
+	var command string
+	if c.Command != nil {
+		command = *c.Command
+	}
+	if command == "" {
+		usage, err := s.HelpCacher.RPCUsage(false)
+		if err != nil {
+			Error(err)
+			context := "Failed to generate RPC usage"
+			return nil, InternalRPCError(err.Error(), context)
+		}
+		return usage, nil
+	}
+	// Check that the command asked for is supported and implemented. Only search the main list of handlers since help
+	// should not be provided for commands that are unimplemented or related to wallet functionality.
+	if _, ok := RPCHandlers[command]; !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Unknown command: " + command,
+		}
+	}
+	// Get the help for the command.
+	help, err := s.HelpCacher.RPCMethodHelp(command)
+	if err != nil {
+		Error(err)
+		context := "Failed to generate help"
+		return nil, InternalRPCError(err.Error(), context)
+	}
+	return help, nil
+}
+
+// HandleInvalidateBlock implements the invalidateblock command.
+func HandleInvalidateBlock(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.InvalidateBlockCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("invalidateblock")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.BlockHash)
+	}
+	err = s.Cfg.Chain.InvalidateBlock(hash)
+	if err == blockchain.ErrUnknownBlock {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to invalidate block")
+	}
+	return nil, nil
+}
+
+// HandleListBanned implements the listbanned command.
+func HandleListBanned(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	bans := s.Cfg.ConnMgr.ListBanned()
+	result := make([]btcjson.ListBannedResult, len(bans))
+	for i, ban := range bans {
+		result[i] = btcjson.ListBannedResult{
+			Address:     ban.Subnet.String(),
+			BannedUntil: ban.Until.Unix(),
+			BanCreated:  ban.Created.Unix(),
+			BanReason:   ban.Reason,
+		}
+	}
+	return result, nil
+}
+
+// HandleLoadAddrMan implements the loadaddrman command, adding the addresses of a file written by dumpaddrman, or of a
+// peers file, that are not known yet. Relative file names are in the data directory. NOTE: This is a pod extension.
+func HandleLoadAddrMan(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.LoadAddrManCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("loadaddrman")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	filename := DataFilename(s, c.Filename)
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Could not open " + filename + ": " + err.Error(),
+		}
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			Error(err)
+		}
+	}()
+	added, err := s.Cfg.ConnMgr.ImportAddresses(f)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDeserialization,
+			Message: "Could not load addresses: " + err.Error(),
+		}
+	}
+	info := s.Cfg.ConnMgr.AddressBuckets()
+	return &btcjson.LoadAddrManResult{
+		Added:     added,
+		Addresses: info.New + info.Tried,
+	}, nil
 }
 
-// HandleHelp implements the help command.
-func HandleHelp(s *Server, cmd interface{}, closeChan <-chan struct{}) (
-	interface{}, error) {
+// HandleLoadUTXOSet implements the loadutxoset command, bootstrapping the chain from a snapshot of the UTXO set written
+// by dumputxoset. Only the snapshots known to the network parameters are accepted. This is a pod extension.
+func HandleLoadUTXOSet(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	var msg string
-	var err error
-	// c, ok := cmd.(*btcjson.GetRawTransactionCmd)
-	c, ok := cmd.(*btcjson.HelpCmd)
+	c, ok := cmd.(*btcjson.LoadUTXOSetCmd)
 	if !ok {
-		var h string
-		h, err = s.HelpCacher.RPCUsage(true)
+		h, err := s.HelpCacher.RPCMethodHelp("loadutxoset")
 		if err != nil {
-			msg = err.Error() + "\n"
+			msg = err.Error() + "\n\n"
 		}
 		msg += h
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCInvalidParameter,
 			Message: msg,
-			// "invalid subcommand for addnode",
 		}
 	}
-	// Provide a usage overview of all commands when no specific command was specified.
-	var command string
-	if c.Command != nil {
-		command = *c.Command
+	filename := DataFilename(s, c.Filename)
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Could not open " + filename + ": " + err.Error(),
+		}
 	}
-	if command == "" {
-		usage, err := s.HelpCacher.RPCUsage(false)
-		if err != nil {
+	defer func() {
+		if err := f.Close(); err != nil {
 			Error(err)
-			context := "Failed to generate RPC usage"
-			return nil, InternalRPCError(err.Error(), context)
 		}
-		return usage, nil
+	}()
+	snap, err := s.Cfg.Chain.LoadUTXOSnapshot(f)
+	if err == blockchain.ErrSnapshotIndexes {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "The tx, address and committed filter indexes must be disabled to load a UTXO set snapshot",
+		}
 	}
-	// Check that the command asked for is supported and implemented. Only search the main list of handlers since help
-	// should not be provided for commands that are unimplemented or related to wallet functionality.
-	if _, ok := RPCHandlers[command]; !ok {
+	if err == blockchain.ErrNoAssumeUTXO {
 		return nil, &btcjson.RPCError{
-			Code:    btcjson.ErrRPCInvalidParameter,
-			Message: "Unknown command: " + command,
+			Code:    btcjson.ErrRPCMisc,
+			Message: "UTXO set snapshots can not be loaded on this network yet, as no verified snapshot has been added to its parameters",
 		}
 	}
-	// Get the help for the command.
-	help, err := s.HelpCacher.RPCMethodHelp(command)
 	if err != nil {
-		Error(err)
-		context := "Failed to generate help"
-		return nil, InternalRPCError(err.Error(), context)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDeserialization,
+			Message: "Could not load the UTXO set snapshot: " + err.Error(),
+		}
 	}
-	return help, nil
+	return &btcjson.UTXOSetSnapshotResult{
+		Filename: filename,
+		Hash:     snap.Hash.String(),
+		Height:   snap.Height,
+		UTXOHash: snap.UTXOHash.String(),
+		Coins:    snap.Coins,
+	}, nil
 }
 
 // HandleNode handles node commands.
@@ -2216,6 +3506,173 @@ func HandlePing(s *Server, cmd interface{}, closeChan <-chan struct{}) (
 	return nil, nil
 }
 
+// HandleReconsiderBlock implements the reconsiderblock command.
+func HandleReconsiderBlock(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.ReconsiderBlockCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("reconsiderblock")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hash, err := chainhash.NewHashFromStr(c.BlockHash)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(c.BlockHash)
+	}
+	err = s.Cfg.Chain.ReconsiderBlock(hash)
+	if err == blockchain.ErrUnknownBlock {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+	}
+	if err != nil {
+		Error(err)
+		return nil, InternalRPCError(err.Error(), "Failed to reconsider block")
+	}
+	return nil, nil
+}
+
+// HandleReleaseSnapshot implements the releasesnapshot command. NOTE: This is a pod extension.
+func HandleReleaseSnapshot(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.ReleaseSnapshotCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("releasesnapshot")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	if !s.Snapshots.Release(c.Snapshot) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Unknown or expired snapshot " + c.Snapshot,
+		}
+	}
+	return nil, nil
+}
+
+// HandleScanTxOutSet implements the scantxoutset command. The scan runs within the call, so there is never a scan in
+// progress to report the status of or to abort.
+func HandleScanTxOutSet(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.ScanTxOutSetCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("scantxoutset")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	switch c.Action {
+	case "start":
+	case "status":
+		return nil, nil
+	case "abort":
+		return false, nil
+	default:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Invalid action " + c.Action,
+		}
+	}
+	if c.ScanObjects == nil || len(*c.ScanObjects) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Scan objects are required for the start action",
+		}
+	}
+	// The scan objects are kept by the script they describe, to report which of them each output was found for.
+	descs := make(map[string]string)
+	var pkScripts [][]byte
+	for _, desc := range *c.ScanObjects {
+		var pkScript []byte
+		if pkScript, err = DescriptorScript(desc, s.Cfg.ChainParams); err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidAddressOrKey,
+				Message: "Invalid scan object " + desc + ": " + err.Error(),
+			}
+		}
+		if _, ok := descs[string(pkScript)]; !ok {
+			descs[string(pkScript)] = desc
+			pkScripts = append(pkScripts, pkScript)
+		}
+	}
+	scan, err := s.Cfg.Chain.ScanUTXOSet(pkScripts)
+	if err != nil {
+		return nil, InternalRPCError(err.Error(), "Could not scan the UTXO set")
+	}
+	result := &btcjson.ScanTxOutSetResult{
+		Success:   true,
+		TxOuts:    scan.Scanned,
+		Height:    int64(scan.Height),
+		BestBlock: scan.Hash.String(),
+		Unspents:  []btcjson.ScanTxOutSetUnspent{},
+	}
+	var total util.Amount
+	for _, out := range scan.Outputs {
+		amount := util.Amount(out.Entry.Amount())
+		total += amount
+		result.Unspents = append(result.Unspents, btcjson.ScanTxOutSetUnspent{
+			TxID:         out.OutPoint.Hash.String(),
+			Vout:         out.OutPoint.Index,
+			ScriptPubKey: hex.EncodeToString(out.Entry.PkScript()),
+			Desc:         descs[string(out.Entry.PkScript())],
+			Amount:       amount.ToDUO(),
+			Height:       int64(out.Entry.BlockHeight()),
+		})
+	}
+	result.TotalAmount = total.ToDUO()
+	return result, nil
+}
+
+// DescriptorScript returns the public key script described by an addr(<address>) or raw(<hex script>) output
+// descriptor. A checksum following the descriptor is ignored.
+func DescriptorScript(desc string, params *netparams.Params) ([]byte, error) {
+	if i := strings.IndexByte(desc, '#'); i >= 0 {
+		desc = desc[:i]
+	}
+	open := strings.IndexByte(desc, '(')
+	if open < 0 || !strings.HasSuffix(desc, ")") {
+		return nil, errors.New("not an output descriptor")
+	}
+	arg := desc[open+1 : len(desc)-1]
+	switch desc[:open] {
+	case "addr":
+		addr, err := util.DecodeAddress(arg, params)
+		if err != nil {
+			return nil, err
+		}
+		if !addr.IsForNet(params) {
+			return nil, errors.New("address is for the wrong network")
+		}
+		return txscript.PayToAddrScript(addr)
+	case "raw":
+		return hex.DecodeString(arg)
+	}
+	return nil, errors.New("only addr and raw descriptors are supported")
+}
+
 // HandleSearchRawTransactions implements the searchrawtransactions command.
 // TODO: simplify this, break it up
 func HandleSearchRawTransactions(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
@@ -2488,6 +3945,12 @@ func HandleSendRawTransaction(
 	}
 	// Use 0 for the tag to represent local node.
 	tx := util.NewTx(&msgTx)
+	// A transaction that is already in the memory pool is relayed again instead of being rejected as a duplicate, so a
+	// wallet can rebroadcast a transaction its peers have dropped.
+	if txD, err := s.Cfg.TxMemPool.FetchTxDesc(tx.Hash()); err == nil {
+		s.Cfg.ConnMgr.RelayTransactions([]*mempool.TxDesc{txD})
+		return tx.Hash().String(), nil
+	}
 	acceptedTxs, err := s.Cfg.TxMemPool.ProcessTransaction(s.Cfg.Chain, tx, false, false, 0)
 	if err != nil {
 		Error(err)
@@ -2502,6 +3965,7 @@ func HandleSendRawTransaction(
 				"failed to process transaction %v: %v", tx.Hash(), err,
 			)
 		}
+		s.NotifyMempoolEvent(tx, "", err)
 		return nil, &btcjson.RPCError{
 			Code:    btcjson.ErrRPCDeserialization,
 			Message: "TX rejected: " + err.Error(),
@@ -2523,6 +3987,7 @@ func HandleSendRawTransaction(
 	s.Cfg.ConnMgr.RelayTransactions(acceptedTxs)
 	// Notify both websocket and getblocktemplate long poll clients of all newly accepted transactions.
 	s.NotifyNewTransactions(acceptedTxs)
+	s.NotifyMempoolEvent(tx, "", nil)
 	// Keep track of all the sendrawtransaction request txns so that they can be rebroadcast if they don't make their
 	// way into a block.
 	txD := acceptedTxs[0]
@@ -2531,6 +3996,66 @@ func HandleSendRawTransaction(
 	return tx.Hash().String(), nil
 }
 
+// HandleSetBan implements the setban command. The ban time is in seconds from now, or the time in seconds since the Unix
+// epoch the ban ends when it is absolute. Bans without a ban time last for the configured ban duration.
+func HandleSetBan(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	c, ok := cmd.(*btcjson.SetBanCmd)
+	if !ok {
+		h, err := s.HelpCacher.RPCMethodHelp("setban")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	subnet, err := connmgr.ParseSubnet(c.Subnet)
+	if err != nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCClientInvalidIPOrSubnet,
+			Message: err.Error(),
+		}
+	}
+	switch c.SubCmd {
+	case btcjson.SBAdd:
+		var banTime int64
+		if c.BanTime != nil {
+			banTime = *c.BanTime
+		}
+		var until time.Time
+		switch {
+		case banTime < 0:
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "ban time can't be negative",
+			}
+		case banTime == 0:
+			until = time.Now().Add(*s.Config.BanDuration)
+		case c.Absolute != nil && *c.Absolute:
+			until = time.Unix(banTime, 0)
+		default:
+			until = time.Now().Add(time.Duration(banTime) * time.Second)
+		}
+		s.Cfg.ConnMgr.Ban(subnet, until)
+	case btcjson.SBRemove:
+		if err = s.Cfg.ConnMgr.Unban(subnet); err != nil {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCClientInvalidIPOrSubnet,
+				Message: err.Error(),
+			}
+		}
+	default:
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "invalid subcommand for setban",
+		}
+	}
+	return nil, nil
+}
+
 // HandleSetGenerate implements the setgenerate command.
 func HandleSetGenerate(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) { // cpuminer
 	var msg string
@@ -2596,6 +4121,14 @@ func HandleSetGenerate(s *Server, cmd interface{}, closeChan <-chan struct{}) (i
 	}
 	Debug("saving configuration")
 	save.Pod(s.Config)
+	// with the in-process miner the threads are started in the node rather than in a kopach process
+	if s.StateCfg.LocalMiner != nil {
+		s.StateCfg.LocalMiner.Stop()
+		if *s.Config.Generate && *s.Config.GenThreads != 0 {
+			s.StateCfg.LocalMiner.Start(*s.Config.GenThreads)
+		}
+		return nil, nil
+	}
 	if *s.Config.Generate && *s.Config.GenThreads != 0 {
 		Debug("starting miner")
 		args := []string{os.Args[0], "-D", *s.Config.DataDir}
@@ -2686,6 +4219,146 @@ func HandleSubmitBlock(s *Server, cmd interface{}, closeChan <-chan struct{}) (i
 	return nil, nil
 }
 
+// HandleSubmitHeader implements the submitheader command, which checks a block header would be valid on the best block
+// without the transactions of the block, so miners can check their work before building a full block to submit.
+func HandleSubmitHeader(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.SubmitHeaderCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("submitheader")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	hexStr := c.HexData
+	if len(hexStr)%2 != 0 {
+		hexStr = "0" + hexStr
+	}
+	serializedHeader, err := hex.DecodeString(hexStr)
+	if err != nil {
+		Error(err)
+		return nil, DecodeHexError(hexStr)
+	}
+	if len(serializedHeader) != wire.MaxBlockHeaderPayload {
+		return nil, &btcjson.RPCError{
+			Code: btcjson.ErrRPCDeserialization,
+			Message: fmt.Sprintf("Header decode failed: a header is %d bytes, not %d",
+				wire.MaxBlockHeaderPayload, len(serializedHeader)),
+		}
+	}
+	var header wire.BlockHeader
+	if err = header.Deserialize(bytes.NewReader(serializedHeader)); err != nil {
+		Error(err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCDeserialization,
+			Message: "Header decode failed: " + err.Error(),
+		}
+	}
+	best := s.Cfg.Chain.BestSnapshot()
+	if !header.PrevBlock.IsEqual(&best.Hash) {
+		return "bad-prevblk", nil
+	}
+	height := best.Height + 1
+	algo := fork.GetAlgoName(header.Version, height)
+	if _, ok := fork.List[fork.GetCurrent(height)].Algos[algo]; !ok {
+		return "bad-version", nil
+	}
+	reason, err := CheckHeaderTimeAndTarget(s, &header, best, algo)
+	if err != nil || reason != "" {
+		return reason, err
+	}
+	block := util.NewBlock(&wire.MsgBlock{Header: header})
+	if err = blockchain.CheckProofOfWork(block, fork.GetMinDiff(algo, height), height); err != nil {
+		if _, ok := err.(blockchain.RuleError); !ok {
+			Error(err)
+			return nil, InternalRPCError(err.Error(), "Unable to check the proof of work")
+		}
+		return ChainErrToGBTErrString(err), nil
+	}
+	Debug("header", header.BlockHashWithAlgos(height), "of", algo, "is valid on", best.Hash)
+	return nil, nil
+}
+
+// HandleTestMempoolAccept implements the testmempoolaccept command.
+func HandleTestMempoolAccept(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	var msg string
+	var err error
+	c, ok := cmd.(*btcjson.TestMempoolAcceptCmd)
+	if !ok {
+		var h string
+		h, err = s.HelpCacher.RPCMethodHelp("testmempoolaccept")
+		if err != nil {
+			msg = err.Error() + "\n\n"
+		}
+		msg += h
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: msg,
+		}
+	}
+	// The maximum fee rate is in DUO/kB, and 0 allows any fee rate.
+	var maxFeeRate util.Amount
+	if c.MaxFeeRate != nil {
+		if maxFeeRate, err = util.NewAmount(*c.MaxFeeRate); err != nil || maxFeeRate < 0 {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "Invalid maxfeerate",
+			}
+		}
+	}
+	results := make([]btcjson.TestMempoolAcceptResult, 0, len(c.RawTxns))
+	for _, hexStr := range c.RawTxns {
+		if len(hexStr)%2 != 0 {
+			hexStr = "0" + hexStr
+		}
+		serializedTx, err := hex.DecodeString(hexStr)
+		if err != nil {
+			Error(err)
+			return nil, DecodeHexError(hexStr)
+		}
+		var msgTx wire.MsgTx
+		if err = msgTx.Deserialize(bytes.NewReader(serializedTx)); err != nil {
+			Error(err)
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCDeserialization,
+				Message: "TX decode failed: " + err.Error(),
+			}
+		}
+		// Each transaction is checked against the mempool on its own, so one can not spend the outputs of another.
+		tx := util.NewTx(&msgTx)
+		result := btcjson.TestMempoolAcceptResult{
+			Txid:  tx.Hash().String(),
+			Wtxid: tx.WitnessHash().String(),
+		}
+		acceptance, err := s.Cfg.TxMemPool.CheckMempoolAcceptance(s.Cfg.Chain, tx)
+		switch {
+		case err != nil:
+			result.RejectReason = err.Error()
+		case len(acceptance.MissingParents) > 0:
+			result.RejectReason = "missing-inputs"
+		case maxFeeRate > 0 && acceptance.TxFee*1000/acceptance.TxSize > int64(maxFeeRate):
+			result.RejectReason = "max-fee-exceeded"
+		default:
+			result.Allowed = true
+			result.VSize = acceptance.TxSize
+			result.Fees = &btcjson.TestMempoolAcceptFees{Base: util.Amount(acceptance.TxFee).ToDUO()}
+			for _, replaced := range acceptance.Replaces {
+				result.Replaces = append(result.Replaces, replaced.Hash().String())
+			}
+			sort.Strings(result.Replaces)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 // HandleUnimplemented is the handler for commands that should ultimately be supported but are not yet implemented.
 func HandleUnimplemented(s *Server, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 	return nil, ErrRPCUnimplemented
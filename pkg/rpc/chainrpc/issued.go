@@ -0,0 +1,69 @@
+package chainrpc
+
+import (
+	"sync"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// MaxIssuedTemplates is the number of the block templates most recently issued on the best block that are kept to tell
+// miners when a transaction in their work leaves the mempool.
+const MaxIssuedTemplates = 16
+
+// issuedTemplate is a block template handed out to miners and the transactions it includes, apart from the coinbase.
+type issuedTemplate struct {
+	id  string
+	txs map[chainhash.Hash]struct{}
+}
+
+// IssuedTemplates keeps the transactions of the block templates most recently issued to miners on the current best
+// block, so that the miners can be notified when a transaction in their work is evicted or replaced in the mempool.
+//
+// Like LongPollWaiters it has its own lock, as it is consulted from mempool callbacks that must never wait for the work
+// state lock, which is held while the mempool is read to generate block templates.
+type IssuedTemplates struct {
+	sync.Mutex
+	prevHash  chainhash.Hash
+	templates []issuedTemplate
+	max       int
+}
+
+// NewIssuedTemplates creates a record of the last max block templates issued on the best block
+func NewIssuedTemplates(max int) *IssuedTemplates {
+	return &IssuedTemplates{max: max}
+}
+
+// Add records that the block template with the template ID, built on the block with the previous hash and including
+// the transactions, was issued. The templates issued on an earlier block are forgotten as they are stale already.
+func (t *IssuedTemplates) Add(prevHash *chainhash.Hash, id string, txHashes []chainhash.Hash) {
+	t.Lock()
+	defer t.Unlock()
+	if !t.prevHash.IsEqual(prevHash) {
+		t.prevHash = *prevHash
+		t.templates = t.templates[:0]
+	}
+	// a template is usually issued many times, to every miner asking for work until it is regenerated
+	if n := len(t.templates); n > 0 && t.templates[n-1].id == id {
+		return
+	}
+	txs := make(map[chainhash.Hash]struct{}, len(txHashes))
+	for i := range txHashes {
+		txs[txHashes[i]] = struct{}{}
+	}
+	if len(t.templates) >= t.max {
+		t.templates = append(t.templates[:0], t.templates[len(t.templates)-t.max+1:]...)
+	}
+	t.templates = append(t.templates, issuedTemplate{id: id, txs: txs})
+}
+
+// Containing returns the template IDs of the issued block templates that include the transaction, most recent first.
+func (t *IssuedTemplates) Containing(txHash *chainhash.Hash) (ids []string) {
+	t.Lock()
+	defer t.Unlock()
+	for i := len(t.templates) - 1; i >= 0; i-- {
+		if _, ok := t.templates[i].txs[*txHash]; ok {
+			ids = append(ids, t.templates[i].id)
+		}
+	}
+	return
+}
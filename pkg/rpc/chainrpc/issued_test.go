@@ -0,0 +1,36 @@
+package chainrpc
+
+import (
+	"reflect"
+	"testing"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// TestIssuedTemplates ensures that the issued block templates including a transaction are found most recent first, that
+// only the last templates are kept and that the templates of an earlier best block are forgotten.
+func TestIssuedTemplates(t *testing.T) {
+	issued := NewIssuedTemplates(2)
+	tip := &chainhash.Hash{1}
+	txA, txB := chainhash.Hash{0xa}, chainhash.Hash{0xb}
+	issued.Add(tip, "t1", []chainhash.Hash{txA})
+	issued.Add(tip, "t2", []chainhash.Hash{txA, txB})
+	// reissuing the latest template does not record it again
+	issued.Add(tip, "t2", []chainhash.Hash{txA, txB})
+	if got, want := issued.Containing(&txA), []string{"t2", "t1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got templates %v including tx a, want %v", got, want)
+	}
+	if got, want := issued.Containing(&txB), []string{"t2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got templates %v including tx b, want %v", got, want)
+	}
+	// the oldest template is dropped once more than the maximum are issued
+	issued.Add(tip, "t3", []chainhash.Hash{txB})
+	if got, want := issued.Containing(&txA), []string{"t2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got templates %v including tx a after t3, want %v", got, want)
+	}
+	// a new best block makes the templates of the previous tip stale
+	issued.Add(&chainhash.Hash{2}, "t4", nil)
+	if got := issued.Containing(&txB); len(got) != 0 {
+		t.Errorf("got templates %v of a previous best block", got)
+	}
+}
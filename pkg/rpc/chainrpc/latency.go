@@ -0,0 +1,149 @@
+package chainrpc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+const (
+	// RelayBatchSize is the number of peers a new block is announced to at once. The peers with the lowest latency are
+	// announced to first so that they are not competing with slow peers for our upload bandwidth when they request the
+	// block, which matters on the short block intervals of the multi-algo schedule.
+	RelayBatchSize = 8
+	// RelayBatchStagger is the delay between announcing a new block to successive batches of peers.
+	RelayBatchStagger = time.Millisecond * 50
+	// latencyWeight is the inverse of the weight of a new sample in the moving averages of peer latencies.
+	latencyWeight = 8
+	// maxTimedBlockRequests is the most block requests to a peer that are timed at once.
+	maxTimedBlockRequests = 1024
+	// blockRequestExpiry is how long a block request is timed before it is assumed the block will not be served.
+	blockRequestExpiry = time.Minute * 2
+)
+
+// PeerLatency keeps moving averages of the ping round trip time of a peer and of the time it takes the peer to serve
+// the blocks we request from it.
+type PeerLatency struct {
+	sync.Mutex
+	ping       time.Duration
+	blockServe time.Duration
+	requested  map[chainhash.Hash]time.Time
+	lastServed time.Time
+}
+
+// NewPeerLatency creates a latency tracker with no measurements
+func NewPeerLatency() *PeerLatency {
+	return &PeerLatency{requested: make(map[chainhash.Hash]time.Time)}
+}
+
+// average adds a sample to a moving average, which starts out as the first sample.
+func average(avg, sample time.Duration) time.Duration {
+	if avg == 0 {
+		return sample
+	}
+	return avg + (sample-avg)/latencyWeight
+}
+
+// AddPing adds the round trip time of a ping to the ping average.
+func (l *PeerLatency) AddPing(rtt time.Duration) {
+	if rtt <= 0 {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.ping = average(l.ping, rtt)
+}
+
+// BlocksRequested starts timing the requests of blocks made at the given time.
+func (l *PeerLatency) BlocksRequested(hashes []*chainhash.Hash, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	for _, hash := range hashes {
+		if len(l.requested) >= maxTimedBlockRequests {
+			l.expire(now)
+			if len(l.requested) >= maxTimedBlockRequests {
+				return
+			}
+		}
+		l.requested[*hash] = now
+	}
+}
+
+// BlockServed adds the time taken to serve a requested block that arrived at the given time to the block serve
+// average. Blocks requested together are sent one after another, so the time of a block is counted from the arrival of
+// the previous one when that is later than the request.
+func (l *PeerLatency) BlockServed(hash *chainhash.Hash, now time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	requested, ok := l.requested[*hash]
+	if !ok {
+		return
+	}
+	delete(l.requested, *hash)
+	if l.lastServed.After(requested) {
+		requested = l.lastServed
+	}
+	l.lastServed = now
+	if d := now.Sub(requested); d > 0 {
+		l.blockServe = average(l.blockServe, d)
+	}
+}
+
+// expire stops timing the block requests that were not served in time.
+//
+// This function MUST be called with the latency tracker locked.
+func (l *PeerLatency) expire(now time.Time) {
+	for hash, requested := range l.requested {
+		if now.Sub(requested) > blockRequestExpiry {
+			delete(l.requested, hash)
+		}
+	}
+}
+
+// Ping returns the average ping round trip time, which is zero until the peer answers a ping
+func (l *PeerLatency) Ping() time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	return l.ping
+}
+
+// BlockServe returns the average time taken to serve a block, which is zero until the peer serves a requested block
+func (l *PeerLatency) BlockServe() time.Duration {
+	l.Lock()
+	defer l.Unlock()
+	return l.blockServe
+}
+
+// Score returns the expected time for the peer to get a block announced to it, the round trip of the announcement and
+// the request plus the time to serve the block, and whether any of it has been measured yet.
+func (l *PeerLatency) Score() (score time.Duration, measured bool) {
+	l.Lock()
+	defer l.Unlock()
+	return l.ping + l.blockServe, l.ping != 0 || l.blockServe != 0
+}
+
+// OrderByLatency sorts peers by increasing latency score. Peers whose latency has not been measured yet come last, in
+// their original order.
+func OrderByLatency(peers []*NodePeer) {
+	type scored struct {
+		sp       *NodePeer
+		score    time.Duration
+		measured bool
+	}
+	s := make([]scored, len(peers))
+	for i, sp := range peers {
+		s[i].sp = sp
+		s[i].score, s[i].measured = sp.Latency.Score()
+	}
+	sort.SliceStable(s, func(i, j int) bool {
+		if s[i].measured != s[j].measured {
+			return s[i].measured
+		}
+		return s[i].score < s[j].score
+	})
+	for i := range s {
+		peers[i] = s[i].sp
+	}
+}
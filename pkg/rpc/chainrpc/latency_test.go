@@ -0,0 +1,56 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// TestPeerLatency ensures that block serve times are measured from the request, or from the previous block when blocks
+// arrive back to back, and that unrequested blocks are ignored.
+func TestPeerLatency(t *testing.T) {
+	l := NewPeerLatency()
+	if _, measured := l.Score(); measured {
+		t.Error("new tracker is measured")
+	}
+	now := time.Now()
+	l.BlocksRequested([]*chainhash.Hash{{1}, {2}}, now)
+	l.BlockServed(&chainhash.Hash{1}, now.Add(time.Millisecond*400))
+	if got := l.BlockServe(); got != time.Millisecond*400 {
+		t.Errorf("got block serve time %v after the first block, want 400ms", got)
+	}
+	// The second block is timed from the arrival of the first one.
+	l.BlockServed(&chainhash.Hash{2}, now.Add(time.Millisecond*480))
+	if got, want := l.BlockServe(), time.Millisecond*(400-(400-80)/latencyWeight); got != want {
+		t.Errorf("got block serve time %v after the second block, want %v", got, want)
+	}
+	serve := l.BlockServe()
+	l.BlockServed(&chainhash.Hash{3}, now.Add(time.Second))
+	if got := l.BlockServe(); got != serve {
+		t.Errorf("unrequested block changed the block serve time from %v to %v", serve, got)
+	}
+	l.AddPing(time.Millisecond * 100)
+	if score, measured := l.Score(); !measured || score != serve+time.Millisecond*100 {
+		t.Errorf("got score %v %v, want %v", score, measured, serve+time.Millisecond*100)
+	}
+}
+
+// TestOrderByLatency ensures that peers are ordered fastest first with unmeasured peers last in their original order.
+func TestOrderByLatency(t *testing.T) {
+	peers := make([]*NodePeer, 5)
+	for i := range peers {
+		peers[i] = &NodePeer{Latency: NewPeerLatency()}
+	}
+	peers[1].Latency.AddPing(time.Millisecond * 300)
+	peers[3].Latency.AddPing(time.Millisecond * 100)
+	peers[4].Latency.AddPing(time.Millisecond * 200)
+	ordered := append([]*NodePeer{}, peers...)
+	OrderByLatency(ordered)
+	want := []*NodePeer{peers[3], peers[4], peers[1], peers[0], peers[2]}
+	for i := range want {
+		if ordered[i] != want[i] {
+			t.Fatalf("peer #%d is out of order", i)
+		}
+	}
+}
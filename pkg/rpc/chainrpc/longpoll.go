@@ -0,0 +1,197 @@
+package chainrpc
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+const (
+	// MaxLongPollClients is the maximum number of getblocktemplate long poll requests that can wait for a new block
+	// template at once.
+	MaxLongPollClients = 1024
+	// LongPollTimeout is the longest a getblocktemplate long poll request waits for a new block template before it is
+	// answered with the current one.
+	LongPollTimeout = time.Minute * 10
+)
+
+// ErrTooManyLongPollClients is returned when registering a long poll request while MaxLongPollClients are waiting.
+var ErrTooManyLongPollClients = errors.New("too many getblocktemplate long poll clients are waiting")
+
+// longPollWaiter is the channel closed when the block template identified by a previous hash and sequence number is
+// stale, shared by every client waiting for that template.
+type longPollWaiter struct {
+	c       chan struct{}
+	clients int
+}
+
+// LongPollWaiters keeps the getblocktemplate long poll requests waiting for their block template to go stale.
+//
+// It holds its own copy of the identity of the current block template so that notifications about new blocks and
+// transactions never have to wait for the work state lock, which is held while block templates are generated.
+type LongPollWaiters struct {
+	sync.Mutex
+	waiters       map[chainhash.Hash]map[uint64]*longPollWaiter
+	clients       int
+	max           int
+	prevHash      *chainhash.Hash
+	seq           uint64
+	lastGenerated time.Time
+}
+
+// LongPollStats are the counters of the long poll requests waiting for a new block template
+type LongPollStats struct {
+	// Clients is the number of requests waiting
+	Clients int
+	// Templates is the number of distinct block templates the requests are waiting on
+	Templates int
+}
+
+// NewLongPollWaiters creates a long poll registry accepting up to max waiting clients
+func NewLongPollWaiters(max int) *LongPollWaiters {
+	return &LongPollWaiters{
+		waiters: make(map[chainhash.Hash]map[uint64]*longPollWaiter),
+		max:     max,
+	}
+}
+
+// Register adds a client waiting for the block template with the given previous hash and sequence number to go stale.
+// The returned channel is closed when it does, and done must be called once the client stops waiting, whether the
+// channel was closed or not.
+func (l *LongPollWaiters) Register(prevHash *chainhash.Hash, seq uint64) (c <-chan struct{}, done func(),
+	err error) {
+	l.Lock()
+	defer l.Unlock()
+	if l.clients >= l.max {
+		return nil, nil, ErrTooManyLongPollClients
+	}
+	templates, ok := l.waiters[*prevHash]
+	if !ok {
+		templates = make(map[uint64]*longPollWaiter)
+		l.waiters[*prevHash] = templates
+	}
+	w, ok := templates[seq]
+	if !ok {
+		w = &longPollWaiter{c: make(chan struct{})}
+		templates[seq] = w
+	}
+	w.clients++
+	l.clients++
+	hash := *prevHash
+	var once sync.Once
+	done = func() {
+		once.Do(func() { l.unregister(hash, seq, w) })
+	}
+	return w.c, done, nil
+}
+
+// unregister removes a client from its waiter, and the waiter once it has no clients left. The waiter may already have
+// been removed when it was notified.
+func (l *LongPollWaiters) unregister(prevHash chainhash.Hash, seq uint64, w *longPollWaiter) {
+	l.Lock()
+	defer l.Unlock()
+	w.clients--
+	l.clients--
+	if w.clients > 0 {
+		return
+	}
+	templates := l.waiters[prevHash]
+	if templates[seq] != w {
+		return
+	}
+	delete(templates, seq)
+	if len(templates) == 0 {
+		delete(l.waiters, prevHash)
+	}
+}
+
+// SetTemplate records the identity of a newly generated block template.
+func (l *LongPollWaiters) SetTemplate(prevHash *chainhash.Hash, seq uint64, lastGenerated time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	l.prevHash = prevHash
+	l.seq = seq
+	l.lastGenerated = lastGenerated
+}
+
+// NotifyBlockConnected notifies the clients waiting for a block template that is stale because the block with the
+// given hash is the new tip of the best chain.
+func (l *LongPollWaiters) NotifyBlockConnected(blockHash *chainhash.Hash) {
+	l.Lock()
+	defer l.Unlock()
+	l.notify(blockHash, 0)
+}
+
+// NotifyMempoolTx notifies the clients waiting for a block template that is stale because the memory pool has changed
+// and enough time has passed since the current template was generated.
+func (l *LongPollWaiters) NotifyMempoolTx(lastUpdated time.Time) {
+	l.Lock()
+	defer l.Unlock()
+	// No need to notify anything if no block templates have been generated yet.
+	if l.prevHash == nil || l.lastGenerated.IsZero() {
+		return
+	}
+	// Clients can only wait on the current template, as older ones were notified when it was generated, so if the
+	// current template predates the update every client of the current best block is notified.
+	if time.Now().After(l.lastGenerated.Add(time.Second*GBTRegenerateSeconds)) &&
+		l.lastGenerated.Before(lastUpdated) {
+		l.notify(l.prevHash, l.seq+1)
+	}
+}
+
+// Notify notifies the clients waiting for a block template that is stale given the latest best block hash and the
+// sequence number of the latest block template.
+func (l *LongPollWaiters) Notify(latestHash *chainhash.Hash, seq uint64) {
+	l.Lock()
+	defer l.Unlock()
+	l.notify(latestHash, seq)
+}
+
+// notify closes the channels of stale block templates and forgets them. Their clients are still counted until they call
+// their done function.
+//
+// This function MUST be called with the registry locked.
+func (l *LongPollWaiters) notify(latestHash *chainhash.Hash, seq uint64) {
+	// Notify anything that is waiting for a block template update from a hash which is not the hash of the tip of the
+	// best chain since their work is now invalid.
+	for hash, templates := range l.waiters {
+		if !hash.IsEqual(latestHash) {
+			for _, w := range templates {
+				close(w.c)
+			}
+			delete(l.waiters, hash)
+		}
+	}
+	// Return now if no template sequence number was provided.
+	if seq == 0 {
+		return
+	}
+	templates, ok := l.waiters[*latestHash]
+	if !ok {
+		return
+	}
+	// Notify anything that is waiting for a block template update from a block template generated before the most
+	// recently generated block template.
+	for s, w := range templates {
+		if s < seq {
+			close(w.c)
+			delete(templates, s)
+		}
+	}
+	if len(templates) == 0 {
+		delete(l.waiters, *latestHash)
+	}
+}
+
+// Stats returns the number of waiting clients and of the block templates they are waiting on
+func (l *LongPollWaiters) Stats() (stats LongPollStats) {
+	l.Lock()
+	defer l.Unlock()
+	stats.Clients = l.clients
+	for _, templates := range l.waiters {
+		stats.Templates += len(templates)
+	}
+	return
+}
@@ -0,0 +1,123 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// closed reports whether a long poll channel has been closed.
+func closed(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// TestLongPollWaiters ensures that long poll clients share the channel of their template, are notified when the
+// template goes stale, are limited in number and are forgotten when they stop waiting.
+func TestLongPollWaiters(t *testing.T) {
+	l := NewLongPollWaiters(3)
+	tip := &chainhash.Hash{1}
+	var seq uint64 = 2
+	c1, done1, err := l.Register(tip, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, done2, err := l.Register(tip, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c1 != c2 {
+		t.Error("clients of the same template got different channels")
+	}
+	c3, done3, err := l.Register(tip, seq-1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = l.Register(tip, seq); err != ErrTooManyLongPollClients {
+		t.Fatalf("Register: got error %v, want %v", err, ErrTooManyLongPollClients)
+	}
+	if stats := l.Stats(); stats.Clients != 3 || stats.Templates != 2 {
+		t.Errorf("got %+v, want 3 clients of 2 templates", stats)
+	}
+	// A client leaving forgets its template once no one else waits on it.
+	done3()
+	done3()
+	if stats := l.Stats(); stats.Clients != 2 || stats.Templates != 1 {
+		t.Errorf("got %+v after a client left, want 2 clients of 1 template", stats)
+	}
+	if closed(c3) {
+		t.Error("channel of a client that left was closed")
+	}
+	// A template generated later than the one the clients wait on makes theirs stale.
+	l.Notify(tip, seq)
+	if closed(c1) {
+		t.Error("channel closed by the template it waits on")
+	}
+	l.Notify(tip, seq+1)
+	if !closed(c1) {
+		t.Error("channel not closed by a newer template")
+	}
+	if stats := l.Stats(); stats.Clients != 2 || stats.Templates != 0 {
+		t.Errorf("got %+v after notifying, want 2 clients of 0 templates", stats)
+	}
+	done1()
+	done2()
+	if stats := l.Stats(); stats.Clients != 0 {
+		t.Errorf("got %+v after all clients left, want none", stats)
+	}
+	// A new best block makes the templates of the previous tip stale.
+	c, done, err := l.Register(tip, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+	l.NotifyBlockConnected(tip)
+	if closed(c) {
+		t.Error("channel closed by the block the template builds on")
+	}
+	l.NotifyBlockConnected(&chainhash.Hash{2})
+	if !closed(c) {
+		t.Error("channel not closed by a new best block")
+	}
+	// Changes to the mempool make the current template stale once it is old enough.
+	l.SetTemplate(tip, seq, time.Now().Add(-time.Second*GBTRegenerateSeconds*2))
+	c, done, err = l.Register(tip, seq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer done()
+	l.NotifyMempoolTx(time.Now().Add(-time.Second * GBTRegenerateSeconds * 3))
+	if closed(c) {
+		t.Error("channel closed by a mempool change older than the template")
+	}
+	l.NotifyMempoolTx(time.Now())
+	if !closed(c) {
+		t.Error("channel not closed by a mempool change")
+	}
+}
+
+// TestTemplateID ensures that template IDs round trip and that IDs in the deprecated format are still accepted.
+func TestTemplateID(t *testing.T) {
+	hash := &chainhash.Hash{1}
+	generated := time.Unix(1600000000, 0)
+	prevHash, lastGenerated, seq, err := DecodeTemplateID(EncodeTemplateID(hash, generated, 7))
+	if err != nil || !prevHash.IsEqual(hash) || lastGenerated != generated.Unix() || seq != 7 {
+		t.Errorf("got %v %d %d %v, want %v %d 7", prevHash, lastGenerated, seq, err, hash, generated.Unix())
+	}
+	prevHash, lastGenerated, seq, err = DecodeTemplateID(hash.String() + "-1600000000")
+	if err != nil || !prevHash.IsEqual(hash) || lastGenerated != generated.Unix() || seq != 0 {
+		t.Errorf("deprecated ID: got %v %d %d %v, want %v %d 0", prevHash, lastGenerated, seq, err, hash,
+			generated.Unix())
+	}
+	for _, id := range []string{"", hash.String(), hash.String() + "-1-0", hash.String() + "-1-x", "x-1-1",
+		hash.String() + "-1-1-1"} {
+		if _, _, _, err = DecodeTemplateID(id); err == nil {
+			t.Errorf("decoded invalid ID %q", id)
+		}
+	}
+}
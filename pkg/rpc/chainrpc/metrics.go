@@ -0,0 +1,49 @@
+package chainrpc
+
+import (
+	"net/http"
+
+	"github.com/p9c/pod/pkg/db/ffldb"
+	"github.com/p9c/pod/pkg/util/metrics"
+)
+
+// RegisterMetrics registers the gauges and counters of the node in its metrics registry and returns the histogram of
+// RPC request durations that is shared by its RPC servers.
+func (n *Node) RegisterMetrics() *metrics.Histogram {
+	n.Metrics.GaugeFunc("pod_peers", "Number of connected peers",
+		func() float64 { return float64(n.ConnectedCount()) })
+	n.Metrics.GaugeFunc("pod_block_height", "Height of the best block",
+		func() float64 { return float64(n.Chain.BestSnapshot().Height) })
+	n.Metrics.GaugeFunc("pod_mempool_transactions", "Number of transactions in the mempool",
+		func() float64 { return float64(n.TxMemPool.Count()) })
+	n.Metrics.GaugeFunc("pod_mempool_bytes", "Serialized size of the transactions in the mempool",
+		func() float64 {
+			var size int
+			for _, txD := range n.TxMemPool.TxDescs() {
+				size += txD.Tx.MsgTx().SerializeSize()
+			}
+			return float64(size)
+		})
+	n.Metrics.CounterFunc("pod_db_read_bytes_total", "Bytes read from the block database files",
+		func() float64 {
+			read, _ := ffldb.BlockIOStats()
+			return float64(read)
+		})
+	n.Metrics.CounterFunc("pod_db_written_bytes_total", "Bytes written to the block database files",
+		func() float64 {
+			_, written := ffldb.BlockIOStats()
+			return float64(written)
+		})
+	return n.Metrics.Histogram("pod_rpc_request_duration_seconds", "Time taken to handle RPC requests", "method",
+		metrics.DefaultBuckets)
+}
+
+// ServeMetrics serves the metrics registry at /metrics on the configured metrics listener until the metrics server is
+// closed when the node stops.
+func (n *Node) ServeMetrics() {
+	defer n.WG.Done()
+	Info("serving metrics on", n.MetricsServer.Addr)
+	if err := n.MetricsServer.ListenAndServe(); err != http.ErrServerClosed {
+		Error("metrics server failed:", err)
+	}
+}
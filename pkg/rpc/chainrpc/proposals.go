@@ -0,0 +1,141 @@
+package chainrpc
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+// MaxCachedProposals is the number of the block proposals most recently checked on the best block whose results are
+// kept, so that pool software submitting the same proposal repeatedly doesn't have it validated in full each time.
+const MaxCachedProposals = 64
+
+// checkedProposal is the result of checking a block proposal, which is nil when it was accepted or the BIP 0023 reject
+// reason otherwise, and the time of the block that was checked.
+type checkedProposal struct {
+	result    interface{}
+	timestamp time.Time
+}
+
+// templateKey identifies the block template of an algorithm built on a previous block.
+type templateKey struct {
+	prevHash chainhash.Hash
+	algo     string
+}
+
+// ProposalCache keeps the results of the block proposals most recently checked on the current best block, and the
+// block templates generated for each algorithm, which were checked when they were made.
+//
+// Proposals are told apart by ProposalKey, which leaves out the time and nonce of the block. An accepted proposal is
+// still accepted with a later time, as the time/increment mutation only makes more of its transactions final, so that
+// result is reused for the same block with a later time once the time and target have been checked on their own. A
+// rejection is only reused for the very same block.
+type ProposalCache struct {
+	sync.Mutex
+	prevHash  chainhash.Hash
+	proposals map[chainhash.Hash]checkedProposal
+	order     []chainhash.Hash
+	templates map[templateKey]chainhash.Hash
+	times     map[templateKey]time.Time
+	max       int
+}
+
+// NewProposalCache creates a cache of the results of the last max block proposals checked on the best block
+func NewProposalCache(max int) *ProposalCache {
+	return &ProposalCache{
+		proposals: make(map[chainhash.Hash]checkedProposal),
+		templates: make(map[templateKey]chainhash.Hash),
+		times:     make(map[templateKey]time.Time),
+		max:       max,
+	}
+}
+
+// ProposalKey returns the hash of the block with the time and nonce of its header cleared, which identifies a block
+// proposal apart from the mutations that don't need it to be checked again.
+func ProposalKey(block *wire.MsgBlock) (key chainhash.Hash, err error) {
+	header := block.Header
+	header.Timestamp, header.Nonce = time.Unix(0, 0), 0
+	buf := bytes.NewBuffer(make([]byte, 0, block.SerializeSize()))
+	if err = header.Serialize(buf); err != nil {
+		return
+	}
+	if err = wire.WriteVarInt(buf, 0, uint64(len(block.Transactions))); err != nil {
+		return
+	}
+	for _, tx := range block.Transactions {
+		if err = tx.Serialize(buf); err != nil {
+			return
+		}
+	}
+	return chainhash.DoubleHashH(buf.Bytes()), nil
+}
+
+// reset forgets the proposals and templates of an earlier best block, as they can't be connected any more.
+//
+// This function MUST be called with the cache locked.
+func (c *ProposalCache) reset(prevHash *chainhash.Hash) {
+	if c.prevHash.IsEqual(prevHash) {
+		return
+	}
+	c.prevHash = *prevHash
+	c.proposals = make(map[chainhash.Hash]checkedProposal)
+	c.order = c.order[:0]
+	c.templates = make(map[templateKey]chainhash.Hash)
+	c.times = make(map[templateKey]time.Time)
+}
+
+// AddTemplate records the block template of the algorithm built on the previous block, which the generator checked
+// connects to it, so that proposing it back is accepted without checking it again.
+func (c *ProposalCache) AddTemplate(prevHash *chainhash.Hash, algo string, block *wire.MsgBlock) (err error) {
+	var key chainhash.Hash
+	if key, err = ProposalKey(block); err != nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.reset(prevHash)
+	tk := templateKey{prevHash: *prevHash, algo: algo}
+	c.templates[tk], c.times[tk] = key, block.Header.Timestamp
+	return
+}
+
+// Add records the result of checking the block proposal with the key and time, built on the previous block
+func (c *ProposalCache) Add(prevHash *chainhash.Hash, key chainhash.Hash, timestamp time.Time, result interface{}) {
+	c.Lock()
+	defer c.Unlock()
+	c.reset(prevHash)
+	if _, ok := c.proposals[key]; !ok {
+		if len(c.order) >= c.max {
+			delete(c.proposals, c.order[0])
+			c.order = append(c.order[:0], c.order[1:]...)
+		}
+		c.order = append(c.order, key)
+	}
+	c.proposals[key] = checkedProposal{result: result, timestamp: timestamp}
+}
+
+// Lookup returns the result of an earlier check that holds for the block proposal of the algorithm with the key and
+// time, built on the previous block, and whether there was one.
+func (c *ProposalCache) Lookup(prevHash *chainhash.Hash, algo string, key chainhash.Hash, timestamp time.Time) (
+	result interface{}, ok bool) {
+	c.Lock()
+	defer c.Unlock()
+	if !c.prevHash.IsEqual(prevHash) {
+		return nil, false
+	}
+	tk := templateKey{prevHash: *prevHash, algo: algo}
+	if tmpl, found := c.templates[tk]; found && tmpl == key && !timestamp.Before(c.times[tk]) {
+		return nil, true
+	}
+	checked, found := c.proposals[key]
+	if !found {
+		return nil, false
+	}
+	if checked.result == nil {
+		return nil, !timestamp.Before(checked.timestamp)
+	}
+	return checked.result, timestamp.Equal(checked.timestamp)
+}
@@ -0,0 +1,108 @@
+package chainrpc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+)
+
+func testProposal(prevHash chainhash.Hash, value int64) *wire.MsgBlock {
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{1, 2}, nil))
+	coinbase.AddTxOut(wire.NewTxOut(value, []byte{0x51}))
+	return &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			Version:   fork.GetAlgoVer(fork.SHA256d, 1),
+			PrevBlock: prevHash,
+			Timestamp: time.Unix(1600000000, 0),
+			Bits:      0x1d00ffff,
+		},
+		Transactions: []*wire.MsgTx{coinbase},
+	}
+}
+
+func mustProposalKey(t *testing.T, block *wire.MsgBlock) chainhash.Hash {
+	key, err := ProposalKey(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// TestProposalKey ensures that proposals differing only by their time and nonce share a key, and others don't.
+func TestProposalKey(t *testing.T) {
+	block := testProposal(chainhash.Hash{1}, 5000)
+	key := mustProposalKey(t, block)
+	mutated := testProposal(chainhash.Hash{1}, 5000)
+	mutated.Header.Timestamp = mutated.Header.Timestamp.Add(time.Minute)
+	mutated.Header.Nonce = 12345
+	if mustProposalKey(t, mutated) != key {
+		t.Error("the time and nonce changed the key of the proposal")
+	}
+	if block.Header.Timestamp.IsZero() || block.Header.Timestamp.Unix() == 0 {
+		t.Error("the time of the proposal was cleared")
+	}
+	if mustProposalKey(t, testProposal(chainhash.Hash{1}, 5001)) == key {
+		t.Error("a different coinbase has the same key")
+	}
+	bits := testProposal(chainhash.Hash{1}, 5000)
+	bits.Header.Bits = 0x1c00ffff
+	if mustProposalKey(t, bits) == key {
+		t.Error("a different target has the same key")
+	}
+}
+
+// TestProposalCache ensures that an accepted proposal is accepted again with the same or a later time, a rejection
+// only for the very same block, that templates are accepted when proposed back, that only the last proposals are kept
+// and that everything is forgotten on a new best block.
+func TestProposalCache(t *testing.T) {
+	tip := chainhash.Hash{1}
+	cache := NewProposalCache(2)
+	accepted := testProposal(tip, 5000)
+	acceptedKey := mustProposalKey(t, accepted)
+	at := accepted.Header.Timestamp
+	cache.Add(&tip, acceptedKey, at, nil)
+	if result, ok := cache.Lookup(&tip, fork.SHA256d, acceptedKey, at.Add(time.Minute)); !ok || result != nil {
+		t.Errorf("accepted proposal with a later time got %v, %v", result, ok)
+	}
+	if _, ok := cache.Lookup(&tip, fork.SHA256d, acceptedKey, at.Add(-time.Minute)); ok {
+		t.Error("accepted proposal with an earlier time was not checked again")
+	}
+	rejectedKey := mustProposalKey(t, testProposal(tip, 1<<40))
+	cache.Add(&tip, rejectedKey, at, "bad-cb-amount")
+	if result, ok := cache.Lookup(&tip, fork.SHA256d, rejectedKey, at); !ok || result != "bad-cb-amount" {
+		t.Errorf("rejected proposal got %v, %v", result, ok)
+	}
+	if _, ok := cache.Lookup(&tip, fork.SHA256d, rejectedKey, at.Add(time.Minute)); ok {
+		t.Error("rejected proposal with another time was not checked again")
+	}
+	// the template of an algorithm is accepted when proposed back, but not for another algorithm
+	template := testProposal(tip, 6000)
+	if err := cache.AddTemplate(&tip, fork.SHA256d, template); err != nil {
+		t.Fatal(err)
+	}
+	templateKey := mustProposalKey(t, template)
+	if result, ok := cache.Lookup(&tip, fork.SHA256d, templateKey, at.Add(time.Second)); !ok || result != nil {
+		t.Errorf("template proposed back got %v, %v", result, ok)
+	}
+	if _, ok := cache.Lookup(&tip, fork.Scrypt, templateKey, at); ok {
+		t.Error("template of another algorithm was accepted")
+	}
+	// the oldest proposal is dropped once more than the maximum are checked
+	cache.Add(&tip, mustProposalKey(t, testProposal(tip, 7000)), at, nil)
+	if _, ok := cache.Lookup(&tip, fork.SHA256d, acceptedKey, at); ok {
+		t.Error("oldest proposal was kept past the maximum")
+	}
+	// a new best block makes the proposals and templates of the previous tip stale
+	next := chainhash.Hash{2}
+	cache.Add(&next, acceptedKey, at, nil)
+	if _, ok := cache.Lookup(&tip, fork.SHA256d, templateKey, at); ok {
+		t.Error("template of a previous best block was accepted")
+	}
+	if _, ok := cache.Lookup(&next, fork.SHA256d, rejectedKey, at); ok {
+		t.Error("proposal of a previous best block was kept")
+	}
+}
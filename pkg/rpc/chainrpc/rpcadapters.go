@@ -1,7 +1,10 @@
 package chainrpc
 
 import (
+	"io"
+	"net"
 	"sync/atomic"
+	"time"
 
 	"github.com/p9c/pod/cmd/node/mempool"
 	blockchain "github.com/p9c/pod/pkg/chain"
@@ -9,6 +12,8 @@ import (
 	netsync "github.com/p9c/pod/pkg/chain/sync"
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/addrmgr"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
 	"github.com/p9c/pod/pkg/util"
 )
 
@@ -49,6 +54,34 @@ func (p *Peer) GetFeeFilter() int64 {
 	return atomic.LoadInt64(&(*NodePeer)(p).FeeFilter)
 }
 
+// GetBlockServeTime returns the average time the peer has taken to serve the blocks requested from it.
+//
+// This function is safe for concurrent access and is part of the RPCServerPeer interface implementation.
+func (p *Peer) GetBlockServeTime() time.Duration {
+	return (*NodePeer)(p).Latency.BlockServe()
+}
+
+// GetPermissions returns the permissions the whitelists grant the peer.
+//
+// This function is safe for concurrent access and is part of the RPCServerPeer interface implementation.
+func (p *Peer) GetPermissions() connmgr.Permissions {
+	return (*NodePeer)(p).Permissions
+}
+
+// IsAddrRelayEnabled returns whether the addresses advertised by the peer are passed to the address manager.
+//
+// This function is safe for concurrent access and is part of the RPCServerPeer interface implementation.
+func (p *Peer) IsAddrRelayEnabled() bool {
+	return (*NodePeer)(p).AddrRelayEnabled()
+}
+
+// GetAddrProcessed returns the number of addresses advertised by the peer that were passed to the address manager.
+//
+// This function is safe for concurrent access and is part of the RPCServerPeer interface implementation.
+func (p *Peer) GetAddrProcessed() uint64 {
+	return atomic.LoadUint64(&(*NodePeer)(p).AddrProcessed)
+}
+
 // ConnManager provides a connection manager for use with the RPC server and implements the rpcserver ConnManager
 // interface.
 type ConnManager struct {
@@ -134,6 +167,44 @@ func (cm *ConnManager) DisconnectByAddr(addr string) error {
 	return <-replyChan
 }
 
+// Ban bans the subnet until the given time and disconnects the peers in it.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) Ban(subnet *net.IPNet, until time.Time) {
+	replyChan := make(chan error)
+	cm.server.Query <- SetBanMsg{Subnet: subnet, Until: until, Reply: replyChan}
+	<-replyChan
+}
+
+// Unban removes the ban of the subnet.
+//
+// Attempting to unban a subnet that is not banned will return an error.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) Unban(subnet *net.IPNet) error {
+	replyChan := make(chan error)
+	cm.server.Query <- SetBanMsg{Subnet: subnet, Remove: true, Reply: replyChan}
+	return <-replyChan
+}
+
+// ListBanned returns the bans of the node, oldest first.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) ListBanned() []connmgr.BanEntry {
+	replyChan := make(chan []connmgr.BanEntry)
+	cm.server.Query <- ListBannedMsg{Reply: replyChan}
+	return <-replyChan
+}
+
+// ClearBanned removes all the bans of the node.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) ClearBanned() {
+	replyChan := make(chan struct{})
+	cm.server.Query <- ClearBannedMsg{Reply: replyChan}
+	<-replyChan
+}
+
 // ConnectedCount returns the number of currently connected peers.
 //
 // This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
@@ -148,6 +219,41 @@ func (cm *ConnManager) NetTotals() (uint64, uint64) {
 	return cm.server.NetTotals()
 }
 
+// LocalServices returns the services advertised to peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) LocalServices() wire.ServiceFlag {
+	return cm.server.Services
+}
+
+// LocalAddresses returns the local addresses advertised to peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) LocalAddresses() []addrmgr.LocalAddress {
+	return cm.server.AddrManager.LocalAddresses()
+}
+
+// ExportAddresses writes the known peer addresses to w in the format of the peers file.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) ExportAddresses(w io.Writer) (int, error) {
+	return cm.server.AddrManager.Export(w)
+}
+
+// ImportAddresses adds the addresses of a peers file read from r that are not known yet.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) ImportAddresses(r io.Reader) (int, error) {
+	return cm.server.AddrManager.Import(r)
+}
+
+// AddressBuckets returns the fill level of the buckets of the address manager.
+//
+// This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
+func (cm *ConnManager) AddressBuckets() addrmgr.BucketInfo {
+	return cm.server.AddrManager.BucketInfo()
+}
+
 // ConnectedPeers returns an array consisting of all connected peers.
 //
 // This function is safe for concurrent access and is part of the RPCServerConnManager interface implementation.
@@ -238,6 +344,13 @@ func (b *SyncManager) SyncPeerID() int32 {
 	return b.syncMgr.SyncPeerID()
 }
 
+// SyncStatus returns the stage and progress of the sync of the chain with the connected peers.
+//
+// This function is safe for concurrent access and is part of the RPCServerSyncManager interface implementation.
+func (b *SyncManager) SyncStatus() *netsync.SyncStatus {
+	return b.syncMgr.SyncStatus()
+}
+
 // LocateBlocks returns the hashes of the blocks after the first known block in the provided locators until the provided
 // stop hash or the current tip is reached, up to a max of wire.MaxBlockHeadersPerMsg hashes.
 //
@@ -56,6 +56,16 @@ type (
 		Res *None
 		Err error
 	}
+	// CalculateSigHashRes is the result from a call to CalculateSigHash
+	CalculateSigHashRes struct {
+		Res *btcjson.CalculateSigHashResult
+		Err error
+	}
+	// ClearBannedRes is the result from a call to ClearBanned
+	ClearBannedRes struct {
+		Res *None
+		Err error
+	}
 	// CreateRawTransactionRes is the result from a call to CreateRawTransaction
 	CreateRawTransactionRes struct {
 		Res *string
@@ -71,21 +81,76 @@ type (
 		Res *btcjson.DecodeScriptResult
 		Err error
 	}
+	// DumpAddrManRes is the result from a call to DumpAddrMan
+	DumpAddrManRes struct {
+		Res *btcjson.DumpAddrManResult
+		Err error
+	}
+	// DumpUTXOSetRes is the result from a call to DumpUTXOSet
+	DumpUTXOSetRes struct {
+		Res *btcjson.UTXOSetSnapshotResult
+		Err error
+	}
 	// EstimateFeeRes is the result from a call to EstimateFee
 	EstimateFeeRes struct {
 		Res *float64
 		Err error
 	}
+	// EstimateSmartFeeRes is the result from a call to EstimateSmartFee
+	EstimateSmartFeeRes struct {
+		Res *btcjson.EstimateSmartFeeResult
+		Err error
+	}
+	// EvaluateScriptRes is the result from a call to EvaluateScript
+	EvaluateScriptRes struct {
+		Res *btcjson.EvaluateScriptResult
+		Err error
+	}
 	// GenerateRes is the result from a call to Generate
 	GenerateRes struct {
 		Res *[]string
 		Err error
 	}
+	// GenerateBlockRes is the result from a call to GenerateBlock
+	GenerateBlockRes struct {
+		Res *btcjson.GenerateBlockResult
+		Err error
+	}
+	// GenerateToAddressRes is the result from a call to GenerateToAddress
+	GenerateToAddressRes struct {
+		Res *[]string
+		Err error
+	}
 	// GetAddedNodeInfoRes is the result from a call to GetAddedNodeInfo
 	GetAddedNodeInfoRes struct {
 		Res *[]btcjson.GetAddedNodeInfoResultAddr
 		Err error
 	}
+	// GetAddressBalanceRes is the result from a call to GetAddressBalance
+	GetAddressBalanceRes struct {
+		Res *btcjson.GetAddressBalanceResult
+		Err error
+	}
+	// GetAddressTxIDsRes is the result from a call to GetAddressTxIDs
+	GetAddressTxIDsRes struct {
+		Res *[]string
+		Err error
+	}
+	// GetAddressUtxosRes is the result from a call to GetAddressUtxos
+	GetAddressUtxosRes struct {
+		Res *[]btcjson.GetAddressUtxosResult
+		Err error
+	}
+	// GetAddrManInfoRes is the result from a call to GetAddrManInfo
+	GetAddrManInfoRes struct {
+		Res *btcjson.GetAddrManInfoResult
+		Err error
+	}
+	// GetAuditLogRes is the result from a call to GetAuditLog
+	GetAuditLogRes struct {
+		Res *[]btcjson.AuditLogEntry
+		Err error
+	}
 	// GetBestBlockRes is the result from a call to GetBestBlock
 	GetBestBlockRes struct {
 		Res *btcjson.GetBestBlockResult
@@ -111,6 +176,11 @@ type (
 		Res *int64
 		Err error
 	}
+	// GetBlockFilterRes is the result from a call to GetBlockFilter
+	GetBlockFilterRes struct {
+		Res *btcjson.GetBlockFilterResult
+		Err error
+	}
 	// GetBlockHashRes is the result from a call to GetBlockHash
 	GetBlockHashRes struct {
 		Res *string
@@ -121,6 +191,11 @@ type (
 		Res *btcjson.GetBlockHeaderVerboseResult
 		Err error
 	}
+	// GetBlockSubsidyRes is the result from a call to GetBlockSubsidy
+	GetBlockSubsidyRes struct {
+		Res *btcjson.GetBlockSubsidyResult
+		Err error
+	}
 	// GetBlockTemplateRes is the result from a call to GetBlockTemplate
 	GetBlockTemplateRes struct {
 		Res *string
@@ -176,6 +251,11 @@ type (
 		Res *btcjson.GetMempoolInfoResult
 		Err error
 	}
+	// GetMinerStatsRes is the result from a call to GetMinerStats
+	GetMinerStatsRes struct {
+		Res *btcjson.GetMinerStatsResult
+		Err error
+	}
 	// GetMiningInfoRes is the result from a call to GetMiningInfo
 	GetMiningInfoRes struct {
 		Res *btcjson.GetMiningInfoResult
@@ -191,11 +271,21 @@ type (
 		Res *[]btcjson.GetPeerInfoResult
 		Err error
 	}
+	// GetNetworkInfoRes is the result from a call to GetNetworkInfo
+	GetNetworkInfoRes struct {
+		Res *btcjson.GetNetworkInfoResult
+		Err error
+	}
 	// GetPeerInfoRes is the result from a call to GetPeerInfo
 	GetPeerInfoRes struct {
 		Res *[]btcjson.GetPeerInfoResult
 		Err error
 	}
+	// GetPeerStatsRes is the result from a call to GetPeerStats
+	GetPeerStatsRes struct {
+		Res *btcjson.GetPeerStatsResult
+		Err error
+	}
 	// GetRawMempoolRes is the result from a call to GetRawMempool
 	GetRawMempoolRes struct {
 		Res *[]string
@@ -206,16 +296,66 @@ type (
 		Res *string
 		Err error
 	}
+	// GetSnapshotRes is the result from a call to GetSnapshot
+	GetSnapshotRes struct {
+		Res *btcjson.GetSnapshotResult
+		Err error
+	}
+	// GetSpentInfoRes is the result from a call to GetSpentInfo
+	GetSpentInfoRes struct {
+		Res *btcjson.GetSpentInfoResult
+		Err error
+	}
+	// GetSyncStatusRes is the result from a call to GetSyncStatus
+	GetSyncStatusRes struct {
+		Res *btcjson.GetSyncStatusResult
+		Err error
+	}
+	// GetTelemetryInfoRes is the result from a call to GetTelemetryInfo
+	GetTelemetryInfoRes struct {
+		Res *btcjson.GetTelemetryInfoResult
+		Err error
+	}
 	// GetTxOutRes is the result from a call to GetTxOut
 	GetTxOutRes struct {
 		Res *string
 		Err error
 	}
+	// GetTxSpendingPrevOutRes is the result from a call to GetTxSpendingPrevOut
+	GetTxSpendingPrevOutRes struct {
+		Res *[]btcjson.GetTxSpendingPrevOutResult
+		Err error
+	}
+	// GetWorkRes is the result from a call to GetWork
+	GetWorkRes struct {
+		Res *btcjson.GetWorkResult
+		Err error
+	}
 	// HelpRes is the result from a call to Help
 	HelpRes struct {
 		Res *string
 		Err error
 	}
+	// InvalidateBlockRes is the result from a call to InvalidateBlock
+	InvalidateBlockRes struct {
+		Res *None
+		Err error
+	}
+	// ListBannedRes is the result from a call to ListBanned
+	ListBannedRes struct {
+		Res *[]btcjson.ListBannedResult
+		Err error
+	}
+	// LoadAddrManRes is the result from a call to LoadAddrMan
+	LoadAddrManRes struct {
+		Res *btcjson.LoadAddrManResult
+		Err error
+	}
+	// LoadUTXOSetRes is the result from a call to LoadUTXOSet
+	LoadUTXOSetRes struct {
+		Res *btcjson.UTXOSetSnapshotResult
+		Err error
+	}
 	// NodeRes is the result from a call to Node
 	NodeRes struct {
 		Res *None
@@ -226,6 +366,16 @@ type (
 		Res *None
 		Err error
 	}
+	// ReconsiderBlockRes is the result from a call to ReconsiderBlock
+	ReconsiderBlockRes struct {
+		Res *None
+		Err error
+	}
+	// ReleaseSnapshotRes is the result from a call to ReleaseSnapshot
+	ReleaseSnapshotRes struct {
+		Res *None
+		Err error
+	}
 	// ResetChainRes is the result from a call to ResetChain
 	ResetChainRes struct {
 		Res *None
@@ -236,6 +386,11 @@ type (
 		Res *None
 		Err error
 	}
+	// ScanTxOutSetRes is the result from a call to ScanTxOutSet
+	ScanTxOutSetRes struct {
+		Res *btcjson.ScanTxOutSetResult
+		Err error
+	}
 	// SearchRawTransactionsRes is the result from a call to SearchRawTransactions
 	SearchRawTransactionsRes struct {
 		Res *[]btcjson.SearchRawTransactionsResult
@@ -246,6 +401,11 @@ type (
 		Res *None
 		Err error
 	}
+	// SetBanRes is the result from a call to SetBan
+	SetBanRes struct {
+		Res *None
+		Err error
+	}
 	// SetGenerateRes is the result from a call to SetGenerate
 	SetGenerateRes struct {
 		Res *None
@@ -261,6 +421,16 @@ type (
 		Res *string
 		Err error
 	}
+	// SubmitHeaderRes is the result from a call to SubmitHeader
+	SubmitHeaderRes struct {
+		Res *string
+		Err error
+	}
+	// TestMempoolAcceptRes is the result from a call to TestMempoolAccept
+	TestMempoolAcceptRes struct {
+		Res *[]btcjson.TestMempoolAcceptResult
+		Err error
+	}
 	// UptimeRes is the result from a call to Uptime
 	UptimeRes struct {
 		Res *btcjson.GetMempoolInfoResult
@@ -305,6 +475,12 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"addnode": {
 		Fn: HandleAddNode, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan AddNodeRes)} }},
+	"calculatesighash": {
+		Fn: HandleCalculateSigHash, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CalculateSigHashRes)} }},
+	"clearbanned": {
+		Fn: HandleClearBanned, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ClearBannedRes)} }},
 	"createrawtransaction": {
 		Fn: HandleCreateRawTransaction, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateRawTransactionRes)} }},
@@ -314,15 +490,48 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"decodescript": {
 		Fn: HandleDecodeScript, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan DecodeScriptRes)} }},
+	"dumpaddrman": {
+		Fn: HandleDumpAddrMan, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan DumpAddrManRes)} }},
+	"dumputxoset": {
+		Fn: HandleDumpUTXOSet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan DumpUTXOSetRes)} }},
 	"estimatefee": {
 		Fn: HandleEstimateFee, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan EstimateFeeRes)} }},
+	"estimatesmartfee": {
+		Fn: HandleEstimateSmartFee, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan EstimateSmartFeeRes)} }},
+	"evaluatescript": {
+		Fn: HandleEvaluateScript, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan EvaluateScriptRes)} }},
 	"generate": {
 		Fn: HandleGenerate, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GenerateRes)} }},
+	"generateblock": {
+		Fn: HandleGenerateBlock, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GenerateBlockRes)} }},
+	"generatetoaddress": {
+		Fn: HandleGenerateToAddress, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GenerateToAddressRes)} }},
 	"getaddednodeinfo": {
 		Fn: HandleGetAddedNodeInfo, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAddedNodeInfoRes)} }},
+	"getaddressbalance": {
+		Fn: HandleGetAddressBalance, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAddressBalanceRes)} }},
+	"getaddresstxids": {
+		Fn: HandleGetAddressTxIDs, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAddressTxIDsRes)} }},
+	"getaddressutxos": {
+		Fn: HandleGetAddressUtxos, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAddressUtxosRes)} }},
+	"getaddrmaninfo": {
+		Fn: HandleGetAddrManInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAddrManInfoRes)} }},
+	"getauditlog": {
+		Fn: HandleGetAuditLog, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAuditLogRes)} }},
 	"getbestblock": {
 		Fn: HandleGetBestBlock, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBestBlockRes)} }},
@@ -338,12 +547,18 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getblockcount": {
 		Fn: HandleGetBlockCount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBlockCountRes)} }},
+	"getblockfilter": {
+		Fn: HandleGetBlockFilter, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetBlockFilterRes)} }},
 	"getblockhash": {
 		Fn: HandleGetBlockHash, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBlockHashRes)} }},
 	"getblockheader": {
 		Fn: HandleGetBlockHeader, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBlockHeaderRes)} }},
+	"getblocksubsidy": {
+		Fn: HandleGetBlockSubsidy, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetBlockSubsidyRes)} }},
 	"getblocktemplate": {
 		Fn: HandleGetBlockTemplate, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetBlockTemplateRes)} }},
@@ -377,6 +592,9 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getmempoolinfo": {
 		Fn: HandleGetMempoolInfo, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetMempoolInfoRes)} }},
+	"getminerstats": {
+		Fn: HandleGetMinerStats, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetMinerStatsRes)} }},
 	"getmininginfo": {
 		Fn: HandleGetMiningInfo, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetMiningInfoRes)} }},
@@ -386,39 +604,87 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"getnetworkhashps": {
 		Fn: HandleGetNetworkHashPS, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetNetworkHashPSRes)} }},
+	"getnetworkinfo": {
+		Fn: HandleGetNetworkInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetNetworkInfoRes)} }},
 	"getpeerinfo": {
 		Fn: HandleGetPeerInfo, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetPeerInfoRes)} }},
+	"getpeerstats": {
+		Fn: HandleGetPeerStats, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetPeerStatsRes)} }},
 	"getrawmempool": {
 		Fn: HandleGetRawMempool, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetRawMempoolRes)} }},
 	"getrawtransaction": {
 		Fn: HandleGetRawTransaction, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetRawTransactionRes)} }},
+	"getsnapshot": {
+		Fn: HandleGetSnapshot, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetSnapshotRes)} }},
+	"getspentinfo": {
+		Fn: HandleGetSpentInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetSpentInfoRes)} }},
+	"getsyncstatus": {
+		Fn: HandleGetSyncStatus, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetSyncStatusRes)} }},
+	"gettelemetryinfo": {
+		Fn: HandleGetTelemetryInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetTelemetryInfoRes)} }},
 	"gettxout": {
 		Fn: HandleGetTxOut, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetTxOutRes)} }},
+	"gettxspendingprevout": {
+		Fn: HandleGetTxSpendingPrevOut, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetTxSpendingPrevOutRes)} }},
+	"getwork": {
+		Fn: HandleGetWork, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetWorkRes)} }},
 	"help": {
 		Fn: HandleHelp, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HelpRes)} }},
+	"invalidateblock": {
+		Fn: HandleInvalidateBlock, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan InvalidateBlockRes)} }},
+	"listbanned": {
+		Fn: HandleListBanned, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListBannedRes)} }},
+	"loadaddrman": {
+		Fn: HandleLoadAddrMan, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan LoadAddrManRes)} }},
+	"loadutxoset": {
+		Fn: HandleLoadUTXOSet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan LoadUTXOSetRes)} }},
 	"node": {
 		Fn: HandleNode, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan NodeRes)} }},
 	"ping": {
 		Fn: HandlePing, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan PingRes)} }},
+	"reconsiderblock": {
+		Fn: HandleReconsiderBlock, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ReconsiderBlockRes)} }},
+	"releasesnapshot": {
+		Fn: HandleReleaseSnapshot, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ReleaseSnapshotRes)} }},
 	"resetchain": {
 		Fn: HandleResetChain, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ResetChainRes)} }},
 	"restart": {
 		Fn: HandleRestart, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan RestartRes)} }},
+	"scantxoutset": {
+		Fn: HandleScanTxOutSet, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ScanTxOutSetRes)} }},
 	"searchrawtransactions": {
 		Fn: HandleSearchRawTransactions, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SearchRawTransactionsRes)} }},
 	"sendrawtransaction": {
 		Fn: HandleSendRawTransaction, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SendRawTransactionRes)} }},
+	"setban": {
+		Fn: HandleSetBan, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SetBanRes)} }},
 	"setgenerate": {
 		Fn: HandleSetGenerate, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SetGenerateRes)} }},
@@ -428,6 +694,12 @@ var RPCHandlersBeforeInit = map[string]CommandHandler{
 	"submitblock": {
 		Fn: HandleSubmitBlock, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SubmitBlockRes)} }},
+	"submitheader": {
+		Fn: HandleSubmitHeader, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SubmitHeaderRes)} }},
+	"testmempoolaccept": {
+		Fn: HandleTestMempoolAccept, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan TestMempoolAcceptRes)} }},
 	"uptime": {
 		Fn: HandleUptime, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan UptimeRes)} }},
@@ -492,6 +764,88 @@ func (a API) AddNodeWait(cmd *btcjson.AddNodeCmd) (out *None, err error) {
 	return
 }
 
+// CalculateSigHash calls the method with the given parameters
+func (a API) CalculateSigHash(cmd *btcjson.CalculateSigHashCmd) (err error) {
+	RPCHandlers["calculatesighash"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// CalculateSigHashCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) CalculateSigHashCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan CalculateSigHashRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// CalculateSigHashGetRes returns a pointer to the value in the Result field
+func (a API) CalculateSigHashGetRes() (out *btcjson.CalculateSigHashResult, err error) {
+	out, _ = a.Result.(*btcjson.CalculateSigHashResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// CalculateSigHashWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CalculateSigHashWait(cmd *btcjson.CalculateSigHashCmd) (out *btcjson.CalculateSigHashResult, err error) {
+	RPCHandlers["calculatesighash"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan CalculateSigHashRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// ClearBanned calls the method with the given parameters
+func (a API) ClearBanned(cmd *None) (err error) {
+	RPCHandlers["clearbanned"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ClearBannedCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ClearBannedCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ClearBannedRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ClearBannedGetRes returns a pointer to the value in the Result field
+func (a API) ClearBannedGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return
+}
+
+// ClearBannedWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ClearBannedWait(cmd *None) (out *None, err error) {
+	RPCHandlers["clearbanned"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan ClearBannedRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // CreateRawTransaction calls the method with the given parameters
 func (a API) CreateRawTransaction(cmd *btcjson.CreateRawTransactionCmd) (err error) {
 	RPCHandlers["createrawtransaction"].Call <- API{a.Ch, cmd, nil}
@@ -615,17 +969,17 @@ func (a API) DecodeScriptWait(cmd *btcjson.DecodeScriptCmd) (out *btcjson.Decode
 	return
 }
 
-// EstimateFee calls the method with the given parameters
-func (a API) EstimateFee(cmd *btcjson.EstimateFeeCmd) (err error) {
-	RPCHandlers["estimatefee"].Call <- API{a.Ch, cmd, nil}
+// DumpAddrMan calls the method with the given parameters
+func (a API) DumpAddrMan(cmd *btcjson.DumpAddrManCmd) (err error) {
+	RPCHandlers["dumpaddrman"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// EstimateFeeCheck checks if a new message arrived on the result channel and 
+// DumpAddrManCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) EstimateFeeCheck() (isNew bool) {
+func (a API) DumpAddrManCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan EstimateFeeRes):
+	case o := <-a.Ch.(chan DumpAddrManRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -637,36 +991,36 @@ func (a API) EstimateFeeCheck() (isNew bool) {
 	return
 }
 
-// EstimateFeeGetRes returns a pointer to the value in the Result field
-func (a API) EstimateFeeGetRes() (out *float64, err error) {
-	out, _ = a.Result.(*float64)
+// DumpAddrManGetRes returns a pointer to the value in the Result field
+func (a API) DumpAddrManGetRes() (out *btcjson.DumpAddrManResult, err error) {
+	out, _ = a.Result.(*btcjson.DumpAddrManResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// EstimateFeeWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) EstimateFeeWait(cmd *btcjson.EstimateFeeCmd) (out *float64, err error) {
-	RPCHandlers["estimatefee"].Call <- API{a.Ch, cmd, nil}
+// DumpAddrManWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DumpAddrManWait(cmd *btcjson.DumpAddrManCmd) (out *btcjson.DumpAddrManResult, err error) {
+	RPCHandlers["dumpaddrman"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan EstimateFeeRes):
+	case o := <-a.Ch.(chan DumpAddrManRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// Generate calls the method with the given parameters
-func (a API) Generate(cmd *None) (err error) {
-	RPCHandlers["generate"].Call <- API{a.Ch, cmd, nil}
+// DumpUTXOSet calls the method with the given parameters
+func (a API) DumpUTXOSet(cmd *btcjson.DumpUTXOSetCmd) (err error) {
+	RPCHandlers["dumputxoset"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GenerateCheck checks if a new message arrived on the result channel and 
+// DumpUTXOSetCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GenerateCheck() (isNew bool) {
+func (a API) DumpUTXOSetCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GenerateRes):
+	case o := <-a.Ch.(chan DumpUTXOSetRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -678,36 +1032,36 @@ func (a API) GenerateCheck() (isNew bool) {
 	return
 }
 
-// GenerateGetRes returns a pointer to the value in the Result field
-func (a API) GenerateGetRes() (out *[]string, err error) {
-	out, _ = a.Result.(*[]string)
+// DumpUTXOSetGetRes returns a pointer to the value in the Result field
+func (a API) DumpUTXOSetGetRes() (out *btcjson.UTXOSetSnapshotResult, err error) {
+	out, _ = a.Result.(*btcjson.UTXOSetSnapshotResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GenerateWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GenerateWait(cmd *None) (out *[]string, err error) {
-	RPCHandlers["generate"].Call <- API{a.Ch, cmd, nil}
+// DumpUTXOSetWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DumpUTXOSetWait(cmd *btcjson.DumpUTXOSetCmd) (out *btcjson.UTXOSetSnapshotResult, err error) {
+	RPCHandlers["dumputxoset"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GenerateRes):
+	case o := <-a.Ch.(chan DumpUTXOSetRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetAddedNodeInfo calls the method with the given parameters
-func (a API) GetAddedNodeInfo(cmd *btcjson.GetAddedNodeInfoCmd) (err error) {
-	RPCHandlers["getaddednodeinfo"].Call <- API{a.Ch, cmd, nil}
+// EstimateFee calls the method with the given parameters
+func (a API) EstimateFee(cmd *btcjson.EstimateFeeCmd) (err error) {
+	RPCHandlers["estimatefee"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetAddedNodeInfoCheck checks if a new message arrived on the result channel and 
+// EstimateFeeCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetAddedNodeInfoCheck() (isNew bool) {
+func (a API) EstimateFeeCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetAddedNodeInfoRes):
+	case o := <-a.Ch.(chan EstimateFeeRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -719,36 +1073,36 @@ func (a API) GetAddedNodeInfoCheck() (isNew bool) {
 	return
 }
 
-// GetAddedNodeInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetAddedNodeInfoGetRes() (out *[]btcjson.GetAddedNodeInfoResultAddr, err error) {
-	out, _ = a.Result.(*[]btcjson.GetAddedNodeInfoResultAddr)
+// EstimateFeeGetRes returns a pointer to the value in the Result field
+func (a API) EstimateFeeGetRes() (out *float64, err error) {
+	out, _ = a.Result.(*float64)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetAddedNodeInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetAddedNodeInfoWait(cmd *btcjson.GetAddedNodeInfoCmd) (out *[]btcjson.GetAddedNodeInfoResultAddr, err error) {
-	RPCHandlers["getaddednodeinfo"].Call <- API{a.Ch, cmd, nil}
+// EstimateFeeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) EstimateFeeWait(cmd *btcjson.EstimateFeeCmd) (out *float64, err error) {
+	RPCHandlers["estimatefee"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetAddedNodeInfoRes):
+	case o := <-a.Ch.(chan EstimateFeeRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBestBlock calls the method with the given parameters
-func (a API) GetBestBlock(cmd *None) (err error) {
-	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+// EstimateSmartFee calls the method with the given parameters
+func (a API) EstimateSmartFee(cmd *btcjson.EstimateSmartFeeCmd) (err error) {
+	RPCHandlers["estimatesmartfee"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBestBlockCheck checks if a new message arrived on the result channel and 
+// EstimateSmartFeeCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBestBlockCheck() (isNew bool) {
+func (a API) EstimateSmartFeeCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBestBlockRes):
+	case o := <-a.Ch.(chan EstimateSmartFeeRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -760,36 +1114,36 @@ func (a API) GetBestBlockCheck() (isNew bool) {
 	return
 }
 
-// GetBestBlockGetRes returns a pointer to the value in the Result field
-func (a API) GetBestBlockGetRes() (out *btcjson.GetBestBlockResult, err error) {
-	out, _ = a.Result.(*btcjson.GetBestBlockResult)
+// EstimateSmartFeeGetRes returns a pointer to the value in the Result field
+func (a API) EstimateSmartFeeGetRes() (out *btcjson.EstimateSmartFeeResult, err error) {
+	out, _ = a.Result.(*btcjson.EstimateSmartFeeResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBestBlockWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBestBlockWait(cmd *None) (out *btcjson.GetBestBlockResult, err error) {
-	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+// EstimateSmartFeeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) EstimateSmartFeeWait(cmd *btcjson.EstimateSmartFeeCmd) (out *btcjson.EstimateSmartFeeResult, err error) {
+	RPCHandlers["estimatesmartfee"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBestBlockRes):
+	case o := <-a.Ch.(chan EstimateSmartFeeRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBestBlockHash calls the method with the given parameters
-func (a API) GetBestBlockHash(cmd *None) (err error) {
-	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+// EvaluateScript calls the method with the given parameters
+func (a API) EvaluateScript(cmd *btcjson.EvaluateScriptCmd) (err error) {
+	RPCHandlers["evaluatescript"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBestBlockHashCheck checks if a new message arrived on the result channel and 
+// EvaluateScriptCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBestBlockHashCheck() (isNew bool) {
+func (a API) EvaluateScriptCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBestBlockHashRes):
+	case o := <-a.Ch.(chan EvaluateScriptRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -801,36 +1155,36 @@ func (a API) GetBestBlockHashCheck() (isNew bool) {
 	return
 }
 
-// GetBestBlockHashGetRes returns a pointer to the value in the Result field
-func (a API) GetBestBlockHashGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// EvaluateScriptGetRes returns a pointer to the value in the Result field
+func (a API) EvaluateScriptGetRes() (out *btcjson.EvaluateScriptResult, err error) {
+	out, _ = a.Result.(*btcjson.EvaluateScriptResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBestBlockHashWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBestBlockHashWait(cmd *None) (out *string, err error) {
-	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+// EvaluateScriptWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) EvaluateScriptWait(cmd *btcjson.EvaluateScriptCmd) (out *btcjson.EvaluateScriptResult, err error) {
+	RPCHandlers["evaluatescript"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBestBlockHashRes):
+	case o := <-a.Ch.(chan EvaluateScriptRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBlock calls the method with the given parameters
-func (a API) GetBlock(cmd *btcjson.GetBlockCmd) (err error) {
-	RPCHandlers["getblock"].Call <- API{a.Ch, cmd, nil}
+// Generate calls the method with the given parameters
+func (a API) Generate(cmd *btcjson.GenerateCmd) (err error) {
+	RPCHandlers["generate"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockCheck checks if a new message arrived on the result channel and 
+// GenerateCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBlockCheck() (isNew bool) {
+func (a API) GenerateCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBlockRes):
+	case o := <-a.Ch.(chan GenerateRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -842,36 +1196,36 @@ func (a API) GetBlockCheck() (isNew bool) {
 	return
 }
 
-// GetBlockGetRes returns a pointer to the value in the Result field
-func (a API) GetBlockGetRes() (out *btcjson.GetBlockVerboseResult, err error) {
-	out, _ = a.Result.(*btcjson.GetBlockVerboseResult)
+// GenerateGetRes returns a pointer to the value in the Result field
+func (a API) GenerateGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBlockWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockWait(cmd *btcjson.GetBlockCmd) (out *btcjson.GetBlockVerboseResult, err error) {
-	RPCHandlers["getblock"].Call <- API{a.Ch, cmd, nil}
+// GenerateWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GenerateWait(cmd *btcjson.GenerateCmd) (out *[]string, err error) {
+	RPCHandlers["generate"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBlockRes):
+	case o := <-a.Ch.(chan GenerateRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBlockChainInfo calls the method with the given parameters
-func (a API) GetBlockChainInfo(cmd *None) (err error) {
-	RPCHandlers["getblockchaininfo"].Call <- API{a.Ch, cmd, nil}
+// GenerateBlock calls the method with the given parameters
+func (a API) GenerateBlock(cmd *btcjson.GenerateBlockCmd) (err error) {
+	RPCHandlers["generateblock"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockChainInfoCheck checks if a new message arrived on the result channel and 
+// GenerateBlockCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBlockChainInfoCheck() (isNew bool) {
+func (a API) GenerateBlockCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBlockChainInfoRes):
+	case o := <-a.Ch.(chan GenerateBlockRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -883,36 +1237,36 @@ func (a API) GetBlockChainInfoCheck() (isNew bool) {
 	return
 }
 
-// GetBlockChainInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetBlockChainInfoGetRes() (out *btcjson.GetBlockChainInfoResult, err error) {
-	out, _ = a.Result.(*btcjson.GetBlockChainInfoResult)
+// GenerateBlockGetRes returns a pointer to the value in the Result field
+func (a API) GenerateBlockGetRes() (out *btcjson.GenerateBlockResult, err error) {
+	out, _ = a.Result.(*btcjson.GenerateBlockResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBlockChainInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockChainInfoWait(cmd *None) (out *btcjson.GetBlockChainInfoResult, err error) {
-	RPCHandlers["getblockchaininfo"].Call <- API{a.Ch, cmd, nil}
+// GenerateBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GenerateBlockWait(cmd *btcjson.GenerateBlockCmd) (out *btcjson.GenerateBlockResult, err error) {
+	RPCHandlers["generateblock"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBlockChainInfoRes):
+	case o := <-a.Ch.(chan GenerateBlockRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBlockCount calls the method with the given parameters
-func (a API) GetBlockCount(cmd *None) (err error) {
-	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+// GenerateToAddress calls the method with the given parameters
+func (a API) GenerateToAddress(cmd *btcjson.GenerateToAddressCmd) (err error) {
+	RPCHandlers["generatetoaddress"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockCountCheck checks if a new message arrived on the result channel and 
+// GenerateToAddressCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBlockCountCheck() (isNew bool) {
+func (a API) GenerateToAddressCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBlockCountRes):
+	case o := <-a.Ch.(chan GenerateToAddressRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -924,36 +1278,979 @@ func (a API) GetBlockCountCheck() (isNew bool) {
 	return
 }
 
-// GetBlockCountGetRes returns a pointer to the value in the Result field
-func (a API) GetBlockCountGetRes() (out *int64, err error) {
-	out, _ = a.Result.(*int64)
+// GenerateToAddressGetRes returns a pointer to the value in the Result field
+func (a API) GenerateToAddressGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBlockCountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockCountWait(cmd *None) (out *int64, err error) {
+// GenerateToAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GenerateToAddressWait(cmd *btcjson.GenerateToAddressCmd) (out *[]string, err error) {
+	RPCHandlers["generatetoaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GenerateToAddressRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAddedNodeInfo calls the method with the given parameters
+func (a API) GetAddedNodeInfo(cmd *btcjson.GetAddedNodeInfoCmd) (err error) {
+	RPCHandlers["getaddednodeinfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAddedNodeInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetAddedNodeInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAddedNodeInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAddedNodeInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetAddedNodeInfoGetRes() (out *[]btcjson.GetAddedNodeInfoResultAddr, err error) {
+	out, _ = a.Result.(*[]btcjson.GetAddedNodeInfoResultAddr)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAddedNodeInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddedNodeInfoWait(cmd *btcjson.GetAddedNodeInfoCmd) (out *[]btcjson.GetAddedNodeInfoResultAddr, err error) {
+	RPCHandlers["getaddednodeinfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAddedNodeInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAddressBalance calls the method with the given parameters
+func (a API) GetAddressBalance(cmd *btcjson.GetAddressBalanceCmd) (err error) {
+	RPCHandlers["getaddressbalance"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAddressBalanceCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetAddressBalanceCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAddressBalanceRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAddressBalanceGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressBalanceGetRes() (out *btcjson.GetAddressBalanceResult, err error) {
+	out, _ = a.Result.(*btcjson.GetAddressBalanceResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAddressBalanceWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressBalanceWait(cmd *btcjson.GetAddressBalanceCmd) (out *btcjson.GetAddressBalanceResult, err error) {
+	RPCHandlers["getaddressbalance"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAddressBalanceRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAddressTxIDs calls the method with the given parameters
+func (a API) GetAddressTxIDs(cmd *btcjson.GetAddressTxIDsCmd) (err error) {
+	RPCHandlers["getaddresstxids"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAddressTxIDsCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetAddressTxIDsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAddressTxIDsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAddressTxIDsGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressTxIDsGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAddressTxIDsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressTxIDsWait(cmd *btcjson.GetAddressTxIDsCmd) (out *[]string, err error) {
+	RPCHandlers["getaddresstxids"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAddressTxIDsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAddressUtxos calls the method with the given parameters
+func (a API) GetAddressUtxos(cmd *btcjson.GetAddressUtxosCmd) (err error) {
+	RPCHandlers["getaddressutxos"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAddressUtxosCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetAddressUtxosCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAddressUtxosRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAddressUtxosGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressUtxosGetRes() (out *[]btcjson.GetAddressUtxosResult, err error) {
+	out, _ = a.Result.(*[]btcjson.GetAddressUtxosResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAddressUtxosWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressUtxosWait(cmd *btcjson.GetAddressUtxosCmd) (out *[]btcjson.GetAddressUtxosResult, err error) {
+	RPCHandlers["getaddressutxos"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAddressUtxosRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAddrManInfo calls the method with the given parameters
+func (a API) GetAddrManInfo(cmd *None) (err error) {
+	RPCHandlers["getaddrmaninfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAddrManInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetAddrManInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAddrManInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAddrManInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetAddrManInfoGetRes() (out *btcjson.GetAddrManInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetAddrManInfoResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAddrManInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddrManInfoWait(cmd *None) (out *btcjson.GetAddrManInfoResult, err error) {
+	RPCHandlers["getaddrmaninfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAddrManInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAuditLog calls the method with the given parameters
+func (a API) GetAuditLog(cmd *btcjson.GetAuditLogCmd) (err error) {
+	RPCHandlers["getauditlog"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAuditLogCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetAuditLogCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAuditLogRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAuditLogGetRes returns a pointer to the value in the Result field
+func (a API) GetAuditLogGetRes() (out *[]btcjson.AuditLogEntry, err error) {
+	out, _ = a.Result.(*[]btcjson.AuditLogEntry)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAuditLogWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAuditLogWait(cmd *btcjson.GetAuditLogCmd) (out *[]btcjson.AuditLogEntry, err error) {
+	RPCHandlers["getauditlog"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAuditLogRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBestBlock calls the method with the given parameters
+func (a API) GetBestBlock(cmd *None) (err error) {
+	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBestBlockCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBestBlockCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBestBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBestBlockGetRes returns a pointer to the value in the Result field
+func (a API) GetBestBlockGetRes() (out *btcjson.GetBestBlockResult, err error) {
+	out, _ = a.Result.(*btcjson.GetBestBlockResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBestBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBestBlockWait(cmd *None) (out *btcjson.GetBestBlockResult, err error) {
+	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBestBlockRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBestBlockHash calls the method with the given parameters
+func (a API) GetBestBlockHash(cmd *None) (err error) {
+	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBestBlockHashCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBestBlockHashCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBestBlockHashRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBestBlockHashGetRes returns a pointer to the value in the Result field
+func (a API) GetBestBlockHashGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBestBlockHashWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBestBlockHashWait(cmd *None) (out *string, err error) {
+	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBestBlockHashRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlock calls the method with the given parameters
+func (a API) GetBlock(cmd *btcjson.GetBlockCmd) (err error) {
+	RPCHandlers["getblock"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockGetRes() (out *btcjson.GetBlockVerboseResult, err error) {
+	out, _ = a.Result.(*btcjson.GetBlockVerboseResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockWait(cmd *btcjson.GetBlockCmd) (out *btcjson.GetBlockVerboseResult, err error) {
+	RPCHandlers["getblock"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockChainInfo calls the method with the given parameters
+func (a API) GetBlockChainInfo(cmd *None) (err error) {
+	RPCHandlers["getblockchaininfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockChainInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockChainInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockChainInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockChainInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockChainInfoGetRes() (out *btcjson.GetBlockChainInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetBlockChainInfoResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockChainInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockChainInfoWait(cmd *None) (out *btcjson.GetBlockChainInfoResult, err error) {
+	RPCHandlers["getblockchaininfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockChainInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockCount calls the method with the given parameters
+func (a API) GetBlockCount(cmd *btcjson.GetBlockCountCmd) (err error) {
+	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockCountCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockCountCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockCountRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockCountGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockCountGetRes() (out *int64, err error) {
+	out, _ = a.Result.(*int64)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockCountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockCountWait(cmd *btcjson.GetBlockCountCmd) (out *int64, err error) {
 	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBlockCountRes):
+	case o := <-a.Ch.(chan GetBlockCountRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockFilter calls the method with the given parameters
+func (a API) GetBlockFilter(cmd *btcjson.GetBlockFilterCmd) (err error) {
+	RPCHandlers["getblockfilter"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockFilterCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockFilterCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockFilterRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockFilterGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockFilterGetRes() (out *btcjson.GetBlockFilterResult, err error) {
+	out, _ = a.Result.(*btcjson.GetBlockFilterResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockFilterWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockFilterWait(cmd *btcjson.GetBlockFilterCmd) (out *btcjson.GetBlockFilterResult, err error) {
+	RPCHandlers["getblockfilter"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockFilterRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockHash calls the method with the given parameters
+func (a API) GetBlockHash(cmd *btcjson.GetBlockHashCmd) (err error) {
+	RPCHandlers["getblockhash"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockHashCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockHashCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockHashRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockHashGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockHashGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockHashWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockHashWait(cmd *btcjson.GetBlockHashCmd) (out *string, err error) {
+	RPCHandlers["getblockhash"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockHashRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockHeader calls the method with the given parameters
+func (a API) GetBlockHeader(cmd *btcjson.GetBlockHeaderCmd) (err error) {
+	RPCHandlers["getblockheader"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockHeaderCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockHeaderCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockHeaderRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockHeaderGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockHeaderGetRes() (out *btcjson.GetBlockHeaderVerboseResult, err error) {
+	out, _ = a.Result.(*btcjson.GetBlockHeaderVerboseResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockHeaderWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockHeaderWait(cmd *btcjson.GetBlockHeaderCmd) (out *btcjson.GetBlockHeaderVerboseResult, err error) {
+	RPCHandlers["getblockheader"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockHeaderRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockSubsidy calls the method with the given parameters
+func (a API) GetBlockSubsidy(cmd *btcjson.GetBlockSubsidyCmd) (err error) {
+	RPCHandlers["getblocksubsidy"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockSubsidyCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockSubsidyCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockSubsidyRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockSubsidyGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockSubsidyGetRes() (out *btcjson.GetBlockSubsidyResult, err error) {
+	out, _ = a.Result.(*btcjson.GetBlockSubsidyResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockSubsidyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockSubsidyWait(cmd *btcjson.GetBlockSubsidyCmd) (out *btcjson.GetBlockSubsidyResult, err error) {
+	RPCHandlers["getblocksubsidy"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockSubsidyRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockTemplate calls the method with the given parameters
+func (a API) GetBlockTemplate(cmd *btcjson.GetBlockTemplateCmd) (err error) {
+	RPCHandlers["getblocktemplate"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockTemplateCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetBlockTemplateCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockTemplateRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBlockTemplateGetRes returns a pointer to the value in the Result field
+func (a API) GetBlockTemplateGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBlockTemplateWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockTemplateWait(cmd *btcjson.GetBlockTemplateCmd) (out *string, err error) {
+	RPCHandlers["getblocktemplate"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockTemplateRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetCFilter calls the method with the given parameters
+func (a API) GetCFilter(cmd *btcjson.GetCFilterCmd) (err error) {
+	RPCHandlers["getcfilter"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetCFilterCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetCFilterCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetCFilterRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetCFilterGetRes returns a pointer to the value in the Result field
+func (a API) GetCFilterGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetCFilterWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetCFilterWait(cmd *btcjson.GetCFilterCmd) (out *string, err error) {
+	RPCHandlers["getcfilter"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetCFilterRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetCFilterHeader calls the method with the given parameters
+func (a API) GetCFilterHeader(cmd *btcjson.GetCFilterHeaderCmd) (err error) {
+	RPCHandlers["getcfilterheader"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetCFilterHeaderCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetCFilterHeaderCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetCFilterHeaderRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetCFilterHeaderGetRes returns a pointer to the value in the Result field
+func (a API) GetCFilterHeaderGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetCFilterHeaderWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetCFilterHeaderWait(cmd *btcjson.GetCFilterHeaderCmd) (out *string, err error) {
+	RPCHandlers["getcfilterheader"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetCFilterHeaderRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetConnectionCount calls the method with the given parameters
+func (a API) GetConnectionCount(cmd *None) (err error) {
+	RPCHandlers["getconnectioncount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetConnectionCountCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetConnectionCountCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetConnectionCountRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetConnectionCountGetRes returns a pointer to the value in the Result field
+func (a API) GetConnectionCountGetRes() (out *int32, err error) {
+	out, _ = a.Result.(*int32)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetConnectionCountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetConnectionCountWait(cmd *None) (out *int32, err error) {
+	RPCHandlers["getconnectioncount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetConnectionCountRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetCurrentNet calls the method with the given parameters
+func (a API) GetCurrentNet(cmd *None) (err error) {
+	RPCHandlers["getcurrentnet"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetCurrentNetCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetCurrentNetCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetCurrentNetRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetCurrentNetGetRes returns a pointer to the value in the Result field
+func (a API) GetCurrentNetGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetCurrentNetWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetCurrentNetWait(cmd *None) (out *string, err error) {
+	RPCHandlers["getcurrentnet"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetCurrentNetRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetDifficulty calls the method with the given parameters
+func (a API) GetDifficulty(cmd *btcjson.GetDifficultyCmd) (err error) {
+	RPCHandlers["getdifficulty"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetDifficultyCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetDifficultyCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetDifficultyRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetDifficultyGetRes returns a pointer to the value in the Result field
+func (a API) GetDifficultyGetRes() (out *float64, err error) {
+	out, _ = a.Result.(*float64)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetDifficultyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetDifficultyWait(cmd *btcjson.GetDifficultyCmd) (out *float64, err error) {
+	RPCHandlers["getdifficulty"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetDifficultyRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetGenerate calls the method with the given parameters
+func (a API) GetGenerate(cmd *btcjson.GetHeadersCmd) (err error) {
+	RPCHandlers["getgenerate"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetGenerateCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetGenerateCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetGenerateRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetGenerateGetRes returns a pointer to the value in the Result field
+func (a API) GetGenerateGetRes() (out *bool, err error) {
+	out, _ = a.Result.(*bool)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetGenerateWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetGenerateWait(cmd *btcjson.GetHeadersCmd) (out *bool, err error) {
+	RPCHandlers["getgenerate"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetGenerateRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetHashesPerSec calls the method with the given parameters
+func (a API) GetHashesPerSec(cmd *None) (err error) {
+	RPCHandlers["gethashespersec"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetHashesPerSecCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetHashesPerSecCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetHashesPerSecRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetHashesPerSecGetRes returns a pointer to the value in the Result field
+func (a API) GetHashesPerSecGetRes() (out *float64, err error) {
+	out, _ = a.Result.(*float64)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetHashesPerSecWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetHashesPerSecWait(cmd *None) (out *float64, err error) {
+	RPCHandlers["gethashespersec"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetHashesPerSecRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBlockHash calls the method with the given parameters
-func (a API) GetBlockHash(cmd *btcjson.GetBlockHashCmd) (err error) {
-	RPCHandlers["getblockhash"].Call <- API{a.Ch, cmd, nil}
+// GetHeaders calls the method with the given parameters
+func (a API) GetHeaders(cmd *btcjson.GetHeadersCmd) (err error) {
+	RPCHandlers["getheaders"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockHashCheck checks if a new message arrived on the result channel and 
+// GetHeadersCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBlockHashCheck() (isNew bool) {
+func (a API) GetHeadersCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBlockHashRes):
+	case o := <-a.Ch.(chan GetHeadersRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -965,36 +2262,241 @@ func (a API) GetBlockHashCheck() (isNew bool) {
 	return
 }
 
-// GetBlockHashGetRes returns a pointer to the value in the Result field
-func (a API) GetBlockHashGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// GetHeadersGetRes returns a pointer to the value in the Result field
+func (a API) GetHeadersGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBlockHashWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockHashWait(cmd *btcjson.GetBlockHashCmd) (out *string, err error) {
-	RPCHandlers["getblockhash"].Call <- API{a.Ch, cmd, nil}
+// GetHeadersWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetHeadersWait(cmd *btcjson.GetHeadersCmd) (out *[]string, err error) {
+	RPCHandlers["getheaders"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBlockHashRes):
+	case o := <-a.Ch.(chan GetHeadersRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBlockHeader calls the method with the given parameters
-func (a API) GetBlockHeader(cmd *btcjson.GetBlockHeaderCmd) (err error) {
-	RPCHandlers["getblockheader"].Call <- API{a.Ch, cmd, nil}
+// GetInfo calls the method with the given parameters
+func (a API) GetInfo(cmd *None) (err error) {
+	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockHeaderCheck checks if a new message arrived on the result channel and 
+// GetInfoCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBlockHeaderCheck() (isNew bool) {
+func (a API) GetInfoCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBlockHeaderRes):
+	case o := <-a.Ch.(chan GetInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetInfoGetRes() (out *btcjson.InfoChainResult0, err error) {
+	out, _ = a.Result.(*btcjson.InfoChainResult0)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoChainResult0, err error) {
+	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetMempoolInfo calls the method with the given parameters
+func (a API) GetMempoolInfo(cmd *None) (err error) {
+	RPCHandlers["getmempoolinfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMempoolInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMempoolInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMempoolInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMempoolInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetMempoolInfoGetRes() (out *btcjson.GetMempoolInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetMempoolInfoResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetMempoolInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMempoolInfoWait(cmd *None) (out *btcjson.GetMempoolInfoResult, err error) {
+	RPCHandlers["getmempoolinfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetMempoolInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetMinerStats calls the method with the given parameters
+func (a API) GetMinerStats(cmd *None) (err error) {
+	RPCHandlers["getminerstats"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMinerStatsCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMinerStatsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMinerStatsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMinerStatsGetRes returns a pointer to the value in the Result field
+func (a API) GetMinerStatsGetRes() (out *btcjson.GetMinerStatsResult, err error) {
+	out, _ = a.Result.(*btcjson.GetMinerStatsResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetMinerStatsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMinerStatsWait(cmd *None) (out *btcjson.GetMinerStatsResult, err error) {
+	RPCHandlers["getminerstats"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetMinerStatsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetMiningInfo calls the method with the given parameters
+func (a API) GetMiningInfo(cmd *None) (err error) {
+	RPCHandlers["getmininginfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetMiningInfoCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetMiningInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetMiningInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetMiningInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetMiningInfoGetRes() (out *btcjson.GetMiningInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetMiningInfoResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetMiningInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetMiningInfoWait(cmd *None) (out *btcjson.GetMiningInfoResult, err error) {
+	RPCHandlers["getmininginfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetMiningInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetNetTotals calls the method with the given parameters
+func (a API) GetNetTotals(cmd *None) (err error) {
+	RPCHandlers["getnettotals"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetNetTotalsCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetNetTotalsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetNetTotalsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetNetTotalsGetRes returns a pointer to the value in the Result field
+func (a API) GetNetTotalsGetRes() (out *btcjson.GetNetTotalsResult, err error) {
+	out, _ = a.Result.(*btcjson.GetNetTotalsResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetNetTotalsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetTotalsWait(cmd *None) (out *btcjson.GetNetTotalsResult, err error) {
+	RPCHandlers["getnettotals"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetNetTotalsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetNetworkHashPS calls the method with the given parameters
+func (a API) GetNetworkHashPS(cmd *btcjson.GetNetworkHashPSCmd) (err error) {
+	RPCHandlers["getnetworkhashps"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetNetworkHashPSCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) GetNetworkHashPSCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetNetworkHashPSRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1006,36 +2508,36 @@ func (a API) GetBlockHeaderCheck() (isNew bool) {
 	return
 }
 
-// GetBlockHeaderGetRes returns a pointer to the value in the Result field
-func (a API) GetBlockHeaderGetRes() (out *btcjson.GetBlockHeaderVerboseResult, err error) {
-	out, _ = a.Result.(*btcjson.GetBlockHeaderVerboseResult)
+// GetNetworkHashPSGetRes returns a pointer to the value in the Result field
+func (a API) GetNetworkHashPSGetRes() (out *[]btcjson.GetPeerInfoResult, err error) {
+	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBlockHeaderWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockHeaderWait(cmd *btcjson.GetBlockHeaderCmd) (out *btcjson.GetBlockHeaderVerboseResult, err error) {
-	RPCHandlers["getblockheader"].Call <- API{a.Ch, cmd, nil}
+// GetNetworkHashPSWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetworkHashPSWait(cmd *btcjson.GetNetworkHashPSCmd) (out *[]btcjson.GetPeerInfoResult, err error) {
+	RPCHandlers["getnetworkhashps"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBlockHeaderRes):
+	case o := <-a.Ch.(chan GetNetworkHashPSRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBlockTemplate calls the method with the given parameters
-func (a API) GetBlockTemplate(cmd *btcjson.GetBlockTemplateCmd) (err error) {
-	RPCHandlers["getblocktemplate"].Call <- API{a.Ch, cmd, nil}
+// GetNetworkInfo calls the method with the given parameters
+func (a API) GetNetworkInfo(cmd *None) (err error) {
+	RPCHandlers["getnetworkinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockTemplateCheck checks if a new message arrived on the result channel and 
+// GetNetworkInfoCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetBlockTemplateCheck() (isNew bool) {
+func (a API) GetNetworkInfoCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBlockTemplateRes):
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1047,36 +2549,36 @@ func (a API) GetBlockTemplateCheck() (isNew bool) {
 	return
 }
 
-// GetBlockTemplateGetRes returns a pointer to the value in the Result field
-func (a API) GetBlockTemplateGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// GetNetworkInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetNetworkInfoGetRes() (out *btcjson.GetNetworkInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetNetworkInfoResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBlockTemplateWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockTemplateWait(cmd *btcjson.GetBlockTemplateCmd) (out *string, err error) {
-	RPCHandlers["getblocktemplate"].Call <- API{a.Ch, cmd, nil}
+// GetNetworkInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNetworkInfoWait(cmd *None) (out *btcjson.GetNetworkInfoResult, err error) {
+	RPCHandlers["getnetworkinfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBlockTemplateRes):
+	case o := <-a.Ch.(chan GetNetworkInfoRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetCFilter calls the method with the given parameters
-func (a API) GetCFilter(cmd *btcjson.GetCFilterCmd) (err error) {
-	RPCHandlers["getcfilter"].Call <- API{a.Ch, cmd, nil}
+// GetPeerInfo calls the method with the given parameters
+func (a API) GetPeerInfo(cmd *None) (err error) {
+	RPCHandlers["getpeerinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetCFilterCheck checks if a new message arrived on the result channel and 
+// GetPeerInfoCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetCFilterCheck() (isNew bool) {
+func (a API) GetPeerInfoCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetCFilterRes):
+	case o := <-a.Ch.(chan GetPeerInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1088,36 +2590,36 @@ func (a API) GetCFilterCheck() (isNew bool) {
 	return
 }
 
-// GetCFilterGetRes returns a pointer to the value in the Result field
-func (a API) GetCFilterGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// GetPeerInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetPeerInfoGetRes() (out *[]btcjson.GetPeerInfoResult, err error) {
+	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetCFilterWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetCFilterWait(cmd *btcjson.GetCFilterCmd) (out *string, err error) {
-	RPCHandlers["getcfilter"].Call <- API{a.Ch, cmd, nil}
+// GetPeerInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetPeerInfoWait(cmd *None) (out *[]btcjson.GetPeerInfoResult, err error) {
+	RPCHandlers["getpeerinfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetCFilterRes):
+	case o := <-a.Ch.(chan GetPeerInfoRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetCFilterHeader calls the method with the given parameters
-func (a API) GetCFilterHeader(cmd *btcjson.GetCFilterHeaderCmd) (err error) {
-	RPCHandlers["getcfilterheader"].Call <- API{a.Ch, cmd, nil}
+// GetPeerStats calls the method with the given parameters
+func (a API) GetPeerStats(cmd *None) (err error) {
+	RPCHandlers["getpeerstats"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetCFilterHeaderCheck checks if a new message arrived on the result channel and 
+// GetPeerStatsCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetCFilterHeaderCheck() (isNew bool) {
+func (a API) GetPeerStatsCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetCFilterHeaderRes):
+	case o := <-a.Ch.(chan GetPeerStatsRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1129,36 +2631,36 @@ func (a API) GetCFilterHeaderCheck() (isNew bool) {
 	return
 }
 
-// GetCFilterHeaderGetRes returns a pointer to the value in the Result field
-func (a API) GetCFilterHeaderGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// GetPeerStatsGetRes returns a pointer to the value in the Result field
+func (a API) GetPeerStatsGetRes() (out *btcjson.GetPeerStatsResult, err error) {
+	out, _ = a.Result.(*btcjson.GetPeerStatsResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetCFilterHeaderWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetCFilterHeaderWait(cmd *btcjson.GetCFilterHeaderCmd) (out *string, err error) {
-	RPCHandlers["getcfilterheader"].Call <- API{a.Ch, cmd, nil}
+// GetPeerStatsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetPeerStatsWait(cmd *None) (out *btcjson.GetPeerStatsResult, err error) {
+	RPCHandlers["getpeerstats"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetCFilterHeaderRes):
+	case o := <-a.Ch.(chan GetPeerStatsRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetConnectionCount calls the method with the given parameters
-func (a API) GetConnectionCount(cmd *None) (err error) {
-	RPCHandlers["getconnectioncount"].Call <- API{a.Ch, cmd, nil}
+// GetRawMempool calls the method with the given parameters
+func (a API) GetRawMempool(cmd *btcjson.GetRawMempoolCmd) (err error) {
+	RPCHandlers["getrawmempool"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetConnectionCountCheck checks if a new message arrived on the result channel and 
+// GetRawMempoolCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetConnectionCountCheck() (isNew bool) {
+func (a API) GetRawMempoolCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetConnectionCountRes):
+	case o := <-a.Ch.(chan GetRawMempoolRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1170,36 +2672,36 @@ func (a API) GetConnectionCountCheck() (isNew bool) {
 	return
 }
 
-// GetConnectionCountGetRes returns a pointer to the value in the Result field
-func (a API) GetConnectionCountGetRes() (out *int32, err error) {
-	out, _ = a.Result.(*int32)
+// GetRawMempoolGetRes returns a pointer to the value in the Result field
+func (a API) GetRawMempoolGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetConnectionCountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetConnectionCountWait(cmd *None) (out *int32, err error) {
-	RPCHandlers["getconnectioncount"].Call <- API{a.Ch, cmd, nil}
+// GetRawMempoolWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetRawMempoolWait(cmd *btcjson.GetRawMempoolCmd) (out *[]string, err error) {
+	RPCHandlers["getrawmempool"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetConnectionCountRes):
+	case o := <-a.Ch.(chan GetRawMempoolRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetCurrentNet calls the method with the given parameters
-func (a API) GetCurrentNet(cmd *None) (err error) {
-	RPCHandlers["getcurrentnet"].Call <- API{a.Ch, cmd, nil}
+// GetRawTransaction calls the method with the given parameters
+func (a API) GetRawTransaction(cmd *btcjson.GetRawTransactionCmd) (err error) {
+	RPCHandlers["getrawtransaction"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetCurrentNetCheck checks if a new message arrived on the result channel and 
+// GetRawTransactionCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetCurrentNetCheck() (isNew bool) {
+func (a API) GetRawTransactionCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetCurrentNetRes):
+	case o := <-a.Ch.(chan GetRawTransactionRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1211,36 +2713,36 @@ func (a API) GetCurrentNetCheck() (isNew bool) {
 	return
 }
 
-// GetCurrentNetGetRes returns a pointer to the value in the Result field
-func (a API) GetCurrentNetGetRes() (out *string, err error) {
+// GetRawTransactionGetRes returns a pointer to the value in the Result field
+func (a API) GetRawTransactionGetRes() (out *string, err error) {
 	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetCurrentNetWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetCurrentNetWait(cmd *None) (out *string, err error) {
-	RPCHandlers["getcurrentnet"].Call <- API{a.Ch, cmd, nil}
+// GetRawTransactionWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetRawTransactionWait(cmd *btcjson.GetRawTransactionCmd) (out *string, err error) {
+	RPCHandlers["getrawtransaction"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetCurrentNetRes):
+	case o := <-a.Ch.(chan GetRawTransactionRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetDifficulty calls the method with the given parameters
-func (a API) GetDifficulty(cmd *btcjson.GetDifficultyCmd) (err error) {
-	RPCHandlers["getdifficulty"].Call <- API{a.Ch, cmd, nil}
+// GetSnapshot calls the method with the given parameters
+func (a API) GetSnapshot(cmd *None) (err error) {
+	RPCHandlers["getsnapshot"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetDifficultyCheck checks if a new message arrived on the result channel and 
+// GetSnapshotCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetDifficultyCheck() (isNew bool) {
+func (a API) GetSnapshotCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetDifficultyRes):
+	case o := <-a.Ch.(chan GetSnapshotRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1252,36 +2754,36 @@ func (a API) GetDifficultyCheck() (isNew bool) {
 	return
 }
 
-// GetDifficultyGetRes returns a pointer to the value in the Result field
-func (a API) GetDifficultyGetRes() (out *float64, err error) {
-	out, _ = a.Result.(*float64)
+// GetSnapshotGetRes returns a pointer to the value in the Result field
+func (a API) GetSnapshotGetRes() (out *btcjson.GetSnapshotResult, err error) {
+	out, _ = a.Result.(*btcjson.GetSnapshotResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetDifficultyWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetDifficultyWait(cmd *btcjson.GetDifficultyCmd) (out *float64, err error) {
-	RPCHandlers["getdifficulty"].Call <- API{a.Ch, cmd, nil}
+// GetSnapshotWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetSnapshotWait(cmd *None) (out *btcjson.GetSnapshotResult, err error) {
+	RPCHandlers["getsnapshot"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetDifficultyRes):
+	case o := <-a.Ch.(chan GetSnapshotRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetGenerate calls the method with the given parameters
-func (a API) GetGenerate(cmd *btcjson.GetHeadersCmd) (err error) {
-	RPCHandlers["getgenerate"].Call <- API{a.Ch, cmd, nil}
+// GetSpentInfo calls the method with the given parameters
+func (a API) GetSpentInfo(cmd *btcjson.GetSpentInfoCmd) (err error) {
+	RPCHandlers["getspentinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetGenerateCheck checks if a new message arrived on the result channel and 
+// GetSpentInfoCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetGenerateCheck() (isNew bool) {
+func (a API) GetSpentInfoCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetGenerateRes):
+	case o := <-a.Ch.(chan GetSpentInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1293,36 +2795,36 @@ func (a API) GetGenerateCheck() (isNew bool) {
 	return
 }
 
-// GetGenerateGetRes returns a pointer to the value in the Result field
-func (a API) GetGenerateGetRes() (out *bool, err error) {
-	out, _ = a.Result.(*bool)
+// GetSpentInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetSpentInfoGetRes() (out *btcjson.GetSpentInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetSpentInfoResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetGenerateWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetGenerateWait(cmd *btcjson.GetHeadersCmd) (out *bool, err error) {
-	RPCHandlers["getgenerate"].Call <- API{a.Ch, cmd, nil}
+// GetSpentInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetSpentInfoWait(cmd *btcjson.GetSpentInfoCmd) (out *btcjson.GetSpentInfoResult, err error) {
+	RPCHandlers["getspentinfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetGenerateRes):
+	case o := <-a.Ch.(chan GetSpentInfoRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetHashesPerSec calls the method with the given parameters
-func (a API) GetHashesPerSec(cmd *None) (err error) {
-	RPCHandlers["gethashespersec"].Call <- API{a.Ch, cmd, nil}
+// GetSyncStatus calls the method with the given parameters
+func (a API) GetSyncStatus(cmd *btcjson.GetSyncStatusCmd) (err error) {
+	RPCHandlers["getsyncstatus"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetHashesPerSecCheck checks if a new message arrived on the result channel and 
+// GetSyncStatusCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetHashesPerSecCheck() (isNew bool) {
+func (a API) GetSyncStatusCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetHashesPerSecRes):
+	case o := <-a.Ch.(chan GetSyncStatusRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1334,36 +2836,36 @@ func (a API) GetHashesPerSecCheck() (isNew bool) {
 	return
 }
 
-// GetHashesPerSecGetRes returns a pointer to the value in the Result field
-func (a API) GetHashesPerSecGetRes() (out *float64, err error) {
-	out, _ = a.Result.(*float64)
+// GetSyncStatusGetRes returns a pointer to the value in the Result field
+func (a API) GetSyncStatusGetRes() (out *btcjson.GetSyncStatusResult, err error) {
+	out, _ = a.Result.(*btcjson.GetSyncStatusResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetHashesPerSecWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetHashesPerSecWait(cmd *None) (out *float64, err error) {
-	RPCHandlers["gethashespersec"].Call <- API{a.Ch, cmd, nil}
+// GetSyncStatusWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetSyncStatusWait(cmd *btcjson.GetSyncStatusCmd) (out *btcjson.GetSyncStatusResult, err error) {
+	RPCHandlers["getsyncstatus"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetHashesPerSecRes):
+	case o := <-a.Ch.(chan GetSyncStatusRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetHeaders calls the method with the given parameters
-func (a API) GetHeaders(cmd *btcjson.GetHeadersCmd) (err error) {
-	RPCHandlers["getheaders"].Call <- API{a.Ch, cmd, nil}
+// GetTelemetryInfo calls the method with the given parameters
+func (a API) GetTelemetryInfo(cmd *None) (err error) {
+	RPCHandlers["gettelemetryinfo"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetHeadersCheck checks if a new message arrived on the result channel and 
+// GetTelemetryInfoCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetHeadersCheck() (isNew bool) {
+func (a API) GetTelemetryInfoCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetHeadersRes):
+	case o := <-a.Ch.(chan GetTelemetryInfoRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1375,36 +2877,36 @@ func (a API) GetHeadersCheck() (isNew bool) {
 	return
 }
 
-// GetHeadersGetRes returns a pointer to the value in the Result field
-func (a API) GetHeadersGetRes() (out *[]string, err error) {
-	out, _ = a.Result.(*[]string)
+// GetTelemetryInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetTelemetryInfoGetRes() (out *btcjson.GetTelemetryInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetTelemetryInfoResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetHeadersWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetHeadersWait(cmd *btcjson.GetHeadersCmd) (out *[]string, err error) {
-	RPCHandlers["getheaders"].Call <- API{a.Ch, cmd, nil}
+// GetTelemetryInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetTelemetryInfoWait(cmd *None) (out *btcjson.GetTelemetryInfoResult, err error) {
+	RPCHandlers["gettelemetryinfo"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetHeadersRes):
+	case o := <-a.Ch.(chan GetTelemetryInfoRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetInfo calls the method with the given parameters
-func (a API) GetInfo(cmd *None) (err error) {
-	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+// GetTxOut calls the method with the given parameters
+func (a API) GetTxOut(cmd *btcjson.GetTxOutCmd) (err error) {
+	RPCHandlers["gettxout"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetInfoCheck checks if a new message arrived on the result channel and 
+// GetTxOutCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetInfoCheck() (isNew bool) {
+func (a API) GetTxOutCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetInfoRes):
+	case o := <-a.Ch.(chan GetTxOutRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1416,36 +2918,36 @@ func (a API) GetInfoCheck() (isNew bool) {
 	return
 }
 
-// GetInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetInfoGetRes() (out *btcjson.InfoChainResult0, err error) {
-	out, _ = a.Result.(*btcjson.InfoChainResult0)
+// GetTxOutGetRes returns a pointer to the value in the Result field
+func (a API) GetTxOutGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoChainResult0, err error) {
-	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+// GetTxOutWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetTxOutWait(cmd *btcjson.GetTxOutCmd) (out *string, err error) {
+	RPCHandlers["gettxout"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetInfoRes):
+	case o := <-a.Ch.(chan GetTxOutRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetMempoolInfo calls the method with the given parameters
-func (a API) GetMempoolInfo(cmd *None) (err error) {
-	RPCHandlers["getmempoolinfo"].Call <- API{a.Ch, cmd, nil}
+// GetTxSpendingPrevOut calls the method with the given parameters
+func (a API) GetTxSpendingPrevOut(cmd *btcjson.GetTxSpendingPrevOutCmd) (err error) {
+	RPCHandlers["gettxspendingprevout"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetMempoolInfoCheck checks if a new message arrived on the result channel and 
+// GetTxSpendingPrevOutCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetMempoolInfoCheck() (isNew bool) {
+func (a API) GetTxSpendingPrevOutCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetMempoolInfoRes):
+	case o := <-a.Ch.(chan GetTxSpendingPrevOutRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1457,36 +2959,36 @@ func (a API) GetMempoolInfoCheck() (isNew bool) {
 	return
 }
 
-// GetMempoolInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetMempoolInfoGetRes() (out *btcjson.GetMempoolInfoResult, err error) {
-	out, _ = a.Result.(*btcjson.GetMempoolInfoResult)
+// GetTxSpendingPrevOutGetRes returns a pointer to the value in the Result field
+func (a API) GetTxSpendingPrevOutGetRes() (out *[]btcjson.GetTxSpendingPrevOutResult, err error) {
+	out, _ = a.Result.(*[]btcjson.GetTxSpendingPrevOutResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetMempoolInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetMempoolInfoWait(cmd *None) (out *btcjson.GetMempoolInfoResult, err error) {
-	RPCHandlers["getmempoolinfo"].Call <- API{a.Ch, cmd, nil}
+// GetTxSpendingPrevOutWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetTxSpendingPrevOutWait(cmd *btcjson.GetTxSpendingPrevOutCmd) (out *[]btcjson.GetTxSpendingPrevOutResult, err error) {
+	RPCHandlers["gettxspendingprevout"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetMempoolInfoRes):
+	case o := <-a.Ch.(chan GetTxSpendingPrevOutRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetMiningInfo calls the method with the given parameters
-func (a API) GetMiningInfo(cmd *None) (err error) {
-	RPCHandlers["getmininginfo"].Call <- API{a.Ch, cmd, nil}
+// GetWork calls the method with the given parameters
+func (a API) GetWork(cmd *btcjson.GetWorkCmd) (err error) {
+	RPCHandlers["getwork"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetMiningInfoCheck checks if a new message arrived on the result channel and 
+// GetWorkCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetMiningInfoCheck() (isNew bool) {
+func (a API) GetWorkCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetMiningInfoRes):
+	case o := <-a.Ch.(chan GetWorkRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1498,36 +3000,36 @@ func (a API) GetMiningInfoCheck() (isNew bool) {
 	return
 }
 
-// GetMiningInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetMiningInfoGetRes() (out *btcjson.GetMiningInfoResult, err error) {
-	out, _ = a.Result.(*btcjson.GetMiningInfoResult)
+// GetWorkGetRes returns a pointer to the value in the Result field
+func (a API) GetWorkGetRes() (out *btcjson.GetWorkResult, err error) {
+	out, _ = a.Result.(*btcjson.GetWorkResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetMiningInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetMiningInfoWait(cmd *None) (out *btcjson.GetMiningInfoResult, err error) {
-	RPCHandlers["getmininginfo"].Call <- API{a.Ch, cmd, nil}
+// GetWorkWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetWorkWait(cmd *btcjson.GetWorkCmd) (out *btcjson.GetWorkResult, err error) {
+	RPCHandlers["getwork"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetMiningInfoRes):
+	case o := <-a.Ch.(chan GetWorkRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetNetTotals calls the method with the given parameters
-func (a API) GetNetTotals(cmd *None) (err error) {
-	RPCHandlers["getnettotals"].Call <- API{a.Ch, cmd, nil}
+// Help calls the method with the given parameters
+func (a API) Help(cmd *btcjson.HelpCmd) (err error) {
+	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetNetTotalsCheck checks if a new message arrived on the result channel and 
+// HelpCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetNetTotalsCheck() (isNew bool) {
+func (a API) HelpCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetNetTotalsRes):
+	case o := <-a.Ch.(chan HelpRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1539,36 +3041,36 @@ func (a API) GetNetTotalsCheck() (isNew bool) {
 	return
 }
 
-// GetNetTotalsGetRes returns a pointer to the value in the Result field
-func (a API) GetNetTotalsGetRes() (out *btcjson.GetNetTotalsResult, err error) {
-	out, _ = a.Result.(*btcjson.GetNetTotalsResult)
+// HelpGetRes returns a pointer to the value in the Result field
+func (a API) HelpGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetNetTotalsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetNetTotalsWait(cmd *None) (out *btcjson.GetNetTotalsResult, err error) {
-	RPCHandlers["getnettotals"].Call <- API{a.Ch, cmd, nil}
+// HelpWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) HelpWait(cmd *btcjson.HelpCmd) (out *string, err error) {
+	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetNetTotalsRes):
+	case o := <-a.Ch.(chan HelpRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetNetworkHashPS calls the method with the given parameters
-func (a API) GetNetworkHashPS(cmd *btcjson.GetNetworkHashPSCmd) (err error) {
-	RPCHandlers["getnetworkhashps"].Call <- API{a.Ch, cmd, nil}
+// InvalidateBlock calls the method with the given parameters
+func (a API) InvalidateBlock(cmd *btcjson.InvalidateBlockCmd) (err error) {
+	RPCHandlers["invalidateblock"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetNetworkHashPSCheck checks if a new message arrived on the result channel and 
+// InvalidateBlockCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetNetworkHashPSCheck() (isNew bool) {
+func (a API) InvalidateBlockCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetNetworkHashPSRes):
+	case o := <-a.Ch.(chan InvalidateBlockRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1580,36 +3082,36 @@ func (a API) GetNetworkHashPSCheck() (isNew bool) {
 	return
 }
 
-// GetNetworkHashPSGetRes returns a pointer to the value in the Result field
-func (a API) GetNetworkHashPSGetRes() (out *[]btcjson.GetPeerInfoResult, err error) {
-	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
+// InvalidateBlockGetRes returns a pointer to the value in the Result field
+func (a API) InvalidateBlockGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetNetworkHashPSWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetNetworkHashPSWait(cmd *btcjson.GetNetworkHashPSCmd) (out *[]btcjson.GetPeerInfoResult, err error) {
-	RPCHandlers["getnetworkhashps"].Call <- API{a.Ch, cmd, nil}
+// InvalidateBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) InvalidateBlockWait(cmd *btcjson.InvalidateBlockCmd) (out *None, err error) {
+	RPCHandlers["invalidateblock"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetNetworkHashPSRes):
+	case o := <-a.Ch.(chan InvalidateBlockRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetPeerInfo calls the method with the given parameters
-func (a API) GetPeerInfo(cmd *None) (err error) {
-	RPCHandlers["getpeerinfo"].Call <- API{a.Ch, cmd, nil}
+// ListBanned calls the method with the given parameters
+func (a API) ListBanned(cmd *None) (err error) {
+	RPCHandlers["listbanned"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetPeerInfoCheck checks if a new message arrived on the result channel and 
+// ListBannedCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetPeerInfoCheck() (isNew bool) {
+func (a API) ListBannedCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetPeerInfoRes):
+	case o := <-a.Ch.(chan ListBannedRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1621,36 +3123,36 @@ func (a API) GetPeerInfoCheck() (isNew bool) {
 	return
 }
 
-// GetPeerInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetPeerInfoGetRes() (out *[]btcjson.GetPeerInfoResult, err error) {
-	out, _ = a.Result.(*[]btcjson.GetPeerInfoResult)
+// ListBannedGetRes returns a pointer to the value in the Result field
+func (a API) ListBannedGetRes() (out *[]btcjson.ListBannedResult, err error) {
+	out, _ = a.Result.(*[]btcjson.ListBannedResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetPeerInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetPeerInfoWait(cmd *None) (out *[]btcjson.GetPeerInfoResult, err error) {
-	RPCHandlers["getpeerinfo"].Call <- API{a.Ch, cmd, nil}
+// ListBannedWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListBannedWait(cmd *None) (out *[]btcjson.ListBannedResult, err error) {
+	RPCHandlers["listbanned"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetPeerInfoRes):
+	case o := <-a.Ch.(chan ListBannedRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetRawMempool calls the method with the given parameters
-func (a API) GetRawMempool(cmd *btcjson.GetRawMempoolCmd) (err error) {
-	RPCHandlers["getrawmempool"].Call <- API{a.Ch, cmd, nil}
+// LoadAddrMan calls the method with the given parameters
+func (a API) LoadAddrMan(cmd *btcjson.LoadAddrManCmd) (err error) {
+	RPCHandlers["loadaddrman"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetRawMempoolCheck checks if a new message arrived on the result channel and 
+// LoadAddrManCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetRawMempoolCheck() (isNew bool) {
+func (a API) LoadAddrManCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetRawMempoolRes):
+	case o := <-a.Ch.(chan LoadAddrManRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1662,36 +3164,36 @@ func (a API) GetRawMempoolCheck() (isNew bool) {
 	return
 }
 
-// GetRawMempoolGetRes returns a pointer to the value in the Result field
-func (a API) GetRawMempoolGetRes() (out *[]string, err error) {
-	out, _ = a.Result.(*[]string)
+// LoadAddrManGetRes returns a pointer to the value in the Result field
+func (a API) LoadAddrManGetRes() (out *btcjson.LoadAddrManResult, err error) {
+	out, _ = a.Result.(*btcjson.LoadAddrManResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetRawMempoolWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetRawMempoolWait(cmd *btcjson.GetRawMempoolCmd) (out *[]string, err error) {
-	RPCHandlers["getrawmempool"].Call <- API{a.Ch, cmd, nil}
+// LoadAddrManWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) LoadAddrManWait(cmd *btcjson.LoadAddrManCmd) (out *btcjson.LoadAddrManResult, err error) {
+	RPCHandlers["loadaddrman"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetRawMempoolRes):
+	case o := <-a.Ch.(chan LoadAddrManRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetRawTransaction calls the method with the given parameters
-func (a API) GetRawTransaction(cmd *btcjson.GetRawTransactionCmd) (err error) {
-	RPCHandlers["getrawtransaction"].Call <- API{a.Ch, cmd, nil}
+// LoadUTXOSet calls the method with the given parameters
+func (a API) LoadUTXOSet(cmd *btcjson.LoadUTXOSetCmd) (err error) {
+	RPCHandlers["loadutxoset"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetRawTransactionCheck checks if a new message arrived on the result channel and 
+// LoadUTXOSetCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetRawTransactionCheck() (isNew bool) {
+func (a API) LoadUTXOSetCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetRawTransactionRes):
+	case o := <-a.Ch.(chan LoadUTXOSetRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1703,36 +3205,36 @@ func (a API) GetRawTransactionCheck() (isNew bool) {
 	return
 }
 
-// GetRawTransactionGetRes returns a pointer to the value in the Result field
-func (a API) GetRawTransactionGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// LoadUTXOSetGetRes returns a pointer to the value in the Result field
+func (a API) LoadUTXOSetGetRes() (out *btcjson.UTXOSetSnapshotResult, err error) {
+	out, _ = a.Result.(*btcjson.UTXOSetSnapshotResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetRawTransactionWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetRawTransactionWait(cmd *btcjson.GetRawTransactionCmd) (out *string, err error) {
-	RPCHandlers["getrawtransaction"].Call <- API{a.Ch, cmd, nil}
+// LoadUTXOSetWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) LoadUTXOSetWait(cmd *btcjson.LoadUTXOSetCmd) (out *btcjson.UTXOSetSnapshotResult, err error) {
+	RPCHandlers["loadutxoset"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetRawTransactionRes):
+	case o := <-a.Ch.(chan LoadUTXOSetRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetTxOut calls the method with the given parameters
-func (a API) GetTxOut(cmd *btcjson.GetTxOutCmd) (err error) {
-	RPCHandlers["gettxout"].Call <- API{a.Ch, cmd, nil}
+// Node calls the method with the given parameters
+func (a API) Node(cmd *btcjson.NodeCmd) (err error) {
+	RPCHandlers["node"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetTxOutCheck checks if a new message arrived on the result channel and 
+// NodeCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) GetTxOutCheck() (isNew bool) {
+func (a API) NodeCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetTxOutRes):
+	case o := <-a.Ch.(chan NodeRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1744,36 +3246,36 @@ func (a API) GetTxOutCheck() (isNew bool) {
 	return
 }
 
-// GetTxOutGetRes returns a pointer to the value in the Result field
-func (a API) GetTxOutGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// NodeGetRes returns a pointer to the value in the Result field
+func (a API) NodeGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetTxOutWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetTxOutWait(cmd *btcjson.GetTxOutCmd) (out *string, err error) {
-	RPCHandlers["gettxout"].Call <- API{a.Ch, cmd, nil}
+// NodeWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) NodeWait(cmd *btcjson.NodeCmd) (out *None, err error) {
+	RPCHandlers["node"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetTxOutRes):
+	case o := <-a.Ch.(chan NodeRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// Help calls the method with the given parameters
-func (a API) Help(cmd *btcjson.HelpCmd) (err error) {
-	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
+// Ping calls the method with the given parameters
+func (a API) Ping(cmd *None) (err error) {
+	RPCHandlers["ping"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// HelpCheck checks if a new message arrived on the result channel and 
+// PingCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) HelpCheck() (isNew bool) {
+func (a API) PingCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan HelpRes):
+	case o := <-a.Ch.(chan PingRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1785,36 +3287,36 @@ func (a API) HelpCheck() (isNew bool) {
 	return
 }
 
-// HelpGetRes returns a pointer to the value in the Result field
-func (a API) HelpGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// PingGetRes returns a pointer to the value in the Result field
+func (a API) PingGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// HelpWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) HelpWait(cmd *btcjson.HelpCmd) (out *string, err error) {
-	RPCHandlers["help"].Call <- API{a.Ch, cmd, nil}
+// PingWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PingWait(cmd *None) (out *None, err error) {
+	RPCHandlers["ping"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan HelpRes):
+	case o := <-a.Ch.(chan PingRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// Node calls the method with the given parameters
-func (a API) Node(cmd *btcjson.NodeCmd) (err error) {
-	RPCHandlers["node"].Call <- API{a.Ch, cmd, nil}
+// ReconsiderBlock calls the method with the given parameters
+func (a API) ReconsiderBlock(cmd *btcjson.ReconsiderBlockCmd) (err error) {
+	RPCHandlers["reconsiderblock"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// NodeCheck checks if a new message arrived on the result channel and 
+// ReconsiderBlockCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) NodeCheck() (isNew bool) {
+func (a API) ReconsiderBlockCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan NodeRes):
+	case o := <-a.Ch.(chan ReconsiderBlockRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1826,36 +3328,36 @@ func (a API) NodeCheck() (isNew bool) {
 	return
 }
 
-// NodeGetRes returns a pointer to the value in the Result field
-func (a API) NodeGetRes() (out *None, err error) {
+// ReconsiderBlockGetRes returns a pointer to the value in the Result field
+func (a API) ReconsiderBlockGetRes() (out *None, err error) {
 	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// NodeWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) NodeWait(cmd *btcjson.NodeCmd) (out *None, err error) {
-	RPCHandlers["node"].Call <- API{a.Ch, cmd, nil}
+// ReconsiderBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ReconsiderBlockWait(cmd *btcjson.ReconsiderBlockCmd) (out *None, err error) {
+	RPCHandlers["reconsiderblock"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan NodeRes):
+	case o := <-a.Ch.(chan ReconsiderBlockRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// Ping calls the method with the given parameters
-func (a API) Ping(cmd *None) (err error) {
-	RPCHandlers["ping"].Call <- API{a.Ch, cmd, nil}
+// ReleaseSnapshot calls the method with the given parameters
+func (a API) ReleaseSnapshot(cmd *btcjson.ReleaseSnapshotCmd) (err error) {
+	RPCHandlers["releasesnapshot"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// PingCheck checks if a new message arrived on the result channel and 
+// ReleaseSnapshotCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) PingCheck() (isNew bool) {
+func (a API) ReleaseSnapshotCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan PingRes):
+	case o := <-a.Ch.(chan ReleaseSnapshotRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1867,20 +3369,20 @@ func (a API) PingCheck() (isNew bool) {
 	return
 }
 
-// PingGetRes returns a pointer to the value in the Result field
-func (a API) PingGetRes() (out *None, err error) {
+// ReleaseSnapshotGetRes returns a pointer to the value in the Result field
+func (a API) ReleaseSnapshotGetRes() (out *None, err error) {
 	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// PingWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) PingWait(cmd *None) (out *None, err error) {
-	RPCHandlers["ping"].Call <- API{a.Ch, cmd, nil}
+// ReleaseSnapshotWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ReleaseSnapshotWait(cmd *btcjson.ReleaseSnapshotCmd) (out *None, err error) {
+	RPCHandlers["releasesnapshot"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan PingRes):
+	case o := <-a.Ch.(chan ReleaseSnapshotRes):
 		out, err = o.Res, o.Err
 	}
 	return
@@ -1968,6 +3470,47 @@ func (a API) RestartWait(cmd *None) (out *None, err error) {
 	return
 }
 
+// ScanTxOutSet calls the method with the given parameters
+func (a API) ScanTxOutSet(cmd *btcjson.ScanTxOutSetCmd) (err error) {
+	RPCHandlers["scantxoutset"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ScanTxOutSetCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) ScanTxOutSetCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ScanTxOutSetRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ScanTxOutSetGetRes returns a pointer to the value in the Result field
+func (a API) ScanTxOutSetGetRes() (out *btcjson.ScanTxOutSetResult, err error) {
+	out, _ = a.Result.(*btcjson.ScanTxOutSetResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// ScanTxOutSetWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ScanTxOutSetWait(cmd *btcjson.ScanTxOutSetCmd) (out *btcjson.ScanTxOutSetResult, err error) {
+	RPCHandlers["scantxoutset"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan ScanTxOutSetRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // SearchRawTransactions calls the method with the given parameters
 func (a API) SearchRawTransactions(cmd *btcjson.SearchRawTransactionsCmd) (err error) {
 	RPCHandlers["searchrawtransactions"].Call <- API{a.Ch, cmd, nil}
@@ -2050,17 +3593,140 @@ func (a API) SendRawTransactionWait(cmd *btcjson.SendRawTransactionCmd) (out *No
 	return
 }
 
+// SetBan calls the method with the given parameters
+func (a API) SetBan(cmd *btcjson.SetBanCmd) (err error) {
+	RPCHandlers["setban"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SetBanCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SetBanCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SetBanRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetBanGetRes returns a pointer to the value in the Result field
+func (a API) SetBanGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return
+}
+
+// SetBanWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetBanWait(cmd *btcjson.SetBanCmd) (out *None, err error) {
+	RPCHandlers["setban"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan SetBanRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // SetGenerate calls the method with the given parameters
 func (a API) SetGenerate(cmd *btcjson.SetGenerateCmd) (err error) {
 	RPCHandlers["setgenerate"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// SetGenerateCheck checks if a new message arrived on the result channel and 
+// SetGenerateCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) SetGenerateCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SetGenerateRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SetGenerateGetRes returns a pointer to the value in the Result field
+func (a API) SetGenerateGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return
+}
+
+// SetGenerateWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SetGenerateWait(cmd *btcjson.SetGenerateCmd) (out *None, err error) {
+	RPCHandlers["setgenerate"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan SetGenerateRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// Stop calls the method with the given parameters
+func (a API) Stop(cmd *None) (err error) {
+	RPCHandlers["stop"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// StopCheck checks if a new message arrived on the result channel and 
+// returns true if it does, as well as storing the value in the Result field
+func (a API) StopCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan StopRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// StopGetRes returns a pointer to the value in the Result field
+func (a API) StopGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
+	err, _ = a.Result.(error)
+	return
+}
+
+// StopWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) StopWait(cmd *None) (out *None, err error) {
+	RPCHandlers["stop"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan StopRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// SubmitBlock calls the method with the given parameters
+func (a API) SubmitBlock(cmd *btcjson.SubmitBlockCmd) (err error) {
+	RPCHandlers["submitblock"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SubmitBlockCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) SetGenerateCheck() (isNew bool) {
+func (a API) SubmitBlockCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan SetGenerateRes):
+	case o := <-a.Ch.(chan SubmitBlockRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -2072,36 +3738,36 @@ func (a API) SetGenerateCheck() (isNew bool) {
 	return
 }
 
-// SetGenerateGetRes returns a pointer to the value in the Result field
-func (a API) SetGenerateGetRes() (out *None, err error) {
-	out, _ = a.Result.(*None)
+// SubmitBlockGetRes returns a pointer to the value in the Result field
+func (a API) SubmitBlockGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// SetGenerateWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) SetGenerateWait(cmd *btcjson.SetGenerateCmd) (out *None, err error) {
-	RPCHandlers["setgenerate"].Call <- API{a.Ch, cmd, nil}
+// SubmitBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SubmitBlockWait(cmd *btcjson.SubmitBlockCmd) (out *string, err error) {
+	RPCHandlers["submitblock"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan SetGenerateRes):
+	case o := <-a.Ch.(chan SubmitBlockRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// Stop calls the method with the given parameters
-func (a API) Stop(cmd *None) (err error) {
-	RPCHandlers["stop"].Call <- API{a.Ch, cmd, nil}
+// SubmitHeader calls the method with the given parameters
+func (a API) SubmitHeader(cmd *btcjson.SubmitHeaderCmd) (err error) {
+	RPCHandlers["submitheader"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// StopCheck checks if a new message arrived on the result channel and 
+// SubmitHeaderCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) StopCheck() (isNew bool) {
+func (a API) SubmitHeaderCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan StopRes):
+	case o := <-a.Ch.(chan SubmitHeaderRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -2113,36 +3779,36 @@ func (a API) StopCheck() (isNew bool) {
 	return
 }
 
-// StopGetRes returns a pointer to the value in the Result field
-func (a API) StopGetRes() (out *None, err error) {
-	out, _ = a.Result.(*None)
+// SubmitHeaderGetRes returns a pointer to the value in the Result field
+func (a API) SubmitHeaderGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// StopWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) StopWait(cmd *None) (out *None, err error) {
-	RPCHandlers["stop"].Call <- API{a.Ch, cmd, nil}
+// SubmitHeaderWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SubmitHeaderWait(cmd *btcjson.SubmitHeaderCmd) (out *string, err error) {
+	RPCHandlers["submitheader"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan StopRes):
+	case o := <-a.Ch.(chan SubmitHeaderRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// SubmitBlock calls the method with the given parameters
-func (a API) SubmitBlock(cmd *btcjson.SubmitBlockCmd) (err error) {
-	RPCHandlers["submitblock"].Call <- API{a.Ch, cmd, nil}
+// TestMempoolAccept calls the method with the given parameters
+func (a API) TestMempoolAccept(cmd *btcjson.TestMempoolAcceptCmd) (err error) {
+	RPCHandlers["testmempoolaccept"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// SubmitBlockCheck checks if a new message arrived on the result channel and 
+// TestMempoolAcceptCheck checks if a new message arrived on the result channel and 
 // returns true if it does, as well as storing the value in the Result field
-func (a API) SubmitBlockCheck() (isNew bool) {
+func (a API) TestMempoolAcceptCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan SubmitBlockRes):
+	case o := <-a.Ch.(chan TestMempoolAcceptRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -2154,20 +3820,20 @@ func (a API) SubmitBlockCheck() (isNew bool) {
 	return
 }
 
-// SubmitBlockGetRes returns a pointer to the value in the Result field
-func (a API) SubmitBlockGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// TestMempoolAcceptGetRes returns a pointer to the value in the Result field
+func (a API) TestMempoolAcceptGetRes() (out *[]btcjson.TestMempoolAcceptResult, err error) {
+	out, _ = a.Result.(*[]btcjson.TestMempoolAcceptResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// SubmitBlockWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) SubmitBlockWait(cmd *btcjson.SubmitBlockCmd) (out *string, err error) {
-	RPCHandlers["submitblock"].Call <- API{a.Ch, cmd, nil}
+// TestMempoolAcceptWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) TestMempoolAcceptWait(cmd *btcjson.TestMempoolAcceptCmd) (out *[]btcjson.TestMempoolAcceptResult, err error) {
+	RPCHandlers["testmempoolaccept"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan SubmitBlockRes):
+	case o := <-a.Ch.(chan TestMempoolAcceptRes):
 		out, err = o.Res, o.Err
 	}
 	return
@@ -2396,6 +4062,20 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(None); ok {
 					msg.Ch.(chan AddNodeRes) <- AddNodeRes{&r, err}
 				}
+			case msg := <-nrh["calculatesighash"].Call:
+				if res, err = nrh["calculatesighash"].
+					Fn(server, msg.Params.(*btcjson.CalculateSigHashCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.CalculateSigHashResult); ok {
+					msg.Ch.(chan CalculateSigHashRes) <- CalculateSigHashRes{&r, err}
+				}
+			case msg := <-nrh["clearbanned"].Call:
+				if res, err = nrh["clearbanned"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok {
+					msg.Ch.(chan ClearBannedRes) <- ClearBannedRes{&r, err}
+				}
 			case msg := <-nrh["createrawtransaction"].Call:
 				if res, err = nrh["createrawtransaction"].
 					Fn(server, msg.Params.(*btcjson.CreateRawTransactionCmd), nil); Check(err) {
@@ -2417,6 +4097,20 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(btcjson.DecodeScriptResult); ok {
 					msg.Ch.(chan DecodeScriptRes) <- DecodeScriptRes{&r, err}
 				}
+			case msg := <-nrh["dumpaddrman"].Call:
+				if res, err = nrh["dumpaddrman"].
+					Fn(server, msg.Params.(*btcjson.DumpAddrManCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.DumpAddrManResult); ok {
+					msg.Ch.(chan DumpAddrManRes) <- DumpAddrManRes{&r, err}
+				}
+			case msg := <-nrh["dumputxoset"].Call:
+				if res, err = nrh["dumputxoset"].
+					Fn(server, msg.Params.(*btcjson.DumpUTXOSetCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.UTXOSetSnapshotResult); ok {
+					msg.Ch.(chan DumpUTXOSetRes) <- DumpUTXOSetRes{&r, err}
+				}
 			case msg := <-nrh["estimatefee"].Call:
 				if res, err = nrh["estimatefee"].
 					Fn(server, msg.Params.(*btcjson.EstimateFeeCmd), nil); Check(err) {
@@ -2424,13 +4118,41 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(float64); ok {
 					msg.Ch.(chan EstimateFeeRes) <- EstimateFeeRes{&r, err}
 				}
+			case msg := <-nrh["estimatesmartfee"].Call:
+				if res, err = nrh["estimatesmartfee"].
+					Fn(server, msg.Params.(*btcjson.EstimateSmartFeeCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.EstimateSmartFeeResult); ok {
+					msg.Ch.(chan EstimateSmartFeeRes) <- EstimateSmartFeeRes{&r, err}
+				}
+			case msg := <-nrh["evaluatescript"].Call:
+				if res, err = nrh["evaluatescript"].
+					Fn(server, msg.Params.(*btcjson.EvaluateScriptCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.EvaluateScriptResult); ok {
+					msg.Ch.(chan EvaluateScriptRes) <- EvaluateScriptRes{&r, err}
+				}
 			case msg := <-nrh["generate"].Call:
 				if res, err = nrh["generate"].
-					Fn(server, msg.Params.(*None), nil); Check(err) {
+					Fn(server, msg.Params.(*btcjson.GenerateCmd), nil); Check(err) {
 				}
 				if r, ok := res.([]string); ok {
 					msg.Ch.(chan GenerateRes) <- GenerateRes{&r, err}
 				}
+			case msg := <-nrh["generateblock"].Call:
+				if res, err = nrh["generateblock"].
+					Fn(server, msg.Params.(*btcjson.GenerateBlockCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GenerateBlockResult); ok {
+					msg.Ch.(chan GenerateBlockRes) <- GenerateBlockRes{&r, err}
+				}
+			case msg := <-nrh["generatetoaddress"].Call:
+				if res, err = nrh["generatetoaddress"].
+					Fn(server, msg.Params.(*btcjson.GenerateToAddressCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]string); ok {
+					msg.Ch.(chan GenerateToAddressRes) <- GenerateToAddressRes{&r, err}
+				}
 			case msg := <-nrh["getaddednodeinfo"].Call:
 				if res, err = nrh["getaddednodeinfo"].
 					Fn(server, msg.Params.(*btcjson.GetAddedNodeInfoCmd), nil); Check(err) {
@@ -2438,6 +4160,41 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.([]btcjson.GetAddedNodeInfoResultAddr); ok {
 					msg.Ch.(chan GetAddedNodeInfoRes) <- GetAddedNodeInfoRes{&r, err}
 				}
+			case msg := <-nrh["getaddressbalance"].Call:
+				if res, err = nrh["getaddressbalance"].
+					Fn(server, msg.Params.(*btcjson.GetAddressBalanceCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetAddressBalanceResult); ok {
+					msg.Ch.(chan GetAddressBalanceRes) <- GetAddressBalanceRes{&r, err}
+				}
+			case msg := <-nrh["getaddresstxids"].Call:
+				if res, err = nrh["getaddresstxids"].
+					Fn(server, msg.Params.(*btcjson.GetAddressTxIDsCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]string); ok {
+					msg.Ch.(chan GetAddressTxIDsRes) <- GetAddressTxIDsRes{&r, err}
+				}
+			case msg := <-nrh["getaddressutxos"].Call:
+				if res, err = nrh["getaddressutxos"].
+					Fn(server, msg.Params.(*btcjson.GetAddressUtxosCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.GetAddressUtxosResult); ok {
+					msg.Ch.(chan GetAddressUtxosRes) <- GetAddressUtxosRes{&r, err}
+				}
+			case msg := <-nrh["getaddrmaninfo"].Call:
+				if res, err = nrh["getaddrmaninfo"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetAddrManInfoResult); ok {
+					msg.Ch.(chan GetAddrManInfoRes) <- GetAddrManInfoRes{&r, err}
+				}
+			case msg := <-nrh["getauditlog"].Call:
+				if res, err = nrh["getauditlog"].
+					Fn(server, msg.Params.(*btcjson.GetAuditLogCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.AuditLogEntry); ok {
+					msg.Ch.(chan GetAuditLogRes) <- GetAuditLogRes{&r, err}
+				}
 			case msg := <-nrh["getbestblock"].Call:
 				if res, err = nrh["getbestblock"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2468,11 +4225,18 @@ func RunAPI(server *Server, quit chan struct{}) {
 				}
 			case msg := <-nrh["getblockcount"].Call:
 				if res, err = nrh["getblockcount"].
-					Fn(server, msg.Params.(*None), nil); Check(err) {
+					Fn(server, msg.Params.(*btcjson.GetBlockCountCmd), nil); Check(err) {
 				}
 				if r, ok := res.(int64); ok {
 					msg.Ch.(chan GetBlockCountRes) <- GetBlockCountRes{&r, err}
 				}
+			case msg := <-nrh["getblockfilter"].Call:
+				if res, err = nrh["getblockfilter"].
+					Fn(server, msg.Params.(*btcjson.GetBlockFilterCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetBlockFilterResult); ok {
+					msg.Ch.(chan GetBlockFilterRes) <- GetBlockFilterRes{&r, err}
+				}
 			case msg := <-nrh["getblockhash"].Call:
 				if res, err = nrh["getblockhash"].
 					Fn(server, msg.Params.(*btcjson.GetBlockHashCmd), nil); Check(err) {
@@ -2487,6 +4251,13 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(btcjson.GetBlockHeaderVerboseResult); ok {
 					msg.Ch.(chan GetBlockHeaderRes) <- GetBlockHeaderRes{&r, err}
 				}
+			case msg := <-nrh["getblocksubsidy"].Call:
+				if res, err = nrh["getblocksubsidy"].
+					Fn(server, msg.Params.(*btcjson.GetBlockSubsidyCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetBlockSubsidyResult); ok {
+					msg.Ch.(chan GetBlockSubsidyRes) <- GetBlockSubsidyRes{&r, err}
+				}
 			case msg := <-nrh["getblocktemplate"].Call:
 				if res, err = nrh["getblocktemplate"].
 					Fn(server, msg.Params.(*btcjson.GetBlockTemplateCmd), nil); Check(err) {
@@ -2564,6 +4335,13 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(btcjson.GetMempoolInfoResult); ok {
 					msg.Ch.(chan GetMempoolInfoRes) <- GetMempoolInfoRes{&r, err}
 				}
+			case msg := <-nrh["getminerstats"].Call:
+				if res, err = nrh["getminerstats"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetMinerStatsResult); ok {
+					msg.Ch.(chan GetMinerStatsRes) <- GetMinerStatsRes{&r, err}
+				}
 			case msg := <-nrh["getmininginfo"].Call:
 				if res, err = nrh["getmininginfo"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2585,6 +4363,13 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.([]btcjson.GetPeerInfoResult); ok {
 					msg.Ch.(chan GetNetworkHashPSRes) <- GetNetworkHashPSRes{&r, err}
 				}
+			case msg := <-nrh["getnetworkinfo"].Call:
+				if res, err = nrh["getnetworkinfo"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetNetworkInfoResult); ok {
+					msg.Ch.(chan GetNetworkInfoRes) <- GetNetworkInfoRes{&r, err}
+				}
 			case msg := <-nrh["getpeerinfo"].Call:
 				if res, err = nrh["getpeerinfo"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2592,6 +4377,13 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.([]btcjson.GetPeerInfoResult); ok {
 					msg.Ch.(chan GetPeerInfoRes) <- GetPeerInfoRes{&r, err}
 				}
+			case msg := <-nrh["getpeerstats"].Call:
+				if res, err = nrh["getpeerstats"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetPeerStatsResult); ok {
+					msg.Ch.(chan GetPeerStatsRes) <- GetPeerStatsRes{&r, err}
+				}
 			case msg := <-nrh["getrawmempool"].Call:
 				if res, err = nrh["getrawmempool"].
 					Fn(server, msg.Params.(*btcjson.GetRawMempoolCmd), nil); Check(err) {
@@ -2606,6 +4398,34 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan GetRawTransactionRes) <- GetRawTransactionRes{&r, err}
 				}
+			case msg := <-nrh["getsnapshot"].Call:
+				if res, err = nrh["getsnapshot"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetSnapshotResult); ok {
+					msg.Ch.(chan GetSnapshotRes) <- GetSnapshotRes{&r, err}
+				}
+			case msg := <-nrh["getspentinfo"].Call:
+				if res, err = nrh["getspentinfo"].
+					Fn(server, msg.Params.(*btcjson.GetSpentInfoCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetSpentInfoResult); ok {
+					msg.Ch.(chan GetSpentInfoRes) <- GetSpentInfoRes{&r, err}
+				}
+			case msg := <-nrh["getsyncstatus"].Call:
+				if res, err = nrh["getsyncstatus"].
+					Fn(server, msg.Params.(*btcjson.GetSyncStatusCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetSyncStatusResult); ok {
+					msg.Ch.(chan GetSyncStatusRes) <- GetSyncStatusRes{&r, err}
+				}
+			case msg := <-nrh["gettelemetryinfo"].Call:
+				if res, err = nrh["gettelemetryinfo"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetTelemetryInfoResult); ok {
+					msg.Ch.(chan GetTelemetryInfoRes) <- GetTelemetryInfoRes{&r, err}
+				}
 			case msg := <-nrh["gettxout"].Call:
 				if res, err = nrh["gettxout"].
 					Fn(server, msg.Params.(*btcjson.GetTxOutCmd), nil); Check(err) {
@@ -2613,6 +4433,20 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan GetTxOutRes) <- GetTxOutRes{&r, err}
 				}
+			case msg := <-nrh["gettxspendingprevout"].Call:
+				if res, err = nrh["gettxspendingprevout"].
+					Fn(server, msg.Params.(*btcjson.GetTxSpendingPrevOutCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.GetTxSpendingPrevOutResult); ok {
+					msg.Ch.(chan GetTxSpendingPrevOutRes) <- GetTxSpendingPrevOutRes{&r, err}
+				}
+			case msg := <-nrh["getwork"].Call:
+				if res, err = nrh["getwork"].
+					Fn(server, msg.Params.(*btcjson.GetWorkCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetWorkResult); ok {
+					msg.Ch.(chan GetWorkRes) <- GetWorkRes{&r, err}
+				}
 			case msg := <-nrh["help"].Call:
 				if res, err = nrh["help"].
 					Fn(server, msg.Params.(*btcjson.HelpCmd), nil); Check(err) {
@@ -2620,6 +4454,34 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan HelpRes) <- HelpRes{&r, err}
 				}
+			case msg := <-nrh["invalidateblock"].Call:
+				if res, err = nrh["invalidateblock"].
+					Fn(server, msg.Params.(*btcjson.InvalidateBlockCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok {
+					msg.Ch.(chan InvalidateBlockRes) <- InvalidateBlockRes{&r, err}
+				}
+			case msg := <-nrh["listbanned"].Call:
+				if res, err = nrh["listbanned"].
+					Fn(server, msg.Params.(*None), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.ListBannedResult); ok {
+					msg.Ch.(chan ListBannedRes) <- ListBannedRes{&r, err}
+				}
+			case msg := <-nrh["loadaddrman"].Call:
+				if res, err = nrh["loadaddrman"].
+					Fn(server, msg.Params.(*btcjson.LoadAddrManCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.LoadAddrManResult); ok {
+					msg.Ch.(chan LoadAddrManRes) <- LoadAddrManRes{&r, err}
+				}
+			case msg := <-nrh["loadutxoset"].Call:
+				if res, err = nrh["loadutxoset"].
+					Fn(server, msg.Params.(*btcjson.LoadUTXOSetCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.UTXOSetSnapshotResult); ok {
+					msg.Ch.(chan LoadUTXOSetRes) <- LoadUTXOSetRes{&r, err}
+				}
 			case msg := <-nrh["node"].Call:
 				if res, err = nrh["node"].
 					Fn(server, msg.Params.(*btcjson.NodeCmd), nil); Check(err) {
@@ -2634,6 +4496,20 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(None); ok {
 					msg.Ch.(chan PingRes) <- PingRes{&r, err}
 				}
+			case msg := <-nrh["reconsiderblock"].Call:
+				if res, err = nrh["reconsiderblock"].
+					Fn(server, msg.Params.(*btcjson.ReconsiderBlockCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok {
+					msg.Ch.(chan ReconsiderBlockRes) <- ReconsiderBlockRes{&r, err}
+				}
+			case msg := <-nrh["releasesnapshot"].Call:
+				if res, err = nrh["releasesnapshot"].
+					Fn(server, msg.Params.(*btcjson.ReleaseSnapshotCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok {
+					msg.Ch.(chan ReleaseSnapshotRes) <- ReleaseSnapshotRes{&r, err}
+				}
 			case msg := <-nrh["resetchain"].Call:
 				if res, err = nrh["resetchain"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2648,6 +4524,13 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(None); ok {
 					msg.Ch.(chan RestartRes) <- RestartRes{&r, err}
 				}
+			case msg := <-nrh["scantxoutset"].Call:
+				if res, err = nrh["scantxoutset"].
+					Fn(server, msg.Params.(*btcjson.ScanTxOutSetCmd), nil); Check(err) {
+				}
+				if r, ok := res.(btcjson.ScanTxOutSetResult); ok {
+					msg.Ch.(chan ScanTxOutSetRes) <- ScanTxOutSetRes{&r, err}
+				}
 			case msg := <-nrh["searchrawtransactions"].Call:
 				if res, err = nrh["searchrawtransactions"].
 					Fn(server, msg.Params.(*btcjson.SearchRawTransactionsCmd), nil); Check(err) {
@@ -2662,6 +4545,13 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(None); ok {
 					msg.Ch.(chan SendRawTransactionRes) <- SendRawTransactionRes{&r, err}
 				}
+			case msg := <-nrh["setban"].Call:
+				if res, err = nrh["setban"].
+					Fn(server, msg.Params.(*btcjson.SetBanCmd), nil); Check(err) {
+				}
+				if r, ok := res.(None); ok {
+					msg.Ch.(chan SetBanRes) <- SetBanRes{&r, err}
+				}
 			case msg := <-nrh["setgenerate"].Call:
 				if res, err = nrh["setgenerate"].
 					Fn(server, msg.Params.(*btcjson.SetGenerateCmd), nil); Check(err) {
@@ -2683,6 +4573,20 @@ func RunAPI(server *Server, quit chan struct{}) {
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan SubmitBlockRes) <- SubmitBlockRes{&r, err}
 				}
+			case msg := <-nrh["submitheader"].Call:
+				if res, err = nrh["submitheader"].
+					Fn(server, msg.Params.(*btcjson.SubmitHeaderCmd), nil); Check(err) {
+				}
+				if r, ok := res.(string); ok {
+					msg.Ch.(chan SubmitHeaderRes) <- SubmitHeaderRes{&r, err}
+				}
+			case msg := <-nrh["testmempoolaccept"].Call:
+				if res, err = nrh["testmempoolaccept"].
+					Fn(server, msg.Params.(*btcjson.TestMempoolAcceptCmd), nil); Check(err) {
+				}
+				if r, ok := res.([]btcjson.TestMempoolAcceptResult); ok {
+					msg.Ch.(chan TestMempoolAcceptRes) <- TestMempoolAcceptRes{&r, err}
+				}
 			case msg := <-nrh["uptime"].Call:
 				if res, err = nrh["uptime"].
 					Fn(server, msg.Params.(*None), nil); Check(err) {
@@ -2726,78 +4630,221 @@ func RunAPI(server *Server, quit chan struct{}) {
 	}()
 }
 
-// RPC API functions to use with net/rpc
-
-func (c *CAPI) AddNode(req *btcjson.AddNodeCmd, resp None) (err error) {
+// RPC API functions to use with net/rpc
+
+func (c *CAPI) AddNode(req *btcjson.AddNodeCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["addnode"].Result()
+	res.Params = req
+	nrh["addnode"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) CalculateSigHash(req *btcjson.CalculateSigHashCmd, resp btcjson.CalculateSigHashResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["calculatesighash"].Result()
+	res.Params = req
+	nrh["calculatesighash"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.CalculateSigHashResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) ClearBanned(req *None, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["clearbanned"].Result()
+	res.Params = req
+	nrh["clearbanned"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) CreateRawTransaction(req *btcjson.CreateRawTransactionCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["createrawtransaction"].Result()
+	res.Params = req
+	nrh["createrawtransaction"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) DecodeRawTransaction(req *btcjson.DecodeRawTransactionCmd, resp btcjson.TxRawDecodeResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["decoderawtransaction"].Result()
+	res.Params = req
+	nrh["decoderawtransaction"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.TxRawDecodeResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) DecodeScript(req *btcjson.DecodeScriptCmd, resp btcjson.DecodeScriptResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["decodescript"].Result()
+	res.Params = req
+	nrh["decodescript"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.DecodeScriptResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) DumpAddrMan(req *btcjson.DumpAddrManCmd, resp btcjson.DumpAddrManResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["dumpaddrman"].Result()
+	res.Params = req
+	nrh["dumpaddrman"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.DumpAddrManResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) DumpUTXOSet(req *btcjson.DumpUTXOSetCmd, resp btcjson.UTXOSetSnapshotResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["dumputxoset"].Result()
+	res.Params = req
+	nrh["dumputxoset"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.UTXOSetSnapshotResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) EstimateFee(req *btcjson.EstimateFeeCmd, resp float64) (err error) {
+	nrh := RPCHandlers
+	res := nrh["estimatefee"].Result()
+	res.Params = req
+	nrh["estimatefee"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan float64):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) EstimateSmartFee(req *btcjson.EstimateSmartFeeCmd, resp btcjson.EstimateSmartFeeResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["estimatesmartfee"].Result()
+	res.Params = req
+	nrh["estimatesmartfee"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.EstimateSmartFeeResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) EvaluateScript(req *btcjson.EvaluateScriptCmd, resp btcjson.EvaluateScriptResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["evaluatescript"].Result()
+	res.Params = req
+	nrh["evaluatescript"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.EvaluateScriptResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) Generate(req *btcjson.GenerateCmd, resp []string) (err error) {
 	nrh := RPCHandlers
-	res := nrh["addnode"].Result()
+	res := nrh["generate"].Result()
 	res.Params = req
-	nrh["addnode"].Call <- res
+	nrh["generate"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan []string):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) CreateRawTransaction(req *btcjson.CreateRawTransactionCmd, resp string) (err error) {
+func (c *CAPI) GenerateBlock(req *btcjson.GenerateBlockCmd, resp btcjson.GenerateBlockResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["createrawtransaction"].Result()
+	res := nrh["generateblock"].Result()
 	res.Params = req
-	nrh["createrawtransaction"].Call <- res
+	nrh["generateblock"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan btcjson.GenerateBlockResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) DecodeRawTransaction(req *btcjson.DecodeRawTransactionCmd, resp btcjson.TxRawDecodeResult) (err error) {
+func (c *CAPI) GenerateToAddress(req *btcjson.GenerateToAddressCmd, resp []string) (err error) {
 	nrh := RPCHandlers
-	res := nrh["decoderawtransaction"].Result()
+	res := nrh["generatetoaddress"].Result()
 	res.Params = req
-	nrh["decoderawtransaction"].Call <- res
+	nrh["generatetoaddress"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.TxRawDecodeResult):
+	case resp = <-res.Ch.(chan []string):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) DecodeScript(req *btcjson.DecodeScriptCmd, resp btcjson.DecodeScriptResult) (err error) {
+func (c *CAPI) GetAddedNodeInfo(req *btcjson.GetAddedNodeInfoCmd, resp []btcjson.GetAddedNodeInfoResultAddr) (err error) {
 	nrh := RPCHandlers
-	res := nrh["decodescript"].Result()
+	res := nrh["getaddednodeinfo"].Result()
 	res.Params = req
-	nrh["decodescript"].Call <- res
+	nrh["getaddednodeinfo"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan btcjson.DecodeScriptResult):
+	case resp = <-res.Ch.(chan []btcjson.GetAddedNodeInfoResultAddr):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) EstimateFee(req *btcjson.EstimateFeeCmd, resp float64) (err error) {
+func (c *CAPI) GetAddressBalance(req *btcjson.GetAddressBalanceCmd, resp btcjson.GetAddressBalanceResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["estimatefee"].Result()
+	res := nrh["getaddressbalance"].Result()
 	res.Params = req
-	nrh["estimatefee"].Call <- res
+	nrh["getaddressbalance"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan float64):
+	case resp = <-res.Ch.(chan btcjson.GetAddressBalanceResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) Generate(req *None, resp []string) (err error) {
+func (c *CAPI) GetAddressTxIDs(req *btcjson.GetAddressTxIDsCmd, resp []string) (err error) {
 	nrh := RPCHandlers
-	res := nrh["generate"].Result()
+	res := nrh["getaddresstxids"].Result()
 	res.Params = req
-	nrh["generate"].Call <- res
+	nrh["getaddresstxids"].Call <- res
 	select {
 	case resp = <-res.Ch.(chan []string):
 	case <-time.After(c.Timeout):
@@ -2806,13 +4853,39 @@ func (c *CAPI) Generate(req *None, resp []string) (err error) {
 	return
 }
 
-func (c *CAPI) GetAddedNodeInfo(req *btcjson.GetAddedNodeInfoCmd, resp []btcjson.GetAddedNodeInfoResultAddr) (err error) {
+func (c *CAPI) GetAddressUtxos(req *btcjson.GetAddressUtxosCmd, resp []btcjson.GetAddressUtxosResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["getaddednodeinfo"].Result()
+	res := nrh["getaddressutxos"].Result()
 	res.Params = req
-	nrh["getaddednodeinfo"].Call <- res
+	nrh["getaddressutxos"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.GetAddedNodeInfoResultAddr):
+	case resp = <-res.Ch.(chan []btcjson.GetAddressUtxosResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetAddrManInfo(req *None, resp btcjson.GetAddrManInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getaddrmaninfo"].Result()
+	res.Params = req
+	nrh["getaddrmaninfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetAddrManInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetAuditLog(req *btcjson.GetAuditLogCmd, resp []btcjson.AuditLogEntry) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getauditlog"].Result()
+	res.Params = req
+	nrh["getauditlog"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.AuditLogEntry):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
@@ -2871,7 +4944,7 @@ func (c *CAPI) GetBlockChainInfo(req *None, resp btcjson.GetBlockChainInfoResult
 	return
 }
 
-func (c *CAPI) GetBlockCount(req *None, resp int64) (err error) {
+func (c *CAPI) GetBlockCount(req *btcjson.GetBlockCountCmd, resp int64) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getblockcount"].Result()
 	res.Params = req
@@ -2884,6 +4957,19 @@ func (c *CAPI) GetBlockCount(req *None, resp int64) (err error) {
 	return
 }
 
+func (c *CAPI) GetBlockFilter(req *btcjson.GetBlockFilterCmd, resp btcjson.GetBlockFilterResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getblockfilter"].Result()
+	res.Params = req
+	nrh["getblockfilter"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetBlockFilterResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetBlockHash(req *btcjson.GetBlockHashCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getblockhash"].Result()
@@ -2910,6 +4996,19 @@ func (c *CAPI) GetBlockHeader(req *btcjson.GetBlockHeaderCmd, resp btcjson.GetBl
 	return
 }
 
+func (c *CAPI) GetBlockSubsidy(req *btcjson.GetBlockSubsidyCmd, resp btcjson.GetBlockSubsidyResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getblocksubsidy"].Result()
+	res.Params = req
+	nrh["getblocksubsidy"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetBlockSubsidyResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetBlockTemplate(req *btcjson.GetBlockTemplateCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getblocktemplate"].Result()
@@ -3053,6 +5152,19 @@ func (c *CAPI) GetMempoolInfo(req *None, resp btcjson.GetMempoolInfoResult) (err
 	return
 }
 
+func (c *CAPI) GetMinerStats(req *None, resp btcjson.GetMinerStatsResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getminerstats"].Result()
+	res.Params = req
+	nrh["getminerstats"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetMinerStatsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetMiningInfo(req *None, resp btcjson.GetMiningInfoResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getmininginfo"].Result()
@@ -3092,6 +5204,19 @@ func (c *CAPI) GetNetworkHashPS(req *btcjson.GetNetworkHashPSCmd, resp []btcjson
 	return
 }
 
+func (c *CAPI) GetNetworkInfo(req *None, resp btcjson.GetNetworkInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getnetworkinfo"].Result()
+	res.Params = req
+	nrh["getnetworkinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetNetworkInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetPeerInfo(req *None, resp []btcjson.GetPeerInfoResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getpeerinfo"].Result()
@@ -3105,6 +5230,19 @@ func (c *CAPI) GetPeerInfo(req *None, resp []btcjson.GetPeerInfoResult) (err err
 	return
 }
 
+func (c *CAPI) GetPeerStats(req *None, resp btcjson.GetPeerStatsResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getpeerstats"].Result()
+	res.Params = req
+	nrh["getpeerstats"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetPeerStatsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetRawMempool(req *btcjson.GetRawMempoolCmd, resp []string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getrawmempool"].Result()
@@ -3131,6 +5269,58 @@ func (c *CAPI) GetRawTransaction(req *btcjson.GetRawTransactionCmd, resp string)
 	return
 }
 
+func (c *CAPI) GetSnapshot(req *None, resp btcjson.GetSnapshotResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getsnapshot"].Result()
+	res.Params = req
+	nrh["getsnapshot"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetSnapshotResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetSpentInfo(req *btcjson.GetSpentInfoCmd, resp btcjson.GetSpentInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getspentinfo"].Result()
+	res.Params = req
+	nrh["getspentinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetSpentInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetSyncStatus(req *btcjson.GetSyncStatusCmd, resp btcjson.GetSyncStatusResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getsyncstatus"].Result()
+	res.Params = req
+	nrh["getsyncstatus"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetSyncStatusResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetTelemetryInfo(req *None, resp btcjson.GetTelemetryInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["gettelemetryinfo"].Result()
+	res.Params = req
+	nrh["gettelemetryinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetTelemetryInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetTxOut(req *btcjson.GetTxOutCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["gettxout"].Result()
@@ -3144,6 +5334,32 @@ func (c *CAPI) GetTxOut(req *btcjson.GetTxOutCmd, resp string) (err error) {
 	return
 }
 
+func (c *CAPI) GetTxSpendingPrevOut(req *btcjson.GetTxSpendingPrevOutCmd, resp []btcjson.GetTxSpendingPrevOutResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["gettxspendingprevout"].Result()
+	res.Params = req
+	nrh["gettxspendingprevout"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.GetTxSpendingPrevOutResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetWork(req *btcjson.GetWorkCmd, resp btcjson.GetWorkResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getwork"].Result()
+	res.Params = req
+	nrh["getwork"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetWorkResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) Help(req *btcjson.HelpCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["help"].Result()
@@ -3157,6 +5373,58 @@ func (c *CAPI) Help(req *btcjson.HelpCmd, resp string) (err error) {
 	return
 }
 
+func (c *CAPI) InvalidateBlock(req *btcjson.InvalidateBlockCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["invalidateblock"].Result()
+	res.Params = req
+	nrh["invalidateblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) ListBanned(req *None, resp []btcjson.ListBannedResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["listbanned"].Result()
+	res.Params = req
+	nrh["listbanned"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.ListBannedResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) LoadAddrMan(req *btcjson.LoadAddrManCmd, resp btcjson.LoadAddrManResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["loadaddrman"].Result()
+	res.Params = req
+	nrh["loadaddrman"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.LoadAddrManResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) LoadUTXOSet(req *btcjson.LoadUTXOSetCmd, resp btcjson.UTXOSetSnapshotResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["loadutxoset"].Result()
+	res.Params = req
+	nrh["loadutxoset"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.UTXOSetSnapshotResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) Node(req *btcjson.NodeCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["node"].Result()
@@ -3183,6 +5451,32 @@ func (c *CAPI) Ping(req *None, resp None) (err error) {
 	return
 }
 
+func (c *CAPI) ReconsiderBlock(req *btcjson.ReconsiderBlockCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["reconsiderblock"].Result()
+	res.Params = req
+	nrh["reconsiderblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) ReleaseSnapshot(req *btcjson.ReleaseSnapshotCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["releasesnapshot"].Result()
+	res.Params = req
+	nrh["releasesnapshot"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) ResetChain(req *None, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["resetchain"].Result()
@@ -3209,6 +5503,19 @@ func (c *CAPI) Restart(req *None, resp None) (err error) {
 	return
 }
 
+func (c *CAPI) ScanTxOutSet(req *btcjson.ScanTxOutSetCmd, resp btcjson.ScanTxOutSetResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["scantxoutset"].Result()
+	res.Params = req
+	nrh["scantxoutset"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.ScanTxOutSetResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) SearchRawTransactions(req *btcjson.SearchRawTransactionsCmd, resp []btcjson.SearchRawTransactionsResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["searchrawtransactions"].Result()
@@ -3235,6 +5542,19 @@ func (c *CAPI) SendRawTransaction(req *btcjson.SendRawTransactionCmd, resp None)
 	return
 }
 
+func (c *CAPI) SetBan(req *btcjson.SetBanCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["setban"].Result()
+	res.Params = req
+	nrh["setban"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) SetGenerate(req *btcjson.SetGenerateCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["setgenerate"].Result()
@@ -3250,24 +5570,50 @@ func (c *CAPI) SetGenerate(req *btcjson.SetGenerateCmd, resp None) (err error) {
 
 func (c *CAPI) Stop(req *None, resp None) (err error) {
 	nrh := RPCHandlers
-	res := nrh["stop"].Result()
+	res := nrh["stop"].Result()
+	res.Params = req
+	nrh["stop"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) SubmitBlock(req *btcjson.SubmitBlockCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["submitblock"].Result()
+	res.Params = req
+	nrh["submitblock"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) SubmitHeader(req *btcjson.SubmitHeaderCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["submitheader"].Result()
 	res.Params = req
-	nrh["stop"].Call <- res
+	nrh["submitheader"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan string):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) SubmitBlock(req *btcjson.SubmitBlockCmd, resp string) (err error) {
+func (c *CAPI) TestMempoolAccept(req *btcjson.TestMempoolAcceptCmd, resp []btcjson.TestMempoolAcceptResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["submitblock"].Result()
+	res := nrh["testmempoolaccept"].Result()
 	res.Params = req
-	nrh["submitblock"].Call <- res
+	nrh["testmempoolaccept"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan string):
+	case resp = <-res.Ch.(chan []btcjson.TestMempoolAcceptResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
@@ -3351,6 +5697,26 @@ func (r *CAPIClient) AddNode(cmd ...*btcjson.AddNodeCmd) (res None, err error) {
 	return
 }
 
+func (r *CAPIClient) CalculateSigHash(cmd ...*btcjson.CalculateSigHashCmd) (res btcjson.CalculateSigHashResult, err error) {
+	var c *btcjson.CalculateSigHashCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.CalculateSigHash", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ClearBanned(cmd ...*None) (res None, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ClearBanned", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) CreateRawTransaction(cmd ...*btcjson.CreateRawTransactionCmd) (res string, err error) {
 	var c *btcjson.CreateRawTransactionCmd
 	if len(cmd) > 0 {
@@ -3381,6 +5747,26 @@ func (r *CAPIClient) DecodeScript(cmd ...*btcjson.DecodeScriptCmd) (res btcjson.
 	return
 }
 
+func (r *CAPIClient) DumpAddrMan(cmd ...*btcjson.DumpAddrManCmd) (res btcjson.DumpAddrManResult, err error) {
+	var c *btcjson.DumpAddrManCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.DumpAddrMan", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) DumpUTXOSet(cmd ...*btcjson.DumpUTXOSetCmd) (res btcjson.UTXOSetSnapshotResult, err error) {
+	var c *btcjson.DumpUTXOSetCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.DumpUTXOSet", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) EstimateFee(cmd ...*btcjson.EstimateFeeCmd) (res float64, err error) {
 	var c *btcjson.EstimateFeeCmd
 	if len(cmd) > 0 {
@@ -3391,8 +5777,28 @@ func (r *CAPIClient) EstimateFee(cmd ...*btcjson.EstimateFeeCmd) (res float64, e
 	return
 }
 
-func (r *CAPIClient) Generate(cmd ...*None) (res []string, err error) {
-	var c *None
+func (r *CAPIClient) EstimateSmartFee(cmd ...*btcjson.EstimateSmartFeeCmd) (res btcjson.EstimateSmartFeeResult, err error) {
+	var c *btcjson.EstimateSmartFeeCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.EstimateSmartFee", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) EvaluateScript(cmd ...*btcjson.EvaluateScriptCmd) (res btcjson.EvaluateScriptResult, err error) {
+	var c *btcjson.EvaluateScriptCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.EvaluateScript", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) Generate(cmd ...*btcjson.GenerateCmd) (res []string, err error) {
+	var c *btcjson.GenerateCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
@@ -3401,6 +5807,26 @@ func (r *CAPIClient) Generate(cmd ...*None) (res []string, err error) {
 	return
 }
 
+func (r *CAPIClient) GenerateBlock(cmd ...*btcjson.GenerateBlockCmd) (res btcjson.GenerateBlockResult, err error) {
+	var c *btcjson.GenerateBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GenerateBlock", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GenerateToAddress(cmd ...*btcjson.GenerateToAddressCmd) (res []string, err error) {
+	var c *btcjson.GenerateToAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GenerateToAddress", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetAddedNodeInfo(cmd ...*btcjson.GetAddedNodeInfoCmd) (res []btcjson.GetAddedNodeInfoResultAddr, err error) {
 	var c *btcjson.GetAddedNodeInfoCmd
 	if len(cmd) > 0 {
@@ -3411,6 +5837,56 @@ func (r *CAPIClient) GetAddedNodeInfo(cmd ...*btcjson.GetAddedNodeInfoCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetAddressBalance(cmd ...*btcjson.GetAddressBalanceCmd) (res btcjson.GetAddressBalanceResult, err error) {
+	var c *btcjson.GetAddressBalanceCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetAddressBalance", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAddressTxIDs(cmd ...*btcjson.GetAddressTxIDsCmd) (res []string, err error) {
+	var c *btcjson.GetAddressTxIDsCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetAddressTxIDs", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAddressUtxos(cmd ...*btcjson.GetAddressUtxosCmd) (res []btcjson.GetAddressUtxosResult, err error) {
+	var c *btcjson.GetAddressUtxosCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetAddressUtxos", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAddrManInfo(cmd ...*None) (res btcjson.GetAddrManInfoResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetAddrManInfo", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetAuditLog(cmd ...*btcjson.GetAuditLogCmd) (res []btcjson.AuditLogEntry, err error) {
+	var c *btcjson.GetAuditLogCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetAuditLog", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetBestBlock(cmd ...*None) (res btcjson.GetBestBlockResult, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3451,8 +5927,8 @@ func (r *CAPIClient) GetBlockChainInfo(cmd ...*None) (res btcjson.GetBlockChainI
 	return
 }
 
-func (r *CAPIClient) GetBlockCount(cmd ...*None) (res int64, err error) {
-	var c *None
+func (r *CAPIClient) GetBlockCount(cmd ...*btcjson.GetBlockCountCmd) (res int64, err error) {
+	var c *btcjson.GetBlockCountCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
@@ -3461,6 +5937,16 @@ func (r *CAPIClient) GetBlockCount(cmd ...*None) (res int64, err error) {
 	return
 }
 
+func (r *CAPIClient) GetBlockFilter(cmd ...*btcjson.GetBlockFilterCmd) (res btcjson.GetBlockFilterResult, err error) {
+	var c *btcjson.GetBlockFilterCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetBlockFilter", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetBlockHash(cmd ...*btcjson.GetBlockHashCmd) (res string, err error) {
 	var c *btcjson.GetBlockHashCmd
 	if len(cmd) > 0 {
@@ -3481,6 +5967,16 @@ func (r *CAPIClient) GetBlockHeader(cmd ...*btcjson.GetBlockHeaderCmd) (res btcj
 	return
 }
 
+func (r *CAPIClient) GetBlockSubsidy(cmd ...*btcjson.GetBlockSubsidyCmd) (res btcjson.GetBlockSubsidyResult, err error) {
+	var c *btcjson.GetBlockSubsidyCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetBlockSubsidy", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetBlockTemplate(cmd ...*btcjson.GetBlockTemplateCmd) (res string, err error) {
 	var c *btcjson.GetBlockTemplateCmd
 	if len(cmd) > 0 {
@@ -3591,6 +6087,16 @@ func (r *CAPIClient) GetMempoolInfo(cmd ...*None) (res btcjson.GetMempoolInfoRes
 	return
 }
 
+func (r *CAPIClient) GetMinerStats(cmd ...*None) (res btcjson.GetMinerStatsResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetMinerStats", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetMiningInfo(cmd ...*None) (res btcjson.GetMiningInfoResult, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3621,6 +6127,16 @@ func (r *CAPIClient) GetNetworkHashPS(cmd ...*btcjson.GetNetworkHashPSCmd) (res
 	return
 }
 
+func (r *CAPIClient) GetNetworkInfo(cmd ...*None) (res btcjson.GetNetworkInfoResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetNetworkInfo", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetPeerInfo(cmd ...*None) (res []btcjson.GetPeerInfoResult, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3631,6 +6147,16 @@ func (r *CAPIClient) GetPeerInfo(cmd ...*None) (res []btcjson.GetPeerInfoResult,
 	return
 }
 
+func (r *CAPIClient) GetPeerStats(cmd ...*None) (res btcjson.GetPeerStatsResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetPeerStats", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetRawMempool(cmd ...*btcjson.GetRawMempoolCmd) (res []string, err error) {
 	var c *btcjson.GetRawMempoolCmd
 	if len(cmd) > 0 {
@@ -3651,6 +6177,46 @@ func (r *CAPIClient) GetRawTransaction(cmd ...*btcjson.GetRawTransactionCmd) (re
 	return
 }
 
+func (r *CAPIClient) GetSnapshot(cmd ...*None) (res btcjson.GetSnapshotResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetSnapshot", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetSpentInfo(cmd ...*btcjson.GetSpentInfoCmd) (res btcjson.GetSpentInfoResult, err error) {
+	var c *btcjson.GetSpentInfoCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetSpentInfo", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetSyncStatus(cmd ...*btcjson.GetSyncStatusCmd) (res btcjson.GetSyncStatusResult, err error) {
+	var c *btcjson.GetSyncStatusCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetSyncStatus", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetTelemetryInfo(cmd ...*None) (res btcjson.GetTelemetryInfoResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetTelemetryInfo", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetTxOut(cmd ...*btcjson.GetTxOutCmd) (res string, err error) {
 	var c *btcjson.GetTxOutCmd
 	if len(cmd) > 0 {
@@ -3661,6 +6227,26 @@ func (r *CAPIClient) GetTxOut(cmd ...*btcjson.GetTxOutCmd) (res string, err erro
 	return
 }
 
+func (r *CAPIClient) GetTxSpendingPrevOut(cmd ...*btcjson.GetTxSpendingPrevOutCmd) (res []btcjson.GetTxSpendingPrevOutResult, err error) {
+	var c *btcjson.GetTxSpendingPrevOutCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetTxSpendingPrevOut", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetWork(cmd ...*btcjson.GetWorkCmd) (res btcjson.GetWorkResult, err error) {
+	var c *btcjson.GetWorkCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetWork", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) Help(cmd ...*btcjson.HelpCmd) (res string, err error) {
 	var c *btcjson.HelpCmd
 	if len(cmd) > 0 {
@@ -3671,6 +6257,46 @@ func (r *CAPIClient) Help(cmd ...*btcjson.HelpCmd) (res string, err error) {
 	return
 }
 
+func (r *CAPIClient) InvalidateBlock(cmd ...*btcjson.InvalidateBlockCmd) (res None, err error) {
+	var c *btcjson.InvalidateBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.InvalidateBlock", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ListBanned(cmd ...*None) (res []btcjson.ListBannedResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ListBanned", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) LoadAddrMan(cmd ...*btcjson.LoadAddrManCmd) (res btcjson.LoadAddrManResult, err error) {
+	var c *btcjson.LoadAddrManCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.LoadAddrMan", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) LoadUTXOSet(cmd ...*btcjson.LoadUTXOSetCmd) (res btcjson.UTXOSetSnapshotResult, err error) {
+	var c *btcjson.LoadUTXOSetCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.LoadUTXOSet", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) Node(cmd ...*btcjson.NodeCmd) (res None, err error) {
 	var c *btcjson.NodeCmd
 	if len(cmd) > 0 {
@@ -3691,6 +6317,26 @@ func (r *CAPIClient) Ping(cmd ...*None) (res None, err error) {
 	return
 }
 
+func (r *CAPIClient) ReconsiderBlock(cmd ...*btcjson.ReconsiderBlockCmd) (res None, err error) {
+	var c *btcjson.ReconsiderBlockCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ReconsiderBlock", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ReleaseSnapshot(cmd ...*btcjson.ReleaseSnapshotCmd) (res None, err error) {
+	var c *btcjson.ReleaseSnapshotCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ReleaseSnapshot", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) ResetChain(cmd ...*None) (res None, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3711,6 +6357,16 @@ func (r *CAPIClient) Restart(cmd ...*None) (res None, err error) {
 	return
 }
 
+func (r *CAPIClient) ScanTxOutSet(cmd ...*btcjson.ScanTxOutSetCmd) (res btcjson.ScanTxOutSetResult, err error) {
+	var c *btcjson.ScanTxOutSetCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ScanTxOutSet", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) SearchRawTransactions(cmd ...*btcjson.SearchRawTransactionsCmd) (res []btcjson.SearchRawTransactionsResult, err error) {
 	var c *btcjson.SearchRawTransactionsCmd
 	if len(cmd) > 0 {
@@ -3731,6 +6387,16 @@ func (r *CAPIClient) SendRawTransaction(cmd ...*btcjson.SendRawTransactionCmd) (
 	return
 }
 
+func (r *CAPIClient) SetBan(cmd ...*btcjson.SetBanCmd) (res None, err error) {
+	var c *btcjson.SetBanCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.SetBan", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) SetGenerate(cmd ...*btcjson.SetGenerateCmd) (res None, err error) {
 	var c *btcjson.SetGenerateCmd
 	if len(cmd) > 0 {
@@ -3761,6 +6427,26 @@ func (r *CAPIClient) SubmitBlock(cmd ...*btcjson.SubmitBlockCmd) (res string, er
 	return
 }
 
+func (r *CAPIClient) SubmitHeader(cmd ...*btcjson.SubmitHeaderCmd) (res string, err error) {
+	var c *btcjson.SubmitHeaderCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.SubmitHeader", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) TestMempoolAccept(cmd ...*btcjson.TestMempoolAcceptCmd) (res []btcjson.TestMempoolAcceptResult, err error) {
+	var c *btcjson.TestMempoolAcceptCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.TestMempoolAccept", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) Uptime(cmd ...*None) (res btcjson.GetMempoolInfoResult, err error) {
 	var c *None
 	if len(cmd) > 0 {
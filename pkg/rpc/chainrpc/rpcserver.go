@@ -16,6 +16,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -23,8 +24,8 @@ import (
 	"time"
 
 	"github.com/btcsuite/websocket"
-	uberatomic "go.uber.org/atomic"
 
+	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/node/mempool"
 	"github.com/p9c/pod/cmd/node/state"
 	blockchain "github.com/p9c/pod/pkg/chain"
@@ -33,13 +34,20 @@ import (
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	indexers "github.com/p9c/pod/pkg/chain/index"
 	"github.com/p9c/pod/pkg/chain/mining"
+	netsync "github.com/p9c/pod/pkg/chain/sync"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	p "github.com/p9c/pod/pkg/comm/peer"
+	"github.com/p9c/pod/pkg/comm/peer/addrmgr"
+	"github.com/p9c/pod/pkg/comm/peer/connmgr"
+	"github.com/p9c/pod/pkg/comm/telemetry"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/pod"
+	"github.com/p9c/pod/pkg/rpc/audit"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
+	rpcclient "github.com/p9c/pod/pkg/rpc/client"
 	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/metrics"
 )
 
 const (
@@ -54,18 +62,33 @@ type CommandHandler struct {
 }
 
 // GBTWorkState houses state that is used in between multiple RPC invocations to getblocktemplate.
+//
+// TemplateSeq is incremented for every new block template, so that templates generated within the same second are told
+// apart by their template IDs.
+//
+// The variations of the block template handed out by getwork are kept by their merkle root, in the order they were
+// handed out, for as long as they are built on the best block.
 type GBTWorkState struct {
 	sync.Mutex
 	LastTxUpdate  time.Time
 	LastGenerated time.Time
+	TemplateSeq   uint64
 	prevHash      *chainhash.Hash
 	MinTimestamp  time.Time
 	Template      *mining.BlockTemplate
-	NotifyMap     map[chainhash.Hash]map[int64]chan struct{}
+	LongPoll      *LongPollWaiters
+	Issued        *IssuedTemplates
 	TimeSource    blockchain.MedianTimeSource
 	Algo          string
 	StateCfg      *state.Config
 	Config        *pod.Config
+
+	getWorkBlocks   map[chainhash.Hash]*getWorkBlock
+	getWorkOrder    []chainhash.Hash
+	getWorkPrevHash chainhash.Hash
+	extraNonce      uint64
+	// payAddr is the address the coinbase of the template pays to, when it has one
+	payAddr util.Address
 }
 
 // ParsedRPCCmd represents a JSON-RPC request object that has been parsed into a known concrete command along with any
@@ -73,6 +96,7 @@ type GBTWorkState struct {
 type ParsedRPCCmd struct {
 	ID     interface{}
 	Method string
+	Params []js.RawMessage
 	Cmd    interface{}
 	Err    *btcjson.RPCError
 }
@@ -91,6 +115,26 @@ type RetrievedTx struct {
 	Tx      *util.Tx
 }
 
+// AddressTx is a transaction involving one of the addresses of an address index query. Height is the height of the
+// block containing the transaction and Offset is where it starts in the block, both are 0 for a transaction in the
+// mempool.
+type AddressTx struct {
+	Tx      *wire.MsgTx
+	Hash    chainhash.Hash
+	Height  int32
+	Offset  uint32
+	Mempool bool
+}
+
+// AddressOutput is an output of an AddressTx that pays one of the addresses of an address index query.
+type AddressOutput struct {
+	Address  string
+	OutPoint wire.OutPoint
+	TxOut    *wire.TxOut
+	Height   int32
+	Mempool  bool
+}
+
 // Server provides a concurrent safe RPC server to a chain server.
 type Server struct {
 	Cfg                    ServerConfig
@@ -101,7 +145,11 @@ type Server struct {
 	StatusLock             sync.RWMutex
 	WG                     sync.WaitGroup
 	GBTWorkState           *GBTWorkState
+	AlgoWorkStates         map[string]*GBTWorkState
+	AlgoWorkStatesLock     sync.Mutex
 	HelpCacher             *HelpCacher
+	Snapshots              *Snapshots
+	Proposals              *ProposalCache
 	RequestProcessShutdown chan struct{}
 	Quit                   chan struct{}
 	Started                int32
@@ -145,17 +193,34 @@ type ServerConfig struct {
 	// CPUMiner  *cpuminer.CPUMiner
 	//
 	// These fields define any optional indexes the RPC server can make use of to provide additional data when queried.
-	TxIndex   *indexers.TxIndex
-	AddrIndex *indexers.AddrIndex
-	CfIndex   *indexers.CFIndex
+	TxIndex    *indexers.TxIndex
+	WTxIndex   *indexers.WTxIndex
+	AddrIndex  *indexers.AddrIndex
+	CfIndex    *indexers.CFIndex
+	SpentIndex *indexers.SpentIndex
+	// IndexManager catches up the optional indexes with the chain, nil if none are enabled.
+	IndexManager *indexers.Manager
 	// The fee estimator keeps track of how long transactions are left in the mempool before they are mined into blocks.
 	FeeEstimator *mempool.FeeEstimator
 	// Algo sets the algorithm expected from the RPC endpoint. This allows multiple ports to serve multiple types of
 	// miners with one main node per algorithm. Currently 514 for Scrypt and anything else passes for SHA256d.
 	Algo string
 	// CPUMiner *exec.Cmd
-	Hashrate uberatomic.Uint64
-	Quit     chan struct{}
+	//
+	// MinerStats are the statistics of the kopach workers taking work from the node's controller
+	MinerStats *hashrate.Stats
+	// Telemetry is the opt-in telemetry reporter of the node
+	Telemetry *telemetry.Reporter
+	// Announcements are the verified maintainer announcements received by the node
+	Announcements *Announcements
+	// RequestDuration is the histogram of the time taken to handle requests by method
+	RequestDuration *metrics.Histogram
+	// AuditLog records the state-changing commands run on the server
+	AuditLog *audit.Log
+	// WalletClient is the client of the wallet RPC server that wallet commands are forwarded to, nil if they are
+	// answered with ErrRPCNoWallet.
+	WalletClient *rpcclient.Client
+	Quit         chan struct{}
 }
 
 // ServerConnManager represents a connection manager for use with the RPC server. The interface contract requires that
@@ -185,8 +250,31 @@ type ServerConnManager interface {
 	DisconnectByAddr(addr string) error
 	// ConnectedCount returns the number of currently connected peers.
 	ConnectedCount() int32
+	// Ban bans the subnet until the given time and disconnects the peers in it.
+	Ban(subnet *net.IPNet, until time.Time)
+	// Unban removes the ban of the subnet.
+	//
+	// Attempting to unban a subnet that is not banned will return an error.
+	Unban(subnet *net.IPNet) error
+	// ListBanned returns the bans of the node, oldest first.
+	ListBanned() []connmgr.BanEntry
+	// ClearBanned removes all the bans of the node.
+	ClearBanned()
 	// NetTotals returns the sum of all bytes received and sent across the network for all peers.
 	NetTotals() (uint64, uint64)
+	// LocalServices returns the services advertised to peers.
+	LocalServices() wire.ServiceFlag
+	// LocalAddresses returns the local addresses advertised to peers, discovered from the interfaces, UPnP and the
+	// configuration.
+	LocalAddresses() []addrmgr.LocalAddress
+	// ExportAddresses writes the known peer addresses to w in the format of the peers file and returns how many were
+	// written.
+	ExportAddresses(w io.Writer) (int, error)
+	// ImportAddresses adds the addresses of a peers file read from r that are not known yet and returns how many were
+	// added.
+	ImportAddresses(r io.Reader) (int, error)
+	// AddressBuckets returns the fill level of the buckets of the address manager.
+	AddressBuckets() addrmgr.BucketInfo
 	// ConnectedPeers returns an array consisting of all connected peers.
 	ConnectedPeers() []ServerPeer
 	// PersistentPeers returns an array consisting of all the persistent peers.
@@ -214,6 +302,15 @@ type ServerPeer interface {
 	GetBanScore() uint32
 	// GetFeeFilter returns the requested current minimum fee rate for which transactions should be announced.
 	GetFeeFilter() int64
+	// GetBlockServeTime returns the average time the peer has taken to serve the blocks requested from it, zero if it
+	// has not served any yet.
+	GetBlockServeTime() time.Duration
+	// GetPermissions returns the permissions the whitelists grant the peer.
+	GetPermissions() connmgr.Permissions
+	// IsAddrRelayEnabled returns whether the addresses advertised by the peer are passed to the address manager.
+	IsAddrRelayEnabled() bool
+	// GetAddrProcessed returns the number of addresses advertised by the peer that were passed to the address manager.
+	GetAddrProcessed() uint64
 }
 
 // ServerSyncManager represents a sync manager for use with the RPC server.
@@ -229,6 +326,8 @@ type ServerSyncManager interface {
 	Pause() chan<- struct{}
 	// SyncPeerID returns the ID of the peer that is currently the peer being used to sync from or 0 if there is none.
 	SyncPeerID() int32
+	// SyncStatus returns the stage and progress of the sync of the chain with the connected peers.
+	SyncStatus() *netsync.SyncStatus
 	// LocateHeaders returns the headers of the blocks after the first known block in the provided locators until the
 	// provided stop hash or the current tip is reached, up to a max of wire.MaxBlockHeadersPerMsg hashes.
 	LocateHeaders(locators []*chainhash.Hash, hashStop *chainhash.Hash) []wire.BlockHeader
@@ -269,12 +368,19 @@ var (
 		Code:    btcjson.ErrRPCUnimplemented,
 		Message: "Command unimplemented",
 	}
+	// ErrRPCNoMining is an error returned to RPC clients when the provided command is a mining command and mining is
+	// turned off.
+	ErrRPCNoMining = &btcjson.RPCError{
+		Code:    btcjson.ErrRPCMisc,
+		Message: "Mining is turned off with --nomining",
+	}
 	// GBTCapabilities describes additional capabilities returned with a block template generated by the
 	// getblocktemplate RPC.
 	//
 	// It is declared here to avoid the overhead of creating the slice on every invocation for constant data.
 	GBTCapabilities = []string{"proposal"}
-	// GBTCoinbaseAux describes additional data that miners should include in the coinbase signature script.
+	// GBTCoinbaseAux describes additional data that miners should include in the coinbase signature script, when no
+	// coinbase data is configured.
 	//
 	// It is declared here to avoid the overhead of creating a new object on every invocation for constant data.
 	GBTCoinbaseAux = &btcjson.GetBlockTemplateResultAux{
@@ -285,9 +391,12 @@ var (
 	// GBTMutableFields are the manipulations the server allows to be made to block templates generated by the
 	// getblocktemplate RPC.
 	//
+	// A proposal with a later time is checked against the target required at that time, which is the target of the
+	// block on networks where the difficulty does not depend on the time, so the target may follow the time.
+	//
 	// It is declared here to avoid the overhead of creating the slice on every invocation for constant data.
 	GBTMutableFields = []string{
-		"time", "transactions/add", "prevblock", "coinbase/append",
+		"time", "time/increment", "target", "transactions/add", "prevblock", "coinbase/append",
 	}
 
 	// RPCAskWallet is list of commands that we recognize, but for which pod has no support because it lacks support for
@@ -532,7 +641,6 @@ var (
 	// 				make(chan GetTxOutRes)}
 	// 		},
 	// 	},
-	// 	// "getwork":               HandleGetWork,
 	// 	"help": {
 	// 		HandleHelp, make(chan API),
 	// 		func() API {
@@ -629,14 +737,15 @@ var (
 	// RPCLimited isCommands that are available to a limited user
 	RPCLimited = map[string]CommandHandler{
 		// Websockets commands
-		"loadtxfilter":          {},
-		"notifyblocks":          {},
-		"notifynewtransactions": {},
-		"notifyreceived":        {},
-		"notifyspent":           {},
-		"rescan":                {},
-		"rescanblocks":          {},
-		"session":               {},
+		"loadtxfilter":                {},
+		"notifyblocks":                {},
+		"notifynewtransactions":       {},
+		"notifyreceived":              {},
+		"notifyspent":                 {},
+		"rescan":                      {},
+		"rescanblocks":                {},
+		"searchrawtransactionsstream": {},
+		"session":                     {},
 		// Websockets AND HTTP/S commands
 		"help": {},
 		// HTTP/S-only commands
@@ -648,8 +757,10 @@ var (
 		"getbestblockhash":      {},
 		"getblock":              {},
 		"getblockcount":         {},
+		"getblockfilter":        {},
 		"getblockhash":          {},
 		"getblockheader":        {},
+		"getblocksubsidy":       {},
 		"getcfilter":            {},
 		"getcfilterheader":      {},
 		"getcurrentnet":         {},
@@ -658,56 +769,65 @@ var (
 		"getinfo":               {},
 		"getnettotals":          {},
 		"getnetworkhashps":      {},
+		"getnetworkinfo":        {},
 		"getrawmempool":         {},
 		"getrawtransaction":     {},
 		"gettxout":              {},
 		"searchrawtransactions": {},
 		"sendrawtransaction":    {},
 		"submitblock":           {},
+		"submitheader":          {},
 		"uptime":                {},
 		"validateaddress":       {},
 		"verifymessage":         {},
 		"version":               {},
 	}
+	// RPCAudited is the commands that change the state of the node, which are recorded in the audit log
+	RPCAudited = map[string]struct{}{
+		"addnode":            {},
+		"generate":           {},
+		"generateblock":      {},
+		"generatetoaddress":  {},
+		"invalidateblock":    {},
+		"loadaddrman":        {},
+		"loadutxoset":        {},
+		"node":               {},
+		"reconsiderblock":    {},
+		"resetchain":         {},
+		"restart":            {},
+		"sendrawtransaction": {},
+		"setgenerate":        {},
+		"stop":               {},
+	}
+	// RPCMining is commands that are refused when mining is turned off.
+	RPCMining = map[string]struct{}{
+		"generate":          {},
+		"generateblock":     {},
+		"generatetoaddress": {},
+		"getblocktemplate":  {},
+		"getwork":           {},
+		"setgenerate":       {},
+	}
 	// RPCUnimplemented is commands that are currently unimplemented, but should ultimately be.
 	RPCUnimplemented = map[string]struct{}{
 		"estimatepriority": {},
 		"getchaintips":     {},
 		"getmempoolentry":  {},
-		"getnetworkinfo":   {},
-		"getwork":          {},
-		"invalidateblock":  {},
 		"preciousblock":    {},
-		"reconsiderblock":  {},
 	}
 )
 
 // NotifyBlockConnected uses the newly-connected block to notify any long poll clients with a new block template when
 // their existing block template is stale due to the newly connected block.
 func (state *GBTWorkState) NotifyBlockConnected(blockHash *chainhash.Hash) {
-	go func() {
-		state.Lock()
-		statelasttxupdate := state.LastTxUpdate
-		state.Unlock()
-		state.NotifyLongPollers(blockHash, statelasttxupdate)
-	}()
+	state.LongPoll.NotifyBlockConnected(blockHash)
 }
 
 // NotifyMempoolTx uses the new last updated time for the transaction memory pool to notify any long poll clients with a
 // new block template when their existing block template is stale due to enough time passing and the contents of the
 // memory pool changing.
 func (state *GBTWorkState) NotifyMempoolTx(lastUpdated time.Time) {
-	go func() {
-		state.Lock()
-		defer state.Unlock()
-		// No need to notify anything if no block templates have been generated yet.
-		if state.prevHash == nil || state.LastGenerated.IsZero() {
-			return
-		}
-		if time.Now().After(state.LastGenerated.Add(time.Second * GBTRegenerateSeconds)) {
-			state.NotifyLongPollers(state.prevHash, lastUpdated)
-		}
-	}()
+	state.LongPoll.NotifyMempoolTx(lastUpdated)
 }
 
 // BlockTemplateResult returns the current block template associated with the state as a json.GetBlockTemplateResult
@@ -740,6 +860,7 @@ func (state *GBTWorkState) BlockTemplateResult(useCoinbaseValue bool, submitOld
 	// coinbase, so notice the adjustments to the various lengths and indices.
 	numTx := len(msgBlock.Transactions)
 	transactions := make([]btcjson.GetBlockTemplateResultTx, 0, numTx-1)
+	txHashes := make([]chainhash.Hash, 0, numTx-1)
 	txIndex := make(map[chainhash.Hash]int64, numTx)
 	for i, tx := range msgBlock.Transactions {
 		txHash := tx.TxHash()
@@ -781,6 +902,7 @@ func (state *GBTWorkState) BlockTemplateResult(useCoinbaseValue bool, submitOld
 			Weight:  blockchain.GetTransactionWeight(bTx),
 		}
 		transactions = append(transactions, resultTx)
+		txHashes = append(txHashes, txHash)
 	}
 	// Generate the block template reply.
 	//
@@ -790,7 +912,8 @@ func (state *GBTWorkState) BlockTemplateResult(useCoinbaseValue bool, submitOld
 	//
 	//   Omitting CoinbaseTxn -> coinbase, generation
 	targetDifficulty := fmt.Sprintf("%064x", fork.CompactToBig(header.Bits))
-	templateID := EncodeTemplateID(state.prevHash, state.LastGenerated)
+	templateID := EncodeTemplateID(state.prevHash, state.LastGenerated, state.TemplateSeq)
+	algo := fork.GetAlgoName(header.Version, template.Height)
 	reply := btcjson.GetBlockTemplateResult{
 		Bits:         strconv.FormatInt(int64(header.Bits), 16),
 		CurTime:      header.Timestamp.Unix(),
@@ -809,6 +932,9 @@ func (state *GBTWorkState) BlockTemplateResult(useCoinbaseValue bool, submitOld
 		Mutable:      GBTMutableFields,
 		NonceRange:   GBTNonceRange,
 		Capabilities: GBTCapabilities,
+		PayoutPolicy: state.StateCfg.Payout.PolicyName(),
+		PowAlgo:      algo,
+		PowAlgoID:    fork.GetAlgoID(algo, template.Height),
 	}
 	// If the generated block template includes transactions with witness data, then include the witness commitment in
 	// the GBT result.
@@ -817,6 +943,14 @@ func (state *GBTWorkState) BlockTemplateResult(useCoinbaseValue bool, submitOld
 	}
 	if useCoinbaseValue {
 		reply.CoinbaseAux = GBTCoinbaseAux
+		// the coinbase data goes after the flags, so miners making their own coinbase include it as well
+		if state.Config != nil && *state.Config.CoinbaseData != "" {
+			flags, err := mining.CoinbaseFlagsScript([]byte(*state.Config.CoinbaseData))
+			if err != nil {
+				return nil, InternalRPCError(err.Error(), "Failed to create the coinbase flags")
+			}
+			reply.CoinbaseAux = &btcjson.GetBlockTemplateResultAux{Flags: hex.EncodeToString(flags)}
+		}
 		reply.CoinbaseValue = &msgBlock.Transactions[0].TxOut[0].Value
 	} else {
 		// Ensure the template has a valid payment address associated with it when a full coinbase is requested.
@@ -844,72 +978,33 @@ func (state *GBTWorkState) BlockTemplateResult(useCoinbaseValue bool, submitOld
 			SigOps:  template.SigOpCosts[0],
 		}
 		reply.CoinbaseTxn = &resultTx
+		if state.payAddr != nil {
+			reply.PayoutAddress = state.payAddr.EncodeAddress()
+		}
 	}
+	state.Issued.Add(state.prevHash, templateID, txHashes)
 	return &reply, nil
 }
 
-// NotifyLongPollers notifies any channels that have been registered to be notified when block templates are stale.
-//
-// This function MUST be called with the state locked.
-func (state *GBTWorkState) NotifyLongPollers(latestHash *chainhash.Hash,
-	lastGenerated time.Time) {
-	// Notify anything that is waiting for a block template update from a hash which is not the hash of the tip of the
-	// best chain since their work is now invalid.
-	for hash, channels := range state.NotifyMap {
-		if !hash.IsEqual(latestHash) {
-			for _, c := range channels {
-				close(c)
-			}
-			delete(state.NotifyMap, hash)
-		}
-	}
-	// Return now if the provided last generated timestamp has not been initialized.
-	if lastGenerated.IsZero() {
-		return
+// ApplyRequestTarget sets the target of a block template reply to the target asked for by the basic pool extension of
+// BIP 0023, when that is harder than the target of the block, as a block meeting it meets the target of the block as
+// well. An easier target is ignored, as the blocks only meeting it would be rejected.
+func ApplyRequestTarget(result interface{}, target string) (interface{}, error) {
+	reply, ok := result.(*btcjson.GetBlockTemplateResult)
+	if target == "" || !ok {
+		return result, nil
 	}
-	// Return now if there is nothing registered for updates to the current best block hash.
-	channels, ok := state.NotifyMap[*latestHash]
-	if !ok {
-		return
-	}
-	// Notify anything that is waiting for a block template update from a block template generated before the most
-	// recently generated block template.
-	lastGeneratedUnix := lastGenerated.Unix()
-	for lastGen, c := range channels {
-		if lastGen < lastGeneratedUnix {
-			close(c)
-			delete(channels, lastGen)
+	requested, ok := new(big.Int).SetString(target, 16)
+	if !ok || requested.Sign() <= 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Invalid target " + target,
 		}
 	}
-	// Remove the entry altogether if there are no more registered channels.
-	if len(channels) == 0 {
-		delete(state.NotifyMap, *latestHash)
-	}
-}
-
-// TemplateUpdateChan returns a channel that will be closed once the block template associated with the passed previous
-// hash and last generated time is stale.
-//
-// The function will return existing channels for duplicate parameters which allows to wait for the same block template
-// without requiring a different channel for each client.
-//
-// This function MUST be called with the state locked.
-func (state *GBTWorkState) TemplateUpdateChan(prevHash *chainhash.Hash, lastGenerated int64) chan struct{} {
-	// Either get the current list of channels waiting for updates about changes to block template for the previous hash
-	// or create a new one.
-	channels, ok := state.NotifyMap[*prevHash]
-	if !ok {
-		m := make(map[int64]chan struct{})
-		state.NotifyMap[*prevHash] = m
-		channels = m
-	}
-	// Get the current channel associated with the time the block template was last generated or create a new one.
-	c, ok := channels[lastGenerated]
-	if !ok {
-		c = make(chan struct{})
-		channels[lastGenerated] = c
+	if blockTarget, ok := new(big.Int).SetString(reply.Target, 16); ok && requested.Cmp(blockTarget) < 0 {
+		reply.Target = fmt.Sprintf("%064x", requested)
 	}
-	return c
+	return reply, nil
 }
 
 // UpdateBlockTemplate creates or updates a block template for the work state.
@@ -946,13 +1041,17 @@ func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 		// Reset the previous best hash the block template was generated against so any errors below cause the next
 		// invocation to try again.
 		state.prevHash = nil
-		// Choose a payment address at random if the caller requests a full coinbase as opposed to only the pertinent
-		// details needed to create their own coinbase.
+		// Choose a payment address by the payout policy if the caller requests a full coinbase as opposed to only the
+		// pertinent details needed to create their own coinbase.
 		var payAddr util.Address
 		if !useCoinbaseValue {
-			payAddr = s.StateCfg.ActiveMiningAddrs[rand.Intn(len(s.StateCfg.
-				ActiveMiningAddrs))]
+			var err error
+			if payAddr, err = s.StateCfg.Payout.Address(s.StateCfg.ActiveMiningAddrs, state.Algo,
+				s.Cfg.Chain.BestSnapshot().Height+1); Check(err) {
+				return InternalRPCError("Failed to choose a payment address: "+err.Error(), "")
+			}
 		}
+		state.payAddr = payAddr
 		// Create a new block template that has a coinbase which anyone can redeem.
 		//
 		// This is only acceptable because the returned block template doesn't include the coinbase, so the caller will
@@ -974,9 +1073,14 @@ func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 		// Update work state to ensure another block template isn't generated until needed.
 		state.Template = template
 		state.LastGenerated = time.Now()
+		state.TemplateSeq++
 		state.LastTxUpdate = lastTxUpdate
 		state.prevHash = latestHash
 		state.MinTimestamp = minTimestamp
+		state.LongPoll.SetTemplate(latestHash, state.TemplateSeq, state.LastGenerated)
+		// The generator checked the template connects to the best block, so proposing it back needn't be checked again.
+		if err = s.Proposals.AddTemplate(latestHash, state.Algo, msgBlock); Check(err) {
+		}
 		Debugf(
 			"generated block template (timestamp %v, target %s, merkle root %s)",
 			msgBlock.Header.Timestamp,
@@ -984,7 +1088,7 @@ func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 			msgBlock.Header.MerkleRoot)
 
 		// Notify any clients that are long polling about the new template.
-		state.NotifyLongPollers(latestHash, lastTxUpdate)
+		state.LongPoll.Notify(latestHash, state.TemplateSeq)
 	} else {
 		// At this point, there is a saved block template and another request for a template was made, but either the
 		// available transactions haven't change or it hasn't been long enough to trigger a new block template to be
@@ -998,10 +1102,13 @@ func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 		// Since this requires mining addresses to be specified via the config, an error is returned if none have been
 		// specified.
 		if !useCoinbaseValue && !template.ValidPayAddress {
-			// Choose a payment address at random.
-			payToAddr := s.StateCfg.ActiveMiningAddrs[rand.Intn(len(s.
-				StateCfg.ActiveMiningAddrs))]
-			// Update the block coinbase output of the template to pay to the randomly selected payment address.
+			// Choose a payment address by the payout policy.
+			payToAddr, err := s.StateCfg.Payout.Address(s.StateCfg.ActiveMiningAddrs, state.Algo, template.Height)
+			if err != nil {
+				Error(err)
+				return InternalRPCError(err.Error(), "Failed to choose a payment address")
+			}
+			// Update the block coinbase output of the template to pay to the selected payment address.
 			pkScript, err := txscript.PayToAddrScript(payToAddr)
 			if err != nil {
 				Error(err)
@@ -1010,10 +1117,13 @@ func (state *GBTWorkState) UpdateBlockTemplate(s *Server,
 			}
 			template.Block.Transactions[0].TxOut[0].PkScript = pkScript
 			template.ValidPayAddress = true
+			state.payAddr = payToAddr
 			// Update the merkle root.
 			block := util.NewBlock(template.Block)
 			merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
 			template.Block.Header.MerkleRoot = *merkles[len(merkles)-1]
+			if err = s.Proposals.AddTemplate(state.prevHash, state.Algo, template.Block); Check(err) {
+			}
 		}
 		// Set locals for convenience.
 		msgBlock = template.Block
@@ -1046,8 +1156,48 @@ func (s *Server) NotifyNewTransactions(txns []*mempool.TxDesc) {
 		s.NtfnMgr.SendNotifyMempoolTx(txD.Tx, true)
 		// Potentially notify any getblocktemplate long poll clients about stale block templates due to the new
 		// transaction.
-		s.GBTWorkState.NotifyMempoolTx(s.Cfg.TxMemPool.LastUpdated())
+		for _, state := range s.WorkStates() {
+			state.NotifyMempoolTx(s.Cfg.TxMemPool.LastUpdated())
+		}
+	}
+}
+
+// NotifyMempoolEvent notifies websocket clients subscribed to mempool events that tx was accepted into the mempool, or
+// rejected with err. The source is the address of the peer that relayed the transaction, and empty for transactions
+// submitted over RPC.
+func (s *Server) NotifyMempoolEvent(tx *util.Tx, source string, err error) {
+	ntfn := &btcjson.MempoolEventNtfn{
+		TxID:     tx.Hash().String(),
+		Accepted: err == nil,
+		Peer:     source,
+	}
+	if err != nil {
+		var code wire.RejectCode
+		code, ntfn.Reason = mempool.ErrToRejectErr(err)
+		ntfn.RejectCode = code.String()
+	}
+	s.NtfnMgr.SendNotifyMempoolEvent(ntfn)
+}
+
+// NotifyTemplateEviction notifies websocket clients subscribed to template evictions that tx was evicted from the
+// mempool, or replaced by replacedBy when it is not nil, if it is included in the block templates issued to miners, so
+// they can refresh their work without waiting for a new template.
+func (s *Server) NotifyTemplateEviction(tx, replacedBy *util.Tx) {
+	var templateIDs []string
+	for _, state := range s.WorkStates() {
+		templateIDs = append(templateIDs, state.Issued.Containing(tx.Hash())...)
+	}
+	if len(templateIDs) == 0 {
+		return
+	}
+	ntfn := &btcjson.TemplateEvictionNtfn{
+		TxID:        tx.Hash().String(),
+		TemplateIDs: templateIDs,
 	}
+	if replacedBy != nil {
+		ntfn.ReplacedBy = replacedBy.Hash().String()
+	}
+	s.NtfnMgr.SendNotifyTemplateEviction(ntfn)
 }
 
 // RequestedProcessShutdown returns a channel that is sent to when an authorized RPC client requests the process to
@@ -1071,6 +1221,9 @@ func (s *Server) Start() {
 		w.Header().Set("Connection", "close")
 		w.Header().Set("Content-Type", "application/json")
 		r.Close = true
+		if !s.AllowedIP(w, r.RemoteAddr) {
+			return
+		}
 		// Limit the number of connections to max allowed.
 		if s.LimitConnections(w, r.RemoteAddr) {
 			return
@@ -1089,6 +1242,9 @@ func (s *Server) Start() {
 	})
 	// Websocket endpoint.
 	rpcServeMux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		if !s.AllowedIP(w, r.RemoteAddr) {
+			return
+		}
 		authenticated, isAdmin, err := s.CheckAuth(r, false)
 		if err != nil {
 			Error(err)
@@ -1147,6 +1303,8 @@ func (s *Server) Stop() error {
 	s.NtfnMgr.WaitForShutdown()
 	// close(s.Quit)
 	s.WG.Wait()
+	// pinned snapshots hold database transactions open which would block closing the database
+	s.Snapshots.ReleaseAll()
 	Debug("RPC server shutdown complete")
 	return nil
 }
@@ -1208,7 +1366,9 @@ func (s *Server) HandleBlockchainNotification(notification *blockchain.Notificat
 			}
 			// Allow any clients performing long polling via the getblocktemplate RPC to be notified when the new block
 			// causes their old block template to become stale.
-			s.GBTWorkState.NotifyBlockConnected(block.Hash())
+			for _, state := range s.WorkStates() {
+				state.NotifyBlockConnected(block.Hash())
+			}
 		case blockchain.NTBlockConnected:
 			block, ok := notification.Data.(*util.Block)
 			if !ok {
@@ -1316,74 +1476,40 @@ func (s *Server) JSONRPCRead(w http.ResponseWriter, r *http.Request, isAdmin boo
 	if err != nil {
 		Debug(err)
 	}
-	// Attempt to parse the raw body into a JSON-RPC request.
-	var responseID interface{}
-	var jsonErr error
-	var result interface{}
-	var request btcjson.Request
-	if err := js.Unmarshal(body, &request); err != nil {
-		jsonErr = &btcjson.RPCError{
-			Code:    btcjson.ErrRPCParse.Code,
-			Message: "Failed to parse request: " + err.Error(),
+	// Setup a close notifier. Since the connection is hijacked, the CloseNotifer on the ResponseWriter is not available.
+	closeChan := make(chan struct{}, 1)
+	go func() {
+		_, err := conn.Read(make([]byte, 1))
+		if err != nil {
+			// L.ScriptError(err)
+			close(closeChan)
 		}
-	}
-	if jsonErr == nil {
-		// The JSON-RPC 1.0 spec defines that notifications must have their "id"
-		// set to null and states that notifications do not have a response.
-		//
-		// A JSON-RPC 2.0 notification is a request with "json-rpc":"2.0", and without an "id" member.
-		//
-		// The specification states that notifications must not be responded to. JSON-RPC 2.0 permits the null value as
-		// a valid request id, therefore such requests are not notifications.
-		//
-		// Bitcoin Core serves requests with "id":null or even an absent "id", and responds to such requests with
-		// "id":null in the response. Pod does not respond to any request without and "id" or "id":null, regardless the
-		// indicated JSON-RPC protocol version unless RPC quirks are enabled.
-		//
-		// With RPC quirks enabled, such requests will be responded to if the request does not indicate JSON-RPC
-		// version. RPC quirks can be enabled by the user to avoid compatibility issues with software relying on Core's
-		// behavior.
-		if request.ID == nil && !(*s.Config.RPCQuirks && request.Jsonrpc == "") {
+	}()
+	// A getwork request to the long poll path it was told about is held until there is new work for its algorithm.
+	getWorkAlgo, isGetWork := GetWorkAlgo(body)
+	if isGetWork && r.URL.Path == GetWorkLongPollPath {
+		if err = s.WaitForWork(getWorkAlgo, closeChan); err == ErrClientQuit {
 			return
 		}
-		// The parse was at least successful enough to have an ID so set it for the response.
-		responseID = request.ID
-		// Setup a close notifier. Since the connection is hijacked, the CloseNotifer on the ResponseWriter is not
-		// available.
-		closeChan := make(chan struct{}, 1)
-		go func() {
-			_, err := conn.Read(make([]byte, 1))
-			if err != nil {
-				// L.ScriptError(err)
-				close(closeChan)
-			}
-		}()
-		// Check if the user is limited and set error if method unauthorized
-		if !isAdmin {
-			if _, ok := RPCLimited[request.Method]; !ok {
-				jsonErr = &btcjson.RPCError{
-					Code:    btcjson.ErrRPCInvalidParams.Code,
-					Message: "limited user not authorized for this method",
-				}
-			}
+	}
+	// A body that is a JSON array is a batch of requests, which is answered with an array of the replies to the
+	// requests that are not notifications, in the same order.
+	var msg []byte
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var ok bool
+		if msg, ok = s.ProcessBatch(trimmed, isAdmin, r.RemoteAddr, closeChan); !ok {
+			return
 		}
-		if jsonErr == nil {
-			// Attempt to parse the JSON-RPC request into a known concrete command.
-			parsedCmd := ParseCmd(&request)
-			if parsedCmd.Err != nil {
-				jsonErr = parsedCmd.Err
-			} else {
-				result, jsonErr = s.StandardCmdResult(parsedCmd, closeChan)
-			}
+	} else {
+		var ok bool
+		if msg, ok = s.ProcessRequest(body, isAdmin, r.RemoteAddr, closeChan); !ok {
+			return
 		}
 	}
-	// Marshal the response.
-	msg, err := CreateMarshalledReply(responseID, result, jsonErr)
-	if err != nil {
-		Error(err)
-		Error("failed to marshal reply:", err)
-
-		return
+	// Tell getwork clients where to long poll for new work and that they may roll the timestamp.
+	if isGetWork {
+		w.Header().Set("X-Long-Polling", GetWorkLongPollPath)
+		w.Header().Set("X-Roll-NTime", "expire="+strconv.Itoa(GetWorkRollNTimeSeconds))
 	}
 	// Write the response.
 	err = s.WriteHTTPResponseHeaders(r, w.Header(), http.StatusOK, buf)
@@ -1404,6 +1530,125 @@ func (s *Server) JSONRPCRead(w http.ResponseWriter, r *http.Request, isAdmin boo
 	}
 }
 
+// ProcessBatch runs each request of a JSON-RPC batch and returns the marshalled array of the replies. No reply is
+// returned if every request of the batch is a notification.
+func (s *Server) ProcessBatch(body []byte, isAdmin bool, remoteAddr string, closeChan <-chan struct{}) ([]byte, bool) {
+	var requests []js.RawMessage
+	if err := js.Unmarshal(body, &requests); err != nil {
+		return s.MarshalReply(nil, nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCParse.Code,
+			Message: "Failed to parse request: " + err.Error(),
+		})
+	}
+	if len(requests) == 0 {
+		return s.MarshalReply(nil, nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidRequest.Code,
+			Message: "Empty batch request",
+		})
+	}
+	var batch bytes.Buffer
+	batch.WriteByte('[')
+	replies := 0
+	for i := range requests {
+		reply, ok := s.ProcessRequest(requests[i], isAdmin, remoteAddr, closeChan)
+		if !ok {
+			continue
+		}
+		if replies > 0 {
+			batch.WriteByte(',')
+		}
+		batch.Write(reply)
+		replies++
+	}
+	if replies == 0 {
+		return nil, false
+	}
+	batch.WriteByte(']')
+	return batch.Bytes(), true
+}
+
+// ProcessRequest runs a single JSON-RPC request and returns the marshalled reply. No reply is returned if the request
+// is a notification.
+func (s *Server) ProcessRequest(body []byte, isAdmin bool, remoteAddr string, closeChan <-chan struct{}) ([]byte,
+	bool) {
+	// Attempt to parse the raw body into a JSON-RPC request.
+	var request btcjson.Request
+	if err := js.Unmarshal(body, &request); err != nil {
+		return s.MarshalReply(nil, nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCParse.Code,
+			Message: "Failed to parse request: " + err.Error(),
+		})
+	}
+	// The JSON-RPC 1.0 spec defines that notifications must have their "id" set to null and states that notifications
+	// do not have a response.
+	//
+	// A JSON-RPC 2.0 notification is a request with "json-rpc":"2.0", and without an "id" member.
+	//
+	// The specification states that notifications must not be responded to. JSON-RPC 2.0 permits the null value as a
+	// valid request id, therefore such requests are not notifications.
+	//
+	// Bitcoin Core serves requests with "id":null or even an absent "id", and responds to such requests with "id":null
+	// in the response. Pod does not respond to any request without and "id" or "id":null, regardless the indicated
+	// JSON-RPC protocol version unless RPC quirks are enabled.
+	//
+	// With RPC quirks enabled, such requests will be responded to if the request does not indicate JSON-RPC version.
+	// RPC quirks can be enabled by the user to avoid compatibility issues with software relying on Core's behavior.
+	if request.ID == nil && !(*s.Config.RPCQuirks && request.Jsonrpc == "") {
+		return nil, false
+	}
+	// Check if the user is limited and set error if method unauthorized
+	if !isAdmin {
+		if _, ok := RPCLimited[request.Method]; !ok {
+			return s.MarshalReply(request.ID, nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParams.Code,
+				Message: "limited user not authorized for this method",
+			})
+		}
+	}
+	// Attempt to parse the JSON-RPC request into a known concrete command.
+	parsedCmd := ParseCmd(&request)
+	if parsedCmd.Err != nil {
+		return s.MarshalReply(request.ID, nil, parsedCmd.Err)
+	}
+	result, jsonErr := s.StandardCmdResult(parsedCmd, closeChan)
+	s.Audit(parsedCmd, isAdmin, remoteAddr, jsonErr)
+	return s.MarshalReply(request.ID, result, jsonErr)
+}
+
+// Audit records a command in the audit log if it changes the state of the node, along with the user that ran it, the
+// address it came from and the error it failed with, if any.
+func (s *Server) Audit(cmd *ParsedRPCCmd, isAdmin bool, remoteAddr string, err error) {
+	if _, ok := RPCAudited[cmd.Method]; !ok || s.Cfg.AuditLog == nil {
+		return
+	}
+	user := *s.Config.Username
+	if !isAdmin {
+		user = *s.Config.LimitUser
+	}
+	entry := &btcjson.AuditLogEntry{
+		Time:   time.Now().Unix(),
+		Server: "node",
+		User:   user,
+		Source: remoteAddr,
+		Method: cmd.Method,
+		Params: cmd.Params,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	s.Cfg.AuditLog.Record(entry)
+}
+
+// MarshalReply marshals the reply to a request, returning false if it could not be marshalled.
+func (s *Server) MarshalReply(id, result interface{}, replyErr error) ([]byte, bool) {
+	msg, err := s.CreateReply(id, result, replyErr)
+	if err != nil {
+		Error("failed to marshal reply:", err)
+		return nil, false
+	}
+	return msg, true
+}
+
 // LimitConnections responds with a 503 service unavailable and returns true if adding another client would exceed the
 // maximum allow RPC clients.
 //
@@ -1421,12 +1666,38 @@ func (s *Server) LimitConnections(w http.ResponseWriter, remoteAddr string) bool
 	return false
 }
 
+// AllowedIP returns whether a client connecting from remoteAddr is in one of the networks allowed by rpcallowip, and
+// otherwise writes a forbidden response to it. It is checked before authentication so clients from other networks
+// cannot even attempt to log in. Every client is allowed when rpcallowip is not set.
+func (s *Server) AllowedIP(w http.ResponseWriter, remoteAddr string) bool {
+	if s.StateCfg == nil || s.StateCfg.ActiveRPCAllowIPs == nil {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		for _, ipnet := range s.StateCfg.ActiveRPCAllowIPs {
+			if ipnet.Contains(ip) {
+				return true
+			}
+		}
+	}
+	Warn("refusing RPC connection from", remoteAddr, "which is not in an allowed network")
+	http.Error(w, "403 Forbidden", http.StatusForbidden)
+	return false
+}
+
 // StandardCmdResult checks that a parsed command is a standard Bitcoin JSON-RPC command and runs the appropriate
 // handler to reply to the command.
 //
 // Any commands which are not recognized or not implemented will return an error suitable for use in replies.
 func (s *Server) StandardCmdResult(cmd *ParsedRPCCmd,
 	closeChan <-chan struct{}) (interface{}, error) {
+	if _, ok := RPCMining[cmd.Method]; ok && s.Config != nil && *s.Config.NoMining {
+		return nil, ErrRPCNoMining
+	}
 	handler, ok := RPCHandlers[cmd.Method]
 	if ok {
 		goto handled
@@ -1434,6 +1705,11 @@ func (s *Server) StandardCmdResult(cmd *ParsedRPCCmd,
 	_, ok = RPCAskWallet[cmd.Method]
 	if ok {
 		handler.Fn = HandleAskWallet
+		if s.Cfg.WalletClient != nil {
+			handler.Fn = func(*Server, interface{}, <-chan struct{}) (interface{}, error) {
+				return s.ForwardToWallet(cmd)
+			}
+		}
 		goto handled
 	}
 	_, ok = RPCUnimplemented[cmd.Method]
@@ -1443,7 +1719,27 @@ func (s *Server) StandardCmdResult(cmd *ParsedRPCCmd,
 	}
 	return nil, btcjson.ErrRPCMethodNotFound
 handled:
-	return handler.Fn(s, cmd.Cmd, closeChan)
+	start := time.Now()
+	result, err := handler.Fn(s, cmd.Cmd, closeChan)
+	s.Cfg.RequestDuration.Observe(cmd.Method, time.Since(start).Seconds())
+	return result, err
+}
+
+// ForwardToWallet passes a wallet command through to the wallet RPC server and returns its reply unchanged, so clients
+// only need the RPC port and credentials of the node.
+func (s *Server) ForwardToWallet(cmd *ParsedRPCCmd) (interface{}, error) {
+	res, err := s.Cfg.WalletClient.RawRequest(cmd.Method, cmd.Params)
+	if err != nil {
+		if rpcErr, ok := err.(*btcjson.RPCError); ok {
+			return nil, rpcErr
+		}
+		Error("failed to forward", cmd.Method, "to the wallet:", err)
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCNoWallet,
+			Message: "Wallet RPC is unavailable: " + err.Error(),
+		}
+	}
+	return &res, nil
 }
 
 // WriteHTTPResponseHeaders writes the necessary response headers prior to writing an HTTP body given a request to use
@@ -1578,15 +1874,28 @@ func ChainErrToGBTErrString(err error) string {
 // CreateMarshalledReply returns a new marshalled JSON-RPC response given the passed parameters. It will automatically
 // convert errors that are not of the type *json.RPCError to the appropriate type as needed.
 func CreateMarshalledReply(id, result interface{}, replyErr error) ([]byte, error) {
-	var jsonErr *btcjson.RPCError
-	if replyErr != nil {
-		if jErr, ok := replyErr.(*btcjson.RPCError); ok {
-			jsonErr = jErr
-		} else {
-			jsonErr = InternalRPCError(replyErr.Error(), "")
-		}
+	return btcjson.MarshalResponse(id, result, ReplyError(replyErr))
+}
+
+// CreateReply returns a new marshalled JSON-RPC response like CreateMarshalledReply, with the result encoded as
+// canonical JSON when the server is configured to.
+func (s *Server) CreateReply(id, result interface{}, replyErr error) ([]byte, error) {
+	if s.Config != nil && s.Config.RPCCanonicalJSON != nil && *s.Config.RPCCanonicalJSON {
+		return btcjson.MarshalCanonicalResponse(id, result, ReplyError(replyErr))
+	}
+	return CreateMarshalledReply(id, result, replyErr)
+}
+
+// ReplyError converts an error returned by a handler to the error of a JSON-RPC response, errors that are not of the
+// type *json.RPCError become internal errors.
+func ReplyError(replyErr error) *btcjson.RPCError {
+	if replyErr == nil {
+		return nil
+	}
+	if jErr, ok := replyErr.(*btcjson.RPCError); ok {
+		return jErr
 	}
-	return btcjson.MarshalResponse(id, result, jsonErr)
+	return InternalRPCError(replyErr.Error(), "")
 }
 
 // CreateTxRawResult converts the passed transaction and associated parameters to a raw transaction JSON object.
@@ -1790,28 +2099,35 @@ func CreateVoutList(mtx *wire.MsgTx, chainParams *netparams.Params,
 
 // DecodeTemplateID decodes an ID that is used to uniquely identify a block template. This is mainly used as a mechanism
 // to track when to update clients that are using long polling for block templates. The ID consists of the previous
-// block hash for the associated template and the time the associated template was generated.
-func DecodeTemplateID(templateID string) (*chainhash.Hash, int64, error) {
+// block hash for the associated template, the time the associated template was generated and its sequence number.
+//
+// IDs in the deprecated format without the sequence number, which can not tell apart templates generated within the
+// same second, are still accepted and decode with a zero sequence number.
+func DecodeTemplateID(templateID string) (prevHash *chainhash.Hash, lastGenerated int64, seq uint64, err error) {
 	fields := strings.Split(templateID, "-")
-	if len(fields) != 2 {
-		return nil, 0, errors.New("invalid longpollid format")
+	if len(fields) != 2 && len(fields) != 3 {
+		return nil, 0, 0, errors.New("invalid longpollid format")
 	}
-	prevHash, err := chainhash.NewHashFromStr(fields[0])
-	if err != nil {
+	if prevHash, err = chainhash.NewHashFromStr(fields[0]); err != nil {
 		Error(err)
-		return nil, 0, errors.New("invalid longpollid format")
+		return nil, 0, 0, errors.New("invalid longpollid format")
 	}
-	lastGenerated, err := strconv.ParseInt(fields[1], 10, 64)
-	if err != nil {
+	if lastGenerated, err = strconv.ParseInt(fields[1], 10, 64); err != nil {
 		Error(err)
-		return nil, 0, errors.New("invalid longpollid format")
+		return nil, 0, 0, errors.New("invalid longpollid format")
 	}
-	return prevHash, lastGenerated, nil
+	if len(fields) == 3 {
+		if seq, err = strconv.ParseUint(fields[2], 10, 64); err != nil || seq == 0 {
+			Error(err)
+			return nil, 0, 0, errors.New("invalid longpollid format")
+		}
+	}
+	return prevHash, lastGenerated, seq, nil
 }
 
 // EncodeTemplateID encodes the passed details into an ID that can be used to uniquely identify a block template.
-func EncodeTemplateID(prevHash *chainhash.Hash, lastGenerated time.Time) string {
-	return fmt.Sprintf("%s-%d", prevHash.String(), lastGenerated.Unix())
+func EncodeTemplateID(prevHash *chainhash.Hash, lastGenerated time.Time, seq uint64) string {
+	return fmt.Sprintf("%s-%d-%d", prevHash.String(), lastGenerated.Unix(), seq)
 }
 
 // FetchInputTxos fetches the outpoints from all transactions referenced by the inputs to the passed transaction by
@@ -1893,6 +2209,137 @@ func FetchMempoolTxnsForAddress(s *Server, addr util.Address, numToSkip,
 	return mpTxns[numToSkip:rangeEnd], numToSkip
 }
 
+// DecodeIndexedAddresses decodes the addresses of an address index query. It fails when the address index is not
+// enabled, no address is given or one of them is not valid for the network.
+func DecodeIndexedAddresses(s *Server, addresses []string) ([]util.Address, error) {
+	if s.Cfg.AddrIndex == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCMisc,
+			Message: "Address index must be enabled (--addrindex)",
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "No addresses given",
+		}
+	}
+	addrs := make([]util.Address, 0, len(addresses))
+	for _, address := range addresses {
+		addr, err := util.DecodeAddress(address, s.Cfg.ChainParams)
+		if err != nil {
+			Error(err)
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidAddressOrKey,
+				Message: "Invalid address or key: " + err.Error(),
+			}
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// FetchAddressHistory gathers every transaction involving the passed addresses from the address index along with the
+// outputs of them that pay to the addresses. The confirmed transactions come first in the order they appear in the
+// chain, followed by the transactions in the mempool if includeMempool is set. A transaction involving more than one
+// of the addresses is only returned once.
+func FetchAddressHistory(s *Server, addrs []util.Address, includeMempool bool) ([]AddressTx, []AddressOutput, error) {
+	wanted := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		wanted[addr.EncodeAddress()] = struct{}{}
+	}
+	seen := make(map[chainhash.Hash]struct{})
+	var txns []AddressTx
+	err := s.Cfg.DB.View(func(dbTx database.Tx) error {
+		for _, addr := range addrs {
+			regions, err := s.Cfg.AddrIndex.AllTxRegionsForAddress(dbTx, addr)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			serializedTxns, err := dbTx.FetchBlockRegions(regions)
+			if err != nil {
+				Error(err)
+				return err
+			}
+			for i, serializedTx := range serializedTxns {
+				mtx := new(wire.MsgTx)
+				if err = mtx.Deserialize(bytes.NewReader(serializedTx)); err != nil {
+					Error(err)
+					return err
+				}
+				hash := mtx.TxHash()
+				if _, ok := seen[hash]; ok {
+					continue
+				}
+				seen[hash] = struct{}{}
+				height, err := s.Cfg.Chain.BlockHeightByHash(regions[i].Hash)
+				if err != nil {
+					Error(err)
+					return err
+				}
+				txns = append(txns, AddressTx{
+					Tx:     mtx,
+					Hash:   hash,
+					Height: height,
+					Offset: regions[i].Offset,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, nil, err
+	}
+	sort.SliceStable(txns, func(i, j int) bool {
+		if txns[i].Height != txns[j].Height {
+			return txns[i].Height < txns[j].Height
+		}
+		return txns[i].Offset < txns[j].Offset
+	})
+	if includeMempool {
+		// The mempool has no order of its own, so sort its transactions by hash to keep results stable.
+		var mpTxns []AddressTx
+		for _, addr := range addrs {
+			for _, tx := range s.Cfg.AddrIndex.UnconfirmedTxnsForAddress(addr) {
+				if _, ok := seen[*tx.Hash()]; ok {
+					continue
+				}
+				seen[*tx.Hash()] = struct{}{}
+				mpTxns = append(mpTxns, AddressTx{Tx: tx.MsgTx(), Hash: *tx.Hash(), Mempool: true})
+			}
+		}
+		sort.Slice(mpTxns, func(i, j int) bool {
+			return bytes.Compare(mpTxns[i].Hash[:], mpTxns[j].Hash[:]) < 0
+		})
+		txns = append(txns, mpTxns...)
+	}
+	var outputs []AddressOutput
+	for i := range txns {
+		atx := &txns[i]
+		for index, txOut := range atx.Tx.TxOut {
+			// Nonstandard scripts yield no addresses, so they are skipped here.
+			_, outAddrs, _, _ := txscript.ExtractPkScriptAddrs(txOut.PkScript, s.Cfg.ChainParams)
+			for _, outAddr := range outAddrs {
+				encoded := outAddr.EncodeAddress()
+				if _, ok := wanted[encoded]; !ok {
+					continue
+				}
+				outputs = append(outputs, AddressOutput{
+					Address:  encoded,
+					OutPoint: *wire.NewOutPoint(&atx.Hash, uint32(index)),
+					TxOut:    txOut,
+					Height:   atx.Height,
+					Mempool:  atx.Mempool,
+				})
+				break
+			}
+		}
+	}
+	return txns, outputs, nil
+}
+
 // GenCertPair generates a key/cert pair to the paths provided.
 func GenCertPair(certFile, keyFile string) error {
 	Info("generating TLS certificates...")
@@ -1987,12 +2434,51 @@ func MessageToHex(msg wire.Message) (string, error) {
 func NewGbtWorkState(timeSource blockchain.MedianTimeSource,
 	algoName string) *GBTWorkState {
 	return &GBTWorkState{
-		NotifyMap:  make(map[chainhash.Hash]map[int64]chan struct{}),
+		LongPoll:   NewLongPollWaiters(MaxLongPollClients),
+		Issued:     NewIssuedTemplates(MaxIssuedTemplates),
 		TimeSource: timeSource,
 		Algo:       algoName,
 	}
 }
 
+// WorkState returns the work state of the block templates of an algorithm, or of the default algorithm of the server
+// when none is given, so that a single RPC port can serve work for every algorithm. The work state of an algorithm
+// other than the default is created when it is first requested. An error suitable for the reply is returned if the
+// algorithm is not one of the current hard fork.
+func (s *Server) WorkState(algo string) (*GBTWorkState, error) {
+	if algo == "" || algo == s.GBTWorkState.Algo {
+		return s.GBTWorkState, nil
+	}
+	height := s.Cfg.Chain.BestSnapshot().Height
+	if _, ok := fork.List[fork.GetCurrent(height)].Algos[algo]; !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Unknown algorithm " + algo,
+		}
+	}
+	s.AlgoWorkStatesLock.Lock()
+	defer s.AlgoWorkStatesLock.Unlock()
+	state, ok := s.AlgoWorkStates[algo]
+	if !ok {
+		state = NewGbtWorkState(s.Cfg.TimeSource, algo)
+		s.AlgoWorkStates[algo] = state
+	}
+	return state, nil
+}
+
+// WorkStates returns the work state of the default algorithm followed by those of the other algorithms work has been
+// requested for
+func (s *Server) WorkStates() []*GBTWorkState {
+	s.AlgoWorkStatesLock.Lock()
+	defer s.AlgoWorkStatesLock.Unlock()
+	states := make([]*GBTWorkState, 0, len(s.AlgoWorkStates)+1)
+	states = append(states, s.GBTWorkState)
+	for _, state := range s.AlgoWorkStates {
+		states = append(states, state)
+	}
+	return states
+}
+
 // NewRPCServer returns a new instance of the RPCServer struct.
 func NewRPCServer(config *ServerConfig, statecfg *state.Config,
 	podcfg *pod.Config) (*Server, error) {
@@ -2002,7 +2488,10 @@ func NewRPCServer(config *ServerConfig, statecfg *state.Config,
 		StateCfg:               statecfg,
 		StatusLines:            make(map[int]string),
 		GBTWorkState:           NewGbtWorkState(config.TimeSource, config.Algo),
+		AlgoWorkStates:         make(map[string]*GBTWorkState),
 		HelpCacher:             NewHelpCacher(),
+		Snapshots:              NewSnapshots(),
+		Proposals:              NewProposalCache(MaxCachedProposals),
 		RequestProcessShutdown: make(chan struct{}),
 		Quit:                   config.Quit,
 	}
@@ -2028,6 +2517,7 @@ func ParseCmd(request *btcjson.Request) *ParsedRPCCmd {
 	var parsedCmd ParsedRPCCmd
 	parsedCmd.ID = request.ID
 	parsedCmd.Method = request.Method
+	parsedCmd.Params = request.Params
 	cmd, err := btcjson.UnmarshalCmd(request)
 	if err != nil {
 		Error(err)
@@ -0,0 +1,252 @@
+package chainrpc
+
+import (
+	js "encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/cmd/node/state"
+	"github.com/p9c/pod/pkg/pod"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// TestProcessBatch ensures that each request of a batch is answered in order, that notifications are not answered and
+// that malformed requests are answered with an error without failing the rest of the batch.
+func TestProcessBatch(t *testing.T) {
+	config, _ := pod.EmptyConfig()
+	s := &Server{Config: config, Cfg: ServerConfig{StartupTime: time.Now().Unix() - 10}}
+	body := []byte(`[
+		{"jsonrpc":"1.0","method":"uptime","params":[],"id":1},
+		{"jsonrpc":"2.0","method":"uptime","params":[]},
+		{"jsonrpc":"1.0","method":"boguscommand","params":[],"id":"two"},
+		"not a request",
+		{"jsonrpc":"1.0","method":"uptime","params":[],"id":3}
+	]`)
+	msg, ok := s.ProcessBatch(body, true, "", nil)
+	if !ok {
+		t.Fatal("no reply to the batch")
+	}
+	var replies []btcjson.Response
+	if err := js.Unmarshal(msg, &replies); err != nil {
+		t.Fatalf("reply %s is not an array of responses: %v", msg, err)
+	}
+	if len(replies) != 4 {
+		t.Fatalf("got %d replies, want 4: %s", len(replies), msg)
+	}
+	ids := []string{"1", "two", "null", "3"}
+	for i := range replies {
+		id := "null"
+		if replies[i].ID != nil {
+			id = fmt.Sprint(*replies[i].ID)
+		}
+		if id != ids[i] {
+			t.Errorf("reply #%d has id %s, want %s", i, id, ids[i])
+		}
+		if failed := replies[i].Error != nil; failed != (i == 1 || i == 2) {
+			t.Errorf("reply #%d has error %v", i, replies[i].Error)
+		}
+	}
+	if _, ok = s.ProcessBatch([]byte(`[{"jsonrpc":"2.0","method":"uptime","params":[]}]`), true, "", nil); ok {
+		t.Error("replied to a batch of notifications")
+	}
+	if msg, ok = s.ProcessBatch([]byte(`[]`), true, "", nil); !ok {
+		t.Error("no reply to an empty batch")
+	}
+	var reply btcjson.Response
+	if err := js.Unmarshal(msg, &reply); err != nil || reply.Error == nil ||
+		reply.Error.Code != btcjson.ErrRPCInvalidRequest.Code {
+		t.Errorf("got reply %s to an empty batch, want an invalid request error", msg)
+	}
+	// A limited user is refused methods it is not authorized for, for each request of a batch.
+	msg, _ = s.ProcessBatch([]byte(`[{"jsonrpc":"1.0","method":"stop","params":[],"id":1}]`), false, "", nil)
+	if err := js.Unmarshal(msg, &replies); err != nil || len(replies) != 1 || replies[0].Error == nil {
+		t.Errorf("got reply %s to a limited user, want an error", msg)
+	}
+}
+
+// TestAllowedIP ensures that clients are only refused when rpcallowip is set and they are not in any allowed network,
+// and that an allowlist left empty by invalid entries refuses everyone.
+func TestAllowedIP(t *testing.T) {
+	_, lan, _ := net.ParseCIDR("192.168.1.0/24")
+	_, local, _ := net.ParseCIDR("::1/128")
+	tests := []struct {
+		name    string
+		allowed []*net.IPNet
+		addr    string
+		want    bool
+	}{
+		{"no allowlist", nil, "10.0.0.1:1234", true},
+		{"in network", []*net.IPNet{lan, local}, "192.168.1.20:1234", true},
+		{"ipv6 in network", []*net.IPNet{lan, local}, "[::1]:1234", true},
+		{"outside networks", []*net.IPNet{lan, local}, "192.168.2.20:1234", false},
+		{"unparseable address", []*net.IPNet{lan}, "somewhere:1234", false},
+		{"empty allowlist", []*net.IPNet{}, "192.168.1.20:1234", false},
+	}
+	for _, test := range tests {
+		s := &Server{StateCfg: &state.Config{ActiveRPCAllowIPs: test.allowed}}
+		w := httptest.NewRecorder()
+		if got := s.AllowedIP(w, test.addr); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+		if !test.want && w.Code != http.StatusForbidden {
+			t.Errorf("%s: refused client got status %d, want %d", test.name, w.Code, http.StatusForbidden)
+		}
+	}
+}
+
+// TestInterfaceListeners ensures a listener given by the name of a network interface is bound to the addresses of the
+// interface.
+func TestInterfaceListeners(t *testing.T) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Skip(err)
+	}
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil || len(addrs) == 0 {
+			continue
+		}
+		netAddrs, err := ParseListeners([]string{net.JoinHostPort(iface.Name, "11048")})
+		if err != nil {
+			t.Fatalf("listener on interface %s: %v", iface.Name, err)
+		}
+		if len(netAddrs) == 0 {
+			t.Fatalf("no addresses to listen on for interface %s", iface.Name)
+		}
+		for _, addr := range netAddrs {
+			if _, port, err := net.SplitHostPort(addr.String()); err != nil || port != "11048" {
+				t.Errorf("listener address %s of interface %s does not keep the port", addr, iface.Name)
+			}
+		}
+		if _, err = ParseListeners([]string{"no-such-interface:11048"}); err == nil {
+			t.Error("listener on an unknown interface was not rejected")
+		}
+		return
+	}
+	t.Skip("no network interface with an address")
+}
+
+// TestCreateReply ensures results are only encoded as canonical JSON when the server is configured to.
+func TestCreateReply(t *testing.T) {
+	config, _ := pod.EmptyConfig()
+	s := &Server{Config: config}
+	result := map[string]float64{"b": 1, "a": 0.5}
+	for _, canonical := range []bool{false, true} {
+		config.RPCCanonicalJSON = &canonical
+		msg, err := s.CreateReply(1, result, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"result":{"a":0.5,"b":1},"error":null,"id":1}`
+		if canonical {
+			want = `{"result":{"a":"0.50000000","b":"1.00000000"},"error":null,"id":1}`
+		}
+		if string(msg) != want {
+			t.Errorf("canonical %v: got %s, want %s", canonical, msg, want)
+		}
+	}
+}
+
+// TestOnionTarget ensures the tor hidden service forwards to an IPv4 listener on the loopback interface when the
+// listener is on all interfaces.
+func TestOnionTarget(t *testing.T) {
+	listener, err := net.Listen("tcp4", "0.0.0.0:0")
+	if err != nil {
+		t.Skip("can't listen on IPv4:", err)
+	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
+	if target := OnionTarget([]net.Listener{listener}); target != fmt.Sprintf("127.0.0.1:%d", port) {
+		t.Errorf("got target %s for listener %s", target, listener.Addr())
+	}
+}
+
+// TestForwardToWallet ensures that wallet commands are answered with ErrRPCNoWallet without rpcwalletproxy, and are
+// otherwise passed through to the wallet with their parameters, returning its result or error unchanged.
+func TestForwardToWallet(t *testing.T) {
+	wallet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req btcjson.Request
+		if err := js.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("wallet got a malformed request: %v", err)
+			return
+		}
+		switch {
+		case req.Method == "getbalance" && len(req.Params) == 1 && string(req.Params[0]) == `"*"`:
+			fmt.Fprintf(w, `{"result":1.5,"error":null,"id":%v}`, req.ID)
+		default:
+			fmt.Fprintf(w, `{"result":null,"error":{"code":-4,"message":"wallet error"},"id":%v}`, req.ID)
+		}
+	}))
+	defer wallet.Close()
+	config, _ := pod.EmptyConfig()
+	s := &Server{Config: config}
+	parse := func(method, params string) *ParsedRPCCmd {
+		var raw []js.RawMessage
+		if err := js.Unmarshal([]byte(params), &raw); err != nil {
+			t.Fatal(err)
+		}
+		cmd := ParseCmd(&btcjson.Request{Jsonrpc: "1.0", Method: method, Params: raw, ID: 1})
+		if cmd.Err != nil {
+			t.Fatalf("failed to parse %s: %v", method, cmd.Err)
+		}
+		return cmd
+	}
+	if _, err := s.StandardCmdResult(parse("getbalance", `["*"]`), nil); err != ErrRPCNoWallet {
+		t.Fatalf("got error %v without a wallet proxy, want ErrRPCNoWallet", err)
+	}
+	*config.RPCWalletProxy = wallet.Listener.Addr().String()
+	*config.TLS = false
+	var err error
+	if s.Cfg.WalletClient, err = NewWalletClient(config); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Cfg.WalletClient.Shutdown()
+	res, err := s.StandardCmdResult(parse("getbalance", `["*"]`), nil)
+	if err != nil {
+		t.Fatalf("failed to forward getbalance: %v", err)
+	}
+	if raw, ok := res.(*js.RawMessage); !ok || string(*raw) != "1.5" {
+		t.Errorf("got result %v, want the raw result of the wallet", res)
+	}
+	_, err = s.StandardCmdResult(parse("getnewaddress", `[]`), nil)
+	if rpcErr, ok := err.(*btcjson.RPCError); !ok || rpcErr.Code != -4 || rpcErr.Message != "wallet error" {
+		t.Errorf("got error %v, want the error of the wallet", err)
+	}
+}
+
+// TestApplyRequestTarget ensures that a template is given the target requested when it is harder than the target of
+// the block, and that invalid targets are refused.
+func TestApplyRequestTarget(t *testing.T) {
+	blockTarget := fmt.Sprintf("%064x", 0xffff)
+	tests := []struct {
+		target string
+		want   string
+		fails  bool
+	}{
+		{"", blockTarget, false},
+		{"ff", fmt.Sprintf("%064x", 0xff), false},
+		{"ffffff", blockTarget, false},
+		{"zz", "", true},
+		{"0", "", true},
+	}
+	for _, test := range tests {
+		result, err := ApplyRequestTarget(&btcjson.GetBlockTemplateResult{Target: blockTarget}, test.target)
+		if test.fails {
+			if err == nil {
+				t.Errorf("target %q was accepted", test.target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("target %q failed: %v", test.target, err)
+			continue
+		}
+		if got := result.(*btcjson.GetBlockTemplateResult).Target; got != test.want {
+			t.Errorf("target %q gave %s, want %s", test.target, got, test.want)
+		}
+	}
+}
@@ -36,6 +36,41 @@ var HelpDescsEnUS = map[string]string{
 	"node-target": "Either the IP address and port of the peer to" +
 		" operate on, or a valid peer ID.",
 	"node-connectsubcmd": "'perm' to make the connected peer a permanent one, 'temp' to try a single connect to a peer",
+	// CalculateSigHashCmd help.
+	"calculatesighash--synopsis": "Returns the signature hash the script engine computes for an input of a transaction.\n" +
+		"The valid hashtype options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.",
+	"calculatesighash-rawtx":    "Hex-encoded serialized transaction",
+	"calculatesighash-index":    "The index of the input being signed",
+	"calculatesighash-script":   "Hex-encoded script being signed, the previous output script or the redeem or witness script",
+	"calculatesighash-amount":   "The amount in DUO of the output being spent, only used for witness sighashes",
+	"calculatesighash-hashtype": "The sighash type",
+	"calculatesighash-witness":  "Calculate the BIP0143 witness sighash instead of the legacy one",
+	// CalculateSigHashResult help.
+	"calculatesighashresult-sighash":  "Hex-encoded signature hash",
+	"calculatesighashresult-hashtype": "The numeric sighash type appended to signatures",
+	"calculatesighashresult-witness":  "Whether the sighash is a witness sighash",
+	// EvaluateScriptCmd help.
+	"evaluatescript--synopsis": "Runs a public key script through the script engine one opcode at a time and returns the stack after every opcode, identifying the opcode execution failed at.\n" +
+		"The signature script is taken from scriptsig, or from the input of rawtx being spent. Without a transaction the scripts are run in an otherwise empty transaction, so signature checks fail.",
+	"evaluatescript-scriptpubkey": "Hex-encoded public key script to evaluate",
+	"evaluatescript-scriptsig":    "Hex-encoded signature script, overriding the one of the transaction input when rawtx is given",
+	"evaluatescript-rawtx":        "Hex-encoded serialized transaction spending the public key script",
+	"evaluatescript-index":        "The index of the input of rawtx spending the public key script",
+	"evaluatescript-amount":       "The amount in DUO of the output being spent, used by witness programs",
+	// EvaluateScriptResult help.
+	"evaluatescriptresult-valid":        "Whether the scripts executed successfully",
+	"evaluatescriptresult-error":        "The error execution failed with",
+	"evaluatescriptresult-failedopcode": "The opcode execution failed at, if an opcode failed",
+	"evaluatescriptresult-steps":        "The executed opcodes",
+	// EvaluateScriptStep help.
+	"evaluatescriptstep-script":   "The script the opcode is in: 0 for the signature script, 1 for the public key script, and 2 or more for redeem and witness scripts",
+	"evaluatescriptstep-offset":   "The index of the opcode in its script",
+	"evaluatescriptstep-opcode":   "The disassembled opcode",
+	"evaluatescriptstep-stack":    "The hex-encoded data stack after the opcode, from the bottom to the top",
+	"evaluatescriptstep-altstack": "The hex-encoded alternate stack after the opcode, from the bottom to the top",
+	"evaluatescriptstep-failed":   "Whether executing the opcode failed",
+	// ClearBannedCmd help.
+	"clearbanned--synopsis": "Removes all the bans of the node.",
 	// TransactionInput help.
 	"transactioninput-txid": "The hash of the input transaction",
 	"transactioninput-vout": "The specific output of the input transaction to redeem",
@@ -111,6 +146,25 @@ var HelpDescsEnUS = map[string]string{
 	"decodescript--synopsis": "Returns a JSON object with information about" +
 		" the provided hex-encoded script.",
 	"decodescript-hexscript": "Hex-encoded script",
+	// DumpAddrManCmd help.
+	"dumpaddrman--synopsis": "Writes the known peer addresses and the buckets they are in to a file in the format of the peers file, so that other nodes can import them with loadaddrman.",
+	"dumpaddrman-filename":  "The file to write, relative to the data directory unless absolute",
+
+	// DumpAddrManResult help.
+	"dumpaddrmanresult-filename":  "The full name of the file written",
+	"dumpaddrmanresult-addresses": "The number of addresses written",
+
+	// DumpUTXOSetCmd help.
+	"dumputxoset--synopsis": "Writes a snapshot of the UTXO set at the best block to a file, which new nodes can be bootstrapped from with loadutxoset once its hash is added to the network parameters.",
+	"dumputxoset-filename":  "The file to write, relative to the data directory unless absolute",
+
+	// UTXOSetSnapshotResult help.
+	"utxosetsnapshotresult-filename": "The full name of the snapshot file",
+	"utxosetsnapshotresult-hash":     "The hash of the block the snapshot was taken at",
+	"utxosetsnapshotresult-height":   "The height of the block the snapshot was taken at",
+	"utxosetsnapshotresult-utxohash": "The hash of the unspent outputs of the snapshot",
+	"utxosetsnapshotresult-coins":    "The number of unspent outputs in the snapshot",
+
 	// EstimateFeeCmd help.
 	"estimatefee--synopsis": "Estimate the fee per kilobyte in satoshis " +
 		"required for a transaction to be mined before a certain number of " +
@@ -119,12 +173,40 @@ var HelpDescsEnUS = map[string]string{
 		"generated before the transaction is mined.",
 	"estimatefee--result0": "Estimated fee per kilobyte in satoshis for a block to " +
 		"be mined in the next NumBlocks blocks.",
+	// EstimateSmartFeeCmd help.
+	"estimatesmartfee--synopsis": "Estimate the fee per kilobyte in DUO for a transaction to be confirmed within a " +
+		"number of blocks, from how fast transactions paying each fee rate have been confirmed.",
+	"estimatesmartfee-conftarget": "The number of blocks the transaction should be confirmed within (1-25)",
+	"estimatesmartfee-estimatemode": "The estimation mode, ECONOMICAL uses recent blocks only and CONSERVATIVE " +
+		"needs more of the transactions to have confirmed in time (UNSET uses the configured mode)",
+	// EstimateSmartFeeResult help.
+	"estimatesmartfeeresult-feerate": "The estimated fee rate in DUO/kB, missing when no estimate could be made",
+	"estimatesmartfeeresult-errors":  "The reasons no estimate could be made",
+	"estimatesmartfeeresult-blocks": "The number of blocks the estimate is for, which is more than the target when " +
+		"there was not enough data for it",
 	// GenerateCmd help
 	"generate--synopsis": "Generates a set number of blocks (simnet or" +
 		" regtest only) and returns a JSON\n" +
 		" array of their hashes.",
 	"generate-numblocks": "Number of blocks to generate",
 	"generate--result0":  "The hashes, in order, of blocks generated by the call",
+	// GenerateToAddressCmd help
+	"generatetoaddress--synopsis": "Mines a number of blocks paying to an address (regtest or simnet only) and " +
+		"returns a JSON array of their hashes.",
+	"generatetoaddress-numblocks": "Number of blocks to generate",
+	"generatetoaddress-address":   "The address the coinbase of the blocks pays to",
+	"generatetoaddress-maxtries":  "The number of nonces to try over all the blocks before giving up",
+	"generatetoaddress-algo":      "The proof of work algorithm of the blocks (default: the algorithm of the RPC endpoint)",
+	"generatetoaddress--result0":  "The hashes, in order, of blocks generated by the call",
+	// GenerateBlockCmd help
+	"generateblock--synopsis": "Mines a block with exactly the transactions listed, paying to an address " +
+		"(regtest or simnet only).",
+	"generateblock-address": "The address the coinbase of the block pays to",
+	"generateblock-transactions": "The transactions of the block in order, as IDs of mempool transactions or " +
+		"serialized, hex-encoded transactions",
+	"generateblock-algo": "The proof of work algorithm of the block (default: the algorithm of the RPC endpoint)",
+	// GenerateBlockResult help
+	"generateblockresult-hash": "The hash of the block generated",
 	// GetAddedNodeInfoResultAddr help.
 	"getaddednodeinforesultaddr-address":   "The ip address for this DNS entry",
 	"getaddednodeinforesultaddr-connected": "The connection 'direction' (inbound/outbound/false)",
@@ -144,6 +226,61 @@ var HelpDescsEnUS = map[string]string{
 	// GetBestBlockResult help.
 	"getbestblockresult-hash":   "Hex-encoded bytes of the best block hash",
 	"getbestblockresult-height": "Height of the best block",
+	// GetAddressBalanceCmd help.
+	"getaddressbalance--synopsis": "Returns the balance of a set of addresses computed from the address index, which must be enabled (--addrindex).",
+	"getaddressbalance-addresses": "The addresses to sum the balance of",
+
+	// GetAddressBalanceResult help.
+	"getaddressbalanceresult-balance":     "The value in satoshi of the unspent outputs paying the addresses in the chain",
+	"getaddressbalanceresult-received":    "The value in satoshi of all outputs paying the addresses in the chain, spent or not",
+	"getaddressbalanceresult-unconfirmed": "The value in satoshi the transactions in the mempool add to or, if negative, take from the balance",
+
+	// GetAddressTxIDsCmd help.
+	"getaddresstxids--synopsis":      "Returns the IDs of the transactions involving a set of addresses from the address index, which must be enabled (--addrindex), in the order they were confirmed.",
+	"getaddresstxids-addresses":      "The addresses to list the transactions of",
+	"getaddresstxids-includemempool": "Also list the transactions in the mempool, after the confirmed ones",
+	"getaddresstxids--result0":       "The IDs of the transactions",
+
+	// GetAddressUtxosCmd help.
+	"getaddressutxos--synopsis":      "Returns the unspent outputs paying a set of addresses from the address index, which must be enabled (--addrindex).",
+	"getaddressutxos-addresses":      "The addresses to list the unspent outputs of",
+	"getaddressutxos-includemempool": "Also list the outputs created in the mempool and leave out those spent there",
+
+	// GetAddressUtxosResult help.
+	"getaddressutxosresult-address":       "The address the output pays",
+	"getaddressutxosresult-txid":          "The hash of the transaction of the output",
+	"getaddressutxosresult-outputIndex":   "The index of the output in the transaction",
+	"getaddressutxosresult-script":        "The hex-encoded public key script of the output",
+	"getaddressutxosresult-satoshis":      "The value of the output in satoshi",
+	"getaddressutxosresult-height":        "The height of the block containing the output, 0 when it is in the mempool",
+	"getaddressutxosresult-confirmations": "The number of confirmations of the output, 0 when it is in the mempool",
+
+	// GetAddrManInfoCmd help.
+	"getaddrmaninfo--synopsis": "Returns the number of new and tried addresses known to the address manager and how full each of its buckets is.",
+
+	// GetAddrManInfoResult help.
+	"getaddrmaninforesult-new":             "The number of addresses that have not been connected to successfully",
+	"getaddrmaninforesult-tried":           "The number of addresses that have been connected to successfully",
+	"getaddrmaninforesult-total":           "The total number of addresses",
+	"getaddrmaninforesult-newbucketsize":   "The number of addresses a new bucket can hold",
+	"getaddrmaninforesult-triedbucketsize": "The number of addresses a tried bucket can hold",
+	"getaddrmaninforesult-newbuckets":      "The number of addresses in each new bucket, an address can be in several of them",
+	"getaddrmaninforesult-triedbuckets":    "The number of addresses in each tried bucket",
+
+	// GetAuditLogCmd help.
+	"getauditlog--synopsis": "Returns the most recent state-changing commands recorded in the audit log, oldest first.",
+	"getauditlog-count":     "The number of entries to return",
+	"getauditlog-skip":      "The number of most recent entries to leave out",
+
+	// AuditLogEntry help.
+	"auditlogentry-time":   "The time the command was run in seconds since 1 Jan 1970 GMT",
+	"auditlogentry-server": "The server that ran the command, node or wallet",
+	"auditlogentry-user":   "The authenticated user that ran the command",
+	"auditlogentry-source": "The address the command came from",
+	"auditlogentry-method": "The command",
+	"auditlogentry-params": "The parameters of the command",
+	"auditlogentry-error":  "The error the command failed with, if any",
+
 	// GetBestBlockCmd help.
 	"getbestblock--synopsis": "Get block height and hash of best block in" +
 		" the main chain.",
@@ -218,6 +355,20 @@ var HelpDescsEnUS = map[string]string{
 	"txrawresult-size":          "The size of the transaction in bytes",
 	"txrawresult-vsize":         "The virtual size of the transaction in bytes",
 	"txrawresult-hash":          "The wtxid of the transaction",
+	// ScanTxOutSetResult help.
+	"scantxoutsetresult-success":      "Whether the scan was completed",
+	"scantxoutsetresult-txouts":       "The number of unspent outputs scanned",
+	"scantxoutsetresult-height":       "The height of the best block the UTXO set was scanned at",
+	"scantxoutsetresult-bestblock":    "The hash of the best block the UTXO set was scanned at",
+	"scantxoutsetresult-unspents":     "The unspent outputs found",
+	"scantxoutsetresult-total_amount": "The total amount of the unspent outputs found in DUO",
+	// ScanTxOutSetUnspent help.
+	"scantxoutsetunspent-txid":         "The hash of the transaction of the output",
+	"scantxoutsetunspent-vout":         "The index of the output in the transaction",
+	"scantxoutsetunspent-scriptPubKey": "The hex-encoded public key script of the output",
+	"scantxoutsetunspent-desc":         "The scan object the output was found for",
+	"scantxoutsetunspent-amount":       "The amount of the output in DUO",
+	"scantxoutsetunspent-height":       "The height of the block the output was created in",
 	// SearchRawTransactionsResult help.
 	"searchrawtransactionsresult-hex":           "Hex-encoded transaction",
 	"searchrawtransactionsresult-txid":          "The hash of the transaction",
@@ -253,10 +404,12 @@ var HelpDescsEnUS = map[string]string{
 	"getblockverboseresult-weight":            "The weight of the block",
 	// GetBlockCountCmd help.
 	"getblockcount--synopsis": "Returns the number of blocks in the longest block chain.",
+	"getblockcount-snapshot":  "A snapshot token returned by getsnapshot to return the block count of the chain as of the snapshot",
 	"getblockcount--result0":  "The current block count",
 	// GetBlockHashCmd help.
 	"getblockhash--synopsis": "Returns hash of the block in best block chain at the given height.",
 	"getblockhash-index":     "The block height",
+	"getblockhash-snapshot":  "A snapshot token returned by getsnapshot to look up the block in the chain as of the snapshot",
 	"getblockhash--result0":  "The block hash",
 	// GetBlockHeaderCmd help.
 	"getblockheader--synopsis":   "Returns information about a block header given its hash.",
@@ -279,6 +432,20 @@ var HelpDescsEnUS = map[string]string{
 		" difficulty as a multiple of the minimum difficulty",
 	"getblockheaderverboseresult-previousblockhash": "The hash of the previous block",
 	"getblockheaderverboseresult-nextblockhash":     "The hash of the next block (only if there is one)",
+	// GetBlockSubsidyCmd help.
+	"getblocksubsidy--synopsis": "Returns the subsidy paid by the coinbase of a block at a height mined with an algorithm, excluding fees.",
+	"getblocksubsidy-height":    "The height of the block (default: the next block)",
+	"getblocksubsidy-algo":      "The algorithm the block is mined with (default: the algorithm of the RPC endpoint)",
+	// GetBlockSubsidyResult help.
+	"getblocksubsidyresult-height":          "The height of the block",
+	"getblocksubsidyresult-algo":            "The algorithm the subsidy is calculated for",
+	"getblocksubsidyresult-fork":            "The hard fork active at the height",
+	"getblocksubsidyresult-subsidy":         "The total subsidy paid by the coinbase in DUO",
+	"getblocksubsidyresult-miner":           "The subsidy paid to the miner in DUO",
+	"getblocksubsidyresult-payees":          "The total paid to the hard fork disbursement payees in DUO (hard fork activation block only)",
+	"getblocksubsidyresult-core":            "The amount paid to the development team in DUO (hard fork activation block only)",
+	"getblocksubsidyresult-halvinginterval": "The number of blocks between subsidy halvings (before the hard fork only)",
+	"getblocksubsidyresult-nexthalving":     "The height of the next subsidy halving (only if it is before the hard fork)",
 	// TemplateRequest help.
 	"templaterequest-mode":         "This is 'template', 'proposal', or omitted",
 	"templaterequest-capabilities": "List of capabilities",
@@ -290,6 +457,7 @@ var HelpDescsEnUS = map[string]string{
 	"templaterequest-target":     "The desired target for the block template (this parameter is ignored)",
 	"templaterequest-data":       "Hex-encoded block data (only for mode=proposal)",
 	"templaterequest-workid":     "The server provided workid if provided in block template (not applicable)",
+	"templaterequest-algo":       "The proof of work algorithm of the block template (pod extension, default: the algorithm the server is configured with)",
 	// GetBlockTemplateResultTx help.
 	"getblocktemplateresulttx-data": "Hex-encoded transaction data (byte-for-byte)",
 	"getblocktemplateresulttx-hash": "Hex-encoded transaction hash (little endian if treated as a 256-bit number)",
@@ -341,6 +509,12 @@ var HelpDescsEnUS = map[string]string{
 		" including 'proposal' to indicate support for block proposals",
 	"getblocktemplateresult-reject-reason": "Reason the proposal was invalid" +
 		" as-is (only applies to proposal responses)",
+	"getblocktemplateresult-payoutpolicy": "How the node picks the address" +
+		" block rewards are paid to: random, roundrobin, peralgo or fresh",
+	"getblocktemplateresult-payoutaddress": "The address the coinbase" +
+		" transaction pays to (only with coinbasetxn)",
+	"getblocktemplateresult-pow_algo":    "The proof of work algorithm of the block template",
+	"getblocktemplateresult-pow_algo_id": "The ID of the proof of work algorithm of the block template",
 	"getblocktemplateresult-default_witness_commitment": "The witness" +
 		" commitment itself. Will be populated if the block has witness data",
 	"getblocktemplateresult-weightlimit": "The current limit on the max" +
@@ -356,6 +530,14 @@ var HelpDescsEnUS = map[string]string{
 	"getblocktemplate--condition2": "mode=proposal, accepted",
 	"getblocktemplate--result1":    "An error string which represents why the proposal was rejected or nothing if accepted",
 
+	// GetBlockFilterCmd help.
+	"getblockfilter--synopsis":  "Returns a block's BIP0158 compact filter and filter header given its hash.",
+	"getblockfilter-blockhash":  "The hash of the block",
+	"getblockfilter-filtertype": "The name of the filter type",
+	// GetBlockFilterResult help.
+	"getblockfilterresult-filter": "The hex-encoded filter",
+	"getblockfilterresult-header": "The filter header, in the same byte order as block hashes",
+
 	// GetCFilterCmd help.
 	"getcfilter--synopsis":  "Returns a block's committed filter given its hash.",
 	"getcfilter-filtertype": "The type of filter to return (0=regular)",
@@ -385,7 +567,7 @@ var HelpDescsEnUS = map[string]string{
 	"getgenerate--result0":  "True if mining, false if not",
 
 	// GetHashesPerSecCmd help.
-	"gethashespersec--synopsis": "Returns a recent hashes per second performance measurement while generating coins (mining).",
+	"gethashespersec--synopsis": "Returns the recent hashes per second of the kopach workers taking work from this node.",
 	"gethashespersec--result0":  "The number of hashes per second",
 
 	// InfoChainResult help.
@@ -399,6 +581,7 @@ var HelpDescsEnUS = map[string]string{
 	"infochainresult-testnet":         "Whether or not server is using testnet",
 	"infochainresult-relayfee":        "The minimum relay fee for non-free transactions in BTC/KB",
 	"infochainresult-errors":          "Any current errors",
+	"infochainresult-announcements":   "The text of the active network announcements, from the highest priority",
 
 	// InfoWalletResult help.
 	"infowalletresult-version":         "The version of the server",
@@ -434,6 +617,32 @@ var HelpDescsEnUS = map[string]string{
 	"getmempoolinforesult-bytes": "Size in bytes of the mempool",
 	"getmempoolinforesult-size":  "Number of transactions in the mempool",
 
+	// GetMinerStatsCmd help.
+	"getminerstats--synopsis": "Returns a JSON object with the hashrate, solution and rejected block statistics of the kopach workers taking work from this node.",
+
+	// GetMinerStatsResult help.
+	"getminerstatsresult-hashrate":  "The total hashrate of the workers in hashes per second",
+	"getminerstatsresult-hashes":    "The total number of hashes done since the node started",
+	"getminerstatsresult-solutions": "The number of blocks found by the workers that were accepted",
+	"getminerstatsresult-rejected":  "The number of blocks found by the workers that were stale or rejected",
+	"getminerstatsresult-uptime":    "The number of seconds since the statistics started",
+	"getminerstatsresult-algos":     "The hashrate and hashes of all the workers by algorithm",
+	"getminerstatsresult-workers":   "The statistics of each worker that reported in the last minute",
+
+	// MinerWorkerStatsResult help.
+	"minerworkerstatsresult-id":        "The address the worker reports from",
+	"minerworkerstatsresult-hashrate":  "The hashrate of the worker in hashes per second",
+	"minerworkerstatsresult-hashes":    "The number of hashes done by the worker",
+	"minerworkerstatsresult-solutions": "The number of blocks found by the worker that were accepted",
+	"minerworkerstatsresult-rejected":  "The number of blocks found by the worker that were stale or rejected",
+	"minerworkerstatsresult-lastseen":  "The time of the latest report from the worker in seconds since 1 Jan 1970 GMT",
+	"minerworkerstatsresult-algos":     "The hashrate and hashes of the worker by algorithm",
+
+	// MinerAlgoStatsResult help.
+	"mineralgostatsresult-algo":     "The name of the algorithm",
+	"mineralgostatsresult-hashrate": "The hashrate on the algorithm in hashes per second",
+	"mineralgostatsresult-hashes":   "The number of hashes done on the algorithm",
+
 	// GetMiningInfoResult help.
 	"getmininginforesult-blocks":             "Height of the latest best block",
 	"getmininginforesult-currentblocksize":   "Size of the latest best block",
@@ -444,6 +653,8 @@ var HelpDescsEnUS = map[string]string{
 	"getmininginforesult-generate":           "Whether or not server is set to generate coins",
 	"getmininginforesult-genproclimit":       "Number of processors to use for coin generation (-1 when disabled)",
 	"getmininginforesult-hashespersec":       "Recent hashes per second performance measurement while generating coins",
+	"getmininginforesult-longpollclients":    "Number of getblocktemplate long poll requests waiting for a new block template",
+	"getmininginforesult-longpolltemplates":  "Number of distinct block templates the waiting long poll requests were issued for",
 	"getmininginforesult-networkhashps":      "Estimated network hashes per second for the most recent blocks",
 	"getmininginforesult-pooledtx":           "Number of transactions in the memory pool",
 	"getmininginforesult-testnet":            "Whether or not server is using testnet",
@@ -457,6 +668,37 @@ var HelpDescsEnUS = map[string]string{
 	"getnetworkhashps-height":    "Perform estimate ending with this height or -1 for current best chain block height",
 	"getnetworkhashps--result0":  "Estimated hashes per second",
 
+	// GetNetworkInfoCmd help.
+	"getnetworkinfo--synopsis": "Returns a JSON object containing the state of the peer to peer networking of the node.",
+
+	// GetNetworkInfoResult help.
+	"getnetworkinforesult-version":         "The version of the node as a numeric value",
+	"getnetworkinforesult-subversion":      "The user agent the node advertises to peers",
+	"getnetworkinforesult-protocolversion": "The latest supported protocol version",
+	"getnetworkinforesult-localservices":   "The services the node advertises to peers, as hex encoded flags",
+	"getnetworkinforesult-localrelay":      "Whether transactions are relayed to and requested from peers",
+	"getnetworkinforesult-timeoffset":      "The time offset from the median of the connected peers in seconds",
+	"getnetworkinforesult-connections":     "The number of connected peers",
+	"getnetworkinforesult-networkactive":   "Whether peer to peer networking is enabled",
+	"getnetworkinforesult-networks":        "The reachability of each network type",
+	"getnetworkinforesult-relayfee":        "The minimum fee per kilobyte in DUO for transactions to be relayed",
+	"getnetworkinforesult-incrementalfee":  "The minimum fee rate increase in DUO per kilobyte for replacing a mempool transaction, always 0 as replacements are not accepted",
+	"getnetworkinforesult-localaddresses":  "The local addresses advertised to peers",
+	"getnetworkinforesult-warnings":        "Any network and blockchain warnings",
+	"getnetworkinforesult-announcements":   "The text of the active network announcements, from the highest priority",
+
+	// NetworksResult help.
+	"networksresult-name":                        "The network type, one of ipv4, ipv6 or onion",
+	"networksresult-limited":                     "Whether connections to the network are disabled",
+	"networksresult-reachable":                   "Whether peers on the network can be connected to",
+	"networksresult-proxy":                       "The proxy used to connect to the network, if any",
+	"networksresult-proxy_randomize_credentials": "Whether each connection through the proxy uses separate credentials",
+
+	// LocalAddressesResult help.
+	"localaddressesresult-address": "The IP or onion address",
+	"localaddressesresult-port":    "The port",
+	"localaddressesresult-score":   "The priority of the address, higher for addresses from UPnP or the configuration than from the interfaces",
+
 	// GetNetTotalsCmd help.
 	"getnettotals--synopsis": "Returns a JSON object containing network traffic statistics.",
 
@@ -466,31 +708,73 @@ var HelpDescsEnUS = map[string]string{
 	"getnettotalsresult-timemillis":     "Number of milliseconds since 1 Jan 1970 GMT",
 
 	// GetPeerInfoResult help.
-	"getpeerinforesult-id":             "A unique node ID",
-	"getpeerinforesult-addr":           "The ip address and port of the peer",
-	"getpeerinforesult-addrlocal":      "Local address",
-	"getpeerinforesult-services":       "Services bitmask which represents the services supported by the peer",
-	"getpeerinforesult-relaytxes":      "Peer has requested transactions be relayed to it",
-	"getpeerinforesult-lastsend":       "Time the last message was received in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-lastrecv":       "Time the last message was sent in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-bytessent":      "Total bytes sent",
-	"getpeerinforesult-bytesrecv":      "Total bytes received",
-	"getpeerinforesult-conntime":       "Time the connection was made in seconds since 1 Jan 1970 GMT",
-	"getpeerinforesult-timeoffset":     "The time offset of the peer",
-	"getpeerinforesult-pingtime":       "Number of microseconds the last ping took",
-	"getpeerinforesult-pingwait":       "Number of microseconds a queued ping has been waiting for a response",
-	"getpeerinforesult-version":        "The protocol version of the peer",
-	"getpeerinforesult-subver":         "The user agent of the peer",
-	"getpeerinforesult-inbound":        "Whether or not the peer is an inbound connection",
-	"getpeerinforesult-startingheight": "The latest block height the peer knew about when the connection was established",
-	"getpeerinforesult-currentheight":  "The current height of the peer",
-	"getpeerinforesult-banscore":       "The ban score",
-	"getpeerinforesult-feefilter":      "The requested minimum fee a transaction must have to be announced to the peer",
-	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
+	"getpeerinforesult-id":                     "A unique node ID",
+	"getpeerinforesult-addr":                   "The ip address and port of the peer",
+	"getpeerinforesult-addrlocal":              "Local address",
+	"getpeerinforesult-services":               "Services bitmask which represents the services supported by the peer",
+	"getpeerinforesult-relaytxes":              "Peer has requested transactions be relayed to it",
+	"getpeerinforesult-lastsend":               "Time the last message was received in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-lastrecv":               "Time the last message was sent in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-bytessent":              "Total bytes sent",
+	"getpeerinforesult-bytesrecv":              "Total bytes received",
+	"getpeerinforesult-conntime":               "Time the connection was made in seconds since 1 Jan 1970 GMT",
+	"getpeerinforesult-timeoffset":             "The time offset of the peer",
+	"getpeerinforesult-pingtime":               "Number of microseconds the last ping took",
+	"getpeerinforesult-pingwait":               "Number of microseconds a queued ping has been waiting for a response",
+	"getpeerinforesult-blockservetime":         "Average number of microseconds the peer has taken to serve a requested block",
+	"getpeerinforesult-version":                "The protocol version of the peer",
+	"getpeerinforesult-subver":                 "The user agent of the peer",
+	"getpeerinforesult-inbound":                "Whether or not the peer is an inbound connection",
+	"getpeerinforesult-startingheight":         "The latest block height the peer knew about when the connection was established",
+	"getpeerinforesult-currentheight":          "The current height of the peer",
+	"getpeerinforesult-banscore":               "The ban score",
+	"getpeerinforesult-feefilter":              "The requested minimum fee a transaction must have to be announced to the peer",
+	"getpeerinforesult-syncnode":               "Whether or not the peer is the sync peer",
+	"getpeerinforesult-permissions":            "The permissions the whitelists grant the peer: noban, forcerelay, relay, mempool and bloomfilter",
+	"getpeerinforesult-bytessentpermsg":        "Bytes sent to the peer by message command",
+	"getpeerinforesult-bytessentpermsg--key":   "command",
+	"getpeerinforesult-bytessentpermsg--value": "n",
+	"getpeerinforesult-bytessentpermsg--desc":  "The message command as the key and the bytes sent as the value",
+	"getpeerinforesult-bytesrecvpermsg":        "Bytes received from the peer by message command",
+	"getpeerinforesult-bytesrecvpermsg--key":   "command",
+	"getpeerinforesult-bytesrecvpermsg--value": "n",
+	"getpeerinforesult-bytesrecvpermsg--desc":  "The message command as the key and the bytes received as the value",
+	"getpeerinforesult-pingpercentiles":        "The distribution of the round trip times of the latest pings of the peer",
+	"getpeerinforesult-addrrelayenabled":       "Whether the addresses advertised by the peer are added to the address manager",
+	"getpeerinforesult-addrv2":                 "Whether the peer asked for addresses in addrv2 messages",
+	"getpeerinforesult-addrprocessed":          "The number of addresses advertised by the peer that were added to the address manager",
+
+	// PingPercentilesResult help.
+	"pingpercentilesresult-samples": "The number of ping round trip times the distribution is calculated from",
+	"pingpercentilesresult-min":     "The shortest round trip time in microseconds",
+	"pingpercentilesresult-p50":     "The median round trip time in microseconds",
+	"pingpercentilesresult-p90":     "The 90th percentile round trip time in microseconds",
+	"pingpercentilesresult-max":     "The longest round trip time in microseconds",
 
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
 
+	// GetPeerStatsCmd help.
+	"getpeerstats--synopsis": "Returns a JSON object aggregating the traffic and ping statistics of the connected peers.",
+
+	// GetPeerStatsResult help.
+	"getpeerstatsresult-peers":                  "The number of connected peers",
+	"getpeerstatsresult-inbound":                "The number of inbound peers",
+	"getpeerstatsresult-outbound":               "The number of outbound peers",
+	"getpeerstatsresult-totalbytesrecv":         "Total bytes received",
+	"getpeerstatsresult-totalbytessent":         "Total bytes sent",
+	"getpeerstatsresult-bytessentpermsg":        "Bytes sent to the connected peers by message command",
+	"getpeerstatsresult-bytessentpermsg--key":   "command",
+	"getpeerstatsresult-bytessentpermsg--value": "n",
+	"getpeerstatsresult-bytessentpermsg--desc":  "The message command as the key and the bytes sent as the value",
+	"getpeerstatsresult-bytesrecvpermsg":        "Bytes received from the connected peers by message command",
+	"getpeerstatsresult-bytesrecvpermsg--key":   "command",
+	"getpeerstatsresult-bytesrecvpermsg--value": "n",
+	"getpeerstatsresult-bytesrecvpermsg--desc":  "The message command as the key and the bytes received as the value",
+	"getpeerstatsresult-pingpercentiles":        "The distribution of the round trip times of the latest pings of all connected peers",
+	"getpeerstatsresult-syncpeer":               "The ID of the sync peer, zero if there is none",
+	"getpeerstatsresult-timemillis":             "Number of milliseconds since 1 Jan 1970 GMT",
+
 	// GetRawMempoolVerboseResult help.
 	"getrawmempoolverboseresult-size":             "Transaction size in bytes",
 	"getrawmempoolverboseresult-fee":              "Transaction fee in bitcoins",
@@ -511,12 +795,76 @@ var HelpDescsEnUS = map[string]string{
 
 	// GetRawTransactionCmd help.
 	"getrawtransaction--synopsis":   "Returns information about a transaction given its hash.",
-	"getrawtransaction-txid":        "The hash of the transaction",
+	"getrawtransaction-txid":        "The hash of the transaction, or its witness hash when the witness transaction index is enabled (--wtxindex)",
 	"getrawtransaction-verbose":     "Specifies the transaction is returned as a JSON object instead of a hex-encoded string",
 	"getrawtransaction--condition0": "verbose=false",
 	"getrawtransaction--condition1": "verbose=true",
 	"getrawtransaction--result0":    "Hex-encoded bytes of the serialized transaction",
 
+	// GetSnapshotCmd help.
+	"getsnapshot--synopsis": "Pins the current state of the chain and returns a token that getblockhash and gettxout accept to read the chain as of the pinned block, even while new blocks arrive.\n" +
+		"The snapshot is released by releasesnapshot, or when it has not been used for the given number of seconds.",
+
+	// GetSnapshotResult help.
+	"getsnapshotresult-snapshot": "The token identifying the snapshot",
+	"getsnapshotresult-height":   "The height of the best block of the snapshot",
+	"getsnapshotresult-hash":     "The hash of the best block of the snapshot",
+	"getsnapshotresult-expires":  "The number of seconds the snapshot is kept after it was last used",
+
+	// GetSpentInfoCmd help.
+	"getspentinfo--synopsis": "Returns the transaction input in the main chain that spends an output, from the spent output index.\n" +
+		"Outputs that are unspent, or only spent by transactions in the mempool, are not found.",
+	"getspentinfo-txid":  "The hash of the transaction with the output",
+	"getspentinfo-index": "The index of the output",
+
+	// GetSpentInfoResult help.
+	"getspentinforesult-txid":   "The hash of the spending transaction",
+	"getspentinforesult-index":  "The index of the spending input",
+	"getspentinforesult-height": "The height of the block containing the spending transaction",
+
+	// GetSyncStatusCmd help.
+	"getsyncstatus--synopsis": "Returns the stage of the sync of the chain with the network, the progress of each stage, " +
+		"the download rate of blocks and the estimated time until the chain is in sync.\n" +
+		"The stages are connecting to a peer to sync from, downloading headers up to the next checkpoint, downloading " +
+		"and connecting blocks and catching up the optional indexes, which is done when the chain is opened.",
+
+	// GetSyncStatusResult help.
+	"getsyncstatusresult-stage":           "The current stage: connecting, headers, blocks or synced",
+	"getsyncstatusresult-syncpeer":        "The ID of the peer the chain is synced from, 0 if there is none",
+	"getsyncstatusresult-blocks":          "The height of the best block of the chain",
+	"getsyncstatusresult-headers":         "The height of the last downloaded header",
+	"getsyncstatusresult-peerblocks":      "The height of the best block announced by the sync peer",
+	"getsyncstatusresult-stages":          "The progress of each stage of the sync",
+	"getsyncstatusresult-blockspersecond": "The number of blocks downloaded per second over the last minute",
+	"getsyncstatusresult-bytespersecond":  "The number of bytes of blocks downloaded per second over the last minute",
+	"getsyncstatusresult-eta":             "The estimated number of seconds until the chain is in sync, 0 when unknown or in sync",
+	// SyncStageResult help.
+	"syncstageresult-name":     "The name of the stage: connecting, headers, blocks or indexes",
+	"syncstageresult-progress": "The percentage of the stage that is done",
+
+	// GetTelemetryInfoCmd help.
+	"gettelemetryinfo--synopsis": "Returns the state of the opt-in telemetry reporter and the anonymous report it sends.",
+
+	// GetTelemetryInfoResult help.
+	"gettelemetryinforesult-enabled":     "Whether the user has opted in to sending telemetry reports",
+	"gettelemetryinforesult-endpoint":    "The https endpoint reports are sent to",
+	"gettelemetryinforesult-interval":    "The number of seconds between reports",
+	"gettelemetryinforesult-reportssent": "The number of reports delivered since the node started",
+	"gettelemetryinforesult-lastsent":    "Unix time of the last delivered report, or 0 if none has been sent",
+	"gettelemetryinforesult-lasterror":   "The error returned by the last attempt to send a report, if any",
+	"gettelemetryinforesult-report":      "The report that will be sent next",
+
+	// TelemetryReportResult help.
+	"telemetryreportresult-session": "Random identifier regenerated every time the node starts",
+	"telemetryreportresult-version": "The version of the node software",
+	"telemetryreportresult-os":      "The operating system the node is running on",
+	"telemetryreportresult-arch":    "The processor architecture the node is running on",
+	"telemetryreportresult-network": "The network the node is connected to",
+	"telemetryreportresult-height":  "The height of the best block",
+	"telemetryreportresult-peers":   "The number of connected peers",
+	"telemetryreportresult-synced":  "Whether the node believes it is synced to the network",
+	"telemetryreportresult-uptime":  "The number of seconds the node has been running",
+
 	// GetTxOutResult help.
 	"gettxoutresult-bestblock":     "The block hash that contains the transaction output",
 	"gettxoutresult-confirmations": "The number of confirmations",
@@ -530,6 +878,33 @@ var HelpDescsEnUS = map[string]string{
 	"gettxout-txid":           "The hash of the transaction",
 	"gettxout-vout":           "The index of the output",
 	"gettxout-includemempool": "Include the mempool when true",
+	"gettxout-snapshot":       "A snapshot token returned by getsnapshot to look up the output in the chain as of the snapshot, the mempool is not included",
+
+	// GetTxSpendingPrevOutResult help.
+	"gettxspendingprevoutresult-txid":         "The hash of the transaction of the output",
+	"gettxspendingprevoutresult-vout":         "The index of the output",
+	"gettxspendingprevoutresult-spendingtxid": "The hash of the mempool transaction spending the output, omitted if no mempool transaction spends it",
+
+	// GetTxSpendingPrevOutCmd help.
+	"gettxspendingprevout--synopsis": "Returns, for each of the given transaction outputs, the mempool transaction spending it if there is one.",
+	"gettxspendingprevout-outputs":   "The transaction outputs to look up",
+
+	// GetWorkResult help.
+	"getworkresult-data":     "Hex-encoded block header with the sha256 padding, with each 4 bytes byte swapped",
+	"getworkresult-hash1":    "Hex-encoded zero hash with the sha256 padding, only kept for compatibility",
+	"getworkresult-midstate": "Hex-encoded sha256 state after the first 64 bytes of the block header, only of use for sha256d",
+	"getworkresult-target":   "Hex-encoded little-endian target the block hash must not be above",
+
+	// GetWorkCmd help.
+	"getwork--synopsis": "Returns a block header to work on with the proof of work algorithm, or submits a solved block header.\n" +
+		"Every request is handed out work with a different extra nonce in the coinbase, which pays to one of the mining addresses.\n" +
+		"The replies have an X-Long-Polling header with the path that a request can be made to for new work once the work goes stale, " +
+		"and an X-Roll-NTime header with how many seconds the timestamp may be rolled forward.",
+	"getwork-data":        "The hex-encoded data of a solved block header to submit, as it was handed out apart from the nonce and the timestamp",
+	"getwork-algo":        "The proof of work algorithm to work on, the default algorithm of the node if not given",
+	"getwork--condition0": "no data provided",
+	"getwork--condition1": "data provided",
+	"getwork--result1":    "Whether or not the solved block was accepted",
 
 	// HelpCmd help.
 	"help--synopsis":   "Returns a list of all commands or help for a specified command.",
@@ -539,10 +914,58 @@ var HelpDescsEnUS = map[string]string{
 	"help--result0":    "List of commands",
 	"help--result1":    "Help for specified command",
 
+	// InvalidateBlockCmd help.
+	"invalidateblock--synopsis": "Marks a block and all of its descendants as invalid.\n" +
+		"When the block is in the main chain, the chain is reorganized to the valid chain with the most work, which is at least the chain ending at the parent of the block.",
+	"invalidateblock-blockhash": "The hash of the block to invalidate",
+
+	// ListBannedCmd help.
+	"listbanned--synopsis":          "Returns the banned IP addresses and subnets.",
+	"listbanned--result0":           "The bans, oldest first",
+	"listbannedresult-address":      "The banned subnet, a single address for a misbehaving peer",
+	"listbannedresult-banned_until": "The time the ban ends in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-ban_created":  "The time the ban was added in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-ban_reason":   "Why the subnet was banned, the offense of a misbehaving peer or 'manually added' for bans added with setban",
+
+	// LoadAddrManCmd help.
+	"loadaddrman--synopsis": "Adds the addresses of a file written by dumpaddrman, or of a peers file, that are not known yet.\n" +
+		"The addresses are placed in buckets chosen by this node, and those the other node had connected to successfully are kept as tried when there is room for them.",
+	"loadaddrman-filename": "The file to read, relative to the data directory unless absolute",
+
+	// LoadAddrManResult help.
+	"loadaddrmanresult-added":     "The number of addresses added",
+	"loadaddrmanresult-addresses": "The number of addresses known after adding them",
+
+	// LoadUTXOSetCmd help.
+	"loadutxoset--synopsis": "Bootstraps the chain from a snapshot of the UTXO set written by dumputxoset, instead of downloading and validating the blocks before it.\n" +
+		"Only the snapshots in the network parameters are accepted, and the tx, address and committed filter indexes must be disabled.\n" +
+		"No network has a verified snapshot in its parameters yet, so this command fails until one is added.\n" +
+		"The blocks before the snapshot are treated as pruned.",
+	"loadutxoset-filename": "The file to read, relative to the data directory unless absolute",
+
 	// PingCmd help.
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getpeerinfo via the pingtime and pingwait fields.",
 
+	// ReconsiderBlockCmd help.
+	"reconsiderblock--synopsis": "Removes the invalid status of a block, its ancestors and its descendants, as set by invalidateblock, and reorganizes the chain to the valid chain with the most work.\n" +
+		"Blocks that fail validation are marked invalid again.",
+	"reconsiderblock-blockhash": "The hash of the block to reconsider",
+
+	// ReleaseSnapshotCmd help.
+	"releasesnapshot--synopsis": "Releases a chain snapshot pinned by getsnapshot.",
+	"releasesnapshot-snapshot":  "The snapshot token returned by getsnapshot",
+
+	// ScanTxOutSetCmd help.
+	"scantxoutset--synopsis": "Scans the UTXO set for unspent outputs paying to the passed output descriptors, without " +
+		"needing the address index or a rescan of the blocks.\n" +
+		"The scan runs within the call, so the status action always returns null and the abort action false.",
+	"scantxoutset-action":      "The action to perform: start, status or abort",
+	"scantxoutset-scanobjects": "The output descriptors to scan for, of the form addr(<address>) or raw(<hex script>)",
+	"scantxoutset--condition0": "action=start",
+	"scantxoutset--condition1": "action=abort",
+	"scantxoutset--result1":    "Always false, as there is never a scan in progress to abort",
+
 	// SearchRawTransactionsCmd help.
 	"searchrawtransactions--synopsis": "Returns raw data for transactions involving the passed address.\n" +
 		"Returned transactions are pulled from both the database, and transactions currently in the mempool.\n" +
@@ -567,6 +990,14 @@ var HelpDescsEnUS = map[string]string{
 	"sendrawtransaction-maxfeerate":    "Used by bitcoind on or after v0.19.0",
 	"sendrawtransaction--result0":      "The hash of the transaction",
 
+	// SetBanCmd help.
+	"setban--synopsis": "Bans an IP address or subnet, disconnecting its peers, or removes its ban.\n" +
+		"Bans are kept across restarts of the node.",
+	"setban-subnet":   "The IP address or subnet in CIDR notation, such as 192.0.2.0/24, to operate on",
+	"setban-subcmd":   "'add' to ban the subnet, 'remove' to remove its ban",
+	"setban-bantime":  "How long the ban lasts in seconds, or 0 for the configured ban duration",
+	"setban-absolute": "Whether the ban time is the time the ban ends in seconds since 1 Jan 1970 GMT",
+
 	// SetGenerateCmd help.
 	"setgenerate--synopsis":    "Set the server to generate coins (mine) or not.",
 	"setgenerate-generate":     "Use true to enable generation, false to disable it",
@@ -587,6 +1018,32 @@ var HelpDescsEnUS = map[string]string{
 	"submitblock--condition1": "Block rejected",
 	"submitblock--result1":    "The reason the block was rejected",
 
+	// SubmitHeaderCmd help.
+	"submitheader--synopsis": "Checks a serialized, hex-encoded block header would be valid on the current best block, " +
+		"without the transactions of the block: the previous block, the time, the target of its algorithm and its proof of work.\n" +
+		"The header is not stored.",
+	"submitheader-hexdata":     "Serialized, hex-encoded block header",
+	"submitheader--condition0": "Header is valid",
+	"submitheader--condition1": "Header is invalid",
+	"submitheader--result1":    "The BIP 0022 reason the header is invalid",
+
+	// TestMempoolAcceptCmd help.
+	"testmempoolaccept--synopsis": "Returns whether raw transactions would be accepted by the mempool, without adding them. " +
+		"Each transaction is checked on its own, so one can not spend the outputs of another.",
+	"testmempoolaccept-rawtxns":    "Serialized, hex-encoded signed transactions",
+	"testmempoolaccept-maxfeerate": "Reject transactions paying a fee rate higher than this, in DUO/kB, or 0 to allow any fee rate",
+	"testmempoolaccept--result0":   "The result of the check of each transaction",
+
+	// TestMempoolAcceptResult help.
+	"testmempoolacceptresult-txid":          "The hash of the transaction",
+	"testmempoolacceptresult-wtxid":         "The witness hash of the transaction",
+	"testmempoolacceptresult-allowed":       "Whether the transaction would be accepted by the mempool",
+	"testmempoolacceptresult-vsize":         "The virtual size of the transaction, only when allowed",
+	"testmempoolacceptresult-fees":          "The fees of the transaction, only when allowed",
+	"testmempoolacceptresult-replaces":      "The hashes of the mempool transactions, and their descendants, the transaction replaces (BIP125)",
+	"testmempoolacceptresult-reject-reason": "The reason the transaction would be rejected, only when not allowed",
+	"testmempoolacceptfees-base":            "The fee paid by the transaction in DUO",
+
 	// ValidateAddressResult help.
 	"validateaddresschainresult-isvalid": "Whether or not the address is valid",
 	"validateaddresschainresult-address": "The bitcoin address (only when isvalid is true)",
@@ -631,6 +1088,20 @@ var HelpDescsEnUS = map[string]string{
 	// StopNotifyNewTransactionsCmd help.
 	"stopnotifynewtransactions--synopsis": "Stop sending either a txaccepted or a txacceptedverbose notification when a new transaction is accepted into the mempool.",
 
+	// NotifyMempoolEventsCmd help.
+	"notifymempoolevents--synopsis": "Send a mempoolevent notification whenever a transaction relayed by a peer or submitted with sendrawtransaction is accepted or rejected by the mempool.\n" +
+		"Rejections carry the reject code and reason the transaction failed mempool policy for.",
+
+	// StopNotifyMempoolEventsCmd help.
+	"stopnotifymempoolevents--synopsis": "Stop sending mempoolevent notifications.",
+
+	// NotifyTemplateEvictionsCmd help.
+	"notifytemplateevictions--synopsis": "Send a templateeviction notification whenever a transaction included in recently issued block templates is evicted or replaced in the mempool.\n" +
+		"The notification carries the ID of the replacing transaction, if any, and the IDs of the affected templates, so work can be refreshed before a new template is generated.",
+
+	// StopNotifyTemplateEvictionsCmd help.
+	"stopnotifytemplateevictions--synopsis": "Stop sending templateeviction notifications.",
+
 	// NotifyReceivedCmd help.
 	"notifyreceived--synopsis": "Send a recvtx notification when a transaction added to mempool or appears in a newly-attached block contains a txout pkScript sending to any of the passed addresses.\n" +
 		"Matching outpoints are automatically registered for redeemingtx notifications.",
@@ -657,6 +1128,8 @@ var HelpDescsEnUS = map[string]string{
 	"loadtxfilter-reload":    "Load a new filter instead of adding data to an existing one",
 	"loadtxfilter-addresses": "Array of addresses to add to the transaction filter",
 	"loadtxfilter-outpoints": "Array of outpoints to add to the transaction filter",
+	"loadtxfilter-scripts":   "Array of addr() or raw() output descriptors, hex public key scripts, or hex templates ending in * matching every script starting with the bytes before it, to add to the transaction filter",
+	"loadtxfilter-compact":   "Send txmatch notifications of only the matched outputs and spent outpoints instead of whole transactions, left as it is when adding to a filter if omitted",
 
 	// Rescan help.
 	"rescan--synopsis": "Rescan block chain for transactions to addresses.\n" +
@@ -677,6 +1150,23 @@ var HelpDescsEnUS = map[string]string{
 	"rescannedblock-hash":         "Hash of the matching block.",
 	"rescannedblock-transactions": "List of matching transactions, serialized and hex-encoded.",
 
+	// SearchRawTransactionsStreamCmd help.
+	"searchrawtransactionsstream--synopsis": "Send the transactions involving the passed address, in the order of searchrawtransactions, as searchrawtransactionsbatch notifications.\n" +
+		"Each notification holds at most batchsize verbose transactions and the cursor to continue the search after them.\n" +
+		"The next batch is only loaded once the previous one was sent, and the search stops if the client disconnects.\n" +
+		"This call returns once the last batch was sent.",
+	"searchrawtransactionsstream-address":     "The address to search for",
+	"searchrawtransactionsstream-cursor":      "The number of leading transactions to leave out, such as the cursor of the last batch received",
+	"searchrawtransactionsstream-batchsize":   "The maximum number of transactions in each batch, up to 1000",
+	"searchrawtransactionsstream-vinextra":    "Specify that extra data from previous output will be returned in vin",
+	"searchrawtransactionsstream-reverse":     "Specifies that the transactions should be sent in reverse chronological order",
+	"searchrawtransactionsstream-filteraddrs": "Address list.  Only inputs or outputs with matching address will be returned",
+
+	// SearchRawTransactionsStreamResult help.
+	"searchrawtransactionsstreamresult-cursor":   "The cursor to continue the search after the transactions that were sent",
+	"searchrawtransactionsstreamresult-count":    "The number of transactions that were sent",
+	"searchrawtransactionsstreamresult-complete": "Whether there are no more transactions after the cursor",
+
 	// Uptime help.
 	"uptime--synopsis": "Returns the total uptime of the server.",
 	"uptime--result0":  "The number of seconds that the server has been running",
@@ -701,21 +1191,36 @@ var HelpDescsEnUS = map[string]string{
 // pointer to the type (or nil to indicate no return value).
 var ResultTypes = map[string][]interface{}{
 	"addnode":               nil,
+	"calculatesighash":      {(*btcjson.CalculateSigHashResult)(nil)},
+	"clearbanned":           nil,
 	"createrawtransaction":  {(*string)(nil)},
+	"evaluatescript":        {(*btcjson.EvaluateScriptResult)(nil)},
 	"debuglevel":            {(*string)(nil), (*string)(nil)},
 	"decoderawtransaction":  {(*btcjson.TxRawDecodeResult)(nil)},
 	"decodescript":          {(*btcjson.DecodeScriptResult)(nil)},
+	"dumpaddrman":           {(*btcjson.DumpAddrManResult)(nil)},
+	"dumputxoset":           {(*btcjson.UTXOSetSnapshotResult)(nil)},
 	"estimatefee":           {(*float64)(nil)},
+	"estimatesmartfee":      {(*btcjson.EstimateSmartFeeResult)(nil)},
 	"generate":              {(*[]string)(nil)},
+	"generateblock":         {(*btcjson.GenerateBlockResult)(nil)},
+	"generatetoaddress":     {(*[]string)(nil)},
 	"getaddednodeinfo":      {(*[]string)(nil), (*[]btcjson.GetAddedNodeInfoResult)(nil)},
+	"getaddressbalance":     {(*btcjson.GetAddressBalanceResult)(nil)},
+	"getaddresstxids":       {(*[]string)(nil)},
+	"getaddressutxos":       {(*[]btcjson.GetAddressUtxosResult)(nil)},
+	"getaddrmaninfo":        {(*btcjson.GetAddrManInfoResult)(nil)},
+	"getauditlog":           {(*[]btcjson.AuditLogEntry)(nil)},
 	"getbestblock":          {(*btcjson.GetBestBlockResult)(nil)},
 	"getbestblockhash":      {(*string)(nil)},
 	"getblock":              {(*string)(nil), (*btcjson.GetBlockVerboseResult)(nil)},
 	"getblockcount":         {(*int64)(nil)},
 	"getblockhash":          {(*string)(nil)},
 	"getblockheader":        {(*string)(nil), (*btcjson.GetBlockHeaderVerboseResult)(nil)},
+	"getblocksubsidy":       {(*btcjson.GetBlockSubsidyResult)(nil)},
 	"getblocktemplate":      {(*btcjson.GetBlockTemplateResult)(nil), (*string)(nil), nil},
 	"getblockchaininfo":     {(*btcjson.GetBlockChainInfoResult)(nil)},
+	"getblockfilter":        {(*btcjson.GetBlockFilterResult)(nil)},
 	"getcfilter":            {(*string)(nil)},
 	"getcfilterheader":      {(*string)(nil)},
 	"getconnectioncount":    {(*int32)(nil)},
@@ -726,42 +1231,66 @@ var ResultTypes = map[string][]interface{}{
 	"getheaders":            {(*[]string)(nil)},
 	"getinfo":               {(*btcjson.InfoChainResult)(nil)},
 	"getmempoolinfo":        {(*btcjson.GetMempoolInfoResult)(nil)},
+	"getminerstats":         {(*btcjson.GetMinerStatsResult)(nil)},
 	"getmininginfo":         {(*btcjson.GetMiningInfoResult)(nil)},
 	"getnettotals":          {(*btcjson.GetNetTotalsResult)(nil)},
 	"getnetworkhashps":      {(*int64)(nil)},
+	"getnetworkinfo":        {(*btcjson.GetNetworkInfoResult)(nil)},
 	"getpeerinfo":           {(*[]btcjson.GetPeerInfoResult)(nil)},
+	"getpeerstats":          {(*btcjson.GetPeerStatsResult)(nil)},
 	"getrawmempool":         {(*[]string)(nil), (*btcjson.GetRawMempoolVerboseResult)(nil)},
 	"getrawtransaction":     {(*string)(nil), (*btcjson.TxRawResult)(nil)},
+	"getsnapshot":           {(*btcjson.GetSnapshotResult)(nil)},
+	"getspentinfo":          {(*btcjson.GetSpentInfoResult)(nil)},
+	"getsyncstatus":         {(*btcjson.GetSyncStatusResult)(nil)},
+	"gettelemetryinfo":      {(*btcjson.GetTelemetryInfoResult)(nil)},
 	"gettxout":              {(*btcjson.GetTxOutResult)(nil)},
+	"gettxspendingprevout":  {(*[]btcjson.GetTxSpendingPrevOutResult)(nil)},
+	"getwork":               {(*btcjson.GetWorkResult)(nil), (*bool)(nil)},
 	"node":                  nil,
 	"help":                  {(*string)(nil), (*string)(nil)},
+	"invalidateblock":       nil,
+	"listbanned":            {(*[]btcjson.ListBannedResult)(nil)},
+	"loadaddrman":           {(*btcjson.LoadAddrManResult)(nil)},
+	"loadutxoset":           {(*btcjson.UTXOSetSnapshotResult)(nil)},
 	"ping":                  nil,
+	"reconsiderblock":       nil,
+	"releasesnapshot":       nil,
+	"scantxoutset":          {(*btcjson.ScanTxOutSetResult)(nil), (*bool)(nil)},
 	"searchrawtransactions": {(*string)(nil), (*[]btcjson.SearchRawTransactionsResult)(nil)},
 	"sendrawtransaction":    {(*string)(nil)},
+	"setban":                nil,
 	"setgenerate":           nil,
 	"stop":                  {(*string)(nil)},
 	"restart":               {(*string)(nil)},
 	"resetchain":            {(*string)(nil)},
 	// "dropwallethistory":     {(*string)(nil)},
-	"submitblock":     {nil, (*string)(nil)},
-	"uptime":          {(*int64)(nil)},
-	"validateaddress": {(*btcjson.ValidateAddressChainResult)(nil)},
-	"verifychain":     {(*bool)(nil)},
-	"verifymessage":   {(*bool)(nil)},
-	"version":         {(*map[string]btcjson.VersionResult)(nil)},
+	"submitblock":       {nil, (*string)(nil)},
+	"submitheader":      {nil, (*string)(nil)},
+	"testmempoolaccept": {(*[]btcjson.TestMempoolAcceptResult)(nil)},
+	"uptime":            {(*int64)(nil)},
+	"validateaddress":   {(*btcjson.ValidateAddressChainResult)(nil)},
+	"verifychain":       {(*bool)(nil)},
+	"verifymessage":     {(*bool)(nil)},
+	"version":           {(*map[string]btcjson.VersionResult)(nil)},
 	// Websocket commands.
-	"loadtxfilter":              nil,
-	"session":                   {(*btcjson.SessionResult)(nil)},
-	"notifyblocks":              nil,
-	"stopnotifyblocks":          nil,
-	"notifynewtransactions":     nil,
-	"stopnotifynewtransactions": nil,
-	"notifyreceived":            nil,
-	"stopnotifyreceived":        nil,
-	"notifyspent":               nil,
-	"stopnotifyspent":           nil,
-	"rescan":                    nil,
-	"rescanblocks":              {(*[]btcjson.RescannedBlock)(nil)},
+	"loadtxfilter":                nil,
+	"session":                     {(*btcjson.SessionResult)(nil)},
+	"notifyblocks":                nil,
+	"stopnotifyblocks":            nil,
+	"notifynewtransactions":       nil,
+	"stopnotifynewtransactions":   nil,
+	"notifymempoolevents":         nil,
+	"stopnotifymempoolevents":     nil,
+	"notifytemplateevictions":     nil,
+	"stopnotifytemplateevictions": nil,
+	"notifyreceived":              nil,
+	"stopnotifyreceived":          nil,
+	"notifyspent":                 nil,
+	"stopnotifyspent":             nil,
+	"rescan":                      nil,
+	"rescanblocks":                {(*[]btcjson.RescannedBlock)(nil)},
+	"searchrawtransactionsstream": {(*btcjson.SearchRawTransactionsStreamResult)(nil)},
 }
 
 // HelpCacher provides a concurrent safe type that provides help and usage for the RPC server commands and caches the
@@ -37,9 +37,15 @@ type NotificationRegisterAddr struct {
 }
 type NotificationRegisterBlocks WSClient
 
+type NotificationMempoolEvent btcjson.MempoolEventNtfn
+
+type NotificationTemplateEviction btcjson.TemplateEvictionNtfn
+
 // Notification control requests
 type NotificationRegisterClient WSClient
+type NotificationRegisterMempoolEvents WSClient
 type NotificationRegisterNewMempoolTxs WSClient
+type NotificationRegisterTemplateEvictions WSClient
 type NotificationRegisterSpent struct {
 	WSC *WSClient
 	OPs []*wire.OutPoint
@@ -54,7 +60,9 @@ type NotificationUnregisterAddr struct {
 }
 type NotificationUnregisterBlocks WSClient
 type NotificationUnregisterClient WSClient
+type NotificationUnregisterMempoolEvents WSClient
 type NotificationUnregisterNewMempoolTxs WSClient
+type NotificationUnregisterTemplateEvictions WSClient
 type NotificationUnregisterSpent struct {
 	WSC *WSClient
 	OP  *wire.OutPoint
@@ -139,6 +147,11 @@ type WSClientFilter struct {
 	OtherAddresses map[string]struct{}
 	// Outpoints of Unspent outputs.
 	Unspent map[wire.OutPoint]struct{}
+	// Public key scripts matched exactly, and templates matching the scripts starting with them.
+	Scripts         map[string]struct{}
+	ScriptTemplates [][]byte
+	// Compact filters are notified with txmatch notifications of only the matched outputs and spent outpoints.
+	Compact bool
 }
 
 // WSCommandHandler describes a callback function used to handle a specific command.
@@ -179,6 +192,9 @@ const (
 	// applies to requests handled directly in the websocket client input handler or the async handler since
 	// notifications have their own queuing mechanism independent of the send channel buffer.
 	WebsocketSendBufferSize = 50
+	// MaxSearchStreamBatchSize is the largest number of transactions searchrawtransactionsstream sends in one
+	// searchrawtransactionsbatch notification.
+	MaxSearchStreamBatchSize = 1000
 )
 
 // ErrClientQuit describes the error where a client send is not processed due to the client having already been
@@ -199,19 +215,24 @@ var TimeZeroVal = time.Time{}
 var WSHandlers map[string]WSCommandHandler
 
 var WSHandlersBeforeInit = map[string]WSCommandHandler{
-	"loadtxfilter":              HandleLoadTxFilter,
-	"help":                      HandleWebsocketHelp,
-	"notifyblocks":              HandleNotifyBlocks,
-	"notifynewtransactions":     HandleNotifyNewTransactions,
-	"notifyreceived":            HandleNotifyReceived,
-	"notifyspent":               HandleNotifySpent,
-	"session":                   HandleSession,
-	"stopnotifyblocks":          HandleStopNotifyBlocks,
-	"stopnotifynewtransactions": HandleStopNotifyNewTransactions,
-	"stopnotifyspent":           HandleStopNotifySpent,
-	"stopnotifyreceived":        HandleStopNotifyReceived,
-	"rescan":                    HandleRescan,
-	"rescanblocks":              HandleRescanBlocks,
+	"loadtxfilter":                HandleLoadTxFilter,
+	"help":                        HandleWebsocketHelp,
+	"notifyblocks":                HandleNotifyBlocks,
+	"notifymempoolevents":         HandleNotifyMempoolEvents,
+	"notifynewtransactions":       HandleNotifyNewTransactions,
+	"notifyreceived":              HandleNotifyReceived,
+	"notifyspent":                 HandleNotifySpent,
+	"notifytemplateevictions":     HandleNotifyTemplateEvictions,
+	"session":                     HandleSession,
+	"stopnotifyblocks":            HandleStopNotifyBlocks,
+	"stopnotifymempoolevents":     HandleStopNotifyMempoolEvents,
+	"stopnotifynewtransactions":   HandleStopNotifyNewTransactions,
+	"stopnotifyspent":             HandleStopNotifySpent,
+	"stopnotifyreceived":          HandleStopNotifyReceived,
+	"stopnotifytemplateevictions": HandleStopNotifyTemplateEvictions,
+	"rescan":                      HandleRescan,
+	"rescanblocks":                HandleRescanBlocks,
+	"searchrawtransactionsstream": HandleSearchRawTransactionsStream,
 }
 
 // UnspentSlice returns a slice of currently-unspent outpoints for the rescan lookup keys. This is primarily intended to
@@ -604,7 +625,8 @@ func (c *WSClient) ServiceRequest(r *ParsedRPCCmd) {
 	} else {
 		result, err = c.Server.StandardCmdResult(r, nil)
 	}
-	reply, err := CreateMarshalledReply(r.ID, result, err)
+	c.Server.Audit(r, c.IsAdmin, c.Addr, err)
+	reply, err := c.Server.CreateReply(r.ID, result, err)
 	if err != nil {
 		Error(err)
 		Errorf(
@@ -791,6 +813,28 @@ func (m *WSNtfnMgr) SendNotifyMempoolTx(tx *util.Tx, isNew bool) {
 	}
 }
 
+// SendNotifyMempoolEvent passes the outcome of submitting a transaction to the mempool to the notification manager for
+// delivery to the clients subscribed to mempool events.
+func (m *WSNtfnMgr) SendNotifyMempoolEvent(ntfn *btcjson.MempoolEventNtfn) {
+	// As NotifyMempoolEvent will be called by the sync manager and the RPC server may no longer be running, use a
+	// select statement to unblock enqueuing the notification once the RPC server has begun shutting down.
+	select {
+	case m.QueueNotification <- (*NotificationMempoolEvent)(ntfn):
+	case <-m.Quit:
+	}
+}
+
+// SendNotifyTemplateEviction passes the eviction of a transaction in issued block templates from the mempool to the
+// notification manager for delivery to the clients subscribed to template evictions.
+func (m *WSNtfnMgr) SendNotifyTemplateEviction(ntfn *btcjson.TemplateEvictionNtfn) {
+	// This is called by the mempool, so as with mempool events use a select statement to unblock enqueuing the
+	// notification once the RPC server has begun shutting down.
+	select {
+	case m.QueueNotification <- (*NotificationTemplateEviction)(ntfn):
+	case <-m.Quit:
+	}
+}
+
 // GetNumClients returns the number of clients actively being served.
 func (m *WSNtfnMgr) GetNumClients() (n int) {
 	select {
@@ -805,6 +849,12 @@ func (m *WSNtfnMgr) RegisterBlockUpdates(wsc *WSClient) {
 	m.QueueNotification <- (*NotificationRegisterBlocks)(wsc)
 }
 
+// RegisterMempoolEvents requests notifications to the passed websocket client when a transaction is accepted or
+// rejected by the memory pool.
+func (m *WSNtfnMgr) RegisterMempoolEvents(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationRegisterMempoolEvents)(wsc)
+}
+
 // RegisterNewMempoolTxsUpdates requests notifications to the passed websocket client when new transactions are added to
 // the memory pool.
 func (m *WSNtfnMgr) RegisterNewMempoolTxsUpdates(wsc *WSClient) {
@@ -830,6 +880,12 @@ func (m *WSNtfnMgr) RegisterTxOutAddressRequests(wsc *WSClient, addrs []string)
 	}
 }
 
+// RegisterTemplateEvictions requests notifications to the passed websocket client when a transaction in issued block
+// templates is evicted or replaced in the memory pool.
+func (m *WSNtfnMgr) RegisterTemplateEvictions(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationRegisterTemplateEvictions)(wsc)
+}
+
 // RemoveClient removes the passed websocket client and all notifications registered for it.
 func (m *WSNtfnMgr) RemoveClient(wsc *WSClient) {
 	select {
@@ -854,6 +910,11 @@ func (m *WSNtfnMgr) UnregisterBlockUpdates(wsc *WSClient) {
 	m.QueueNotification <- (*NotificationUnregisterBlocks)(wsc)
 }
 
+// UnregisterMempoolEvents removes mempool acceptance and rejection notifications for the passed websocket client.
+func (m *WSNtfnMgr) UnregisterMempoolEvents(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationUnregisterMempoolEvents)(wsc)
+}
+
 // UnregisterNewMempoolTxsUpdates removes notifications to the passed websocket client when new transaction are added to
 // the memory pool.
 func (m *WSNtfnMgr) UnregisterNewMempoolTxsUpdates(wsc *WSClient) {
@@ -879,6 +940,11 @@ func (m *WSNtfnMgr) UnregisterTxOutAddressRequest(wsc *WSClient, addr string) {
 	}
 }
 
+// UnregisterTemplateEvictions removes template eviction notifications for the passed websocket client.
+func (m *WSNtfnMgr) UnregisterTemplateEvictions(wsc *WSClient) {
+	m.QueueNotification <- (*NotificationUnregisterTemplateEvictions)(wsc)
+}
+
 // WaitForShutdown blocks until all notification manager goroutines have finished.
 func (m *WSNtfnMgr) WaitForShutdown() {
 	m.WG.Wait()
@@ -944,6 +1010,8 @@ func (m *WSNtfnMgr) NotificationHandler() {
 	// than using the entire struct.
 	blockNotifications := make(map[chan struct{}]*WSClient)
 	txNotifications := make(map[chan struct{}]*WSClient)
+	mempoolEventNotifications := make(map[chan struct{}]*WSClient)
+	templateEvictionNotifications := make(map[chan struct{}]*WSClient)
 	watchedOutPoints := make(map[wire.OutPoint]map[chan struct{}]*WSClient)
 	watchedAddrs := make(map[string]map[chan struct{}]*WSClient)
 out:
@@ -984,6 +1052,16 @@ out:
 				}
 				m.NotifyForTx(watchedOutPoints, watchedAddrs, n.Tx, nil)
 				m.NotifyRelevantTxAccepted(n.Tx, clients)
+			case *NotificationMempoolEvent:
+				if len(mempoolEventNotifications) != 0 {
+					m.NotifyMempoolEvent(mempoolEventNotifications,
+						(*btcjson.MempoolEventNtfn)(n))
+				}
+			case *NotificationTemplateEviction:
+				if len(templateEvictionNotifications) != 0 {
+					m.NotifyTemplateEviction(templateEvictionNotifications,
+						(*btcjson.TemplateEvictionNtfn)(n))
+				}
 			case *NotificationRegisterBlocks:
 				wsc := (*WSClient)(n)
 				blockNotifications[wsc.Quit] = wsc
@@ -998,6 +1076,8 @@ out:
 				// Remove any requests made by the client as well as the client itself.
 				delete(blockNotifications, wsc.Quit)
 				delete(txNotifications, wsc.Quit)
+				delete(mempoolEventNotifications, wsc.Quit)
+				delete(templateEvictionNotifications, wsc.Quit)
 				for k := range wsc.SpentRequests {
 					op := k
 					m.RemoveSpentRequest(watchedOutPoints, wsc, &op)
@@ -1020,6 +1100,18 @@ out:
 			case *NotificationUnregisterNewMempoolTxs:
 				wsc := (*WSClient)(n)
 				delete(txNotifications, wsc.Quit)
+			case *NotificationRegisterMempoolEvents:
+				wsc := (*WSClient)(n)
+				mempoolEventNotifications[wsc.Quit] = wsc
+			case *NotificationUnregisterMempoolEvents:
+				wsc := (*WSClient)(n)
+				delete(mempoolEventNotifications, wsc.Quit)
+			case *NotificationRegisterTemplateEvictions:
+				wsc := (*WSClient)(n)
+				templateEvictionNotifications[wsc.Quit] = wsc
+			case *NotificationUnregisterTemplateEvictions:
+				wsc := (*WSClient)(n)
+				delete(templateEvictionNotifications, wsc.Quit)
 			default:
 				Warn("unhandled notification type")
 			}
@@ -1095,11 +1187,22 @@ func (m *WSNtfnMgr) NotifyFilteredBlockConnected(
 	}
 	ntfn := btcjson.NewFilteredBlockConnectedNtfn(block.Height(),
 		hex.EncodeToString(w.Bytes()), nil)
-	// Search for relevant transactions for each client and save them serialized in hex encoding for the notification.
+	// Search for relevant transactions for each client and save them serialized in hex encoding for the notification,
+	// or marshal a txmatch notification of what they matched for clients with a compact filter.
 	subscribedTxs := make(map[chan struct{}][]string)
+	compactNtfns := make(map[chan struct{}][][]byte)
 	for _, tx := range block.Transactions() {
 		var txHex string
-		for quitChan := range m.GetSubscribedClients(tx, clients) {
+		for quitChan, match := range m.MatchClients(tx, clients) {
+			if match.Compact {
+				n, err := TxMatchNotification(tx, match, block.Height(), block.Hash().String())
+				if err != nil {
+					Error("failed to marshal txmatch notification:", err)
+					continue
+				}
+				compactNtfns[quitChan] = append(compactNtfns[quitChan], n)
+				continue
+			}
 			if txHex == "" {
 				txHex = TxHexString(tx.MsgTx())
 			}
@@ -1107,6 +1210,12 @@ func (m *WSNtfnMgr) NotifyFilteredBlockConnected(
 		}
 	}
 	for quitChan, wsc := range clients {
+		// The matches of compact filters are sent before the block, so once it arrives all of them have been.
+		for _, n := range compactNtfns[quitChan] {
+			if err := wsc.QueueNotification(n); err != nil {
+				Debug(err)
+			}
+		}
 		// Add all discovered transactions for this client. For clients that have no new-style filter, add the empty
 		// string slice.
 		ntfn.SubscribedTxs = subscribedTxs[quitChan]
@@ -1152,6 +1261,40 @@ func (*WSNtfnMgr) NotifyFilteredBlockDisconnected(
 	}
 }
 
+// NotifyMempoolEvent notifies websocket clients that have registered for mempool events that a transaction was
+// accepted or rejected by the memory pool.
+func (*WSNtfnMgr) NotifyMempoolEvent(clients map[chan struct{}]*WSClient,
+	ntfn *btcjson.MempoolEventNtfn) {
+	marshalledJSON, err := btcjson.MarshalCmd(nil, ntfn)
+	if err != nil {
+		Error("failed to marshal mempool event notification:", err)
+		return
+	}
+	for _, wsc := range clients {
+		err := wsc.QueueNotification(marshalledJSON)
+		if err != nil {
+			Debug(err)
+		}
+	}
+}
+
+// NotifyTemplateEviction notifies websocket clients that have registered for template evictions that a transaction in
+// issued block templates was evicted or replaced in the memory pool.
+func (*WSNtfnMgr) NotifyTemplateEviction(clients map[chan struct{}]*WSClient,
+	ntfn *btcjson.TemplateEvictionNtfn) {
+	marshalledJSON, err := btcjson.MarshalCmd(nil, ntfn)
+	if err != nil {
+		Error("failed to marshal template eviction notification:", err)
+		return
+	}
+	for _, wsc := range clients {
+		err := wsc.QueueNotification(marshalledJSON)
+		if err != nil {
+			Debug(err)
+		}
+	}
+}
+
 // NotifyForNewTx notifies websocket clients that have registered for updates when a new transaction is added to the
 // memory pool.
 func (m *WSNtfnMgr) NotifyForNewTx(clients map[chan struct{}]*WSClient,
@@ -1306,23 +1449,32 @@ func (m *WSNtfnMgr) NotifyForTxOuts(ops map[wire.OutPoint]map[chan struct{}]*WSC
 }
 
 // NotifyRelevantTxAccepted examines the inputs and outputs of the passed transaction, notifying websocket clients of
-// outputs spending to a watched address and inputs spending a watched outpoint.
+// outputs spending to a watched address or script and inputs spending a watched outpoint. Clients with a compact filter
+// are sent only what matched.
 //
 // Any outputs paying to a watched address result in the output being watched as well for future notifications.
 func (m *WSNtfnMgr) NotifyRelevantTxAccepted(tx *util.Tx, clients map[chan struct{}]*WSClient) {
-	clientsToNotify := m.GetSubscribedClients(tx, clients)
-	if len(clientsToNotify) != 0 {
-		n := btcjson.NewRelevantTxAcceptedNtfn(TxHexString(tx.MsgTx()))
-		marshalled, err := btcjson.MarshalCmd(nil, n)
+	var full []byte
+	for quitChan, match := range m.MatchClients(tx, clients) {
+		var marshalled []byte
+		var err error
+		switch {
+		case match.Compact:
+			marshalled, err = TxMatchNotification(tx, match, -1, "")
+		case full == nil:
+			n := btcjson.NewRelevantTxAcceptedNtfn(TxHexString(tx.MsgTx()))
+			full, err = btcjson.MarshalCmd(nil, n)
+			marshalled = full
+		default:
+			marshalled = full
+		}
 		if err != nil {
 			Error("failed to marshal notification:", err)
-			return
+			continue
 		}
-		for quitChan := range clientsToNotify {
-			err := clients[quitChan].QueueNotification(marshalled)
-			if err != nil {
-				Error(err)
-			}
+		err = clients[quitChan].QueueNotification(marshalled)
+		if err != nil {
+			Error(err)
 		}
 	}
 }
@@ -1374,61 +1526,39 @@ OutPoint]map[chan struct{}]*WSClient, wsc *WSClient, op *wire.OutPoint) {
 }
 
 // GetSubscribedClients returns the set of all websocket client quit channels that are registered to receive
-// notifications regarding tx, either due to tx spending a watched output or outputting to a watched address.
+// notifications regarding tx, either due to tx spending a watched output or outputting to a watched address or script.
 //
 // Matching client's filters are updated based on this transaction's outputs and output addresses that may be relevant
 // for a client.
 func (m *WSNtfnMgr) GetSubscribedClients(tx *util.Tx,
 	clients map[chan struct{}]*WSClient) map[chan struct{}]struct{} {
-	// Use a map of client quit channels as keys to prevent duplicates when multiple inputs and/or outputs are relevant
-	// to the client.
 	subscribed := make(map[chan struct{}]struct{})
-	msgTx := tx.MsgTx()
-	for _, input := range msgTx.TxIn {
-		for quitChan, wsc := range clients {
-			wsc.Lock()
-			filter := wsc.FilterData
-			wsc.Unlock()
-			if filter == nil {
-				continue
-			}
-			filter.mu.Lock()
-			if filter.ExistsUnspentOutPoint(&input.PreviousOutPoint) {
-				subscribed[quitChan] = struct{}{}
-			}
-			filter.mu.Unlock()
-		}
+	for quitChan := range m.MatchClients(tx, clients) {
+		subscribed[quitChan] = struct{}{}
 	}
-	for i, output := range msgTx.TxOut {
-		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
-			output.PkScript, m.Server.Cfg.ChainParams)
-		if err != nil {
-			Error(err)
-			// Clients are not able to subscribe to nonstandard or non-address outputs.
+	return subscribed
+}
+
+// MatchClients returns what tx matched in the filters of the websocket clients, by the quit channels of the clients
+// it matched. Matching client's filters are updated the same as by GetSubscribedClients.
+func (m *WSNtfnMgr) MatchClients(tx *util.Tx,
+	clients map[chan struct{}]*WSClient) map[chan struct{}]*TxMatch {
+	matches := make(map[chan struct{}]*TxMatch)
+	for quitChan, wsc := range clients {
+		wsc.Lock()
+		filter := wsc.FilterData
+		wsc.Unlock()
+		if filter == nil {
 			continue
 		}
-		for quitChan, wsc := range clients {
-			wsc.Lock()
-			filter := wsc.FilterData
-			wsc.Unlock()
-			if filter == nil {
-				continue
-			}
-			filter.mu.Lock()
-			for _, a := range addrs {
-				if filter.ExistsAddress(a) {
-					subscribed[quitChan] = struct{}{}
-					op := wire.OutPoint{
-						Hash:  *tx.Hash(),
-						Index: uint32(i),
-					}
-					filter.AddUnspentOutPoint(&op)
-				}
-			}
-			filter.mu.Unlock()
+		filter.mu.Lock()
+		match := filter.MatchTx(tx, m.Server.Cfg.ChainParams)
+		filter.mu.Unlock()
+		if match.Matched() {
+			matches[quitChan] = &match
 		}
 	}
-	return subscribed
+	return matches
 }
 func (s Semaphore) Acquire() {
 	s <- struct{}{}
@@ -1519,13 +1649,29 @@ func HandleLoadTxFilter(wsc *WSClient, icmd interface{}) (interface{}, error) {
 		}
 	}
 	params := wsc.Server.Cfg.ChainParams
+	// The scripts are all parsed before any is added, so an invalid one leaves the filter as it was.
+	type filterScript struct {
+		script   []byte
+		template bool
+	}
+	var scripts []filterScript
+	if cmd.Scripts != nil {
+		for _, s := range *cmd.Scripts {
+			script, template, err := ParseFilterScript(s, params)
+			if err != nil {
+				return nil, &btcjson.RPCError{
+					Code:    btcjson.ErrRPCInvalidParameter,
+					Message: "Invalid script " + s + ": " + err.Error(),
+				}
+			}
+			scripts = append(scripts, filterScript{script, template})
+		}
+	}
 	wsc.Lock()
 	if cmd.Reload || wsc.FilterData == nil {
 		wsc.FilterData = NewWSClientFilter(cmd.Addresses, outPoints,
 			params)
-		wsc.Unlock()
 	} else {
-		wsc.Unlock()
 		wsc.FilterData.mu.Lock()
 		for _, a := range cmd.Addresses {
 			wsc.FilterData.AddAddressStr(a, params)
@@ -1535,6 +1681,16 @@ func HandleLoadTxFilter(wsc *WSClient, icmd interface{}) (interface{}, error) {
 		}
 		wsc.FilterData.mu.Unlock()
 	}
+	filter := wsc.FilterData
+	wsc.Unlock()
+	filter.mu.Lock()
+	for _, s := range scripts {
+		filter.AddScript(s.script, s.template)
+	}
+	if cmd.Compact != nil {
+		filter.Compact = *cmd.Compact
+	}
+	filter.mu.Unlock()
 	return nil, nil
 }
 
@@ -1557,6 +1713,13 @@ func HandleNotifyNewTransactions(wsc *WSClient,
 	return nil, nil
 }
 
+// HandleNotifyMempoolEvents implements the notifymempoolevents command extension for websocket connections. It is not
+// in the limited user command set, so only admin clients can subscribe.
+func HandleNotifyMempoolEvents(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.RegisterMempoolEvents(wsc)
+	return nil, nil
+}
+
 // HandleNotifyReceived implements the notifyreceived command extension for websocket connections.
 func HandleNotifyReceived(wsc *WSClient, icmd interface{}) (interface{},
 	error) {
@@ -1574,6 +1737,13 @@ func HandleNotifyReceived(wsc *WSClient, icmd interface{}) (interface{},
 	return nil, nil
 }
 
+// HandleNotifyTemplateEvictions implements the notifytemplateevictions command extension for websocket connections. Like
+// getblocktemplate it is not in the limited user command set, so only admin clients can subscribe.
+func HandleNotifyTemplateEvictions(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.RegisterTemplateEvictions(wsc)
+	return nil, nil
+}
+
 // HandleNotifySpent implements the notifyspent command extension for websocket connections.
 func HandleNotifySpent(wsc *WSClient, icmd interface{}) (interface{}, error) {
 	cmd, ok := icmd.(*btcjson.NotifySpentCmd)
@@ -1933,6 +2103,76 @@ func HandleRescanBlocks(wsc *WSClient, icmd interface{}) (interface{}, error) {
 	return &discoveredData, nil
 }
 
+// HandleSearchRawTransactionsStream implements the searchrawtransactionsstream command extension for websocket
+// connections. The transactions involving the address are sent, in the same order as searchrawtransactions returns
+// them, as searchrawtransactionsbatch notifications of at most the batch size, so the whole history of the address is
+// never held in memory at once. Each batch is written to the client before the next one is loaded, so a slow client
+// slows the search down rather than letting the batches pile up, and the search stops when the client disconnects.
+// The cursor of each batch can be passed back to continue the search after it.
+//
+// NOTE: This is a pod extension.
+func HandleSearchRawTransactionsStream(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SearchRawTransactionsStreamCmd)
+	if !ok {
+		return nil, btcjson.ErrRPCInternal
+	}
+	var cursor int
+	if cmd.Cursor != nil && *cmd.Cursor > 0 {
+		cursor = *cmd.Cursor
+	}
+	batchSize := 100
+	if cmd.BatchSize != nil {
+		batchSize = *cmd.BatchSize
+	}
+	if batchSize < 1 || batchSize > MaxSearchStreamBatchSize {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("Batch size must be between 1 and %d", MaxSearchStreamBatchSize),
+		}
+	}
+	result := &btcjson.SearchRawTransactionsStreamResult{Cursor: cursor}
+	for {
+		search := btcjson.NewSearchRawTransactionsCmd(cmd.Address, btcjson.Int(1), btcjson.Int(result.Cursor),
+			btcjson.Int(batchSize), cmd.VinExtra, cmd.Reverse, cmd.FilterAddrs)
+		res, err := HandleSearchRawTransactions(wsc.Server, search, wsc.Quit)
+		if err != nil {
+			// There are no transactions after the cursor.
+			if jErr, ok := err.(*btcjson.RPCError); ok && jErr.Code == btcjson.ErrRPCNoTxInfo {
+				result.Complete = true
+				return result, nil
+			}
+			return nil, err
+		}
+		txs, _ := res.([]btcjson.SearchRawTransactionsResult)
+		result.Cursor += len(txs)
+		result.Count += len(txs)
+		n := btcjson.NewSearchRawTransactionsBatchNtfn(cmd.Address, result.Cursor, txs)
+		mn, err := btcjson.MarshalCmd(nil, n)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		// The batch is sent as a message rather than queued as a notification so that the search waits until it is
+		// written, which also keeps the batches in order before the reply.
+		done := make(chan bool, 1)
+		wsc.SendMessage(mn, done)
+		select {
+		case sent := <-done:
+			if !sent {
+				Debugf("stopped searchrawtransactionsstream at cursor %d for disconnected client", result.Cursor)
+				return nil, nil
+			}
+		case <-wsc.Quit:
+			Debugf("stopped searchrawtransactionsstream at cursor %d for disconnected client", result.Cursor)
+			return nil, nil
+		}
+		if len(txs) < batchSize {
+			result.Complete = true
+			return result, nil
+		}
+	}
+}
+
 // HandleSession implements the session command extension for websocket connections.
 func HandleSession(wsc *WSClient, icmd interface{}) (interface{}, error) {
 	return &btcjson.SessionResult{SessionID: wsc.SessionID}, nil
@@ -1951,6 +2191,12 @@ func HandleStopNotifyNewTransactions(wsc *WSClient, icmd interface{}) (interface
 	return nil, nil
 }
 
+// HandleStopNotifyMempoolEvents implements the stopnotifymempoolevents command extension for websocket connections.
+func HandleStopNotifyMempoolEvents(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.UnregisterMempoolEvents(wsc)
+	return nil, nil
+}
+
 // HandleStopNotifyReceived implements the stopnotifyreceived command extension for websocket connections.
 func HandleStopNotifyReceived(wsc *WSClient, icmd interface{}) (interface{},
 	error) {
@@ -1970,6 +2216,13 @@ func HandleStopNotifyReceived(wsc *WSClient, icmd interface{}) (interface{},
 	return nil, nil
 }
 
+// HandleStopNotifyTemplateEvictions implements the stopnotifytemplateevictions command extension for websocket
+// connections.
+func HandleStopNotifyTemplateEvictions(wsc *WSClient, icmd interface{}) (interface{}, error) {
+	wsc.Server.NtfnMgr.UnregisterTemplateEvictions(wsc)
+	return nil, nil
+}
+
 // HandleStopNotifySpent implements the stopnotifyspent command extension for websocket connections.
 func HandleStopNotifySpent(wsc *WSClient, icmd interface{}) (interface{}, error) {
 	cmd, ok := icmd.(*btcjson.StopNotifySpentCmd)
@@ -2059,6 +2312,7 @@ func NewWSClientFilter(addresses []string, unspentOutPoints []wire.OutPoint,
 		OtherAddresses:      map[string]struct{}{},
 		Unspent: make(map[wire.OutPoint]struct{},
 			len(unspentOutPoints)),
+		Scripts: map[string]struct{}{},
 	}
 	for _, s := range addresses {
 		filter.AddAddressStr(s, params)
@@ -2312,47 +2566,9 @@ func RescanBlockFilter(filter *WSClientFilter, block *util.Block,
 	var transactions []string
 	filter.mu.Lock()
 	for _, tx := range block.Transactions() {
-		msgTx := tx.MsgTx()
-		// Keep track of whether the transaction has already been added to the result. It shouldn't be added twice.
-		added := false
-		// Scan inputs if not a coinbase transaction.
-		if !blockchain.IsCoinBaseTx(msgTx) {
-			for _, input := range msgTx.TxIn {
-				if !filter.ExistsUnspentOutPoint(&input.PreviousOutPoint) {
-					continue
-				}
-				if !added {
-					transactions = append(
-						transactions,
-						TxHexString(msgTx))
-					added = true
-				}
-			}
-		}
-		// Scan outputs.
-		for i, output := range msgTx.TxOut {
-			_, addrs, _, err := txscript.ExtractPkScriptAddrs(
-				output.PkScript, params)
-			if err != nil {
-				Error(err)
-				continue
-			}
-			for _, a := range addrs {
-				if !filter.ExistsAddress(a) {
-					continue
-				}
-				op := wire.OutPoint{
-					Hash:  *tx.Hash(),
-					Index: uint32(i),
-				}
-				filter.AddUnspentOutPoint(&op)
-				if !added {
-					transactions = append(
-						transactions,
-						TxHexString(msgTx))
-					added = true
-				}
-			}
+		// A transaction is added to the result once, whatever number of its inputs and outputs match.
+		if match := filter.MatchTx(tx, params); match.Matched() {
+			transactions = append(transactions, TxHexString(tx.MsgTx()))
 		}
 	}
 	filter.mu.Unlock()
@@ -7,10 +7,13 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"net"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
@@ -24,6 +27,7 @@ import (
 	log "github.com/p9c/pod/pkg/util/logi"
 	"github.com/p9c/pod/pkg/util/logi/consume"
 
+	"github.com/p9c/pod/cmd/kopach/control/hashrate"
 	"github.com/p9c/pod/cmd/node/mempool"
 	"github.com/p9c/pod/cmd/node/state"
 	"github.com/p9c/pod/cmd/node/version"
@@ -33,22 +37,36 @@ import (
 	"github.com/p9c/pod/pkg/chain/fork"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
 	indexers "github.com/p9c/pod/pkg/chain/index"
+	"github.com/p9c/pod/pkg/chain/mining"
 	netsync "github.com/p9c/pod/pkg/chain/sync"
 	txscript "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/coding/bloom"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/comm/nodeauth"
 	"github.com/p9c/pod/pkg/comm/peer"
 	"github.com/p9c/pod/pkg/comm/peer/addrmgr"
 	"github.com/p9c/pod/pkg/comm/peer/connmgr"
+	"github.com/p9c/pod/pkg/comm/telemetry"
+	"github.com/p9c/pod/pkg/comm/torcontrol"
 	"github.com/p9c/pod/pkg/comm/upnp"
 	database "github.com/p9c/pod/pkg/db"
 	"github.com/p9c/pod/pkg/pod"
+	"github.com/p9c/pod/pkg/rpc/audit"
+	rpcclient "github.com/p9c/pod/pkg/rpc/client"
 	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/metrics"
 )
 
 const DefaultMaxOrphanTxSize = 100000
 
 type (
+	// BanPeerMsg asks the peer handler to ban a peer for the duration, or the configured ban duration when it is zero.
+	BanPeerMsg struct {
+		Peer     *NodePeer
+		Duration time.Duration
+		Reason   string
+	}
 	// BroadcastInventoryAdd is a type used to declare that the InvVect it contains needs to be added to the rebroadcast
 	// map
 	BroadcastInventoryAdd RelayMsg
@@ -69,7 +87,10 @@ type (
 	}
 	// CheckpointSorter implements sort.Interface to allow a slice of checkpoints to be sorted.
 	CheckpointSorter []chaincfg.Checkpoint
-	ConnectNodeMsg   struct {
+	ClearBannedMsg   struct {
+		Reply chan struct{}
+	}
+	ConnectNodeMsg struct {
 		Addr      string
 		Permanent bool
 		Reply     chan error
@@ -91,6 +112,9 @@ type (
 	GetPeersMsg struct {
 		Reply chan []*NodePeer
 	}
+	ListBannedMsg struct {
+		Reply chan []connmgr.BanEntry
+	}
 	// OnionAddr implements the net.Addr interface and represents a tor address.
 	OnionAddr struct {
 		Addr string
@@ -100,9 +124,14 @@ type (
 		InboundPeers    map[int32]*NodePeer
 		OutboundPeers   map[int32]*NodePeer
 		PersistentPeers map[int32]*NodePeer
-		Banned          map[string]time.Time
+		Banned          connmgr.BanList
 		OutboundGroups  map[string]int
 	}
+	// PeerMisbehavedMsg reports an offense of a peer found outside of the server, such as by the sync manager.
+	PeerMisbehavedMsg struct {
+		Peer    *peer.Peer
+		Offense connmgr.Offense
+	}
 	// RelayMsg packages an inventory vector along with the newly discovered inventory so the relay has access to that
 	// information.
 	RelayMsg struct {
@@ -113,6 +142,13 @@ type (
 		Cmp   func(*NodePeer) bool
 		Reply chan error
 	}
+	// SetBanMsg bans the subnet until the given time, or removes its ban.
+	SetBanMsg struct {
+		Subnet *net.IPNet
+		Until  time.Time
+		Remove bool
+		Reply  chan error
+	}
 	// Node provides a bitcoin Node for handling communications to and from bitcoin peers.
 	Node struct {
 		// The following variables must only be used atomically. Putting the uint64s first makes them 64-bit aligned for
@@ -133,7 +169,7 @@ type (
 		ModifyRebroadcastInv chan interface{}
 		NewPeers             chan *NodePeer
 		DonePeers            chan *NodePeer
-		BanPeers             chan *NodePeer
+		BanPeers             chan BanPeerMsg
 		Query                chan interface{}
 		RelayInv             chan RelayMsg
 		Broadcast            chan BroadcastMsg
@@ -144,16 +180,40 @@ type (
 		DB                   database.DB
 		TimeSource           blockchain.MedianTimeSource
 		Services             wire.ServiceFlag
+		// OnionTarget is the address of the listener the tor hidden service of the node forwards connections to, it is
+		// empty when no hidden service is created
+		OnionTarget string
+		// NodeKey is the identity key the node authenticates itself to its peers with, and PeerAllow the keys of the
+		// peers it accepts, when peer authentication is enabled. NodeKey is nil otherwise.
+		NodeKey   *ec.PrivateKey
+		PeerAllow nodeauth.Allowlist
+		// Offenses are the ban scores of the kinds of misbehavior of peers, with the scores set in the configuration
+		Offenses connmgr.OffenseScores
 		// The following fields are used for optional indexes. They will be nil if the associated index is not enabled.
 		//
 		// These fields are set during initial creation of the server and never changed afterwards, so they do not need
 		// to be protected for concurrent access.
-		TxIndex   *indexers.TxIndex
-		AddrIndex *indexers.AddrIndex
-		CFIndex   *indexers.CFIndex
+		TxIndex    *indexers.TxIndex
+		WTxIndex   *indexers.WTxIndex
+		AddrIndex  *indexers.AddrIndex
+		CFIndex    *indexers.CFIndex
+		SpentIndex *indexers.SpentIndex
+		// IndexManager catches up the enabled optional indexes with the chain, nil if none are enabled
+		IndexManager *indexers.Manager
 		// The fee estimator keeps track of how long transactions are left in the mempool before they are mined into
 		// blocks.
 		FeeEstimator *mempool.FeeEstimator
+		// Telemetry sends anonymous node statistics if the user has opted in
+		Telemetry *telemetry.Reporter
+		// Announcements holds the verified maintainer announcements received from the network
+		Announcements *Announcements
+		// Metrics are the metrics of the node, which are served by MetricsServer when enabled
+		Metrics       *metrics.Registry
+		MetricsServer *http.Server
+		// AuditLog records the state-changing commands run on the RPC servers of the node
+		AuditLog *audit.Log
+		// WalletClient forwards the wallet commands received by the RPC servers when rpcwalletproxy is set
+		WalletClient *rpcclient.Client
 		// CFCheckptCaches stores a cached slice of filter headers for cfcheckpt messages for each filter type.
 		CFCheckptCaches    map[wire.FilterType][]CFHeaderKV
 		CFCheckptCachesMtx sync.RWMutex
@@ -171,6 +231,8 @@ type (
 		*peer.Peer
 		// The following variables must only be used atomically
 		FeeFilter      int64
+		// AddrProcessed is the number of addresses the peer advertised that were passed to the address manager.
+		AddrProcessed  uint64
 		ConnReq        *connmgr.ConnReq
 		Server         *Node
 		ContinueHash   *chainhash.Hash
@@ -178,12 +240,14 @@ type (
 		Filter         *bloom.Filter
 		KnownAddresses map[string]struct{}
 		BanScore       connmgr.DynamicBanScore
+		Latency        *PeerLatency
 		Quit           chan struct{}
 		// The following chans are used to sync blockmanager and server.
 		TxProcessed    chan struct{}
 		BlockProcessed chan struct{}
 		SentAddrs      bool
-		IsWhitelisted  bool
+		// Permissions are granted to the peer by the whitelists its address is in.
+		Permissions    connmgr.Permissions
 		Persistent     bool
 		DisableRelayTx bool
 	}
@@ -205,7 +269,8 @@ type (
 )
 
 const (
-	// DefaultServices describes the default services that are supported by the server.
+	// DefaultServices describes the default services that are supported by the server. The bloom and committed filter
+	// services are removed when serving them is disabled.
 	DefaultServices = wire.SFNodeNetwork | wire.SFNodeBloom |
 		wire.SFNodeWitness | wire.SFNodeCF
 	// DefaultRequiredServices describes the default services that are required to be supported by outbound peers.
@@ -215,6 +280,21 @@ const (
 	// ConnectionRetryInterval is the base amount of time to wait in between retries when connecting to persistent
 	// peers. It is adjusted by the number of retries such that there is a retry backoff.
 	ConnectionRetryInterval = time.Second
+	// OnionKeyFileName is the name of the file in the network data directory that the key of the tor hidden service
+	// is kept in, so that the onion address of the node stays the same.
+	OnionKeyFileName = "onion_private_key"
+	// OnionServiceRetryInterval is how long to wait before trying again to create the tor hidden service when the tor
+	// control port can't be used.
+	OnionServiceRetryInterval = time.Minute
+	// NodeKeyFileName is the name of the file in the network data directory that the identity key of the node used for
+	// peer authentication is kept in.
+	NodeKeyFileName = "node_private_key"
+	// NodeAuthTimeout is how long a peer has to complete the authentication handshake.
+	NodeAuthTimeout = time.Second * 10
+	// BanListFileName is the name of the file in the network data directory that the bans of the node are kept in.
+	BanListFileName = "banlist.json"
+	// ManualBanReason is the reason given for the bans added with the setban command.
+	ManualBanReason = "manually added"
 )
 
 var (
@@ -285,8 +365,8 @@ func (n *Node) AddPeer(sp *NodePeer) {
 // AddRebroadcastInventory adds 'iv' to the list of inventories to be rebroadcasted at random intervals until they show
 // up in a block.
 func (n *Node) AddRebroadcastInventory(iv *wire.InvVect, data interface{}) {
-	// Ignore if shutting down.
-	if atomic.LoadInt32(&n.Shutdown) != 0 {
+	// Ignore if shutting down or not relaying transactions.
+	if atomic.LoadInt32(&n.Shutdown) != 0 || *n.Config.NoRelay {
 		return
 	}
 	n.ModifyRebroadcastInv <- BroadcastInventoryAdd{InvVect: iv, Data: data}
@@ -297,8 +377,10 @@ func (n *Node) AddRebroadcastInventory(iv *wire.InvVect, data interface{}) {
 //
 // This function should be called whenever new transactions are added to the mempool.
 func (n *Node) AnnounceNewTransactions(txns []*mempool.TxDesc) {
-	// Generate and relay inventory vectors for all newly accepted transactions.
-	n.RelayTransactions(txns)
+	// Generate and relay inventory vectors for all newly accepted transactions, unless transaction relay is turned off.
+	if !*n.Config.NoRelay {
+		n.RelayTransactions(txns)
+	}
 	// Notify both websocket and getblocktemplate long poll clients of all newly accepted transactions.
 	for i := range n.RPCServers {
 		if n.RPCServers[i] != nil {
@@ -307,9 +389,15 @@ func (n *Node) AnnounceNewTransactions(txns []*mempool.TxDesc) {
 	}
 }
 
-// BanPeer bans a peer that has already been connected to the server by ip.
-func (n *Node) BanPeer(sp *NodePeer) {
-	n.BanPeers <- sp
+// BanPeer bans a peer that has already been connected to the server by ip, for the duration or the configured ban
+// duration when it is zero.
+func (n *Node) BanPeer(sp *NodePeer, duration time.Duration, reason string) {
+	n.BanPeers <- BanPeerMsg{Peer: sp, Duration: duration, Reason: reason}
+}
+
+// BanListPath returns the path of the file the bans of the node are saved in.
+func (n *Node) BanListPath() string {
+	return filepath.Join(*n.Config.DataDir, n.ActiveNet.Name, BanListFileName)
 }
 
 // BroadcastMessage sends msg to all peers currently connected to the server except those in the passed peers to
@@ -416,6 +504,26 @@ func (n *Node) Start() {
 		n.WG.Add(1)
 		go n.UPNPUpdateThread()
 	}
+	if n.OnionTarget != "" {
+		n.WG.Add(1)
+		go n.OnionServiceThread()
+	}
+	n.WG.Add(1)
+	go func() {
+		n.Telemetry.Run(n.Quit)
+		n.WG.Done()
+	}()
+	if *n.Config.Metrics {
+		listener := *n.Config.MetricsListener
+		if listener == "" {
+			listener = metrics.DefaultListener
+		}
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", n.Metrics)
+		n.MetricsServer = &http.Server{Addr: listener, Handler: mux}
+		n.WG.Add(1)
+		go n.ServeMetrics()
+	}
 	if !*n.Config.DisableRPC {
 		n.WG.Add(1)
 		// Start the rebroadcastHandler, which ensures user tx received by the RPC server are rebroadcast until being
@@ -450,6 +558,18 @@ func (n *Node) Start() {
 	// })
 }
 
+// TelemetryStats gathers the statistics included in telemetry reports
+func (n *Node) TelemetryStats() telemetry.Stats {
+	return telemetry.Stats{
+		Version: version.Version(),
+		Network: n.ActiveNet.Name,
+		Height:  n.Chain.BestSnapshot().Height,
+		Peers:   n.ConnectedCount(),
+		Synced:  n.SyncManager.IsCurrent(),
+		Uptime:  time.Now().Unix() - n.StartupTime,
+	}
+}
+
 // Stop gracefully shuts down the server by stopping and disconnecting all peers and the main listener.
 func (n *Node) Stop() (err error) {
 	// Make sure this only happens once.
@@ -458,7 +578,10 @@ func (n *Node) Stop() (err error) {
 		return nil
 	}
 	Trace("node shutting down")
-
+	if n.MetricsServer != nil {
+		if err = n.MetricsServer.Close(); Check(err) {
+		}
+	}
 	// Shutdown the RPC server if it'n not disabled.
 	if !*n.Config.DisableRPC {
 		for i := range n.RPCServers {
@@ -468,6 +591,11 @@ func (n *Node) Stop() (err error) {
 			}
 		}
 	}
+	if err = n.AuditLog.Close(); Check(err) {
+	}
+	if n.WalletClient != nil {
+		n.WalletClient.Shutdown()
+	}
 	// Save fee estimator state in the database.
 	if err = n.DB.Update(func(tx database.Tx) error {
 		metadata := tx.Metadata()
@@ -498,6 +626,27 @@ func (n *Node) TransactionConfirmed(tx *util.Tx) {
 	n.RemoveRebroadcastInventory(iv)
 }
 
+// TransactionProcessed notifies websocket clients subscribed to mempool events that a transaction relayed by source was
+// accepted into the mempool, or rejected with err.
+func (n *Node) TransactionProcessed(tx *util.Tx, source *peer.Peer, err error) {
+	for i := range n.RPCServers {
+		if n.RPCServers[i] != nil {
+			n.RPCServers[i].NotifyMempoolEvent(tx, source.Addr(), err)
+		}
+	}
+}
+
+// TransactionEvicted notifies websocket clients subscribed to template evictions that tx was evicted from the mempool,
+// or replaced by replacedBy, when it is included in issued block templates. It is called by the mempool with its lock
+// held.
+func (n *Node) TransactionEvicted(tx, replacedBy *util.Tx) {
+	for i := range n.RPCServers {
+		if n.RPCServers[i] != nil {
+			n.RPCServers[i].NotifyTemplateEviction(tx, replacedBy)
+		}
+	}
+}
+
 // UpdatePeerHeights updates the heights of all peers who have have announced the latest connected main chain block, or
 // a recognized orphan.
 //
@@ -512,6 +661,17 @@ func (n *Node) UpdatePeerHeights(latestBlkHash *chainhash.Hash,
 	}
 }
 
+// PeerMisbehaved increases the ban score of the peer for the offense and disconnects it. It doesn't wait for the peer
+// handler, so that it is safe to call from the sync manager.
+func (n *Node) PeerMisbehaved(p *peer.Peer, offense connmgr.Offense) {
+	go func() {
+		select {
+		case n.Query <- PeerMisbehavedMsg{Peer: p, Offense: offense}:
+		case <-n.Quit:
+		}
+	}()
+}
+
 // WaitForShutdown blocks until the main listener and peer handlers are stopped.
 func (n *Node) WaitForShutdown() {
 	n.WG.Wait()
@@ -535,15 +695,14 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 		sp.Disconnect()
 		return false
 	}
-	if banEnd, ok := state.Banned[host]; ok {
-		if time.Now().Before(banEnd) {
-			Debugf("peer %n is banned for another %v - disconnecting %n",
-				host, time.Until(banEnd))
-			sp.Disconnect()
-			return false
-		}
-		Infof("peer %n is no longer banned", host)
-		delete(state.Banned, host)
+	now := time.Now()
+	if state.Banned.Expire(now) {
+		n.SaveBanList(state)
+	}
+	if ban, ok := state.Banned.Banned(net.ParseIP(host), now); ok && !sp.Permissions.Has(connmgr.PermissionNoBan) {
+		Debugf("peer %s is banned for another %v - disconnecting", host, ban.Until.Sub(now))
+		sp.Disconnect()
+		return false
 	}
 	// TODO: Check for max peers from a single IP.
 
@@ -570,15 +729,35 @@ func (n *Node) HandleAddPeerMsg(state *PeerState, sp *NodePeer) bool {
 }
 
 // HandleBanPeerMsg deals with banning peers. It is invoked from the peerHandler goroutine.
-func (n *Node) HandleBanPeerMsg(state *PeerState, sp *NodePeer) {
+func (n *Node) HandleBanPeerMsg(state *PeerState, msg BanPeerMsg) {
+	sp := msg.Peer
 	host, _, err := net.SplitHostPort(sp.Addr())
 	if err != nil {
-		Errorf("can't split ban peer %n %v %n", sp.Addr(), err)
+		Errorf("can't split ban peer %s %v", sp.Addr(), err)
 		return
 	}
+	subnet, err := connmgr.ParseSubnet(host)
+	if err != nil {
+		Errorf("can't ban peer %s: %v", sp.Addr(), err)
+		return
+	}
+	duration := msg.Duration
+	if duration == 0 {
+		duration = *n.Config.BanDuration
+	}
 	direction := log.DirectionString(sp.Inbound())
-	Infof("banned peer %n (%n) for %v", host, direction, *n.Config.BanDuration)
-	state.Banned[host] = time.Now().Add(*n.Config.BanDuration)
+	Infof("banned peer %s (%s) for %v: %s", host, direction, duration, msg.Reason)
+	state.Banned.Ban(subnet, time.Now().Add(duration), msg.Reason)
+	n.SaveBanList(state)
+}
+
+// SaveBanList removes the bans that ended from the ban list and saves it to its file. It is invoked from the
+// peerHandler goroutine.
+func (n *Node) SaveBanList(state *PeerState) {
+	state.Banned.Expire(time.Now())
+	if err := state.Banned.Save(n.BanListPath()); err != nil {
+		Error("unable to save the ban list:", err)
+	}
 }
 
 // HandleBroadcastMsg deals with broadcasting messages to peers. It is invoked from the peerHandler goroutine.
@@ -592,6 +771,10 @@ func (n *Node) HandleBroadcastMsg(state *PeerState, bmsg *BroadcastMsg) {
 				return
 			}
 		}
+		// Peers that predate announcements would disconnect on receiving one.
+		if _, ok := bmsg.Message.(*wire.MsgAnnounce); ok && sp.ProtocolVersion() < wire.AnnounceVersion {
+			return
+		}
 		sp.QueueMessage(bmsg.Message, nil)
 	})
 }
@@ -749,58 +932,142 @@ func (n *Node) HandleQuery(state *PeerState, querymsg interface{}) {
 			return
 		}
 		msg.Reply <- errors.New("nodePeer not found")
+	case PeerMisbehavedMsg:
+		var misbehaving *NodePeer
+		state.ForAllPeers(func(sp *NodePeer) {
+			if sp.Peer == msg.Peer {
+				misbehaving = sp
+			}
+		})
+		if misbehaving == nil {
+			msg.Peer.Disconnect()
+			return
+		}
+		// Banning the peer is queued back to this handler, so the score is added in another goroutine.
+		go func() {
+			misbehaving.AddOffense(msg.Offense)
+			misbehaving.Disconnect()
+		}()
+	case SetBanMsg:
+		if msg.Remove {
+			if !state.Banned.Unban(msg.Subnet) {
+				msg.Reply <- errors.New("subnet is not banned")
+				return
+			}
+			Infof("removed the ban of %s", msg.Subnet)
+		} else {
+			state.Banned.Ban(msg.Subnet, msg.Until, ManualBanReason)
+			Infof("banned %s until %v", msg.Subnet, msg.Until)
+			state.ForAllPeers(func(sp *NodePeer) {
+				host, _, err := net.SplitHostPort(sp.Addr())
+				if err == nil && msg.Subnet.Contains(net.ParseIP(host)) {
+					sp.Disconnect()
+				}
+			})
+		}
+		n.SaveBanList(state)
+		msg.Reply <- nil
+	case ListBannedMsg:
+		state.Banned.Expire(time.Now())
+		bans := make([]connmgr.BanEntry, 0, len(state.Banned))
+		for _, entry := range state.Banned {
+			bans = append(bans, *entry)
+		}
+		sort.Slice(bans, func(i, j int) bool {
+			return bans[i].Created.Before(bans[j].Created)
+		})
+		msg.Reply <- bans
+	case ClearBannedMsg:
+		state.Banned = make(connmgr.BanList)
+		Info("cleared all bans")
+		n.SaveBanList(state)
+		msg.Reply <- struct{}{}
 	}
 }
 
 // HandleRelayInvMsg deals with relaying inventory to peers that are not already known to have it. It is invoked from
 // the peerHandler goroutine.
+//
+// Peers are relayed to in order of increasing latency. New blocks are announced to batches of RelayBatchSize peers, each
+// batch RelayBatchStagger after the previous one, so that the fastest peers get our blocks first.
 func (n *Node) HandleRelayInvMsg(state *PeerState, msg RelayMsg) {
+	var peers []*NodePeer
 	state.ForAllPeers(func(sp *NodePeer) {
-		if !sp.Connected() {
-			return
+		if sp.Connected() {
+			peers = append(peers, sp)
 		}
-		// If the inventory is a block and the peer prefers headers, generate and send a headers message instead of an
-		// inventory message.
-		if msg.InvVect.Type == wire.InvTypeBlock && sp.WantsHeaders() {
-			blockHeader, ok := msg.Data.(wire.BlockHeader)
-			if !ok {
-				Warn("underlying data for headers is not a block header")
-				return
-			}
-			msgHeaders := wire.NewMsgHeaders()
-			if err := msgHeaders.AddBlockHeader(&blockHeader); err != nil {
-				Error("failed to add block header:", err)
-				return
+	})
+	OrderByLatency(peers)
+	if msg.InvVect.Type != wire.InvTypeBlock {
+		for _, sp := range peers {
+			n.RelayInvToPeer(sp, msg)
+		}
+		return
+	}
+	for i := 0; i < len(peers); i += RelayBatchSize {
+		end := i + RelayBatchSize
+		if end > len(peers) {
+			end = len(peers)
+		}
+		batch := peers[i:end]
+		relay := func() {
+			for _, sp := range batch {
+				if sp.Connected() {
+					n.RelayInvToPeer(sp, msg)
+				}
 			}
-			sp.QueueMessage(msgHeaders, nil)
+		}
+		if i == 0 {
+			relay()
+			continue
+		}
+		time.AfterFunc(RelayBatchStagger*time.Duration(i/RelayBatchSize), relay)
+	}
+}
+
+// RelayInvToPeer relays inventory to a peer unless it is already known to have it or does not want it.
+func (n *Node) RelayInvToPeer(sp *NodePeer, msg RelayMsg) {
+	// If the inventory is a block and the peer prefers headers, generate and send a headers message instead of an
+	// inventory message.
+	if msg.InvVect.Type == wire.InvTypeBlock && sp.WantsHeaders() {
+		blockHeader, ok := msg.Data.(wire.BlockHeader)
+		if !ok {
+			Warn("underlying data for headers is not a block header")
 			return
 		}
-		if msg.InvVect.Type == wire.InvTypeTx {
-			// Don't relay the transaction to the peer when it has transaction relaying disabled.
-			if sp.IsRelayTxDisabled() {
-				return
-			}
-			txD, ok := msg.Data.(*mempool.TxDesc)
-			if !ok {
-				Warnf("underlying data for tx inv relay is not a *mempool.TxDesc: %T", msg.Data)
-				return
-			}
-			// Don't relay the transaction if the transaction fee-per-kb is less than the peer'n feefilter.
-			feeFilter := atomic.LoadInt64(&sp.FeeFilter)
-			if feeFilter > 0 && txD.FeePerKB < feeFilter {
+		msgHeaders := wire.NewMsgHeaders()
+		if err := msgHeaders.AddBlockHeader(&blockHeader); err != nil {
+			Error("failed to add block header:", err)
+			return
+		}
+		sp.QueueMessage(msgHeaders, nil)
+		return
+	}
+	if msg.InvVect.Type == wire.InvTypeTx {
+		// Don't relay the transaction to the peer when it has transaction relaying disabled.
+		if sp.IsRelayTxDisabled() {
+			return
+		}
+		txD, ok := msg.Data.(*mempool.TxDesc)
+		if !ok {
+			Warnf("underlying data for tx inv relay is not a *mempool.TxDesc: %T", msg.Data)
+			return
+		}
+		// Don't relay the transaction if the transaction fee-per-kb is less than the peer'n feefilter.
+		feeFilter := atomic.LoadInt64(&sp.FeeFilter)
+		if feeFilter > 0 && txD.FeePerKB < feeFilter {
+			return
+		}
+		// Don't relay the transaction if there is a bloom filter loaded and the transaction doesn't match it.
+		if sp.Filter.IsLoaded() {
+			if !sp.Filter.MatchTxAndUpdate(txD.Tx) {
 				return
 			}
-			// Don't relay the transaction if there is a bloom filter loaded and the transaction doesn't match it.
-			if sp.Filter.IsLoaded() {
-				if !sp.Filter.MatchTxAndUpdate(txD.Tx) {
-					return
-				}
-			}
 		}
-		// Queue the inventory to be relayed with the next batch. It will be ignored if the peer is already known to
-		// have the inventory.
-		sp.QueueInventory(msg.InvVect)
-	})
+	}
+	// Queue the inventory to be relayed with the next batch. It will be ignored if the peer is already known to
+	// have the inventory.
+	sp.QueueInventory(msg.InvVect)
 }
 
 // handleUpdatePeerHeight updates the heights of all peers who were known to announce a block we recently accepted.
@@ -830,8 +1097,18 @@ func (n *Node) HandleUpdatePeerHeights(state *PeerState,
 // initializes a new inbound server peer instance, associates it with the connection, and starts a goroutine to wait for
 // disconnection.
 func (n *Node) InboundPeerConnected(conn net.Conn) {
+	if n.NodeKey != nil {
+		authConn, err := nodeauth.Handshake(conn, n.NodeKey, n.PeerAllow, false, NodeAuthTimeout)
+		if err != nil {
+			Warnf("refusing inbound peer %s: %v", conn.RemoteAddr(), err)
+			if err = conn.Close(); Check(err) {
+			}
+			return
+		}
+		conn = authConn
+	}
 	sp := NewServerPeer(n, false)
-	sp.IsWhitelisted = GetIsWhitelisted(n.StateCfg, conn.RemoteAddr())
+	sp.Permissions = GetPermissions(n.StateCfg, conn.RemoteAddr())
 	sp.Peer = peer.NewInboundPeer(NewPeerConfig(sp))
 	sp.AssociateConnection(conn)
 	go n.PeerDoneHandler(sp)
@@ -841,6 +1118,15 @@ func (n *Node) InboundPeerConnected(conn net.Conn) {
 // initializes a new outbound server peer instance, associates it with the relevant state such as the connection request
 // instance and the connection itself, and finally notifies the address manager of the attempt.
 func (n *Node) OutboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
+	if n.NodeKey != nil {
+		authConn, err := nodeauth.Handshake(conn, n.NodeKey, n.PeerAllow, true, NodeAuthTimeout)
+		if err != nil {
+			Warnf("can't authenticate outbound peer %s: %v", c.Addr, err)
+			n.ConnManager.Disconnect(c.ID())
+			return
+		}
+		conn = authConn
+	}
 	sp := NewServerPeer(n, c.Permanent)
 	p, err := peer.NewOutboundPeer(NewPeerConfig(sp), c.Addr.String())
 	if err != nil {
@@ -849,7 +1135,7 @@ func (n *Node) OutboundPeerConnected(c *connmgr.ConnReq, conn net.Conn) {
 	}
 	sp.Peer = p
 	sp.ConnReq = c
-	sp.IsWhitelisted = GetIsWhitelisted(n.StateCfg, conn.RemoteAddr())
+	sp.Permissions = GetPermissions(n.StateCfg, conn.RemoteAddr())
 	sp.AssociateConnection(conn)
 	go n.PeerDoneHandler(sp)
 	n.AddrManager.Attempt(sp.NA())
@@ -874,6 +1160,21 @@ func (n *Node) PeerDoneHandler(sp *NodePeer) {
 	close(sp.Quit)
 }
 
+// LoadBanList loads the bans of the node saved in the ban list file, dropping those that ended. The node starts without
+// bans if the file can't be read.
+func (n *Node) LoadBanList() connmgr.BanList {
+	banned, err := connmgr.LoadBanList(n.BanListPath())
+	if err != nil {
+		Error("unable to load the ban list:", err)
+		return make(connmgr.BanList)
+	}
+	banned.Expire(time.Now())
+	if len(banned) > 0 {
+		Infof("loaded %d bans", len(banned))
+	}
+	return banned
+}
+
 // PeerHandler is used to handle peer operations such as adding and removing peers to and from the server, banning
 // peers, and broadcasting messages to peers. It must be run in a goroutine.
 func (n *Node) PeerHandler() {
@@ -887,10 +1188,11 @@ func (n *Node) PeerHandler() {
 		InboundPeers:    make(map[int32]*NodePeer),
 		PersistentPeers: make(map[int32]*NodePeer),
 		OutboundPeers:   make(map[int32]*NodePeer),
-		Banned:          make(map[string]time.Time),
+		Banned:          n.LoadBanList(),
 		OutboundGroups:  make(map[string]int),
 	}
-	if !*n.Config.DisableDNSSeed || len(*n.Config.ConnectPeers) < 0 {
+	// The DNS seeds only know of public nodes, which can't authenticate as peers of a private network.
+	if (!*n.Config.DisableDNSSeed || len(*n.Config.ConnectPeers) < 0) && n.NodeKey == nil {
 		// Add peers discovered through DNS to the address manager.
 		connmgr.SeedFromDNS(n.ActiveNet, DefaultRequiredServices,
 			Lookup(n.StateCfg), func(addrs []*wire.NetAddress) {
@@ -916,8 +1218,8 @@ out:
 		case umsg := <-n.PeerHeightsUpdate:
 			n.HandleUpdatePeerHeights(peerState, umsg)
 		// Peer to ban.
-		case p := <-n.BanPeers:
-			n.HandleBanPeerMsg(peerState, p)
+		case msg := <-n.BanPeers:
+			n.HandleBanPeerMsg(peerState, msg)
 		// New inventory to potentially be relayed to other peers.
 		case invMsg := <-n.RelayInv:
 			n.HandleRelayInvMsg(peerState, invMsg)
@@ -932,6 +1234,7 @@ out:
 				Tracef("shutdown peer %n", sp)
 				sp.Disconnect()
 			})
+			n.SaveBanList(peerState)
 			break out
 		}
 	}
@@ -967,6 +1270,14 @@ cleanup:
 func (n *Node) PushBlockMsg(sp *NodePeer, hash *chainhash.Hash,
 	doneChan chan<- struct{}, waitChan <-chan struct{},
 	encoding wire.MessageEncoding) error {
+	// Only the blocks retained by pruning can be served.
+	if n.Chain.IsBlockPruned(hash) {
+		Debugf("not serving pruned block %v to %s", hash, sp)
+		if doneChan != nil {
+			doneChan <- struct{}{}
+		}
+		return fmt.Errorf("block %v has been pruned", hash)
+	}
 	// Fetch the raw block bytes from the database.
 	var blockBytes []byte
 	err := sp.Server.DB.View(func(dbTx database.Tx) error {
@@ -1036,6 +1347,14 @@ func (n *Node) PushMerkleBlockMsg(sp *NodePeer, hash *chainhash.Hash,
 		}
 		return nil
 	}
+	// Only the blocks retained by pruning can be served.
+	if n.Chain.IsBlockPruned(hash) {
+		Debugf("not serving pruned block %v to %s", hash, sp)
+		if doneChan != nil {
+			doneChan <- struct{}{}
+		}
+		return fmt.Errorf("block %v has been pruned", hash)
+	}
 	// Fetch the raw block bytes from the database.
 	blk, err := sp.Server.Chain.BlockByHash(hash)
 	if err != nil {
@@ -1205,27 +1524,161 @@ out:
 	n.WG.Done()
 }
 
+// OnionServiceThread creates a tor hidden service for the peer listener through the tor control port and keeps it for as
+// long as the node runs, creating it again when the connection to the control port is lost, such as when tor restarts.
+func (n *Node) OnionServiceThread() {
+	keyFile := filepath.Join(*n.Config.DataDir, n.ActiveNet.Name, OnionKeyFileName)
+	port, _ := strconv.Atoi(n.ActiveNet.DefaultPort)
+	timer := time.NewTimer(0)
+out:
+	for {
+		select {
+		case <-timer.C:
+		case <-n.Quit:
+			break out
+		}
+		conn, err := n.AddOnionService(keyFile, port)
+		if err != nil {
+			Warnf("can't create tor hidden service with the control port at %s: %v", *n.Config.TorControl, err)
+			timer.Reset(OnionServiceRetryInterval)
+			continue
+		}
+		// The hidden service is removed by tor when the connection it was created on is closed.
+		lost := make(chan error, 1)
+		go func() {
+			lost <- conn.Wait()
+		}()
+		select {
+		case err = <-lost:
+			Warn("lost the connection to the tor control port, the tor hidden service was removed:", err)
+			timer.Reset(OnionServiceRetryInterval)
+		case <-n.Quit:
+			if err = conn.Close(); Check(err) {
+			}
+			<-lost
+			break out
+		}
+	}
+	timer.Stop()
+	n.WG.Done()
+}
+
+// AddOnionService connects to the tor control port and creates the hidden service with the key kept in keyFile, or with
+// a new key that is then saved to it, returning the connection the service lasts for.
+func (n *Node) AddOnionService(keyFile string, port int) (conn *torcontrol.Conn, err error) {
+	if conn, err = torcontrol.Dial(*n.Config.TorControl, DefaultConnectTimeout); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			if cErr := conn.Close(); Check(cErr) {
+			}
+		}
+	}()
+	if err = conn.Authenticate(*n.Config.TorPassword); err != nil {
+		return nil, err
+	}
+	key := torcontrol.NewKey
+	if b, rErr := ioutil.ReadFile(keyFile); rErr == nil {
+		key = strings.TrimSpace(string(b))
+	}
+	var serviceID, newKey string
+	if serviceID, newKey, err = conn.AddOnion(key, port, n.OnionTarget); err != nil {
+		return nil, err
+	}
+	if newKey != "" {
+		if err = ioutil.WriteFile(keyFile, []byte(newKey), 0600); err != nil {
+			return nil, err
+		}
+	}
+	Infof("accepting peers over tor at %s.onion:%d", serviceID, port)
+	// Advertise the hidden service to peers, which relay it in addrv2 messages.
+	na, aErr := n.AddrManager.HostToNetAddress(serviceID+".onion", uint16(port), n.Services)
+	if aErr == nil {
+		aErr = n.AddrManager.AddLocalAddress(na, addrmgr.ManualPrio)
+	}
+	if aErr != nil {
+		Warn("unable to advertise the tor hidden service:", aErr)
+	}
+	return conn, nil
+}
+
+// LoadNodeAuth loads the identity key of the node from the network data directory, creating it the first time, and the
+// allowlist of the keys of the peers it accepts, for peer authentication.
+func LoadNodeAuth(cx *Context) (key *ec.PrivateKey, allowed nodeauth.Allowlist, err error) {
+	if allowed, err = nodeauth.ParseAllowlist(*cx.Config.PeerAllow); err != nil {
+		return nil, nil, err
+	}
+	if len(allowed) == 0 {
+		return nil, nil, errors.New("peerauth is enabled but no node keys are allowed with peerallow")
+	}
+	dir := filepath.Join(*cx.Config.DataDir, cx.ActiveNet.Name)
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return nil, nil, err
+	}
+	var created bool
+	if key, created, err = nodeauth.LoadOrCreateKey(filepath.Join(dir, NodeKeyFileName)); err != nil {
+		return nil, nil, err
+	}
+	if created {
+		Info("created a new node key for peer authentication")
+	}
+	Infof("authenticating peers, the node key of this node is %s", nodeauth.KeyString(key.PubKey()))
+	return key, allowed, nil
+}
+
+// OnionTarget returns the address that the tor hidden service forwards connections to, which is the first IPv4
+// listener, or the first listener if there is none, on the loopback interface if it listens on all interfaces.
+func OnionTarget(listeners []net.Listener) string {
+	addr := listeners[0].Addr()
+	for _, l := range listeners {
+		if tcpAddr, ok := l.Addr().(*net.TCPAddr); ok && tcpAddr.IP.To4() != nil {
+			addr = tcpAddr
+			break
+		}
+	}
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return addr.String()
+	}
+	ip := tcpAddr.IP
+	if ip.IsUnspecified() {
+		if ip.To4() != nil {
+			ip = net.IPv4(127, 0, 0, 1)
+		} else {
+			ip = net.IPv6loopback
+		}
+	}
+	return net.JoinHostPort(ip.String(), strconv.Itoa(tcpAddr.Port))
+}
+
 // OnAddr is invoked when a peer receives an addr bitcoin message and is used to notify the server about advertised addresses.
 func (np *NodePeer) OnAddr(_ *peer.Peer,
 	msg *wire.MsgAddr) {
-	// Ignore addresses when running on the simulation test network. This helps prevent the network from becoming
-	// another public test network since it will not be able to learn about other peers that have not specifically been
-	// provided.
-	if (*np.Server.Config.Network)[0] == 's' {
-		return
-	}
-	// Ignore old style addresses which don't include a timestamp.
-	if np.ProtocolVersion() < wire.NetAddressTimeVersion {
+	np.AddAdvertisedAddresses(msg.Command(), msg.AddrList)
+}
+
+// OnAddrV2 is invoked when a peer receives an addrv2 bitcoin message, which can also carry Tor v3 and I2P addresses, and
+// is used to notify the server about advertised addresses.
+func (np *NodePeer) OnAddrV2(_ *peer.Peer, msg *wire.MsgAddrV2) {
+	np.AddAdvertisedAddresses(msg.Command(), msg.AddrList)
+}
+
+// AddAdvertisedAddresses adds the addresses advertised by the peer in an addr or addrv2 message to the known addresses
+// of the peer and to the address manager.
+func (np *NodePeer) AddAdvertisedAddresses(command string, addrList []*wire.NetAddress) {
+	// Ignore addresses on the simulation test network and old style addresses which don't include a timestamp.
+	if !np.AddrRelayEnabled() {
 		return
 	}
 	// A message that has no addresses is invalid.
-	if len(msg.AddrList) == 0 {
+	if len(addrList) == 0 {
 		Errorf("command [%s] from %s does not contain any addresses",
-			msg.Command(), np.Peer)
+			command, np.Peer)
 		np.Disconnect()
 		return
 	}
-	for _, na := range msg.AddrList {
+	for _, na := range addrList {
 		// Don't add more address if we're disconnecting.
 		if !np.Connected() {
 			return
@@ -1242,7 +1695,17 @@ func (np *NodePeer) OnAddr(_ *peer.Peer,
 	// Add addresses to server address manager. The address manager handles the details of things such as preventing
 	// duplicate addresses, max addresses, and last seen updates. XXX bitcoind gives a 2 hour time penalty here, do we
 	// want to do the same?
-	np.Server.AddrManager.AddAddresses(msg.AddrList, np.NA())
+	np.Server.AddrManager.AddAddresses(addrList, np.NA())
+	atomic.AddUint64(&np.AddrProcessed, uint64(len(addrList)))
+}
+
+// AddrRelayEnabled returns whether the addresses advertised by the peer are passed to the address manager. Addresses
+// are ignored when running on the simulation test network. This helps prevent the network from becoming another public
+// test network since it will not be able to learn about other peers that have not specifically been provided. Peers
+// too old to include timestamps in their addresses are ignored as well.
+func (np *NodePeer) AddrRelayEnabled() bool {
+	return (*np.Server.Config.Network)[0] != 's' &&
+		np.ProtocolVersion() >= wire.NetAddressTimeVersion
 }
 
 // OnBlock is invoked when a peer receives a block bitcoin message. It blocks until the bitcoin block has been fully
@@ -1254,6 +1717,7 @@ func (np *NodePeer) OnBlock(_ *peer.Peer, msg *wire.MsgBlock, buf []byte) {
 	// Add the block to the known inventory for the peer.
 	iv := wire.NewInvVect(wire.InvTypeBlock, block.Hash())
 	np.AddKnownInventory(iv)
+	np.Latency.BlockServed(block.Hash(), time.Now())
 	// Queue the block up to be handled by the block manager and intentionally block further receives until the bitcoin
 	// block is fully processed and known good or bad.
 	//
@@ -1293,7 +1757,7 @@ func (np *NodePeer) OnFilterAdd(_ *peer.Peer,
 		return
 	}
 	if !np.Filter.IsLoaded() {
-		Debugf("%s sent a filteradd request with no filter loaded -- disconnecting %s", np)
+		Debugf("%s sent a filteradd request with no filter loaded -- disconnecting", np)
 		np.Disconnect()
 		return
 	}
@@ -1311,7 +1775,7 @@ func (np *NodePeer) OnFilterClear(_ *peer.Peer,
 	}
 	if !np.Filter.IsLoaded() {
 		Debugf("%s sent a filterclear request with no filter loaded"+
-			" -- disconnecting %s", np)
+			" -- disconnecting", np)
 		np.Disconnect()
 		return
 	}
@@ -1370,6 +1834,12 @@ func (np *NodePeer) OnGetBlocks(_ *peer.Peer,
 	chain := np.Server.Chain
 	hashList := chain.LocateBlocks(msg.BlockLocatorHashes, &msg.HashStop,
 		wire.MaxBlocksPerMsg)
+	// A pruned node doesn't announce blocks it can no longer serve. The blocks follow on from the locator, so when the
+	// first has been pruned the peer has to sync the history before the retained window from a full node.
+	if len(hashList) > 0 && chain.IsBlockPruned(&hashList[0]) {
+		Debugf("not announcing pruned blocks from %v to %s", hashList[0], np)
+		return
+	}
 	// Generate inventory message.
 	invMsg := wire.NewMsgInv()
 	for i := range hashList {
@@ -1667,13 +2137,23 @@ func (np *NodePeer) OnGetData(_ *peer.Peer, msg *wire.MsgGetData) {
 	//
 	// Sustained bursts of small requests are not penalized as that would potentially ban peers performing IBD. This
 	// incremental score decays each minute to half of its value.
-	if np.AddBanScore(0, uint32(length)*99/wire.MaxInvPerMsg, "getdata") {
+	if np.AddScaledOffense(connmgr.OffenseGetData, uint32(length), wire.MaxInvPerMsg) {
 		return
 	}
 	// We wait on this wait channel periodically to prevent queuing far more data than we can send in a reasonable time,
 	// wasting memory.
 	//
 	// The waiting occurs after the database fetch for the next one to provide a little pipelining.
+	// Merkle blocks are filtered with the bloom filter of the peer, so requesting them is only allowed when bloom
+	// filtering is enabled.
+	for _, iv := range msg.InvList {
+		if iv.Type == wire.InvTypeFilteredBlock || iv.Type == wire.InvTypeFilteredWitnessBlock {
+			if !np.EnforceNodeBloomFlag(msg.Command()) {
+				return
+			}
+			break
+		}
+	}
 	var waitChan chan struct{}
 	doneChan := make(chan struct{}, 1)
 	for i, iv := range msg.InvList {
@@ -1772,7 +2252,7 @@ func (np *NodePeer) OnHeaders(_ *peer.Peer,
 func (np *NodePeer) OnInv(
 	_ *peer.Peer,
 	msg *wire.MsgInv) {
-	if !*np.Server.Config.BlocksOnly {
+	if !*np.Server.Config.BlocksOnly || np.Permissions.Has(connmgr.PermissionRelay) {
 		if len(msg.InvList) > 0 {
 			np.Server.SyncManager.QueueInv(msg, np.Peer)
 		}
@@ -1802,11 +2282,12 @@ func (np *NodePeer) OnInv(
 
 // OnMemPool is invoked when a peer receives a mempool bitcoin message. It creates and sends an inventory message with
 // the contents of the memory pool up to the maximum inventory allowed per message. When the peer has a bloom filter
-// loaded, the contents are filtered accordingly.
+// loaded, the contents are filtered accordingly, and transactions paying less than the peer's fee filter are left out.
 func (np *NodePeer) OnMemPool(_ *peer.Peer,
 	msg *wire.MsgMemPool) {
-	// Only allow mempool requests if the server has bloom filtering enabled.
-	if np.Server.Services&wire.SFNodeBloom != wire.SFNodeBloom {
+	// Only allow mempool requests if the server has bloom filtering enabled, or the peer has the mempool permission.
+	allowed := np.Permissions.Has(connmgr.PermissionMempool)
+	if np.Server.Services&wire.SFNodeBloom != wire.SFNodeBloom && !allowed {
 		Debug("peer", np, "sent mempool request with bloom filtering disabled"+
 			" -- disconnecting")
 		np.Disconnect()
@@ -1814,7 +2295,9 @@ func (np *NodePeer) OnMemPool(_ *peer.Peer,
 	}
 	// A decaying ban score increase is applied to prevent flooding. The ban score accumulates and passes the ban
 	// threshold if a burst of mempool messages comes from a peer. The score decays each minute to half of its value.
-	np.AddBanScore(0, 33, "mempool")
+	if !allowed {
+		np.AddOffense(connmgr.OffenseMempool)
+	}
 	// Generate inventory message with the available transactions in the transaction memory pool. Limit it to the max
 	// allowed inventory per message.
 	//
@@ -1822,8 +2305,12 @@ func (np *NodePeer) OnMemPool(_ *peer.Peer,
 	// it without double checking it here.
 	txMemPool := np.Server.TxMemPool
 	txDescs := txMemPool.TxDescs()
+	feeFilter := atomic.LoadInt64(&np.FeeFilter)
 	invMsg := wire.NewMsgInvSizeHint(uint(len(txDescs)))
 	for _, txDesc := range txDescs {
+		if feeFilter > 0 && txDesc.FeePerKB < feeFilter {
+			continue
+		}
 		// Either add all transactions when there is no bloom filter, or only the transactions that match the filter
 		// when there is one.
 		if !np.Filter.IsLoaded() || np.Filter.MatchTxAndUpdate(txDesc.Tx) {
@@ -1832,6 +2319,8 @@ func (np *NodePeer) OnMemPool(_ *peer.Peer,
 			if err != nil {
 				Error(err)
 			}
+			// The peer now knows about the transaction so it is not announced again when it is relayed.
+			np.AddKnownInventory(iv)
 			if len(invMsg.InvList)+1 > wire.MaxInvPerMsg {
 				break
 			}
@@ -1843,6 +2332,16 @@ func (np *NodePeer) OnMemPool(_ *peer.Peer,
 	}
 }
 
+// OnPong is invoked when a peer receives a pong bitcoin message and is used to add the round trip time of the ping it
+// answers to the latency of the peer.
+func (np *NodePeer) OnPong(_ *peer.Peer, msg *wire.MsgPong) {
+	// The ping statistics are only updated by the pong answering the last ping, which clears its nonce.
+	if np.LastPingNonce() != 0 {
+		return
+	}
+	np.Latency.AddPing(time.Duration(np.LastPingMicros()) * time.Microsecond)
+}
+
 // OnRead is invoked when a peer receives a message and it is used to update the bytes received by the server.
 func (np *NodePeer) OnRead(_ *peer.Peer,
 	bytesRead int, msg wire.Message, err error) {
@@ -1855,7 +2354,7 @@ func (np *NodePeer) OnRead(_ *peer.Peer,
 func (np *NodePeer) OnTx(
 	_ *peer.Peer,
 	msg *wire.MsgTx) {
-	if *np.Server.Config.BlocksOnly {
+	if *np.Server.Config.BlocksOnly && !np.Permissions.Has(connmgr.PermissionRelay) {
 		Tracef("ignoring tx %v from %v - blocksonly enabled", msg.TxHash(), np)
 		return
 	}
@@ -1864,6 +2363,10 @@ func (np *NodePeer) OnTx(
 	tx := util.NewTx(msg)
 	iv := wire.NewInvVect(wire.InvTypeTx, tx.Hash())
 	np.AddKnownInventory(iv)
+	// A transaction from a peer with the forcerelay permission that is already in the mempool is not announced again by
+	// the sync manager, so it is relayed here once it has been processed.
+	forceRelay := np.Permissions.Has(connmgr.PermissionForceRelay) &&
+		np.Server.TxMemPool.IsTransactionInPool(tx.Hash())
 	// Queue the transaction up to be handled by the sync manager and intentionally block further receives until the
 	// transaction is fully processed and known good or bad.
 	//
@@ -1871,6 +2374,12 @@ func (np *NodePeer) OnTx(
 	// disconnected) and wasting memory.
 	np.Server.SyncManager.QueueTx(tx, np.Peer, np.TxProcessed)
 	<-np.TxProcessed
+	if forceRelay {
+		if txD, err := np.Server.TxMemPool.FetchTxDesc(tx.Hash()); err == nil {
+			Debugf("force relaying transaction %v from %s", tx.Hash(), np)
+			np.Server.RelayInventory(iv, txD)
+		}
+	}
 }
 
 // OnVersion is invoked when a peer receives a version bitcoin message and is used to negotiate the protocol version
@@ -1896,8 +2405,12 @@ func (np *NodePeer) OnVersion(
 	if msg.ProtocolVersion < int32(peer.MinAcceptableProtocolVersion) {
 		return nil
 	}
-	// Reject outbound peers that are not full nodes.
+	// Reject outbound peers that are not full nodes. Pruned peers are enough once the chain is current, as the blocks
+	// still to be synced are then within the window they serve.
 	wantServices := wire.SFNodeNetwork
+	if np.Server.SyncManager.IsCurrent() && GetHasServices(msg.Services, wire.SFNodeNetworkLimited) {
+		wantServices = wire.SFNodeNetworkLimited
+	}
 	if !isInbound && !GetHasServices(msg.Services, wantServices) {
 		missingServices := wantServices & ^msg.Services
 		Debugf("rejecting peer %s with services %v due to not providing"+
@@ -1956,6 +2469,8 @@ func (np *NodePeer) OnVersion(
 	if msg.LastBlock >= hn {
 		np.Server.HighestKnown.Store(msg.LastBlock)
 	}
+	// Pass on any active maintainer announcements.
+	np.PushAnnouncements()
 	// Add valid peer to the server.
 	np.Server.AddPeer(np)
 	return nil
@@ -1965,18 +2480,51 @@ func (np *NodePeer) OnVersion(
 func (np *NodePeer) OnWrite(_ *peer.Peer, bytesWritten int,
 	msg wire.Message, err error) {
 	np.Server.AddBytesSent(uint64(bytesWritten))
+	// Time the blocks requested from the peer to measure how fast it serves them.
+	if getData, ok := msg.(*wire.MsgGetData); ok && err == nil {
+		var hashes []*chainhash.Hash
+		for _, iv := range getData.InvList {
+			if iv.Type == wire.InvTypeBlock || iv.Type == wire.InvTypeWitnessBlock {
+				hashes = append(hashes, &iv.Hash)
+			}
+		}
+		np.Latency.BlocksRequested(hashes, time.Now())
+	}
 }
 
 // AddBanScore increases the persistent and decaying ban score fields by the values passed as parameters. If the
 // resulting score exceeds half of the ban threshold, a warning is logged including the reason provided. Further, if the
 // score is above the ban threshold, the peer will be banned and disconnected.
 func (np *NodePeer) AddBanScore(persistent, transient uint32, reason string) bool {
+	return np.addBanScore(persistent, transient, reason, 0)
+}
+
+// AddOffense increases the ban score of the peer by the configured score of the offense, and bans it for the duration
+// configured for the offense if the score goes over the ban threshold.
+func (np *NodePeer) AddOffense(offense connmgr.Offense) bool {
+	return np.AddScaledOffense(offense, 1, 1)
+}
+
+// AddScaledOffense increases the ban score of the peer by the part n of of the configured score of the offense, for
+// offenses that come in sizes, and bans it like AddOffense.
+func (np *NodePeer) AddScaledOffense(offense connmgr.Offense, n, of uint32) bool {
+	score := np.Server.Offenses[offense]
+	scaled := uint32(uint64(score.Score) * uint64(n) / uint64(of))
+	if score.Transient {
+		return np.addBanScore(0, scaled, offense.String(), score.Duration)
+	}
+	return np.addBanScore(scaled, 0, offense.String(), score.Duration)
+}
+
+// addBanScore increases the ban score of the peer like AddBanScore, banning it for the duration, or the configured ban
+// duration when it is zero.
+func (np *NodePeer) addBanScore(persistent, transient uint32, reason string, duration time.Duration) bool {
 	// No warning is logged and no score is calculated if banning is disabled.
 	if *np.Server.Config.DisableBanning {
 		return false
 	}
-	if np.IsWhitelisted {
-		Debugf("misbehaving whitelisted peer %s: %s %s", np, reason)
+	if np.Permissions.Has(connmgr.PermissionNoBan) {
+		Debugf("misbehaving peer %s with the noban permission: %s", np, reason)
 		return false
 	}
 	warnThreshold := *np.Server.Config.BanThreshold >> 1
@@ -1994,7 +2542,7 @@ func (np *NodePeer) AddBanScore(persistent, transient uint32, reason string) boo
 		Warnf("misbehaving peer %s: %s -- ban score increased to %d", np, reason, score)
 		if int(score) > *np.Server.Config.BanThreshold {
 			Warnf("misbehaving peer %s -- banning and disconnecting", np)
-			np.Server.BanPeer(np)
+			np.Server.BanPeer(np, duration, reason)
 			np.Disconnect()
 			return true
 		}
@@ -2020,7 +2568,7 @@ func (np *NodePeer) IsAddressKnown(na *wire.NetAddress) bool {
 // the peer has negotiated to a protocol version that is high enough to observe the bloom filter service support bit, it
 // will be banned since it is intentionally violating the protocol.
 func (np *NodePeer) EnforceNodeBloomFlag(cmd string) bool {
-	if np.Server.Services&wire.SFNodeBloom != wire.SFNodeBloom {
+	if np.Server.Services&wire.SFNodeBloom != wire.SFNodeBloom && !np.Permissions.Has(connmgr.PermissionBloomFilter) {
 		// Ban the peer if the protocol version is high enough that the peer is knowingly violating the protocol and
 		// banning is enabled.
 		//
@@ -2029,12 +2577,12 @@ func (np *NodePeer) EnforceNodeBloomFlag(cmd string) bool {
 		if np.ProtocolVersion() >= wire.BIP0111Version &&
 			!*np.Server.Config.DisableBanning {
 			// Disconnect the peer regardless of whether it was banned.
-			np.AddBanScore(100, 0, cmd)
+			np.AddOffense(connmgr.OffenseBloomFilter)
 			np.Disconnect()
 			return false
 		}
 		// Disconnect the peer regardless of protocol version or banning state.
-		Debugf("%s sent an unsupported %s request -- disconnecting %s", np, cmd)
+		Debugf("%s sent an unsupported %s request -- disconnecting", np, cmd)
 		np.Disconnect()
 		return false
 	}
@@ -2275,6 +2823,11 @@ func InitListeners(config *pod.Config, activeNet *netparams.Params,
 		listeners = append(listeners, listener)
 	}
 	var nat upnp.NAT
+	// In onion only mode the clearnet addresses of the node are not advertised, as they would link it to its hidden
+	// service.
+	if *config.OnlyNet == "onion" {
+		return listeners, nil, nil
+	}
 	if len(*config.ExternalIPs) != 0 {
 		defaultPort, err := strconv.ParseUint(activeNet.DefaultPort, 10, 16)
 		if err != nil {
@@ -2327,28 +2880,23 @@ func InitListeners(config *pod.Config, activeNet *netparams.Params,
 	return listeners, nat, nil
 }
 
-// GetIsWhitelisted returns whether the IP address is included in the whitelisted networks and IPs.
-func GetIsWhitelisted(statecfg *state.Config, addr net.Addr) bool {
+// GetPermissions returns the permissions granted by the whitelisted networks and IPs that include the IP address.
+func GetPermissions(statecfg *state.Config, addr net.Addr) connmgr.Permissions {
 	if len(statecfg.ActiveWhitelists) == 0 {
-		return false
+		return 0
 	}
 	host, _, err := net.SplitHostPort(addr.String())
 	if err != nil {
 		Error(err)
 		Errorf("unable to SplitHostPort on '%s': %v", addr, err)
-		return false
+		return 0
 	}
 	ip := net.ParseIP(host)
 	if ip == nil {
 		Warnf("unable to parse IP '%s'", addr)
-		return false
+		return 0
 	}
-	for _, ipnet := range statecfg.ActiveWhitelists {
-		if ipnet.Contains(ip) {
-			return true
-		}
-	}
-	return false
+	return connmgr.WhitelistPermissions(statecfg.ActiveWhitelists, ip)
 }
 
 // MergeCheckpoints returns two slices of checkpoints merged into one slice such that the checkpoints are sorted by
@@ -2399,13 +2947,16 @@ NewPeerConfig(sp *NodePeer) *peer.Config {
 			OnGetCFHeaders: sp.OnGetCFHeaders,
 			OnGetCFCheckpt: sp.OnGetCFCheckpt,
 			OnFeeFilter:    sp.OnFeeFilter,
+			OnAnnounce:     sp.OnAnnounce,
 			OnFilterAdd:    sp.OnFilterAdd,
 			OnFilterClear:  sp.OnFilterClear,
 			OnFilterLoad:   sp.OnFilterLoad,
 			OnGetAddr:      sp.OnGetAddr,
 			OnAddr:         sp.OnAddr,
+			OnAddrV2:       sp.OnAddrV2,
 			OnRead:         sp.OnRead,
 			OnWrite:        sp.OnWrite,
+			OnPong:         sp.OnPong,
 			// Note: The reference client currently bans peers that send alerts not signed with its key. We could verify
 			// against their key, but since the reference client is currently unwilling to support other
 			// implementations' alert messages, we will not relay theirs.
@@ -2432,8 +2983,8 @@ type Context struct {
 	StateCfg *state.Config
 	// ActiveNet is the active net parameters
 	ActiveNet *netparams.Params
-	// Hashrate is the hash counter
-	Hashrate uberatomic.Uint64
+	// MinerStats are the statistics of the kopach workers taking work from the node's controller
+	MinerStats *hashrate.Stats
 }
 
 // NewNode returns a new pod server configured to listen on addr for the bitcoin network type specified by chainParams.
@@ -2450,6 +3001,11 @@ func NewNode(listenAddrs []string, db database.DB,
 	if *cx.Config.NoCFilters {
 		services &^= wire.SFNodeCF
 	}
+	// A pruned node can't serve the full chain, so it advertises only the recent blocks it keeps instead of itself as
+	// a full node.
+	if *cx.Config.PruneTarget > 0 {
+		services = services&^wire.SFNodeNetwork | wire.SFNodeNetworkLimited
+	}
 	aMgr := addrmgr.New(*cx.Config.DataDir+string(os.PathSeparator)+cx.ActiveNet.Name, Lookup(cx.StateCfg))
 	var listeners []net.Listener
 	var nat upnp.NAT
@@ -2464,6 +3020,26 @@ func NewNode(listenAddrs []string, db database.DB,
 			return nil, errors.New("no valid listen address")
 		}
 	}
+	var onionTarget string
+	if *cx.Config.TorControl != "" {
+		if len(listeners) > 0 {
+			onionTarget = OnionTarget(listeners)
+		} else {
+			Warn("torcontrol is set but listening is disabled, not creating a tor hidden service")
+		}
+	}
+	var nodeKey *ec.PrivateKey
+	var peerAllow nodeauth.Allowlist
+	if *cx.Config.PeerAuth {
+		var err error
+		if nodeKey, peerAllow, err = LoadNodeAuth(cx); err != nil {
+			return nil, err
+		}
+	}
+	offenses, oErr := connmgr.ParseOffenseScores(*cx.Config.BanScores)
+	if oErr != nil {
+		return nil, oErr
+	}
 	nThreads := runtime.NumCPU()
 	var thr int
 	if *cx.Config.GenThreads == -1 || thr > nThreads {
@@ -2477,7 +3053,7 @@ func NewNode(listenAddrs []string, db database.DB,
 		AddrManager:          aMgr,
 		NewPeers:             make(chan *NodePeer, *cx.Config.MaxPeers),
 		DonePeers:            make(chan *NodePeer, *cx.Config.MaxPeers),
-		BanPeers:             make(chan *NodePeer, *cx.Config.MaxPeers),
+		BanPeers:             make(chan BanPeerMsg, *cx.Config.MaxPeers),
 		Query:                make(chan interface{}),
 		RelayInv:             make(chan RelayMsg, *cx.Config.MaxPeers),
 		Broadcast:            make(chan BroadcastMsg, *cx.Config.MaxPeers),
@@ -2485,6 +3061,10 @@ func NewNode(listenAddrs []string, db database.DB,
 		ModifyRebroadcastInv: make(chan interface{}),
 		PeerHeightsUpdate:    make(chan UpdatePeerHeightsMsg),
 		NAT:                  nat,
+		OnionTarget:          onionTarget,
+		NodeKey:              nodeKey,
+		PeerAllow:            peerAllow,
+		Offenses:             offenses,
 		DB:                   db,
 		TimeSource:           blockchain.NewMedianTime(),
 		Services:             services,
@@ -2504,17 +3084,22 @@ func NewNode(listenAddrs []string, db database.DB,
 	// If the addrindex is run first, it may not have the transactions from the current block indexed.
 	var indexes []indexers.Indexer
 	Debug("txindex", *cx.Config.TxIndex, "addrindex", *cx.Config.AddrIndex)
-	if *cx.Config.TxIndex || *cx.Config.AddrIndex {
-		// Enable transaction index if address index is enabled since it requires it.
-		if !*cx.Config.TxIndex {
-			Info("transaction index enabled because it is required by the address index")
-			*cx.Config.TxIndex = true
-		} else {
-			Info("transaction index is enabled")
-		}
+	// The configuration has already turned off the address index if the transaction index it requires is off.
+	if *cx.Config.TxIndex {
+		Info("transaction index is enabled")
 		s.TxIndex = indexers.NewTxIndex(db)
 		indexes = append(indexes, s.TxIndex)
 	}
+	// The witness transaction index uses the internal block IDs of the transaction index, so it must come after it.
+	if *cx.Config.WTxIndex {
+		if s.TxIndex == nil {
+			Warn("witness transaction index disabled because it requires the transaction index")
+		} else {
+			Info("witness transaction index is enabled")
+			s.WTxIndex = indexers.NewWTxIndex(db)
+			indexes = append(indexes, s.WTxIndex)
+		}
+	}
 	if *cx.Config.AddrIndex {
 		Info("address index is enabled")
 		s.AddrIndex = indexers.NewAddrIndex(db, cx.ActiveNet)
@@ -2525,10 +3110,16 @@ func NewNode(listenAddrs []string, db database.DB,
 		s.CFIndex = indexers.NewCfIndex(db, cx.ActiveNet)
 		indexes = append(indexes, s.CFIndex)
 	}
+	if *cx.Config.SpentIndex {
+		Info("spent output index is enabled")
+		s.SpentIndex = indexers.NewSpentIndex(db)
+		indexes = append(indexes, s.SpentIndex)
+	}
 	// Create an index manager if any of the optional indexes are enabled.
 	var indexManager blockchain.IndexManager
 	if len(indexes) > 0 {
-		indexManager = indexers.NewManager(db, indexes)
+		s.IndexManager = indexers.NewManager(db, indexes)
+		indexManager = s.IndexManager
 	}
 	// Merge given checkpoints with the default ones unless they are disabled.
 	var checkpoints []chaincfg.Checkpoint
@@ -2540,20 +3131,27 @@ func NewNode(listenAddrs []string, db database.DB,
 	var err error
 	s.Chain, err = blockchain.New(
 		&blockchain.Config{
-			DB:           s.DB,
-			Interrupt:    interruptChan,
-			ChainParams:  s.ChainParams,
-			Checkpoints:  checkpoints,
-			TimeSource:   s.TimeSource,
-			SigCache:     s.SigCache,
-			IndexManager: indexManager,
-			HashCache:    s.HashCache,
+			DB:            s.DB,
+			Interrupt:     interruptChan,
+			ChainParams:   s.ChainParams,
+			Checkpoints:   checkpoints,
+			TimeSource:    s.TimeSource,
+			SigCache:      s.SigCache,
+			IndexManager:  indexManager,
+			HashCache:     s.HashCache,
+			PruneTarget:   uint64(*cx.Config.PruneTarget) * 1024 * 1024,
+			PruneDepth:    int32(*cx.Config.PruneDepth),
+			ScriptWorkers: *cx.Config.ScriptWorkers,
 		},
 	)
 	if err != nil {
 		Error(err)
 		return nil, err
 	}
+	// A chain bootstrapped from a UTXO set snapshot lacks the blocks before it just like a pruned one.
+	if s.Chain.IsPruned() {
+		s.Services = s.Services&^wire.SFNodeNetwork | wire.SFNodeNetworkLimited
+	}
 	s.Chain.DifficultyAdjustments = make(map[string]float64)
 	s.Chain.DifficultyBits.Store(make(blockchain.TargetBits))
 	// Search for a FeeEstimator state in the database. If none can be found or if it cannot be loaded, create a new
@@ -2599,6 +3197,7 @@ func NewNode(listenAddrs []string, db database.DB,
 			MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost / 4,
 			MinRelayTxFee:        cx.StateCfg.ActiveMinRelayTxFee,
 			MaxTxVersion:         2,
+			RejectReplacement:    *cx.Config.RejectReplacement,
 		},
 		ChainParams:   cx.ActiveNet,
 		FetchUtxoView: s.Chain.FetchUtxoView,
@@ -2617,6 +3216,7 @@ func NewNode(listenAddrs []string, db database.DB,
 		HashCache:          s.HashCache,
 		AddrIndex:          s.AddrIndex,
 		FeeEstimator:       s.FeeEstimator,
+		TxEvicted:          s.TransactionEvicted,
 	}
 	s.TxMemPool = mempool.New(&txC)
 	s.SyncManager, err =
@@ -2635,21 +3235,20 @@ func NewNode(listenAddrs []string, db database.DB,
 		Error(err)
 		return nil, err
 	}
-	// // Create the mining policy and block template generator based on the
-	// // configuration options.
-	// // NOTE: The CPU miner relies on the mempool, so the mempool has to be
-	// // created before calling the function to create the CPU miner.
-	// policy := mining.Policy{
-	// 	BlockMinWeight:    uint32(*config.BlockMinWeight),
-	// 	BlockMaxWeight:    uint32(*config.BlockMaxWeight),
-	// 	BlockMinSize:      uint32(*config.BlockMinSize),
-	// 	BlockMaxSize:      uint32(*config.BlockMaxSize),
-	// 	BlockPrioritySize: uint32(*config.BlockPrioritySize),
-	// 	TxMinFreeFee:      stateCfg.ActiveMinRelayTxFee,
-	// }
-	// blockTemplateGenerator := mining.NewBlkTmplGenerator(&policy,
-	// 	s.ChainParams, s.TxMemPool, s.Chain, s.TimeSource,
-	// 	s.SigCache, s.HashCache, s.Algo)
+	// Create the mining policy and block template generator of the RPC servers based on the configuration options.
+	// The generator relies on the mempool, so the mempool has to be created before it.
+	policy := mining.Policy{
+		BlockMinWeight:    uint32(*cx.Config.BlockMinWeight),
+		BlockMaxWeight:    uint32(*cx.Config.BlockMaxWeight),
+		BlockMinSize:      uint32(*cx.Config.BlockMinSize),
+		BlockMaxSize:      uint32(*cx.Config.BlockMaxSize),
+		BlockPrioritySize: uint32(*cx.Config.BlockPrioritySize),
+		TxMinFreeFee:      cx.StateCfg.ActiveMinRelayTxFee,
+	}
+	blockTemplateGenerator := mining.NewBlkTmplGenerator(&policy,
+		s.ChainParams, s.TxMemPool, s.Chain, s.TimeSource,
+		s.SigCache, s.HashCache)
+	blockTemplateGenerator.CoinbaseData = []byte(*cx.Config.CoinbaseData)
 	// s.CPUMiner = cpuminer.New(&cpuminer.Config{
 	// 	Blockchain:             s.Chain,
 	// 	ChainParams:            chainParams,
@@ -2680,6 +3279,14 @@ func NewNode(listenAddrs []string, db database.DB,
 				//
 				// Just check that we don't already have an address in the same group so that we are not connecting to
 				// the same network segment at the expense of others.
+				if *cx.Config.OnlyNet == "onion" && !addrmgr.IsTor(addr.NetAddress()) {
+					continue
+				}
+				// I2P addresses are only stored and relayed as there is no I2P transport, and Tor addresses can only
+				// be connected to through tor.
+				if addrmgr.IsI2P(addr.NetAddress()) || (!*cx.Config.Onion && addrmgr.IsTor(addr.NetAddress())) {
+					continue
+				}
 				key := addrmgr.GroupKey(addr.NetAddress())
 				if s.OutboundGroupCount(key) != 0 {
 					continue
@@ -2739,7 +3346,33 @@ func NewNode(listenAddrs []string, db database.DB,
 			},
 		)
 	}
+	// The telemetry reporter is always created so the RPC can show what would be sent, but it only sends anything if
+	// the user has opted in, and the endpoint is only required to be usable then.
+	if s.Telemetry, err = telemetry.New(*cx.Config.TelemetryURL, telemetry.DefaultInterval, *cx.Config.Telemetry,
+		s.TelemetryStats); Check(err) {
+		return nil, err
+	}
+	if s.Announcements, err = NewAnnouncements(cx.ActiveNet.AnnounceKeys); Check(err) {
+		return nil, err
+	}
+	s.Metrics = metrics.NewRegistry()
+	rpcDuration := s.RegisterMetrics()
+	if s.AuditLog, err = audit.Open(filepath.Join(*cx.Config.DataDir, cx.ActiveNet.Name, audit.FileName)); Check(err) {
+		return nil, err
+	}
 	if !*cx.Config.DisableRPC {
+		if *cx.Config.RPCWalletProxy != "" {
+			if s.WalletClient, err = NewWalletClient(cx.Config); Check(err) {
+				return nil, err
+			}
+			if cx.StateCfg.Payout != nil {
+				// the fresh payout policy pays each block to a new address of the default account of the wallet
+				walletClient := s.WalletClient
+				cx.StateCfg.Payout.NewAddress = func() (util.Address, error) {
+					return walletClient.GetNewAddress("default")
+				}
+			}
+		}
 		// Setup listeners for the configured RPC listen addresses and TLS settings.
 		listeners := map[string][]string{
 			fork.SHA256d: *cx.Config.RPCListeners,
@@ -2763,15 +3396,23 @@ func NewNode(listenAddrs []string, db database.DB,
 				ChainParams: cx.ActiveNet,
 				DB:          db,
 				TxMemPool:   s.TxMemPool,
-				// Generator:    blockTemplateGenerator,
+				Generator:   blockTemplateGenerator,
 				// CPUMiner:     s.CPUMiner,
-				TxIndex:      s.TxIndex,
-				AddrIndex:    s.AddrIndex,
-				CfIndex:      s.CFIndex,
-				FeeEstimator: s.FeeEstimator,
-				Algo:         l,
-				Hashrate:     cx.Hashrate,
-				Quit:         s.Quit,
+				TxIndex:         s.TxIndex,
+				WTxIndex:        s.WTxIndex,
+				AddrIndex:       s.AddrIndex,
+				CfIndex:         s.CFIndex,
+				SpentIndex:      s.SpentIndex,
+				IndexManager:    s.IndexManager,
+				FeeEstimator:    s.FeeEstimator,
+				Algo:            l,
+				MinerStats:      cx.MinerStats,
+				Telemetry:       s.Telemetry,
+				Announcements:   s.Announcements,
+				RequestDuration: rpcDuration,
+				AuditLog:        s.AuditLog,
+				WalletClient:    s.WalletClient,
+				Quit:            s.Quit,
 			}, cx.StateCfg, cx.Config)
 			if err != nil {
 				Error(err)
@@ -2797,6 +3438,7 @@ func NewServerPeer(s *Node, isPersistent bool) *NodePeer {
 		Persistent:     isPersistent,
 		Filter:         bloom.LoadFilter(nil),
 		KnownAddresses: make(map[string]struct{}),
+		Latency:        NewPeerLatency(),
 		Quit:           make(chan struct{}),
 		TxProcessed:    make(chan struct{}, 1),
 		BlockProcessed: make(chan struct{}, 1),
@@ -2810,7 +3452,7 @@ func NewServerPeer(s *Node, isPersistent bool) *NodePeer {
 func ParseListeners(addrs []string) ([]net.Addr, error) {
 	netAddrs := make([]net.Addr, 0, len(addrs)*2)
 	for _, addr := range addrs {
-		host, _, err := net.SplitHostPort(addr)
+		host, port, err := net.SplitHostPort(addr)
 		if err != nil {
 			Error(err)
 			// Shouldn't happen due to already being normalized.
@@ -2830,7 +3472,14 @@ func ParseListeners(addrs []string) ([]net.Addr, error) {
 		// Parse the IP.
 		ip := net.ParseIP(host)
 		if ip == nil {
-			return nil, fmt.Errorf("'%s' is not a valid IP address", host)
+			// A host that is not an IP may name a network interface, which binds the listener to each of its addresses.
+			ifaceAddrs, err := InterfaceListeners(host, port)
+			if err != nil {
+				Error(err)
+				return nil, fmt.Errorf("'%s' is not a valid IP address or network interface", host)
+			}
+			netAddrs = append(netAddrs, ifaceAddrs...)
+			continue
 		}
 		// To4 returns nil when the IP is not an IPv4 address, so use this determine the address type.
 		if ip.To4() == nil {
@@ -2842,6 +3491,40 @@ func ParseListeners(addrs []string) ([]net.Addr, error) {
 	return netAddrs, nil
 }
 
+// InterfaceListeners returns the addresses to listen on with TCP to bind a listener to the network interface with the
+// given name, one for each address of the interface. IPv6 link local addresses are qualified with the interface as
+// their zone.
+func InterfaceListeners(name, port string) ([]net.Addr, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+	ifaceAddrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+	netAddrs := make([]net.Addr, 0, len(ifaceAddrs))
+	for _, ifaceAddr := range ifaceAddrs {
+		ipnet, ok := ifaceAddr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ipnet.IP.To4() != nil {
+			netAddrs = append(netAddrs, SimpleAddr{Net: "tcp4", Addr: net.JoinHostPort(ipnet.IP.String(), port)})
+			continue
+		}
+		host := ipnet.IP.String()
+		if ipnet.IP.IsLinkLocalUnicast() {
+			host += "%" + name
+		}
+		netAddrs = append(netAddrs, SimpleAddr{Net: "tcp6", Addr: net.JoinHostPort(host, port)})
+	}
+	if len(netAddrs) == 0 {
+		return nil, fmt.Errorf("network interface %s has no addresses", name)
+	}
+	return netAddrs, nil
+}
+
 // RandomUint16Number returns a random uint16 in a specified input range. Note that the range is in zeroth ordering; if
 // you pass it 1800, you will get values from 0 to 1800.
 func RandomUint16Number(max uint16) uint16 {
@@ -2906,6 +3589,27 @@ func SetupRPCListeners(config *pod.Config, urls []string) ([]net.Listener, error
 	return listeners, nil
 }
 
+// NewWalletClient returns a client of the wallet RPC server at the rpcwalletproxy address, which uses the RPC username
+// and password and the TLS settings of the node. The client posts each request over HTTP, so it doesn't fail when the
+// wallet is not running yet.
+func NewWalletClient(config *pod.Config) (*rpcclient.Client, error) {
+	var certs []byte
+	if *config.TLS {
+		var err error
+		if certs, err = ioutil.ReadFile(*config.CAFile); Check(err) {
+			return nil, err
+		}
+	}
+	return rpcclient.New(&rpcclient.ConnConfig{
+		Host:         *config.RPCWalletProxy,
+		User:         *config.Username,
+		Pass:         *config.Password,
+		Certificates: certs,
+		TLS:          *config.TLS,
+		HTTPPostMode: true,
+	}, nil)
+}
+
 // FileExists reports whether the named file or directory exists.
 func FileExists(name string) bool {
 	if _, err := os.Stat(name); err != nil {
@@ -0,0 +1,49 @@
+package chainrpc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/cmd/node/state"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	database "github.com/p9c/pod/pkg/db"
+	_ "github.com/p9c/pod/pkg/db/ffldb"
+	"github.com/p9c/pod/pkg/pod"
+)
+
+// TestNewNodeTelemetryDisabled ensures a node starts with a telemetry endpoint that could not be used, as long as the
+// user has not opted in to telemetry.
+func TestNewNodeTelemetryDisabled(t *testing.T) {
+	dir, err := ioutil.TempDir("", "server_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	params := &netparams.SimNetParams
+	db, err := database.Create("ffldb", filepath.Join(dir, "db"), params.Net)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	cfg, _ := pod.EmptyConfig()
+	*cfg.DataDir = dir
+	*cfg.Network = params.Name
+	*cfg.DisableListen = true
+	*cfg.DisableRPC = true
+	*cfg.MaxPeers = 8
+	*cfg.Telemetry = false
+	*cfg.TelemetryURL = "http://192.168.1.2/report"
+	cx := &Context{Config: cfg, StateCfg: &state.Config{}, ActiveNet: params}
+	n, err := NewNode(nil, db, make(chan struct{}), cx)
+	if err != nil {
+		t.Fatalf("NewNode with disabled telemetry and an http endpoint: %v", err)
+	}
+	// A disabled reporter returns at once instead of waiting to send its first report.
+	n.Telemetry.Run(make(chan struct{}))
+	*cfg.Telemetry = true
+	if _, err = NewNode(nil, db, make(chan struct{}), cx); err == nil {
+		t.Fatal("NewNode with enabled telemetry accepted an http endpoint")
+	}
+}
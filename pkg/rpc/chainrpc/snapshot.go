@@ -0,0 +1,121 @@
+package chainrpc
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+const (
+	// SnapshotTimeout is how long a pinned chain snapshot is kept after it was last used before it is released.
+	SnapshotTimeout = time.Minute * 5
+	// MaxSnapshots is the maximum number of chain snapshots that can be pinned at once. Each snapshot holds a database
+	// transaction open, which stops the database from reclaiming space and from closing, so they are kept few.
+	MaxSnapshots = 8
+)
+
+// ErrTooManySnapshots is returned when pinning a snapshot while MaxSnapshots are already pinned.
+var ErrTooManySnapshots = errors.New("too many chain snapshots are pinned, release one first")
+
+// snapshot is a pinned chain state and the timer releasing it when it is left unused.
+type snapshot struct {
+	state *blockchain.PinnedState
+	timer *time.Timer
+}
+
+// Snapshots keeps the chain snapshots pinned by getsnapshot, keyed by the token handed to the client, so a sequence of
+// calls can read the chain as of the same block even while new blocks are connected.
+type Snapshots struct {
+	sync.Mutex
+	pinned map[string]*snapshot
+}
+
+// NewSnapshots creates an empty snapshot store
+func NewSnapshots() *Snapshots {
+	return &Snapshots{pinned: make(map[string]*snapshot)}
+}
+
+// Pin pins the current state of the chain and returns the token it can be retrieved with
+func (s *Snapshots) Pin(chain *blockchain.BlockChain) (token string, state *blockchain.PinnedState, err error) {
+	s.Lock()
+	defer s.Unlock()
+	if len(s.pinned) >= MaxSnapshots {
+		return "", nil, ErrTooManySnapshots
+	}
+	b := make([]byte, 16)
+	if _, err = rand.Read(b); Check(err) {
+		return
+	}
+	token = hex.EncodeToString(b)
+	if state, err = chain.PinState(); Check(err) {
+		return
+	}
+	s.pinned[token] = &snapshot{
+		state: state,
+		timer: time.AfterFunc(SnapshotTimeout, func() { s.Release(token) }),
+	}
+	return
+}
+
+// Get returns the pinned state of a token, or nil if it is unknown or has expired. Getting a snapshot restarts its
+// expiry timeout.
+func (s *Snapshots) Get(token string) *blockchain.PinnedState {
+	s.Lock()
+	defer s.Unlock()
+	p, ok := s.pinned[token]
+	if !ok {
+		return nil
+	}
+	p.timer.Reset(SnapshotTimeout)
+	return p.state
+}
+
+// Release releases the snapshot of a token and reports whether it was pinned
+func (s *Snapshots) Release(token string) bool {
+	s.Lock()
+	p, ok := s.pinned[token]
+	delete(s.pinned, token)
+	s.Unlock()
+	if !ok {
+		return false
+	}
+	p.timer.Stop()
+	if err := p.state.Release(); err != nil {
+		Error(err)
+	}
+	return true
+}
+
+// ReleaseAll releases every pinned snapshot, which must be done before the database can be closed
+func (s *Snapshots) ReleaseAll() {
+	s.Lock()
+	tokens := make([]string, 0, len(s.pinned))
+	for token := range s.pinned {
+		tokens = append(tokens, token)
+	}
+	s.Unlock()
+	for i := range tokens {
+		s.Release(tokens[i])
+	}
+}
+
+// PinnedState returns the pinned state of an optional snapshot token given to a command, which is nil if no token was
+// given. An error suitable for the reply is returned if the token is unknown or has expired.
+func (s *Server) PinnedState(token *string) (*blockchain.PinnedState, error) {
+	if token == nil || *token == "" {
+		return nil, nil
+	}
+	state := s.Snapshots.Get(*token)
+	if state == nil {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: "Unknown or expired snapshot " + *token,
+		}
+	}
+	return state, nil
+}
@@ -0,0 +1,64 @@
+package chainrpc
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	database "github.com/p9c/pod/pkg/db"
+	_ "github.com/p9c/pod/pkg/db/ffldb"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// TestGetBlockCountSnapshot ensures getblockcount returns the height of a pinned snapshot when given its token, and
+// rejects tokens that are unknown or have been released.
+func TestGetBlockCountSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshot_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := database.Create("ffldb", filepath.Join(dir, "db"), wire.MainNet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	chain, err := blockchain.New(&blockchain.Config{
+		DB:          db,
+		ChainParams: &netparams.MainNetParams,
+		TimeSource:  blockchain.NewMedianTime(),
+		SigCache:    txscript.NewSigCache(1000),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &Server{
+		Cfg:        ServerConfig{Chain: chain},
+		HelpCacher: NewHelpCacher(),
+		Snapshots:  NewSnapshots(),
+	}
+	defer s.Snapshots.ReleaseAll()
+	token, pinned, err := s.Snapshots.Pin(chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := HandleGetBlockCount(s, btcjson.NewGetBlockCountCmd(), nil)
+	if err != nil || res.(int64) != int64(chain.BestSnapshot().Height) {
+		t.Fatalf("getblockcount: got %v, %v, want %d", res, err, chain.BestSnapshot().Height)
+	}
+	cmd := btcjson.NewGetBlockCountCmd()
+	cmd.Snapshot = &token
+	res, err = HandleGetBlockCount(s, cmd, nil)
+	if err != nil || res.(int64) != int64(pinned.Best().Height) {
+		t.Fatalf("getblockcount snapshot: got %v, %v, want %d", res, err, pinned.Best().Height)
+	}
+	s.Snapshots.Release(token)
+	if _, err = HandleGetBlockCount(s, cmd, nil); err == nil {
+		t.Fatal("getblockcount with a released snapshot should fail")
+	}
+}
@@ -0,0 +1,134 @@
+package chainrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TxMatch is what a transaction matched in the filter of a websocket client: the indexes of its outputs paying to the
+// addresses and scripts of the filter and the outpoints of the filter it spends.
+type TxMatch struct {
+	Outputs []uint32
+	Spends  []wire.OutPoint
+	// Compact is set if the client is notified of the match with a txmatch notification
+	Compact bool
+}
+
+// ParseFilterScript parses a script of a loadtxfilter command, which is an addr() or raw() output descriptor, a hex
+// public key script, or a hex template ending in * matching every script starting with the bytes before it. It returns
+// the public key script, or the bytes of the template if template is set.
+func ParseFilterScript(s string, params *netparams.Params) (script []byte, template bool, err error) {
+	if strings.Contains(s, "(") {
+		script, err = DescriptorScript(s, params)
+		return
+	}
+	if strings.HasSuffix(s, "*") {
+		template, s = true, strings.TrimSuffix(s, "*")
+	}
+	if script, err = hex.DecodeString(s); err != nil {
+		return
+	}
+	if len(script) == 0 {
+		// an empty template would match every output in every block
+		err = errors.New("empty script")
+	}
+	return
+}
+
+// AddScript adds a public key script to the wsClientFilter, or a template matching the scripts starting with it if
+// template is set.
+func (f *WSClientFilter) AddScript(script []byte, template bool) {
+	if template {
+		f.ScriptTemplates = append(f.ScriptTemplates, script)
+		return
+	}
+	f.Scripts[string(script)] = struct{}{}
+}
+
+// ExistsScript returns true if the public key script has been added to the wsClientFilter or starts with one of its
+// templates.
+func (f *WSClientFilter) ExistsScript(pkScript []byte) bool {
+	if _, ok := f.Scripts[string(pkScript)]; ok {
+		return true
+	}
+	for _, t := range f.ScriptTemplates {
+		if bytes.HasPrefix(pkScript, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchTx returns the outputs of the transaction paying to the addresses and scripts of the wsClientFilter and the
+// outpoints of the filter it spends. The matched outputs are added to the outpoints of the filter so spending them is
+// matched in turn. The filter must be locked by the caller.
+func (f *WSClientFilter) MatchTx(tx *util.Tx, params *netparams.Params) (match TxMatch) {
+	msgTx := tx.MsgTx()
+	if !blockchain.IsCoinBaseTx(msgTx) {
+		for _, input := range msgTx.TxIn {
+			if f.ExistsUnspentOutPoint(&input.PreviousOutPoint) {
+				match.Spends = append(match.Spends, input.PreviousOutPoint)
+			}
+		}
+	}
+	for i, output := range msgTx.TxOut {
+		matched := f.ExistsScript(output.PkScript)
+		if !matched {
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript, params)
+			if err != nil {
+				Error(err)
+				// Clients are not able to subscribe to nonstandard or non-address outputs by address.
+				continue
+			}
+			for _, a := range addrs {
+				if f.ExistsAddress(a) {
+					matched = true
+					break
+				}
+			}
+		}
+		if matched {
+			match.Outputs = append(match.Outputs, uint32(i))
+			f.AddUnspentOutPoint(&wire.OutPoint{Hash: *tx.Hash(), Index: uint32(i)})
+		}
+	}
+	match.Compact = f.Compact
+	return
+}
+
+// Matched returns whether the transaction matched anything in the filter
+func (m *TxMatch) Matched() bool {
+	return len(m.Outputs) != 0 || len(m.Spends) != 0
+}
+
+// TxMatchNotification marshals a txmatch notification of what a transaction matched in a compact filter. The height
+// and hash are of the block the transaction is in, or -1 and empty for a transaction accepted into the mempool.
+func TxMatchNotification(tx *util.Tx, match *TxMatch, height int32, blockHash string) ([]byte, error) {
+	msgTx := tx.MsgTx()
+	outputs := make([]btcjson.MatchedOutput, len(match.Outputs))
+	for i, vout := range match.Outputs {
+		out := msgTx.TxOut[vout]
+		outputs[i] = btcjson.MatchedOutput{
+			Vout:         vout,
+			Amount:       util.Amount(out.Value).ToDUO(),
+			ScriptPubKey: hex.EncodeToString(out.PkScript),
+		}
+	}
+	spends := make([]btcjson.OutPoint, len(match.Spends))
+	for i := range match.Spends {
+		spends[i] = btcjson.OutPoint{
+			Hash:  match.Spends[i].Hash.String(),
+			Index: match.Spends[i].Index,
+		}
+	}
+	return btcjson.MarshalCmd(nil, btcjson.NewTxMatchNtfn(tx.Hash().String(), height, blockHash, outputs, spends))
+}
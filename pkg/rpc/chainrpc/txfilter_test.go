@@ -0,0 +1,79 @@
+package chainrpc
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestTxFilterScripts ensures transactions are matched by exact scripts, descriptors and script templates, that
+// matched outputs are watched for being spent, and that a compact match lists only what matched.
+func TestTxFilterScripts(t *testing.T) {
+	params := &netparams.MainNetParams
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2pkh, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opReturn := []byte{txscript.OP_RETURN, 0x01, 0x02}
+	other := []byte{txscript.OP_TRUE}
+	for _, s := range []string{"", "*", "zz", "raw(zz)", "pk(00)"} {
+		if _, _, err = ParseFilterScript(s, params); err == nil {
+			t.Errorf("script %q should be invalid", s)
+		}
+	}
+	tests := []struct {
+		script  string
+		outputs []uint32
+	}{
+		{hex.EncodeToString(p2pkh), []uint32{0}},
+		{"addr(" + addr.EncodeAddress() + ")", []uint32{0}},
+		{"6a*", []uint32{1}},
+		{"raw(" + hex.EncodeToString(other) + ")", []uint32{2}},
+		{"6a0102", []uint32{1}},
+		{"6a01", nil},
+	}
+	for _, test := range tests {
+		script, template, err := ParseFilterScript(test.script, params)
+		if err != nil {
+			t.Fatalf("%s: %v", test.script, err)
+		}
+		f := NewWSClientFilter(nil, nil, params)
+		f.AddScript(script, template)
+		f.Compact = true
+		tx := wire.NewMsgTx(1)
+		tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+		tx.AddTxOut(wire.NewTxOut(1e8, p2pkh))
+		tx.AddTxOut(wire.NewTxOut(0, opReturn))
+		tx.AddTxOut(wire.NewTxOut(2e8, other))
+		match := f.MatchTx(util.NewTx(tx), params)
+		if len(match.Outputs) != len(test.outputs) || !match.Compact {
+			t.Fatalf("%s: matched outputs %v, want %v", test.script, match.Outputs, test.outputs)
+		}
+		for i := range test.outputs {
+			if match.Outputs[i] != test.outputs[i] {
+				t.Fatalf("%s: matched outputs %v, want %v", test.script, match.Outputs, test.outputs)
+			}
+		}
+		if !match.Matched() {
+			continue
+		}
+		// spending a matched output matches in turn
+		spend := wire.NewMsgTx(1)
+		spend.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: tx.TxHash(), Index: test.outputs[0]}, nil, nil))
+		spend.AddTxOut(wire.NewTxOut(1, []byte{txscript.OP_FALSE}))
+		if m := f.MatchTx(util.NewTx(spend), params); len(m.Spends) != 1 || len(m.Outputs) != 0 {
+			t.Fatalf("%s: spend of matched output not matched: %+v", test.script, m)
+		}
+		if _, err = TxMatchNotification(util.NewTx(tx), &match, -1, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
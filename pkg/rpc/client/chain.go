@@ -187,6 +187,25 @@ func (c *Client) GetBlockCount() (int64, error) {
 	return c.GetBlockCountAsync().Receive()
 }
 
+// GetBlockCountSnapshotAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetBlockCountSnapshot for the blocking version
+// and more details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetBlockCountSnapshotAsync(snapshot string) FutureGetBlockCountResult {
+	cmd := btcjson.NewGetBlockCountCmd()
+	cmd.Snapshot = &snapshot
+	return c.sendCmd(cmd)
+}
+
+// GetBlockCountSnapshot returns the number of blocks in the longest block chain as of a snapshot pinned with
+// GetSnapshot.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetBlockCountSnapshot(snapshot string) (int64, error) {
+	return c.GetBlockCountSnapshotAsync(snapshot).Receive()
+}
+
 // FutureGetDifficultyResult is a future promise to deliver the result of a GetDifficultyAsync RPC invocation (or an
 // applicable error).
 type FutureGetDifficultyResult chan *response
@@ -287,6 +306,25 @@ func (c *Client) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
 	return c.GetBlockHashAsync(blockHeight).Receive()
 }
 
+// GetBlockHashSnapshotAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetBlockHashSnapshot for the blocking version and
+// more details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetBlockHashSnapshotAsync(blockHeight int64, snapshot string) FutureGetBlockHashResult {
+	cmd := btcjson.NewGetBlockHashCmd(blockHeight)
+	cmd.Snapshot = &snapshot
+	return c.sendCmd(cmd)
+}
+
+// GetBlockHashSnapshot returns the hash of the block at the given height in the best block chain as of a snapshot
+// pinned with GetSnapshot.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetBlockHashSnapshot(blockHeight int64, snapshot string) (*chainhash.Hash, error) {
+	return c.GetBlockHashSnapshotAsync(blockHeight, snapshot).Receive()
+}
+
 // FutureGetBlockHeaderResult is a future promise to deliver the result of a GetBlockHeaderAsync RPC invocation (or an
 // applicable error).
 type FutureGetBlockHeaderResult chan *response
@@ -528,6 +566,75 @@ func (c *Client) EstimateFee(numBlocks int64) (float64, error) {
 	return c.EstimateFeeAsync(numBlocks).Receive()
 }
 
+// FutureScanTxOutSetResult is a future promise to deliver the result of a ScanTxOutSetAsync RPC invocation (or an
+// applicable error).
+type FutureScanTxOutSetResult chan *response
+
+// Receive waits for the response promised by the future and returns the unspent outputs found by the scan.
+func (r FutureScanTxOutSetResult) Receive() (*btcjson.ScanTxOutSetResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a scantxoutset result object.
+	var scan btcjson.ScanTxOutSetResult
+	err = js.Unmarshal(res, &scan)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// ScanTxOutSetAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See ScanTxOutSet for the blocking version and more details.
+func (c *Client) ScanTxOutSetAsync(scanObjects []string) FutureScanTxOutSetResult {
+	cmd := btcjson.NewScanTxOutSetCmd("start", &scanObjects)
+	return c.sendCmd(cmd)
+}
+
+// ScanTxOutSet scans the UTXO set of the server for unspent outputs paying to the passed output descriptors, which
+// are of the form addr(<address>) or raw(<hex script>).
+func (c *Client) ScanTxOutSet(scanObjects []string) (*btcjson.ScanTxOutSetResult, error) {
+	return c.ScanTxOutSetAsync(scanObjects).Receive()
+}
+
+// FutureEstimateSmartFeeResult is a future promise to deliver the result of a EstimateSmartFeeAsync RPC invocation
+// (or an applicable error).
+type FutureEstimateSmartFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the fee estimate provided by the server.
+func (r FutureEstimateSmartFeeResult) Receive() (*btcjson.EstimateSmartFeeResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an estimatesmartfee result object.
+	var fee btcjson.EstimateSmartFeeResult
+	err = js.Unmarshal(res, &fee)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &fee, nil
+}
+
+// EstimateSmartFeeAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See EstimateSmartFee for the blocking version and
+// more details.
+func (c *Client) EstimateSmartFeeAsync(confTarget int64, mode *btcjson.EstimateSmartFeeMode) FutureEstimateSmartFeeResult {
+	cmd := btcjson.NewEstimateSmartFeeCmd(confTarget, mode)
+	return c.sendCmd(cmd)
+}
+
+// EstimateSmartFee requests a fee estimate for confirmation within confTarget blocks, using the conservative or
+// economical estimation mode, or the server's configured mode when mode is nil.
+func (c *Client) EstimateSmartFee(confTarget int64, mode *btcjson.EstimateSmartFeeMode) (*btcjson.EstimateSmartFeeResult, error) {
+	return c.EstimateSmartFeeAsync(confTarget, mode).Receive()
+}
+
 // FutureVerifyChainResult is a future promise to deliver the result of a VerifyChainAsync, VerifyChainLevelAsyncRPC, or
 // VerifyChainBlocksAsync invocation (or an applicable error).
 type FutureVerifyChainResult chan *response
@@ -636,6 +743,176 @@ func (c *Client) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*
 	return c.GetTxOutAsync(txHash, index, mempool).Receive()
 }
 
+// GetTxOutSnapshotAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetTxOutSnapshot for the blocking version and more
+// details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetTxOutSnapshotAsync(txHash *chainhash.Hash, index uint32, snapshot string) FutureGetTxOutResult {
+	hash := ""
+	if txHash != nil {
+		hash = txHash.String()
+	}
+	mempool := false
+	cmd := btcjson.NewGetTxOutCmd(hash, index, &mempool)
+	cmd.Snapshot = &snapshot
+	return c.sendCmd(cmd)
+}
+
+// GetTxOutSnapshot returns the transaction output info if it's unspent in the best block chain as of a snapshot pinned
+// with GetSnapshot and nil, otherwise.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetTxOutSnapshot(txHash *chainhash.Hash, index uint32, snapshot string) (*btcjson.GetTxOutResult,
+	error) {
+	return c.GetTxOutSnapshotAsync(txHash, index, snapshot).Receive()
+}
+
+// FutureGetSnapshotResult is a future promise to deliver the result of a GetSnapshotAsync RPC invocation (or an
+// applicable error).
+type FutureGetSnapshotResult chan *response
+
+// Receive waits for the response promised by the future and returns the token and best block of the pinned snapshot.
+func (r FutureGetSnapshotResult) Receive() (*btcjson.GetSnapshotResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var snapshot btcjson.GetSnapshotResult
+	err = js.Unmarshal(res, &snapshot)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// GetSnapshotAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetSnapshot for the blocking version and more details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetSnapshotAsync() FutureGetSnapshotResult {
+	cmd := btcjson.NewGetSnapshotCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetSnapshot pins the current state of the best block chain on the server, so that a sequence of GetBlockHashSnapshot
+// and GetTxOutSnapshot calls see the same chain even while new blocks arrive. The snapshot should be released with
+// ReleaseSnapshot when it is no longer needed.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetSnapshot() (*btcjson.GetSnapshotResult, error) {
+	return c.GetSnapshotAsync().Receive()
+}
+
+// FutureGetSyncStatusResult is a future promise to deliver the result of a GetSyncStatusAsync RPC invocation (or an
+// applicable error).
+type FutureGetSyncStatusResult chan *response
+
+// Receive waits for the response promised by the future and returns the sync status of the server.
+func (r FutureGetSyncStatusResult) Receive() (*btcjson.GetSyncStatusResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var status btcjson.GetSyncStatusResult
+	err = js.Unmarshal(res, &status)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &status, nil
+}
+
+// GetSyncStatusAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetSyncStatus for the blocking version and more
+// details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetSyncStatusAsync() FutureGetSyncStatusResult {
+	cmd := btcjson.NewGetSyncStatusCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetSyncStatus returns the stage of the sync of the chain of the server, the progress of each stage, the download
+// rate of blocks and the estimated time until the chain is in sync.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetSyncStatus() (*btcjson.GetSyncStatusResult, error) {
+	return c.GetSyncStatusAsync().Receive()
+}
+
+// FutureReleaseSnapshotResult is a future promise to deliver the result of a ReleaseSnapshotAsync RPC invocation (or an
+// applicable error).
+type FutureReleaseSnapshotResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the snapshot could not be released.
+func (r FutureReleaseSnapshotResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ReleaseSnapshotAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See ReleaseSnapshot for the blocking version and more
+// details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) ReleaseSnapshotAsync(snapshot string) FutureReleaseSnapshotResult {
+	cmd := btcjson.NewReleaseSnapshotCmd(snapshot)
+	return c.sendCmd(cmd)
+}
+
+// ReleaseSnapshot releases a snapshot pinned with GetSnapshot.
+//
+// NOTE: This is a pod extension.
+func (c *Client) ReleaseSnapshot(snapshot string) error {
+	return c.ReleaseSnapshotAsync(snapshot).Receive()
+}
+
+// FutureGetTxSpendingPrevOutResult is a future promise to deliver the result of a GetTxSpendingPrevOutAsync RPC
+// invocation (or an applicable error).
+type FutureGetTxSpendingPrevOutResult chan *response
+
+// Receive waits for the response promised by the future and returns the mempool spenders of the requested outputs.
+func (r FutureGetTxSpendingPrevOutResult) Receive() ([]btcjson.GetTxSpendingPrevOutResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an array of gettxspendingprevout result objects.
+	var spending []btcjson.GetTxSpendingPrevOutResult
+	err = js.Unmarshal(res, &spending)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return spending, nil
+}
+
+// GetTxSpendingPrevOutAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetTxSpendingPrevOut for the blocking version and
+// more details.
+func (c *Client) GetTxSpendingPrevOutAsync(outPoints []wire.OutPoint) FutureGetTxSpendingPrevOutResult {
+	outputs := make([]btcjson.TransactionInput, 0, len(outPoints))
+	for _, op := range outPoints {
+		outputs = append(outputs, btcjson.TransactionInput{
+			Txid: op.Hash.String(),
+			Vout: op.Index,
+		})
+	}
+	cmd := btcjson.NewGetTxSpendingPrevOutCmd(outputs)
+	return c.sendCmd(cmd)
+}
+
+// GetTxSpendingPrevOut returns, for each of the passed outpoints, the hash of the mempool transaction spending it, if
+// there is one.
+func (c *Client) GetTxSpendingPrevOut(outPoints []wire.OutPoint) ([]btcjson.GetTxSpendingPrevOutResult, error) {
+	return c.GetTxSpendingPrevOutAsync(outPoints).Receive()
+}
+
 // FutureRescanBlocksResult is a future promise to deliver the result of a RescanBlocksAsync RPC invocation (or an
 // applicable error).
 //
@@ -708,6 +985,71 @@ func (c *Client) InvalidateBlock(blockHash *chainhash.Hash) error {
 	return c.InvalidateBlockAsync(blockHash).Receive()
 }
 
+// FutureReconsiderBlockResult is a future promise to deliver the result of a ReconsiderBlockAsync RPC invocation (or
+// an applicable error).
+type FutureReconsiderBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the block could not be reconsidered.
+func (r FutureReconsiderBlockResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ReconsiderBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See ReconsiderBlock for the blocking version and more
+// details.
+func (c *Client) ReconsiderBlockAsync(blockHash *chainhash.Hash) FutureReconsiderBlockResult {
+	hash := ""
+	if blockHash != nil {
+		hash = blockHash.String()
+	}
+	cmd := btcjson.NewReconsiderBlockCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// ReconsiderBlock removes the invalid status of a block invalidated with InvalidateBlock, reorganizing the chain to it
+// when it is on the valid chain with the most work.
+func (c *Client) ReconsiderBlock(blockHash *chainhash.Hash) error {
+	return c.ReconsiderBlockAsync(blockHash).Receive()
+}
+
+// FutureGetBlockFilterResult is a future promise to deliver the result of a GetBlockFilterAsync RPC invocation (or an
+// applicable error).
+type FutureGetBlockFilterResult chan *response
+
+// Receive waits for the response promised by the future and returns the filter and filter header of the block
+// requested from the server.
+func (r FutureGetBlockFilterResult) Receive() (*btcjson.GetBlockFilterResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var blockFilter btcjson.GetBlockFilterResult
+	err = js.Unmarshal(res, &blockFilter)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &blockFilter, nil
+}
+
+// GetBlockFilterAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetBlockFilter for the blocking version and more
+// details.
+func (c *Client) GetBlockFilterAsync(blockHash chainhash.Hash,
+	filterType *btcjson.FilterTypeName) FutureGetBlockFilterResult {
+	cmd := btcjson.NewGetBlockFilterCmd(blockHash.String(), filterType)
+	return c.sendCmd(cmd)
+}
+
+// GetBlockFilter returns the BIP0158 compact filter and filter header of a block given its hash. A nil filter type
+// requests the basic filter.
+func (c *Client) GetBlockFilter(blockHash chainhash.Hash,
+	filterType *btcjson.FilterTypeName) (*btcjson.GetBlockFilterResult, error) {
+	return c.GetBlockFilterAsync(blockHash, filterType).Receive()
+}
+
 // FutureGetCFilterResult is a future promise to deliver the result of a GetCFilterAsync RPC invocation (or an
 // applicable error).
 type FutureGetCFilterResult chan *response
@@ -806,3 +1148,58 @@ func (c *Client) GetCFilterHeader(blockHash *chainhash.Hash,
 	filterType wire.FilterType) (*wire.MsgCFHeaders, error) {
 	return c.GetCFilterHeaderAsync(blockHash, filterType).Receive()
 }
+
+// FutureUTXOSetSnapshotResult is a future promise to deliver the result of a DumpUTXOSetAsync or LoadUTXOSetAsync RPC
+// invocation (or an applicable error).
+type FutureUTXOSetSnapshotResult chan *response
+
+// Receive waits for the response promised by the future and returns the block and hash of the UTXO set snapshot.
+func (r FutureUTXOSetSnapshotResult) Receive() (*btcjson.UTXOSetSnapshotResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a UTXO set snapshot result object.
+	var result btcjson.UTXOSetSnapshotResult
+	err = js.Unmarshal(res, &result)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DumpUTXOSetAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See DumpUTXOSet for the blocking version and more details.
+func (c *Client) DumpUTXOSetAsync(filename string) FutureUTXOSetSnapshotResult {
+	cmd := btcjson.NewDumpUTXOSetCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// DumpUTXOSet writes a snapshot of the UTXO set at the best block of the server to a file in its data directory, or at
+// an absolute path.
+//
+// NOTE: This is a pod extension.
+func (c *Client) DumpUTXOSet(filename string) (*btcjson.UTXOSetSnapshotResult, error) {
+	return c.DumpUTXOSetAsync(filename).Receive()
+}
+
+// LoadUTXOSetAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See LoadUTXOSet for the blocking version and more details.
+func (c *Client) LoadUTXOSetAsync(filename string) FutureUTXOSetSnapshotResult {
+	cmd := btcjson.NewLoadUTXOSetCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// LoadUTXOSet bootstraps the chain of the server from a UTXO set snapshot written by DumpUTXOSet. It fails on networks
+// that have no verified snapshot in their parameters, which is currently all of them.
+//
+// NOTE: This is a pod extension.
+func (c *Client) LoadUTXOSet(filename string) (*btcjson.UTXOSetSnapshotResult, error) {
+	return c.LoadUTXOSetAsync(filename).Receive()
+}
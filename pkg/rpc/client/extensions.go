@@ -55,6 +55,32 @@ func (c *Client) DebugLevel(levelSpec string) (string, error) {
 	return c.DebugLevelAsync(levelSpec).Receive()
 }
 
+// FutureRestartResult is a future promise to deliver the result of a RestartAsync RPC invocation (or an applicable
+// error).
+type FutureRestartResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the restart could not be requested.
+func (r FutureRestartResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// RestartAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See Restart for the blocking version and more details.
+// NOTE: This is a pod extension.
+func (c *Client) RestartAsync() FutureRestartResult {
+	cmd := btcjson.NewRestartCmd()
+	return c.sendCmd(cmd)
+}
+
+// Restart asks the server to shut down and start again with the same arguments, so that changes to its configuration
+// file take effect. The connection is lost while the server restarts.
+//
+// NOTE: This is a pod extension.
+func (c *Client) Restart() error {
+	return c.RestartAsync().Receive()
+}
+
 // FutureCreateEncryptedWalletResult is a future promise to deliver the error result of a CreateEncryptedWalletAsync RPC
 // invocation.
 type FutureCreateEncryptedWalletResult chan *response
@@ -168,6 +194,45 @@ func (c *Client) GetBestBlock() (*chainhash.Hash, int32, error) {
 	return c.GetBestBlockAsync().Receive()
 }
 
+// FutureGetBlockSubsidyResult is a future promise to deliver the result of a GetBlockSubsidyAsync RPC invocation (or
+// an applicable error).
+type FutureGetBlockSubsidyResult chan *response
+
+// Receive waits for the response promised by the future and returns the subsidy paid by the coinbase of the block.
+func (r FutureGetBlockSubsidyResult) Receive() (*btcjson.GetBlockSubsidyResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a getblocksubsidy result object.
+	var subsidy btcjson.GetBlockSubsidyResult
+	err = js.Unmarshal(res, &subsidy)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &subsidy, nil
+}
+
+// GetBlockSubsidyAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetBlockSubsidy for the blocking version and more
+// details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetBlockSubsidyAsync(height *int32, algo *string) FutureGetBlockSubsidyResult {
+	cmd := btcjson.NewGetBlockSubsidyCmd(height, algo)
+	return c.sendCmd(cmd)
+}
+
+// GetBlockSubsidy returns the subsidy paid by the coinbase of a block at the height mined with the algorithm. Nil
+// arguments default to the next block and the algorithm of the RPC endpoint.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetBlockSubsidy(height *int32, algo *string) (*btcjson.GetBlockSubsidyResult, error) {
+	return c.GetBlockSubsidyAsync(height, algo).Receive()
+}
+
 // FutureGetCurrentNetResult is a future promise to deliver the result of a GetCurrentNetAsync RPC invocation (or an
 // applicable error).
 type FutureGetCurrentNetResult chan *response
@@ -412,3 +477,215 @@ func (c *Client) VersionAsync() FutureVersionResult {
 func (c *Client) Version() (map[string]btcjson.VersionResult, error) {
 	return c.VersionAsync().Receive()
 }
+
+// FutureGetAuditLogResult is a future promise to deliver the result of a GetAuditLogAsync RPC invocation (or an
+// applicable error).
+type FutureGetAuditLogResult chan *response
+
+// Receive waits for the response promised by the future and returns the entries of the audit log.
+func (r FutureGetAuditLogResult) Receive() ([]btcjson.AuditLogEntry, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an array of audit log entries.
+	var entries []btcjson.AuditLogEntry
+	err = js.Unmarshal(res, &entries)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetAuditLogAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetAuditLog for the blocking version and more details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetAuditLogAsync(count, skip int) FutureGetAuditLogResult {
+	cmd := btcjson.NewGetAuditLogCmd(&count, &skip)
+	return c.sendCmd(cmd)
+}
+
+// GetAuditLog returns up to count of the state-changing commands recorded in the audit log of the server, oldest
+// first, leaving out the skip most recent ones.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetAuditLog(count, skip int) ([]btcjson.AuditLogEntry, error) {
+	return c.GetAuditLogAsync(count, skip).Receive()
+}
+
+// encodeAddresses returns the encoded form of the passed addresses as used in the parameters of the address index
+// commands.
+func encodeAddresses(addresses []util.Address) []string {
+	addrs := make([]string, len(addresses))
+	for i, addr := range addresses {
+		addrs[i] = addr.EncodeAddress()
+	}
+	return addrs
+}
+
+// FutureGetAddressBalanceResult is a future promise to deliver the result of a GetAddressBalanceAsync RPC invocation
+// (or an applicable error).
+type FutureGetAddressBalanceResult chan *response
+
+// Receive waits for the response promised by the future and returns the balance of the addresses.
+func (r FutureGetAddressBalanceResult) Receive() (*btcjson.GetAddressBalanceResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var balance btcjson.GetAddressBalanceResult
+	err = js.Unmarshal(res, &balance)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// GetAddressBalanceAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GetAddressBalance for the blocking version and
+// more details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetAddressBalanceAsync(addresses []util.Address) FutureGetAddressBalanceResult {
+	cmd := btcjson.NewGetAddressBalanceCmd(encodeAddresses(addresses))
+	return c.sendCmd(cmd)
+}
+
+// GetAddressBalance returns the confirmed balance, the total received and the unconfirmed change of the balance of the
+// passed addresses.
+//
+// NOTE: Chain servers do not typically provide this capability unless the address index has specifically been
+// enabled. This is a pod extension.
+func (c *Client) GetAddressBalance(addresses []util.Address) (*btcjson.GetAddressBalanceResult, error) {
+	return c.GetAddressBalanceAsync(addresses).Receive()
+}
+
+// FutureGetAddressTxIDsResult is a future promise to deliver the result of a GetAddressTxIDsAsync RPC invocation (or
+// an applicable error).
+type FutureGetAddressTxIDsResult chan *response
+
+// Receive waits for the response promised by the future and returns the hashes of the transactions involving the
+// addresses.
+func (r FutureGetAddressTxIDsResult) Receive() ([]*chainhash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var txIDs []string
+	err = js.Unmarshal(res, &txIDs)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	hashes := make([]*chainhash.Hash, len(txIDs))
+	for i, txID := range txIDs {
+		hashes[i], err = chainhash.NewHashFromStr(txID)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	return hashes, nil
+}
+
+// GetAddressTxIDsAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetAddressTxIDs for the blocking version and more
+// details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetAddressTxIDsAsync(addresses []util.Address, includeMempool bool) FutureGetAddressTxIDsResult {
+	cmd := btcjson.NewGetAddressTxIDsCmd(encodeAddresses(addresses), &includeMempool)
+	return c.sendCmd(cmd)
+}
+
+// GetAddressTxIDs returns the hashes of the transactions involving the passed addresses in the order they were
+// confirmed, followed by those in the mempool if includeMempool is set.
+//
+// NOTE: Chain servers do not typically provide this capability unless the address index has specifically been
+// enabled. This is a pod extension.
+func (c *Client) GetAddressTxIDs(addresses []util.Address, includeMempool bool) ([]*chainhash.Hash, error) {
+	return c.GetAddressTxIDsAsync(addresses, includeMempool).Receive()
+}
+
+// FutureGetAddressUtxosResult is a future promise to deliver the result of a GetAddressUtxosAsync RPC invocation (or
+// an applicable error).
+type FutureGetAddressUtxosResult chan *response
+
+// Receive waits for the response promised by the future and returns the unspent outputs paying the addresses.
+func (r FutureGetAddressUtxosResult) Receive() ([]btcjson.GetAddressUtxosResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var utxos []btcjson.GetAddressUtxosResult
+	err = js.Unmarshal(res, &utxos)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return utxos, nil
+}
+
+// GetAddressUtxosAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GetAddressUtxos for the blocking version and more
+// details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetAddressUtxosAsync(addresses []util.Address, includeMempool bool) FutureGetAddressUtxosResult {
+	cmd := btcjson.NewGetAddressUtxosCmd(encodeAddresses(addresses), &includeMempool)
+	return c.sendCmd(cmd)
+}
+
+// GetAddressUtxos returns the unspent outputs paying the passed addresses. When includeMempool is set the outputs
+// created by transactions in the mempool are included and those spent by them are left out.
+//
+// NOTE: Chain servers do not typically provide this capability unless the address index has specifically been
+// enabled. This is a pod extension.
+func (c *Client) GetAddressUtxos(addresses []util.Address, includeMempool bool) ([]btcjson.GetAddressUtxosResult, error) {
+	return c.GetAddressUtxosAsync(addresses, includeMempool).Receive()
+}
+
+// FutureGetSpentInfoResult is a future promise to deliver the result of a GetSpentInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetSpentInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the transaction input spending the output.
+func (r FutureGetSpentInfoResult) Receive() (*btcjson.GetSpentInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var info btcjson.GetSpentInfoResult
+	err = js.Unmarshal(res, &info)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetSpentInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetSpentInfo for the blocking version and more details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetSpentInfoAsync(outPoint *wire.OutPoint) FutureGetSpentInfoResult {
+	cmd := btcjson.NewGetSpentInfoCmd(outPoint.Hash.String(), outPoint.Index)
+	return c.sendCmd(cmd)
+}
+
+// GetSpentInfo returns the transaction input in the main chain that spends the passed output and the height of its
+// block.
+//
+// NOTE: Chain servers do not typically provide this capability unless the spent output index has specifically been
+// enabled. This is a pod extension.
+func (c *Client) GetSpentInfo(outPoint *wire.OutPoint) (*btcjson.GetSpentInfoResult, error) {
+	return c.GetSpentInfoAsync(outPoint).Receive()
+}
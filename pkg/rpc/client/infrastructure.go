@@ -972,7 +972,7 @@ func newHTTPClient(config *ConnConfig) (*http.Client, error) {
 	}
 	// Configure TLS if needed.
 	var tlsConfig *tls.Config
-	if !config.TLS {
+	if config.TLS {
 		if len(config.Certificates) > 0 {
 			pool := x509.NewCertPool()
 			pool.AppendCertsFromPEM(config.Certificates)
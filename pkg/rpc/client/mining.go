@@ -1,11 +1,13 @@
 package rpcclient
 
 import (
+	"bytes"
 	"encoding/hex"
 	js "encoding/json"
 	"errors"
 
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	"github.com/p9c/pod/pkg/util"
 )
@@ -52,6 +54,56 @@ func (c *Client) Generate(numBlocks uint32) ([]*chainhash.Hash, error) {
 	return c.GenerateAsync(numBlocks).Receive()
 }
 
+// GenerateToAddressAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance. See GenerateToAddress for the blocking version and
+// more details.
+func (c *Client) GenerateToAddressAsync(numBlocks uint32, address util.Address, maxTries *uint64,
+	algo *string) FutureGenerateResult {
+	cmd := btcjson.NewGenerateToAddressCmd(numBlocks, address.EncodeAddress(), maxTries, algo)
+	return c.sendCmd(cmd)
+}
+
+// GenerateToAddress generates numBlocks blocks paying to the address, on regtest or simnet, and returns their hashes.
+func (c *Client) GenerateToAddress(numBlocks uint32, address util.Address, maxTries *uint64,
+	algo *string) ([]*chainhash.Hash, error) {
+	return c.GenerateToAddressAsync(numBlocks, address, maxTries, algo).Receive()
+}
+
+// FutureGenerateBlockResult is a future promise to deliver the result of a GenerateBlockAsync RPC invocation (or an
+// applicable error).
+type FutureGenerateBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash of the block generated by the call.
+func (r FutureGenerateBlockResult) Receive() (*chainhash.Hash, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var result btcjson.GenerateBlockResult
+	err = js.Unmarshal(res, &result)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return chainhash.NewHashFromStr(result.Hash)
+}
+
+// GenerateBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance. See GenerateBlock for the blocking version and more
+// details.
+func (c *Client) GenerateBlockAsync(address util.Address, transactions []string,
+	algo *string) FutureGenerateBlockResult {
+	cmd := btcjson.NewGenerateBlockCmd(address.EncodeAddress(), transactions, algo)
+	return c.sendCmd(cmd)
+}
+
+// GenerateBlock generates a block with exactly the transactions listed, as mempool transaction IDs or serialized,
+// hex-encoded transactions, paying to the address, on regtest or simnet, and returns its hash.
+func (c *Client) GenerateBlock(address util.Address, transactions []string, algo *string) (*chainhash.Hash, error) {
+	return c.GenerateBlockAsync(address, transactions, algo).Receive()
+}
+
 // FutureGetGenerateResult is a future promise to deliver the result of a GetGenerateAsync RPC invocation (or an
 // applicable error).
 type FutureGetGenerateResult chan *response
@@ -144,6 +196,40 @@ func (c *Client) GetHashesPerSec() (int64, error) {
 	return c.GetHashesPerSecAsync().Receive()
 }
 
+// FutureGetMinerStatsResult is a future promise to deliver the result of a GetMinerStatsAsync RPC invocation (or an
+// applicable error).
+type FutureGetMinerStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the statistics of the miner workers.
+func (r FutureGetMinerStatsResult) Receive() (*btcjson.GetMinerStatsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a getminerstats result object.
+	var stats btcjson.GetMinerStatsResult
+	err = js.Unmarshal(res, &stats)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetMinerStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See GetMinerStats for the blocking version and more details.
+func (c *Client) GetMinerStatsAsync() FutureGetMinerStatsResult {
+	cmd := btcjson.NewGetMinerStatsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetMinerStats returns the hashrate, solution and rejected block statistics of the kopach workers taking work from
+// the node.
+func (c *Client) GetMinerStats() (*btcjson.GetMinerStatsResult, error) {
+	return c.GetMinerStatsAsync().Receive()
+}
+
 // FutureGetMiningInfoResult is a future promise to deliver the result of a GetMiningInfoAsync RPC invocation (or an
 // applicable error).
 type FutureGetMiningInfoResult chan *response
@@ -364,4 +450,26 @@ func (c *Client) SubmitBlock(block *util.Block, options *btcjson.SubmitBlockOpti
 	return c.SubmitBlockAsync(block, options).Receive()
 }
 
+// SubmitHeaderAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance. See SubmitHeader for the blocking version and more details.
+//
+// NOTE: This is a pod extension.
+func (c *Client) SubmitHeaderAsync(header *wire.BlockHeader) FutureSubmitBlockResult {
+	var buf bytes.Buffer
+	if err := header.Serialize(&buf); err != nil {
+		Error(err)
+		return newFutureError(err)
+	}
+	cmd := btcjson.NewSubmitHeaderCmd(hex.EncodeToString(buf.Bytes()))
+	return c.sendCmd(cmd)
+}
+
+// SubmitHeader checks the block header would be valid on the best block of the server, without sending the
+// transactions of the block. The reason the header is invalid is returned as the error.
+//
+// NOTE: This is a pod extension.
+func (c *Client) SubmitHeader(header *wire.BlockHeader) error {
+	return c.SubmitHeaderAsync(header).Receive()
+}
+
 // TODO(davec): Implement GetBlockTemplate
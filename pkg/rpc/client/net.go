@@ -273,3 +273,278 @@ func (c *Client) GetNetTotalsAsync() FutureGetNetTotalsResult {
 func (c *Client) GetNetTotals() (*btcjson.GetNetTotalsResult, error) {
 	return c.GetNetTotalsAsync().Receive()
 }
+
+// FutureGetPeerStatsResult is a future promise to deliver the result of a GetPeerStatsAsync RPC invocation (or an
+// applicable error).
+type FutureGetPeerStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the aggregated statistics of the connected peers.
+func (r FutureGetPeerStatsResult) Receive() (*btcjson.GetPeerStatsResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a getpeerstats result object.
+	var stats btcjson.GetPeerStatsResult
+	err = js.Unmarshal(res, &stats)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// GetPeerStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See GetPeerStats for the blocking version and more details.
+func (c *Client) GetPeerStatsAsync() FutureGetPeerStatsResult {
+	cmd := btcjson.NewGetPeerStatsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetPeerStats returns the traffic by message command and the ping time distribution aggregated over the connected
+// peers.
+func (c *Client) GetPeerStats() (*btcjson.GetPeerStatsResult, error) {
+	return c.GetPeerStatsAsync().Receive()
+}
+
+// FutureGetNetworkInfoResult is a future promise to deliver the result of a GetNetworkInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetNetworkInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the network state of the server.
+func (r FutureGetNetworkInfoResult) Receive() (*btcjson.GetNetworkInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a getnetworkinfo result object.
+	var info btcjson.GetNetworkInfoResult
+	err = js.Unmarshal(res, &info)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetNetworkInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetNetworkInfo for the blocking version and more details.
+func (c *Client) GetNetworkInfoAsync() FutureGetNetworkInfoResult {
+	cmd := btcjson.NewGetNetworkInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetNetworkInfo returns the peer to peer network state of the server, such as its version, services, local addresses
+// and the reachability of each network type.
+func (c *Client) GetNetworkInfo() (*btcjson.GetNetworkInfoResult, error) {
+	return c.GetNetworkInfoAsync().Receive()
+}
+
+// FutureDumpAddrManResult is a future promise to deliver the result of a DumpAddrManAsync RPC invocation (or an
+// applicable error).
+type FutureDumpAddrManResult chan *response
+
+// Receive waits for the response promised by the future and returns the name of the file written and the number of
+// addresses in it.
+func (r FutureDumpAddrManResult) Receive() (*btcjson.DumpAddrManResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a dumpaddrman result object.
+	var result btcjson.DumpAddrManResult
+	err = js.Unmarshal(res, &result)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DumpAddrManAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See DumpAddrMan for the blocking version and more details.
+func (c *Client) DumpAddrManAsync(filename string) FutureDumpAddrManResult {
+	cmd := btcjson.NewDumpAddrManCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// DumpAddrMan writes the known peer addresses of the server to a file in its data directory, or at an absolute path, in
+// the format of the peers file.
+//
+// NOTE: This is a pod extension.
+func (c *Client) DumpAddrMan(filename string) (*btcjson.DumpAddrManResult, error) {
+	return c.DumpAddrManAsync(filename).Receive()
+}
+
+// FutureLoadAddrManResult is a future promise to deliver the result of a LoadAddrManAsync RPC invocation (or an
+// applicable error).
+type FutureLoadAddrManResult chan *response
+
+// Receive waits for the response promised by the future and returns the number of addresses added.
+func (r FutureLoadAddrManResult) Receive() (*btcjson.LoadAddrManResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a loadaddrman result object.
+	var result btcjson.LoadAddrManResult
+	err = js.Unmarshal(res, &result)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LoadAddrManAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See LoadAddrMan for the blocking version and more details.
+func (c *Client) LoadAddrManAsync(filename string) FutureLoadAddrManResult {
+	cmd := btcjson.NewLoadAddrManCmd(filename)
+	return c.sendCmd(cmd)
+}
+
+// LoadAddrMan adds the addresses of a file written by DumpAddrMan that are not known to the server yet.
+//
+// NOTE: This is a pod extension.
+func (c *Client) LoadAddrMan(filename string) (*btcjson.LoadAddrManResult, error) {
+	return c.LoadAddrManAsync(filename).Receive()
+}
+
+// FutureGetAddrManInfoResult is a future promise to deliver the result of a GetAddrManInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetAddrManInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the counts and bucket fill levels of the address
+// manager.
+func (r FutureGetAddrManInfoResult) Receive() (*btcjson.GetAddrManInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a getaddrmaninfo result object.
+	var result btcjson.GetAddrManInfoResult
+	err = js.Unmarshal(res, &result)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetAddrManInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetAddrManInfo for the blocking version and more details.
+func (c *Client) GetAddrManInfoAsync() FutureGetAddrManInfoResult {
+	cmd := btcjson.NewGetAddrManInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetAddrManInfo returns the number of new and tried addresses known to the server and the fill level of each bucket of
+// its address manager.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetAddrManInfo() (*btcjson.GetAddrManInfoResult, error) {
+	return c.GetAddrManInfoAsync().Receive()
+}
+
+// FutureSetBanResult is a future promise to deliver the result of a SetBanAsync RPC invocation (or an applicable
+// error).
+type FutureSetBanResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if any occurred when performing the
+// specified command.
+func (r FutureSetBanResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetBanAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See SetBan for the blocking version and more details.
+func (c *Client) SetBanAsync(subnet string, command btcjson.SetBanSubCmd, banTime *int64,
+	absolute *bool) FutureSetBanResult {
+	cmd := btcjson.NewSetBanCmd(subnet, command, banTime, absolute)
+	return c.sendCmd(cmd)
+}
+
+// SetBan bans the IP address or subnet, or removes its ban. The ban lasts for the ban time in seconds, or until the ban
+// time in seconds since the Unix epoch when absolute is true. Nil or a ban time of zero uses the ban duration of the
+// server.
+func (c *Client) SetBan(subnet string, command btcjson.SetBanSubCmd, banTime *int64, absolute *bool) error {
+	return c.SetBanAsync(subnet, command, banTime, absolute).Receive()
+}
+
+// FutureListBannedResult is a future promise to deliver the result of a ListBannedAsync RPC invocation (or an
+// applicable error).
+type FutureListBannedResult chan *response
+
+// Receive waits for the response promised by the future and returns the bans of the server.
+func (r FutureListBannedResult) Receive() ([]btcjson.ListBannedResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an array of listbanned result objects.
+	var bans []btcjson.ListBannedResult
+	err = js.Unmarshal(res, &bans)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return bans, nil
+}
+
+// ListBannedAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See ListBanned for the blocking version and more details.
+func (c *Client) ListBannedAsync() FutureListBannedResult {
+	cmd := btcjson.NewListBannedCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListBanned returns the banned IP addresses and subnets of the server.
+func (c *Client) ListBanned() ([]btcjson.ListBannedResult, error) {
+	return c.ListBannedAsync().Receive()
+}
+
+// FutureClearBannedResult is a future promise to deliver the result of a ClearBannedAsync RPC invocation (or an
+// applicable error).
+type FutureClearBannedResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if any occurred when performing the
+// specified command.
+func (r FutureClearBannedResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ClearBannedAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See ClearBanned for the blocking version and more details.
+func (c *Client) ClearBannedAsync() FutureClearBannedResult {
+	cmd := btcjson.NewClearBannedCmd()
+	return c.sendCmd(cmd)
+}
+
+// ClearBanned removes all the bans of the server.
+func (c *Client) ClearBanned() error {
+	return c.ClearBannedAsync().Receive()
+}
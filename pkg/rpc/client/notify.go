@@ -129,10 +129,20 @@ type // NotificationHandlers defines callback function pointers to invoke with
 		//
 		// NOTE: Deprecated. Not used with RescanBlocks.
 		OnRescanProgress func(hash *chainhash.Hash, height int32, blkTime time.Time)
+		// OnSearchRawTransactionsBatch is invoked for each batch of transactions sent by a preceding call to
+		// SearchRawTransactionsStream, with the cursor to continue the search after them.
+		//
+		// NOTE: This is a pod extension.
+		OnSearchRawTransactionsBatch func(address string, cursor int, transactions []btcjson.SearchRawTransactionsResult)
 		// OnTxAccepted is invoked when a transaction is accepted into the memory pool. It will only be invoked if a
 		// preceding call to NotifyNewTransactions with the verbose flag set to false has been made to register for the
 		// notification and the function is non-nil.
 		OnTxAccepted func(hash *chainhash.Hash, amount util.Amount)
+		// OnTxMatch is invoked when a transaction in the mempool or a new block matches the client's transaction filter
+		// loaded as compact with LoadTxScriptFilter, with only the outputs and spent outpoints that matched.
+		//
+		// NOTE: This is a pod extension.
+		OnTxMatch func(match *btcjson.TxMatchNtfn)
 		// OnTxAccepted is invoked when a transaction is accepted into the memory pool. It will only be invoked if a
 		// preceding call to NotifyNewTransactions with the verbose flag set to true has been made to register for the
 		// notification and the function is non-nil.
@@ -146,6 +156,15 @@ type // NotificationHandlers defines callback function pointers to invoke with
 		// OnWalletLockState is invoked when a wallet is locked or unlocked. This will only be available when client is
 		// connected to a wallet server such as btcwallet.
 		OnWalletLockState func(locked bool)
+		// OnNewTx is invoked with the listtransactions details of wallet transactions as they are added to the mempool
+		// and mined. This will only be available when client is connected to a wallet server.
+		OnNewTx func(account string, details *btcjson.ListTransactionsResult)
+		// OnTxConflicted is invoked when a wallet transaction is conflicted by a mined transaction spending the same
+		// inputs. This will only be available when client is connected to a wallet server.
+		OnTxConflicted func(txid, conflictedBy *chainhash.Hash)
+		// OnWalletRescanProgress is invoked as a rescan of the chain for the wallet's transactions progresses, and once
+		// more when it finishes. This will only be available when client is connected to a wallet server.
+		OnWalletRescanProgress func(progress *btcjson.WalletRescanProgressNtfn)
 		// OnUnknownNotification is invoked when an unrecognized notification is received. This typically means the
 		// notification handling code for this package needs to be updated for a new notification type or the caller is
 		// using a custom notification this package does not know about.
@@ -274,6 +293,30 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 			return
 		}
 		c.ntfnHandlers.OnRescanProgress(hash, height, blkTime)
+	// OnSearchRawTransactionsBatch
+	case btcjson.SearchRawTransactionsBatchNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnSearchRawTransactionsBatch == nil {
+			return
+		}
+		address, cursor, transactions, err := parseSearchRawTransactionsBatchParams(ntfn.Params)
+		if err != nil {
+			Warn("received invalid searchrawtransactionsbatch notification:", err)
+			return
+		}
+		c.ntfnHandlers.OnSearchRawTransactionsBatch(address, cursor, transactions)
+	// OnTxMatch
+	case btcjson.TxMatchNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnTxMatch == nil {
+			return
+		}
+		match, err := parseTxMatchParams(ntfn.Params)
+		if err != nil {
+			Warn("received invalid txmatch notification:", err)
+			return
+		}
+		c.ntfnHandlers.OnTxMatch(match)
 	// OnTxAccepted
 	case btcjson.TxAcceptedNtfnMethod:
 		// Ignore the notification if the client is not interested in it.
@@ -338,6 +381,45 @@ func (c *Client) handleNotification(ntfn *rawNotification) {
 			return
 		}
 		c.ntfnHandlers.OnWalletLockState(locked)
+	// OnNewTx
+	case btcjson.NewTxNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnNewTx == nil {
+			return
+		}
+		account, details, err := parseNewTxNtfnParams(ntfn.Params)
+		if err != nil {
+			Error(err)
+			Warn("received invalid new tx notification:", err)
+			return
+		}
+		c.ntfnHandlers.OnNewTx(account, details)
+	// OnTxConflicted
+	case btcjson.TxConflictedNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnTxConflicted == nil {
+			return
+		}
+		txid, conflictedBy, err := parseTxConflictedNtfnParams(ntfn.Params)
+		if err != nil {
+			Error(err)
+			Warn("received invalid tx conflicted notification:", err)
+			return
+		}
+		c.ntfnHandlers.OnTxConflicted(txid, conflictedBy)
+	// OnWalletRescanProgress
+	case btcjson.WalletRescanProgressNtfnMethod:
+		// Ignore the notification if the client is not interested in it.
+		if c.ntfnHandlers.OnWalletRescanProgress == nil {
+			return
+		}
+		progress, err := parseWalletRescanProgressNtfnParams(ntfn.Params)
+		if err != nil {
+			Error(err)
+			Warn("received invalid wallet rescan progress notification:", err)
+			return
+		}
+		c.ntfnHandlers.OnWalletRescanProgress(progress)
 	// OnUnknownNotification
 	default:
 		if c.ntfnHandlers.OnUnknownNotification == nil {
@@ -498,6 +580,44 @@ func parseRelevantTxAcceptedParams(params []js.RawMessage) (transaction []byte,
 	return parseHexParam(params[0])
 }
 
+// parseSearchRawTransactionsBatchParams parses out the address, cursor and transactions included in a
+// searchrawtransactionsbatch notification.
+func parseSearchRawTransactionsBatchParams(params []js.RawMessage) (address string, cursor int,
+	transactions []btcjson.SearchRawTransactionsResult, err error) {
+	if len(params) != 3 {
+		return "", 0, nil, wrongNumParams(len(params))
+	}
+	if err = js.Unmarshal(params[0], &address); err != nil {
+		Error(err)
+		return "", 0, nil, err
+	}
+	if err = js.Unmarshal(params[1], &cursor); err != nil {
+		Error(err)
+		return "", 0, nil, err
+	}
+	if err = js.Unmarshal(params[2], &transactions); err != nil {
+		Error(err)
+		return "", 0, nil, err
+	}
+	return address, cursor, transactions, nil
+}
+
+// parseTxMatchParams parses out the transaction, block and matched outputs and spends from the parameters of a txmatch
+// notification.
+func parseTxMatchParams(params []js.RawMessage) (match *btcjson.TxMatchNtfn, err error) {
+	if len(params) != 5 {
+		return nil, wrongNumParams(len(params))
+	}
+	match = &btcjson.TxMatchNtfn{}
+	for i, v := range []interface{}{&match.TxID, &match.Height, &match.BlockHash, &match.Outputs, &match.Spends} {
+		if err = js.Unmarshal(params[i], v); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	return match, nil
+}
+
 // parseChainTxNtfnParams parses out the transaction and optional details about the block it's mined in from the
 // parameters of recvtx and redeemingtx notifications.
 func parseChainTxNtfnParams(params []js.RawMessage) (*util.Tx,
@@ -704,6 +824,69 @@ func parseWalletLockStateNtfnParams(params []js.RawMessage) (account string,
 	return account, locked, nil
 }
 
+// parseTxConflictedNtfnParams parses out the hashes of the conflicted and the conflicting transaction from the
+// parameters of a txconflicted notification.
+func parseTxConflictedNtfnParams(params []js.RawMessage) (txid, conflictedBy *chainhash.Hash, err error) {
+	if len(params) != 2 {
+		return nil, nil, wrongNumParams(len(params))
+	}
+	var hashes [2]string
+	for i := range params {
+		if err = js.Unmarshal(params[i], &hashes[i]); err != nil {
+			Error(err)
+			return nil, nil, err
+		}
+	}
+	if txid, err = chainhash.NewHashFromStr(hashes[0]); err != nil {
+		Error(err)
+		return nil, nil, err
+	}
+	if conflictedBy, err = chainhash.NewHashFromStr(hashes[1]); err != nil {
+		Error(err)
+		return nil, nil, err
+	}
+	return txid, conflictedBy, nil
+}
+
+// parseNewTxNtfnParams parses out the account name and the listtransactions details of a wallet transaction from the
+// parameters of a newtx notification.
+func parseNewTxNtfnParams(params []js.RawMessage) (account string, details *btcjson.ListTransactionsResult,
+	err error) {
+	if len(params) != 2 {
+		return "", nil, wrongNumParams(len(params))
+	}
+	if err = js.Unmarshal(params[0], &account); err != nil {
+		Error(err)
+		return "", nil, err
+	}
+	details = new(btcjson.ListTransactionsResult)
+	if err = js.Unmarshal(params[1], details); err != nil {
+		Error(err)
+		return "", nil, err
+	}
+	return account, details, nil
+}
+
+// parseWalletRescanProgressNtfnParams parses out the progress of a wallet rescan from the parameters of a
+// walletrescanprogress notification.
+func parseWalletRescanProgressNtfnParams(params []js.RawMessage) (*btcjson.WalletRescanProgressNtfn, error) {
+	if len(params) != 6 {
+		return nil, wrongNumParams(len(params))
+	}
+	var progress btcjson.WalletRescanProgressNtfn
+	fields := []interface{}{
+		&progress.StartHeight, &progress.Height, &progress.EndHeight, &progress.Percent, &progress.Found,
+		&progress.Finished,
+	}
+	for i := range params {
+		if err := js.Unmarshal(params[i], fields[i]); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	return &progress, nil
+}
+
 // FutureNotifyBlocksResult is a future promise to deliver the result of a NotifyBlocksAsync RPC invocation (or an
 // applicable error).
 type FutureNotifyBlocksResult chan *response
@@ -1125,7 +1308,7 @@ func (c *Client) LoadTxFilterAsync(reload bool, addresses []util.Address,
 			Index: outPoints[i].Index,
 		}
 	}
-	cmd := btcjson.NewLoadTxFilterCmd(reload, addrStrs, outPointObjects)
+	cmd := btcjson.NewLoadTxFilterCmd(reload, addrStrs, outPointObjects, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -1138,3 +1321,76 @@ func (c *Client) LoadTxFilterAsync(reload bool, addresses []util.Address,
 func (c *Client) LoadTxFilter(reload bool, addresses []util.Address, outPoints []wire.OutPoint) error {
 	return c.LoadTxFilterAsync(reload, addresses, outPoints).Receive()
 }
+
+// LoadTxScriptFilterAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See LoadTxScriptFilter for the blocking version and more details.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) LoadTxScriptFilterAsync(reload bool, scripts []string, compact bool) FutureLoadTxFilterResult {
+	cmd := btcjson.NewLoadTxFilterCmd(reload, []string{}, []btcjson.OutPoint{}, &scripts, &compact)
+	return c.sendCmd(cmd)
+}
+
+// LoadTxScriptFilter loads, reloads or adds scripts to a websocket client's transaction filter. Scripts are addr() and
+// raw() output descriptors, hex public key scripts, or hex templates ending in * matching every script starting with
+// the bytes before it. A compact filter is notified through OnTxMatch with only the outputs and spent outpoints that
+// matched, instead of the whole transactions.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) LoadTxScriptFilter(reload bool, scripts []string, compact bool) error {
+	return c.LoadTxScriptFilterAsync(reload, scripts, compact).Receive()
+}
+
+// FutureSearchRawTransactionsStreamResult is a future promise to deliver the result of a
+// SearchRawTransactionsStreamAsync RPC invocation (or an applicable error).
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+type FutureSearchRawTransactionsStreamResult chan *response
+
+// Receive waits for the response promised by the future and returns the cursor after the last batch, the number of
+// transactions sent and whether the search reached the end.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (r FutureSearchRawTransactionsStreamResult) Receive() (*btcjson.SearchRawTransactionsStreamResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var result btcjson.SearchRawTransactionsStreamResult
+	if err = js.Unmarshal(res, &result); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchRawTransactionsStreamAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See SearchRawTransactionsStream for the blocking version and more details.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) SearchRawTransactionsStreamAsync(address util.Address, cursor, batchSize int, includePrevOut,
+	reverse bool, filterAddrs *[]string) FutureSearchRawTransactionsStreamResult {
+	var prevOut *int
+	if includePrevOut {
+		prevOut = btcjson.Int(1)
+	}
+	cmd := btcjson.NewSearchRawTransactionsStreamCmd(address.EncodeAddress(), &cursor, &batchSize, prevOut,
+		&reverse, filterAddrs)
+	return c.sendCmd(cmd)
+}
+
+// SearchRawTransactionsStream searches the transactions involving the address after the cursor, which are passed to
+// the OnSearchRawTransactionsBatch notification handler in batches of at most batchSize transactions, and returns once
+// the last batch was sent.
+//
+// NOTE: This is a pod extension and requires a websocket connection.
+func (c *Client) SearchRawTransactionsStream(address util.Address, cursor, batchSize int, includePrevOut,
+	reverse bool, filterAddrs []string) (*btcjson.SearchRawTransactionsStreamResult, error) {
+	return c.SearchRawTransactionsStreamAsync(address, cursor, batchSize, includePrevOut, reverse,
+		&filterAddrs).Receive()
+}
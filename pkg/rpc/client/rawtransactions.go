@@ -582,3 +582,146 @@ func (c *Client) DecodeScriptAsync(serializedScript []byte) FutureDecodeScriptRe
 func (c *Client) DecodeScript(serializedScript []byte) (*btcjson.DecodeScriptResult, error) {
 	return c.DecodeScriptAsync(serializedScript).Receive()
 }
+
+// FutureCalculateSigHashResult is a future promise to deliver the result of a CalculateSigHashAsync RPC invocation (or
+// an applicable error).
+type FutureCalculateSigHashResult chan *response
+
+// Receive waits for the response promised by the future and returns the signature hash of the requested input.
+func (r FutureCalculateSigHashResult) Receive() ([]byte, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a calculatesighash result object.
+	var sigHashResult btcjson.CalculateSigHashResult
+	err = js.Unmarshal(res, &sigHashResult)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return hex.DecodeString(sigHashResult.SigHash)
+}
+
+// CalculateSigHashAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See CalculateSigHash for the blocking version and more details.
+func (c *Client) CalculateSigHashAsync(tx *wire.MsgTx, idx uint32, script []byte, amount util.Amount,
+	hashType SigHashType, witness bool) FutureCalculateSigHashResult {
+	txHex := ""
+	if tx != nil {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		txHex = hex.EncodeToString(buf.Bytes())
+	}
+	amt := amount.ToDUO()
+	flags := string(hashType)
+	cmd := btcjson.NewCalculateSigHashCmd(txHex, idx, hex.EncodeToString(script), &amt, &flags, &witness)
+	return c.sendCmd(cmd)
+}
+
+// CalculateSigHash returns the signature hash the server computes for input idx of the passed transaction, so a
+// signer can check its own signature hashes against it. The amount is only used for witness signature hashes.
+func (c *Client) CalculateSigHash(tx *wire.MsgTx, idx uint32, script []byte, amount util.Amount,
+	hashType SigHashType, witness bool) ([]byte, error) {
+	return c.CalculateSigHashAsync(tx, idx, script, amount, hashType, witness).Receive()
+}
+
+// FutureEvaluateScriptResult is a future promise to deliver the result of an EvaluateScriptAsync RPC invocation (or an
+// applicable error).
+type FutureEvaluateScriptResult chan *response
+
+// Receive waits for the response promised by the future and returns the execution trace of the scripts.
+func (r FutureEvaluateScriptResult) Receive() (*btcjson.EvaluateScriptResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an evaluatescript result object.
+	var evaluateScriptResult btcjson.EvaluateScriptResult
+	err = js.Unmarshal(res, &evaluateScriptResult)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &evaluateScriptResult, nil
+}
+
+// EvaluateScriptAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See EvaluateScript for the blocking version and more details.
+func (c *Client) EvaluateScriptAsync(pkScript, sigScript []byte, tx *wire.MsgTx, idx uint32,
+	amount util.Amount) FutureEvaluateScriptResult {
+	var txHex *string
+	if tx != nil {
+		// Serialize the transaction and convert to hex string.
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		s := hex.EncodeToString(buf.Bytes())
+		txHex = &s
+	}
+	sigScriptHex := hex.EncodeToString(sigScript)
+	amt := amount.ToDUO()
+	cmd := btcjson.NewEvaluateScriptCmd(hex.EncodeToString(pkScript), &sigScriptHex, txHex, &idx, &amt)
+	return c.sendCmd(cmd)
+}
+
+// EvaluateScript runs the passed public key script through the server's script engine and returns the stacks after
+// every executed opcode. The signature script is sigScript when it is not empty, and otherwise that of input idx of tx.
+// The transaction may be nil, in which case signature checks fail.
+func (c *Client) EvaluateScript(pkScript, sigScript []byte, tx *wire.MsgTx, idx uint32,
+	amount util.Amount) (*btcjson.EvaluateScriptResult, error) {
+	return c.EvaluateScriptAsync(pkScript, sigScript, tx, idx, amount).Receive()
+}
+
+// FutureTestMempoolAcceptResult is a future promise to deliver the result of a TestMempoolAcceptAsync RPC invocation
+// (or an applicable error).
+type FutureTestMempoolAcceptResult chan *response
+
+// Receive waits for the response promised by the future and returns whether each of the transactions would be
+// accepted by the mempool.
+func (r FutureTestMempoolAcceptResult) Receive() ([]btcjson.TestMempoolAcceptResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var results []btcjson.TestMempoolAcceptResult
+	if err = js.Unmarshal(res, &results); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// TestMempoolAcceptAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See TestMempoolAccept for the blocking version and more details.
+func (c *Client) TestMempoolAcceptAsync(txs []*wire.MsgTx, maxFeeRate *float64) FutureTestMempoolAcceptResult {
+	txHexes := make([]string, len(txs))
+	for i, tx := range txs {
+		buf := bytes.NewBuffer(make([]byte, 0, tx.SerializeSize()))
+		if err := tx.Serialize(buf); err != nil {
+			return newFutureError(err)
+		}
+		txHexes[i] = hex.EncodeToString(buf.Bytes())
+	}
+	cmd := btcjson.NewTestMempoolAcceptCmd(txHexes, maxFeeRate)
+	return c.sendCmd(cmd)
+}
+
+// TestMempoolAccept returns whether each of the transactions would be accepted by the mempool of the server, and the
+// mempool transactions it would replace, without adding them. A nil maximum fee rate uses the default of the server.
+func (c *Client) TestMempoolAccept(txs []*wire.MsgTx, maxFeeRate *float64) ([]btcjson.TestMempoolAcceptResult, error) {
+	return c.TestMempoolAcceptAsync(txs, maxFeeRate).Receive()
+}
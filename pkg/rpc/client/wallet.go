@@ -3,6 +3,7 @@ package rpcclient
 import (
 	js "encoding/json"
 	"strconv"
+	"time"
 
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	chainhash "github.com/p9c/pod/pkg/chain/hash"
@@ -472,6 +473,25 @@ func (c *Client) SendToAddressComment(address util.Address, amount util.Amount,
 		commentTo).Receive()
 }
 
+// SendToAddressFeeRateAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See SendToAddressFeeRate for the blocking version and more details.
+func (c *Client) SendToAddressFeeRateAsync(address util.Address, amount, feeRate util.Amount) FutureSendToAddressResult {
+	cmd := btcjson.NewSendToAddressCmd(address.EncodeAddress(), amount.ToDUO(), nil, nil)
+	rate := feeRate.ToDUO()
+	cmd.FeeRate = &rate
+	return c.sendCmd(cmd)
+}
+
+// SendToAddressFeeRate sends the passed amount to the given address paying the fee rate per kilobyte, which must be at
+// least the relay fee.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendToAddressFeeRate(address util.Address, amount, feeRate util.Amount) (*chainhash.Hash, error) {
+	return c.SendToAddressFeeRateAsync(address, amount, feeRate).Receive()
+}
+
 // FutureSendFromResult is a future promise to deliver the result of a SendFromAsync, SendFromMinConfAsync, or
 // SendFromCommentAsync RPC invocation (or an applicable error).
 type FutureSendFromResult chan *response
@@ -668,6 +688,33 @@ func (c *Client) SendManyComment(fromAccount string,
 		comment).Receive()
 }
 
+// SendManyFeeRateAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See SendManyFeeRate for the blocking version and more details.
+func (c *Client) SendManyFeeRateAsync(fromAccount string,
+	amounts map[util.Address]util.Amount, minConfirms int,
+	feeRate util.Amount) FutureSendManyResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewSendManyCmd(fromAccount, convertedAmounts, &minConfirms, nil)
+	rate := feeRate.ToDUO()
+	cmd.FeeRate = &rate
+	return c.sendCmd(cmd)
+}
+
+// SendManyFeeRate sends multiple amounts to multiple addresses using the provided account as a source of funds in a
+// single transaction, paying the fee rate per kilobyte, which must be at least the relay fee.
+//
+// NOTE: This function requires to the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) SendManyFeeRate(fromAccount string,
+	amounts map[util.Address]util.Amount, minConfirms int,
+	feeRate util.Amount) (*chainhash.Hash, error) {
+	return c.SendManyFeeRateAsync(fromAccount, amounts, minConfirms, feeRate).Receive()
+}
+
 // *************************
 // Address/Account Functions
 // *************************
@@ -1111,6 +1158,581 @@ func (c *Client) MoveComment(fromAccount, toAccount string, amount util.Amount,
 		comment).Receive()
 }
 
+// FutureGetPrivacyReportResult is a future promise to deliver the result of a GetPrivacyReportAsync RPC invocation (or
+// an applicable error).
+type FutureGetPrivacyReportResult chan *response
+
+// Receive waits for the response promised by the future and returns the privacy report of the wallet.
+func (r FutureGetPrivacyReportResult) Receive() (*btcjson.PrivacyReportResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a getprivacyreport result object.
+	var report btcjson.PrivacyReportResult
+	err = js.Unmarshal(res, &report)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetPrivacyReportAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetPrivacyReport for the blocking version and more details.
+func (c *Client) GetPrivacyReportAsync() FutureGetPrivacyReportResult {
+	cmd := btcjson.NewGetPrivacyReportCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetPrivacyReport returns an analysis of the wallet's history for address reuse, merged inputs and round amount
+// payments, along with suggestions for avoiding them.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetPrivacyReport() (*btcjson.PrivacyReportResult, error) {
+	return c.GetPrivacyReportAsync().Receive()
+}
+
+// FutureGetReconciliationResult is a future promise to deliver the result of a GetReconciliationAsync RPC invocation
+// (or an applicable error).
+type FutureGetReconciliationResult chan *response
+
+// Receive waits for the response promised by the future and returns the outcome of the latest reconciliation of the
+// wallet history with the chain.
+func (r FutureGetReconciliationResult) Receive() (*btcjson.GetReconciliationResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var reconciliation btcjson.GetReconciliationResult
+	err = js.Unmarshal(res, &reconciliation)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &reconciliation, nil
+}
+
+// GetReconciliationAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See GetReconciliation for the blocking version and more details.
+func (c *Client) GetReconciliationAsync() FutureGetReconciliationResult {
+	cmd := btcjson.NewGetReconciliationCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetReconciliation returns the transactions found missing from and removed from the wallet, the addresses derived and
+// the change of balance the last time the wallet history was reconciled with the chain.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetReconciliation() (*btcjson.GetReconciliationResult, error) {
+	return c.GetReconciliationAsync().Receive()
+}
+
+// FutureGetWalletInfoResult is a future promise to deliver the result of a GetWalletInfoAsync RPC invocation (or an
+// applicable error).
+type FutureGetWalletInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns whether the wallet is watching-only or locked, and
+// its birthday.
+func (r FutureGetWalletInfoResult) Receive() (*btcjson.GetWalletInfoResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var info btcjson.GetWalletInfoResult
+	err = js.Unmarshal(res, &info)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetWalletInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See GetWalletInfo for the blocking version and more details.
+func (c *Client) GetWalletInfoAsync() FutureGetWalletInfoResult {
+	cmd := btcjson.NewGetWalletInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetWalletInfo returns whether the wallet is watching-only, holding no private keys, or locked, and its birthday.
+func (c *Client) GetWalletInfo() (*btcjson.GetWalletInfoResult, error) {
+	return c.GetWalletInfoAsync().Receive()
+}
+
+// FutureCreateMultisigAccountResult is a future promise to deliver the result of a CreateMultisigAccountAsync RPC
+// invocation (or an applicable error).
+type FutureCreateMultisigAccountResult chan *response
+
+// Receive waits for the response promised by the future and returns the created multisig account.
+func (r FutureCreateMultisigAccountResult) Receive() (*btcjson.MultisigAccountResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a multisig account result object.
+	var acct btcjson.MultisigAccountResult
+	err = js.Unmarshal(res, &acct)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &acct, nil
+}
+
+// CreateMultisigAccountAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See CreateMultisigAccount for the blocking version and more details.
+func (c *Client) CreateMultisigAccountAsync(account string, nRequired int, keys []string) FutureCreateMultisigAccountResult {
+	cmd := btcjson.NewCreateMultisigAccountCmd(account, nRequired, keys)
+	return c.sendCmd(cmd)
+}
+
+// CreateMultisigAccount creates an m of n multisig account from the extended public keys of the cosigners and the
+// wallet account of the same name.
+//
+// NOTE: This is a pod extension.
+func (c *Client) CreateMultisigAccount(account string, nRequired int, keys []string) (*btcjson.MultisigAccountResult, error) {
+	return c.CreateMultisigAccountAsync(account, nRequired, keys).Receive()
+}
+
+// FutureMultisigPSBTResult is a future promise to deliver the result of a CreateMultisigPSBTAsync,
+// SignMultisigPSBTAsync or ImportMultisigPSBTAsync RPC invocation (or an applicable error).
+type FutureMultisigPSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the partially signed transaction along with its
+// signing progress.
+func (r FutureMultisigPSBTResult) Receive() (*btcjson.MultisigPSBTResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a multisig psbt result object.
+	var p btcjson.MultisigPSBTResult
+	err = js.Unmarshal(res, &p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateMultisigPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See CreateMultisigPSBT for the blocking version and more details.
+func (c *Client) CreateMultisigPSBTAsync(account string, amounts map[util.Address]util.Amount,
+	feeRate *util.Amount) FutureMultisigPSBTResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	var rate *float64
+	if feeRate != nil {
+		rate = btcjson.Float64(feeRate.ToDUO())
+	}
+	cmd := btcjson.NewCreateMultisigPSBTCmd(account, convertedAmounts, rate)
+	return c.sendCmd(cmd)
+}
+
+// CreateMultisigPSBT creates a partially signed transaction spending from a multisig account. A nil fee rate uses the
+// relay fee of the wallet.
+//
+// NOTE: This is a pod extension.
+func (c *Client) CreateMultisigPSBT(account string, amounts map[util.Address]util.Amount,
+	feeRate *util.Amount) (*btcjson.MultisigPSBTResult, error) {
+	return c.CreateMultisigPSBTAsync(account, amounts, feeRate).Receive()
+}
+
+// SignMultisigPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See SignMultisigPSBT for the blocking version and more details.
+func (c *Client) SignMultisigPSBTAsync(psbt string) FutureMultisigPSBTResult {
+	cmd := btcjson.NewSignMultisigPSBTCmd(psbt)
+	return c.sendCmd(cmd)
+}
+
+// SignMultisigPSBT adds the wallet's signatures to a base64 encoded partially signed transaction. Once enough
+// signatures are collected the signed transaction is returned in the Hex field.
+//
+// NOTE: This is a pod extension.
+func (c *Client) SignMultisigPSBT(psbt string) (*btcjson.MultisigPSBTResult, error) {
+	return c.SignMultisigPSBTAsync(psbt).Receive()
+}
+
+// ImportMultisigPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ImportMultisigPSBT for the blocking version and more details.
+func (c *Client) ImportMultisigPSBTAsync(psbt string) FutureMultisigPSBTResult {
+	cmd := btcjson.NewImportMultisigPSBTCmd(psbt)
+	return c.sendCmd(cmd)
+}
+
+// ImportMultisigPSBT merges the signatures a cosigner added to a base64 encoded partially signed transaction into
+// those the wallet has collected for it, without signing it. Once enough signatures are collected the signed
+// transaction is returned in the Hex field.
+//
+// NOTE: This is a pod extension.
+func (c *Client) ImportMultisigPSBT(psbt string) (*btcjson.MultisigPSBTResult, error) {
+	return c.ImportMultisigPSBTAsync(psbt).Receive()
+}
+
+// FutureStringResult is a future promise to deliver the result of a GetAccountXPubAsync or GetNewMultisigAddressAsync
+// RPC invocation (or an applicable error).
+type FutureStringResult chan *response
+
+// Receive waits for the response promised by the future and returns the string result.
+func (r FutureStringResult) Receive() (string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	// Unmarshal result as a string.
+	var s string
+	err = js.Unmarshal(res, &s)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	return s, nil
+}
+
+// GetAccountXPubAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See GetAccountXPub for the blocking version and more details.
+func (c *Client) GetAccountXPubAsync(account string) FutureStringResult {
+	cmd := btcjson.NewGetAccountXPubCmd(account)
+	return c.sendCmd(cmd)
+}
+
+// GetAccountXPub returns the extended public key of an account, which is shared with the cosigners of a multisig
+// account.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetAccountXPub(account string) (string, error) {
+	return c.GetAccountXPubAsync(account).Receive()
+}
+
+// GetNewMultisigAddressAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See GetNewMultisigAddress for the blocking version and more details.
+func (c *Client) GetNewMultisigAddressAsync(account string) FutureStringResult {
+	cmd := btcjson.NewGetNewMultisigAddressCmd(account)
+	return c.sendCmd(cmd)
+}
+
+// GetNewMultisigAddress derives the next receiving address of a multisig account and returns it encoded.
+//
+// NOTE: This is a pod extension.
+func (c *Client) GetNewMultisigAddress(account string) (string, error) {
+	return c.GetNewMultisigAddressAsync(account).Receive()
+}
+
+// FutureListMultisigAccountsResult is a future promise to deliver the result of a ListMultisigAccountsAsync RPC
+// invocation (or an applicable error).
+type FutureListMultisigAccountsResult chan *response
+
+// Receive waits for the response promised by the future and returns the multisig accounts of the wallet.
+func (r FutureListMultisigAccountsResult) Receive() ([]btcjson.MultisigAccountResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an array of multisig account result objects.
+	var accts []btcjson.MultisigAccountResult
+	err = js.Unmarshal(res, &accts)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return accts, nil
+}
+
+// ListMultisigAccountsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListMultisigAccounts for the blocking version and more details.
+func (c *Client) ListMultisigAccountsAsync() FutureListMultisigAccountsResult {
+	cmd := btcjson.NewListMultisigAccountsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListMultisigAccounts returns the multisig accounts of the wallet.
+//
+// NOTE: This is a pod extension.
+func (c *Client) ListMultisigAccounts() ([]btcjson.MultisigAccountResult, error) {
+	return c.ListMultisigAccountsAsync().Receive()
+}
+
+// FutureListMultisigPSBTsResult is a future promise to deliver the result of a ListMultisigPSBTsAsync RPC invocation
+// (or an applicable error).
+type FutureListMultisigPSBTsResult chan *response
+
+// Receive waits for the response promised by the future and returns the pending partially signed transactions of the
+// wallet's multisig accounts.
+func (r FutureListMultisigPSBTsResult) Receive() ([]btcjson.MultisigPSBTResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an array of multisig psbt result objects.
+	var pending []btcjson.MultisigPSBTResult
+	err = js.Unmarshal(res, &pending)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return pending, nil
+}
+
+// ListMultisigPSBTsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListMultisigPSBTs for the blocking version and more details.
+func (c *Client) ListMultisigPSBTsAsync() FutureListMultisigPSBTsResult {
+	cmd := btcjson.NewListMultisigPSBTsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListMultisigPSBTs returns the partially signed transactions of the wallet's multisig accounts that are still
+// collecting signatures, with the signing progress of each input.
+//
+// NOTE: This is a pod extension.
+func (c *Client) ListMultisigPSBTs() ([]btcjson.MultisigPSBTResult, error) {
+	return c.ListMultisigPSBTsAsync().Receive()
+}
+
+// FuturePaymentRequestResult is a future promise to deliver the result of a CreatePaymentRequestAsync RPC invocation
+// (or an applicable error).
+type FuturePaymentRequestResult chan *response
+
+// Receive waits for the response promised by the future and returns the created payment request.
+func (r FuturePaymentRequestResult) Receive() (*btcjson.PaymentRequestResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a payment request result object.
+	var request btcjson.PaymentRequestResult
+	err = js.Unmarshal(res, &request)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &request, nil
+}
+
+// CreatePaymentRequestAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See CreatePaymentRequest for the blocking version and more details.
+func (c *Client) CreatePaymentRequestAsync(amount util.Amount, label, message string,
+	expiry time.Duration) FuturePaymentRequestResult {
+	seconds := int64(expiry / time.Second)
+	cmd := btcjson.NewCreatePaymentRequestCmd(amount.ToDUO(), &label, &message, &seconds)
+	return c.sendCmd(cmd)
+}
+
+// CreatePaymentRequest creates a request for a payment of amount to a new address of the default account, which the
+// wallet marks fulfilled once the amount is received in confirmed transactions. An expiry of 0 never expires.
+//
+// NOTE: This is a pod extension.
+func (c *Client) CreatePaymentRequest(amount util.Amount, label, message string,
+	expiry time.Duration) (*btcjson.PaymentRequestResult, error) {
+	return c.CreatePaymentRequestAsync(amount, label, message, expiry).Receive()
+}
+
+// FutureListPaymentRequestsResult is a future promise to deliver the result of a ListPaymentRequestsAsync RPC
+// invocation (or an applicable error).
+type FutureListPaymentRequestsResult chan *response
+
+// Receive waits for the response promised by the future and returns the payment requests of the wallet.
+func (r FutureListPaymentRequestsResult) Receive() ([]btcjson.PaymentRequestResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as an array of payment request result objects.
+	var requests []btcjson.PaymentRequestResult
+	err = js.Unmarshal(res, &requests)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return requests, nil
+}
+
+// ListPaymentRequestsAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See ListPaymentRequests for the blocking version and more details.
+func (c *Client) ListPaymentRequestsAsync() FutureListPaymentRequestsResult {
+	cmd := btcjson.NewListPaymentRequestsCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListPaymentRequests returns the payment requests of the wallet, oldest first, along with whether each is pending,
+// fulfilled or expired.
+//
+// NOTE: This is a pod extension.
+func (c *Client) ListPaymentRequests() ([]btcjson.PaymentRequestResult, error) {
+	return c.ListPaymentRequestsAsync().Receive()
+}
+
+// FuturePreviewSendResult is a future promise to deliver the result of a PreviewSendAsync RPC invocation (or an
+// applicable error).
+type FuturePreviewSendResult chan *response
+
+// Receive waits for the response promised by the future and returns the fee the previewed transaction would pay.
+func (r FuturePreviewSendResult) Receive() (*btcjson.PreviewSendResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a previewsend result object.
+	var preview btcjson.PreviewSendResult
+	err = js.Unmarshal(res, &preview)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &preview, nil
+}
+
+// PreviewSendAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See PreviewSend for the blocking version and more details.
+func (c *Client) PreviewSendAsync(fromAccount string, amounts map[util.Address]util.Amount,
+	minConfirms int) FuturePreviewSendResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewPreviewSendCmd(convertedAmounts, &fromAccount, &minConfirms, nil)
+	return c.sendCmd(cmd)
+}
+
+// PreviewSend returns the fee a send of the amounts from the account would pay, along with the fee rate it pays
+// together with any unconfirmed parent transactions, without signing or broadcasting anything.
+//
+// NOTE: This is a pod extension.
+func (c *Client) PreviewSend(fromAccount string, amounts map[util.Address]util.Amount,
+	minConfirms int) (*btcjson.PreviewSendResult, error) {
+	return c.PreviewSendAsync(fromAccount, amounts, minConfirms).Receive()
+}
+
+// PreviewSendFeeRateAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See PreviewSendFeeRate for the blocking version and more details.
+func (c *Client) PreviewSendFeeRateAsync(fromAccount string, amounts map[util.Address]util.Amount,
+	minConfirms int, feeRate util.Amount) FuturePreviewSendResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	rate := feeRate.ToDUO()
+	cmd := btcjson.NewPreviewSendCmd(convertedAmounts, &fromAccount, &minConfirms, &rate)
+	return c.sendCmd(cmd)
+}
+
+// PreviewSendFeeRate is PreviewSend for a send paying the fee rate per kilobyte rather than the relay fee.
+//
+// NOTE: This is a pod extension.
+func (c *Client) PreviewSendFeeRate(fromAccount string, amounts map[util.Address]util.Amount,
+	minConfirms int, feeRate util.Amount) (*btcjson.PreviewSendResult, error) {
+	return c.PreviewSendFeeRateAsync(fromAccount, amounts, minConfirms, feeRate).Receive()
+}
+
+// FutureSigningDevicePSBTResult is a future promise to deliver the result of a CreateSigningDevicePSBTAsync RPC
+// invocation (or an applicable error).
+type FutureSigningDevicePSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the unsigned transaction along with the signing
+// device that is to sign it.
+func (r FutureSigningDevicePSBTResult) Receive() (*btcjson.SigningDevicePSBTResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a signing device psbt result object.
+	var p btcjson.SigningDevicePSBTResult
+	err = js.Unmarshal(res, &p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CreateSigningDevicePSBTAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See CreateSigningDevicePSBT for the blocking version and more details.
+func (c *Client) CreateSigningDevicePSBTAsync(fromAccount string, amounts map[util.Address]util.Amount,
+	minConfirms int, feeRate *util.Amount) FutureSigningDevicePSBTResult {
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	var rate *float64
+	if feeRate != nil {
+		rate = btcjson.Float64(feeRate.ToDUO())
+	}
+	cmd := btcjson.NewCreateSigningDevicePSBTCmd(convertedAmounts, &fromAccount, &minConfirms, rate)
+	return c.sendCmd(cmd)
+}
+
+// CreateSigningDevicePSBT creates an unsigned transaction spending from an account paired with a signing device, to be
+// signed by the device and then published with PublishPSBT. A nil fee rate uses the relay fee of the wallet.
+//
+// NOTE: This is a pod extension.
+func (c *Client) CreateSigningDevicePSBT(fromAccount string, amounts map[util.Address]util.Amount,
+	minConfirms int, feeRate *util.Amount) (*btcjson.SigningDevicePSBTResult, error) {
+	return c.CreateSigningDevicePSBTAsync(fromAccount, amounts, minConfirms, feeRate).Receive()
+}
+
+// PublishPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See PublishPSBT for the blocking version and more details.
+func (c *Client) PublishPSBTAsync(psbt string) FutureSendToAddressResult {
+	cmd := btcjson.NewPublishPSBTCmd(psbt)
+	return c.sendCmd(cmd)
+}
+
+// PublishPSBT finalizes a base64 encoded partially signed transaction whose inputs have all been signed, broadcasts it
+// and returns its hash.
+//
+// NOTE: This is a pod extension.
+func (c *Client) PublishPSBT(psbt string) (*chainhash.Hash, error) {
+	return c.PublishPSBTAsync(psbt).Receive()
+}
+
 // FutureRenameAccountResult is a future promise to deliver the result of a RenameAccountAsync RPC invocation (or an
 // applicable error).
 type FutureRenameAccountResult chan *response
@@ -1135,6 +1757,59 @@ func (c *Client) RenameAccount(oldAccount, newAccount string) error {
 	return c.RenameAccountAsync(oldAccount, newAccount).Receive()
 }
 
+// FutureRescanFromHeightResult is a future promise to deliver the result of a RescanFromHeightAsync RPC invocation (or
+// an applicable error).
+type FutureRescanFromHeightResult chan *response
+
+// Receive waits for the response promised by the future and returns whether the rescan was started.
+func (r FutureRescanFromHeightResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// RescanFromHeightAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See RescanFromHeight for the blocking version and more details.
+func (c *Client) RescanFromHeightAsync(height int32) FutureRescanFromHeightResult {
+	cmd := btcjson.NewRescanFromHeightCmd(height)
+	return c.sendCmd(cmd)
+}
+
+// RescanFromHeight starts a rescan of the chain for the wallet's transactions from the block at the given height. The
+// rescan runs in the background, its progress is reported to the OnWalletRescanProgress notification handler of
+// websocket clients.
+func (c *Client) RescanFromHeight(height int32) error {
+	return c.RescanFromHeightAsync(height).Receive()
+}
+
+// FutureEncryptWalletDBResult is a future promise to deliver the result of an EncryptWalletDBAsync RPC invocation (or
+// an applicable error).
+type FutureEncryptWalletDBResult chan *response
+
+// Receive waits for the response promised by the future and returns whether the wallet database was encrypted.
+func (r FutureEncryptWalletDBResult) Receive() error {
+	_, err := receiveFuture(r)
+	return err
+}
+
+// EncryptWalletDBAsync returns an instance of a type that can be used to get the result of the RPC at some future time
+// by invoking the Receive function on the returned instance.
+//
+// See EncryptWalletDB for the blocking version and more details.
+func (c *Client) EncryptWalletDBAsync(pubPassphrase string) FutureEncryptWalletDBResult {
+	cmd := btcjson.NewEncryptWalletDBCmd(pubPassphrase)
+	return c.sendCmd(cmd)
+}
+
+// EncryptWalletDB encrypts the whole wallet database with a key derived from the public passphrase, which the wallet
+// then needs to be opened.
+//
+// NOTE: This is a pod extension.
+func (c *Client) EncryptWalletDB(pubPassphrase string) error {
+	return c.EncryptWalletDBAsync(pubPassphrase).Receive()
+}
+
 // FutureValidateAddressResult is a future promise to deliver the result of a ValidateAddressAsync RPC invocation (or an
 // applicable error).
 type FutureValidateAddressResult chan *response
@@ -1480,6 +2155,42 @@ func (c *Client) GetUnconfirmedBalance(account string) (util.Amount, error) {
 	return c.GetUnconfirmedBalanceAsync(account).Receive()
 }
 
+// FutureGetWalletBalancesResult is a future promise to deliver the result of a GetWalletBalancesAsync RPC invocation
+// (or an applicable error).
+type FutureGetWalletBalancesResult chan *response
+
+// Receive waits for the response promised by the future and returns the balances of the account broken down by
+// confirmations.
+func (r FutureGetWalletBalancesResult) Receive() (*btcjson.GetWalletBalancesResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var balances btcjson.GetWalletBalancesResult
+	err = js.Unmarshal(res, &balances)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &balances, nil
+}
+
+// GetWalletBalancesAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See GetWalletBalances for the blocking version and more details.
+func (c *Client) GetWalletBalancesAsync(account string) FutureGetWalletBalancesResult {
+	cmd := btcjson.NewGetWalletBalancesCmd(&account)
+	return c.sendCmd(cmd)
+}
+
+// GetWalletBalances returns the unconfirmed, confirming, confirmed, immature and locked balances of the specified
+// account, or of all accounts for "*", all taken from the same state of the wallet so that they add up to the total.
+func (c *Client) GetWalletBalances(account string) (*btcjson.GetWalletBalancesResult, error) {
+	return c.GetWalletBalancesAsync(account).Receive()
+}
+
 // FutureGetReceivedByAddressResult is a future promise to deliver the result of a GetReceivedByAddressAsync or
 // GetReceivedByAddressMinConfAsync RPC invocation (or an applicable error).
 type FutureGetReceivedByAddressResult chan *response
@@ -1837,6 +2548,210 @@ func (c *Client) VerifyMessage(address util.Address, signature, message string)
 	return c.VerifyMessageAsync(address, signature, message).Receive()
 }
 
+// **************************************
+// Partially Signed Transaction Functions
+// **************************************
+
+// FutureWalletCreateFundedPSBTResult is a future promise to deliver the result of a WalletCreateFundedPSBTAsync RPC
+// invocation (or an applicable error).
+type FutureWalletCreateFundedPSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the funded partially signed transaction along with
+// its fee and the index of its change output.
+func (r FutureWalletCreateFundedPSBTResult) Receive() (*btcjson.WalletCreateFundedPSBTResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a walletcreatefundedpsbt result object.
+	var p btcjson.WalletCreateFundedPSBTResult
+	err = js.Unmarshal(res, &p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+// WalletCreateFundedPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some
+// future time by invoking the Receive function on the returned instance.
+//
+// See WalletCreateFundedPSBT for the blocking version and more details.
+func (c *Client) WalletCreateFundedPSBTAsync(inputs []btcjson.TransactionInput, amounts map[util.Address]util.Amount,
+	lockTime *uint32, options *btcjson.WalletCreateFundedPSBTOpts, bip32Derivs *bool) FutureWalletCreateFundedPSBTResult {
+	if inputs == nil {
+		inputs = []btcjson.TransactionInput{}
+	}
+	convertedAmounts := make(map[string]float64, len(amounts))
+	for addr, amount := range amounts {
+		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
+	}
+	cmd := btcjson.NewWalletCreateFundedPSBTCmd(inputs, convertedAmounts, lockTime, options, bip32Derivs)
+	return c.sendCmd(cmd)
+}
+
+// WalletCreateFundedPSBT creates an unsigned transaction paying the passed amounts, spending the passed inputs and as
+// many more outputs of the wallet as are needed to cover the amounts and fee, and returns it as a base64 encoded
+// partially signed transaction. Nil optional parameters use the defaults of the wallet.
+func (c *Client) WalletCreateFundedPSBT(inputs []btcjson.TransactionInput, amounts map[util.Address]util.Amount,
+	lockTime *uint32, options *btcjson.WalletCreateFundedPSBTOpts,
+	bip32Derivs *bool) (*btcjson.WalletCreateFundedPSBTResult, error) {
+	return c.WalletCreateFundedPSBTAsync(inputs, amounts, lockTime, options, bip32Derivs).Receive()
+}
+
+// FutureWalletProcessPSBTResult is a future promise to deliver the result of a WalletProcessPSBTAsync RPC invocation
+// (or an applicable error).
+type FutureWalletProcessPSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the updated partially signed transaction and
+// whether it is complete.
+func (r FutureWalletProcessPSBTResult) Receive() (*btcjson.WalletProcessPSBTResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a walletprocesspsbt result object.
+	var p btcjson.WalletProcessPSBTResult
+	err = js.Unmarshal(res, &p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+// WalletProcessPSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future
+// time by invoking the Receive function on the returned instance.
+//
+// See WalletProcessPSBT for the blocking version and more details.
+func (c *Client) WalletProcessPSBTAsync(psbt string, sign bool, hashType SigHashType,
+	bip32Derivs bool) FutureWalletProcessPSBTResult {
+	cmd := btcjson.NewWalletProcessPSBTCmd(psbt, &sign, btcjson.String(string(hashType)), &bip32Derivs)
+	return c.sendCmd(cmd)
+}
+
+// WalletProcessPSBT adds what the wallet knows about the inputs of a base64 encoded partially signed transaction,
+// optionally signs the inputs it holds keys for with the passed hash type and finalizes the inputs that have all their
+// signatures.
+//
+// NOTE: Signing requires the wallet to be unlocked. See the WalletPassphrase function for more details.
+func (c *Client) WalletProcessPSBT(psbt string, sign bool, hashType SigHashType,
+	bip32Derivs bool) (*btcjson.WalletProcessPSBTResult, error) {
+	return c.WalletProcessPSBTAsync(psbt, sign, hashType, bip32Derivs).Receive()
+}
+
+// FutureDecodePSBTResult is a future promise to deliver the result of a DecodePSBTAsync RPC invocation (or an
+// applicable error).
+type FutureDecodePSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the decoded partially signed transaction.
+func (r FutureDecodePSBTResult) Receive() (*btcjson.DecodePSBTResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a decodepsbt result object.
+	var p btcjson.DecodePSBTResult
+	err = js.Unmarshal(res, &p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+// DecodePSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See DecodePSBT for the blocking version and more details.
+func (c *Client) DecodePSBTAsync(psbt string) FutureDecodePSBTResult {
+	cmd := btcjson.NewDecodePSBTCmd(psbt)
+	return c.sendCmd(cmd)
+}
+
+// DecodePSBT returns the unsigned transaction of a base64 encoded partially signed transaction and the data attached
+// to its inputs and outputs.
+func (c *Client) DecodePSBT(psbt string) (*btcjson.DecodePSBTResult, error) {
+	return c.DecodePSBTAsync(psbt).Receive()
+}
+
+// FutureFinalizePSBTResult is a future promise to deliver the result of a FinalizePSBTAsync RPC invocation (or an
+// applicable error).
+type FutureFinalizePSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the finalized partially signed transaction, or
+// the extracted transaction when it is complete.
+func (r FutureFinalizePSBTResult) Receive() (*btcjson.FinalizePSBTResult, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Unmarshal result as a finalizepsbt result object.
+	var p btcjson.FinalizePSBTResult
+	err = js.Unmarshal(res, &p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FinalizePSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See FinalizePSBT for the blocking version and more details.
+func (c *Client) FinalizePSBTAsync(psbt string, extract bool) FutureFinalizePSBTResult {
+	cmd := btcjson.NewFinalizePSBTCmd(psbt, &extract)
+	return c.sendCmd(cmd)
+}
+
+// FinalizePSBT finalizes the inputs of a base64 encoded partially signed transaction that have all their signatures.
+// When every input is finalized and extract is set the result carries the signed transaction in hex rather than the
+// packet.
+func (c *Client) FinalizePSBT(psbt string, extract bool) (*btcjson.FinalizePSBTResult, error) {
+	return c.FinalizePSBTAsync(psbt, extract).Receive()
+}
+
+// FutureCombinePSBTResult is a future promise to deliver the result of a CombinePSBTAsync RPC invocation (or an
+// applicable error).
+type FutureCombinePSBTResult chan *response
+
+// Receive waits for the response promised by the future and returns the combined partially signed transaction.
+func (r FutureCombinePSBTResult) Receive() (string, error) {
+	res, err := receiveFuture(r)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	// Unmarshal result as a string.
+	var psbt string
+	err = js.Unmarshal(res, &psbt)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	return psbt, nil
+}
+
+// CombinePSBTAsync returns an instance of a type that can be used to get the result of the RPC at some future time by
+// invoking the Receive function on the returned instance.
+//
+// See CombinePSBT for the blocking version and more details.
+func (c *Client) CombinePSBTAsync(psbts []string) FutureCombinePSBTResult {
+	cmd := btcjson.NewCombinePSBTCmd(psbts)
+	return c.sendCmd(cmd)
+}
+
+// CombinePSBT merges several base64 encoded copies of the same partially signed transaction, such as those signed by
+// different cosigners, into one.
+func (c *Client) CombinePSBT(psbts []string) (string, error) {
+	return c.CombinePSBTAsync(psbts).Receive()
+}
+
 // *********************
 // Dump/Import Functions
 // *********************
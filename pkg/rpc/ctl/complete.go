@@ -0,0 +1,47 @@
+package ctl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// Methods returns the names of the registered commands that can be sent over HTTP, sorted
+func Methods() (methods []string) {
+	for _, method := range btcjson.RegisteredCmdMethods() {
+		flags, err := btcjson.MethodUsageFlags(method)
+		if err != nil || flags&unusableFlags != 0 {
+			continue
+		}
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return
+}
+
+// IsWalletMethod returns whether the method is only served by the wallet
+func IsWalletMethod(method string) bool {
+	flags, err := btcjson.MethodUsageFlags(method)
+	return err == nil && flags&btcjson.UFWalletOnly != 0
+}
+
+// Complete returns the methods starting with the prefix, and the longest prefix they all share, which is what the
+// prefix can be completed to
+func Complete(methods []string, prefix string) (matches []string, common string) {
+	for _, method := range methods {
+		if strings.HasPrefix(method, prefix) {
+			matches = append(matches, method)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, prefix
+	}
+	common = matches[0]
+	for _, m := range matches[1:] {
+		for !strings.HasPrefix(m, common) {
+			common = common[:len(common)-1]
+		}
+	}
+	return
+}
@@ -0,0 +1,64 @@
+package ctl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	methods := []string{"getbalance", "getblock", "getblockcount", "getinfo", "sendtoaddress"}
+	tests := []struct {
+		prefix, common string
+		matches        int
+	}{
+		{"getbl", "getblock", 2},
+		{"getblockc", "getblockcount", 1},
+		{"get", "get", 4},
+		{"send", "sendtoaddress", 1},
+		{"x", "x", 0},
+	}
+	for _, test := range tests {
+		matches, common := Complete(methods, test.prefix)
+		if common != test.common || len(matches) != test.matches {
+			t.Errorf("%q completed to %q with %v", test.prefix, common, matches)
+		}
+	}
+	all := Methods()
+	if len(all) == 0 {
+		t.Fatal("no methods registered")
+	}
+	for _, m := range all {
+		if m == "notifyblocks" {
+			t.Error("websocket only methods should not be completed")
+		}
+	}
+	if !IsWalletMethod("getbalance") || IsWalletMethod("getblockcount") {
+		t.Error("wallet methods not told apart from chain methods")
+	}
+}
+
+func TestPretty(t *testing.T) {
+	tests := []struct {
+		result, want string
+	}{
+		{`null`, ""},
+		{`"text"`, "text"},
+		{`12`, "12"},
+		{`{"a":[1,true]}`, "{\n  \"a\": [\n    1,\n    true\n  ]\n}"},
+	}
+	for _, test := range tests {
+		got, err := Pretty([]byte(test.result), false)
+		if err != nil || got != test.want {
+			t.Errorf("%s formatted as %q, %v", test.result, got, err)
+		}
+	}
+	got, err := Pretty([]byte(`{"key":"va\"l","n":-1.5,"b":null}`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{colorKey + `"key"`, colorString + `"va\"l"`, colorNumber + "-1.5", colorLiteral + "null"} {
+		if !strings.Contains(got, part+colorReset) {
+			t.Errorf("%q not colored in %q", part, got)
+		}
+	}
+}
@@ -103,7 +103,7 @@ func sendPostRequest(marshalledJSON []byte, cx *conte.Xt, wallet bool) ([]byte,
 	serverAddr := *cx.Config.RPCConnect
 	if wallet {
 		serverAddr = *cx.Config.WalletServer
-		fmt.Println("using wallet server", serverAddr)
+		Debug("using wallet server", serverAddr)
 	}
 	url := protocol + "://" + serverAddr
 	bodyReader := bytes.NewReader(marshalledJSON)
@@ -0,0 +1,84 @@
+package ctl
+
+import (
+	"bytes"
+	js "encoding/json"
+	"strings"
+)
+
+// the ANSI colors of the parts of a JSON result
+const (
+	colorKey     = "\x1b[36m"
+	colorString  = "\x1b[32m"
+	colorNumber  = "\x1b[33m"
+	colorLiteral = "\x1b[35m"
+	colorReset   = "\x1b[0m"
+)
+
+// Pretty formats the raw JSON result of a call for reading. Objects and arrays are indented and, if color is set,
+// their keys and values are colored, a string result is printed without its quotes and a null result is empty.
+func Pretty(result []byte, color bool) (s string, err error) {
+	result = bytes.TrimSpace(result)
+	switch {
+	case len(result) == 0 || string(result) == "null":
+		return "", nil
+	case result[0] == '"':
+		err = js.Unmarshal(result, &s)
+		return
+	case result[0] != '{' && result[0] != '[':
+		return string(result), nil
+	}
+	var dst bytes.Buffer
+	if err = js.Indent(&dst, result, "", "  "); err != nil {
+		return
+	}
+	if !color {
+		return dst.String(), nil
+	}
+	return colorize(dst.Bytes()), nil
+}
+
+// colorize wraps the strings, numbers and literals of indented JSON in ANSI colors, strings followed by a colon are
+// keys
+func colorize(b []byte) string {
+	var out strings.Builder
+	for i := 0; i < len(b); {
+		switch c := b[i]; {
+		case c == '"':
+			j := i + 1
+			for ; j < len(b) && b[j] != '"'; j++ {
+				if b[j] == '\\' {
+					j++
+				}
+			}
+			j++
+			if j > len(b) {
+				j = len(b)
+			}
+			color := colorString
+			if j < len(b) && b[j] == ':' {
+				color = colorKey
+			}
+			out.WriteString(color)
+			out.Write(b[i:j])
+			out.WriteString(colorReset)
+			i = j
+		case c == '-' || c >= '0' && c <= '9' || c == 't' || c == 'f' || c == 'n':
+			j := i
+			for ; j < len(b) && !strings.ContainsRune(",]} \n", rune(b[j])); j++ {
+			}
+			color := colorNumber
+			if c == 't' || c == 'f' || c == 'n' {
+				color = colorLiteral
+			}
+			out.WriteString(color)
+			out.Write(b[i:j])
+			out.WriteString(colorReset)
+			i = j
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,36 @@
+package ctl
+
+import (
+	"net"
+	"time"
+
+	"github.com/p9c/pod/app/conte"
+)
+
+// rpcWaitInterval is how often the RPC server is tried while waiting for it
+const rpcWaitInterval = time.Second
+
+// WaitForRPC blocks until the RPC server of the node, or the wallet if wallet is set, accepts connections, or quit is
+// closed. It returns whether the server became reachable.
+func WaitForRPC(cx *conte.Xt, wallet bool, quit <-chan struct{}) bool {
+	addr := *cx.Config.RPCConnect
+	if wallet {
+		addr = *cx.Config.WalletServer
+	}
+	for logged := false; ; logged = true {
+		conn, err := net.DialTimeout("tcp", addr, rpcWaitInterval)
+		if err == nil {
+			if err = conn.Close(); Check(err) {
+			}
+			return true
+		}
+		if !logged {
+			Info("waiting for the RPC server at", addr)
+		}
+		select {
+		case <-time.After(rpcWaitInterval):
+		case <-quit:
+			return false
+		}
+	}
+}
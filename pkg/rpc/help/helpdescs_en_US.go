@@ -9,6 +9,10 @@ var helpDescsEnUS = map[string]string{
 	"addmultisigaddress-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
 	"addmultisigaddress-nrequired": "The number of signatures required to redeem outputs paid to this address",
 	"addmultisigaddress--result0":  "The imported pay-to-script-hash address",
+	// CombinePSBTCmd help.
+	"combinepsbt--synopsis": "Combines several copies of the same partially signed transaction, such as those signed by different cosigners, into one carrying all their signatures and data.",
+	"combinepsbt-txs":       "The partially signed transactions encoded as base64 strings",
+	"combinepsbt--result0":  "The combined partially signed transaction encoded as a base64 string",
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -16,10 +20,82 @@ var helpDescsEnUS = map[string]string{
 	// CreateMultisigResult help.
 	"createmultisigresult-address":      "The generated pay-to-script-hash address",
 	"createmultisigresult-redeemScript": "The script required to redeem outputs paid to the multisig address",
+	// DecodePSBTCmd help.
+	"decodepsbt--synopsis": "Returns a JSON object describing a partially signed transaction: its unsigned transaction and the data attached to its inputs and outputs.",
+	"decodepsbt-psbt":      "The partially signed transaction encoded as a base64 string",
+	// DecodePSBTResult help.
+	"decodepsbtresult-tx":             "The unsigned transaction",
+	"decodepsbtresult-unknown":        "The fields of the packet that are not understood",
+	"decodepsbtresult-unknown--desc":  "JSON object using the hex-encoded keys of the fields as keys",
+	"decodepsbtresult-unknown--key":   "The hex-encoded key of the field",
+	"decodepsbtresult-unknown--value": "The hex-encoded value of the field",
+	"decodepsbtresult-inputs":         "The data attached to each input",
+	"decodepsbtresult-outputs":        "The data attached to each output",
+	"decodepsbtresult-fee":            "The fee paid by the transaction valued in bitcoin, when the outputs spent by all the inputs are known",
+	// DecodePSBTInputResult help.
+	"decodepsbtinputresult-non_witness_utxo":          "The output spent by the input, taken from the previous transaction attached to it",
+	"decodepsbtinputresult-partial_signatures":        "The signatures collected for the input",
+	"decodepsbtinputresult-partial_signatures--desc":  "JSON object using the hex-encoded public keys that signed as keys",
+	"decodepsbtinputresult-partial_signatures--key":   "The hex-encoded public key",
+	"decodepsbtinputresult-partial_signatures--value": "The hex-encoded signature",
+	"decodepsbtinputresult-sighash":                   "The sighash type the input is to be signed with",
+	"decodepsbtinputresult-redeem_script":             "The redeem script of a pay-to-script-hash input",
+	"decodepsbtinputresult-bip32_derivs":              "The derivations of the keys that sign the input",
+	"decodepsbtinputresult-final_scriptsig":           "The signature script of a finalized input",
+	"decodepsbtinputresult-unknown":                   "The fields of the input that are not understood",
+	"decodepsbtinputresult-unknown--desc":             "JSON object using the hex-encoded keys of the fields as keys",
+	"decodepsbtinputresult-unknown--key":              "The hex-encoded key of the field",
+	"decodepsbtinputresult-unknown--value":            "The hex-encoded value of the field",
+	// DecodePSBTOutputResult help.
+	"decodepsbtoutputresult-redeem_script":  "The redeem script of a pay-to-script-hash output",
+	"decodepsbtoutputresult-bip32_derivs":   "The derivations of the keys of the output",
+	"decodepsbtoutputresult-unknown":        "The fields of the output that are not understood",
+	"decodepsbtoutputresult-unknown--desc":  "JSON object using the hex-encoded keys of the fields as keys",
+	"decodepsbtoutputresult-unknown--key":   "The hex-encoded key of the field",
+	"decodepsbtoutputresult-unknown--value": "The hex-encoded value of the field",
+	// PSBTBip32DerivResult help.
+	"psbtbip32derivresult-pubkey":             "The hex-encoded public key",
+	"psbtbip32derivresult-master_fingerprint": "The fingerprint of the master key the public key is derived from",
+	"psbtbip32derivresult-path":               "The derivation path of the public key",
+	// TxRawDecodeResult help.
+	"txrawdecoderesult-txid":     "The hash of the transaction",
+	"txrawdecoderesult-version":  "The transaction version",
+	"txrawdecoderesult-locktime": "The transaction lock time",
+	"txrawdecoderesult-vin":      "The transaction inputs as JSON objects",
+	"txrawdecoderesult-vout":     "The transaction outputs as JSON objects",
+	// Vin help.
+	"vin-coinbase":    "The hex-encoded bytes of the signature script (coinbase txns only)",
+	"vin-txid":        "The hash of the origin transaction (non-coinbase txns only)",
+	"vin-vout":        "The index of the output being redeemed from the origin transaction (non-coinbase txns only)",
+	"vin-scriptSig":   "The signature script used to redeem the origin transaction as a JSON object (non-coinbase txns only)",
+	"vin-txinwitness": "The witness used to redeem the input encoded as a string array of its items",
+	"vin-sequence":    "The script sequence number",
+	// ScriptSig help.
+	"scriptsig-asm": "Disassembly of the script",
+	"scriptsig-hex": "Hex-encoded bytes of the script",
+	// Vout help.
+	"vout-value":        "The amount valued in bitcoin",
+	"vout-n":            "The index of this transaction output",
+	"vout-scriptPubKey": "The public key script used to pay coins as a JSON object",
+	// ScriptPubKeyResult help.
+	"scriptpubkeyresult-asm":       "Disassembly of the script",
+	"scriptpubkeyresult-hex":       "Hex-encoded bytes of the script",
+	"scriptpubkeyresult-reqSigs":   "The number of required signatures",
+	"scriptpubkeyresult-type":      "The type of the script (e.g. 'pubkeyhash')",
+	"scriptpubkeyresult-addresses": "The addresses associated with this script",
 	// DumpPrivKeyCmd help.
 	"dumpprivkey--synopsis": "Returns the private key in WIF encoding that controls some wallet address.",
 	"dumpprivkey-address":   "The address to return a private key for",
 	"dumpprivkey--result0":  "The WIF-encoded private key",
+	// FinalizePSBTCmd help.
+	"finalizepsbt--synopsis": "Finalizes the inputs of a partially signed transaction that have collected all their signatures.\n" +
+		"When every input is finalized and extract is set the network-serialized transaction is returned, ready to be broadcast with sendrawtransaction, otherwise the updated packet is.",
+	"finalizepsbt-psbt":    "The partially signed transaction encoded as a base64 string",
+	"finalizepsbt-extract": "Return the transaction rather than the packet once it is complete",
+	// FinalizePSBTResult help.
+	"finalizepsbtresult-psbt":     "The partially signed transaction encoded as a base64 string, unless the transaction is extracted",
+	"finalizepsbtresult-hex":      "The signed transaction encoded as a hexadecimal string, when it is extracted",
+	"finalizepsbtresult-complete": "Whether every input is finalized",
 	// GetAccountCmd help.
 	"getaccount--synopsis": "DEPRECATED -- Lookup the account name that some wallet address belongs to.",
 	"getaccount-address":   "The address to query the account for",
@@ -46,6 +122,7 @@ var helpDescsEnUS = map[string]string{
 	"getbestblockhash--result0":  "The hash of the most recent synced-to block",
 	// GetBlockCountCmd help.
 	"getblockcount--synopsis": "Returns the blockchain height of the newest block in the best chain that wallet has finished syncing with.",
+	"getblockcount-snapshot":  "Unused",
 	"getblockcount--result0":  "The blockchain height of the most recent synced-to block",
 	// GetInfoCmd help.
 	"getinfo--synopsis": "Returns a JSON object containing various state info.",
@@ -88,6 +165,15 @@ var helpDescsEnUS = map[string]string{
 	"gettransaction--synopsis":        "Returns a JSON object with details regarding a transaction relevant to this wallet.",
 	"gettransaction-txid":             "Hash of the transaction to query",
 	"gettransaction-includewatchonly": "Also consider transactions involving watched addresses",
+	// GetWalletInfoCmd help.
+	"getwalletinfo--synopsis": "Returns whether the wallet is watching-only or locked, its birthday, and whether its database is encrypted.",
+	// GetWalletInfoResult help.
+	"getwalletinforesult-watchingonly":      "Whether the wallet holds no private keys, having been created from the extended public key of an account",
+	"getwalletinforesult-locked":            "Whether the wallet is locked, which a watching-only wallet always is",
+	"getwalletinforesult-birthday":          "The Unix time before which the wallet has no transactions",
+	"getwalletinforesult-signingdevice":     "The model of the signing device, such as a hardware wallet, holding the keys of the default account, if it is paired with one",
+	"getwalletinforesult-fingerprint":       "The fingerprint of the master key of the signing device, if the default account is paired with one",
+	"getwalletinforesult-databaseencrypted": "Whether the whole wallet database is encrypted with a key derived from the public passphrase",
 	// HelpCmd help.
 	"help--synopsis":   "Returns a list of all commands or help for a specified command.",
 	"help-command":     "The command to retrieve help for",
@@ -131,7 +217,7 @@ var helpDescsEnUS = map[string]string{
 	"listaccounts--result0--key":   "The account name",
 	"listaccounts--result0--value": "The account balance valued in bitcoin",
 	// ListLockUnspentCmd help.
-	"listlockunspent--synopsis": "Returns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.",
+	"listlockunspent--synopsis": "Returns a JSON array of outpoints marked as locked (with lockunspent).",
 	// TransactionInput help.
 	"transactioninput-txid": "The transaction hash of the referenced output",
 	"transactioninput-vout": "The output index of the referenced output",
@@ -173,7 +259,9 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-address":            "Payment address for a transaction output",
 	"listtransactionsresult-category":           `The kind of transaction: "send" for sent transactions, "immature" for immature coinbase outputs, "generate" for mature coinbase outputs, or "recv" for all other received outputs.  Note: A single output may be included multiple times under different categories`,
 	"listtransactionsresult-amount":             "The value of the transaction output valued in bitcoin",
-	"listtransactionsresult-fee":                "The total input value minus the total output value for sent transactions",
+	"listtransactionsresult-fee":                "The total output value minus the total input value for sent transactions, unset if the value of an input the wallet doesn't own can't be fetched from the chain server",
+	"listtransactionsresult-vsize":              "The virtual size of sent transactions in bytes",
+	"listtransactionsresult-feerate":            "The effective fee rate of sent transactions in satoshis per virtual byte, unset when the fee is unknown",
 	"listtransactionsresult-confirmations":      "The number of block confirmations of the transaction",
 	"listtransactionsresult-generated":          "Whether the transaction output is a coinbase output",
 	"listtransactionsresult-blockhash":          "The hash of the block this transaction is mined in, or the empty string if unmined",
@@ -182,6 +270,7 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-txid":               "The hash of the transaction",
 	"listtransactionsresult-vout":               "The transaction output index",
 	"listtransactionsresult-walletconflicts":    "Unset",
+	"listtransactionsresult-conflicted":         "Whether a competing spend of an input of the transaction was mined, in which case confirmations is -1 and walletconflicts holds the competing transaction",
 	"listtransactionsresult-time":               "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-timereceived":       "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-involveswatchonly":  "Unset",
@@ -214,7 +303,7 @@ var helpDescsEnUS = map[string]string{
 	// LockUnspentCmd help.
 	"lockunspent--synopsis": "Locks or unlocks an unspent output.\n" +
 		"Locked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\n" +
-		"Locked outputs are volatile and are not saved across wallet restarts.\n" +
+		"Locked outputs are saved in the wallet and stay locked across wallet restarts until they are unlocked or spent.\n" +
 		"If unlock is true and no transaction outputs are specified, all locked outputs are marked unlocked.",
 	"lockunspent-unlock":       "True to unlock outputs, false to lock",
 	"lockunspent-transactions": "Transaction outputs to lock or unlock",
@@ -239,6 +328,7 @@ var helpDescsEnUS = map[string]string{
 	"sendmany-amounts--value": "Amount to send to the payment address valued in bitcoin",
 	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
 	"sendmany-comment":        "Unused",
+	"sendmany-feerate":        "The fee rate in bitcoin per kilobyte (default=the relay fee)",
 	"sendmany--result0":       "The transaction hash of the sent transaction",
 	// SendToAddressCmd help.
 	"sendtoaddress--synopsis": "Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
@@ -248,6 +338,7 @@ var helpDescsEnUS = map[string]string{
 	"sendtoaddress-amount":    "Amount to send to the payment address valued in bitcoin",
 	"sendtoaddress-comment":   "Unused",
 	"sendtoaddress-commentto": "Unused",
+	"sendtoaddress-feerate":   "The fee rate in bitcoin per kilobyte (default=the relay fee)",
 	"sendtoaddress--result0":  "The transaction hash of the sent transaction",
 	// SetTxFeeCmd help.
 	"settxfee--synopsis": "Modify the increment used each time more fee is required for an authored transaction.",
@@ -296,12 +387,36 @@ var helpDescsEnUS = map[string]string{
 	"validateaddresswalletresult-hex":          "The redeem script ",
 	"validateaddresswalletresult-script":       "The class of redeem script for a multisig address",
 	"validateaddresswalletresult-sigsrequired": "The number of required signatures to redeem outputs to the multisig address",
+	"validateaddresswalletresult-hdkeypath":    "The derivation path of the key of the address from the master key, if it was derived rather than imported",
 	// VerifyMessageCmd help.
 	"verifymessage--synopsis": "Verify a message was signed with the associated private key of some address.",
 	"verifymessage-address":   "Address used to sign message",
 	"verifymessage-signature": "The signature to verify",
 	"verifymessage-message":   "The message to verify",
 	"verifymessage--result0":  "Whether the message was signed with the private key of 'address'",
+	// WalletCreateFundedPSBTCmd help.
+	"walletcreatefundedpsbt--synopsis": "Creates an unsigned transaction paying the outputs from an account, adding outputs of the account to the inputs passed until they cover the amount and fee, and returns it as a partially signed transaction.\n" +
+		"The packet carries the previous transactions of its inputs, so any signer can sign it with walletprocesspsbt.\n" +
+		"Change is paid to a pay-to-pubkey-hash address.",
+	"walletcreatefundedpsbt-inputs":         "Outputs of the account that must be spent",
+	"walletcreatefundedpsbt-outputs":        "Pairs of payment addresses and the output amount to pay each",
+	"walletcreatefundedpsbt-outputs--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"walletcreatefundedpsbt-outputs--key":   "Address to pay",
+	"walletcreatefundedpsbt-outputs--value": "Amount to send to the payment address valued in bitcoin",
+	"walletcreatefundedpsbt-locktime":       "The lock time of the transaction",
+	"walletcreatefundedpsbt-options":        "Options for funding the transaction",
+	"walletcreatefundedpsbt-bip32derivs":    "Add the derivations of the keys of the inputs and the change",
+	// WalletCreateFundedPSBTOpts help.
+	"walletcreatefundedpsbtopts-account":        "Account to pick unspent outputs from (default=\"default\")",
+	"walletcreatefundedpsbtopts-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent (default=1)",
+	"walletcreatefundedpsbtopts-changeAddress":  "Address to pay the change to (default=a new change address of the account)",
+	"walletcreatefundedpsbtopts-changePosition": "The index of the change output (default=random)",
+	"walletcreatefundedpsbtopts-lockUnspents":   "Lock the spent outputs so they are not spent again before the transaction is published",
+	"walletcreatefundedpsbtopts-feeRate":        "The fee rate in bitcoin per kilobyte (default=the relay fee)",
+	// WalletCreateFundedPSBTResult help.
+	"walletcreatefundedpsbtresult-psbt":      "The partially signed transaction encoded as a base64 string",
+	"walletcreatefundedpsbtresult-fee":       "The fee paid by the transaction valued in bitcoin",
+	"walletcreatefundedpsbtresult-changepos": "The index of the change output, -1 when there is none",
 	// WalletLockCmd help.
 	"walletlock--synopsis": "Lock the wallet.",
 	// WalletPassphraseCmd help.
@@ -312,20 +427,150 @@ var helpDescsEnUS = map[string]string{
 	"walletpassphrasechange--synopsis":     "Change the wallet passphrase.",
 	"walletpassphrasechange-oldpassphrase": "The old wallet passphrase",
 	"walletpassphrasechange-newpassphrase": "The new wallet passphrase",
+	// WalletProcessPSBTCmd help.
+	"walletprocesspsbt--synopsis": "Updates a partially signed transaction with what the wallet knows about its inputs, signs the inputs it holds keys for and finalizes those that have all their signatures.\n" +
+		"The valid sighashtype options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.",
+	"walletprocesspsbt-psbt":        "The partially signed transaction encoded as a base64 string",
+	"walletprocesspsbt-sign":        "Sign the inputs the wallet holds keys for, which needs the wallet to be unlocked",
+	"walletprocesspsbt-sighashtype": "The sighash type to sign with",
+	"walletprocesspsbt-bip32derivs": "Add the derivations of the wallet's keys",
+	// WalletProcessPSBTResult help.
+	"walletprocesspsbtresult-psbt":     "The updated partially signed transaction encoded as a base64 string",
+	"walletprocesspsbtresult-complete": "Whether every input is finalized",
 	// CreateNewAccountCmd help.
 	"createnewaccount--synopsis": "Creates a new account.\n" +
 		"The wallet must be unlocked for this request to succeed.",
 	"createnewaccount-account": "Name of the new account",
+	// CreateMultisigAccountCmd help.
+	"createmultisigaccount--synopsis": "Creates an m of n multisig account from the extended public keys of the cosigners and the wallet account of the same name.\n" +
+		"The wallet account's extended public key is added to the cosigner keys and all keys are sorted, so every cosigner derives the same addresses.",
+	"createmultisigaccount-account":   "Name of the multisig account, which must also be the name of an existing wallet account",
+	"createmultisigaccount-nrequired": "The number of signatures required to spend from the account",
+	"createmultisigaccount-keys":      "The extended public keys of the other cosigners",
+	// MultisigAccountResult help.
+	"multisigaccountresult-account":   "The name of the multisig account",
+	"multisigaccountresult-required":  "The number of signatures required to spend from the account",
+	"multisigaccountresult-keys":      "The sorted extended public keys of all cosigners",
+	"multisigaccountresult-xpub":      "The extended public key this wallet contributes to the account",
+	"multisigaccountresult-addresses": "The number of addresses derived for the account",
+	"multisigaccountresult-balance":   "The total of the unspent outputs paying to the account's addresses",
+	"multisigaccountresult-spendable": "The part of the balance with at least one confirmation",
+	// CreateMultisigPSBTCmd help.
+	"createmultisigpsbt--synopsis": "Creates a partially signed transaction spending from a multisig account.\n" +
+		"The transaction is passed between the cosigners with signmultisigpsbt until it has collected enough signatures.",
+	"createmultisigpsbt-account":        "The multisig account to spend from",
+	"createmultisigpsbt-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"createmultisigpsbt-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"createmultisigpsbt-amounts--key":   "Address to pay",
+	"createmultisigpsbt-amounts--value": "Amount to send to the payment address valued in bitcoin",
+	"createmultisigpsbt-feerate":        "The fee rate in bitcoin per kilobyte (default=the relay fee)",
+	// MultisigPSBTResult help.
+	"multisigpsbtresult-account":  "The multisig account the transaction spends from",
+	"multisigpsbtresult-txid":     "The hash of the unsigned transaction",
+	"multisigpsbtresult-psbt":     "The partially signed transaction encoded as a base64 string",
+	"multisigpsbtresult-signed":   "The number of signatures added by this request",
+	"multisigpsbtresult-complete": "Whether every input has collected enough signatures",
+	"multisigpsbtresult-hex":      "The signed transaction encoded as a hexadecimal string, once complete",
+	"multisigpsbtresult-inputs":   "The signing progress of each input",
+	// MultisigPSBTInputResult help.
+	"multisigpsbtinputresult-signatures": "The number of signatures collected for the input",
+	"multisigpsbtinputresult-required":   "The number of signatures required to spend the input",
+	// CreatePaymentRequestCmd help.
+	"createpaymentrequest--synopsis": "Creates a request for a payment to a new address of the default account.\n" +
+		"The request is marked fulfilled once the address has received the amount in confirmed transactions, or expired if that has not happened before the expiry.",
+	"createpaymentrequest-amount":  "The amount to request valued in bitcoin",
+	"createpaymentrequest-label":   "A label for the request",
+	"createpaymentrequest-message": "A message describing the payment",
+	"createpaymentrequest-expiry":  "The number of seconds after which an unpaid request expires, or 0 to never expire",
+	// PaymentRequestResult help.
+	"paymentrequestresult-address":   "The address the payment is requested to",
+	"paymentrequestresult-amount":    "The requested amount valued in bitcoin",
+	"paymentrequestresult-label":     "The label of the request",
+	"paymentrequestresult-message":   "The message of the request",
+	"paymentrequestresult-created":   "The Unix time the request was created",
+	"paymentrequestresult-expires":   "The Unix time the request expires, or 0 if it never expires",
+	"paymentrequestresult-status":    "The state of the request: \"pending\", \"fulfilled\" or \"expired\"",
+	"paymentrequestresult-received":  "The amount received by the address in confirmed transactions valued in bitcoin",
+	"paymentrequestresult-fulfilled": "The time of the block that fulfilled the request, or unset if it is not fulfilled",
+	// CreateSigningDevicePSBTCmd help.
+	"createsigningdevicepsbt--synopsis": "Creates an unsigned transaction spending from an account paired with a signing device, such as a hardware wallet, as a partially signed transaction carrying the key derivations the device needs to sign it.\n" +
+		"Once the device has signed it the transaction is broadcast with publishpsbt.",
+	"createsigningdevicepsbt-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"createsigningdevicepsbt-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"createsigningdevicepsbt-amounts--key":   "Address to pay",
+	"createsigningdevicepsbt-amounts--value": "Amount to send to the payment address valued in bitcoin",
+	"createsigningdevicepsbt-fromaccount":    "Account to pick unspent outputs from, which must be paired with a signing device",
+	"createsigningdevicepsbt-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"createsigningdevicepsbt-feerate":        "The fee rate in bitcoin per kilobyte (default=the relay fee)",
+	// SigningDevicePSBTResult help.
+	"signingdevicepsbtresult-txid":          "The hash of the unsigned transaction",
+	"signingdevicepsbtresult-psbt":          "The partially signed transaction encoded as a base64 string",
+	"signingdevicepsbtresult-fee":           "The fee paid by the transaction valued in bitcoin",
+	"signingdevicepsbtresult-signingdevice": "The model of the signing device the account is paired with",
+	"signingdevicepsbtresult-fingerprint":   "The fingerprint of the master key of the signing device",
 	// ExportWatchingWalletCmd help.
 	"exportwatchingwallet--synopsis": "Creates and returns a duplicate of the wallet database without any private keys to be used as a watching-only wallet.",
 	"exportwatchingwallet-account":   "Unused (must be unset or \"*\")",
 	"exportwatchingwallet-download":  "Unused",
 	"exportwatchingwallet--result0":  "The watching-only database encoded as a base64 string",
+	// GetAccountXPubCmd help.
+	"getaccountxpub--synopsis": "Returns the extended public key of an account, to be shared with cosigners of a multisig account.",
+	"getaccountxpub-account":   "The account to return the extended public key of",
+	"getaccountxpub--result0":  "The extended public key of the account",
 	// GetBestBlockCmd help.
 	"getbestblock--synopsis": "Returns the hash and height of the newest block in the best chain that wallet has finished syncing with.",
 	// GetBestBlockResult help.
 	"getbestblockresult-hash":   "The hash of the block",
 	"getbestblockresult-height": "The blockchain height of the block",
+	// GetNewMultisigAddressCmd help.
+	"getnewmultisigaddress--synopsis": "Derives the next receiving address of a multisig account.",
+	"getnewmultisigaddress-account":   "The multisig account to derive the address for",
+	"getnewmultisigaddress--result0":  "The pay to script hash address",
+	// GetPrivacyReportCmd help.
+	"getprivacyreport--synopsis": "Analyses the wallet's transaction history for address reuse, transactions merging several addresses and round amount payments, and suggests how to avoid them.",
+	// PrivacyReportResult help.
+	"privacyreportresult-transactions":    "The number of wallet transactions analysed",
+	"privacyreportresult-reusedaddresses": "Wallet addresses that received funds in more than one transaction",
+	"privacyreportresult-mergedinputs":    "Transactions that spent outputs of more than one wallet address together",
+	"privacyreportresult-roundamounts":    "Payments of round amounts that give away which output is the change",
+	"privacyreportresult-suggestions":     "Suggestions for improving the privacy of future transactions",
+	// ReusedAddressResult help.
+	"reusedaddressresult-address":  "The reused address",
+	"reusedaddressresult-received": "The number of transactions that paid the address",
+	"reusedaddressresult-amount":   "The total amount received by the address valued in bitcoin",
+	// MergedInputsResult help.
+	"mergedinputsresult-txid":      "The hash of the transaction",
+	"mergedinputsresult-addresses": "The wallet addresses whose outputs were spent together",
+	// RoundAmountResult help.
+	"roundamountresult-txid":    "The hash of the transaction",
+	"roundamountresult-address": "The address that was paid",
+	"roundamountresult-amount":  "The round amount paid valued in bitcoin",
+	// GetReconciliationCmd help.
+	"getreconciliation--synopsis": "Returns the outcome of the latest reconciliation of the wallet history with the chain, which runs each time the wallet connects to it and finds the transactions a wallet restored from a backup is missing.",
+	// GetReconciliationResult help.
+	"getreconciliationresult-reconciled":       "Whether the wallet history has been reconciled with the chain since the wallet connected to it",
+	"getreconciliationresult-changed":          "Whether the wallet history was found out of line with the chain",
+	"getreconciliationresult-started":          "The Unix time the reconciliation started",
+	"getreconciliationresult-finished":         "The Unix time the reconciliation finished",
+	"getreconciliationresult-fromheight":       "The height the wallet was synced to before the reconciliation",
+	"getreconciliationresult-toheight":         "The best height of the chain when the reconciliation started",
+	"getreconciliationresult-addressesderived": "The number of addresses found in use on the chain that had not been derived",
+	"getreconciliationresult-missingtxs":       "The hashes of the transactions found on the chain that the wallet did not have",
+	"getreconciliationresult-extratxs":         "The hashes of the transactions the wallet had that the chain rejected, which were removed",
+	"getreconciliationresult-balancebefore":    "The balance of the wallet before the reconciliation valued in bitcoin",
+	"getreconciliationresult-balanceafter":     "The balance of the wallet after the reconciliation valued in bitcoin",
+	// GetWalletBalancesCmd help.
+	"getwalletbalances--synopsis": "Returns the balances of an account, or of all accounts, broken down by the confirmations of the unspent outputs, all taken from the same state of the wallet.",
+	"getwalletbalances-account":   "The account to query the balances for, or \"*\" for all accounts",
+	// GetWalletBalancesResult help.
+	"getwalletbalancesresult-height":      "The height of the block the wallet was synced to when the balances were taken",
+	"getwalletbalancesresult-blockhash":   "The hash of the block the wallet was synced to when the balances were taken",
+	"getwalletbalancesresult-unconfirmed": "The value of the unmined outputs valued in bitcoin",
+	"getwalletbalancesresult-confirming":  "The value of the outputs with 1 to 5 confirmations valued in bitcoin",
+	"getwalletbalancesresult-confirmed":   "The value of the outputs with 6 or more confirmations valued in bitcoin",
+	"getwalletbalancesresult-immature":    "The value of the coinbase outputs that have not reached maturity valued in bitcoin",
+	"getwalletbalancesresult-locked":      "The value of the outputs locked with lockunspent valued in bitcoin",
+	"getwalletbalancesresult-total":       "The value of all unspent outputs, the sum of the other balances, valued in bitcoin",
 	// GetUnconfirmedBalanceCmd help.
 	"getunconfirmedbalance--synopsis": "Calculates the unspent output value of all unmined transaction outputs for an account.",
 	"getunconfirmedbalance-account":   "The account to query the unconfirmed balance for (default=\"default\")",
@@ -337,10 +582,56 @@ var helpDescsEnUS = map[string]string{
 	// ListAllTransactionsCmd help.
 	"listalltransactions--synopsis": "Returns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.",
 	"listalltransactions-account":   "Unused (must be unset or \"*\")",
+	// ListMultisigAccountsCmd help.
+	"listmultisigaccounts--synopsis": "Returns a JSON array of the wallet's multisig accounts.",
+	// ListMultisigPSBTsCmd help.
+	"listmultisigpsbts--synopsis": "Returns a JSON array of the partially signed transactions of the wallet's multisig accounts that are still collecting signatures or waiting to be broadcast.",
+	// ListPaymentRequestsCmd help.
+	"listpaymentrequests--synopsis": "Returns a JSON array of the wallet's payment requests, oldest first.",
+	// PreviewSendCmd help.
+	"previewsend--synopsis": "Creates the transaction a sendmany with the same arguments would send, without signing or broadcasting it, and returns its fee.\n" +
+		"When the transaction spends unconfirmed change the fee rate of the whole unconfirmed package is also returned, which is the rate miners see when deciding whether to include the parents.",
+	"previewsend-amounts":        "Pairs of payment addresses and the output amount to pay each",
+	"previewsend-amounts--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"previewsend-amounts--key":   "Address to pay",
+	"previewsend-amounts--value": "Amount to send to the payment address valued in bitcoin",
+	"previewsend-fromaccount":    "Account to pick unspent outputs from",
+	"previewsend-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"previewsend-feerate":        "The fee rate in bitcoin per kilobyte (default=the relay fee)",
+	// PreviewSendResult help.
+	"previewsendresult-fee":            "The fee paid by the transaction valued in bitcoin",
+	"previewsendresult-vsize":          "The estimated virtual size of the signed transaction",
+	"previewsendresult-feerate":        "The fee rate of the transaction on its own in bitcoin per kilobyte",
+	"previewsendresult-ancestorfees":   "The total fee paid by the unconfirmed transactions the inputs spend from",
+	"previewsendresult-ancestorvsize":  "The total virtual size of the unconfirmed transactions the inputs spend from",
+	"previewsendresult-packagefeerate": "The fee rate of the transaction together with its unconfirmed ancestors in bitcoin per kilobyte",
+	"previewsendresult-cpfp":           "Whether the fee was raised to pay for unconfirmed ancestors (child pays for parent)",
+	// PublishPSBTCmd help.
+	"publishpsbt--synopsis": "Finalizes a partially signed transaction whose inputs have all been signed, such as one created with createsigningdevicepsbt and signed by a hardware wallet, and broadcasts it.\n" +
+		"Returns the hash of the transaction.",
+	"publishpsbt-psbt":     "The signed partially signed transaction encoded as a base64 string",
+	"publishpsbt--result0": "The hash of the published transaction",
 	// RenameAccountCmd help.
 	"renameaccount--synopsis":  "Renames an account.",
 	"renameaccount-oldaccount": "The old account name to rename",
 	"renameaccount-newaccount": "The new name for the account",
+	// EncryptWalletDBCmd help.
+	"encryptwalletdb--synopsis": "Encrypts the whole wallet database, including the transaction history, with a key derived from the public passphrase, while the wallet keeps running.\n" +
+		"The public passphrase must have been changed from the default, and is then needed to open the wallet.",
+	"encryptwalletdb-passphrase": "The public passphrase of the wallet",
+	// RescanFromHeightCmd help.
+	"rescanfromheight--synopsis": "Rescans the chain from the block at the given height for the transactions of all of the wallet's addresses and unspent outputs.\n" +
+		"Returns once the rescan is started. Its progress is sent to websocket clients in walletrescanprogress notifications, with the start, current and end heights, the percentage done, the number of transactions found and whether it finished.",
+	"rescanfromheight-height": "The height of the block to start the rescan from",
+	// SignMultisigPSBTCmd help.
+	"signmultisigpsbt--synopsis": "Adds the wallet's signatures to a partially signed multisig transaction and merges in the signatures already collected for it.\n" +
+		"Once enough signatures are present the signed transaction is returned in the hex field, ready for sendrawtransaction.\n" +
+		"The wallet must be unlocked for this request to succeed.",
+	"signmultisigpsbt-psbt": "The partially signed transaction encoded as a base64 string",
+	// ImportMultisigPSBTCmd help.
+	"importmultisigpsbt--synopsis": "Merges the signatures a cosigner added to a partially signed multisig transaction into the signatures already collected for it, without signing it.\n" +
+		"Once enough signatures are present the signed transaction is returned in the hex field, ready for sendrawtransaction.",
+	"importmultisigpsbt-psbt": "The partially signed transaction encoded as a base64 string",
 	// WalletIsLockedCmd help.
 	"walletislocked--synopsis": "Returns whether or not the wallet is locked.",
 	"walletislocked--result0":  "Whether the wallet is locked",
@@ -21,8 +21,11 @@ var Methods = []struct {
 	ResultTypes []interface{}
 }{
 	{"addmultisigaddress", returnsString},
+	{"combinepsbt", returnsString},
 	{"createmultisig", []interface{}{(*btcjson.CreateMultiSigResult)(nil)}},
+	{"decodepsbt", []interface{}{(*btcjson.DecodePSBTResult)(nil)}},
 	{"dumpprivkey", returnsString},
+	{"finalizepsbt", []interface{}{(*btcjson.FinalizePSBTResult)(nil)}},
 	{"getaccount", returnsString},
 	{"getaccountaddress", returnsString},
 	{"getaddressesbyaccount", returnsStringArray},
@@ -35,6 +38,7 @@ var Methods = []struct {
 	{"getreceivedbyaccount", returnsNumber},
 	{"getreceivedbyaddress", returnsNumber},
 	{"gettransaction", []interface{}{(*btcjson.GetTransactionResult)(nil)}},
+	{"getwalletinfo", []interface{}{(*btcjson.GetWalletInfoResult)(nil)}},
 	{"help", append(returnsString, returnsString[0])},
 	{"importprivkey", nil},
 	{"keypoolrefill", nil},
@@ -54,16 +58,36 @@ var Methods = []struct {
 	{"signrawtransaction", []interface{}{(*btcjson.SignRawTransactionResult)(nil)}},
 	{"validateaddress", []interface{}{(*btcjson.ValidateAddressWalletResult)(nil)}},
 	{"verifymessage", returnsBool},
+	{"walletcreatefundedpsbt", []interface{}{(*btcjson.WalletCreateFundedPSBTResult)(nil)}},
 	{"walletlock", nil},
 	{"walletpassphrase", nil},
 	{"walletpassphrasechange", nil},
+	{"walletprocesspsbt", []interface{}{(*btcjson.WalletProcessPSBTResult)(nil)}},
 	{"createnewaccount", nil},
+	{"createmultisigaccount", []interface{}{(*btcjson.MultisigAccountResult)(nil)}},
+	{"createmultisigpsbt", []interface{}{(*btcjson.MultisigPSBTResult)(nil)}},
+	{"createpaymentrequest", []interface{}{(*btcjson.PaymentRequestResult)(nil)}},
+	{"createsigningdevicepsbt", []interface{}{(*btcjson.SigningDevicePSBTResult)(nil)}},
+	{"encryptwalletdb", nil},
 	{"exportwatchingwallet", returnsString},
+	{"getaccountxpub", returnsString},
 	{"getbestblock", []interface{}{(*btcjson.GetBestBlockResult)(nil)}},
+	{"getnewmultisigaddress", returnsString},
+	{"getprivacyreport", []interface{}{(*btcjson.PrivacyReportResult)(nil)}},
+	{"getreconciliation", []interface{}{(*btcjson.GetReconciliationResult)(nil)}},
 	{"getunconfirmedbalance", returnsNumber},
+	{"getwalletbalances", []interface{}{(*btcjson.GetWalletBalancesResult)(nil)}},
+	{"importmultisigpsbt", []interface{}{(*btcjson.MultisigPSBTResult)(nil)}},
 	{"listaddresstransactions", returnsLTRArray},
 	{"listalltransactions", returnsLTRArray},
+	{"listmultisigaccounts", []interface{}{(*[]btcjson.MultisigAccountResult)(nil)}},
+	{"listmultisigpsbts", []interface{}{(*[]btcjson.MultisigPSBTResult)(nil)}},
+	{"listpaymentrequests", []interface{}{(*[]btcjson.PaymentRequestResult)(nil)}},
+	{"previewsend", []interface{}{(*btcjson.PreviewSendResult)(nil)}},
+	{"publishpsbt", returnsString},
 	{"renameaccount", nil},
+	{"rescanfromheight", nil},
+	{"signmultisigpsbt", []interface{}{(*btcjson.MultisigPSBTResult)(nil)}},
 	{"walletislocked", returnsBool},
 }
 
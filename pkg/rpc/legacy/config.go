@@ -1,9 +1,17 @@
 package legacy
 
+import (
+	"github.com/p9c/pod/pkg/rpc/audit"
+)
+
 // Options contains the required options for running the legacy RPC server.
 type Options struct {
 	Username            string
 	Password            string
 	MaxPOSTClients      int64
 	MaxWebsocketClients int64
+	// AuditLog records the state-changing commands run on the server, it is closed when the server stops
+	AuditLog *audit.Log
+	// CanonicalJSON encodes the results of commands as canonical JSON
+	CanonicalJSON bool
 }
@@ -35,18 +35,36 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.AddMultisigAddressCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "combinepsbt",
+		Handler: "CombinePSBT",
+		Cmd:     "*btcjson.CombinePSBTCmd",
+		ResType: "string",
+	},
 	{
 		Method:  "createmultisig",
 		Handler: "CreateMultiSig",
 		Cmd:     "*btcjson.CreateMultisigCmd",
 		ResType: "btcjson.CreateMultiSigResult",
 	},
+	{
+		Method:  "decodepsbt",
+		Handler: "DecodePSBT",
+		Cmd:     "*btcjson.DecodePSBTCmd",
+		ResType: "btcjson.DecodePSBTResult",
+	},
 	{
 		Method:  "dumpprivkey",
 		Handler: "DumpPrivKey",
 		Cmd:     "*btcjson.DumpPrivKeyCmd",
 		ResType: "string",
 	},
+	{
+		Method:  "finalizepsbt",
+		Handler: "FinalizePSBT",
+		Cmd:     "*btcjson.FinalizePSBTCmd",
+		ResType: "btcjson.FinalizePSBTResult",
+	},
 	{
 		Method:  "getaccount",
 		Handler: "GetAccount",
@@ -119,6 +137,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetTransactionCmd",
 		ResType: "btcjson.GetTransactionResult",
 	},
+	{
+		Method:  "getwalletinfo",
+		Handler: "GetWalletInfo",
+		Cmd:     "*None",
+		ResType: "btcjson.GetWalletInfoResult",
+	},
 	{
 		Method:           "help",
 		Handler:          "HelpNoChainRPC",
@@ -182,11 +206,10 @@ var handlers = handlersT{
 		ResType: "[]btcjson.ListUnspentResult",
 	},
 	{
-		Method:           "sendfrom",
-		Handler:          "LockUnspent",
-		HandlerWithChain: "LockUnspent",
-		Cmd:              "btcjson.LockUnspentCmd",
-		ResType:          "bool",
+		Method:  "lockunspent",
+		Handler: "LockUnspent",
+		Cmd:     "*btcjson.LockUnspentCmd",
+		ResType: "bool",
 	},
 	{
 		Method:  "sendmany",
@@ -231,6 +254,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.VerifyMessageCmd",
 		ResType: "bool",
 	},
+	{
+		Method:  "walletcreatefundedpsbt",
+		Handler: "WalletCreateFundedPSBT",
+		Cmd:     "*btcjson.WalletCreateFundedPSBTCmd",
+		ResType: "btcjson.WalletCreateFundedPSBTResult",
+	},
 	{
 		Method:  "walletlock",
 		Handler: "WalletLock",
@@ -249,6 +278,12 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.WalletPassphraseChangeCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "walletprocesspsbt",
+		Handler: "WalletProcessPSBT",
+		Cmd:     "*btcjson.WalletProcessPSBTCmd",
+		ResType: "btcjson.WalletProcessPSBTResult",
+	},
 	{
 		Method:  "createnewaccount",
 		Handler: "CreateNewAccount",
@@ -267,6 +302,24 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.GetUnconfirmedBalanceCmd",
 		ResType: "float64",
 	},
+	{
+		Method:  "getprivacyreport",
+		Handler: "GetPrivacyReport",
+		Cmd:     "*None",
+		ResType: "btcjson.PrivacyReportResult",
+	},
+	{
+		Method:  "getreconciliation",
+		Handler: "GetReconciliation",
+		Cmd:     "*None",
+		ResType: "btcjson.GetReconciliationResult",
+	},
+	{
+		Method:  "getwalletbalances",
+		Handler: "GetWalletBalances",
+		Cmd:     "*btcjson.GetWalletBalancesCmd",
+		ResType: "btcjson.GetWalletBalancesResult",
+	},
 	{
 		Method:  "listaddresstransactions",
 		Handler: "ListAddressTransactions",
@@ -285,6 +338,96 @@ var handlers = handlersT{
 		Cmd:     "*btcjson.RenameAccountCmd",
 		ResType: "None",
 	},
+	{
+		Method:  "encryptwalletdb",
+		Handler: "EncryptWalletDB",
+		Cmd:     "*btcjson.EncryptWalletDBCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "rescanfromheight",
+		Handler: "RescanFromHeight",
+		Cmd:     "*btcjson.RescanFromHeightCmd",
+		ResType: "None",
+	},
+	{
+		Method:  "createmultisigaccount",
+		Handler: "CreateMultisigAccount",
+		Cmd:     "*btcjson.CreateMultisigAccountCmd",
+		ResType: "btcjson.MultisigAccountResult",
+	},
+	{
+		Method:  "createmultisigpsbt",
+		Handler: "CreateMultisigPSBT",
+		Cmd:     "*btcjson.CreateMultisigPSBTCmd",
+		ResType: "btcjson.MultisigPSBTResult",
+	},
+	{
+		Method:  "createpaymentrequest",
+		Handler: "CreatePaymentRequest",
+		Cmd:     "*btcjson.CreatePaymentRequestCmd",
+		ResType: "btcjson.PaymentRequestResult",
+	},
+	{
+		Method:  "createsigningdevicepsbt",
+		Handler: "CreateSigningDevicePSBT",
+		Cmd:     "*btcjson.CreateSigningDevicePSBTCmd",
+		ResType: "btcjson.SigningDevicePSBTResult",
+	},
+	{
+		Method:  "getaccountxpub",
+		Handler: "GetAccountXPub",
+		Cmd:     "*btcjson.GetAccountXPubCmd",
+		ResType: "string",
+	},
+	{
+		Method:  "getnewmultisigaddress",
+		Handler: "GetNewMultisigAddress",
+		Cmd:     "*btcjson.GetNewMultisigAddressCmd",
+		ResType: "string",
+	},
+	{
+		Method:  "listmultisigaccounts",
+		Handler: "ListMultisigAccounts",
+		Cmd:     "*None",
+		ResType: "[]btcjson.MultisigAccountResult",
+	},
+	{
+		Method:  "listmultisigpsbts",
+		Handler: "ListMultisigPSBTs",
+		Cmd:     "*None",
+		ResType: "[]btcjson.MultisigPSBTResult",
+	},
+	{
+		Method:  "listpaymentrequests",
+		Handler: "ListPaymentRequests",
+		Cmd:     "*None",
+		ResType: "[]btcjson.PaymentRequestResult",
+	},
+	{
+		Method:  "signmultisigpsbt",
+		Handler: "SignMultisigPSBT",
+		Cmd:     "*btcjson.SignMultisigPSBTCmd",
+		ResType: "btcjson.MultisigPSBTResult",
+	},
+	{
+		Method:  "importmultisigpsbt",
+		Handler: "ImportMultisigPSBT",
+		Cmd:     "*btcjson.ImportMultisigPSBTCmd",
+		ResType: "btcjson.MultisigPSBTResult",
+	},
+	{
+		Method:  "previewsend",
+		Handler: "PreviewSend",
+		Cmd:     "*btcjson.PreviewSendCmd",
+		ResType: "btcjson.PreviewSendResult",
+	},
+	{
+		Method:  "publishpsbt",
+		Handler: "PublishPSBT",
+		Cmd:     "*btcjson.PublishPSBTCmd",
+		ResType: "string",
+	},
 	{
 		Method:  "walletislocked",
 		Handler: "WalletIsLocked",
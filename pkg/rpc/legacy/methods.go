@@ -825,6 +825,42 @@ func GetUnconfirmedBalance(icmd interface{}, w *wallet.Wallet, chainClient ...*c
 	return (bals.Total - bals.Spendable).ToDUO(), nil
 }
 
+// GetWalletBalances handles a getwalletbalances request by returning the balances of an account, or of all accounts for
+// "*", broken down by confirmations from a single snapshot of the wallet.
+func GetWalletBalances(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetWalletBalancesCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getwalletbalances"],
+		}
+	}
+	var account *uint32
+	if cmd.Account != nil && *cmd.Account != "*" {
+		n, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, *cmd.Account)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		account = &n
+	}
+	snap, err := w.CalculateBalanceSnapshot(account)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return btcjson.GetWalletBalancesResult{
+		Height:      snap.Height,
+		BlockHash:   snap.Hash.String(),
+		Unconfirmed: snap.Unconfirmed.ToDUO(),
+		Confirming:  snap.Confirming.ToDUO(),
+		Confirmed:   snap.Confirmed.ToDUO(),
+		Immature:    snap.ImmatureReward.ToDUO(),
+		Locked:      snap.Locked.ToDUO(),
+		Total:       snap.Total.ToDUO(),
+	}, nil
+}
+
 // ImportPrivKey handles an importprivkey request by parsing a WIF-encoded private key and adding it to an account.
 func ImportPrivKey(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
 	cmd, ok := icmd.(*btcjson.ImportPrivKeyCmd)
@@ -926,6 +962,39 @@ func RenameAccount(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPC
 	return nil, w.RenameAccount(waddrmgr.KeyScopeBIP0044, account, cmd.NewAccount)
 }
 
+// RescanFromHeight handles a rescanfromheight request by starting a rescan of the chain for the wallet's transactions
+// from the block at the given height. Its progress is sent to the websocket clients as it runs.
+func RescanFromHeight(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.RescanFromHeightCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["rescanfromheight"],
+		}
+	}
+	return nil, w.RescanFromHeight(cmd.Height)
+}
+
+// EncryptWalletDB handles an encryptwalletdb request by encrypting the whole wallet database with a key derived from
+// the public passphrase, without stopping the wallet.
+func EncryptWalletDB(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.EncryptWalletDBCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["encryptwalletdb"],
+		}
+	}
+	err := w.EncryptDB([]byte(cmd.Passphrase))
+	if waddrmgr.IsError(err, waddrmgr.ErrWrongPassphrase) {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCWalletPassphraseIncorrect,
+			Message: "Incorrect public passphrase",
+		}
+	}
+	return nil, err
+}
+
 // GetNewAddress handles a getnewaddress request by returning a new address for an account. If the account does not
 // exist an appropiate error is returned.
 //
@@ -957,6 +1026,41 @@ func GetNewAddress(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPC
 	return addr.EncodeAddress(), nil
 }
 
+// GetPrivacyReport handles a getprivacyreport request by analysing the wallet's history for address reuse, merged
+// inputs and round amount payments, along with suggestions for avoiding them.
+func GetPrivacyReport(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	return w.PrivacyReport()
+}
+
+// GetReconciliation handles a getreconciliation request by returning the outcome of the latest reconciliation of the
+// wallet history with the chain.
+func GetReconciliation(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	r := w.LastReconciliation()
+	if r == nil {
+		return btcjson.GetReconciliationResult{}, nil
+	}
+	result := btcjson.GetReconciliationResult{
+		Reconciled:       true,
+		Changed:          r.Changed(),
+		Started:          r.Started.Unix(),
+		Finished:         r.Finished.Unix(),
+		FromHeight:       r.FromHeight,
+		ToHeight:         r.ToHeight,
+		AddressesDerived: r.AddressesDerived,
+		MissingTxs:       make([]string, len(r.MissingTxs)),
+		ExtraTxs:         make([]string, len(r.ExtraTxs)),
+		BalanceBefore:    r.BalanceBefore.ToDUO(),
+		BalanceAfter:     r.BalanceAfter.ToDUO(),
+	}
+	for i := range r.MissingTxs {
+		result.MissingTxs[i] = r.MissingTxs[i].String()
+	}
+	for i := range r.ExtraTxs {
+		result.ExtraTxs[i] = r.ExtraTxs[i].String()
+	}
+	return result, nil
+}
+
 // GetRawChangeAddress handles a getrawchangeaddress request by creating and returning a new change address for an
 // account.
 //
@@ -1672,7 +1776,10 @@ func LockUnspent(icmd interface{}, w *wallet.Wallet,
 	}
 	switch {
 	case cmd.Unlock && len(cmd.Transactions) == 0:
-		w.ResetLockedOutpoints()
+		if err := w.ResetLockedOutpoints(); err != nil {
+			Error(err)
+			return nil, err
+		}
 	default:
 		for _, input := range cmd.Transactions {
 			txHash, err := chainhash.NewHashFromStr(input.Txid)
@@ -1682,9 +1789,13 @@ func LockUnspent(icmd interface{}, w *wallet.Wallet,
 			}
 			op := wire.OutPoint{Hash: *txHash, Index: input.Vout}
 			if cmd.Unlock {
-				w.UnlockOutpoint(op)
+				err = w.UnlockOutpoint(op)
 			} else {
-				w.LockOutpoint(op)
+				err = w.LockOutpoint(op)
+			}
+			if err != nil {
+				Error(err)
+				return nil, err
 			}
 		}
 	}
@@ -1836,7 +1947,12 @@ func SendMany(icmd interface{}, w *wallet.Wallet,
 		}
 		pairs[k] = amt
 	}
-	return SendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb)
+	feeRate, err := FeeRateParam(cmd.FeeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return SendPairs(w, pairs, account, minConf, feeRate)
 }
 
 // SendToAddress handles a sendtoaddress RPC request by creating a new transaction spending unspent transaction outputs
@@ -1875,9 +1991,99 @@ func SendToAddress(icmd interface{}, w *wallet.Wallet,
 	pairs := map[string]util.Amount{
 		cmd.Address: amt,
 	}
+	feeRate, err := FeeRateParam(cmd.FeeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
 	// sendtoaddress always spends from the default account, this matches bitcoind
-	return SendPairs(w, pairs, waddrmgr.DefaultAccountNum, 1,
-		txrules.DefaultRelayFeePerKb)
+	return SendPairs(w, pairs, waddrmgr.DefaultAccountNum, 1, feeRate)
+}
+
+// FeeRateParam returns the fee rate in DUO per kilobyte passed to a send command, or the relay fee when none is passed.
+// A rate below the relay fee is refused, as nodes would not relay the transaction.
+func FeeRateParam(feeRate *float64) (util.Amount, error) {
+	if feeRate == nil {
+		return txrules.DefaultRelayFeePerKb, nil
+	}
+	rate, err := util.NewAmount(*feeRate)
+	if err != nil {
+		return 0, err
+	}
+	if rate < txrules.DefaultRelayFeePerKb {
+		return 0, &btcjson.RPCError{
+			Code: btcjson.ErrRPCInvalidParameter,
+			Message: fmt.Sprintf("fee rate %v per kilobyte is below the relay fee of %v", rate,
+				txrules.DefaultRelayFeePerKb),
+		}
+	}
+	return rate, nil
+}
+
+// PreviewSend handles a previewsend request by creating the transaction a sendmany with the same arguments would send,
+// without signing or broadcasting it. The fee is returned along with the fee rate the transaction pays together with
+// any unconfirmed parent transactions it spends from, so the user can see whether those parents will confirm with it.
+func PreviewSend(icmd interface{}, w *wallet.Wallet,
+	chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.PreviewSendCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["previewsend"],
+		}
+	}
+	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, *cmd.FromAccount)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	pairs := make(map[string]util.Amount, len(cmd.Amounts))
+	for k, v := range cmd.Amounts {
+		amt, err := util.NewAmount(v)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		if amt <= 0 {
+			return nil, ErrNeedPositiveAmount
+		}
+		pairs[k] = amt
+	}
+	feeRate, err := FeeRateParam(cmd.FeeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	outputs, err := MakeOutputs(pairs, w.ChainParams())
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	for _, output := range outputs {
+		if err = txrules.CheckOutput(output, feeRate); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	tx, err := w.PreviewTx(outputs, account, minConf, feeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	fee := tx.Fee()
+	return btcjson.PreviewSendResult{
+		Fee:            fee.ToDUO(),
+		Size:           tx.EstimatedSize,
+		FeeRate:        (fee * 1000 / util.Amount(tx.EstimatedSize)).ToDUO(),
+		AncestorFees:   tx.AncestorFees.ToDUO(),
+		AncestorSize:   tx.AncestorSize,
+		PackageFeeRate: tx.PackageFeeRate().ToDUO(),
+		CPFP:           fee > txrules.FeeForSerializeSize(feeRate, tx.EstimatedSize),
+	}, nil
 }
 
 // SetTxFee sets the transaction fee per kilobyte added to transactions.
@@ -1971,21 +2177,8 @@ func SignRawTransaction(icmd interface{}, w *wallet.Wallet,
 		e := errors.New("TX decode failed")
 		return nil, DeserializationError{e}
 	}
-	var hashType txscript.SigHashType
-	switch *cmd.Flags {
-	case "ALL":
-		hashType = txscript.SigHashAll
-	case "NONE":
-		hashType = txscript.SigHashNone
-	case "SINGLE":
-		hashType = txscript.SigHashSingle
-	case "ALL|ANYONECANPAY":
-		hashType = txscript.SigHashAll | txscript.SigHashAnyOneCanPay
-	case "NONE|ANYONECANPAY":
-		hashType = txscript.SigHashNone | txscript.SigHashAnyOneCanPay
-	case "SINGLE|ANYONECANPAY":
-		hashType = txscript.SigHashSingle | txscript.SigHashAnyOneCanPay
-	default:
+	hashType, err := txscript.ParseSigHashType(*cmd.Flags)
+	if err != nil {
 		e := errors.New("Invalid sighash parameter")
 		return nil, InvalidParameterError{e}
 	}
@@ -2158,6 +2351,9 @@ func ValidateAddress(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.R
 	case waddrmgr.ManagedPubKeyAddress:
 		result.IsCompressed = ma.Compressed()
 		result.PubKey = ma.ExportPubKey()
+		if path, err := w.AddressDerivationPath(addr); err == nil {
+			result.HDKeyPath = wallet.FormatDerivationPath(path)
+		}
 	case waddrmgr.ManagedScriptAddress:
 		result.IsScript = true
 		// The script is only available if the manager is unlocked, so just break out now if there is an error.
@@ -2252,6 +2448,27 @@ func VerifyMessage(icmd interface{}, w *wallet.Wallet,
 	}
 }
 
+// GetWalletInfo handles a getwalletinfo request by returning whether the wallet is watching-only or locked, and its
+// birthday.
+func GetWalletInfo(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	device, err := w.PairedSigningDevice(waddrmgr.DefaultAccountNum)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	res := btcjson.GetWalletInfoResult{
+		WatchingOnly:      w.WatchingOnly(),
+		Locked:            w.Locked(),
+		Birthday:          w.Manager.Birthday().Unix(),
+		DatabaseEncrypted: w.DBEncrypted(),
+	}
+	if device != nil {
+		res.SigningDevice = device.Model
+		res.Fingerprint = device.FingerprintString()
+	}
+	return res, nil
+}
+
 // WalletIsLocked handles the walletislocked extension request by returning the current lock state (false for unlocked,
 // true for locked) of an account.
 func WalletIsLocked(icmd interface{}, w *wallet.Wallet,
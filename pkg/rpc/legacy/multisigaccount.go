@@ -0,0 +1,256 @@
+package legacy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// CreateMultisigAccount handles a createmultisigaccount request by creating an m of n account from the extended public
+// keys of the cosigners and the wallet account of the same name.
+func CreateMultisigAccount(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CreateMultisigAccountCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["createmultisigaccount"],
+		}
+	}
+	acct, err := w.CreateMultisigAccount(cmd.Account, cmd.NRequired, cmd.Keys)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return multisigAccountResult(w, acct, wallet.Balances{})
+}
+
+// CreateMultisigPSBT handles a createmultisigpsbt request by creating a partially signed transaction spending from a
+// multisig account, which is then passed between the cosigners to collect their signatures.
+func CreateMultisigPSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CreateMultisigPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["createmultisigpsbt"],
+		}
+	}
+	pairs := make(map[string]util.Amount, len(cmd.Amounts))
+	for k, v := range cmd.Amounts {
+		amt, err := util.NewAmount(v)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		if amt <= 0 {
+			return nil, ErrNeedPositiveAmount
+		}
+		pairs[k] = amt
+	}
+	feeRate := txrules.DefaultRelayFeePerKb
+	if cmd.FeeRate != nil {
+		var err error
+		if feeRate, err = util.NewAmount(*cmd.FeeRate); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	outputs, err := MakeOutputs(pairs, w.ChainParams())
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	p, err := w.CreateMultisigPSBT(cmd.Account, outputs, feeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return multisigPSBTResult(cmd.Account, p, 0)
+}
+
+// GetAccountXPub handles a getaccountxpub request by returning the extended public key of a wallet account, which is
+// shared with cosigners to create a multisig account.
+func GetAccountXPub(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetAccountXPubCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getaccountxpub"],
+		}
+	}
+	return w.AccountXPub(cmd.Account)
+}
+
+// GetNewMultisigAddress handles a getnewmultisigaddress request by deriving the next receiving address of a multisig
+// account.
+func GetNewMultisigAddress(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.GetNewMultisigAddressCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["getnewmultisigaddress"],
+		}
+	}
+	addr, err := w.NewMultisigAddress(cmd.Account)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+// ListMultisigAccounts handles a listmultisigaccounts request by returning the multisig accounts of the wallet with
+// their balances.
+func ListMultisigAccounts(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	accts, err := w.MultisigAccounts()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	bals, err := w.MultisigBalances(1)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	results := make([]btcjson.MultisigAccountResult, 0, len(accts))
+	for i := range accts {
+		res, err := multisigAccountResult(w, &accts[i], bals[accts[i].Name])
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		results = append(results, *res)
+	}
+	return results, nil
+}
+
+// ListMultisigPSBTs handles a listmultisigpsbts request by returning the partially signed transactions still
+// collecting signatures for the wallet's multisig accounts, along with the signing progress of each input.
+func ListMultisigPSBTs(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	pending, err := w.MultisigPSBTs()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	results := make([]btcjson.MultisigPSBTResult, 0, len(pending))
+	for _, m := range pending {
+		res, err := multisigPSBTResult(m.Account, m.Packet, 0)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		results = append(results, *res)
+	}
+	return results, nil
+}
+
+// SignMultisigPSBT handles a signmultisigpsbt request by adding the wallet's signatures to a partially signed
+// transaction and merging in the signatures already collected for it. Once enough signatures are present the signed
+// transaction is returned in the hex field, ready for sendrawtransaction.
+func SignMultisigPSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.SignMultisigPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["signmultisigpsbt"],
+		}
+	}
+	p, err := psbt.NewFromRawBytes(strings.NewReader(cmd.PSBT), true)
+	if err != nil {
+		Error(err)
+		return nil, DeserializationError{err}
+	}
+	p, signed, err := w.SignMultisigPSBT(p)
+	if err != nil {
+		Error(err)
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+			return nil, &ErrWalletUnlockNeeded
+		}
+		return nil, err
+	}
+	return multisigPSBTResult("", p, signed)
+}
+
+// ImportMultisigPSBT handles an importmultisigpsbt request by merging the signatures a cosigner added to a partially
+// signed transaction into those already collected for it. Once enough signatures are present the signed transaction is
+// returned in the hex field, ready for sendrawtransaction.
+func ImportMultisigPSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.ImportMultisigPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["importmultisigpsbt"],
+		}
+	}
+	p, err := psbt.NewFromRawBytes(strings.NewReader(cmd.PSBT), true)
+	if err != nil {
+		Error(err)
+		return nil, DeserializationError{err}
+	}
+	if p, err = w.ImportMultisigPSBT(p); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return multisigPSBTResult("", p, 0)
+}
+
+// multisigAccountResult converts a multisig account and its balance to its RPC result.
+func multisigAccountResult(w *wallet.Wallet, acct *wallet.MultisigAccount,
+	bal wallet.Balances) (*btcjson.MultisigAccountResult, error) {
+	xpub, err := w.AccountXPub(acct.Name)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return &btcjson.MultisigAccountResult{
+		Account:   acct.Name,
+		Required:  acct.Required,
+		Keys:      acct.XPubs,
+		XPub:      xpub,
+		Addresses: acct.NextIndex,
+		Balance:   bal.Total.ToDUO(),
+		Spendable: bal.Spendable.ToDUO(),
+	}, nil
+}
+
+// multisigPSBTResult converts a partially signed transaction to its RPC result, including the signed transaction once
+// every input has been finalized.
+func multisigPSBTResult(account string, p *psbt.Packet, signed int) (*btcjson.MultisigPSBTResult, error) {
+	b64, err := p.B64Encode()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	res := &btcjson.MultisigPSBTResult{
+		Account:  account,
+		TxID:     p.UnsignedTx.TxHash().String(),
+		PSBT:     b64,
+		Signed:   signed,
+		Complete: p.IsComplete(),
+		Inputs:   make([]btcjson.MultisigPSBTInputResult, len(p.Inputs)),
+	}
+	for i := range p.Inputs {
+		res.Inputs[i].Signatures, res.Inputs[i].Required = p.Inputs[i].Progress()
+	}
+	if res.Complete {
+		tx, err := psbt.Extract(p)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err = tx.Serialize(&buf); err != nil {
+			Error(err)
+			return nil, err
+		}
+		res.Hex = hex.EncodeToString(buf.Bytes())
+	}
+	return res, nil
+}
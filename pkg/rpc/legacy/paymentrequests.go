@@ -0,0 +1,69 @@
+package legacy
+
+import (
+	"time"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// CreatePaymentRequest handles a createpaymentrequest request by creating a request for a payment to a new address of
+// the default account, which the wallet marks fulfilled once the amount is received in a confirmed transaction.
+func CreatePaymentRequest(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CreatePaymentRequestCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["createpaymentrequest"],
+		}
+	}
+	amt, err := util.NewAmount(cmd.Amount)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if amt <= 0 {
+		return nil, ErrNeedPositiveAmount
+	}
+	var label, message string
+	if cmd.Label != nil {
+		label = *cmd.Label
+	}
+	if cmd.Message != nil {
+		message = *cmd.Message
+	}
+	var expiry time.Duration
+	if cmd.Expiry != nil {
+		if *cmd.Expiry < 0 {
+			return nil, &btcjson.RPCError{
+				Code:    btcjson.ErrRPCInvalidParameter,
+				Message: "expiry must not be negative",
+			}
+		}
+		expiry = time.Duration(*cmd.Expiry) * time.Second
+	}
+	r, err := w.CreatePaymentRequest(waddrmgr.DefaultAccountNum, amt, label, message, expiry)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return r.Result(), nil
+}
+
+// ListPaymentRequests handles a listpaymentrequests request by returning all payment requests of the wallet along with
+// whether they are pending, fulfilled or expired.
+func ListPaymentRequests(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	requests, err := w.PaymentRequests()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	results := make([]btcjson.PaymentRequestResult, 0, len(requests))
+	for i := range requests {
+		results = append(results, requests[i].Result())
+	}
+	return results, nil
+}
@@ -0,0 +1,371 @@
+package legacy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// CombinePSBT handles a combinepsbt request by merging the signatures and other data of several copies of the same
+// partially signed transaction into one.
+func CombinePSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.CombinePSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["combinepsbt"],
+		}
+	}
+	if len(cmd.Txs) == 0 {
+		return nil, InvalidParameterError{errors.New("no partially signed transactions to combine")}
+	}
+	combined, err := decodePSBT(cmd.Txs[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, b64 := range cmd.Txs[1:] {
+		p, err := decodePSBT(b64)
+		if err != nil {
+			return nil, err
+		}
+		if err = combined.Combine(p); err != nil {
+			Error(err)
+			return nil, InvalidParameterError{err}
+		}
+	}
+	return combined.B64Encode()
+}
+
+// DecodePSBT handles a decodepsbt request by returning the unsigned transaction of a partially signed transaction and
+// the data attached to its inputs and outputs.
+func DecodePSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.DecodePSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["decodepsbt"],
+		}
+	}
+	p, err := decodePSBT(cmd.PSBT)
+	if err != nil {
+		return nil, err
+	}
+	params := w.ChainParams()
+	tx := p.UnsignedTx
+	res := btcjson.DecodePSBTResult{
+		Tx: btcjson.TxRawDecodeResult{
+			Txid:     tx.TxHash().String(),
+			Version:  tx.Version,
+			Locktime: tx.LockTime,
+			Vin:      make([]btcjson.Vin, len(tx.TxIn)),
+			Vout:     make([]btcjson.Vout, len(tx.TxOut)),
+		},
+		Unknown: unknownsResult(p.Unknowns),
+		Inputs:  make([]btcjson.DecodePSBTInputResult, len(p.Inputs)),
+		Outputs: make([]btcjson.DecodePSBTOutputResult, len(p.Outputs)),
+	}
+	if res.Unknown == nil {
+		res.Unknown = map[string]string{}
+	}
+	for i, txIn := range tx.TxIn {
+		res.Tx.Vin[i] = btcjson.Vin{
+			Txid:      txIn.PreviousOutPoint.Hash.String(),
+			Vout:      txIn.PreviousOutPoint.Index,
+			ScriptSig: scriptResult(txIn.SignatureScript),
+			Sequence:  txIn.Sequence,
+		}
+	}
+	for i, txOut := range tx.TxOut {
+		res.Tx.Vout[i] = voutResult(txOut, i, params)
+	}
+	for i := range p.Inputs {
+		in := &p.Inputs[i]
+		r := &res.Inputs[i]
+		if prevOut := p.PrevOut(i); prevOut != nil {
+			vout := voutResult(prevOut, int(tx.TxIn[i].PreviousOutPoint.Index), params)
+			r.NonWitnessUtxo = &vout
+		}
+		if len(in.PartialSigs) > 0 {
+			r.PartialSignatures = make(map[string]string, len(in.PartialSigs))
+			for _, sig := range in.PartialSigs {
+				r.PartialSignatures[hex.EncodeToString(sig.PubKey)] = hex.EncodeToString(sig.Signature)
+			}
+		}
+		if in.SighashType != 0 {
+			r.Sighash = in.SighashType.String()
+		}
+		if in.RedeemScript != nil {
+			r.RedeemScript = scriptResult(in.RedeemScript)
+		}
+		r.Bip32Derivs = derivationsResult(in.Bip32Derivation)
+		if in.FinalScriptSig != nil {
+			r.FinalScriptSig = scriptResult(in.FinalScriptSig)
+		}
+		r.Unknown = unknownsResult(in.Unknowns)
+	}
+	for i := range p.Outputs {
+		out := &p.Outputs[i]
+		r := &res.Outputs[i]
+		if out.RedeemScript != nil {
+			r.RedeemScript = scriptResult(out.RedeemScript)
+		}
+		r.Bip32Derivs = derivationsResult(out.Bip32Derivation)
+		r.Unknown = unknownsResult(out.Unknowns)
+	}
+	if fee, ok := p.Fee(); ok {
+		duo := fee.ToDUO()
+		res.Fee = &duo
+	}
+	return res, nil
+}
+
+// FinalizePSBT handles a finalizepsbt request by finalizing the inputs of a partially signed transaction that have all
+// their signatures and, when it is complete and extraction is asked for, returning the network serialized transaction.
+func FinalizePSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.FinalizePSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["finalizepsbt"],
+		}
+	}
+	p, err := decodePSBT(cmd.PSBT)
+	if err != nil {
+		return nil, err
+	}
+	complete, err := psbt.MaybeFinalizeAll(p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	res := btcjson.FinalizePSBTResult{Complete: complete}
+	if complete && *cmd.Extract {
+		tx, err := psbt.Extract(p)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		var buf bytes.Buffer
+		buf.Grow(tx.SerializeSize())
+		if err = tx.Serialize(&buf); err != nil {
+			Error(err)
+			return nil, err
+		}
+		res.Hex = hex.EncodeToString(buf.Bytes())
+		return res, nil
+	}
+	if res.PSBT, err = p.B64Encode(); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// WalletCreateFundedPSBT handles a walletcreatefundedpsbt request by creating an unsigned transaction paying the
+// outputs from an account, spending the inputs passed and more of the account's outputs to cover the amount and fee,
+// and returning it as a partially signed transaction.
+func WalletCreateFundedPSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{},
+	error) {
+	cmd, ok := icmd.(*btcjson.WalletCreateFundedPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["walletcreatefundedpsbt"],
+		}
+	}
+	options := cmd.Options
+	if options == nil {
+		options = &btcjson.WalletCreateFundedPSBTOpts{}
+	}
+	accountName := "default"
+	if options.Account != nil {
+		accountName = *options.Account
+	}
+	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, accountName)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	minConf := int32(1)
+	if options.MinConf != nil {
+		minConf = int32(*options.MinConf)
+	}
+	if minConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	pairs := make(map[string]util.Amount, len(cmd.Outputs))
+	for k, v := range cmd.Outputs {
+		amt, err := util.NewAmount(v)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		if amt <= 0 {
+			return nil, ErrNeedPositiveAmount
+		}
+		pairs[k] = amt
+	}
+	feeRate, err := FeeRateParam(options.FeeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	outputs, err := MakeOutputs(pairs, w.ChainParams())
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	opts := &wallet.FundPSBTOptions{
+		LockTime:         *cmd.LockTime,
+		ChangePosition:   -1,
+		Bip32Derivations: *cmd.Bip32Derivs,
+	}
+	for _, input := range cmd.Inputs {
+		hash, err := chainhash.NewHashFromStr(input.Txid)
+		if err != nil {
+			Error(err)
+			return nil, DeserializationError{err}
+		}
+		opts.Inputs = append(opts.Inputs, *wire.NewOutPoint(hash, input.Vout))
+	}
+	if options.ChangeAddress != nil {
+		if opts.ChangeAddress, err = DecodeAddress(*options.ChangeAddress, w.ChainParams()); err != nil {
+			return nil, err
+		}
+	}
+	if options.ChangePosition != nil {
+		opts.ChangePosition = *options.ChangePosition
+	}
+	if options.LockUnspents != nil {
+		opts.LockUnspents = *options.LockUnspents
+	}
+	p, fee, changePos, err := w.CreateFundedPSBT(account, outputs, minConf, feeRate, opts)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	b64, err := p.B64Encode()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return btcjson.WalletCreateFundedPSBTResult{
+		PSBT:      b64,
+		Fee:       fee.ToDUO(),
+		ChangePos: changePos,
+	}, nil
+}
+
+// WalletProcessPSBT handles a walletprocesspsbt request by adding what the wallet knows about the inputs of a partially
+// signed transaction, signing those it holds keys for and finalizing those that have all their signatures.
+func WalletProcessPSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.WalletProcessPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["walletprocesspsbt"],
+		}
+	}
+	p, err := decodePSBT(cmd.PSBT)
+	if err != nil {
+		return nil, err
+	}
+	hashType, err := txscript.ParseSigHashType(*cmd.SighashType)
+	if err != nil {
+		e := errors.New("Invalid sighash parameter")
+		return nil, InvalidParameterError{e}
+	}
+	p, complete, err := w.ProcessPSBT(p, *cmd.Sign, hashType, *cmd.Bip32Derivs)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	b64, err := p.B64Encode()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return btcjson.WalletProcessPSBTResult{
+		PSBT:     b64,
+		Complete: complete,
+	}, nil
+}
+
+// decodePSBT decodes a base64 partially signed transaction passed to a command.
+func decodePSBT(b64 string) (*psbt.Packet, error) {
+	p, err := psbt.NewFromRawBytes(strings.NewReader(b64), true)
+	if err != nil {
+		Error(err)
+		return nil, DeserializationError{err}
+	}
+	return p, nil
+}
+
+// scriptResult returns the disassembly and hex of a script.
+func scriptResult(script []byte) *btcjson.ScriptSig {
+	// the disassembly shows [error] where the script does not parse
+	asm, _ := txscript.DisasmString(script)
+	return &btcjson.ScriptSig{
+		Asm: asm,
+		Hex: hex.EncodeToString(script),
+	}
+}
+
+// voutResult returns the JSON form of output n of a transaction.
+func voutResult(txOut *wire.TxOut, n int, params *netparams.Params) btcjson.Vout {
+	asm, _ := txscript.DisasmString(txOut.PkScript)
+	class, addrs, reqSigs, _ := txscript.ExtractPkScriptAddrs(txOut.PkScript, params)
+	encoded := make([]string, len(addrs))
+	for i, addr := range addrs {
+		encoded[i] = addr.EncodeAddress()
+	}
+	return btcjson.Vout{
+		Value: util.Amount(txOut.Value).ToDUO(),
+		N:     uint32(n),
+		ScriptPubKey: btcjson.ScriptPubKeyResult{
+			Asm:       asm,
+			Hex:       hex.EncodeToString(txOut.PkScript),
+			ReqSigs:   int32(reqSigs),
+			Type:      class.String(),
+			Addresses: encoded,
+		},
+	}
+}
+
+// derivationsResult returns the JSON form of the key derivations of an input or output.
+func derivationsResult(derivations []*psbt.Bip32Derivation) []btcjson.PSBTBip32DerivResult {
+	var res []btcjson.PSBTBip32DerivResult
+	for _, d := range derivations {
+		res = append(res, btcjson.PSBTBip32DerivResult{
+			PubKey:            hex.EncodeToString(d.PubKey),
+			MasterFingerprint: wallet.SigningDeviceInfo{Fingerprint: d.MasterKeyFingerprint}.FingerprintString(),
+			Path:              wallet.FormatDerivationPath(d.Path),
+		})
+	}
+	return res
+}
+
+// unknownsResult returns the fields of a map of a partially signed transaction that are not understood, keyed by the
+// hex of their keys.
+func unknownsResult(unknowns []*psbt.Unknown) map[string]string {
+	if len(unknowns) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(unknowns))
+	for _, u := range unknowns {
+		res[hex.EncodeToString(u.Key)] = hex.EncodeToString(u.Value)
+	}
+	return res
+}
@@ -57,16 +57,46 @@ type (
 		Res *string
 		Err error
 	}
+	// CombinePSBTRes is the result from a call to CombinePSBT
+	CombinePSBTRes struct {
+		Res *string
+		Err error
+	}
 	// CreateMultiSigRes is the result from a call to CreateMultiSig
 	CreateMultiSigRes struct {
 		Res *btcjson.CreateMultiSigResult
 		Err error
 	}
+	// CreateMultisigAccountRes is the result from a call to CreateMultisigAccount
+	CreateMultisigAccountRes struct {
+		Res *btcjson.MultisigAccountResult
+		Err error
+	}
+	// CreateMultisigPSBTRes is the result from a call to CreateMultisigPSBT
+	CreateMultisigPSBTRes struct {
+		Res *btcjson.MultisigPSBTResult
+		Err error
+	}
 	// CreateNewAccountRes is the result from a call to CreateNewAccount
 	CreateNewAccountRes struct {
 		Res *None
 		Err error
 	}
+	// CreatePaymentRequestRes is the result from a call to CreatePaymentRequest
+	CreatePaymentRequestRes struct {
+		Res *btcjson.PaymentRequestResult
+		Err error
+	}
+	// CreateSigningDevicePSBTRes is the result from a call to CreateSigningDevicePSBT
+	CreateSigningDevicePSBTRes struct {
+		Res *btcjson.SigningDevicePSBTResult
+		Err error
+	}
+	// DecodePSBTRes is the result from a call to DecodePSBT
+	DecodePSBTRes struct {
+		Res *btcjson.DecodePSBTResult
+		Err error
+	}
 	// HandleDropWalletHistoryRes is the result from a call to HandleDropWalletHistory
 	HandleDropWalletHistoryRes struct {
 		Res *string
@@ -77,6 +107,16 @@ type (
 		Res *string
 		Err error
 	}
+	// EncryptWalletDBRes is the result from a call to EncryptWalletDB
+	EncryptWalletDBRes struct {
+		Res *None
+		Err error
+	}
+	// FinalizePSBTRes is the result from a call to FinalizePSBT
+	FinalizePSBTRes struct {
+		Res *btcjson.FinalizePSBTResult
+		Err error
+	}
 	// GetAccountRes is the result from a call to GetAccount
 	GetAccountRes struct {
 		Res *string
@@ -87,6 +127,11 @@ type (
 		Res *string
 		Err error
 	}
+	// GetAccountXPubRes is the result from a call to GetAccountXPub
+	GetAccountXPubRes struct {
+		Res *string
+		Err error
+	}
 	// GetAddressesByAccountRes is the result from a call to GetAddressesByAccount
 	GetAddressesByAccountRes struct {
 		Res *[]string
@@ -122,6 +167,31 @@ type (
 		Res *string
 		Err error
 	}
+	// GetNewMultisigAddressRes is the result from a call to GetNewMultisigAddress
+	GetNewMultisigAddressRes struct {
+		Res *string
+		Err error
+	}
+	// GetPrivacyReportRes is the result from a call to GetPrivacyReport
+	GetPrivacyReportRes struct {
+		Res *btcjson.PrivacyReportResult
+		Err error
+	}
+	// GetReconciliationRes is the result from a call to GetReconciliation
+	GetReconciliationRes struct {
+		Res *btcjson.GetReconciliationResult
+		Err error
+	}
+	// GetWalletInfoRes is the result from a call to GetWalletInfo
+	GetWalletInfoRes struct {
+		Res *btcjson.GetWalletInfoResult
+		Err error
+	}
+	// GetWalletBalancesRes is the result from a call to GetWalletBalances
+	GetWalletBalancesRes struct {
+		Res *btcjson.GetWalletBalancesResult
+		Err error
+	}
 	// GetRawChangeAddressRes is the result from a call to GetRawChangeAddress
 	GetRawChangeAddressRes struct {
 		Res *string
@@ -152,6 +222,11 @@ type (
 		Res *string
 		Err error
 	}
+	// ImportMultisigPSBTRes is the result from a call to ImportMultisigPSBT
+	ImportMultisigPSBTRes struct {
+		Res *btcjson.MultisigPSBTResult
+		Err error
+	}
 	// ImportPrivKeyRes is the result from a call to ImportPrivKey
 	ImportPrivKeyRes struct {
 		Res *None
@@ -182,6 +257,21 @@ type (
 		Res *[]btcjson.TransactionInput
 		Err error
 	}
+	// ListMultisigAccountsRes is the result from a call to ListMultisigAccounts
+	ListMultisigAccountsRes struct {
+		Res *[]btcjson.MultisigAccountResult
+		Err error
+	}
+	// ListMultisigPSBTsRes is the result from a call to ListMultisigPSBTs
+	ListMultisigPSBTsRes struct {
+		Res *[]btcjson.MultisigPSBTResult
+		Err error
+	}
+	// ListPaymentRequestsRes is the result from a call to ListPaymentRequests
+	ListPaymentRequestsRes struct {
+		Res *[]btcjson.PaymentRequestResult
+		Err error
+	}
 	// ListReceivedByAccountRes is the result from a call to ListReceivedByAccount
 	ListReceivedByAccountRes struct {
 		Res *[]btcjson.ListReceivedByAccountResult
@@ -207,14 +297,29 @@ type (
 		Res *[]btcjson.ListUnspentResult
 		Err error
 	}
+	// LockUnspentRes is the result from a call to LockUnspent
+	LockUnspentRes struct {
+		Res *bool
+		Err error
+	}
+	// PreviewSendRes is the result from a call to PreviewSend
+	PreviewSendRes struct {
+		Res *btcjson.PreviewSendResult
+		Err error
+	}
+	// PublishPSBTRes is the result from a call to PublishPSBT
+	PublishPSBTRes struct {
+		Res *string
+		Err error
+	}
 	// RenameAccountRes is the result from a call to RenameAccount
 	RenameAccountRes struct {
 		Res *None
 		Err error
 	}
-	// LockUnspentRes is the result from a call to LockUnspent
-	LockUnspentRes struct {
-		Res *bool
+	// RescanFromHeightRes is the result from a call to RescanFromHeight
+	RescanFromHeightRes struct {
+		Res *None
 		Err error
 	}
 	// SendManyRes is the result from a call to SendMany
@@ -237,6 +342,11 @@ type (
 		Res *string
 		Err error
 	}
+	// SignMultisigPSBTRes is the result from a call to SignMultisigPSBT
+	SignMultisigPSBTRes struct {
+		Res *btcjson.MultisigPSBTResult
+		Err error
+	}
 	// SignRawTransactionRes is the result from a call to SignRawTransaction
 	SignRawTransactionRes struct {
 		Res *btcjson.SignRawTransactionResult
@@ -252,6 +362,11 @@ type (
 		Res *bool
 		Err error
 	}
+	// WalletCreateFundedPSBTRes is the result from a call to WalletCreateFundedPSBT
+	WalletCreateFundedPSBTRes struct {
+		Res *btcjson.WalletCreateFundedPSBTResult
+		Err error
+	}
 	// WalletIsLockedRes is the result from a call to WalletIsLocked
 	WalletIsLockedRes struct {
 		Res *bool
@@ -272,6 +387,11 @@ type (
 		Res *None
 		Err error
 	}
+	// WalletProcessPSBTRes is the result from a call to WalletProcessPSBT
+	WalletProcessPSBTRes struct {
+		Res *btcjson.WalletProcessPSBTResult
+		Err error
+	}
 )
 
 // RequestHandler is a handler function to handle an unmarshaled and parsed request into a marshalable response.  If the 
@@ -310,24 +430,51 @@ var RPCHandlers = map[string]struct {
 	"addmultisigaddress": {
 		Handler: AddMultiSigAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan AddMultiSigAddressRes)} }},
+	"combinepsbt": {
+		Handler: CombinePSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CombinePSBTRes)} }},
 	"createmultisig": {
 		Handler: CreateMultiSig, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateMultiSigRes)} }},
+	"createmultisigaccount": {
+		Handler: CreateMultisigAccount, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CreateMultisigAccountRes)} }},
+	"createmultisigpsbt": {
+		Handler: CreateMultisigPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CreateMultisigPSBTRes)} }},
 	"createnewaccount": {
 		Handler: CreateNewAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan CreateNewAccountRes)} }},
+	"createpaymentrequest": {
+		Handler: CreatePaymentRequest, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CreatePaymentRequestRes)} }},
+	"createsigningdevicepsbt": {
+		Handler: CreateSigningDevicePSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan CreateSigningDevicePSBTRes)} }},
+	"decodepsbt": {
+		Handler: DecodePSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan DecodePSBTRes)} }},
 	"dropwallethistory": {
 		Handler: HandleDropWalletHistory, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HandleDropWalletHistoryRes)} }},
 	"dumpprivkey": {
 		Handler: DumpPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan DumpPrivKeyRes)} }},
+	"encryptwalletdb": {
+		Handler: EncryptWalletDB, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan EncryptWalletDBRes)} }},
+	"finalizepsbt": {
+		Handler: FinalizePSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan FinalizePSBTRes)} }},
 	"getaccount": {
 		Handler: GetAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAccountRes)} }},
 	"getaccountaddress": {
 		Handler: GetAccountAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAccountAddressRes)} }},
+	"getaccountxpub": {
+		Handler: GetAccountXPub, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetAccountXPubRes)} }},
 	"getaddressesbyaccount": {
 		Handler: GetAddressesByAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetAddressesByAccountRes)} }},
@@ -349,6 +496,21 @@ var RPCHandlers = map[string]struct {
 	"getnewaddress": {
 		Handler: GetNewAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetNewAddressRes)} }},
+	"getnewmultisigaddress": {
+		Handler: GetNewMultisigAddress, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetNewMultisigAddressRes)} }},
+	"getprivacyreport": {
+		Handler: GetPrivacyReport, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetPrivacyReportRes)} }},
+	"getreconciliation": {
+		Handler: GetReconciliation, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetReconciliationRes)} }},
+	"getwalletinfo": {
+		Handler: GetWalletInfo, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetWalletInfoRes)} }},
+	"getwalletbalances": {
+		Handler: GetWalletBalances, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan GetWalletBalancesRes)} }},
 	"getrawchangeaddress": {
 		Handler: GetRawChangeAddress, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan GetRawChangeAddressRes)} }},
@@ -367,6 +529,9 @@ var RPCHandlers = map[string]struct {
 	"help": {
 		Handler: HelpNoChainRPC, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan HelpNoChainRPCRes)} }},
+	"importmultisigpsbt": {
+		Handler: ImportMultisigPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ImportMultisigPSBTRes)} }},
 	"importprivkey": {
 		Handler: ImportPrivKey, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ImportPrivKeyRes)} }},
@@ -385,6 +550,15 @@ var RPCHandlers = map[string]struct {
 	"listlockunspent": {
 		Handler: ListLockUnspent, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListLockUnspentRes)} }},
+	"listmultisigaccounts": {
+		Handler: ListMultisigAccounts, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListMultisigAccountsRes)} }},
+	"listmultisigpsbts": {
+		Handler: ListMultisigPSBTs, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListMultisigPSBTsRes)} }},
+	"listpaymentrequests": {
+		Handler: ListPaymentRequests, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan ListPaymentRequestsRes)} }},
 	"listreceivedbyaccount": {
 		Handler: ListReceivedByAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListReceivedByAccountRes)} }},
@@ -400,12 +574,21 @@ var RPCHandlers = map[string]struct {
 	"listunspent": {
 		Handler: ListUnspent, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan ListUnspentRes)} }},
+	"lockunspent": {
+		Handler: LockUnspent, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan LockUnspentRes)} }},
+	"previewsend": {
+		Handler: PreviewSend, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan PreviewSendRes)} }},
+	"publishpsbt": {
+		Handler: PublishPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan PublishPSBTRes)} }},
 	"renameaccount": {
 		Handler: RenameAccount, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan RenameAccountRes)} }},
-	"sendfrom": {
-		Handler: LockUnspent, Call: make(chan API, 32),
-		Result: func() API { return API{Ch: make(chan LockUnspentRes)} }},
+	"rescanfromheight": {
+		Handler: RescanFromHeight, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan RescanFromHeightRes)} }},
 	"sendmany": {
 		Handler: SendMany, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SendManyRes)} }},
@@ -418,6 +601,9 @@ var RPCHandlers = map[string]struct {
 	"signmessage": {
 		Handler: SignMessage, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SignMessageRes)} }},
+	"signmultisigpsbt": {
+		Handler: SignMultisigPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan SignMultisigPSBTRes)} }},
 	"signrawtransaction": {
 		Handler: SignRawTransaction, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan SignRawTransactionRes)} }},
@@ -427,6 +613,9 @@ var RPCHandlers = map[string]struct {
 	"verifymessage": {
 		Handler: VerifyMessage, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan VerifyMessageRes)} }},
+	"walletcreatefundedpsbt": {
+		Handler: WalletCreateFundedPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan WalletCreateFundedPSBTRes)} }},
 	"walletislocked": {
 		Handler: WalletIsLocked, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan WalletIsLockedRes)} }},
@@ -439,6 +628,9 @@ var RPCHandlers = map[string]struct {
 	"walletpassphrasechange": {
 		Handler: WalletPassphraseChange, Call: make(chan API, 32),
 		Result: func() API { return API{Ch: make(chan WalletPassphraseChangeRes)} }},
+	"walletprocesspsbt": {
+		Handler: WalletProcessPSBT, Call: make(chan API, 32),
+		Result: func() API { return API{Ch: make(chan WalletProcessPSBTRes)} }},
 }
 
 // API functions
@@ -487,6 +679,47 @@ func (a API) AddMultiSigAddressWait(cmd *btcjson.AddMultisigAddressCmd) (out *st
 	return
 }
 
+// CombinePSBT calls the method with the given parameters
+func (a API) CombinePSBT(cmd *btcjson.CombinePSBTCmd) (err error) {
+	RPCHandlers["combinepsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// CombinePSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) CombinePSBTCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan CombinePSBTRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// CombinePSBTGetRes returns a pointer to the value in the Result field
+func (a API) CombinePSBTGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// CombinePSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CombinePSBTWait(cmd *btcjson.CombinePSBTCmd) (out *string, err error) {
+	RPCHandlers["combinepsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan CombinePSBTRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // CreateMultiSig calls the method with the given parameters
 func (a API) CreateMultiSig(cmd *btcjson.CreateMultisigCmd) (err error) {
 	RPCHandlers["createmultisig"].Call <- API{a.Ch, cmd, nil}
@@ -528,17 +761,17 @@ func (a API) CreateMultiSigWait(cmd *btcjson.CreateMultisigCmd) (out *btcjson.Cr
 	return
 }
 
-// CreateNewAccount calls the method with the given parameters
-func (a API) CreateNewAccount(cmd *btcjson.CreateNewAccountCmd) (err error) {
-	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
+// CreateMultisigAccount calls the method with the given parameters
+func (a API) CreateMultisigAccount(cmd *btcjson.CreateMultisigAccountCmd) (err error) {
+	RPCHandlers["createmultisigaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// CreateNewAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateMultisigAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) CreateNewAccountCheck() (isNew bool) {
+func (a API) CreateMultisigAccountCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan CreateNewAccountRes):
+	case o := <-a.Ch.(chan CreateMultisigAccountRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -550,36 +783,36 @@ func (a API) CreateNewAccountCheck() (isNew bool) {
 	return
 }
 
-// CreateNewAccountGetRes returns a pointer to the value in the Result field
-func (a API) CreateNewAccountGetRes() (out *None, err error) {
-	out, _ = a.Result.(*None)
+// CreateMultisigAccountGetRes returns a pointer to the value in the Result field
+func (a API) CreateMultisigAccountGetRes() (out *btcjson.MultisigAccountResult, err error) {
+	out, _ = a.Result.(*btcjson.MultisigAccountResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// CreateNewAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) CreateNewAccountWait(cmd *btcjson.CreateNewAccountCmd) (out *None, err error) {
-	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
+// CreateMultisigAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateMultisigAccountWait(cmd *btcjson.CreateMultisigAccountCmd) (out *btcjson.MultisigAccountResult, err error) {
+	RPCHandlers["createmultisigaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan CreateNewAccountRes):
+	case o := <-a.Ch.(chan CreateMultisigAccountRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// HandleDropWalletHistory calls the method with the given parameters
-func (a API) HandleDropWalletHistory(cmd *None) (err error) {
-	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
+// CreateMultisigPSBT calls the method with the given parameters
+func (a API) CreateMultisigPSBT(cmd *btcjson.CreateMultisigPSBTCmd) (err error) {
+	RPCHandlers["createmultisigpsbt"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// HandleDropWalletHistoryCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateMultisigPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) HandleDropWalletHistoryCheck() (isNew bool) {
+func (a API) CreateMultisigPSBTCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan HandleDropWalletHistoryRes):
+	case o := <-a.Ch.(chan CreateMultisigPSBTRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -591,36 +824,36 @@ func (a API) HandleDropWalletHistoryCheck() (isNew bool) {
 	return
 }
 
-// HandleDropWalletHistoryGetRes returns a pointer to the value in the Result field
-func (a API) HandleDropWalletHistoryGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// CreateMultisigPSBTGetRes returns a pointer to the value in the Result field
+func (a API) CreateMultisigPSBTGetRes() (out *btcjson.MultisigPSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.MultisigPSBTResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// HandleDropWalletHistoryWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) HandleDropWalletHistoryWait(cmd *None) (out *string, err error) {
-	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
+// CreateMultisigPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateMultisigPSBTWait(cmd *btcjson.CreateMultisigPSBTCmd) (out *btcjson.MultisigPSBTResult, err error) {
+	RPCHandlers["createmultisigpsbt"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan HandleDropWalletHistoryRes):
+	case o := <-a.Ch.(chan CreateMultisigPSBTRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// DumpPrivKey calls the method with the given parameters
-func (a API) DumpPrivKey(cmd *btcjson.DumpPrivKeyCmd) (err error) {
-	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
+// CreateNewAccount calls the method with the given parameters
+func (a API) CreateNewAccount(cmd *btcjson.CreateNewAccountCmd) (err error) {
+	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// DumpPrivKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateNewAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) DumpPrivKeyCheck() (isNew bool) {
+func (a API) CreateNewAccountCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan DumpPrivKeyRes):
+	case o := <-a.Ch.(chan CreateNewAccountRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -632,36 +865,36 @@ func (a API) DumpPrivKeyCheck() (isNew bool) {
 	return
 }
 
-// DumpPrivKeyGetRes returns a pointer to the value in the Result field
-func (a API) DumpPrivKeyGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// CreateNewAccountGetRes returns a pointer to the value in the Result field
+func (a API) CreateNewAccountGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// DumpPrivKeyWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) DumpPrivKeyWait(cmd *btcjson.DumpPrivKeyCmd) (out *string, err error) {
-	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
+// CreateNewAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateNewAccountWait(cmd *btcjson.CreateNewAccountCmd) (out *None, err error) {
+	RPCHandlers["createnewaccount"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan DumpPrivKeyRes):
+	case o := <-a.Ch.(chan CreateNewAccountRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetAccount calls the method with the given parameters
-func (a API) GetAccount(cmd *btcjson.GetAccountCmd) (err error) {
-	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
+// CreatePaymentRequest calls the method with the given parameters
+func (a API) CreatePaymentRequest(cmd *btcjson.CreatePaymentRequestCmd) (err error) {
+	RPCHandlers["createpaymentrequest"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreatePaymentRequestCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetAccountCheck() (isNew bool) {
+func (a API) CreatePaymentRequestCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetAccountRes):
+	case o := <-a.Ch.(chan CreatePaymentRequestRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -673,36 +906,36 @@ func (a API) GetAccountCheck() (isNew bool) {
 	return
 }
 
-// GetAccountGetRes returns a pointer to the value in the Result field
-func (a API) GetAccountGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// CreatePaymentRequestGetRes returns a pointer to the value in the Result field
+func (a API) CreatePaymentRequestGetRes() (out *btcjson.PaymentRequestResult, err error) {
+	out, _ = a.Result.(*btcjson.PaymentRequestResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetAccountWait(cmd *btcjson.GetAccountCmd) (out *string, err error) {
-	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
+// CreatePaymentRequestWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreatePaymentRequestWait(cmd *btcjson.CreatePaymentRequestCmd) (out *btcjson.PaymentRequestResult, err error) {
+	RPCHandlers["createpaymentrequest"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetAccountRes):
+	case o := <-a.Ch.(chan CreatePaymentRequestRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetAccountAddress calls the method with the given parameters
-func (a API) GetAccountAddress(cmd *btcjson.GetAccountAddressCmd) (err error) {
-	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
+// CreateSigningDevicePSBT calls the method with the given parameters
+func (a API) CreateSigningDevicePSBT(cmd *btcjson.CreateSigningDevicePSBTCmd) (err error) {
+	RPCHandlers["createsigningdevicepsbt"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetAccountAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// CreateSigningDevicePSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetAccountAddressCheck() (isNew bool) {
+func (a API) CreateSigningDevicePSBTCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetAccountAddressRes):
+	case o := <-a.Ch.(chan CreateSigningDevicePSBTRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -714,36 +947,36 @@ func (a API) GetAccountAddressCheck() (isNew bool) {
 	return
 }
 
-// GetAccountAddressGetRes returns a pointer to the value in the Result field
-func (a API) GetAccountAddressGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// CreateSigningDevicePSBTGetRes returns a pointer to the value in the Result field
+func (a API) CreateSigningDevicePSBTGetRes() (out *btcjson.SigningDevicePSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.SigningDevicePSBTResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetAccountAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetAccountAddressWait(cmd *btcjson.GetAccountAddressCmd) (out *string, err error) {
-	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
+// CreateSigningDevicePSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) CreateSigningDevicePSBTWait(cmd *btcjson.CreateSigningDevicePSBTCmd) (out *btcjson.SigningDevicePSBTResult, err error) {
+	RPCHandlers["createsigningdevicepsbt"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetAccountAddressRes):
+	case o := <-a.Ch.(chan CreateSigningDevicePSBTRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetAddressesByAccount calls the method with the given parameters
-func (a API) GetAddressesByAccount(cmd *btcjson.GetAddressesByAccountCmd) (err error) {
-	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
+// DecodePSBT calls the method with the given parameters
+func (a API) DecodePSBT(cmd *btcjson.DecodePSBTCmd) (err error) {
+	RPCHandlers["decodepsbt"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetAddressesByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// DecodePSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetAddressesByAccountCheck() (isNew bool) {
+func (a API) DecodePSBTCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetAddressesByAccountRes):
+	case o := <-a.Ch.(chan DecodePSBTRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -755,36 +988,36 @@ func (a API) GetAddressesByAccountCheck() (isNew bool) {
 	return
 }
 
-// GetAddressesByAccountGetRes returns a pointer to the value in the Result field
-func (a API) GetAddressesByAccountGetRes() (out *[]string, err error) {
-	out, _ = a.Result.(*[]string)
+// DecodePSBTGetRes returns a pointer to the value in the Result field
+func (a API) DecodePSBTGetRes() (out *btcjson.DecodePSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.DecodePSBTResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetAddressesByAccountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetAddressesByAccountWait(cmd *btcjson.GetAddressesByAccountCmd) (out *[]string, err error) {
-	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
+// DecodePSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DecodePSBTWait(cmd *btcjson.DecodePSBTCmd) (out *btcjson.DecodePSBTResult, err error) {
+	RPCHandlers["decodepsbt"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetAddressesByAccountRes):
+	case o := <-a.Ch.(chan DecodePSBTRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBalance calls the method with the given parameters
-func (a API) GetBalance(cmd *btcjson.GetBalanceCmd) (err error) {
-	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
+// HandleDropWalletHistory calls the method with the given parameters
+func (a API) HandleDropWalletHistory(cmd *None) (err error) {
+	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBalanceCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// HandleDropWalletHistoryCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBalanceCheck() (isNew bool) {
+func (a API) HandleDropWalletHistoryCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBalanceRes):
+	case o := <-a.Ch.(chan HandleDropWalletHistoryRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -796,36 +1029,36 @@ func (a API) GetBalanceCheck() (isNew bool) {
 	return
 }
 
-// GetBalanceGetRes returns a pointer to the value in the Result field
-func (a API) GetBalanceGetRes() (out *float64, err error) {
-	out, _ = a.Result.(*float64)
+// HandleDropWalletHistoryGetRes returns a pointer to the value in the Result field
+func (a API) HandleDropWalletHistoryGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBalanceWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBalanceWait(cmd *btcjson.GetBalanceCmd) (out *float64, err error) {
-	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
+// HandleDropWalletHistoryWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) HandleDropWalletHistoryWait(cmd *None) (out *string, err error) {
+	RPCHandlers["dropwallethistory"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBalanceRes):
+	case o := <-a.Ch.(chan HandleDropWalletHistoryRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBestBlock calls the method with the given parameters
-func (a API) GetBestBlock(cmd *None) (err error) {
-	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+// DumpPrivKey calls the method with the given parameters
+func (a API) DumpPrivKey(cmd *btcjson.DumpPrivKeyCmd) (err error) {
+	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBestBlockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// DumpPrivKeyCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBestBlockCheck() (isNew bool) {
+func (a API) DumpPrivKeyCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBestBlockRes):
+	case o := <-a.Ch.(chan DumpPrivKeyRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -837,36 +1070,36 @@ func (a API) GetBestBlockCheck() (isNew bool) {
 	return
 }
 
-// GetBestBlockGetRes returns a pointer to the value in the Result field
-func (a API) GetBestBlockGetRes() (out *btcjson.GetBestBlockResult, err error) {
-	out, _ = a.Result.(*btcjson.GetBestBlockResult)
+// DumpPrivKeyGetRes returns a pointer to the value in the Result field
+func (a API) DumpPrivKeyGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBestBlockWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBestBlockWait(cmd *None) (out *btcjson.GetBestBlockResult, err error) {
-	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+// DumpPrivKeyWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) DumpPrivKeyWait(cmd *btcjson.DumpPrivKeyCmd) (out *string, err error) {
+	RPCHandlers["dumpprivkey"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBestBlockRes):
+	case o := <-a.Ch.(chan DumpPrivKeyRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBestBlockHash calls the method with the given parameters
-func (a API) GetBestBlockHash(cmd *None) (err error) {
-	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+// EncryptWalletDB calls the method with the given parameters
+func (a API) EncryptWalletDB(cmd *btcjson.EncryptWalletDBCmd) (err error) {
+	RPCHandlers["encryptwalletdb"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBestBlockHashCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// EncryptWalletDBCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBestBlockHashCheck() (isNew bool) {
+func (a API) EncryptWalletDBCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBestBlockHashRes):
+	case o := <-a.Ch.(chan EncryptWalletDBRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -878,36 +1111,364 @@ func (a API) GetBestBlockHashCheck() (isNew bool) {
 	return
 }
 
-// GetBestBlockHashGetRes returns a pointer to the value in the Result field
-func (a API) GetBestBlockHashGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// EncryptWalletDBGetRes returns a pointer to the value in the Result field
+func (a API) EncryptWalletDBGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetBestBlockHashWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBestBlockHashWait(cmd *None) (out *string, err error) {
-	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+// EncryptWalletDBWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) EncryptWalletDBWait(cmd *btcjson.EncryptWalletDBCmd) (out *None, err error) {
+	RPCHandlers["encryptwalletdb"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBestBlockHashRes):
+	case o := <-a.Ch.(chan EncryptWalletDBRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetBlockCount calls the method with the given parameters
-func (a API) GetBlockCount(cmd *None) (err error) {
-	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+// FinalizePSBT calls the method with the given parameters
+func (a API) FinalizePSBT(cmd *btcjson.FinalizePSBTCmd) (err error) {
+	RPCHandlers["finalizepsbt"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetBlockCountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// FinalizePSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetBlockCountCheck() (isNew bool) {
+func (a API) FinalizePSBTCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetBlockCountRes):
+	case o := <-a.Ch.(chan FinalizePSBTRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// FinalizePSBTGetRes returns a pointer to the value in the Result field
+func (a API) FinalizePSBTGetRes() (out *btcjson.FinalizePSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.FinalizePSBTResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// FinalizePSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) FinalizePSBTWait(cmd *btcjson.FinalizePSBTCmd) (out *btcjson.FinalizePSBTResult, err error) {
+	RPCHandlers["finalizepsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan FinalizePSBTRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAccount calls the method with the given parameters
+func (a API) GetAccount(cmd *btcjson.GetAccountCmd) (err error) {
+	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetAccountCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAccountRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAccountGetRes returns a pointer to the value in the Result field
+func (a API) GetAccountGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAccountWait(cmd *btcjson.GetAccountCmd) (out *string, err error) {
+	RPCHandlers["getaccount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAccountRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAccountAddress calls the method with the given parameters
+func (a API) GetAccountAddress(cmd *btcjson.GetAccountAddressCmd) (err error) {
+	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAccountAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetAccountAddressCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAccountAddressRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAccountAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetAccountAddressGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAccountAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAccountAddressWait(cmd *btcjson.GetAccountAddressCmd) (out *string, err error) {
+	RPCHandlers["getaccountaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAccountAddressRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAccountXPub calls the method with the given parameters
+func (a API) GetAccountXPub(cmd *btcjson.GetAccountXPubCmd) (err error) {
+	RPCHandlers["getaccountxpub"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAccountXPubCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetAccountXPubCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAccountXPubRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAccountXPubGetRes returns a pointer to the value in the Result field
+func (a API) GetAccountXPubGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAccountXPubWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAccountXPubWait(cmd *btcjson.GetAccountXPubCmd) (out *string, err error) {
+	RPCHandlers["getaccountxpub"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAccountXPubRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetAddressesByAccount calls the method with the given parameters
+func (a API) GetAddressesByAccount(cmd *btcjson.GetAddressesByAccountCmd) (err error) {
+	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetAddressesByAccountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetAddressesByAccountCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetAddressesByAccountRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetAddressesByAccountGetRes returns a pointer to the value in the Result field
+func (a API) GetAddressesByAccountGetRes() (out *[]string, err error) {
+	out, _ = a.Result.(*[]string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetAddressesByAccountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetAddressesByAccountWait(cmd *btcjson.GetAddressesByAccountCmd) (out *[]string, err error) {
+	RPCHandlers["getaddressesbyaccount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetAddressesByAccountRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBalance calls the method with the given parameters
+func (a API) GetBalance(cmd *btcjson.GetBalanceCmd) (err error) {
+	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBalanceCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetBalanceCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBalanceRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBalanceGetRes returns a pointer to the value in the Result field
+func (a API) GetBalanceGetRes() (out *float64, err error) {
+	out, _ = a.Result.(*float64)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBalanceWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBalanceWait(cmd *btcjson.GetBalanceCmd) (out *float64, err error) {
+	RPCHandlers["getbalance"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBalanceRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBestBlock calls the method with the given parameters
+func (a API) GetBestBlock(cmd *None) (err error) {
+	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBestBlockCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetBestBlockCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBestBlockRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBestBlockGetRes returns a pointer to the value in the Result field
+func (a API) GetBestBlockGetRes() (out *btcjson.GetBestBlockResult, err error) {
+	out, _ = a.Result.(*btcjson.GetBestBlockResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBestBlockWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBestBlockWait(cmd *None) (out *btcjson.GetBestBlockResult, err error) {
+	RPCHandlers["getbestblock"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBestBlockRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBestBlockHash calls the method with the given parameters
+func (a API) GetBestBlockHash(cmd *None) (err error) {
+	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBestBlockHashCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetBestBlockHashCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBestBlockHashRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetBestBlockHashGetRes returns a pointer to the value in the Result field
+func (a API) GetBestBlockHashGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetBestBlockHashWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBestBlockHashWait(cmd *None) (out *string, err error) {
+	RPCHandlers["getbestblockhash"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBestBlockHashRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetBlockCount calls the method with the given parameters
+func (a API) GetBlockCount(cmd *None) (err error) {
+	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetBlockCountCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetBlockCountCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetBlockCountRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -926,29 +1487,152 @@ func (a API) GetBlockCountGetRes() (out *int32, err error) {
 	return
 }
 
-// GetBlockCountWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetBlockCountWait(cmd *None) (out *int32, err error) {
-	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+// GetBlockCountWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetBlockCountWait(cmd *None) (out *int32, err error) {
+	RPCHandlers["getblockcount"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetBlockCountRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetInfo calls the method with the given parameters
+func (a API) GetInfo(cmd *None) (err error) {
+	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetInfoGetRes() (out *btcjson.InfoWalletResult, err error) {
+	out, _ = a.Result.(*btcjson.InfoWalletResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoWalletResult, err error) {
+	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetNewAddress calls the method with the given parameters
+func (a API) GetNewAddress(cmd *btcjson.GetNewAddressCmd) (err error) {
+	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetNewAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetNewAddressCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetNewAddressRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetNewAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetNewAddressGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetNewAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNewAddressWait(cmd *btcjson.GetNewAddressCmd) (out *string, err error) {
+	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetNewAddressRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetNewMultisigAddress calls the method with the given parameters
+func (a API) GetNewMultisigAddress(cmd *btcjson.GetNewMultisigAddressCmd) (err error) {
+	RPCHandlers["getnewmultisigaddress"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetNewMultisigAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetNewMultisigAddressCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetNewMultisigAddressRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetNewMultisigAddressGetRes returns a pointer to the value in the Result field
+func (a API) GetNewMultisigAddressGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetNewMultisigAddressWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetNewMultisigAddressWait(cmd *btcjson.GetNewMultisigAddressCmd) (out *string, err error) {
+	RPCHandlers["getnewmultisigaddress"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetBlockCountRes):
+	case o := <-a.Ch.(chan GetNewMultisigAddressRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetInfo calls the method with the given parameters
-func (a API) GetInfo(cmd *None) (err error) {
-	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+// GetPrivacyReport calls the method with the given parameters
+func (a API) GetPrivacyReport(cmd *None) (err error) {
+	RPCHandlers["getprivacyreport"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetPrivacyReportCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetInfoCheck() (isNew bool) {
+func (a API) GetPrivacyReportCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetInfoRes):
+	case o := <-a.Ch.(chan GetPrivacyReportRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -960,36 +1644,36 @@ func (a API) GetInfoCheck() (isNew bool) {
 	return
 }
 
-// GetInfoGetRes returns a pointer to the value in the Result field
-func (a API) GetInfoGetRes() (out *btcjson.InfoWalletResult, err error) {
-	out, _ = a.Result.(*btcjson.InfoWalletResult)
+// GetPrivacyReportGetRes returns a pointer to the value in the Result field
+func (a API) GetPrivacyReportGetRes() (out *btcjson.PrivacyReportResult, err error) {
+	out, _ = a.Result.(*btcjson.PrivacyReportResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetInfoWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetInfoWait(cmd *None) (out *btcjson.InfoWalletResult, err error) {
-	RPCHandlers["getinfo"].Call <- API{a.Ch, cmd, nil}
+// GetPrivacyReportWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetPrivacyReportWait(cmd *None) (out *btcjson.PrivacyReportResult, err error) {
+	RPCHandlers["getprivacyreport"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetInfoRes):
+	case o := <-a.Ch.(chan GetPrivacyReportRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// GetNewAddress calls the method with the given parameters
-func (a API) GetNewAddress(cmd *btcjson.GetNewAddressCmd) (err error) {
-	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
+// GetReconciliation calls the method with the given parameters
+func (a API) GetReconciliation(cmd *None) (err error) {
+	RPCHandlers["getreconciliation"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// GetNewAddressCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// GetReconciliationCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) GetNewAddressCheck() (isNew bool) {
+func (a API) GetReconciliationCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan GetNewAddressRes):
+	case o := <-a.Ch.(chan GetReconciliationRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1001,20 +1685,102 @@ func (a API) GetNewAddressCheck() (isNew bool) {
 	return
 }
 
-// GetNewAddressGetRes returns a pointer to the value in the Result field
-func (a API) GetNewAddressGetRes() (out *string, err error) {
-	out, _ = a.Result.(*string)
+// GetReconciliationGetRes returns a pointer to the value in the Result field
+func (a API) GetReconciliationGetRes() (out *btcjson.GetReconciliationResult, err error) {
+	out, _ = a.Result.(*btcjson.GetReconciliationResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// GetNewAddressWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) GetNewAddressWait(cmd *btcjson.GetNewAddressCmd) (out *string, err error) {
-	RPCHandlers["getnewaddress"].Call <- API{a.Ch, cmd, nil}
+// GetReconciliationWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetReconciliationWait(cmd *None) (out *btcjson.GetReconciliationResult, err error) {
+	RPCHandlers["getreconciliation"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan GetNewAddressRes):
+	case o := <-a.Ch.(chan GetReconciliationRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetWalletInfo calls the method with the given parameters
+func (a API) GetWalletInfo(cmd *None) (err error) {
+	RPCHandlers["getwalletinfo"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetWalletInfoCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetWalletInfoCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetWalletInfoRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetWalletInfoGetRes returns a pointer to the value in the Result field
+func (a API) GetWalletInfoGetRes() (out *btcjson.GetWalletInfoResult, err error) {
+	out, _ = a.Result.(*btcjson.GetWalletInfoResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetWalletInfoWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetWalletInfoWait(cmd *None) (out *btcjson.GetWalletInfoResult, err error) {
+	RPCHandlers["getwalletinfo"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetWalletInfoRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// GetWalletBalances calls the method with the given parameters
+func (a API) GetWalletBalances(cmd *btcjson.GetWalletBalancesCmd) (err error) {
+	RPCHandlers["getwalletbalances"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// GetWalletBalancesCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) GetWalletBalancesCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan GetWalletBalancesRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// GetWalletBalancesGetRes returns a pointer to the value in the Result field
+func (a API) GetWalletBalancesGetRes() (out *btcjson.GetWalletBalancesResult, err error) {
+	out, _ = a.Result.(*btcjson.GetWalletBalancesResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// GetWalletBalancesWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) GetWalletBalancesWait(cmd *btcjson.GetWalletBalancesCmd) (out *btcjson.GetWalletBalancesResult, err error) {
+	RPCHandlers["getwalletbalances"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan GetWalletBalancesRes):
 		out, err = o.Res, o.Err
 	}
 	return
@@ -1266,6 +2032,47 @@ func (a API) HelpNoChainRPCWait(cmd btcjson.HelpCmd) (out *string, err error) {
 	return
 }
 
+// ImportMultisigPSBT calls the method with the given parameters
+func (a API) ImportMultisigPSBT(cmd *btcjson.ImportMultisigPSBTCmd) (err error) {
+	RPCHandlers["importmultisigpsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ImportMultisigPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ImportMultisigPSBTCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ImportMultisigPSBTRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ImportMultisigPSBTGetRes returns a pointer to the value in the Result field
+func (a API) ImportMultisigPSBTGetRes() (out *btcjson.MultisigPSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.MultisigPSBTResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// ImportMultisigPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ImportMultisigPSBTWait(cmd *btcjson.ImportMultisigPSBTCmd) (out *btcjson.MultisigPSBTResult, err error) {
+	RPCHandlers["importmultisigpsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan ImportMultisigPSBTRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // ImportPrivKey calls the method with the given parameters
 func (a API) ImportPrivKey(cmd *btcjson.ImportPrivKeyCmd) (err error) {
 	RPCHandlers["importprivkey"].Call <- API{a.Ch, cmd, nil}
@@ -1354,11 +2161,134 @@ func (a API) ListAccounts(cmd *btcjson.ListAccountsCmd) (err error) {
 	return
 }
 
-// ListAccountsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ListAccountsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAccountsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ListAccountsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAccountsGetRes returns a pointer to the value in the Result field
+func (a API) ListAccountsGetRes() (out *map[string]float64, err error) {
+	out, _ = a.Result.(*map[string]float64)
+	err, _ = a.Result.(error)
+	return
+}
+
+// ListAccountsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAccountsWait(cmd *btcjson.ListAccountsCmd) (out *map[string]float64, err error) {
+	RPCHandlers["listaccounts"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan ListAccountsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// ListAddressTransactions calls the method with the given parameters
+func (a API) ListAddressTransactions(cmd *btcjson.ListAddressTransactionsCmd) (err error) {
+	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAddressTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAddressTransactionsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ListAddressTransactionsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAddressTransactionsGetRes returns a pointer to the value in the Result field
+func (a API) ListAddressTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, err error) {
+	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// ListAddressTransactionsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAddressTransactionsWait(cmd *btcjson.ListAddressTransactionsCmd) (out *[]btcjson.ListTransactionsResult, err error) {
+	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan ListAddressTransactionsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// ListAllTransactions calls the method with the given parameters
+func (a API) ListAllTransactions(cmd *btcjson.ListAllTransactionsCmd) (err error) {
+	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListAllTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) ListAllTransactionsCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan ListAllTransactionsRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// ListAllTransactionsGetRes returns a pointer to the value in the Result field
+func (a API) ListAllTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, err error) {
+	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// ListAllTransactionsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListAllTransactionsWait(cmd *btcjson.ListAllTransactionsCmd) (out *[]btcjson.ListTransactionsResult, err error) {
+	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan ListAllTransactionsRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// ListLockUnspent calls the method with the given parameters
+func (a API) ListLockUnspent(cmd *None) (err error) {
+	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// ListLockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListAccountsCheck() (isNew bool) {
+func (a API) ListLockUnspentCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan ListAccountsRes):
+	case o := <-a.Ch.(chan ListLockUnspentRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1370,36 +2300,36 @@ func (a API) ListAccountsCheck() (isNew bool) {
 	return
 }
 
-// ListAccountsGetRes returns a pointer to the value in the Result field
-func (a API) ListAccountsGetRes() (out *map[string]float64, err error) {
-	out, _ = a.Result.(*map[string]float64)
+// ListLockUnspentGetRes returns a pointer to the value in the Result field
+func (a API) ListLockUnspentGetRes() (out *[]btcjson.TransactionInput, err error) {
+	out, _ = a.Result.(*[]btcjson.TransactionInput)
 	err, _ = a.Result.(error)
 	return
 }
 
-// ListAccountsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListAccountsWait(cmd *btcjson.ListAccountsCmd) (out *map[string]float64, err error) {
-	RPCHandlers["listaccounts"].Call <- API{a.Ch, cmd, nil}
+// ListLockUnspentWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListLockUnspentWait(cmd *None) (out *[]btcjson.TransactionInput, err error) {
+	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan ListAccountsRes):
+	case o := <-a.Ch.(chan ListLockUnspentRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// ListAddressTransactions calls the method with the given parameters
-func (a API) ListAddressTransactions(cmd *btcjson.ListAddressTransactionsCmd) (err error) {
-	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+// ListMultisigAccounts calls the method with the given parameters
+func (a API) ListMultisigAccounts(cmd *None) (err error) {
+	RPCHandlers["listmultisigaccounts"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListAddressTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ListMultisigAccountsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListAddressTransactionsCheck() (isNew bool) {
+func (a API) ListMultisigAccountsCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan ListAddressTransactionsRes):
+	case o := <-a.Ch.(chan ListMultisigAccountsRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1411,36 +2341,36 @@ func (a API) ListAddressTransactionsCheck() (isNew bool) {
 	return
 }
 
-// ListAddressTransactionsGetRes returns a pointer to the value in the Result field
-func (a API) ListAddressTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, err error) {
-	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+// ListMultisigAccountsGetRes returns a pointer to the value in the Result field
+func (a API) ListMultisigAccountsGetRes() (out *[]btcjson.MultisigAccountResult, err error) {
+	out, _ = a.Result.(*[]btcjson.MultisigAccountResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// ListAddressTransactionsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListAddressTransactionsWait(cmd *btcjson.ListAddressTransactionsCmd) (out *[]btcjson.ListTransactionsResult, err error) {
-	RPCHandlers["listaddresstransactions"].Call <- API{a.Ch, cmd, nil}
+// ListMultisigAccountsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListMultisigAccountsWait(cmd *None) (out *[]btcjson.MultisigAccountResult, err error) {
+	RPCHandlers["listmultisigaccounts"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan ListAddressTransactionsRes):
+	case o := <-a.Ch.(chan ListMultisigAccountsRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// ListAllTransactions calls the method with the given parameters
-func (a API) ListAllTransactions(cmd *btcjson.ListAllTransactionsCmd) (err error) {
-	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+// ListMultisigPSBTs calls the method with the given parameters
+func (a API) ListMultisigPSBTs(cmd *None) (err error) {
+	RPCHandlers["listmultisigpsbts"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListAllTransactionsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ListMultisigPSBTsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListAllTransactionsCheck() (isNew bool) {
+func (a API) ListMultisigPSBTsCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan ListAllTransactionsRes):
+	case o := <-a.Ch.(chan ListMultisigPSBTsRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1452,36 +2382,36 @@ func (a API) ListAllTransactionsCheck() (isNew bool) {
 	return
 }
 
-// ListAllTransactionsGetRes returns a pointer to the value in the Result field
-func (a API) ListAllTransactionsGetRes() (out *[]btcjson.ListTransactionsResult, err error) {
-	out, _ = a.Result.(*[]btcjson.ListTransactionsResult)
+// ListMultisigPSBTsGetRes returns a pointer to the value in the Result field
+func (a API) ListMultisigPSBTsGetRes() (out *[]btcjson.MultisigPSBTResult, err error) {
+	out, _ = a.Result.(*[]btcjson.MultisigPSBTResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// ListAllTransactionsWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListAllTransactionsWait(cmd *btcjson.ListAllTransactionsCmd) (out *[]btcjson.ListTransactionsResult, err error) {
-	RPCHandlers["listalltransactions"].Call <- API{a.Ch, cmd, nil}
+// ListMultisigPSBTsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListMultisigPSBTsWait(cmd *None) (out *[]btcjson.MultisigPSBTResult, err error) {
+	RPCHandlers["listmultisigpsbts"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan ListAllTransactionsRes):
+	case o := <-a.Ch.(chan ListMultisigPSBTsRes):
 		out, err = o.Res, o.Err
 	}
 	return
 }
 
-// ListLockUnspent calls the method with the given parameters
-func (a API) ListLockUnspent(cmd *None) (err error) {
-	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
+// ListPaymentRequests calls the method with the given parameters
+func (a API) ListPaymentRequests(cmd *None) (err error) {
+	RPCHandlers["listpaymentrequests"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// ListLockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// ListPaymentRequestsCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) ListLockUnspentCheck() (isNew bool) {
+func (a API) ListPaymentRequestsCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan ListLockUnspentRes):
+	case o := <-a.Ch.(chan ListPaymentRequestsRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1493,20 +2423,20 @@ func (a API) ListLockUnspentCheck() (isNew bool) {
 	return
 }
 
-// ListLockUnspentGetRes returns a pointer to the value in the Result field
-func (a API) ListLockUnspentGetRes() (out *[]btcjson.TransactionInput, err error) {
-	out, _ = a.Result.(*[]btcjson.TransactionInput)
+// ListPaymentRequestsGetRes returns a pointer to the value in the Result field
+func (a API) ListPaymentRequestsGetRes() (out *[]btcjson.PaymentRequestResult, err error) {
+	out, _ = a.Result.(*[]btcjson.PaymentRequestResult)
 	err, _ = a.Result.(error)
 	return
 }
 
-// ListLockUnspentWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) ListLockUnspentWait(cmd *None) (out *[]btcjson.TransactionInput, err error) {
-	RPCHandlers["listlockunspent"].Call <- API{a.Ch, cmd, nil}
+// ListPaymentRequestsWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) ListPaymentRequestsWait(cmd *None) (out *[]btcjson.PaymentRequestResult, err error) {
+	RPCHandlers["listpaymentrequests"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan ListLockUnspentRes):
+	case o := <-a.Ch.(chan ListPaymentRequestsRes):
 		out, err = o.Res, o.Err
 	}
 	return
@@ -1717,6 +2647,129 @@ func (a API) ListUnspentWait(cmd *btcjson.ListUnspentCmd) (out *[]btcjson.ListUn
 	return
 }
 
+// LockUnspent calls the method with the given parameters
+func (a API) LockUnspent(cmd *btcjson.LockUnspentCmd) (err error) {
+	RPCHandlers["lockunspent"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// LockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) LockUnspentCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan LockUnspentRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// LockUnspentGetRes returns a pointer to the value in the Result field
+func (a API) LockUnspentGetRes() (out *bool, err error) {
+	out, _ = a.Result.(*bool)
+	err, _ = a.Result.(error)
+	return
+}
+
+// LockUnspentWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) LockUnspentWait(cmd *btcjson.LockUnspentCmd) (out *bool, err error) {
+	RPCHandlers["lockunspent"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan LockUnspentRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// PreviewSend calls the method with the given parameters
+func (a API) PreviewSend(cmd *btcjson.PreviewSendCmd) (err error) {
+	RPCHandlers["previewsend"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// PreviewSendCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) PreviewSendCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan PreviewSendRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// PreviewSendGetRes returns a pointer to the value in the Result field
+func (a API) PreviewSendGetRes() (out *btcjson.PreviewSendResult, err error) {
+	out, _ = a.Result.(*btcjson.PreviewSendResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// PreviewSendWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PreviewSendWait(cmd *btcjson.PreviewSendCmd) (out *btcjson.PreviewSendResult, err error) {
+	RPCHandlers["previewsend"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan PreviewSendRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
+// PublishPSBT calls the method with the given parameters
+func (a API) PublishPSBT(cmd *btcjson.PublishPSBTCmd) (err error) {
+	RPCHandlers["publishpsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// PublishPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) PublishPSBTCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan PublishPSBTRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// PublishPSBTGetRes returns a pointer to the value in the Result field
+func (a API) PublishPSBTGetRes() (out *string, err error) {
+	out, _ = a.Result.(*string)
+	err, _ = a.Result.(error)
+	return
+}
+
+// PublishPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) PublishPSBTWait(cmd *btcjson.PublishPSBTCmd) (out *string, err error) {
+	RPCHandlers["publishpsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan PublishPSBTRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // RenameAccount calls the method with the given parameters
 func (a API) RenameAccount(cmd *btcjson.RenameAccountCmd) (err error) {
 	RPCHandlers["renameaccount"].Call <- API{a.Ch, cmd, nil}
@@ -1758,17 +2811,17 @@ func (a API) RenameAccountWait(cmd *btcjson.RenameAccountCmd) (out *None, err er
 	return
 }
 
-// LockUnspent calls the method with the given parameters
-func (a API) LockUnspent(cmd btcjson.LockUnspentCmd) (err error) {
-	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
+// RescanFromHeight calls the method with the given parameters
+func (a API) RescanFromHeight(cmd *btcjson.RescanFromHeightCmd) (err error) {
+	RPCHandlers["rescanfromheight"].Call <- API{a.Ch, cmd, nil}
 	return
 }
 
-// LockUnspentCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// RescanFromHeightCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
 // storing the value in the Result field
-func (a API) LockUnspentCheck() (isNew bool) {
+func (a API) RescanFromHeightCheck() (isNew bool) {
 	select {
-	case o := <-a.Ch.(chan LockUnspentRes):
+	case o := <-a.Ch.(chan RescanFromHeightRes):
 		if o.Err != nil {
 			a.Result = o.Err
 		} else {
@@ -1780,20 +2833,20 @@ func (a API) LockUnspentCheck() (isNew bool) {
 	return
 }
 
-// LockUnspentGetRes returns a pointer to the value in the Result field
-func (a API) LockUnspentGetRes() (out *bool, err error) {
-	out, _ = a.Result.(*bool)
+// RescanFromHeightGetRes returns a pointer to the value in the Result field
+func (a API) RescanFromHeightGetRes() (out *None, err error) {
+	out, _ = a.Result.(*None)
 	err, _ = a.Result.(error)
 	return
 }
 
-// LockUnspentWait calls the method and blocks until it returns or 5 seconds passes
-func (a API) LockUnspentWait(cmd btcjson.LockUnspentCmd) (out *bool, err error) {
-	RPCHandlers["sendfrom"].Call <- API{a.Ch, cmd, nil}
+// RescanFromHeightWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) RescanFromHeightWait(cmd *btcjson.RescanFromHeightCmd) (out *None, err error) {
+	RPCHandlers["rescanfromheight"].Call <- API{a.Ch, cmd, nil}
 	select {
 	case <-time.After(time.Second * 5):
 		break
-	case o := <-a.Ch.(chan LockUnspentRes):
+	case o := <-a.Ch.(chan RescanFromHeightRes):
 		out, err = o.Res, o.Err
 	}
 	return
@@ -1963,6 +3016,47 @@ func (a API) SignMessageWait(cmd *btcjson.SignMessageCmd) (out *string, err erro
 	return
 }
 
+// SignMultisigPSBT calls the method with the given parameters
+func (a API) SignMultisigPSBT(cmd *btcjson.SignMultisigPSBTCmd) (err error) {
+	RPCHandlers["signmultisigpsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// SignMultisigPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) SignMultisigPSBTCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan SignMultisigPSBTRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// SignMultisigPSBTGetRes returns a pointer to the value in the Result field
+func (a API) SignMultisigPSBTGetRes() (out *btcjson.MultisigPSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.MultisigPSBTResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// SignMultisigPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) SignMultisigPSBTWait(cmd *btcjson.SignMultisigPSBTCmd) (out *btcjson.MultisigPSBTResult, err error) {
+	RPCHandlers["signmultisigpsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan SignMultisigPSBTRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // SignRawTransaction calls the method with the given parameters
 func (a API) SignRawTransaction(cmd btcjson.SignRawTransactionCmd) (err error) {
 	RPCHandlers["signrawtransaction"].Call <- API{a.Ch, cmd, nil}
@@ -2086,6 +3180,47 @@ func (a API) VerifyMessageWait(cmd *btcjson.VerifyMessageCmd) (out *bool, err er
 	return
 }
 
+// WalletCreateFundedPSBT calls the method with the given parameters
+func (a API) WalletCreateFundedPSBT(cmd *btcjson.WalletCreateFundedPSBTCmd) (err error) {
+	RPCHandlers["walletcreatefundedpsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletCreateFundedPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletCreateFundedPSBTCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan WalletCreateFundedPSBTRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletCreateFundedPSBTGetRes returns a pointer to the value in the Result field
+func (a API) WalletCreateFundedPSBTGetRes() (out *btcjson.WalletCreateFundedPSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.WalletCreateFundedPSBTResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// WalletCreateFundedPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletCreateFundedPSBTWait(cmd *btcjson.WalletCreateFundedPSBTCmd) (out *btcjson.WalletCreateFundedPSBTResult, err error) {
+	RPCHandlers["walletcreatefundedpsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan WalletCreateFundedPSBTRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // WalletIsLocked calls the method with the given parameters
 func (a API) WalletIsLocked(cmd *None) (err error) {
 	RPCHandlers["walletislocked"].Call <- API{a.Ch, cmd, nil}
@@ -2250,6 +3385,47 @@ func (a API) WalletPassphraseChangeWait(cmd *btcjson.WalletPassphraseChangeCmd)
 	return
 }
 
+// WalletProcessPSBT calls the method with the given parameters
+func (a API) WalletProcessPSBT(cmd *btcjson.WalletProcessPSBTCmd) (err error) {
+	RPCHandlers["walletprocesspsbt"].Call <- API{a.Ch, cmd, nil}
+	return
+}
+
+// WalletProcessPSBTCheck checks if a new message arrived on the result channel and returns true if it does, as well as 
+// storing the value in the Result field
+func (a API) WalletProcessPSBTCheck() (isNew bool) {
+	select {
+	case o := <-a.Ch.(chan WalletProcessPSBTRes):
+		if o.Err != nil {
+			a.Result = o.Err
+		} else {
+			a.Result = o.Res
+		}
+		isNew = true
+	default:
+	}
+	return
+}
+
+// WalletProcessPSBTGetRes returns a pointer to the value in the Result field
+func (a API) WalletProcessPSBTGetRes() (out *btcjson.WalletProcessPSBTResult, err error) {
+	out, _ = a.Result.(*btcjson.WalletProcessPSBTResult)
+	err, _ = a.Result.(error)
+	return
+}
+
+// WalletProcessPSBTWait calls the method and blocks until it returns or 5 seconds passes
+func (a API) WalletProcessPSBTWait(cmd *btcjson.WalletProcessPSBTCmd) (out *btcjson.WalletProcessPSBTResult, err error) {
+	RPCHandlers["walletprocesspsbt"].Call <- API{a.Ch, cmd, nil}
+	select {
+	case <-time.After(time.Second * 5):
+		break
+	case o := <-a.Ch.(chan WalletProcessPSBTRes):
+		out, err = o.Res, o.Err
+	}
+	return
+}
+
 // RunAPI starts up the api handler server that receives rpc.API messages and runs the handler and returns the result
 // Note that the parameters are type asserted to prevent the consumer of the API from sending wrong message types not
 // because it's necessary since they are interfaces end to end
@@ -2270,6 +3446,14 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan AddMultiSigAddressRes) <- AddMultiSigAddressRes{&r, err}
 				}
+			case msg := <-nrh["combinepsbt"].Call:
+				if res, err = nrh["combinepsbt"].
+					Handler(msg.Params.(*btcjson.CombinePSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(string); ok {
+					msg.Ch.(chan CombinePSBTRes) <- CombinePSBTRes{&r, err}
+				}
 			case msg := <-nrh["createmultisig"].Call:
 				if res, err = nrh["createmultisig"].
 					Handler(msg.Params.(*btcjson.CreateMultisigCmd), wallet,
@@ -2278,6 +3462,22 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(btcjson.CreateMultiSigResult); ok {
 					msg.Ch.(chan CreateMultiSigRes) <- CreateMultiSigRes{&r, err}
 				}
+			case msg := <-nrh["createmultisigaccount"].Call:
+				if res, err = nrh["createmultisigaccount"].
+					Handler(msg.Params.(*btcjson.CreateMultisigAccountCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.MultisigAccountResult); ok {
+					msg.Ch.(chan CreateMultisigAccountRes) <- CreateMultisigAccountRes{&r, err}
+				}
+			case msg := <-nrh["createmultisigpsbt"].Call:
+				if res, err = nrh["createmultisigpsbt"].
+					Handler(msg.Params.(*btcjson.CreateMultisigPSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.MultisigPSBTResult); ok {
+					msg.Ch.(chan CreateMultisigPSBTRes) <- CreateMultisigPSBTRes{&r, err}
+				}
 			case msg := <-nrh["createnewaccount"].Call:
 				if res, err = nrh["createnewaccount"].
 					Handler(msg.Params.(*btcjson.CreateNewAccountCmd), wallet,
@@ -2286,6 +3486,30 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(None); ok {
 					msg.Ch.(chan CreateNewAccountRes) <- CreateNewAccountRes{&r, err}
 				}
+			case msg := <-nrh["createpaymentrequest"].Call:
+				if res, err = nrh["createpaymentrequest"].
+					Handler(msg.Params.(*btcjson.CreatePaymentRequestCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.PaymentRequestResult); ok {
+					msg.Ch.(chan CreatePaymentRequestRes) <- CreatePaymentRequestRes{&r, err}
+				}
+			case msg := <-nrh["createsigningdevicepsbt"].Call:
+				if res, err = nrh["createsigningdevicepsbt"].
+					Handler(msg.Params.(*btcjson.CreateSigningDevicePSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.SigningDevicePSBTResult); ok {
+					msg.Ch.(chan CreateSigningDevicePSBTRes) <- CreateSigningDevicePSBTRes{&r, err}
+				}
+			case msg := <-nrh["decodepsbt"].Call:
+				if res, err = nrh["decodepsbt"].
+					Handler(msg.Params.(*btcjson.DecodePSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.DecodePSBTResult); ok {
+					msg.Ch.(chan DecodePSBTRes) <- DecodePSBTRes{&r, err}
+				}
 			case msg := <-nrh["dropwallethistory"].Call:
 				if res, err = nrh["dropwallethistory"].
 					Handler(msg.Params.(*None), wallet,
@@ -2302,6 +3526,22 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan DumpPrivKeyRes) <- DumpPrivKeyRes{&r, err}
 				}
+			case msg := <-nrh["encryptwalletdb"].Call:
+				if res, err = nrh["encryptwalletdb"].
+					Handler(msg.Params.(*btcjson.EncryptWalletDBCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(None); ok {
+					msg.Ch.(chan EncryptWalletDBRes) <- EncryptWalletDBRes{&r, err}
+				}
+			case msg := <-nrh["finalizepsbt"].Call:
+				if res, err = nrh["finalizepsbt"].
+					Handler(msg.Params.(*btcjson.FinalizePSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.FinalizePSBTResult); ok {
+					msg.Ch.(chan FinalizePSBTRes) <- FinalizePSBTRes{&r, err}
+				}
 			case msg := <-nrh["getaccount"].Call:
 				if res, err = nrh["getaccount"].
 					Handler(msg.Params.(*btcjson.GetAccountCmd), wallet,
@@ -2318,6 +3558,14 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan GetAccountAddressRes) <- GetAccountAddressRes{&r, err}
 				}
+			case msg := <-nrh["getaccountxpub"].Call:
+				if res, err = nrh["getaccountxpub"].
+					Handler(msg.Params.(*btcjson.GetAccountXPubCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(string); ok {
+					msg.Ch.(chan GetAccountXPubRes) <- GetAccountXPubRes{&r, err}
+				}
 			case msg := <-nrh["getaddressesbyaccount"].Call:
 				if res, err = nrh["getaddressesbyaccount"].
 					Handler(msg.Params.(*btcjson.GetAddressesByAccountCmd), wallet,
@@ -2374,6 +3622,46 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan GetNewAddressRes) <- GetNewAddressRes{&r, err}
 				}
+			case msg := <-nrh["getnewmultisigaddress"].Call:
+				if res, err = nrh["getnewmultisigaddress"].
+					Handler(msg.Params.(*btcjson.GetNewMultisigAddressCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(string); ok {
+					msg.Ch.(chan GetNewMultisigAddressRes) <- GetNewMultisigAddressRes{&r, err}
+				}
+			case msg := <-nrh["getprivacyreport"].Call:
+				if res, err = nrh["getprivacyreport"].
+					Handler(msg.Params.(*None), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.PrivacyReportResult); ok {
+					msg.Ch.(chan GetPrivacyReportRes) <- GetPrivacyReportRes{&r, err}
+				}
+			case msg := <-nrh["getreconciliation"].Call:
+				if res, err = nrh["getreconciliation"].
+					Handler(msg.Params.(*None), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetReconciliationResult); ok {
+					msg.Ch.(chan GetReconciliationRes) <- GetReconciliationRes{&r, err}
+				}
+			case msg := <-nrh["getwalletinfo"].Call:
+				if res, err = nrh["getwalletinfo"].
+					Handler(msg.Params.(*None), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetWalletInfoResult); ok {
+					msg.Ch.(chan GetWalletInfoRes) <- GetWalletInfoRes{&r, err}
+				}
+			case msg := <-nrh["getwalletbalances"].Call:
+				if res, err = nrh["getwalletbalances"].
+					Handler(msg.Params.(*btcjson.GetWalletBalancesCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.GetWalletBalancesResult); ok {
+					msg.Ch.(chan GetWalletBalancesRes) <- GetWalletBalancesRes{&r, err}
+				}
 			case msg := <-nrh["getrawchangeaddress"].Call:
 				if res, err = nrh["getrawchangeaddress"].
 					Handler(msg.Params.(*btcjson.GetRawChangeAddressCmd), wallet,
@@ -2422,6 +3710,14 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan HelpNoChainRPCRes) <- HelpNoChainRPCRes{&r, err}
 				}
+			case msg := <-nrh["importmultisigpsbt"].Call:
+				if res, err = nrh["importmultisigpsbt"].
+					Handler(msg.Params.(*btcjson.ImportMultisigPSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.MultisigPSBTResult); ok {
+					msg.Ch.(chan ImportMultisigPSBTRes) <- ImportMultisigPSBTRes{&r, err}
+				}
 			case msg := <-nrh["importprivkey"].Call:
 				if res, err = nrh["importprivkey"].
 					Handler(msg.Params.(*btcjson.ImportPrivKeyCmd), wallet,
@@ -2470,6 +3766,30 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.([]btcjson.TransactionInput); ok {
 					msg.Ch.(chan ListLockUnspentRes) <- ListLockUnspentRes{&r, err}
 				}
+			case msg := <-nrh["listmultisigaccounts"].Call:
+				if res, err = nrh["listmultisigaccounts"].
+					Handler(msg.Params.(*None), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.([]btcjson.MultisigAccountResult); ok {
+					msg.Ch.(chan ListMultisigAccountsRes) <- ListMultisigAccountsRes{&r, err}
+				}
+			case msg := <-nrh["listmultisigpsbts"].Call:
+				if res, err = nrh["listmultisigpsbts"].
+					Handler(msg.Params.(*None), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.([]btcjson.MultisigPSBTResult); ok {
+					msg.Ch.(chan ListMultisigPSBTsRes) <- ListMultisigPSBTsRes{&r, err}
+				}
+			case msg := <-nrh["listpaymentrequests"].Call:
+				if res, err = nrh["listpaymentrequests"].
+					Handler(msg.Params.(*None), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.([]btcjson.PaymentRequestResult); ok {
+					msg.Ch.(chan ListPaymentRequestsRes) <- ListPaymentRequestsRes{&r, err}
+				}
 			case msg := <-nrh["listreceivedbyaccount"].Call:
 				if res, err = nrh["listreceivedbyaccount"].
 					Handler(msg.Params.(*btcjson.ListReceivedByAccountCmd), wallet,
@@ -2510,6 +3830,30 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.([]btcjson.ListUnspentResult); ok {
 					msg.Ch.(chan ListUnspentRes) <- ListUnspentRes{&r, err}
 				}
+			case msg := <-nrh["lockunspent"].Call:
+				if res, err = nrh["lockunspent"].
+					Handler(msg.Params.(*btcjson.LockUnspentCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(bool); ok {
+					msg.Ch.(chan LockUnspentRes) <- LockUnspentRes{&r, err}
+				}
+			case msg := <-nrh["previewsend"].Call:
+				if res, err = nrh["previewsend"].
+					Handler(msg.Params.(*btcjson.PreviewSendCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.PreviewSendResult); ok {
+					msg.Ch.(chan PreviewSendRes) <- PreviewSendRes{&r, err}
+				}
+			case msg := <-nrh["publishpsbt"].Call:
+				if res, err = nrh["publishpsbt"].
+					Handler(msg.Params.(*btcjson.PublishPSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(string); ok {
+					msg.Ch.(chan PublishPSBTRes) <- PublishPSBTRes{&r, err}
+				}
 			case msg := <-nrh["renameaccount"].Call:
 				if res, err = nrh["renameaccount"].
 					Handler(msg.Params.(*btcjson.RenameAccountCmd), wallet,
@@ -2518,13 +3862,13 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(None); ok {
 					msg.Ch.(chan RenameAccountRes) <- RenameAccountRes{&r, err}
 				}
-			case msg := <-nrh["sendfrom"].Call:
-				if res, err = nrh["sendfrom"].
-					Handler(msg.Params.(btcjson.LockUnspentCmd), wallet,
+			case msg := <-nrh["rescanfromheight"].Call:
+				if res, err = nrh["rescanfromheight"].
+					Handler(msg.Params.(*btcjson.RescanFromHeightCmd), wallet,
 						chainRPC); Check(err) {
 				}
-				if r, ok := res.(bool); ok {
-					msg.Ch.(chan LockUnspentRes) <- LockUnspentRes{&r, err}
+				if r, ok := res.(None); ok {
+					msg.Ch.(chan RescanFromHeightRes) <- RescanFromHeightRes{&r, err}
 				}
 			case msg := <-nrh["sendmany"].Call:
 				if res, err = nrh["sendmany"].
@@ -2558,6 +3902,14 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(string); ok {
 					msg.Ch.(chan SignMessageRes) <- SignMessageRes{&r, err}
 				}
+			case msg := <-nrh["signmultisigpsbt"].Call:
+				if res, err = nrh["signmultisigpsbt"].
+					Handler(msg.Params.(*btcjson.SignMultisigPSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.MultisigPSBTResult); ok {
+					msg.Ch.(chan SignMultisigPSBTRes) <- SignMultisigPSBTRes{&r, err}
+				}
 			case msg := <-nrh["signrawtransaction"].Call:
 				if res, err = nrh["signrawtransaction"].
 					Handler(msg.Params.(btcjson.SignRawTransactionCmd), wallet,
@@ -2582,6 +3934,14 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(bool); ok {
 					msg.Ch.(chan VerifyMessageRes) <- VerifyMessageRes{&r, err}
 				}
+			case msg := <-nrh["walletcreatefundedpsbt"].Call:
+				if res, err = nrh["walletcreatefundedpsbt"].
+					Handler(msg.Params.(*btcjson.WalletCreateFundedPSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.WalletCreateFundedPSBTResult); ok {
+					msg.Ch.(chan WalletCreateFundedPSBTRes) <- WalletCreateFundedPSBTRes{&r, err}
+				}
 			case msg := <-nrh["walletislocked"].Call:
 				if res, err = nrh["walletislocked"].
 					Handler(msg.Params.(*None), wallet,
@@ -2614,6 +3974,14 @@ func RunAPI(chainRPC *chain.RPCClient, wallet *wallet.Wallet,
 				if r, ok := res.(None); ok {
 					msg.Ch.(chan WalletPassphraseChangeRes) <- WalletPassphraseChangeRes{&r, err}
 				}
+			case msg := <-nrh["walletprocesspsbt"].Call:
+				if res, err = nrh["walletprocesspsbt"].
+					Handler(msg.Params.(*btcjson.WalletProcessPSBTCmd), wallet,
+						chainRPC); Check(err) {
+				}
+				if r, ok := res.(btcjson.WalletProcessPSBTResult); ok {
+					msg.Ch.(chan WalletProcessPSBTRes) <- WalletProcessPSBTRes{&r, err}
+				}
 			case <-quit:
 				Debug("stopping wallet cAPI")
 				return
@@ -2637,6 +4005,19 @@ func (c *CAPI) AddMultiSigAddress(req *btcjson.AddMultisigAddressCmd, resp strin
 	return
 }
 
+func (c *CAPI) CombinePSBT(req *btcjson.CombinePSBTCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["combinepsbt"].Result()
+	res.Params = req
+	nrh["combinepsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) CreateMultiSig(req *btcjson.CreateMultisigCmd, resp btcjson.CreateMultiSigResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["createmultisig"].Result()
@@ -2650,6 +4031,32 @@ func (c *CAPI) CreateMultiSig(req *btcjson.CreateMultisigCmd, resp btcjson.Creat
 	return
 }
 
+func (c *CAPI) CreateMultisigAccount(req *btcjson.CreateMultisigAccountCmd, resp btcjson.MultisigAccountResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["createmultisigaccount"].Result()
+	res.Params = req
+	nrh["createmultisigaccount"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.MultisigAccountResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) CreateMultisigPSBT(req *btcjson.CreateMultisigPSBTCmd, resp btcjson.MultisigPSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["createmultisigpsbt"].Result()
+	res.Params = req
+	nrh["createmultisigpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.MultisigPSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) CreateNewAccount(req *btcjson.CreateNewAccountCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["createnewaccount"].Result()
@@ -2663,6 +4070,45 @@ func (c *CAPI) CreateNewAccount(req *btcjson.CreateNewAccountCmd, resp None) (er
 	return
 }
 
+func (c *CAPI) CreatePaymentRequest(req *btcjson.CreatePaymentRequestCmd, resp btcjson.PaymentRequestResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["createpaymentrequest"].Result()
+	res.Params = req
+	nrh["createpaymentrequest"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.PaymentRequestResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) CreateSigningDevicePSBT(req *btcjson.CreateSigningDevicePSBTCmd, resp btcjson.SigningDevicePSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["createsigningdevicepsbt"].Result()
+	res.Params = req
+	nrh["createsigningdevicepsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.SigningDevicePSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) DecodePSBT(req *btcjson.DecodePSBTCmd, resp btcjson.DecodePSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["decodepsbt"].Result()
+	res.Params = req
+	nrh["decodepsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.DecodePSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) HandleDropWalletHistory(req *None, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["dropwallethistory"].Result()
@@ -2689,6 +4135,32 @@ func (c *CAPI) DumpPrivKey(req *btcjson.DumpPrivKeyCmd, resp string) (err error)
 	return
 }
 
+func (c *CAPI) EncryptWalletDB(req *btcjson.EncryptWalletDBCmd, resp None) (err error) {
+	nrh := RPCHandlers
+	res := nrh["encryptwalletdb"].Result()
+	res.Params = req
+	nrh["encryptwalletdb"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) FinalizePSBT(req *btcjson.FinalizePSBTCmd, resp btcjson.FinalizePSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["finalizepsbt"].Result()
+	res.Params = req
+	nrh["finalizepsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.FinalizePSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetAccount(req *btcjson.GetAccountCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getaccount"].Result()
@@ -2715,6 +4187,19 @@ func (c *CAPI) GetAccountAddress(req *btcjson.GetAccountAddressCmd, resp string)
 	return
 }
 
+func (c *CAPI) GetAccountXPub(req *btcjson.GetAccountXPubCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getaccountxpub"].Result()
+	res.Params = req
+	nrh["getaccountxpub"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetAddressesByAccount(req *btcjson.GetAddressesByAccountCmd, resp []string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getaddressesbyaccount"].Result()
@@ -2806,6 +4291,71 @@ func (c *CAPI) GetNewAddress(req *btcjson.GetNewAddressCmd, resp string) (err er
 	return
 }
 
+func (c *CAPI) GetNewMultisigAddress(req *btcjson.GetNewMultisigAddressCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getnewmultisigaddress"].Result()
+	res.Params = req
+	nrh["getnewmultisigaddress"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetPrivacyReport(req *None, resp btcjson.PrivacyReportResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getprivacyreport"].Result()
+	res.Params = req
+	nrh["getprivacyreport"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.PrivacyReportResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetReconciliation(req *None, resp btcjson.GetReconciliationResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getreconciliation"].Result()
+	res.Params = req
+	nrh["getreconciliation"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetReconciliationResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetWalletInfo(req *None, resp btcjson.GetWalletInfoResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getwalletinfo"].Result()
+	res.Params = req
+	nrh["getwalletinfo"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetWalletInfoResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) GetWalletBalances(req *btcjson.GetWalletBalancesCmd, resp btcjson.GetWalletBalancesResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["getwalletbalances"].Result()
+	res.Params = req
+	nrh["getwalletbalances"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.GetWalletBalancesResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) GetRawChangeAddress(req *btcjson.GetRawChangeAddressCmd, resp string) (err error) {
 	nrh := RPCHandlers
 	res := nrh["getrawchangeaddress"].Result()
@@ -2884,6 +4434,19 @@ func (c *CAPI) HelpNoChainRPC(req btcjson.HelpCmd, resp string) (err error) {
 	return
 }
 
+func (c *CAPI) ImportMultisigPSBT(req *btcjson.ImportMultisigPSBTCmd, resp btcjson.MultisigPSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["importmultisigpsbt"].Result()
+	res.Params = req
+	nrh["importmultisigpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.MultisigPSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) ImportPrivKey(req *btcjson.ImportPrivKeyCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["importprivkey"].Result()
@@ -2903,59 +4466,98 @@ func (c *CAPI) KeypoolRefill(req *None, resp None) (err error) {
 	res.Params = req
 	nrh["keypoolrefill"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan None):
+	case resp = <-res.Ch.(chan None):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) ListAccounts(req *btcjson.ListAccountsCmd, resp map[string]float64) (err error) {
+	nrh := RPCHandlers
+	res := nrh["listaccounts"].Result()
+	res.Params = req
+	nrh["listaccounts"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan map[string]float64):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) ListAddressTransactions(req *btcjson.ListAddressTransactionsCmd, resp []btcjson.ListTransactionsResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["listaddresstransactions"].Result()
+	res.Params = req
+	nrh["listaddresstransactions"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) ListAllTransactions(req *btcjson.ListAllTransactionsCmd, resp []btcjson.ListTransactionsResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["listalltransactions"].Result()
+	res.Params = req
+	nrh["listalltransactions"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) ListAccounts(req *btcjson.ListAccountsCmd, resp map[string]float64) (err error) {
+func (c *CAPI) ListLockUnspent(req *None, resp []btcjson.TransactionInput) (err error) {
 	nrh := RPCHandlers
-	res := nrh["listaccounts"].Result()
+	res := nrh["listlockunspent"].Result()
 	res.Params = req
-	nrh["listaccounts"].Call <- res
+	nrh["listlockunspent"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan map[string]float64):
+	case resp = <-res.Ch.(chan []btcjson.TransactionInput):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) ListAddressTransactions(req *btcjson.ListAddressTransactionsCmd, resp []btcjson.ListTransactionsResult) (err error) {
+func (c *CAPI) ListMultisigAccounts(req *None, resp []btcjson.MultisigAccountResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["listaddresstransactions"].Result()
+	res := nrh["listmultisigaccounts"].Result()
 	res.Params = req
-	nrh["listaddresstransactions"].Call <- res
+	nrh["listmultisigaccounts"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case resp = <-res.Ch.(chan []btcjson.MultisigAccountResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) ListAllTransactions(req *btcjson.ListAllTransactionsCmd, resp []btcjson.ListTransactionsResult) (err error) {
+func (c *CAPI) ListMultisigPSBTs(req *None, resp []btcjson.MultisigPSBTResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["listalltransactions"].Result()
+	res := nrh["listmultisigpsbts"].Result()
 	res.Params = req
-	nrh["listalltransactions"].Call <- res
+	nrh["listmultisigpsbts"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.ListTransactionsResult):
+	case resp = <-res.Ch.(chan []btcjson.MultisigPSBTResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
 	return
 }
 
-func (c *CAPI) ListLockUnspent(req *None, resp []btcjson.TransactionInput) (err error) {
+func (c *CAPI) ListPaymentRequests(req *None, resp []btcjson.PaymentRequestResult) (err error) {
 	nrh := RPCHandlers
-	res := nrh["listlockunspent"].Result()
+	res := nrh["listpaymentrequests"].Result()
 	res.Params = req
-	nrh["listlockunspent"].Call <- res
+	nrh["listpaymentrequests"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan []btcjson.TransactionInput):
+	case resp = <-res.Ch.(chan []btcjson.PaymentRequestResult):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
@@ -3027,6 +4629,45 @@ func (c *CAPI) ListUnspent(req *btcjson.ListUnspentCmd, resp []btcjson.ListUnspe
 	return
 }
 
+func (c *CAPI) LockUnspent(req *btcjson.LockUnspentCmd, resp bool) (err error) {
+	nrh := RPCHandlers
+	res := nrh["lockunspent"].Result()
+	res.Params = req
+	nrh["lockunspent"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan bool):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) PreviewSend(req *btcjson.PreviewSendCmd, resp btcjson.PreviewSendResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["previewsend"].Result()
+	res.Params = req
+	nrh["previewsend"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.PreviewSendResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
+func (c *CAPI) PublishPSBT(req *btcjson.PublishPSBTCmd, resp string) (err error) {
+	nrh := RPCHandlers
+	res := nrh["publishpsbt"].Result()
+	res.Params = req
+	nrh["publishpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan string):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) RenameAccount(req *btcjson.RenameAccountCmd, resp None) (err error) {
 	nrh := RPCHandlers
 	res := nrh["renameaccount"].Result()
@@ -3040,13 +4681,13 @@ func (c *CAPI) RenameAccount(req *btcjson.RenameAccountCmd, resp None) (err erro
 	return
 }
 
-func (c *CAPI) LockUnspent(req btcjson.LockUnspentCmd, resp bool) (err error) {
+func (c *CAPI) RescanFromHeight(req *btcjson.RescanFromHeightCmd, resp None) (err error) {
 	nrh := RPCHandlers
-	res := nrh["sendfrom"].Result()
+	res := nrh["rescanfromheight"].Result()
 	res.Params = req
-	nrh["sendfrom"].Call <- res
+	nrh["rescanfromheight"].Call <- res
 	select {
-	case resp = <-res.Ch.(chan bool):
+	case resp = <-res.Ch.(chan None):
 	case <-time.After(c.Timeout):
 	case <-c.quit:
 	}
@@ -3105,6 +4746,19 @@ func (c *CAPI) SignMessage(req *btcjson.SignMessageCmd, resp string) (err error)
 	return
 }
 
+func (c *CAPI) SignMultisigPSBT(req *btcjson.SignMultisigPSBTCmd, resp btcjson.MultisigPSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["signmultisigpsbt"].Result()
+	res.Params = req
+	nrh["signmultisigpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.MultisigPSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) SignRawTransaction(req btcjson.SignRawTransactionCmd, resp btcjson.SignRawTransactionResult) (err error) {
 	nrh := RPCHandlers
 	res := nrh["signrawtransaction"].Result()
@@ -3144,6 +4798,19 @@ func (c *CAPI) VerifyMessage(req *btcjson.VerifyMessageCmd, resp bool) (err erro
 	return
 }
 
+func (c *CAPI) WalletCreateFundedPSBT(req *btcjson.WalletCreateFundedPSBTCmd, resp btcjson.WalletCreateFundedPSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["walletcreatefundedpsbt"].Result()
+	res.Params = req
+	nrh["walletcreatefundedpsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.WalletCreateFundedPSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 func (c *CAPI) WalletIsLocked(req *None, resp bool) (err error) {
 	nrh := RPCHandlers
 	res := nrh["walletislocked"].Result()
@@ -3196,6 +4863,19 @@ func (c *CAPI) WalletPassphraseChange(req *btcjson.WalletPassphraseChangeCmd, re
 	return
 }
 
+func (c *CAPI) WalletProcessPSBT(req *btcjson.WalletProcessPSBTCmd, resp btcjson.WalletProcessPSBTResult) (err error) {
+	nrh := RPCHandlers
+	res := nrh["walletprocesspsbt"].Result()
+	res.Params = req
+	nrh["walletprocesspsbt"].Call <- res
+	select {
+	case resp = <-res.Ch.(chan btcjson.WalletProcessPSBTResult):
+	case <-time.After(c.Timeout):
+	case <-c.quit:
+	}
+	return
+}
+
 // Client call wrappers for a CAPI client with a given Conn
 
 func (r *CAPIClient) AddMultiSigAddress(cmd ...*btcjson.AddMultisigAddressCmd) (res string, err error) {
@@ -3208,6 +4888,16 @@ func (r *CAPIClient) AddMultiSigAddress(cmd ...*btcjson.AddMultisigAddressCmd) (
 	return
 }
 
+func (r *CAPIClient) CombinePSBT(cmd ...*btcjson.CombinePSBTCmd) (res string, err error) {
+	var c *btcjson.CombinePSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.CombinePSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) CreateMultiSig(cmd ...*btcjson.CreateMultisigCmd) (res btcjson.CreateMultiSigResult, err error) {
 	var c *btcjson.CreateMultisigCmd
 	if len(cmd) > 0 {
@@ -3218,6 +4908,26 @@ func (r *CAPIClient) CreateMultiSig(cmd ...*btcjson.CreateMultisigCmd) (res btcj
 	return
 }
 
+func (r *CAPIClient) CreateMultisigAccount(cmd ...*btcjson.CreateMultisigAccountCmd) (res btcjson.MultisigAccountResult, err error) {
+	var c *btcjson.CreateMultisigAccountCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.CreateMultisigAccount", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) CreateMultisigPSBT(cmd ...*btcjson.CreateMultisigPSBTCmd) (res btcjson.MultisigPSBTResult, err error) {
+	var c *btcjson.CreateMultisigPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.CreateMultisigPSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) CreateNewAccount(cmd ...*btcjson.CreateNewAccountCmd) (res None, err error) {
 	var c *btcjson.CreateNewAccountCmd
 	if len(cmd) > 0 {
@@ -3228,6 +4938,36 @@ func (r *CAPIClient) CreateNewAccount(cmd ...*btcjson.CreateNewAccountCmd) (res
 	return
 }
 
+func (r *CAPIClient) CreatePaymentRequest(cmd ...*btcjson.CreatePaymentRequestCmd) (res btcjson.PaymentRequestResult, err error) {
+	var c *btcjson.CreatePaymentRequestCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.CreatePaymentRequest", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) CreateSigningDevicePSBT(cmd ...*btcjson.CreateSigningDevicePSBTCmd) (res btcjson.SigningDevicePSBTResult, err error) {
+	var c *btcjson.CreateSigningDevicePSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.CreateSigningDevicePSBT", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) DecodePSBT(cmd ...*btcjson.DecodePSBTCmd) (res btcjson.DecodePSBTResult, err error) {
+	var c *btcjson.DecodePSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.DecodePSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) HandleDropWalletHistory(cmd ...*None) (res string, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3248,6 +4988,26 @@ func (r *CAPIClient) DumpPrivKey(cmd ...*btcjson.DumpPrivKeyCmd) (res string, er
 	return
 }
 
+func (r *CAPIClient) EncryptWalletDB(cmd ...*btcjson.EncryptWalletDBCmd) (res None, err error) {
+	var c *btcjson.EncryptWalletDBCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.EncryptWalletDB", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) FinalizePSBT(cmd ...*btcjson.FinalizePSBTCmd) (res btcjson.FinalizePSBTResult, err error) {
+	var c *btcjson.FinalizePSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.FinalizePSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetAccount(cmd ...*btcjson.GetAccountCmd) (res string, err error) {
 	var c *btcjson.GetAccountCmd
 	if len(cmd) > 0 {
@@ -3268,6 +5028,16 @@ func (r *CAPIClient) GetAccountAddress(cmd ...*btcjson.GetAccountAddressCmd) (re
 	return
 }
 
+func (r *CAPIClient) GetAccountXPub(cmd ...*btcjson.GetAccountXPubCmd) (res string, err error) {
+	var c *btcjson.GetAccountXPubCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetAccountXPub", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetAddressesByAccount(cmd ...*btcjson.GetAddressesByAccountCmd) (res []string, err error) {
 	var c *btcjson.GetAddressesByAccountCmd
 	if len(cmd) > 0 {
@@ -3338,6 +5108,56 @@ func (r *CAPIClient) GetNewAddress(cmd ...*btcjson.GetNewAddressCmd) (res string
 	return
 }
 
+func (r *CAPIClient) GetNewMultisigAddress(cmd ...*btcjson.GetNewMultisigAddressCmd) (res string, err error) {
+	var c *btcjson.GetNewMultisigAddressCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetNewMultisigAddress", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetPrivacyReport(cmd ...*None) (res btcjson.PrivacyReportResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetPrivacyReport", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetReconciliation(cmd ...*None) (res btcjson.GetReconciliationResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetReconciliation", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetWalletInfo(cmd ...*None) (res btcjson.GetWalletInfoResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetWalletInfo", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) GetWalletBalances(cmd ...*btcjson.GetWalletBalancesCmd) (res btcjson.GetWalletBalancesResult, err error) {
+	var c *btcjson.GetWalletBalancesCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.GetWalletBalances", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) GetRawChangeAddress(cmd ...*btcjson.GetRawChangeAddressCmd) (res string, err error) {
 	var c *btcjson.GetRawChangeAddressCmd
 	if len(cmd) > 0 {
@@ -3398,6 +5218,16 @@ func (r *CAPIClient) HelpNoChainRPC(cmd ...btcjson.HelpCmd) (res string, err err
 	return
 }
 
+func (r *CAPIClient) ImportMultisigPSBT(cmd ...*btcjson.ImportMultisigPSBTCmd) (res btcjson.MultisigPSBTResult, err error) {
+	var c *btcjson.ImportMultisigPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ImportMultisigPSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) ImportPrivKey(cmd ...*btcjson.ImportPrivKeyCmd) (res None, err error) {
 	var c *btcjson.ImportPrivKeyCmd
 	if len(cmd) > 0 {
@@ -3458,6 +5288,36 @@ func (r *CAPIClient) ListLockUnspent(cmd ...*None) (res []btcjson.TransactionInp
 	return
 }
 
+func (r *CAPIClient) ListMultisigAccounts(cmd ...*None) (res []btcjson.MultisigAccountResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ListMultisigAccounts", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ListMultisigPSBTs(cmd ...*None) (res []btcjson.MultisigPSBTResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ListMultisigPSBTs", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) ListPaymentRequests(cmd ...*None) (res []btcjson.PaymentRequestResult, err error) {
+	var c *None
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.ListPaymentRequests", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) ListReceivedByAccount(cmd ...*btcjson.ListReceivedByAccountCmd) (res []btcjson.ListReceivedByAccountResult, err error) {
 	var c *btcjson.ListReceivedByAccountCmd
 	if len(cmd) > 0 {
@@ -3508,6 +5368,36 @@ func (r *CAPIClient) ListUnspent(cmd ...*btcjson.ListUnspentCmd) (res []btcjson.
 	return
 }
 
+func (r *CAPIClient) LockUnspent(cmd ...*btcjson.LockUnspentCmd) (res bool, err error) {
+	var c *btcjson.LockUnspentCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.LockUnspent", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) PreviewSend(cmd ...*btcjson.PreviewSendCmd) (res btcjson.PreviewSendResult, err error) {
+	var c *btcjson.PreviewSendCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.PreviewSend", c, &res); Check(err) {
+	}
+	return
+}
+
+func (r *CAPIClient) PublishPSBT(cmd ...*btcjson.PublishPSBTCmd) (res string, err error) {
+	var c *btcjson.PublishPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.PublishPSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) RenameAccount(cmd ...*btcjson.RenameAccountCmd) (res None, err error) {
 	var c *btcjson.RenameAccountCmd
 	if len(cmd) > 0 {
@@ -3518,12 +5408,12 @@ func (r *CAPIClient) RenameAccount(cmd ...*btcjson.RenameAccountCmd) (res None,
 	return
 }
 
-func (r *CAPIClient) LockUnspent(cmd ...btcjson.LockUnspentCmd) (res bool, err error) {
-	var c btcjson.LockUnspentCmd
+func (r *CAPIClient) RescanFromHeight(cmd ...*btcjson.RescanFromHeightCmd) (res None, err error) {
+	var c *btcjson.RescanFromHeightCmd
 	if len(cmd) > 0 {
 		c = cmd[0]
 	}
-	if err = r.Call("CAPI.LockUnspent", c, &res); Check(err) {
+	if err = r.Call("CAPI.RescanFromHeight", c, &res); Check(err) {
 	}
 	return
 }
@@ -3568,6 +5458,16 @@ func (r *CAPIClient) SignMessage(cmd ...*btcjson.SignMessageCmd) (res string, er
 	return
 }
 
+func (r *CAPIClient) SignMultisigPSBT(cmd ...*btcjson.SignMultisigPSBTCmd) (res btcjson.MultisigPSBTResult, err error) {
+	var c *btcjson.SignMultisigPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.SignMultisigPSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) SignRawTransaction(cmd ...btcjson.SignRawTransactionCmd) (res btcjson.SignRawTransactionResult, err error) {
 	var c btcjson.SignRawTransactionCmd
 	if len(cmd) > 0 {
@@ -3598,6 +5498,16 @@ func (r *CAPIClient) VerifyMessage(cmd ...*btcjson.VerifyMessageCmd) (res bool,
 	return
 }
 
+func (r *CAPIClient) WalletCreateFundedPSBT(cmd ...*btcjson.WalletCreateFundedPSBTCmd) (res btcjson.WalletCreateFundedPSBTResult, err error) {
+	var c *btcjson.WalletCreateFundedPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.WalletCreateFundedPSBT", c, &res); Check(err) {
+	}
+	return
+}
+
 func (r *CAPIClient) WalletIsLocked(cmd ...*None) (res bool, err error) {
 	var c *None
 	if len(cmd) > 0 {
@@ -3637,3 +5547,12 @@ func (r *CAPIClient) WalletPassphraseChange(cmd ...*btcjson.WalletPassphraseChan
 	}
 	return
 }
+func (r *CAPIClient) WalletProcessPSBT(cmd ...*btcjson.WalletProcessPSBTCmd) (res btcjson.WalletProcessPSBTResult, err error) {
+	var c *btcjson.WalletProcessPSBTCmd
+	if len(cmd) > 0 {
+		c = cmd[0]
+	}
+	if err = r.Call("CAPI.WalletProcessPSBT", c, &res); Check(err) {
+	}
+	return
+}
@@ -1,6 +1,7 @@
 package legacy
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
@@ -16,9 +17,15 @@ import (
 
 	"github.com/btcsuite/websocket"
 
+	blockchain "github.com/p9c/pod/pkg/chain"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/rpc/audit"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
 	"github.com/p9c/pod/pkg/util/interrupt"
 	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
 	"github.com/p9c/pod/pkg/wallet/chain"
 )
 
@@ -69,6 +76,32 @@ type Server struct {
 	Quit                chan struct{}
 	QuitMutex           sync.Mutex
 	RequestShutdownChan chan struct{}
+	// NotifyClients are the authenticated websocket clients that are sent wallet notifications
+	NotifyClients    map[*WebsocketClient]struct{}
+	NotifyClientsMtx sync.Mutex
+	// Username is the user that authenticated clients run commands as, which is recorded in the audit log
+	Username string
+	AuditLog *audit.Log
+	// CanonicalJSON encodes the results of commands with sorted object keys and fixed point amounts
+	CanonicalJSON bool
+}
+
+// RPCAudited is the commands that spend from or stop the wallet, which are recorded in the audit log
+var RPCAudited = map[string]struct{}{
+	"restart":       {},
+	"sendfrom":      {},
+	"sendmany":      {},
+	"sendtoaddress": {},
+	"stop":          {},
+}
+
+// MarshalResponse marshals the response to a request, with the result encoded as canonical JSON when the server is
+// configured to.
+func (s *Server) MarshalResponse(id interface{}, result interface{}, rpcErr *btcjson.RPCError) ([]byte, error) {
+	if s.CanonicalJSON {
+		return btcjson.MarshalCanonicalResponse(id, result, rpcErr)
+	}
+	return btcjson.MarshalResponse(id, result, rpcErr)
 }
 
 // JSONAuthFail sends a message back to the client if the http auth is rejected.
@@ -99,6 +132,10 @@ func NewServer(opts *Options, walletLoader *wallet.Loader, listeners []net.Liste
 		},
 		Quit:                make(chan struct{}),
 		RequestShutdownChan: make(chan struct{}, 1),
+		NotifyClients:       make(map[*WebsocketClient]struct{}),
+		Username:            opts.Username,
+		AuditLog:            opts.AuditLog,
+		CanonicalJSON:       opts.CanonicalJSON,
 	}
 	serveMux.Handle("/", ThrottledFn(opts.MaxPOSTClients,
 		func(w http.ResponseWriter, r *http.Request) {
@@ -182,6 +219,191 @@ func (s *Server) RegisterWallet(w *wallet.Wallet) {
 	s.HandlerMutex.Lock()
 	s.Wallet = w
 	s.HandlerMutex.Unlock()
+	s.WG.Add(3)
+	go s.TransactionNotifications(w)
+	go s.ConflictNotifications(w)
+	go s.RescanNotifications(w)
+}
+
+// TransactionNotifications sends a newtx notification to the websocket clients for each listtransactions result of
+// the wallet transactions that are added to the mempool or mined, until the server is stopped.
+func (s *Server) TransactionNotifications(w *wallet.Wallet) {
+	client := w.NtfnServer.TransactionNotifications()
+out:
+	for {
+		select {
+		case n := <-client.C:
+			// the notification is shared with the other clients of the wallet so it is copied rather than appended to
+			txs := append([]wallet.TransactionSummary(nil), n.UnminedTransactions...)
+			blocks := make([]*wallet.Block, len(txs))
+			for i := range n.AttachedBlocks {
+				for range n.AttachedBlocks[i].Transactions {
+					blocks = append(blocks, &n.AttachedBlocks[i])
+				}
+				txs = append(txs, n.AttachedBlocks[i].Transactions...)
+			}
+			for i := range txs {
+				results, err := newTxResults(w, &txs[i], blocks[i])
+				if err != nil {
+					Error(err)
+					continue
+				}
+				for j := range results {
+					ntfn := btcjson.NewNewTxNtfn(results[j].Account, results[j])
+					b, err := btcjson.MarshalCmd(nil, ntfn)
+					if err != nil {
+						Error(err)
+						continue
+					}
+					s.NotifyWebsocketClients(b)
+				}
+			}
+		case <-s.Quit:
+			break out
+		}
+	}
+	client.Done()
+	s.WG.Done()
+}
+
+// newTxResults creates the listtransactions results of a transaction of a wallet notification, mined in the block or
+// unmined if it is nil. They are made from the summary in the notification as the transaction is only committed to the
+// wallet database once the notification has been sent.
+func newTxResults(w *wallet.Wallet, summary *wallet.TransactionSummary, block *wallet.Block) (
+	results []btcjson.ListTransactionsResult, err error) {
+	var tx wire.MsgTx
+	if err = tx.Deserialize(bytes.NewReader(summary.Transaction)); err != nil {
+		return
+	}
+	result := btcjson.ListTransactionsResult{
+		Generated:       blockchain.IsCoinBaseTx(&tx),
+		TxID:            summary.Hash.String(),
+		WalletConflicts: []string{},
+		Time:            summary.Timestamp,
+		TimeReceived:    summary.Timestamp,
+	}
+	if block != nil {
+		result.BlockHash = block.Hash.String()
+		result.BlockTime = block.Timestamp
+		result.Confirmations = 1
+	}
+	recvCat := "receive"
+	if result.Generated {
+		recvCat = "immature"
+	}
+	credits := make(map[uint32]wallet.TransactionSummaryOutput, len(summary.MyOutputs))
+	for _, o := range summary.MyOutputs {
+		credits[o.Index] = o
+	}
+	send := len(summary.MyInputs) != 0
+	var fee *float64
+	if send {
+		f := (-summary.Fee).ToDUO()
+		fee = &f
+	}
+	for i, out := range tx.TxOut {
+		credit, isCredit := credits[uint32(i)]
+		// change outputs are left out, as they are by listtransactions
+		if isCredit && credit.Internal {
+			continue
+		}
+		result.Vout = uint32(i)
+		result.Address = ""
+		if _, addrs, _, _ := txscript.ExtractPkScriptAddrs(out.PkScript, w.ChainParams()); len(addrs) == 1 {
+			result.Address = addrs[0].EncodeAddress()
+		}
+		amount := util.Amount(out.Value).ToDUO()
+		if send {
+			r := result
+			r.Category, r.Amount, r.Fee = "send", -amount, fee
+			results = append(results, r)
+		}
+		if isCredit {
+			r := result
+			r.Category, r.Amount = recvCat, amount
+			if r.Account, err = w.AccountName(waddrmgr.KeyScopeBIP0044, credit.Account); err != nil {
+				return
+			}
+			results = append(results, r)
+		}
+	}
+	return
+}
+
+// ConflictNotifications forwards the wallet's transaction conflict notifications to the websocket clients until the
+// server is stopped.
+func (s *Server) ConflictNotifications(w *wallet.Wallet) {
+	client := w.NtfnServer.ConflictNotifications()
+out:
+	for {
+		select {
+		case n := <-client.C:
+			ntfn := btcjson.NewTxConflictedNtfn(n.Hash.String(), n.ConflictedBy.String())
+			b, err := btcjson.MarshalCmd(nil, ntfn)
+			if err != nil {
+				Error(err)
+				continue
+			}
+			s.NotifyWebsocketClients(b)
+		case <-s.Quit:
+			break out
+		}
+	}
+	client.Done()
+	s.WG.Done()
+}
+
+// RescanNotifications forwards the progress of the wallet's rescans to the websocket clients until the server is
+// stopped.
+func (s *Server) RescanNotifications(w *wallet.Wallet) {
+	client := w.NtfnServer.RescanNotifications()
+out:
+	for {
+		select {
+		case n := <-client.C:
+			ntfn := btcjson.NewWalletRescanProgressNtfn(n.StartHeight, n.Height, n.EndHeight, n.Percent(), n.Found,
+				n.Finished)
+			b, err := btcjson.MarshalCmd(nil, ntfn)
+			if err != nil {
+				Error(err)
+				continue
+			}
+			s.NotifyWebsocketClients(b)
+		case <-s.Quit:
+			break out
+		}
+	}
+	client.Done()
+	s.WG.Done()
+}
+
+// NotifyWebsocketClients sends a marshalled notification to every authenticated websocket client without waiting for
+// slow clients.
+func (s *Server) NotifyWebsocketClients(b []byte) {
+	s.NotifyClientsMtx.Lock()
+	for wsc := range s.NotifyClients {
+		// The client waits for the send to finish before closing its response channel, see WebsocketClientRespond.
+		wsc.wg.Add(1)
+		go func(wsc *WebsocketClient) {
+			_ = wsc.Send(b)
+			wsc.wg.Done()
+		}(wsc)
+	}
+	s.NotifyClientsMtx.Unlock()
+}
+
+// AddNotifyClient registers an authenticated websocket client for wallet notifications.
+func (s *Server) AddNotifyClient(wsc *WebsocketClient) {
+	s.NotifyClientsMtx.Lock()
+	s.NotifyClients[wsc] = struct{}{}
+	s.NotifyClientsMtx.Unlock()
+}
+
+// RemoveNotifyClient unregisters a websocket client from wallet notifications.
+func (s *Server) RemoveNotifyClient(wsc *WebsocketClient) {
+	s.NotifyClientsMtx.Lock()
+	delete(s.NotifyClients, wsc)
+	s.NotifyClientsMtx.Unlock()
 }
 
 // Stop gracefully shuts down the rpc server by stopping and disconnecting all clients, disconnecting the chain server
@@ -227,6 +449,29 @@ func (s *Server) Stop() {
 	}
 	// Wait for all remaining goroutines to exit.
 	s.WG.Wait()
+	if err := s.AuditLog.Close(); err != nil {
+		Error(err)
+	}
+}
+
+// Audit records a request in the audit log if it spends from or stops the wallet, along with the address it came from
+// and the error it failed with, if any.
+func (s *Server) Audit(req *btcjson.Request, remoteAddr string, jsonErr *btcjson.RPCError) {
+	if _, ok := RPCAudited[req.Method]; !ok || s.AuditLog == nil {
+		return
+	}
+	entry := &btcjson.AuditLogEntry{
+		Time:   time.Now().Unix(),
+		Server: "wallet",
+		User:   s.Username,
+		Source: remoteAddr,
+		Method: req.Method,
+		Params: req.Params,
+	}
+	if jsonErr != nil {
+		entry.Error = jsonErr.Error()
+	}
+	s.AuditLog.Record(entry)
 }
 
 // SetChainServer sets the chain server client component needed to run a fully functional bitcoin wallet RPC server.
@@ -404,6 +649,7 @@ out:
 					break out
 				}
 				wsc.authenticated = true
+				s.AddNotifyClient(wsc)
 				resp := MakeResponse(req.ID, nil, nil)
 				// Expected to never fail.
 				mResp, err := js.Marshal(resp)
@@ -424,6 +670,7 @@ out:
 			}
 			switch req.Method {
 			case "stop":
+				s.Audit(&req, wsc.remoteAddr, nil)
 				resp := MakeResponse(req.ID,
 					"wallet stopping.", nil)
 				mResp, err := js.Marshal(resp)
@@ -440,6 +687,7 @@ out:
 				s.RequestProcessShutdown()
 				// break
 			case "restart":
+				s.Audit(&req, wsc.remoteAddr, nil)
 				resp := MakeResponse(req.ID,
 					"wallet restarting.", nil)
 				mResp, err := js.Marshal(resp)
@@ -462,7 +710,8 @@ out:
 				wsc.wg.Add(1)
 				go func() {
 					resp, jsonErr := f()
-					mResp, err := btcjson.MarshalResponse(req.ID, resp, jsonErr)
+					s.Audit(&req, wsc.remoteAddr, jsonErr)
+					mResp, err := s.MarshalResponse(req.ID, resp, jsonErr)
 					if err != nil {
 						Error(err)
 						Error(
@@ -477,6 +726,8 @@ out:
 			break out
 		}
 	}
+	// no notifications may be started once the client is removed, so the wait below also covers them
+	s.RemoveNotifyClient(wsc)
 	// allow client to disconnect after all handler goroutines are done
 	wsc.wg.Wait()
 	close(wsc.responses)
@@ -534,6 +785,9 @@ func (s *Server) WebsocketClientRPC(wsc *WebsocketClient) {
 	// prevent a hang during shutdown where the goroutine is blocked on a read of the websocket connection if the client
 	// is still connected.
 	go s.WebsocketClientRead(wsc)
+	if wsc.authenticated {
+		s.AddNotifyClient(wsc)
+	}
 	s.WG.Add(2)
 	go s.WebsocketClientRespond(wsc)
 	go s.WebsocketClientSend(wsc)
@@ -598,8 +852,9 @@ func (s *Server) POSTClientRPC(w http.ResponseWriter, r *http.Request) {
 	default:
 		res, jsonErr = s.HandlerClosure(&req)()
 	}
+	s.Audit(&req, r.RemoteAddr, jsonErr)
 	// Marshal and send.
-	mResp, err := btcjson.MarshalResponse(req.ID, res, jsonErr)
+	mResp, err := s.MarshalResponse(req.ID, res, jsonErr)
 	if err != nil {
 		Error(err)
 		Error(
@@ -0,0 +1,101 @@
+package legacy
+
+import (
+	"strings"
+
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+	"github.com/p9c/pod/pkg/wallet"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// CreateSigningDevicePSBT handles a createsigningdevicepsbt request by creating an unsigned transaction spending from an
+// account paired with a signing device, which the device signs before it is published with publishpsbt.
+func CreateSigningDevicePSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{},
+	error) {
+	cmd, ok := icmd.(*btcjson.CreateSigningDevicePSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["createsigningdevicepsbt"],
+		}
+	}
+	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, *cmd.FromAccount)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	minConf := int32(*cmd.MinConf)
+	if minConf < 0 {
+		return nil, ErrNeedPositiveMinconf
+	}
+	pairs := make(map[string]util.Amount, len(cmd.Amounts))
+	for k, v := range cmd.Amounts {
+		amt, err := util.NewAmount(v)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		if amt <= 0 {
+			return nil, ErrNeedPositiveAmount
+		}
+		pairs[k] = amt
+	}
+	feeRate, err := FeeRateParam(cmd.FeeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	outputs, err := MakeOutputs(pairs, w.ChainParams())
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	device, err := w.PairedSigningDevice(account)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	p, fee, err := w.CreateSigningDevicePSBT(account, outputs, minConf, feeRate)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	b64, err := p.B64Encode()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return btcjson.SigningDevicePSBTResult{
+		TxID:          p.UnsignedTx.TxHash().String(),
+		PSBT:          b64,
+		Fee:           fee.ToDUO(),
+		SigningDevice: device.Model,
+		Fingerprint:   device.FingerprintString(),
+	}, nil
+}
+
+// PublishPSBT handles a publishpsbt request by finalizing a partially signed transaction whose inputs have all been
+// signed and broadcasting it.
+func PublishPSBT(icmd interface{}, w *wallet.Wallet, chainClient ...*chain.RPCClient) (interface{}, error) {
+	cmd, ok := icmd.(*btcjson.PublishPSBTCmd)
+	if !ok {
+		return nil, &btcjson.RPCError{
+			Code:    btcjson.ErrRPCInvalidParameter,
+			Message: HelpDescsEnUS()["publishpsbt"],
+		}
+	}
+	p, err := psbt.NewFromRawBytes(strings.NewReader(cmd.PSBT), true)
+	if err != nil {
+		Error(err)
+		return nil, DeserializationError{err}
+	}
+	txHash, err := w.PublishPSBT(p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return txHash.String(), nil
+}
@@ -0,0 +1,223 @@
+package stratum
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// ExtraNonce1Size is the size of the extra nonce the server gives each connection
+	ExtraNonce1Size = 4
+	// ExtraNonce2Size is the size of the extra nonce each miner rolls
+	ExtraNonce2Size = 4
+	// MaxNTimeRoll is how far past the current time miners may roll the timestamp of a job
+	MaxNTimeRoll = 10 * time.Minute
+)
+
+// Share errors are sent to the miner in the error of a mining.submit reply
+var (
+	ErrJobNotFound   = errors.New("job not found")
+	ErrDuplicate     = errors.New("duplicate share")
+	ErrLowDifficulty = errors.New("low difficulty share")
+	ErrBadNTime      = errors.New("ntime out of range")
+)
+
+// job is a block template split up for mining.notify, with the coinbase broken in two around the extra nonces.
+type job struct {
+	id     string
+	algo   string
+	height int32
+	block  *wire.MsgBlock
+	// prefix and suffix are the coinbase signature script before and after the extra nonces
+	prefix, suffix []byte
+	coinb1, coinb2 []byte
+	branch         []chainhash.Hash
+	// shares are the shares submitted for the job, to reject duplicates
+	shares map[string]struct{}
+}
+
+// newJob splits a block template into a job. The signature script of the coinbase is the block height, the extra
+// nonces as a single push and the coinbase flags followed by the coinbase data, so the coinbase serialized without
+// witness data can be cut around the extra nonces.
+func newJob(id string, tmpl *mining.BlockTemplate, coinbaseData []byte) (j *job, err error) {
+	j = &job{
+		id:     id,
+		height: tmpl.Height,
+		block:  tmpl.Block,
+		shares: make(map[string]struct{}),
+	}
+	j.algo = fork.GetAlgoName(j.block.Header.Version, j.height)
+	if j.prefix, err = txscript.NewScriptBuilder().AddInt64(int64(j.height)).Script(); err != nil {
+		return nil, err
+	}
+	j.prefix = append(j.prefix, txscript.OP_DATA_1+ExtraNonce1Size+ExtraNonce2Size-1)
+	if j.suffix, err = mining.CoinbaseFlagsScript(coinbaseData); err != nil {
+		return nil, err
+	}
+	script := j.sigScript(make([]byte, ExtraNonce1Size), make([]byte, ExtraNonce2Size))
+	if len(script) > blockchain.MaxCoinbaseScriptLen {
+		return nil, fmt.Errorf("coinbase script length of %d is over %d", len(script),
+			blockchain.MaxCoinbaseScriptLen)
+	}
+	coinbase := j.block.Transactions[0].Copy()
+	coinbase.TxIn[0].SignatureScript = script
+	var buf bytes.Buffer
+	if err = coinbase.SerializeNoWitness(&buf); err != nil {
+		return nil, err
+	}
+	// The version, input count and outpoint of the coinbase come before its signature script.
+	offset := 4 + wire.VarIntSerializeSize(1) + chainhash.HashSize + 4 +
+		wire.VarIntSerializeSize(uint64(len(script))) + len(j.prefix)
+	b := buf.Bytes()
+	j.coinb1 = b[:offset]
+	j.coinb2 = b[offset+ExtraNonce1Size+ExtraNonce2Size:]
+	hashes := make([]*chainhash.Hash, len(j.block.Transactions))
+	hashes[0] = &chainhash.Hash{}
+	for i, tx := range j.block.Transactions[1:] {
+		hash := tx.TxHash()
+		hashes[i+1] = &hash
+	}
+	j.branch = merkleBranch(hashes)
+	return j, nil
+}
+
+// merkleBranch returns the hashes the coinbase hash is combined with in turn to get the merkle root of the
+// transactions. The first hash, of the coinbase, is only a placeholder.
+func merkleBranch(hashes []*chainhash.Hash) (branch []chainhash.Hash) {
+	for len(hashes) > 1 {
+		branch = append(branch, *hashes[1])
+		next := make([]*chainhash.Hash, 0, (len(hashes)+1)/2)
+		for i := 0; i < len(hashes); i += 2 {
+			right := hashes[i]
+			if i+1 < len(hashes) {
+				right = hashes[i+1]
+			}
+			next = append(next, blockchain.HashMerkleBranches(hashes[i], right))
+		}
+		hashes = next
+	}
+	return
+}
+
+// sigScript returns the coinbase signature script with the extra nonces.
+func (j *job) sigScript(extraNonce1, extraNonce2 []byte) []byte {
+	script := make([]byte, 0, len(j.prefix)+len(extraNonce1)+len(extraNonce2)+len(j.suffix))
+	script = append(script, j.prefix...)
+	script = append(script, extraNonce1...)
+	script = append(script, extraNonce2...)
+	return append(script, j.suffix...)
+}
+
+// notifyParams returns the params of the mining.notify for the job.
+func (j *job) notifyParams(clean bool) []interface{} {
+	branch := make([]string, len(j.branch))
+	for i := range j.branch {
+		branch[i] = hex.EncodeToString(j.branch[i][:])
+	}
+	h := &j.block.Header
+	return []interface{}{
+		j.id,
+		hex.EncodeToString(swapWords(h.PrevBlock[:])),
+		hex.EncodeToString(j.coinb1),
+		hex.EncodeToString(j.coinb2),
+		branch,
+		uint32Hex(uint32(h.Version)),
+		uint32Hex(h.Bits),
+		uint32Hex(uint32(h.Timestamp.Unix())),
+		clean,
+	}
+}
+
+// solve returns the block of the job with the extra nonces, timestamp and nonce of a share.
+func (j *job) solve(extraNonce1, extraNonce2 []byte, nTime, nonce uint32) *wire.MsgBlock {
+	coinbase := j.block.Transactions[0].Copy()
+	coinbase.TxIn[0].SignatureScript = j.sigScript(extraNonce1, extraNonce2)
+	root := coinbase.TxHash()
+	for i := range j.branch {
+		root = *blockchain.HashMerkleBranches(&root, &j.branch[i])
+	}
+	block := &wire.MsgBlock{
+		Header:       j.block.Header,
+		Transactions: make([]*wire.MsgTx, len(j.block.Transactions)),
+	}
+	copy(block.Transactions, j.block.Transactions)
+	block.Transactions[0] = coinbase
+	block.Header.MerkleRoot = root
+	block.Header.Timestamp = time.Unix(int64(nTime), 0)
+	block.Header.Nonce = nonce
+	return block
+}
+
+// check checks a share against the share difficulty and returns its block, and whether it also meets the target of
+// the block.
+func (j *job) check(extraNonce1, extraNonce2 []byte, nTime, nonce uint32, difficulty float64) (
+	block *wire.MsgBlock, isBlock bool, err error) {
+	if nTime < uint32(j.block.Header.Timestamp.Unix()) || int64(nTime) > time.Now().Add(MaxNTimeRoll).Unix() {
+		return nil, false, ErrBadNTime
+	}
+	key := hex.EncodeToString(extraNonce1) + hex.EncodeToString(extraNonce2) + uint32Hex(nTime) + uint32Hex(nonce)
+	if _, ok := j.shares[key]; ok {
+		return nil, false, ErrDuplicate
+	}
+	j.shares[key] = struct{}{}
+	block = j.solve(extraNonce1, extraNonce2, nTime, nonce)
+	hash := block.Header.BlockHashWithAlgos(j.height)
+	hashNum := blockchain.HashToBig(&hash)
+	if hashNum.Cmp(ShareTarget(j.algo, j.height, difficulty)) > 0 {
+		return nil, false, ErrLowDifficulty
+	}
+	return block, hashNum.Cmp(fork.CompactToBig(block.Header.Bits)) <= 0, nil
+}
+
+// ShareTarget returns the target of shares of the given difficulty, the proof of work limit of the algorithm divided
+// by the difficulty.
+func ShareTarget(algo string, height int32, difficulty float64) *big.Int {
+	limit := new(big.Float).SetInt(fork.GetMinDiff(algo, height))
+	target, _ := limit.Quo(limit, big.NewFloat(difficulty)).Int(nil)
+	return target
+}
+
+// swapWords reverses the bytes of every 4 byte word, which is how stratum sends the previous block hash.
+func swapWords(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := 0; i+4 <= len(b); i += 4 {
+		out[i], out[i+1], out[i+2], out[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+	}
+	return out
+}
+
+// uint32Hex encodes a number as stratum does, in big endian hex.
+func uint32Hex(n uint32) string {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], n)
+	return hex.EncodeToString(b[:])
+}
+
+// parseUint32Hex decodes a number sent by a miner in big endian hex.
+func parseUint32Hex(s string) (uint32, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 4 {
+		return 0, fmt.Errorf("'%s' is not 8 hex digits", s)
+	}
+	return binary.BigEndian.Uint32(b), nil
+}
+
+// newBlock wraps a solved block for submission.
+func newBlock(block *wire.MsgBlock, height int32) *util.Block {
+	b := util.NewBlock(block)
+	b.SetHeight(height)
+	return b
+}
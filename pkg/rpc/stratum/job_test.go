@@ -0,0 +1,98 @@
+package stratum
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// testTemplate returns a block template with a coinbase and two other transactions.
+func testTemplate() *mining.BlockTemplate {
+	block := &wire.MsgBlock{Header: wire.BlockHeader{
+		Version:   2,
+		PrevBlock: chainhash.Hash{1, 2, 3, 4, 5},
+		Timestamp: time.Unix(time.Now().Unix(), 0),
+		Bits:      0x1e0fffff,
+	}}
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), []byte{1, 100}, nil))
+	coinbase.AddTxOut(wire.NewTxOut(5000, []byte{0x51}))
+	block.AddTransaction(coinbase)
+	for i := byte(0); i < 2; i++ {
+		tx := wire.NewMsgTx(wire.TxVersion)
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{i + 10}, 0), nil, nil))
+		tx.AddTxOut(wire.NewTxOut(1000, []byte{0x51}))
+		block.AddTransaction(tx)
+	}
+	return &mining.BlockTemplate{Block: block, Height: 100}
+}
+
+// TestJob checks that the coinbase of a job put back together around the extra nonces is a valid transaction, and
+// that the merkle root of a solved block is the one of its transactions.
+func TestJob(t *testing.T) {
+	j, err := newJob("1", testTemplate(), []byte("/pool tag/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	extraNonce1, extraNonce2 := []byte{1, 2, 3, 4}, []byte{5, 6, 7, 8}
+	raw := append(append(append(append([]byte{}, j.coinb1...), extraNonce1...), extraNonce2...), j.coinb2...)
+	var coinbase wire.MsgTx
+	if err = coinbase.Deserialize(bytes.NewReader(raw)); err != nil {
+		t.Fatal("coinbase put back together doesn't deserialize:", err)
+	}
+	if script := j.sigScript(extraNonce1, extraNonce2); !bytes.Equal(coinbase.TxIn[0].SignatureScript, script) {
+		t.Errorf("got signature script %x, want %x", coinbase.TxIn[0].SignatureScript, script)
+	}
+	if !bytes.HasSuffix(coinbase.TxIn[0].SignatureScript, []byte("/pool tag/")) {
+		t.Errorf("signature script %x doesn't end with the coinbase data", coinbase.TxIn[0].SignatureScript)
+	}
+	block := j.solve(extraNonce1, extraNonce2, uint32(time.Now().Unix()), 42)
+	merkles := blockchain.BuildMerkleTreeStore(util.NewBlock(block).Transactions(), false)
+	if !block.Header.MerkleRoot.IsEqual(merkles[len(merkles)-1]) {
+		t.Errorf("got merkle root %v from the branch, want %v", block.Header.MerkleRoot, merkles[len(merkles)-1])
+	}
+	if block.Transactions[0].TxHash() != coinbase.TxHash() {
+		t.Error("coinbase of the solved block is not the one put together by the miner")
+	}
+	params := j.notifyParams(true)
+	if prevHash := params[1].(string); prevHash[:8] != "04030201" {
+		t.Errorf("got previous block hash %s, want its words byte swapped", prevHash)
+	}
+	if version := params[5].(string); version != "00000002" {
+		t.Errorf("got version %s, want 00000002", version)
+	}
+	txHash := j.block.Transactions[1].TxHash()
+	if branch := params[4].([]string); len(branch) != 2 || branch[0] != hex.EncodeToString(txHash[:]) {
+		t.Errorf("got merkle branch %v", branch)
+	}
+}
+
+// TestCheck checks that shares are checked against the difficulty, the timestamp of the job and the shares already
+// submitted.
+func TestCheck(t *testing.T) {
+	j, err := newJob("1", testTemplate(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	extraNonce1, extraNonce2 := []byte{1, 2, 3, 4}, []byte{5, 6, 7, 8}
+	nTime := uint32(j.block.Header.Timestamp.Unix())
+	if _, _, err = j.check(extraNonce1, extraNonce2, nTime-1, 1, 1e-70); err != ErrBadNTime {
+		t.Errorf("got %v for an ntime before the job, want %v", err, ErrBadNTime)
+	}
+	if _, _, err = j.check(extraNonce1, extraNonce2, nTime, 1, 1e-70); err != nil {
+		t.Errorf("got %v for a share of the lowest difficulty", err)
+	}
+	if _, _, err = j.check(extraNonce1, extraNonce2, nTime, 1, 1e-70); err != ErrDuplicate {
+		t.Errorf("got %v for a share submitted twice, want %v", err, ErrDuplicate)
+	}
+	if _, _, err = j.check(extraNonce1, extraNonce2, nTime, 2, 1e70); err != ErrLowDifficulty {
+		t.Errorf("got %v for a share of the highest difficulty, want %v", err, ErrLowDifficulty)
+	}
+}
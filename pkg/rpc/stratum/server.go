@@ -0,0 +1,511 @@
+// Package stratum is a stratum v1 mining server, so standard miners can mine on the node with mining.subscribe,
+// mining.authorize, mining.notify and mining.submit, with the share difficulty of each connection adjusted to how
+// fast it finds shares. Each server serves one algorithm, the same as each RPC port does for getwork.
+package stratum
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/fork"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/mining"
+	"github.com/p9c/pod/pkg/chain/mining/payout"
+	"github.com/p9c/pod/pkg/util"
+)
+
+const (
+	// MaxJobs is how many of the latest jobs shares are accepted for
+	MaxJobs = 16
+	// StaleTemplateAge is how long a block template is kept while new transactions arrive in the mempool
+	StaleTemplateAge = time.Minute
+	// writeTimeout is how long a miner is given to read a message before it is disconnected
+	writeTimeout = 30 * time.Second
+	// maxLineSize is the size of the longest request a miner can send
+	maxLineSize = 16 * 1024
+	// maxRejectedShares is how many shares in a row a miner can have rejected before it is disconnected
+	maxRejectedShares = 100
+)
+
+// Config is the configuration of a stratum server.
+type Config struct {
+	// Algo is the algorithm the server hands out work for
+	Algo string
+	// Generator creates the block templates that jobs are made from
+	Generator *mining.BlkTmplGenerator
+	// PayToAddrs are the addresses block rewards are paid to, one picked by the Payout for each template
+	PayToAddrs []util.Address
+	// Payout picks which address each template pays to, at random if it is nil
+	Payout *payout.Payout
+	// ProcessBlock submits a solved block to the chain
+	ProcessBlock func(block *util.Block) (isOrphan bool, err error)
+	// IsCurrent returns whether the chain is synced, work is only handed out when it is
+	IsCurrent func() bool
+	// Difficulty is the share difficulty connections start with, and the lowest it is adjusted to
+	Difficulty float64
+}
+
+// ParseListener splits a stratum listener of the form algo=address and checks that the algorithm is known.
+func ParseListener(listener string) (algo, address string, err error) {
+	split := strings.SplitN(listener, "=", 2)
+	if len(split) != 2 || split[1] == "" {
+		return "", "", fmt.Errorf("stratum listener '%s' is not of the form algo=address", listener)
+	}
+	algo, address = split[0], split[1]
+	for i := range fork.List {
+		if _, ok := fork.List[i].Algos[algo]; ok {
+			return algo, address, nil
+		}
+	}
+	return "", "", fmt.Errorf("stratum listener '%s' has an unknown algorithm", listener)
+}
+
+// Server is a stratum server handing out work for one algorithm to the miners connected to its listener.
+type Server struct {
+	cfg      *Config
+	listener net.Listener
+	mx       sync.Mutex
+	jobs     map[string]*job
+	jobOrder []string
+	current  *job
+	conns    map[*conn]struct{}
+	// jobID and extraNonce1 are counters for the ids of jobs and the extra nonces of connections
+	jobID       uint64
+	extraNonce1 uint32
+	// prevHash, lastTxUpdate and lastGenerated are the chain tip, mempool update time and creation time of the current
+	// job, to tell when it is stale
+	prevHash      chainhash.Hash
+	lastTxUpdate  time.Time
+	lastGenerated time.Time
+	quit          chan struct{}
+	wg            sync.WaitGroup
+}
+
+// New returns a stratum server that accepts miners from the listener once it is started.
+func New(cfg *Config, listener net.Listener) *Server {
+	if cfg.Difficulty <= 0 {
+		cfg.Difficulty = 1
+	}
+	return &Server{
+		cfg:      cfg,
+		listener: listener,
+		jobs:     make(map[string]*job),
+		conns:    make(map[*conn]struct{}),
+		quit:     make(chan struct{}),
+	}
+}
+
+// Start accepts miners and keeps their work up to date with the chain and the mempool.
+func (s *Server) Start() {
+	Infof("stratum server for %s listening on %s", s.cfg.Algo, s.listener.Addr())
+	s.wg.Add(2)
+	go s.accept()
+	go s.refresh()
+}
+
+// Stop closes the listener and the connections of the miners and waits for them to finish.
+func (s *Server) Stop() {
+	select {
+	case <-s.quit:
+		return
+	default:
+	}
+	close(s.quit)
+	if err := s.listener.Close(); Check(err) {
+	}
+	s.mx.Lock()
+	for c := range s.conns {
+		c.close()
+	}
+	s.mx.Unlock()
+	s.wg.Wait()
+}
+
+// accept starts a connection for every miner that connects to the listener.
+func (s *Server) accept() {
+	defer s.wg.Done()
+	for {
+		netConn, err := s.listener.Accept()
+		if err != nil {
+			select {
+			case <-s.quit:
+			default:
+				Error("stratum listener failed:", err)
+			}
+			return
+		}
+		c := &conn{
+			server:     s,
+			conn:       netConn,
+			extraNonce: make([]byte, ExtraNonce1Size),
+			difficulty: s.cfg.Difficulty,
+			vardiff:    time.Now(),
+		}
+		binary.BigEndian.PutUint32(c.extraNonce, atomic.AddUint32(&s.extraNonce1, 1))
+		c.jobDifficulty = c.difficulty
+		s.mx.Lock()
+		s.conns[c] = struct{}{}
+		s.mx.Unlock()
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			c.serve()
+			s.mx.Lock()
+			delete(s.conns, c)
+			s.mx.Unlock()
+		}()
+	}
+}
+
+// refresh makes a new job when a block is connected to the chain, or when new transactions have been waiting in the
+// mempool for StaleTemplateAge, and sends it to the miners.
+func (s *Server) refresh() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !s.cfg.IsCurrent() {
+				break
+			}
+			best := s.cfg.Generator.BestSnapshot()
+			s.mx.Lock()
+			newBlock := !best.Hash.IsEqual(&s.prevHash)
+			stale := s.cfg.Generator.GetTxSource().LastUpdated() != s.lastTxUpdate &&
+				time.Since(s.lastGenerated) > StaleTemplateAge
+			s.mx.Unlock()
+			if newBlock || stale {
+				if err := s.newJob(newBlock); err != nil {
+					Error("failed to make stratum job:", err)
+				}
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// newJob makes a job from a new block template and sends it to every subscribed miner. When the job is for a new
+// block the earlier jobs are dropped and the miners are told to abandon them.
+func (s *Server) newJob(clean bool) error {
+	lastTxUpdate := s.cfg.Generator.GetTxSource().LastUpdated()
+	payTo, err := s.cfg.Payout.Address(s.cfg.PayToAddrs, s.cfg.Algo, s.cfg.Generator.BestSnapshot().Height+1)
+	if err != nil {
+		return err
+	}
+	tmpl, err := s.cfg.Generator.NewBlockTemplate(0, payTo, s.cfg.Algo)
+	if err != nil {
+		return err
+	}
+	s.mx.Lock()
+	s.jobID++
+	j, err := newJob(strconv.FormatUint(s.jobID, 16), tmpl, s.cfg.Generator.CoinbaseData)
+	if err != nil {
+		s.mx.Unlock()
+		return err
+	}
+	if clean {
+		s.jobs = make(map[string]*job)
+		s.jobOrder = nil
+	}
+	s.jobs[j.id] = j
+	s.jobOrder = append(s.jobOrder, j.id)
+	if len(s.jobOrder) > MaxJobs {
+		delete(s.jobs, s.jobOrder[0])
+		s.jobOrder = s.jobOrder[1:]
+	}
+	s.current = j
+	s.prevHash = tmpl.Block.Header.PrevBlock
+	s.lastTxUpdate = lastTxUpdate
+	s.lastGenerated = time.Now()
+	conns := make([]*conn, 0, len(s.conns))
+	for c := range s.conns {
+		conns = append(conns, c)
+	}
+	s.mx.Unlock()
+	Debug("new stratum job", j.id, "for", j.algo, "at height", j.height)
+	for _, c := range conns {
+		c.notify(j, clean)
+	}
+	return nil
+}
+
+// submit checks a share from a connection and submits the block if it solves one.
+func (s *Server) submit(c *conn, jobID string, extraNonce2 []byte, nTime, nonce uint32, difficulty float64) error {
+	s.mx.Lock()
+	j, ok := s.jobs[jobID]
+	if !ok {
+		s.mx.Unlock()
+		return ErrJobNotFound
+	}
+	block, isBlock, err := j.check(c.extraNonce, extraNonce2, nTime, nonce, difficulty)
+	s.mx.Unlock()
+	if err != nil || !isBlock {
+		return err
+	}
+	hash := block.Header.BlockHash()
+	isOrphan, err := s.cfg.ProcessBlock(newBlock(block, j.height))
+	if err != nil {
+		Warn("block", hash, "from stratum miner", c.worker, "rejected:", err)
+		return nil
+	}
+	if isOrphan {
+		Warn("block", hash, "from stratum miner", c.worker, "is an orphan")
+		return nil
+	}
+	Info("stratum miner", c.worker, "found block", hash, "at height", j.height)
+	return nil
+}
+
+// request is a stratum request from a miner.
+type request struct {
+	ID     interface{}     `json:"id"`
+	Method string          `json:"method"`
+	Params []js.RawMessage `json:"params"`
+}
+
+// response is the reply to a request, with the error as the code, message and traceback stratum uses.
+type response struct {
+	ID     interface{} `json:"id"`
+	Result interface{} `json:"result"`
+	Error  interface{} `json:"error"`
+}
+
+// notification is a message the server sends to a miner unrequested.
+type notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// Stratum error codes
+const (
+	ErrCodeOther         = 20
+	ErrCodeJobNotFound   = 21
+	ErrCodeDuplicate     = 22
+	ErrCodeLowDifficulty = 23
+	ErrCodeUnauthorized  = 24
+	ErrCodeNotSubscribed = 25
+)
+
+// stratumError returns the error of a reply in the form stratum uses.
+func stratumError(err error) []interface{} {
+	code := ErrCodeOther
+	switch err {
+	case ErrJobNotFound:
+		code = ErrCodeJobNotFound
+	case ErrDuplicate:
+		code = ErrCodeDuplicate
+	case ErrLowDifficulty:
+		code = ErrCodeLowDifficulty
+	case errUnauthorized:
+		code = ErrCodeUnauthorized
+	case errNotSubscribed:
+		code = ErrCodeNotSubscribed
+	}
+	return []interface{}{code, err.Error(), nil}
+}
+
+var (
+	errUnauthorized  = errors.New("unauthorized worker")
+	errNotSubscribed = errors.New("not subscribed")
+)
+
+// conn is the connection of a miner.
+type conn struct {
+	server     *Server
+	conn       net.Conn
+	writeMx    sync.Mutex
+	extraNonce []byte
+	subscribed bool
+	rejected   int
+	shares     int
+	vardiff    time.Time
+	// mx guards the fields notify reads while serve changes them
+	mx         sync.Mutex
+	worker     string
+	difficulty float64
+	// jobDifficulty is the difficulty when the latest job was sent, which shares are still accepted at after the
+	// difficulty goes up until the next job
+	jobDifficulty float64
+}
+
+// serve handles the requests of the miner until it disconnects.
+func (c *conn) serve() {
+	defer c.close()
+	Debug("stratum miner connected from", c.conn.RemoteAddr())
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 0, 1024), maxLineSize)
+	for scanner.Scan() {
+		var req request
+		if err := js.Unmarshal(scanner.Bytes(), &req); err != nil {
+			Debug("malformed stratum request from", c.conn.RemoteAddr(), err)
+			return
+		}
+		result, err := c.handle(&req)
+		res := &response{ID: req.ID, Result: result}
+		if err != nil {
+			res.Error = stratumError(err)
+		}
+		if c.write(res) != nil {
+			return
+		}
+		if req.Method == "mining.submit" {
+			if err != nil {
+				if c.rejected++; c.rejected >= maxRejectedShares {
+					Warn("disconnecting stratum miner", c.worker, "after", c.rejected, "rejected shares")
+					return
+				}
+			} else {
+				c.rejected = 0
+				c.shares++
+				c.adjustDifficulty()
+			}
+		}
+		if req.Method == "mining.authorize" && err == nil {
+			c.setDifficulty()
+			c.server.mx.Lock()
+			j := c.server.current
+			c.server.mx.Unlock()
+			if j != nil {
+				c.notify(j, true)
+			}
+		}
+	}
+	Debug("stratum miner", c.worker, "disconnected from", c.conn.RemoteAddr())
+}
+
+// handle handles a request and returns the result of the reply.
+func (c *conn) handle(req *request) (interface{}, error) {
+	params := make([]string, len(req.Params))
+	for i := range req.Params {
+		// Parameters that are not strings, such as the list of versions some miners send, are ignored.
+		_ = js.Unmarshal(req.Params[i], &params[i])
+	}
+	switch req.Method {
+	case "mining.subscribe":
+		c.subscribed = true
+		subscription := hex.EncodeToString(c.extraNonce)
+		return []interface{}{
+			[][]string{{"mining.set_difficulty", subscription}, {"mining.notify", subscription}},
+			hex.EncodeToString(c.extraNonce),
+			ExtraNonce2Size,
+		}, nil
+	case "mining.authorize":
+		if !c.subscribed {
+			return nil, errNotSubscribed
+		}
+		if len(params) < 1 || params[0] == "" {
+			return false, errUnauthorized
+		}
+		c.mx.Lock()
+		c.worker = params[0]
+		c.mx.Unlock()
+		Info("stratum miner", c.worker, "authorized from", c.conn.RemoteAddr())
+		return true, nil
+	case "mining.extranonce.subscribe":
+		return false, nil
+	case "mining.submit":
+		if c.worker == "" {
+			return nil, errUnauthorized
+		}
+		if len(params) < 5 {
+			return nil, errors.New("mining.submit needs worker, job id, extranonce2, ntime and nonce")
+		}
+		extraNonce2, err := hex.DecodeString(params[2])
+		if err != nil || len(extraNonce2) != ExtraNonce2Size {
+			return nil, fmt.Errorf("extranonce2 '%s' is not %d bytes of hex", params[2], ExtraNonce2Size)
+		}
+		nTime, err := parseUint32Hex(params[3])
+		if err != nil {
+			return nil, err
+		}
+		nonce, err := parseUint32Hex(params[4])
+		if err != nil {
+			return nil, err
+		}
+		difficulty := c.difficulty
+		c.mx.Lock()
+		if c.jobDifficulty < difficulty {
+			difficulty = c.jobDifficulty
+		}
+		c.mx.Unlock()
+		if err = c.server.submit(c, params[1], extraNonce2, nTime, nonce, difficulty); err != nil {
+			return nil, err
+		}
+		return true, nil
+	}
+	return nil, fmt.Errorf("unknown method '%s'", req.Method)
+}
+
+// adjustDifficulty changes the share difficulty when enough time has passed and tells the miner.
+func (c *conn) adjustDifficulty() {
+	elapsed := time.Since(c.vardiff)
+	if c.shares < VardiffShares && elapsed < VardiffInterval {
+		return
+	}
+	difficulty := Retarget(c.difficulty, c.server.cfg.Difficulty, c.shares, elapsed)
+	c.shares = 0
+	c.vardiff = time.Now()
+	if difficulty == c.difficulty {
+		return
+	}
+	Debugf("stratum miner %s difficulty %g -> %g", c.worker, c.difficulty, difficulty)
+	c.mx.Lock()
+	c.difficulty = difficulty
+	c.mx.Unlock()
+	c.setDifficulty()
+}
+
+// setDifficulty sends the share difficulty to the miner.
+func (c *conn) setDifficulty() {
+	if err := c.write(&notification{Method: "mining.set_difficulty", Params: []interface{}{c.difficulty}}); Check(err) {
+	}
+}
+
+// notify sends a job to the miner if it is subscribed.
+func (c *conn) notify(j *job, clean bool) {
+	c.mx.Lock()
+	worker := c.worker
+	c.jobDifficulty = c.difficulty
+	c.mx.Unlock()
+	if worker == "" {
+		return
+	}
+	if err := c.write(&notification{Method: "mining.notify", Params: j.notifyParams(clean)}); err != nil {
+		Debug("failed to send stratum job to", worker, err)
+	}
+}
+
+// write sends a message to the miner as a line of JSON.
+func (c *conn) write(msg interface{}) error {
+	b, err := js.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	c.writeMx.Lock()
+	defer c.writeMx.Unlock()
+	if err = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	_, err = c.conn.Write(append(b, '\n'))
+	return err
+}
+
+// close closes the connection of the miner.
+func (c *conn) close() {
+	if err := c.conn.Close(); err != nil {
+		Trace(err)
+	}
+}
@@ -0,0 +1,61 @@
+package stratum
+
+import (
+	"bufio"
+	js "encoding/json"
+	"net"
+	"testing"
+)
+
+func TestParseListener(t *testing.T) {
+	if algo, address, err := ParseListener("sha256d=127.0.0.1:11048"); err != nil || algo != "sha256d" ||
+		address != "127.0.0.1:11048" {
+		t.Errorf("got %s, %s, %v", algo, address, err)
+	}
+	for _, listener := range []string{"127.0.0.1:11048", "sha256d=", "nosuchalgo=127.0.0.1:11048"} {
+		if _, _, err := ParseListener(listener); err == nil {
+			t.Errorf("'%s' parsed without an error", listener)
+		}
+	}
+}
+
+// TestConn runs a miner through subscribing, authorizing and submitting a share for a job that doesn't exist.
+func TestConn(t *testing.T) {
+	server := New(&Config{Algo: "sha256d"}, nil)
+	client, serverConn := net.Pipe()
+	defer client.Close()
+	c := &conn{server: server, conn: serverConn, extraNonce: []byte{0, 0, 0, 1}, difficulty: 1}
+	go c.serve()
+	lines := bufio.NewScanner(client)
+	call := func(request string) (msg map[string]interface{}) {
+		if _, err := client.Write([]byte(request + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		if !lines.Scan() {
+			t.Fatal("no reply to", request)
+		}
+		if err := js.Unmarshal(lines.Bytes(), &msg); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	res := call(`{"id":1,"method":"mining.subscribe","params":["test/1.0"]}`)
+	result, ok := res["result"].([]interface{})
+	if !ok || len(result) != 3 || result[1] != "00000001" || result[2] != float64(ExtraNonce2Size) {
+		t.Fatalf("got subscribe reply %v", res)
+	}
+	if res = call(`{"id":2,"method":"mining.authorize","params":["worker","x"]}`); res["result"] != true {
+		t.Fatalf("got authorize reply %v", res)
+	}
+	if !lines.Scan() {
+		t.Fatal("no difficulty after authorizing")
+	}
+	var msg map[string]interface{}
+	if err := js.Unmarshal(lines.Bytes(), &msg); err != nil || msg["method"] != "mining.set_difficulty" {
+		t.Fatalf("got %s after authorizing, want the difficulty", lines.Text())
+	}
+	res = call(`{"id":3,"method":"mining.submit","params":["worker","ff","00000000","5f000000","00000000"]}`)
+	if e, ok := res["error"].([]interface{}); !ok || e[0] != float64(ErrCodeJobNotFound) {
+		t.Errorf("got submit reply %v, want job not found", res)
+	}
+}
@@ -0,0 +1,42 @@
+package stratum
+
+import (
+	"time"
+)
+
+const (
+	// ShareInterval is how often each miner is aimed to find a share
+	ShareInterval = 10 * time.Second
+	// VardiffInterval is how long shares are counted for before the difficulty is adjusted
+	VardiffInterval = 2 * time.Minute
+	// VardiffShares is how many shares are counted before the difficulty is adjusted, if they come sooner than
+	// VardiffInterval
+	VardiffShares = 30
+	// vardiffTolerance is how far the share rate can be off before the difficulty is changed
+	vardiffTolerance = 1.5
+	// vardiffMaxStep is how many times the difficulty can go up or down in one adjustment
+	vardiffMaxStep = 4
+)
+
+// Retarget returns the share difficulty that brings the rate of shares found over the elapsed time to one every
+// ShareInterval, not lower than the minimum and changing by at most vardiffMaxStep times. The difficulty is kept when
+// the rate is within vardiffTolerance of the aim.
+func Retarget(difficulty, minimum float64, shares int, elapsed time.Duration) float64 {
+	ratio := float64(1) / vardiffMaxStep
+	if shares > 0 {
+		ratio = float64(ShareInterval) * float64(shares) / float64(elapsed)
+	}
+	if ratio < vardiffTolerance && ratio > 1/vardiffTolerance {
+		return difficulty
+	}
+	if ratio > vardiffMaxStep {
+		ratio = vardiffMaxStep
+	} else if ratio < 1.0/vardiffMaxStep {
+		ratio = 1.0 / vardiffMaxStep
+	}
+	difficulty *= ratio
+	if difficulty < minimum {
+		difficulty = minimum
+	}
+	return difficulty
+}
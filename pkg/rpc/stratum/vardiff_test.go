@@ -0,0 +1,28 @@
+package stratum
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetarget(t *testing.T) {
+	tests := []struct {
+		name       string
+		difficulty float64
+		shares     int
+		elapsed    time.Duration
+		want       float64
+	}{
+		{"on aim", 8, 12, 2 * time.Minute, 8},
+		{"twice as fast", 8, 24, 2 * time.Minute, 16},
+		{"far too fast", 8, 1000, 2 * time.Minute, 32},
+		{"half as fast", 8, 6, 2 * time.Minute, 4},
+		{"no shares", 8, 0, 2 * time.Minute, 2},
+		{"not below the minimum", 1, 0, 2 * time.Minute, 1},
+	}
+	for _, test := range tests {
+		if got := Retarget(test.difficulty, 1, test.shares, test.elapsed); got != test.want {
+			t.Errorf("%s: got difficulty %g, want %g", test.name, got, test.want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"math"
 	"strconv"
+	"strings"
+	"sync"
 )
 
 // AmountUnit describes a method of converting an Amount to something other than the base unit of a bitcoin. The value
@@ -42,6 +44,70 @@ func (u AmountUnit) String() string {
 	}
 }
 
+// ParseAmountUnit returns the unit named by the string form of one of the recognized units. "uDUO" is also accepted
+// for μDUO and the unit names are case sensitive, as mDUO and MDUO differ only by case.
+func ParseAmountUnit(s string) (AmountUnit, error) {
+	switch s {
+	case "MDUO":
+		return AmountMegaDUO, nil
+	case "kDUO":
+		return AmountKiloDUO, nil
+	case "DUO":
+		return AmountDUO, nil
+	case "mDUO":
+		return AmountMilliDUO, nil
+	case "μDUO", "uDUO":
+		return AmountMicroDUO, nil
+	case "Satoshi":
+		return AmountSatoshi, nil
+	}
+	return AmountDUO, errors.New("unknown amount unit " + s)
+}
+
+// AmountLocale holds the separators used when formatting amounts for a language.
+type AmountLocale struct {
+	// Decimal separates the integer and fractional digits
+	Decimal string
+	// Group separates groups of three integer digits
+	Group string
+}
+
+// AmountLocales are the amount formats of the languages of the user interface, by language code. Languages without an
+// entry are formatted as English.
+var AmountLocales = map[string]AmountLocale{
+	"en": {Decimal: ".", Group: ","},
+	"rs": {Decimal: ",", Group: "."},
+}
+
+// LocaleFor returns the amount format of a language
+func LocaleFor(lang string) AmountLocale {
+	if l, ok := AmountLocales[lang]; ok {
+		return l
+	}
+	return AmountLocales["en"]
+}
+
+var (
+	displayMx     sync.Mutex
+	displayUnit   = AmountDUO
+	displayLocale = AmountLocales["en"]
+)
+
+// SetDisplay sets the unit and the language amounts are shown in by Display, which is configured once from the user's
+// settings and used by everything that shows amounts to the user.
+func SetDisplay(u AmountUnit, lang string) {
+	displayMx.Lock()
+	defer displayMx.Unlock()
+	displayUnit, displayLocale = u, LocaleFor(lang)
+}
+
+// DisplayUnit returns the unit amounts are shown in by Display
+func DisplayUnit() AmountUnit {
+	displayMx.Lock()
+	defer displayMx.Unlock()
+	return displayUnit
+}
+
 // Amount represents the base bitcoin monetary unit (colloquially referred to as a `Satoshi'). A single Amount is equal
 // to 1e-8 of a bitcoin.
 type Amount int64
@@ -98,6 +164,68 @@ func (a Amount) Format(u AmountUnit) string {
 	return strconv.FormatFloat(a.ToUnit(u), 'f', -int(u+8), 64) + units
 }
 
+// FormatLocale formats a monetary amount like Format, but with the separators of the given locale and with all the
+// fractional digits the unit can have, so that amounts line up when shown in a list. Amounts in units larger than the
+// DUO are shown to the precision they need.
+func (a Amount) FormatLocale(u AmountUnit, l AmountLocale) string {
+	units := " " + u.String()
+	var sign string
+	abs := uint64(a)
+	if a < 0 {
+		sign, abs = "-", uint64(-a)
+	}
+	decimals := int(u + 8)
+	if u > AmountDUO || decimals < 0 {
+		return sign + localize(strconv.FormatFloat(Amount(abs).ToUnit(u), 'f', -1, 64), l) + units
+	}
+	// Integer arithmetic keeps every satoshi exact at any magnitude.
+	scale := uint64(math.Pow10(decimals))
+	s := strconv.FormatUint(abs/scale, 10)
+	if decimals > 0 {
+		frac := strconv.FormatUint(abs%scale, 10)
+		s += "." + strings.Repeat("0", decimals-len(frac)) + frac
+	}
+	return sign + localize(s, l) + units
+}
+
+// localize replaces the separators of an unsigned decimal number formatted by strconv with those of a locale and
+// groups the integer digits in threes.
+func localize(s string, l AmountLocale) string {
+	integer, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, frac = s[:i], l.Decimal+s[i+1:]
+	}
+	var b strings.Builder
+	for i := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(l.Group)
+		}
+		b.WriteByte(integer[i])
+	}
+	return b.String() + frac
+}
+
+// ParseAmountLocale parses an amount typed in the given unit with the separators of the given locale, so that amounts
+// are entered the same way they are shown by FormatLocale. A trailing unit name is ignored.
+func ParseAmountLocale(s string, u AmountUnit, l AmountLocale) (Amount, error) {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), u.String()))
+	s = strings.ReplaceAll(s, l.Group, "")
+	s = strings.ReplaceAll(s, l.Decimal, ".")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return NewAmount(f * math.Pow10(int(u)))
+}
+
+// Display formats an amount in the unit and the locale set by SetDisplay, for showing to the user.
+func (a Amount) Display() string {
+	displayMx.Lock()
+	u, l := displayUnit, displayLocale
+	displayMx.Unlock()
+	return a.FormatLocale(u, l)
+}
+
 // String is the equivalent of calling Format with AmountDUO.
 func (a Amount) String() string {
 	return a.Format(AmountDUO)
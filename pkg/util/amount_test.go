@@ -290,3 +290,141 @@ func TestAmountMulF64(t *testing.T) {
 		}
 	}
 }
+func TestAmountFormatLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		unit   AmountUnit
+		lang   string
+		s      string
+	}{
+		{
+			name:   "DUO en",
+			amount: 44433322211100,
+			unit:   AmountDUO,
+			lang:   "en",
+			s:      "444,333.22211100 DUO",
+		},
+		{
+			name:   "DUO rs",
+			amount: 44433322211100,
+			unit:   AmountDUO,
+			lang:   "rs",
+			s:      "444.333,22211100 DUO",
+		},
+		{
+			name:   "mDUO negative",
+			amount: -1234567,
+			unit:   AmountMilliDUO,
+			lang:   "en",
+			s:      "-12.34567 mDUO",
+		},
+		{
+			name:   "satoshi",
+			amount: 1234567,
+			unit:   AmountSatoshi,
+			lang:   "rs",
+			s:      "1.234.567 Satoshi",
+		},
+		{
+			name:   "kDUO",
+			amount: -123456789000000,
+			unit:   AmountKiloDUO,
+			lang:   "en",
+			s:      "-1,234.56789 kDUO",
+		},
+		{
+			name:   "unknown language",
+			amount: 100000000000,
+			unit:   AmountDUO,
+			lang:   "xx",
+			s:      "1,000.00000000 DUO",
+		},
+	}
+	for _, test := range tests {
+		s := test.amount.FormatLocale(test.unit, LocaleFor(test.lang))
+		if s != test.s {
+			t.Errorf("%v: format '%v' does not match expected '%v'", test.name, s, test.s)
+		}
+	}
+	SetDisplay(AmountMilliDUO, "rs")
+	defer SetDisplay(AmountDUO, "en")
+	if s := Amount(123456789).Display(); s != "1.234,56789 mDUO" {
+		t.Errorf("display '%v' does not match expected '1.234,56789 mDUO'", s)
+	}
+}
+func TestParseAmountUnit(t *testing.T) {
+	for _, u := range []AmountUnit{AmountMegaDUO, AmountKiloDUO, AmountDUO, AmountMilliDUO, AmountMicroDUO,
+		AmountSatoshi} {
+		if p, err := ParseAmountUnit(u.String()); err != nil || p != u {
+			t.Errorf("%v: parsed as %v, %v", u, p, err)
+		}
+	}
+	if p, err := ParseAmountUnit("uDUO"); err != nil || p != AmountMicroDUO {
+		t.Errorf("uDUO: parsed as %v, %v", p, err)
+	}
+	if _, err := ParseAmountUnit("BTC"); err == nil {
+		t.Error("parsed unknown unit BTC")
+	}
+}
+func TestParseAmountLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      string
+		unit   AmountUnit
+		lang   string
+		amount Amount
+		valid  bool
+	}{
+		{
+			name:   "DUO en",
+			s:      "1,234.5",
+			unit:   AmountDUO,
+			lang:   "en",
+			amount: 123450000000,
+			valid:  true,
+		},
+		{
+			name:   "DUO rs",
+			s:      "1.234,5 DUO",
+			unit:   AmountDUO,
+			lang:   "rs",
+			amount: 123450000000,
+			valid:  true,
+		},
+		{
+			name:   "mDUO",
+			s:      "0.00001",
+			unit:   AmountMilliDUO,
+			lang:   "en",
+			amount: 1,
+			valid:  true,
+		},
+		{
+			name:   "satoshi",
+			s:      "1234567",
+			unit:   AmountSatoshi,
+			lang:   "en",
+			amount: 1234567,
+			valid:  true,
+		},
+		{
+			name:  "not a number",
+			s:     "lots",
+			unit:  AmountDUO,
+			lang:  "en",
+			valid: false,
+		},
+	}
+	for _, test := range tests {
+		a, err := ParseAmountLocale(test.s, test.unit, LocaleFor(test.lang))
+		switch {
+		case test.valid && err != nil:
+			t.Errorf("%v: parse failed: %v", test.name, err)
+		case !test.valid && err == nil:
+			t.Errorf("%v: invalid amount parsed", test.name)
+		case a != test.amount:
+			t.Errorf("%v: parsed amount %v does not match expected %v", test.name, a, test.amount)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MinEntropyBits is the least entropy a recovery phrase can carry, giving 12 words
+	MinEntropyBits = 128
+	// MaxEntropyBits is the most entropy a recovery phrase can carry, giving 24 words
+	MaxEntropyBits = 256
+	// RecommendedEntropyBits is the entropy of new recovery phrases, matching the recommended length of a BIP0032 seed
+	RecommendedEntropyBits = 256
+	// SeedBytes is the length of the seed derived from a recovery phrase
+	SeedBytes = 64
+	// seedIterations is the number of rounds of PBKDF2 used to stretch a phrase into a seed
+	seedIterations = 2048
+)
+
+var (
+	// ErrInvalidEntropyLength describes an error in which the entropy is not a multiple of 32 bits from 128 to 256
+	ErrInvalidEntropyLength = fmt.Errorf("entropy length must be a multiple of 32 bits from %d to %d bits",
+		MinEntropyBits, MaxEntropyBits)
+	// ErrInvalidWordCount describes an error in which a recovery phrase does not have 12, 15, 18, 21 or 24 words
+	ErrInvalidWordCount = errors.New("a recovery phrase has 12, 15, 18, 21 or 24 words")
+	// ErrChecksum describes an error in which the checksum in the last word of a recovery phrase does not match, which
+	// happens when a word is mistyped or the words are out of order
+	ErrChecksum = errors.New("the recovery phrase checksum does not match")
+)
+
+// wordIndex looks up the 11 bit index of each word in the list
+var wordIndex = func() map[string]int {
+	m := make(map[string]int, len(english))
+	for i, w := range english {
+		m[w] = i
+	}
+	return m
+}()
+
+// NewEntropy returns the given number of bits of random entropy for a new recovery phrase
+func NewEntropy(bits int) (entropy []byte, err error) {
+	if err = checkEntropyBits(bits); Check(err) {
+		return
+	}
+	entropy = make([]byte, bits/8)
+	if _, err = rand.Read(entropy); Check(err) {
+		return nil, err
+	}
+	return
+}
+
+// NewMnemonic returns the recovery phrase for the entropy, as words separated by single spaces
+func NewMnemonic(entropy []byte) (mnemonic string, err error) {
+	bits := len(entropy) * 8
+	if err = checkEntropyBits(bits); Check(err) {
+		return
+	}
+	// the checksum is the first bit of the hash of the entropy for every 32 bits of entropy
+	sum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), sum[0])
+	words := make([]string, (bits+bits/32)/11)
+	for i := range words {
+		words[i] = english[readBits(data, i*11, 11)]
+	}
+	return strings.Join(words, " "), nil
+}
+
+// EntropyFromMnemonic returns the entropy that a recovery phrase stands for, after checking that its words are in the
+// list and that its checksum matches
+func EntropyFromMnemonic(mnemonic string) (entropy []byte, err error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, ErrInvalidWordCount
+	}
+	// the phrase is packed back into bits, with the checksum left in the last byte
+	total := len(words) * 11
+	bits := total * 32 / 33
+	data := make([]byte, (total+7)/8)
+	for i, w := range words {
+		index, ok := wordIndex[strings.ToLower(w)]
+		if !ok {
+			return nil, fmt.Errorf("word %d, '%s', is not in the word list", i+1, w)
+		}
+		writeBits(data, i*11, 11, index)
+	}
+	entropy = data[:bits/8]
+	checkBits := total - bits
+	sum := sha256.Sum256(entropy)
+	if readBits(data, bits, checkBits) != readBits(sum[:], 0, checkBits) {
+		return nil, ErrChecksum
+	}
+	return
+}
+
+// IsMnemonicValid returns whether the recovery phrase has only words from the list and a matching checksum
+func IsMnemonicValid(mnemonic string) bool {
+	_, err := EntropyFromMnemonic(mnemonic)
+	return err == nil
+}
+
+// NewSeed returns the seed for a BIP0032 master key that is derived from the recovery phrase and the passphrase, which
+// may be empty. The phrase is not checked, so it should be checked with EntropyFromMnemonic first
+func NewSeed(mnemonic, passphrase string) []byte {
+	m := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key([]byte(m), []byte(salt), seedIterations, SeedBytes, sha512.New)
+}
+
+// NewSeedFromMnemonic checks the recovery phrase and returns the seed derived from it and the passphrase
+func NewSeedFromMnemonic(mnemonic, passphrase string) (seed []byte, err error) {
+	if _, err = EntropyFromMnemonic(mnemonic); err != nil {
+		return
+	}
+	return NewSeed(mnemonic, passphrase), nil
+}
+
+func checkEntropyBits(bits int) error {
+	if bits < MinEntropyBits || bits > MaxEntropyBits || bits%32 != 0 {
+		return ErrInvalidEntropyLength
+	}
+	return nil
+}
+
+// readBits returns n bits of data starting from bit offset, most significant bit first
+func readBits(data []byte, offset, n int) (v int) {
+	for i := offset; i < offset+n; i++ {
+		v = v<<1 | int(data[i/8]>>(7-uint(i%8))&1)
+	}
+	return
+}
+
+// writeBits sets n bits of data starting from bit offset to the lowest n bits of v, most significant bit first
+func writeBits(data []byte, offset, n, v int) {
+	for i := 0; i < n; i++ {
+		if v>>(uint(n-1-i))&1 == 1 {
+			pos := offset + i
+			data[pos/8] |= 1 << (7 - uint(pos%8))
+		}
+	}
+}
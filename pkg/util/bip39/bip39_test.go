@@ -0,0 +1,122 @@
+package bip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// TestVectors checks phrases and seeds against the reference test vectors of BIP0039, which use the passphrase TREZOR.
+func TestVectors(t *testing.T) {
+	tests := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4a" +
+				"b7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd38" +
+				"1ee6260e8d9739fce1f607",
+		},
+		{
+			"80808080808080808080808080808080",
+			"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f" +
+				"985ec81778c1b370b652a8",
+		},
+		{
+			"ffffffffffffffffffffffffffffffff",
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651" +
+				"a14c34e18231052e48c069",
+		},
+		{
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			strings.Repeat("abandon ", 23) + "art",
+			"bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3de6f5d4a10" +
+				"be8ed2a5e608d68f92fcc8",
+		},
+	}
+	for i, test := range tests {
+		entropy, _ := hex.DecodeString(test.entropy)
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Errorf("%d: NewMnemonic: %v", i, err)
+			continue
+		}
+		if mnemonic != test.mnemonic {
+			t.Errorf("%d: mnemonic is '%s', want '%s'", i, mnemonic, test.mnemonic)
+		}
+		back, err := EntropyFromMnemonic(test.mnemonic)
+		if err != nil {
+			t.Errorf("%d: EntropyFromMnemonic: %v", i, err)
+		} else if !bytes.Equal(back, entropy) {
+			t.Errorf("%d: entropy is %x, want %s", i, back, test.entropy)
+		}
+		if seed := hex.EncodeToString(NewSeed(test.mnemonic, "TREZOR")); seed != test.seed {
+			t.Errorf("%d: seed is %s, want %s", i, seed, test.seed)
+		}
+	}
+}
+
+// TestInvalidMnemonics checks phrases with unknown words, the wrong number of words or a bad checksum are refused.
+func TestInvalidMnemonics(t *testing.T) {
+	tests := []struct {
+		name     string
+		mnemonic string
+		err      error
+	}{
+		{"too few words", "abandon abandon abandon", ErrInvalidWordCount},
+		{"uneven count", strings.Repeat("abandon ", 13), ErrInvalidWordCount},
+		{"bad checksum", strings.Repeat("abandon ", 12), ErrChecksum},
+		{"swapped words", "legal winner thank year wave sausage worth useful legal winner yellow thank", ErrChecksum},
+		{"unknown word", strings.Repeat("abandon ", 11) + "abut", nil},
+	}
+	for _, test := range tests {
+		_, err := EntropyFromMnemonic(test.mnemonic)
+		if err == nil {
+			t.Errorf("%s: accepted", test.name)
+			continue
+		}
+		if test.err != nil && err != test.err {
+			t.Errorf("%s: error is %v, want %v", test.name, err, test.err)
+		}
+		if IsMnemonicValid(test.mnemonic) {
+			t.Errorf("%s: reported valid", test.name)
+		}
+	}
+}
+
+// TestNewEntropy checks new entropy round trips through a phrase and that lengths outside the standard are refused.
+func TestNewEntropy(t *testing.T) {
+	for bits := MinEntropyBits; bits <= MaxEntropyBits; bits += 32 {
+		entropy, err := NewEntropy(bits)
+		if err != nil {
+			t.Fatalf("%d bits: %v", bits, err)
+		}
+		mnemonic, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatalf("%d bits: %v", bits, err)
+		}
+		if n := len(strings.Fields(mnemonic)); n != bits*33/32/11 {
+			t.Errorf("%d bits: %d words", bits, n)
+		}
+		back, err := EntropyFromMnemonic(strings.ToUpper(mnemonic))
+		if err != nil || !bytes.Equal(back, entropy) {
+			t.Errorf("%d bits: round trip gave %x, %v", bits, back, err)
+		}
+	}
+	for _, bits := range []int{96, 130, 288} {
+		if _, err := NewEntropy(bits); err != ErrInvalidEntropyLength {
+			t.Errorf("%d bits: error is %v", bits, err)
+		}
+	}
+}
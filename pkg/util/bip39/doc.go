@@ -0,0 +1,15 @@
+/*Package bip39 implements the mnemonic recovery phrases of BIP0039.
+
+Overview
+
+A recovery phrase writes the random entropy a wallet is created from as a list of words from a fixed list of 2048, each
+word carrying 11 bits, with the last word also carrying a checksum of the entropy so that a mistyped or misordered word
+is caught. Phrases of 12 to 24 words stand for 128 to 256 bits of entropy.
+
+The seed that the BIP0032 master key is derived from is stretched from the phrase together with an optional passphrase,
+so that the same words with a different passphrase give an entirely different wallet. There is no way to tell a wrong
+passphrase from a right one, as every passphrase gives a valid seed.
+
+Only the English word list is supported.
+*/
+package bip39
@@ -0,0 +1,134 @@
+package lang
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Catalog maps the messages of the user interface, as they are written in English, to their translation in a language
+type Catalog map[string]string
+
+var (
+	catalogMx sync.Mutex
+	catalogs  = map[string]Catalog{"rs": guiRS}
+	current   Catalog
+	code      = "en"
+)
+
+// RegisterCatalog adds the translations to the catalog of the language with the code, replacing those it already has
+func RegisterCatalog(lang string, c Catalog) {
+	catalogMx.Lock()
+	defer catalogMx.Unlock()
+	cat, ok := catalogs[lang]
+	if !ok {
+		cat = make(Catalog)
+		catalogs[lang] = cat
+	}
+	for msg, tr := range c {
+		cat[msg] = tr
+	}
+}
+
+// LoadCatalogs registers the catalogs in the folder, one JSON object of messages and their translations per language
+// named after its code, such as de.json, so the interface can be translated without rebuilding it. A missing folder is
+// not an error
+func LoadCatalogs(dir string) (err error) {
+	var files []os.FileInfo
+	if files, err = ioutil.ReadDir(dir); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		var b []byte
+		if b, err = ioutil.ReadFile(filepath.Join(dir, f.Name())); err != nil {
+			return
+		}
+		c := make(Catalog)
+		if err = json.Unmarshal(b, &c); err != nil {
+			return fmt.Errorf("catalog %s: %v", f.Name(), err)
+		}
+		RegisterCatalog(strings.TrimSuffix(f.Name(), ".json"), c)
+	}
+	return
+}
+
+// Languages returns the codes of the languages the interface can be shown in
+func Languages() (out []string) {
+	catalogMx.Lock()
+	defer catalogMx.Unlock()
+	out = []string{"en"}
+	for lang := range catalogs {
+		if lang != "en" {
+			out = append(out, lang)
+		}
+	}
+	sort.Strings(out[1:])
+	return
+}
+
+// SetLanguage sets the language messages are translated to, languages without a catalog are shown in English
+func SetLanguage(lang string) {
+	catalogMx.Lock()
+	defer catalogMx.Unlock()
+	code, current = lang, catalogs[lang]
+}
+
+// Current returns the code of the language messages are translated to
+func Current() string {
+	catalogMx.Lock()
+	defer catalogMx.Unlock()
+	return code
+}
+
+// T returns the translation of the message to the current language, or the message itself if it has none
+func T(msg string) string {
+	catalogMx.Lock()
+	defer catalogMx.Unlock()
+	if tr, ok := current[msg]; ok && tr != "" {
+		return tr
+	}
+	return msg
+}
+
+// Tf translates the format to the current language and formats the arguments with it
+func Tf(format string, a ...interface{}) string {
+	return fmt.Sprintf(T(format), a...)
+}
+
+// Detect returns the language of the user's locale, from the environment variables the locale is set with, in the
+// codes of the catalogs, where serbian is rs
+func Detect() string {
+	for _, v := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		locale := os.Getenv(v)
+		if locale == "" {
+			continue
+		}
+		// locales are written as language_TERRITORY.codeset@modifier, such as sr_RS.UTF-8
+		fields := strings.FieldsFunc(locale, func(r rune) bool {
+			return r == '_' || r == '-' || r == '.' || r == '@'
+		})
+		if len(fields) == 0 {
+			continue
+		}
+		lang := strings.ToLower(fields[0])
+		switch lang {
+		case "c", "posix":
+			return "en"
+		case "sr":
+			return "rs"
+		}
+		return lang
+	}
+	return "en"
+}
@@ -0,0 +1,74 @@
+package lang
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCatalogs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lang")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	catalog := []byte(`{"Send": "Senden", "%d connected peers": "%d verbundene Knoten", "Back": ""}`)
+	if err = ioutil.WriteFile(filepath.Join(dir, "de.json"), catalog, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = LoadCatalogs(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err = LoadCatalogs(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("a missing folder of catalogs is not an error: %v", err)
+	}
+	defer SetLanguage("en")
+	tests := []struct {
+		lang, msg, want string
+	}{
+		{"en", "Send", "Send"},
+		{"de", "Send", "Senden"},
+		// untranslated and empty translations fall back to english
+		{"de", "Copy", "Copy"},
+		{"de", "Back", "Back"},
+		{"rs", "Send", "Posalji"},
+		{"xx", "Send", "Send"},
+	}
+	for _, test := range tests {
+		SetLanguage(test.lang)
+		if got := T(test.msg); got != test.want {
+			t.Errorf("%s: %q translated to %q, want %q", test.lang, test.msg, got, test.want)
+		}
+	}
+	SetLanguage("de")
+	if got := Tf("%d connected peers", 3); got != "3 verbundene Knoten" {
+		t.Errorf("formatted translation is %q", got)
+	}
+	langs := Languages()
+	if len(langs) < 3 || langs[0] != "en" {
+		t.Errorf("languages %v should start with en and include de and rs", langs)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for _, v := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		defer os.Setenv(v, os.Getenv(v))
+		os.Unsetenv(v)
+	}
+	tests := []struct {
+		locale, want string
+	}{
+		{"", "en"},
+		{"C", "en"},
+		{"de_DE.UTF-8", "de"},
+		{"sr_RS", "rs"},
+		{"pt-BR", "pt"},
+	}
+	for _, test := range tests {
+		os.Setenv("LANG", test.locale)
+		if got := Detect(); got != test.want {
+			t.Errorf("locale %q detected as %q, want %q", test.locale, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package lang
+
+// guiRS is the serbian catalog of the wallet interface. It is written without diacritics, which the fonts of the
+// interface don't all have, as is the serbian text of the goApp dictionary
+var guiRS = Catalog{
+	// sidebar
+	"overview":        "pregled",
+	"send":            "slanje",
+	"receive":         "primanje",
+	"history":         "istorija",
+	"address book":    "imenik adresa",
+	"privacy":         "privatnost",
+	"shared wallets":  "zajednicki novcanici",
+	"coins":           "novcici",
+	"offline signing": "potpisivanje van mreze",
+	"import key":      "uvoz kljuca",
+	"rescan":          "ponovno skeniranje",
+	"explorer":        "pretrazivac",
+	"peers":           "cvorovi",
+	"mining":          "rudarenje",
+	"console":         "konzola",
+	"settings":        "podesavanja",
+	"log":             "dnevnik",
+	"help":            "pomoc",
+	"quit":            "izlaz",
+	// buttons
+	"Add":                         "Dodaj",
+	"Back":                        "Nazad",
+	"Ban":                         "Zabrani",
+	"Broadcast":                   "Objavi",
+	"Check and broadcast":         "Proveri i objavi",
+	"Clear":                       "Obrisi",
+	"Clear selection":             "Ponisti izbor",
+	"Copy":                        "Kopiraj",
+	"Create":                      "Napravi",
+	"Create payment request":      "Napravi zahtev za placanje",
+	"Delete":                      "Izbrisi",
+	"Disconnect":                  "Prekini vezu",
+	"Edit":                        "Izmeni",
+	"Encrypt":                     "Sifruj",
+	"Export":                      "Izvezi",
+	"Export key":                  "Izvezi kljuc",
+	"I have written them down":    "Zapisao sam ih",
+	"Latest block":                "Poslednji blok",
+	"New address":                 "Nova adresa",
+	"Paste":                       "Nalepi",
+	"Pay":                         "Plati",
+	"Preview":                     "Pregled",
+	"QR code":                     "QR kod",
+	"Read file":                   "Ucitaj fajl",
+	"Rebroadcast":                 "Objavi ponovo",
+	"Rescan":                      "Skeniraj ponovo",
+	"Save":                        "Sacuvaj",
+	"Search":                      "Trazi",
+	"Send":                        "Posalji",
+	"Show the words again":        "Prikazi reci ponovo",
+	"Sign":                        "Potpisi",
+	"Spend":                       "Potrosi",
+	"Sweep to wallet":             "Prebaci u novcanik",
+	"Unban":                       "Ukini zabranu",
+	"Unlock":                      "Otkljucaj",
+	"< Page":                      "< Strana",
+	"Page >":                      "Strana >",
+	"Import from hardware wallet": "Uvezi iz hardverskog novcanika",
+	// headings
+	"add a peer":                             "dodaj cvor",
+	"add or edit an address":                 "dodaj ili izmeni adresu",
+	"address":                                "adresa",
+	"addresses":                              "adrese",
+	"banned":                                 "zabranjeni",
+	"coin control":                           "kontrola novcica",
+	"create a shared wallet":                 "napravi zajednicki novcanik",
+	"import private key":                     "uvezi privatni kljuc",
+	"progress":                               "napredak",
+	"rescan the chain":                       "ponovo skeniraj lanac",
+	"spendable outputs":                      "potrosivi izlazi",
+	"transaction":                            "transakcija",
+	"transactions, newest first":             "transakcije, najnovije prve",
+	"wallet database":                        "baza novcanika",
+	"the node is not connected to any peers": "cvor nije povezan ni sa jednim cvorom",
+	// settings
+	"config":   "konfiguracija",
+	"debug":    "otklanjanje gresaka",
+	"node":     "cvor",
+	"policy":   "pravila",
+	"proxy":    "proksi",
+	"wallet":   "novcanik",
+	"Language": "Jezik",
+	// notifications
+	"Payment received":   "Uplata primljena",
+	"Payment confirmed":  "Uplata potvrdjena",
+	"Block found":        "Blok pronadjen",
+	"%d connected peers": "%d povezanih cvorova",
+}
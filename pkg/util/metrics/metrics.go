@@ -0,0 +1,191 @@
+// Package metrics exports the gauges, counters and histograms of a node in the Prometheus text exposition format, so
+// that it can be scraped by standard monitoring tools without depending on a client library.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// ContentType is the content type of the Prometheus text exposition format
+	ContentType = "text/plain; version=0.0.4; charset=utf-8"
+	// DefaultListener is the default address metrics are served on
+	DefaultListener = "127.0.0.1:11050"
+)
+
+// DefaultBuckets are the upper bounds in seconds of the histogram buckets used for request latencies
+var DefaultBuckets = []float64{.001, .005, .01, .05, .1, .5, 1, 5, 10, 60}
+
+// collector is a metric that writes its samples when the metrics are collected
+type collector interface {
+	write(w io.Writer)
+}
+
+// Registry is a set of named metrics, which serves them over HTTP.
+type Registry struct {
+	sync.Mutex
+	names      map[string]struct{}
+	collectors []collector
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]struct{})}
+}
+
+// add registers a collector. Registering two metrics with the same name is a programming error, so it panics.
+func (r *Registry) add(name string, c collector) {
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.names[name]; ok {
+		panic("metrics: metric " + name + " registered twice")
+	}
+	r.names[name] = struct{}{}
+	r.collectors = append(r.collectors, c)
+}
+
+// funcMetric is a gauge or a counter whose value is read when the metrics are collected
+type funcMetric struct {
+	name, help, typ string
+	fn              func() float64
+}
+
+func (m *funcMetric) write(w io.Writer) {
+	writeHeader(w, m.name, m.help, m.typ)
+	_, _ = fmt.Fprintf(w, "%s %s\n", m.name, formatValue(m.fn()))
+}
+
+// GaugeFunc registers a gauge whose value is returned by fn when the metrics are collected
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	r.add(name, &funcMetric{name: name, help: help, typ: "gauge", fn: fn})
+}
+
+// CounterFunc registers a counter whose value is returned by fn when the metrics are collected. The value must only
+// ever increase.
+func (r *Registry) CounterFunc(name, help string, fn func() float64) {
+	r.add(name, &funcMetric{name: name, help: help, typ: "counter", fn: fn})
+}
+
+// Histogram counts observations in buckets, separately for each value of a label.
+type Histogram struct {
+	sync.Mutex
+	name, help, label string
+	buckets           []float64
+	series            map[string]*series
+}
+
+// series is the distribution of the observations for one value of the label of a histogram
+type series struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Histogram registers a histogram with the given bucket upper bounds, which must be sorted, whose observations are
+// labelled with the given label name.
+func (r *Registry) Histogram(name, help, label string, buckets []float64) *Histogram {
+	h := &Histogram{
+		name:    name,
+		help:    help,
+		label:   label,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.add(name, h)
+	return h
+}
+
+// Observe adds an observation for a label value. Observing on a nil histogram does nothing, so that code can be
+// instrumented whether or not metrics are collected.
+func (h *Histogram) Observe(labelValue string, v float64) {
+	if h == nil {
+		return
+	}
+	h.Lock()
+	defer h.Unlock()
+	s, ok := h.series[labelValue]
+	if !ok {
+		s = &series{counts: make([]uint64, len(h.buckets))}
+		h.series[labelValue] = s
+	}
+	for i, upper := range h.buckets {
+		if v <= upper {
+			s.counts[i]++
+		}
+	}
+	s.sum += v
+	s.count++
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.Lock()
+	defer h.Unlock()
+	writeHeader(w, h.name, h.help, "histogram")
+	values := make([]string, 0, len(h.series))
+	for value := range h.series {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	for _, value := range values {
+		s := h.series[value]
+		label := h.label + `="` + escapeLabel(value) + `"`
+		for i, upper := range h.buckets {
+			_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", h.name, label, formatValue(upper), s.counts[i])
+		}
+		_, _ = fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", h.name, label, s.count)
+		_, _ = fmt.Fprintf(w, "%s_sum{%s} %s\n", h.name, label, formatValue(s.sum))
+		_, _ = fmt.Fprintf(w, "%s_count{%s} %d\n", h.name, label, s.count)
+	}
+}
+
+// Write writes all the metrics of the registry to w in the order they were registered
+func (r *Registry) Write(w io.Writer) {
+	r.Lock()
+	collectors := append([]collector{}, r.collectors...)
+	r.Unlock()
+	for _, c := range collectors {
+		c.write(w)
+	}
+}
+
+// ServeHTTP serves the metrics of the registry in the Prometheus text exposition format
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var buf bytes.Buffer
+	r.Write(&buf)
+	w.Header().Set("Content-Type", ContentType)
+	if _, err := buf.WriteTo(w); err != nil {
+		Debug("failed to write metrics:", err)
+	}
+}
+
+// writeHeader writes the help and type comments that precede the samples of a metric
+func writeHeader(w io.Writer, name, help, typ string) {
+	help = strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// escapeLabel escapes a label value as required by the exposition format
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// formatValue formats a sample value as required by the exposition format
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRegistry ensures that metrics are written in the Prometheus text exposition format, in the order they were
+// registered, with histogram series sorted by label value.
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	peers := 3.0
+	r.GaugeFunc("pod_peers", "Number of connected peers", func() float64 { return peers })
+	r.CounterFunc("pod_bytes_total", "Bytes written\nto disk", func() float64 { return 1e9 })
+	h := r.Histogram("pod_rpc_seconds", "RPC latency", "method", []float64{.1, 1})
+	h.Observe("getinfo", .05)
+	h.Observe("getinfo", .5)
+	h.Observe(`get"block`, 2)
+	var nilHistogram *Histogram
+	nilHistogram.Observe("getinfo", 1)
+	want := `# HELP pod_peers Number of connected peers
+# TYPE pod_peers gauge
+pod_peers 3
+# HELP pod_bytes_total Bytes written\nto disk
+# TYPE pod_bytes_total counter
+pod_bytes_total 1e+09
+# HELP pod_rpc_seconds RPC latency
+# TYPE pod_rpc_seconds histogram
+pod_rpc_seconds_bucket{method="get\"block",le="0.1"} 0
+pod_rpc_seconds_bucket{method="get\"block",le="1"} 0
+pod_rpc_seconds_bucket{method="get\"block",le="+Inf"} 1
+pod_rpc_seconds_sum{method="get\"block"} 2
+pod_rpc_seconds_count{method="get\"block"} 1
+pod_rpc_seconds_bucket{method="getinfo",le="0.1"} 1
+pod_rpc_seconds_bucket{method="getinfo",le="1"} 2
+pod_rpc_seconds_bucket{method="getinfo",le="+Inf"} 2
+pod_rpc_seconds_sum{method="getinfo"} 0.55
+pod_rpc_seconds_count{method="getinfo"} 2
+`
+	var buf bytes.Buffer
+	r.Write(&buf)
+	if buf.String() != want {
+		t.Errorf("got metrics\n%s\nwant\n%s", buf.String(), want)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Header().Get("Content-Type") != ContentType || rec.Body.String() != want {
+		t.Errorf("got response %q with content type %q", rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a metric twice did not panic")
+		}
+	}()
+	r.GaugeFunc("pod_peers", "", func() float64 { return 0 })
+}
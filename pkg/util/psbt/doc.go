@@ -0,0 +1,15 @@
+/*Package psbt implements the partially signed transaction format of BIP0174.
+
+Overview
+
+A partially signed transaction carries an unsigned transaction together with the data each signer needs to sign its
+inputs, such as the previous transactions being spent and the redeem scripts of pay to script hash outputs. Signers add
+their signatures to the packet and hand it on, and once enough signatures have been collected the packet is finalized
+and the fully signed transaction extracted from it.
+
+This package supports the fields needed to collect signatures for pay to script hash multi-signature inputs, and to have
+pay to pubkey hash inputs signed by a device holding the master key, such as a hardware wallet, which finds its keys from
+the BIP0032 derivations of the inputs and outputs. Fields it
+does not interpret are kept as unknowns so that they survive a round trip through the packet.
+*/
+package psbt
@@ -0,0 +1,632 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// magic is the prefix of every serialized packet, the ascii string "psbt" followed by a separator byte.
+var magic = []byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+// The key types of the fields this package interprets. Each of the global, input and output maps has its own key
+// space, which is why the values overlap.
+const (
+	globalUnsignedTxType      = 0x00
+	inputNonWitnessUtxoType   = 0x00
+	inputPartialSigType       = 0x02
+	inputSighashType          = 0x03
+	inputRedeemScriptType     = 0x04
+	inputBip32DerivationType  = 0x06
+	inputFinalScriptSigType   = 0x07
+	outputRedeemScriptType    = 0x00
+	outputBip32DerivationType = 0x02
+)
+
+// maxFieldSize is the largest key or value accepted when parsing a packet.
+const maxFieldSize = wire.MaxMessagePayload
+
+var (
+	// ErrInvalidMagic is returned when the serialized packet does not start with the psbt magic bytes.
+	ErrInvalidMagic = errors.New("invalid psbt magic bytes")
+	// ErrInvalidPacket is returned when a serialized packet is malformed.
+	ErrInvalidPacket = errors.New("invalid psbt packet")
+	// ErrDuplicateKey is returned when a map of a serialized packet contains the same key twice.
+	ErrDuplicateKey = errors.New("duplicate key in psbt map")
+	// ErrUnsignedTxHasScripts is returned when the transaction a packet is created from already carries signature
+	// scripts or witnesses.
+	ErrUnsignedTxHasScripts = errors.New("unsigned transaction has signature scripts or witnesses")
+	// ErrTxMismatch is returned when combining packets for different transactions.
+	ErrTxMismatch = errors.New("psbt packets are for different transactions")
+	// ErrNotMultisig is returned when finalizing an input whose redeem script is not a standard multisig script.
+	ErrNotMultisig = errors.New("input redeem script is not a multisig script")
+	// ErrNotPubKeyHash is returned when finalizing an input without a redeem script whose previous output is not a pay
+	// to pubkey hash output, or whose previous transaction is missing.
+	ErrNotPubKeyHash = errors.New("input does not spend a pay to pubkey hash output")
+	// ErrNotEnoughSignatures is returned when finalizing an input that has not collected enough signatures.
+	ErrNotEnoughSignatures = errors.New("input does not have enough signatures")
+	// ErrIncomplete is returned when extracting the transaction from a packet that has not been finalized.
+	ErrIncomplete = errors.New("psbt is not finalized")
+)
+
+// Unknown is a key value pair of a field this package does not interpret.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// PartialSig is the signature of one public key for an input.
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// Bip32Derivation is the BIP0032 derivation of a public key from a master key, which tells a signer holding the
+// master key, such as a hardware wallet, which of its keys signs an input or receives an output.
+type Bip32Derivation struct {
+	PubKey               []byte
+	MasterKeyFingerprint uint32
+	Path                 []uint32
+}
+
+// PInput holds the signing data for one input of the unsigned transaction.
+type PInput struct {
+	NonWitnessUtxo  *wire.MsgTx
+	PartialSigs     []*PartialSig
+	SighashType     txscript.SigHashType
+	RedeemScript    []byte
+	Bip32Derivation []*Bip32Derivation
+	FinalScriptSig  []byte
+	Unknowns        []*Unknown
+}
+
+// POutput holds the data attached to one output of the unsigned transaction.
+type POutput struct {
+	RedeemScript    []byte
+	Bip32Derivation []*Bip32Derivation
+	Unknowns        []*Unknown
+}
+
+// Packet is a partially signed transaction.
+type Packet struct {
+	UnsignedTx *wire.MsgTx
+	Inputs     []PInput
+	Outputs    []POutput
+	Unknowns   []*Unknown
+}
+
+// New creates a packet for an unsigned transaction. The transaction must not carry any signature scripts or witnesses.
+func New(tx *wire.MsgTx) (*Packet, error) {
+	if err := checkUnsigned(tx); err != nil {
+		return nil, err
+	}
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}, nil
+}
+
+// NewFromRawBytes parses a serialized packet, which is read as base64 if b64 is set.
+func NewFromRawBytes(r io.Reader, b64 bool) (*Packet, error) {
+	if b64 {
+		r = base64.NewDecoder(base64.StdEncoding, r)
+	}
+	m := make([]byte, len(magic))
+	if _, err := io.ReadFull(r, m); err != nil || !bytes.Equal(m, magic) {
+		return nil, ErrInvalidMagic
+	}
+	p := &Packet{}
+	err := readMap(r, func(key, value []byte) error {
+		if key[0] != globalUnsignedTxType {
+			p.Unknowns = append(p.Unknowns, &Unknown{Key: key, Value: value})
+			return nil
+		}
+		if len(key) != 1 {
+			return ErrInvalidPacket
+		}
+		tx := wire.NewMsgTx(wire.TxVersion)
+		if err := tx.DeserializeNoWitness(bytes.NewReader(value)); err != nil {
+			return ErrInvalidPacket
+		}
+		if err := checkUnsigned(tx); err != nil {
+			return err
+		}
+		p.UnsignedTx = tx
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if p.UnsignedTx == nil {
+		return nil, ErrInvalidPacket
+	}
+	p.Inputs = make([]PInput, len(p.UnsignedTx.TxIn))
+	for i := range p.Inputs {
+		if err = p.Inputs[i].parse(r); err != nil {
+			return nil, err
+		}
+		utxo := p.Inputs[i].NonWitnessUtxo
+		if utxo != nil && utxo.TxHash() != p.UnsignedTx.TxIn[i].PreviousOutPoint.Hash {
+			return nil, ErrInvalidPacket
+		}
+	}
+	p.Outputs = make([]POutput, len(p.UnsignedTx.TxOut))
+	for i := range p.Outputs {
+		if err = p.Outputs[i].parse(r); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Parse parses a packet as it is read from a file, where it may be written either in the binary format of BIP0174 or as
+// base64 text, around which white space is ignored.
+func Parse(b []byte) (*Packet, error) {
+	if bytes.HasPrefix(b, magic) {
+		return NewFromRawBytes(bytes.NewReader(b), false)
+	}
+	return NewFromRawBytes(bytes.NewReader(bytes.TrimSpace(b)), true)
+}
+
+// Serialize writes the packet in the binary format of BIP0174.
+func (p *Packet) Serialize(w io.Writer) error {
+	if _, err := w.Write(magic); err != nil {
+		return err
+	}
+	var tx bytes.Buffer
+	if err := p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+		return err
+	}
+	if err := writeField(w, []byte{globalUnsignedTxType}, tx.Bytes()); err != nil {
+		return err
+	}
+	if err := writeUnknowns(w, p.Unknowns); err != nil {
+		return err
+	}
+	for i := range p.Inputs {
+		if err := p.Inputs[i].serialize(w); err != nil {
+			return err
+		}
+	}
+	for i := range p.Outputs {
+		if err := p.Outputs[i].serialize(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// B64Encode returns the serialized packet encoded as base64, the usual way packets are passed between signers.
+func (p *Packet) B64Encode() (string, error) {
+	var b bytes.Buffer
+	if err := p.Serialize(&b); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// AddPartialSig adds the signature of a public key to the input at index i, unless that key already signed it. It
+// returns whether the signature was added.
+func (p *Packet) AddPartialSig(i int, pubKey, sig []byte) bool {
+	in := &p.Inputs[i]
+	if in.FinalScriptSig != nil {
+		return false
+	}
+	for _, ps := range in.PartialSigs {
+		if bytes.Equal(ps.PubKey, pubKey) {
+			return false
+		}
+	}
+	in.PartialSigs = append(in.PartialSigs, &PartialSig{PubKey: pubKey, Signature: sig})
+	return true
+}
+
+// Combine merges the signatures and scripts of another packet for the same transaction into p.
+func (p *Packet) Combine(o *Packet) error {
+	if p.UnsignedTx.TxHash() != o.UnsignedTx.TxHash() {
+		return ErrTxMismatch
+	}
+	for i := range p.Inputs {
+		in, oin := &p.Inputs[i], &o.Inputs[i]
+		if in.NonWitnessUtxo == nil {
+			in.NonWitnessUtxo = oin.NonWitnessUtxo
+		}
+		if in.FinalScriptSig != nil {
+			continue
+		}
+		if oin.FinalScriptSig != nil {
+			*in = *oin
+			continue
+		}
+		if in.RedeemScript == nil {
+			in.RedeemScript = oin.RedeemScript
+		}
+		if in.SighashType == 0 {
+			in.SighashType = oin.SighashType
+		}
+		if in.Bip32Derivation == nil {
+			in.Bip32Derivation = oin.Bip32Derivation
+		}
+		for _, ps := range oin.PartialSigs {
+			p.AddPartialSig(i, ps.PubKey, ps.Signature)
+		}
+	}
+	for i := range p.Outputs {
+		if p.Outputs[i].RedeemScript == nil {
+			p.Outputs[i].RedeemScript = o.Outputs[i].RedeemScript
+		}
+		if p.Outputs[i].Bip32Derivation == nil {
+			p.Outputs[i].Bip32Derivation = o.Outputs[i].Bip32Derivation
+		}
+	}
+	return nil
+}
+
+// Progress returns the number of signatures the input has collected and the number its multisig redeem script
+// requires. Inputs that are not multisig report zero for both.
+func (in *PInput) Progress() (have, need int) {
+	if in.FinalScriptSig != nil {
+		pushes, err := txscript.PushedData(in.FinalScriptSig)
+		if err != nil || len(pushes) == 0 {
+			return 0, 0
+		}
+		if _, need, err = txscript.CalcMultiSigStats(pushes[len(pushes)-1]); err != nil {
+			return 0, 0
+		}
+		return need, need
+	}
+	if txscript.GetScriptClass(in.RedeemScript) != txscript.MultiSigTy {
+		return 0, 0
+	}
+	_, need, _ = txscript.CalcMultiSigStats(in.RedeemScript)
+	return len(in.PartialSigs), need
+}
+
+// Finalize builds the signature script of the input at index i from its partial signatures. Inputs with a redeem script
+// must be multisig, and are signed in the order the public keys appear in the redeem script. Inputs without one must
+// spend a pay to pubkey hash output of their previous transaction.
+func Finalize(p *Packet, i int) error {
+	in := &p.Inputs[i]
+	if in.FinalScriptSig != nil {
+		return nil
+	}
+	if in.RedeemScript == nil {
+		return finalizePubKeyHash(p, i)
+	}
+	if txscript.GetScriptClass(in.RedeemScript) != txscript.MultiSigTy {
+		return ErrNotMultisig
+	}
+	_, need, err := txscript.CalcMultiSigStats(in.RedeemScript)
+	if err != nil {
+		return err
+	}
+	pubKeys, err := txscript.PushedData(in.RedeemScript)
+	if err != nil {
+		return err
+	}
+	builder := txscript.NewScriptBuilder().AddOp(txscript.OP_0)
+	var count int
+	for _, pubKey := range pubKeys {
+		for _, ps := range in.PartialSigs {
+			if count < need && bytes.Equal(ps.PubKey, pubKey) {
+				builder.AddData(ps.Signature)
+				count++
+			}
+		}
+	}
+	if count < need {
+		return ErrNotEnoughSignatures
+	}
+	script, err := builder.AddData(in.RedeemScript).Script()
+	if err != nil {
+		return err
+	}
+	in.FinalScriptSig = script
+	in.PartialSigs = nil
+	in.SighashType = 0
+	in.RedeemScript = nil
+	return nil
+}
+
+// finalizePubKeyHash builds the signature script of the pay to pubkey hash input at index i from the signature of the
+// key its previous output pays to.
+func finalizePubKeyHash(p *Packet, i int) error {
+	in := &p.Inputs[i]
+	prevOut := p.PrevOut(i)
+	if prevOut == nil {
+		return ErrNotPubKeyHash
+	}
+	pkScript := prevOut.PkScript
+	if txscript.GetScriptClass(pkScript) != txscript.PubKeyHashTy {
+		return ErrNotPubKeyHash
+	}
+	pushes, err := txscript.PushedData(pkScript)
+	if err != nil || len(pushes) != 1 {
+		return ErrNotPubKeyHash
+	}
+	for _, ps := range in.PartialSigs {
+		if !bytes.Equal(util.Hash160(ps.PubKey), pushes[0]) {
+			continue
+		}
+		script, err := txscript.NewScriptBuilder().AddData(ps.Signature).AddData(ps.PubKey).Script()
+		if err != nil {
+			return err
+		}
+		in.FinalScriptSig = script
+		in.PartialSigs = nil
+		in.SighashType = 0
+		in.Bip32Derivation = nil
+		return nil
+	}
+	return ErrNotEnoughSignatures
+}
+
+// MaybeFinalizeAll finalizes every input that has collected enough signatures and returns whether the packet is now
+// complete.
+func MaybeFinalizeAll(p *Packet) (bool, error) {
+	for i := range p.Inputs {
+		err := Finalize(p, i)
+		if err != nil && err != ErrNotEnoughSignatures {
+			return false, err
+		}
+	}
+	return p.IsComplete(), nil
+}
+
+// IsComplete returns whether every input of the packet has been finalized.
+func (p *Packet) IsComplete() bool {
+	for i := range p.Inputs {
+		if p.Inputs[i].FinalScriptSig == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// PrevOut returns the output spent by the input at index i, taken from the previous transaction attached to the input.
+// It returns nil when the previous transaction is not attached.
+func (p *Packet) PrevOut(i int) *wire.TxOut {
+	prevTx := p.Inputs[i].NonWitnessUtxo
+	index := p.UnsignedTx.TxIn[i].PreviousOutPoint.Index
+	if prevTx == nil || int(index) >= len(prevTx.TxOut) {
+		return nil
+	}
+	return prevTx.TxOut[index]
+}
+
+// Fee returns the fee paid by the transaction. The fee is only known, and ok only set, when the previous transactions
+// of all inputs are attached.
+func (p *Packet) Fee() (fee util.Amount, ok bool) {
+	for i := range p.Inputs {
+		prevOut := p.PrevOut(i)
+		if prevOut == nil {
+			return 0, false
+		}
+		fee += util.Amount(prevOut.Value)
+	}
+	for _, out := range p.UnsignedTx.TxOut {
+		fee -= util.Amount(out.Value)
+	}
+	return fee, true
+}
+
+// Extract returns the signed transaction of a complete packet.
+func Extract(p *Packet) (*wire.MsgTx, error) {
+	if !p.IsComplete() {
+		return nil, ErrIncomplete
+	}
+	tx := p.UnsignedTx.Copy()
+	for i := range tx.TxIn {
+		tx.TxIn[i].SignatureScript = p.Inputs[i].FinalScriptSig
+	}
+	return tx, nil
+}
+
+func (in *PInput) parse(r io.Reader) error {
+	return readMap(r, func(key, value []byte) error {
+		switch key[0] {
+		case inputNonWitnessUtxoType:
+			if len(key) != 1 {
+				return ErrInvalidPacket
+			}
+			tx := wire.NewMsgTx(wire.TxVersion)
+			if err := tx.Deserialize(bytes.NewReader(value)); err != nil {
+				return ErrInvalidPacket
+			}
+			in.NonWitnessUtxo = tx
+		case inputPartialSigType:
+			if len(key) != 34 && len(key) != 66 {
+				return ErrInvalidPacket
+			}
+			in.PartialSigs = append(in.PartialSigs, &PartialSig{PubKey: key[1:], Signature: value})
+		case inputSighashType:
+			if len(key) != 1 || len(value) != 4 {
+				return ErrInvalidPacket
+			}
+			in.SighashType = txscript.SigHashType(binary.LittleEndian.Uint32(value))
+		case inputRedeemScriptType:
+			if len(key) != 1 {
+				return ErrInvalidPacket
+			}
+			in.RedeemScript = value
+		case inputBip32DerivationType:
+			d, err := parseBip32Derivation(key, value)
+			if err != nil {
+				return err
+			}
+			in.Bip32Derivation = append(in.Bip32Derivation, d)
+		case inputFinalScriptSigType:
+			if len(key) != 1 {
+				return ErrInvalidPacket
+			}
+			in.FinalScriptSig = value
+		default:
+			in.Unknowns = append(in.Unknowns, &Unknown{Key: key, Value: value})
+		}
+		return nil
+	})
+}
+
+func (in *PInput) serialize(w io.Writer) error {
+	if in.NonWitnessUtxo != nil {
+		var tx bytes.Buffer
+		if err := in.NonWitnessUtxo.Serialize(&tx); err != nil {
+			return err
+		}
+		if err := writeField(w, []byte{inputNonWitnessUtxoType}, tx.Bytes()); err != nil {
+			return err
+		}
+	}
+	for _, ps := range in.PartialSigs {
+		key := append([]byte{inputPartialSigType}, ps.PubKey...)
+		if err := writeField(w, key, ps.Signature); err != nil {
+			return err
+		}
+	}
+	if in.SighashType != 0 {
+		var v [4]byte
+		binary.LittleEndian.PutUint32(v[:], uint32(in.SighashType))
+		if err := writeField(w, []byte{inputSighashType}, v[:]); err != nil {
+			return err
+		}
+	}
+	if in.RedeemScript != nil {
+		if err := writeField(w, []byte{inputRedeemScriptType}, in.RedeemScript); err != nil {
+			return err
+		}
+	}
+	if err := writeBip32Derivation(w, inputBip32DerivationType, in.Bip32Derivation); err != nil {
+		return err
+	}
+	if in.FinalScriptSig != nil {
+		if err := writeField(w, []byte{inputFinalScriptSigType}, in.FinalScriptSig); err != nil {
+			return err
+		}
+	}
+	return writeUnknowns(w, in.Unknowns)
+}
+
+func (out *POutput) parse(r io.Reader) error {
+	return readMap(r, func(key, value []byte) error {
+		switch key[0] {
+		case outputRedeemScriptType:
+			if len(key) != 1 {
+				return ErrInvalidPacket
+			}
+			out.RedeemScript = value
+		case outputBip32DerivationType:
+			d, err := parseBip32Derivation(key, value)
+			if err != nil {
+				return err
+			}
+			out.Bip32Derivation = append(out.Bip32Derivation, d)
+		default:
+			out.Unknowns = append(out.Unknowns, &Unknown{Key: key, Value: value})
+		}
+		return nil
+	})
+}
+
+func (out *POutput) serialize(w io.Writer) error {
+	if out.RedeemScript != nil {
+		if err := writeField(w, []byte{outputRedeemScriptType}, out.RedeemScript); err != nil {
+			return err
+		}
+	}
+	if err := writeBip32Derivation(w, outputBip32DerivationType, out.Bip32Derivation); err != nil {
+		return err
+	}
+	return writeUnknowns(w, out.Unknowns)
+}
+
+// parseBip32Derivation parses a derivation field, keyed by the public key and holding the master key fingerprint
+// followed by the path.
+func parseBip32Derivation(key, value []byte) (*Bip32Derivation, error) {
+	if len(key) != 34 && len(key) != 66 || len(value) < 4 || len(value)%4 != 0 {
+		return nil, ErrInvalidPacket
+	}
+	d := &Bip32Derivation{
+		PubKey:               key[1:],
+		MasterKeyFingerprint: binary.LittleEndian.Uint32(value),
+	}
+	for i := 4; i < len(value); i += 4 {
+		d.Path = append(d.Path, binary.LittleEndian.Uint32(value[i:]))
+	}
+	return d, nil
+}
+
+// writeBip32Derivation writes the derivation fields of an input or output with the key type of the map.
+func writeBip32Derivation(w io.Writer, keyType byte, derivations []*Bip32Derivation) error {
+	for _, d := range derivations {
+		value := make([]byte, 4*(len(d.Path)+1))
+		binary.LittleEndian.PutUint32(value, d.MasterKeyFingerprint)
+		for i, index := range d.Path {
+			binary.LittleEndian.PutUint32(value[4*(i+1):], index)
+		}
+		if err := writeField(w, append([]byte{keyType}, d.PubKey...), value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readMap reads the key value pairs of one map up to its separator, passing each to fn.
+func readMap(r io.Reader, fn func(key, value []byte) error) error {
+	seen := make(map[string]struct{})
+	for {
+		key, err := wire.ReadVarBytes(r, 0, maxFieldSize, "psbt key")
+		if err != nil {
+			return ErrInvalidPacket
+		}
+		if len(key) == 0 {
+			return nil
+		}
+		if _, ok := seen[string(key)]; ok {
+			return ErrDuplicateKey
+		}
+		seen[string(key)] = struct{}{}
+		value, err := wire.ReadVarBytes(r, 0, maxFieldSize, "psbt value")
+		if err != nil {
+			return ErrInvalidPacket
+		}
+		if err = fn(key, value); err != nil {
+			return err
+		}
+	}
+}
+
+// writeField writes one key value pair.
+func writeField(w io.Writer, key, value []byte) error {
+	if err := wire.WriteVarBytes(w, 0, key); err != nil {
+		return err
+	}
+	return wire.WriteVarBytes(w, 0, value)
+}
+
+// writeUnknowns writes the unknown fields of a map followed by the map separator.
+func writeUnknowns(w io.Writer, unknowns []*Unknown) error {
+	for _, u := range unknowns {
+		if err := writeField(w, u.Key, u.Value); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write([]byte{0x00})
+	return err
+}
+
+// checkUnsigned returns an error if any input of tx carries a signature script or witness.
+func checkUnsigned(tx *wire.MsgTx) error {
+	for _, txIn := range tx.TxIn {
+		if len(txIn.SignatureScript) != 0 || len(txIn.Witness) != 0 {
+			return ErrUnsignedTxHasScripts
+		}
+	}
+	return nil
+}
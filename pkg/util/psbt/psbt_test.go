@@ -0,0 +1,262 @@
+package psbt_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/psbt"
+)
+
+// TestMultisigRoundTrip collects the signatures of a 2 of 3 multisig spend in separate packets, combines them,
+// finalizes the result and checks the extracted transaction passes script validation.
+func TestMultisigRoundTrip(t *testing.T) {
+	params := &netparams.TestNet3Params
+	keys := make([]*ec.PrivateKey, 3)
+	pubKeys := make([]*util.AddressPubKey, 3)
+	for i := range keys {
+		seed := bytes.Repeat([]byte{byte(i + 1)}, 32)
+		keys[i], _ = ec.PrivKeyFromBytes(ec.S256(), seed)
+		var err error
+		pubKeys[i], err = util.NewAddressPubKey(keys[i].PubKey().SerializeCompressed(), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	redeemScript, err := txscript.MultiSigScript(pubKeys, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p2sh, err := util.NewAddressScriptHash(redeemScript, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(p2sh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevTx := wire.NewMsgTx(wire.TxVersion)
+	prevTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 0}, []byte{txscript.OP_TRUE}, nil))
+	prevTx.AddTxOut(wire.NewTxOut(100000, pkScript))
+	prevHash := prevTx.TxHash()
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 0), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(90000, pkScript))
+	p, err := psbt.New(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Inputs[0].NonWitnessUtxo = prevTx
+	p.Inputs[0].RedeemScript = redeemScript
+	p.Inputs[0].SighashType = txscript.SigHashAll
+	encoded, err := p.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Two cosigners sign their own copies of the packet.
+	signed := make([]*psbt.Packet, 2)
+	for i := range signed {
+		if signed[i], err = psbt.NewFromRawBytes(strings.NewReader(encoded), true); err != nil {
+			t.Fatal(err)
+		}
+		sig, err := txscript.RawTxInSignature(tx, 0, redeemScript, txscript.SigHashAll, keys[i*2])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !signed[i].AddPartialSig(0, pubKeys[i*2].ScriptAddress(), sig) {
+			t.Fatal("signature was not added")
+		}
+	}
+	if have, need := signed[0].Inputs[0].Progress(); have != 1 || need != 2 {
+		t.Fatalf("got progress %d/%d, want 1/2", have, need)
+	}
+	if complete, err := psbt.MaybeFinalizeAll(signed[0]); err != nil || complete {
+		t.Fatalf("packet with one signature finalized: %v", err)
+	}
+	if err = signed[0].Combine(signed[1]); err != nil {
+		t.Fatal(err)
+	}
+	// The combined packet must survive a round trip before it is finalized.
+	var buf bytes.Buffer
+	if err = signed[0].Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	combined, err := psbt.NewFromRawBytes(&buf, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(combined.Inputs[0].PartialSigs) != 2 {
+		t.Fatalf("got %d partial signatures, want 2", len(combined.Inputs[0].PartialSigs))
+	}
+	complete, err := psbt.MaybeFinalizeAll(combined)
+	if err != nil || !complete {
+		t.Fatalf("packet was not finalized: %v", err)
+	}
+	if have, need := combined.Inputs[0].Progress(); have != 2 || need != 2 {
+		t.Fatalf("got progress %d/%d after finalizing, want 2/2", have, need)
+	}
+	final, err := psbt.Extract(combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := txscript.NewEngine(pkScript, final, 0, txscript.StandardVerifyFlags, nil, nil, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = vm.Execute(); err != nil {
+		t.Fatalf("extracted transaction failed validation: %v", err)
+	}
+}
+
+// TestPubKeyHashRoundTrip has a pay to pubkey hash input signed the way a hardware wallet signs it, finding its key
+// from the derivation of the input, and checks the finalized transaction passes script validation.
+func TestPubKeyHashRoundTrip(t *testing.T) {
+	params := &netparams.TestNet3Params
+	key, _ := ec.PrivKeyFromBytes(ec.S256(), bytes.Repeat([]byte{1}, 32))
+	pubKey := key.PubKey().SerializeCompressed()
+	addr, err := util.NewAddressPubKeyHash(util.Hash160(pubKey), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkScript, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevTx := wire.NewMsgTx(wire.TxVersion)
+	prevTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 0}, []byte{txscript.OP_TRUE}, nil))
+	prevTx.AddTxOut(wire.NewTxOut(50000, []byte{txscript.OP_TRUE}))
+	prevTx.AddTxOut(wire.NewTxOut(100000, pkScript))
+	prevHash := prevTx.TxHash()
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, 1), nil, nil))
+	tx.AddTxOut(wire.NewTxOut(90000, pkScript))
+	p, err := psbt.New(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.Fee(); ok {
+		t.Fatal("fee is known without the previous transaction")
+	}
+	derivation := []*psbt.Bip32Derivation{{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: 0xdeadbeef,
+		Path:                 []uint32{44 + 0x80000000, 1 + 0x80000000, 0x80000000, 0, 7},
+	}}
+	p.Inputs[0].NonWitnessUtxo = prevTx
+	p.Inputs[0].Bip32Derivation = derivation
+	p.Outputs[0].Bip32Derivation = derivation
+	if fee, ok := p.Fee(); !ok || fee != 10000 {
+		t.Fatalf("got fee %v, want 10000", fee)
+	}
+	if err = psbt.Finalize(p, 0); err != psbt.ErrNotEnoughSignatures {
+		t.Fatalf("got error %v finalizing an unsigned input, want %v", err, psbt.ErrNotEnoughSignatures)
+	}
+	var buf bytes.Buffer
+	if err = p.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := psbt.NewFromRawBytes(&buf, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range [][]*psbt.Bip32Derivation{signer.Inputs[0].Bip32Derivation, signer.Outputs[0].Bip32Derivation} {
+		if len(d) != 1 || !bytes.Equal(d[0].PubKey, pubKey) || d[0].MasterKeyFingerprint != 0xdeadbeef ||
+			len(d[0].Path) != 5 || d[0].Path[4] != 7 {
+			t.Fatalf("derivation did not survive a round trip: %+v", d)
+		}
+	}
+	sig, err := txscript.RawTxInSignature(tx, 0, pkScript, txscript.SigHashAll, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer.AddPartialSig(0, pubKey, sig)
+	if err = p.Combine(signer); err != nil {
+		t.Fatal(err)
+	}
+	complete, err := psbt.MaybeFinalizeAll(p)
+	if err != nil || !complete {
+		t.Fatalf("packet was not finalized: %v", err)
+	}
+	final, err := psbt.Extract(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := txscript.NewEngine(pkScript, final, 0, txscript.StandardVerifyFlags, nil, nil, 100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = vm.Execute(); err != nil {
+		t.Fatalf("extracted transaction failed validation: %v", err)
+	}
+}
+
+// TestNewFromRawBytesErrors checks that malformed packets are rejected.
+func TestNewFromRawBytesErrors(t *testing.T) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 0}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1, nil))
+	p, err := psbt.New(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = p.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	valid := buf.Bytes()
+	tests := []struct {
+		name string
+		raw  []byte
+		err  error
+	}{
+		{"bad magic", append([]byte("psbx"), valid[4:]...), psbt.ErrInvalidMagic},
+		{"truncated", valid[:len(valid)-1], psbt.ErrInvalidPacket},
+		{"no transaction", append(append([]byte{}, valid[:5]...), 0x00), psbt.ErrInvalidPacket},
+	}
+	for _, test := range tests {
+		if _, err := psbt.NewFromRawBytes(bytes.NewReader(test.raw), false); err != test.err {
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+		}
+	}
+	signedTx := tx.Copy()
+	signedTx.TxIn[0].SignatureScript = []byte{txscript.OP_TRUE}
+	if _, err = psbt.New(signedTx); err != psbt.ErrUnsignedTxHasScripts {
+		t.Errorf("got error %v for signed transaction, want %v", err, psbt.ErrUnsignedTxHasScripts)
+	}
+}
+
+// TestParse checks that a packet is read both in the binary format and as base64 with white space around it.
+func TestParse(t *testing.T) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1, nil))
+	p, err := psbt.New(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err = p.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	b64, err := p.B64Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, raw := range [][]byte{buf.Bytes(), []byte(b64), []byte(" " + b64 + "\n")} {
+		parsed, err := psbt.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed.UnsignedTx.TxHash() != tx.TxHash() {
+			t.Fatalf("parsed transaction %v, want %v", parsed.UnsignedTx.TxHash(), tx.TxHash())
+		}
+	}
+	if _, err = psbt.Parse([]byte("not a packet")); err == nil {
+		t.Fatal("text that is not a packet was parsed")
+	}
+}
@@ -0,0 +1,91 @@
+package util
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+)
+
+// URIScheme is the scheme of payment URIs, which are shared as text or QR codes to ask for a payment
+const URIScheme = "parallelcoin"
+
+// PaymentURI is a request for a payment to an address, optionally for an amount, in the format of BIP21:
+//
+//	parallelcoin:<address>[?amount=<DUO>][&label=<label>][&message=<message>]
+type PaymentURI struct {
+	Address Address
+	Amount  Amount
+	Label   string
+	Message string
+}
+
+// String encodes the payment request as a URI, leaving out the parameters that are not set
+func (p PaymentURI) String() string {
+	q := url.Values{}
+	if p.Amount > 0 {
+		q.Set("amount", strconv.FormatFloat(p.Amount.ToDUO(), 'f', -1, 64))
+	}
+	if p.Label != "" {
+		q.Set("label", p.Label)
+	}
+	if p.Message != "" {
+		q.Set("message", p.Message)
+	}
+	uri := URIScheme + ":" + p.Address.EncodeAddress()
+	if len(q) > 0 {
+		// spaces are written as %20 rather than +, which BIP21 does not decode
+		uri += "?" + strings.Replace(q.Encode(), "+", "%20", -1)
+	}
+	return uri
+}
+
+// ParsePaymentURI decodes a payment URI for an address on the network. A bare address is accepted too, as it is what
+// many QR codes hold. Parameters that are not understood are ignored unless they are prefixed with req-, which BIP21
+// says means the payment must not be made.
+func ParsePaymentURI(uri string, net *netparams.Params) (p PaymentURI, err error) {
+	uri = strings.TrimSpace(uri)
+	address, query := uri, ""
+	if i := strings.Index(uri, ":"); i >= 0 {
+		if !strings.EqualFold(uri[:i], URIScheme) {
+			return p, errors.New("not a " + URIScheme + " payment URI")
+		}
+		address = strings.TrimPrefix(uri[i+1:], "//")
+	}
+	if i := strings.Index(address, "?"); i >= 0 {
+		address, query = address[:i], address[i+1:]
+	}
+	if p.Address, err = DecodeAddress(address, net); err != nil {
+		return
+	}
+	if !p.Address.IsForNet(net) {
+		return p, errors.New("the address is for another network")
+	}
+	var q url.Values
+	if q, err = url.ParseQuery(query); err != nil {
+		return
+	}
+	for k, v := range q {
+		switch k {
+		case "amount":
+			var f float64
+			if f, err = strconv.ParseFloat(v[0], 64); err != nil || f < 0 {
+				return p, errors.New("invalid amount in payment URI")
+			}
+			if p.Amount, err = NewAmount(f); err != nil {
+				return
+			}
+		case "label":
+			p.Label = v[0]
+		case "message":
+			p.Message = v[0]
+		default:
+			if strings.HasPrefix(k, "req-") {
+				return p, errors.New("payment URI requires " + k + " which is not supported")
+			}
+		}
+	}
+	return
+}
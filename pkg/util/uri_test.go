@@ -0,0 +1,55 @@
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/util"
+)
+
+func TestPaymentURI(t *testing.T) {
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), &netparams.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := util.PaymentURI{Address: addr, Amount: 150000000, Label: "Coffee & cake", Message: "table 4"}
+	uri := p.String()
+	want := "parallelcoin:" + addr.EncodeAddress() + "?amount=1.5&label=Coffee%20%26%20cake&message=table%204"
+	if uri != want {
+		t.Fatalf("got %s, want %s", uri, want)
+	}
+	var parsed util.PaymentURI
+	if parsed, err = util.ParsePaymentURI(uri, &netparams.MainNetParams); err != nil {
+		t.Fatal(err)
+	}
+	if parsed.Address.EncodeAddress() != addr.EncodeAddress() || parsed.Amount != p.Amount ||
+		parsed.Label != p.Label || parsed.Message != p.Message {
+		t.Fatalf("parsed %+v from %s", parsed, uri)
+	}
+	if uri = (util.PaymentURI{Address: addr}).String(); uri != "parallelcoin:"+addr.EncodeAddress() {
+		t.Fatalf("got %s for a bare address", uri)
+	}
+	tests := []struct {
+		name  string
+		uri   string
+		valid bool
+	}{
+		{"bare address", addr.EncodeAddress(), true},
+		{"upper case scheme", "PARALLELCOIN:" + addr.EncodeAddress() + "?amount=2", true},
+		{"unknown parameter", "parallelcoin:" + addr.EncodeAddress() + "?foo=bar", true},
+		{"required parameter", "parallelcoin:" + addr.EncodeAddress() + "?req-foo=bar", false},
+		{"other scheme", "bitcoin:" + addr.EncodeAddress(), false},
+		{"negative amount", "parallelcoin:" + addr.EncodeAddress() + "?amount=-1", false},
+		{"invalid address", "parallelcoin:" + strings.ToLower(addr.EncodeAddress()), false},
+	}
+	for _, test := range tests {
+		_, err = util.ParsePaymentURI(test.uri, &netparams.MainNetParams)
+		if (err == nil) != test.valid {
+			t.Errorf("%s: %s gave error %v", test.name, test.uri, err)
+		}
+	}
+	if _, err = util.ParsePaymentURI(addr.EncodeAddress(), &netparams.TestNet3Params); err == nil {
+		t.Error("accepted a mainnet address on testnet")
+	}
+}
@@ -3,6 +3,7 @@ package waddrmgr
 import (
 	"crypto/rand"
 	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
 	"sync"
 	"time"
@@ -124,6 +125,8 @@ type AccountProperties struct {
 	ExternalKeyCount uint32
 	InternalKeyCount uint32
 	ImportedKeyCount uint32
+	// AccountPubKey is the extended public key of the account, it is nil for the imported account.
+	AccountPubKey *hdkeychain.ExtendedKey
 }
 
 // unlockDeriveInfo houses the information needed to derive a private key for a managed address when the address manager
@@ -270,6 +273,38 @@ func (m *Manager) WatchOnly() bool {
 	return m.watchingOnly
 }
 
+// MasterKeyFingerprint returns the BIP0032 fingerprint of the master key the wallet's accounts are derived from, the
+// first four bytes of the hash160 of its public key read in little endian order. Watching-only wallets created from an
+// account key do not know their master key.
+func (m *Manager) MasterKeyFingerprint(ns walletdb.ReadBucket) (uint32, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	_, masterHDPubEnc, err := fetchMasterHDKeys(ns)
+	if err != nil {
+		Error(err)
+		return 0, err
+	}
+	if masterHDPubEnc == nil {
+		return 0, managerError(ErrWatchingOnly, "the master key of the wallet is not known", nil)
+	}
+	serializedKey, err := m.cryptoKeyPub.Decrypt(masterHDPubEnc)
+	if err != nil {
+		str := "failed to decrypt master public key"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+	masterKey, err := hdkeychain.NewKeyFromString(string(serializedKey))
+	if err != nil {
+		str := "failed to parse master public key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+	pubKey, err := masterKey.ECPubKey()
+	if err != nil {
+		str := "failed to get master public key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+	return binary.LittleEndian.Uint32(util.Hash160(pubKey.SerializeCompressed())[:4]), nil
+}
+
 // lock performs a best try effort to remove and zero all secret keys associated with the address manager.
 //
 // This function MUST be called with the manager lock held for writes.
@@ -606,6 +641,22 @@ func (m *Manager) ChainParams() *netparams.Params {
 	return m.chainParams
 }
 
+// CheckPublicPassphrase returns ErrWrongPassphrase if the passphrase is not the public passphrase of the manager.
+func (m *Manager) CheckPublicPassphrase(passphrase []byte) error {
+	m.mtx.RLock()
+	secretKey := snacl.SecretKey{Key: &snacl.CryptoKey{}}
+	secretKey.Parameters = m.masterKeyPub.Parameters
+	m.mtx.RUnlock()
+	defer secretKey.Zero()
+	if err := secretKey.DeriveKey(&passphrase); err != nil {
+		if err == snacl.ErrInvalidPassword {
+			return managerError(ErrWrongPassphrase, "invalid passphrase for public master key", nil)
+		}
+		return managerError(ErrCrypto, "failed to derive public master key", err)
+	}
+	return nil
+}
+
 // ChangePassphrase changes either the public or private passphrase to the provided value depending on the private flag.
 // In order to change the private password, the address manager must not be watching-only.
 //
@@ -1576,3 +1627,103 @@ func Create(ns walletdb.ReadWriteBucket, seed, pubPassphrase, privPassphrase []b
 	// Use 48 hours as margin of safety for wallet birthday.
 	return putBirthday(ns, birthday.Add(-48*time.Hour))
 }
+
+// CreateWatchingOnly creates a new watching-only address manager in the given namespace from the extended public key of
+// an account, such as is returned by getaccountxpub. The account becomes the default account of the BIP0044 scope, so
+// its addresses are derived and watched as they are in the wallet holding its private keys, which this manager never
+// has and so cannot be unlocked.
+//
+// The public passphrase protects the extended public key as it does in a manager created from a seed. A ManagerError
+// with an error code of ErrAlreadyExists will be returned the address manager already exists in the specified
+// namespace.
+func CreateWatchingOnly(ns walletdb.ReadWriteBucket, accountPubKey *hdkeychain.ExtendedKey, pubPassphrase []byte,
+	chainParams *netparams.Params, config *ScryptOptions, birthday time.Time) error {
+	if managerExists(ns) {
+		return managerError(ErrAlreadyExists, errAlreadyExists, nil)
+	}
+	if accountPubKey.IsPrivate() {
+		str := "the account key must be an extended public key"
+		return managerError(ErrKeyChain, str, nil)
+	}
+	if !accountPubKey.IsForNet(chainParams) {
+		str := "the account key is for another network"
+		return managerError(ErrWrongNet, str, nil)
+	}
+	// An account key is three levels below the root, at m/purpose'/cointype'/account'.
+	if accountPubKey.Depth() != 3 {
+		str := fmt.Sprintf("the key is at depth %d rather than that of an account", accountPubKey.Depth())
+		return managerError(ErrKeyChain, str, nil)
+	}
+	if err := checkBranchKeys(accountPubKey); err != nil {
+		str := "the account key is unusable"
+		return managerError(ErrKeyChain, str, err)
+	}
+	// Only the scope of the account is created, as the keys of the others cannot be derived from it.
+	scopes := map[KeyScope]ScopeAddrSchema{KeyScopeBIP0044: ScopeAddrMap[KeyScopeBIP0044]}
+	if err := createManagerNS(ns, scopes); err != nil {
+		return maybeConvertDbError(err)
+	}
+	if config == nil {
+		config = &DefaultScryptOptions
+	}
+	masterKeyPub, err := newSecretKey(&pubPassphrase, config)
+	if err != nil {
+		Error(err)
+		str := "failed to master public key"
+		return managerError(ErrCrypto, str, err)
+	}
+	cryptoKeyPub, err := newCryptoKey()
+	if err != nil {
+		Error(err)
+		str := "failed to generate crypto public key"
+		return managerError(ErrCrypto, str, err)
+	}
+	cryptoKeyPubEnc, err := masterKeyPub.Encrypt(cryptoKeyPub.Bytes())
+	if err != nil {
+		Error(err)
+		str := "failed to encrypt crypto public key"
+		return managerError(ErrCrypto, str, err)
+	}
+	acctPubEnc, err := cryptoKeyPub.Encrypt([]byte(accountPubKey.String()))
+	if err != nil {
+		Error(err)
+		str := "failed to  encrypt public key for account 0"
+		return managerError(ErrCrypto, str, err)
+	}
+	// The private key parameters are left out, as they are once a manager is converted to watching-only.
+	if err = putMasterKeyParams(ns, masterKeyPub.Marshal(), nil); err != nil {
+		Error(err)
+		return maybeConvertDbError(err)
+	}
+	if err = putCryptoKeys(ns, cryptoKeyPubEnc, nil, nil); err != nil {
+		Error(err)
+		return maybeConvertDbError(err)
+	}
+	scope := KeyScopeBIP0044
+	err = putAccountInfo(ns, &scope, DefaultAccountNum, acctPubEnc, nil, 0, 0, defaultAccountName)
+	if err != nil {
+		Error(err)
+		return maybeConvertDbError(err)
+	}
+	err = putAccountInfo(ns, &scope, ImportedAddrAccount, nil, nil, 0, 0, ImportedAddrAccountName)
+	if err != nil {
+		Error(err)
+		return maybeConvertDbError(err)
+	}
+	if err = putWatchingOnly(ns, true); err != nil {
+		Error(err)
+		return maybeConvertDbError(err)
+	}
+	// The history of the account is older than the manager, so the sync state starts at the genesis block.
+	createdAt := &BlockStamp{Hash: *chainParams.GenesisHash, Height: 0}
+	syncInfo := newSyncState(createdAt, createdAt)
+	if err = putSyncedTo(ns, &syncInfo.syncedTo); err != nil {
+		Error(err)
+		return maybeConvertDbError(err)
+	}
+	if err = putStartBlock(ns, &syncInfo.startBlock); err != nil {
+		Error(err)
+		return maybeConvertDbError(err)
+	}
+	return putBirthday(ns, birthday.Add(-48*time.Hour))
+}
@@ -3,6 +3,7 @@ package waddrmgr_test
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"github.com/p9c/pod/pkg/coding/snacl"
 	"github.com/p9c/pod/pkg/db/walletdb"
 	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
 	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
 )
 
@@ -2157,3 +2159,156 @@ func TestNewRawAccount(t *testing.T) {
 			accountTargetAddr.AddrHash())
 	}
 }
+
+// TestCreateWatchingOnly ensures a watching-only manager created from the extended public key of an account derives the
+// same addresses as the manager holding the account's private keys, and cannot be unlocked.
+func TestCreateWatchingOnly(t *testing.T) {
+	t.Parallel()
+	teardown, db := emptyDB(t)
+	defer teardown()
+	var mgr *waddrmgr.Manager
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+		if err != nil {
+			return err
+		}
+		err = waddrmgr.Create(
+			ns, seed, pubPassphrase, privPassphrase,
+			&netparams.MainNetParams, fastScrypt, time.Time{},
+		)
+		if err != nil {
+			return err
+		}
+		mgr, err = waddrmgr.Open(ns, pubPassphrase, &netparams.MainNetParams)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("create/open: unexpected error: %v", err)
+	}
+	defer mgr.Close()
+	scopedMgr, err := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		t.Fatalf("unable to fetch scope %v: %v", waddrmgr.KeyScopeBIP0044, err)
+	}
+	var acctPubKey *hdkeychain.ExtendedKey
+	var wantAddrs []waddrmgr.ManagedAddress
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		props, err := scopedMgr.AccountProperties(ns, waddrmgr.DefaultAccountNum)
+		if err != nil {
+			return err
+		}
+		acctPubKey = props.AccountPubKey
+		wantAddrs, err = scopedMgr.NextExternalAddresses(ns, waddrmgr.DefaultAccountNum, 3)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to derive addresses: %v", err)
+	}
+	teardownWatch, watchDB := emptyDB(t)
+	defer teardownWatch()
+	var watchMgr *waddrmgr.Manager
+	err = walletdb.Update(watchDB, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+		if err != nil {
+			return err
+		}
+		acctPrivKey, err := hdkeychain.NewMaster(seed, &netparams.MainNetParams)
+		if err != nil {
+			return err
+		}
+		err = waddrmgr.CreateWatchingOnly(
+			ns, acctPrivKey, pubPassphrase, &netparams.MainNetParams, fastScrypt, time.Time{},
+		)
+		if !checkManagerError(t, "private key", err, waddrmgr.ErrKeyChain) {
+			return fmt.Errorf("created a watching-only manager from a private key")
+		}
+		err = waddrmgr.CreateWatchingOnly(
+			ns, acctPubKey, pubPassphrase, &netparams.MainNetParams, fastScrypt, time.Time{},
+		)
+		if err != nil {
+			return err
+		}
+		watchMgr, err = waddrmgr.Open(ns, pubPassphrase, &netparams.MainNetParams)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("create/open watching-only: unexpected error: %v", err)
+	}
+	defer watchMgr.Close()
+	if !watchMgr.WatchOnly() {
+		t.Fatal("manager created from an account key is not watching-only")
+	}
+	err = walletdb.View(watchDB, func(tx walletdb.ReadTx) error {
+		_, err := watchMgr.MasterKeyFingerprint(tx.ReadBucket(waddrmgrNamespaceKey))
+		return err
+	})
+	checkManagerError(t, "master key fingerprint", err, waddrmgr.ErrWatchingOnly)
+	err = walletdb.View(watchDB, func(tx walletdb.ReadTx) error {
+		return watchMgr.Unlock(tx.ReadBucket(waddrmgrNamespaceKey), privPassphrase)
+	})
+	checkManagerError(t, "unlock", err, waddrmgr.ErrWatchingOnly)
+	watchScopedMgr, err := watchMgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		t.Fatalf("unable to fetch scope %v: %v", waddrmgr.KeyScopeBIP0044, err)
+	}
+	err = walletdb.Update(watchDB, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		gotAddrs, err := watchScopedMgr.NextExternalAddresses(ns, waddrmgr.DefaultAccountNum, 3)
+		if err != nil {
+			return err
+		}
+		for i := range wantAddrs {
+			if gotAddrs[i].Address().EncodeAddress() != wantAddrs[i].Address().EncodeAddress() {
+				t.Errorf("address %d: got %s, want %s", i, gotAddrs[i].Address(), wantAddrs[i].Address())
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unable to derive watching-only addresses: %v", err)
+	}
+}
+
+// TestMasterKeyFingerprint ensures the fingerprint of the master key matches the one derived from the seed.
+func TestMasterKeyFingerprint(t *testing.T) {
+	t.Parallel()
+	teardown, db := emptyDB(t)
+	defer teardown()
+	var fingerprint uint32
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+		if err != nil {
+			return err
+		}
+		err = waddrmgr.Create(
+			ns, seed, pubPassphrase, privPassphrase,
+			&netparams.MainNetParams, fastScrypt, time.Time{},
+		)
+		if err != nil {
+			return err
+		}
+		mgr, err := waddrmgr.Open(ns, pubPassphrase, &netparams.MainNetParams)
+		if err != nil {
+			return err
+		}
+		defer mgr.Close()
+		fingerprint, err = mgr.MasterKeyFingerprint(ns)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unable to get the master key fingerprint: %v", err)
+	}
+	masterKey, err := hdkeychain.NewMaster(seed, &netparams.MainNetParams)
+	if err != nil {
+		t.Fatalf("unable to create master key: %v", err)
+	}
+	pubKey, err := masterKey.ECPubKey()
+	if err != nil {
+		t.Fatalf("unable to get master public key: %v", err)
+	}
+	want := binary.LittleEndian.Uint32(util.Hash160(pubKey.SerializeCompressed())[:4])
+	if fingerprint != want {
+		t.Fatalf("got fingerprint %08x, want %08x", fingerprint, want)
+	}
+}
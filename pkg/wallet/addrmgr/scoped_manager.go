@@ -369,6 +369,7 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 		props.AccountName = acctInfo.acctName
 		props.ExternalKeyCount = acctInfo.nextExternalIndex
 		props.InternalKeyCount = acctInfo.nextInternalIndex
+		props.AccountPubKey = acctInfo.acctKeyPub
 	} else {
 		props.AccountName = ImportedAddrAccountName // reserved, nonchangable
 		// Could be more efficient if this was tracked by the db.
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestBalanceSnapshotBuckets checks that each output is counted in exactly one bucket, with immature coinbase rewards
+// and locked outputs taking precedence over the confirmation buckets.
+func TestBalanceSnapshotBuckets(t *testing.T) {
+	s := BalanceSnapshot{Height: 200}
+	outputs := []struct {
+		amount   util.Amount
+		height   int32
+		coinbase bool
+		locked   bool
+	}{
+		{1, -1, false, false},
+		{2, 200, false, false},
+		{4, 196, false, false},
+		{8, 195, false, false},
+		{16, 150, true, false},
+		{32, 100, true, false},
+		{64, 10, false, true},
+		{128, 199, true, true},
+	}
+	for _, o := range outputs {
+		s.add(o.amount, o.height, o.coinbase, o.locked, 100)
+	}
+	want := BalanceSnapshot{
+		Height:         200,
+		Unconfirmed:    1,
+		Confirming:     2 + 4,
+		Confirmed:      8 + 32,
+		ImmatureReward: 16 + 128,
+		Locked:         64,
+		Total:          255,
+	}
+	if s != want {
+		t.Errorf("got %+v, want %+v", s, want)
+	}
+}
@@ -1,6 +1,7 @@
 package chain
 
 import (
+	"encoding/hex"
 	"errors"
 	"sync"
 	"time"
@@ -182,17 +183,22 @@ func (c *RPCClient) FilterBlocks(
 	// watchlist generated above. If the filter returns a positive match, the full block is then requested and scanned
 	// for addresses using the block filterer.
 	for i, blk := range req.Blocks {
-		rawFilter, err := c.GetCFilter(&blk.Hash, wire.GCSFilterRegular)
+		blockFilter, err := c.GetBlockFilter(blk.Hash, nil)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		rawFilter, err := hex.DecodeString(blockFilter.Filter)
 		if err != nil {
 			Error(err)
 			return nil, err
 		}
 		// Ensure the filter is large enough to be deserialized.
-		if len(rawFilter.Data) < 4 {
+		if len(rawFilter) < 4 {
 			continue
 		}
 		filter, err := gcs.FromNBytes(
-			builder.DefaultP, builder.DefaultM, rawFilter.Data,
+			builder.DefaultP, builder.DefaultM, rawFilter,
 		)
 		if err != nil {
 			Error(err)
@@ -25,7 +25,7 @@ func (w *Wallet) handleChainNotifications() {
 		if w.db != nil {
 			// At the moment there is no recourse if the rescan fails for some reason, however, the wallet will not be
 			// marked synced and many methods will error early since the wallet is known to be out of date.
-			err := w.syncWithChain()
+			err := w.reconcileWithChain()
 			if err != nil && !w.ShuttingDown() {
 				Warn("unable to synchronize wallet to chain:", err)
 			}
@@ -93,6 +93,9 @@ func (w *Wallet) handleChainNotifications() {
 				err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
 					return w.connectBlock(tx, tm.BlockMeta(n))
 				})
+				if err == nil {
+					w.settlePaymentRequests(n.Time)
+				}
 				notificationName = "blockconnected"
 			case chain.BlockDisconnected:
 				err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
@@ -103,6 +106,9 @@ func (w *Wallet) handleChainNotifications() {
 				err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
 					return w.addRelevantTx(tx, n.TxRecord, n.Block)
 				})
+				if err == nil && n.Block != nil {
+					w.countRescanFound(1)
+				}
 				notificationName = "recvtx/redeemingtx"
 			case chain.FilteredBlockConnected:
 				// Atomically update for the whole block.
@@ -120,6 +126,9 @@ func (w *Wallet) handleChainNotifications() {
 						}
 						return nil
 					})
+					if err == nil {
+						w.countRescanFound(len(n.RelevantTxs))
+					}
 				}
 				notificationName = "filteredblockconnected"
 			// The following require some database maintenance, but also need to be reported to the wallet's rescan
@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// cpfpEnabled returns whether the wallet spends its own unconfirmed change and raises the fee of the spending
+// transaction to pay for unconfirmed parents (child pays for parent).
+func (w *Wallet) cpfpEnabled() bool {
+	return w.PodConfig != nil && w.PodConfig.CPFP != nil && *w.PodConfig.CPFP
+}
+
+// isUnminedChange returns whether the outpoint is a change output of an unmined transaction the wallet created itself,
+// which unlike unconfirmed outputs received from others cannot be double spent out from under the wallet.
+func (w *Wallet) isUnminedChange(txmgrNs walletdb.ReadBucket, op *wire.OutPoint) bool {
+	details, err := w.TxStore.TxDetails(txmgrNs, &op.Hash)
+	if err != nil || details == nil || len(details.Debits) == 0 {
+		return false
+	}
+	for _, c := range details.Credits {
+		if c.Index == op.Index {
+			return c.Change
+		}
+	}
+	return false
+}
+
+// unminedAncestors returns an ancestor source reporting the total fee and virtual size of the unmined wallet
+// transactions that inputs spend from, following their own unmined inputs back to the confirmed chain. Transactions
+// whose fee can not be determined, because they spend outputs the wallet does not know about, are left out.
+func (w *Wallet) unminedAncestors(txmgrNs walletdb.ReadBucket) txauthor.AncestorSource {
+	return func(inputs []*wire.TxIn) (fees util.Amount, size int, err error) {
+		seen := make(map[chainhash.Hash]struct{})
+		queue := make([]chainhash.Hash, 0, len(inputs))
+		for _, in := range inputs {
+			queue = append(queue, in.PreviousOutPoint.Hash)
+		}
+		for len(queue) > 0 {
+			hash := queue[0]
+			queue = queue[1:]
+			if _, ok := seen[hash]; ok {
+				continue
+			}
+			seen[hash] = struct{}{}
+			var details *wtxmgr.TxDetails
+			if details, err = w.TxStore.TxDetails(txmgrNs, &hash); err != nil {
+				Error(err)
+				return 0, 0, err
+			}
+			if details == nil || details.Block.Height != -1 {
+				continue
+			}
+			for _, in := range details.MsgTx.TxIn {
+				queue = append(queue, in.PreviousOutPoint.Hash)
+			}
+			// The fee is only known when every input spends a wallet output.
+			if len(details.Debits) != len(details.MsgTx.TxIn) {
+				continue
+			}
+			var in, out util.Amount
+			for _, d := range details.Debits {
+				in += d.Amount
+			}
+			for _, o := range details.MsgTx.TxOut {
+				out += util.Amount(o.Value)
+			}
+			fees += in - out
+			size += int(txVirtualSize(&details.MsgTx))
+		}
+		return fees, size, nil
+	}
+}
+
+// PreviewTx creates the unsigned transaction that sending to outputs from the account would produce, without deriving
+// a change address, locking the inputs or broadcasting anything. It is used to show the fee, and the fee rate of the
+// transaction together with any unconfirmed parents, before the user confirms a send.
+func (w *Wallet) PreviewTx(outputs []*wire.TxOut, account uint32, minconf int32,
+	satPerKb util.Amount) (tx *txauthor.AuthoredTx, err error) {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	bs, err := chainClient.BlockStamp()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// The change script is only used for its size, which matches the P2WPKH change addresses the wallet derives.
+	changeSource := func() ([]byte, error) {
+		return append([]byte{0x00, 0x14}, make([]byte, 20)...), nil
+	}
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		tx, err = w.authorTx(dbtx, outputs, account, minconf, satPerKb, bs, changeSource)
+		return err
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return tx, nil
+}
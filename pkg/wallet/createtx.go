@@ -26,6 +26,11 @@ func makeInputSource(eligible []wtxmgr.Credit) txauthor.InputSource {
 	// Pick largest outputs first. This is only done for compatibility with previous tx creation code, not because it's
 	// a good idea.
 	sort.Sort(sort.Reverse(byAmount(eligible)))
+	// Confirmed outputs are used before unconfirmed ones so the transaction only depends on unconfirmed parents, and
+	// pays towards their fees, when there is no other way to fund it.
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return eligible[i].Height != -1 && eligible[j].Height == -1
+	})
 	// Current inputs and their total value. These are closed over by the returned input source and reused across
 	// multiple calls.
 	currentTotal := util.Amount(0)
@@ -106,12 +111,6 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
 			Error(err)
 			return err
 		}
-		eligible, err := w.findEligibleOutputs(dbtx, account, minconf, bs)
-		if err != nil {
-			Error(err)
-			return err
-		}
-		inputSource := makeInputSource(eligible)
 		changeSource := func() ([]byte, error) {
 			// Derive the change output script. As a hack to allow spending from the imported account, change addresses
 			// are created from account 0.
@@ -128,8 +127,7 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
 			}
 			return txscript.PayToAddrScript(changeAddr)
 		}
-		tx, err = txauthor.NewUnsignedTransaction(outputs, feeSatPerKb,
-			inputSource, changeSource)
+		tx, err = w.authorTx(dbtx, outputs, account, minconf, feeSatPerKb, bs, changeSource)
 		if err != nil {
 			Error(err)
 			return err
@@ -159,6 +157,39 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
 	}
 	return tx, nil
 }
+
+// authorTx creates an unsigned transaction paying to outputs from the eligible outputs of the account. When CPFP is
+// enabled the fee is raised to cover any unconfirmed parents that were sent with too low a fee, otherwise the
+// unconfirmed parents are only recorded so the package fee rate can be shown.
+func (w *Wallet) authorTx(dbtx walletdb.ReadTx, outputs []*wire.TxOut, account uint32, minconf int32,
+	feeSatPerKb util.Amount, bs *waddrmgr.BlockStamp, changeSource txauthor.ChangeSource) (*txauthor.AuthoredTx, error) {
+	eligible, err := w.findEligibleOutputs(dbtx, account, minconf, bs)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return w.authorTxFrom(dbtx, outputs, makeInputSource(eligible), feeSatPerKb, changeSource)
+}
+
+// authorTxFrom creates an unsigned transaction paying to outputs from the outputs picked by inputSource, handling
+// unconfirmed parents the way authorTx does.
+func (w *Wallet) authorTxFrom(dbtx walletdb.ReadTx, outputs []*wire.TxOut, inputSource txauthor.InputSource,
+	feeSatPerKb util.Amount, changeSource txauthor.ChangeSource) (*txauthor.AuthoredTx, error) {
+	ancestors := w.unminedAncestors(dbtx.ReadBucket(wtxmgrNamespaceKey))
+	if w.cpfpEnabled() {
+		return txauthor.NewUnsignedPackageTransaction(outputs, feeSatPerKb, inputSource, changeSource, ancestors)
+	}
+	tx, err := txauthor.NewUnsignedTransaction(outputs, feeSatPerKb, inputSource, changeSource)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if tx.AncestorFees, tx.AncestorSize, err = ancestors(tx.Tx.TxIn); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return tx, nil
+}
 func (w *Wallet) findEligibleOutputs(dbtx walletdb.ReadTx, account uint32, minconf int32, bs *waddrmgr.BlockStamp) ([]wtxmgr.Credit, error) {
 	addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
 	txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
@@ -177,7 +208,11 @@ func (w *Wallet) findEligibleOutputs(dbtx walletdb.ReadTx, account uint32, minco
 		// Only include this output if it meets the required number of confirmations. Coinbase transactions must have
 		// have reached maturity before their outputs may be spent.
 		if !confirmed(minconf, output.Height, bs.Height) {
-			continue
+			// With CPFP enabled change from the wallet's own unconfirmed transactions may be spent, as the spending
+			// transaction pays for its parent to be mined along with it.
+			if !w.cpfpEnabled() || output.Height != -1 || !w.isUnminedChange(txmgrNs, &output.OutPoint) {
+				continue
+			}
 		}
 		if output.FromCoinBase {
 			target := int32(w.chainParams.CoinbaseMaturity)
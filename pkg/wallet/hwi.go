@@ -0,0 +1,180 @@
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/psbt"
+)
+
+// HWICommand is the command run to talk to hardware wallets. It is the command line tool of the Hardware Wallet
+// Interface project, which supports Trezor, Ledger, Coldcard and other devices over USB and prints its results as JSON.
+var HWICommand = "hwi"
+
+func init() {
+	RegisterSigningDeviceDriver(hwiDriver{})
+}
+
+// hwiDriver finds the hardware wallets HWI can talk to.
+type hwiDriver struct{}
+
+// Name is the name of the driver.
+func (hwiDriver) Name() string {
+	return "hwi"
+}
+
+// Enumerate returns the connected hardware wallets. None are found when HWI is not installed.
+func (hwiDriver) Enumerate(net *netparams.Params) ([]SigningDevice, error) {
+	if _, err := exec.LookPath(HWICommand); err != nil {
+		return nil, nil
+	}
+	var found []struct {
+		Type                string `json:"type"`
+		Model               string `json:"model"`
+		Path                string `json:"path"`
+		Fingerprint         string `json:"fingerprint"`
+		NeedsPinSent        bool   `json:"needs_pin_sent"`
+		NeedsPassphraseSent bool   `json:"needs_passphrase_sent"`
+		Error               string `json:"error"`
+	}
+	if err := runHWI(&found, "enumerate"); err != nil {
+		return nil, err
+	}
+	var devices []SigningDevice
+	for _, f := range found {
+		// a locked device does not report its fingerprint until it is unlocked on the device itself
+		if f.Error != "" || f.NeedsPinSent || f.NeedsPassphraseSent {
+			Warn("hardware wallet", f.Model, "at", f.Path, "is not ready:", f.Error)
+			continue
+		}
+		fingerprint, err := ParseFingerprint(f.Fingerprint)
+		if err != nil {
+			Error(err)
+			continue
+		}
+		devices = append(devices, &hwiDevice{
+			info: SigningDeviceInfo{
+				Driver:      "hwi",
+				Model:       f.Model,
+				Path:        f.Path,
+				Fingerprint: fingerprint,
+			},
+			deviceType: f.Type,
+			net:        net,
+		})
+	}
+	return devices, nil
+}
+
+// hwiDevice is a hardware wallet reached through HWI.
+type hwiDevice struct {
+	info       SigningDeviceInfo
+	deviceType string
+	net        *netparams.Params
+}
+
+// Info describes the device.
+func (d *hwiDevice) Info() SigningDeviceInfo {
+	return d.info
+}
+
+// AccountXPub returns the extended public key of an account derived at path from the device's master key, encoded for
+// the wallet's network.
+func (d *hwiDevice) AccountXPub(path []uint32) (string, error) {
+	var res struct {
+		XPub string `json:"xpub"`
+	}
+	if err := d.run(&res, "getxpub", FormatDerivationPath(path)); err != nil {
+		return "", err
+	}
+	key, err := hdkeychain.NewKeyFromString(res.XPub)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	key.SetNet(d.net)
+	return key.String(), nil
+}
+
+// DisplayAddress shows the pay to pubkey hash address of the key at path on the device's screen. The device encodes
+// the address for the bitcoin network it believes it is on, so it carries the same key hash as the wallet's address
+// under a different version byte.
+func (d *hwiDevice) DisplayAddress(path []uint32) (string, error) {
+	var res struct {
+		Address string `json:"address"`
+	}
+	if err := d.run(&res, "displayaddress", "--path", FormatDerivationPath(path), "--addr-type", "legacy"); err != nil {
+		return "", err
+	}
+	return res.Address, nil
+}
+
+// SignPSBT has the device sign the inputs of a partially signed transaction, which the user confirms on the device,
+// and merges the signatures into the packet.
+func (d *hwiDevice) SignPSBT(p *psbt.Packet) (*psbt.Packet, error) {
+	b64, err := p.B64Encode()
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	var res struct {
+		PSBT string `json:"psbt"`
+	}
+	if err = d.run(&res, "signtx", b64); err != nil {
+		return nil, err
+	}
+	signed, err := psbt.NewFromRawBytes(strings.NewReader(res.PSBT), true)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if err = p.Combine(signed); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return p, nil
+}
+
+// run runs an HWI command against the device.
+func (d *hwiDevice) run(result interface{}, args ...string) error {
+	chain := "test"
+	switch d.net.Name {
+	case netparams.MainNetParams.Name:
+		chain = "main"
+	case netparams.RegressionTestParams.Name:
+		chain = "regtest"
+	}
+	return runHWI(result, append([]string{"--device-type", d.deviceType, "--device-path", d.info.Path,
+		"--chain", chain}, args...)...)
+}
+
+// runHWI runs HWI and decodes the JSON it prints into result. HWI reports failures as a JSON object with an error
+// field, which is returned as the error.
+func runHWI(result interface{}, args ...string) error {
+	out, err := exec.Command(HWICommand, args...).Output()
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		err = errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	// failures are printed as JSON on standard output along with a non-zero exit status
+	var failure struct {
+		Error string `json:"error"`
+		Code  int    `json:"code"`
+	}
+	if json.Unmarshal(out, &failure) == nil && failure.Error != "" {
+		return fmt.Errorf("%s (code %d)", failure.Error, failure.Code)
+	}
+	if err != nil {
+		Error(err)
+		return err
+	}
+	if err = json.Unmarshal(out, result); err != nil {
+		Error(err)
+		return err
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/p9c/pod/pkg/chain/config/netparams"
 	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/db/walletdb/edb"
 	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/util/prompt"
 	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
@@ -51,6 +52,23 @@ var (
 // non-nil, addresses are derived from this seed. If nil, a secure random seed is generated.
 func (ld *Loader) CreateNewWallet(pubPassphrase, privPassphrase, seed []byte, bday time.Time, noStart bool,
 	podConfig *pod.Config) (*Wallet, error) {
+	return ld.createNewWallet(pubPassphrase, noStart, podConfig, func(db walletdb.DB) error {
+		return Create(db, pubPassphrase, privPassphrase, seed, ld.ChainParams, bday)
+	})
+}
+
+// CreateNewWatchingOnlyWallet creates a new watching-only wallet tracking the account whose extended public key is
+// passed. The history of the account is found by rescanning the chain from the birthday.
+func (ld *Loader) CreateNewWatchingOnlyWallet(pubPassphrase []byte, accountXPub string, bday time.Time,
+	noStart bool, podConfig *pod.Config) (*Wallet, error) {
+	return ld.createNewWallet(pubPassphrase, noStart, podConfig, func(db walletdb.DB) error {
+		return CreateWatchingOnly(db, pubPassphrase, accountXPub, ld.ChainParams, bday)
+	})
+}
+
+// createNewWallet creates the wallet database, writes the wallet to it with create and opens it.
+func (ld *Loader) createNewWallet(pubPassphrase []byte, noStart bool, podConfig *pod.Config,
+	create func(db walletdb.DB) error) (*Wallet, error) {
 	ld.Mutex.Lock()
 	defer ld.Mutex.Unlock()
 	if ld.Loaded {
@@ -72,13 +90,18 @@ func (ld *Loader) CreateNewWallet(pubPassphrase, privPassphrase, seed []byte, bd
 		Error(err)
 		return nil, err
 	}
-	db, err := walletdb.Create("bdb", ld.DDDirPath)
+	var db walletdb.DB
+	if encryptDB(podConfig) {
+		db, err = walletdb.Create("edb", ld.DDDirPath, pubPassphrase)
+	} else if db, err = walletdb.Create("bdb", ld.DDDirPath); err == nil {
+		db, err = edb.Wrap(db)
+	}
 	if err != nil {
 		Error(err)
 		return nil, err
 	}
 	// Initialize the newly created database for the wallet before opening.
-	err = Create(db, pubPassphrase, privPassphrase, seed, ld.ChainParams, bday)
+	err = create(db)
 	if err != nil {
 		Error(err)
 		return nil, err
@@ -127,7 +150,7 @@ func (ld *Loader) OpenExistingWallet(pubPassphrase []byte, canConsolePrompt bool
 	// Open the database using the boltdb backend.
 	dbPath := ld.DDDirPath
 	Info("opening database", dbPath)
-	db, err := walletdb.Open("bdb", dbPath)
+	db, err := openDB(dbPath, pubPassphrase, encryptDB(podConfig))
 	if err != nil {
 		Error("failed to open database '", ld.DDDirPath, "':", err)
 		return nil, err
@@ -166,6 +189,43 @@ func (ld *Loader) OpenExistingWallet(pubPassphrase []byte, canConsolePrompt bool
 	return w, nil
 }
 
+// encryptDB returns whether the wallet database is to be encrypted at rest.
+func encryptDB(podConfig *pod.Config) bool {
+	return podConfig != nil && podConfig.EncryptWalletDB != nil && *podConfig.EncryptWalletDB
+}
+
+// openDB opens the wallet database, which is decrypted with the public passphrase if it was created encrypted. An
+// unencrypted database is encrypted first when encrypt is set, and otherwise is wrapped so that it can be encrypted
+// while the wallet is running.
+func openDB(dbPath string, pubPassphrase []byte, encrypt bool) (walletdb.DB, error) {
+	db, err := walletdb.Open("edb", dbPath, pubPassphrase)
+	if err != edb.ErrNotEncrypted {
+		return db, err
+	}
+	if !encrypt {
+		if db, err = walletdb.Open("bdb", dbPath); err != nil {
+			return nil, err
+		}
+		return edb.Wrap(db)
+	}
+	Info("encrypting wallet database", dbPath)
+	if err = edb.EncryptFile(dbPath, pubPassphrase); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return walletdb.Open("edb", dbPath, pubPassphrase)
+}
+
+// changeDBPassphrase encrypts the key of an encrypted wallet database with a new public passphrase. Unencrypted
+// databases are left as they are.
+func changeDBPassphrase(tx walletdb.ReadWriteTx, pubPassphrase []byte) error {
+	if err := edb.ChangePassphrase(tx, pubPassphrase); err != nil && err != edb.ErrNotEncrypted {
+		Error(err)
+		return err
+	}
+	return nil
+}
+
 // RunAfterLoad adds a function to be executed when the loader creates or opens a wallet. Functions are executed in a
 // single goroutine in the order they are added.
 func (ld *Loader) RunAfterLoad(fn func(*Wallet)) {
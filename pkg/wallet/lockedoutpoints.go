@@ -0,0 +1,174 @@
+package wallet
+
+import (
+	"encoding/binary"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+)
+
+// lockedOutpointsNamespaceKey is the top level bucket holding the outpoints locked with lockunspent, so they remain
+// reserved across wallet restarts.
+var lockedOutpointsNamespaceKey = []byte("lockedoutpoints")
+
+// LockedOutpoint returns whether an outpoint has been marked as locked and should not be used as an input for created
+// transactions.
+func (w *Wallet) LockedOutpoint(op wire.OutPoint) bool {
+	w.lockedOutpointsMtx.Lock()
+	defer w.lockedOutpointsMtx.Unlock()
+	_, locked := w.lockedOutpoints[op]
+	return locked
+}
+
+// LockOutpoint marks an outpoint as locked, that is, it should not be used as an input for newly created transactions.
+// The lock is saved in the wallet database and stays in place until the outpoint is unlocked or spent.
+func (w *Wallet) LockOutpoint(op wire.OutPoint) error {
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(lockedOutpointsNamespaceKey)
+		if ns == nil {
+			var err error
+			if ns, err = tx.CreateTopLevelBucket(lockedOutpointsNamespaceKey); err != nil {
+				return err
+			}
+		}
+		return ns.Put(outpointKey(&op), nil)
+	})
+	if err != nil {
+		Error(err)
+		return err
+	}
+	w.lockedOutpointsMtx.Lock()
+	w.lockedOutpoints[op] = struct{}{}
+	w.lockedOutpointsMtx.Unlock()
+	return nil
+}
+
+// UnlockOutpoint marks an outpoint as unlocked, that is, it may be used as an input for newly created transactions.
+func (w *Wallet) UnlockOutpoint(op wire.OutPoint) error {
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(lockedOutpointsNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		return ns.Delete(outpointKey(&op))
+	})
+	if err != nil {
+		Error(err)
+		return err
+	}
+	w.lockedOutpointsMtx.Lock()
+	delete(w.lockedOutpoints, op)
+	w.lockedOutpointsMtx.Unlock()
+	return nil
+}
+
+// ResetLockedOutpoints resets the set of locked outpoints so all may be used as inputs for new transactions.
+func (w *Wallet) ResetLockedOutpoints() error {
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		if tx.ReadBucket(lockedOutpointsNamespaceKey) == nil {
+			return nil
+		}
+		return tx.DeleteTopLevelBucket(lockedOutpointsNamespaceKey)
+	})
+	if err != nil {
+		Error(err)
+		return err
+	}
+	w.lockedOutpointsMtx.Lock()
+	w.lockedOutpoints = map[wire.OutPoint]struct{}{}
+	w.lockedOutpointsMtx.Unlock()
+	return nil
+}
+
+// LockedOutpoints returns a slice of currently locked outpoints. This is intended to be used by marshaling the result
+// as a JSON array for listlockunspent RPC results.
+func (w *Wallet) LockedOutpoints() []btcjson.TransactionInput {
+	w.lockedOutpointsMtx.Lock()
+	defer w.lockedOutpointsMtx.Unlock()
+	locked := make([]btcjson.TransactionInput, len(w.lockedOutpoints))
+	i := 0
+	for op := range w.lockedOutpoints {
+		locked[i] = btcjson.TransactionInput{
+			Txid: op.Hash.String(),
+			Vout: op.Index,
+		}
+		i++
+	}
+	return locked
+}
+
+// loadLockedOutpoints reads the outpoints locked in previous sessions. Locks on outputs that have since been spent are
+// removed, as they can no longer be chosen as inputs.
+func (w *Wallet) loadLockedOutpoints() error {
+	locked := map[wire.OutPoint]struct{}{}
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(lockedOutpointsNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		var stale [][]byte
+		err := ns.ForEach(func(k, v []byte) error {
+			if len(k) != chainhash.HashSize+4 {
+				stale = append(stale, k)
+				return nil
+			}
+			var op wire.OutPoint
+			copy(op.Hash[:], k)
+			op.Index = binary.LittleEndian.Uint32(k[chainhash.HashSize:])
+			spent, err := w.outpointSpent(txmgrNs, &op)
+			if err != nil {
+				return err
+			}
+			if spent {
+				stale = append(stale, k)
+				return nil
+			}
+			locked[op] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range stale {
+			if err = ns.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.lockedOutpointsMtx.Lock()
+	for op := range locked {
+		w.lockedOutpoints[op] = struct{}{}
+	}
+	w.lockedOutpointsMtx.Unlock()
+	return nil
+}
+
+// outpointSpent returns whether the wallet has recorded the output as spent. Outputs of transactions the wallet does
+// not know about are reported as unspent.
+func (w *Wallet) outpointSpent(txmgrNs walletdb.ReadBucket, op *wire.OutPoint) (bool, error) {
+	details, err := w.TxStore.TxDetails(txmgrNs, &op.Hash)
+	if err != nil || details == nil {
+		return false, err
+	}
+	for _, c := range details.Credits {
+		if c.Index == op.Index {
+			return c.Spent, nil
+		}
+	}
+	return false, nil
+}
+
+// outpointKey serializes an outpoint as the transaction hash followed by the little endian output index.
+func outpointKey(op *wire.OutPoint) []byte {
+	k := make([]byte, chainhash.HashSize+4)
+	copy(k, op.Hash[:])
+	binary.LittleEndian.PutUint32(k[chainhash.HashSize:], op.Index)
+	return k
+}
@@ -0,0 +1,80 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	_ "github.com/p9c/pod/pkg/db/walletdb/bdb"
+)
+
+// TestLockedOutpointsPersist checks that locked outpoints are restored when the wallet database is opened again.
+func TestLockedOutpointsPersist(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lockedoutpoints_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := walletdb.Create("bdb", filepath.Join(dir, "wallet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var store *wtxmgr.Store
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(wtxmgrNamespaceKey)
+		if err != nil {
+			return err
+		}
+		if err = wtxmgr.Create(ns); err != nil {
+			return err
+		}
+		store, err = wtxmgr.Open(ns, &netparams.TestNet3Params)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	open := func() *Wallet {
+		w := &Wallet{db: db, TxStore: store, lockedOutpoints: map[wire.OutPoint]struct{}{}}
+		if err := w.loadLockedOutpoints(); err != nil {
+			t.Fatal(err)
+		}
+		return w
+	}
+	ops := []wire.OutPoint{
+		{Hash: chainhash.Hash{1}, Index: 0},
+		{Hash: chainhash.Hash{2}, Index: 7},
+	}
+	w := open()
+	for _, op := range ops {
+		if err = w.LockOutpoint(op); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.UnlockOutpoint(ops[0]); err != nil {
+		t.Fatal(err)
+	}
+	w = open()
+	if w.LockedOutpoint(ops[0]) {
+		t.Errorf("unlocked outpoint %v was restored", ops[0])
+	}
+	if !w.LockedOutpoint(ops[1]) {
+		t.Errorf("locked outpoint %v was not restored", ops[1])
+	}
+	if len(w.LockedOutpoints()) != 1 {
+		t.Errorf("got %d locked outpoints, want 1", len(w.LockedOutpoints()))
+	}
+	if err = w.ResetLockedOutpoints(); err != nil {
+		t.Fatal(err)
+	}
+	if w = open(); len(w.LockedOutpoints()) != 0 {
+		t.Errorf("got %d locked outpoints after reset, want 0", len(w.LockedOutpoints()))
+	}
+}
@@ -64,7 +64,9 @@ func (w *Wallet) MakeMultiSigScript(addrs []util.Address, nRequired int) ([]byte
 	return txscript.MultiSigScript(pubKeys, nRequired)
 }
 
-// ImportP2SHRedeemScript adds a P2SH redeem script to the wallet.
+// ImportP2SHRedeemScript adds a P2SH redeem script to the wallet, storing the script so that outputs paying to its
+// address can be signed for, and starts watching the address for payments when the wallet is connected to a chain
+// server.
 func (w *Wallet) ImportP2SHRedeemScript(script []byte) (*util.AddressScriptHash, error) {
 	var p2shAddr *util.AddressScriptHash
 	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
@@ -98,5 +100,14 @@ func (w *Wallet) ImportP2SHRedeemScript(script []byte) (*util.AddressScriptHash,
 		p2shAddr = addrInfo.Address().(*util.AddressScriptHash)
 		return nil
 	})
-	return p2shAddr, err
+	if err != nil {
+		return nil, err
+	}
+	if chainClient := w.ChainClient(); chainClient != nil {
+		if err = chainClient.NotifyReceived([]util.Address{p2shAddr}); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	return p2shAddr, nil
 }
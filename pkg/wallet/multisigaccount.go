@@ -0,0 +1,772 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/psbt"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+var (
+	// multisigNamespaceKey is the top level bucket holding the multisig accounts, the redeem scripts derived for them
+	// and the partially signed transactions spending from them.
+	multisigNamespaceKey = []byte("multisig")
+	msAccountsBucketName = []byte("accounts")
+	msScriptsBucketName  = []byte("scripts")
+	msPSBTsBucketName    = []byte("psbts")
+)
+
+// maxMultisigKeys is the largest number of cosigners of a multisig account. Fifteen compressed public keys is the most
+// a standard pay to script hash redeem script can hold.
+const maxMultisigKeys = 15
+
+var (
+	// ErrMultisigAccountExists is returned when creating a multisig account for a wallet account that already has one.
+	ErrMultisigAccountExists = errors.New("multisig account already exists")
+	// ErrMultisigAccountNotFound is returned when a multisig account does not exist.
+	ErrMultisigAccountNotFound = errors.New("multisig account not found")
+	// ErrMultisigInsufficientFunds is returned when the outputs of a multisig account cannot pay for a spend.
+	ErrMultisigInsufficientFunds = errors.New("insufficient funds in multisig account")
+	// ErrNotMultisigPSBT is returned when importing a partially signed transaction that does not spend from any of the
+	// wallet's multisig accounts.
+	ErrNotMultisigPSBT = errors.New("transaction does not spend from a multisig account")
+)
+
+// MultisigAccount is an m of n account whose addresses pay to scripts requiring the signatures of Required of the
+// cosigners. The keys of each address are derived from the external branch of the cosigners' extended public keys at
+// the same index, one of which belongs to the wallet account of the same name.
+type MultisigAccount struct {
+	Name      string
+	Account   uint32
+	Required  int
+	XPubs     []string
+	NextIndex uint32
+}
+
+// MultisigPSBT is a partially signed transaction spending from a multisig account, along with the account name.
+type MultisigPSBT struct {
+	Account string
+	Packet  *psbt.Packet
+}
+
+// AccountXPub returns the extended public key of a wallet account, which is what cosigners need to create a multisig
+// account including this wallet.
+func (w *Wallet) AccountXPub(name string) (string, error) {
+	manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		Error(err)
+		return "", err
+	}
+	var xpub string
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		account, err := manager.LookupAccount(addrmgrNs, name)
+		if err != nil {
+			return err
+		}
+		props, err := manager.AccountProperties(addrmgrNs, account)
+		if err != nil {
+			return err
+		}
+		if props.AccountPubKey == nil {
+			return fmt.Errorf("account %q has no extended public key", name)
+		}
+		xpub = props.AccountPubKey.String()
+		return nil
+	})
+	return xpub, err
+}
+
+// CreateMultisigAccount creates a multisig account requiring required signatures from this wallet's account of the
+// given name and the cosigners' extended public keys.
+func (w *Wallet) CreateMultisigAccount(name string, required int, cosigners []string) (*MultisigAccount, error) {
+	if len(name) > 255 {
+		return nil, fmt.Errorf("multisig account name is too long")
+	}
+	own, err := w.AccountXPub(name)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, name)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	xpubs := []string{own}
+	for _, c := range cosigners {
+		key, err := hdkeychain.NewKeyFromString(c)
+		if err != nil {
+			Error(err)
+			return nil, fmt.Errorf("invalid cosigner key %q: %v", c, err)
+		}
+		if key.IsPrivate() {
+			return nil, fmt.Errorf("cosigner key %q is private, share the extended public key instead", c)
+		}
+		if !key.IsForNet(w.chainParams) {
+			return nil, fmt.Errorf("cosigner key %q is for another network", c)
+		}
+		for _, x := range xpubs {
+			if x == c {
+				return nil, fmt.Errorf("cosigner key %q is given twice", c)
+			}
+		}
+		xpubs = append(xpubs, c)
+	}
+	if len(xpubs) > maxMultisigKeys {
+		return nil, fmt.Errorf("a multisig account can have at most %d cosigners", maxMultisigKeys)
+	}
+	if required < 1 || required > len(xpubs) {
+		return nil, fmt.Errorf("required signatures must be between 1 and %d", len(xpubs))
+	}
+	sort.Strings(xpubs)
+	acct := &MultisigAccount{
+		Name:     name,
+		Account:  account,
+		Required: required,
+		XPubs:    xpubs,
+	}
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		accounts, _, _, err := multisigBuckets(tx)
+		if err != nil {
+			return err
+		}
+		if accounts.Get([]byte(name)) != nil {
+			return ErrMultisigAccountExists
+		}
+		return accounts.Put([]byte(name), serializeMultisigAccount(acct))
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return acct, nil
+}
+
+// MultisigAccounts returns all multisig accounts of the wallet.
+func (w *Wallet) MultisigAccounts() ([]MultisigAccount, error) {
+	var accts []MultisigAccount
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(multisigNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		return ns.NestedReadBucket(msAccountsBucketName).ForEach(func(k, v []byte) error {
+			acct, err := deserializeMultisigAccount(string(k), v)
+			if err != nil {
+				return err
+			}
+			accts = append(accts, *acct)
+			return nil
+		})
+	})
+	return accts, err
+}
+
+// NewMultisigAddress derives the next address of a multisig account and starts watching it.
+func (w *Wallet) NewMultisigAddress(name string) (util.Address, error) {
+	if _, err := w.requireChainClient(); err != nil {
+		Error(err)
+		return nil, err
+	}
+	var script []byte
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		accounts, scripts, _, err := multisigBuckets(tx)
+		if err != nil {
+			return err
+		}
+		v := accounts.Get([]byte(name))
+		if v == nil {
+			return ErrMultisigAccountNotFound
+		}
+		acct, err := deserializeMultisigAccount(name, v)
+		if err != nil {
+			return err
+		}
+		if script, err = acct.redeemScript(acct.NextIndex); err != nil {
+			return err
+		}
+		if err = scripts.Put(util.Hash160(script), serializeMultisigScript(name, acct.NextIndex, script)); err != nil {
+			return err
+		}
+		acct.NextIndex++
+		return accounts.Put([]byte(name), serializeMultisigAccount(acct))
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	addr, err := w.ImportP2SHRedeemScript(script)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return addr, nil
+}
+
+// CreateMultisigPSBT creates a partially signed transaction paying the given outputs from the unspent outputs of a
+// multisig account, with any change going to a new address of the account. The spent outputs are locked so that they
+// are not picked again while signatures are being collected.
+func (w *Wallet) CreateMultisigPSBT(name string, outputs []*wire.TxOut, satPerKb util.Amount) (*psbt.Packet, error) {
+	var target util.Amount
+	for _, output := range outputs {
+		if err := txrules.CheckOutput(output, satPerKb); err != nil {
+			return nil, err
+		}
+		target += util.Amount(output.Value)
+	}
+	type candidate struct {
+		outPoint wire.OutPoint
+		amount   util.Amount
+		prevTx   *wire.MsgTx
+		script   []byte
+	}
+	var (
+		acct       *MultisigAccount
+		candidates []candidate
+	)
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		ns := tx.ReadBucket(multisigNamespaceKey)
+		if ns == nil {
+			return ErrMultisigAccountNotFound
+		}
+		v := ns.NestedReadBucket(msAccountsBucketName).Get([]byte(name))
+		if v == nil {
+			return ErrMultisigAccountNotFound
+		}
+		var err error
+		if acct, err = deserializeMultisigAccount(name, v); err != nil {
+			return err
+		}
+		scripts := ns.NestedReadBucket(msScriptsBucketName)
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for _, credit := range unspent {
+			if w.LockedOutpoint(credit.OutPoint) {
+				continue
+			}
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(credit.PkScript, w.chainParams)
+			if err != nil || len(addrs) != 1 {
+				continue
+			}
+			if _, ok := addrs[0].(*util.AddressScriptHash); !ok {
+				continue
+			}
+			v := scripts.Get(addrs[0].ScriptAddress())
+			if v == nil {
+				continue
+			}
+			owner, _, script, err := deserializeMultisigScript(v)
+			if err != nil {
+				return err
+			}
+			if owner != name {
+				continue
+			}
+			details, err := w.TxStore.TxDetails(txmgrNs, &credit.OutPoint.Hash)
+			if err != nil || details == nil {
+				continue
+			}
+			candidates = append(candidates, candidate{
+				outPoint: credit.OutPoint,
+				amount:   credit.Amount,
+				prevTx:   &details.MsgTx,
+				script:   script,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	// Spend the largest outputs first to keep the number of inputs, and so the number of signatures, low.
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].amount > candidates[j].amount })
+	tx := wire.NewMsgTx(wire.TxVersion)
+	for _, output := range outputs {
+		tx.AddTxOut(output)
+	}
+	changeSize := wire.NewTxOut(0, make([]byte, p2shPkScriptSize)).SerializeSize()
+	var (
+		selected []candidate
+		total    util.Amount
+		fee      util.Amount
+	)
+	for _, c := range candidates {
+		selected = append(selected, c)
+		total += c.amount
+		size := tx.SerializeSize() + changeSize
+		for _, s := range selected {
+			size += multisigInputSize(s.script, acct.Required)
+		}
+		fee = txrules.FeeForSerializeSize(satPerKb, size)
+		if total >= target+fee {
+			break
+		}
+	}
+	if total < target+fee {
+		return nil, ErrMultisigInsufficientFunds
+	}
+	for _, s := range selected {
+		outPoint := s.outPoint
+		tx.AddTxIn(wire.NewTxIn(&outPoint, nil, nil))
+	}
+	changeIndex := -1
+	change := total - target - fee
+	if !txrules.IsDustAmount(change, p2shPkScriptSize, satPerKb) {
+		changeAddr, err := w.NewMultisigAddress(name)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		pkScript, err := txscript.PayToAddrScript(changeAddr)
+		if err != nil {
+			Error(err)
+			return nil, err
+		}
+		tx.AddTxOut(wire.NewTxOut(int64(change), pkScript))
+		changeIndex = txauthor.RandomizeOutputPosition(tx.TxOut, len(tx.TxOut)-1)
+	}
+	p, err := psbt.New(tx)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	for i, s := range selected {
+		p.Inputs[i].NonWitnessUtxo = s.prevTx
+		p.Inputs[i].RedeemScript = s.script
+		p.Inputs[i].SighashType = txscript.SigHashAll
+	}
+	if changeIndex >= 0 {
+		if p.Outputs[changeIndex].RedeemScript, err = w.multisigScript(tx.TxOut[changeIndex].PkScript); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	if err = w.storeMultisigPSBT(p); err != nil {
+		Error(err)
+		return nil, err
+	}
+	for _, s := range selected {
+		if err = w.LockOutpoint(s.outPoint); err != nil {
+			Error(err)
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// SignMultisigPSBT adds this wallet's signatures to the inputs of a partially signed transaction that spend from its
+// multisig accounts, merges in the signatures already collected for the same transaction and finalizes the inputs that
+// have enough of them. It returns the resulting packet and the number of signatures added. The wallet must be
+// unlocked.
+func (w *Wallet) SignMultisigPSBT(p *psbt.Packet) (*psbt.Packet, int, error) {
+	var signed, known int
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		ns := tx.ReadBucket(multisigNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+		if err != nil {
+			return err
+		}
+		accounts := ns.NestedReadBucket(msAccountsBucketName)
+		scripts := ns.NestedReadBucket(msScriptsBucketName)
+		for i := range p.Inputs {
+			in := &p.Inputs[i]
+			if in.RedeemScript == nil || in.FinalScriptSig != nil {
+				continue
+			}
+			v := scripts.Get(util.Hash160(in.RedeemScript))
+			if v == nil {
+				continue
+			}
+			name, index, _, err := deserializeMultisigScript(v)
+			if err != nil {
+				return err
+			}
+			acct, err := deserializeMultisigAccount(name, accounts.Get([]byte(name)))
+			if err != nil {
+				return err
+			}
+			known++
+			addr, err := manager.DeriveFromKeyPath(addrmgrNs, waddrmgr.DerivationPath{
+				Account: acct.Account,
+				Branch:  waddrmgr.ExternalBranch,
+				Index:   index,
+			})
+			if err != nil {
+				return err
+			}
+			privKey, err := addr.(waddrmgr.ManagedPubKeyAddress).PrivKey()
+			if err != nil {
+				return err
+			}
+			hashType := in.SighashType
+			if hashType == 0 {
+				hashType = txscript.SigHashAll
+			}
+			sig, err := txscript.RawTxInSignature(p.UnsignedTx, i, in.RedeemScript, hashType, privKey)
+			if err != nil {
+				return err
+			}
+			if p.AddPartialSig(i, privKey.PubKey().SerializeCompressed(), sig) {
+				signed++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, 0, err
+	}
+	if known == 0 {
+		return p, 0, nil
+	}
+	if p, err = w.mergeMultisigPSBT(p); err != nil {
+		return nil, 0, err
+	}
+	return p, signed, nil
+}
+
+// ImportMultisigPSBT records the signatures a cosigner added to a partially signed transaction spending from one of
+// the wallet's multisig accounts, merging them into the signatures already collected for it, and finalizes the inputs
+// that have enough of them. Unlike SignMultisigPSBT it does not sign, so the wallet need not be unlocked.
+func (w *Wallet) ImportMultisigPSBT(p *psbt.Packet) (*psbt.Packet, error) {
+	var known bool
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(multisigNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		scripts := ns.NestedReadBucket(msScriptsBucketName)
+		for i := range p.Inputs {
+			if script := multisigInputScript(&p.Inputs[i]); script != nil && scripts.Get(util.Hash160(script)) != nil {
+				known = true
+				return nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if !known {
+		return nil, ErrNotMultisigPSBT
+	}
+	return w.mergeMultisigPSBT(p)
+}
+
+// mergeMultisigPSBT combines a partially signed transaction with the one stored for the same transaction, if any,
+// finalizes the inputs that have enough signatures and stores the result.
+func (w *Wallet) mergeMultisigPSBT(p *psbt.Packet) (*psbt.Packet, error) {
+	err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		_, _, psbts, err := multisigBuckets(tx)
+		if err != nil {
+			return err
+		}
+		txHash := p.UnsignedTx.TxHash()
+		if v := psbts.Get(txHash[:]); v != nil {
+			stored, err := psbt.NewFromRawBytes(bytes.NewReader(v), false)
+			if err != nil {
+				return err
+			}
+			if err = stored.Combine(p); err != nil {
+				return err
+			}
+			p = stored
+		}
+		if _, err = psbt.MaybeFinalizeAll(p); err != nil {
+			return err
+		}
+		var b bytes.Buffer
+		if err = p.Serialize(&b); err != nil {
+			return err
+		}
+		return psbts.Put(txHash[:], b.Bytes())
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return p, nil
+}
+
+// MultisigBalances returns the balances of the wallet's multisig accounts by account name, summing the unspent outputs
+// paying to the addresses derived for each. Spendable counts the outputs with at least confirms confirmations.
+func (w *Wallet) MultisigBalances(confirms int32) (map[string]Balances, error) {
+	bals := make(map[string]Balances)
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		ns := tx.ReadBucket(multisigNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		scripts := ns.NestedReadBucket(msScriptsBucketName)
+		syncBlock := w.Manager.SyncedTo()
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for i := range unspent {
+			output := &unspent[i]
+			_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript, w.chainParams)
+			if err != nil || len(addrs) != 1 {
+				continue
+			}
+			if _, ok := addrs[0].(*util.AddressScriptHash); !ok {
+				continue
+			}
+			v := scripts.Get(addrs[0].ScriptAddress())
+			if v == nil {
+				continue
+			}
+			name, _, _, err := deserializeMultisigScript(v)
+			if err != nil {
+				return err
+			}
+			b := bals[name]
+			b.Total += output.Amount
+			if output.FromCoinBase && !confirmed(int32(w.chainParams.CoinbaseMaturity), output.Height,
+				syncBlock.Height) {
+				b.ImmatureReward += output.Amount
+			} else if confirmed(confirms, output.Height, syncBlock.Height) {
+				b.Spendable += output.Amount
+			}
+			bals[name] = b
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return bals, nil
+}
+
+// MultisigPSBTs returns the partially signed transactions being collected for the wallet's multisig accounts. Complete
+// transactions are left out once the wallet has seen them published.
+func (w *Wallet) MultisigPSBTs() ([]MultisigPSBT, error) {
+	var out []MultisigPSBT
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		ns := tx.ReadBucket(multisigNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		scripts := ns.NestedReadBucket(msScriptsBucketName)
+		return ns.NestedReadBucket(msPSBTsBucketName).ForEach(func(k, v []byte) error {
+			p, err := psbt.NewFromRawBytes(bytes.NewReader(v), false)
+			if err != nil {
+				return err
+			}
+			if p.IsComplete() {
+				final, err := psbt.Extract(p)
+				if err != nil {
+					return err
+				}
+				txHash := final.TxHash()
+				if details, err := w.TxStore.TxDetails(txmgrNs, &txHash); err == nil && details != nil {
+					return nil
+				}
+			}
+			var name string
+			for _, in := range p.Inputs {
+				if v := scripts.Get(util.Hash160(multisigInputScript(&in))); v != nil {
+					name, _, _, _ = deserializeMultisigScript(v)
+					break
+				}
+			}
+			out = append(out, MultisigPSBT{Account: name, Packet: p})
+			return nil
+		})
+	})
+	return out, err
+}
+
+// storeMultisigPSBT records a new partially signed transaction so that signatures can be tracked against it.
+func (w *Wallet) storeMultisigPSBT(p *psbt.Packet) error {
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		_, _, psbts, err := multisigBuckets(tx)
+		if err != nil {
+			return err
+		}
+		var b bytes.Buffer
+		if err = p.Serialize(&b); err != nil {
+			return err
+		}
+		txHash := p.UnsignedTx.TxHash()
+		return psbts.Put(txHash[:], b.Bytes())
+	})
+}
+
+// multisigScript returns the redeem script of a multisig account address paid by pkScript.
+func (w *Wallet) multisigScript(pkScript []byte) ([]byte, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+	if err != nil || len(addrs) != 1 {
+		return nil, fmt.Errorf("not a multisig account output")
+	}
+	var script []byte
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(multisigNamespaceKey)
+		if ns == nil {
+			return ErrMultisigAccountNotFound
+		}
+		v := ns.NestedReadBucket(msScriptsBucketName).Get(addrs[0].ScriptAddress())
+		if v == nil {
+			return ErrMultisigAccountNotFound
+		}
+		_, _, script, err = deserializeMultisigScript(v)
+		return err
+	})
+	return script, err
+}
+
+// redeemScript returns the multisig redeem script of the account at the given index, with the public keys sorted as
+// described by BIP0067 so that all cosigners derive the same script.
+func (a *MultisigAccount) redeemScript(index uint32) ([]byte, error) {
+	pubKeys := make([][]byte, len(a.XPubs))
+	for i, xpub := range a.XPubs {
+		key, err := hdkeychain.NewKeyFromString(xpub)
+		if err != nil {
+			return nil, err
+		}
+		if key, err = key.Child(waddrmgr.ExternalBranch); err != nil {
+			return nil, err
+		}
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+		pubKey, err := key.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		pubKeys[i] = pubKey.SerializeCompressed()
+	}
+	sort.Slice(pubKeys, func(i, j int) bool { return bytes.Compare(pubKeys[i], pubKeys[j]) < 0 })
+	builder := txscript.NewScriptBuilder().AddInt64(int64(a.Required))
+	for _, pubKey := range pubKeys {
+		builder.AddData(pubKey)
+	}
+	return builder.AddInt64(int64(len(pubKeys))).AddOp(txscript.OP_CHECKMULTISIG).Script()
+}
+
+// p2shPkScriptSize is the size of a pay to script hash output script.
+const p2shPkScriptSize = 23
+
+// multisigInputSize returns the worst case serialized size of an input spending a multisig redeem script.
+func multisigInputSize(redeemScript []byte, required int) int {
+	// OP_0, then each signature of at most 73 bytes behind a single byte push, then the redeem script behind a push of
+	// up to three bytes.
+	sigScriptSize := 1 + required*(1+73) + 3 + len(redeemScript)
+	return 32 + 4 + wire.VarIntSerializeSize(uint64(sigScriptSize)) + sigScriptSize + 4
+}
+
+// multisigInputScript returns the redeem script of an input, taking it from the signature script once finalized.
+func multisigInputScript(in *psbt.PInput) []byte {
+	if in.FinalScriptSig == nil {
+		return in.RedeemScript
+	}
+	pushes, err := txscript.PushedData(in.FinalScriptSig)
+	if err != nil || len(pushes) == 0 {
+		return nil
+	}
+	return pushes[len(pushes)-1]
+}
+
+// multisigBuckets returns the buckets of the multisig namespace, creating them for wallets made before multisig
+// accounts existed.
+func multisigBuckets(tx walletdb.ReadWriteTx) (accounts, scripts, psbts walletdb.ReadWriteBucket, err error) {
+	ns := tx.ReadWriteBucket(multisigNamespaceKey)
+	if ns == nil {
+		if ns, err = tx.CreateTopLevelBucket(multisigNamespaceKey); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if accounts, err = ns.CreateBucketIfNotExists(msAccountsBucketName); err != nil {
+		return nil, nil, nil, err
+	}
+	if scripts, err = ns.CreateBucketIfNotExists(msScriptsBucketName); err != nil {
+		return nil, nil, nil, err
+	}
+	psbts, err = ns.CreateBucketIfNotExists(msPSBTsBucketName)
+	return
+}
+
+// serializeMultisigAccount serializes an account as the wallet account number, the required signatures, the next
+// address index and the length prefixed extended public keys.
+func serializeMultisigAccount(a *MultisigAccount) []byte {
+	var b bytes.Buffer
+	var v [4]byte
+	binary.LittleEndian.PutUint32(v[:], a.Account)
+	b.Write(v[:])
+	b.WriteByte(byte(a.Required))
+	binary.LittleEndian.PutUint32(v[:], a.NextIndex)
+	b.Write(v[:])
+	b.WriteByte(byte(len(a.XPubs)))
+	for _, xpub := range a.XPubs {
+		b.WriteByte(byte(len(xpub)))
+		b.WriteString(xpub)
+	}
+	return b.Bytes()
+}
+
+func deserializeMultisigAccount(name string, v []byte) (*MultisigAccount, error) {
+	errMalformed := fmt.Errorf("malformed multisig account %q", name)
+	if len(v) < 10 {
+		return nil, errMalformed
+	}
+	a := &MultisigAccount{
+		Name:      name,
+		Account:   binary.LittleEndian.Uint32(v[0:4]),
+		Required:  int(v[4]),
+		NextIndex: binary.LittleEndian.Uint32(v[5:9]),
+		XPubs:     make([]string, v[9]),
+	}
+	v = v[10:]
+	for i := range a.XPubs {
+		if len(v) < 1 || len(v) < 1+int(v[0]) {
+			return nil, errMalformed
+		}
+		a.XPubs[i] = string(v[1 : 1+v[0]])
+		v = v[1+v[0]:]
+	}
+	return a, nil
+}
+
+// serializeMultisigScript serializes the account name and index a redeem script was derived for, followed by the
+// script itself.
+func serializeMultisigScript(name string, index uint32, script []byte) []byte {
+	b := make([]byte, 0, 5+len(name)+len(script))
+	b = append(b, byte(len(name)))
+	b = append(b, name...)
+	var v [4]byte
+	binary.LittleEndian.PutUint32(v[:], index)
+	b = append(b, v[:]...)
+	return append(b, script...)
+}
+
+func deserializeMultisigScript(v []byte) (name string, index uint32, script []byte, err error) {
+	if len(v) < 1 || len(v) < 5+int(v[0]) {
+		return "", 0, nil, errors.New("malformed multisig script record")
+	}
+	n := int(v[0])
+	return string(v[1 : 1+n]), binary.LittleEndian.Uint32(v[1+n : 5+n]), v[5+n:], nil
+}
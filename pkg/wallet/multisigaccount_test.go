@@ -0,0 +1,179 @@
+package wallet
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	ec "github.com/p9c/pod/pkg/coding/elliptic"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	_ "github.com/p9c/pod/pkg/db/walletdb/bdb"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/psbt"
+)
+
+// TestMultisigAccountScripts checks that multisig accounts survive serialization and that every cosigner derives the
+// same redeem script whatever order it holds the extended public keys in.
+func TestMultisigAccountScripts(t *testing.T) {
+	xpubs := make([]string, 3)
+	for i := range xpubs {
+		master, err := hdkeychain.NewMaster(bytes.Repeat([]byte{byte(i + 1)}, 32), &netparams.TestNet3Params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pub, err := master.Neuter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		xpubs[i] = pub.String()
+	}
+	acct := &MultisigAccount{Name: "shared", Account: 3, Required: 2, XPubs: xpubs, NextIndex: 7}
+	got, err := deserializeMultisigAccount(acct.Name, serializeMultisigAccount(acct))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Account != 3 || got.Required != 2 || got.NextIndex != 7 || len(got.XPubs) != 3 {
+		t.Fatalf("unexpected account after round trip %+v", got)
+	}
+	for i := range xpubs {
+		if got.XPubs[i] != xpubs[i] {
+			t.Fatalf("key %d changed in round trip", i)
+		}
+	}
+	script, err := acct.redeemScript(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reversed := &MultisigAccount{Required: 2, XPubs: []string{xpubs[2], xpubs[1], xpubs[0]}}
+	other, err := reversed.redeemScript(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(script, other) {
+		t.Fatal("redeem script depends on the order of the cosigner keys")
+	}
+	if next, _ := acct.redeemScript(8); bytes.Equal(script, next) {
+		t.Fatal("redeem scripts of different indexes are equal")
+	}
+	numPubKeys, numSigs, err := txscript.CalcMultiSigStats(script)
+	if err != nil || numPubKeys != 3 || numSigs != 2 {
+		t.Fatalf("got a %d of %d script, want 2 of 3: %v", numSigs, numPubKeys, err)
+	}
+	name, index, s, err := deserializeMultisigScript(serializeMultisigScript("shared", 7, script))
+	if err != nil || name != "shared" || index != 7 || !bytes.Equal(s, script) {
+		t.Fatalf("unexpected script record %q %d: %v", name, index, err)
+	}
+}
+
+// TestMultisigBucketsReopen checks that the multisig buckets can be opened again once they have been created.
+func TestMultisigBucketsReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "multisig_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := walletdb.Create("bdb", filepath.Join(dir, "wallet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for i := 0; i < 2; i++ {
+		err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+			accounts, _, _, err := multisigBuckets(tx)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				return accounts.Put([]byte("acct"), []byte{1})
+			}
+			if v := accounts.Get([]byte("acct")); !bytes.Equal(v, []byte{1}) {
+				t.Errorf("got stored value %x, want 01", v)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("opening multisig buckets (pass %d): %v", i, err)
+		}
+	}
+}
+
+// TestImportMultisigPSBT checks that the signatures of each cosigner's copy of a transaction are merged into the one the
+// wallet stores, and that a transaction not spending from a multisig account is refused.
+func TestImportMultisigPSBT(t *testing.T) {
+	dir, err := ioutil.TempDir("", "multisig_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := walletdb.Create("bdb", filepath.Join(dir, "wallet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	w := &Wallet{db: db}
+	params := &netparams.TestNet3Params
+	keys := make([]*ec.PrivateKey, 3)
+	pubKeys := make([]*util.AddressPubKey, 3)
+	for i := range keys {
+		keys[i], _ = ec.PrivKeyFromBytes(ec.S256(), bytes.Repeat([]byte{byte(i + 1)}, 32))
+		if pubKeys[i], err = util.NewAddressPubKey(keys[i].PubKey().SerializeCompressed(), params); err != nil {
+			t.Fatal(err)
+		}
+	}
+	script, err := txscript.MultiSigScript(pubKeys, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		_, scripts, _, err := multisigBuckets(tx)
+		if err != nil {
+			return err
+		}
+		return scripts.Put(util.Hash160(script), serializeMultisigScript("shared", 0, script))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1000, nil))
+	cosigned := func(signer int) *psbt.Packet {
+		p, err := psbt.New(tx.Copy())
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Inputs[0].RedeemScript = script
+		sig, err := txscript.RawTxInSignature(p.UnsignedTx, 0, script, txscript.SigHashAll, keys[signer])
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.AddPartialSig(0, keys[signer].PubKey().SerializeCompressed(), sig)
+		return p
+	}
+	p, err := w.ImportMultisigPSBT(cosigned(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, need := p.Inputs[0].Progress(); have != 1 || need != 2 {
+		t.Fatalf("got %d of %d signatures after the first cosigner, want 1 of 2", have, need)
+	}
+	if p, err = w.ImportMultisigPSBT(cosigned(2)); err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsComplete() {
+		t.Fatal("the transaction is not complete with the signatures of two cosigners")
+	}
+	other, err := psbt.New(tx.Copy())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.ImportMultisigPSBT(other); err != ErrNotMultisigPSBT {
+		t.Fatalf("got error %v importing a transaction of no multisig account, want %v", err, ErrNotMultisigPSBT)
+	}
+}
@@ -60,6 +60,8 @@ type NotificationServer struct {
 	currentTxNtfn  *TransactionNotifications // coalesce this since wallet does not add mined txs together
 	spentness      map[uint32][]chan *SpentnessNotifications
 	accountClients []chan *AccountNotification
+	conflicts      []chan *ConflictNotification
+	rescans        []chan *RescanNotification
 	mu             sync.Mutex // Only protects registered client channels
 	wallet         *Wallet    // smells like hacks
 }
@@ -109,6 +111,47 @@ type TransactionNotificationsClient struct {
 	server *NotificationServer
 }
 
+// ConflictNotification is a notification that a wallet transaction was removed because a mined transaction spends one
+// of the same inputs, either directly or through an unmined transaction it depended on.
+type ConflictNotification struct {
+	Hash         *chainhash.Hash
+	ConflictedBy *chainhash.Hash
+}
+
+// ConflictNotificationsClient receives ConflictNotifications from the NotificationServer over the channel C.
+type ConflictNotificationsClient struct {
+	C      <-chan *ConflictNotification
+	server *NotificationServer
+}
+
+// RescanNotification reports the progress of a rescan from StartHeight, which has reached Height of the chain ending at
+// EndHeight, finding Found transactions of the wallet in blocks on the way.
+type RescanNotification struct {
+	StartHeight int32
+	Height      int32
+	EndHeight   int32
+	Found       int
+	Finished    bool
+}
+
+// Percent is how much of the rescan is done.
+func (n *RescanNotification) Percent() float64 {
+	if n.Finished || n.EndHeight <= n.StartHeight {
+		return 100
+	}
+	done := float64(n.Height-n.StartHeight) / float64(n.EndHeight-n.StartHeight) * 100
+	if done < 0 {
+		return 0
+	}
+	return done
+}
+
+// RescanNotificationsClient receives RescanNotifications from the NotificationServer over the channel C.
+type RescanNotificationsClient struct {
+	C      <-chan *RescanNotification
+	server *NotificationServer
+}
+
 // TransactionSummary contains a transaction relevant to the wallet and marks which inputs and outputs were relevant.
 type TransactionSummary struct {
 	Hash        *chainhash.Hash
@@ -199,6 +242,30 @@ func (s *NotificationServer) TransactionNotifications() TransactionNotifications
 		server: s,
 	}
 }
+// ConflictNotifications returns a client for receiving ConflictNotifications over a channel. The channel is unbuffered.
+// When finished, the client's Done method should be called to disassociate the client from the server.
+func (s *NotificationServer) ConflictNotifications() ConflictNotificationsClient {
+	c := make(chan *ConflictNotification)
+	s.mu.Lock()
+	s.conflicts = append(s.conflicts, c)
+	s.mu.Unlock()
+	return ConflictNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
+// RescanNotifications returns a client for receiving RescanNotifications over a channel. The channel is unbuffered.
+// When finished, the client's Done method should be called to disassociate the client from the server.
+func (s *NotificationServer) RescanNotifications() RescanNotificationsClient {
+	c := make(chan *RescanNotification)
+	s.mu.Lock()
+	s.rescans = append(s.rescans, c)
+	s.mu.Unlock()
+	return RescanNotificationsClient{
+		C:      c,
+		server: s,
+	}
+}
 func (s *NotificationServer) notifyAccountProperties(props *waddrmgr.AccountProperties) {
 	defer s.mu.Unlock()
 	s.mu.Lock()
@@ -360,6 +427,33 @@ func (s *NotificationServer) notifyUnminedTransaction(dbtx walletdb.ReadTx, deta
 	}
 }
 
+// notifyConflictedTransaction notifies registered clients that a wallet transaction was conflicted by a mined
+// transaction.
+func (s *NotificationServer) notifyConflictedTransaction(hash, conflictedBy *chainhash.Hash) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	clients := s.conflicts
+	if len(clients) == 0 {
+		return
+	}
+	n := &ConflictNotification{
+		Hash:         hash,
+		ConflictedBy: conflictedBy,
+	}
+	for _, c := range clients {
+		c <- n
+	}
+}
+
+// notifyRescanProgress notifies registered clients of the progress of a rescan.
+func (s *NotificationServer) notifyRescanProgress(n *RescanNotification) {
+	defer s.mu.Unlock()
+	s.mu.Lock()
+	for _, c := range s.rescans {
+		c <- n
+	}
+}
+
 // notifyUnspentOutput notifies registered clients of a new unspent output that is controlled by the wallet.
 func (s *NotificationServer) notifyUnspentOutput(account uint32, hash *chainhash.Hash, index uint32) {
 	defer s.mu.Unlock()
@@ -440,6 +534,54 @@ func (c *TransactionNotificationsClient) Done() {
 		s.mu.Unlock()
 	}()
 }
+
+// Done unregisters the client from the server and drains any remaining messages. It must be called exactly once when
+// the client is finished receiving notifications.
+func (c *ConflictNotificationsClient) Done() {
+	go func() {
+		// Drain notifications until the client channel is removed from the server and closed.
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.conflicts
+		for i, ch := range clients {
+			if c.C == ch {
+				clients[i] = clients[len(clients)-1]
+				s.conflicts = clients[:len(clients)-1]
+				close(ch)
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
+
+// Done unregisters the client from the server and drains any remaining messages. It must be called exactly once when
+// the client is finished receiving notifications.
+func (c *RescanNotificationsClient) Done() {
+	go func() {
+		// Drain notifications until the client channel is removed from the server and closed.
+		for range c.C {
+		}
+	}()
+	go func() {
+		s := c.server
+		s.mu.Lock()
+		clients := s.rescans
+		for i, ch := range clients {
+			if c.C == ch {
+				clients[i] = clients[len(clients)-1]
+				s.rescans = clients[:len(clients)-1]
+				close(ch)
+				break
+			}
+		}
+		s.mu.Unlock()
+	}()
+}
 func flattenBalanceMap(m map[uint32]util.Amount) []AccountBalance {
 	s := make([]AccountBalance, 0, len(m))
 	for k, v := range m {
@@ -0,0 +1,326 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// paymentRequestsNamespaceKey is the top level bucket holding the payment requests created with createpaymentrequest,
+// keyed by the address each request was issued for.
+var paymentRequestsNamespaceKey = []byte("paymentrequests")
+
+const (
+	// paymentRequestMinConf is the number of confirmations a payment needs before it fulfills a request.
+	paymentRequestMinConf = 1
+	// maxPaymentRequestText is the longest label or message a payment request may carry.
+	maxPaymentRequestText = 1024
+	// paymentWebhookTimeout is how long the webhook endpoint is given to accept a fulfillment notification.
+	paymentWebhookTimeout = 30 * time.Second
+)
+
+// ErrPaymentRequestAmount is returned when creating a payment request for an amount that is not positive.
+var ErrPaymentRequestAmount = errors.New("payment request amount must be positive")
+
+// PaymentRequestStatus is the state of a payment request.
+type PaymentRequestStatus byte
+
+const (
+	// PaymentRequestPending is a request that has not yet been paid in full and has not expired.
+	PaymentRequestPending PaymentRequestStatus = iota
+	// PaymentRequestFulfilled is a request whose address has received at least the requested amount in confirmed
+	// transactions.
+	PaymentRequestFulfilled
+	// PaymentRequestExpired is a request that was not paid in full before it expired.
+	PaymentRequestExpired
+)
+
+// String returns the name of the status as used in RPC results.
+func (s PaymentRequestStatus) String() string {
+	switch s {
+	case PaymentRequestPending:
+		return "pending"
+	case PaymentRequestFulfilled:
+		return "fulfilled"
+	case PaymentRequestExpired:
+		return "expired"
+	}
+	return fmt.Sprintf("unknown (%d)", byte(s))
+}
+
+// PaymentRequest is a request for a payment of Amount to a fresh wallet address. It is fulfilled once the address has
+// received the amount in confirmed transactions, or expires if that has not happened by Expires. A zero Expires never
+// expires.
+type PaymentRequest struct {
+	Address   string
+	Amount    util.Amount
+	Label     string
+	Message   string
+	Created   time.Time
+	Expires   time.Time
+	Status    PaymentRequestStatus
+	Received  util.Amount
+	Fulfilled time.Time
+}
+
+// Result returns the request in the form returned by the payment request RPCs and posted to the payment webhook.
+func (r *PaymentRequest) Result() btcjson.PaymentRequestResult {
+	res := btcjson.PaymentRequestResult{
+		Address:  r.Address,
+		Amount:   r.Amount.ToDUO(),
+		Label:    r.Label,
+		Message:  r.Message,
+		Created:  r.Created.Unix(),
+		Status:   r.Status.String(),
+		Received: r.Received.ToDUO(),
+	}
+	if !r.Expires.IsZero() {
+		res.Expires = r.Expires.Unix()
+	}
+	if !r.Fulfilled.IsZero() {
+		res.Fulfilled = r.Fulfilled.Unix()
+	}
+	return res
+}
+
+// CreatePaymentRequest creates a request for a payment of amount to a new address of the account. The request expires
+// after expiry has passed unless it is 0.
+func (w *Wallet) CreatePaymentRequest(account uint32, amount util.Amount, label, message string,
+	expiry time.Duration) (*PaymentRequest, error) {
+	if amount <= 0 {
+		return nil, ErrPaymentRequestAmount
+	}
+	if len(label) > maxPaymentRequestText || len(message) > maxPaymentRequestText {
+		return nil, fmt.Errorf("payment request label and message are limited to %d bytes", maxPaymentRequestText)
+	}
+	addr, err := w.NewAddress(account, waddrmgr.KeyScopeBIP0044, false)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	now := time.Now()
+	r := &PaymentRequest{
+		Address: addr.EncodeAddress(),
+		Amount:  amount,
+		Label:   label,
+		Message: message,
+		Created: now,
+		Status:  PaymentRequestPending,
+	}
+	if expiry > 0 {
+		r.Expires = now.Add(expiry)
+	}
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(paymentRequestsNamespaceKey)
+		if ns == nil {
+			var err error
+			if ns, err = tx.CreateTopLevelBucket(paymentRequestsNamespaceKey); err != nil {
+				return err
+			}
+		}
+		return ns.Put([]byte(r.Address), serializePaymentRequest(r))
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return r, nil
+}
+
+// PaymentRequests returns all payment requests, oldest first. Pending requests that have passed their expiry but have
+// not been settled by a new block yet are reported as expired.
+func (w *Wallet) PaymentRequests() ([]PaymentRequest, error) {
+	var requests []PaymentRequest
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		ns := tx.ReadBucket(paymentRequestsNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		return ns.ForEach(func(k, v []byte) error {
+			r, err := deserializePaymentRequest(string(k), v)
+			if err != nil {
+				return err
+			}
+			requests = append(requests, *r)
+			return nil
+		})
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	now := time.Now()
+	for i := range requests {
+		r := &requests[i]
+		if r.Status == PaymentRequestPending && !r.Expires.IsZero() && now.After(r.Expires) {
+			r.Status = PaymentRequestExpired
+		}
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Created.Before(requests[j].Created)
+	})
+	return requests, nil
+}
+
+// updatePaymentRequests settles the pending payment requests against a newly connected block with the given timestamp.
+// Requests whose address has received the requested amount are marked fulfilled, and the remaining requests that
+// expired before the block are marked expired. The requests that were fulfilled are returned.
+func (w *Wallet) updatePaymentRequests(blockTime time.Time) (fulfilled []PaymentRequest, err error) {
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		ns := tx.ReadWriteBucket(paymentRequestsNamespaceKey)
+		if ns == nil {
+			return nil
+		}
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		var pending []*PaymentRequest
+		err := ns.ForEach(func(k, v []byte) error {
+			r, err := deserializePaymentRequest(string(k), v)
+			if err != nil {
+				return err
+			}
+			if r.Status == PaymentRequestPending {
+				pending = append(pending, r)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, r := range pending {
+			addr, err := util.DecodeAddress(r.Address, w.chainParams)
+			if err != nil {
+				return err
+			}
+			if r.Received, err = w.totalReceivedForAddr(txmgrNs, addr, paymentRequestMinConf); err != nil {
+				return err
+			}
+			switch {
+			case !r.Expires.IsZero() && blockTime.After(r.Expires):
+				r.Status = PaymentRequestExpired
+			case r.Received >= r.Amount:
+				r.Status = PaymentRequestFulfilled
+				r.Fulfilled = blockTime
+				fulfilled = append(fulfilled, *r)
+			}
+			if err = ns.Put([]byte(r.Address), serializePaymentRequest(r)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return
+}
+
+// settlePaymentRequests updates the payment requests after a block is connected and announces the requests that were
+// fulfilled by it to the configured webhook.
+func (w *Wallet) settlePaymentRequests(blockTime time.Time) {
+	fulfilled, err := w.updatePaymentRequests(blockTime)
+	if err != nil {
+		Error("failed to update payment requests:", err)
+		return
+	}
+	for i := range fulfilled {
+		r := &fulfilled[i]
+		Infof("payment request for %v to %s fulfilled", r.Amount, r.Address)
+		if w.PodConfig != nil && w.PodConfig.PaymentWebhook != nil && *w.PodConfig.PaymentWebhook != "" {
+			go postPaymentWebhook(*w.PodConfig.PaymentWebhook, r.Result())
+		}
+	}
+}
+
+// postPaymentWebhook posts a fulfilled payment request as JSON to the webhook url.
+func postPaymentWebhook(url string, r btcjson.PaymentRequestResult) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		Error(err)
+		return
+	}
+	client := &http.Client{Timeout: paymentWebhookTimeout}
+	res, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		Warn("payment webhook failed:", err)
+		return
+	}
+	if err = res.Body.Close(); err != nil {
+		Error(err)
+	}
+	if res.StatusCode/100 != 2 {
+		Warn("payment webhook returned status", res.Status)
+	}
+}
+
+// serializePaymentRequest serializes a request as the amount, the creation, expiry and fulfillment unix times, the
+// status, the amount received and the length prefixed label and message.
+func serializePaymentRequest(r *PaymentRequest) []byte {
+	var b bytes.Buffer
+	var v [8]byte
+	putInt64 := func(i int64) {
+		binary.LittleEndian.PutUint64(v[:], uint64(i))
+		b.Write(v[:])
+	}
+	unix := func(t time.Time) int64 {
+		if t.IsZero() {
+			return 0
+		}
+		return t.Unix()
+	}
+	putInt64(int64(r.Amount))
+	putInt64(unix(r.Created))
+	putInt64(unix(r.Expires))
+	putInt64(unix(r.Fulfilled))
+	b.WriteByte(byte(r.Status))
+	putInt64(int64(r.Received))
+	for _, s := range []string{r.Label, r.Message} {
+		binary.LittleEndian.PutUint16(v[:2], uint16(len(s)))
+		b.Write(v[:2])
+		b.WriteString(s)
+	}
+	return b.Bytes()
+}
+
+func deserializePaymentRequest(address string, v []byte) (*PaymentRequest, error) {
+	errMalformed := fmt.Errorf("malformed payment request for %s", address)
+	if len(v) < 41 {
+		return nil, errMalformed
+	}
+	int64At := func(i int) int64 {
+		return int64(binary.LittleEndian.Uint64(v[i : i+8]))
+	}
+	unix := func(i int64) time.Time {
+		if i == 0 {
+			return time.Time{}
+		}
+		return time.Unix(i, 0)
+	}
+	r := &PaymentRequest{
+		Address:   address,
+		Amount:    util.Amount(int64At(0)),
+		Created:   unix(int64At(8)),
+		Expires:   unix(int64At(16)),
+		Fulfilled: unix(int64At(24)),
+		Status:    PaymentRequestStatus(v[32]),
+		Received:  util.Amount(int64At(33)),
+	}
+	v = v[41:]
+	var strs [2]string
+	for i := range strs {
+		if len(v) < 2 || len(v) < 2+int(binary.LittleEndian.Uint16(v)) {
+			return nil, errMalformed
+		}
+		n := int(binary.LittleEndian.Uint16(v))
+		strs[i] = string(v[2 : 2+n])
+		v = v[2+n:]
+	}
+	r.Label, r.Message = strs[0], strs[1]
+	return r, nil
+}
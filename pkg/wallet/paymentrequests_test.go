@@ -0,0 +1,100 @@
+package wallet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/db/walletdb"
+	_ "github.com/p9c/pod/pkg/db/walletdb/bdb"
+)
+
+// TestPaymentRequestSerialization checks that a payment request survives a round trip through its database encoding.
+func TestPaymentRequestSerialization(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+	r := &PaymentRequest{
+		Address:   "address",
+		Amount:    150000000,
+		Label:     "invoice 7",
+		Message:   "thanks",
+		Created:   now,
+		Expires:   now.Add(time.Hour),
+		Status:    PaymentRequestFulfilled,
+		Received:  160000000,
+		Fulfilled: now.Add(time.Minute),
+	}
+	got, err := deserializePaymentRequest(r.Address, serializePaymentRequest(r))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, r) {
+		t.Errorf("got %+v, want %+v", got, r)
+	}
+	if _, err = deserializePaymentRequest(r.Address, serializePaymentRequest(r)[:45]); err == nil {
+		t.Error("truncated payment request was not rejected")
+	}
+}
+
+// TestPaymentRequestsExpiry checks that requests are listed oldest first and that pending requests past their expiry
+// are reported as expired.
+func TestPaymentRequestsExpiry(t *testing.T) {
+	dir, err := ioutil.TempDir("", "paymentrequests_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := walletdb.Create("bdb", filepath.Join(dir, "wallet.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	w := &Wallet{db: db}
+	if requests, err := w.PaymentRequests(); err != nil || len(requests) != 0 {
+		t.Fatalf("got %d requests and error %v from a new wallet", len(requests), err)
+	}
+	now := time.Now()
+	stored := []*PaymentRequest{
+		{Address: "never", Amount: 1, Created: now.Add(-3 * time.Hour)},
+		{Address: "expired", Amount: 1, Created: now.Add(-2 * time.Hour), Expires: now.Add(-time.Hour)},
+		{Address: "pending", Amount: 1, Created: now.Add(-time.Hour), Expires: now.Add(time.Hour)},
+	}
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		ns, err := tx.CreateTopLevelBucket(paymentRequestsNamespaceKey)
+		if err != nil {
+			return err
+		}
+		for _, r := range stored {
+			if err = ns.Put([]byte(r.Address), serializePaymentRequest(r)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests, err := w.PaymentRequests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		address string
+		status  PaymentRequestStatus
+	}{
+		{"never", PaymentRequestPending},
+		{"expired", PaymentRequestExpired},
+		{"pending", PaymentRequestPending},
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(requests), len(want))
+	}
+	for i := range want {
+		if requests[i].Address != want[i].address || requests[i].Status != want[i].status {
+			t.Errorf("request %d is %s %v, want %s %v", i, requests[i].Address, requests[i].Status,
+				want[i].address, want[i].status)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package wallet
+
+import (
+	"sort"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/rpc/btcjson"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// roundAmountUnit is the granularity at which a payment amount is treated as round. Amounts typed in by a person are
+// usually exact multiples of it, while change almost never is, so such a payment reveals which output is the change.
+const roundAmountUnit = util.Amount(100000)
+
+// PrivacyReport analyses the wallet's transaction history for patterns that weaken the privacy of its owner on a
+// transparent chain: addresses that received funds more than once, transactions that spent outputs of several wallet
+// addresses together, and round amount payments that give away the change output.
+func (w *Wallet) PrivacyReport() (*btcjson.PrivacyReportResult, error) {
+	var details []wtxmgr.TxDetails
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		rangeFn := func(d []wtxmgr.TxDetails) (bool, error) {
+			// The details slice is reused by the store between calls, appending copies the records out of it.
+			details = append(details, d...)
+			return false, nil
+		}
+		return w.TxStore.RangeTransactions(txmgrNs, 0, -1, rangeFn)
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return privacyReport(details, w.chainParams), nil
+}
+
+// privacyReport builds the privacy report for the given transaction details, which must be ordered oldest first.
+func privacyReport(details []wtxmgr.TxDetails, chainParams *netparams.Params) *btcjson.PrivacyReportResult {
+	type receipt struct {
+		txs    map[chainhash.Hash]struct{}
+		amount util.Amount
+	}
+	// Record the address of every wallet credit so that the inputs spending them can be traced back.
+	owners := make(map[wire.OutPoint]string)
+	received := make(map[string]*receipt)
+	var order []string
+	for i := range details {
+		d := &details[i]
+		for _, cred := range d.Credits {
+			addr := outputAddress(d.MsgTx.TxOut[cred.Index].PkScript, chainParams)
+			if addr == "" {
+				continue
+			}
+			owners[wire.OutPoint{Hash: d.Hash, Index: cred.Index}] = addr
+			r, ok := received[addr]
+			if !ok {
+				r = &receipt{txs: make(map[chainhash.Hash]struct{})}
+				received[addr] = r
+				order = append(order, addr)
+			}
+			r.txs[d.Hash] = struct{}{}
+			r.amount += cred.Amount
+		}
+	}
+	res := &btcjson.PrivacyReportResult{
+		Transactions:    len(details),
+		ReusedAddresses: []btcjson.ReusedAddressResult{},
+		MergedInputs:    []btcjson.MergedInputsResult{},
+		RoundAmounts:    []btcjson.RoundAmountResult{},
+		Suggestions:     []string{},
+	}
+	for _, addr := range order {
+		r := received[addr]
+		if len(r.txs) < 2 {
+			continue
+		}
+		res.ReusedAddresses = append(res.ReusedAddresses, btcjson.ReusedAddressResult{
+			Address:  addr,
+			Received: len(r.txs),
+			Amount:   r.amount.ToDUO(),
+		})
+	}
+	for i := range details {
+		d := &details[i]
+		if len(d.Debits) == 0 {
+			continue
+		}
+		seen := make(map[string]struct{})
+		var addrs []string
+		for _, deb := range d.Debits {
+			addr, ok := owners[d.MsgTx.TxIn[deb.Index].PreviousOutPoint]
+			if !ok {
+				continue
+			}
+			if _, ok := seen[addr]; ok {
+				continue
+			}
+			seen[addr] = struct{}{}
+			addrs = append(addrs, addr)
+		}
+		if len(addrs) > 1 {
+			sort.Strings(addrs)
+			res.MergedInputs = append(res.MergedInputs, btcjson.MergedInputsResult{
+				TxID:      d.Hash.String(),
+				Addresses: addrs,
+			})
+		}
+		// A round payment only gives something away when it sits next to a change output that is not round itself.
+		credited := make(map[uint32]struct{})
+		roundChange := true
+		for _, cred := range d.Credits {
+			credited[cred.Index] = struct{}{}
+			if cred.Amount%roundAmountUnit != 0 {
+				roundChange = false
+			}
+		}
+		if len(credited) == 0 || roundChange {
+			continue
+		}
+		for j, txOut := range d.MsgTx.TxOut {
+			if _, ok := credited[uint32(j)]; ok {
+				continue
+			}
+			amt := util.Amount(txOut.Value)
+			if amt == 0 || amt%roundAmountUnit != 0 {
+				continue
+			}
+			res.RoundAmounts = append(res.RoundAmounts, btcjson.RoundAmountResult{
+				TxID:    d.Hash.String(),
+				Address: outputAddress(txOut.PkScript, chainParams),
+				Amount:  amt.ToDUO(),
+			})
+		}
+	}
+	if len(res.ReusedAddresses) > 0 {
+		res.Suggestions = append(res.Suggestions,
+			"use a new receiving address for every payment, reused addresses link all of their payments together")
+	}
+	if len(res.MergedInputs) > 0 {
+		res.Suggestions = append(res.Suggestions,
+			"avoid spending outputs of several addresses in one transaction, it shows they belong to the same owner")
+	}
+	if len(res.RoundAmounts) > 0 {
+		res.Suggestions = append(res.Suggestions,
+			"avoid sending round amounts, they make it easy to tell the payment apart from the change")
+	}
+	return res
+}
+
+// outputAddress returns the encoded address paid by a public key script, or an empty string if the script does not
+// pay to exactly one address.
+func outputAddress(pkScript []byte, chainParams *netparams.Params) string {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, chainParams)
+	if err != nil || len(addrs) != 1 {
+		return ""
+	}
+	return addrs[0].EncodeAddress()
+}
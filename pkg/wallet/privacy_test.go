@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestPrivacyReport checks that address reuse, merged inputs and round amount payments are all picked up from a small
+// synthetic history.
+func TestPrivacyReport(t *testing.T) {
+	params := &netparams.TestNet3Params
+	addrs := make([]string, 4)
+	scripts := make([][]byte, 4)
+	for i := range addrs {
+		hash := make([]byte, 20)
+		hash[0] = byte(i + 1)
+		addr, err := util.NewAddressPubKeyHash(hash, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addrs[i] = addr.EncodeAddress()
+		if scripts[i], err = txscript.PayToAddrScript(addr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	newDetails := func(msgTx *wire.MsgTx, credits []wtxmgr.CreditRecord, debits []wtxmgr.DebitRecord) wtxmgr.TxDetails {
+		rec, err := wtxmgr.NewTxRecordFromMsgTx(msgTx, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return wtxmgr.TxDetails{TxRecord: *rec, Credits: credits, Debits: debits}
+	}
+	// Two payments to the first address and one to the second.
+	fund1 := wire.NewMsgTx(wire.TxVersion)
+	fund1.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 1}, nil, nil))
+	fund1.AddTxOut(wire.NewTxOut(123456, scripts[0]))
+	fund2 := wire.NewMsgTx(wire.TxVersion)
+	fund2.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 2}, nil, nil))
+	fund2.AddTxOut(wire.NewTxOut(234567, scripts[0]))
+	fund2.AddTxOut(wire.NewTxOut(345678, scripts[1]))
+	// A spend merging the first two addresses, paying a round amount to a foreign address with change to the third.
+	fund1Hash, fund2Hash := fund1.TxHash(), fund2.TxHash()
+	spend := wire.NewMsgTx(wire.TxVersion)
+	spend.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&fund1Hash, 0), nil, nil))
+	spend.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&fund2Hash, 1), nil, nil))
+	spend.AddTxOut(wire.NewTxOut(300000, scripts[3]))
+	spend.AddTxOut(wire.NewTxOut(169134, scripts[2]))
+	details := []wtxmgr.TxDetails{
+		newDetails(fund1, []wtxmgr.CreditRecord{{Amount: 123456, Index: 0}}, nil),
+		newDetails(fund2, []wtxmgr.CreditRecord{{Amount: 234567, Index: 0}, {Amount: 345678, Index: 1}}, nil),
+		newDetails(spend, []wtxmgr.CreditRecord{{Amount: 169134, Index: 1, Change: true}},
+			[]wtxmgr.DebitRecord{{Amount: 123456, Index: 0}, {Amount: 345678, Index: 1}}),
+	}
+	res := privacyReport(details, params)
+	if res.Transactions != 3 {
+		t.Errorf("got %d transactions, want 3", res.Transactions)
+	}
+	if len(res.ReusedAddresses) != 1 || res.ReusedAddresses[0].Address != addrs[0] ||
+		res.ReusedAddresses[0].Received != 2 {
+		t.Errorf("unexpected reused addresses %+v", res.ReusedAddresses)
+	}
+	if len(res.MergedInputs) != 1 || res.MergedInputs[0].TxID != spend.TxHash().String() ||
+		len(res.MergedInputs[0].Addresses) != 2 {
+		t.Errorf("unexpected merged inputs %+v", res.MergedInputs)
+	}
+	if len(res.RoundAmounts) != 1 || res.RoundAmounts[0].Address != addrs[3] ||
+		res.RoundAmounts[0].Amount != 0.003 {
+		t.Errorf("unexpected round amounts %+v", res.RoundAmounts)
+	}
+	if len(res.Suggestions) != 3 {
+		t.Errorf("got %d suggestions, want 3", len(res.Suggestions))
+	}
+}
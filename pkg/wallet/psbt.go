@@ -0,0 +1,458 @@
+package wallet
+
+import (
+	"fmt"
+
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/psbt"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// FundPSBTOptions are the choices made when creating a funded partially signed transaction with CreateFundedPSBT.
+type FundPSBTOptions struct {
+	// Inputs are outputs of the account that must be spent. More are added when they do not cover the payment.
+	Inputs []wire.OutPoint
+	// LockTime is the lock time of the transaction. When it is set the inputs are given a sequence number that lets
+	// the lock time take effect.
+	LockTime uint32
+	// ChangeAddress receives the change. A new change address of the account is used when it is nil.
+	ChangeAddress util.Address
+	// ChangePosition is the index of the change output, which is placed at random when it is negative.
+	ChangePosition int
+	// LockUnspents locks the spent outputs so the wallet does not spend them again before the transaction is
+	// published.
+	LockUnspents bool
+	// Bip32Derivations adds the derivations of the keys of the inputs and the change from the wallet's master key.
+	Bip32Derivations bool
+}
+
+// CreateFundedPSBT creates an unsigned transaction paying outputs from an account, returned as a partially signed
+// transaction carrying the previous transactions of its inputs for any signer to sign. Change goes to a pay to pubkey
+// hash address, as partially signed transactions spending witness outputs cannot be finalized. It returns the packet,
+// the fee it pays and the index of the change output, which is -1 when there is no change. Nothing is recorded until
+// the signed transaction is published.
+func (w *Wallet) CreateFundedPSBT(account uint32, outputs []*wire.TxOut, minconf int32, satPerKb util.Amount,
+	opts *FundPSBTOptions) (*psbt.Packet, util.Amount, int, error) {
+	for _, output := range outputs {
+		if err := txrules.CheckOutput(output, satPerKb); err != nil {
+			return nil, 0, 0, err
+		}
+	}
+	if opts.ChangePosition > len(outputs) {
+		return nil, 0, 0, fmt.Errorf("change position %d is past the end of the %d outputs", opts.ChangePosition,
+			len(outputs))
+	}
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		Error(err)
+		return nil, 0, 0, err
+	}
+	bs, err := chainClient.BlockStamp()
+	if err != nil {
+		Error(err)
+		return nil, 0, 0, err
+	}
+	manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		Error(err)
+		return nil, 0, 0, err
+	}
+	var (
+		authored *txauthor.AuthoredTx
+		p        *psbt.Packet
+	)
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		eligible, err := w.findEligibleOutputs(dbtx, account, minconf, bs)
+		if err != nil {
+			return err
+		}
+		inputSource, err := requiredInputSource(eligible, opts.Inputs)
+		if err != nil {
+			return err
+		}
+		var changeSource txauthor.ChangeSource
+		if opts.ChangeAddress != nil {
+			changeSource = func() ([]byte, error) {
+				return txscript.PayToAddrScript(opts.ChangeAddress)
+			}
+		} else {
+			// As when sending, change from the imported account goes to the default account.
+			changeAccount := account
+			if account == waddrmgr.ImportedAddrAccount {
+				changeAccount = waddrmgr.DefaultAccountNum
+			}
+			changeSource = pubKeyHashChangeSource(manager, addrmgrNs, changeAccount)
+		}
+		if authored, err = w.authorTxFrom(dbtx, outputs, inputSource, satPerKb, changeSource); err != nil {
+			return err
+		}
+		if authored.ChangeIndex >= 0 {
+			if opts.ChangePosition < 0 {
+				authored.RandomizeChangePosition()
+			} else {
+				moveChange(authored, opts.ChangePosition)
+			}
+		}
+		if opts.LockTime != 0 {
+			authored.Tx.LockTime = opts.LockTime
+			for _, txIn := range authored.Tx.TxIn {
+				txIn.Sequence = wire.MaxTxInSequenceNum - 1
+			}
+		}
+		if p, err = psbt.New(authored.Tx); err != nil {
+			return err
+		}
+		fingerprint, err := w.derivationFingerprint(dbtx, account)
+		if err != nil {
+			return err
+		}
+		for i, txIn := range authored.Tx.TxIn {
+			details, err := w.TxStore.TxDetails(txmgrNs, &txIn.PreviousOutPoint.Hash)
+			if err != nil {
+				return err
+			}
+			if details == nil {
+				return fmt.Errorf("%v not found", txIn.PreviousOutPoint)
+			}
+			p.Inputs[i].NonWitnessUtxo = &details.MsgTx
+			if opts.Bip32Derivations {
+				// imported keys have no derivation to add
+				p.Inputs[i].Bip32Derivation, _ = w.bip32Derivation(addrmgrNs, authored.PrevScripts[i], fingerprint)
+			}
+		}
+		if opts.Bip32Derivations && authored.ChangeIndex >= 0 {
+			p.Outputs[authored.ChangeIndex].Bip32Derivation, _ = w.bip32Derivation(addrmgrNs,
+				authored.Tx.TxOut[authored.ChangeIndex].PkScript, fingerprint)
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, 0, 0, err
+	}
+	if opts.LockUnspents {
+		for _, txIn := range authored.Tx.TxIn {
+			if err = w.LockOutpoint(txIn.PreviousOutPoint); err != nil {
+				Error(err)
+				return nil, 0, 0, err
+			}
+		}
+	}
+	return p, authored.Fee(), authored.ChangeIndex, nil
+}
+
+// ProcessPSBT updates a partially signed transaction with what the wallet knows about its inputs: the previous
+// transactions they spend, the redeem scripts of the wallet's pay to script hash addresses and, when bip32Derivs is set,
+// the derivations of the wallet's keys. When sign is set the inputs the wallet holds keys for are signed with hashType,
+// including those of its multisig accounts, which needs the wallet to be unlocked. Keys of addresses the wallet has not
+// derived itself, as when it signs offline for a watching-only wallet, are found by the derivations the inputs carry.
+// Inputs that have all their signatures are finalized. It returns the updated packet and whether it is complete.
+func (w *Wallet) ProcessPSBT(p *psbt.Packet, sign bool, hashType txscript.SigHashType,
+	bip32Derivs bool) (*psbt.Packet, bool, error) {
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		for i := range p.Inputs {
+			in := &p.Inputs[i]
+			if in.FinalScriptSig != nil {
+				continue
+			}
+			if in.NonWitnessUtxo == nil {
+				details, err := w.TxStore.TxDetails(txmgrNs, &p.UnsignedTx.TxIn[i].PreviousOutPoint.Hash)
+				if err != nil {
+					return err
+				}
+				if details != nil {
+					in.NonWitnessUtxo = &details.MsgTx
+				}
+			}
+			prevOut := p.PrevOut(i)
+			if prevOut == nil {
+				continue
+			}
+			if sign && in.SighashType != 0 && in.SighashType != hashType {
+				return fmt.Errorf("input %d is to be signed with sighash type %v, not %v", i, in.SighashType,
+					hashType)
+			}
+			keys, script, err := w.inputKeys(addrmgrNs, in, prevOut.PkScript)
+			if err != nil {
+				return err
+			}
+			for _, key := range keys {
+				if bip32Derivs && !hasDerivation(in.Bip32Derivation, key.pubKey) {
+					fingerprint, err := w.derivationFingerprint(dbtx, key.addr.Account())
+					if err != nil {
+						return err
+					}
+					if d := keyDerivation(key.addr, fingerprint); d != nil {
+						d.PubKey = key.pubKey
+						in.Bip32Derivation = append(in.Bip32Derivation, d)
+					}
+				}
+				if !sign {
+					continue
+				}
+				privKey, err := key.addr.PrivKey()
+				if waddrmgr.IsError(err, waddrmgr.ErrWatchingOnly) {
+					continue
+				}
+				if err != nil {
+					return err
+				}
+				sig, err := txscript.RawTxInSignature(p.UnsignedTx, i, script, hashType, privKey)
+				if err != nil {
+					return err
+				}
+				p.AddPartialSig(i, key.pubKey, sig)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		Error(err)
+		return nil, false, err
+	}
+	if sign && hashType == txscript.SigHashAll {
+		if p, _, err = w.SignMultisigPSBT(p); err != nil {
+			return nil, false, err
+		}
+	}
+	complete, err := psbt.MaybeFinalizeAll(p)
+	if err != nil {
+		Error(err)
+		return nil, false, err
+	}
+	return p, complete, nil
+}
+
+// inputKey is a wallet key that signs an input, with its public key serialized as it appears in the script it signs.
+type inputKey struct {
+	addr   waddrmgr.ManagedPubKeyAddress
+	pubKey []byte
+}
+
+// inputKeys returns the wallet keys that sign an input spending pkScript and the script they sign, which is the redeem
+// script for a pay to script hash output. The redeem script of a wallet script address is added to the input.
+func (w *Wallet) inputKeys(addrmgrNs walletdb.ReadBucket, in *psbt.PInput,
+	pkScript []byte) (keys []inputKey, script []byte, err error) {
+	script = pkScript
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+	if err != nil || len(addrs) != 1 {
+		return nil, script, nil
+	}
+	ma, err := w.Manager.Address(addrmgrNs, addrs[0])
+	if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+		// a wallet that has not yet derived the address, such as an offline signer, finds its key by the derivation
+		// the input carries
+		ma, err = w.derivedAddress(addrmgrNs, in.Bip32Derivation, addrs[0])
+	}
+	if err != nil || ma == nil {
+		return nil, script, err
+	}
+	switch a := ma.(type) {
+	case waddrmgr.ManagedPubKeyAddress:
+		pubKey := a.PubKey().SerializeUncompressed()
+		if a.Compressed() {
+			pubKey = a.PubKey().SerializeCompressed()
+		}
+		return []inputKey{{addr: a, pubKey: pubKey}}, script, nil
+	case waddrmgr.ManagedScriptAddress:
+		if in.RedeemScript == nil {
+			if in.RedeemScript, err = a.Script(); err != nil {
+				return nil, script, err
+			}
+		}
+	}
+	if in.RedeemScript == nil {
+		return nil, script, nil
+	}
+	// the wallet signs with those of the keys of a multisig redeem script that it holds
+	script = in.RedeemScript
+	_, addrs, _, err = txscript.ExtractPkScriptAddrs(script, w.chainParams)
+	if err != nil {
+		return nil, script, nil
+	}
+	for _, addr := range addrs {
+		pk, ok := addr.(*util.AddressPubKey)
+		if !ok {
+			continue
+		}
+		ma, err := w.Manager.Address(addrmgrNs, pk.AddressPubKeyHash())
+		if waddrmgr.IsError(err, waddrmgr.ErrAddressNotFound) {
+			ma, err = w.derivedAddress(addrmgrNs, in.Bip32Derivation, pk.AddressPubKeyHash())
+		}
+		if err != nil || ma == nil {
+			continue
+		}
+		if pka, ok := ma.(waddrmgr.ManagedPubKeyAddress); ok {
+			keys = append(keys, inputKey{addr: pka, pubKey: pk.ScriptAddress()})
+		}
+	}
+	return keys, script, nil
+}
+
+// derivedAddress derives the wallet address of one of the derivations of an input whose public key hashes to addr. A
+// derivation is only used when its path is that of a wallet account in one of the wallet's key scopes and the key the
+// wallet derives at the path is the one it names, so the master key fingerprint, which a watching-only wallet leaves
+// zero, is not relied upon. It returns nil when there is no such derivation.
+func (w *Wallet) derivedAddress(addrmgrNs walletdb.ReadBucket, derivations []*psbt.Bip32Derivation,
+	addr util.Address) (waddrmgr.ManagedAddress, error) {
+	for _, d := range derivations {
+		if string(util.Hash160(d.PubKey)) != string(addr.ScriptAddress()) {
+			continue
+		}
+		scope, path, ok := splitDerivationPath(d.Path)
+		if !ok {
+			continue
+		}
+		manager, err := w.Manager.FetchScopedKeyManager(scope)
+		if err != nil {
+			continue
+		}
+		ma, err := manager.DeriveFromKeyPath(addrmgrNs, path)
+		if waddrmgr.IsError(err, waddrmgr.ErrAccountNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if pka, ok := ma.(waddrmgr.ManagedPubKeyAddress); ok &&
+			string(pka.PubKey().SerializeCompressed()) == string(d.PubKey) {
+			return ma, nil
+		}
+	}
+	return nil, nil
+}
+
+// splitDerivationPath splits a derivation path from the master key into the key scope and the path within the scope, the
+// reverse of fullDerivationPath. It returns false for a path that is not of the form m/purpose'/coin'/account'/branch/index.
+func splitDerivationPath(path []uint32) (scope waddrmgr.KeyScope, kp waddrmgr.DerivationPath, ok bool) {
+	if len(path) != 5 {
+		return
+	}
+	for i, index := range path {
+		if hardened := i < 3; hardened != (index >= hdkeychain.HardenedKeyStart) {
+			return
+		}
+	}
+	scope = waddrmgr.KeyScope{
+		Purpose: path[0] - hdkeychain.HardenedKeyStart,
+		Coin:    path[1] - hdkeychain.HardenedKeyStart,
+	}
+	kp = waddrmgr.DerivationPath{
+		Account: path[2] - hdkeychain.HardenedKeyStart,
+		Branch:  path[3],
+		Index:   path[4],
+	}
+	return scope, kp, true
+}
+
+// hasDerivation returns whether derivations include one for a public key.
+func hasDerivation(derivations []*psbt.Bip32Derivation, pubKey []byte) bool {
+	for _, d := range derivations {
+		if string(d.PubKey) == string(pubKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// derivationFingerprint returns the fingerprint of the master key the keys of an account are derived from. An account
+// paired with a signing device carries the fingerprint of the device. A watching-only wallet does not know its master
+// key and gives a zero fingerprint, so that the derivation paths it adds still let an offline signer holding the keys
+// find them.
+func (w *Wallet) derivationFingerprint(dbtx walletdb.ReadTx, account uint32) (fingerprint uint32, err error) {
+	var device *SigningDeviceInfo
+	if device, err = pairedSigningDevice(dbtx, account); err != nil {
+		return
+	}
+	if device != nil {
+		return device.Fingerprint, nil
+	}
+	fingerprint, err = w.Manager.MasterKeyFingerprint(dbtx.ReadBucket(waddrmgrNamespaceKey))
+	if waddrmgr.IsError(err, waddrmgr.ErrWatchingOnly) {
+		return 0, nil
+	}
+	return fingerprint, err
+}
+
+// pubKeyHashChangeSource returns a change source paying to new pay to pubkey hash addresses of the internal branch of
+// an account.
+func pubKeyHashChangeSource(manager *waddrmgr.ScopedKeyManager, addrmgrNs walletdb.ReadWriteBucket,
+	account uint32) txauthor.ChangeSource {
+	return func() ([]byte, error) {
+		addrs, err := manager.NextInternalAddresses(addrmgrNs, account, 1)
+		if err != nil {
+			return nil, err
+		}
+		return txscript.PayToAddrScript(addrs[0].Address())
+	}
+}
+
+// requiredInputSource returns an input source that spends the required outputs, in the order given, and picks more
+// from the rest of the eligible outputs when they do not cover the target.
+func requiredInputSource(eligible []wtxmgr.Credit, required []wire.OutPoint) (txauthor.InputSource, error) {
+	if len(required) == 0 {
+		return makeInputSource(eligible), nil
+	}
+	byOutPoint := make(map[wire.OutPoint]int, len(eligible))
+	for i := range eligible {
+		byOutPoint[eligible[i].OutPoint] = i
+	}
+	var (
+		total   util.Amount
+		inputs  []*wire.TxIn
+		values  []util.Amount
+		scripts [][]byte
+	)
+	for i := range required {
+		j, ok := byOutPoint[required[i]]
+		if !ok {
+			return nil, fmt.Errorf("%v is not a spendable output of the account", required[i])
+		}
+		delete(byOutPoint, required[i])
+		total += eligible[j].Amount
+		inputs = append(inputs, wire.NewTxIn(&eligible[j].OutPoint, nil, nil))
+		values = append(values, eligible[j].Amount)
+		scripts = append(scripts, eligible[j].PkScript)
+	}
+	rest := make([]wtxmgr.Credit, 0, len(byOutPoint))
+	for i := range eligible {
+		if _, ok := byOutPoint[eligible[i].OutPoint]; ok {
+			rest = append(rest, eligible[i])
+		}
+	}
+	more := makeInputSource(rest)
+	return func(target util.Amount) (util.Amount, []*wire.TxIn, []util.Amount, [][]byte, error) {
+		if total >= target {
+			return total, inputs, values, scripts, nil
+		}
+		moreTotal, moreInputs, moreValues, moreScripts, err := more(target - total)
+		if err != nil {
+			return 0, nil, nil, nil, err
+		}
+		return total + moreTotal,
+			append(inputs[:len(inputs):len(inputs)], moreInputs...),
+			append(values[:len(values):len(values)], moreValues...),
+			append(scripts[:len(scripts):len(scripts)], moreScripts...),
+			nil
+	}, nil
+}
+
+// moveChange moves the change output of a transaction, which the author adds last, to index pos.
+func moveChange(tx *txauthor.AuthoredTx, pos int) {
+	txOut := tx.Tx.TxOut
+	change := txOut[tx.ChangeIndex]
+	copy(txOut[pos+1:tx.ChangeIndex+1], txOut[pos:tx.ChangeIndex])
+	txOut[pos] = change
+	tx.ChangeIndex = pos
+}
@@ -0,0 +1,86 @@
+package wallet
+
+import (
+	"testing"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// TestRequiredInputSource checks that the required outputs are spent first, in the order given, that more outputs are
+// only added when they do not cover the target, and that an output that cannot be spent is refused.
+func TestRequiredInputSource(t *testing.T) {
+	credit := func(n byte, amount util.Amount) wtxmgr.Credit {
+		return wtxmgr.Credit{
+			OutPoint: wire.OutPoint{Hash: chainhash.Hash{n}},
+			Amount:   amount,
+			PkScript: []byte{n},
+		}
+	}
+	eligible := func() []wtxmgr.Credit {
+		return []wtxmgr.Credit{credit(1, 5000), credit(2, 1000), credit(3, 3000)}
+	}
+	required := []wire.OutPoint{{Hash: chainhash.Hash{2}}, {Hash: chainhash.Hash{3}}}
+	source, err := requiredInputSource(eligible(), required)
+	if err != nil {
+		t.Fatal(err)
+	}
+	total, inputs, values, scripts, err := source(4000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 4000 || len(inputs) != 2 || inputs[0].PreviousOutPoint != required[0] ||
+		inputs[1].PreviousOutPoint != required[1] || values[1] != 3000 || scripts[1][0] != 3 {
+		t.Fatalf("got total %v from %d inputs, want the 4000 of the required outputs", total, len(inputs))
+	}
+	if total, inputs, _, _, err = source(6000); err != nil {
+		t.Fatal(err)
+	}
+	if total != 9000 || len(inputs) != 3 || inputs[2].PreviousOutPoint.Hash != (chainhash.Hash{1}) {
+		t.Fatalf("got total %v from %d inputs, want 9000 from 3", total, len(inputs))
+	}
+	if _, err = requiredInputSource(eligible(), []wire.OutPoint{{Hash: chainhash.Hash{4}}}); err == nil {
+		t.Fatal("an output that is not eligible was accepted")
+	}
+}
+
+// TestMoveChange checks that the change output is moved to the position asked for, keeping the order of the others.
+func TestMoveChange(t *testing.T) {
+	tx := &txauthor.AuthoredTx{Tx: wire.NewMsgTx(wire.TxVersion), ChangeIndex: 3}
+	for i := 0; i < 4; i++ {
+		tx.Tx.AddTxOut(wire.NewTxOut(int64(i), nil))
+	}
+	moveChange(tx, 1)
+	want := []int64{0, 3, 1, 2}
+	for i, txOut := range tx.Tx.TxOut {
+		if txOut.Value != want[i] {
+			t.Fatalf("output %d has value %d, want %d", i, txOut.Value, want[i])
+		}
+	}
+	if tx.ChangeIndex != 1 {
+		t.Fatalf("change index is %d, want 1", tx.ChangeIndex)
+	}
+}
+
+// TestSplitDerivationPath checks that a derivation path from the master key splits into the scope and path it was
+// built from, and that paths of another form are refused.
+func TestSplitDerivationPath(t *testing.T) {
+	path := waddrmgr.DerivationPath{Account: 2, Branch: 1, Index: 7}
+	scope, kp, ok := splitDerivationPath(fullDerivationPath(waddrmgr.KeyScopeBIP0044, path))
+	if !ok || scope != waddrmgr.KeyScopeBIP0044 || kp != path {
+		t.Fatalf("got scope %v path %v, want %v %v", scope, kp, waddrmgr.KeyScopeBIP0044, path)
+	}
+	for _, s := range []string{"m/44'/0'/2'", "m/44'/0'/2/1/7", "m/44'/0'/2'/1'/7", "m/44'/0'/2'/1/7/0"} {
+		p, err := ParseDerivationPath(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, _, ok := splitDerivationPath(p); ok {
+			t.Fatalf("%s was split", s)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package wallet
+
+import (
+	"bytes"
+	"sort"
+	"time"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// Reconciliation is the outcome of bringing the wallet history in line with the chain when the wallet connects to
+// it. A wallet restored from a backup onto a node that has advanced past it is missing the transactions made since the
+// backup, and may still hold unmined transactions that have since been replaced, so the difference is reported rather
+// than silently showing a wrong balance.
+type Reconciliation struct {
+	Started  time.Time
+	Finished time.Time
+	// FromHeight is the height the wallet was synced to before, and ToHeight the best height of the chain when it
+	// connected
+	FromHeight int32
+	ToHeight   int32
+	// AddressesDerived is the number of addresses found in use on the chain that the wallet had not derived yet
+	AddressesDerived int
+	// MissingTxs are the transactions found on the chain that the wallet did not have
+	MissingTxs []chainhash.Hash
+	// ExtraTxs are the transactions the wallet had that the chain rejected, which were removed
+	ExtraTxs      []chainhash.Hash
+	BalanceBefore util.Amount
+	BalanceAfter  util.Amount
+}
+
+// Changed returns whether the reconciliation found the wallet history out of line with the chain.
+func (r *Reconciliation) Changed() bool {
+	return r.AddressesDerived > 0 || len(r.MissingTxs) > 0 || len(r.ExtraTxs) > 0 ||
+		r.BalanceBefore != r.BalanceAfter
+}
+
+// historyState is the part of the state of the wallet a reconciliation compares from before to after syncing.
+type historyState struct {
+	txs       map[chainhash.Hash]struct{}
+	addresses int
+	balance   util.Amount
+}
+
+// LastReconciliation returns the outcome of the latest reconciliation of the wallet history with the chain, or nil if
+// the wallet has not finished syncing with the chain yet.
+func (w *Wallet) LastReconciliation() *Reconciliation {
+	w.reconciliationMtx.Lock()
+	defer w.reconciliationMtx.Unlock()
+	return w.reconciliation
+}
+
+// reconcileWithChain syncs the wallet with the chain, which rescans for the transactions it is missing and derives the
+// addresses in use up to the recovery window, then resends the unmined transactions so those the chain rejects are
+// removed, and records how the wallet history changed.
+func (w *Wallet) reconcileWithChain() error {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	r := &Reconciliation{Started: time.Now(), FromHeight: w.Manager.SyncedTo().Height}
+	if _, r.ToHeight, err = chainClient.GetBestBlock(); err != nil {
+		Error(err)
+		return err
+	}
+	before, err := w.historyState()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	if err = w.syncWithChain(); err != nil {
+		return err
+	}
+	w.resendUnminedTxs()
+	after, err := w.historyState()
+	if err != nil {
+		Error(err)
+		return err
+	}
+	r.Finished = time.Now()
+	r.AddressesDerived = after.addresses - before.addresses
+	r.MissingTxs = txsNotIn(after.txs, before.txs)
+	r.ExtraTxs = txsNotIn(before.txs, after.txs)
+	r.BalanceBefore, r.BalanceAfter = before.balance, after.balance
+	if r.Changed() {
+		Warnf(
+			"wallet history reconciled from height %d to %d: %d addresses derived, %d transactions missing, %d "+
+				"transactions removed, balance %v -> %v",
+			r.FromHeight, r.ToHeight, r.AddressesDerived, len(r.MissingTxs), len(r.ExtraTxs), r.BalanceBefore,
+			r.BalanceAfter,
+		)
+	} else {
+		Debug("wallet history is in line with the chain")
+	}
+	w.reconciliationMtx.Lock()
+	w.reconciliation = r
+	w.reconciliationMtx.Unlock()
+	return nil
+}
+
+// historyState reads the transactions, the number of active addresses and the balance of the wallet.
+func (w *Wallet) historyState() (s historyState, err error) {
+	s.txs = make(map[chainhash.Hash]struct{})
+	err = walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		if err := w.Manager.ForEachActiveAddress(addrmgrNs, func(util.Address) error {
+			s.addresses++
+			return nil
+		}); err != nil {
+			return err
+		}
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			return err
+		}
+		for i := range unspent {
+			s.balance += unspent[i].Amount
+		}
+		return w.TxStore.RangeTransactions(txmgrNs, 0, -1, func(d []wtxmgr.TxDetails) (bool, error) {
+			for i := range d {
+				s.txs[d[i].Hash] = struct{}{}
+			}
+			return false, nil
+		})
+	})
+	return
+}
+
+// txsNotIn returns the hashes in a that are not in b, sorted so the result is stable.
+func txsNotIn(a, b map[chainhash.Hash]struct{}) (out []chainhash.Hash) {
+	for hash := range a {
+		if _, ok := b[hash]; !ok {
+			out = append(out, hash)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return bytes.Compare(out[i][:], out[j][:]) < 0
+	})
+	return
+}
@@ -0,0 +1,43 @@
+package wallet
+
+import (
+	"testing"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+)
+
+// TestTxsNotIn checks that the transactions missing from the wallet and those removed from it are told apart when the
+// history before and after syncing is compared.
+func TestTxsNotIn(t *testing.T) {
+	hash := func(b byte) chainhash.Hash {
+		return chainhash.Hash{b}
+	}
+	set := func(hashes ...chainhash.Hash) map[chainhash.Hash]struct{} {
+		m := make(map[chainhash.Hash]struct{})
+		for _, h := range hashes {
+			m[h] = struct{}{}
+		}
+		return m
+	}
+	before := set(hash(1), hash(2), hash(3))
+	after := set(hash(1), hash(5), hash(3), hash(4))
+	missing := txsNotIn(after, before)
+	if len(missing) != 2 || missing[0] != hash(4) || missing[1] != hash(5) {
+		t.Errorf("missing transactions are %v, want %v and %v", missing, hash(4), hash(5))
+	}
+	extra := txsNotIn(before, after)
+	if len(extra) != 1 || extra[0] != hash(2) {
+		t.Errorf("extra transactions are %v, want %v", extra, hash(2))
+	}
+	if out := txsNotIn(before, before); len(out) != 0 {
+		t.Errorf("got %v from the same history, want none", out)
+	}
+	r := &Reconciliation{BalanceBefore: 1, BalanceAfter: 1}
+	if r.Changed() {
+		t.Error("reconciliation without differences reported as changed")
+	}
+	r.ExtraTxs = extra
+	if !r.Changed() {
+		t.Error("reconciliation that removed a transaction reported as unchanged")
+	}
+}
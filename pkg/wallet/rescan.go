@@ -1,9 +1,12 @@
 package wallet
 
 import (
+	"fmt"
+
 	tm "github.com/p9c/pod/pkg/chain/tx/mgr"
 	txs "github.com/p9c/pod/pkg/chain/tx/script"
 	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
 	"github.com/p9c/pod/pkg/util"
 	log "github.com/p9c/pod/pkg/util/logi"
 	wm "github.com/p9c/pod/pkg/wallet/addrmgr"
@@ -13,6 +16,7 @@ import (
 // RescanProgressMsg reports the current progress made by a rescan for a set of wallet addresses.
 type RescanProgressMsg struct {
 	Addresses    []util.Address
+	StartHeight  int32
 	Notification *chain.RescanProgress
 }
 
@@ -20,6 +24,7 @@ type RescanProgressMsg struct {
 // batch of addresses.
 type RescanFinishedMsg struct {
 	Addresses    []util.Address
+	StartHeight  int32
 	Notification *chain.RescanFinished
 }
 
@@ -119,6 +124,7 @@ out:
 				}
 				w.rescanProgress <- &RescanProgressMsg{
 					Addresses:    curBatch.addrs,
+					StartHeight:  curBatch.bs.Height,
 					Notification: n,
 				}
 			case *chain.RescanFinished:
@@ -130,6 +136,7 @@ out:
 				}
 				w.rescanFinished <- &RescanFinishedMsg{
 					Addresses:    curBatch.addrs,
+					StartHeight:  curBatch.bs.Height,
 					Notification: n,
 				}
 				curBatch, nextBatch = nextBatch, nil
@@ -162,6 +169,7 @@ out:
 				"rescanned through block %v (height %d)",
 				n.Hash, n.Height,
 			)
+			w.notifyRescanProgress(msg.StartHeight, n.Height, false)
 		case msg := <-w.rescanFinished:
 			n := msg.Notification
 			addrs := msg.Addresses
@@ -170,6 +178,7 @@ out:
 				"finished rescan for %d %s (synced to block %s, height %d)",
 				len(addrs), noun, n.Hash, n.Height,
 			)
+			w.notifyRescanProgress(msg.StartHeight, n.Height, true)
 			go w.resendUnminedTxs()
 		case <-quit:
 			break out
@@ -200,6 +209,9 @@ out:
 				"started rescan from block %v (height %d) for %d %s",
 				batch.bs.Hash, batch.bs.Height, numAddrs, noun,
 			)
+			w.rescanFoundMtx.Lock()
+			w.rescanFound = 0
+			w.rescanFoundMtx.Unlock()
 			err := chainClient.Rescan(&batch.bs.Hash, batch.addrs,
 				batch.outpoints)
 			if err != nil {
@@ -221,10 +233,95 @@ func (w *Wallet) Rescan(addrs []util.Address, unspent []tm.Credit) error {
 	return w.rescanWithTarget(addrs, unspent, nil)
 }
 
+// RescanFromHeight rescans the chain from the block at the given height for the transactions of all of the wallet's
+// addresses and unspent outputs. It returns once the rescan is submitted, its progress is sent to the clients of the
+// notification server's RescanNotifications.
+func (w *Wallet) RescanFromHeight(height int32) error {
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		return err
+	}
+	_, best, err := chainClient.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	if height < 0 || height > best {
+		return fmt.Errorf("height %d is not in the chain, which ends at height %d", height, best)
+	}
+	hash, err := chainClient.GetBlockHash(int64(height))
+	if err != nil {
+		return err
+	}
+	header, err := chainClient.GetBlockHeader(hash)
+	if err != nil {
+		return err
+	}
+	var (
+		addrs   []util.Address
+		unspent []tm.Credit
+	)
+	if err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+		var err error
+		addrs, unspent, err = w.activeData(dbtx)
+		return err
+	}); err != nil {
+		return err
+	}
+	job, err := w.rescanJob(addrs, unspent, &wm.BlockStamp{Height: height, Hash: *hash, Timestamp: header.Timestamp})
+	if err != nil {
+		return err
+	}
+	// The outcome of the rescan is reported by its notifications, so the channel is not read.
+	_ = w.SubmitRescan(job)
+	return nil
+}
+
+// notifyRescanProgress sends the progress of the running rescan from the start height to the clients of the
+// notification server.
+func (w *Wallet) notifyRescanProgress(start, height int32, finished bool) {
+	end := height
+	// while the rescan runs the chain may grow, so the end is the best block at the time of each report
+	if chainClient := w.ChainClient(); chainClient != nil && !finished {
+		if _, best, err := chainClient.GetBestBlock(); err == nil && best > end {
+			end = best
+		}
+	}
+	w.rescanFoundMtx.Lock()
+	found := w.rescanFound
+	w.rescanFoundMtx.Unlock()
+	w.NtfnServer.notifyRescanProgress(&RescanNotification{
+		StartHeight: start,
+		Height:      height,
+		EndHeight:   end,
+		Found:       found,
+		Finished:    finished,
+	})
+}
+
+// countRescanFound adds n transactions found in blocks to the count of the running rescan.
+func (w *Wallet) countRescanFound(n int) {
+	w.rescanFoundMtx.Lock()
+	w.rescanFound += n
+	w.rescanFoundMtx.Unlock()
+}
+
 // rescanWithTarget performs a rescan starting at the optional startStamp. If none is provided, the rescan will begin
 // from the manager's sync tip.
 func (w *Wallet) rescanWithTarget(addrs []util.Address,
 	unspent []tm.Credit, startStamp *wm.BlockStamp) error {
+	job, err := w.rescanJob(addrs, unspent, startStamp)
+	if err != nil {
+		return err
+	}
+	job.InitialSync = true
+	// Submit merged job and block until rescan completes.
+	return <-w.SubmitRescan(job)
+}
+
+// rescanJob creates the RescanJob for the addresses and unspent outputs starting at the optional
+// startStamp, or at the manager's sync tip.
+func (w *Wallet) rescanJob(addrs []util.Address, unspent []tm.Credit, startStamp *wm.BlockStamp) (*RescanJob,
+	error) {
 	outpoints := make(map[wire.OutPoint]util.Address, len(unspent))
 	for _, output := range unspent {
 		_, outputAddrs, _, err := txs.ExtractPkScriptAddrs(
@@ -232,7 +329,7 @@ func (w *Wallet) rescanWithTarget(addrs []util.Address,
 		)
 		if err != nil {
 			Error(err)
-			return err
+			return nil, err
 		}
 		outpoints[output.OutPoint] = outputAddrs[0]
 	}
@@ -241,12 +338,9 @@ func (w *Wallet) rescanWithTarget(addrs []util.Address,
 		startStamp = &wm.BlockStamp{}
 		*startStamp = w.Manager.SyncedTo()
 	}
-	job := &RescanJob{
-		InitialSync: true,
-		Addrs:       addrs,
-		OutPoints:   outpoints,
-		BlockStamp:  *startStamp,
-	}
-	// Submit merged job and block until rescan completes.
-	return <-w.SubmitRescan(job)
+	return &RescanJob{
+		Addrs:      addrs,
+		OutPoints:  outpoints,
+		BlockStamp: *startStamp,
+	}, nil
 }
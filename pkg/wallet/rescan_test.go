@@ -0,0 +1,27 @@
+package wallet
+
+import (
+	"testing"
+)
+
+// TestRescanNotificationPercent checks the share of a rescan that is done is counted from its start height and is full
+// once it finishes.
+func TestRescanNotificationPercent(t *testing.T) {
+	tests := []struct {
+		name string
+		n    RescanNotification
+		want float64
+	}{
+		{"started", RescanNotification{StartHeight: 100, Height: 100, EndHeight: 300}, 0},
+		{"halfway", RescanNotification{StartHeight: 100, Height: 200, EndHeight: 300}, 50},
+		{"at the tip", RescanNotification{StartHeight: 100, Height: 300, EndHeight: 300}, 100},
+		{"from the tip", RescanNotification{StartHeight: 300, Height: 300, EndHeight: 300}, 100},
+		{"finished", RescanNotification{StartHeight: 100, Height: 250, EndHeight: 300, Finished: true}, 100},
+		{"below the start", RescanNotification{StartHeight: 100, Height: 90, EndHeight: 300}, 0},
+	}
+	for _, test := range tests {
+		if got := test.n.Percent(); got != test.want {
+			t.Errorf("%s: percent is %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,465 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/p9c/pod/pkg/chain/config/netparams"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	txauthor "github.com/p9c/pod/pkg/chain/tx/author"
+	txrules "github.com/p9c/pod/pkg/chain/tx/rules"
+	txscript "github.com/p9c/pod/pkg/chain/tx/script"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+	"github.com/p9c/pod/pkg/util/psbt"
+	waddrmgr "github.com/p9c/pod/pkg/wallet/addrmgr"
+)
+
+// signingDevicesNamespaceKey is the top level bucket recording the signing device each paired account's keys are held
+// by, keyed by account number.
+var signingDevicesNamespaceKey = []byte("signingdevices")
+
+var (
+	// ErrSigningDeviceNotFound is returned when no connected signing device has the master key an account is paired
+	// with.
+	ErrSigningDeviceNotFound = errors.New("signing device not found, connect and unlock it")
+	// ErrSigningDeviceMismatch is returned when pairing an account with a signing device that does not hold its keys.
+	ErrSigningDeviceMismatch = errors.New("the signing device does not hold the keys of the account")
+	// ErrNotPaired is returned when creating a transaction for a signing device from an account that is not paired with
+	// one.
+	ErrNotPaired = errors.New("account is not paired with a signing device")
+	// ErrPSBTIncomplete is returned when publishing a partially signed transaction that is missing signatures.
+	ErrPSBTIncomplete = errors.New("the transaction has not been signed")
+)
+
+// SigningDeviceInfo describes a signing device, such as a hardware wallet.
+type SigningDeviceInfo struct {
+	// Driver is the name of the driver that talks to the device.
+	Driver string
+	// Model is the make and model of the device.
+	Model string
+	// Path is where the device is connected, in the form its driver uses.
+	Path string
+	// Fingerprint is the fingerprint of the device's master key, as it appears in BIP0032 derivations.
+	Fingerprint uint32
+}
+
+// FingerprintString returns the fingerprint of the device's master key in the hex form hardware wallets show it in.
+func (i SigningDeviceInfo) FingerprintString() string {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], i.Fingerprint)
+	return hex.EncodeToString(b[:])
+}
+
+// SigningDevice is a device holding the keys of wallet accounts, such as a hardware wallet, which signs transactions
+// the wallet builds after the user confirms them on the device. The keys never leave the device, so a wallet paired
+// with one is watching-only.
+type SigningDevice interface {
+	// Info describes the device.
+	Info() SigningDeviceInfo
+	// AccountXPub returns the extended public key of an account derived at path from the device's master key.
+	AccountXPub(path []uint32) (string, error)
+	// DisplayAddress shows the pay to pubkey hash address of the key at path on the device's screen, and returns the
+	// address as the device encoded it.
+	DisplayAddress(path []uint32) (string, error)
+	// SignPSBT has the device sign the inputs of a partially signed transaction whose derivations name its master key.
+	SignPSBT(p *psbt.Packet) (*psbt.Packet, error)
+}
+
+// SigningDeviceDriver finds the signing devices of one kind that are connected to the computer.
+type SigningDeviceDriver interface {
+	// Name is the name of the driver.
+	Name() string
+	// Enumerate returns the connected devices, set up for the network.
+	Enumerate(net *netparams.Params) ([]SigningDevice, error)
+}
+
+var (
+	signingDeviceDriversMtx sync.Mutex
+	signingDeviceDrivers    []SigningDeviceDriver
+)
+
+// RegisterSigningDeviceDriver adds a driver to those searched for signing devices.
+func RegisterSigningDeviceDriver(d SigningDeviceDriver) {
+	signingDeviceDriversMtx.Lock()
+	defer signingDeviceDriversMtx.Unlock()
+	signingDeviceDrivers = append(signingDeviceDrivers, d)
+}
+
+// SigningDevices returns the signing devices connected to the computer. The error of a driver is only returned when no
+// driver found a device.
+func SigningDevices(net *netparams.Params) (devices []SigningDevice, err error) {
+	signingDeviceDriversMtx.Lock()
+	drivers := append([]SigningDeviceDriver{}, signingDeviceDrivers...)
+	signingDeviceDriversMtx.Unlock()
+	for _, d := range drivers {
+		found, e := d.Enumerate(net)
+		if e != nil {
+			Error(e)
+			err = fmt.Errorf("%s: %v", d.Name(), e)
+			continue
+		}
+		devices = append(devices, found...)
+	}
+	if len(devices) > 0 {
+		err = nil
+	}
+	return
+}
+
+// FindSigningDevice returns the connected signing device with the master key of the fingerprint.
+func FindSigningDevice(net *netparams.Params, fingerprint uint32) (SigningDevice, error) {
+	devices, err := SigningDevices(net)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range devices {
+		if d.Info().Fingerprint == fingerprint {
+			return d, nil
+		}
+	}
+	return nil, ErrSigningDeviceNotFound
+}
+
+// ParseFingerprint parses the hex fingerprint of a master key as hardware wallets show it.
+func ParseFingerprint(s string) (uint32, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) != 4 {
+		return 0, fmt.Errorf("invalid master key fingerprint %q", s)
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// AccountDerivationPath returns the BIP0044 derivation path of a wallet account, m/44'/coin'/account'.
+func AccountDerivationPath(account uint32) []uint32 {
+	return fullDerivationPath(waddrmgr.KeyScopeBIP0044, waddrmgr.DerivationPath{Account: account})[:3]
+}
+
+// fullDerivationPath returns the derivation path from the master key of a key derived at path in a scope.
+func fullDerivationPath(scope waddrmgr.KeyScope, path waddrmgr.DerivationPath) []uint32 {
+	return []uint32{
+		scope.Purpose + hdkeychain.HardenedKeyStart,
+		scope.Coin + hdkeychain.HardenedKeyStart,
+		path.Account + hdkeychain.HardenedKeyStart,
+		path.Branch,
+		path.Index,
+	}
+}
+
+// FormatDerivationPath formats a derivation path in the m/44'/0'/0'/0/1 notation.
+func FormatDerivationPath(path []uint32) string {
+	s := "m"
+	for _, index := range path {
+		if index >= hdkeychain.HardenedKeyStart {
+			s += "/" + strconv.FormatUint(uint64(index-hdkeychain.HardenedKeyStart), 10) + "'"
+		} else {
+			s += "/" + strconv.FormatUint(uint64(index), 10)
+		}
+	}
+	return s
+}
+
+// ParseDerivationPath parses a derivation path in the m/44'/0'/0'/0/1 notation, also accepting h for hardened
+// indexes.
+func ParseDerivationPath(s string) ([]uint32, error) {
+	parts := strings.Split(s, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q", s)
+	}
+	path := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		var hardened uint32
+		if strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") {
+			hardened = hdkeychain.HardenedKeyStart
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path %q", s)
+		}
+		path = append(path, uint32(index)+hardened)
+	}
+	return path, nil
+}
+
+// PairSigningDevice records that the keys of a wallet account are held by a signing device, so that transactions
+// spending from the account are handed to the device to sign. The device must derive the same extended public key for
+// the account as the wallet has.
+func (w *Wallet) PairSigningDevice(account uint32, d SigningDevice) error {
+	manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	xpub, err := d.AccountXPub(AccountDerivationPath(account))
+	if err != nil {
+		Error(err)
+		return err
+	}
+	info := d.Info()
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+		props, err := manager.AccountProperties(tx.ReadBucket(waddrmgrNamespaceKey), account)
+		if err != nil {
+			return err
+		}
+		if props.AccountPubKey == nil || props.AccountPubKey.String() != xpub {
+			return ErrSigningDeviceMismatch
+		}
+		ns := tx.ReadWriteBucket(signingDevicesNamespaceKey)
+		if ns == nil {
+			if ns, err = tx.CreateTopLevelBucket(signingDevicesNamespaceKey); err != nil {
+				return err
+			}
+		}
+		var k [4]byte
+		binary.LittleEndian.PutUint32(k[:], account)
+		return ns.Put(k[:], serializeSigningDevice(&info))
+	})
+}
+
+// PairedSigningDevice returns the signing device an account is paired with, or nil if it is not paired. The path of
+// the device is not recorded, as it changes each time the device is connected.
+func (w *Wallet) PairedSigningDevice(account uint32) (*SigningDeviceInfo, error) {
+	var info *SigningDeviceInfo
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		var err error
+		info, err = pairedSigningDevice(tx, account)
+		return err
+	})
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	return info, nil
+}
+
+// pairedSigningDevice reads the signing device an account is paired with, or nil if it is not paired.
+func pairedSigningDevice(tx walletdb.ReadTx, account uint32) (*SigningDeviceInfo, error) {
+	ns := tx.ReadBucket(signingDevicesNamespaceKey)
+	if ns == nil {
+		return nil, nil
+	}
+	var k [4]byte
+	binary.LittleEndian.PutUint32(k[:], account)
+	v := ns.Get(k[:])
+	if v == nil {
+		return nil, nil
+	}
+	return deserializeSigningDevice(v)
+}
+
+// AddressDerivationPath returns the full derivation path of a wallet address from the master key, which a signing
+// device needs to find its key.
+func (w *Wallet) AddressDerivationPath(a util.Address) ([]uint32, error) {
+	ma, err := w.AddressInfo(a)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+	if !ok {
+		return nil, fmt.Errorf("address %s is not a pubkey address", a)
+	}
+	scope, path, ok := pka.DerivationInfo()
+	if !ok {
+		return nil, fmt.Errorf("address %s is imported and has no derivation path", a)
+	}
+	return fullDerivationPath(scope, path), nil
+}
+
+// CreateSigningDevicePSBT creates an unsigned transaction paying outputs from an account paired with a signing device,
+// with any change going to the account's internal branch. It is returned as a partially signed transaction carrying
+// the previous transactions and the derivations of the input and change keys, which the device needs to sign it and to
+// show the user what is being paid. Nothing is recorded until the signed transaction is published with PublishPSBT.
+func (w *Wallet) CreateSigningDevicePSBT(account uint32, outputs []*wire.TxOut, minconf int32,
+	satPerKb util.Amount) (*psbt.Packet, util.Amount, error) {
+	for _, output := range outputs {
+		if err := txrules.CheckOutput(output, satPerKb); err != nil {
+			return nil, 0, err
+		}
+	}
+	device, err := w.PairedSigningDevice(account)
+	if err != nil {
+		Error(err)
+		return nil, 0, err
+	}
+	if device == nil {
+		return nil, 0, ErrNotPaired
+	}
+	chainClient, err := w.requireChainClient()
+	if err != nil {
+		Error(err)
+		return nil, 0, err
+	}
+	bs, err := chainClient.BlockStamp()
+	if err != nil {
+		Error(err)
+		return nil, 0, err
+	}
+	manager, err := w.Manager.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+	if err != nil {
+		Error(err)
+		return nil, 0, err
+	}
+	var (
+		authored *txauthor.AuthoredTx
+		p        *psbt.Packet
+	)
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+		addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		// The device signs pay to pubkey hash inputs, so the change goes to the BIP0044 scope of the account rather
+		// than the witness scope the wallet otherwise uses for change.
+		changeSource := pubKeyHashChangeSource(manager, addrmgrNs, account)
+		var err error
+		if authored, err = w.authorTx(dbtx, outputs, account, minconf, satPerKb, bs, changeSource); err != nil {
+			return err
+		}
+		if authored.ChangeIndex >= 0 {
+			authored.RandomizeChangePosition()
+		}
+		if p, err = psbt.New(authored.Tx); err != nil {
+			return err
+		}
+		for i, txIn := range authored.Tx.TxIn {
+			details, err := w.TxStore.TxDetails(txmgrNs, &txIn.PreviousOutPoint.Hash)
+			if err != nil {
+				return err
+			}
+			if details == nil {
+				return fmt.Errorf("%v not found", txIn.PreviousOutPoint)
+			}
+			p.Inputs[i].NonWitnessUtxo = &details.MsgTx
+			p.Inputs[i].SighashType = txscript.SigHashAll
+			if p.Inputs[i].Bip32Derivation, err = w.bip32Derivation(addrmgrNs, authored.PrevScripts[i],
+				device.Fingerprint); err != nil {
+				return err
+			}
+		}
+		if authored.ChangeIndex >= 0 {
+			p.Outputs[authored.ChangeIndex].Bip32Derivation, err = w.bip32Derivation(addrmgrNs,
+				authored.Tx.TxOut[authored.ChangeIndex].PkScript, device.Fingerprint)
+		}
+		return err
+	})
+	if err != nil {
+		Error(err)
+		return nil, 0, err
+	}
+	return p, authored.Fee(), nil
+}
+
+// bip32Derivation returns the derivation of the key a pay to pubkey hash script pays to from the master key of the
+// fingerprint.
+func (w *Wallet) bip32Derivation(addrmgrNs walletdb.ReadBucket, pkScript []byte,
+	fingerprint uint32) ([]*psbt.Bip32Derivation, error) {
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) != 1 {
+		return nil, fmt.Errorf("script does not pay to a single address")
+	}
+	ma, err := w.Manager.Address(addrmgrNs, addrs[0])
+	if err != nil {
+		return nil, err
+	}
+	pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+	if !ok {
+		return nil, fmt.Errorf("address %s is not a pubkey address", addrs[0])
+	}
+	d := keyDerivation(pka, fingerprint)
+	if d == nil {
+		return nil, fmt.Errorf("address %s is imported and cannot be signed by the device", addrs[0])
+	}
+	return []*psbt.Bip32Derivation{d}, nil
+}
+
+// keyDerivation returns the derivation of the key of a wallet address from the master key of the fingerprint, or nil
+// for an imported key.
+func keyDerivation(pka waddrmgr.ManagedPubKeyAddress, fingerprint uint32) *psbt.Bip32Derivation {
+	scope, path, ok := pka.DerivationInfo()
+	if !ok {
+		return nil
+	}
+	return &psbt.Bip32Derivation{
+		PubKey:               pka.PubKey().SerializeCompressed(),
+		MasterKeyFingerprint: fingerprint,
+		Path:                 fullDerivationPath(scope, path),
+	}
+}
+
+// PublishPSBT finalizes a partially signed transaction whose inputs have all been signed, checks the signatures and
+// publishes the transaction. It returns the hash of the published transaction.
+func (w *Wallet) PublishPSBT(p *psbt.Packet) (*chainhash.Hash, error) {
+	complete, err := psbt.MaybeFinalizeAll(p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	if !complete {
+		return nil, ErrPSBTIncomplete
+	}
+	tx, err := psbt.Extract(p)
+	if err != nil {
+		Error(err)
+		return nil, err
+	}
+	prevScripts := make([][]byte, len(tx.TxIn))
+	inputValues := make([]util.Amount, len(tx.TxIn))
+	for i := range tx.TxIn {
+		prevOut := p.PrevOut(i)
+		if prevOut == nil {
+			return nil, fmt.Errorf("input %d is missing its previous transaction", i)
+		}
+		prevScripts[i] = prevOut.PkScript
+		inputValues[i] = util.Amount(prevOut.Value)
+	}
+	if err = validateMsgTx(tx, prevScripts, inputValues); err != nil {
+		Error(err)
+		return nil, err
+	}
+	return w.publishTransaction(tx)
+}
+
+// serializeSigningDevice serializes the pairing of an account as the master key fingerprint followed by the length
+// prefixed driver and model names.
+func serializeSigningDevice(i *SigningDeviceInfo) []byte {
+	var b bytes.Buffer
+	var v [4]byte
+	binary.LittleEndian.PutUint32(v[:], i.Fingerprint)
+	b.Write(v[:])
+	for _, s := range []string{i.Driver, i.Model} {
+		if len(s) > 255 {
+			s = s[:255]
+		}
+		b.WriteByte(byte(len(s)))
+		b.WriteString(s)
+	}
+	return b.Bytes()
+}
+
+// deserializeSigningDevice deserializes the pairing of an account.
+func deserializeSigningDevice(v []byte) (*SigningDeviceInfo, error) {
+	if len(v) < 4 {
+		return nil, fmt.Errorf("short signing device record")
+	}
+	info := &SigningDeviceInfo{Fingerprint: binary.LittleEndian.Uint32(v)}
+	v = v[4:]
+	for _, s := range []*string{&info.Driver, &info.Model} {
+		if len(v) < 1 || len(v) < 1+int(v[0]) {
+			return nil, fmt.Errorf("short signing device record")
+		}
+		*s = string(v[1 : 1+v[0]])
+		v = v[1+v[0]:]
+	}
+	return info, nil
+}
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/p9c/pod/pkg/util/hdkeychain"
+)
+
+// TestSigningDeviceSerialization checks that the pairing of an account survives serialization, and that truncated
+// records are rejected.
+func TestSigningDeviceSerialization(t *testing.T) {
+	fingerprint, err := ParseFingerprint("d34db33f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &SigningDeviceInfo{Driver: "hwi", Model: "trezor_t", Path: "webusb:001:1", Fingerprint: fingerprint}
+	if got := info.FingerprintString(); got != "d34db33f" {
+		t.Fatalf("got fingerprint %s, want d34db33f", got)
+	}
+	v := serializeSigningDevice(info)
+	got, err := deserializeSigningDevice(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the path is not recorded as it changes each time the device is connected
+	if got.Driver != "hwi" || got.Model != "trezor_t" || got.Path != "" || got.Fingerprint != fingerprint {
+		t.Fatalf("unexpected pairing after round trip %+v", got)
+	}
+	for i := 0; i < len(v); i++ {
+		if _, err = deserializeSigningDevice(v[:i]); err == nil {
+			t.Fatalf("truncated record of %d bytes was accepted", i)
+		}
+	}
+	if _, err = ParseFingerprint("d34db3"); err == nil {
+		t.Fatal("short fingerprint was accepted")
+	}
+}
+
+// TestDerivationPaths checks the formatting and parsing of derivation paths.
+func TestDerivationPaths(t *testing.T) {
+	h := uint32(hdkeychain.HardenedKeyStart)
+	path := []uint32{44 + h, h, 2 + h, 1, 15}
+	s := FormatDerivationPath(path)
+	if s != "m/44'/0'/2'/1/15" {
+		t.Fatalf("got path %s", s)
+	}
+	for _, in := range []string{s, "m/44h/0h/2h/1/15"} {
+		got, err := ParseDerivationPath(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, path) {
+			t.Fatalf("%s parsed as %v, want %v", in, got, path)
+		}
+	}
+	if got := AccountDerivationPath(2); !reflect.DeepEqual(got, path[:3]) {
+		t.Fatalf("got account path %v, want %v", got, path[:3])
+	}
+	for _, in := range []string{"44'/0'", "m/x", "m/2147483648"} {
+		if _, err := ParseDerivationPath(in); err == nil {
+			t.Errorf("invalid path %s was accepted", in)
+		}
+	}
+}
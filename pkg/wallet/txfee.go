@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	blockchain "github.com/p9c/pod/pkg/chain"
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+	"github.com/p9c/pod/pkg/wallet/chain"
+)
+
+// PrevOutSource returns the value of the output an input spends, and false if it can not be found.
+type PrevOutSource func(op wire.OutPoint) (util.Amount, bool)
+
+// txVirtualSize returns the virtual size of a transaction, its weight divided by the witness scale factor and rounded
+// up, which fee rates are calculated from.
+func txVirtualSize(tx *wire.MsgTx) int64 {
+	weight := blockchain.GetTransactionWeight(util.NewTx(tx))
+	return (weight + blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor
+}
+
+// txFee returns the fee paid by a transaction of the wallet. The values of the inputs that are debits are known to the
+// wallet, the values of other inputs are looked up with prevOut, which may be nil. The fee is unknown when the value of
+// any input can not be found.
+func txFee(details *wtxmgr.TxDetails, prevOut PrevOutSource) (fee util.Amount, ok bool) {
+	debits := make(map[uint32]util.Amount, len(details.Debits))
+	for _, deb := range details.Debits {
+		debits[deb.Index] = deb.Amount
+	}
+	for i, in := range details.MsgTx.TxIn {
+		amount, isDebit := debits[uint32(i)]
+		if !isDebit {
+			if prevOut == nil {
+				return 0, false
+			}
+			if amount, ok = prevOut(in.PreviousOutPoint); !ok {
+				return 0, false
+			}
+		}
+		fee += amount
+	}
+	for _, output := range details.MsgTx.TxOut {
+		fee -= util.Amount(output.Value)
+	}
+	return fee, true
+}
+
+// prevOutValue looks up the value of an output the wallet does not own in the transaction fetched from the chain
+// server, which for confirmed transactions requires the node to keep a transaction index. The transactions are cached,
+// including the ones that could not be fetched, since they never change and listing transactions would otherwise ask
+// the node again every time.
+func (w *Wallet) prevOutValue(op wire.OutPoint) (util.Amount, bool) {
+	w.prevTxsMtx.Lock()
+	defer w.prevTxsMtx.Unlock()
+	tx, cached := w.prevTxs[op.Hash]
+	if !cached {
+		client, ok := w.ChainClient().(*chain.RPCClient)
+		if !ok {
+			return 0, false
+		}
+		hash := op.Hash
+		if utx, err := client.GetRawTransaction(&hash); err == nil {
+			tx = utx.MsgTx()
+		} else {
+			Debug("can't fetch transaction", hash, "spent by a wallet transaction:", err)
+		}
+		if w.prevTxs == nil {
+			w.prevTxs = make(map[chainhash.Hash]*wire.MsgTx)
+		}
+		w.prevTxs[op.Hash] = tx
+	}
+	if tx == nil || int(op.Index) >= len(tx.TxOut) {
+		return 0, false
+	}
+	return util.Amount(tx.TxOut[op.Index].Value), true
+}
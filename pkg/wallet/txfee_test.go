@@ -0,0 +1,49 @@
+package wallet
+
+import (
+	"testing"
+
+	chainhash "github.com/p9c/pod/pkg/chain/hash"
+	wtxmgr "github.com/p9c/pod/pkg/chain/tx/mgr"
+	"github.com/p9c/pod/pkg/chain/wire"
+	"github.com/p9c/pod/pkg/util"
+)
+
+// TestTxFee checks that the fee of a transaction is calculated from the debits of the wallet and the values looked up
+// for the inputs it doesn't own, and that it is unknown when any of them can't be found.
+func TestTxFee(t *testing.T) {
+	foreign := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 2}
+	msgTx := wire.MsgTx{Version: 1}
+	msgTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{3}}, nil, nil))
+	msgTx.AddTxIn(wire.NewTxIn(&foreign, nil, nil))
+	msgTx.AddTxOut(wire.NewTxOut(1500, []byte{0x51}))
+	details := &wtxmgr.TxDetails{
+		TxRecord: wtxmgr.TxRecord{MsgTx: msgTx},
+		Debits:   []wtxmgr.DebitRecord{{Amount: 1000, Index: 0}},
+	}
+	prevOut := func(op wire.OutPoint) (util.Amount, bool) {
+		if op == foreign {
+			return 700, true
+		}
+		return 0, false
+	}
+	if fee, ok := txFee(details, prevOut); !ok || fee != 200 {
+		t.Errorf("got fee %v, %v with the foreign input found, want 200", fee, ok)
+	}
+	if _, ok := txFee(details, nil); ok {
+		t.Error("got a fee without a way to look up the foreign input")
+	}
+	missing := func(wire.OutPoint) (util.Amount, bool) { return 0, false }
+	if _, ok := txFee(details, missing); ok {
+		t.Error("got a fee with the foreign input missing")
+	}
+	details.MsgTx.TxIn = details.MsgTx.TxIn[:1]
+	details.MsgTx.TxOut[0].Value = 900
+	if fee, ok := txFee(details, nil); !ok || fee != 100 {
+		t.Errorf("got fee %v, %v with every input a debit, want 100", fee, ok)
+	}
+	// Without witness data the virtual size is the serialized size.
+	if vsize := txVirtualSize(&details.MsgTx); vsize != int64(details.MsgTx.SerializeSize()) {
+		t.Errorf("got virtual size %d, want %d", vsize, details.MsgTx.SerializeSize())
+	}
+}
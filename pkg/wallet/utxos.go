@@ -34,6 +34,10 @@ func (w *Wallet) UnspentOutputs(policy OutputSelectionPolicy) ([]*TransactionOut
 			if !policy.meetsRequiredConfs(output.Height, syncBlock.Height) {
 				continue
 			}
+			// Locked outputs are reserved and must not be selected.
+			if w.LockedOutpoint(output.OutPoint) {
+				continue
+			}
 			// Ignore outputs that are not controlled by the account.
 			_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript,
 				w.chainParams)
@@ -22,6 +22,7 @@ import (
 	"github.com/p9c/pod/pkg/chain/wire"
 	ec "github.com/p9c/pod/pkg/coding/elliptic"
 	"github.com/p9c/pod/pkg/db/walletdb"
+	"github.com/p9c/pod/pkg/db/walletdb/edb"
 	"github.com/p9c/pod/pkg/pod"
 	"github.com/p9c/pod/pkg/rpc/btcjson"
 	rpcclient "github.com/p9c/pod/pkg/rpc/client"
@@ -67,7 +68,18 @@ type Wallet struct {
 	chainClientSynced  bool
 	chainClientSyncMtx sync.Mutex
 	lockedOutpoints    map[wire.OutPoint]struct{}
+	lockedOutpointsMtx sync.Mutex
+	// prevTxs caches the transactions fetched from the chain server for the values of inputs the wallet doesn't own,
+	// nil for the ones that could not be fetched.
+	prevTxs            map[chainhash.Hash]*wire.MsgTx
+	prevTxsMtx         sync.Mutex
+	// reconciliation is the outcome of the latest reconciliation of the wallet history with the chain.
+	reconciliation     *Reconciliation
+	reconciliationMtx  sync.Mutex
 	recoveryWindow     uint32
+	// rescanFound counts the transactions found in blocks since the running rescan started, for its progress reports.
+	rescanFound        int
+	rescanFoundMtx     sync.Mutex
 	// Channels for rescan processing. Requests are added and merged with any waiting requests, before being sent to
 	// another goroutine to call the rescan RPC.
 	rescanAddJob        chan *RescanJob
@@ -611,6 +623,10 @@ func (w *Wallet) defaultScopeManagers() (
 	for _, scope := range waddrmgr.DefaultKeyScopes {
 		scopedMgr, err := w.Manager.FetchScopedKeyManager(scope)
 		if err != nil {
+			// a watching-only wallet created from an account key only has the scope of the account
+			if w.Manager.WatchOnly() && waddrmgr.IsError(err, waddrmgr.ErrScopeNotFound) {
+				continue
+			}
 			Error(err)
 			return nil, err
 		}
@@ -1067,10 +1083,14 @@ out:
 		case req := <-w.changePassphrase:
 			err := walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
 				addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
-				return w.Manager.ChangePassphrase(
+				err := w.Manager.ChangePassphrase(
 					addrmgrNs, req.old, req.new, req.private,
 					&waddrmgr.DefaultScryptOptions,
 				)
+				if err != nil || req.private {
+					return err
+				}
+				return changeDBPassphrase(tx, req.new)
 			})
 			req.err <- err
 			continue
@@ -1085,10 +1105,15 @@ out:
 					Error(err)
 					return err
 				}
-				return w.Manager.ChangePassphrase(
+				err = w.Manager.ChangePassphrase(
 					addrmgrNs, req.privateOld, req.privateNew,
 					true, &waddrmgr.DefaultScryptOptions,
 				)
+				if err != nil {
+					Error(err)
+					return err
+				}
+				return changeDBPassphrase(tx, req.publicNew)
 			})
 			req.err <- err
 			continue
@@ -1200,6 +1225,24 @@ func (w *Wallet) ChangePublicPassphrase(old, new []byte) error {
 	return <-err
 }
 
+// EncryptDB encrypts the whole wallet database, including its transaction history, with a key derived from the public
+// passphrase, while the wallet keeps running. The database must have been opened unencrypted, and the public passphrase
+// must have been changed from the insecure default, as anyone could otherwise decrypt it.
+func (w *Wallet) EncryptDB(pubPass []byte) error {
+	if bytes.Equal(pubPass, []byte(InsecurePubPassphrase)) {
+		return errors.New("the public passphrase must be set before the wallet database is encrypted")
+	}
+	if err := w.Manager.CheckPublicPassphrase(pubPass); err != nil {
+		return err
+	}
+	return edb.Encrypt(w.db, pubPass)
+}
+
+// DBEncrypted returns whether the wallet database is encrypted at rest.
+func (w *Wallet) DBEncrypted() bool {
+	return edb.IsEncrypted(w.db)
+}
+
 // ChangePassphrases modifies the public and private passphrase of the wallet atomically.
 func (w *Wallet) ChangePassphrases(publicOld, publicNew, privateOld,
 	privateNew []byte) error {
@@ -1313,6 +1356,80 @@ func (w *Wallet) CalculateAccountBalances(account uint32, confirms int32) (Balan
 	return bals, err
 }
 
+// ConfirmedDepth is the number of confirmations from which an output is counted as confirmed in a BalanceSnapshot,
+// outputs with fewer confirmations in a block are counted as confirming.
+const ConfirmedDepth = 6
+
+// BalanceSnapshot is the balance of a wallet broken down by the confirmations of its unspent outputs, as of the block
+// the wallet was synced to. Each output is counted in exactly one of the buckets: immature coinbase rewards first, then
+// locked outputs, then by confirmations, so the buckets add up to the total.
+type BalanceSnapshot struct {
+	Height         int32
+	Hash           chainhash.Hash
+	Unconfirmed    util.Amount
+	Confirming     util.Amount
+	Confirmed      util.Amount
+	ImmatureReward util.Amount
+	Locked         util.Amount
+	Total          util.Amount
+}
+
+// CalculateBalanceSnapshot sums the unspent outputs to the given account, or to all accounts when account is nil, into
+// the buckets of a BalanceSnapshot. The outputs, the synced block and the locked outpoints are all read while holding
+// the locked outpoints lock inside a single database transaction, so the buckets are consistent with each other, unlike
+// the results of separate balance queries.
+func (w *Wallet) CalculateBalanceSnapshot(account *uint32) (BalanceSnapshot, error) {
+	var snap BalanceSnapshot
+	w.lockedOutpointsMtx.Lock()
+	defer w.lockedOutpointsMtx.Unlock()
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+		addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		syncBlock := w.Manager.SyncedTo()
+		snap.Height, snap.Hash = syncBlock.Height, syncBlock.Hash
+		unspent, err := w.TxStore.UnspentOutputs(txmgrNs)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		for i := range unspent {
+			output := &unspent[i]
+			if account != nil {
+				var outputAcct uint32
+				_, addrs, _, err := txscript.ExtractPkScriptAddrs(output.PkScript, w.chainParams)
+				if err == nil && len(addrs) > 0 {
+					_, outputAcct, err = w.Manager.AddrAccount(addrmgrNs, addrs[0])
+				}
+				if err != nil || outputAcct != *account {
+					continue
+				}
+			}
+			_, locked := w.lockedOutpoints[output.OutPoint]
+			snap.add(output.Amount, output.Height, output.FromCoinBase, locked, int32(w.chainParams.CoinbaseMaturity))
+		}
+		return nil
+	})
+	return snap, err
+}
+
+// add counts an unspent output mined at the given height, or -1 if it is unmined, in its bucket of the snapshot.
+func (s *BalanceSnapshot) add(amount util.Amount, height int32, coinbase, locked bool, maturity int32) {
+	s.Total += amount
+	confs := confirms(height, s.Height)
+	switch {
+	case coinbase && confs < maturity:
+		s.ImmatureReward += amount
+	case locked:
+		s.Locked += amount
+	case confs == 0:
+		s.Unconfirmed += amount
+	case confs < ConfirmedDepth:
+		s.Confirming += amount
+	default:
+		s.Confirmed += amount
+	}
+}
+
 // CurrentAddress gets the most recently requested Bitcoin payment address from a wallet for a particular key-chain
 // scope. If the address has already been used (there is at least one transaction spending to it in the blockchain or
 // pod mempool), the next chained address is returned.
@@ -1610,7 +1727,7 @@ func RecvCategory(details *wtxmgr.TxDetails, syncHeight int32, net *netparams.Pa
 //
 // TODO: This should be moved to the legacyrpc package.
 func listTransactions(tx walletdb.ReadTx, details *wtxmgr.TxDetails, addrMgr *waddrmgr.Manager,
-	syncHeight int32, net *netparams.Params) []btcjson.ListTransactionsResult {
+	syncHeight int32, net *netparams.Params, prevOut PrevOutSource) []btcjson.ListTransactionsResult {
 	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
 	var (
 		blockHashStr  string
@@ -1628,20 +1745,20 @@ func listTransactions(tx walletdb.ReadTx, details *wtxmgr.TxDetails, addrMgr *wa
 	generated := blockchain.IsCoinBaseTx(&details.MsgTx)
 	recvCat := RecvCategory(details, syncHeight, net).String()
 	send := len(details.Debits) != 0
-	// Fee can only be determined if every input is a debit.
-	var feeF64 float64
-	if len(details.Debits) == len(details.MsgTx.TxIn) {
-		var debitTotal util.Amount
-		for _, deb := range details.Debits {
-			debitTotal += deb.Amount
-		}
-		var outputTotal util.Amount
-		for _, output := range details.MsgTx.TxOut {
-			outputTotal += util.Amount(output.Value)
+	// Fee can only be determined if the value of every input is known, which for inputs that are not debits is looked
+	// up with prevOut. The size and fee rate are only reported for sends.
+	var (
+		feeF64  *float64
+		feeRate *float64
+		vsize   int64
+	)
+	if send {
+		vsize = txVirtualSize(&details.MsgTx)
+		if fee, ok := txFee(details, prevOut); ok {
+			// Note: This RPC reports negative numbers for fees, so the inverse is calculated.
+			f, r := (-fee).ToDUO(), float64(fee)/float64(vsize)
+			feeF64, feeRate = &f, &r
 		}
-		// Note: The actual fee is debitTotal - outputTotal. However, this RPC reports negative numbers for fees, so the
-		// inverse is calculated.
-		feeF64 = (outputTotal - debitTotal).ToDUO()
 	}
 outputs:
 	for i, output := range details.MsgTx.TxOut {
@@ -1707,7 +1824,9 @@ outputs:
 		if send || spentCredit {
 			result.Category = "send"
 			result.Amount = -amountF64
-			result.Fee = &feeF64
+			result.Fee = feeF64
+			result.VSize = vsize
+			result.FeeRate = feeRate
 			results = append(results, result)
 		}
 		if isCredit {
@@ -1715,12 +1834,35 @@ outputs:
 			result.Category = recvCat
 			result.Amount = amountF64
 			result.Fee = nil
+			result.VSize = 0
+			result.FeeRate = nil
 			results = append(results, result)
 		}
 	}
 	return results
 }
 
+// listConflictedTransactions creates the listtransactions results for the transactions that were removed from the
+// store because a competing spend was mined. They are marked conflicted, with -1 confirmations and the competing
+// transaction in the wallet conflicts.
+func listConflictedTransactions(tx walletdb.ReadTx, txStore *wtxmgr.Store, addrMgr *waddrmgr.Manager,
+	syncHeight int32, net *netparams.Params, prevOut PrevOutSource) (results []btcjson.ListTransactionsResult, err error) {
+	var conflicted []wtxmgr.ConflictedTx
+	if conflicted, err = txStore.ConflictedTxs(tx.ReadBucket(wtxmgrNamespaceKey)); Check(err) {
+		return
+	}
+	for i := range conflicted {
+		jsonResults := listTransactions(tx, &conflicted[i].TxDetails, addrMgr, syncHeight, net, prevOut)
+		for j := range jsonResults {
+			jsonResults[j].Confirmations = -1
+			jsonResults[j].Conflicted = true
+			jsonResults[j].WalletConflicts = []string{conflicted[i].ConflictedBy.String()}
+		}
+		results = append(results, jsonResults...)
+	}
+	return
+}
+
 // ListSinceBlock returns a slice of objects with details about transactions since the given block. If the block is -1
 // then all transactions are included. This is intended to be used for listsinceblock RPC replies.
 func (w *Wallet) ListSinceBlock(start, end, syncHeight int32) (txList []btcjson.ListTransactionsResult, err error) {
@@ -1730,11 +1872,19 @@ func (w *Wallet) ListSinceBlock(start, end, syncHeight int32) (txList []btcjson.
 		rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
 			for _, detail := range details {
 				jsonResults := listTransactions(tx, &detail,
-					w.Manager, syncHeight, w.chainParams)
+					w.Manager, syncHeight, w.chainParams, w.prevOutValue)
 				txList = append(txList, jsonResults...)
 			}
 			return false, nil
 		}
+		if end < 0 {
+			conflicted, err := listConflictedTransactions(tx, w.TxStore, w.Manager, syncHeight, w.chainParams,
+				w.prevOutValue)
+			if err != nil {
+				return err
+			}
+			txList = append(txList, conflicted...)
+		}
 		return w.TxStore.RangeTransactions(txmgrNs, start, end, rangeFn)
 	})
 	return
@@ -1766,7 +1916,7 @@ func (w *Wallet) ListTransactions(from, count int) (txList []btcjson.ListTransac
 					return true, nil
 				}
 				jsonResults := listTransactions(tx, &details[i],
-					w.Manager, syncBlock.Height, w.chainParams)
+					w.Manager, syncBlock.Height, w.chainParams, w.prevOutValue)
 				txList = append(txList, jsonResults...)
 				if len(jsonResults) > 0 {
 					n++
@@ -1774,6 +1924,23 @@ func (w *Wallet) ListTransactions(from, count int) (txList []btcjson.ListTransac
 			}
 			return false, nil
 		}
+		// Conflicted transactions never confirm so they are listed first along with the unmined transactions.
+		conflicted, err := listConflictedTransactions(tx, w.TxStore, w.Manager, syncBlock.Height, w.chainParams,
+			w.prevOutValue)
+		if err != nil {
+			return err
+		}
+		for i := range conflicted {
+			if from > skipped {
+				skipped++
+				continue
+			}
+			n++
+			if n > count {
+				return nil
+			}
+			txList = append(txList, conflicted[i])
+		}
 		// Return newer results first by starting at mempool height and working down to the genesis block.
 		return w.TxStore.RangeTransactions(txmgrNs, -1, 0, rangeFn)
 	}); Check(err) {
@@ -1809,7 +1976,7 @@ func (w *Wallet) ListAddressTransactions(pkHashes map[string]struct{}) ([]btcjso
 						continue
 					}
 					jsonResults := listTransactions(tx, detail,
-						w.Manager, syncBlock.Height, w.chainParams)
+						w.Manager, syncBlock.Height, w.chainParams, w.prevOutValue)
 					// if err != nil {
 					// 	return false, err
 					// }
@@ -1837,11 +2004,17 @@ func (w *Wallet) ListAllTransactions() ([]btcjson.ListTransactionsResult, error)
 			// which are unsorted, but it will process mined transactions in the reverse order they were marked mined.
 			for i := len(details) - 1; i >= 0; i-- {
 				jsonResults := listTransactions(tx, &details[i], w.Manager,
-					syncBlock.Height, w.chainParams)
+					syncBlock.Height, w.chainParams, w.prevOutValue)
 				txList = append(txList, jsonResults...)
 			}
 			return false, nil
 		}
+		conflicted, err := listConflictedTransactions(tx, w.TxStore, w.Manager, syncBlock.Height, w.chainParams,
+			w.prevOutValue)
+		if err != nil {
+			return err
+		}
+		txList = append(txList, conflicted...)
 		// Return newer results first by starting at mempool height and working down to the genesis block.
 		return w.TxStore.RangeTransactions(txmgrNs, -1, 0, rangeFn)
 	})
@@ -2427,43 +2600,6 @@ func (w *Wallet) ImportPrivateKey(scope waddrmgr.KeyScope, wif *util.WIF,
 	return addrStr, nil
 }
 
-// LockedOutpoint returns whether an outpoint has been marked as locked and should not be used as an input for created
-// transactions.
-func (w *Wallet) LockedOutpoint(op wire.OutPoint) bool {
-	_, locked := w.lockedOutpoints[op]
-	return locked
-}
-
-// LockOutpoint marks an outpoint as locked, that is, it should not be used as an input for newly created transactions.
-func (w *Wallet) LockOutpoint(op wire.OutPoint) {
-	w.lockedOutpoints[op] = struct{}{}
-}
-
-// UnlockOutpoint marks an outpoint as unlocked, that is, it may be used as an input for newly created transactions.
-func (w *Wallet) UnlockOutpoint(op wire.OutPoint) {
-	delete(w.lockedOutpoints, op)
-}
-
-// ResetLockedOutpoints resets the set of locked outpoints so all may be used as inputs for new transactions.
-func (w *Wallet) ResetLockedOutpoints() {
-	w.lockedOutpoints = map[wire.OutPoint]struct{}{}
-}
-
-// LockedOutpoints returns a slice of currently locked outpoints. This is intended to be used by marshaling the result
-// as a JSON array for listlockunspent RPC results.
-func (w *Wallet) LockedOutpoints() []btcjson.TransactionInput {
-	locked := make([]btcjson.TransactionInput, len(w.lockedOutpoints))
-	i := 0
-	for op := range w.lockedOutpoints {
-		locked[i] = btcjson.TransactionInput{
-			Txid: op.Hash.String(),
-			Vout: op.Index,
-		}
-		i++
-	}
-	return locked
-}
-
 // resendUnminedTxs iterates through all transactions that spend from wallet credits that are not known to have been
 // mined into a block, and attempts to send each to the chain server for relay.
 func (w *Wallet) resendUnminedTxs() {
@@ -2764,42 +2900,52 @@ func (w *Wallet) TotalReceivedForAccounts(scope waddrmgr.KeyScope,
 func (w *Wallet) TotalReceivedForAddr(addr util.Address, minConf int32) (util.Amount, error) {
 	var amount util.Amount
 	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
-		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
-		syncBlock := w.Manager.SyncedTo()
-		var (
-			addrStr    = addr.EncodeAddress()
-			stopHeight int32
-		)
-		if minConf > 0 {
-			stopHeight = syncBlock.Height - minConf + 1
-		} else {
-			stopHeight = -1
-		}
-		rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
-			for i := range details {
-				detail := &details[i]
-				for _, cred := range detail.Credits {
-					pkScript := detail.MsgTx.TxOut[cred.Index].PkScript
-					_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript,
-						w.chainParams)
-					// An error creating addresses from the output script only indicates a non-standard script, so
-					// ignore this credit.
-					if err != nil {
-						Error(err)
-						continue
-					}
-					for _, a := range addrs {
-						if addrStr == a.EncodeAddress() {
-							amount += cred.Amount
-							break
-						}
+		var err error
+		amount, err = w.totalReceivedForAddr(tx.ReadBucket(wtxmgrNamespaceKey), addr, minConf)
+		return err
+	})
+	return amount, err
+}
+
+// totalReceivedForAddr sums the credits to a single wallet address with at least minConf confirmations using an
+// existing database transaction.
+func (w *Wallet) totalReceivedForAddr(txmgrNs walletdb.ReadBucket, addr util.Address,
+	minConf int32) (util.Amount, error) {
+	var amount util.Amount
+	syncBlock := w.Manager.SyncedTo()
+	var (
+		addrStr    = addr.EncodeAddress()
+		stopHeight int32
+	)
+	if minConf > 0 {
+		stopHeight = syncBlock.Height - minConf + 1
+	} else {
+		stopHeight = -1
+	}
+	rangeFn := func(details []wtxmgr.TxDetails) (bool, error) {
+		for i := range details {
+			detail := &details[i]
+			for _, cred := range detail.Credits {
+				pkScript := detail.MsgTx.TxOut[cred.Index].PkScript
+				_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript,
+					w.chainParams)
+				// An error creating addresses from the output script only indicates a non-standard script, so
+				// ignore this credit.
+				if err != nil {
+					Error(err)
+					continue
+				}
+				for _, a := range addrs {
+					if addrStr == a.EncodeAddress() {
+						amount += cred.Amount
+						break
 					}
 				}
 			}
-			return false, nil
 		}
-		return w.TxStore.RangeTransactions(txmgrNs, 0, stopHeight, rangeFn)
-	})
+		return false, nil
+	}
+	err := w.TxStore.RangeTransactions(txmgrNs, 0, stopHeight, rangeFn)
 	return amount, err
 }
 
@@ -3070,6 +3216,40 @@ func Create(db walletdb.DB, pubPass, privPass, seed []byte, params *netparams.Pa
 	})
 }
 
+// CreateWatchingOnly creates a new watching-only wallet, writing it to an empty database. The wallet tracks the
+// addresses of the account whose extended public key is passed, and holds no private keys so it can never spend.
+func CreateWatchingOnly(db walletdb.DB, pubPass []byte, accountXPub string, params *netparams.Params,
+	birthday time.Time) error {
+	acctPubKey, err := hdkeychain.NewKeyFromString(accountXPub)
+	if err != nil {
+		Error(err)
+		return err
+	}
+	return walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+		addrmgrNs, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		txmgrNs, err := tx.CreateTopLevelBucket(wtxmgrNamespaceKey)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		err = waddrmgr.CreateWatchingOnly(addrmgrNs, acctPubKey, pubPass, params, nil, birthday)
+		if err != nil {
+			Error(err)
+			return err
+		}
+		return wtxmgr.Create(txmgrNs)
+	})
+}
+
+// WatchingOnly returns whether the wallet is watching-only, holding no private keys.
+func (w *Wallet) WatchingOnly() bool {
+	return w.Manager.WatchOnly()
+}
+
 // Open loads an already-created wallet from the passed database and namespaces.
 func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 	params *netparams.Params, recoveryWindow uint32, podConfig *pod.Config) (*Wallet, error) {
@@ -3153,5 +3333,12 @@ func Open(db walletdb.DB, pubPass []byte, cbs *waddrmgr.OpenCallbacks,
 	w.TxStore.NotifyUnspent = func(hash *chainhash.Hash, index uint32) {
 		w.NtfnServer.notifyUnspentOutput(0, hash, index)
 	}
+	w.TxStore.NotifyConflict = func(conflicted, by *chainhash.Hash) {
+		w.NtfnServer.notifyConflictedTransaction(conflicted, by)
+	}
+	if err = w.loadLockedOutpoints(); err != nil {
+		Error(err)
+		return nil, err
+	}
 	return w, nil
 }